@@ -0,0 +1,204 @@
+// Package admission sends the mutations of the checks and notification rules
+// to an external validation webhook before they are persisted, so operators
+// can enforce their own conventions, such as runbook links in the descriptions.
+//
+// The webhook receives a review of the mutation as json:
+//
+//	{
+//	  "operation": "create",
+//	  "resourceType": "checks",
+//	  "orgID": "0000000000000001",
+//	  "userID": "0000000000000002",
+//	  "object": {...},
+//	  "oldObject": {...},
+//	  "dryRun": true
+//	}
+//
+// and responds with {"allowed": true}, or {"allowed": false, "message": "..."}
+// to reject the mutation. An allowed response may also return the "object"
+// to persist in place of the one of the review.
+package admission
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	icontext "github.com/influxdata/influxdb/context"
+	"go.uber.org/zap"
+)
+
+// DefaultTimeout is the maximum time the webhook responds in when the
+// operator doesn't configure one.
+const DefaultTimeout = 5 * time.Second
+
+// responseLimit bounds the size of the responses of the webhook.
+const responseLimit = 1 << 20
+
+// Operation is the mutation of a reviewed resource.
+type Operation string
+
+// Operations of the reviews.
+const (
+	Create Operation = "create"
+	Update Operation = "update"
+	Delete Operation = "delete"
+)
+
+// FailurePolicy is what happens to a mutation when the webhook fails to respond.
+type FailurePolicy string
+
+const (
+	// FailClosed rejects the mutations the webhook failed to review.
+	FailClosed FailurePolicy = "fail-closed"
+	// FailOpen persists the mutations the webhook failed to review, logging the failure.
+	FailOpen FailurePolicy = "fail-open"
+)
+
+// Valid returns an error if p is not a known failure policy.
+func (p FailurePolicy) Valid() error {
+	switch p {
+	case FailClosed, FailOpen:
+		return nil
+	default:
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("unknown validation webhook failure policy %q, expected %s or %s", p, FailClosed, FailOpen),
+		}
+	}
+}
+
+// Review is the json sent to the webhook.
+type Review struct {
+	Operation    Operation             `json:"operation"`
+	ResourceType influxdb.ResourceType `json:"resourceType"`
+	OrgID        influxdb.ID           `json:"orgID"`
+	UserID       *influxdb.ID          `json:"userID,omitempty"`
+	Object       json.RawMessage       `json:"object,omitempty"`
+	OldObject    json.RawMessage       `json:"oldObject,omitempty"`
+	// DryRun is set if the mutation is validated without being persisted,
+	// the webhook mustn't have side effects then.
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// Response is the json responded by the webhook.
+type Response struct {
+	Allowed bool            `json:"allowed"`
+	Message string          `json:"message,omitempty"`
+	Object  json.RawMessage `json:"object,omitempty"`
+}
+
+// Webhook reviews the mutations with an external http validator.
+type Webhook struct {
+	URL           string
+	Timeout       time.Duration
+	FailurePolicy FailurePolicy
+	Client        *http.Client
+	Logger        *zap.Logger
+}
+
+// NewWebhook returns a fail-closed webhook posting the reviews to url.
+func NewWebhook(url string, logger *zap.Logger) *Webhook {
+	return &Webhook{
+		URL:           url,
+		Timeout:       DefaultTimeout,
+		FailurePolicy: FailClosed,
+		Client:        &http.Client{},
+		Logger:        logger,
+	}
+}
+
+// Review sends r to the webhook, and returns the object to persist in place
+// of the one of the review, if the webhook mutated it.
+// The mutations the webhook rejects return an invalid error.
+func (w *Webhook) Review(ctx context.Context, r Review) (json.RawMessage, error) {
+	r.DryRun = icontext.GetDryRun(ctx) != nil
+	res, err := w.post(ctx, r)
+	if err != nil {
+		if w.FailurePolicy == FailOpen {
+			w.Logger.Warn("validation webhook failed, allowing the mutation",
+				zap.String("operation", string(r.Operation)),
+				zap.String("resource_type", string(r.ResourceType)),
+				zap.Error(err))
+			return nil, nil
+		}
+		return nil, &influxdb.Error{
+			Code: influxdb.EUnavailable,
+			Msg:  fmt.Sprintf("unable to %s %s, the validation webhook failed", r.Operation, r.ResourceType),
+			Err:  err,
+		}
+	}
+	if !res.Allowed {
+		msg := fmt.Sprintf("%s of %s rejected by the validation webhook", r.Operation, r.ResourceType)
+		if res.Message != "" {
+			msg += ": " + res.Message
+		}
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  msg,
+		}
+	}
+	if r.Operation == Delete {
+		return nil, nil
+	}
+	return res.Object, nil
+}
+
+func (w *Webhook) post(ctx context.Context, r Review) (*Response, error) {
+	b, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+	timeout := w.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequest("POST", w.URL, bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+
+	client := w.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, responseLimit))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode/100 != 2 {
+		return nil, fmt.Errorf("validation webhook responded with status %d", resp.StatusCode)
+	}
+	var res Response
+	if err := json.Unmarshal(body, &res); err != nil {
+		return nil, fmt.Errorf("invalid response of the validation webhook: %v", err)
+	}
+	return &res, nil
+}
+
+// typeChanged returns the error of an object the webhook mutated into another type.
+func typeChanged(resourceType, typ, newTyp string) error {
+	if typ == newTyp {
+		return nil
+	}
+	return &influxdb.Error{
+		Code: influxdb.EInvalid,
+		Msg:  fmt.Sprintf("validation webhook changed the type of the %s from %s to %s", resourceType, typ, newTyp),
+	}
+}
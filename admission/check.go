@@ -0,0 +1,152 @@
+package admission
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/influxdata/influxdb"
+	pctx "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/notification/check"
+)
+
+var _ influxdb.CheckService = (*CheckService)(nil)
+
+// CheckService reviews the mutations of the checks with a webhook before
+// persisting them with the wrapped service.
+type CheckService struct {
+	influxdb.CheckService
+	Webhook *Webhook
+}
+
+// NewCheckService wraps s to review the mutations of the checks with w.
+func NewCheckService(s influxdb.CheckService, w *Webhook) *CheckService {
+	return &CheckService{
+		CheckService: s,
+		Webhook:      w,
+	}
+}
+
+// review sends the mutation of c to the webhook, and returns the check to persist.
+func (s *CheckService) review(ctx context.Context, op Operation, c, old influxdb.Check, userID *influxdb.ID) (influxdb.Check, error) {
+	r := Review{
+		Operation:    op,
+		ResourceType: influxdb.ChecksResourceType,
+		UserID:       userID,
+	}
+	var err error
+	if c != nil {
+		r.OrgID = c.GetOrgID()
+		if r.Object, err = json.Marshal(c); err != nil {
+			return nil, err
+		}
+	}
+	if old != nil {
+		r.OrgID = old.GetOrgID()
+		if r.OldObject, err = json.Marshal(old); err != nil {
+			return nil, err
+		}
+	}
+	obj, err := s.Webhook.Review(ctx, r)
+	if err != nil || obj == nil {
+		return c, err
+	}
+
+	mc, err := check.UnmarshalJSON(obj)
+	if err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "invalid check mutated by the validation webhook",
+			Err:  err,
+		}
+	}
+	if err := typeChanged("check", c.Type(), mc.Type()); err != nil {
+		return nil, err
+	}
+	// the webhook doesn't move checks to other organizations, nor renumbers them.
+	mc.SetID(c.GetID())
+	mc.SetOrgID(c.GetOrgID())
+	return mc, nil
+}
+
+// CreateCheck reviews the creation of c before creating it.
+func (s *CheckService) CreateCheck(ctx context.Context, c influxdb.Check, userID influxdb.ID) error {
+	mc, err := s.review(ctx, Create, c, nil, &userID)
+	if err != nil {
+		return err
+	}
+	if err := s.CheckService.CreateCheck(ctx, mc, userID); err != nil {
+		return err
+	}
+	if mc != c {
+		c.SetID(mc.GetID())
+		c.SetCreatedAt(mc.GetCRUDLog().CreatedAt)
+		c.SetUpdatedAt(mc.GetCRUDLog().UpdatedAt)
+	}
+	return nil
+}
+
+// UpdateCheck reviews the update of the check to c before updating it.
+func (s *CheckService) UpdateCheck(ctx context.Context, id influxdb.ID, c influxdb.Check) (influxdb.Check, error) {
+	old, err := s.CheckService.FindCheckByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	c.SetID(id)
+	mc, err := s.review(ctx, Update, c, old, userIDFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return s.CheckService.UpdateCheck(ctx, id, mc)
+}
+
+// PatchCheck reviews the check patched with upd before patching it.
+// A check the webhook mutated is updated as a whole.
+func (s *CheckService) PatchCheck(ctx context.Context, id influxdb.ID, upd influxdb.CheckUpdate) (influxdb.Check, error) {
+	old, err := s.CheckService.FindCheckByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	c, err := s.CheckService.FindCheckByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if upd.Name != nil {
+		c.SetName(*upd.Name)
+	}
+	if upd.Description != nil {
+		c.SetDescription(*upd.Description)
+	}
+	if upd.Status != nil {
+		c.SetStatus(*upd.Status)
+	}
+	mc, err := s.review(ctx, Update, c, old, userIDFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if mc != c {
+		return s.CheckService.UpdateCheck(ctx, id, mc)
+	}
+	return s.CheckService.PatchCheck(ctx, id, upd)
+}
+
+// DeleteCheck reviews the deletion of the check before deleting it.
+func (s *CheckService) DeleteCheck(ctx context.Context, id influxdb.ID) error {
+	old, err := s.CheckService.FindCheckByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if _, err := s.review(ctx, Delete, nil, old, userIDFromContext(ctx)); err != nil {
+		return err
+	}
+	return s.CheckService.DeleteCheck(ctx, id)
+}
+
+// userIDFromContext returns the user of the authorizer of ctx, if any.
+func userIDFromContext(ctx context.Context) *influxdb.ID {
+	a, err := pctx.GetAuthorizer(ctx)
+	if err != nil {
+		return nil
+	}
+	id := a.GetUserID()
+	return &id
+}
@@ -0,0 +1,45 @@
+package admission
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.CheckBulkCreateService = (*CheckBulkCreateService)(nil)
+
+// CheckBulkCreateService reviews each check of a bulk creation with a
+// webhook before creating them with the wrapped service.
+type CheckBulkCreateService struct {
+	influxdb.CheckBulkCreateService
+	checks *CheckService
+}
+
+// NewCheckBulkCreateService wraps s to review the checks it creates with w.
+func NewCheckBulkCreateService(s influxdb.CheckBulkCreateService, w *Webhook) *CheckBulkCreateService {
+	return &CheckBulkCreateService{
+		CheckBulkCreateService: s,
+		checks:                 &CheckService{Webhook: w},
+	}
+}
+
+// CreateChecks reviews the creation of every check, and creates the checks
+// as reviewed if the webhook rejects none of them.
+func (s *CheckBulkCreateService) CreateChecks(ctx context.Context, c influxdb.CheckBulkCreate, userID influxdb.ID) error {
+	reviewed := c
+	reviewed.Checks = make([]influxdb.Check, len(c.Checks))
+	for i, ch := range c.Checks {
+		mc, err := s.checks.review(ctx, Create, ch, nil, &userID)
+		if err != nil {
+			return err
+		}
+		reviewed.Checks[i] = mc
+	}
+	if err := s.CheckBulkCreateService.CreateChecks(ctx, reviewed, userID); err != nil {
+		return err
+	}
+	for i, mc := range reviewed.Checks {
+		c.Checks[i] = mc
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package admission_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/admission"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/notification/check"
+	"go.uber.org/zap"
+)
+
+// runbookWebhook rejects the checks without a runbook link in their description,
+// and adds a tag to the others.
+func runbookWebhook(t *testing.T, reviews *[]admission.Review) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var review admission.Review
+		if err := json.NewDecoder(r.Body).Decode(&review); err != nil {
+			t.Fatalf("invalid review: %v", err)
+		}
+		*reviews = append(*reviews, review)
+		if review.Operation == admission.Delete {
+			json.NewEncoder(w).Encode(admission.Response{Allowed: true})
+			return
+		}
+		var c map[string]interface{}
+		if err := json.Unmarshal(review.Object, &c); err != nil {
+			t.Fatalf("invalid check: %v", err)
+		}
+		desc, _ := c["description"].(string)
+		if !strings.Contains(desc, "runbook: ") {
+			json.NewEncoder(w).Encode(admission.Response{Message: "checks require a runbook link"})
+			return
+		}
+		c["tags"] = []map[string]string{{"key": "reviewed", "value": "true"}}
+		c["orgID"] = "00000000000000ff"
+		obj, _ := json.Marshal(c)
+		json.NewEncoder(w).Encode(admission.Response{Allowed: true, Object: obj})
+	}
+}
+
+func newDeadman(desc string) *check.Deadman {
+	return &check.Deadman{
+		Base: check.Base{
+			Name:        "heartbeat",
+			Description: desc,
+			OrgID:       influxdb.ID(1),
+			Status:      influxdb.Active,
+			Every:       influxdb.Duration{Duration: time.Minute},
+		},
+		TimeSince: 60,
+	}
+}
+
+func TestCheckService(t *testing.T) {
+	var reviews []admission.Review
+	srv := httptest.NewServer(runbookWebhook(t, &reviews))
+	defer srv.Close()
+
+	var created influxdb.Check
+	var deleted bool
+	s := admission.NewCheckService(&mock.CheckService{
+		FindCheckByIDF: func(ctx context.Context, id influxdb.ID) (influxdb.Check, error) {
+			c := newDeadman("runbook: https://wiki/heartbeat")
+			c.ID = id
+			return c, nil
+		},
+		CreateCheckF: func(ctx context.Context, c influxdb.Check, userID influxdb.ID) error {
+			c.SetID(influxdb.ID(10))
+			created = c
+			return nil
+		},
+		DeleteCheckF: func(ctx context.Context, id influxdb.ID) error {
+			deleted = true
+			return nil
+		},
+	}, admission.NewWebhook(srv.URL, zap.NewNop()))
+	ctx := context.Background()
+
+	err := s.CreateCheck(ctx, newDeadman("heartbeat of the hosts"), influxdb.ID(2))
+	if influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Fatalf("expected the check without runbook to be rejected, got %v", err)
+	}
+	if want := "create of checks rejected by the validation webhook: checks require a runbook link"; influxdb.ErrorMessage(err) != want {
+		t.Errorf("got error %q, want %q", influxdb.ErrorMessage(err), want)
+	}
+	if created != nil {
+		t.Fatalf("expected the rejected check not to be created")
+	}
+
+	c := newDeadman("runbook: https://wiki/heartbeat")
+	if err := s.CreateCheck(ctx, c, influxdb.ID(2)); err != nil {
+		t.Fatalf("unexpected error creating check: %v", err)
+	}
+	if created == nil || len(created.(*check.Deadman).Tags) != 1 {
+		t.Fatalf("expected the check mutated by the webhook to be created, got %+v", created)
+	}
+	if created.GetOrgID() != influxdb.ID(1) {
+		t.Errorf("expected the webhook not to change the organization of the check, got %s", created.GetOrgID())
+	}
+	if c.ID != influxdb.ID(10) {
+		t.Errorf("expected the id of the created check to be set, got %s", c.ID)
+	}
+	if reviews[1].UserID == nil || *reviews[1].UserID != influxdb.ID(2) || reviews[1].ResourceType != influxdb.ChecksResourceType {
+		t.Errorf("unexpected review %+v", reviews[1])
+	}
+
+	if err := s.DeleteCheck(ctx, influxdb.ID(10)); err != nil {
+		t.Fatalf("unexpected error deleting check: %v", err)
+	}
+	if !deleted || reviews[2].Operation != admission.Delete || reviews[2].OldObject == nil {
+		t.Errorf("expected the deletion to be reviewed, got %+v", reviews[2])
+	}
+}
+
+func TestWebhook_failurePolicy(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	cs := &mock.CheckServiceMock{
+		CreateCheckFunc: func(ctx context.Context, c influxdb.Check, userID influxdb.ID) error {
+			return nil
+		},
+	}
+
+	w := admission.NewWebhook(srv.URL, zap.NewNop())
+	err := admission.NewCheckService(cs, w).CreateCheck(context.Background(), newDeadman(""), influxdb.ID(2))
+	if influxdb.ErrorCode(err) != influxdb.EUnavailable || len(cs.CreateCheckCalls()) != 0 {
+		t.Fatalf("expected a fail-closed webhook to reject the check, got %v", err)
+	}
+
+	w.FailurePolicy = admission.FailOpen
+	err = admission.NewCheckService(cs, w).CreateCheck(context.Background(), newDeadman(""), influxdb.ID(2))
+	if err != nil {
+		t.Fatalf("expected a fail-open webhook to allow the check, got %v", err)
+	}
+	calls := cs.CreateCheckCalls()
+	if len(calls) != 1 || calls[0].UserID != influxdb.ID(2) || calls[0].C.GetName() != "heartbeat" {
+		t.Fatalf("expected the check to be created once by user 2, got %+v", calls)
+	}
+}
@@ -0,0 +1,146 @@
+package admission
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification/rule"
+)
+
+var _ influxdb.NotificationRuleStore = (*NotificationRuleStore)(nil)
+
+// NotificationRuleStore reviews the mutations of the notification rules with
+// a webhook before persisting them with the wrapped store.
+type NotificationRuleStore struct {
+	influxdb.NotificationRuleStore
+	Webhook *Webhook
+}
+
+// NewNotificationRuleStore wraps s to review the mutations of the notification rules with w.
+func NewNotificationRuleStore(s influxdb.NotificationRuleStore, w *Webhook) *NotificationRuleStore {
+	return &NotificationRuleStore{
+		NotificationRuleStore: s,
+		Webhook:               w,
+	}
+}
+
+// review sends the mutation of nr to the webhook, and returns the notification rule to persist.
+func (s *NotificationRuleStore) review(ctx context.Context, op Operation, nr, old influxdb.NotificationRule, userID *influxdb.ID) (influxdb.NotificationRule, error) {
+	r := Review{
+		Operation:    op,
+		ResourceType: influxdb.NotificationRuleResourceType,
+		UserID:       userID,
+	}
+	var err error
+	if nr != nil {
+		r.OrgID = nr.GetOrgID()
+		if r.Object, err = json.Marshal(nr); err != nil {
+			return nil, err
+		}
+	}
+	if old != nil {
+		r.OrgID = old.GetOrgID()
+		if r.OldObject, err = json.Marshal(old); err != nil {
+			return nil, err
+		}
+	}
+	obj, err := s.Webhook.Review(ctx, r)
+	if err != nil || obj == nil {
+		return nr, err
+	}
+
+	mnr, err := rule.UnmarshalJSON(obj)
+	if err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "invalid notification rule mutated by the validation webhook",
+			Err:  err,
+		}
+	}
+	if err := typeChanged("notification rule", nr.Type(), mnr.Type()); err != nil {
+		return nil, err
+	}
+	// the webhook doesn't move rules to other organizations, nor renumbers them.
+	mnr.SetID(nr.GetID())
+	mnr.SetOrgID(nr.GetOrgID())
+	return mnr, nil
+}
+
+// CreateNotificationRule reviews the creation of nr before creating it.
+func (s *NotificationRuleStore) CreateNotificationRule(ctx context.Context, nr influxdb.NotificationRule, userID influxdb.ID) error {
+	mnr, err := s.review(ctx, Create, nr, nil, &userID)
+	if err != nil {
+		return err
+	}
+	if err := s.NotificationRuleStore.CreateNotificationRule(ctx, mnr, userID); err != nil {
+		return err
+	}
+	if mnr != nr {
+		nr.SetID(mnr.GetID())
+		nr.SetCreatedAt(mnr.GetCRUDLog().CreatedAt)
+		nr.SetUpdatedAt(mnr.GetCRUDLog().UpdatedAt)
+	}
+	return nil
+}
+
+// UpdateNotificationRule reviews the update of the notification rule to nr before updating it.
+func (s *NotificationRuleStore) UpdateNotificationRule(ctx context.Context, id influxdb.ID, nr influxdb.NotificationRule, userID influxdb.ID) (influxdb.NotificationRule, error) {
+	old, err := s.NotificationRuleStore.FindNotificationRuleByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	nr.SetID(id)
+	mnr, err := s.review(ctx, Update, nr, old, &userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.NotificationRuleStore.UpdateNotificationRule(ctx, id, mnr, userID)
+}
+
+// PatchNotificationRule reviews the notification rule patched with upd before patching it.
+// A notification rule the webhook mutated is updated as a whole.
+func (s *NotificationRuleStore) PatchNotificationRule(ctx context.Context, id influxdb.ID, upd influxdb.NotificationRuleUpdate) (influxdb.NotificationRule, error) {
+	old, err := s.NotificationRuleStore.FindNotificationRuleByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	nr, err := s.NotificationRuleStore.FindNotificationRuleByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if upd.Name != nil {
+		nr.SetName(*upd.Name)
+	}
+	if upd.Description != nil {
+		nr.SetDescription(*upd.Description)
+	}
+	if upd.Status != nil {
+		nr.SetStatus(*upd.Status)
+	}
+	userID := userIDFromContext(ctx)
+	mnr, err := s.review(ctx, Update, nr, old, userID)
+	if err != nil {
+		return nil, err
+	}
+	if mnr != nr {
+		var uid influxdb.ID
+		if userID != nil {
+			uid = *userID
+		}
+		return s.NotificationRuleStore.UpdateNotificationRule(ctx, id, mnr, uid)
+	}
+	return s.NotificationRuleStore.PatchNotificationRule(ctx, id, upd)
+}
+
+// DeleteNotificationRule reviews the deletion of the notification rule before deleting it.
+func (s *NotificationRuleStore) DeleteNotificationRule(ctx context.Context, id influxdb.ID) error {
+	old, err := s.NotificationRuleStore.FindNotificationRuleByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if _, err := s.review(ctx, Delete, nil, old, userIDFromContext(ctx)); err != nil {
+		return err
+	}
+	return s.NotificationRuleStore.DeleteNotificationRule(ctx, id)
+}
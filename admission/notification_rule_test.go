@@ -0,0 +1,86 @@
+package admission_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/admission"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/notification/rule"
+	"go.uber.org/zap"
+)
+
+// allowWebhook allows every review without mutating it, but the deletions.
+func allowWebhook(w http.ResponseWriter, r *http.Request) {
+	var review admission.Review
+	json.NewDecoder(r.Body).Decode(&review)
+	if review.Operation == admission.Delete {
+		json.NewEncoder(w).Encode(admission.Response{Message: "rules are never deleted"})
+		return
+	}
+	json.NewEncoder(w).Encode(admission.Response{Allowed: true})
+}
+
+func newSlackRule(id influxdb.ID) *rule.Slack {
+	return &rule.Slack{
+		Base: rule.Base{
+			ID:     id,
+			Name:   "pager",
+			OrgID:  influxdb.ID(1),
+			Status: influxdb.Active,
+		},
+		Channel: "#ops",
+	}
+}
+
+func TestNotificationRuleStore(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(allowWebhook))
+	defer srv.Close()
+
+	rs := &mock.NotificationRuleStoreMock{
+		FindNotificationRuleByIDFunc: func(ctx context.Context, id influxdb.ID) (influxdb.NotificationRule, error) {
+			return newSlackRule(id), nil
+		},
+		PatchNotificationRuleFunc: func(ctx context.Context, id influxdb.ID, upd influxdb.NotificationRuleUpdate) (influxdb.NotificationRule, error) {
+			nr := newSlackRule(id)
+			nr.Name = *upd.Name
+			return nr, nil
+		},
+	}
+	s := admission.NewNotificationRuleStore(rs, admission.NewWebhook(srv.URL, zap.NewNop()))
+	ctx := context.Background()
+
+	name := "on call"
+	nr, err := s.PatchNotificationRule(ctx, influxdb.ID(3), influxdb.NotificationRuleUpdate{Name: &name})
+	if err != nil {
+		t.Fatalf("unexpected error patching notification rule: %v", err)
+	}
+	if nr.GetName() != name {
+		t.Errorf("expected the patched rule to be returned, got %q", nr.GetName())
+	}
+	for _, c := range rs.FindNotificationRuleByIDCalls() {
+		if c.Id != influxdb.ID(3) {
+			t.Errorf("expected rule 3 to be found, got %s", c.Id)
+		}
+	}
+	// a rule the webhook didn't mutate is patched rather than updated as a whole.
+	patches := rs.PatchNotificationRuleCalls()
+	if len(patches) != 1 || patches[0].Id != influxdb.ID(3) || *patches[0].Upd.Name != name {
+		t.Fatalf("expected rule 3 to be patched once, got %+v", patches)
+	}
+
+	err = s.DeleteNotificationRule(ctx, influxdb.ID(3))
+	if influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Fatalf("expected the deletion to be rejected, got %v", err)
+	}
+	if want := "delete of notificationRules rejected by the validation webhook: rules are never deleted"; influxdb.ErrorMessage(err) != want {
+		t.Errorf("got error %q, want %q", influxdb.ErrorMessage(err), want)
+	}
+	if n := len(rs.DeleteNotificationRuleCalls()); n != 0 {
+		t.Errorf("expected the rejected rule not to be deleted, got %d deletions", n)
+	}
+}
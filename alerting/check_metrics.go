@@ -0,0 +1,172 @@
+package alerting
+
+import (
+	"context"
+	"sort"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// DefaultCheckMetricsOrgLimit is the default number of organizations the
+// check metrics are split out by.
+const DefaultCheckMetricsOrgLimit = 100
+
+// otherOrg is the org label of the checks of the organizations over the
+// limit of a CheckCollector.
+const otherOrg = "other"
+
+var (
+	checksDesc = prometheus.NewDesc(
+		"influxdb_checks_total",
+		"Number of checks, split out by organization.",
+		[]string{"org"}, nil)
+
+	checksFiringDesc = prometheus.NewDesc(
+		"influxdb_checks_firing",
+		"Number of active checks whose latest status isn't ok, split out by organization and level.",
+		[]string{"org", "level"}, nil)
+)
+
+var _ prometheus.Collector = (*CheckCollector)(nil)
+
+// CheckCollector collects the number of checks of the organizations, and of
+// their checks firing at each level, when the metrics are scraped. The
+// organizations with the most checks are split out up to OrgLimit, the
+// checks of the others are collected with the "other" org label so the
+// cardinality of the metrics stays bounded.
+type CheckCollector struct {
+	CheckService       influxdb.CheckService
+	CheckStatusService influxdb.CheckStatusService
+	// OrgLimit is the number of organizations split out, every organization
+	// is when 0.
+	OrgLimit int
+	Logger   *zap.Logger
+}
+
+// NewCheckCollector returns a collector of the checks of checkService and
+// of their statuses, split out by DefaultCheckMetricsOrgLimit organizations.
+func NewCheckCollector(checkService influxdb.CheckService, checkStatusService influxdb.CheckStatusService) *CheckCollector {
+	return &CheckCollector{
+		CheckService:       checkService,
+		CheckStatusService: checkStatusService,
+		OrgLimit:           DefaultCheckMetricsOrgLimit,
+		Logger:             zap.NewNop(),
+	}
+}
+
+// Describe returns all descriptions of the collector.
+func (c *CheckCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- checksDesc
+	ch <- checksFiringDesc
+}
+
+// Collect returns the current state of all metrics of the collector. No
+// metric is collected when the checks or their statuses can't be found.
+func (c *CheckCollector) Collect(ch chan<- prometheus.Metric) {
+	stats, err := c.stats(context.Background())
+	if err != nil {
+		c.Logger.Info("failed to collect check metrics", zap.Error(err))
+		return
+	}
+	for _, s := range stats {
+		ch <- prometheus.MustNewConstMetric(
+			checksDesc,
+			prometheus.GaugeValue,
+			float64(s.checks),
+			s.org,
+		)
+		for _, level := range sortedLevels(s.firing) {
+			ch <- prometheus.MustNewConstMetric(
+				checksFiringDesc,
+				prometheus.GaugeValue,
+				float64(s.firing[level]),
+				s.org, level,
+			)
+		}
+	}
+}
+
+// orgCheckStats are the number of checks of an organization, and of its
+// checks firing by level.
+type orgCheckStats struct {
+	org    string
+	checks int
+	firing map[string]int
+}
+
+func (s *orgCheckStats) add(o *orgCheckStats) {
+	s.checks += o.checks
+	for level, n := range o.firing {
+		s.firing[level] += n
+	}
+}
+
+// stats returns the stats of the organizations up to the limit, and then the
+// stats of the organizations over it added up.
+func (c *CheckCollector) stats(ctx context.Context) ([]*orgCheckStats, error) {
+	cs, _, err := c.CheckService.FindChecks(ctx, influxdb.CheckFilter{})
+	if err != nil {
+		return nil, err
+	}
+	var active []influxdb.ID
+	for _, ch := range cs {
+		if ch.GetStatus() == influxdb.Active {
+			active = append(active, ch.GetID())
+		}
+	}
+	var sts []*influxdb.CheckStatus
+	if len(active) > 0 {
+		if sts, err = c.CheckStatusService.FindCheckStatuses(ctx, active); err != nil {
+			return nil, err
+		}
+	}
+
+	byOrg := make(map[influxdb.ID]*orgCheckStats)
+	orgStats := func(orgID influxdb.ID) *orgCheckStats {
+		s, ok := byOrg[orgID]
+		if !ok {
+			s = &orgCheckStats{org: orgID.String(), firing: make(map[string]int)}
+			byOrg[orgID] = s
+		}
+		return s
+	}
+	for _, ch := range cs {
+		orgStats(ch.GetOrgID()).checks++
+	}
+	for _, st := range sts {
+		if st.Level != "" && st.Level != notification.Ok.String() {
+			orgStats(st.OrgID).firing[st.Level]++
+		}
+	}
+
+	stats := make([]*orgCheckStats, 0, len(byOrg))
+	for _, s := range byOrg {
+		stats = append(stats, s)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].checks != stats[j].checks {
+			return stats[i].checks > stats[j].checks
+		}
+		return stats[i].org < stats[j].org
+	})
+	if c.OrgLimit <= 0 || len(stats) <= c.OrgLimit {
+		return stats, nil
+	}
+	other := &orgCheckStats{org: otherOrg, firing: make(map[string]int)}
+	for _, s := range stats[c.OrgLimit:] {
+		other.add(s)
+	}
+	return append(stats[:c.OrgLimit], other), nil
+}
+
+func sortedLevels(firing map[string]int) []string {
+	levels := make([]string, 0, len(firing))
+	for level := range firing {
+		levels = append(levels, level)
+	}
+	sort.Strings(levels)
+	return levels
+}
@@ -0,0 +1,76 @@
+package alerting_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/alerting"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/notification/check"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestCheckCollector(t *testing.T) {
+	newCheck := func(id, orgID influxdb.ID, status influxdb.Status) influxdb.Check {
+		return &check.Deadman{Base: check.Base{ID: id, OrgID: orgID, Status: status}}
+	}
+	checks := []influxdb.Check{
+		newCheck(1, 10, influxdb.Active),
+		newCheck(2, 10, influxdb.Active),
+		newCheck(3, 10, influxdb.Inactive),
+		newCheck(4, 20, influxdb.Active),
+		newCheck(5, 20, influxdb.Active),
+		newCheck(6, 30, influxdb.Active),
+	}
+	levels := map[influxdb.ID]string{1: "CRIT", 2: "OK", 4: "WARN", 6: "CRIT"}
+
+	c := alerting.NewCheckCollector(
+		&mock.CheckService{
+			FindChecksF: func(ctx context.Context, filter influxdb.CheckFilter, opt ...influxdb.FindOptions) ([]influxdb.Check, int, error) {
+				return checks, len(checks), nil
+			},
+		},
+		&mock.CheckStatusService{
+			FindCheckStatusesF: func(ctx context.Context, checkIDs []influxdb.ID) ([]*influxdb.CheckStatus, error) {
+				var sts []*influxdb.CheckStatus
+				for _, id := range checkIDs {
+					if id == 3 {
+						t.Errorf("unexpected status of inactive check %s", id)
+					}
+					sts = append(sts, &influxdb.CheckStatus{CheckID: id, OrgID: checks[id-1].GetOrgID(), Level: levels[id]})
+				}
+				return sts, nil
+			},
+		},
+	)
+	c.OrgLimit = 1
+
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(c)
+	mfs, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("failed to gather metrics: %v", err)
+	}
+	got := map[string]float64{}
+	for _, mf := range mfs {
+		for _, m := range mf.GetMetric() {
+			name := mf.GetName()
+			for _, l := range m.GetLabel() {
+				name += "{" + l.GetValue() + "}"
+			}
+			got[name] = m.GetGauge().GetValue()
+		}
+	}
+	want := map[string]float64{
+		"influxdb_checks_total{000000000000000a}":        3,
+		"influxdb_checks_total{other}":                   3,
+		"influxdb_checks_firing{CRIT}{000000000000000a}": 1,
+		"influxdb_checks_firing{CRIT}{other}":            1,
+		"influxdb_checks_firing{WARN}{other}":            1,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected metrics\ngot  %v\nwant %v", got, want)
+	}
+}
@@ -0,0 +1,409 @@
+package alerting
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/lang"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/query"
+	"github.com/influxdata/influxdb/query/influxql"
+	"github.com/influxdata/influxdb/query/promql"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+)
+
+// Series is a series of the data of a check, such as a table of the result
+// of a flux query, and its values in time order.
+type Series struct {
+	Tags   map[string]string
+	Values []float64
+	Times  []time.Time
+}
+
+// SeriesQuery is the query of a check run by a data source.
+type SeriesQuery struct {
+	OrgID influxdb.ID
+	// Text is the query in the language of the data source.
+	Text string
+	// ScrapeURL is the metrics endpoint of the prometheus queries.
+	ScrapeURL string
+	// Now is the time the check is evaluated at.
+	Now time.Time
+}
+
+// DataSource runs the queries of the checks of a query type, such as flux.
+type DataSource interface {
+	// QuerySeries returns the series of the data of a query.
+	QuerySeries(ctx context.Context, q SeriesQuery) ([]*Series, error)
+}
+
+// queriedCheck is a check whose query is run by the data source of its query type.
+type queriedCheck interface {
+	GetQuery() influxdb.DashboardQuery
+	GetQueryType() string
+	GetScrapeURL() string
+}
+
+// querySeries returns the series of the data of the query of a check, from
+// the data source of its query type.
+func (r *run) querySeries(ctx context.Context, c influxdb.Check) ([]*Series, error) {
+	qc, ok := c.(queriedCheck)
+	if !ok {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("check type %s has no query", c.Type()),
+		}
+	}
+	ds, err := r.engine.dataSource(qc.GetQueryType())
+	if err != nil {
+		return nil, err
+	}
+	return ds.QuerySeries(ctx, SeriesQuery{
+		OrgID:     c.GetOrgID(),
+		Text:      qc.GetQuery().Text,
+		ScrapeURL: qc.GetScrapeURL(),
+		Now:       r.now,
+	})
+}
+
+// dataSource returns the data source of a query type.
+func (e *Engine) dataSource(queryType string) (DataSource, error) {
+	ds, ok := e.DataSources[queryType]
+	if !ok {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("no data source runs the %s queries of the checks", queryType),
+		}
+	}
+	return ds, nil
+}
+
+// fluxDataSource runs flux queries with a query service.
+type fluxDataSource struct {
+	queryService query.QueryService
+}
+
+// NewFluxDataSource returns the data source of the flux queries, run by qs.
+func NewFluxDataSource(qs query.QueryService) DataSource {
+	return &fluxDataSource{queryService: qs}
+}
+
+// QuerySeries returns a series per table of the results of the query.
+func (s *fluxDataSource) QuerySeries(ctx context.Context, q SeriesQuery) ([]*Series, error) {
+	return querySeries(ctx, s.queryService, q, lang.FluxCompiler{
+		Now:   q.Now,
+		Query: q.Text,
+	})
+}
+
+// influxQLDataSource runs InfluxQL queries with a query service.
+type influxQLDataSource struct {
+	queryService query.QueryService
+	dbrpMappings influxdb.DBRPMappingService
+}
+
+// NewInfluxQLDataSource returns the data source of the InfluxQL queries, run
+// by qs on the buckets mapped to their database and retention policy by
+// dbrpMappings.
+func NewInfluxQLDataSource(qs query.QueryService, dbrpMappings influxdb.DBRPMappingService) DataSource {
+	return &influxQLDataSource{
+		queryService: qs,
+		dbrpMappings: dbrpMappings,
+	}
+}
+
+// QuerySeries returns a series per table of the results of the query, the
+// values of a series are its first numeric column.
+func (s *influxQLDataSource) QuerySeries(ctx context.Context, q SeriesQuery) ([]*Series, error) {
+	c := influxql.NewCompiler(s.dbrpMappings)
+	c.Query = q.Text
+	c.Now = &q.Now
+	return querySeries(ctx, s.queryService, q, c)
+}
+
+// querySeries returns the series of the results of a compiled query, one per
+// table. The series are tagged with the string columns of the group key of
+// their table which aren't prefixed with an underscore. Their values are the
+// _value column, or the first numeric column which isn't prefixed with an
+// underscore when there's no _value column, such as a field selected by
+// InfluxQL.
+func querySeries(ctx context.Context, qs query.QueryService, q SeriesQuery, compiler flux.Compiler) ([]*Series, error) {
+	var ss []*Series
+	err := runQuery(ctx, qs, q.OrgID, compiler, func(res flux.Result) error {
+		return res.Tables().Do(func(tbl flux.Table) error {
+			s := &Series{Tags: make(map[string]string)}
+			key := tbl.Key()
+			for j, col := range key.Cols() {
+				if !strings.HasPrefix(col.Label, "_") && col.Type == flux.TString {
+					s.Tags[col.Label] = key.ValueString(j)
+				}
+			}
+			ss = append(ss, s)
+			return tbl.Do(func(cr flux.ColReader) error {
+				valueIdx, timeIdx := -1, -1
+				for j, col := range cr.Cols() {
+					switch {
+					case col.Label == "_value":
+						valueIdx = j
+					case col.Label == "_time" && col.Type == flux.TTime:
+						timeIdx = j
+					}
+				}
+				if valueIdx < 0 {
+					for j, col := range cr.Cols() {
+						if !strings.HasPrefix(col.Label, "_") && isNumeric(col.Type) && !tbl.Key().HasCol(col.Label) {
+							valueIdx = j
+							break
+						}
+					}
+				}
+				if valueIdx < 0 {
+					return nil
+				}
+				for i := 0; i < cr.Len(); i++ {
+					v, ok := floatValue(cr, valueIdx, i)
+					if !ok {
+						continue
+					}
+					t := q.Now
+					if timeIdx >= 0 && cr.Times(timeIdx).IsValid(i) {
+						t = time.Unix(0, cr.Times(timeIdx).Value(i)).UTC()
+					}
+					s.Values = append(s.Values, v)
+					s.Times = append(s.Times, t)
+				}
+				return nil
+			})
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ss, nil
+}
+
+// runQuery runs a compiled query, calling fn for each result.
+func runQuery(ctx context.Context, qs query.QueryService, orgID influxdb.ID, compiler flux.Compiler, fn func(flux.Result) error) error {
+	req := &query.Request{
+		OrganizationID: orgID,
+		Compiler:       compiler,
+	}
+	ittr, err := qs.Query(ctx, req)
+	if err != nil {
+		return err
+	}
+	defer ittr.Release()
+
+	for ittr.More() {
+		if err := fn(ittr.Next()); err != nil {
+			return err
+		}
+	}
+	return ittr.Err()
+}
+
+func isNumeric(t flux.ColType) bool {
+	return t == flux.TFloat || t == flux.TInt || t == flux.TUInt
+}
+
+// prometheusDataSource scrapes Prometheus-compatible metrics endpoints.
+type prometheusDataSource struct {
+	client *http.Client
+}
+
+// NewPrometheusDataSource returns the data source of the prometheus queries,
+// which scrapes the metrics endpoint of a check with client, and evaluates
+// the metric selector of the check on the scraped metrics.
+func NewPrometheusDataSource(client *http.Client) DataSource {
+	return &prometheusDataSource{client: client}
+}
+
+// QuerySeries returns a series per metric matching the selector of the
+// query, tagged with the labels of the metric. The _sum and _count suffixes
+// select the sum and count of the summaries and histograms. A metric without
+// a timestamp is at the time of the query.
+func (s *prometheusDataSource) QuerySeries(ctx context.Context, q SeriesQuery) ([]*Series, error) {
+	sel, err := parseSelector(q.Text)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", q.ScrapeURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", string(expfmt.FmtText))
+	resp, err := s.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, &influxdb.Error{
+			Code: influxdb.EUnavailable,
+			Msg:  fmt.Sprintf("scraping %s failed with status %d", q.ScrapeURL, resp.StatusCode),
+		}
+	}
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(resp.Body)
+	if err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("unable to parse the metrics of %s", q.ScrapeURL),
+			Err:  err,
+		}
+	}
+
+	name, value := sel.Name, metricValue
+	family, ok := families[name]
+	if !ok {
+		for suffix, v := range map[string]func(*dto.Metric) (float64, bool){"_sum": metricSum, "_count": metricCount} {
+			if f, found := families[strings.TrimSuffix(name, suffix)]; found && strings.HasSuffix(name, suffix) {
+				family, value = f, v
+				break
+			}
+		}
+	}
+	if family == nil {
+		return nil, nil
+	}
+
+	var ss []*Series
+	for _, m := range family.Metric {
+		tags := make(map[string]string, len(m.Label))
+		for _, l := range m.Label {
+			tags[l.GetName()] = l.GetValue()
+		}
+		if !sel.matches(tags) {
+			continue
+		}
+		v, ok := value(m)
+		if !ok {
+			continue
+		}
+		t := q.Now
+		if m.TimestampMs != nil {
+			t = time.Unix(0, m.GetTimestampMs()*int64(time.Millisecond)).UTC()
+		}
+		ss = append(ss, &Series{
+			Tags:   tags,
+			Values: []float64{v},
+			Times:  []time.Time{t},
+		})
+	}
+	return ss, nil
+}
+
+func metricValue(m *dto.Metric) (float64, bool) {
+	switch {
+	case m.Gauge != nil:
+		return m.Gauge.GetValue(), true
+	case m.Counter != nil:
+		return m.Counter.GetValue(), true
+	case m.Untyped != nil:
+		return m.Untyped.GetValue(), true
+	}
+	return 0, false
+}
+
+func metricSum(m *dto.Metric) (float64, bool) {
+	switch {
+	case m.Summary != nil:
+		return m.Summary.GetSampleSum(), true
+	case m.Histogram != nil:
+		return m.Histogram.GetSampleSum(), true
+	}
+	return 0, false
+}
+
+func metricCount(m *dto.Metric) (float64, bool) {
+	switch {
+	case m.Summary != nil:
+		return float64(m.Summary.GetSampleCount()), true
+	case m.Histogram != nil:
+		return float64(m.Histogram.GetSampleCount()), true
+	}
+	return 0, false
+}
+
+// selector is a metric selector of a prometheus query.
+type selector struct {
+	Name     string
+	matchers []labelMatcher
+}
+
+type labelMatcher struct {
+	name  string
+	kind  promql.MatchKind
+	value string
+	re    *regexp.Regexp
+}
+
+// parseSelector parses a metric selector, such as http_requests_total{code="500"}.
+func parseSelector(text string) (*selector, error) {
+	parsed, err := promql.ParsePromQL(text)
+	if err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("unable to parse the prometheus query %q", text),
+			Err:  err,
+		}
+	}
+	ps, ok := parsed.(*promql.Selector)
+	if !ok || ps.Range != 0 || ps.Offset != 0 {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("prometheus query %q must be a metric selector, such as http_requests_total{code=\"500\"}", text),
+		}
+	}
+	sel := &selector{Name: ps.Name}
+	for _, m := range ps.LabelMatchers {
+		lm := labelMatcher{
+			name: m.Name,
+			kind: m.Kind,
+		}
+		if m.Value != nil {
+			lm.value = fmt.Sprint(m.Value.Value())
+		}
+		if lm.kind == promql.RegexMatch || lm.kind == promql.RegexNoMatch {
+			if lm.re, err = regexp.Compile("^(?:" + lm.value + ")$"); err != nil {
+				return nil, &influxdb.Error{
+					Code: influxdb.EInvalid,
+					Msg:  fmt.Sprintf("invalid regular expression of the label %s", m.Name),
+					Err:  err,
+				}
+			}
+		}
+		sel.matchers = append(sel.matchers, lm)
+	}
+	return sel, nil
+}
+
+// matches returns whether the labels of a metric match the selector, a
+// missing label is empty.
+func (s *selector) matches(labels map[string]string) bool {
+	for _, m := range s.matchers {
+		v := labels[m.name]
+		var ok bool
+		switch m.kind {
+		case promql.Equal:
+			ok = v == m.value
+		case promql.NotEqual:
+			ok = v != m.value
+		case promql.RegexMatch:
+			ok = m.re.MatchString(v)
+		case promql.RegexNoMatch:
+			ok = !m.re.MatchString(v)
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
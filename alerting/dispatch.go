@@ -0,0 +1,502 @@
+package alerting
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/sender"
+	"go.uber.org/zap"
+)
+
+// routedRule is a notification rule sending the statuses it matches to an endpoint.
+type routedRule interface {
+	GetEndpointID() *influxdb.ID
+	EndpointAt(t time.Time) *influxdb.ID
+	GetRoutes() []influxdb.NotificationRoute
+	GetTagRules() []notification.TagRule
+	GetStatusRules() []notification.StatusRule
+}
+
+// recoveryRule is a notification rule which may notify the recoveries of the series.
+type recoveryRule interface {
+	GetNotifyRecovery() bool
+}
+
+// notifiesRecovery returns whether a rule notifies a status as the recovery of its series.
+func notifiesRecovery(nr influxdb.NotificationRule, st notification.Status) bool {
+	rr, ok := nr.(recoveryRule)
+	return ok && rr.GetNotifyRecovery() && st.Level == notification.Ok && st.IncidentDuration > 0
+}
+
+// templatedRule is a notification rule with a message template.
+type templatedRule interface {
+	GetMessageTemplate() string
+}
+
+// dispatch sends the notifications of the statuses of a check to the endpoints
+// of the active rules of its organization matching them. The notifications
+// which fail to be sent are logged, they don't stop the others. The
+// statuses of the checks carrying the label of an active silence, and the
+// statuses of the organizations whose alerting is paused, are muted.
+// It returns the decision trace of every status, which is recorded for the
+// statuses with an id.
+func (r *run) dispatch(ctx context.Context, orgID influxdb.ID, sts []notification.Status) ([]*influxdb.StatusTrace, error) {
+	rules, err := r.findRules(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	pause, err := r.findPause(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	traces := make([]*influxdb.StatusTrace, 0, len(sts))
+	for _, st := range sts {
+		prev, hasPrev := r.engine.swapLevel(&st)
+		tags := statusTags(st)
+		trace := &influxdb.StatusTrace{
+			StatusID: st.ID,
+			CheckID:  st.CheckID,
+			OrgID:    st.OrgID,
+			Level:    st.Level.String(),
+			Message:  st.Message,
+			Time:     st.Time,
+			Rules:    make([]influxdb.RuleTrace, 0, len(rules)),
+		}
+		silence, err := r.findSilence(ctx, st)
+		if err != nil {
+			r.engine.Logger.Info("failed to find the silences of check",
+				zap.String("checkID", st.CheckID.String()),
+				zap.Error(err))
+		}
+		for _, nr := range rules {
+			if pause != nil {
+				trace.Rules = append(trace.Rules, pausedRule(nr, pause))
+				continue
+			}
+			if silence != nil {
+				trace.Rules = append(trace.Rules, silencedRule(nr, silence))
+				continue
+			}
+			trace.Rules = append(trace.Rules, r.route(ctx, st, tags, prev, hasPrev, nr))
+		}
+		if st.ID.Valid() && r.engine.StatusTraceService != nil {
+			if err := r.engine.StatusTraceService.CreateStatusTrace(ctx, trace); err != nil {
+				r.engine.Logger.Info("failed to record status trace",
+					zap.String("statusID", st.ID.String()),
+					zap.String("checkID", st.CheckID.String()),
+					zap.Error(err))
+			}
+		}
+		r.engine.metrics.dispatched(trace)
+		traces = append(traces, trace)
+	}
+	return traces, nil
+}
+
+// route decides what a rule does with a status, given the previous level of
+// its series, and sends its notification if the rule matches it.
+func (r *run) route(ctx context.Context, st notification.Status, tags []notification.Tag, prev notification.CheckLevel, hasPrev bool, nr influxdb.NotificationRule) influxdb.RuleTrace {
+	rt, endpointID := match(st, tags, prev, hasPrev, nr, r.now)
+	if endpointID == nil {
+		return rt
+	}
+	if err := r.notify(ctx, st, nr, *endpointID, &rt); err != nil {
+		rt.Decision, rt.Reason = influxdb.RuleFailed, err.Error()
+		r.engine.Logger.Info("failed to send notification",
+			zap.String("ruleID", nr.GetID().String()),
+			zap.String("checkID", st.CheckID.String()),
+			zap.Error(err))
+	}
+	return rt
+}
+
+// match returns the endpoint a rule sends a status to at now, given the
+// previous level of its series, or nil with the decision of the rule on the
+// trace if the rule doesn't match the status. A rule notifying the recoveries
+// matches the statuses recovering to ok whatever its status rules. The
+// endpoint of the rule is chosen by the time, so the routes of the rule may
+// send it elsewhere out of hours.
+func match(st notification.Status, tags []notification.Tag, prev notification.CheckLevel, hasPrev bool, nr influxdb.NotificationRule, now time.Time) (influxdb.RuleTrace, *influxdb.ID) {
+	rt := influxdb.RuleTrace{
+		RuleID:   nr.GetID(),
+		RuleName: nr.GetName(),
+	}
+	rr, ok := nr.(routedRule)
+	switch {
+	case nr.GetStatus() != influxdb.Active:
+		rt.Decision, rt.Reason = influxdb.RuleMuted, "the rule is inactive"
+	case !ok || rr.GetEndpointID() == nil && len(rr.GetRoutes()) == 0:
+		rt.Decision, rt.Reason = influxdb.RuleMuted, "the rule has no notification endpoint"
+	case !notification.MatchTagRules(rr.GetTagRules(), tags):
+		rt.Decision, rt.Reason = influxdb.RuleUnmatched, "the tags of the status don't match the tag rules"
+	case !notifiesRecovery(nr, st) && !matchStatusRules(rr.GetStatusRules(), st.Level, prev, hasPrev):
+		switch {
+		case len(rr.GetStatusRules()) != 0:
+			rt.Decision, rt.Reason = influxdb.RuleUnmatched, "the levels of the series don't match the status rules"
+		case hasPrev:
+			rt.Decision, rt.Reason = influxdb.RuleDeduplicated, "the level of the series didn't change"
+		default:
+			rt.Decision, rt.Reason = influxdb.RuleUnmatched, "the first status of the series is ok"
+		}
+	case rr.EndpointAt(now) == nil:
+		rt.Decision, rt.Reason = influxdb.RuleMuted, "no route of the rule matches the time and it has no notification endpoint"
+	default:
+		return rt, rr.EndpointAt(now)
+	}
+	return rt, nil
+}
+
+// notify sends the notification of a status matched by a rule to its
+// endpoint, unless the rule exceeds its limit or notified the level of the
+// series within the dedup window of the organization. The preferences of the
+// user the endpoint is addressed to, and the quiet hours of the organization,
+// may defer or suppress the notification, and the budget of the endpoint may
+// redirect or drop it. The decision and the
+// endpoint of the notification are set on the rule trace.
+func (r *run) notify(ctx context.Context, st notification.Status, nr influxdb.NotificationRule, endpointID influxdb.ID, rt *influxdb.RuleTrace) error {
+	edp, err := r.engine.store.FindNotificationEndpointByID(ctx, endpointID)
+	if err != nil {
+		return err
+	}
+	if edp.GetStatus() != influxdb.Active {
+		rt.Decision, rt.Reason = influxdb.RuleMuted, "the notification endpoint is inactive"
+		return nil
+	}
+	settings, err := r.findSettings(ctx, nr.GetOrgID())
+	if err != nil {
+		return err
+	}
+	if !st.Synthetic && r.engine.duplicate(nr, st, settings.DedupWindow.Duration, r.now) {
+		rt.Decision, rt.Reason = influxdb.RuleDeduplicated, "the rule notified the level of the series within the dedup window of the organization"
+		return nil
+	}
+	if !st.Synthetic && !r.engine.allow(nr, st.CheckID, r.now) {
+		rt.Decision, rt.Reason = influxdb.RuleDeduplicated, "the rule reached its limit"
+		return nil
+	}
+
+	n := &sender.Notification{
+		Status:   st,
+		Rule:     nr,
+		Endpoint: edp,
+	}
+	// the recoveries are notified with the built-in phrase of their duration.
+	if tr, ok := nr.(templatedRule); ok && tr.GetMessageTemplate() != "" && !notifiesRecovery(nr, st) {
+		partials, err := r.findPartials(ctx, nr.GetOrgID())
+		if err != nil {
+			return err
+		}
+		load := func(key string) (string, error) {
+			return r.engine.SenderConfig.LoadSecret(ctx, nr.GetOrgID(), key)
+		}
+		if n.Message, err = notification.RenderSecretMessage(tr.GetMessageTemplate(), partials, st, load); err != nil {
+			return err
+		}
+	}
+
+	delivery, until := sender.Deliver, time.Time{}
+	if r.engine.Preferences != nil {
+		prefs := *r.engine.Preferences
+		if prefs.TimeGenerator == nil {
+			prefs.TimeGenerator = r.engine.TimeGenerator
+		}
+		d, err := prefs.Decide(ctx, n)
+		if err != nil {
+			return err
+		}
+		n.Endpoint = d.Endpoint
+		if d.Delivery == sender.Suppress {
+			rt.Decision, rt.Reason = influxdb.RuleMuted, "the notification preferences of the user suppress it"
+			return nil
+		}
+		delivery, until = d.Delivery, d.Until
+	}
+	quietOf := "the user"
+	if qh := settings.QuietHours; qh != nil {
+		quiet, end, err := qh.Window(r.now)
+		if err != nil {
+			return err
+		}
+		if quiet && qh.Action == influxdb.QuietHoursSuppress {
+			rt.Decision, rt.Reason = influxdb.RuleMuted, "the quiet hours of the organization suppress it"
+			return nil
+		}
+		if quiet && (delivery != sender.Defer || end.After(until)) {
+			delivery, until, quietOf = sender.Defer, end, "the organization"
+		}
+	}
+
+	if ok, err := r.spend(ctx, n, rt); err != nil || !ok {
+		return err
+	}
+
+	endpointID = n.Endpoint.GetID()
+	rt.EndpointID = &endpointID
+	r.engine.truncate(n, rt)
+	if delivery == sender.Defer {
+		rt.Decision, rt.Reason = influxdb.RuleDeferred, "the quiet hours of "+quietOf+" end at "+until.Format(time.RFC3339)
+		r.engine.deferNotification(n, until)
+		return nil
+	}
+	if err := r.engine.send(ctx, n); err != nil {
+		return err
+	}
+	rt.Decision = influxdb.RuleNotified
+	return nil
+}
+
+// spend counts a notification against the monthly budget of its endpoint.
+// Over the budget, the notification is sent to the fallback endpoint of the
+// budget, or dropped without one, and the first notification over the budget
+// of a month is reported to its admin endpoint. It returns whether the
+// notification is still sent.
+func (r *run) spend(ctx context.Context, n *sender.Notification, rt *influxdb.RuleTrace) (bool, error) {
+	svc := r.engine.NotificationBudgetService
+	if svc == nil {
+		return true, nil
+	}
+	endpointID := n.Endpoint.GetID()
+	u, err := svc.SpendNotificationBudget(ctx, endpointID, r.now)
+	if influxdb.ErrorCode(err) == influxdb.ENotFound {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if !u.Exceeded() {
+		return true, nil
+	}
+
+	b, err := svc.FindNotificationBudget(ctx, endpointID)
+	if err != nil {
+		return false, err
+	}
+	if u.Overage == 1 && b.AdminEndpointID != nil {
+		r.notifyBudgetAdmin(ctx, n, b)
+	}
+	if b.FallbackEndpointID == nil {
+		rt.Decision, rt.Reason = influxdb.RuleMuted, fmt.Sprintf("the notification endpoint exceeded its monthly budget of %d notifications", b.Monthly)
+		return false, nil
+	}
+	fallback, err := r.engine.store.FindNotificationEndpointByID(ctx, *b.FallbackEndpointID)
+	if err != nil {
+		return false, err
+	}
+	if fallback.GetStatus() != influxdb.Active {
+		rt.Decision, rt.Reason = influxdb.RuleMuted, "the notification endpoint exceeded its monthly budget and its fallback is inactive"
+		return false, nil
+	}
+	n.Endpoint = fallback
+	rt.Reason = "the notification endpoint exceeded its monthly budget, the notification is sent to its fallback"
+	return true, nil
+}
+
+// notifyBudgetAdmin tells the admin endpoint of a budget that the endpoint
+// of a notification exceeded it. The failures are logged.
+func (r *run) notifyBudgetAdmin(ctx context.Context, n *sender.Notification, b *influxdb.NotificationBudget) {
+	edp, err := r.engine.store.FindNotificationEndpointByID(ctx, *b.AdminEndpointID)
+	if err == nil && edp.GetStatus() == influxdb.Active {
+		err = r.engine.send(ctx, &sender.Notification{
+			Status: notification.Status{
+				CheckID:   n.Status.CheckID,
+				CheckName: n.Status.CheckName,
+				OrgID:     b.OrgID,
+				Level:     notification.Critical,
+				Time:      r.now,
+			},
+			Rule:     n.Rule,
+			Endpoint: edp,
+			Message:  fmt.Sprintf("notification endpoint %s exceeded its monthly budget of %d notifications", n.Endpoint.GetName(), b.Monthly),
+		})
+	}
+	if err != nil {
+		r.engine.Logger.Info("failed to notify the admin of a notification budget",
+			zap.String("endpointID", b.EndpointID.String()),
+			zap.Error(err))
+	}
+}
+
+// truncate cuts the messages of a notification over the maximum size of the
+// messages of its endpoint, so the service of the endpoint doesn't reject or
+// drop it. The cut is noted in the reason of the rule trace.
+func (e *Engine) truncate(n *sender.Notification, rt *influxdb.RuleTrace) {
+	if truncateMessages(e.SenderConfig.MessageLimits, n, rt) {
+		e.metrics.truncated.WithLabelValues(n.Endpoint.Type()).Inc()
+	}
+}
+
+// truncateMessages cuts the messages of a notification to limits, noting the
+// cut in the reason of the rule trace, and returns whether they were cut.
+func truncateMessages(limits sender.MessageLimits, n *sender.Notification, rt *influxdb.RuleTrace) bool {
+	typ := n.Endpoint.Type()
+	msg, msgCut := limits.Truncate(typ, n.Message)
+	stMsg, stCut := limits.Truncate(typ, n.Status.Message)
+	if !msgCut && !stCut {
+		return false
+	}
+	n.Message, n.Status.Message = msg, stMsg
+	reason := fmt.Sprintf("the message is truncated to the %d bytes of the %s endpoints", limits.Size(typ), typ)
+	if rt.Reason != "" {
+		reason = rt.Reason + "; " + reason
+	}
+	rt.Reason = reason
+	return true
+}
+
+// deferredNotification is a notification sent when the quiet hours of its
+// user end.
+type deferredNotification struct {
+	n     *sender.Notification
+	until time.Time
+}
+
+// deferNotification keeps a notification until a run at or after until.
+func (e *Engine) deferNotification(n *sender.Notification, until time.Time) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.deferred = append(e.deferred, deferredNotification{n: n, until: until})
+}
+
+// sendDeferred sends the deferred notifications whose quiet hours ended at
+// now, in the order of the priority of their check, the earliest due first
+// within a priority. The notifications which fail to be sent are
+// logged and dropped.
+func (e *Engine) sendDeferred(ctx context.Context, now time.Time) {
+	e.mu.Lock()
+	var due []deferredNotification
+	pending := e.deferred[:0]
+	for _, d := range e.deferred {
+		if d.until.After(now) {
+			pending = append(pending, d)
+			continue
+		}
+		due = append(due, d)
+	}
+	e.deferred = pending
+	e.mu.Unlock()
+
+	sort.SliceStable(due, func(i, j int) bool {
+		ri, rj := due[i].n.Status.Priority.Rank(), due[j].n.Status.Priority.Rank()
+		if ri != rj {
+			return ri < rj
+		}
+		return due[i].until.Before(due[j].until)
+	})
+	for _, d := range due {
+		n := d.n
+		if err := e.send(ctx, n); err != nil {
+			e.Logger.Info("failed to send deferred notification",
+				zap.String("ruleID", n.Rule.GetID().String()),
+				zap.String("checkID", n.Status.CheckID.String()),
+				zap.Error(err))
+		}
+	}
+}
+
+// send sends a notification to its endpoint, signing the requests at the
+// time of the engine unless the sender config has a clock.
+func (e *Engine) send(ctx context.Context, n *sender.Notification) (err error) {
+	span, ctx := e.startSpan(ctx, "alerting.send")
+	if n.Rule != nil {
+		span.SetTag("ruleID", n.Rule.GetID().String())
+	}
+	span.SetTag("endpointID", n.Endpoint.GetID().String())
+	span.SetTag("endpointType", n.Endpoint.Type())
+	defer func() {
+		if err != nil {
+			span.SetTag("error", true)
+		}
+		span.Finish()
+	}()
+
+	config := e.SenderConfig
+	if config.TimeGenerator == nil {
+		config.TimeGenerator = e.TimeGenerator
+	}
+	s, err := sender.New(n.Endpoint.Type(), config)
+	if err != nil {
+		return err
+	}
+	return s.Send(ctx, n)
+}
+
+// findRules returns the notification rules of an organization.
+func (r *run) findRules(ctx context.Context, orgID influxdb.ID) ([]influxdb.NotificationRule, error) {
+	if rules, ok := r.rules[orgID]; ok {
+		return rules, nil
+	}
+	rules, _, err := r.engine.store.FindNotificationRules(ctx, influxdb.NotificationRuleFilter{
+		OrgID: &orgID,
+		UserResourceMappingFilter: influxdb.UserResourceMappingFilter{
+			ResourceType: influxdb.NotificationRuleResourceType,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	r.rules[orgID] = rules
+	return rules, nil
+}
+
+// findPartials returns the notification templates of an organization by name,
+// none without a notification template service.
+func (r *run) findPartials(ctx context.Context, orgID influxdb.ID) (map[string]string, error) {
+	if partials, ok := r.partials[orgID]; ok {
+		return partials, nil
+	}
+	partials := make(map[string]string)
+	if svc := r.engine.NotificationTemplateService; svc != nil {
+		ts, _, err := svc.FindNotificationTemplates(ctx, influxdb.NotificationTemplateFilter{OrgID: &orgID})
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range ts {
+			partials[t.Name] = t.Template
+		}
+	}
+	r.partials[orgID] = partials
+	return partials, nil
+}
+
+// matchStatusRules returns whether the level of a status, and the previous
+// level of its series, satisfy one of the status rules. Without status rules
+// only the changes of level are notified, and the first status of a series if
+// it isn't ok. The count and period of the status rules aren't enforced.
+func matchStatusRules(srs []notification.StatusRule, level, prev notification.CheckLevel, hasPrev bool) bool {
+	if len(srs) == 0 {
+		if !hasPrev {
+			return level != notification.Ok
+		}
+		return level != prev
+	}
+	for _, sr := range srs {
+		if !matchLevelRule(sr.CurrentLevel, level) {
+			continue
+		}
+		if sr.PreviousLevel == nil || (hasPrev && matchLevelRule(*sr.PreviousLevel, prev)) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchLevelRule returns whether a level satisfies a level rule.
+func matchLevelRule(lr notification.LevelRule, level notification.CheckLevel) bool {
+	return (level == lr.CheckLevel) == bool(lr.Operation)
+}
+
+// statusTags returns the tags of a status sorted by key.
+func statusTags(st notification.Status) []notification.Tag {
+	tags := make([]notification.Tag, 0, len(st.Tags))
+	for k, v := range st.Tags {
+		tags = append(tags, notification.Tag{Key: k, Value: v})
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		return tags[i].Key < tags[j].Key
+	})
+	return tags
+}
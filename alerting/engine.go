@@ -0,0 +1,919 @@
+// Package alerting evaluates checks and dispatches their notifications
+// in-process, so Go programs embedding influxdb can alert without the HTTP
+// server or the task scheduler.
+package alerting
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/check"
+	"github.com/influxdata/influxdb/notification/sender"
+	"github.com/influxdata/influxdb/query"
+	"github.com/influxdata/influxdb/snowflake"
+	"github.com/opentracing/opentracing-go"
+	"go.uber.org/zap"
+	cron "gopkg.in/robfig/cron.v2"
+)
+
+// DefaultInterval is how often an open engine looks for the checks which are due.
+const DefaultInterval = 10 * time.Second
+
+// DefaultScrapeTimeout bounds the scrapes of the metrics endpoints of the
+// prometheus queries.
+const DefaultScrapeTimeout = 10 * time.Second
+
+// Store finds the checks, notification rules and notification endpoints of
+// the engine, and the monitoring buckets the statuses are written to. The
+// kv.Service implements it.
+type Store interface {
+	FindCheckByID(ctx context.Context, id influxdb.ID) (influxdb.Check, error)
+	FindChecks(ctx context.Context, filter influxdb.CheckFilter, opt ...influxdb.FindOptions) ([]influxdb.Check, int, error)
+	FindNotificationRules(ctx context.Context, filter influxdb.NotificationRuleFilter, opt ...influxdb.FindOptions) ([]influxdb.NotificationRule, int, error)
+	FindNotificationRuleByID(ctx context.Context, id influxdb.ID) (influxdb.NotificationRule, error)
+	FindNotificationEndpointByID(ctx context.Context, id influxdb.ID) (influxdb.NotificationEndpoint, error)
+	FindBucket(ctx context.Context, filter influxdb.BucketFilter) (*influxdb.Bucket, error)
+	CreateBucket(ctx context.Context, b *influxdb.Bucket) error
+	FindCheckPing(ctx context.Context, checkID influxdb.ID) (time.Time, error)
+	PutCheckPing(ctx context.Context, checkID influxdb.ID, at time.Time) error
+}
+
+// scheduledCheck is a check run every interval or on a cron.
+type scheduledCheck interface {
+	GetEvery() time.Duration
+	GetCron() string
+	GetOffset() time.Duration
+}
+
+// occurrencesCheck is a check changing the level of a series after
+// consecutive evaluations at the level.
+type occurrencesCheck interface {
+	GetOccurrences() int
+}
+
+// alignedCheck is a check whose windows may be aligned to its interval.
+type alignedCheck interface {
+	GetAlignToInterval() bool
+}
+
+// prioritizedCheck is a check evaluated and notified before the checks of a
+// lower priority.
+type prioritizedCheck interface {
+	GetPriority() influxdb.CheckPriority
+}
+
+// checkPriority returns the priority of a check, normal for the checks
+// without a priority.
+func checkPriority(c influxdb.Check) influxdb.CheckPriority {
+	if pc, ok := c.(prioritizedCheck); ok {
+		return pc.GetPriority()
+	}
+	return influxdb.CheckPriorityNormal
+}
+
+// taggedCheck is a check adding its tags to its statuses.
+type taggedCheck interface {
+	GetTags() []notification.Tag
+}
+
+// runbookCheck is a check linking its statuses to its runbook.
+type runbookCheck interface {
+	GetRunbookURL() string
+}
+
+// archivedCheck is a check which may be archived.
+type archivedCheck interface {
+	IsArchived() bool
+}
+
+// Engine evaluates the active checks of a store when they are due, writes
+// their statuses to the monitoring bucket of their organization and sends
+// the notifications of the notification rules matching the statuses.
+//
+// The checks are queried without an authorization, the query service must
+// be allowed to read the buckets of every check.
+//
+// Every time based decision of the engine is taken at the time of its
+// clock, so a test can fast-forward the engine by changing its
+// TimeGenerator between runs.
+type Engine struct {
+	// TimeGenerator is the clock of the engine, the checks are due, their
+	// statuses are written, the limits of the rules are enforced and the
+	// quiet hours of the users are applied at its time.
+	TimeGenerator influxdb.TimeGenerator
+	// Interval is how often an open engine looks for the checks which are due.
+	Interval time.Duration
+	// Evaluates selects the checks the engine runs, every active check when
+	// nil. The checks run by tasks are left to their tasks, whose runs the
+	// engine summarizes.
+	Evaluates func(influxdb.Check) bool
+	// SenderConfig includes the dependencies of the senders of the
+	// notifications, its TimeGenerator defaults to the clock of the engine.
+	SenderConfig sender.Config
+	// Preferences applies the notification preferences of the users to the
+	// notifications, which are all delivered when nil. Its TimeGenerator
+	// defaults to the clock of the engine.
+	Preferences *sender.Preferences
+	// StatusTraceService records why each rule did or didn't notify each
+	// status, the statuses aren't traced when nil. The id of a traced status
+	// is written to the _status_id field of the monitoring bucket.
+	StatusTraceService influxdb.StatusTraceService
+	// IDGenerator generates the ids of the traced statuses.
+	IDGenerator influxdb.IDGenerator
+	// NotificationTemplateService loads the partials of the message templates
+	// of the rules, the partials aren't expanded when nil.
+	NotificationTemplateService influxdb.NotificationTemplateService
+	// NotificationBudgetService enforces the monthly budgets of the endpoints,
+	// the notifications aren't counted when nil. The notifications deferred
+	// by quiet hours are counted when they are deferred.
+	NotificationBudgetService influxdb.NotificationBudgetService
+	// SilenceService and LabelService mute the statuses of the checks
+	// carrying the label of an active silence, the checks aren't silenced
+	// when either is nil.
+	SilenceService influxdb.SilenceService
+	LabelService   influxdb.LabelService
+	// AlertingSettingsService applies the status bucket, the quiet hours and
+	// the dedup window of the organizations, which have none when nil. The
+	// notifications deferred by the quiet hours of an organization are sent
+	// when they end, like the ones of the users.
+	AlertingSettingsService influxdb.AlertingSettingsService
+	// AlertingPauseService suppresses the notifications of the organizations
+	// whose alerting is paused, none are when nil.
+	AlertingPauseService influxdb.AlertingPauseService
+	// LeaseService shares the checks between the engines of the servers of
+	// a cluster: an engine runs the checks it holds the lease of, dispatching
+	// their statuses, and renews their leases every run. The checks of an
+	// engine which stopped are run by another one once their leases expire.
+	// The engine runs every check when nil. The notifications deferred by
+	// quiet hours are sent by the engine which deferred them.
+	LeaseService influxdb.LeaseService
+	// Node identifies the engine among the engines sharing the leases of the
+	// checks, it defaults to a random id.
+	Node string
+	// LeaseTTL is how long an engine keeps the checks it stopped renewing the
+	// leases of, it defaults to three intervals.
+	LeaseTTL time.Duration
+	// CheckStateService persists the state the engine keeps of the checks it
+	// runs: their latest run, the levels of their series and when the rules
+	// with a limit sent the notifications of their statuses. An engine
+	// restores the state of a check when it claims it, to carry on where the
+	// engine which ran it before stopped, and saves it after running the
+	// check. The state is only kept in memory when nil.
+	CheckStateService influxdb.CheckStateService
+	Logger            *zap.Logger
+	// DataSources run the queries of the threshold and deadman checks by
+	// their query type. NewEngine registers the flux and prometheus data
+	// sources; the influxql data source needs the DBRP mappings of the
+	// buckets, see NewInfluxQLDataSource.
+	DataSources map[string]DataSource
+	// Tracer records the spans of the runs, of the checks and of the
+	// deliveries of the notifications, the global tracer when nil.
+	Tracer opentracing.Tracer
+
+	metrics      *engineMetrics
+	store        Store
+	queryService query.QueryService
+	writeService influxdb.WriteService
+
+	mu sync.Mutex
+	// lastRun is the latest scheduled time of each check.
+	lastRun map[influxdb.ID]time.Time
+	// levels is the latest level of each series of statuses.
+	levels map[string]notification.CheckLevel
+	// incidents is when each series which isn't ok left the ok level.
+	incidents map[string]time.Time
+	// pending is the level each series is evaluated at, which isn't its
+	// level yet, and for how many consecutive evaluations.
+	pending map[string]pendingLevel
+	// sent is when each rule with a limit sent its latest notifications.
+	sent map[influxdb.ID][]sentNotification
+	// notified is the level each rule last notified each series at, and when.
+	notified map[string]notifiedLevel
+	// leased are the checks the engine holds the lease of.
+	leased map[influxdb.ID]bool
+	// lags are the latest lag samples of each check.
+	lags map[influxdb.ID]*checkLags
+	// deferred are the notifications waiting for the quiet hours of
+	// their users to end.
+	deferred []deferredNotification
+	cancel   context.CancelFunc
+	done     chan struct{}
+}
+
+// NewEngine returns an engine evaluating the checks of store with
+// queryService and writing their statuses with writeService.
+func NewEngine(store Store, queryService query.QueryService, writeService influxdb.WriteService) *Engine {
+	idGen := snowflake.NewIDGenerator()
+	return &Engine{
+		TimeGenerator: influxdb.RealTimeGenerator{},
+		Interval:      DefaultInterval,
+		IDGenerator:   idGen,
+		Node:          idGen.ID().String(),
+		Logger:        zap.NewNop(),
+		DataSources: map[string]DataSource{
+			check.QueryTypeFlux:       NewFluxDataSource(queryService),
+			check.QueryTypePrometheus: NewPrometheusDataSource(&http.Client{Timeout: DefaultScrapeTimeout}),
+		},
+		metrics:      newEngineMetrics(),
+		store:        store,
+		queryService: queryService,
+		writeService: writeService,
+		lastRun:      make(map[influxdb.ID]time.Time),
+		levels:       make(map[string]notification.CheckLevel),
+		pending:      make(map[string]pendingLevel),
+		incidents:    make(map[string]time.Time),
+		sent:         make(map[influxdb.ID][]sentNotification),
+		notified:     make(map[string]notifiedLevel),
+		leased:       make(map[influxdb.ID]bool),
+		lags:         make(map[influxdb.ID]*checkLags),
+	}
+}
+
+// Open starts running the checks which are due every interval, until Close.
+func (e *Engine) Open(ctx context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.cancel != nil {
+		return &influxdb.Error{
+			Code: influxdb.EConflict,
+			Msg:  "alerting engine is already open",
+		}
+	}
+	interval := e.Interval
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	e.cancel = cancel
+	e.done = make(chan struct{})
+	go func(done chan struct{}) {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := e.Run(ctx); err != nil {
+					e.Logger.Info("failed to run checks", zap.Error(err))
+				}
+			}
+		}
+	}(e.done)
+	return nil
+}
+
+// Close stops the engine, waiting for the checks being run, and releases
+// the leases of its checks for the other engines to run them right away.
+func (e *Engine) Close() error {
+	e.mu.Lock()
+	cancel, done := e.cancel, e.done
+	e.cancel, e.done = nil, nil
+	e.mu.Unlock()
+	if cancel != nil {
+		cancel()
+		<-done
+	}
+	return e.releaseLeases(context.Background())
+}
+
+// Run sends the deferred notifications whose quiet hours ended, evaluates
+// the checks which are due at the time of the engine, and whose lease the
+// engine holds when it shares its checks, writes their statuses
+// and dispatches their notifications. The due checks run in the order of
+// their priority. It runs every check even if some fail, and returns the
+// first error. The engine drops the state it keeps of the checks which are
+// inactive or which it lost the lease of.
+func (e *Engine) Run(ctx context.Context) error {
+	span, ctx := e.startSpan(ctx, "alerting.run")
+	defer span.Finish()
+
+	now := e.TimeGenerator.Now()
+	e.sendDeferred(ctx, now)
+
+	cs, _, err := e.store.FindChecks(ctx, influxdb.CheckFilter{})
+	if err != nil {
+		return err
+	}
+
+	type dueCheck struct {
+		c     influxdb.Check
+		at    time.Time
+		dueAt time.Time
+	}
+	var (
+		dues    []dueCheck
+		ran     []influxdb.ID
+		stopped []influxdb.ID
+	)
+	for _, c := range cs {
+		if c.GetStatus() != influxdb.Active {
+			stopped = append(stopped, c.GetID())
+			continue
+		}
+		if e.Evaluates != nil && !e.Evaluates(c) {
+			continue
+		}
+		if !e.claim(ctx, c.GetID()) {
+			stopped = append(stopped, c.GetID())
+			continue
+		}
+		e.restore(ctx, c)
+		scheduled, dueAt, ok := e.due(c, now)
+		if !ok {
+			continue
+		}
+		ran = append(ran, c.GetID())
+		at := now
+		if ac, ok := c.(alignedCheck); ok && ac.GetAlignToInterval() {
+			at = scheduled
+		}
+		dues = append(dues, dueCheck{c: c, at: at, dueAt: dueAt})
+	}
+	sort.SliceStable(dues, func(i, j int) bool {
+		return checkPriority(dues[i].c).Rank() < checkPriority(dues[j].c).Rank()
+	})
+
+	r := e.newRun(now)
+	var firstErr error
+	for _, d := range dues {
+		if !d.dueAt.IsZero() {
+			e.recordLag(d.c, d.dueAt, e.TimeGenerator.Now())
+		}
+		if err := r.runCheck(ctx, d.c, d.at); err != nil {
+			e.Logger.Info("failed to run check", zap.String("checkID", d.c.GetID().String()), zap.Error(err))
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	e.forget(stopped)
+	e.saveStates(ctx, ran)
+	return firstErr
+}
+
+// InjectStatus writes a synthetic status of a check at the time of the
+// engine and dispatches it like the statuses of the check, and returns its
+// decision trace. Synthetic statuses don't change the level of their series
+// and don't count toward the limits of the rules, they count toward the
+// budgets of the endpoints they are sent to. The trace is recorded when
+// the engine has a status trace service.
+func (e *Engine) InjectStatus(ctx context.Context, i *influxdb.StatusInjection) (*influxdb.StatusTrace, error) {
+	if err := i.Valid(); err != nil {
+		return nil, err
+	}
+	c, err := e.store.FindCheckByID(ctx, i.CheckID)
+	if err != nil {
+		return nil, err
+	}
+
+	r := e.newRun(e.TimeGenerator.Now())
+	st := r.newStatus(c, notification.ParseCheckLevel(i.Level), i.Value, i.Tags)
+	st.ID = e.IDGenerator.ID()
+	st.Synthetic = true
+	if st.Message = i.Message; st.Message == "" {
+		expandMessage(c, &st)
+	}
+
+	sts := []notification.Status{st}
+	if err := r.writeStatuses(ctx, c.GetOrgID(), sts); err != nil {
+		return nil, err
+	}
+	traces, err := r.dispatch(ctx, c.GetOrgID(), sts)
+	if err != nil {
+		return nil, err
+	}
+	return traces[0], nil
+}
+
+// WriteExternalStatus writes a status of an external check at the time of
+// the engine, as reported by its external system, and dispatches it like
+// the statuses evaluated from the other checks: it changes the level of its
+// series, once reported for the occurrences of the check, and counts toward
+// the limits of the rules. It returns the decision trace of the status,
+// which is recorded when the engine has a status trace service. A status of
+// a new series held back by the occurrences of the check isn't written, its
+// trace has no rules and isn't recorded.
+//
+// The levels of the series are kept by the engine receiving the statuses, an
+// external system should report the statuses of a check to a single server.
+func (e *Engine) WriteExternalStatus(ctx context.Context, checkID influxdb.ID, s *influxdb.ExternalStatus) (*influxdb.StatusTrace, error) {
+	if err := s.Valid(); err != nil {
+		return nil, err
+	}
+	c, err := e.store.FindCheckByID(ctx, checkID)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := c.(*check.External); !ok {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("check %s is a %s check, only external checks accept external statuses", c.GetID(), c.Type()),
+		}
+	}
+	if ac, ok := c.(archivedCheck); c.GetStatus() != influxdb.Active || (ok && ac.IsArchived()) {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("external check %s isn't active", c.GetID()),
+		}
+	}
+
+	r := e.newRun(e.TimeGenerator.Now())
+	st := r.newStatus(c, notification.ParseCheckLevel(s.Level), s.Value, s.Tags)
+	st.ID = e.IDGenerator.ID()
+	if oc, ok := c.(occurrencesCheck); ok && oc.GetOccurrences() > 1 && !e.confirmLevel(&st, oc.GetOccurrences()) {
+		return &influxdb.StatusTrace{
+			StatusID: st.ID,
+			CheckID:  st.CheckID,
+			OrgID:    st.OrgID,
+			Level:    st.Level.String(),
+			Time:     st.Time,
+			Rules:    []influxdb.RuleTrace{},
+		}, nil
+	}
+	if st.Message = s.Message; st.Message == "" {
+		expandMessage(c, &st)
+	}
+
+	sts := []notification.Status{st}
+	if err := r.writeStatuses(ctx, c.GetOrgID(), sts); err != nil {
+		return nil, err
+	}
+	traces, err := r.dispatch(ctx, c.GetOrgID(), sts)
+	if err != nil {
+		return nil, err
+	}
+	return traces[0], nil
+}
+
+// PingCheck records a ping of a heartbeat check at the time of the engine.
+// The pings are kept in the store, a job can ping its check on any server
+// sharing the store.
+func (e *Engine) PingCheck(ctx context.Context, checkID influxdb.ID) error {
+	c, err := e.store.FindCheckByID(ctx, checkID)
+	if err != nil {
+		return err
+	}
+	if _, ok := c.(*check.Heartbeat); !ok {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("check %s is a %s check, only heartbeat checks accept pings", c.GetID(), c.Type()),
+		}
+	}
+	return e.store.PutCheckPing(ctx, checkID, e.TimeGenerator.Now())
+}
+
+// lastPing returns when a heartbeat check was last pinged, or first
+// evaluated, recording now as its first evaluation if it wasn't pinged yet.
+func (e *Engine) lastPing(ctx context.Context, checkID influxdb.ID, now time.Time) (time.Time, error) {
+	last, err := e.store.FindCheckPing(ctx, checkID)
+	if influxdb.ErrorCode(err) == influxdb.ENotFound {
+		return now, e.store.PutCheckPing(ctx, checkID, now)
+	}
+	return last, err
+}
+
+// newRun returns a run of the engine at now.
+func (e *Engine) newRun(now time.Time) *run {
+	return &run{
+		engine:      e,
+		now:         now,
+		buckets:     make(map[influxdb.ID]influxdb.ID),
+		rules:       make(map[influxdb.ID][]influxdb.NotificationRule),
+		partials:    make(map[influxdb.ID]map[string]string),
+		silences:    make(map[influxdb.ID][]*influxdb.Silence),
+		settings:    make(map[influxdb.ID]*influxdb.AlertingSettings),
+		pauses:      make(map[influxdb.ID]*influxdb.AlertingPause),
+		checkLabels: make(map[influxdb.ID]map[influxdb.ID]bool),
+	}
+}
+
+// leaseKey is the key of the lease of a check.
+func leaseKey(checkID influxdb.ID) string {
+	return "alerting/checks/" + checkID.String()
+}
+
+// claim acquires or renews the lease of a check, and returns whether the
+// engine holds it. The checks whose lease fails to be acquired are skipped,
+// rather than risking to notify their statuses twice.
+func (e *Engine) claim(ctx context.Context, checkID influxdb.ID) bool {
+	if e.LeaseService == nil {
+		return true
+	}
+	ttl := e.LeaseTTL
+	if ttl <= 0 {
+		interval := e.Interval
+		if interval <= 0 {
+			interval = DefaultInterval
+		}
+		ttl = 3 * interval
+	}
+
+	l, err := e.LeaseService.AcquireLease(ctx, leaseKey(checkID), e.Node, ttl)
+	if err != nil {
+		e.Logger.Info("failed to acquire the lease of check", zap.String("checkID", checkID.String()), zap.Error(err))
+	}
+	held := err == nil && l.Owner == e.Node
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if held {
+		e.leased[checkID] = true
+	} else {
+		delete(e.leased, checkID)
+	}
+	return held
+}
+
+// releaseLeases releases the leases of the checks of the engine.
+func (e *Engine) releaseLeases(ctx context.Context) error {
+	if e.LeaseService == nil {
+		return nil
+	}
+	e.mu.Lock()
+	ids := make([]influxdb.ID, 0, len(e.leased))
+	for id := range e.leased {
+		ids = append(ids, id)
+	}
+	e.leased = make(map[influxdb.ID]bool)
+	e.mu.Unlock()
+
+	var firstErr error
+	for _, id := range ids {
+		if err := e.LeaseService.ReleaseLease(ctx, leaseKey(id), e.Node); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// restore loads the persisted state of a check the engine doesn't keep the
+// state of, as when it claims a check another engine ran.
+func (e *Engine) restore(ctx context.Context, c influxdb.Check) {
+	if e.CheckStateService == nil {
+		return
+	}
+	checkID := c.GetID()
+	e.mu.Lock()
+	_, kept := e.lastRun[checkID]
+	e.mu.Unlock()
+	if kept {
+		return
+	}
+
+	st, err := e.CheckStateService.FindCheckState(ctx, checkID)
+	if influxdb.ErrorCode(err) == influxdb.ENotFound {
+		return
+	}
+	if err != nil {
+		e.Logger.Info("failed to find the state of check", zap.String("checkID", checkID.String()), zap.Error(err))
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.lastRun[checkID] = st.LastRun
+	if len(st.Lags) > 0 {
+		e.lags[checkID] = &checkLags{orgID: c.GetOrgID(), samples: st.Lags}
+	}
+	for key, ss := range st.Series {
+		if ss.Level != "" {
+			e.levels[key] = notification.ParseCheckLevel(ss.Level)
+		}
+		if ss.Pending != "" {
+			e.pending[key] = pendingLevel{level: notification.ParseCheckLevel(ss.Pending), count: ss.PendingCount}
+		}
+		if ss.IncidentStart != nil {
+			e.incidents[key] = *ss.IncidentStart
+		}
+		for ruleID, n := range ss.Notified {
+			e.notified[ruleID.String()+"/"+key] = notifiedLevel{level: notification.ParseCheckLevel(n.Level), at: n.At}
+		}
+	}
+	for ruleID, ts := range st.Sent {
+		for _, t := range ts {
+			e.sent[ruleID] = append(e.sent[ruleID], sentNotification{checkID: checkID, at: t})
+		}
+	}
+}
+
+// forget drops the state the engine keeps of checks, as when they are
+// inactive or when another engine holds their leases.
+func (e *Engine) forget(checkIDs []influxdb.ID) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	drop := make(map[influxdb.ID]bool, len(checkIDs))
+	for _, id := range checkIDs {
+		if _, ok := e.lastRun[id]; ok {
+			drop[id] = true
+			delete(e.lastRun, id)
+			delete(e.lags, id)
+		}
+	}
+	if len(drop) == 0 {
+		return
+	}
+	for key := range e.levels {
+		if id, ok := seriesCheckID(key); ok && drop[id] {
+			delete(e.levels, key)
+		}
+	}
+	for key := range e.pending {
+		if id, ok := seriesCheckID(key); ok && drop[id] {
+			delete(e.pending, key)
+		}
+	}
+	for key := range e.incidents {
+		if id, ok := seriesCheckID(key); ok && drop[id] {
+			delete(e.incidents, key)
+		}
+	}
+	for key := range e.notified {
+		if _, series, ok := notifiedSeries(key); ok {
+			if id, ok := seriesCheckID(series); ok && drop[id] {
+				delete(e.notified, key)
+			}
+		}
+	}
+	for ruleID, ns := range e.sent {
+		kept := ns[:0]
+		for _, n := range ns {
+			if !drop[n.checkID] {
+				kept = append(kept, n)
+			}
+		}
+		if len(kept) == 0 {
+			delete(e.sent, ruleID)
+			continue
+		}
+		e.sent[ruleID] = kept
+	}
+}
+
+// saveStates persists the state the engine keeps of checks.
+func (e *Engine) saveStates(ctx context.Context, checkIDs []influxdb.ID) {
+	if e.CheckStateService == nil || len(checkIDs) == 0 {
+		return
+	}
+	e.mu.Lock()
+	states := make(map[influxdb.ID]*influxdb.CheckState, len(checkIDs))
+	for _, id := range checkIDs {
+		states[id] = &influxdb.CheckState{CheckID: id, LastRun: e.lastRun[id]}
+		if l, ok := e.lags[id]; ok {
+			states[id].Lags = append([]influxdb.CheckLagSample(nil), l.samples...)
+		}
+	}
+	series := func(key string, set func(*influxdb.SeriesState)) {
+		id, ok := seriesCheckID(key)
+		if !ok || states[id] == nil {
+			return
+		}
+		st := states[id]
+		if st.Series == nil {
+			st.Series = make(map[string]influxdb.SeriesState)
+		}
+		ss := st.Series[key]
+		set(&ss)
+		st.Series[key] = ss
+	}
+	for key, level := range e.levels {
+		series(key, func(ss *influxdb.SeriesState) { ss.Level = level.String() })
+	}
+	for key, p := range e.pending {
+		series(key, func(ss *influxdb.SeriesState) { ss.Pending, ss.PendingCount = p.level.String(), p.count })
+	}
+	for key, start := range e.incidents {
+		start := start
+		series(key, func(ss *influxdb.SeriesState) { ss.IncidentStart = &start })
+	}
+	for key, n := range e.notified {
+		ruleID, skey, ok := notifiedSeries(key)
+		if !ok {
+			continue
+		}
+		series(skey, func(ss *influxdb.SeriesState) {
+			if ss.Notified == nil {
+				ss.Notified = make(map[influxdb.ID]influxdb.NotifiedLevel)
+			}
+			ss.Notified[ruleID] = influxdb.NotifiedLevel{Level: n.level.String(), At: n.at}
+		})
+	}
+	for ruleID, ns := range e.sent {
+		for _, n := range ns {
+			st := states[n.checkID]
+			if st == nil {
+				continue
+			}
+			if st.Sent == nil {
+				st.Sent = make(map[influxdb.ID][]time.Time)
+			}
+			st.Sent[ruleID] = append(st.Sent[ruleID], n.at)
+		}
+	}
+	e.mu.Unlock()
+
+	for _, id := range checkIDs {
+		if err := e.CheckStateService.PutCheckState(ctx, states[id]); err != nil {
+			e.Logger.Info("failed to save the state of check", zap.String("checkID", id.String()), zap.Error(err))
+		}
+	}
+}
+
+// due returns whether a check is due at now, its scheduled time and the time
+// it was due at, and records the scheduled time if it is. A check is due the
+// first time the engine sees it, without a time it was due at. The checks
+// aligned to their interval are scheduled at the boundaries of the interval
+// since the Unix epoch.
+func (e *Engine) due(c influxdb.Check, now time.Time) (time.Time, time.Time, bool) {
+	sc, ok := c.(scheduledCheck)
+	if !ok {
+		return time.Time{}, time.Time{}, false
+	}
+	scheduled := now.Add(-sc.GetOffset())
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	last, seen := e.lastRun[c.GetID()]
+	var dueAt time.Time
+	switch {
+	case sc.GetCron() != "":
+		schedule, err := cron.Parse(sc.GetCron())
+		if err != nil {
+			e.Logger.Info("failed to parse the cron of check", zap.String("checkID", c.GetID().String()), zap.Error(err))
+			return time.Time{}, time.Time{}, false
+		}
+		if seen {
+			next := schedule.Next(last)
+			if next.After(scheduled) {
+				return time.Time{}, time.Time{}, false
+			}
+			dueAt = next.Add(sc.GetOffset())
+		}
+	case sc.GetEvery() > 0:
+		if ac, ok := c.(alignedCheck); ok && ac.GetAlignToInterval() {
+			scheduled = check.AlignTime(scheduled, sc.GetEvery())
+		} else {
+			scheduled = scheduled.Truncate(sc.GetEvery())
+		}
+		if seen && !scheduled.After(last) {
+			return time.Time{}, time.Time{}, false
+		}
+		if seen {
+			dueAt = scheduled.Add(sc.GetOffset())
+		}
+	default:
+		return time.Time{}, time.Time{}, false
+	}
+	e.lastRun[c.GetID()] = scheduled
+	return scheduled, dueAt, true
+}
+
+// level returns the latest level of the series of a status, and whether the
+// series has one.
+func (e *Engine) level(st notification.Status) (notification.CheckLevel, bool) {
+	key := seriesKey(st)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	prev, ok := e.levels[key]
+	return prev, ok
+}
+
+// pendingLevel is a level a series was evaluated at for count consecutive
+// evaluations.
+type pendingLevel struct {
+	level notification.CheckLevel
+	count int
+}
+
+// confirmLevel keeps a status at the level of its series until the series
+// is evaluated at the level of the status for occurrences consecutive
+// evaluations. It returns false for the status of a series without a level
+// yet, which has none to keep.
+func (e *Engine) confirmLevel(st *notification.Status, occurrences int) bool {
+	key := seriesKey(*st)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	current, ok := e.levels[key]
+	if ok && current == st.Level {
+		delete(e.pending, key)
+		return true
+	}
+	p := e.pending[key]
+	if p.level != st.Level {
+		p = pendingLevel{level: st.Level}
+	}
+	p.count++
+	if p.count >= occurrences {
+		delete(e.pending, key)
+		return true
+	}
+	e.pending[key] = p
+	if !ok {
+		return false
+	}
+	st.Level = current
+	return true
+}
+
+// swapLevel records the level of a series of statuses, unless the status is
+// synthetic, and returns the previous level and whether the series had one.
+// It records when the incidents of the series start, and sets the duration
+// of the incident on the statuses recovering to ok.
+func (e *Engine) swapLevel(st *notification.Status) (notification.CheckLevel, bool) {
+	key := seriesKey(*st)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	prev, ok := e.levels[key]
+	if st.Synthetic {
+		return prev, ok
+	}
+	e.levels[key] = st.Level
+	start, inIncident := e.incidents[key]
+	switch {
+	case st.Level != notification.Ok && !inIncident:
+		e.incidents[key] = st.Time
+	case st.Level == notification.Ok && inIncident:
+		st.IncidentDuration = st.Time.Sub(start)
+		delete(e.incidents, key)
+	}
+	return prev, ok
+}
+
+// sentNotification is when a rule sent a notification of a status of a
+// check.
+type sentNotification struct {
+	checkID influxdb.ID
+	at      time.Time
+}
+
+// allow returns whether a rule can send a notification of a status of a
+// check at now without exceeding its limit, and records the notification if
+// it can.
+func (e *Engine) allow(nr influxdb.NotificationRule, checkID influxdb.ID, now time.Time) bool {
+	limit := nr.GetLimit()
+	if limit == nil || limit.Rate <= 0 || limit.Every <= 0 {
+		return true
+	}
+	since := now.Add(-time.Duration(limit.Every) * time.Second)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	sent := e.sent[nr.GetID()][:0]
+	for _, n := range e.sent[nr.GetID()] {
+		if n.at.After(since) {
+			sent = append(sent, n)
+		}
+	}
+	if len(sent) >= limit.Rate {
+		e.sent[nr.GetID()] = sent
+		return false
+	}
+	e.sent[nr.GetID()] = append(sent, sentNotification{checkID: checkID, at: now})
+	return true
+}
+
+// notifiedLevel is the level a rule last notified a series at.
+type notifiedLevel struct {
+	level notification.CheckLevel
+	at    time.Time
+}
+
+// duplicate returns whether a rule notified the series of a status at its
+// level within the window before now, recording the notification otherwise.
+func (e *Engine) duplicate(nr influxdb.NotificationRule, st notification.Status, window time.Duration, now time.Time) bool {
+	if window <= 0 {
+		return false
+	}
+	key := nr.GetID().String() + "/" + seriesKey(st)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if n, ok := e.notified[key]; ok && n.level == st.Level && now.Sub(n.at) < window {
+		return true
+	}
+	e.notified[key] = notifiedLevel{level: st.Level, at: now}
+	return false
+}
+
+// notifiedSeries returns the id of the rule and the key of the series
+// the key of a notified level is made of.
+func notifiedSeries(key string) (influxdb.ID, string, bool) {
+	i := strings.IndexByte(key, '/')
+	if i < 0 {
+		return 0, "", false
+	}
+	var ruleID influxdb.ID
+	if err := ruleID.DecodeFromString(key[:i]); err != nil {
+		return 0, "", false
+	}
+	return ruleID, key[i+1:], true
+}
@@ -0,0 +1,3292 @@
+package alerting_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/lang"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/alerting"
+	"github.com/influxdata/influxdb/inmem"
+	"github.com/influxdata/influxdb/kv"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/check"
+	"github.com/influxdata/influxdb/notification/endpoint"
+	"github.com/influxdata/influxdb/notification/rule"
+	"github.com/influxdata/influxdb/notification/sender"
+	"github.com/influxdata/influxdb/query"
+	_ "github.com/influxdata/influxdb/query/builtin"
+	qmock "github.com/influxdata/influxdb/query/mock"
+	"github.com/influxdata/influxdb/task/backend"
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// newTestService returns an in-memory kv service with a user and an organization.
+func newTestService(t *testing.T) (*kv.Service, *influxdb.User, *influxdb.Organization) {
+	t.Helper()
+	ctx := context.Background()
+	svc := kv.NewService(inmem.NewKVStore())
+	if err := svc.Initialize(ctx); err != nil {
+		t.Fatalf("error initializing kv service: %v", err)
+	}
+	user := &influxdb.User{Name: "theuser"}
+	if err := svc.CreateUser(ctx, user); err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	org := &influxdb.Organization{Name: "theorg"}
+	if err := svc.CreateOrganization(ctx, org); err != nil {
+		t.Fatalf("failed to create org: %v", err)
+	}
+	// the tasks of the checks read the telegraf bucket.
+	if err := svc.CreateBucket(ctx, &influxdb.Bucket{OrgID: org.ID, Name: "telegraf"}); err != nil {
+		t.Fatalf("failed to create bucket: %v", err)
+	}
+	return svc, user, org
+}
+
+// slackServer is a slack webhook recording the text of its messages.
+type slackServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	messages []string
+}
+
+func newSlackServer(t *testing.T) *slackServer {
+	s := &slackServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var msg struct {
+			Text string `json:"text"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+			t.Errorf("failed to decode slack message: %v", err)
+		}
+		s.mu.Lock()
+		s.messages = append(s.messages, msg.Text)
+		s.mu.Unlock()
+	}))
+	return s
+}
+
+// Messages returns the text of the messages received so far.
+func (s *slackServer) Messages() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.messages...)
+}
+
+func TestEngine_Run(t *testing.T) {
+	ctx := context.Background()
+	svc, user, org := newTestService(t)
+
+	slack := newSlackServer(t)
+	defer slack.Close()
+
+	edp := &endpoint.Slack{
+		Base: endpoint.Base{Name: "slack", OrgID: org.ID, Status: influxdb.Active},
+		URL:  slack.URL,
+	}
+	if err := svc.CreateNotificationEndpoint(ctx, edp, user.ID); err != nil {
+		t.Fatalf("failed to create notification endpoint: %v", err)
+	}
+	nr := &rule.Slack{
+		Base: rule.Base{
+			Name:            "cpu to slack",
+			OrgID:           org.ID,
+			EndpointID:      &edp.ID,
+			AuthorizationID: edp.ID,
+			Status:          influxdb.Active,
+			TagRules: []notification.TagRule{
+				{Tag: notification.Tag{Key: "env", Value: "prod"}, Operator: notification.Equal},
+			},
+		},
+		MessageTemplate: "${r._check_name} on ${r.host} is ${r._level}",
+	}
+	if err := svc.CreateNotificationRule(ctx, nr, user.ID); err != nil {
+		t.Fatalf("failed to create notification rule: %v", err)
+	}
+	c := &check.Threshold{
+		Base: check.Base{
+			Name:   "cpu",
+			OrgID:  org.ID,
+			Status: influxdb.Active,
+			Every:  influxdb.Duration{Duration: time.Minute},
+			Query: influxdb.DashboardQuery{
+				Text: `from(bucket: "telegraf") |> range(start: -1m) |> filter(fn: (r) => r._measurement == "cpu")`,
+			},
+			Tags:                  []notification.Tag{{Key: "env", Value: "prod"}},
+			StatusMessageTemplate: "${r.host} cpu is ${r._value}",
+		},
+		Thresholds: []check.ThresholdConfig{
+			&check.Greater{ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Warn}, Value: 80},
+			&check.Greater{ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Critical}, Value: 90},
+		},
+	}
+	if err := svc.CreateCheck(ctx, c, user.ID); err != nil {
+		t.Fatalf("failed to create check: %v", err)
+	}
+
+	var (
+		value   float64
+		queries []*query.Request
+		written []string
+	)
+	queryService := &qmock.QueryService{
+		QueryF: func(ctx context.Context, req *query.Request) (flux.ResultIterator, error) {
+			queries = append(queries, req)
+			return flux.NewSliceResultIterator([]flux.Result{
+				executetest.NewResult([]*executetest.Table{{
+					KeyCols: []string{"_measurement", "host"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+						{Label: "_measurement", Type: flux.TString},
+						{Label: "host", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC).UnixNano()), 10.0, "cpu", "a"},
+						{execute.Time(time.Date(2019, 10, 1, 0, 0, 10, 0, time.UTC).UnixNano()), value, "cpu", "a"},
+					},
+				}}),
+			}), nil
+		},
+	}
+	writeService := &mock.WriteService{
+		WriteF: func(ctx context.Context, orgID, bucketID influxdb.ID, r io.Reader) error {
+			var buf bytes.Buffer
+			if _, err := buf.ReadFrom(r); err != nil {
+				return err
+			}
+			written = append(written, strings.TrimSpace(buf.String()))
+			return nil
+		},
+	}
+
+	e := alerting.NewEngine(svc, queryService, writeService)
+	now := time.Date(2019, 10, 1, 0, 0, 30, 0, time.UTC)
+	e.TimeGenerator = mock.TimeGenerator{FakeValue: now}
+
+	steps := []struct {
+		name     string
+		at       time.Duration
+		value    float64
+		written  string
+		messages []string
+	}{
+		{
+			name:     "the first status of a series is notified",
+			value:    95,
+			written:  `statuses,_check_id=` + c.ID.String() + `,_check_name=cpu,_level=crit,env=prod,host=a _message="a cpu is 95",_value=95 1569888030000000000`,
+			messages: []string{"cpu on a is CRIT"},
+		},
+		{
+			name:  "the check isn't run before it's due",
+			at:    20 * time.Second,
+			value: 85,
+		},
+		{
+			name:     "a change of level is notified",
+			at:       time.Minute,
+			value:    85,
+			written:  `statuses,_check_id=` + c.ID.String() + `,_check_name=cpu,_level=warn,env=prod,host=a _message="a cpu is 85",_value=85 1569888090000000000`,
+			messages: []string{"cpu on a is CRIT", "cpu on a is WARN"},
+		},
+		{
+			name:     "the same level isn't notified again",
+			at:       2 * time.Minute,
+			value:    86,
+			written:  `statuses,_check_id=` + c.ID.String() + `,_check_name=cpu,_level=warn,env=prod,host=a _message="a cpu is 86",_value=86 1569888150000000000`,
+			messages: []string{"cpu on a is CRIT", "cpu on a is WARN"},
+		},
+	}
+	for _, step := range steps {
+		queries, written = nil, nil
+		value = step.value
+		e.TimeGenerator = mock.TimeGenerator{FakeValue: now.Add(step.at)}
+		if err := e.Run(ctx); err != nil {
+			t.Fatalf("%s: failed to run engine: %v", step.name, err)
+		}
+
+		if step.written == "" {
+			if len(queries) != 0 || len(written) != 0 {
+				t.Errorf("%s: expected the check not to run, got %d queries and %d writes", step.name, len(queries), len(written))
+			}
+			continue
+		}
+		if len(queries) != 1 {
+			t.Fatalf("%s: expected the check to be queried once, got %d", step.name, len(queries))
+		}
+		compiler := queries[0].Compiler.(lang.FluxCompiler)
+		if compiler.Query != c.Query.Text || !compiler.Now.Equal(now.Add(step.at)) || queries[0].OrganizationID != org.ID {
+			t.Errorf("%s: unexpected query %+v", step.name, queries[0])
+		}
+		if len(written) != 1 || written[0] != step.written {
+			t.Errorf("%s: unexpected statuses written\ngot  %v\nwant %s", step.name, written, step.written)
+		}
+		got := slack.Messages()
+		if strings.Join(got, "\n") != strings.Join(step.messages, "\n") {
+			t.Errorf("%s: unexpected notifications, got %q, want %q", step.name, got, step.messages)
+		}
+	}
+
+	if _, err := svc.FindBucketByName(ctx, org.ID, influxdb.MonitoringBucketName); err != nil {
+		t.Errorf("expected the engine to create the monitoring bucket: %v", err)
+	}
+}
+
+func TestEngine_RunAligned(t *testing.T) {
+	ctx := context.Background()
+	svc, user, org := newTestService(t)
+
+	c := &check.Threshold{
+		Base: check.Base{
+			Name:            "cpu",
+			OrgID:           org.ID,
+			Status:          influxdb.Active,
+			Every:           influxdb.Duration{Duration: time.Minute},
+			Offset:          influxdb.Duration{Duration: 10 * time.Second},
+			AlignToInterval: true,
+			Query: influxdb.DashboardQuery{
+				Text: `from(bucket: "telegraf") |> range(start: -1m) |> filter(fn: (r) => r._measurement == "cpu")`,
+			},
+		},
+		Thresholds: []check.ThresholdConfig{
+			&check.Greater{ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Critical}, Value: 90},
+		},
+	}
+	if err := svc.CreateCheck(ctx, c, user.ID); err != nil {
+		t.Fatalf("failed to create check: %v", err)
+	}
+
+	var (
+		queries []*query.Request
+		written []string
+	)
+	queryService := &qmock.QueryService{
+		QueryF: func(ctx context.Context, req *query.Request) (flux.ResultIterator, error) {
+			queries = append(queries, req)
+			return flux.NewSliceResultIterator([]flux.Result{
+				executetest.NewResult([]*executetest.Table{{
+					KeyCols: []string{"_measurement"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_value", Type: flux.TFloat},
+						{Label: "_measurement", Type: flux.TString},
+					},
+					Data: [][]interface{}{{95.0, "cpu"}},
+				}}),
+			}), nil
+		},
+	}
+	writeService := &mock.WriteService{
+		WriteF: func(ctx context.Context, orgID, bucketID influxdb.ID, r io.Reader) error {
+			var buf bytes.Buffer
+			if _, err := buf.ReadFrom(r); err != nil {
+				return err
+			}
+			written = append(written, strings.TrimSpace(buf.String()))
+			return nil
+		},
+	}
+	e := alerting.NewEngine(svc, queryService, writeService)
+
+	steps := []struct {
+		name string
+		now  time.Time
+		want time.Time
+	}{
+		{
+			name: "the window ends at the last minute mark before the offset",
+			now:  time.Date(2019, 10, 1, 0, 1, 17, 123456789, time.UTC),
+			want: time.Date(2019, 10, 1, 0, 1, 0, 0, time.UTC),
+		},
+		{
+			name: "the check isn't run again in the same interval",
+			now:  time.Date(2019, 10, 1, 0, 2, 5, 0, time.UTC),
+		},
+		{
+			name: "the next window ends at the next minute mark",
+			now:  time.Date(2019, 10, 1, 0, 2, 10, 1, time.UTC),
+			want: time.Date(2019, 10, 1, 0, 2, 0, 0, time.UTC),
+		},
+	}
+	for _, step := range steps {
+		queries, written = nil, nil
+		e.TimeGenerator = mock.TimeGenerator{FakeValue: step.now}
+		if err := e.Run(ctx); err != nil {
+			t.Fatalf("%s: failed to run engine: %v", step.name, err)
+		}
+		if step.want.IsZero() {
+			if len(queries) != 0 {
+				t.Errorf("%s: expected the check not to run, got %d queries", step.name, len(queries))
+			}
+			continue
+		}
+		if len(queries) != 1 {
+			t.Fatalf("%s: expected the check to be queried once, got %d", step.name, len(queries))
+		}
+		if got := queries[0].Compiler.(lang.FluxCompiler).Now; !got.Equal(step.want) {
+			t.Errorf("%s: expected the check to be queried at %s, got %s", step.name, step.want, got)
+		}
+		want := `statuses,_check_id=` + c.ID.String() + `,_check_name=cpu,_level=crit _message="",_value=95 ` + strconv.FormatInt(step.want.UnixNano(), 10)
+		if len(written) != 1 || written[0] != want {
+			t.Errorf("%s: unexpected statuses written\ngot  %v\nwant %s", step.name, written, want)
+		}
+	}
+}
+
+func TestEngine_RunHysteresis(t *testing.T) {
+	ctx := context.Background()
+	svc, user, org := newTestService(t)
+
+	recoverValue := 85.0
+	c := &check.Threshold{
+		Base: check.Base{
+			Name:   "cpu",
+			OrgID:  org.ID,
+			Status: influxdb.Active,
+			Every:  influxdb.Duration{Duration: time.Minute},
+			Query: influxdb.DashboardQuery{
+				Text: `from(bucket: "telegraf") |> range(start: -1m) |> filter(fn: (r) => r._measurement == "cpu")`,
+			},
+		},
+		Thresholds: []check.ThresholdConfig{
+			&check.Greater{ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Critical}, Value: 90, Recover: &recoverValue},
+		},
+	}
+	if err := svc.CreateCheck(ctx, c, user.ID); err != nil {
+		t.Fatalf("failed to create check: %v", err)
+	}
+
+	var (
+		value   float64
+		written []string
+	)
+	queryService := &qmock.QueryService{
+		QueryF: func(ctx context.Context, req *query.Request) (flux.ResultIterator, error) {
+			return flux.NewSliceResultIterator([]flux.Result{
+				executetest.NewResult([]*executetest.Table{{
+					KeyCols: []string{"_measurement"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_value", Type: flux.TFloat},
+						{Label: "_measurement", Type: flux.TString},
+					},
+					Data: [][]interface{}{{value, "cpu"}},
+				}}),
+			}), nil
+		},
+	}
+	writeService := &mock.WriteService{
+		WriteF: func(ctx context.Context, orgID, bucketID influxdb.ID, r io.Reader) error {
+			var buf bytes.Buffer
+			if _, err := buf.ReadFrom(r); err != nil {
+				return err
+			}
+			written = append(written, strings.TrimSpace(buf.String()))
+			return nil
+		},
+	}
+	e := alerting.NewEngine(svc, queryService, writeService)
+	now := time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	steps := []struct {
+		name  string
+		value float64
+		level string
+	}{
+		{name: "below the trigger", value: 88, level: "ok"},
+		{name: "above the trigger", value: 95, level: "crit"},
+		{name: "between the recover and the trigger", value: 87, level: "crit"},
+		{name: "below the recover", value: 84, level: "ok"},
+		{name: "between the recover and the trigger after recovering", value: 87, level: "ok"},
+	}
+	for i, step := range steps {
+		written = nil
+		value = step.value
+		e.TimeGenerator = mock.TimeGenerator{FakeValue: now.Add(time.Duration(i) * time.Minute)}
+		if err := e.Run(ctx); err != nil {
+			t.Fatalf("%s: failed to run engine: %v", step.name, err)
+		}
+		if len(written) != 1 || !strings.Contains(written[0], ",_level="+step.level+" ") {
+			t.Errorf("%s: expected a status at level %s, got %v", step.name, step.level, written)
+		}
+	}
+}
+
+func TestEngine_RunOccurrences(t *testing.T) {
+	ctx := context.Background()
+	svc, user, org := newTestService(t)
+
+	c := &check.Threshold{
+		Base: check.Base{
+			Name:        "cpu",
+			OrgID:       org.ID,
+			Status:      influxdb.Active,
+			Every:       influxdb.Duration{Duration: time.Minute},
+			Occurrences: 3,
+			Query: influxdb.DashboardQuery{
+				Text: `from(bucket: "telegraf") |> range(start: -1m) |> filter(fn: (r) => r._measurement == "cpu")`,
+			},
+			StatusMessageTemplate: "cpu is ${r._level}",
+		},
+		Thresholds: []check.ThresholdConfig{
+			&check.Greater{ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Critical}, Value: 90},
+		},
+	}
+	if err := svc.CreateCheck(ctx, c, user.ID); err != nil {
+		t.Fatalf("failed to create check: %v", err)
+	}
+
+	var (
+		value   float64
+		written []string
+	)
+	queryService := &qmock.QueryService{
+		QueryF: func(ctx context.Context, req *query.Request) (flux.ResultIterator, error) {
+			return flux.NewSliceResultIterator([]flux.Result{
+				executetest.NewResult([]*executetest.Table{{
+					KeyCols: []string{"_measurement"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_value", Type: flux.TFloat},
+						{Label: "_measurement", Type: flux.TString},
+					},
+					Data: [][]interface{}{{value, "cpu"}},
+				}}),
+			}), nil
+		},
+	}
+	writeService := &mock.WriteService{
+		WriteF: func(ctx context.Context, orgID, bucketID influxdb.ID, r io.Reader) error {
+			var buf bytes.Buffer
+			if _, err := buf.ReadFrom(r); err != nil {
+				return err
+			}
+			written = append(written, strings.TrimSpace(buf.String()))
+			return nil
+		},
+	}
+	e := alerting.NewEngine(svc, queryService, writeService)
+	now := time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	steps := []struct {
+		name  string
+		value float64
+		level string
+	}{
+		{name: "a series without a level isn't written", value: 50},
+		{name: "a series without a level isn't written until its level occurs", value: 50},
+		{name: "the level of a series occurring enough", value: 50, level: "ok"},
+		{name: "a spike keeps the level", value: 95, level: "ok"},
+		{name: "a spike ends", value: 50, level: "ok"},
+		{name: "a breach keeps the level", value: 95, level: "ok"},
+		{name: "a breach keeps the level until it occurs enough", value: 96, level: "ok"},
+		{name: "a breach occurring enough", value: 97, level: "crit"},
+	}
+	for i, step := range steps {
+		written = nil
+		value = step.value
+		e.TimeGenerator = mock.TimeGenerator{FakeValue: now.Add(time.Duration(i) * time.Minute)}
+		if err := e.Run(ctx); err != nil {
+			t.Fatalf("%s: failed to run engine: %v", step.name, err)
+		}
+		if step.level == "" {
+			if len(written) != 0 {
+				t.Errorf("%s: expected no status, got %v", step.name, written)
+			}
+			continue
+		}
+		if len(written) != 1 || !strings.Contains(written[0], ",_level="+step.level+" _message=\"cpu is "+strings.ToUpper(step.level)+"\"") {
+			t.Errorf("%s: expected a status at level %s, got %v", step.name, step.level, written)
+		}
+	}
+}
+
+func TestEngine_RunRecovery(t *testing.T) {
+	ctx := context.Background()
+	svc, user, org := newTestService(t)
+
+	slack := newSlackServer(t)
+	defer slack.Close()
+
+	edp := &endpoint.Slack{
+		Base: endpoint.Base{Name: "slack", OrgID: org.ID, Status: influxdb.Active},
+		URL:  slack.URL,
+	}
+	if err := svc.CreateNotificationEndpoint(ctx, edp, user.ID); err != nil {
+		t.Fatalf("failed to create notification endpoint: %v", err)
+	}
+	for _, nr := range []*rule.Slack{
+		{
+			Base: rule.Base{
+				Name:            "crit to slack",
+				OrgID:           org.ID,
+				EndpointID:      &edp.ID,
+				AuthorizationID: edp.ID,
+				Status:          influxdb.Active,
+				StatusRules: []notification.StatusRule{
+					{CurrentLevel: notification.LevelRule{CheckLevel: notification.Critical, Operation: true}},
+				},
+				NotifyRecovery: true,
+			},
+			MessageTemplate: "${r._check_name} is ${r._level}",
+		},
+		{
+			Base: rule.Base{
+				Name:            "ok to slack",
+				OrgID:           org.ID,
+				EndpointID:      &edp.ID,
+				AuthorizationID: edp.ID,
+				Status:          influxdb.Active,
+				StatusRules: []notification.StatusRule{
+					{
+						CurrentLevel:  notification.LevelRule{CheckLevel: notification.Ok, Operation: true},
+						PreviousLevel: &notification.LevelRule{CheckLevel: notification.Critical, Operation: true},
+					},
+				},
+			},
+			MessageTemplate: "${r._check_name} is back after ${r._incident_duration}",
+		},
+	} {
+		if err := svc.CreateNotificationRule(ctx, nr, user.ID); err != nil {
+			t.Fatalf("failed to create notification rule: %v", err)
+		}
+	}
+	c := &check.Threshold{
+		Base: check.Base{
+			Name:   "cpu",
+			OrgID:  org.ID,
+			Status: influxdb.Active,
+			Every:  influxdb.Duration{Duration: time.Minute},
+			Query: influxdb.DashboardQuery{
+				Text: `from(bucket: "telegraf") |> range(start: -1m) |> filter(fn: (r) => r._measurement == "cpu")`,
+			},
+		},
+		Thresholds: []check.ThresholdConfig{
+			&check.Greater{ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Warn}, Value: 80},
+			&check.Greater{ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Critical}, Value: 90},
+		},
+	}
+	if err := svc.CreateCheck(ctx, c, user.ID); err != nil {
+		t.Fatalf("failed to create check: %v", err)
+	}
+
+	var value float64
+	queryService := &qmock.QueryService{
+		QueryF: func(ctx context.Context, req *query.Request) (flux.ResultIterator, error) {
+			return flux.NewSliceResultIterator([]flux.Result{
+				executetest.NewResult([]*executetest.Table{{
+					KeyCols: []string{"_measurement"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_value", Type: flux.TFloat},
+						{Label: "_measurement", Type: flux.TString},
+					},
+					Data: [][]interface{}{{value, "cpu"}},
+				}}),
+			}), nil
+		},
+	}
+	writeService := &mock.WriteService{
+		WriteF: func(ctx context.Context, orgID, bucketID influxdb.ID, r io.Reader) error {
+			return nil
+		},
+	}
+	e := alerting.NewEngine(svc, queryService, writeService)
+	now := time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	steps := []struct {
+		at    time.Duration
+		value float64
+	}{
+		{value: 50},
+		{at: 2 * time.Minute, value: 85},
+		{at: 10 * time.Minute, value: 95},
+		{at: 25 * time.Minute, value: 50},
+	}
+	for _, step := range steps {
+		value = step.value
+		e.TimeGenerator = mock.TimeGenerator{FakeValue: now.Add(step.at)}
+		if err := e.Run(ctx); err != nil {
+			t.Fatalf("failed to run engine: %v", err)
+		}
+	}
+
+	want := []string{
+		"cpu is CRIT",
+		"cpu recovered after 23m",
+		"cpu is back after 23m",
+	}
+	got := slack.Messages()
+	sort.Strings(got)
+	sort.Strings(want)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected notifications, got %q, want %q", got, want)
+	}
+}
+
+func TestEngine_Open(t *testing.T) {
+	e := alerting.NewEngine(&kv.Service{}, &qmock.QueryService{}, &mock.WriteService{})
+	if err := e.Open(context.Background()); err != nil {
+		t.Fatalf("failed to open engine: %v", err)
+	}
+	if err := e.Open(context.Background()); influxdb.ErrorCode(err) != influxdb.EConflict {
+		t.Errorf("expected a conflict opening an open engine, got %v", err)
+	}
+	if err := e.Close(); err != nil {
+		t.Fatalf("failed to close engine: %v", err)
+	}
+	if err := e.Open(context.Background()); err != nil {
+		t.Fatalf("failed to open a closed engine: %v", err)
+	}
+	if err := e.Close(); err != nil {
+		t.Fatalf("failed to close engine: %v", err)
+	}
+}
+
+func TestEngine_RunEvaluates(t *testing.T) {
+	ctx := context.Background()
+	svc, user, org := newTestService(t)
+
+	hc := &check.Heartbeat{
+		Base: check.Base{
+			Name:   "backup",
+			OrgID:  org.ID,
+			Status: influxdb.Active,
+			Every:  influxdb.Duration{Duration: time.Minute},
+		},
+		Level: notification.Critical,
+	}
+	dc := &check.Deadman{
+		Base: check.Base{
+			Name:   "cpu",
+			OrgID:  org.ID,
+			Status: influxdb.Active,
+			Every:  influxdb.Duration{Duration: time.Minute},
+			Query: influxdb.DashboardQuery{
+				Text: `from(bucket: "telegraf") |> range(start: -5m) |> filter(fn: (r) => r._measurement == "cpu")`,
+			},
+		},
+		TimeSince: 90,
+		Level:     notification.Critical,
+	}
+	for _, c := range []influxdb.Check{hc, dc} {
+		if err := svc.CreateCheck(ctx, c, user.ID); err != nil {
+			t.Fatalf("failed to create check: %v", err)
+		}
+	}
+
+	var written []string
+	e := alerting.NewEngine(svc, &qmock.QueryService{
+		QueryF: func(ctx context.Context, req *query.Request) (flux.ResultIterator, error) {
+			t.Errorf("unexpected query of the check run by its task: %s", req.Compiler.(lang.FluxCompiler).Query)
+			return flux.NewSliceResultIterator(nil), nil
+		},
+	}, &mock.WriteService{
+		WriteF: func(ctx context.Context, orgID, bucketID influxdb.ID, r io.Reader) error {
+			var buf bytes.Buffer
+			if _, err := buf.ReadFrom(r); err != nil {
+				return err
+			}
+			written = append(written, strings.TrimSpace(buf.String()))
+			return nil
+		},
+	})
+	e.Evaluates = func(c influxdb.Check) bool { return !kv.HasCheckTask(c) }
+	e.TimeGenerator = mock.TimeGenerator{FakeValue: time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC)}
+	if err := e.Run(ctx); err != nil {
+		t.Fatalf("failed to run engine: %v", err)
+	}
+	if len(written) != 1 || !strings.Contains(written[0], "_check_id="+hc.ID.String()) {
+		t.Errorf("expected the status of the heartbeat check only, got %v", written)
+	}
+}
+
+func TestEngine_RunQuietHoursAndLimit(t *testing.T) {
+	ctx := context.Background()
+	svc, user, org := newTestService(t)
+	if err := svc.PutNotificationPreferences(ctx, &influxdb.NotificationPreferences{
+		UserID: user.ID,
+		QuietHours: &influxdb.QuietHours{
+			Start:  "22:00",
+			End:    "07:00",
+			Action: influxdb.QuietHoursDefer,
+		},
+	}); err != nil {
+		t.Fatalf("failed to put notification preferences: %v", err)
+	}
+
+	slack := newSlackServer(t)
+	defer slack.Close()
+
+	edp := &endpoint.Slack{
+		Base: endpoint.Base{Name: "slack", OrgID: org.ID, UserID: &user.ID, Status: influxdb.Active},
+		URL:  slack.URL,
+	}
+	if err := svc.CreateNotificationEndpoint(ctx, edp, user.ID); err != nil {
+		t.Fatalf("failed to create notification endpoint: %v", err)
+	}
+	nr := &rule.Slack{
+		Base: rule.Base{
+			Name:            "crit to slack",
+			OrgID:           org.ID,
+			EndpointID:      &edp.ID,
+			AuthorizationID: edp.ID,
+			Status:          influxdb.Active,
+			StatusRules: []notification.StatusRule{
+				{CurrentLevel: notification.LevelRule{CheckLevel: notification.Critical, Operation: true}},
+			},
+			Limit: &influxdb.Limit{Rate: 2, Every: 3600},
+		},
+		MessageTemplate: "${r._check_name} is ${r._level} at ${r._value}",
+	}
+	if err := svc.CreateNotificationRule(ctx, nr, user.ID); err != nil {
+		t.Fatalf("failed to create notification rule: %v", err)
+	}
+	c := &check.Threshold{
+		Base: check.Base{
+			Name:   "cpu",
+			OrgID:  org.ID,
+			Status: influxdb.Active,
+			Every:  influxdb.Duration{Duration: time.Minute},
+			Query: influxdb.DashboardQuery{
+				Text: `from(bucket: "telegraf") |> range(start: -1m) |> filter(fn: (r) => r._measurement == "cpu")`,
+			},
+		},
+		Thresholds: []check.ThresholdConfig{
+			&check.Greater{ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Critical}, Value: 90},
+		},
+	}
+	if err := svc.CreateCheck(ctx, c, user.ID); err != nil {
+		t.Fatalf("failed to create check: %v", err)
+	}
+
+	var value float64
+	queryService := &qmock.QueryService{
+		QueryF: func(ctx context.Context, req *query.Request) (flux.ResultIterator, error) {
+			now := req.Compiler.(lang.FluxCompiler).Now
+			return flux.NewSliceResultIterator([]flux.Result{
+				executetest.NewResult([]*executetest.Table{{
+					KeyCols: []string{"_measurement"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+						{Label: "_measurement", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(now.UnixNano()), value, "cpu"},
+					},
+				}}),
+			}), nil
+		},
+	}
+	writeService := &mock.WriteService{
+		WriteF: func(ctx context.Context, orgID, bucketID influxdb.ID, r io.Reader) error {
+			return nil
+		},
+	}
+
+	e := alerting.NewEngine(svc, queryService, writeService)
+	e.Preferences = &sender.Preferences{
+		PreferencesService: svc,
+		EndpointService:    svc,
+	}
+
+	night := time.Date(2019, 10, 1, 23, 0, 0, 0, time.UTC)
+	steps := []struct {
+		name     string
+		at       time.Time
+		value    float64
+		messages []string
+	}{
+		{
+			name:  "a notification during the quiet hours is deferred",
+			at:    night,
+			value: 91,
+		},
+		{
+			name:  "the notifications within the limit of the rule are deferred",
+			at:    night.Add(time.Minute),
+			value: 92,
+		},
+		{
+			name:  "the notifications over the limit of the rule are dropped",
+			at:    night.Add(2 * time.Minute),
+			value: 93,
+		},
+		{
+			name:     "the deferred notifications are sent when the quiet hours end",
+			at:       night.Add(8 * time.Hour),
+			value:    94,
+			messages: []string{"cpu is CRIT at 91", "cpu is CRIT at 92", "cpu is CRIT at 94"},
+		},
+	}
+	for _, step := range steps {
+		value = step.value
+		e.TimeGenerator = mock.TimeGenerator{FakeValue: step.at}
+		if err := e.Run(ctx); err != nil {
+			t.Fatalf("%s: failed to run engine: %v", step.name, err)
+		}
+		got := slack.Messages()
+		if strings.Join(got, "\n") != strings.Join(step.messages, "\n") {
+			t.Errorf("%s: unexpected notifications, got %q, want %q", step.name, got, step.messages)
+		}
+	}
+}
+
+func TestEngine_RunAlertingSettings(t *testing.T) {
+	ctx := context.Background()
+	svc, user, org := newTestService(t)
+
+	slack := newSlackServer(t)
+	defer slack.Close()
+
+	statuses := &influxdb.Bucket{OrgID: org.ID, Name: "statuses"}
+	if err := svc.CreateBucket(ctx, statuses); err != nil {
+		t.Fatalf("failed to create bucket: %v", err)
+	}
+	edp := &endpoint.Slack{
+		Base: endpoint.Base{Name: "slack", OrgID: org.ID, Status: influxdb.Active},
+		URL:  slack.URL,
+	}
+	if err := svc.CreateNotificationEndpoint(ctx, edp, user.ID); err != nil {
+		t.Fatalf("failed to create notification endpoint: %v", err)
+	}
+	if err := svc.PutAlertingSettings(ctx, &influxdb.AlertingSettings{
+		OrgID:             org.ID,
+		DefaultEndpointID: &edp.ID,
+		DefaultEvery:      influxdb.Duration{Duration: time.Minute},
+		StatusBucket:      statuses.Name,
+		QuietHours: &influxdb.QuietHours{
+			Start:  "22:00",
+			End:    "07:00",
+			Action: influxdb.QuietHoursSuppress,
+		},
+		DedupWindow: influxdb.Duration{Duration: time.Hour},
+	}); err != nil {
+		t.Fatalf("failed to put alerting settings: %v", err)
+	}
+	nr := &rule.Slack{
+		Base: rule.Base{
+			Name:            "crit to slack",
+			OrgID:           org.ID,
+			AuthorizationID: edp.ID,
+			Status:          influxdb.Active,
+			StatusRules: []notification.StatusRule{
+				{CurrentLevel: notification.LevelRule{CheckLevel: notification.Critical, Operation: true}},
+			},
+		},
+		MessageTemplate: "${r._check_name} is ${r._level} at ${r._value}",
+	}
+	if err := svc.CreateNotificationRule(ctx, nr, user.ID); err != nil {
+		t.Fatalf("failed to create notification rule: %v", err)
+	}
+	c := &check.Threshold{
+		Base: check.Base{
+			Name:   "cpu",
+			OrgID:  org.ID,
+			Status: influxdb.Active,
+			Query: influxdb.DashboardQuery{
+				Text: `from(bucket: "telegraf") |> range(start: -1m) |> filter(fn: (r) => r._measurement == "cpu")`,
+			},
+		},
+		Thresholds: []check.ThresholdConfig{
+			&check.Greater{ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Critical}, Value: 90},
+		},
+	}
+	if err := svc.CreateCheck(ctx, c, user.ID); err != nil {
+		t.Fatalf("failed to create check: %v", err)
+	}
+
+	var value float64
+	queryService := &qmock.QueryService{
+		QueryF: func(ctx context.Context, req *query.Request) (flux.ResultIterator, error) {
+			now := req.Compiler.(lang.FluxCompiler).Now
+			return flux.NewSliceResultIterator([]flux.Result{
+				executetest.NewResult([]*executetest.Table{{
+					KeyCols: []string{"_measurement"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+						{Label: "_measurement", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(now.UnixNano()), value, "cpu"},
+					},
+				}}),
+			}), nil
+		},
+	}
+	var buckets []influxdb.ID
+	writeService := &mock.WriteService{
+		WriteF: func(ctx context.Context, orgID, bucketID influxdb.ID, r io.Reader) error {
+			buckets = append(buckets, bucketID)
+			return nil
+		},
+	}
+
+	e := alerting.NewEngine(svc, queryService, writeService)
+	e.AlertingSettingsService = svc
+
+	morning := time.Date(2019, 10, 1, 10, 0, 0, 0, time.UTC)
+	steps := []struct {
+		name     string
+		at       time.Time
+		value    float64
+		messages []string
+	}{
+		{
+			name:     "the check is scheduled by the default interval and the rule sends to the default endpoint",
+			at:       morning,
+			value:    91,
+			messages: []string{"cpu is CRIT at 91"},
+		},
+		{
+			name:     "the level notified within the dedup window is dropped",
+			at:       morning.Add(time.Minute),
+			value:    92,
+			messages: []string{"cpu is CRIT at 91"},
+		},
+		{
+			name:     "the level is notified again after the dedup window",
+			at:       morning.Add(time.Hour + time.Minute),
+			value:    93,
+			messages: []string{"cpu is CRIT at 91", "cpu is CRIT at 93"},
+		},
+		{
+			name:     "the notifications during the quiet hours of the organization are suppressed",
+			at:       morning.Add(13 * time.Hour),
+			value:    94,
+			messages: []string{"cpu is CRIT at 91", "cpu is CRIT at 93"},
+		},
+	}
+	for _, step := range steps {
+		value = step.value
+		e.TimeGenerator = mock.TimeGenerator{FakeValue: step.at}
+		if err := e.Run(ctx); err != nil {
+			t.Fatalf("%s: failed to run engine: %v", step.name, err)
+		}
+		got := slack.Messages()
+		if strings.Join(got, "\n") != strings.Join(step.messages, "\n") {
+			t.Errorf("%s: unexpected notifications, got %q, want %q", step.name, got, step.messages)
+		}
+	}
+	if len(buckets) != len(steps) {
+		t.Fatalf("expected the statuses of every run to be written, got %d writes", len(buckets))
+	}
+	for _, id := range buckets {
+		if id != statuses.ID {
+			t.Errorf("expected the statuses to be written to the status bucket %s, got %s", statuses.ID, id)
+		}
+	}
+}
+
+func TestEngine_RunRoutes(t *testing.T) {
+	ctx := context.Background()
+	svc, user, org := newTestService(t)
+
+	slack, pager := newSlackServer(t), newSlackServer(t)
+	defer slack.Close()
+	defer pager.Close()
+
+	business := &endpoint.Slack{
+		Base: endpoint.Base{Name: "business hours", OrgID: org.ID, Status: influxdb.Active},
+		URL:  slack.URL,
+	}
+	onCall := &endpoint.Slack{
+		Base: endpoint.Base{Name: "on call", OrgID: org.ID, Status: influxdb.Active},
+		URL:  pager.URL,
+	}
+	for _, edp := range []*endpoint.Slack{business, onCall} {
+		if err := svc.CreateNotificationEndpoint(ctx, edp, user.ID); err != nil {
+			t.Fatalf("failed to create notification endpoint: %v", err)
+		}
+	}
+	nr := &rule.Slack{
+		Base: rule.Base{
+			Name:            "crit by time of day",
+			OrgID:           org.ID,
+			EndpointID:      &onCall.ID,
+			AuthorizationID: onCall.ID,
+			Status:          influxdb.Active,
+			StatusRules: []notification.StatusRule{
+				{CurrentLevel: notification.LevelRule{CheckLevel: notification.Critical, Operation: true}},
+			},
+			Routes: []influxdb.NotificationRoute{
+				{
+					EndpointID: business.ID,
+					Days:       []string{"mon", "tue", "wed", "thu", "fri"},
+					Start:      "09:00",
+					End:        "17:00",
+					Timezone:   "America/New_York",
+				},
+			},
+		},
+		MessageTemplate: "${r._check_name} is ${r._level} at ${r._value}",
+	}
+	if err := svc.CreateNotificationRule(ctx, nr, user.ID); err != nil {
+		t.Fatalf("failed to create notification rule: %v", err)
+	}
+	c := &check.Threshold{
+		Base: check.Base{
+			Name:   "cpu",
+			OrgID:  org.ID,
+			Status: influxdb.Active,
+			Every:  influxdb.Duration{Duration: time.Minute},
+			Query: influxdb.DashboardQuery{
+				Text: `from(bucket: "telegraf") |> range(start: -1m) |> filter(fn: (r) => r._measurement == "cpu")`,
+			},
+		},
+		Thresholds: []check.ThresholdConfig{
+			&check.Greater{ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Critical}, Value: 90},
+		},
+	}
+	if err := svc.CreateCheck(ctx, c, user.ID); err != nil {
+		t.Fatalf("failed to create check: %v", err)
+	}
+
+	var value float64
+	queryService := &qmock.QueryService{
+		QueryF: func(ctx context.Context, req *query.Request) (flux.ResultIterator, error) {
+			now := req.Compiler.(lang.FluxCompiler).Now
+			return flux.NewSliceResultIterator([]flux.Result{
+				executetest.NewResult([]*executetest.Table{{
+					KeyCols: []string{"_measurement"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+						{Label: "_measurement", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(now.UnixNano()), value, "cpu"},
+					},
+				}}),
+			}), nil
+		},
+	}
+	writeService := &mock.WriteService{
+		WriteF: func(ctx context.Context, orgID, bucketID influxdb.ID, r io.Reader) error {
+			return nil
+		},
+	}
+	e := alerting.NewEngine(svc, queryService, writeService)
+
+	// 2019-10-04 is a friday, 14:00 UTC is 10:00 in New York.
+	steps := []struct {
+		name     string
+		at       time.Time
+		value    float64
+		business []string
+		onCall   []string
+	}{
+		{
+			name:     "business hours are routed to their endpoint",
+			at:       time.Date(2019, 10, 4, 14, 0, 0, 0, time.UTC),
+			value:    91,
+			business: []string{"cpu is CRIT at 91"},
+		},
+		{
+			name:     "nights are sent to the endpoint of the rule",
+			at:       time.Date(2019, 10, 5, 2, 0, 0, 0, time.UTC),
+			value:    92,
+			business: []string{"cpu is CRIT at 91"},
+			onCall:   []string{"cpu is CRIT at 92"},
+		},
+		{
+			name:     "weekends are sent to the endpoint of the rule",
+			at:       time.Date(2019, 10, 5, 14, 0, 0, 0, time.UTC),
+			value:    93,
+			business: []string{"cpu is CRIT at 91"},
+			onCall:   []string{"cpu is CRIT at 92", "cpu is CRIT at 93"},
+		},
+	}
+	for _, step := range steps {
+		value = step.value
+		e.TimeGenerator = mock.TimeGenerator{FakeValue: step.at}
+		if err := e.Run(ctx); err != nil {
+			t.Fatalf("%s: failed to run engine: %v", step.name, err)
+		}
+		if got := slack.Messages(); strings.Join(got, "\n") != strings.Join(step.business, "\n") {
+			t.Errorf("%s: unexpected business hours notifications, got %q, want %q", step.name, got, step.business)
+		}
+		if got := pager.Messages(); strings.Join(got, "\n") != strings.Join(step.onCall, "\n") {
+			t.Errorf("%s: unexpected on call notifications, got %q, want %q", step.name, got, step.onCall)
+		}
+	}
+}
+
+func TestEngine_RunSilences(t *testing.T) {
+	ctx := context.Background()
+	svc, user, org := newTestService(t)
+	slack := newSlackServer(t)
+	defer slack.Close()
+
+	edp := &endpoint.Slack{
+		Base: endpoint.Base{Name: "slack", OrgID: org.ID, Status: influxdb.Active},
+		URL:  slack.URL,
+	}
+	if err := svc.CreateNotificationEndpoint(ctx, edp, user.ID); err != nil {
+		t.Fatalf("failed to create notification endpoint: %v", err)
+	}
+	nr := &rule.Slack{
+		Base: rule.Base{
+			Name:            "crit to slack",
+			OrgID:           org.ID,
+			EndpointID:      &edp.ID,
+			AuthorizationID: edp.ID,
+			Status:          influxdb.Active,
+			StatusRules: []notification.StatusRule{
+				{CurrentLevel: notification.LevelRule{CheckLevel: notification.Critical, Operation: true}},
+			},
+		},
+		MessageTemplate: "${r._check_name} is ${r._level}",
+	}
+	if err := svc.CreateNotificationRule(ctx, nr, user.ID); err != nil {
+		t.Fatalf("failed to create notification rule: %v", err)
+	}
+
+	start := time.Date(2019, 10, 1, 12, 0, 0, 0, time.UTC)
+	label := &influxdb.Label{OrgID: org.ID, Name: "team:payments"}
+	if err := svc.CreateLabel(ctx, label); err != nil {
+		t.Fatalf("failed to create label: %v", err)
+	}
+	if err := svc.CreateSilence(ctx, &influxdb.Silence{
+		OrgID:    org.ID,
+		LabelID:  label.ID,
+		StartsAt: start,
+		EndsAt:   start.Add(time.Hour),
+	}); err != nil {
+		t.Fatalf("failed to create silence: %v", err)
+	}
+
+	// the checks are labeled after the silence is created.
+	for _, name := range []string{"payments", "search"} {
+		c := &check.Threshold{
+			Base: check.Base{
+				Name:   name,
+				OrgID:  org.ID,
+				Status: influxdb.Active,
+				Every:  influxdb.Duration{Duration: time.Minute},
+				Query: influxdb.DashboardQuery{
+					Text: `from(bucket: "telegraf") |> range(start: -1m) |> filter(fn: (r) => r._measurement == "cpu")`,
+				},
+			},
+			Thresholds: []check.ThresholdConfig{
+				&check.Greater{ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Critical}, Value: 90},
+			},
+		}
+		if err := svc.CreateCheck(ctx, c, user.ID); err != nil {
+			t.Fatalf("failed to create check: %v", err)
+		}
+		if name != "payments" {
+			continue
+		}
+		if err := svc.CreateLabelMapping(ctx, &influxdb.LabelMapping{
+			LabelID:      label.ID,
+			ResourceID:   c.ID,
+			ResourceType: influxdb.ChecksResourceType,
+		}); err != nil {
+			t.Fatalf("failed to label check: %v", err)
+		}
+	}
+
+	queryService := &qmock.QueryService{
+		QueryF: func(ctx context.Context, req *query.Request) (flux.ResultIterator, error) {
+			now := req.Compiler.(lang.FluxCompiler).Now
+			return flux.NewSliceResultIterator([]flux.Result{
+				executetest.NewResult([]*executetest.Table{{
+					KeyCols: []string{"_measurement"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+						{Label: "_measurement", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(now.UnixNano()), 95.0, "cpu"},
+					},
+				}}),
+			}), nil
+		},
+	}
+	writeService := &mock.WriteService{
+		WriteF: func(ctx context.Context, orgID, bucketID influxdb.ID, r io.Reader) error {
+			return nil
+		},
+	}
+	e := alerting.NewEngine(svc, queryService, writeService)
+	e.SilenceService = svc
+	e.LabelService = svc
+
+	steps := []struct {
+		name     string
+		at       time.Time
+		messages []string
+	}{
+		{
+			name:     "the checks carrying the label of an active silence are muted",
+			at:       start.Add(time.Minute),
+			messages: []string{"search is CRIT"},
+		},
+		{
+			name:     "the checks are notified once the silence ends",
+			at:       start.Add(time.Hour),
+			messages: []string{"search is CRIT", "payments is CRIT", "search is CRIT"},
+		},
+	}
+	for _, step := range steps {
+		e.TimeGenerator = mock.TimeGenerator{FakeValue: step.at}
+		if err := e.Run(ctx); err != nil {
+			t.Fatalf("%s: failed to run engine: %v", step.name, err)
+		}
+		got := slack.Messages()
+		sort.Strings(got)
+		want := append([]string(nil), step.messages...)
+		sort.Strings(want)
+		if strings.Join(got, "\n") != strings.Join(want, "\n") {
+			t.Errorf("%s: unexpected notifications, got %q, want %q", step.name, got, want)
+		}
+	}
+}
+
+func TestEngine_RunAlertingPause(t *testing.T) {
+	ctx := context.Background()
+	svc, user, org := newTestService(t)
+	slack := newSlackServer(t)
+	defer slack.Close()
+
+	edp := &endpoint.Slack{
+		Base: endpoint.Base{Name: "slack", OrgID: org.ID, Status: influxdb.Active},
+		URL:  slack.URL,
+	}
+	if err := svc.CreateNotificationEndpoint(ctx, edp, user.ID); err != nil {
+		t.Fatalf("failed to create notification endpoint: %v", err)
+	}
+	nr := &rule.Slack{
+		Base: rule.Base{
+			Name:            "crit to slack",
+			OrgID:           org.ID,
+			EndpointID:      &edp.ID,
+			AuthorizationID: edp.ID,
+			Status:          influxdb.Active,
+			StatusRules: []notification.StatusRule{
+				{CurrentLevel: notification.LevelRule{CheckLevel: notification.Critical, Operation: true}},
+			},
+		},
+		MessageTemplate: "${r._check_name} is ${r._level}",
+	}
+	if err := svc.CreateNotificationRule(ctx, nr, user.ID); err != nil {
+		t.Fatalf("failed to create notification rule: %v", err)
+	}
+	// the check is reactivated during the pause, its statuses are written
+	// but not notified.
+	c := &check.Threshold{
+		Base: check.Base{
+			Name:   "cpu",
+			OrgID:  org.ID,
+			Status: influxdb.Active,
+			Every:  influxdb.Duration{Duration: time.Minute},
+			Query: influxdb.DashboardQuery{
+				Text: `from(bucket: "telegraf") |> range(start: -1m) |> filter(fn: (r) => r._measurement == "cpu")`,
+			},
+		},
+		Thresholds: []check.ThresholdConfig{
+			&check.Greater{ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Critical}, Value: 90},
+		},
+	}
+	if err := svc.CreateCheck(ctx, c, user.ID); err != nil {
+		t.Fatalf("failed to create check: %v", err)
+	}
+
+	queryService := &qmock.QueryService{
+		QueryF: func(ctx context.Context, req *query.Request) (flux.ResultIterator, error) {
+			now := req.Compiler.(lang.FluxCompiler).Now
+			return flux.NewSliceResultIterator([]flux.Result{
+				executetest.NewResult([]*executetest.Table{{
+					KeyCols: []string{"_measurement"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+						{Label: "_measurement", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(now.UnixNano()), 95.0, "cpu"},
+					},
+				}}),
+			}), nil
+		},
+	}
+	var written int
+	writeService := &mock.WriteService{
+		WriteF: func(ctx context.Context, orgID, bucketID influxdb.ID, r io.Reader) error {
+			written++
+			return nil
+		},
+	}
+	start := time.Date(2019, 10, 1, 12, 0, 0, 0, time.UTC)
+	paused := true
+	e := alerting.NewEngine(svc, queryService, writeService)
+	e.AlertingPauseService = &mock.AlertingPauseService{
+		FindAlertingPauseF: func(ctx context.Context, orgID influxdb.ID) (*influxdb.AlertingPause, error) {
+			if !paused {
+				return nil, &influxdb.Error{Code: influxdb.ENotFound}
+			}
+			return &influxdb.AlertingPause{OrgID: orgID, PausedAt: start, Reason: "maintenance"}, nil
+		},
+	}
+
+	e.TimeGenerator = mock.TimeGenerator{FakeValue: start}
+	if err := e.Run(ctx); err != nil {
+		t.Fatalf("failed to run engine: %v", err)
+	}
+	if got := slack.Messages(); len(got) != 0 || written == 0 {
+		t.Errorf("expected the statuses to be written and not notified during the pause, got %d writes and %q", written, got)
+	}
+
+	paused = false
+	e.TimeGenerator = mock.TimeGenerator{FakeValue: start.Add(time.Minute)}
+	if err := e.Run(ctx); err != nil {
+		t.Fatalf("failed to run engine: %v", err)
+	}
+	if got := slack.Messages(); strings.Join(got, "\n") != "cpu is CRIT" {
+		t.Errorf("expected the statuses to be notified once resumed, got %q", got)
+	}
+}
+
+func TestEngine_RunPriorities(t *testing.T) {
+	ctx := context.Background()
+	svc, user, org := newTestService(t)
+	slack := newSlackServer(t)
+	defer slack.Close()
+
+	edp := &endpoint.Slack{
+		Base: endpoint.Base{Name: "slack", OrgID: org.ID, Status: influxdb.Active},
+		URL:  slack.URL,
+	}
+	if err := svc.CreateNotificationEndpoint(ctx, edp, user.ID); err != nil {
+		t.Fatalf("failed to create notification endpoint: %v", err)
+	}
+	nr := &rule.Slack{
+		Base: rule.Base{
+			Name:            "crit to slack",
+			OrgID:           org.ID,
+			EndpointID:      &edp.ID,
+			AuthorizationID: edp.ID,
+			Status:          influxdb.Active,
+			StatusRules: []notification.StatusRule{
+				{CurrentLevel: notification.LevelRule{CheckLevel: notification.Critical, Operation: true}},
+			},
+		},
+		MessageTemplate: "${r._check_name} is ${r._level}",
+	}
+	if err := svc.CreateNotificationRule(ctx, nr, user.ID); err != nil {
+		t.Fatalf("failed to create notification rule: %v", err)
+	}
+
+	// the checks are created in the reverse order of their priority.
+	for _, p := range []influxdb.CheckPriority{influxdb.CheckPriorityLow, "", influxdb.CheckPriorityCritical} {
+		name := string(p)
+		if name == "" {
+			name = "unset"
+		}
+		c := &check.Threshold{
+			Base: check.Base{
+				Name:     name,
+				OrgID:    org.ID,
+				Status:   influxdb.Active,
+				Every:    influxdb.Duration{Duration: time.Minute},
+				Priority: p,
+				Query: influxdb.DashboardQuery{
+					Text: `from(bucket: "telegraf") |> range(start: -1m) |> filter(fn: (r) => r._measurement == "cpu")`,
+				},
+			},
+			Thresholds: []check.ThresholdConfig{
+				&check.Greater{ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Critical}, Value: 90},
+			},
+		}
+		if err := svc.CreateCheck(ctx, c, user.ID); err != nil {
+			t.Fatalf("failed to create check: %v", err)
+		}
+	}
+
+	queryService := &qmock.QueryService{
+		QueryF: func(ctx context.Context, req *query.Request) (flux.ResultIterator, error) {
+			now := req.Compiler.(lang.FluxCompiler).Now
+			return flux.NewSliceResultIterator([]flux.Result{
+				executetest.NewResult([]*executetest.Table{{
+					KeyCols: []string{"_measurement"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+						{Label: "_measurement", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(now.UnixNano()), 95.0, "cpu"},
+					},
+				}}),
+			}), nil
+		},
+	}
+	writeService := &mock.WriteService{
+		WriteF: func(ctx context.Context, orgID, bucketID influxdb.ID, r io.Reader) error {
+			return nil
+		},
+	}
+	e := alerting.NewEngine(svc, queryService, writeService)
+	e.TimeGenerator = mock.TimeGenerator{FakeValue: time.Date(2019, 10, 1, 12, 0, 0, 0, time.UTC)}
+	if err := e.Run(ctx); err != nil {
+		t.Fatalf("failed to run engine: %v", err)
+	}
+
+	want := []string{"critical is CRIT", "unset is CRIT", "low is CRIT"}
+	if got := slack.Messages(); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected order of notifications, got %q, want %q", got, want)
+	}
+}
+
+func TestEngine_NotifyCheckPaused(t *testing.T) {
+	ctx := context.Background()
+	svc, user, org := newTestService(t)
+	slack := newSlackServer(t)
+	defer slack.Close()
+
+	edp := &endpoint.Slack{
+		Base: endpoint.Base{Name: "slack", OrgID: org.ID, Status: influxdb.Active},
+		URL:  slack.URL,
+	}
+	if err := svc.CreateNotificationEndpoint(ctx, edp, user.ID); err != nil {
+		t.Fatalf("failed to create notification endpoint: %v", err)
+	}
+	c := &check.Threshold{
+		Base: check.Base{
+			ID:          influxdb.ID(1),
+			Name:        "cpu",
+			OrgID:       org.ID,
+			Status:      influxdb.Inactive,
+			PauseReason: "the bucket telegraf read by the query of the check was deleted",
+		},
+	}
+
+	e := alerting.NewEngine(svc, &qmock.QueryService{}, &mock.WriteService{})
+	e.Preferences = &sender.Preferences{
+		PreferencesService: svc,
+		EndpointService:    svc,
+	}
+	if err := e.NotifyCheckPaused(ctx, c, user.ID); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Fatalf("expected the owner without preferences not to be notified, got %v", err)
+	}
+
+	if err := svc.PutNotificationPreferences(ctx, &influxdb.NotificationPreferences{
+		UserID:              user.ID,
+		PreferredEndpointID: &edp.ID,
+	}); err != nil {
+		t.Fatalf("failed to put notification preferences: %v", err)
+	}
+	if err := e.NotifyCheckPaused(ctx, c, user.ID); err != nil {
+		t.Fatalf("failed to notify the owner of the paused check: %v", err)
+	}
+	want := []string{"check cpu was paused: the bucket telegraf read by the query of the check was deleted"}
+	if got := slack.Messages(); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected notifications, got %q, want %q", got, want)
+	}
+}
+
+func TestEngine_NotifyCheckGC(t *testing.T) {
+	ctx := context.Background()
+	svc, user, org := newTestService(t)
+	slack := newSlackServer(t)
+	defer slack.Close()
+
+	edp := &endpoint.Slack{
+		Base: endpoint.Base{Name: "slack", OrgID: org.ID, Status: influxdb.Active},
+		URL:  slack.URL,
+	}
+	if err := svc.CreateNotificationEndpoint(ctx, edp, user.ID); err != nil {
+		t.Fatalf("failed to create notification endpoint: %v", err)
+	}
+	if err := svc.PutNotificationPreferences(ctx, &influxdb.NotificationPreferences{
+		UserID:              user.ID,
+		PreferredEndpointID: &edp.ID,
+	}); err != nil {
+		t.Fatalf("failed to put notification preferences: %v", err)
+	}
+	c := &check.Threshold{
+		Base: check.Base{
+			ID:     influxdb.ID(1),
+			Name:   "cpu",
+			OrgID:  org.ID,
+			Status: influxdb.Inactive,
+		},
+	}
+
+	e := alerting.NewEngine(svc, &qmock.QueryService{}, &mock.WriteService{})
+	e.Preferences = &sender.Preferences{
+		PreferencesService: svc,
+		EndpointService:    svc,
+	}
+	deleteAfter := time.Date(2030, 1, 15, 0, 0, 0, 0, time.UTC)
+	if err := e.NotifyCheckGC(ctx, c, user.ID, deleteAfter, "gc-exclude"); err != nil {
+		t.Fatalf("failed to notify the owner of the collected check: %v", err)
+	}
+	want := []string{"check cpu is unused and will be deleted after 2030-01-15T00:00:00Z unless it is modified or activated, or labeled gc-exclude"}
+	if got := slack.Messages(); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected notifications, got %q, want %q", got, want)
+	}
+}
+
+func TestEngine_SummarizeRun(t *testing.T) {
+	ctx := context.Background()
+	svc, user, org := newTestService(t)
+	slack := newSlackServer(t)
+	defer slack.Close()
+
+	edp := &endpoint.Slack{
+		Base: endpoint.Base{Name: "slack", OrgID: org.ID, Status: influxdb.Active},
+		URL:  slack.URL,
+	}
+	if err := svc.CreateNotificationEndpoint(ctx, edp, user.ID); err != nil {
+		t.Fatalf("failed to create notification endpoint: %v", err)
+	}
+	nr := &rule.Slack{
+		Base: rule.Base{
+			Name:            "cpu to slack",
+			OrgID:           org.ID,
+			EndpointID:      &edp.ID,
+			AuthorizationID: edp.ID,
+			Status:          influxdb.Active,
+		},
+		MessageTemplate: "${r._check_name} on ${r.host} is ${r._level}",
+	}
+	if err := svc.CreateNotificationRule(ctx, nr, user.ID); err != nil {
+		t.Fatalf("failed to create notification rule: %v", err)
+	}
+	c := &check.Threshold{
+		Base: check.Base{
+			Name:   "cpu",
+			OrgID:  org.ID,
+			Status: influxdb.Active,
+			Every:  influxdb.Duration{Duration: time.Minute},
+			Query: influxdb.DashboardQuery{
+				Text: `from(bucket: "telegraf") |> range(start: -1m) |> filter(fn: (r) => r._measurement == "cpu")`,
+			},
+		},
+		Thresholds: []check.ThresholdConfig{
+			&check.Greater{ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Critical}, Value: 90},
+		},
+	}
+	if err := svc.CreateCheck(ctx, c, user.ID); err != nil {
+		t.Fatalf("failed to create check: %v", err)
+	}
+	task, err := svc.FindTaskByID(ctx, c.TaskID)
+	if err != nil {
+		t.Fatalf("failed to find the task of the check: %v", err)
+	}
+
+	var written []string
+	writeService := &mock.WriteService{
+		WriteF: func(ctx context.Context, orgID, bucketID influxdb.ID, r io.Reader) error {
+			var buf bytes.Buffer
+			if _, err := buf.ReadFrom(r); err != nil {
+				return err
+			}
+			written = append(written, strings.TrimSpace(buf.String()))
+			return nil
+		},
+	}
+	e := alerting.NewEngine(svc, &qmock.QueryService{}, writeService)
+	now := time.Date(2019, 10, 1, 0, 1, 0, 0, time.UTC)
+	e.TimeGenerator = mock.TimeGenerator{FakeValue: now}
+
+	results := func(levels ...string) []flux.Result {
+		data := make([][]interface{}, 0, len(levels))
+		for i, level := range levels {
+			data = append(data, []interface{}{execute.Time(now.UnixNano()), float64(i), c.ID.String(), "cpu", level, string(rune('a' + i))})
+		}
+		return []flux.Result{
+			executetest.NewResult([]*executetest.Table{{
+				KeyCols: []string{"host"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+					{Label: "_check_id", Type: flux.TString},
+					{Label: "_check_name", Type: flux.TString},
+					{Label: "_level", Type: flux.TString},
+					{Label: "host", Type: flux.TString},
+				},
+				Data: data,
+			}}),
+		}
+	}
+	summarize := func(rs []flux.Result) *influxdb.CheckRunSummary {
+		t.Helper()
+		rs[0].(*executetest.Result).Nm = "statuses"
+		rs = append(rs, executetest.NewResult([]*executetest.Table{{
+			ColMeta: []flux.ColMeta{{Label: "_level", Type: flux.TString}},
+			Data:    [][]interface{}{{"crit"}},
+		}}))
+		run := e.SummarizeRun(task, backend.QueuedRun{})
+		for _, res := range rs {
+			if err := run.Read(res); err != nil {
+				t.Fatalf("failed to read the result of the run: %v", err)
+			}
+		}
+		msg, err := run.Summarize(ctx)
+		if err != nil {
+			t.Fatalf("failed to summarize the run: %v", err)
+		}
+		s, ok := influxdb.ParseCheckRunSummary(msg)
+		if !ok {
+			t.Fatalf("expected a summary, got %q", msg)
+		}
+		return s
+	}
+
+	s := summarize(results("crit", "ok"))
+	if s.Statuses["CRIT"] != 1 || s.Statuses["OK"] != 1 || s.Notifications != 1 {
+		t.Errorf("unexpected summary of the first run %+v", s)
+	}
+	want := `statuses,_check_id=` + c.ID.String() + `,_check_name=cpu,_level=crit,host=a _message="",_value=0 1569888060000000000`
+	if len(written) != 1 || !strings.HasPrefix(written[0], want) {
+		t.Errorf("unexpected statuses written\ngot  %v\nwant %s", written, want)
+	}
+	if got := slack.Messages(); len(got) != 1 || got[0] != "cpu on a is CRIT" {
+		t.Errorf("unexpected notifications %q", got)
+	}
+
+	s = summarize(results("crit", "crit"))
+	if s.Statuses["CRIT"] != 2 || s.Notifications != 1 || s.Suppressions[influxdb.RuleDeduplicated] != 1 {
+		t.Errorf("unexpected summary of the second run %+v", s)
+	}
+}
+
+func TestEngine_SummarizeRunRecoverAndDeferred(t *testing.T) {
+	ctx := context.Background()
+	svc, user, org := newTestService(t)
+	if err := svc.PutNotificationPreferences(ctx, &influxdb.NotificationPreferences{
+		UserID: user.ID,
+		QuietHours: &influxdb.QuietHours{
+			Start:  "22:00",
+			End:    "07:00",
+			Action: influxdb.QuietHoursDefer,
+		},
+	}); err != nil {
+		t.Fatalf("failed to put notification preferences: %v", err)
+	}
+
+	slack := newSlackServer(t)
+	defer slack.Close()
+
+	edp := &endpoint.Slack{
+		Base: endpoint.Base{Name: "slack", OrgID: org.ID, UserID: &user.ID, Status: influxdb.Active},
+		URL:  slack.URL,
+	}
+	if err := svc.CreateNotificationEndpoint(ctx, edp, user.ID); err != nil {
+		t.Fatalf("failed to create notification endpoint: %v", err)
+	}
+	nr := &rule.Slack{
+		Base: rule.Base{
+			Name:            "cpu to slack",
+			OrgID:           org.ID,
+			EndpointID:      &edp.ID,
+			AuthorizationID: edp.ID,
+			Status:          influxdb.Active,
+		},
+		MessageTemplate: "${r._check_name} is ${r._level} at ${r._value}",
+	}
+	if err := svc.CreateNotificationRule(ctx, nr, user.ID); err != nil {
+		t.Fatalf("failed to create notification rule: %v", err)
+	}
+	recover := 80.0
+	c := &check.Threshold{
+		Base: check.Base{
+			Name:   "cpu",
+			OrgID:  org.ID,
+			Status: influxdb.Active,
+			Every:  influxdb.Duration{Duration: time.Minute},
+			Query: influxdb.DashboardQuery{
+				Text: `from(bucket: "telegraf") |> range(start: -1m) |> filter(fn: (r) => r._measurement == "cpu")`,
+			},
+		},
+		Thresholds: []check.ThresholdConfig{
+			&check.Greater{ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Critical}, Value: 90, Recover: &recover},
+		},
+	}
+	if err := svc.CreateCheck(ctx, c, user.ID); err != nil {
+		t.Fatalf("failed to create check: %v", err)
+	}
+	task, err := svc.FindTaskByID(ctx, c.TaskID)
+	if err != nil {
+		t.Fatalf("failed to find the task of the check: %v", err)
+	}
+
+	e := alerting.NewEngine(svc, &qmock.QueryService{}, &mock.WriteService{
+		WriteF: func(ctx context.Context, orgID, bucketID influxdb.ID, r io.Reader) error {
+			return nil
+		},
+	})
+	e.Preferences = &sender.Preferences{
+		PreferencesService: svc,
+		EndpointService:    svc,
+	}
+
+	// the rows are the statuses of the flux of the check, at the level of the
+	// crossed thresholds, with whether the threshold is crossed and holds.
+	summarize := func(at time.Time, rows ...[]interface{}) *influxdb.CheckRunSummary {
+		t.Helper()
+		e.TimeGenerator = mock.TimeGenerator{FakeValue: at}
+		data := make([][]interface{}, 0, len(rows))
+		for _, row := range rows {
+			data = append(data, append([]interface{}{execute.Time(at.UnixNano()), c.ID.String(), "cpu"}, row...))
+		}
+		res := executetest.NewResult([]*executetest.Table{{
+			ColMeta: []flux.ColMeta{
+				{Label: "_time", Type: flux.TTime},
+				{Label: "_check_id", Type: flux.TString},
+				{Label: "_check_name", Type: flux.TString},
+				{Label: "_value", Type: flux.TFloat},
+				{Label: "_level", Type: flux.TString},
+				{Label: "_crossed0", Type: flux.TFloat},
+				{Label: "_holds0", Type: flux.TFloat},
+			},
+			Data: data,
+		}})
+		res.Nm = "statuses"
+		run := e.SummarizeRun(task, backend.QueuedRun{})
+		if err := run.Read(res); err != nil {
+			t.Fatalf("failed to read the result of the run: %v", err)
+		}
+		msg, err := run.Summarize(ctx)
+		if err != nil {
+			t.Fatalf("failed to summarize the run: %v", err)
+		}
+		s, ok := influxdb.ParseCheckRunSummary(msg)
+		if !ok {
+			t.Fatalf("expected a summary, got %q", msg)
+		}
+		return s
+	}
+
+	night := time.Date(2019, 10, 1, 23, 0, 0, 0, time.UTC)
+	if s := summarize(night, []interface{}{91.0, "crit", 1.0, 1.0}); s.Statuses["CRIT"] != 1 {
+		t.Errorf("expected the crossed threshold to be crit, got %+v", s)
+	}
+	if s := summarize(night.Add(time.Minute), []interface{}{85.0, "ok", 0.0, 1.0}); s.Statuses["CRIT"] != 1 {
+		t.Errorf("expected the threshold to hold above its recover value, got %+v", s)
+	}
+	if s := summarize(night.Add(2*time.Minute), []interface{}{75.0, "ok", 0.0, 0.0}); s.Statuses["OK"] != 1 {
+		t.Errorf("expected the threshold to recover below its recover value, got %+v", s)
+	}
+	if got := slack.Messages(); len(got) != 0 {
+		t.Errorf("expected the notifications to be deferred during the quiet hours, got %q", got)
+	}
+
+	// a run after the quiet hours sends the deferred notifications.
+	summarize(night.Add(8 * time.Hour))
+	want := []string{"cpu is CRIT at 91", "cpu is OK at 75"}
+	if got := slack.Messages(); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected deferred notifications, got %q, want %q", got, want)
+	}
+}
+
+func TestEngine_SummarizeRunLag(t *testing.T) {
+	ctx := context.Background()
+	svc, user, org := newTestService(t)
+	c := &check.Deadman{
+		Base: check.Base{
+			Name:   "cpu",
+			OrgID:  org.ID,
+			Status: influxdb.Active,
+			Every:  influxdb.Duration{Duration: time.Minute},
+			Query: influxdb.DashboardQuery{
+				Text: `from(bucket: "telegraf") |> range(start: -1m) |> filter(fn: (r) => r._measurement == "cpu")`,
+			},
+		},
+		TimeSince: 60,
+		Level:     notification.Critical,
+	}
+	if err := svc.CreateCheck(ctx, c, user.ID); err != nil {
+		t.Fatalf("failed to create check: %v", err)
+	}
+	task, err := svc.FindTaskByID(ctx, c.TaskID)
+	if err != nil {
+		t.Fatalf("failed to find the task of the check: %v", err)
+	}
+
+	e := alerting.NewEngine(svc, &qmock.QueryService{}, &mock.WriteService{})
+	due := time.Date(2019, 10, 1, 0, 1, 0, 0, time.UTC)
+	for _, run := range []struct {
+		qr      backend.QueuedRun
+		started time.Time
+	}{
+		{qr: backend.QueuedRun{DueAt: due.Unix()}, started: due.Add(3 * time.Second)},
+		{qr: backend.QueuedRun{DueAt: due.Add(time.Minute).Unix()}, started: due.Add(time.Minute + 7*time.Second)},
+		// the runs requested manually aren't late.
+		{qr: backend.QueuedRun{DueAt: due.Add(time.Hour).Unix(), RequestedAt: due.Unix()}, started: due.Add(2 * time.Hour)},
+	} {
+		e.TimeGenerator = mock.TimeGenerator{FakeValue: run.started}
+		if _, err := e.SummarizeRun(task, run.qr).Summarize(ctx); err != nil {
+			t.Fatalf("failed to summarize the run: %v", err)
+		}
+	}
+
+	got, err := e.FindCheckLag(ctx, c.ID)
+	if err != nil {
+		t.Fatalf("failed to find the lag of the check: %v", err)
+	}
+	d := func(d time.Duration) influxdb.Duration { return influxdb.Duration{Duration: d} }
+	want := []influxdb.CheckLagSample{
+		{ScheduledFor: due.Add(time.Minute), StartedAt: due.Add(time.Minute + 7*time.Second), Lag: d(7 * time.Second)},
+		{ScheduledFor: due, StartedAt: due.Add(3 * time.Second), Lag: d(3 * time.Second)},
+	}
+	if !reflect.DeepEqual(got.Samples, want) {
+		t.Errorf("unexpected lag samples of the check\ngot  %+v\nwant %+v", got.Samples, want)
+	}
+}
+
+func TestEngine_RunTrace(t *testing.T) {
+	ctx := context.Background()
+	svc, user, org := newTestService(t)
+	slack := newSlackServer(t)
+	defer slack.Close()
+
+	edp := &endpoint.Slack{
+		Base: endpoint.Base{Name: "slack", OrgID: org.ID, Status: influxdb.Active},
+		URL:  slack.URL,
+	}
+	if err := svc.CreateNotificationEndpoint(ctx, edp, user.ID); err != nil {
+		t.Fatalf("failed to create notification endpoint: %v", err)
+	}
+	newRule := func(name string, status influxdb.Status, env string) *rule.Slack {
+		nr := &rule.Slack{
+			Base: rule.Base{
+				Name:            name,
+				OrgID:           org.ID,
+				EndpointID:      &edp.ID,
+				AuthorizationID: edp.ID,
+				Status:          status,
+				TagRules: []notification.TagRule{
+					{Tag: notification.Tag{Key: "env", Value: env}, Operator: notification.Equal},
+				},
+			},
+			MessageTemplate: "${r._check_name} is ${r._level}",
+		}
+		if err := svc.CreateNotificationRule(ctx, nr, user.ID); err != nil {
+			t.Fatalf("failed to create notification rule: %v", err)
+		}
+		return nr
+	}
+	prod := newRule("prod to slack", influxdb.Active, "prod")
+	staging := newRule("staging to slack", influxdb.Active, "staging")
+	paused := newRule("paused", influxdb.Inactive, "prod")
+
+	c := &check.Threshold{
+		Base: check.Base{
+			Name:   "cpu",
+			OrgID:  org.ID,
+			Status: influxdb.Active,
+			Every:  influxdb.Duration{Duration: time.Minute},
+			Query: influxdb.DashboardQuery{
+				Text: `from(bucket: "telegraf") |> range(start: -1m) |> filter(fn: (r) => r._measurement == "cpu")`,
+			},
+			Tags: []notification.Tag{{Key: "env", Value: "prod"}},
+		},
+		Thresholds: []check.ThresholdConfig{
+			&check.Greater{ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Critical}, Value: 90},
+		},
+	}
+	if err := svc.CreateCheck(ctx, c, user.ID); err != nil {
+		t.Fatalf("failed to create check: %v", err)
+	}
+
+	queryService := &qmock.QueryService{
+		QueryF: func(ctx context.Context, req *query.Request) (flux.ResultIterator, error) {
+			now := req.Compiler.(lang.FluxCompiler).Now
+			return flux.NewSliceResultIterator([]flux.Result{
+				executetest.NewResult([]*executetest.Table{{
+					KeyCols: []string{"_measurement"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+						{Label: "_measurement", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(now.UnixNano()), 95.0, "cpu"},
+					},
+				}}),
+			}), nil
+		},
+	}
+	var written []string
+	writeService := &mock.WriteService{
+		WriteF: func(ctx context.Context, orgID, bucketID influxdb.ID, r io.Reader) error {
+			var buf bytes.Buffer
+			if _, err := buf.ReadFrom(r); err != nil {
+				return err
+			}
+			written = append(written, strings.TrimSpace(buf.String()))
+			return nil
+		},
+	}
+
+	e := alerting.NewEngine(svc, queryService, writeService)
+	e.StatusTraceService = svc
+	var nextID influxdb.ID = 100
+	e.IDGenerator = mock.IDGenerator{IDFn: func() influxdb.ID {
+		nextID++
+		return nextID
+	}}
+
+	now := time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC)
+	steps := []struct {
+		name      string
+		at        time.Duration
+		decisions map[influxdb.ID]influxdb.RuleDecision
+	}{
+		{
+			name: "the first status is notified by the matching rule",
+			decisions: map[influxdb.ID]influxdb.RuleDecision{
+				prod.ID:    influxdb.RuleNotified,
+				staging.ID: influxdb.RuleUnmatched,
+				paused.ID:  influxdb.RuleMuted,
+			},
+		},
+		{
+			name: "the same level is deduplicated",
+			at:   time.Minute,
+			decisions: map[influxdb.ID]influxdb.RuleDecision{
+				prod.ID:    influxdb.RuleDeduplicated,
+				staging.ID: influxdb.RuleUnmatched,
+				paused.ID:  influxdb.RuleMuted,
+			},
+		},
+	}
+	for _, step := range steps {
+		written = nil
+		e.TimeGenerator = mock.TimeGenerator{FakeValue: now.Add(step.at)}
+		if err := e.Run(ctx); err != nil {
+			t.Fatalf("%s: failed to run engine: %v", step.name, err)
+		}
+
+		if len(written) != 1 || !strings.Contains(written[0], `_status_id="`+nextID.String()+`"`) {
+			t.Errorf("%s: expected the status to be written with id %s, got %v", step.name, nextID, written)
+		}
+		trace, err := svc.FindStatusTrace(ctx, nextID)
+		if err != nil {
+			t.Fatalf("%s: failed to find status trace: %v", step.name, err)
+		}
+		if trace.CheckID != c.ID || trace.OrgID != org.ID || trace.Level != "CRIT" || !trace.Time.Equal(now.Add(step.at)) {
+			t.Errorf("%s: unexpected status trace %+v", step.name, trace)
+		}
+		got := make(map[influxdb.ID]influxdb.RuleDecision, len(trace.Rules))
+		for _, rt := range trace.Rules {
+			got[rt.RuleID] = rt.Decision
+			if rt.Decision == influxdb.RuleNotified && (rt.EndpointID == nil || *rt.EndpointID != edp.ID) {
+				t.Errorf("%s: expected the notification to be sent to endpoint %s, got %v", step.name, edp.ID, rt.EndpointID)
+			}
+			if rt.Decision != influxdb.RuleNotified && rt.Reason == "" {
+				t.Errorf("%s: expected rule %s to have a reason", step.name, rt.RuleName)
+			}
+		}
+		if !reflect.DeepEqual(got, step.decisions) {
+			t.Errorf("%s: unexpected decisions, got %v, want %v", step.name, got, step.decisions)
+		}
+	}
+	if got := slack.Messages(); len(got) != 1 || got[0] != "cpu is CRIT" {
+		t.Errorf("unexpected notifications %q", got)
+	}
+}
+
+func TestEngine_InjectStatus(t *testing.T) {
+	ctx := context.Background()
+	svc, user, org := newTestService(t)
+	slack := newSlackServer(t)
+	defer slack.Close()
+
+	edp := &endpoint.Slack{
+		Base: endpoint.Base{Name: "slack", OrgID: org.ID, Status: influxdb.Active},
+		URL:  slack.URL,
+	}
+	if err := svc.CreateNotificationEndpoint(ctx, edp, user.ID); err != nil {
+		t.Fatalf("failed to create notification endpoint: %v", err)
+	}
+	nr := &rule.Slack{
+		Base: rule.Base{
+			Name:            "cpu to slack",
+			OrgID:           org.ID,
+			EndpointID:      &edp.ID,
+			AuthorizationID: edp.ID,
+			Status:          influxdb.Active,
+			TagRules: []notification.TagRule{
+				{Tag: notification.Tag{Key: "env", Value: "prod"}, Operator: notification.Equal},
+			},
+			Limit: &influxdb.Limit{Rate: 1, Every: 3600},
+		},
+		MessageTemplate: "${r._check_name} on ${r.host} is ${r._level}",
+	}
+	if err := svc.CreateNotificationRule(ctx, nr, user.ID); err != nil {
+		t.Fatalf("failed to create notification rule: %v", err)
+	}
+	c := &check.Threshold{
+		Base: check.Base{
+			Name:   "cpu",
+			OrgID:  org.ID,
+			Status: influxdb.Active,
+			Every:  influxdb.Duration{Duration: time.Minute},
+			Query: influxdb.DashboardQuery{
+				Text: `from(bucket: "telegraf") |> range(start: -1m) |> filter(fn: (r) => r._measurement == "cpu")`,
+			},
+			Tags:                  []notification.Tag{{Key: "env", Value: "prod"}},
+			StatusMessageTemplate: "cpu of ${r.host} is ${r._level}",
+		},
+		Thresholds: []check.ThresholdConfig{
+			&check.Greater{ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Critical}, Value: 90},
+		},
+	}
+	if err := svc.CreateCheck(ctx, c, user.ID); err != nil {
+		t.Fatalf("failed to create check: %v", err)
+	}
+
+	queryService := &qmock.QueryService{
+		QueryF: func(ctx context.Context, req *query.Request) (flux.ResultIterator, error) {
+			now := req.Compiler.(lang.FluxCompiler).Now
+			return flux.NewSliceResultIterator([]flux.Result{
+				executetest.NewResult([]*executetest.Table{{
+					KeyCols: []string{"_measurement", "host"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+						{Label: "_measurement", Type: flux.TString},
+						{Label: "host", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(now.UnixNano()), 95.0, "cpu", "a"},
+					},
+				}}),
+			}), nil
+		},
+	}
+	var written []string
+	writeService := &mock.WriteService{
+		WriteF: func(ctx context.Context, orgID, bucketID influxdb.ID, r io.Reader) error {
+			var buf bytes.Buffer
+			if _, err := buf.ReadFrom(r); err != nil {
+				return err
+			}
+			written = append(written, strings.TrimSpace(buf.String()))
+			return nil
+		},
+	}
+
+	e := alerting.NewEngine(svc, queryService, writeService)
+	e.IDGenerator = mock.NewIDGenerator("0000000000000100", t)
+	now := time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC)
+	e.TimeGenerator = mock.TimeGenerator{FakeValue: now}
+
+	if _, err := e.InjectStatus(ctx, &influxdb.StatusInjection{CheckID: c.ID, Level: "BAD"}); influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Errorf("expected an invalid level to be rejected, got %v", err)
+	}
+	trace, err := e.InjectStatus(ctx, &influxdb.StatusInjection{
+		CheckID: c.ID,
+		Level:   "CRIT",
+		Tags:    map[string]string{"host": "a"},
+	})
+	if err != nil {
+		t.Fatalf("failed to inject status: %v", err)
+	}
+	if trace.StatusID.String() != "0000000000000100" || trace.CheckID != c.ID || trace.Level != "CRIT" {
+		t.Errorf("unexpected status trace %+v", trace)
+	}
+	if len(trace.Rules) != 1 || trace.Rules[0].Decision != influxdb.RuleNotified {
+		t.Errorf("expected the injected status to be notified, got %+v", trace.Rules)
+	}
+	want := `statuses,_check_id=` + c.ID.String() + `,_check_name=cpu,_level=crit,env=prod,host=a _message="cpu of a is CRIT",_status_id="0000000000000100",_synthetic=true 1569888000000000000`
+	if len(written) != 1 || written[0] != want {
+		t.Errorf("unexpected statuses written\ngot  %v\nwant %s", written, want)
+	}
+
+	// the injected status neither changes the level of the series nor
+	// counts toward the limit of the rule.
+	if err := e.Run(ctx); err != nil {
+		t.Fatalf("failed to run engine: %v", err)
+	}
+	if got, want := slack.Messages(), []string{"[test] cpu on a is CRIT", "cpu on a is CRIT"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected notifications, got %q, want %q", got, want)
+	}
+}
+
+func TestEngine_MessageLimits(t *testing.T) {
+	ctx := context.Background()
+	svc, user, org := newTestService(t)
+	slack := newSlackServer(t)
+	defer slack.Close()
+
+	edp := &endpoint.Slack{
+		Base: endpoint.Base{Name: "slack", OrgID: org.ID, Status: influxdb.Active},
+		URL:  slack.URL,
+	}
+	if err := svc.CreateNotificationEndpoint(ctx, edp, user.ID); err != nil {
+		t.Fatalf("failed to create notification endpoint: %v", err)
+	}
+	nr := &rule.Slack{
+		Base: rule.Base{
+			Name:            "cpu to slack",
+			OrgID:           org.ID,
+			EndpointID:      &edp.ID,
+			AuthorizationID: edp.ID,
+			Status:          influxdb.Active,
+		},
+		MessageTemplate: "${r.host} is ${r._level}",
+	}
+	if err := svc.CreateNotificationRule(ctx, nr, user.ID); err != nil {
+		t.Fatalf("failed to create notification rule: %v", err)
+	}
+	c := &check.Threshold{
+		Base: check.Base{
+			Name:   "cpu",
+			OrgID:  org.ID,
+			Status: influxdb.Active,
+			Every:  influxdb.Duration{Duration: time.Minute},
+			Query: influxdb.DashboardQuery{
+				Text: `from(bucket: "telegraf") |> range(start: -1m)`,
+			},
+		},
+		Thresholds: []check.ThresholdConfig{
+			&check.Greater{ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Critical}, Value: 90},
+		},
+	}
+	if err := svc.CreateCheck(ctx, c, user.ID); err != nil {
+		t.Fatalf("failed to create check: %v", err)
+	}
+
+	e := alerting.NewEngine(svc, &qmock.QueryService{}, &mock.WriteService{
+		WriteF: func(ctx context.Context, orgID, bucketID influxdb.ID, r io.Reader) error {
+			return nil
+		},
+	})
+	e.IDGenerator = mock.NewIDGenerator("0000000000000100", t)
+	e.TimeGenerator = mock.TimeGenerator{FakeValue: time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC)}
+	e.SenderConfig.MessageLimits = sender.MessageLimits{
+		MaxSizes:   map[string]int{"slack": 16},
+		Truncation: sender.TruncateTail,
+	}
+
+	trace, err := e.InjectStatus(ctx, &influxdb.StatusInjection{
+		CheckID: c.ID,
+		Level:   "CRIT",
+		Tags:    map[string]string{"host": "the-very-long-name-of-the-host"},
+	})
+	if err != nil {
+		t.Fatalf("failed to inject status: %v", err)
+	}
+	if len(trace.Rules) != 1 || trace.Rules[0].Decision != influxdb.RuleNotified || !strings.Contains(trace.Rules[0].Reason, "truncated to the 16 bytes") {
+		t.Errorf("expected the notification to be truncated, got %+v", trace.Rules)
+	}
+	// the mark of the synthetic statuses is added to the truncated message.
+	if got, want := slack.Messages(), []string{"[test] …-host is CRIT"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected notifications, got %q, want %q", got, want)
+	}
+}
+
+func TestEngine_faults(t *testing.T) {
+	ctx := context.Background()
+	svc, user, org := newTestService(t)
+	slack := newSlackServer(t)
+	defer slack.Close()
+
+	edp := &endpoint.Slack{
+		Base: endpoint.Base{Name: "slack", OrgID: org.ID, Status: influxdb.Active},
+		URL:  slack.URL,
+	}
+	if err := svc.CreateNotificationEndpoint(ctx, edp, user.ID); err != nil {
+		t.Fatalf("failed to create notification endpoint: %v", err)
+	}
+	nr := &rule.Slack{
+		Base: rule.Base{
+			Name:            "cpu to slack",
+			OrgID:           org.ID,
+			EndpointID:      &edp.ID,
+			AuthorizationID: edp.ID,
+			Status:          influxdb.Active,
+		},
+		MessageTemplate: "${r._check_name} is ${r._level}",
+	}
+	if err := svc.CreateNotificationRule(ctx, nr, user.ID); err != nil {
+		t.Fatalf("failed to create notification rule: %v", err)
+	}
+	c := &check.Threshold{
+		Base: check.Base{
+			Name:   "cpu",
+			OrgID:  org.ID,
+			Status: influxdb.Active,
+			Every:  influxdb.Duration{Duration: time.Minute},
+			Query:  influxdb.DashboardQuery{Text: `from(bucket: "telegraf")`},
+		},
+		Thresholds: []check.ThresholdConfig{
+			&check.Greater{ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Critical}, Value: 90},
+		},
+	}
+	if err := svc.CreateCheck(ctx, c, user.ID); err != nil {
+		t.Fatalf("failed to create check: %v", err)
+	}
+
+	e := alerting.NewEngine(svc, nil, &mock.WriteService{
+		WriteF: func(ctx context.Context, orgID, bucketID influxdb.ID, r io.Reader) error {
+			return nil
+		},
+	})
+	e.TimeGenerator = mock.TimeGenerator{FakeValue: time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC)}
+	e.SenderConfig.Faults = sender.NewFaultScript(
+		&sender.Fault{Reset: true},
+		&sender.Fault{StatusCode: http.StatusBadGateway},
+	)
+
+	for _, want := range []struct {
+		decision influxdb.RuleDecision
+		reason   string
+	}{
+		{decision: influxdb.RuleFailed, reason: "connection reset by peer"},
+		{decision: influxdb.RuleFailed, reason: "slack responded with unexpected status Bad Gateway"},
+		{decision: influxdb.RuleNotified},
+	} {
+		trace, err := e.InjectStatus(ctx, &influxdb.StatusInjection{CheckID: c.ID, Level: "CRIT"})
+		if err != nil {
+			t.Fatalf("failed to inject status: %v", err)
+		}
+		if len(trace.Rules) != 1 || trace.Rules[0].Decision != want.decision || !strings.Contains(trace.Rules[0].Reason, want.reason) {
+			t.Errorf("expected the rule to be %s with %q, got %+v", want.decision, want.reason, trace.Rules)
+		}
+	}
+	if got := slack.Messages(); len(got) != 1 {
+		t.Errorf("expected only the notification without a fault to be delivered, got %q", got)
+	}
+}
+
+func TestEngine_WriteExternalStatus(t *testing.T) {
+	ctx := context.Background()
+	svc, user, org := newTestService(t)
+	slack := newSlackServer(t)
+	defer slack.Close()
+
+	edp := &endpoint.Slack{
+		Base: endpoint.Base{Name: "slack", OrgID: org.ID, Status: influxdb.Active},
+		URL:  slack.URL,
+	}
+	if err := svc.CreateNotificationEndpoint(ctx, edp, user.ID); err != nil {
+		t.Fatalf("failed to create notification endpoint: %v", err)
+	}
+	nr := &rule.Slack{
+		Base: rule.Base{
+			Name:            "ci to slack",
+			OrgID:           org.ID,
+			EndpointID:      &edp.ID,
+			AuthorizationID: edp.ID,
+			Status:          influxdb.Active,
+			NotifyRecovery:  true,
+		},
+		MessageTemplate: "${r._check_name} on ${r.host} is ${r._level}: ${r._message}",
+	}
+	if err := svc.CreateNotificationRule(ctx, nr, user.ID); err != nil {
+		t.Fatalf("failed to create notification rule: %v", err)
+	}
+	c := &check.External{
+		Base: check.Base{
+			Name:        "ci",
+			OrgID:       org.ID,
+			Status:      influxdb.Active,
+			Occurrences: 2,
+			Tags:        []notification.Tag{{Key: "env", Value: "prod"}},
+		},
+	}
+	if err := svc.CreateCheck(ctx, c, user.ID); err != nil {
+		t.Fatalf("failed to create check: %v", err)
+	}
+	tc := &check.Threshold{
+		Base: check.Base{
+			Name:   "cpu",
+			OrgID:  org.ID,
+			Status: influxdb.Inactive,
+			Every:  influxdb.Duration{Duration: time.Minute},
+			Query: influxdb.DashboardQuery{
+				Text: `from(bucket: "telegraf") |> range(start: -1m) |> filter(fn: (r) => r._measurement == "cpu")`,
+			},
+		},
+		Thresholds: []check.ThresholdConfig{
+			&check.Greater{ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Critical}, Value: 90},
+		},
+	}
+	if err := svc.CreateCheck(ctx, tc, user.ID); err != nil {
+		t.Fatalf("failed to create check: %v", err)
+	}
+
+	queryService := &qmock.QueryService{
+		QueryF: func(ctx context.Context, req *query.Request) (flux.ResultIterator, error) {
+			t.Fatalf("unexpected query of an external check")
+			return nil, nil
+		},
+	}
+	var written []string
+	writeService := &mock.WriteService{
+		WriteF: func(ctx context.Context, orgID, bucketID influxdb.ID, r io.Reader) error {
+			var buf bytes.Buffer
+			if _, err := buf.ReadFrom(r); err != nil {
+				return err
+			}
+			written = append(written, strings.TrimSpace(buf.String()))
+			return nil
+		},
+	}
+
+	e := alerting.NewEngine(svc, queryService, writeService)
+	e.IDGenerator = mock.NewIDGenerator("0000000000000100", t)
+	now := time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC)
+	e.TimeGenerator = mock.TimeGenerator{FakeValue: now}
+
+	if _, err := e.WriteExternalStatus(ctx, tc.ID, &influxdb.ExternalStatus{Level: "CRIT"}); influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Errorf("expected a status of a threshold check to be rejected, got %v", err)
+	}
+	if _, err := e.WriteExternalStatus(ctx, c.ID, &influxdb.ExternalStatus{Level: "BAD"}); influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Errorf("expected an invalid level to be rejected, got %v", err)
+	}
+	// the engine doesn't run external checks.
+	if err := e.Run(ctx); err != nil {
+		t.Fatalf("failed to run engine: %v", err)
+	}
+
+	steps := []struct {
+		at      time.Duration
+		level   string
+		traced  string
+		written int
+	}{
+		// the first status of the series is held back by the occurrences.
+		{level: "CRIT", traced: "CRIT"},
+		{at: time.Minute, level: "CRIT", traced: "CRIT", written: 1},
+		// the series stays crit until ok occurs twice.
+		{at: 2 * time.Minute, level: "OK", traced: "CRIT", written: 2},
+		{at: 5 * time.Minute, level: "OK", traced: "OK", written: 3},
+	}
+	for _, step := range steps {
+		e.TimeGenerator = mock.TimeGenerator{FakeValue: now.Add(step.at)}
+		trace, err := e.WriteExternalStatus(ctx, c.ID, &influxdb.ExternalStatus{
+			Level:   step.level,
+			Message: "build " + strings.ToLower(step.level),
+			Tags:    map[string]string{"host": "a"},
+		})
+		if err != nil {
+			t.Fatalf("failed to write external status: %v", err)
+		}
+		if trace.CheckID != c.ID || trace.Level != step.traced {
+			t.Errorf("unexpected status trace %+v", trace)
+		}
+		if len(written) != step.written {
+			t.Errorf("expected %d statuses written at %s, got %d", step.written, step.at, len(written))
+		}
+	}
+
+	want := `statuses,_check_id=` + c.ID.String() + `,_check_name=ci,_level=crit,env=prod,host=a _message="build crit",_status_id="0000000000000100" 1569888060000000000`
+	if len(written) == 0 || written[0] != want {
+		t.Errorf("unexpected statuses written\ngot  %v\nwant %s", written, want)
+	}
+	if got, want := slack.Messages(), []string{"ci on a is CRIT: build crit", "ci recovered after 4m"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected notifications, got %q, want %q", got, want)
+	}
+}
+
+func TestEngine_RunHeartbeat(t *testing.T) {
+	ctx := context.Background()
+	svc, user, org := newTestService(t)
+	slack := newSlackServer(t)
+	defer slack.Close()
+
+	edp := &endpoint.Slack{
+		Base: endpoint.Base{Name: "slack", OrgID: org.ID, Status: influxdb.Active},
+		URL:  slack.URL,
+	}
+	if err := svc.CreateNotificationEndpoint(ctx, edp, user.ID); err != nil {
+		t.Fatalf("failed to create notification endpoint: %v", err)
+	}
+	nr := &rule.Slack{
+		Base: rule.Base{
+			Name:            "backup to slack",
+			OrgID:           org.ID,
+			EndpointID:      &edp.ID,
+			AuthorizationID: edp.ID,
+			Status:          influxdb.Active,
+		},
+		MessageTemplate: "${r._check_name} is ${r._level}",
+	}
+	if err := svc.CreateNotificationRule(ctx, nr, user.ID); err != nil {
+		t.Fatalf("failed to create notification rule: %v", err)
+	}
+	c := &check.Heartbeat{
+		Base: check.Base{
+			Name:   "backup",
+			OrgID:  org.ID,
+			Status: influxdb.Active,
+			Every:  influxdb.Duration{Duration: time.Hour},
+		},
+		Level: notification.Critical,
+	}
+	if err := svc.CreateCheck(ctx, c, user.ID); err != nil {
+		t.Fatalf("failed to create check: %v", err)
+	}
+	dc := &check.Deadman{
+		Base: check.Base{
+			Name:   "cpu",
+			OrgID:  org.ID,
+			Status: influxdb.Inactive,
+			Every:  influxdb.Duration{Duration: time.Minute},
+			Query: influxdb.DashboardQuery{
+				Text: `from(bucket: "telegraf") |> range(start: -5m) |> filter(fn: (r) => r._measurement == "cpu")`,
+			},
+		},
+		TimeSince: 90,
+		Level:     notification.Critical,
+	}
+	if err := svc.CreateCheck(ctx, dc, user.ID); err != nil {
+		t.Fatalf("failed to create check: %v", err)
+	}
+
+	queryService := &qmock.QueryService{
+		QueryF: func(ctx context.Context, req *query.Request) (flux.ResultIterator, error) {
+			t.Fatalf("unexpected query of a heartbeat check")
+			return nil, nil
+		},
+	}
+	var written []string
+	writeService := &mock.WriteService{
+		WriteF: func(ctx context.Context, orgID, bucketID influxdb.ID, r io.Reader) error {
+			var buf bytes.Buffer
+			if _, err := buf.ReadFrom(r); err != nil {
+				return err
+			}
+			written = append(written, strings.TrimSpace(buf.String()))
+			return nil
+		},
+	}
+	e := alerting.NewEngine(svc, queryService, writeService)
+	// the pings are received by another engine sharing the store.
+	p := alerting.NewEngine(svc, queryService, writeService)
+	now := time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	if err := p.PingCheck(ctx, dc.ID); influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Errorf("expected a ping of a deadman check to be rejected, got %v", err)
+	}
+
+	steps := []struct {
+		at   time.Duration
+		ping bool
+	}{
+		// the check isn't late the first time it is evaluated.
+		{},
+		{at: 30 * time.Minute, ping: true},
+		{at: time.Hour},
+		{at: 2 * time.Hour},
+		{at: 150 * time.Minute, ping: true},
+		{at: 3 * time.Hour},
+	}
+	for _, step := range steps {
+		e.TimeGenerator = mock.TimeGenerator{FakeValue: now.Add(step.at)}
+		p.TimeGenerator = e.TimeGenerator
+		if step.ping {
+			if err := p.PingCheck(ctx, c.ID); err != nil {
+				t.Fatalf("failed to ping check: %v", err)
+			}
+			continue
+		}
+		if err := e.Run(ctx); err != nil {
+			t.Fatalf("failed to run engine: %v", err)
+		}
+	}
+
+	want := `statuses,_check_id=` + c.ID.String() + `,_check_name=backup,_level=crit _message="",_value=5400 1569895200000000000`
+	if len(written) != 4 || written[2] != want {
+		t.Errorf("unexpected statuses written\ngot  %v\nwant %s", written, want)
+	}
+	if got, want := slack.Messages(), []string{"backup is CRIT", "backup is OK"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected notifications, got %q, want %q", got, want)
+	}
+}
+
+func TestEngine_NotificationBudget(t *testing.T) {
+	ctx := context.Background()
+	svc, user, org := newTestService(t)
+
+	var servers []*slackServer
+	var edps []*endpoint.Slack
+	for _, name := range []string{"pagerduty", "fallback", "admin"} {
+		s := newSlackServer(t)
+		defer s.Close()
+		edp := &endpoint.Slack{
+			Base: endpoint.Base{Name: name, OrgID: org.ID, Status: influxdb.Active},
+			URL:  s.URL,
+		}
+		if err := svc.CreateNotificationEndpoint(ctx, edp, user.ID); err != nil {
+			t.Fatalf("failed to create notification endpoint: %v", err)
+		}
+		servers = append(servers, s)
+		edps = append(edps, edp)
+	}
+	nr := &rule.Slack{
+		Base: rule.Base{
+			Name:            "cpu to pagerduty",
+			OrgID:           org.ID,
+			EndpointID:      &edps[0].ID,
+			AuthorizationID: edps[0].ID,
+			Status:          influxdb.Active,
+		},
+		MessageTemplate: "${r._check_name} is ${r._level}",
+	}
+	if err := svc.CreateNotificationRule(ctx, nr, user.ID); err != nil {
+		t.Fatalf("failed to create notification rule: %v", err)
+	}
+	c := &check.Deadman{
+		Base: check.Base{
+			Name:   "cpu",
+			OrgID:  org.ID,
+			Status: influxdb.Active,
+			Every:  influxdb.Duration{Duration: time.Minute},
+			Query: influxdb.DashboardQuery{
+				Text: `from(bucket: "telegraf") |> range(start: -1m) |> filter(fn: (r) => r._measurement == "cpu")`,
+			},
+		},
+		TimeSince: 60,
+		Level:     notification.Critical,
+	}
+	if err := svc.CreateCheck(ctx, c, user.ID); err != nil {
+		t.Fatalf("failed to create check: %v", err)
+	}
+	b := &influxdb.NotificationBudget{
+		EndpointID:         edps[0].ID,
+		Monthly:            1,
+		AdminEndpointID:    &edps[2].ID,
+		FallbackEndpointID: &edps[1].ID,
+	}
+	if err := svc.PutNotificationBudget(ctx, b); err != nil {
+		t.Fatalf("failed to put notification budget: %v", err)
+	}
+
+	e := alerting.NewEngine(svc, &qmock.QueryService{}, &mock.WriteService{
+		WriteF: func(ctx context.Context, orgID, bucketID influxdb.ID, r io.Reader) error {
+			return nil
+		},
+	})
+	e.NotificationBudgetService = svc
+	e.TimeGenerator = mock.TimeGenerator{FakeValue: time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC)}
+
+	inject := func() influxdb.RuleTrace {
+		t.Helper()
+		trace, err := e.InjectStatus(ctx, &influxdb.StatusInjection{CheckID: c.ID, Level: "CRIT"})
+		if err != nil {
+			t.Fatalf("failed to inject status: %v", err)
+		}
+		if len(trace.Rules) != 1 {
+			t.Fatalf("expected a single rule trace, got %+v", trace.Rules)
+		}
+		return trace.Rules[0]
+	}
+
+	if rt := inject(); rt.Decision != influxdb.RuleNotified || *rt.EndpointID != edps[0].ID {
+		t.Errorf("expected the first notification to be within the budget, got %+v", rt)
+	}
+	for i := 0; i < 2; i++ {
+		if rt := inject(); rt.Decision != influxdb.RuleNotified || *rt.EndpointID != edps[1].ID {
+			t.Errorf("expected the notifications over the budget to be sent to the fallback, got %+v", rt)
+		}
+	}
+
+	b.FallbackEndpointID = nil
+	if err := svc.PutNotificationBudget(ctx, b); err != nil {
+		t.Fatalf("failed to put notification budget: %v", err)
+	}
+	rt := inject()
+	if rt.Decision != influxdb.RuleMuted || rt.Reason != "the notification endpoint exceeded its monthly budget of 1 notifications" {
+		t.Errorf("expected the notification over the budget to be dropped without a fallback, got %+v", rt)
+	}
+
+	if got := len(servers[0].Messages()); got != 1 {
+		t.Errorf("expected 1 notification within the budget, got %d", got)
+	}
+	if got := len(servers[1].Messages()); got != 2 {
+		t.Errorf("expected 2 notifications to the fallback, got %d", got)
+	}
+	want := []string{"notification endpoint pagerduty exceeded its monthly budget of 1 notifications"}
+	if got := servers[2].Messages(); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected the admin to be notified once, got %q", got)
+	}
+
+	u, err := svc.FindNotificationBudgetUsage(ctx, edps[0].ID, time.Date(2019, 10, 15, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("failed to find notification budget usage: %v", err)
+	}
+	if u.Notifications != 4 || u.Overage != 3 {
+		t.Errorf("unexpected usage %+v", u)
+	}
+}
+
+func TestEngine_RunLeases(t *testing.T) {
+	ctx := context.Background()
+	svc, user, org := newTestService(t)
+	now := time.Date(2019, 10, 1, 0, 0, 30, 0, time.UTC)
+	svc.TimeGenerator = mock.TimeGenerator{FakeValue: now}
+
+	c := &check.Deadman{
+		Base: check.Base{
+			Name:   "heartbeat",
+			OrgID:  org.ID,
+			Status: influxdb.Active,
+			Every:  influxdb.Duration{Duration: time.Minute},
+			Query: influxdb.DashboardQuery{
+				Text: `from(bucket: "telegraf") |> range(start: -1m) |> filter(fn: (r) => r._measurement == "heartbeat")`,
+			},
+		},
+		TimeSince: 60,
+		Level:     notification.Critical,
+	}
+	if err := svc.CreateCheck(ctx, c, user.ID); err != nil {
+		t.Fatalf("failed to create check: %v", err)
+	}
+
+	queried := make(map[string]int)
+	newEngine := func(node string) *alerting.Engine {
+		e := alerting.NewEngine(svc, &qmock.QueryService{
+			QueryF: func(ctx context.Context, req *query.Request) (flux.ResultIterator, error) {
+				queried[node]++
+				return flux.NewSliceResultIterator(nil), nil
+			},
+		}, &mock.WriteService{
+			WriteF: func(ctx context.Context, orgID, bucketID influxdb.ID, r io.Reader) error { return nil },
+		})
+		e.LeaseService = svc
+		e.Node = node
+		e.LeaseTTL = 90 * time.Second
+		return e
+	}
+	a, b := newEngine("a"), newEngine("b")
+	run := func(at time.Duration, engines ...*alerting.Engine) {
+		t.Helper()
+		svc.TimeGenerator = mock.TimeGenerator{FakeValue: now.Add(at)}
+		for _, e := range engines {
+			e.TimeGenerator = mock.TimeGenerator{FakeValue: now.Add(at)}
+			if err := e.Run(ctx); err != nil {
+				t.Fatalf("failed to run engine %s: %v", e.Node, err)
+			}
+		}
+	}
+
+	run(0, a, b)
+	run(time.Minute, b, a)
+	if queried["a"] != 2 || queried["b"] != 0 {
+		t.Fatalf("expected the check to run on the engine holding its lease only, got %v", queried)
+	}
+
+	// a stops renewing its lease, b takes the check over once it expires.
+	run(2*time.Minute, b)
+	if queried["b"] != 0 {
+		t.Fatalf("expected b to wait for the lease of a to expire, got %v", queried)
+	}
+	run(3*time.Minute, b)
+	if queried["b"] != 1 {
+		t.Fatalf("expected the check to run on b once the lease of a expired, got %v", queried)
+	}
+	run(4*time.Minute, a, b)
+	if queried["a"] != 2 || queried["b"] != 2 {
+		t.Fatalf("expected b to keep the check, got %v", queried)
+	}
+
+	// b releases its leases when it's closed.
+	if err := b.Close(); err != nil {
+		t.Fatalf("failed to close engine: %v", err)
+	}
+	run(5*time.Minute, a)
+	if queried["a"] != 3 {
+		t.Fatalf("expected a to run the check released by b, got %v", queried)
+	}
+}
+
+func TestEngine_RunLeasesState(t *testing.T) {
+	ctx := context.Background()
+	svc, user, org := newTestService(t)
+	now := time.Date(2019, 10, 1, 0, 0, 30, 0, time.UTC)
+	svc.TimeGenerator = mock.TimeGenerator{FakeValue: now}
+
+	slack := newSlackServer(t)
+	defer slack.Close()
+
+	edp := &endpoint.Slack{
+		Base: endpoint.Base{Name: "slack", OrgID: org.ID, Status: influxdb.Active},
+		URL:  slack.URL,
+	}
+	if err := svc.CreateNotificationEndpoint(ctx, edp, user.ID); err != nil {
+		t.Fatalf("failed to create notification endpoint: %v", err)
+	}
+	nr := &rule.Slack{
+		Base: rule.Base{
+			Name:            "cpu to slack",
+			OrgID:           org.ID,
+			EndpointID:      &edp.ID,
+			AuthorizationID: edp.ID,
+			Status:          influxdb.Active,
+		},
+		MessageTemplate: "${r._check_name} on ${r.host} is ${r._level}",
+	}
+	if err := svc.CreateNotificationRule(ctx, nr, user.ID); err != nil {
+		t.Fatalf("failed to create notification rule: %v", err)
+	}
+	c := &check.Threshold{
+		Base: check.Base{
+			Name:   "cpu",
+			OrgID:  org.ID,
+			Status: influxdb.Active,
+			Every:  influxdb.Duration{Duration: time.Minute},
+			Query: influxdb.DashboardQuery{
+				Text: `from(bucket: "telegraf") |> range(start: -1m) |> filter(fn: (r) => r._measurement == "cpu")`,
+			},
+		},
+		Thresholds: []check.ThresholdConfig{
+			&check.Greater{ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Warn}, Value: 80},
+			&check.Greater{ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Critical}, Value: 90},
+		},
+	}
+	if err := svc.CreateCheck(ctx, c, user.ID); err != nil {
+		t.Fatalf("failed to create check: %v", err)
+	}
+
+	value := 95.0
+	queried := make(map[string]int)
+	newEngine := func(node string) *alerting.Engine {
+		e := alerting.NewEngine(svc, &qmock.QueryService{
+			QueryF: func(ctx context.Context, req *query.Request) (flux.ResultIterator, error) {
+				queried[node]++
+				return flux.NewSliceResultIterator([]flux.Result{
+					executetest.NewResult([]*executetest.Table{{
+						KeyCols: []string{"_measurement", "host"},
+						ColMeta: []flux.ColMeta{
+							{Label: "_time", Type: flux.TTime},
+							{Label: "_value", Type: flux.TFloat},
+							{Label: "_measurement", Type: flux.TString},
+							{Label: "host", Type: flux.TString},
+						},
+						Data: [][]interface{}{
+							{execute.Time(now.UnixNano()), value, "cpu", "a"},
+						},
+					}}),
+				}), nil
+			},
+		}, &mock.WriteService{
+			WriteF: func(ctx context.Context, orgID, bucketID influxdb.ID, r io.Reader) error { return nil },
+		})
+		e.LeaseService = svc
+		e.CheckStateService = svc
+		e.Node = node
+		e.LeaseTTL = 90 * time.Second
+		return e
+	}
+	a, b := newEngine("a"), newEngine("b")
+	run := func(at time.Duration, engines ...*alerting.Engine) {
+		t.Helper()
+		svc.TimeGenerator = mock.TimeGenerator{FakeValue: now.Add(at)}
+		for _, e := range engines {
+			e.TimeGenerator = mock.TimeGenerator{FakeValue: now.Add(at)}
+			if err := e.Run(ctx); err != nil {
+				t.Fatalf("failed to run engine %s: %v", e.Node, err)
+			}
+		}
+	}
+	messages := func(want ...string) {
+		t.Helper()
+		if got := slack.Messages(); strings.Join(got, "\n") != strings.Join(want, "\n") {
+			t.Fatalf("unexpected notifications, got %q, want %q", got, want)
+		}
+	}
+
+	run(0, a)
+	messages("cpu on a is CRIT")
+	if err := a.Close(); err != nil {
+		t.Fatalf("failed to close engine: %v", err)
+	}
+
+	// b carries on where a stopped: the check isn't due again before its
+	// next run, and the level of its series isn't notified again.
+	run(10*time.Second, b)
+	if queried["b"] != 0 {
+		t.Fatalf("expected b to restore the latest run of the check, got %v", queried)
+	}
+	run(time.Minute, b)
+	if queried["b"] != 1 {
+		t.Fatalf("expected the check to run on b once due, got %v", queried)
+	}
+	messages("cpu on a is CRIT")
+
+	// a drops the state of the check held by b, and restores the state saved
+	// by b when it takes the check back.
+	run(2*time.Minute, a)
+	value = 85
+	run(2*time.Minute, b)
+	messages("cpu on a is CRIT", "cpu on a is WARN")
+	if err := b.Close(); err != nil {
+		t.Fatalf("failed to close engine: %v", err)
+	}
+	run(3*time.Minute, a)
+	if queried["a"] != 2 {
+		t.Fatalf("expected a to run the check released by b, got %v", queried)
+	}
+	messages("cpu on a is CRIT", "cpu on a is WARN")
+}
+
+// dataSourceFunc is a data source running its queries with a function.
+type dataSourceFunc func(ctx context.Context, q alerting.SeriesQuery) ([]*alerting.Series, error)
+
+func (f dataSourceFunc) QuerySeries(ctx context.Context, q alerting.SeriesQuery) ([]*alerting.Series, error) {
+	return f(ctx, q)
+}
+
+func TestEngine_RunDataSources(t *testing.T) {
+	ctx := context.Background()
+	svc, user, org := newTestService(t)
+
+	metrics := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		io.WriteString(w, `# TYPE http_requests_total counter
+http_requests_total{code="200",handler="/api"} 1027
+http_requests_total{code="500",handler="/api"} 12
+http_requests_total{code="503",handler="/metrics"} 3
+`)
+	}))
+	defer metrics.Close()
+
+	prom := &check.Threshold{
+		Base: check.Base{
+			Name:      "errors",
+			OrgID:     org.ID,
+			Status:    influxdb.Active,
+			Every:     influxdb.Duration{Duration: time.Minute},
+			QueryType: check.QueryTypePrometheus,
+			Query:     influxdb.DashboardQuery{Text: `http_requests_total{code=~"5..",handler="/api"}`},
+			ScrapeURL: metrics.URL,
+		},
+		Thresholds: []check.ThresholdConfig{
+			&check.Greater{ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Critical}, Value: 10},
+		},
+	}
+	if err := svc.CreateCheck(ctx, prom, user.ID); err != nil {
+		t.Fatalf("failed to create check: %v", err)
+	}
+	iql := &check.Threshold{
+		Base: check.Base{
+			Name:      "mem",
+			OrgID:     org.ID,
+			Status:    influxdb.Active,
+			Every:     influxdb.Duration{Duration: time.Minute},
+			QueryType: check.QueryTypeInfluxQL,
+			Query:     influxdb.DashboardQuery{Text: `SELECT used_percent FROM "telegraf"."autogen"."mem"`},
+		},
+		Thresholds: []check.ThresholdConfig{
+			&check.Lesser{ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Warn}, Value: 50},
+		},
+	}
+	if err := svc.CreateCheck(ctx, iql, user.ID); err != nil {
+		t.Fatalf("failed to create check: %v", err)
+	}
+
+	var written []string
+	writeService := &mock.WriteService{
+		WriteF: func(ctx context.Context, orgID, bucketID influxdb.ID, r io.Reader) error {
+			var buf bytes.Buffer
+			if _, err := buf.ReadFrom(r); err != nil {
+				return err
+			}
+			written = append(written, strings.TrimSpace(buf.String()))
+			return nil
+		},
+	}
+	queryService := &qmock.QueryService{
+		QueryF: func(ctx context.Context, req *query.Request) (flux.ResultIterator, error) {
+			t.Fatalf("unexpected flux query")
+			return nil, nil
+		},
+	}
+	e := alerting.NewEngine(svc, queryService, writeService)
+	now := time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC)
+	e.TimeGenerator = mock.TimeGenerator{FakeValue: now}
+
+	if err := e.Run(ctx); err == nil || !strings.Contains(err.Error(), "no data source runs the influxql queries of the checks") {
+		t.Fatalf("expected the influxql check to fail without a data source, got %v", err)
+	}
+	want := `statuses,_check_id=` + prom.ID.String() + `,_check_name=errors,_level=crit,code=500,handler=/api _message="",_value=12 1569888000000000000`
+	if len(written) != 1 || written[0] != want {
+		t.Fatalf("unexpected statuses written\ngot  %v\nwant %s", written, want)
+	}
+
+	var queries []alerting.SeriesQuery
+	e.DataSources[check.QueryTypeInfluxQL] = dataSourceFunc(func(ctx context.Context, q alerting.SeriesQuery) ([]*alerting.Series, error) {
+		queries = append(queries, q)
+		return []*alerting.Series{{
+			Tags:   map[string]string{"host": "a"},
+			Values: []float64{40},
+			Times:  []time.Time{now},
+		}}, nil
+	})
+	written = nil
+	e.TimeGenerator = mock.TimeGenerator{FakeValue: now.Add(time.Minute)}
+	if err := e.Run(ctx); err != nil {
+		t.Fatalf("failed to run engine: %v", err)
+	}
+	if len(queries) != 1 || queries[0].Text != iql.Query.Text || queries[0].OrgID != org.ID || !queries[0].Now.Equal(now.Add(time.Minute)) {
+		t.Errorf("unexpected influxql queries %+v", queries)
+	}
+	sort.Strings(written)
+	if len(written) != 2 || !strings.Contains(written[0], `_check_name=errors`) || !strings.Contains(written[1], `_check_name=mem,_level=warn,host=a`) {
+		t.Errorf("unexpected statuses written %v", written)
+	}
+}
+
+func TestEngine_PreviewCheckStage(t *testing.T) {
+	ctx := context.Background()
+	var queries []string
+	queryService := &qmock.QueryService{
+		QueryF: func(ctx context.Context, req *query.Request) (flux.ResultIterator, error) {
+			queries = append(queries, req.Compiler.(lang.FluxCompiler).Query)
+			return flux.NewSliceResultIterator([]flux.Result{
+				executetest.NewResult([]*executetest.Table{{
+					KeyCols: []string{"host"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+						{Label: "host", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC).UnixNano()), 1.5, "a"},
+					},
+				}}),
+			}), nil
+		},
+	}
+	e := alerting.NewEngine(&kv.Service{}, queryService, &mock.WriteService{})
+	e.TimeGenerator = mock.TimeGenerator{FakeValue: time.Date(2019, 10, 1, 0, 1, 0, 0, time.UTC)}
+
+	c := &check.Threshold{
+		Base: check.Base{
+			OrgID: influxdb.ID(1),
+			Stages: []check.QueryStage{
+				{Name: "fetch", Text: `from(bucket: "telegraf") |> range(start: -1h)`},
+				{Name: "normalize", Text: `fetch |> aggregateWindow(every: 5m, fn: mean)`},
+			},
+		},
+	}
+	p, err := e.PreviewCheckStage(ctx, c, "fetch")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "fetch = from(bucket: \"telegraf\") |> range(start: -1h)\n\nfetch\n\t|> yield(name: \"fetch\")\n"
+	if len(queries) != 1 || queries[0] != want || p.Query != want {
+		t.Errorf("unexpected queries %q", queries)
+	}
+	if len(p.Series) != 1 || p.Series[0].Tags["host"] != "a" || p.Series[0].Values[0] != 1.5 {
+		t.Errorf("unexpected series %+v", p.Series)
+	}
+
+	if _, err := e.PreviewCheckStage(ctx, &check.Heartbeat{}, ""); influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Errorf("expected a heartbeat check not to be previewed, got %v", err)
+	}
+}
+
+func TestEngine_Telemetry(t *testing.T) {
+	ctx := context.Background()
+	svc, user, org := newTestService(t)
+	slack := newSlackServer(t)
+	defer slack.Close()
+
+	edp := &endpoint.Slack{
+		Base: endpoint.Base{Name: "slack", OrgID: org.ID, Status: influxdb.Active},
+		URL:  slack.URL,
+	}
+	if err := svc.CreateNotificationEndpoint(ctx, edp, user.ID); err != nil {
+		t.Fatalf("failed to create notification endpoint: %v", err)
+	}
+	nr := &rule.Slack{
+		Base: rule.Base{
+			Name:            "backup to slack",
+			OrgID:           org.ID,
+			EndpointID:      &edp.ID,
+			AuthorizationID: edp.ID,
+			Status:          influxdb.Active,
+		},
+		MessageTemplate: "${r._check_name} is ${r._level}",
+	}
+	if err := svc.CreateNotificationRule(ctx, nr, user.ID); err != nil {
+		t.Fatalf("failed to create notification rule: %v", err)
+	}
+	c := &check.Heartbeat{
+		Base: check.Base{
+			Name:   "backup",
+			OrgID:  org.ID,
+			Status: influxdb.Active,
+			Every:  influxdb.Duration{Duration: time.Hour},
+		},
+		Level: notification.Critical,
+	}
+	if err := svc.CreateCheck(ctx, c, user.ID); err != nil {
+		t.Fatalf("failed to create check: %v", err)
+	}
+
+	writeService := &mock.WriteService{
+		WriteF: func(ctx context.Context, orgID, bucketID influxdb.ID, r io.Reader) error {
+			return nil
+		},
+	}
+	e := alerting.NewEngine(svc, &qmock.QueryService{}, writeService)
+	tracer := mocktracer.New()
+	e.Tracer = tracer
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(e.PrometheusCollectors()...)
+
+	now := time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC)
+	for _, at := range []time.Duration{0, 2 * time.Hour} {
+		e.TimeGenerator = mock.TimeGenerator{FakeValue: now.Add(at)}
+		if err := e.Run(ctx); err != nil {
+			t.Fatalf("failed to run engine: %v", err)
+		}
+	}
+
+	mfs, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("failed to gather metrics: %v", err)
+	}
+	got := map[string]float64{}
+	for _, mf := range mfs {
+		for _, m := range mf.GetMetric() {
+			name := mf.GetName()
+			for _, l := range m.GetLabel() {
+				name += "{" + l.GetValue() + "}"
+			}
+			switch {
+			case m.GetCounter() != nil:
+				got[name] = m.GetCounter().GetValue()
+			case m.GetHistogram() != nil:
+				got[name] = float64(m.GetHistogram().GetSampleCount())
+			}
+		}
+	}
+	want := map[string]float64{
+		"alerting_engine_checks_run_total{success}":       2,
+		"alerting_engine_check_run_duration_seconds":      2,
+		"alerting_engine_check_lag_seconds":               1,
+		"alerting_engine_statuses_total{OK}":              1,
+		"alerting_engine_statuses_total{CRIT}":            1,
+		"alerting_engine_rule_decisions_total{notified}":  1,
+		"alerting_engine_rule_decisions_total{unmatched}": 1,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected metrics\ngot  %v\nwant %v", got, want)
+	}
+
+	spans := map[string]int{}
+	for _, span := range tracer.FinishedSpans() {
+		spans[span.OperationName]++
+	}
+	if want := map[string]int{"alerting.run": 2, "alerting.check": 2, "alerting.send": 1}; !reflect.DeepEqual(spans, want) {
+		t.Errorf("unexpected spans, got %v, want %v", spans, want)
+	}
+}
+
+func TestEngine_RunLag(t *testing.T) {
+	ctx := context.Background()
+	svc, user, org := newTestService(t)
+
+	hc := &check.Heartbeat{
+		Base: check.Base{
+			Name:   "backup",
+			OrgID:  org.ID,
+			Status: influxdb.Active,
+			Every:  influxdb.Duration{Duration: time.Minute},
+		},
+		Level: notification.Critical,
+	}
+	if err := svc.CreateCheck(ctx, hc, user.ID); err != nil {
+		t.Fatalf("failed to create check: %v", err)
+	}
+	lc := &check.Lag{
+		Base: check.Base{
+			Name:   "scheduler",
+			OrgID:  org.ID,
+			Status: influxdb.Active,
+			Every:  influxdb.Duration{Duration: 10 * time.Minute},
+		},
+		Threshold: influxdb.Duration{Duration: 30 * time.Second},
+		Level:     notification.Critical,
+	}
+	if err := svc.CreateCheck(ctx, lc, user.ID); err != nil {
+		t.Fatalf("failed to create check: %v", err)
+	}
+
+	var written []string
+	writeService := &mock.WriteService{
+		WriteF: func(ctx context.Context, orgID, bucketID influxdb.ID, r io.Reader) error {
+			var buf bytes.Buffer
+			if _, err := buf.ReadFrom(r); err != nil {
+				return err
+			}
+			for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+				if strings.Contains(line, "_check_name=scheduler") {
+					written = append(written, line)
+				}
+			}
+			return nil
+		},
+	}
+	e := alerting.NewEngine(svc, &qmock.QueryService{}, writeService)
+	now := time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC)
+	// the checks aren't sampled the first time they are evaluated, then the
+	// heartbeat check starts 5s, 50s and 2s after it is due.
+	for _, at := range []time.Duration{0, time.Minute + 5*time.Second, 2*time.Minute + 50*time.Second, 10*time.Minute + 2*time.Second} {
+		e.TimeGenerator = mock.TimeGenerator{FakeValue: now.Add(at)}
+		if err := e.Run(ctx); err != nil {
+			t.Fatalf("failed to run engine: %v", err)
+		}
+	}
+
+	want := []string{
+		`statuses,_check_id=` + lc.ID.String() + `,_check_name=scheduler,_level=ok _message="",_value=0 1569888000000000000`,
+		`statuses,_check_id=` + lc.ID.String() + `,_check_name=scheduler,_level=crit _message="",_value=50 1569888602000000000`,
+	}
+	if !reflect.DeepEqual(written, want) {
+		t.Errorf("unexpected statuses of the lag check\ngot  %v\nwant %v", written, want)
+	}
+
+	d := func(d time.Duration) influxdb.Duration { return influxdb.Duration{Duration: d} }
+	got, err := e.FindCheckLag(ctx, hc.ID)
+	if err != nil {
+		t.Fatalf("failed to find the lag of the check: %v", err)
+	}
+	wantLag := &influxdb.CheckLag{
+		CheckID: hc.ID,
+		OrgID:   org.ID,
+		CheckLagStats: influxdb.CheckLagStats{
+			Evaluations: 3,
+			P50:         d(5 * time.Second),
+			P90:         d(50 * time.Second),
+			P99:         d(50 * time.Second),
+			Max:         d(50 * time.Second),
+		},
+		Samples: []influxdb.CheckLagSample{
+			{ScheduledFor: now.Add(10 * time.Minute), StartedAt: now.Add(10*time.Minute + 2*time.Second), Lag: d(2 * time.Second)},
+			{ScheduledFor: now.Add(2 * time.Minute), StartedAt: now.Add(2*time.Minute + 50*time.Second), Lag: d(50 * time.Second)},
+			{ScheduledFor: now.Add(time.Minute), StartedAt: now.Add(time.Minute + 5*time.Second), Lag: d(5 * time.Second)},
+		},
+	}
+	if !reflect.DeepEqual(got, wantLag) {
+		t.Errorf("unexpected lag of the check\ngot  %+v\nwant %+v", got, wantLag)
+	}
+
+	sum, err := e.FindCheckLagSummary(ctx, org.ID)
+	if err != nil {
+		t.Fatalf("failed to find the lag summary of the org: %v", err)
+	}
+	if sum.Checks != 2 || sum.Evaluations != 4 || sum.P50 != d(2*time.Second) || sum.P90 != d(50*time.Second) {
+		t.Errorf("unexpected lag summary %+v", sum)
+	}
+	if len(sum.Laggiest) != 2 || sum.Laggiest[0].CheckID != hc.ID || sum.Laggiest[1].CheckID != lc.ID || sum.Laggiest[0].Samples != nil {
+		t.Errorf("unexpected laggiest checks %+v", sum.Laggiest)
+	}
+
+	if _, err := e.FindCheckLag(ctx, 1); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Errorf("expected the lag of a missing check not to be found, got %v", err)
+	}
+}
+
+func TestEngine_TestNotificationRule(t *testing.T) {
+	ctx := context.Background()
+	svc, user, org := newTestService(t)
+	slack := newSlackServer(t)
+	defer slack.Close()
+
+	edp := &endpoint.Slack{
+		Base: endpoint.Base{Name: "slack", OrgID: org.ID, Status: influxdb.Active},
+		URL:  slack.URL,
+	}
+	if err := svc.CreateNotificationEndpoint(ctx, edp, user.ID); err != nil {
+		t.Fatalf("failed to create notification endpoint: %v", err)
+	}
+	nr := &rule.Slack{
+		Base: rule.Base{
+			Name:            "db to slack",
+			OrgID:           org.ID,
+			EndpointID:      &edp.ID,
+			AuthorizationID: edp.ID,
+			Status:          influxdb.Active,
+			TagRules: []notification.TagRule{
+				{Tag: notification.Tag{Key: "host", Value: "^db"}, Operator: notification.RegexEqual},
+			},
+			StatusRules: []notification.StatusRule{
+				{CurrentLevel: notification.LevelRule{CheckLevel: notification.Critical, Operation: true}},
+			},
+		},
+		MessageTemplate: "${r.host} is ${r._level} ${secret.token}",
+	}
+	if err := svc.CreateNotificationRule(ctx, nr, user.ID); err != nil {
+		t.Fatalf("failed to create notification rule: %v", err)
+	}
+
+	e := alerting.NewEngine(svc, &qmock.QueryService{}, &mock.WriteService{})
+	e.TimeGenerator = mock.TimeGenerator{FakeValue: time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC)}
+
+	// the sample tag sets match the tag rule, and don't have the tag.
+	res, err := e.TestNotificationRule(ctx, nr.ID, influxdb.NotificationRuleTest{})
+	if err != nil {
+		t.Fatalf("failed to test notification rule: %v", err)
+	}
+	if len(res.Cases) != 60 {
+		t.Fatalf("expected 60 cases, got %d", len(res.Cases))
+	}
+	notified := 0
+	for _, c := range res.Cases {
+		want := c.Level == "CRIT" && c.Tags["host"] == "db"
+		if c.Notify != want {
+			t.Errorf("expected the rule to notify %v, got %+v", want, c)
+		}
+		if !c.Notify {
+			if c.Decision != influxdb.RuleUnmatched || c.EndpointID != nil || c.Message != "" {
+				t.Errorf("unexpected unmatched case %+v", c)
+			}
+			continue
+		}
+		notified++
+		if c.Decision != influxdb.RuleNotified || c.EndpointID == nil || *c.EndpointID != edp.ID {
+			t.Errorf("unexpected notified case %+v", c)
+		}
+		// the secrets aren't loaded.
+		if want := "db is CRIT ${secret.token}"; c.Message != want {
+			t.Errorf("unexpected message %q, want %q", c.Message, want)
+		}
+	}
+	if notified != 6 {
+		t.Errorf("expected 6 notified cases, got %d", notified)
+	}
+	if got := slack.Messages(); len(got) != 0 {
+		t.Errorf("expected nothing to be sent, got %q", got)
+	}
+
+	res, err = e.TestNotificationRule(ctx, nr.ID, influxdb.NotificationRuleTest{
+		TagSets: []map[string]string{{"host": "web"}},
+	})
+	if err != nil {
+		t.Fatalf("failed to test notification rule: %v", err)
+	}
+	for _, c := range res.Cases {
+		if c.Notify || c.Reason != "the tags of the status don't match the tag rules" {
+			t.Errorf("expected the tags not to match, got %+v", c)
+		}
+	}
+}
@@ -0,0 +1,504 @@
+package alerting
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/lang"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/check"
+)
+
+// statusesMeasurement is the measurement of the statuses written to the monitoring bucket.
+const statusesMeasurement = "statuses"
+
+// statusesResult is the name of the result of the statuses of the flux
+// scripts of the checks.
+const statusesResult = "statuses"
+
+// run is a single run of the engine, it caches the buckets, rules,
+// partials and silences of the organizations of the checks it runs, and
+// the labels of the silenced checks.
+type run struct {
+	engine   *Engine
+	now      time.Time
+	buckets  map[influxdb.ID]influxdb.ID
+	rules    map[influxdb.ID][]influxdb.NotificationRule
+	partials map[influxdb.ID]map[string]string
+	silences map[influxdb.ID][]*influxdb.Silence
+	settings map[influxdb.ID]*influxdb.AlertingSettings
+	// pauses are the pauses of the alerting of the organizations, nil for
+	// the organizations whose alerting isn't paused.
+	pauses map[influxdb.ID]*influxdb.AlertingPause
+	// checkLabels are the ids of the labels of each check.
+	checkLabels map[influxdb.ID]map[influxdb.ID]bool
+}
+
+// runCheck evaluates a check at a time, writes its statuses and dispatches
+// them at the time of the run.
+func (r *run) runCheck(ctx context.Context, c influxdb.Check, at time.Time) (err error) {
+	span, ctx := r.engine.startSpan(ctx, "alerting.check")
+	span.SetTag("checkID", c.GetID().String())
+	span.SetTag("orgID", c.GetOrgID().String())
+	start := time.Now()
+	defer func() {
+		r.engine.metrics.checkRun(time.Since(start), err)
+		if err != nil {
+			span.SetTag("error", true)
+		}
+		span.Finish()
+	}()
+
+	sts, err := r.at(at).evaluate(ctx, c)
+	if err != nil {
+		return err
+	}
+	_, err = r.handle(ctx, c.GetOrgID(), sts)
+	return err
+}
+
+// handle writes the statuses of a check of an organization and dispatches
+// them, and returns their decision traces. The statuses are given an id when
+// the engine traces them.
+func (r *run) handle(ctx context.Context, orgID influxdb.ID, sts []notification.Status) ([]*influxdb.StatusTrace, error) {
+	if len(sts) == 0 {
+		return nil, nil
+	}
+	if r.engine.StatusTraceService != nil {
+		for i := range sts {
+			sts[i].ID = r.engine.IDGenerator.ID()
+		}
+	}
+	if err := r.writeStatuses(ctx, orgID, sts); err != nil {
+		return nil, err
+	}
+	return r.dispatch(ctx, orgID, sts)
+}
+
+// at returns the run at another time, sharing the caches of r.
+func (r *run) at(now time.Time) *run {
+	if now.Equal(r.now) {
+		return r
+	}
+	cp := *r
+	cp.now = now
+	return &cp
+}
+
+// evaluate returns the statuses of a check at the time of the run. The
+// statuses keep the level of their series until the series is evaluated at
+// a new level for the occurrences of the check.
+func (r *run) evaluate(ctx context.Context, c influxdb.Check) ([]notification.Status, error) {
+	var (
+		sts []notification.Status
+		err error
+	)
+	switch c := c.(type) {
+	case *check.Threshold:
+		sts, err = r.evaluateThreshold(ctx, c)
+	case *check.Deadman:
+		sts, err = r.evaluateDeadman(ctx, c)
+	case *check.SLO:
+		sts, err = r.evaluateSLO(ctx, c)
+	case *check.Heartbeat:
+		sts, err = r.evaluateHeartbeat(ctx, c)
+	case *check.Lag:
+		sts = r.evaluateLag(c)
+	default:
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("check type %s can't be evaluated", c.Type()),
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sts = r.engine.confirmLevels(c, sts)
+	for i := range sts {
+		expandMessage(c, &sts[i])
+	}
+	return sts, nil
+}
+
+// confirmLevels keeps the statuses of a check at the level of their series
+// until the series are evaluated at a new level for the occurrences of the
+// check, and leaves out the statuses of the new series held back.
+func (e *Engine) confirmLevels(c influxdb.Check, sts []notification.Status) []notification.Status {
+	oc, ok := c.(occurrencesCheck)
+	if !ok || oc.GetOccurrences() <= 1 {
+		return sts
+	}
+	confirmed := sts[:0]
+	for _, st := range sts {
+		if e.confirmLevel(&st, oc.GetOccurrences()) {
+			confirmed = append(confirmed, st)
+		}
+	}
+	return confirmed
+}
+
+// expandMessage sets the message of a status from the status message
+// template of its check, if it has one.
+func expandMessage(c influxdb.Check, st *notification.Status) {
+	if mc, ok := c.(interface{ GetStatusMessageTemplate() string }); ok && mc.GetStatusMessageTemplate() != "" {
+		st.Message = notification.ExpandTemplate(mc.GetStatusMessageTemplate(), *st)
+	}
+}
+
+// evaluateThreshold returns a status per series of a threshold check, with the
+// level of the most severe threshold crossed by its latest value, or by all of
+// its values for the thresholds of all values, and ok if none is crossed.
+// A threshold stays crossed while the series is at its level, or a more
+// severe one, and the values hold it until they pass its recover values.
+func (r *run) evaluateThreshold(ctx context.Context, c *check.Threshold) ([]notification.Status, error) {
+	ss, err := r.querySeries(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	sts := make([]notification.Status, 0, len(ss))
+	for _, s := range ss {
+		if len(s.Values) == 0 {
+			continue
+		}
+		last := s.Values[len(s.Values)-1]
+		st := r.newStatus(c, notification.Ok, &last, s.Tags)
+		prev, hasPrev := r.engine.level(st)
+		st.Level = thresholdLevel(c, prev, hasPrev, func(i int, holds bool) bool {
+			t := c.Thresholds[i]
+			match := t.Crossed
+			if holds {
+				match = t.Holds
+			}
+			if !t.GetAllValues() {
+				return match(last)
+			}
+			for _, v := range s.Values {
+				if !match(v) {
+					return false
+				}
+			}
+			return true
+		})
+		sts = append(sts, st)
+	}
+	return sts, nil
+}
+
+// thresholdLevel returns the level of the most severe threshold of a check
+// matched by a series whose previous level is prev, ok if none is. The
+// thresholds at most as severe as the previous level match while they hold,
+// the others once they are crossed; matched returns whether the threshold
+// at index i is crossed, or holds.
+func thresholdLevel(c *check.Threshold, prev notification.CheckLevel, hasPrev bool, matched func(i int, holds bool) bool) notification.CheckLevel {
+	level := notification.Ok
+	for i, t := range c.Thresholds {
+		holds := hasPrev && severity(prev) >= severity(t.GetLevel())
+		if matched(i, holds) && severity(t.GetLevel()) > severity(level) {
+			level = t.GetLevel()
+		}
+	}
+	return level
+}
+
+// evaluateDeadman returns a status per series of a deadman check, with the
+// level of the check if the series has no data since TimeSince seconds, or
+// only zero values when it reports zero, and ok otherwise. The series which
+// never had any data in the range of the query aren't reported.
+func (r *run) evaluateDeadman(ctx context.Context, c *check.Deadman) ([]notification.Status, error) {
+	ss, err := r.querySeries(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	since := time.Duration(c.TimeSince) * time.Second
+	sts := make([]notification.Status, 0, len(ss))
+	for _, s := range ss {
+		if len(s.Times) == 0 {
+			continue
+		}
+		latest := s.Times[0]
+		zero := true
+		for i, t := range s.Times {
+			if t.After(latest) {
+				latest = t
+			}
+			zero = zero && s.Values[i] == 0
+		}
+		level := notification.Ok
+		if r.now.Sub(latest) >= since || (c.ReportZero && zero) {
+			level = c.Level
+		}
+		sts = append(sts, r.newStatus(c, level, nil, s.Tags))
+	}
+	return sts, nil
+}
+
+// evaluateHeartbeat returns the status of a heartbeat check, with the level
+// of the check if it wasn't pinged during its every interval, and ok
+// otherwise. The value of the status is the seconds since the latest ping. A
+// check which was never pinged is late an interval after the engine first
+// evaluated it.
+func (r *run) evaluateHeartbeat(ctx context.Context, c *check.Heartbeat) ([]notification.Status, error) {
+	last, err := r.engine.lastPing(ctx, c.ID, r.now)
+	if err != nil {
+		return nil, err
+	}
+	since := r.now.Sub(last)
+	level := notification.Ok
+	if since > c.Every.Duration {
+		level = c.Level
+	}
+	v := since.Seconds()
+	return []notification.Status{r.newStatus(c, level, &v, nil)}, nil
+}
+
+// evaluateLag returns the status of a lag check, with the level of the check
+// if the p90 lag of the evaluations of every check the engine started during
+// the every interval of the check exceeds its threshold, and ok otherwise.
+// The value of the status is the p90 lag in seconds.
+func (r *run) evaluateLag(c *check.Lag) []notification.Status {
+	lag := r.engine.recentLag(r.engine.TimeGenerator.Now(), c.Every.Duration)
+	level := notification.Ok
+	if lag > c.Threshold.Duration {
+		level = c.Level
+	}
+	v := lag.Seconds()
+	return []notification.Status{r.newStatus(c, level, &v, nil)}
+}
+
+// evaluateSLO returns the statuses of the burn rate alerts of an SLO check,
+// read from the statuses result of the flux script of the check.
+func (r *run) evaluateSLO(ctx context.Context, c *check.SLO) ([]notification.Status, error) {
+	script, err := c.GenerateFlux(nil)
+	if err != nil {
+		return nil, err
+	}
+	var sts []notification.Status
+	err = r.query(ctx, c.OrgID, script, func(res flux.Result) error {
+		if res.Name() != statusesResult {
+			return nil
+		}
+		return res.Tables().Do(func(tbl flux.Table) error {
+			return tbl.Do(func(cr flux.ColReader) error {
+				for i := 0; i < cr.Len(); i++ {
+					st := r.newStatus(c, notification.Unknown, nil, nil)
+					for j, col := range cr.Cols() {
+						switch {
+						case col.Label == "_level" && col.Type == flux.TString:
+							st.Level = notification.ParseCheckLevel(strings.ToUpper(cr.Strings(j).ValueString(i)))
+						case col.Label == "_value":
+							if v, ok := floatValue(cr, j, i); ok {
+								st.Value = &v
+							}
+						case col.Label == "_time" && col.Type == flux.TTime:
+							st.Time = time.Unix(0, cr.Times(j).Value(i)).UTC()
+						case !strings.HasPrefix(col.Label, "_") && col.Type == flux.TString:
+							st.Tags[col.Label] = cr.Strings(j).ValueString(i)
+						}
+					}
+					sts = append(sts, st)
+				}
+				return nil
+			})
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sts, nil
+}
+
+// newStatus returns a status of a check at the time of the run, tagged with
+// the tags of the check and then with tags.
+func (r *run) newStatus(c influxdb.Check, level notification.CheckLevel, value *float64, tags map[string]string) notification.Status {
+	var checkTags []notification.Tag
+	if tc, ok := c.(taggedCheck); ok {
+		checkTags = tc.GetTags()
+	}
+	st := notification.Status{
+		CheckID:   c.GetID(),
+		CheckName: c.GetName(),
+		OrgID:     c.GetOrgID(),
+		Level:     level,
+		Value:     value,
+		Tags:      make(map[string]string, len(checkTags)+len(tags)),
+		Time:      r.now,
+	}
+	if p := checkPriority(c); p != influxdb.CheckPriorityNormal {
+		st.Priority = p
+	}
+	if rc, ok := c.(runbookCheck); ok {
+		st.Runbook = rc.GetRunbookURL()
+	}
+	for _, t := range checkTags {
+		st.Tags[t.Key] = t.Value
+	}
+	for k, v := range tags {
+		st.Tags[k] = v
+	}
+	return st
+}
+
+// query runs a flux query at the time of the run, calling fn for each result.
+func (r *run) query(ctx context.Context, orgID influxdb.ID, text string, fn func(flux.Result) error) error {
+	return runQuery(ctx, r.engine.queryService, orgID, lang.FluxCompiler{
+		Now:   r.now,
+		Query: text,
+	}, fn)
+}
+
+// floatValue returns the numeric value of the column j of the row i.
+func floatValue(cr flux.ColReader, j, i int) (float64, bool) {
+	switch cr.Cols()[j].Type {
+	case flux.TFloat:
+		if vs := cr.Floats(j); vs.IsValid(i) {
+			return vs.Value(i), true
+		}
+	case flux.TInt:
+		if vs := cr.Ints(j); vs.IsValid(i) {
+			return float64(vs.Value(i)), true
+		}
+	case flux.TUInt:
+		if vs := cr.UInts(j); vs.IsValid(i) {
+			return float64(vs.Value(i)), true
+		}
+	}
+	return 0, false
+}
+
+// writeStatuses writes statuses to the monitoring bucket of an organization.
+func (r *run) writeStatuses(ctx context.Context, orgID influxdb.ID, sts []notification.Status) error {
+	bucketID, err := r.findMonitoringBucket(ctx, orgID)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	for _, st := range sts {
+		tags := map[string]string{
+			"_check_id":   st.CheckID.String(),
+			"_check_name": st.CheckName,
+			"_level":      strings.ToLower(st.Level.String()),
+		}
+		for k, v := range st.Tags {
+			tags[k] = v
+		}
+		fields := models.Fields{"_message": st.Message}
+		if st.ID.Valid() {
+			fields["_status_id"] = st.ID.String()
+		}
+		if st.Synthetic {
+			fields["_synthetic"] = true
+		}
+		if st.Value != nil {
+			fields["_value"] = *st.Value
+		}
+		pt, err := models.NewPoint(statusesMeasurement, models.NewTags(tags), fields, st.Time)
+		if err != nil {
+			return err
+		}
+		buf.WriteString(pt.String())
+		buf.WriteByte('\n')
+	}
+	return r.engine.writeService.Write(ctx, orgID, bucketID, &buf)
+}
+
+// findMonitoringBucket returns the status bucket of the alerting settings of
+// an organization, or its monitoring bucket, creating the monitoring bucket
+// the first time a check of the organization needs it.
+func (r *run) findMonitoringBucket(ctx context.Context, orgID influxdb.ID) (influxdb.ID, error) {
+	if id, ok := r.buckets[orgID]; ok {
+		return id, nil
+	}
+	settings, err := r.findSettings(ctx, orgID)
+	if err != nil {
+		return 0, err
+	}
+	name := influxdb.MonitoringBucketName
+	if settings.StatusBucket != "" {
+		name = settings.StatusBucket
+	}
+	b, err := r.engine.store.FindBucket(ctx, influxdb.BucketFilter{OrganizationID: &orgID, Name: &name})
+	if influxdb.ErrorCode(err) == influxdb.ENotFound && name == influxdb.MonitoringBucketName {
+		b = &influxdb.Bucket{
+			OrgID:           orgID,
+			Name:            influxdb.MonitoringBucketName,
+			Description:     "statuses written by the checks of the organization",
+			RetentionPeriod: influxdb.MonitoringBucketRetention,
+		}
+		err = r.engine.store.CreateBucket(ctx, b)
+	}
+	if err != nil {
+		return 0, err
+	}
+	r.buckets[orgID] = b.ID
+	return b.ID, nil
+}
+
+// findSettings returns the alerting settings of an organization, the zero
+// settings without an alerting settings service.
+func (r *run) findSettings(ctx context.Context, orgID influxdb.ID) (*influxdb.AlertingSettings, error) {
+	if s, ok := r.settings[orgID]; ok {
+		return s, nil
+	}
+	s := &influxdb.AlertingSettings{OrgID: orgID}
+	if r.engine.AlertingSettingsService != nil {
+		var err error
+		if s, err = r.engine.AlertingSettingsService.FindAlertingSettings(ctx, orgID); err != nil {
+			return nil, err
+		}
+	}
+	r.settings[orgID] = s
+	return s, nil
+}
+
+// seriesKey identifies the series of a status, statuses of the same check
+// and tag set share the same key.
+func seriesKey(st notification.Status) string {
+	keys := make([]string, 0, len(st.Tags))
+	for k := range st.Tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var sb strings.Builder
+	sb.WriteString(st.CheckID.String())
+	for _, k := range keys {
+		fmt.Fprintf(&sb, ",%s=%s", k, st.Tags[k])
+	}
+	return sb.String()
+}
+
+// seriesCheckID returns the id of the check of a series from its key.
+func seriesCheckID(key string) (influxdb.ID, bool) {
+	if i := strings.IndexByte(key, ','); i >= 0 {
+		key = key[:i]
+	}
+	var id influxdb.ID
+	if err := id.DecodeFromString(key); err != nil {
+		return 0, false
+	}
+	return id, true
+}
+
+// severity orders the levels of statuses from unknown to critical.
+func severity(l notification.CheckLevel) int {
+	switch l {
+	case notification.Ok:
+		return 1
+	case notification.Info:
+		return 2
+	case notification.Warn:
+		return 3
+	case notification.Critical:
+		return 4
+	}
+	return 0
+}
@@ -0,0 +1,23 @@
+package alerting
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.CheckGCNotifier = (*Engine)(nil)
+
+// NotifyCheckGC tells the owner of a check that the garbage collection will
+// delete it after deleteAfter, at the preferred endpoint of the notification
+// preferences of the owner, and how to keep it. It fails when the owner has
+// no active preferred endpoint.
+func (e *Engine) NotifyCheckGC(ctx context.Context, c influxdb.Check, ownerID influxdb.ID, deleteAfter time.Time, excludeLabel string) error {
+	msg := fmt.Sprintf("check %s is unused and will be deleted after %s unless it is modified or activated", c.GetName(), deleteAfter.Format(time.RFC3339))
+	if excludeLabel != "" {
+		msg += fmt.Sprintf(", or labeled %s", excludeLabel)
+	}
+	return e.notifyOwner(ctx, c, ownerID, msg)
+}
@@ -0,0 +1,157 @@
+package alerting
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.CheckLagService = (*Engine)(nil)
+
+// checkLags are the latest lag samples of a check, the oldest first.
+type checkLags struct {
+	orgID   influxdb.ID
+	samples []influxdb.CheckLagSample
+}
+
+// recordLag records that the evaluation of a check due at scheduledFor
+// started at startedAt, keeping the latest MaxCheckLagSamples samples.
+func (e *Engine) recordLag(c influxdb.Check, scheduledFor, startedAt time.Time) {
+	lag := startedAt.Sub(scheduledFor)
+	if lag < 0 {
+		lag = 0
+	}
+	e.metrics.checkLag.Observe(lag.Seconds())
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	l, ok := e.lags[c.GetID()]
+	if !ok {
+		l = &checkLags{}
+		e.lags[c.GetID()] = l
+	}
+	l.orgID = c.GetOrgID()
+	l.samples = append(l.samples, influxdb.CheckLagSample{
+		ScheduledFor: scheduledFor,
+		StartedAt:    startedAt,
+		Lag:          influxdb.Duration{Duration: lag},
+	})
+	if n := len(l.samples); n > influxdb.MaxCheckLagSamples {
+		l.samples = append(l.samples[:0:0], l.samples[n-influxdb.MaxCheckLagSamples:]...)
+	}
+}
+
+// FindCheckLag returns the lag of the latest evaluations of a check by the
+// engine. The lag is kept by the engine evaluating the check, a check
+// evaluated by another engine of the cluster has no samples.
+func (e *Engine) FindCheckLag(ctx context.Context, checkID influxdb.ID) (*influxdb.CheckLag, error) {
+	c, err := e.store.FindCheckByID(ctx, checkID)
+	if err != nil {
+		return nil, err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	cl := &influxdb.CheckLag{
+		CheckID: c.GetID(),
+		OrgID:   c.GetOrgID(),
+		Samples: []influxdb.CheckLagSample{},
+	}
+	l, ok := e.lags[checkID]
+	if !ok {
+		return cl, nil
+	}
+	lags := make([]time.Duration, len(l.samples))
+	for i := range l.samples {
+		s := l.samples[len(l.samples)-1-i]
+		cl.Samples = append(cl.Samples, s)
+		lags[i] = s.Lag.Duration
+	}
+	cl.CheckLagStats = lagStats(lags)
+	return cl, nil
+}
+
+// FindCheckLagSummary returns the lag of the latest evaluations of the
+// checks of an organization by the engine, and its checks of the highest
+// p90 lag.
+func (e *Engine) FindCheckLagSummary(ctx context.Context, orgID influxdb.ID) (*influxdb.CheckLagSummary, error) {
+	cs, _, err := e.store.FindChecks(ctx, influxdb.CheckFilter{OrgID: &orgID})
+	if err != nil {
+		return nil, err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	sum := &influxdb.CheckLagSummary{
+		OrgID:    orgID,
+		Laggiest: []*influxdb.CheckLag{},
+	}
+	var all []time.Duration
+	for _, c := range cs {
+		l, ok := e.lags[c.GetID()]
+		if !ok || len(l.samples) == 0 {
+			continue
+		}
+		lags := make([]time.Duration, len(l.samples))
+		for i, s := range l.samples {
+			lags[i] = s.Lag.Duration
+		}
+		all = append(all, lags...)
+		sum.Checks++
+		sum.Laggiest = append(sum.Laggiest, &influxdb.CheckLag{
+			CheckID:       c.GetID(),
+			OrgID:         c.GetOrgID(),
+			CheckLagStats: lagStats(lags),
+		})
+	}
+	sum.CheckLagStats = lagStats(all)
+	sort.SliceStable(sum.Laggiest, func(i, j int) bool {
+		return sum.Laggiest[i].P90.Duration > sum.Laggiest[j].P90.Duration
+	})
+	if len(sum.Laggiest) > influxdb.MaxCheckLagSummaryChecks {
+		sum.Laggiest = sum.Laggiest[:influxdb.MaxCheckLagSummaryChecks]
+	}
+	return sum, nil
+}
+
+// recentLag returns the p90 lag of the evaluations of every check started
+// during the window before now, zero without evaluations.
+func (e *Engine) recentLag(now time.Time, window time.Duration) time.Duration {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	since := now.Add(-window)
+	var lags []time.Duration
+	for _, l := range e.lags {
+		for _, s := range l.samples {
+			if s.StartedAt.After(since) && !s.StartedAt.After(now) {
+				lags = append(lags, s.Lag.Duration)
+			}
+		}
+	}
+	return lagStats(lags).P90.Duration
+}
+
+// lagStats returns the percentiles of lags, by the nearest rank.
+func lagStats(lags []time.Duration) influxdb.CheckLagStats {
+	st := influxdb.CheckLagStats{Evaluations: len(lags)}
+	if len(lags) == 0 {
+		return st
+	}
+	sorted := append([]time.Duration(nil), lags...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	percentile := func(p float64) influxdb.Duration {
+		i := int(math.Ceil(p*float64(len(sorted)))) - 1
+		if i < 0 {
+			i = 0
+		}
+		return influxdb.Duration{Duration: sorted[i]}
+	}
+	st.P50 = percentile(0.5)
+	st.P90 = percentile(0.9)
+	st.P99 = percentile(0.99)
+	st.Max = influxdb.Duration{Duration: sorted[len(sorted)-1]}
+	return st
+}
@@ -0,0 +1,115 @@
+package alerting
+
+import (
+	"context"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/opentracing/opentracing-go"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// engineMetrics are the metrics of the checks run and the notifications
+// dispatched by an engine.
+type engineMetrics struct {
+	checksRun     *prometheus.CounterVec
+	checkDuration prometheus.Histogram
+	checkLag      prometheus.Histogram
+	statuses      *prometheus.CounterVec
+	decisions     *prometheus.CounterVec
+	truncated     *prometheus.CounterVec
+}
+
+func newEngineMetrics() *engineMetrics {
+	const namespace = "alerting"
+	const subsystem = "engine"
+
+	return &engineMetrics{
+		checksRun: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "checks_run_total",
+			Help:      "Total number of checks run, split out by success or failure.",
+		}, []string{"result"}),
+
+		checkDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "check_run_duration_seconds",
+			Help:      "The duration in seconds of the evaluation of a check and the dispatch of its statuses.",
+			Buckets:   prometheus.ExponentialBuckets(0.005, 4, 8),
+		}),
+
+		checkLag: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "check_lag_seconds",
+			Help:      "The lag in seconds between the time a check was due and the start of its evaluation.",
+			Buckets:   prometheus.ExponentialBuckets(0.1, 4, 8),
+		}),
+
+		statuses: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "statuses_total",
+			Help:      "Total number of statuses dispatched, split out by level.",
+		}, []string{"level"}),
+
+		decisions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "rule_decisions_total",
+			Help:      "Total number of decisions of the notification rules for the statuses, split out by decision.",
+		}, []string{"decision"}),
+
+		truncated: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "messages_truncated_total",
+			Help:      "Total number of notifications whose message was cut to the maximum size of the messages of their endpoint, split out by endpoint type.",
+		}, []string{"endpoint_type"}),
+	}
+}
+
+// PrometheusCollectors satisfies the prom.PrometheusCollector interface.
+func (e *Engine) PrometheusCollectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		e.metrics.checksRun,
+		e.metrics.checkDuration,
+		e.metrics.checkLag,
+		e.metrics.statuses,
+		e.metrics.decisions,
+		e.metrics.truncated,
+	}
+}
+
+func (m *engineMetrics) checkRun(d time.Duration, err error) {
+	result := "success"
+	if err != nil {
+		result = "failure"
+	}
+	m.checksRun.WithLabelValues(result).Inc()
+	m.checkDuration.Observe(d.Seconds())
+}
+
+func (m *engineMetrics) dispatched(t *influxdb.StatusTrace) {
+	m.statuses.WithLabelValues(t.Level).Inc()
+	for _, rt := range t.Rules {
+		m.decisions.WithLabelValues(string(rt.Decision)).Inc()
+	}
+}
+
+// startSpan starts a span of the tracer of the engine, the child of the span
+// of ctx if any.
+func (e *Engine) startSpan(ctx context.Context, operationName string) (opentracing.Span, context.Context) {
+	tracer := e.Tracer
+	if tracer == nil {
+		tracer = opentracing.GlobalTracer()
+	}
+	var opts []opentracing.StartSpanOption
+	if parent := opentracing.SpanFromContext(ctx); parent != nil {
+		opts = append(opts, opentracing.ChildOf(parent.Context()))
+	}
+	span := tracer.StartSpan(operationName, opts...)
+	return span, opentracing.ContextWithSpan(ctx, span)
+}
@@ -0,0 +1,224 @@
+// Package otlp exports the metrics and the spans of the alerting engine to an
+// OpenTelemetry collector, with the OTLP/HTTP protocol encoded in JSON.
+package otlp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultInterval is how often an open exporter exports.
+	DefaultInterval = 10 * time.Second
+	// DefaultServiceName is the service.name of the exported resource.
+	DefaultServiceName = "influxd"
+	// DefaultTimeout bounds each request to the collector.
+	DefaultTimeout = 10 * time.Second
+
+	metricsPath = "/v1/metrics"
+	tracesPath  = "/v1/traces"
+	scopeName   = "github.com/influxdata/influxdb/alerting"
+)
+
+// Config configures the collector the exporter sends to.
+type Config struct {
+	// Endpoint is the url of the OTLP/HTTP receiver of the collector, such as
+	// http://localhost:4318. The metrics are sent to its /v1/metrics path and
+	// the spans to its /v1/traces path.
+	Endpoint string
+	// Headers are sent with every request, such as the api key of a
+	// hosted collector.
+	Headers map[string]string
+	// Interval is how often an open exporter exports, DefaultInterval when zero.
+	Interval time.Duration
+	// ServiceName is the service.name of the exported resource,
+	// DefaultServiceName when empty.
+	ServiceName string
+}
+
+// ParseHeaders parses headers formatted as key=value.
+func ParseHeaders(kvs []string) (map[string]string, error) {
+	headers := make(map[string]string, len(kvs))
+	for _, kv := range kvs {
+		i := strings.IndexByte(kv, '=')
+		if i <= 0 {
+			return nil, fmt.Errorf("otlp header %q must be formatted as key=value", kv)
+		}
+		headers[strings.TrimSpace(kv[:i])] = strings.TrimSpace(kv[i+1:])
+	}
+	return headers, nil
+}
+
+// Exporter pushes the metrics of a gatherer and the spans of its tracer to
+// a collector every interval. The metrics are cumulative since the exporter
+// was created.
+type Exporter struct {
+	Client *http.Client
+	Logger *zap.Logger
+
+	config   Config
+	gatherer prometheus.Gatherer
+	tracer   *Tracer
+	start    time.Time
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewExporter returns an exporter of the metrics of gatherer, such as a
+// registry of the collectors of the alerting engine.
+func NewExporter(config Config, gatherer prometheus.Gatherer) *Exporter {
+	if config.Interval <= 0 {
+		config.Interval = DefaultInterval
+	}
+	if config.ServiceName == "" {
+		config.ServiceName = DefaultServiceName
+	}
+	config.Endpoint = strings.TrimSuffix(config.Endpoint, "/")
+	return &Exporter{
+		Client:   &http.Client{Timeout: DefaultTimeout},
+		Logger:   zap.NewNop(),
+		config:   config,
+		gatherer: gatherer,
+		tracer:   NewTracer(DefaultMaxSpans),
+		start:    time.Now(),
+	}
+}
+
+// Tracer returns the tracer whose finished spans are exported.
+func (e *Exporter) Tracer() *Tracer {
+	return e.tracer
+}
+
+// Open starts exporting every interval, until Close.
+func (e *Exporter) Open(ctx context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.cancel != nil {
+		return fmt.Errorf("otlp exporter is already open")
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	e.cancel = cancel
+	e.done = make(chan struct{})
+	go func(done chan struct{}) {
+		defer close(done)
+		ticker := time.NewTicker(e.config.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := e.Export(ctx); err != nil {
+					e.Logger.Info("failed to export alerting telemetry", zap.Error(err))
+				}
+			}
+		}
+	}(e.done)
+	return nil
+}
+
+// Close stops the exporter, and exports the spans and metrics once more.
+func (e *Exporter) Close() error {
+	e.mu.Lock()
+	cancel, done := e.cancel, e.done
+	e.cancel, e.done = nil, nil
+	e.mu.Unlock()
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+	<-done
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultTimeout)
+	defer cancel()
+	return e.Export(ctx)
+}
+
+// Export sends the current metrics, and the spans finished since the
+// previous export, to the collector.
+func (e *Exporter) Export(ctx context.Context) error {
+	now := time.Now()
+	mfs, err := e.gatherer.Gather()
+	if err != nil {
+		return err
+	}
+	res := e.resource()
+	if metrics := convertMetrics(mfs, e.start, now); len(metrics) > 0 {
+		req := &exportMetricsRequest{
+			ResourceMetrics: []resourceMetrics{{
+				Resource: res,
+				ScopeMetrics: []scopeMetrics{{
+					Scope:   scope{Name: scopeName},
+					Metrics: metrics,
+				}},
+			}},
+		}
+		if err := e.post(ctx, metricsPath, req); err != nil {
+			return err
+		}
+	}
+
+	spans, dropped := e.tracer.drain()
+	if dropped > 0 {
+		e.Logger.Info("dropped alerting spans over the buffer of the otlp exporter", zap.Int("spans", dropped))
+	}
+	if len(spans) > 0 {
+		req := &exportTraceRequest{
+			ResourceSpans: []resourceSpans{{
+				Resource: res,
+				ScopeSpans: []scopeSpans{{
+					Scope: scope{Name: scopeName},
+					Spans: spans,
+				}},
+			}},
+		}
+		if err := e.post(ctx, tracesPath, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *Exporter) resource() resource {
+	return resource{Attributes: []keyValue{stringAttribute("service.name", e.config.ServiceName)}}
+}
+
+func (e *Exporter) post(ctx context.Context, path string, body interface{}) error {
+	octets, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest("POST", e.config.Endpoint+path, bytes.NewReader(octets))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range e.config.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := e.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("otlp collector responded to %s with %s: %s", path, resp.Status, bytes.TrimSpace(msg))
+	}
+	return nil
+}
@@ -0,0 +1,203 @@
+package otlp_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/influxdata/influxdb/alerting/otlp"
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// collector records the requests of the exporter by path.
+type collector struct {
+	mu       sync.Mutex
+	requests map[string][]map[string]interface{}
+	headers  http.Header
+}
+
+func (c *collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+	var req map[string]interface{}
+	if err := json.Unmarshal(body, &req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.requests[r.URL.Path] = append(c.requests[r.URL.Path], req)
+	c.headers = r.Header
+}
+
+func TestExporter_Export(t *testing.T) {
+	c := &collector{requests: make(map[string][]map[string]interface{})}
+	ts := httptest.NewServer(c)
+	defer ts.Close()
+
+	reg := prometheus.NewRegistry()
+	runs := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "checks_run_total", Help: "checks run"}, []string{"result"})
+	duration := prometheus.NewHistogram(prometheus.HistogramOpts{Name: "duration_seconds", Buckets: []float64{1, 2}})
+	reg.MustRegister(runs, duration)
+	runs.WithLabelValues("success").Add(3)
+	for _, v := range []float64{0.5, 1.5, 1.5, 5} {
+		duration.Observe(v)
+	}
+
+	e := otlp.NewExporter(otlp.Config{
+		Endpoint: ts.URL + "/",
+		Headers:  map[string]string{"Api-Key": "secret"},
+	}, reg)
+	tracer := e.Tracer()
+	root := tracer.StartSpan("alerting.run")
+	child := tracer.StartSpan("alerting.check", opentracing.ChildOf(root.Context()))
+	child.SetTag("checkID", "0000000000000001")
+	child.SetTag("error", true)
+	child.Finish()
+	root.Finish()
+
+	if err := e.Export(context.Background()); err != nil {
+		t.Fatalf("failed to export: %v", err)
+	}
+	if c.headers.Get("Api-Key") != "secret" || c.headers.Get("Content-Type") != "application/json" {
+		t.Errorf("expected the headers of the config, got %v", c.headers)
+	}
+
+	if len(c.requests["/v1/metrics"]) != 1 {
+		t.Fatalf("expected a metrics request, got %v", c.requests)
+	}
+	var metrics struct {
+		ResourceMetrics []struct {
+			Resource struct {
+				Attributes []struct {
+					Key   string `json:"key"`
+					Value struct {
+						StringValue string `json:"stringValue"`
+					} `json:"value"`
+				} `json:"attributes"`
+			} `json:"resource"`
+			ScopeMetrics []struct {
+				Metrics []struct {
+					Name string `json:"name"`
+					Sum  *struct {
+						IsMonotonic bool `json:"isMonotonic"`
+						DataPoints  []struct {
+							AsDouble float64 `json:"asDouble"`
+						} `json:"dataPoints"`
+					} `json:"sum"`
+					Histogram *struct {
+						DataPoints []struct {
+							Count          string    `json:"count"`
+							BucketCounts   []string  `json:"bucketCounts"`
+							ExplicitBounds []float64 `json:"explicitBounds"`
+						} `json:"dataPoints"`
+					} `json:"histogram"`
+				} `json:"metrics"`
+			} `json:"scopeMetrics"`
+		} `json:"resourceMetrics"`
+	}
+	remarshal(t, c.requests["/v1/metrics"][0], &metrics)
+	rm := metrics.ResourceMetrics[0]
+	if attr := rm.Resource.Attributes[0]; attr.Key != "service.name" || attr.Value.StringValue != "influxd" {
+		t.Errorf("unexpected resource attribute %+v", attr)
+	}
+	ms := rm.ScopeMetrics[0].Metrics
+	if len(ms) != 2 {
+		t.Fatalf("expected 2 metrics, got %+v", ms)
+	}
+	if ms[0].Name != "checks_run_total" || ms[0].Sum == nil || !ms[0].Sum.IsMonotonic || ms[0].Sum.DataPoints[0].AsDouble != 3 {
+		t.Errorf("expected the counter as a monotonic sum, got %+v", ms[0])
+	}
+	h := ms[1].Histogram
+	if h == nil {
+		t.Fatalf("expected the histogram, got %+v", ms[1])
+	}
+	dp := h.DataPoints[0]
+	if dp.Count != "4" || len(dp.ExplicitBounds) != 2 {
+		t.Errorf("unexpected histogram data point %+v", dp)
+	}
+	if want := []string{"1", "2", "1"}; len(dp.BucketCounts) != 3 || dp.BucketCounts[0] != want[0] || dp.BucketCounts[1] != want[1] || dp.BucketCounts[2] != want[2] {
+		t.Errorf("expected the bucket counts %v, got %v", want, dp.BucketCounts)
+	}
+
+	if len(c.requests["/v1/traces"]) != 1 {
+		t.Fatalf("expected a traces request, got %v", c.requests)
+	}
+	var traces struct {
+		ResourceSpans []struct {
+			ScopeSpans []struct {
+				Spans []struct {
+					TraceID      string `json:"traceId"`
+					SpanID       string `json:"spanId"`
+					ParentSpanID string `json:"parentSpanId"`
+					Name         string `json:"name"`
+					Status       *struct {
+						Code int `json:"code"`
+					} `json:"status"`
+				} `json:"spans"`
+			} `json:"scopeSpans"`
+		} `json:"resourceSpans"`
+	}
+	remarshal(t, c.requests["/v1/traces"][0], &traces)
+	spans := traces.ResourceSpans[0].ScopeSpans[0].Spans
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %+v", spans)
+	}
+	check, run := spans[0], spans[1]
+	if check.Name != "alerting.check" || check.TraceID != run.TraceID || check.ParentSpanID != run.SpanID || len(check.TraceID) != 32 {
+		t.Errorf("expected the check span to be a child of the run span, got %+v and %+v", check, run)
+	}
+	if check.Status == nil || check.Status.Code != 2 || run.ParentSpanID != "" {
+		t.Errorf("expected the failed check span to have an error status, got %+v", check)
+	}
+
+	if err := e.Export(context.Background()); err != nil {
+		t.Fatalf("failed to export: %v", err)
+	}
+	if len(c.requests["/v1/traces"]) != 1 || len(c.requests["/v1/metrics"]) != 2 {
+		t.Errorf("expected the spans to be exported once, got %d trace requests", len(c.requests["/v1/traces"]))
+	}
+}
+
+func TestExporter_ExportError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "quota exceeded", http.StatusTooManyRequests)
+	}))
+	defer ts.Close()
+
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(prometheus.NewGauge(prometheus.GaugeOpts{Name: "queue_length", Help: "queue length"}))
+	e := otlp.NewExporter(otlp.Config{Endpoint: ts.URL}, reg)
+	if err := e.Export(context.Background()); err == nil {
+		t.Errorf("expected the response of the collector to fail the export")
+	}
+}
+
+func TestParseHeaders(t *testing.T) {
+	headers, err := otlp.ParseHeaders([]string{"Api-Key=secret", "X-Scope = alerting=prod"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if headers["Api-Key"] != "secret" || headers["X-Scope"] != "alerting=prod" {
+		t.Errorf("unexpected headers %v", headers)
+	}
+	if _, err := otlp.ParseHeaders([]string{"secret"}); err == nil {
+		t.Errorf("expected a header without a key to be rejected")
+	}
+}
+
+func remarshal(t *testing.T, from, to interface{}) {
+	t.Helper()
+	octets, err := json.Marshal(from)
+	if err == nil {
+		err = json.Unmarshal(octets, to)
+	}
+	if err != nil {
+		t.Fatal(errors.New("failed to decode the request: " + err.Error()))
+	}
+}
@@ -0,0 +1,109 @@
+package otlp
+
+import (
+	"math"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+)
+
+// convertMetrics converts the prometheus metric families to OTLP metrics,
+// cumulative since start. The untyped metrics are exported as gauges.
+func convertMetrics(mfs []*dto.MetricFamily, start, now time.Time) []metric {
+	startNano, nowNano := unixNano(start), unixNano(now)
+	metrics := make([]metric, 0, len(mfs))
+	for _, mf := range mfs {
+		m := metric{
+			Name:        mf.GetName(),
+			Description: mf.GetHelp(),
+		}
+		switch mf.GetType() {
+		case dto.MetricType_COUNTER:
+			m.Sum = &sum{
+				AggregationTemporality: aggregationTemporalityCumulative,
+				IsMonotonic:            true,
+			}
+			for _, pm := range mf.GetMetric() {
+				m.Sum.DataPoints = append(m.Sum.DataPoints, numberDataPoint{
+					Attributes:        labelAttributes(pm.GetLabel()),
+					StartTimeUnixNano: startNano,
+					TimeUnixNano:      nowNano,
+					AsDouble:          pm.GetCounter().GetValue(),
+				})
+			}
+		case dto.MetricType_GAUGE, dto.MetricType_UNTYPED:
+			m.Gauge = &gauge{}
+			for _, pm := range mf.GetMetric() {
+				v := pm.GetGauge().GetValue()
+				if mf.GetType() == dto.MetricType_UNTYPED {
+					v = pm.GetUntyped().GetValue()
+				}
+				m.Gauge.DataPoints = append(m.Gauge.DataPoints, numberDataPoint{
+					Attributes:   labelAttributes(pm.GetLabel()),
+					TimeUnixNano: nowNano,
+					AsDouble:     v,
+				})
+			}
+		case dto.MetricType_HISTOGRAM:
+			m.Histogram = &histogram{AggregationTemporality: aggregationTemporalityCumulative}
+			for _, pm := range mf.GetMetric() {
+				h := pm.GetHistogram()
+				dp := histogramDataPoint{
+					Attributes:        labelAttributes(pm.GetLabel()),
+					StartTimeUnixNano: startNano,
+					TimeUnixNano:      nowNano,
+					Count:             uint64String(h.GetSampleCount()),
+					Sum:               h.GetSampleSum(),
+				}
+				// the prometheus buckets count the samples up to their bound,
+				// the OTLP buckets the samples since the previous bound, with a
+				// last bucket over the last bound.
+				var prev uint64
+				for _, b := range h.GetBucket() {
+					if math.IsInf(b.GetUpperBound(), 1) {
+						continue
+					}
+					dp.ExplicitBounds = append(dp.ExplicitBounds, b.GetUpperBound())
+					dp.BucketCounts = append(dp.BucketCounts, uint64String(b.GetCumulativeCount()-prev))
+					prev = b.GetCumulativeCount()
+				}
+				dp.BucketCounts = append(dp.BucketCounts, uint64String(h.GetSampleCount()-prev))
+				m.Histogram.DataPoints = append(m.Histogram.DataPoints, dp)
+			}
+		case dto.MetricType_SUMMARY:
+			m.Summary = &summary{}
+			for _, pm := range mf.GetMetric() {
+				s := pm.GetSummary()
+				dp := summaryDataPoint{
+					Attributes:        labelAttributes(pm.GetLabel()),
+					StartTimeUnixNano: startNano,
+					TimeUnixNano:      nowNano,
+					Count:             uint64String(s.GetSampleCount()),
+					Sum:               s.GetSampleSum(),
+				}
+				for _, q := range s.GetQuantile() {
+					dp.QuantileValues = append(dp.QuantileValues, quantileValue{
+						Quantile: q.GetQuantile(),
+						Value:    q.GetValue(),
+					})
+				}
+				m.Summary.DataPoints = append(m.Summary.DataPoints, dp)
+			}
+		default:
+			continue
+		}
+		metrics = append(metrics, m)
+	}
+	return metrics
+}
+
+func labelAttributes(labels []*dto.LabelPair) []keyValue {
+	if len(labels) == 0 {
+		return nil
+	}
+	attrs := make([]keyValue, 0, len(labels))
+	for _, l := range labels {
+		attrs = append(attrs, stringAttribute(l.GetName(), l.GetValue()))
+	}
+	return attrs
+}
@@ -0,0 +1,272 @@
+package otlp
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/log"
+)
+
+// DefaultMaxSpans is the number of finished spans a tracer keeps until they
+// are exported, the spans finished over it are dropped.
+const DefaultMaxSpans = 4096
+
+// Tracer implements opentracing.Tracer and keeps the finished spans until the
+// exporter exports them. The span contexts of other tracers are ignored, a
+// span referring to one starts a new trace.
+type Tracer struct {
+	maxSpans int
+
+	mu      sync.Mutex
+	spans   []span
+	dropped int
+}
+
+// NewTracer returns a tracer keeping at most maxSpans finished spans.
+func NewTracer(maxSpans int) *Tracer {
+	return &Tracer{maxSpans: maxSpans}
+}
+
+// StartSpan starts a span, the child of the first referenced span of the tracer.
+func (t *Tracer) StartSpan(operationName string, opts ...opentracing.StartSpanOption) opentracing.Span {
+	startOpts := &opentracing.StartSpanOptions{}
+	for _, opt := range opts {
+		opt.Apply(startOpts)
+	}
+	if startOpts.StartTime.IsZero() {
+		startOpts.StartTime = time.Now()
+	}
+
+	s := &Span{
+		tracer: t,
+		name:   operationName,
+		start:  startOpts.StartTime,
+		tags:   make(map[string]interface{}),
+	}
+	for _, ref := range startOpts.References {
+		if parent, ok := ref.ReferencedContext.(SpanContext); ok {
+			s.ctx.traceID = parent.traceID
+			s.parentID = parent.spanID
+			s.ctx.baggage = parent.baggage
+			break
+		}
+	}
+	if s.parentID == ([8]byte{}) {
+		randomID(s.ctx.traceID[:])
+	}
+	randomID(s.ctx.spanID[:])
+	for k, v := range startOpts.Tags {
+		s.tags[k] = v
+	}
+	return s
+}
+
+// Inject isn't supported, the spans of the engine don't cross processes.
+func (t *Tracer) Inject(sm opentracing.SpanContext, format interface{}, carrier interface{}) error {
+	return opentracing.ErrUnsupportedFormat
+}
+
+// Extract isn't supported, the spans of the engine don't cross processes.
+func (t *Tracer) Extract(format interface{}, carrier interface{}) (opentracing.SpanContext, error) {
+	return nil, opentracing.ErrUnsupportedFormat
+}
+
+func (t *Tracer) finish(s span) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.spans) >= t.maxSpans {
+		t.dropped++
+		return
+	}
+	t.spans = append(t.spans, s)
+}
+
+// drain returns the finished spans and the number of spans dropped since
+// the previous drain.
+func (t *Tracer) drain() ([]span, int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	spans, dropped := t.spans, t.dropped
+	t.spans, t.dropped = nil, 0
+	return spans, dropped
+}
+
+func randomID(b []byte) {
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("otlp: failed to generate a random id: %v", err))
+	}
+}
+
+// Span implements opentracing.Span, all Spans must be created using the Tracer.
+type Span struct {
+	tracer   *Tracer
+	name     string
+	start    time.Time
+	parentID [8]byte
+	ctx      SpanContext
+
+	mu     sync.Mutex
+	tags   map[string]interface{}
+	events []event
+}
+
+// Finish finishes the span now.
+func (s *Span) Finish() {
+	s.FinishWithOptions(opentracing.FinishOptions{})
+}
+
+// FinishWithOptions finishes the span, which is exported at the next export.
+func (s *Span) FinishWithOptions(opts opentracing.FinishOptions) {
+	if opts.FinishTime.IsZero() {
+		opts.FinishTime = time.Now()
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	exported := span{
+		TraceID:           hex.EncodeToString(s.ctx.traceID[:]),
+		SpanID:            hex.EncodeToString(s.ctx.spanID[:]),
+		Name:              s.name,
+		Kind:              spanKindInternal,
+		StartTimeUnixNano: unixNano(s.start),
+		EndTimeUnixNano:   unixNano(opts.FinishTime),
+		Events:            s.events,
+	}
+	if s.parentID != ([8]byte{}) {
+		exported.ParentSpanID = hex.EncodeToString(s.parentID[:])
+	}
+	for k, v := range s.tags {
+		if k == "error" && v == true {
+			exported.Status = &status{Code: statusCodeError}
+		}
+		exported.Attributes = append(exported.Attributes, attribute(k, v))
+	}
+	s.tracer.finish(exported)
+}
+
+// Context returns the context of the span.
+func (s *Span) Context() opentracing.SpanContext {
+	return s.ctx
+}
+
+// SetOperationName renames the span.
+func (s *Span) SetOperationName(operationName string) opentracing.Span {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.name = operationName
+	return s
+}
+
+// SetTag sets an attribute of the span.
+func (s *Span) SetTag(key string, value interface{}) opentracing.Span {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tags[key] = value
+	return s
+}
+
+// LogFields records an event of the span with the fields as attributes.
+func (s *Span) LogFields(fields ...log.Field) {
+	e := event{TimeUnixNano: unixNano(time.Now()), Name: "log"}
+	for _, f := range fields {
+		if f.Key() == "event" {
+			e.Name = fmt.Sprint(f.Value())
+			continue
+		}
+		e.Attributes = append(e.Attributes, attribute(f.Key(), f.Value()))
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, e)
+}
+
+// LogKV records an event of the span with alternating keys and values.
+func (s *Span) LogKV(keyValues ...interface{}) {
+	fields, err := log.InterleavedKVToFields(keyValues...)
+	if err != nil {
+		s.LogFields(log.Error(err), log.String("function", "LogKV"))
+		return
+	}
+	s.LogFields(fields...)
+}
+
+// SetBaggageItem sets a baggage item of the span and its future children.
+func (s *Span) SetBaggageItem(restrictedKey string, value string) opentracing.Span {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	baggage := make(map[string]string, len(s.ctx.baggage)+1)
+	for k, v := range s.ctx.baggage {
+		baggage[k] = v
+	}
+	baggage[restrictedKey] = value
+	s.ctx.baggage = baggage
+	return s
+}
+
+// BaggageItem returns a baggage item of the span.
+func (s *Span) BaggageItem(restrictedKey string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.ctx.baggage[restrictedKey]
+}
+
+// Tracer returns the tracer of the span.
+func (s *Span) Tracer() opentracing.Tracer {
+	return s.tracer
+}
+
+// LogEvent is deprecated, as such it is not implemented.
+func (s *Span) LogEvent(event string) {
+	panic("use of deprecated LogEvent: not implemented")
+}
+
+// LogEventWithPayload is deprecated, as such it is not implemented.
+func (s *Span) LogEventWithPayload(event string, payload interface{}) {
+	panic("use of deprecated LogEventWithPayload: not implemented")
+}
+
+// Log is deprecated, as such it is not implemented.
+func (s *Span) Log(data opentracing.LogData) {
+	panic("use of deprecated Log: not implemented")
+}
+
+// SpanContext implements opentracing.SpanContext, all span contexts must be created using the Tracer.
+type SpanContext struct {
+	traceID [16]byte
+	spanID  [8]byte
+	baggage map[string]string
+}
+
+// ForeachBaggageItem calls handler with each baggage item until it returns false.
+func (c SpanContext) ForeachBaggageItem(handler func(k, v string) bool) {
+	for k, v := range c.baggage {
+		if !handler(k, v) {
+			return
+		}
+	}
+}
+
+// attribute converts a tag or a log field to an attribute of its type.
+func attribute(k string, v interface{}) keyValue {
+	switch v := v.(type) {
+	case string:
+		return stringAttribute(k, v)
+	case bool:
+		return keyValue{Key: k, Value: anyValue{BoolValue: &v}}
+	case int:
+		i := strconv.Itoa(v)
+		return keyValue{Key: k, Value: anyValue{IntValue: &i}}
+	case int64:
+		i := strconv.FormatInt(v, 10)
+		return keyValue{Key: k, Value: anyValue{IntValue: &i}}
+	case float64:
+		return keyValue{Key: k, Value: anyValue{DoubleValue: &v}}
+	default:
+		return stringAttribute(k, fmt.Sprint(v))
+	}
+}
@@ -0,0 +1,148 @@
+// Package page filters, sorts and paginates the checks, notification
+// endpoints and notification rules of a store, so that a filter is written
+// once and every store lists the resources the same way.
+package page
+
+import (
+	"sort"
+
+	"github.com/influxdata/influxdb"
+)
+
+// Iterator calls fn with the resources of a store in the order of the store
+// while fn returns true.
+type Iterator func(fn func(influxdb.Getter) bool) error
+
+// Filter returns whether a resource is listed.
+type Filter func(influxdb.Getter) bool
+
+// ID lists the resource with an ID.
+func ID(id influxdb.ID) Filter {
+	return func(r influxdb.Getter) bool {
+		return r.GetID() == id
+	}
+}
+
+// IDs lists the resources whose ID is in ids.
+func IDs(ids map[influxdb.ID]bool) Filter {
+	return func(r influxdb.Getter) bool {
+		return ids[r.GetID()]
+	}
+}
+
+// OrgID lists the resources of an organization.
+func OrgID(orgID influxdb.ID) Filter {
+	return func(r influxdb.Getter) bool {
+		return r.GetOrgID() == orgID
+	}
+}
+
+// Name lists the resources with a name.
+func Name(name string) Filter {
+	return func(r influxdb.Getter) bool {
+		return r.GetName() == name
+	}
+}
+
+// consts of the fields resources can be sorted by.
+const (
+	SortByName      = "name"
+	SortByCreatedAt = "createdAt"
+	SortByUpdatedAt = "updatedAt"
+)
+
+// Find returns the page of opts of the resources of it matching every
+// filter. The resources are in the order of it unless opts sorts them, or
+// is descending which sorts them by descending ID, and the iteration stops
+// once the page is full. The sorted resources are paginated once all the
+// matching resources are sorted.
+func Find(it Iterator, opts influxdb.FindOptions, filters ...Filter) ([]influxdb.Getter, error) {
+	rs, _, err := find(it, opts, false, filters)
+	return rs, err
+}
+
+// FindCount returns the page of opts of the resources of it matching every
+// filter as Find does, and the total count of the matching resources. The
+// iteration reads every resource to count them.
+func FindCount(it Iterator, opts influxdb.FindOptions, filters ...Filter) ([]influxdb.Getter, int, error) {
+	return find(it, opts, true, filters)
+}
+
+func find(it Iterator, opts influxdb.FindOptions, counted bool, filters []Filter) ([]influxdb.Getter, int, error) {
+	rs := make([]influxdb.Getter, 0)
+	sorted := opts.SortBy != "" || opts.Descending
+	count := 0
+	err := it(func(r influxdb.Getter) bool {
+		for _, f := range filters {
+			if !f(r) {
+				return true
+			}
+		}
+		if sorted || (count >= opts.Offset && (opts.Limit <= 0 || len(rs) < opts.Limit)) {
+			rs = append(rs, r)
+		}
+		count++
+		return sorted || counted || opts.Limit <= 0 || len(rs) < opts.Limit
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	if sorted {
+		Sort(opts, rs)
+		rs = Paginate(rs, opts.Offset, opts.Limit)
+	}
+	return rs, count, nil
+}
+
+// Sort sorts resources by the SortBy field of opts, by ID if it isn't one of
+// the sortable fields. Resources with equal fields are sorted by ID.
+func Sort(opts influxdb.FindOptions, rs []influxdb.Getter) {
+	less := func(i, j int) bool {
+		return rs[i].GetID() < rs[j].GetID()
+	}
+	switch opts.SortBy {
+	case SortByName:
+		less = func(i, j int) bool {
+			if rs[i].GetName() != rs[j].GetName() {
+				return rs[i].GetName() < rs[j].GetName()
+			}
+			return rs[i].GetID() < rs[j].GetID()
+		}
+	case SortByCreatedAt:
+		less = func(i, j int) bool {
+			ti, tj := rs[i].GetCRUDLog().CreatedAt, rs[j].GetCRUDLog().CreatedAt
+			if !ti.Equal(tj) {
+				return ti.Before(tj)
+			}
+			return rs[i].GetID() < rs[j].GetID()
+		}
+	case SortByUpdatedAt:
+		less = func(i, j int) bool {
+			ti, tj := rs[i].GetCRUDLog().UpdatedAt, rs[j].GetCRUDLog().UpdatedAt
+			if !ti.Equal(tj) {
+				return ti.Before(tj)
+			}
+			return rs[i].GetID() < rs[j].GetID()
+		}
+	}
+
+	sort.Slice(rs, func(i, j int) bool {
+		if opts.Descending {
+			return less(j, i)
+		}
+		return less(i, j)
+	})
+}
+
+// Paginate returns the page of rs starting at offset with at most limit
+// resources.
+func Paginate(rs []influxdb.Getter, offset, limit int) []influxdb.Getter {
+	if offset >= len(rs) {
+		return []influxdb.Getter{}
+	}
+	rs = rs[offset:]
+	if limit > 0 && limit < len(rs) {
+		rs = rs[:limit]
+	}
+	return rs
+}
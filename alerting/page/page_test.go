@@ -0,0 +1,241 @@
+package page_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/alerting/page"
+	"github.com/influxdata/influxdb/notification/check"
+)
+
+func newCheck(id, orgID influxdb.ID, name string, created, updated int) *check.Deadman {
+	return &check.Deadman{
+		Base: check.Base{
+			ID:    id,
+			OrgID: orgID,
+			Name:  name,
+			CRUDLog: influxdb.CRUDLog{
+				CreatedAt: time.Unix(int64(created), 0),
+				UpdatedAt: time.Unix(int64(updated), 0),
+			},
+		},
+	}
+}
+
+// store is a store of checks in the order of their IDs which counts the
+// checks it iterates over.
+type store struct {
+	rs   []influxdb.Getter
+	read int
+}
+
+func newStore() *store {
+	return &store{
+		rs: []influxdb.Getter{
+			newCheck(1, 10, "cpu", 3, 4),
+			newCheck(2, 10, "mem", 1, 6),
+			newCheck(3, 20, "disk", 2, 5),
+			newCheck(4, 10, "net", 4, 3),
+			newCheck(5, 20, "cpu", 5, 2),
+		},
+	}
+}
+
+func (s *store) iterate(fn func(influxdb.Getter) bool) error {
+	for _, r := range s.rs {
+		s.read++
+		if !fn(r) {
+			return nil
+		}
+	}
+	return nil
+}
+
+func ids(rs []influxdb.Getter) []influxdb.ID {
+	ids := make([]influxdb.ID, 0, len(rs))
+	for _, r := range rs {
+		ids = append(ids, r.GetID())
+	}
+	return ids
+}
+
+func TestFind(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    influxdb.FindOptions
+		filters []page.Filter
+		ids     []influxdb.ID
+		read    int
+	}{
+		{
+			name: "all resources in the order of the store",
+			ids:  []influxdb.ID{1, 2, 3, 4, 5},
+			read: 5,
+		},
+		{
+			name:    "filter by id",
+			filters: []page.Filter{page.ID(3)},
+			ids:     []influxdb.ID{3},
+			read:    5,
+		},
+		{
+			name:    "filter by ids",
+			filters: []page.Filter{page.IDs(map[influxdb.ID]bool{2: true, 5: true, 9: true})},
+			ids:     []influxdb.ID{2, 5},
+			read:    5,
+		},
+		{
+			name:    "filter by org id and name",
+			filters: []page.Filter{page.OrgID(20), page.Name("cpu")},
+			ids:     []influxdb.ID{5},
+			read:    5,
+		},
+		{
+			name:    "offset and limit stop once the page is full",
+			opts:    influxdb.FindOptions{Offset: 1, Limit: 2},
+			filters: []page.Filter{page.OrgID(10)},
+			ids:     []influxdb.ID{2, 4},
+			read:    4,
+		},
+		{
+			name: "offset past the resources",
+			opts: influxdb.FindOptions{Offset: 7},
+			ids:  []influxdb.ID{},
+			read: 5,
+		},
+		{
+			name: "sort by name",
+			opts: influxdb.FindOptions{SortBy: page.SortByName},
+			ids:  []influxdb.ID{1, 5, 3, 2, 4},
+			read: 5,
+		},
+		{
+			name: "sort by created at",
+			opts: influxdb.FindOptions{SortBy: page.SortByCreatedAt},
+			ids:  []influxdb.ID{2, 3, 1, 4, 5},
+			read: 5,
+		},
+		{
+			name: "sort by updated at descending",
+			opts: influxdb.FindOptions{SortBy: page.SortByUpdatedAt, Descending: true},
+			ids:  []influxdb.ID{2, 3, 1, 4, 5},
+			read: 5,
+		},
+		{
+			name: "descending sorts by id",
+			opts: influxdb.FindOptions{Descending: true, Limit: 2},
+			ids:  []influxdb.ID{5, 4},
+			read: 5,
+		},
+		{
+			name:    "sorted page is paginated after sorting",
+			opts:    influxdb.FindOptions{SortBy: page.SortByName, Offset: 1, Limit: 2},
+			filters: []page.Filter{page.OrgID(10)},
+			ids:     []influxdb.ID{2, 4},
+			read:    5,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newStore()
+			rs, err := page.Find(s.iterate, tt.opts, tt.filters...)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := ids(rs); !reflect.DeepEqual(got, tt.ids) {
+				t.Errorf("expected resources %v, got %v", tt.ids, got)
+			}
+			if s.read != tt.read {
+				t.Errorf("expected %d resources to be read, got %d", tt.read, s.read)
+			}
+		})
+	}
+}
+
+func TestFindCount(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    influxdb.FindOptions
+		filters []page.Filter
+		ids     []influxdb.ID
+		count   int
+	}{
+		{
+			name:  "all resources",
+			ids:   []influxdb.ID{1, 2, 3, 4, 5},
+			count: 5,
+		},
+		{
+			name:    "offset and limit read every resource",
+			opts:    influxdb.FindOptions{Offset: 1, Limit: 1},
+			filters: []page.Filter{page.OrgID(10)},
+			ids:     []influxdb.ID{2},
+			count:   3,
+		},
+		{
+			name:  "offset past the resources",
+			opts:  influxdb.FindOptions{Offset: 7, Limit: 2},
+			ids:   []influxdb.ID{},
+			count: 5,
+		},
+		{
+			name:    "sorted page",
+			opts:    influxdb.FindOptions{SortBy: page.SortByName, Limit: 2},
+			filters: []page.Filter{page.Name("cpu")},
+			ids:     []influxdb.ID{1, 5},
+			count:   2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newStore()
+			rs, n, err := page.FindCount(s.iterate, tt.opts, tt.filters...)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := ids(rs); !reflect.DeepEqual(got, tt.ids) {
+				t.Errorf("expected resources %v, got %v", tt.ids, got)
+			}
+			if n != tt.count {
+				t.Errorf("expected a count of %d, got %d", tt.count, n)
+			}
+			if s.read != len(s.rs) {
+				t.Errorf("expected every resource to be read, got %d", s.read)
+			}
+		})
+	}
+}
+
+func TestFind_error(t *testing.T) {
+	want := errors.New("store unavailable")
+	it := func(fn func(influxdb.Getter) bool) error {
+		return want
+	}
+	if _, err := page.Find(it, influxdb.FindOptions{}); err != want {
+		t.Fatalf("expected error %v, got %v", want, err)
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	rs := newStore().rs
+	tests := []struct {
+		name          string
+		offset, limit int
+		ids           []influxdb.ID
+	}{
+		{name: "no limit", offset: 2, ids: []influxdb.ID{3, 4, 5}},
+		{name: "limit", offset: 1, limit: 2, ids: []influxdb.ID{2, 3}},
+		{name: "limit past the end", offset: 3, limit: 5, ids: []influxdb.ID{4, 5}},
+		{name: "offset past the end", offset: 5, ids: []influxdb.ID{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ids(page.Paginate(rs, tt.offset, tt.limit)); !reflect.DeepEqual(got, tt.ids) {
+				t.Errorf("expected resources %v, got %v", tt.ids, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package alerting
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/sender"
+)
+
+var _ influxdb.CheckPauseNotifier = (*Engine)(nil)
+
+// pausedCheck is a check which may have been paused automatically.
+type pausedCheck interface {
+	GetPauseReason() string
+}
+
+// NotifyCheckPaused tells the owner of a check that it was paused
+// automatically, at the preferred endpoint of the notification preferences
+// of the owner. It fails when the owner has no active preferred endpoint.
+func (e *Engine) NotifyCheckPaused(ctx context.Context, c influxdb.Check, ownerID influxdb.ID) error {
+	msg := fmt.Sprintf("check %s was paused", c.GetName())
+	if pc, ok := c.(pausedCheck); ok && pc.GetPauseReason() != "" {
+		msg += ": " + pc.GetPauseReason()
+	}
+	return e.notifyOwner(ctx, c, ownerID, msg)
+}
+
+// notifyOwner sends a message about a check to the preferred endpoint of
+// the notification preferences of an owner of the check.
+func (e *Engine) notifyOwner(ctx context.Context, c influxdb.Check, ownerID influxdb.ID, msg string) error {
+	if e.Preferences == nil || e.Preferences.PreferencesService == nil {
+		return &influxdb.Error{
+			Code: influxdb.ENotFound,
+			Msg:  "the notification preferences of the users aren't enabled",
+		}
+	}
+	prefs, err := e.Preferences.PreferencesService.FindNotificationPreferences(ctx, ownerID)
+	if err != nil {
+		return err
+	}
+	if prefs.PreferredEndpointID == nil {
+		return &influxdb.Error{
+			Code: influxdb.ENotFound,
+			Msg:  "the owner of the check has no preferred notification endpoint",
+		}
+	}
+	edp, err := e.store.FindNotificationEndpointByID(ctx, *prefs.PreferredEndpointID)
+	if err != nil {
+		return err
+	}
+	if edp.GetStatus() != influxdb.Active {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "the preferred notification endpoint of the owner of the check is inactive",
+		}
+	}
+
+	return e.send(ctx, &sender.Notification{
+		Status: notification.Status{
+			CheckID:   c.GetID(),
+			CheckName: c.GetName(),
+			OrgID:     c.GetOrgID(),
+			Level:     notification.Warn,
+			Time:      e.TimeGenerator.Now(),
+		},
+		Endpoint: edp,
+		Message:  msg,
+	})
+}
+
+// findPause returns the pause of the alerting of an organization, nil if
+// its alerting isn't paused or without an alerting pause service.
+func (r *run) findPause(ctx context.Context, orgID influxdb.ID) (*influxdb.AlertingPause, error) {
+	if p, ok := r.pauses[orgID]; ok {
+		return p, nil
+	}
+	var p *influxdb.AlertingPause
+	if r.engine.AlertingPauseService != nil {
+		var err error
+		p, err = r.engine.AlertingPauseService.FindAlertingPause(ctx, orgID)
+		if influxdb.ErrorCode(err) == influxdb.ENotFound {
+			p, err = nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	r.pauses[orgID] = p
+	return p, nil
+}
+
+// pausedRule is the trace of a rule muted by the pause of the alerting of
+// its organization.
+func pausedRule(nr influxdb.NotificationRule, p *influxdb.AlertingPause) influxdb.RuleTrace {
+	reason := fmt.Sprintf("the alerting of the organization is paused since %s", p.PausedAt.Format(time.RFC3339))
+	if p.Reason != "" {
+		reason += ": " + p.Reason
+	}
+	return influxdb.RuleTrace{
+		RuleID:   nr.GetID(),
+		RuleName: nr.GetName(),
+		Decision: influxdb.RuleMuted,
+		Reason:   reason,
+	}
+}
@@ -0,0 +1,66 @@
+package alerting
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.CheckPreviewService = (*Engine)(nil)
+
+// stagedCheck is a check whose query can be previewed stage by stage.
+type stagedCheck interface {
+	queriedCheck
+	StageQuery(stage string) (influxdb.DashboardQuery, error)
+}
+
+// PreviewCheckStage returns the data of a stage of the query of a check, as
+// the check would query it at the time of the engine, or of its whole query
+// if stage is empty. The check needn't be created, nor valid besides its query.
+func (e *Engine) PreviewCheckStage(ctx context.Context, c influxdb.Check, stage string) (*influxdb.CheckStagePreview, error) {
+	sc, ok := c.(stagedCheck)
+	if !ok {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("check type %s has no query", c.Type()),
+		}
+	}
+	q, err := sc.StageQuery(stage)
+	if err != nil {
+		return nil, err
+	}
+	if q.Text == "" {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "Check Query can't be empty",
+		}
+	}
+	ds, err := e.dataSource(sc.GetQueryType())
+	if err != nil {
+		return nil, err
+	}
+	ss, err := ds.QuerySeries(ctx, SeriesQuery{
+		OrgID:     c.GetOrgID(),
+		Text:      q.Text,
+		ScrapeURL: sc.GetScrapeURL(),
+		Now:       e.TimeGenerator.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	p := &influxdb.CheckStagePreview{
+		Stage:  stage,
+		Query:  q.Text,
+		Series: make([]influxdb.CheckSeries, 0, len(ss)),
+	}
+	for _, s := range ss {
+		p.Series = append(p.Series, influxdb.CheckSeries{
+			Tags:   s.Tags,
+			Values: s.Values,
+			Times:  s.Times,
+		})
+	}
+	return p, nil
+}
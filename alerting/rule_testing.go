@@ -0,0 +1,223 @@
+package alerting
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/sender"
+)
+
+var _ influxdb.NotificationRuleTestService = (*Engine)(nil)
+
+// testCheckName is the name of the check of the synthetic statuses of the
+// notification rule tests.
+const testCheckName = "test"
+
+// testIncidentDuration is the duration of the incidents ended by the
+// synthetic statuses recovering to ok.
+const testIncidentDuration = 10 * time.Minute
+
+// testLevels are the levels of the synthetic statuses, from the least to
+// the most severe.
+var testLevels = []notification.CheckLevel{
+	notification.Unknown,
+	notification.Ok,
+	notification.Info,
+	notification.Warn,
+	notification.Critical,
+}
+
+// TestNotificationRule runs a synthetic status at every level, after no level
+// and after every level of its series, for every tag set of the test through
+// the matchers of a notification rule at the time of the engine. It reports
+// whether the rule would notify each status, to which endpoint and with which
+// message, without sending anything. The secrets the message template of the
+// rule references aren't loaded. The pauses, silences, quiet hours,
+// preferences, budgets, dedup window and limits, which depend on the history
+// of the alerting, aren't applied.
+func (e *Engine) TestNotificationRule(ctx context.Context, ruleID influxdb.ID, t influxdb.NotificationRuleTest) (*influxdb.NotificationRuleTestResult, error) {
+	if err := t.Valid(); err != nil {
+		return nil, err
+	}
+	nr, err := e.store.FindNotificationRuleByID(ctx, ruleID)
+	if err != nil {
+		return nil, err
+	}
+	tagSets := t.TagSets
+	if len(tagSets) == 0 {
+		tagSets = sampleTagSets(nr)
+	}
+
+	r := e.newRun(e.TimeGenerator.Now())
+	res := &influxdb.NotificationRuleTestResult{
+		RuleID: nr.GetID(),
+		Time:   r.now,
+		Cases:  make([]influxdb.NotificationRuleTestCase, 0, len(tagSets)*len(testLevels)*(len(testLevels)+1)),
+	}
+	for _, tags := range tagSets {
+		for i := -1; i < len(testLevels); i++ {
+			for _, level := range testLevels {
+				var prev notification.CheckLevel
+				if i >= 0 {
+					prev = testLevels[i]
+				}
+				c, err := r.test(ctx, nr, tags, level, prev, i >= 0)
+				if err != nil {
+					return nil, err
+				}
+				res.Cases = append(res.Cases, c)
+			}
+		}
+	}
+	return res, nil
+}
+
+// test decides what a rule would do with a synthetic status, given the
+// previous level of its series.
+func (r *run) test(ctx context.Context, nr influxdb.NotificationRule, tags map[string]string, level, prev notification.CheckLevel, hasPrev bool) (influxdb.NotificationRuleTestCase, error) {
+	st := notification.Status{
+		CheckName: testCheckName,
+		OrgID:     nr.GetOrgID(),
+		Level:     level,
+		Message:   fmt.Sprintf("%s is %s", testCheckName, level),
+		Tags:      tags,
+		Time:      r.now,
+	}
+	if hasPrev && prev != notification.Ok && level == notification.Ok {
+		st.IncidentDuration = testIncidentDuration
+	}
+	c := influxdb.NotificationRuleTestCase{
+		Level: level.String(),
+		Tags:  tags,
+	}
+	if hasPrev {
+		c.PreviousLevel = prev.String()
+	}
+
+	rt, endpointID := match(st, statusTags(st), prev, hasPrev, nr, r.now)
+	c.Decision, c.Reason = rt.Decision, rt.Reason
+	if endpointID == nil {
+		return c, nil
+	}
+	edp, err := r.engine.store.FindNotificationEndpointByID(ctx, *endpointID)
+	if err != nil {
+		return c, err
+	}
+	if edp.GetStatus() != influxdb.Active {
+		c.Decision, c.Reason = influxdb.RuleMuted, "the notification endpoint is inactive"
+		return c, nil
+	}
+
+	n := &sender.Notification{
+		Status:   st,
+		Rule:     nr,
+		Endpoint: edp,
+	}
+	if tr, ok := nr.(templatedRule); ok && tr.GetMessageTemplate() != "" && !notifiesRecovery(nr, st) {
+		partials, err := r.findPartials(ctx, nr.GetOrgID())
+		if err != nil {
+			return c, err
+		}
+		if n.Message, err = notification.RenderMessage(tr.GetMessageTemplate(), partials, st); err != nil {
+			return c, err
+		}
+	}
+	truncateMessages(r.engine.SenderConfig.MessageLimits, n, &rt)
+
+	id := edp.GetID()
+	c.Notify, c.Decision, c.Reason = true, influxdb.RuleNotified, rt.Reason
+	c.EndpointID = &id
+	c.Message = n.Text()
+	return c, nil
+}
+
+// sampleTagSets returns tag sets exercising the tag rules of a rule: one
+// satisfying every tag rule, one breaking each tag rule in turn, and no
+// tags. The regular expressions of the tag rules are satisfied by their
+// literal prefix, the tag rules whose prefix doesn't match are left out.
+func sampleTagSets(nr influxdb.NotificationRule) []map[string]string {
+	var trs []notification.TagRule
+	if rr, ok := nr.(routedRule); ok {
+		trs = rr.GetTagRules()
+	}
+
+	matching := make(map[string]string)
+	breaks := make([]map[string]string, 0, len(trs))
+	for _, tr := range trs {
+		v, ok := sampleTagValue(tr)
+		if !ok {
+			continue
+		}
+		switch tr.Operator {
+		case notification.Equal, notification.RegexEqual:
+			matching[tr.Key] = v
+			breaks = append(breaks, map[string]string{tr.Key: ""})
+		case notification.NotEqual, notification.NotRegexEqual:
+			breaks = append(breaks, map[string]string{tr.Key: v})
+		}
+	}
+
+	sets := []map[string]string{matching}
+	for _, b := range breaks {
+		tags := make(map[string]string, len(matching)+1)
+		for k, v := range matching {
+			tags[k] = v
+		}
+		for k, v := range b {
+			if v == "" {
+				delete(tags, k)
+			} else {
+				tags[k] = v
+			}
+		}
+		sets = append(sets, tags)
+	}
+	sets = append(sets, map[string]string{})
+
+	seen := make(map[string]bool, len(sets))
+	uniq := sets[:0]
+	for _, tags := range sets {
+		key := tagSetKey(tags)
+		if !seen[key] {
+			seen[key] = true
+			uniq = append(uniq, tags)
+		}
+	}
+	return uniq
+}
+
+// sampleTagValue returns a value satisfying the value of a tag rule, its
+// value or the literal prefix of its regular expression, past its ^ anchor.
+func sampleTagValue(tr notification.TagRule) (string, bool) {
+	switch tr.Operator {
+	case notification.Equal, notification.NotEqual:
+		return tr.Value, true
+	}
+	re, err := regexp.Compile(tr.Value)
+	if err != nil {
+		return "", false
+	}
+	unanchored, err := regexp.Compile(strings.TrimPrefix(tr.Value, "^"))
+	if err != nil {
+		return "", false
+	}
+	prefix, _ := unanchored.LiteralPrefix()
+	if prefix == "" || !re.MatchString(prefix) {
+		return "", false
+	}
+	return prefix, true
+}
+
+// tagSetKey returns a key identifying a tag set.
+func tagSetKey(tags map[string]string) string {
+	var sb strings.Builder
+	for _, t := range statusTags(notification.Status{Tags: tags}) {
+		fmt.Fprintf(&sb, "%q=%q,", t.Key, t.Value)
+	}
+	return sb.String()
+}
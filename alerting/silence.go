@@ -0,0 +1,87 @@
+package alerting
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification"
+)
+
+// findSilence returns the silence muting a status at the time of the run,
+// the first active silence of the organization of the status targeting a
+// label of its check, or nil. The silences of each organization and the
+// labels of each check are looked up once per run.
+func (r *run) findSilence(ctx context.Context, st notification.Status) (*influxdb.Silence, error) {
+	if r.engine.SilenceService == nil || r.engine.LabelService == nil || !st.CheckID.Valid() {
+		return nil, nil
+	}
+	sls, err := r.findSilences(ctx, st.OrgID)
+	if err != nil {
+		return nil, err
+	}
+	var active []*influxdb.Silence
+	for _, s := range sls {
+		if s.Active(r.now) {
+			active = append(active, s)
+		}
+	}
+	// the checks aren't looked up while no silence is active.
+	if len(active) == 0 {
+		return nil, nil
+	}
+	labels, err := r.findCheckLabels(ctx, st.CheckID)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range active {
+		if labels[s.LabelID] {
+			return s, nil
+		}
+	}
+	return nil, nil
+}
+
+// findSilences returns the silences of an organization.
+func (r *run) findSilences(ctx context.Context, orgID influxdb.ID) ([]*influxdb.Silence, error) {
+	if sls, ok := r.silences[orgID]; ok {
+		return sls, nil
+	}
+	sls, _, err := r.engine.SilenceService.FindSilences(ctx, influxdb.SilenceFilter{OrgID: &orgID})
+	if err != nil {
+		return nil, err
+	}
+	r.silences[orgID] = sls
+	return sls, nil
+}
+
+// findCheckLabels returns the ids of the labels of a check.
+func (r *run) findCheckLabels(ctx context.Context, checkID influxdb.ID) (map[influxdb.ID]bool, error) {
+	if labels, ok := r.checkLabels[checkID]; ok {
+		return labels, nil
+	}
+	ls, err := r.engine.LabelService.FindResourceLabels(ctx, influxdb.LabelMappingFilter{
+		ResourceID:   checkID,
+		ResourceType: influxdb.ChecksResourceType,
+	})
+	if err != nil {
+		return nil, err
+	}
+	labels := make(map[influxdb.ID]bool, len(ls))
+	for _, l := range ls {
+		labels[l.ID] = true
+	}
+	r.checkLabels[checkID] = labels
+	return labels, nil
+}
+
+// silencedRule returns the trace of a rule muted by a silence.
+func silencedRule(nr influxdb.NotificationRule, s *influxdb.Silence) influxdb.RuleTrace {
+	return influxdb.RuleTrace{
+		RuleID:   nr.GetID(),
+		RuleName: nr.GetName(),
+		Decision: influxdb.RuleMuted,
+		Reason:   fmt.Sprintf("the check is silenced by the silence %s of its label until %s", s.ID, s.EndsAt.Format(time.RFC3339)),
+	}
+}
@@ -0,0 +1,209 @@
+package alerting
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/check"
+	"github.com/influxdata/influxdb/task/backend"
+	"go.uber.org/zap"
+)
+
+var _ backend.RunSummarizer = (*Engine)(nil)
+
+// SummarizeRun returns the summary of a run of the task of a check. The
+// statuses the run yields as "statuses" are written and dispatched like the
+// statuses of the checks the engine evaluates, at the time of the engine, and
+// the summary counts them by level and counts what the rules did with them.
+// The lag of a scheduled run, from when it was due until it started, is
+// recorded as the lag of the check.
+func (e *Engine) SummarizeRun(t *influxdb.Task, qr backend.QueuedRun) backend.RunSummary {
+	tr := &taskRun{engine: e, task: t}
+	if qr.RequestedAt == 0 && qr.DueAt > 0 {
+		tr.dueAt = time.Unix(qr.DueAt, 0).UTC()
+		tr.startedAt = e.TimeGenerator.Now()
+	}
+	return tr
+}
+
+// taskRun reads the statuses of a run of the task of a check.
+type taskRun struct {
+	engine *Engine
+	task   *influxdb.Task
+	// dueAt and startedAt are when the run was due and when it started, zero
+	// for the runs requested manually.
+	dueAt     time.Time
+	startedAt time.Time
+
+	mu   sync.Mutex
+	rows []statusRow
+}
+
+// statusRow is a status yielded by the task of a check.
+type statusRow struct {
+	checkID influxdb.ID
+	level   notification.CheckLevel
+	value   *float64
+	message string
+	time    time.Time
+	tags    map[string]string
+	// crossed and holds are whether each threshold of a threshold check
+	// with recover values is crossed, and holds.
+	crossed map[int]bool
+	holds   map[int]bool
+}
+
+// Read reads the statuses of a result of the run, the other results are
+// exhausted.
+func (tr *taskRun) Read(res flux.Result) error {
+	statuses := res.Name() == statusesResult
+	return res.Tables().Do(func(tbl flux.Table) error {
+		return tbl.Do(func(cr flux.ColReader) error {
+			if !statuses {
+				return nil
+			}
+			rows := make([]statusRow, 0, cr.Len())
+			for i := 0; i < cr.Len(); i++ {
+				row := statusRow{
+					tags:    make(map[string]string),
+					crossed: make(map[int]bool),
+					holds:   make(map[int]bool),
+				}
+				for j, col := range cr.Cols() {
+					switch {
+					case col.Label == "_check_id" && col.Type == flux.TString:
+						if err := row.checkID.DecodeFromString(cr.Strings(j).ValueString(i)); err != nil {
+							return err
+						}
+					case col.Label == "_level" && col.Type == flux.TString:
+						row.level = notification.ParseCheckLevel(strings.ToUpper(cr.Strings(j).ValueString(i)))
+					case col.Label == "_message" && col.Type == flux.TString:
+						row.message = cr.Strings(j).ValueString(i)
+					case col.Label == "_value":
+						if v, ok := floatValue(cr, j, i); ok {
+							row.value = &v
+						}
+					case col.Label == "_time" && col.Type == flux.TTime:
+						row.time = time.Unix(0, cr.Times(j).Value(i)).UTC()
+					case strings.HasPrefix(col.Label, "_crossed"):
+						if n, err := strconv.Atoi(strings.TrimPrefix(col.Label, "_crossed")); err == nil {
+							v, _ := floatValue(cr, j, i)
+							row.crossed[n] = v > 0
+						}
+					case strings.HasPrefix(col.Label, "_holds"):
+						if n, err := strconv.Atoi(strings.TrimPrefix(col.Label, "_holds")); err == nil {
+							v, _ := floatValue(cr, j, i)
+							row.holds[n] = v > 0
+						}
+					case !strings.HasPrefix(col.Label, "_") && col.Type == flux.TString:
+						row.tags[col.Label] = cr.Strings(j).ValueString(i)
+					}
+				}
+				rows = append(rows, row)
+			}
+			tr.mu.Lock()
+			defer tr.mu.Unlock()
+			tr.rows = append(tr.rows, rows...)
+			return nil
+		})
+	})
+}
+
+// Summarize writes and dispatches the statuses of the run, and returns the
+// log message of their summary. Like the runs of the engine, it first sends
+// the deferred notifications whose quiet hours ended. The statuses of a
+// threshold check keep the level of their series until their values pass the
+// recover values of its thresholds, and the statuses of a check with
+// occurrences keep the level of their series, like the statuses the engine
+// evaluates.
+func (tr *taskRun) Summarize(ctx context.Context) (string, error) {
+	tr.mu.Lock()
+	rows := tr.rows
+	tr.mu.Unlock()
+
+	now := tr.engine.TimeGenerator.Now()
+	tr.engine.sendDeferred(ctx, now)
+	if !tr.dueAt.IsZero() {
+		tr.recordLag(ctx)
+	}
+
+	r := tr.engine.newRun(now)
+	checks := make(map[influxdb.ID]influxdb.Check)
+	var ids []influxdb.ID
+	sts := make(map[influxdb.ID][]notification.Status)
+	for _, row := range rows {
+		c, ok := checks[row.checkID]
+		if !ok {
+			var err error
+			if c, err = tr.engine.store.FindCheckByID(ctx, row.checkID); err != nil {
+				return "", err
+			}
+			checks[row.checkID] = c
+			ids = append(ids, row.checkID)
+		}
+		st := r.newStatus(c, row.level, row.value, row.tags)
+		st.Message = row.message
+		if !row.time.IsZero() {
+			st.Time = row.time
+		}
+		if tc, ok := c.(*check.Threshold); ok && len(row.crossed) > 0 {
+			tr.engine.recoverLevel(tc, &st, row)
+		}
+		sts[row.checkID] = append(sts[row.checkID], st)
+	}
+
+	var traces []*influxdb.StatusTrace
+	for _, id := range ids {
+		c := checks[id]
+		ts, err := r.handle(ctx, c.GetOrgID(), tr.engine.confirmLevels(c, sts[id]))
+		if err != nil {
+			return "", err
+		}
+		traces = append(traces, ts...)
+	}
+	return influxdb.NewCheckRunSummary(traces).LogMessage()
+}
+
+// recoverLevel sets the level of a status of a threshold check from the
+// thresholds its row crossed, and held, at the previous level of its series.
+// The message of the status is expanded again if its level changed.
+func (e *Engine) recoverLevel(c *check.Threshold, st *notification.Status, row statusRow) {
+	prev, hasPrev := e.level(*st)
+	level := thresholdLevel(c, prev, hasPrev, func(i int, holds bool) bool {
+		if h, ok := row.holds[i]; holds && ok {
+			return h
+		}
+		return row.crossed[i]
+	})
+	if level == st.Level {
+		return
+	}
+	st.Level = level
+	expandMessage(c, st)
+}
+
+// recordLag records the lag of the run as the lag of the check of its task.
+// The checks are found by the name of their task, a check renamed since the
+// run was scheduled has no lag recorded.
+func (tr *taskRun) recordLag(ctx context.Context) {
+	cs, _, err := tr.engine.store.FindChecks(ctx, influxdb.CheckFilter{
+		OrgID: &tr.task.OrganizationID,
+		Name:  &tr.task.Name,
+	})
+	if err != nil {
+		tr.engine.Logger.Info("failed to find the check of task", zap.String("taskID", tr.task.ID.String()), zap.Error(err))
+		return
+	}
+	for _, c := range cs {
+		if tc, ok := c.(interface{ GetTaskID() influxdb.ID }); ok && tc.GetTaskID() == tr.task.ID {
+			tr.engine.recordLag(c, tr.dueAt, tr.startedAt)
+			return
+		}
+	}
+}
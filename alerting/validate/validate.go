@@ -0,0 +1,111 @@
+// Package validate validates checks, notification endpoints and notification
+// rules as they are submitted, without a store, so that the clients and the
+// server reject the same resources with the same errors.
+package validate
+
+import (
+	"fmt"
+
+	"github.com/influxdata/influxdb"
+)
+
+// Kind is the kind of a validated resource.
+type Kind string
+
+// consts of Kind
+const (
+	CheckKind                Kind = "check"
+	NotificationEndpointKind Kind = "notification endpoint"
+	NotificationRuleKind     Kind = "notification rule"
+)
+
+// Op is the operation a resource is submitted for, which decides the fields
+// the server fills in.
+type Op int
+
+// consts of Op
+const (
+	// Create validates a new resource, whose ID is assigned by the server.
+	Create Op = iota
+	// Update validates a replacement of a resource, whose ID and
+	// organization are kept by the server.
+	Update
+)
+
+// placeholderID stands for the identifiers filled in by the server.
+const placeholderID = influxdb.ID(1)
+
+// Error is a resource failing its validation.
+type Error struct {
+	Kind Kind `json:"kind"`
+	// Type is the type of the resource, such as threshold or slack.
+	Type string `json:"type"`
+	Name string `json:"name,omitempty"`
+	// ID is only set for the resources which already have one.
+	ID  *influxdb.ID `json:"id,omitempty"`
+	Msg string       `json:"message"`
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	if e.Name == "" {
+		return fmt.Sprintf("invalid %s %s: %s", e.Type, e.Kind, e.Msg)
+	}
+	return fmt.Sprintf("invalid %s %s %q: %s", e.Type, e.Kind, e.Name, e.Msg)
+}
+
+// resource is the part of checks, notification endpoints and notification
+// rules the validation needs.
+type resource interface {
+	Valid() error
+	Type() string
+	influxdb.Updator
+	influxdb.Getter
+}
+
+// Check returns an *Error if c is invalid for op.
+func Check(c influxdb.Check, op Op) error {
+	return validate(CheckKind, c, op)
+}
+
+// NotificationEndpoint returns an *Error if edp is invalid for op.
+func NotificationEndpoint(edp influxdb.NotificationEndpoint, op Op) error {
+	return validate(NotificationEndpointKind, edp, op)
+}
+
+// NotificationRule returns an *Error if nr is invalid for op.
+func NotificationRule(nr influxdb.NotificationRule, op Op) error {
+	return validate(NotificationRuleKind, nr, op)
+}
+
+// validate validates r with placeholders in the fields filled in by the
+// server for op, which are restored before it returns.
+func validate(kind Kind, r resource, op Op) error {
+	id, orgID := r.GetID(), r.GetOrgID()
+	if !id.Valid() {
+		r.SetID(placeholderID)
+		defer r.SetID(id)
+	}
+	if op == Update && !orgID.Valid() {
+		r.SetOrgID(placeholderID)
+		defer r.SetOrgID(orgID)
+	}
+
+	err := r.Valid()
+	if err == nil {
+		return nil
+	}
+	e := &Error{
+		Kind: kind,
+		Type: r.Type(),
+		Name: r.GetName(),
+		Msg:  err.Error(),
+	}
+	if ie, ok := err.(*influxdb.Error); ok {
+		e.Msg = influxdb.ErrorMessage(ie)
+	}
+	if id.Valid() {
+		e.ID = &id
+	}
+	return e
+}
@@ -0,0 +1,101 @@
+package validate_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/alerting/validate"
+	"github.com/influxdata/influxdb/notification/check"
+	"github.com/influxdata/influxdb/notification/endpoint"
+	"github.com/influxdata/influxdb/notification/rule"
+)
+
+func newCheck() *check.Deadman {
+	return &check.Deadman{
+		Base: check.Base{
+			Name:   "heartbeat",
+			OrgID:  2,
+			Status: influxdb.Active,
+			Every:  influxdb.Duration{Duration: time.Minute},
+			Query:  influxdb.DashboardQuery{Text: `from(bucket: "telegraf")`},
+		},
+		TimeSince: 90,
+	}
+}
+
+func TestCheck(t *testing.T) {
+	c := newCheck()
+	if err := validate.Check(c, validate.Create); err != nil {
+		t.Fatalf("expected a check without an ID to be valid to create, got %v", err)
+	}
+	if c.ID.Valid() {
+		t.Errorf("expected the ID of the check to be left unset, got %s", c.ID)
+	}
+
+	c.OrgID = 0
+	err := validate.Check(c, validate.Create)
+	ve, ok := err.(*validate.Error)
+	if !ok {
+		t.Fatalf("expected a validation error, got %v", err)
+	}
+	want := validate.Error{Kind: validate.CheckKind, Type: "deadman", Name: "heartbeat", Msg: "Check OrgID is invalid"}
+	if *ve != want {
+		t.Errorf("got error %+v, want %+v", *ve, want)
+	}
+	if got, want := err.Error(), `invalid deadman check "heartbeat": Check OrgID is invalid`; got != want {
+		t.Errorf("got message %q, want %q", got, want)
+	}
+
+	// the organization of an updated check is kept by the server.
+	c.ID = 1
+	if err := validate.Check(c, validate.Update); err != nil {
+		t.Fatalf("expected a check without an organization to be valid to update, got %v", err)
+	}
+	if c.OrgID.Valid() {
+		t.Errorf("expected the organization of the check to be left unset, got %s", c.OrgID)
+	}
+
+	c.Query.Text = ""
+	err = validate.Check(c, validate.Update)
+	if ve, ok := err.(*validate.Error); !ok || ve.ID == nil || *ve.ID != 1 || ve.Msg != "Check Query can't be empty" {
+		t.Errorf("expected the error of the check with its ID, got %v", err)
+	}
+}
+
+func TestNotificationEndpoint(t *testing.T) {
+	edp := &endpoint.Slack{
+		Base: endpoint.Base{Name: "slack", OrgID: 2, Status: influxdb.Active},
+		URL:  "https://hooks.slack.com/services/1",
+	}
+	if err := validate.NotificationEndpoint(edp, validate.Create); err != nil {
+		t.Fatalf("expected the endpoint to be valid, got %v", err)
+	}
+
+	edp.URL = ""
+	err := validate.NotificationEndpoint(edp, validate.Create)
+	if ve, ok := err.(*validate.Error); !ok || ve.Kind != validate.NotificationEndpointKind || ve.Msg != "slack endpoint URL is empty" {
+		t.Errorf("expected the endpoint to be invalid without a URL, got %v", err)
+	}
+}
+
+func TestNotificationRule(t *testing.T) {
+	nr := &rule.Slack{
+		Base: rule.Base{
+			Name:            "page ops",
+			OrgID:           2,
+			AuthorizationID: 3,
+			Status:          influxdb.Active,
+		},
+		MessageTemplate: "msg",
+	}
+	if err := validate.NotificationRule(nr, validate.Create); err != nil {
+		t.Fatalf("expected the rule to be valid, got %v", err)
+	}
+
+	nr.AuthorizationID = 0
+	err := validate.NotificationRule(nr, validate.Create)
+	if ve, ok := err.(*validate.Error); !ok || ve.Kind != validate.NotificationRuleKind || ve.Msg != "Notification Rule AuthorizationID is invalid" {
+		t.Errorf("expected the rule to be invalid without an authorization, got %v", err)
+	}
+}
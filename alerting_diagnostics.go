@@ -0,0 +1,97 @@
+package influxdb
+
+import (
+	"context"
+	"time"
+)
+
+// consts of the reasons alerting resources are orphaned.
+const (
+	// OrphanedCheckNoRule is the reason of a check whose statuses match no active notification rule.
+	OrphanedCheckNoRule = "no active notification rule matches the tags of the check"
+	// OrphanedRuleNoEndpoint is the reason of a notification rule without endpoint.
+	OrphanedRuleNoEndpoint = "the notification rule has no notification endpoint"
+	// OrphanedRuleMissingEndpoint is the reason of a notification rule whose endpoint was deleted.
+	OrphanedRuleMissingEndpoint = "the notification endpoint of the rule doesn't exist"
+	// OrphanedEndpointNoRule is the reason of a notification endpoint no rule sends to.
+	OrphanedEndpointNoRule = "no notification rule sends to the endpoint"
+)
+
+// OrphanedAlertingReport lists the alerting resources of an organization
+// which never lead to a notification.
+type OrphanedAlertingReport struct {
+	OrgID ID `json:"orgID"`
+	// Checks are the active checks whose statuses match no active notification rule.
+	Checks []OrphanedResource `json:"checks"`
+	// NotificationRules are the rules whose notification endpoint is missing.
+	NotificationRules []OrphanedResource `json:"notificationRules"`
+	// NotificationEndpoints are the endpoints no notification rule sends to.
+	NotificationEndpoints []OrphanedResource `json:"notificationEndpoints"`
+}
+
+// OrphanedResource is an alerting resource of an orphaned alerting report.
+type OrphanedResource struct {
+	ID           ID     `json:"id"`
+	Name         string `json:"name"`
+	Reason       string `json:"reason"`
+	SuggestedFix string `json:"suggestedFix"`
+}
+
+// consts of the priorities of the findings of an alerting diagnosis, the
+// most urgent first.
+const (
+	// AlertingFindingCritical is the priority of the problems losing notifications.
+	AlertingFindingCritical = 1
+	// AlertingFindingWarning is the priority of the problems delaying notifications.
+	AlertingFindingWarning = 2
+	// AlertingFindingInfo is the priority of the resources doing nothing.
+	AlertingFindingInfo = 3
+)
+
+// consts of the kinds of the findings of an alerting diagnosis.
+const (
+	// AlertingFindingDanglingTask is a check whose task is missing.
+	AlertingFindingDanglingTask = "dangling-task"
+	// AlertingFindingRuleWithoutEndpoint is a rule without an existing endpoint.
+	AlertingFindingRuleWithoutEndpoint = "rule-without-endpoint"
+	// AlertingFindingMissingSecret is an endpoint whose secret isn't stored.
+	AlertingFindingMissingSecret = "missing-secret"
+	// AlertingFindingMutedForever is a rule or endpoint whose notifications are always muted.
+	AlertingFindingMutedForever = "muted-forever"
+	// AlertingFindingSchedulerLag is a check whose task runs late.
+	AlertingFindingSchedulerLag = "scheduler-lag"
+	// AlertingFindingOrphaned is a check or endpoint which never leads to a notification.
+	AlertingFindingOrphaned = "orphaned"
+)
+
+// AlertingDiagnosis is the list of the problems of the alerting resources of
+// an organization, the most urgent first, with their fix.
+type AlertingDiagnosis struct {
+	OrgID       ID                `json:"orgID"`
+	DiagnosedAt time.Time         `json:"diagnosedAt"`
+	Findings    []AlertingFinding `json:"findings"`
+}
+
+// AlertingFinding is a problem of an alerting resource.
+type AlertingFinding struct {
+	Priority     int          `json:"priority"`
+	Kind         string       `json:"kind"`
+	ResourceType ResourceType `json:"resourceType"`
+	ID           ID           `json:"id"`
+	Name         string       `json:"name"`
+	Problem      string       `json:"problem"`
+	Fix          string       `json:"fix"`
+}
+
+// AlertingDiagnosticsService diagnoses the alerting resources of an organization.
+type AlertingDiagnosticsService interface {
+	// FindOrphanedAlertingResources returns the checks, notification rules and
+	// notification endpoints of an organization which never lead to a notification.
+	FindOrphanedAlertingResources(ctx context.Context, orgID ID) (*OrphanedAlertingReport, error)
+
+	// DiagnoseAlerting checks the whole alerting stack of an organization:
+	// the tasks of its checks and how late they run, the endpoints of its
+	// rules, the secrets of its endpoints and the rules and endpoints always
+	// muted. The findings are sorted by priority.
+	DiagnoseAlerting(ctx context.Context, orgID ID) (*AlertingDiagnosis, error)
+}
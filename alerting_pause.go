@@ -0,0 +1,45 @@
+package influxdb
+
+import (
+	"context"
+	"time"
+)
+
+// AlertingPause is the pause of the alerting of an organization, the big red
+// button of its incidents and planned maintenance: its active checks are set
+// inactive and its notifications are suppressed until it is resumed.
+type AlertingPause struct {
+	OrgID ID `json:"orgID"`
+	// UserID is the user who paused the alerting, if any.
+	UserID   ID        `json:"userID,omitempty"`
+	PausedAt time.Time `json:"pausedAt"`
+	Reason   string    `json:"reason,omitempty"`
+	// CheckIDs are the checks the pause set inactive, the ones reactivated
+	// when it is resumed. The checks which were inactive already stay so.
+	CheckIDs []ID `json:"checkIDs"`
+}
+
+// CheckPauseReason is the reason the checks set inactive by the pause are
+// annotated with.
+func (p AlertingPause) CheckPauseReason() string {
+	if p.Reason == "" {
+		return "the alerting of the organization was paused"
+	}
+	return "the alerting of the organization was paused: " + p.Reason
+}
+
+// AlertingPauseService pauses and resumes the alerting of organizations.
+type AlertingPauseService interface {
+	// FindAlertingPause returns the pause of the alerting of an organization,
+	// a not found error if its alerting isn't paused.
+	FindAlertingPause(ctx context.Context, orgID ID) (*AlertingPause, error)
+
+	// PauseAlerting sets inactive the active checks of an organization and
+	// suppresses its notifications, recording who paused it, when and why.
+	PauseAlerting(ctx context.Context, orgID ID, reason string) (*AlertingPause, error)
+
+	// ResumeAlerting reactivates the checks set inactive by the pause of the
+	// alerting of an organization, unless they were changed since, and
+	// returns the pause.
+	ResumeAlerting(ctx context.Context, orgID ID) (*AlertingPause, error)
+}
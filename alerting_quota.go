@@ -0,0 +1,107 @@
+package influxdb
+
+import (
+	"context"
+	"fmt"
+)
+
+// DefaultAlertingQuotaWarnPercent is the default percentage of an alerting
+// quota past which the responses carry warnings.
+const DefaultAlertingQuotaWarnPercent = 80
+
+// AlertingQuotas are the maximum numbers of checks, notification rules and
+// notification endpoints of each organization, so operators can bound the
+// load of an organization. The creates over a quota are rejected, and the
+// responses about the resources of an organization near a quota carry
+// warnings. The zero value has no quota.
+type AlertingQuotas struct {
+	// Checks is the maximum number of the checks of an organization,
+	// unlimited if 0. The archived checks aren't counted.
+	Checks int
+	// NotificationRules is the maximum number of the notification rules of
+	// an organization, unlimited if 0.
+	NotificationRules int
+	// NotificationEndpoints is the maximum number of the notification
+	// endpoints of an organization, unlimited if 0.
+	NotificationEndpoints int
+	// WarnPercent is the percentage of a quota past which the responses
+	// carry warnings, DefaultAlertingQuotaWarnPercent when 0.
+	WarnPercent int
+}
+
+// Valid returns an error if the quotas are invalid.
+func (q AlertingQuotas) Valid() error {
+	if q.Checks < 0 || q.NotificationRules < 0 || q.NotificationEndpoints < 0 {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "alerting quotas can't be negative",
+		}
+	}
+	if q.WarnPercent < 0 || q.WarnPercent > 100 {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "alerting quota warn percent must be between 0 and 100",
+		}
+	}
+	return nil
+}
+
+// Quota returns the quota of a resource type, 0 if it is unlimited.
+func (q AlertingQuotas) Quota(rt ResourceType) int {
+	switch rt {
+	case ChecksResourceType:
+		return q.Checks
+	case NotificationRuleResourceType:
+		return q.NotificationRules
+	case NotificationEndpointResourceType:
+		return q.NotificationEndpoints
+	}
+	return 0
+}
+
+// Enforce returns an EForbidden error if an organization with used resources
+// of a type can't create another one.
+func (q AlertingQuotas) Enforce(rt ResourceType, used int) error {
+	if quota := q.Quota(rt); quota > 0 && used >= quota {
+		return &Error{
+			Code: EForbidden,
+			Msg:  fmt.Sprintf("the organization reached its quota of %d %s", quota, rt),
+		}
+	}
+	return nil
+}
+
+// Warning returns the warning of an organization with used resources of a
+// type past the warn percent of its quota, nil if there is none.
+func (q AlertingQuotas) Warning(rt ResourceType, used int) *QuotaWarning {
+	quota := q.Quota(rt)
+	percent := q.WarnPercent
+	if percent == 0 {
+		percent = DefaultAlertingQuotaWarnPercent
+	}
+	if quota <= 0 || used*100 <= quota*percent {
+		return nil
+	}
+	return &QuotaWarning{
+		Resource: rt,
+		Used:     used,
+		Quota:    quota,
+		Message:  fmt.Sprintf("the organization uses %d of its quota of %d %s", used, quota, rt),
+	}
+}
+
+// QuotaWarning warns that an organization is near or at the quota of a
+// resource type, so automation can react before its creates are rejected.
+type QuotaWarning struct {
+	Resource ResourceType `json:"resource"`
+	Used     int          `json:"used"`
+	Quota    int          `json:"quota"`
+	Message  string       `json:"message"`
+}
+
+// AlertingQuotaService finds the warnings of the alerting quotas.
+type AlertingQuotaService interface {
+	// FindAlertingQuotaWarnings returns the warnings of the quotas of the
+	// resource types an organization is near or at, of every type if none.
+	FindAlertingQuotaWarnings(ctx context.Context, orgID ID, rts ...ResourceType) ([]QuotaWarning, error)
+}
@@ -0,0 +1,51 @@
+package influxdb_test
+
+import (
+	"testing"
+
+	"github.com/influxdata/influxdb"
+)
+
+func TestAlertingQuotas_Warning(t *testing.T) {
+	q := influxdb.AlertingQuotas{Checks: 10, NotificationRules: 4}
+	tests := []struct {
+		name string
+		rt   influxdb.ResourceType
+		used int
+		warn bool
+	}{
+		{name: "at the warn percent", rt: influxdb.ChecksResourceType, used: 8},
+		{name: "past the warn percent", rt: influxdb.ChecksResourceType, used: 9, warn: true},
+		{name: "at the quota", rt: influxdb.NotificationRuleResourceType, used: 4, warn: true},
+		{name: "unlimited", rt: influxdb.NotificationEndpointResourceType, used: 1000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := q.Warning(tt.rt, tt.used); (w != nil) != tt.warn {
+				t.Errorf("expected a warning %v, got %v", tt.warn, w)
+			}
+		})
+	}
+}
+
+func TestAlertingQuotas_Enforce(t *testing.T) {
+	q := influxdb.AlertingQuotas{Checks: 10}
+	if err := q.Enforce(influxdb.ChecksResourceType, 9); err != nil {
+		t.Errorf("expected a check under the quota to be allowed, got %v", err)
+	}
+	if err := q.Enforce(influxdb.ChecksResourceType, 10); influxdb.ErrorCode(err) != influxdb.EForbidden {
+		t.Errorf("expected a check over the quota to be forbidden, got %v", err)
+	}
+	if err := q.Enforce(influxdb.NotificationRuleResourceType, 1000); err != nil {
+		t.Errorf("expected the rules without a quota to be allowed, got %v", err)
+	}
+}
+
+func TestAlertingQuotas_Valid(t *testing.T) {
+	if err := (influxdb.AlertingQuotas{Checks: -1}).Valid(); influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Errorf("expected a negative quota to be invalid, got %v", err)
+	}
+	if err := (influxdb.AlertingQuotas{WarnPercent: 101}).Valid(); influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Errorf("expected a warn percent over 100 to be invalid, got %v", err)
+	}
+}
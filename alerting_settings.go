@@ -0,0 +1,121 @@
+package influxdb
+
+import (
+	"context"
+	"strings"
+)
+
+// AlertingSettings are the defaults of the alerting resources of an
+// organization, so the checks and notification rules only configure what
+// differs from them. The zero value has no defaults.
+type AlertingSettings struct {
+	OrgID ID `json:"orgID"`
+	// DefaultEndpointID is the endpoint of the notification rules created
+	// without an endpoint nor routes.
+	DefaultEndpointID *ID `json:"defaultEndpointID,omitempty"`
+	// DefaultEvery and DefaultOffset schedule the checks created without an
+	// interval nor a cron, and without an offset.
+	DefaultEvery  Duration `json:"defaultEvery"`
+	DefaultOffset Duration `json:"defaultOffset"`
+	// StatusBucket is the name of the bucket the statuses of the checks are
+	// written to, the monitoring bucket when empty.
+	StatusBucket string `json:"statusBucket,omitempty"`
+	// QuietHours defers or suppresses every notification of the organization
+	// during a time of day.
+	QuietHours *QuietHours `json:"quietHours,omitempty"`
+	// DedupWindow is how long a rule doesn't notify a series at the level it
+	// last notified it at, the rules notify every status they match when 0.
+	DedupWindow Duration `json:"dedupWindow"`
+	// AllowHTTPPost allows the flux of the checks of the organization to call
+	// http.post, which the sandbox of the checks denies otherwise.
+	AllowHTTPPost bool `json:"allowHTTPPost,omitempty"`
+	CRUDLog
+}
+
+// Valid returns error if some configuration is invalid
+func (s AlertingSettings) Valid() error {
+	if !s.OrgID.Valid() {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "alerting settings orgID is invalid",
+		}
+	}
+	if s.DefaultEndpointID != nil && !s.DefaultEndpointID.Valid() {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "alerting settings defaultEndpointID is invalid",
+		}
+	}
+	if s.DefaultEvery.Duration < 0 || s.DefaultOffset.Duration < 0 || s.DedupWindow.Duration < 0 {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "alerting settings durations can't be negative",
+		}
+	}
+	if s.QuietHours != nil {
+		return s.QuietHours.Valid()
+	}
+	return nil
+}
+
+// Changes returns the names of the settings which differ from old, in the
+// order of their fields.
+func (s AlertingSettings) Changes(old AlertingSettings) []string {
+	var changes []string
+	if !equalIDPtr(s.DefaultEndpointID, old.DefaultEndpointID) {
+		changes = append(changes, "defaultEndpointID")
+	}
+	if s.DefaultEvery != old.DefaultEvery {
+		changes = append(changes, "defaultEvery")
+	}
+	if s.DefaultOffset != old.DefaultOffset {
+		changes = append(changes, "defaultOffset")
+	}
+	if s.StatusBucket != old.StatusBucket {
+		changes = append(changes, "statusBucket")
+	}
+	if (s.QuietHours == nil) != (old.QuietHours == nil) ||
+		s.QuietHours != nil && *s.QuietHours != *old.QuietHours {
+		changes = append(changes, "quietHours")
+	}
+	if s.DedupWindow != old.DedupWindow {
+		changes = append(changes, "dedupWindow")
+	}
+	if s.AllowHTTPPost != old.AllowHTTPPost {
+		changes = append(changes, "allowHTTPPost")
+	}
+	return changes
+}
+
+// AlertingSettingsChangedDescription describes the change of some settings in
+// the operation log of the alerting settings.
+func AlertingSettingsChangedDescription(changes []string) string {
+	if len(changes) == 0 {
+		return "Alerting Settings Updated"
+	}
+	return "Alerting Settings Updated: " + strings.Join(changes, ", ")
+}
+
+func equalIDPtr(a, b *ID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// AlertingSettingsService represents a service for managing the alerting settings of organizations.
+type AlertingSettingsService interface {
+	// FindAlertingSettings returns the alerting settings of an organization,
+	// which are the zero settings until they are set.
+	FindAlertingSettings(ctx context.Context, orgID ID) (*AlertingSettings, error)
+
+	// PutAlertingSettings creates or replaces the alerting settings of an organization.
+	PutAlertingSettings(ctx context.Context, s *AlertingSettings) error
+
+	// DeleteAlertingSettings resets the alerting settings of an organization.
+	DeleteAlertingSettings(ctx context.Context, orgID ID) error
+
+	// GetAlertingSettingsOperationLog retrieves the changes of the alerting
+	// settings of an organization, the audit trail of the settings.
+	GetAlertingSettingsOperationLog(ctx context.Context, orgID ID, opts FindOptions) ([]*OperationLogEntry, int, error)
+}
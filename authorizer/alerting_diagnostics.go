@@ -0,0 +1,71 @@
+package authorizer
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.AlertingDiagnosticsService = (*AlertingDiagnosticsService)(nil)
+
+// AlertingDiagnosticsService wraps a influxdb.AlertingDiagnosticsService and authorizes actions
+// against it appropriately.
+type AlertingDiagnosticsService struct {
+	s influxdb.AlertingDiagnosticsService
+}
+
+// NewAlertingDiagnosticsService constructs an instance of an authorizing alerting diagnostics service.
+func NewAlertingDiagnosticsService(s influxdb.AlertingDiagnosticsService) *AlertingDiagnosticsService {
+	return &AlertingDiagnosticsService{
+		s: s,
+	}
+}
+
+// authorizeReadAlerting checks the authorizer on context can read every
+// alerting resource of an organization.
+func authorizeReadAlerting(ctx context.Context, orgID influxdb.ID) error {
+	for _, t := range []influxdb.ResourceType{
+		influxdb.ChecksResourceType,
+		influxdb.NotificationRuleResourceType,
+		influxdb.NotificationEndpointResourceType,
+	} {
+		p, err := influxdb.NewPermission(influxdb.ReadAction, t, orgID)
+		if err != nil {
+			return err
+		}
+		if err := IsAllowed(ctx, *p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FindOrphanedAlertingResources checks to see if the authorizer on context has read access to
+// the checks, notification rules and notification endpoints of the organization.
+func (s *AlertingDiagnosticsService) FindOrphanedAlertingResources(ctx context.Context, orgID influxdb.ID) (*influxdb.OrphanedAlertingReport, error) {
+	if err := authorizeReadAlerting(ctx, orgID); err != nil {
+		return nil, err
+	}
+	return s.s.FindOrphanedAlertingResources(ctx, orgID)
+}
+
+// DiagnoseAlerting checks to see if the authorizer on context has read access to the
+// checks, notification rules, notification endpoints, tasks and secrets of the organization.
+func (s *AlertingDiagnosticsService) DiagnoseAlerting(ctx context.Context, orgID influxdb.ID) (*influxdb.AlertingDiagnosis, error) {
+	if err := authorizeReadAlerting(ctx, orgID); err != nil {
+		return nil, err
+	}
+	for _, t := range []influxdb.ResourceType{
+		influxdb.TasksResourceType,
+		influxdb.SecretsResourceType,
+	} {
+		p, err := influxdb.NewPermission(influxdb.ReadAction, t, orgID)
+		if err != nil {
+			return nil, err
+		}
+		if err := IsAllowed(ctx, *p); err != nil {
+			return nil, err
+		}
+	}
+	return s.s.DiagnoseAlerting(ctx, orgID)
+}
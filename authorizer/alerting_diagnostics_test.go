@@ -0,0 +1,147 @@
+package authorizer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/authorizer"
+	influxdbcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/mock"
+	influxdbtesting "github.com/influxdata/influxdb/testing"
+)
+
+func TestAlertingDiagnosticsService_FindOrphanedAlertingResources(t *testing.T) {
+	orgPermission := func(t influxdb.ResourceType) influxdb.Permission {
+		return influxdb.Permission{
+			Action: "read",
+			Resource: influxdb.Resource{
+				Type:  t,
+				OrgID: influxdbtesting.IDPtr(10),
+			},
+		}
+	}
+	type args struct {
+		permissions []influxdb.Permission
+	}
+	type wants struct {
+		err error
+	}
+
+	tests := []struct {
+		name  string
+		args  args
+		wants wants
+	}{
+		{
+			name: "authorized to read the alerting resources of the org",
+			args: args{
+				permissions: []influxdb.Permission{
+					orgPermission(influxdb.ChecksResourceType),
+					orgPermission(influxdb.NotificationRuleResourceType),
+					orgPermission(influxdb.NotificationEndpointResourceType),
+				},
+			},
+		},
+		{
+			name: "unauthorized to read the notification rules of the org",
+			args: args{
+				permissions: []influxdb.Permission{
+					orgPermission(influxdb.ChecksResourceType),
+					orgPermission(influxdb.NotificationEndpointResourceType),
+				},
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Msg:  "read:orgs/000000000000000a/notificationRules is unauthorized",
+					Code: influxdb.EUnauthorized,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := authorizer.NewAlertingDiagnosticsService(&mock.AlertingDiagnosticsService{
+				FindOrphanedAlertingResourcesF: func(ctx context.Context, orgID influxdb.ID) (*influxdb.OrphanedAlertingReport, error) {
+					return &influxdb.OrphanedAlertingReport{OrgID: orgID}, nil
+				},
+			})
+
+			ctx := context.Background()
+			ctx = influxdbcontext.SetAuthorizer(ctx, &Authorizer{tt.args.permissions})
+
+			_, err := s.FindOrphanedAlertingResources(ctx, 10)
+			influxdbtesting.ErrorsEqual(t, err, tt.wants.err)
+		})
+	}
+}
+
+func TestAlertingDiagnosticsService_DiagnoseAlerting(t *testing.T) {
+	orgPermission := func(t influxdb.ResourceType) influxdb.Permission {
+		return influxdb.Permission{
+			Action: "read",
+			Resource: influxdb.Resource{
+				Type:  t,
+				OrgID: influxdbtesting.IDPtr(10),
+			},
+		}
+	}
+	alertingPermissions := []influxdb.Permission{
+		orgPermission(influxdb.ChecksResourceType),
+		orgPermission(influxdb.NotificationRuleResourceType),
+		orgPermission(influxdb.NotificationEndpointResourceType),
+	}
+	type args struct {
+		permissions []influxdb.Permission
+	}
+	type wants struct {
+		err error
+	}
+
+	tests := []struct {
+		name  string
+		args  args
+		wants wants
+	}{
+		{
+			name: "authorized to read the alerting resources, tasks and secrets of the org",
+			args: args{
+				permissions: append(alertingPermissions,
+					orgPermission(influxdb.TasksResourceType),
+					orgPermission(influxdb.SecretsResourceType),
+				),
+			},
+		},
+		{
+			name: "unauthorized to read the secrets of the org",
+			args: args{
+				permissions: append(alertingPermissions,
+					orgPermission(influxdb.TasksResourceType),
+				),
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Msg:  "read:orgs/000000000000000a/secrets is unauthorized",
+					Code: influxdb.EUnauthorized,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := authorizer.NewAlertingDiagnosticsService(&mock.AlertingDiagnosticsService{
+				DiagnoseAlertingF: func(ctx context.Context, orgID influxdb.ID) (*influxdb.AlertingDiagnosis, error) {
+					return &influxdb.AlertingDiagnosis{OrgID: orgID}, nil
+				},
+			})
+
+			ctx := context.Background()
+			ctx = influxdbcontext.SetAuthorizer(ctx, &Authorizer{tt.args.permissions})
+
+			_, err := s.DiagnoseAlerting(ctx, 10)
+			influxdbtesting.ErrorsEqual(t, err, tt.wants.err)
+		})
+	}
+}
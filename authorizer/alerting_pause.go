@@ -0,0 +1,49 @@
+package authorizer
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.AlertingPauseService = (*AlertingPauseService)(nil)
+
+// AlertingPauseService wraps a influxdb.AlertingPauseService and authorizes actions
+// against it appropriately. The pause of the alerting of an organization is authorized as the organization.
+type AlertingPauseService struct {
+	s influxdb.AlertingPauseService
+}
+
+// NewAlertingPauseService constructs an instance of an authorizing alerting pause service.
+func NewAlertingPauseService(s influxdb.AlertingPauseService) *AlertingPauseService {
+	return &AlertingPauseService{
+		s: s,
+	}
+}
+
+// FindAlertingPause checks to see if the authorizer on context has read access to the organization provided.
+func (s *AlertingPauseService) FindAlertingPause(ctx context.Context, orgID influxdb.ID) (*influxdb.AlertingPause, error) {
+	if err := authorizeReadOrg(ctx, orgID); err != nil {
+		return nil, err
+	}
+
+	return s.s.FindAlertingPause(ctx, orgID)
+}
+
+// PauseAlerting checks to see if the authorizer on context has write access to the organization provided.
+func (s *AlertingPauseService) PauseAlerting(ctx context.Context, orgID influxdb.ID, reason string) (*influxdb.AlertingPause, error) {
+	if err := authorizeWriteOrg(ctx, orgID); err != nil {
+		return nil, err
+	}
+
+	return s.s.PauseAlerting(ctx, orgID, reason)
+}
+
+// ResumeAlerting checks to see if the authorizer on context has write access to the organization provided.
+func (s *AlertingPauseService) ResumeAlerting(ctx context.Context, orgID influxdb.ID) (*influxdb.AlertingPause, error) {
+	if err := authorizeWriteOrg(ctx, orgID); err != nil {
+		return nil, err
+	}
+
+	return s.s.ResumeAlerting(ctx, orgID)
+}
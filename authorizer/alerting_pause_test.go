@@ -0,0 +1,82 @@
+package authorizer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/authorizer"
+	influxdbcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/mock"
+	influxdbtesting "github.com/influxdata/influxdb/testing"
+)
+
+func TestAlertingPauseService_PauseAlerting(t *testing.T) {
+	type args struct {
+		permission influxdb.Permission
+		orgID      influxdb.ID
+	}
+	type wants struct {
+		err error
+	}
+
+	tests := []struct {
+		name  string
+		args  args
+		wants wants
+	}{
+		{
+			name: "authorized to write the organization",
+			args: args{
+				permission: influxdb.Permission{
+					Action: "write",
+					Resource: influxdb.Resource{
+						Type: influxdb.OrgsResourceType,
+						ID:   influxdbtesting.IDPtr(1),
+					},
+				},
+				orgID: 1,
+			},
+		},
+		{
+			name: "unauthorized to write the organization",
+			args: args{
+				permission: influxdb.Permission{
+					Action: "read",
+					Resource: influxdb.Resource{
+						Type: influxdb.OrgsResourceType,
+						ID:   influxdbtesting.IDPtr(1),
+					},
+				},
+				orgID: 1,
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Msg:  "write:orgs/0000000000000001 is unauthorized",
+					Code: influxdb.EUnauthorized,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := authorizer.NewAlertingPauseService(&mock.AlertingPauseService{
+				PauseAlertingF: func(ctx context.Context, orgID influxdb.ID, reason string) (*influxdb.AlertingPause, error) {
+					return &influxdb.AlertingPause{OrgID: orgID, Reason: reason}, nil
+				},
+				ResumeAlertingF: func(ctx context.Context, orgID influxdb.ID) (*influxdb.AlertingPause, error) {
+					return &influxdb.AlertingPause{OrgID: orgID}, nil
+				},
+			})
+
+			ctx := context.Background()
+			ctx = influxdbcontext.SetAuthorizer(ctx, &Authorizer{[]influxdb.Permission{tt.args.permission}})
+
+			_, err := s.PauseAlerting(ctx, tt.args.orgID, "maintenance")
+			influxdbtesting.ErrorsEqual(t, err, tt.wants.err)
+			_, err = s.ResumeAlerting(ctx, tt.args.orgID)
+			influxdbtesting.ErrorsEqual(t, err, tt.wants.err)
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package authorizer
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.AlertingQuotaService = (*AlertingQuotaService)(nil)
+
+// AlertingQuotaService wraps a influxdb.AlertingQuotaService and authorizes actions
+// against it appropriately.
+type AlertingQuotaService struct {
+	s influxdb.AlertingQuotaService
+}
+
+// NewAlertingQuotaService constructs an instance of an authorizing alerting quota service.
+func NewAlertingQuotaService(s influxdb.AlertingQuotaService) *AlertingQuotaService {
+	return &AlertingQuotaService{
+		s: s,
+	}
+}
+
+// FindAlertingQuotaWarnings checks to see if the authorizer on context has read access to the organization.
+func (s *AlertingQuotaService) FindAlertingQuotaWarnings(ctx context.Context, orgID influxdb.ID, rts ...influxdb.ResourceType) ([]influxdb.QuotaWarning, error) {
+	if err := authorizeReadOrg(ctx, orgID); err != nil {
+		return nil, err
+	}
+	return s.s.FindAlertingQuotaWarnings(ctx, orgID, rts...)
+}
@@ -0,0 +1,58 @@
+package authorizer
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.AlertingSettingsService = (*AlertingSettingsService)(nil)
+
+// AlertingSettingsService wraps a influxdb.AlertingSettingsService and authorizes actions
+// against it appropriately. The alerting settings of an organization are authorized as the organization.
+type AlertingSettingsService struct {
+	s influxdb.AlertingSettingsService
+}
+
+// NewAlertingSettingsService constructs an instance of an authorizing alerting settings service.
+func NewAlertingSettingsService(s influxdb.AlertingSettingsService) *AlertingSettingsService {
+	return &AlertingSettingsService{
+		s: s,
+	}
+}
+
+// FindAlertingSettings checks to see if the authorizer on context has read access to the organization provided.
+func (s *AlertingSettingsService) FindAlertingSettings(ctx context.Context, orgID influxdb.ID) (*influxdb.AlertingSettings, error) {
+	if err := authorizeReadOrg(ctx, orgID); err != nil {
+		return nil, err
+	}
+
+	return s.s.FindAlertingSettings(ctx, orgID)
+}
+
+// PutAlertingSettings checks to see if the authorizer on context has write access to the organization provided.
+func (s *AlertingSettingsService) PutAlertingSettings(ctx context.Context, as *influxdb.AlertingSettings) error {
+	if err := authorizeWriteOrg(ctx, as.OrgID); err != nil {
+		return err
+	}
+
+	return s.s.PutAlertingSettings(ctx, as)
+}
+
+// DeleteAlertingSettings checks to see if the authorizer on context has write access to the organization provided.
+func (s *AlertingSettingsService) DeleteAlertingSettings(ctx context.Context, orgID influxdb.ID) error {
+	if err := authorizeWriteOrg(ctx, orgID); err != nil {
+		return err
+	}
+
+	return s.s.DeleteAlertingSettings(ctx, orgID)
+}
+
+// GetAlertingSettingsOperationLog checks to see if the authorizer on context has read access to the organization provided.
+func (s *AlertingSettingsService) GetAlertingSettingsOperationLog(ctx context.Context, orgID influxdb.ID, opts influxdb.FindOptions) ([]*influxdb.OperationLogEntry, int, error) {
+	if err := authorizeReadOrg(ctx, orgID); err != nil {
+		return nil, 0, err
+	}
+
+	return s.s.GetAlertingSettingsOperationLog(ctx, orgID, opts)
+}
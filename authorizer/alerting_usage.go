@@ -0,0 +1,48 @@
+package authorizer
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.AlertingUsageService = (*AlertingUsageService)(nil)
+
+// AlertingUsageService wraps a influxdb.AlertingUsageService and authorizes actions
+// against it appropriately.
+type AlertingUsageService struct {
+	s influxdb.AlertingUsageService
+}
+
+// NewAlertingUsageService constructs an instance of an authorizing alerting usage service.
+func NewAlertingUsageService(s influxdb.AlertingUsageService) *AlertingUsageService {
+	return &AlertingUsageService{
+		s: s,
+	}
+}
+
+// GetAlertingUsage checks to see if the authorizer on context has read access to the checks,
+// notification rules and notification endpoints of the organization, or of every organization.
+func (s *AlertingUsageService) GetAlertingUsage(ctx context.Context, filter influxdb.UsageFilter) (*influxdb.AlertingUsage, error) {
+	if filter.OrgID != nil {
+		if err := authorizeReadAlerting(ctx, *filter.OrgID); err != nil {
+			return nil, err
+		}
+		return s.s.GetAlertingUsage(ctx, filter)
+	}
+
+	for _, t := range []influxdb.ResourceType{
+		influxdb.ChecksResourceType,
+		influxdb.NotificationRuleResourceType,
+		influxdb.NotificationEndpointResourceType,
+	} {
+		p, err := influxdb.NewGlobalPermission(influxdb.ReadAction, t)
+		if err != nil {
+			return nil, err
+		}
+		if err := IsAllowed(ctx, *p); err != nil {
+			return nil, err
+		}
+	}
+	return s.s.GetAlertingUsage(ctx, filter)
+}
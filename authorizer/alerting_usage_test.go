@@ -0,0 +1,97 @@
+package authorizer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/authorizer"
+	influxdbcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/mock"
+	influxdbtesting "github.com/influxdata/influxdb/testing"
+)
+
+func TestAlertingUsageService_GetAlertingUsage(t *testing.T) {
+	orgPermission := func(t influxdb.ResourceType) influxdb.Permission {
+		return influxdb.Permission{
+			Action: "read",
+			Resource: influxdb.Resource{
+				Type:  t,
+				OrgID: influxdbtesting.IDPtr(10),
+			},
+		}
+	}
+	type args struct {
+		permissions []influxdb.Permission
+		orgID       *influxdb.ID
+	}
+	type wants struct {
+		err error
+	}
+
+	tests := []struct {
+		name  string
+		args  args
+		wants wants
+	}{
+		{
+			name: "authorized to read the alerting resources of the org",
+			args: args{
+				permissions: []influxdb.Permission{
+					orgPermission(influxdb.ChecksResourceType),
+					orgPermission(influxdb.NotificationRuleResourceType),
+					orgPermission(influxdb.NotificationEndpointResourceType),
+				},
+				orgID: influxdbtesting.IDPtr(10),
+			},
+		},
+		{
+			name: "unauthorized to read the notification endpoints of the org",
+			args: args{
+				permissions: []influxdb.Permission{
+					orgPermission(influxdb.ChecksResourceType),
+					orgPermission(influxdb.NotificationRuleResourceType),
+				},
+				orgID: influxdbtesting.IDPtr(10),
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Msg:  "read:orgs/000000000000000a/notificationEndpoints is unauthorized",
+					Code: influxdb.EUnauthorized,
+				},
+			},
+		},
+		{
+			name: "unauthorized to read the alerting resources of every org",
+			args: args{
+				permissions: []influxdb.Permission{
+					orgPermission(influxdb.ChecksResourceType),
+					orgPermission(influxdb.NotificationRuleResourceType),
+					orgPermission(influxdb.NotificationEndpointResourceType),
+				},
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Msg:  "read:checks is unauthorized",
+					Code: influxdb.EUnauthorized,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := authorizer.NewAlertingUsageService(&mock.AlertingUsageService{
+				GetAlertingUsageF: func(ctx context.Context, filter influxdb.UsageFilter) (*influxdb.AlertingUsage, error) {
+					return &influxdb.AlertingUsage{OrgID: filter.OrgID}, nil
+				},
+			})
+
+			ctx := context.Background()
+			ctx = influxdbcontext.SetAuthorizer(ctx, &Authorizer{tt.args.permissions})
+
+			_, err := s.GetAlertingUsage(ctx, influxdb.UsageFilter{OrgID: tt.args.orgID})
+			influxdbtesting.ErrorsEqual(t, err, tt.wants.err)
+		})
+	}
+}
@@ -0,0 +1,232 @@
+package authorizer
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.CheckService = (*CheckService)(nil)
+
+// CheckService wraps a influxdb.CheckService and authorizes actions
+// against it appropriately.
+type CheckService struct {
+	s influxdb.CheckService
+}
+
+// NewCheckService constructs an instance of an authorizing check serivce.
+func NewCheckService(s influxdb.CheckService) *CheckService {
+	return &CheckService{
+		s: s,
+	}
+}
+
+func newCheckPermission(a influxdb.Action, orgID, id influxdb.ID) (*influxdb.Permission, error) {
+	return influxdb.NewPermissionAtID(id, a, influxdb.ChecksResourceType, orgID)
+}
+
+func authorizeReadCheck(ctx context.Context, orgID, id influxdb.ID) error {
+	p, err := newCheckPermission(influxdb.ReadAction, orgID, id)
+	if err != nil {
+		return err
+	}
+
+	if err := IsAllowed(ctx, *p); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func authorizeWriteCheck(ctx context.Context, orgID, id influxdb.ID) error {
+	p, err := newCheckPermission(influxdb.WriteAction, orgID, id)
+	if err != nil {
+		return err
+	}
+
+	if err := IsAllowed(ctx, *p); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func authorizeCheckAction(ctx context.Context, a influxdb.Action, orgID, id influxdb.ID) error {
+	p, err := newCheckPermission(a, orgID, id)
+	if err != nil {
+		return err
+	}
+
+	if err := IsAllowed(ctx, *p); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// FindCheckByID checks to see if the authorizer on context has read access to the id provided.
+func (s *CheckService) FindCheckByID(ctx context.Context, id influxdb.ID) (influxdb.Check, error) {
+	c, err := s.s.FindCheckByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeReadCheck(ctx, c.GetOrgID(), c.GetID()); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// FindCheck will return the check.
+func (s *CheckService) FindCheck(ctx context.Context, filter influxdb.CheckFilter) (influxdb.Check, error) {
+	c, err := s.s.FindCheck(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeReadCheck(ctx, c.GetOrgID(), c.GetID()); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// FindChecks retrieves all checks that match the provided filter and then filters the list down to only the resources that are authorized.
+// The total count is the one of the store when the authorizer can read every check of the organization,
+// otherwise every matching check is retrieved to count the authorized ones and the page is cut from them.
+func (s *CheckService) FindChecks(ctx context.Context, filter influxdb.CheckFilter, opt ...influxdb.FindOptions) ([]influxdb.Check, int, error) {
+	if filter.OrgID != nil {
+		p, err := influxdb.NewPermission(influxdb.ReadAction, influxdb.ChecksResourceType, *filter.OrgID)
+		if err != nil {
+			return nil, 0, err
+		}
+		if IsAllowed(ctx, *p) == nil {
+			return s.s.FindChecks(ctx, filter, opt...)
+		}
+	}
+
+	var opts influxdb.FindOptions
+	if len(opt) > 0 {
+		opts = opt[0]
+	}
+	all := opts
+	all.Offset, all.Limit = 0, 0
+
+	// TODO: we'll likely want to push this operation into the database eventually since fetching the whole list of data
+	// will likely be expensive.
+	cs, _, err := s.s.FindChecks(ctx, filter, all)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// This filters without allocating
+	// https://github.com/golang/go/wiki/SliceTricks#filtering-without-allocating
+	checks := cs[:0]
+	for _, c := range cs {
+		err := authorizeReadCheck(ctx, c.GetOrgID(), c.GetID())
+		if err != nil && influxdb.ErrorCode(err) != influxdb.EUnauthorized {
+			return nil, 0, err
+		}
+
+		if influxdb.ErrorCode(err) == influxdb.EUnauthorized {
+			continue
+		}
+
+		checks = append(checks, c)
+	}
+
+	n := len(checks)
+	if opts.Offset >= n {
+		return []influxdb.Check{}, n, nil
+	}
+	checks = checks[opts.Offset:]
+	if opts.Limit > 0 && opts.Limit < len(checks) {
+		checks = checks[:opts.Limit]
+	}
+	return checks, n, nil
+}
+
+// CreateCheck checks to see if the authorizer on context has create access to the global check resource.
+func (s *CheckService) CreateCheck(ctx context.Context, c influxdb.Check, userID influxdb.ID) error {
+	p, err := influxdb.NewPermission(influxdb.CreateAction, influxdb.ChecksResourceType, c.GetOrgID())
+	if err != nil {
+		return err
+	}
+
+	if err := IsAllowed(ctx, *p); err != nil {
+		return err
+	}
+
+	return s.s.CreateCheck(ctx, c, userID)
+}
+
+// UpdateCheck checks to see if the authorizer on context has update access to the check provided.
+func (s *CheckService) UpdateCheck(ctx context.Context, id influxdb.ID, upd influxdb.Check) (influxdb.Check, error) {
+	c, err := s.FindCheckByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeCheckAction(ctx, influxdb.UpdateAction, c.GetOrgID(), id); err != nil {
+		return nil, err
+	}
+
+	return s.s.UpdateCheck(ctx, id, upd)
+}
+
+// PatchCheck checks to see if the authorizer on context has update access to the check provided.
+func (s *CheckService) PatchCheck(ctx context.Context, id influxdb.ID, upd influxdb.CheckUpdate) (influxdb.Check, error) {
+	c, err := s.FindCheckByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeCheckAction(ctx, influxdb.UpdateAction, c.GetOrgID(), id); err != nil {
+		return nil, err
+	}
+
+	return s.s.PatchCheck(ctx, id, upd)
+}
+
+// DeleteCheck checks to see if the authorizer on context has delete access to the check provided.
+func (s *CheckService) DeleteCheck(ctx context.Context, id influxdb.ID) error {
+	c, err := s.FindCheckByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := authorizeCheckAction(ctx, influxdb.DeleteAction, c.GetOrgID(), id); err != nil {
+		return err
+	}
+
+	return s.s.DeleteCheck(ctx, id)
+}
+
+// ArchiveCheck checks to see if the authorizer on context has update access to the check provided.
+func (s *CheckService) ArchiveCheck(ctx context.Context, id influxdb.ID) (influxdb.Check, error) {
+	c, err := s.FindCheckByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeCheckAction(ctx, influxdb.UpdateAction, c.GetOrgID(), id); err != nil {
+		return nil, err
+	}
+
+	return s.s.ArchiveCheck(ctx, id)
+}
+
+// UnarchiveCheck checks to see if the authorizer on context has update access to the check provided.
+func (s *CheckService) UnarchiveCheck(ctx context.Context, id influxdb.ID) (influxdb.Check, error) {
+	c, err := s.FindCheckByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeCheckAction(ctx, influxdb.UpdateAction, c.GetOrgID(), id); err != nil {
+		return nil, err
+	}
+
+	return s.s.UnarchiveCheck(ctx, id)
+}
@@ -0,0 +1,37 @@
+package authorizer
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.CheckApplyService = (*CheckApplyService)(nil)
+
+// CheckApplyService wraps a influxdb.CheckApplyService and authorizes actions
+// against it appropriately.
+type CheckApplyService struct {
+	s influxdb.CheckApplyService
+}
+
+// NewCheckApplyService constructs an instance of an authorizing check apply service.
+func NewCheckApplyService(s influxdb.CheckApplyService) *CheckApplyService {
+	return &CheckApplyService{
+		s: s,
+	}
+}
+
+// ApplyCheck checks to see if the authorizer on context has create and update access
+// to the checks of the organization, as the apply creates the check or updates it.
+func (s *CheckApplyService) ApplyCheck(ctx context.Context, a influxdb.CheckApply, userID influxdb.ID) (*influxdb.CheckApplyResult, error) {
+	for _, action := range []influxdb.Action{influxdb.CreateAction, influxdb.UpdateAction} {
+		p, err := influxdb.NewPermission(action, influxdb.ChecksResourceType, a.OrgID)
+		if err != nil {
+			return nil, err
+		}
+		if err := IsAllowed(ctx, *p); err != nil {
+			return nil, err
+		}
+	}
+	return s.s.ApplyCheck(ctx, a, userID)
+}
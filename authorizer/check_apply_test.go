@@ -0,0 +1,50 @@
+package authorizer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/authorizer"
+	influxdbcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/mock"
+	influxdbtesting "github.com/influxdata/influxdb/testing"
+)
+
+func TestCheckApplyService(t *testing.T) {
+	s := authorizer.NewCheckApplyService(&mock.CheckApplyService{
+		ApplyCheckF: func(ctx context.Context, a influxdb.CheckApply, userID influxdb.ID) (*influxdb.CheckApplyResult, error) {
+			return &influxdb.CheckApplyResult{}, nil
+		},
+	})
+	a := influxdb.CheckApply{OrgID: 10, Name: "cpu", FieldManager: "operator"}
+
+	for _, tt := range []struct {
+		name    string
+		actions []influxdb.Action
+		wantErr bool
+	}{
+		{name: "write", actions: []influxdb.Action{influxdb.WriteAction}},
+		{name: "create and update", actions: []influxdb.Action{influxdb.CreateAction, influxdb.UpdateAction}},
+		{name: "create only", actions: []influxdb.Action{influxdb.CreateAction}, wantErr: true},
+		{name: "read only", actions: []influxdb.Action{influxdb.ReadAction}, wantErr: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var ps []influxdb.Permission
+			for _, action := range tt.actions {
+				ps = append(ps, influxdb.Permission{
+					Action:   action,
+					Resource: influxdb.Resource{Type: influxdb.ChecksResourceType, OrgID: influxdbtesting.IDPtr(10)},
+				})
+			}
+			ctx := influxdbcontext.SetAuthorizer(context.Background(), &Authorizer{ps})
+			_, err := s.ApplyCheck(ctx, a, 1)
+			if tt.wantErr && influxdb.ErrorCode(err) != influxdb.EUnauthorized {
+				t.Errorf("expected the apply to be unauthorized, got %v", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
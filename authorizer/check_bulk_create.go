@@ -0,0 +1,34 @@
+package authorizer
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.CheckBulkCreateService = (*CheckBulkCreateService)(nil)
+
+// CheckBulkCreateService wraps a influxdb.CheckBulkCreateService and authorizes actions
+// against it appropriately.
+type CheckBulkCreateService struct {
+	s influxdb.CheckBulkCreateService
+}
+
+// NewCheckBulkCreateService constructs an instance of an authorizing check bulk create service.
+func NewCheckBulkCreateService(s influxdb.CheckBulkCreateService) *CheckBulkCreateService {
+	return &CheckBulkCreateService{
+		s: s,
+	}
+}
+
+// CreateChecks checks to see if the authorizer on context has create access to the checks of the organization.
+func (s *CheckBulkCreateService) CreateChecks(ctx context.Context, c influxdb.CheckBulkCreate, userID influxdb.ID) error {
+	p, err := influxdb.NewPermission(influxdb.CreateAction, influxdb.ChecksResourceType, c.OrgID)
+	if err != nil {
+		return err
+	}
+	if err := IsAllowed(ctx, *p); err != nil {
+		return err
+	}
+	return s.s.CreateChecks(ctx, c, userID)
+}
@@ -0,0 +1,35 @@
+package authorizer
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.CheckBulkUpdateService = (*CheckBulkUpdateService)(nil)
+
+// CheckBulkUpdateService wraps a influxdb.CheckBulkUpdateService and authorizes actions
+// against it appropriately.
+type CheckBulkUpdateService struct {
+	s influxdb.CheckBulkUpdateService
+}
+
+// NewCheckBulkUpdateService constructs an instance of an authorizing check bulk update service.
+func NewCheckBulkUpdateService(s influxdb.CheckBulkUpdateService) *CheckBulkUpdateService {
+	return &CheckBulkUpdateService{
+		s: s,
+	}
+}
+
+// BulkUpdateChecks checks to see if the authorizer on context has update access to the checks
+// of the organization, a dry run included.
+func (s *CheckBulkUpdateService) BulkUpdateChecks(ctx context.Context, u influxdb.CheckBulkUpdate) (*influxdb.CheckBulkUpdateResult, error) {
+	p, err := influxdb.NewPermission(influxdb.UpdateAction, influxdb.ChecksResourceType, u.Filter.OrgID)
+	if err != nil {
+		return nil, err
+	}
+	if err := IsAllowed(ctx, *p); err != nil {
+		return nil, err
+	}
+	return s.s.BulkUpdateChecks(ctx, u)
+}
@@ -0,0 +1,71 @@
+package authorizer
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.CheckCloneService = (*CheckCloneService)(nil)
+
+// CheckCloneService wraps a influxdb.CheckCloneService and authorizes actions
+// against it appropriately.
+type CheckCloneService struct {
+	s            influxdb.CheckCloneService
+	checkService influxdb.CheckService
+	labelService influxdb.LabelService
+}
+
+// NewCheckCloneService constructs an instance of an authorizing check clone service.
+// The unauthorized check and label services find the organization and the labels
+// of the checks.
+func NewCheckCloneService(s influxdb.CheckCloneService, checkService influxdb.CheckService, labelService influxdb.LabelService) *CheckCloneService {
+	return &CheckCloneService{
+		s:            s,
+		checkService: checkService,
+		labelService: labelService,
+	}
+}
+
+// CloneCheck checks to see if the authorizer on context has read access to the check
+// and create access to the checks of the organization of the copy. A copy in another
+// organization also requires write access to its labels when the check has labels.
+func (s *CheckCloneService) CloneCheck(ctx context.Context, id influxdb.ID, cl influxdb.CheckClone, userID influxdb.ID) (influxdb.Check, error) {
+	c, err := s.checkService.FindCheckByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeReadCheck(ctx, c.GetOrgID(), c.GetID()); err != nil {
+		return nil, err
+	}
+
+	orgID := cl.TargetOrgID(c.GetOrgID())
+	p, err := influxdb.NewPermission(influxdb.CreateAction, influxdb.ChecksResourceType, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if err := IsAllowed(ctx, *p); err != nil {
+		return nil, err
+	}
+
+	if orgID != c.GetOrgID() {
+		labels, err := s.labelService.FindResourceLabels(ctx, influxdb.LabelMappingFilter{
+			ResourceID:   c.GetID(),
+			ResourceType: influxdb.ChecksResourceType,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(labels) > 0 {
+			p, err := influxdb.NewPermission(influxdb.WriteAction, influxdb.LabelsResourceType, orgID)
+			if err != nil {
+				return nil, err
+			}
+			if err := IsAllowed(ctx, *p); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return s.s.CloneCheck(ctx, id, cl, userID)
+}
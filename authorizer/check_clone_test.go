@@ -0,0 +1,145 @@
+package authorizer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/authorizer"
+	influxdbcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/notification/check"
+	influxdbtesting "github.com/influxdata/influxdb/testing"
+)
+
+func TestCheckCloneService_CloneCheck(t *testing.T) {
+	orgPermission := func(a influxdb.Action, rt influxdb.ResourceType, orgID influxdb.ID) influxdb.Permission {
+		return influxdb.Permission{
+			Action: a,
+			Resource: influxdb.Resource{
+				Type:  rt,
+				OrgID: influxdbtesting.IDPtr(orgID),
+			},
+		}
+	}
+	type args struct {
+		permissions []influxdb.Permission
+		clone       influxdb.CheckClone
+		labels      []*influxdb.Label
+	}
+	type wants struct {
+		err error
+	}
+
+	tests := []struct {
+		name  string
+		args  args
+		wants wants
+	}{
+		{
+			name: "authorized to read the check and create checks in its org",
+			args: args{
+				permissions: []influxdb.Permission{
+					orgPermission("read", influxdb.ChecksResourceType, 10),
+					orgPermission("write", influxdb.ChecksResourceType, 10),
+				},
+				clone:  influxdb.CheckClone{Name: "copy"},
+				labels: []*influxdb.Label{{ID: 1, OrgID: 10, Name: "prod"}},
+			},
+		},
+		{
+			name: "unauthorized to read the check",
+			args: args{
+				permissions: []influxdb.Permission{
+					orgPermission("write", influxdb.ChecksResourceType, 11),
+				},
+				clone: influxdb.CheckClone{Name: "copy", OrgID: 11},
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Msg:  "read:orgs/000000000000000a/checks/0000000000000001 is unauthorized",
+					Code: influxdb.EUnauthorized,
+				},
+			},
+		},
+		{
+			name: "unauthorized to create checks in the other org",
+			args: args{
+				permissions: []influxdb.Permission{
+					orgPermission("read", influxdb.ChecksResourceType, 10),
+					orgPermission("write", influxdb.ChecksResourceType, 10),
+				},
+				clone: influxdb.CheckClone{Name: "copy", OrgID: 11},
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Msg:  "create:orgs/000000000000000b/checks is unauthorized",
+					Code: influxdb.EUnauthorized,
+				},
+			},
+		},
+		{
+			name: "authorized to copy a check without labels to the other org",
+			args: args{
+				permissions: []influxdb.Permission{
+					orgPermission("read", influxdb.ChecksResourceType, 10),
+					orgPermission("write", influxdb.ChecksResourceType, 11),
+				},
+				clone: influxdb.CheckClone{Name: "copy", OrgID: 11},
+			},
+		},
+		{
+			name: "unauthorized to write the labels of the other org",
+			args: args{
+				permissions: []influxdb.Permission{
+					orgPermission("read", influxdb.ChecksResourceType, 10),
+					orgPermission("write", influxdb.ChecksResourceType, 11),
+				},
+				clone:  influxdb.CheckClone{Name: "copy", OrgID: 11},
+				labels: []*influxdb.Label{{ID: 1, OrgID: 10, Name: "prod"}},
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Msg:  "write:orgs/000000000000000b/labels is unauthorized",
+					Code: influxdb.EUnauthorized,
+				},
+			},
+		},
+		{
+			name: "authorized to write the labels of the other org",
+			args: args{
+				permissions: []influxdb.Permission{
+					orgPermission("read", influxdb.ChecksResourceType, 10),
+					orgPermission("write", influxdb.ChecksResourceType, 11),
+					orgPermission("write", influxdb.LabelsResourceType, 11),
+				},
+				clone:  influxdb.CheckClone{Name: "copy", OrgID: 11},
+				labels: []*influxdb.Label{{ID: 1, OrgID: 10, Name: "prod"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			labelService := mock.NewLabelService()
+			labelService.FindResourceLabelsFn = func(ctx context.Context, f influxdb.LabelMappingFilter) ([]*influxdb.Label, error) {
+				return tt.args.labels, nil
+			}
+			s := authorizer.NewCheckCloneService(&mock.CheckCloneService{
+				CloneCheckF: func(ctx context.Context, id influxdb.ID, cl influxdb.CheckClone, userID influxdb.ID) (influxdb.Check, error) {
+					return &check.Heartbeat{Base: check.Base{ID: 2, OrgID: cl.TargetOrgID(10), Name: cl.Name}}, nil
+				},
+			}, &mock.CheckService{
+				FindCheckByIDF: func(ctx context.Context, id influxdb.ID) (influxdb.Check, error) {
+					return &check.Heartbeat{Base: check.Base{ID: id, OrgID: 10}}, nil
+				},
+			}, labelService)
+
+			ctx := context.Background()
+			ctx = influxdbcontext.SetAuthorizer(ctx, &Authorizer{tt.args.permissions})
+
+			_, err := s.CloneCheck(ctx, 1, tt.args.clone, 3)
+			influxdbtesting.ErrorsEqual(t, err, tt.wants.err)
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package authorizer
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.CheckCoverageService = (*CheckCoverageService)(nil)
+
+// CheckCoverageService wraps a influxdb.CheckCoverageService and authorizes actions
+// against it appropriately.
+type CheckCoverageService struct {
+	s influxdb.CheckCoverageService
+}
+
+// NewCheckCoverageService constructs an instance of an authorizing check coverage service.
+func NewCheckCoverageService(s influxdb.CheckCoverageService) *CheckCoverageService {
+	return &CheckCoverageService{
+		s: s,
+	}
+}
+
+// FindCheckCoverage checks to see if the authorizer on context has read access to
+// the checks and buckets of the organization.
+func (s *CheckCoverageService) FindCheckCoverage(ctx context.Context, orgID influxdb.ID, bucketIDs []influxdb.ID) (*influxdb.CheckCoverageReport, error) {
+	for _, t := range []influxdb.ResourceType{
+		influxdb.ChecksResourceType,
+		influxdb.BucketsResourceType,
+	} {
+		p, err := influxdb.NewPermission(influxdb.ReadAction, t, orgID)
+		if err != nil {
+			return nil, err
+		}
+		if err := IsAllowed(ctx, *p); err != nil {
+			return nil, err
+		}
+	}
+	return s.s.FindCheckCoverage(ctx, orgID, bucketIDs)
+}
@@ -0,0 +1,76 @@
+package authorizer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/authorizer"
+	influxdbcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/mock"
+	influxdbtesting "github.com/influxdata/influxdb/testing"
+)
+
+func TestCheckCoverageService_FindCheckCoverage(t *testing.T) {
+	orgPermission := func(t influxdb.ResourceType) influxdb.Permission {
+		return influxdb.Permission{
+			Action: "read",
+			Resource: influxdb.Resource{
+				Type:  t,
+				OrgID: influxdbtesting.IDPtr(10),
+			},
+		}
+	}
+	type args struct {
+		permissions []influxdb.Permission
+	}
+	type wants struct {
+		err error
+	}
+
+	tests := []struct {
+		name  string
+		args  args
+		wants wants
+	}{
+		{
+			name: "authorized to read the checks and buckets of the org",
+			args: args{
+				permissions: []influxdb.Permission{
+					orgPermission(influxdb.ChecksResourceType),
+					orgPermission(influxdb.BucketsResourceType),
+				},
+			},
+		},
+		{
+			name: "unauthorized to read the buckets of the org",
+			args: args{
+				permissions: []influxdb.Permission{
+					orgPermission(influxdb.ChecksResourceType),
+				},
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Msg:  "read:orgs/000000000000000a/buckets is unauthorized",
+					Code: influxdb.EUnauthorized,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := authorizer.NewCheckCoverageService(&mock.CheckCoverageService{
+				FindCheckCoverageF: func(ctx context.Context, orgID influxdb.ID, bucketIDs []influxdb.ID) (*influxdb.CheckCoverageReport, error) {
+					return &influxdb.CheckCoverageReport{OrgID: orgID}, nil
+				},
+			})
+
+			ctx := context.Background()
+			ctx = influxdbcontext.SetAuthorizer(ctx, &Authorizer{tt.args.permissions})
+
+			_, err := s.FindCheckCoverage(ctx, 10, nil)
+			influxdbtesting.ErrorsEqual(t, err, tt.wants.err)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package authorizer
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.CheckGCService = (*CheckGCService)(nil)
+
+// CheckGCService wraps a influxdb.CheckGCService and authorizes actions
+// against it appropriately.
+type CheckGCService struct {
+	s influxdb.CheckGCService
+}
+
+// NewCheckGCService constructs an instance of an authorizing check gc service.
+func NewCheckGCService(s influxdb.CheckGCService) *CheckGCService {
+	return &CheckGCService{
+		s: s,
+	}
+}
+
+// RunCheckGC checks to see if the authorizer on context has write access to
+// the checks of every organization.
+func (s *CheckGCService) RunCheckGC(ctx context.Context, p influxdb.CheckGCPolicy) (*influxdb.CheckGCRun, error) {
+	if err := IsAllowed(ctx, influxdb.Permission{
+		Action:   influxdb.WriteAction,
+		Resource: influxdb.Resource{Type: influxdb.ChecksResourceType},
+	}); err != nil {
+		return nil, err
+	}
+	return s.s.RunCheckGC(ctx, p)
+}
+
+// FindCheckGCEvents returns the events of the garbage collection of the
+// checks the authorizer on context has read access to.
+func (s *CheckGCService) FindCheckGCEvents(ctx context.Context, filter influxdb.CheckGCEventFilter) ([]*influxdb.CheckGCEvent, error) {
+	events, err := s.s.FindCheckGCEvents(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := events[:0]
+	for _, ev := range events {
+		p, err := influxdb.NewPermissionAtID(ev.CheckID, influxdb.ReadAction, influxdb.ChecksResourceType, ev.OrgID)
+		if err != nil {
+			return nil, err
+		}
+		err = IsAllowed(ctx, *p)
+		if influxdb.ErrorCode(err) == influxdb.EUnauthorized {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		filtered = append(filtered, ev)
+	}
+	return filtered, nil
+}
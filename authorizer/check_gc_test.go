@@ -0,0 +1,58 @@
+package authorizer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/authorizer"
+	influxdbcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/mock"
+	influxdbtesting "github.com/influxdata/influxdb/testing"
+)
+
+func TestCheckGCService_FindCheckGCEvents(t *testing.T) {
+	s := authorizer.NewCheckGCService(&mock.CheckGCService{
+		FindCheckGCEventsF: func(ctx context.Context, filter influxdb.CheckGCEventFilter) ([]*influxdb.CheckGCEvent, error) {
+			return []*influxdb.CheckGCEvent{
+				{CheckID: 1, OrgID: 10, Action: influxdb.CheckGCNotified},
+				{CheckID: 2, OrgID: 11, Action: influxdb.CheckGCNotified},
+				{CheckID: 1, OrgID: 10, Action: influxdb.CheckGCDeleted},
+			}, nil
+		},
+	})
+
+	ctx := influxdbcontext.SetAuthorizer(context.Background(), &Authorizer{[]influxdb.Permission{
+		{
+			Action:   "read",
+			Resource: influxdb.Resource{Type: influxdb.ChecksResourceType, OrgID: influxdbtesting.IDPtr(10)},
+		},
+	}})
+	events, err := s.FindCheckGCEvents(ctx, influxdb.CheckGCEventFilter{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(events) != 2 || events[0].CheckID != 1 || events[1].CheckID != 1 {
+		t.Errorf("expected only the events of the readable checks, got %+v", events)
+	}
+}
+
+func TestCheckGCService_RunCheckGC(t *testing.T) {
+	s := authorizer.NewCheckGCService(&mock.CheckGCService{
+		RunCheckGCF: func(ctx context.Context, p influxdb.CheckGCPolicy) (*influxdb.CheckGCRun, error) {
+			return &influxdb.CheckGCRun{}, nil
+		},
+	})
+
+	ctx := influxdbcontext.SetAuthorizer(context.Background(), &Authorizer{[]influxdb.Permission{
+		{
+			Action:   "write",
+			Resource: influxdb.Resource{Type: influxdb.ChecksResourceType, OrgID: influxdbtesting.IDPtr(10)},
+		},
+	}})
+	_, err := s.RunCheckGC(ctx, influxdb.CheckGCPolicy{})
+	influxdbtesting.ErrorsEqual(t, err, &influxdb.Error{
+		Msg:  "write:checks is unauthorized",
+		Code: influxdb.EUnauthorized,
+	})
+}
@@ -0,0 +1,41 @@
+package authorizer
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.CheckImportService = (*CheckImportService)(nil)
+
+// CheckImportService wraps a influxdb.CheckImportService and authorizes actions
+// against it appropriately.
+type CheckImportService struct {
+	s influxdb.CheckImportService
+}
+
+// NewCheckImportService constructs an instance of an authorizing check import service.
+func NewCheckImportService(s influxdb.CheckImportService) *CheckImportService {
+	return &CheckImportService{
+		s: s,
+	}
+}
+
+// ImportChecks checks to see if the authorizer on context has create access to the checks of the organization,
+// and update access too when the import overwrites the conflicting checks.
+func (s *CheckImportService) ImportChecks(ctx context.Context, orgID influxdb.ID, imp influxdb.CheckImport, userID influxdb.ID) ([]*influxdb.CheckImportResult, error) {
+	actions := []influxdb.Action{influxdb.CreateAction}
+	if imp.OnConflict == influxdb.ImportOnConflictOverwrite {
+		actions = append(actions, influxdb.UpdateAction)
+	}
+	for _, a := range actions {
+		p, err := influxdb.NewPermission(a, influxdb.ChecksResourceType, orgID)
+		if err != nil {
+			return nil, err
+		}
+		if err := IsAllowed(ctx, *p); err != nil {
+			return nil, err
+		}
+	}
+	return s.s.ImportChecks(ctx, orgID, imp, userID)
+}
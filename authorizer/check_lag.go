@@ -0,0 +1,51 @@
+package authorizer
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.CheckLagService = (*CheckLagService)(nil)
+
+// CheckLagService wraps a influxdb.CheckLagService and authorizes actions
+// against it appropriately.
+type CheckLagService struct {
+	s influxdb.CheckLagService
+}
+
+// NewCheckLagService constructs an instance of an authorizing check lag service.
+func NewCheckLagService(s influxdb.CheckLagService) *CheckLagService {
+	return &CheckLagService{
+		s: s,
+	}
+}
+
+// FindCheckLag checks to see if the authorizer on context has read access to the check.
+func (s *CheckLagService) FindCheckLag(ctx context.Context, checkID influxdb.ID) (*influxdb.CheckLag, error) {
+	l, err := s.s.FindCheckLag(ctx, checkID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeReadCheck(ctx, l.OrgID, l.CheckID); err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// FindCheckLagSummary checks to see if the authorizer on context has read access
+// to every check of the organization.
+func (s *CheckLagService) FindCheckLagSummary(ctx context.Context, orgID influxdb.ID) (*influxdb.CheckLagSummary, error) {
+	p, err := influxdb.NewPermission(influxdb.ReadAction, influxdb.ChecksResourceType, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := IsAllowed(ctx, *p); err != nil {
+		return nil, err
+	}
+
+	return s.s.FindCheckLagSummary(ctx, orgID)
+}
@@ -0,0 +1,49 @@
+package authorizer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/authorizer"
+	influxdbcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/mock"
+	influxdbtesting "github.com/influxdata/influxdb/testing"
+)
+
+func TestCheckLagService(t *testing.T) {
+	s := authorizer.NewCheckLagService(&mock.CheckLagService{
+		FindCheckLagF: func(ctx context.Context, checkID influxdb.ID) (*influxdb.CheckLag, error) {
+			return &influxdb.CheckLag{CheckID: checkID, OrgID: 10}, nil
+		},
+		FindCheckLagSummaryF: func(ctx context.Context, orgID influxdb.ID) (*influxdb.CheckLagSummary, error) {
+			return &influxdb.CheckLagSummary{OrgID: orgID}, nil
+		},
+	})
+
+	ctx := influxdbcontext.SetAuthorizer(context.Background(), &Authorizer{[]influxdb.Permission{
+		{
+			Action:   "read",
+			Resource: influxdb.Resource{Type: influxdb.ChecksResourceType, ID: influxdbtesting.IDPtr(1), OrgID: influxdbtesting.IDPtr(10)},
+		},
+	}})
+	if _, err := s.FindCheckLag(ctx, 1); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := s.FindCheckLag(ctx, 2); influxdb.ErrorCode(err) != influxdb.EUnauthorized {
+		t.Errorf("expected the lag of an unreadable check to be unauthorized, got %v", err)
+	}
+	if _, err := s.FindCheckLagSummary(ctx, 10); influxdb.ErrorCode(err) != influxdb.EUnauthorized {
+		t.Errorf("expected the lag summary to require reading every check of the org, got %v", err)
+	}
+
+	ctx = influxdbcontext.SetAuthorizer(context.Background(), &Authorizer{[]influxdb.Permission{
+		{
+			Action:   "read",
+			Resource: influxdb.Resource{Type: influxdb.ChecksResourceType, OrgID: influxdbtesting.IDPtr(10)},
+		},
+	}})
+	if _, err := s.FindCheckLagSummary(ctx, 10); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
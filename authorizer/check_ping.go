@@ -0,0 +1,39 @@
+package authorizer
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.CheckPingService = (*CheckPingService)(nil)
+
+// CheckPingService wraps a influxdb.CheckPingService and authorizes actions
+// against it appropriately. Pinging a check is authorized as writing it.
+type CheckPingService struct {
+	s            influxdb.CheckPingService
+	checkService influxdb.CheckService
+}
+
+// NewCheckPingService constructs an instance of an authorizing check ping service.
+// The unauthorized check service finds the organization of the checks.
+func NewCheckPingService(s influxdb.CheckPingService, checkService influxdb.CheckService) *CheckPingService {
+	return &CheckPingService{
+		s:            s,
+		checkService: checkService,
+	}
+}
+
+// PingCheck checks to see if the authorizer on context has write access to the check.
+func (s *CheckPingService) PingCheck(ctx context.Context, checkID influxdb.ID) error {
+	c, err := s.checkService.FindCheckByID(ctx, checkID)
+	if err != nil {
+		return err
+	}
+
+	if err := authorizeWriteCheck(ctx, c.GetOrgID(), c.GetID()); err != nil {
+		return err
+	}
+
+	return s.s.PingCheck(ctx, checkID)
+}
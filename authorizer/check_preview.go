@@ -0,0 +1,68 @@
+package authorizer
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification/check"
+)
+
+var _ influxdb.CheckPreviewService = (*CheckPreviewService)(nil)
+
+// CheckPreviewService wraps a influxdb.CheckPreviewService and authorizes actions
+// against it appropriately. Previewing a check is authorized as creating it, and
+// reading the buckets of its flux query, whose data is returned.
+type CheckPreviewService struct {
+	s             influxdb.CheckPreviewService
+	bucketService influxdb.BucketService
+}
+
+// NewCheckPreviewService constructs an instance of an authorizing check preview service.
+// The unauthorized bucket service finds the buckets of the queries.
+func NewCheckPreviewService(s influxdb.CheckPreviewService, bucketService influxdb.BucketService) *CheckPreviewService {
+	return &CheckPreviewService{
+		s:             s,
+		bucketService: bucketService,
+	}
+}
+
+// previewedCheck is a check whose query reads buckets.
+type previewedCheck interface {
+	GetQuery() influxdb.DashboardQuery
+	GetQueryType() string
+}
+
+// PreviewCheckStage checks to see if the authorizer on context has create access to the
+// checks of the organization of the check, and read access to the buckets of its query.
+func (s *CheckPreviewService) PreviewCheckStage(ctx context.Context, c influxdb.Check, stage string) (*influxdb.CheckStagePreview, error) {
+	p, err := influxdb.NewPermission(influxdb.CreateAction, influxdb.ChecksResourceType, c.GetOrgID())
+	if err != nil {
+		return nil, err
+	}
+	if err := IsAllowed(ctx, *p); err != nil {
+		return nil, err
+	}
+
+	if pc, ok := c.(previewedCheck); ok && pc.GetQueryType() == check.QueryTypeFlux {
+		scope, err := check.ParseQueryScope(pc.GetQuery())
+		if err != nil {
+			return nil, err
+		}
+		orgID := c.GetOrgID()
+		for _, name := range scope.Buckets {
+			name := name
+			b, err := s.bucketService.FindBucket(ctx, influxdb.BucketFilter{
+				Name:           &name,
+				OrganizationID: &orgID,
+			})
+			if err != nil {
+				return nil, err
+			}
+			if err := authorizeReadBucket(ctx, orgID, b.ID); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return s.s.PreviewCheckStage(ctx, c, stage)
+}
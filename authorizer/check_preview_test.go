@@ -0,0 +1,122 @@
+package authorizer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/authorizer"
+	influxdbcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/notification/check"
+	influxdbtesting "github.com/influxdata/influxdb/testing"
+)
+
+func TestCheckPreviewService_PreviewCheckStage(t *testing.T) {
+	type args struct {
+		permissions []influxdb.Permission
+	}
+	type wants struct {
+		err error
+	}
+
+	createChecks := influxdb.Permission{
+		Action: "write",
+		Resource: influxdb.Resource{
+			Type:  influxdb.ChecksResourceType,
+			OrgID: influxdbtesting.IDPtr(10),
+		},
+	}
+	tests := []struct {
+		name  string
+		args  args
+		wants wants
+	}{
+		{
+			name: "authorized to create checks and read the buckets",
+			args: args{
+				permissions: []influxdb.Permission{
+					createChecks,
+					{
+						Action: "read",
+						Resource: influxdb.Resource{
+							Type:  influxdb.BucketsResourceType,
+							OrgID: influxdbtesting.IDPtr(10),
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "unauthorized to read the baseline bucket",
+			args: args{
+				permissions: []influxdb.Permission{
+					createChecks,
+					{
+						Action: "read",
+						Resource: influxdb.Resource{
+							Type: influxdb.BucketsResourceType,
+							ID:   influxdbtesting.IDPtr(1),
+						},
+					},
+				},
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Msg:  "read:orgs/000000000000000a/buckets/0000000000000002 is unauthorized",
+					Code: influxdb.EUnauthorized,
+				},
+			},
+		},
+		{
+			name: "unauthorized to create checks",
+			args: args{
+				permissions: []influxdb.Permission{
+					{
+						Action: "read",
+						Resource: influxdb.Resource{
+							Type:  influxdb.ChecksResourceType,
+							OrgID: influxdbtesting.IDPtr(10),
+						},
+					},
+				},
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Msg:  "create:orgs/000000000000000a/checks is unauthorized",
+					Code: influxdb.EUnauthorized,
+				},
+			},
+		},
+	}
+
+	buckets := map[string]influxdb.ID{"telegraf": 1, "baseline": 2}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := authorizer.NewCheckPreviewService(&mock.CheckPreviewService{
+				PreviewCheckStageF: func(ctx context.Context, c influxdb.Check, stage string) (*influxdb.CheckStagePreview, error) {
+					return &influxdb.CheckStagePreview{Stage: stage}, nil
+				},
+			}, &mock.BucketService{
+				FindBucketFn: func(ctx context.Context, filter influxdb.BucketFilter) (*influxdb.Bucket, error) {
+					return &influxdb.Bucket{ID: buckets[*filter.Name], OrgID: *filter.OrganizationID, Name: *filter.Name}, nil
+				},
+			})
+
+			ctx := context.Background()
+			ctx = influxdbcontext.SetAuthorizer(ctx, &Authorizer{tt.args.permissions})
+
+			c := &check.Threshold{
+				Base: check.Base{
+					OrgID: 10,
+					Stages: []check.QueryStage{
+						{Name: "fetch", Text: `from(bucket: "telegraf") |> range(start: -1h)`},
+						{Name: "baseline", Text: `from(bucket: "baseline") |> range(start: -1h)`},
+					},
+				},
+			}
+			_, err := s.PreviewCheckStage(ctx, c, "fetch")
+			influxdbtesting.ErrorsEqual(t, err, tt.wants.err)
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package authorizer
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.CheckRelatedService = (*CheckRelatedService)(nil)
+
+// CheckRelatedService wraps a influxdb.CheckRelatedService and authorizes actions
+// against it appropriately. The resources linked to a check are returned only
+// when the authorizer on context can read them.
+type CheckRelatedService struct {
+	s influxdb.CheckRelatedService
+}
+
+// NewCheckRelatedService constructs an instance of an authorizing check related service.
+func NewCheckRelatedService(s influxdb.CheckRelatedService) *CheckRelatedService {
+	return &CheckRelatedService{
+		s: s,
+	}
+}
+
+// FindCheckRelated checks to see if the authorizer on context has read access to the check,
+// and drops the linked resources it can't read.
+func (s *CheckRelatedService) FindCheckRelated(ctx context.Context, checkID influxdb.ID) (*influxdb.CheckRelated, error) {
+	r, err := s.s.FindCheckRelated(ctx, checkID)
+	if err != nil {
+		return nil, err
+	}
+
+	orgID := r.Check.GetOrgID()
+	if err := authorizeReadCheck(ctx, orgID, r.Check.GetID()); err != nil {
+		return nil, err
+	}
+
+	if r.Task != nil {
+		ok, err := canRead(ctx, influxdb.TasksResourceType, r.Task.OrganizationID, r.Task.ID)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			r.Task = nil
+		}
+	}
+
+	rules := r.NotificationRules[:0]
+	for _, nr := range r.NotificationRules {
+		ok, err := canRead(ctx, influxdb.NotificationRuleResourceType, nr.GetOrgID(), nr.GetID())
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			rules = append(rules, nr)
+		}
+	}
+	r.NotificationRules = rules
+
+	endpoints := r.NotificationEndpoints[:0]
+	for _, edp := range r.NotificationEndpoints {
+		ok, err := canRead(ctx, influxdb.NotificationEndpointResourceType, edp.GetOrgID(), edp.GetID())
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			endpoints = append(endpoints, edp)
+		}
+	}
+	r.NotificationEndpoints = endpoints
+
+	// a delivery is read as the rule which sent it.
+	deliveries := r.Deliveries[:0]
+	for _, d := range r.Deliveries {
+		ok, err := canRead(ctx, influxdb.NotificationRuleResourceType, orgID, d.RuleID)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			deliveries = append(deliveries, d)
+		}
+	}
+	r.Deliveries = deliveries
+
+	labels := r.Labels[:0]
+	for _, l := range r.Labels {
+		ok, err := canRead(ctx, influxdb.LabelsResourceType, l.OrgID, l.ID)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			labels = append(labels, l)
+		}
+	}
+	r.Labels = labels
+
+	return r, nil
+}
+
+// canRead returns whether the authorizer on context can read the resource of type t.
+func canRead(ctx context.Context, t influxdb.ResourceType, orgID, id influxdb.ID) (bool, error) {
+	p, err := influxdb.NewPermissionAtID(id, influxdb.ReadAction, t, orgID)
+	if err != nil {
+		return false, err
+	}
+	err = IsAllowed(ctx, *p)
+	if influxdb.ErrorCode(err) == influxdb.EUnauthorized {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
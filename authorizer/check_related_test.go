@@ -0,0 +1,65 @@
+package authorizer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/authorizer"
+	influxdbcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/notification/check"
+	"github.com/influxdata/influxdb/notification/endpoint"
+	"github.com/influxdata/influxdb/notification/rule"
+	influxdbtesting "github.com/influxdata/influxdb/testing"
+)
+
+func TestCheckRelatedService_FindCheckRelated(t *testing.T) {
+	s := authorizer.NewCheckRelatedService(&mock.CheckRelatedService{
+		FindCheckRelatedF: func(ctx context.Context, checkID influxdb.ID) (*influxdb.CheckRelated, error) {
+			return &influxdb.CheckRelated{
+				Check: &check.Deadman{Base: check.Base{ID: checkID, OrgID: 10}},
+				Task:  &influxdb.Task{ID: 2, OrganizationID: 10},
+				NotificationRules: []influxdb.NotificationRule{
+					&rule.Slack{Base: rule.Base{ID: 4, OrgID: 10}},
+				},
+				NotificationEndpoints: []influxdb.NotificationEndpoint{
+					&endpoint.Slack{Base: endpoint.Base{ID: 3, OrgID: 10}},
+				},
+				Deliveries: []influxdb.CheckDelivery{{StatusID: 5, RuleID: 4}},
+				Labels:     []*influxdb.Label{{ID: 6, OrgID: 10}},
+			}, nil
+		},
+	})
+
+	permission := func(t influxdb.ResourceType) influxdb.Permission {
+		return influxdb.Permission{
+			Action:   "read",
+			Resource: influxdb.Resource{Type: t, OrgID: influxdbtesting.IDPtr(10)},
+		}
+	}
+
+	ctx := influxdbcontext.SetAuthorizer(context.Background(), &Authorizer{[]influxdb.Permission{
+		permission(influxdb.ChecksResourceType),
+		permission(influxdb.NotificationEndpointResourceType),
+	}})
+	r, err := s.FindCheckRelated(ctx, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if r.Task != nil || len(r.NotificationRules) != 0 || len(r.Deliveries) != 0 || len(r.Labels) != 0 {
+		t.Errorf("expected the unreadable resources to be dropped, got %+v", r)
+	}
+	if len(r.NotificationEndpoints) != 1 {
+		t.Errorf("expected the readable endpoint, got %v", r.NotificationEndpoints)
+	}
+
+	ctx = influxdbcontext.SetAuthorizer(context.Background(), &Authorizer{[]influxdb.Permission{
+		permission(influxdb.NotificationRuleResourceType),
+	}})
+	_, err = s.FindCheckRelated(ctx, 1)
+	influxdbtesting.ErrorsEqual(t, err, &influxdb.Error{
+		Msg:  "read:orgs/000000000000000a/checks/0000000000000001 is unauthorized",
+		Code: influxdb.EUnauthorized,
+	})
+}
@@ -0,0 +1,66 @@
+package authorizer
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.CheckStatusService = (*CheckStatusService)(nil)
+
+// CheckStatusService wraps a influxdb.CheckStatusService and authorizes actions
+// against it appropriately.
+type CheckStatusService struct {
+	s influxdb.CheckStatusService
+}
+
+// NewCheckStatusService constructs an instance of an authorizing check status service.
+func NewCheckStatusService(s influxdb.CheckStatusService) *CheckStatusService {
+	return &CheckStatusService{
+		s: s,
+	}
+}
+
+// FindCheckStatuses checks to see if the authorizer on context has read access to every check.
+func (s *CheckStatusService) FindCheckStatuses(ctx context.Context, checkIDs []influxdb.ID) ([]*influxdb.CheckStatus, error) {
+	sts, err := s.s.FindCheckStatuses(ctx, checkIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, st := range sts {
+		if err := authorizeReadCheck(ctx, st.OrgID, st.CheckID); err != nil {
+			return nil, err
+		}
+	}
+
+	return sts, nil
+}
+
+// FindCheckStatusHistory checks to see if the authorizer on context has read access to the check.
+func (s *CheckStatusService) FindCheckStatusHistory(ctx context.Context, checkID influxdb.ID, filter influxdb.CheckStatusHistoryFilter) (*influxdb.CheckStatusHistory, error) {
+	h, err := s.s.FindCheckStatusHistory(ctx, checkID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeReadCheck(ctx, h.OrgID, h.CheckID); err != nil {
+		return nil, err
+	}
+
+	return h, nil
+}
+
+// FindCheckQuery checks to see if the authorizer on context has read access to the check.
+func (s *CheckStatusService) FindCheckQuery(ctx context.Context, checkID influxdb.ID) (*influxdb.CheckQuery, error) {
+	q, err := s.s.FindCheckQuery(ctx, checkID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeReadCheck(ctx, q.OrgID, q.CheckID); err != nil {
+		return nil, err
+	}
+
+	return q, nil
+}
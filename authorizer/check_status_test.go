@@ -0,0 +1,42 @@
+package authorizer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/authorizer"
+	influxdbcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/mock"
+	influxdbtesting "github.com/influxdata/influxdb/testing"
+)
+
+func TestCheckStatusService_FindCheckStatuses(t *testing.T) {
+	s := authorizer.NewCheckStatusService(&mock.CheckStatusService{
+		FindCheckStatusesF: func(ctx context.Context, checkIDs []influxdb.ID) ([]*influxdb.CheckStatus, error) {
+			sts := make([]*influxdb.CheckStatus, len(checkIDs))
+			for i, id := range checkIDs {
+				sts[i] = &influxdb.CheckStatus{CheckID: id, OrgID: 10, Level: "crit"}
+			}
+			return sts, nil
+		},
+	})
+
+	ctx := influxdbcontext.SetAuthorizer(context.Background(), &Authorizer{[]influxdb.Permission{
+		{
+			Action:   "read",
+			Resource: influxdb.Resource{Type: influxdb.ChecksResourceType, ID: influxdbtesting.IDPtr(1), OrgID: influxdbtesting.IDPtr(10)},
+		},
+	}})
+	sts, err := s.FindCheckStatuses(ctx, []influxdb.ID{1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sts) != 1 || sts[0].Level != "crit" {
+		t.Errorf("unexpected statuses %+v", sts)
+	}
+
+	if _, err := s.FindCheckStatuses(ctx, []influxdb.ID{1, 2}); influxdb.ErrorCode(err) != influxdb.EUnauthorized {
+		t.Errorf("expected the statuses of an unreadable check to be unauthorized, got %v", err)
+	}
+}
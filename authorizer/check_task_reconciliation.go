@@ -0,0 +1,76 @@
+package authorizer
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.CheckTaskReconciler = (*CheckTaskReconciler)(nil)
+
+// CheckTaskReconciler wraps a influxdb.CheckTaskReconciler and authorizes actions
+// against it appropriately.
+type CheckTaskReconciler struct {
+	s influxdb.CheckTaskReconciler
+}
+
+// NewCheckTaskReconciler constructs an instance of an authorizing check task reconciler.
+func NewCheckTaskReconciler(s influxdb.CheckTaskReconciler) *CheckTaskReconciler {
+	return &CheckTaskReconciler{
+		s: s,
+	}
+}
+
+// ReconcileCheckTasks checks to see if the authorizer on context has write access
+// to the checks and the tasks of every organization.
+func (s *CheckTaskReconciler) ReconcileCheckTasks(ctx context.Context, p influxdb.CheckTaskReconcilePolicy) (*influxdb.CheckTaskReconciliation, error) {
+	for _, t := range []influxdb.ResourceType{influxdb.ChecksResourceType, influxdb.TasksResourceType} {
+		if err := IsAllowed(ctx, influxdb.Permission{
+			Action:   influxdb.WriteAction,
+			Resource: influxdb.Resource{Type: t},
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return s.s.ReconcileCheckTasks(ctx, p)
+}
+
+// FindCheckTaskReconciliation returns the latest reconciliation, limited to
+// the checks and the tasks the authorizer on context has read access to.
+func (s *CheckTaskReconciler) FindCheckTaskReconciliation(ctx context.Context) (*influxdb.CheckTaskReconciliation, error) {
+	r, err := s.s.FindCheckTaskReconciliation(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	checks, err := filterReconciledResources(ctx, r.Checks, influxdb.ChecksResourceType)
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := filterReconciledResources(ctx, r.Tasks, influxdb.TasksResourceType)
+	if err != nil {
+		return nil, err
+	}
+	r.Checks, r.Tasks = checks, tasks
+	return r, nil
+}
+
+// filterReconciledResources returns the resources of type t the authorizer on context can read.
+func filterReconciledResources(ctx context.Context, rs []influxdb.ReconciledResource, t influxdb.ResourceType) ([]influxdb.ReconciledResource, error) {
+	filtered := rs[:0]
+	for _, r := range rs {
+		p, err := influxdb.NewPermissionAtID(r.ID, influxdb.ReadAction, t, r.OrgID)
+		if err != nil {
+			return nil, err
+		}
+		err = IsAllowed(ctx, *p)
+		if influxdb.ErrorCode(err) == influxdb.EUnauthorized {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		filtered = append(filtered, r)
+	}
+	return filtered, nil
+}
@@ -0,0 +1,75 @@
+package authorizer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/authorizer"
+	influxdbcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/mock"
+	influxdbtesting "github.com/influxdata/influxdb/testing"
+)
+
+func TestCheckTaskReconciler_FindCheckTaskReconciliation(t *testing.T) {
+	s := authorizer.NewCheckTaskReconciler(&mock.CheckTaskReconciler{
+		FindCheckTaskReconciliationF: func(ctx context.Context) (*influxdb.CheckTaskReconciliation, error) {
+			return &influxdb.CheckTaskReconciliation{
+				Policy: influxdb.CheckTaskReconcileRepair,
+				Checks: []influxdb.ReconciledResource{
+					{ID: 1, OrgID: 10, Reason: influxdb.ReconcileCheckMissingTask},
+					{ID: 2, OrgID: 11, Reason: influxdb.ReconcileCheckMissingTask},
+				},
+				Tasks: []influxdb.ReconciledResource{
+					{ID: 3, OrgID: 10, Reason: influxdb.ReconcileTaskOrphaned},
+				},
+			}, nil
+		},
+	})
+
+	ctx := influxdbcontext.SetAuthorizer(context.Background(), &Authorizer{[]influxdb.Permission{
+		{
+			Action:   "read",
+			Resource: influxdb.Resource{Type: influxdb.ChecksResourceType, OrgID: influxdbtesting.IDPtr(10)},
+		},
+	}})
+	r, err := s.FindCheckTaskReconciliation(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := &influxdb.CheckTaskReconciliation{
+		Policy: influxdb.CheckTaskReconcileRepair,
+		Checks: []influxdb.ReconciledResource{
+			{ID: 1, OrgID: 10, Reason: influxdb.ReconcileCheckMissingTask},
+		},
+		Tasks: []influxdb.ReconciledResource{},
+	}
+	if diff := cmp.Diff(want, r); diff != "" {
+		t.Errorf("unexpected reconciliation -want/+got\n%s", diff)
+	}
+}
+
+func TestCheckTaskReconciler_ReconcileCheckTasks(t *testing.T) {
+	s := authorizer.NewCheckTaskReconciler(&mock.CheckTaskReconciler{
+		ReconcileCheckTasksF: func(ctx context.Context, p influxdb.CheckTaskReconcilePolicy) (*influxdb.CheckTaskReconciliation, error) {
+			return &influxdb.CheckTaskReconciliation{Policy: p}, nil
+		},
+	})
+
+	ctx := influxdbcontext.SetAuthorizer(context.Background(), &Authorizer{[]influxdb.Permission{
+		{
+			Action:   "write",
+			Resource: influxdb.Resource{Type: influxdb.ChecksResourceType, OrgID: influxdbtesting.IDPtr(10)},
+		},
+		{
+			Action:   "write",
+			Resource: influxdb.Resource{Type: influxdb.TasksResourceType, OrgID: influxdbtesting.IDPtr(10)},
+		},
+	}})
+	_, err := s.ReconcileCheckTasks(ctx, influxdb.CheckTaskReconcileRepair)
+	influxdbtesting.ErrorsEqual(t, err, &influxdb.Error{
+		Msg:  "write:checks is unauthorized",
+		Code: influxdb.EUnauthorized,
+	})
+}
@@ -0,0 +1,362 @@
+package authorizer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/authorizer"
+	influxdbcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/notification/check"
+	influxdbtesting "github.com/influxdata/influxdb/testing"
+)
+
+func TestCheckService_FindCheckByID(t *testing.T) {
+	type args struct {
+		permission influxdb.Permission
+		id         influxdb.ID
+	}
+	type wants struct {
+		err error
+	}
+
+	tests := []struct {
+		name  string
+		args  args
+		wants wants
+	}{
+		{
+			name: "authorized to access id",
+			args: args{
+				permission: influxdb.Permission{
+					Action: "read",
+					Resource: influxdb.Resource{
+						Type: influxdb.ChecksResourceType,
+						ID:   influxdbtesting.IDPtr(1),
+					},
+				},
+				id: 1,
+			},
+		},
+		{
+			name: "unauthorized to access id",
+			args: args{
+				permission: influxdb.Permission{
+					Action: "read",
+					Resource: influxdb.Resource{
+						Type: influxdb.ChecksResourceType,
+						ID:   influxdbtesting.IDPtr(2),
+					},
+				},
+				id: 1,
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Msg:  "read:orgs/000000000000000a/checks/0000000000000001 is unauthorized",
+					Code: influxdb.EUnauthorized,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := authorizer.NewCheckService(&mock.CheckService{
+				FindCheckByIDF: func(ctx context.Context, id influxdb.ID) (influxdb.Check, error) {
+					return &check.Deadman{
+						Base: check.Base{
+							ID:    id,
+							OrgID: 10,
+						},
+					}, nil
+				},
+			})
+
+			ctx := context.Background()
+			ctx = influxdbcontext.SetAuthorizer(ctx, &Authorizer{[]influxdb.Permission{tt.args.permission}})
+
+			_, err := s.FindCheckByID(ctx, tt.args.id)
+			influxdbtesting.ErrorsEqual(t, err, tt.wants.err)
+		})
+	}
+}
+
+func TestCheckService_FindChecks(t *testing.T) {
+	type args struct {
+		permission influxdb.Permission
+	}
+	type wants struct {
+		ids []influxdb.ID
+	}
+
+	tests := []struct {
+		name  string
+		args  args
+		wants wants
+	}{
+		{
+			name: "authorized to see all checks of the org",
+			args: args{
+				permission: influxdb.Permission{
+					Action: "read",
+					Resource: influxdb.Resource{
+						Type:  influxdb.ChecksResourceType,
+						OrgID: influxdbtesting.IDPtr(10),
+					},
+				},
+			},
+			wants: wants{
+				ids: []influxdb.ID{1, 2},
+			},
+		},
+		{
+			name: "authorized to see a single check",
+			args: args{
+				permission: influxdb.Permission{
+					Action: "read",
+					Resource: influxdb.Resource{
+						Type: influxdb.ChecksResourceType,
+						ID:   influxdbtesting.IDPtr(2),
+					},
+				},
+			},
+			wants: wants{
+				ids: []influxdb.ID{2},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := authorizer.NewCheckService(&mock.CheckService{
+				FindChecksF: func(ctx context.Context, filter influxdb.CheckFilter, opt ...influxdb.FindOptions) ([]influxdb.Check, int, error) {
+					return []influxdb.Check{
+						&check.Deadman{Base: check.Base{ID: 1, OrgID: 10}},
+						&check.Threshold{Base: check.Base{ID: 2, OrgID: 10}},
+						&check.Deadman{Base: check.Base{ID: 3, OrgID: 11}},
+					}, 3, nil
+				},
+			})
+
+			ctx := context.Background()
+			ctx = influxdbcontext.SetAuthorizer(ctx, &Authorizer{[]influxdb.Permission{tt.args.permission}})
+
+			cs, _, err := s.FindChecks(ctx, influxdb.CheckFilter{})
+			influxdbtesting.ErrorsEqual(t, err, nil)
+
+			ids := make([]influxdb.ID, 0, len(cs))
+			for _, c := range cs {
+				ids = append(ids, c.GetID())
+			}
+			if diff := cmp.Diff(ids, tt.wants.ids); diff != "" {
+				t.Errorf("checks are different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+func TestCheckService_FindChecks_paging(t *testing.T) {
+	var got []influxdb.FindOptions
+	s := authorizer.NewCheckService(&mock.CheckService{
+		FindChecksF: func(ctx context.Context, filter influxdb.CheckFilter, opt ...influxdb.FindOptions) ([]influxdb.Check, int, error) {
+			got = append(got, opt[0])
+			cs := []influxdb.Check{
+				&check.Deadman{Base: check.Base{ID: 1, OrgID: 10}},
+				&check.Deadman{Base: check.Base{ID: 2, OrgID: 10}},
+				&check.Deadman{Base: check.Base{ID: 3, OrgID: 10}},
+				&check.Deadman{Base: check.Base{ID: 4, OrgID: 10}},
+			}
+			if opt[0].Limit > 0 {
+				return cs[opt[0].Offset : opt[0].Offset+opt[0].Limit], len(cs), nil
+			}
+			return cs, len(cs), nil
+		},
+	})
+	orgID := influxdb.ID(10)
+	opts := influxdb.FindOptions{Offset: 1, Limit: 1}
+
+	// the store pages the checks of an organization the authorizer can read.
+	ctx := influxdbcontext.SetAuthorizer(context.Background(), &Authorizer{[]influxdb.Permission{
+		{Action: "read", Resource: influxdb.Resource{Type: influxdb.ChecksResourceType, OrgID: &orgID}},
+	}})
+	cs, n, err := s.FindChecks(ctx, influxdb.CheckFilter{OrgID: &orgID}, opts)
+	influxdbtesting.ErrorsEqual(t, err, nil)
+	if len(cs) != 1 || cs[0].GetID() != 2 || n != 4 || got[0] != opts {
+		t.Errorf("unexpected page %v of %d checks, found with %+v", cs, n, got)
+	}
+
+	// the authorized checks are paged and counted after they are filtered.
+	got = nil
+	ctx = influxdbcontext.SetAuthorizer(context.Background(), &Authorizer{[]influxdb.Permission{
+		{Action: "read", Resource: influxdb.Resource{Type: influxdb.ChecksResourceType, ID: influxdbtesting.IDPtr(2)}},
+		{Action: "read", Resource: influxdb.Resource{Type: influxdb.ChecksResourceType, ID: influxdbtesting.IDPtr(4)}},
+	}})
+	cs, n, err = s.FindChecks(ctx, influxdb.CheckFilter{OrgID: &orgID}, opts)
+	influxdbtesting.ErrorsEqual(t, err, nil)
+	if len(cs) != 1 || cs[0].GetID() != 4 || n != 2 || got[0] != (influxdb.FindOptions{}) {
+		t.Errorf("unexpected page %v of %d checks, found with %+v", cs, n, got)
+	}
+}
+
+func TestCheckService_CreateCheck(t *testing.T) {
+	type args struct {
+		permission influxdb.Permission
+		orgID      influxdb.ID
+	}
+	type wants struct {
+		err error
+	}
+
+	tests := []struct {
+		name  string
+		args  args
+		wants wants
+	}{
+		{
+			name: "authorized to create check",
+			args: args{
+				orgID: 10,
+				permission: influxdb.Permission{
+					Action: "write",
+					Resource: influxdb.Resource{
+						Type:  influxdb.ChecksResourceType,
+						OrgID: influxdbtesting.IDPtr(10),
+					},
+				},
+			},
+		},
+		{
+			name: "authorized to create check with a create token",
+			args: args{
+				orgID: 10,
+				permission: influxdb.Permission{
+					Action: influxdb.CreateAction,
+					Resource: influxdb.Resource{
+						Type:  influxdb.ChecksResourceType,
+						OrgID: influxdbtesting.IDPtr(10),
+					},
+				},
+			},
+		},
+		{
+			name: "unauthorized to create check",
+			args: args{
+				orgID: 10,
+				permission: influxdb.Permission{
+					Action: "write",
+					Resource: influxdb.Resource{
+						Type:  influxdb.ChecksResourceType,
+						OrgID: influxdbtesting.IDPtr(11),
+					},
+				},
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Msg:  "create:orgs/000000000000000a/checks is unauthorized",
+					Code: influxdb.EUnauthorized,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := authorizer.NewCheckService(&mock.CheckService{
+				CreateCheckF: func(ctx context.Context, c influxdb.Check, userID influxdb.ID) error {
+					return nil
+				},
+			})
+
+			ctx := context.Background()
+			ctx = influxdbcontext.SetAuthorizer(ctx, &Authorizer{[]influxdb.Permission{tt.args.permission}})
+
+			err := s.CreateCheck(ctx, &check.Deadman{Base: check.Base{OrgID: tt.args.orgID}}, 1)
+			influxdbtesting.ErrorsEqual(t, err, tt.wants.err)
+		})
+	}
+}
+
+func TestCheckService_DeleteCheck(t *testing.T) {
+	type args struct {
+		permissions []influxdb.Permission
+	}
+	type wants struct {
+		err error
+	}
+
+	tests := []struct {
+		name  string
+		args  args
+		wants wants
+	}{
+		{
+			name: "authorized to delete check",
+			args: args{
+				permissions: []influxdb.Permission{
+					{
+						Action: influxdb.ReadAction,
+						Resource: influxdb.Resource{
+							Type:  influxdb.ChecksResourceType,
+							OrgID: influxdbtesting.IDPtr(10),
+						},
+					},
+					{
+						Action: influxdb.DeleteAction,
+						Resource: influxdb.Resource{
+							Type:  influxdb.ChecksResourceType,
+							OrgID: influxdbtesting.IDPtr(10),
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "unauthorized to delete check with an update token",
+			args: args{
+				permissions: []influxdb.Permission{
+					{
+						Action: influxdb.ReadAction,
+						Resource: influxdb.Resource{
+							Type:  influxdb.ChecksResourceType,
+							OrgID: influxdbtesting.IDPtr(10),
+						},
+					},
+					{
+						Action: influxdb.UpdateAction,
+						Resource: influxdb.Resource{
+							Type:  influxdb.ChecksResourceType,
+							OrgID: influxdbtesting.IDPtr(10),
+						},
+					},
+				},
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Msg:  "delete:orgs/000000000000000a/checks/0000000000000001 is unauthorized",
+					Code: influxdb.EUnauthorized,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := authorizer.NewCheckService(&mock.CheckService{
+				FindCheckByIDF: func(ctx context.Context, id influxdb.ID) (influxdb.Check, error) {
+					return &check.Deadman{Base: check.Base{ID: id, OrgID: 10}}, nil
+				},
+				DeleteCheckF: func(ctx context.Context, id influxdb.ID) error {
+					return nil
+				},
+			})
+
+			ctx := context.Background()
+			ctx = influxdbcontext.SetAuthorizer(ctx, &Authorizer{tt.args.permissions})
+
+			err := s.DeleteCheck(ctx, 1)
+			influxdbtesting.ErrorsEqual(t, err, tt.wants.err)
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package authorizer
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.CheckTransferService = (*CheckTransferService)(nil)
+
+// CheckTransferService wraps a influxdb.CheckTransferService and authorizes actions
+// against it appropriately.
+type CheckTransferService struct {
+	s influxdb.CheckTransferService
+}
+
+// NewCheckTransferService constructs an instance of an authorizing check transfer service.
+func NewCheckTransferService(s influxdb.CheckTransferService) *CheckTransferService {
+	return &CheckTransferService{
+		s: s,
+	}
+}
+
+// TransferCheck checks to see if the authorizer on context has update access to the
+// checks of every organization, and to their notification rules if they're moved too.
+func (s *CheckTransferService) TransferCheck(ctx context.Context, id influxdb.ID, t influxdb.CheckTransfer) (*influxdb.CheckTransferResult, error) {
+	types := []influxdb.ResourceType{influxdb.ChecksResourceType}
+	if t.IncludeRules {
+		types = append(types, influxdb.NotificationRuleResourceType)
+	}
+	for _, rt := range types {
+		p, err := influxdb.NewGlobalPermission(influxdb.UpdateAction, rt)
+		if err != nil {
+			return nil, err
+		}
+		if err := IsAllowed(ctx, *p); err != nil {
+			return nil, err
+		}
+	}
+	return s.s.TransferCheck(ctx, id, t)
+}
@@ -0,0 +1,106 @@
+package authorizer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/authorizer"
+	influxdbcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/mock"
+	influxdbtesting "github.com/influxdata/influxdb/testing"
+)
+
+func TestCheckTransferService_TransferCheck(t *testing.T) {
+	writeAll := func(t influxdb.ResourceType) influxdb.Permission {
+		return influxdb.Permission{
+			Action: "write",
+			Resource: influxdb.Resource{
+				Type: t,
+			},
+		}
+	}
+	type args struct {
+		permissions  []influxdb.Permission
+		includeRules bool
+	}
+	type wants struct {
+		err error
+	}
+
+	tests := []struct {
+		name  string
+		args  args
+		wants wants
+	}{
+		{
+			name: "authorized to write all checks",
+			args: args{
+				permissions: []influxdb.Permission{
+					writeAll(influxdb.ChecksResourceType),
+				},
+			},
+		},
+		{
+			name: "unauthorized to update the checks of every org",
+			args: args{
+				permissions: []influxdb.Permission{
+					{
+						Action: "write",
+						Resource: influxdb.Resource{
+							Type:  influxdb.ChecksResourceType,
+							OrgID: influxdbtesting.IDPtr(10),
+						},
+					},
+				},
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Msg:  "update:checks is unauthorized",
+					Code: influxdb.EUnauthorized,
+				},
+			},
+		},
+		{
+			name: "authorized to write all checks and notification rules",
+			args: args{
+				permissions: []influxdb.Permission{
+					writeAll(influxdb.ChecksResourceType),
+					writeAll(influxdb.NotificationRuleResourceType),
+				},
+				includeRules: true,
+			},
+		},
+		{
+			name: "unauthorized to update all notification rules",
+			args: args{
+				permissions: []influxdb.Permission{
+					writeAll(influxdb.ChecksResourceType),
+				},
+				includeRules: true,
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Msg:  "update:notificationRules is unauthorized",
+					Code: influxdb.EUnauthorized,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := authorizer.NewCheckTransferService(&mock.CheckTransferService{
+				TransferCheckF: func(ctx context.Context, id influxdb.ID, t influxdb.CheckTransfer) (*influxdb.CheckTransferResult, error) {
+					return &influxdb.CheckTransferResult{}, nil
+				},
+			})
+
+			ctx := context.Background()
+			ctx = influxdbcontext.SetAuthorizer(ctx, &Authorizer{tt.args.permissions})
+
+			_, err := s.TransferCheck(ctx, 1, influxdb.CheckTransfer{OrgID: 11, IncludeRules: tt.args.includeRules})
+			influxdbtesting.ErrorsEqual(t, err, tt.wants.err)
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package authorizer
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.CheckWatchService = (*CheckWatchService)(nil)
+
+// CheckWatchService wraps a influxdb.CheckWatchService and authorizes actions
+// against it appropriately.
+type CheckWatchService struct {
+	s influxdb.CheckWatchService
+}
+
+// NewCheckWatchService constructs an instance of an authorizing check watch service.
+func NewCheckWatchService(s influxdb.CheckWatchService) *CheckWatchService {
+	return &CheckWatchService{
+		s: s,
+	}
+}
+
+// FindCheckEvents checks to see if the authorizer on context has read access
+// to every check of the organization.
+func (s *CheckWatchService) FindCheckEvents(ctx context.Context, filter influxdb.CheckEventFilter) ([]*influxdb.CheckEvent, uint64, error) {
+	p, err := influxdb.NewPermission(influxdb.ReadAction, influxdb.ChecksResourceType, filter.OrgID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err := IsAllowed(ctx, *p); err != nil {
+		return nil, 0, err
+	}
+
+	return s.s.FindCheckEvents(ctx, filter)
+}
@@ -0,0 +1,43 @@
+package authorizer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/authorizer"
+	influxdbcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/mock"
+	influxdbtesting "github.com/influxdata/influxdb/testing"
+)
+
+func TestCheckWatchService(t *testing.T) {
+	s := authorizer.NewCheckWatchService(&mock.CheckWatchService{
+		FindCheckEventsF: func(ctx context.Context, filter influxdb.CheckEventFilter) ([]*influxdb.CheckEvent, uint64, error) {
+			return []*influxdb.CheckEvent{}, 1, nil
+		},
+	})
+
+	ctx := influxdbcontext.SetAuthorizer(context.Background(), &Authorizer{[]influxdb.Permission{
+		{
+			Action:   "read",
+			Resource: influxdb.Resource{Type: influxdb.ChecksResourceType, ID: influxdbtesting.IDPtr(1), OrgID: influxdbtesting.IDPtr(10)},
+		},
+	}})
+	if _, _, err := s.FindCheckEvents(ctx, influxdb.CheckEventFilter{OrgID: 10}); influxdb.ErrorCode(err) != influxdb.EUnauthorized {
+		t.Errorf("expected the watch to require reading every check of the org, got %v", err)
+	}
+
+	ctx = influxdbcontext.SetAuthorizer(context.Background(), &Authorizer{[]influxdb.Permission{
+		{
+			Action:   "read",
+			Resource: influxdb.Resource{Type: influxdb.ChecksResourceType, OrgID: influxdbtesting.IDPtr(10)},
+		},
+	}})
+	if _, _, err := s.FindCheckEvents(ctx, influxdb.CheckEventFilter{OrgID: 10}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, _, err := s.FindCheckEvents(ctx, influxdb.CheckEventFilter{OrgID: 11}); influxdb.ErrorCode(err) != influxdb.EUnauthorized {
+		t.Errorf("expected the watch of another org to be unauthorized, got %v", err)
+	}
+}
@@ -0,0 +1,40 @@
+package authorizer
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.ExternalStatusService = (*ExternalStatusService)(nil)
+
+// ExternalStatusService wraps a influxdb.ExternalStatusService and authorizes actions
+// against it appropriately. Writing a status of an external check is authorized as
+// writing the check, so an external system only needs a token scoped to its check.
+type ExternalStatusService struct {
+	s            influxdb.ExternalStatusService
+	checkService influxdb.CheckService
+}
+
+// NewExternalStatusService constructs an instance of an authorizing external status service.
+// The unauthorized check service finds the organization of the checks.
+func NewExternalStatusService(s influxdb.ExternalStatusService, checkService influxdb.CheckService) *ExternalStatusService {
+	return &ExternalStatusService{
+		s:            s,
+		checkService: checkService,
+	}
+}
+
+// WriteExternalStatus checks to see if the authorizer on context has write access to the check.
+func (s *ExternalStatusService) WriteExternalStatus(ctx context.Context, checkID influxdb.ID, st *influxdb.ExternalStatus) (*influxdb.StatusTrace, error) {
+	c, err := s.checkService.FindCheckByID(ctx, checkID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeWriteCheck(ctx, c.GetOrgID(), c.GetID()); err != nil {
+		return nil, err
+	}
+
+	return s.s.WriteExternalStatus(ctx, checkID, st)
+}
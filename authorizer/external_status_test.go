@@ -0,0 +1,79 @@
+package authorizer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/authorizer"
+	influxdbcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/notification/check"
+	influxdbtesting "github.com/influxdata/influxdb/testing"
+)
+
+func TestExternalStatusService_WriteExternalStatus(t *testing.T) {
+	type args struct {
+		permission influxdb.Permission
+	}
+	type wants struct {
+		err error
+	}
+
+	tests := []struct {
+		name  string
+		args  args
+		wants wants
+	}{
+		{
+			name: "authorized to write the check",
+			args: args{
+				permission: influxdb.Permission{
+					Action: "write",
+					Resource: influxdb.Resource{
+						Type: influxdb.ChecksResourceType,
+						ID:   influxdbtesting.IDPtr(1),
+					},
+				},
+			},
+		},
+		{
+			name: "unauthorized to write the check",
+			args: args{
+				permission: influxdb.Permission{
+					Action: "read",
+					Resource: influxdb.Resource{
+						Type: influxdb.ChecksResourceType,
+						ID:   influxdbtesting.IDPtr(1),
+					},
+				},
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Msg:  "write:orgs/000000000000000a/checks/0000000000000001 is unauthorized",
+					Code: influxdb.EUnauthorized,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := authorizer.NewExternalStatusService(&mock.ExternalStatusService{
+				WriteExternalStatusF: func(ctx context.Context, checkID influxdb.ID, st *influxdb.ExternalStatus) (*influxdb.StatusTrace, error) {
+					return &influxdb.StatusTrace{StatusID: 100, CheckID: checkID, OrgID: 10}, nil
+				},
+			}, &mock.CheckService{
+				FindCheckByIDF: func(ctx context.Context, id influxdb.ID) (influxdb.Check, error) {
+					return &check.External{Base: check.Base{ID: id, OrgID: 10}}, nil
+				},
+			})
+
+			ctx := context.Background()
+			ctx = influxdbcontext.SetAuthorizer(ctx, &Authorizer{[]influxdb.Permission{tt.args.permission}})
+
+			_, err := s.WriteExternalStatus(ctx, 1, &influxdb.ExternalStatus{Level: "CRIT"})
+			influxdbtesting.ErrorsEqual(t, err, tt.wants.err)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package authorizer
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.LabelBulkService = (*LabelBulkService)(nil)
+
+// LabelBulkService wraps a influxdb.LabelBulkService and authorizes actions
+// against it appropriately.
+type LabelBulkService struct {
+	s            influxdb.LabelBulkService
+	labelService influxdb.LabelService
+}
+
+// NewLabelBulkService constructs an instance of an authorizing label bulk service.
+// The unauthorized label service finds the organization of the labels.
+func NewLabelBulkService(s influxdb.LabelBulkService, labelService influxdb.LabelService) *LabelBulkService {
+	return &LabelBulkService{
+		s:            s,
+		labelService: labelService,
+	}
+}
+
+// ApplyLabel checks to see if the authorizer on context has read access to the label
+// and update access to the checks of the organization, a dry run included.
+func (s *LabelBulkService) ApplyLabel(ctx context.Context, labelID influxdb.ID, o influxdb.LabelBulkOperation) (*influxdb.LabelBulkResult, error) {
+	if err := s.authorize(ctx, labelID, o); err != nil {
+		return nil, err
+	}
+	return s.s.ApplyLabel(ctx, labelID, o)
+}
+
+// RemoveLabel checks to see if the authorizer on context has read access to the label
+// and update access to the checks of the organization, a dry run included.
+func (s *LabelBulkService) RemoveLabel(ctx context.Context, labelID influxdb.ID, o influxdb.LabelBulkOperation) (*influxdb.LabelBulkResult, error) {
+	if err := s.authorize(ctx, labelID, o); err != nil {
+		return nil, err
+	}
+	return s.s.RemoveLabel(ctx, labelID, o)
+}
+
+func (s *LabelBulkService) authorize(ctx context.Context, labelID influxdb.ID, o influxdb.LabelBulkOperation) error {
+	l, err := s.labelService.FindLabelByID(ctx, labelID)
+	if err != nil {
+		return err
+	}
+	if err := authorizeReadLabel(ctx, l.OrgID, l.ID); err != nil {
+		return err
+	}
+	if err := o.Valid(); err != nil {
+		return err
+	}
+	p, err := influxdb.NewPermission(influxdb.UpdateAction, o.ResourceType, o.Filter.OrgID)
+	if err != nil {
+		return err
+	}
+	return IsAllowed(ctx, *p)
+}
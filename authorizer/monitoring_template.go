@@ -0,0 +1,138 @@
+package authorizer
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.MonitoringTemplateService = (*MonitoringTemplateService)(nil)
+
+// MonitoringTemplateService wraps a influxdb.MonitoringTemplateService and authorizes actions
+// against it appropriately. An installed template is read as the checks, notification
+// endpoints and notification rules of its organization, and changing it requires the
+// actions of its changes on every resource type of the template.
+type MonitoringTemplateService struct {
+	s influxdb.MonitoringTemplateService
+}
+
+// NewMonitoringTemplateService constructs an instance of an authorizing monitoring template service.
+func NewMonitoringTemplateService(s influxdb.MonitoringTemplateService) *MonitoringTemplateService {
+	return &MonitoringTemplateService{
+		s: s,
+	}
+}
+
+// monitoringTemplateResourceTypes are the alerting resource types created by the templates.
+var monitoringTemplateResourceTypes = []influxdb.ResourceType{
+	influxdb.ChecksResourceType,
+	influxdb.NotificationEndpointResourceType,
+	influxdb.NotificationRuleResourceType,
+}
+
+func authorizeMonitoringTemplate(ctx context.Context, orgID influxdb.ID, actions []influxdb.Action, others ...influxdb.ResourceType) error {
+	for _, a := range actions {
+		for _, rt := range monitoringTemplateResourceTypes {
+			p, err := influxdb.NewPermission(a, rt, orgID)
+			if err != nil {
+				return err
+			}
+			if err := IsAllowed(ctx, *p); err != nil {
+				return err
+			}
+		}
+	}
+	// the labels and the secrets of the endpoints are written along the resources.
+	for _, rt := range others {
+		p, err := influxdb.NewPermission(influxdb.WriteAction, rt, orgID)
+		if err != nil {
+			return err
+		}
+		if err := IsAllowed(ctx, *p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func authorizeReadMonitoringTemplate(ctx context.Context, orgID influxdb.ID) error {
+	return authorizeMonitoringTemplate(ctx, orgID, []influxdb.Action{influxdb.ReadAction})
+}
+
+// FindMonitoringTemplateManifestByID checks to see if the authorizer on context has read access to the
+// alerting resources of the organization of the installed template.
+func (s *MonitoringTemplateService) FindMonitoringTemplateManifestByID(ctx context.Context, id influxdb.ID) (*influxdb.MonitoringTemplateManifest, error) {
+	m, err := s.s.FindMonitoringTemplateManifestByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeReadMonitoringTemplate(ctx, m.OrgID); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// FindMonitoringTemplateManifests checks to see if the authorizer on context has read access to the
+// alerting resources of the organization.
+func (s *MonitoringTemplateService) FindMonitoringTemplateManifests(ctx context.Context, orgID influxdb.ID) ([]*influxdb.MonitoringTemplateManifest, error) {
+	if err := authorizeReadMonitoringTemplate(ctx, orgID); err != nil {
+		return nil, err
+	}
+
+	return s.s.FindMonitoringTemplateManifests(ctx, orgID)
+}
+
+// InstallMonitoringTemplate checks to see if the authorizer on context has create access to the
+// alerting resources of the organization, and write access to its labels and secrets.
+func (s *MonitoringTemplateService) InstallMonitoringTemplate(ctx context.Context, orgID influxdb.ID, install influxdb.MonitoringTemplateInstall, userID influxdb.ID) (*influxdb.MonitoringTemplateManifest, error) {
+	actions := []influxdb.Action{influxdb.ReadAction, influxdb.CreateAction}
+	if err := authorizeMonitoringTemplate(ctx, orgID, actions, influxdb.LabelsResourceType, influxdb.SecretsResourceType); err != nil {
+		return nil, err
+	}
+
+	return s.s.InstallMonitoringTemplate(ctx, orgID, install, userID)
+}
+
+// PreviewMonitoringTemplate checks to see if the authorizer on context has read access to the
+// alerting resources of the organization.
+func (s *MonitoringTemplateService) PreviewMonitoringTemplate(ctx context.Context, orgID influxdb.ID, install influxdb.MonitoringTemplateInstall) ([]influxdb.MonitoringTemplateResource, error) {
+	if err := authorizeReadMonitoringTemplate(ctx, orgID); err != nil {
+		return nil, err
+	}
+
+	return s.s.PreviewMonitoringTemplate(ctx, orgID, install)
+}
+
+// UpgradeMonitoringTemplate checks to see if the authorizer on context has create, update and delete access
+// to the alerting resources of the organization of the installed template, and write access to its labels and secrets.
+func (s *MonitoringTemplateService) UpgradeMonitoringTemplate(ctx context.Context, id influxdb.ID, install influxdb.MonitoringTemplateInstall, userID influxdb.ID) (*influxdb.MonitoringTemplateManifest, error) {
+	m, err := s.s.FindMonitoringTemplateManifestByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	actions := []influxdb.Action{influxdb.ReadAction, influxdb.CreateAction, influxdb.UpdateAction, influxdb.DeleteAction}
+	if err := authorizeMonitoringTemplate(ctx, m.OrgID, actions, influxdb.LabelsResourceType, influxdb.SecretsResourceType); err != nil {
+		return nil, err
+	}
+
+	return s.s.UpgradeMonitoringTemplate(ctx, id, install, userID)
+}
+
+// UninstallMonitoringTemplate checks to see if the authorizer on context has delete access to the
+// alerting resources of the organization of the installed template, and write access to its labels and secrets.
+func (s *MonitoringTemplateService) UninstallMonitoringTemplate(ctx context.Context, id influxdb.ID) error {
+	m, err := s.s.FindMonitoringTemplateManifestByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	actions := []influxdb.Action{influxdb.DeleteAction}
+	if err := authorizeMonitoringTemplate(ctx, m.OrgID, actions, influxdb.LabelsResourceType, influxdb.SecretsResourceType); err != nil {
+		return err
+	}
+
+	return s.s.UninstallMonitoringTemplate(ctx, id)
+}
@@ -0,0 +1,126 @@
+package authorizer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/authorizer"
+	influxdbcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/mock"
+	influxdbtesting "github.com/influxdata/influxdb/testing"
+)
+
+func TestMonitoringTemplateService_InstallMonitoringTemplate(t *testing.T) {
+	orgPermissions := func(actions ...influxdb.Action) []influxdb.Permission {
+		var ps []influxdb.Permission
+		for _, a := range actions {
+			for _, rt := range []influxdb.ResourceType{influxdb.ChecksResourceType, influxdb.NotificationEndpointResourceType, influxdb.NotificationRuleResourceType} {
+				ps = append(ps, influxdb.Permission{
+					Action:   a,
+					Resource: influxdb.Resource{Type: rt, OrgID: influxdbtesting.IDPtr(10)},
+				})
+			}
+		}
+		return ps
+	}
+	writeLabelsAndSecrets := []influxdb.Permission{
+		{Action: "write", Resource: influxdb.Resource{Type: influxdb.LabelsResourceType, OrgID: influxdbtesting.IDPtr(10)}},
+		{Action: "write", Resource: influxdb.Resource{Type: influxdb.SecretsResourceType, OrgID: influxdbtesting.IDPtr(10)}},
+	}
+
+	tests := []struct {
+		name        string
+		permissions []influxdb.Permission
+		err         error
+	}{
+		{
+			name:        "authorized to read and write the alerting resources, labels and secrets of the org",
+			permissions: append(orgPermissions("read", "write"), writeLabelsAndSecrets...),
+		},
+		{
+			name:        "authorized to create the alerting resources of the org",
+			permissions: append(orgPermissions("read", "create"), writeLabelsAndSecrets...),
+		},
+		{
+			name:        "unauthorized without access to the secrets",
+			permissions: append(orgPermissions("read", "write"), writeLabelsAndSecrets[0]),
+			err: &influxdb.Error{
+				Msg:  "write:orgs/000000000000000a/secrets is unauthorized",
+				Code: influxdb.EUnauthorized,
+			},
+		},
+		{
+			name:        "unauthorized to update the alerting resources only",
+			permissions: append(orgPermissions("read", "update"), writeLabelsAndSecrets...),
+			err: &influxdb.Error{
+				Msg:  "create:orgs/000000000000000a/checks is unauthorized",
+				Code: influxdb.EUnauthorized,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := authorizer.NewMonitoringTemplateService(&mock.MonitoringTemplateService{
+				InstallMonitoringTemplateF: func(ctx context.Context, orgID influxdb.ID, install influxdb.MonitoringTemplateInstall, userID influxdb.ID) (*influxdb.MonitoringTemplateManifest, error) {
+					return &influxdb.MonitoringTemplateManifest{ID: 1, OrgID: orgID}, nil
+				},
+			})
+
+			ctx := context.Background()
+			ctx = influxdbcontext.SetAuthorizer(ctx, &Authorizer{tt.permissions})
+
+			_, err := s.InstallMonitoringTemplate(ctx, 10, influxdb.MonitoringTemplateInstall{}, 2)
+			influxdbtesting.ErrorsEqual(t, err, tt.err)
+		})
+	}
+}
+
+func TestMonitoringTemplateService_UninstallMonitoringTemplate(t *testing.T) {
+	tests := []struct {
+		name   string
+		action influxdb.Action
+		err    error
+	}{
+		{
+			name:   "authorized to delete the alerting resources of the org",
+			action: "delete",
+		},
+		{
+			name:   "unauthorized to create the alerting resources only",
+			action: "create",
+			err: &influxdb.Error{
+				Msg:  "delete:orgs/000000000000000a/checks is unauthorized",
+				Code: influxdb.EUnauthorized,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := authorizer.NewMonitoringTemplateService(&mock.MonitoringTemplateService{
+				FindMonitoringTemplateManifestByIDF: func(ctx context.Context, id influxdb.ID) (*influxdb.MonitoringTemplateManifest, error) {
+					return &influxdb.MonitoringTemplateManifest{ID: id, OrgID: 10}, nil
+				},
+				UninstallMonitoringTemplateF: func(ctx context.Context, id influxdb.ID) error {
+					return nil
+				},
+			})
+
+			var ps []influxdb.Permission
+			for _, rt := range []influxdb.ResourceType{influxdb.ChecksResourceType, influxdb.NotificationEndpointResourceType, influxdb.NotificationRuleResourceType} {
+				ps = append(ps, influxdb.Permission{Action: tt.action, Resource: influxdb.Resource{Type: rt, OrgID: influxdbtesting.IDPtr(10)}})
+			}
+			for _, rt := range []influxdb.ResourceType{influxdb.LabelsResourceType, influxdb.SecretsResourceType} {
+				ps = append(ps, influxdb.Permission{Action: "write", Resource: influxdb.Resource{Type: rt, OrgID: influxdbtesting.IDPtr(10)}})
+			}
+
+			ctx := context.Background()
+			ctx = influxdbcontext.SetAuthorizer(ctx, &Authorizer{ps})
+
+			err := s.UninstallMonitoringTemplate(ctx, 1)
+			influxdbtesting.ErrorsEqual(t, err, tt.err)
+		})
+	}
+}
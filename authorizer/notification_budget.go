@@ -0,0 +1,113 @@
+package authorizer
+
+import (
+	"context"
+	"time"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.NotificationBudgetService = (*NotificationBudgetService)(nil)
+
+// NotificationBudgetService wraps a influxdb.NotificationBudgetService and authorizes actions
+// against it appropriately. The budgets are read as their endpoint, and only the
+// users allowed to write every notification endpoint of the organization, such as
+// its owners, may change them.
+type NotificationBudgetService struct {
+	s         influxdb.NotificationBudgetService
+	endpoints influxdb.NotificationEndpointService
+}
+
+// NewNotificationBudgetService constructs an instance of an authorizing notification budget service.
+// The endpoints are found with endpoints to authorize the budgets which aren't stored yet.
+func NewNotificationBudgetService(s influxdb.NotificationBudgetService, endpoints influxdb.NotificationEndpointService) *NotificationBudgetService {
+	return &NotificationBudgetService{
+		s:         s,
+		endpoints: endpoints,
+	}
+}
+
+func authorizeWriteNotificationBudget(ctx context.Context, orgID influxdb.ID) error {
+	p, err := influxdb.NewPermission(influxdb.WriteAction, influxdb.NotificationEndpointResourceType, orgID)
+	if err != nil {
+		return err
+	}
+
+	if err := IsAllowed(ctx, *p); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// FindNotificationBudget checks to see if the authorizer on context has read access to the endpoint of the budget.
+func (s *NotificationBudgetService) FindNotificationBudget(ctx context.Context, endpointID influxdb.ID) (*influxdb.NotificationBudget, error) {
+	b, err := s.s.FindNotificationBudget(ctx, endpointID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeReadNotificationEndpoint(ctx, b.OrgID, b.EndpointID); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// PutNotificationBudget checks to see if the authorizer on context has write access to the notification endpoints
+// of the organization of the endpoint of the budget.
+func (s *NotificationBudgetService) PutNotificationBudget(ctx context.Context, b *influxdb.NotificationBudget) error {
+	edp, err := s.endpoints.FindNotificationEndpointByID(ctx, b.EndpointID)
+	if err != nil {
+		return err
+	}
+
+	if err := authorizeWriteNotificationBudget(ctx, edp.GetOrgID()); err != nil {
+		return err
+	}
+
+	return s.s.PutNotificationBudget(ctx, b)
+}
+
+// DeleteNotificationBudget checks to see if the authorizer on context has write access to the notification endpoints
+// of the organization of the budget.
+func (s *NotificationBudgetService) DeleteNotificationBudget(ctx context.Context, endpointID influxdb.ID) error {
+	b, err := s.s.FindNotificationBudget(ctx, endpointID)
+	if err != nil {
+		return err
+	}
+
+	if err := authorizeWriteNotificationBudget(ctx, b.OrgID); err != nil {
+		return err
+	}
+
+	return s.s.DeleteNotificationBudget(ctx, endpointID)
+}
+
+// FindNotificationBudgetUsage checks to see if the authorizer on context has read access to the endpoint of the budget.
+func (s *NotificationBudgetService) FindNotificationBudgetUsage(ctx context.Context, endpointID influxdb.ID, t time.Time) (*influxdb.NotificationBudgetUsage, error) {
+	u, err := s.s.FindNotificationBudgetUsage(ctx, endpointID, t)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeReadNotificationEndpoint(ctx, u.OrgID, u.EndpointID); err != nil {
+		return nil, err
+	}
+
+	return u, nil
+}
+
+// SpendNotificationBudget checks to see if the authorizer on context has write access to the endpoint of the budget.
+func (s *NotificationBudgetService) SpendNotificationBudget(ctx context.Context, endpointID influxdb.ID, t time.Time) (*influxdb.NotificationBudgetUsage, error) {
+	b, err := s.s.FindNotificationBudget(ctx, endpointID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeWriteNotificationEndpoint(ctx, b.OrgID, b.EndpointID); err != nil {
+		return nil, err
+	}
+
+	return s.s.SpendNotificationBudget(ctx, endpointID, t)
+}
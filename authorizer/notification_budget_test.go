@@ -0,0 +1,80 @@
+package authorizer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/authorizer"
+	influxdbcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/notification/endpoint"
+	influxdbtesting "github.com/influxdata/influxdb/testing"
+)
+
+func TestNotificationBudgetService_PutNotificationBudget(t *testing.T) {
+	type args struct {
+		permission influxdb.Permission
+	}
+	type wants struct {
+		err error
+	}
+
+	tests := []struct {
+		name  string
+		args  args
+		wants wants
+	}{
+		{
+			name: "authorized to write the notification endpoints of the org",
+			args: args{
+				permission: influxdb.Permission{
+					Action: "write",
+					Resource: influxdb.Resource{
+						Type:  influxdb.NotificationEndpointResourceType,
+						OrgID: influxdbtesting.IDPtr(10),
+					},
+				},
+			},
+		},
+		{
+			name: "unauthorized with write access to the endpoint only",
+			args: args{
+				permission: influxdb.Permission{
+					Action: "write",
+					Resource: influxdb.Resource{
+						Type:  influxdb.NotificationEndpointResourceType,
+						OrgID: influxdbtesting.IDPtr(10),
+						ID:    influxdbtesting.IDPtr(1),
+					},
+				},
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Msg:  "write:orgs/000000000000000a/notificationEndpoints is unauthorized",
+					Code: influxdb.EUnauthorized,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := authorizer.NewNotificationBudgetService(&mock.NotificationBudgetService{
+				PutNotificationBudgetF: func(ctx context.Context, b *influxdb.NotificationBudget) error {
+					return nil
+				},
+			}, &mock.NotificationEndpointService{
+				FindNotificationEndpointByIDF: func(ctx context.Context, id influxdb.ID) (influxdb.NotificationEndpoint, error) {
+					return &endpoint.Slack{Base: endpoint.Base{ID: id, OrgID: 10}}, nil
+				},
+			})
+
+			ctx := context.Background()
+			ctx = influxdbcontext.SetAuthorizer(ctx, &Authorizer{[]influxdb.Permission{tt.args.permission}})
+
+			err := s.PutNotificationBudget(ctx, &influxdb.NotificationBudget{EndpointID: 1, Monthly: 500})
+			influxdbtesting.ErrorsEqual(t, err, tt.wants.err)
+		})
+	}
+}
@@ -0,0 +1,264 @@
+package authorizer
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.NotificationEndpointService = (*NotificationEndpointService)(nil)
+
+// NotificationEndpointService wraps a influxdb.NotificationEndpointService and authorizes actions
+// against it appropriately.
+type NotificationEndpointService struct {
+	s influxdb.NotificationEndpointService
+	influxdb.UserResourceMappingService
+	influxdb.OrganizationService
+}
+
+// NewNotificationEndpointService constructs an instance of an authorizing notification endpoint service.
+func NewNotificationEndpointService(s influxdb.NotificationEndpointService, urm influxdb.UserResourceMappingService, org influxdb.OrganizationService) *NotificationEndpointService {
+	return &NotificationEndpointService{
+		s:                          s,
+		UserResourceMappingService: urm,
+		OrganizationService:        org,
+	}
+}
+
+func newNotificationEndpointPermission(a influxdb.Action, orgID, id influxdb.ID) (*influxdb.Permission, error) {
+	return influxdb.NewPermissionAtID(id, a, influxdb.NotificationEndpointResourceType, orgID)
+}
+
+func authorizeReadNotificationEndpoint(ctx context.Context, orgID, id influxdb.ID) error {
+	p, err := newNotificationEndpointPermission(influxdb.ReadAction, orgID, id)
+	if err != nil {
+		return err
+	}
+
+	if err := IsAllowed(ctx, *p); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func authorizeWriteNotificationEndpoint(ctx context.Context, orgID, id influxdb.ID) error {
+	p, err := newNotificationEndpointPermission(influxdb.WriteAction, orgID, id)
+	if err != nil {
+		return err
+	}
+
+	if err := IsAllowed(ctx, *p); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func authorizeNotificationEndpointAction(ctx context.Context, a influxdb.Action, orgID, id influxdb.ID) error {
+	p, err := newNotificationEndpointPermission(a, orgID, id)
+	if err != nil {
+		return err
+	}
+
+	if err := IsAllowed(ctx, *p); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// FindNotificationEndpointByID checks to see if the authorizer on context has read access to the id provided.
+func (s *NotificationEndpointService) FindNotificationEndpointByID(ctx context.Context, id influxdb.ID) (influxdb.NotificationEndpoint, error) {
+	edp, err := s.s.FindNotificationEndpointByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeReadNotificationEndpoint(ctx, edp.GetOrgID(), edp.GetID()); err != nil {
+		return nil, err
+	}
+
+	return edp, nil
+}
+
+// FindNotificationEndpoints retrieves all notification endpoints that match the provided filter and then filters the list down to only the resources that are authorized.
+func (s *NotificationEndpointService) FindNotificationEndpoints(ctx context.Context, filter influxdb.NotificationEndpointFilter, opt ...influxdb.FindOptions) ([]influxdb.NotificationEndpoint, int, error) {
+	// TODO: we'll likely want to push this operation into the database eventually since fetching the whole list of data
+	// will likely be expensive.
+	edps, _, err := s.s.FindNotificationEndpoints(ctx, filter, opt...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// This filters without allocating
+	// https://github.com/golang/go/wiki/SliceTricks#filtering-without-allocating
+	endpoints := edps[:0]
+	for _, edp := range edps {
+		err := authorizeReadNotificationEndpoint(ctx, edp.GetOrgID(), edp.GetID())
+		if err != nil && influxdb.ErrorCode(err) != influxdb.EUnauthorized {
+			return nil, 0, err
+		}
+
+		if influxdb.ErrorCode(err) == influxdb.EUnauthorized {
+			continue
+		}
+
+		endpoints = append(endpoints, edp)
+	}
+
+	return endpoints, len(endpoints), nil
+}
+
+// CreateNotificationEndpoint checks to see if the authorizer on context has create access to the global notification endpoint resource.
+func (s *NotificationEndpointService) CreateNotificationEndpoint(ctx context.Context, edp influxdb.NotificationEndpoint, userID influxdb.ID) error {
+	p, err := influxdb.NewPermission(influxdb.CreateAction, influxdb.NotificationEndpointResourceType, edp.GetOrgID())
+	if err != nil {
+		return err
+	}
+
+	if err := IsAllowed(ctx, *p); err != nil {
+		return err
+	}
+
+	return s.s.CreateNotificationEndpoint(ctx, edp, userID)
+}
+
+// UpdateNotificationEndpoint checks to see if the authorizer on context has update access to the notification endpoint provided.
+func (s *NotificationEndpointService) UpdateNotificationEndpoint(ctx context.Context, id influxdb.ID, upd influxdb.NotificationEndpoint, userID influxdb.ID) (influxdb.NotificationEndpoint, error) {
+	edp, err := s.FindNotificationEndpointByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeNotificationEndpointAction(ctx, influxdb.UpdateAction, edp.GetOrgID(), id); err != nil {
+		return nil, err
+	}
+
+	return s.s.UpdateNotificationEndpoint(ctx, id, upd, userID)
+}
+
+// PatchNotificationEndpoint checks to see if the authorizer on context has update access to the notification endpoint provided.
+func (s *NotificationEndpointService) PatchNotificationEndpoint(ctx context.Context, id influxdb.ID, upd influxdb.NotificationEndpointUpdate) (influxdb.NotificationEndpoint, error) {
+	edp, err := s.FindNotificationEndpointByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeNotificationEndpointAction(ctx, influxdb.UpdateAction, edp.GetOrgID(), id); err != nil {
+		return nil, err
+	}
+
+	return s.s.PatchNotificationEndpoint(ctx, id, upd)
+}
+
+// DeleteNotificationEndpoint checks to see if the authorizer on context has delete access to the notification endpoint provided.
+func (s *NotificationEndpointService) DeleteNotificationEndpoint(ctx context.Context, id influxdb.ID) error {
+	edp, err := s.FindNotificationEndpointByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := authorizeNotificationEndpointAction(ctx, influxdb.DeleteAction, edp.GetOrgID(), id); err != nil {
+		return err
+	}
+
+	return s.s.DeleteNotificationEndpoint(ctx, id)
+}
+
+var _ influxdb.NotificationEndpointCascader = (*NotificationEndpointCascader)(nil)
+
+// NotificationEndpointCascader wraps a influxdb.NotificationEndpointCascader and
+// authorizes actions against it appropriately. Only the users allowed to delete every
+// notification rule of the organization may delete the rules sending to an endpoint with it.
+type NotificationEndpointCascader struct {
+	s         influxdb.NotificationEndpointCascader
+	endpoints influxdb.NotificationEndpointService
+}
+
+// NewNotificationEndpointCascader constructs an instance of an authorizing notification
+// endpoint cascade service. The endpoints are found with endpoints to authorize their deletion.
+func NewNotificationEndpointCascader(s influxdb.NotificationEndpointCascader, endpoints influxdb.NotificationEndpointService) *NotificationEndpointCascader {
+	return &NotificationEndpointCascader{
+		s:         s,
+		endpoints: endpoints,
+	}
+}
+
+// DeleteNotificationEndpointCascade checks to see if the authorizer on context has delete access to the
+// notification endpoint and to the notification rules of its organization.
+func (s *NotificationEndpointCascader) DeleteNotificationEndpointCascade(ctx context.Context, id influxdb.ID) ([]influxdb.ID, error) {
+	edp, err := s.endpoints.FindNotificationEndpointByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeNotificationEndpointAction(ctx, influxdb.DeleteAction, edp.GetOrgID(), id); err != nil {
+		return nil, err
+	}
+
+	p, err := influxdb.NewPermission(influxdb.DeleteAction, influxdb.NotificationRuleResourceType, edp.GetOrgID())
+	if err != nil {
+		return nil, err
+	}
+	if err := IsAllowed(ctx, *p); err != nil {
+		return nil, err
+	}
+
+	return s.s.DeleteNotificationEndpointCascade(ctx, id)
+}
+
+var _ influxdb.NotificationEndpointReassigner = (*NotificationEndpointReassigner)(nil)
+
+// NotificationEndpointReassigner wraps a influxdb.NotificationEndpointReassigner and
+// authorizes actions against it appropriately. Only the users allowed to update every
+// notification rule of the organization may move the rules of an endpoint to another one.
+type NotificationEndpointReassigner struct {
+	s         influxdb.NotificationEndpointReassigner
+	endpoints influxdb.NotificationEndpointService
+}
+
+// NewNotificationEndpointReassigner constructs an instance of an authorizing notification
+// endpoint reassignment service. The endpoints are found with endpoints to authorize their reassignment.
+func NewNotificationEndpointReassigner(s influxdb.NotificationEndpointReassigner, endpoints influxdb.NotificationEndpointService) *NotificationEndpointReassigner {
+	return &NotificationEndpointReassigner{
+		s:         s,
+		endpoints: endpoints,
+	}
+}
+
+// ReassignNotificationEndpoint checks to see if the authorizer on context has update, or delete,
+// access to the notification endpoint, read access to the target endpoint and update access
+// to the notification rules of its organization.
+func (s *NotificationEndpointReassigner) ReassignNotificationEndpoint(ctx context.Context, id influxdb.ID, r influxdb.NotificationEndpointReassign) (*influxdb.NotificationEndpointReassignResult, error) {
+	edp, err := s.endpoints.FindNotificationEndpointByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	action := influxdb.UpdateAction
+	if r.Delete {
+		action = influxdb.DeleteAction
+	}
+	if err := authorizeNotificationEndpointAction(ctx, action, edp.GetOrgID(), id); err != nil {
+		return nil, err
+	}
+
+	if target, err := s.endpoints.FindNotificationEndpointByID(ctx, r.TargetEndpointID); err == nil {
+		if err := authorizeReadNotificationEndpoint(ctx, target.GetOrgID(), target.GetID()); err != nil {
+			return nil, err
+		}
+	} else if influxdb.ErrorCode(err) != influxdb.ENotFound {
+		return nil, err
+	}
+
+	p, err := influxdb.NewPermission(influxdb.UpdateAction, influxdb.NotificationRuleResourceType, edp.GetOrgID())
+	if err != nil {
+		return nil, err
+	}
+	if err := IsAllowed(ctx, *p); err != nil {
+		return nil, err
+	}
+
+	return s.s.ReassignNotificationEndpoint(ctx, id, r)
+}
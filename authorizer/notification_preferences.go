@@ -0,0 +1,49 @@
+package authorizer
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.NotificationPreferencesService = (*NotificationPreferencesService)(nil)
+
+// NotificationPreferencesService wraps a influxdb.NotificationPreferencesService and authorizes actions
+// against it appropriately. The notification preferences of a user are authorized as the user.
+type NotificationPreferencesService struct {
+	s influxdb.NotificationPreferencesService
+}
+
+// NewNotificationPreferencesService constructs an instance of an authorizing notification preferences service.
+func NewNotificationPreferencesService(s influxdb.NotificationPreferencesService) *NotificationPreferencesService {
+	return &NotificationPreferencesService{
+		s: s,
+	}
+}
+
+// FindNotificationPreferences checks to see if the authorizer on context has read access to the user provided.
+func (s *NotificationPreferencesService) FindNotificationPreferences(ctx context.Context, userID influxdb.ID) (*influxdb.NotificationPreferences, error) {
+	if err := authorizeReadUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	return s.s.FindNotificationPreferences(ctx, userID)
+}
+
+// PutNotificationPreferences checks to see if the authorizer on context has write access to the user provided.
+func (s *NotificationPreferencesService) PutNotificationPreferences(ctx context.Context, p *influxdb.NotificationPreferences) error {
+	if err := authorizeWriteUser(ctx, p.UserID); err != nil {
+		return err
+	}
+
+	return s.s.PutNotificationPreferences(ctx, p)
+}
+
+// DeleteNotificationPreferences checks to see if the authorizer on context has write access to the user provided.
+func (s *NotificationPreferencesService) DeleteNotificationPreferences(ctx context.Context, userID influxdb.ID) error {
+	if err := authorizeWriteUser(ctx, userID); err != nil {
+		return err
+	}
+
+	return s.s.DeleteNotificationPreferences(ctx, userID)
+}
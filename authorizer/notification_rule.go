@@ -42,8 +42,8 @@ func authorizeReadNotificationRule(ctx context.Context, orgID, id influxdb.ID) e
 	return nil
 }
 
-func authorizeWriteNotificationRule(ctx context.Context, orgID, id influxdb.ID) error {
-	p, err := newNotificationRulePermission(influxdb.WriteAction, orgID, id)
+func authorizeNotificationRuleAction(ctx context.Context, a influxdb.Action, orgID, id influxdb.ID) error {
+	p, err := newNotificationRulePermission(a, orgID, id)
 	if err != nil {
 		return err
 	}
@@ -97,9 +97,9 @@ func (s *NotificationRuleStore) FindNotificationRules(ctx context.Context, filte
 	return rules, len(rules), nil
 }
 
-// CreateNotificationRule checks to see if the authorizer on context has write access to the global notification rule resource.
+// CreateNotificationRule checks to see if the authorizer on context has create access to the global notification rule resource.
 func (s *NotificationRuleStore) CreateNotificationRule(ctx context.Context, nr influxdb.NotificationRule, userID influxdb.ID) error {
-	p, err := influxdb.NewPermission(influxdb.WriteAction, influxdb.NotificationRuleResourceType, nr.GetOrgID())
+	p, err := influxdb.NewPermission(influxdb.CreateAction, influxdb.NotificationRuleResourceType, nr.GetOrgID())
 	if err != nil {
 		return err
 	}
@@ -111,42 +111,42 @@ func (s *NotificationRuleStore) CreateNotificationRule(ctx context.Context, nr i
 	return s.s.CreateNotificationRule(ctx, nr, userID)
 }
 
-// UpdateNotificationRule checks to see if the authorizer on context has write access to the notification rule provided.
+// UpdateNotificationRule checks to see if the authorizer on context has update access to the notification rule provided.
 func (s *NotificationRuleStore) UpdateNotificationRule(ctx context.Context, id influxdb.ID, upd influxdb.NotificationRule, userID influxdb.ID) (influxdb.NotificationRule, error) {
 	nr, err := s.FindNotificationRuleByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := authorizeWriteNotificationRule(ctx, nr.GetOrgID(), id); err != nil {
+	if err := authorizeNotificationRuleAction(ctx, influxdb.UpdateAction, nr.GetOrgID(), id); err != nil {
 		return nil, err
 	}
 
 	return s.s.UpdateNotificationRule(ctx, id, upd, userID)
 }
 
-// PatchNotificationRule checks to see if the authorizer on context has write access to the notification rule provided.
+// PatchNotificationRule checks to see if the authorizer on context has update access to the notification rule provided.
 func (s *NotificationRuleStore) PatchNotificationRule(ctx context.Context, id influxdb.ID, upd influxdb.NotificationRuleUpdate) (influxdb.NotificationRule, error) {
 	nr, err := s.FindNotificationRuleByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := authorizeWriteNotificationRule(ctx, nr.GetOrgID(), id); err != nil {
+	if err := authorizeNotificationRuleAction(ctx, influxdb.UpdateAction, nr.GetOrgID(), id); err != nil {
 		return nil, err
 	}
 
 	return s.s.PatchNotificationRule(ctx, id, upd)
 }
 
-// DeleteNotificationRule checks to see if the authorizer on context has write access to the notification rule provided.
+// DeleteNotificationRule checks to see if the authorizer on context has delete access to the notification rule provided.
 func (s *NotificationRuleStore) DeleteNotificationRule(ctx context.Context, id influxdb.ID) error {
 	nr, err := s.FindNotificationRuleByID(ctx, id)
 	if err != nil {
 		return err
 	}
 
-	if err := authorizeWriteNotificationRule(ctx, nr.GetOrgID(), id); err != nil {
+	if err := authorizeNotificationRuleAction(ctx, influxdb.DeleteAction, nr.GetOrgID(), id); err != nil {
 		return err
 	}
 
@@ -370,7 +370,7 @@ func TestNotificationRuleStore_UpdateNotificationRule(t *testing.T) {
 			},
 			wants: wants{
 				err: &influxdb.Error{
-					Msg:  "write:orgs/000000000000000a/notificationRules/0000000000000001 is unauthorized",
+					Msg:  "update:orgs/000000000000000a/notificationRules/0000000000000001 is unauthorized",
 					Code: influxdb.EUnauthorized,
 				},
 			},
@@ -489,7 +489,7 @@ func TestNotificationRuleStore_PatchNotificationRule(t *testing.T) {
 			},
 			wants: wants{
 				err: &influxdb.Error{
-					Msg:  "write:orgs/000000000000000a/notificationRules/0000000000000001 is unauthorized",
+					Msg:  "update:orgs/000000000000000a/notificationRules/0000000000000001 is unauthorized",
 					Code: influxdb.EUnauthorized,
 				},
 			},
@@ -598,7 +598,7 @@ func TestNotificationRuleStore_DeleteNotificationRule(t *testing.T) {
 			},
 			wants: wants{
 				err: &influxdb.Error{
-					Msg:  "write:orgs/000000000000000a/notificationRules/0000000000000001 is unauthorized",
+					Msg:  "delete:orgs/000000000000000a/notificationRules/0000000000000001 is unauthorized",
 					Code: influxdb.EUnauthorized,
 				},
 			},
@@ -680,7 +680,7 @@ func TestNotificationRuleStore_CreateNotificationRule(t *testing.T) {
 			},
 			wants: wants{
 				err: &influxdb.Error{
-					Msg:  "write:orgs/000000000000000a/notificationRules is unauthorized",
+					Msg:  "create:orgs/000000000000000a/notificationRules is unauthorized",
 					Code: influxdb.EUnauthorized,
 				},
 			},
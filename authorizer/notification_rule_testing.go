@@ -0,0 +1,39 @@
+package authorizer
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.NotificationRuleTestService = (*NotificationRuleTestService)(nil)
+
+// NotificationRuleTestService wraps a influxdb.NotificationRuleTestService and authorizes actions
+// against it appropriately. Testing a rule is authorized as reading it, as nothing is sent.
+type NotificationRuleTestService struct {
+	s         influxdb.NotificationRuleTestService
+	ruleStore influxdb.NotificationRuleStore
+}
+
+// NewNotificationRuleTestService constructs an instance of an authorizing notification rule test service.
+// The unauthorized rule store finds the organization of the rules.
+func NewNotificationRuleTestService(s influxdb.NotificationRuleTestService, ruleStore influxdb.NotificationRuleStore) *NotificationRuleTestService {
+	return &NotificationRuleTestService{
+		s:         s,
+		ruleStore: ruleStore,
+	}
+}
+
+// TestNotificationRule checks to see if the authorizer on context has read access to the rule.
+func (s *NotificationRuleTestService) TestNotificationRule(ctx context.Context, ruleID influxdb.ID, t influxdb.NotificationRuleTest) (*influxdb.NotificationRuleTestResult, error) {
+	nr, err := s.ruleStore.FindNotificationRuleByID(ctx, ruleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeReadNotificationRule(ctx, nr.GetOrgID(), nr.GetID()); err != nil {
+		return nil, err
+	}
+
+	return s.s.TestNotificationRule(ctx, ruleID, t)
+}
@@ -0,0 +1,135 @@
+package authorizer
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.NotificationTemplateService = (*NotificationTemplateService)(nil)
+
+// NotificationTemplateService wraps a influxdb.NotificationTemplateService and authorizes actions
+// against it appropriately.
+type NotificationTemplateService struct {
+	s influxdb.NotificationTemplateService
+}
+
+// NewNotificationTemplateService constructs an instance of an authorizing notification template service.
+func NewNotificationTemplateService(s influxdb.NotificationTemplateService) *NotificationTemplateService {
+	return &NotificationTemplateService{
+		s: s,
+	}
+}
+
+func newNotificationTemplatePermission(a influxdb.Action, orgID, id influxdb.ID) (*influxdb.Permission, error) {
+	return influxdb.NewPermissionAtID(id, a, influxdb.NotificationTemplateResourceType, orgID)
+}
+
+func authorizeReadNotificationTemplate(ctx context.Context, orgID, id influxdb.ID) error {
+	p, err := newNotificationTemplatePermission(influxdb.ReadAction, orgID, id)
+	if err != nil {
+		return err
+	}
+
+	if err := IsAllowed(ctx, *p); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func authorizeWriteNotificationTemplate(ctx context.Context, orgID, id influxdb.ID) error {
+	p, err := newNotificationTemplatePermission(influxdb.WriteAction, orgID, id)
+	if err != nil {
+		return err
+	}
+
+	if err := IsAllowed(ctx, *p); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// FindNotificationTemplateByID checks to see if the authorizer on context has read access to the id provided.
+func (s *NotificationTemplateService) FindNotificationTemplateByID(ctx context.Context, id influxdb.ID) (*influxdb.NotificationTemplate, error) {
+	t, err := s.s.FindNotificationTemplateByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeReadNotificationTemplate(ctx, t.OrgID, id); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// FindNotificationTemplates retrieves all notification templates that match the provided filter and then filters the list down to only the resources that are authorized.
+func (s *NotificationTemplateService) FindNotificationTemplates(ctx context.Context, filter influxdb.NotificationTemplateFilter, opt ...influxdb.FindOptions) ([]*influxdb.NotificationTemplate, int, error) {
+	// TODO: we'll likely want to push this operation into the database since fetching the whole list of data will likely be expensive.
+	ts, _, err := s.s.FindNotificationTemplates(ctx, filter, opt...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// This filters without allocating
+	// https://github.com/golang/go/wiki/SliceTricks#filtering-without-allocating
+	templates := ts[:0]
+	for _, t := range ts {
+		err := authorizeReadNotificationTemplate(ctx, t.OrgID, t.ID)
+		if err != nil && influxdb.ErrorCode(err) != influxdb.EUnauthorized {
+			return nil, 0, err
+		}
+
+		if influxdb.ErrorCode(err) == influxdb.EUnauthorized {
+			continue
+		}
+
+		templates = append(templates, t)
+	}
+
+	return templates, len(templates), nil
+}
+
+// CreateNotificationTemplate checks to see if the authorizer on context has write access to the global notification template resource.
+func (s *NotificationTemplateService) CreateNotificationTemplate(ctx context.Context, t *influxdb.NotificationTemplate) error {
+	p, err := influxdb.NewPermission(influxdb.WriteAction, influxdb.NotificationTemplateResourceType, t.OrgID)
+	if err != nil {
+		return err
+	}
+
+	if err := IsAllowed(ctx, *p); err != nil {
+		return err
+	}
+
+	return s.s.CreateNotificationTemplate(ctx, t)
+}
+
+// UpdateNotificationTemplate checks to see if the authorizer on context has write access to the notification template provided.
+func (s *NotificationTemplateService) UpdateNotificationTemplate(ctx context.Context, id influxdb.ID, upd influxdb.NotificationTemplateUpdate) (*influxdb.NotificationTemplate, error) {
+	t, err := s.FindNotificationTemplateByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeWriteNotificationTemplate(ctx, t.OrgID, id); err != nil {
+		return nil, err
+	}
+
+	return s.s.UpdateNotificationTemplate(ctx, id, upd)
+}
+
+// DeleteNotificationTemplate checks to see if the authorizer on context has write access to the notification template provided.
+func (s *NotificationTemplateService) DeleteNotificationTemplate(ctx context.Context, id influxdb.ID) error {
+	t, err := s.FindNotificationTemplateByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := authorizeWriteNotificationTemplate(ctx, t.OrgID, id); err != nil {
+		return err
+	}
+
+	return s.s.DeleteNotificationTemplate(ctx, id)
+}
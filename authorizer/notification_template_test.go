@@ -0,0 +1,312 @@
+package authorizer_test
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/authorizer"
+	influxdbcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/mock"
+	influxdbtesting "github.com/influxdata/influxdb/testing"
+)
+
+var notificationTemplateCmpOptions = cmp.Options{
+	cmp.Transformer("Sort", func(in []*influxdb.NotificationTemplate) []*influxdb.NotificationTemplate {
+		out := append([]*influxdb.NotificationTemplate(nil), in...) // Copy input to avoid mutating it
+		sort.Slice(out, func(i, j int) bool {
+			return out[i].ID.String() > out[j].ID.String()
+		})
+		return out
+	}),
+}
+
+func TestNotificationTemplateService_FindNotificationTemplateByID(t *testing.T) {
+	type fields struct {
+		NotificationTemplateService influxdb.NotificationTemplateService
+	}
+	type args struct {
+		permission influxdb.Permission
+		id         influxdb.ID
+	}
+	type wants struct {
+		err error
+	}
+
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		wants  wants
+	}{
+		{
+			name: "authorized to access id",
+			fields: fields{
+				NotificationTemplateService: &mock.NotificationTemplateService{
+					FindNotificationTemplateByIDF: func(ctx context.Context, id influxdb.ID) (*influxdb.NotificationTemplate, error) {
+						return &influxdb.NotificationTemplate{
+							ID:    id,
+							OrgID: 10,
+						}, nil
+					},
+				},
+			},
+			args: args{
+				permission: influxdb.Permission{
+					Action: "read",
+					Resource: influxdb.Resource{
+						Type: influxdb.NotificationTemplateResourceType,
+						ID:   influxdbtesting.IDPtr(1),
+					},
+				},
+				id: 1,
+			},
+			wants: wants{
+				err: nil,
+			},
+		},
+		{
+			name: "unauthorized to access id",
+			fields: fields{
+				NotificationTemplateService: &mock.NotificationTemplateService{
+					FindNotificationTemplateByIDF: func(ctx context.Context, id influxdb.ID) (*influxdb.NotificationTemplate, error) {
+						return &influxdb.NotificationTemplate{
+							ID:    id,
+							OrgID: 10,
+						}, nil
+					},
+				},
+			},
+			args: args{
+				permission: influxdb.Permission{
+					Action: "read",
+					Resource: influxdb.Resource{
+						Type: influxdb.NotificationTemplateResourceType,
+						ID:   influxdbtesting.IDPtr(2),
+					},
+				},
+				id: 1,
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Msg:  "read:orgs/000000000000000a/notificationTemplates/0000000000000001 is unauthorized",
+					Code: influxdb.EUnauthorized,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := authorizer.NewNotificationTemplateService(tt.fields.NotificationTemplateService)
+
+			ctx := context.Background()
+			ctx = influxdbcontext.SetAuthorizer(ctx, &Authorizer{[]influxdb.Permission{tt.args.permission}})
+
+			_, err := s.FindNotificationTemplateByID(ctx, tt.args.id)
+			influxdbtesting.ErrorsEqual(t, err, tt.wants.err)
+		})
+	}
+}
+
+func TestNotificationTemplateService_FindNotificationTemplates(t *testing.T) {
+	type fields struct {
+		NotificationTemplateService influxdb.NotificationTemplateService
+	}
+	type args struct {
+		permission influxdb.Permission
+	}
+	type wants struct {
+		err       error
+		templates []*influxdb.NotificationTemplate
+	}
+
+	find := func(ctx context.Context, filter influxdb.NotificationTemplateFilter, opt ...influxdb.FindOptions) ([]*influxdb.NotificationTemplate, int, error) {
+		return []*influxdb.NotificationTemplate{
+			{
+				ID:    1,
+				OrgID: 10,
+			},
+			{
+				ID:    2,
+				OrgID: 10,
+			},
+			{
+				ID:    3,
+				OrgID: 11,
+			},
+		}, 3, nil
+	}
+
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		wants  wants
+	}{
+		{
+			name: "authorized to see all notification templates",
+			fields: fields{
+				NotificationTemplateService: &mock.NotificationTemplateService{
+					FindNotificationTemplatesF: find,
+				},
+			},
+			args: args{
+				permission: influxdb.Permission{
+					Action: "read",
+					Resource: influxdb.Resource{
+						Type: influxdb.NotificationTemplateResourceType,
+					},
+				},
+			},
+			wants: wants{
+				templates: []*influxdb.NotificationTemplate{
+					{
+						ID:    1,
+						OrgID: 10,
+					},
+					{
+						ID:    2,
+						OrgID: 10,
+					},
+					{
+						ID:    3,
+						OrgID: 11,
+					},
+				},
+			},
+		},
+		{
+			name: "authorized to access a single orgs notification templates",
+			fields: fields{
+				NotificationTemplateService: &mock.NotificationTemplateService{
+					FindNotificationTemplatesF: find,
+				},
+			},
+			args: args{
+				permission: influxdb.Permission{
+					Action: "read",
+					Resource: influxdb.Resource{
+						Type:  influxdb.NotificationTemplateResourceType,
+						OrgID: influxdbtesting.IDPtr(10),
+					},
+				},
+			},
+			wants: wants{
+				templates: []*influxdb.NotificationTemplate{
+					{
+						ID:    1,
+						OrgID: 10,
+					},
+					{
+						ID:    2,
+						OrgID: 10,
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := authorizer.NewNotificationTemplateService(tt.fields.NotificationTemplateService)
+
+			ctx := context.Background()
+			ctx = influxdbcontext.SetAuthorizer(ctx, &Authorizer{[]influxdb.Permission{tt.args.permission}})
+
+			templates, n, err := s.FindNotificationTemplates(ctx, influxdb.NotificationTemplateFilter{})
+			influxdbtesting.ErrorsEqual(t, err, tt.wants.err)
+
+			if n != len(tt.wants.templates) {
+				t.Errorf("expected %d notification templates, got %d", len(tt.wants.templates), n)
+			}
+			if diff := cmp.Diff(templates, tt.wants.templates, notificationTemplateCmpOptions...); diff != "" {
+				t.Errorf("notification templates are different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+func TestNotificationTemplateService_CreateNotificationTemplate(t *testing.T) {
+	type fields struct {
+		NotificationTemplateService influxdb.NotificationTemplateService
+	}
+	type args struct {
+		permission influxdb.Permission
+		orgID      influxdb.ID
+	}
+	type wants struct {
+		err error
+	}
+
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		wants  wants
+	}{
+		{
+			name: "authorized to create notification template",
+			fields: fields{
+				NotificationTemplateService: &mock.NotificationTemplateService{
+					CreateNotificationTemplateF: func(ctx context.Context, t *influxdb.NotificationTemplate) error {
+						return nil
+					},
+				},
+			},
+			args: args{
+				orgID: 10,
+				permission: influxdb.Permission{
+					Action: "write",
+					Resource: influxdb.Resource{
+						Type:  influxdb.NotificationTemplateResourceType,
+						OrgID: influxdbtesting.IDPtr(10),
+					},
+				},
+			},
+			wants: wants{
+				err: nil,
+			},
+		},
+		{
+			name: "unauthorized to create notification template",
+			fields: fields{
+				NotificationTemplateService: &mock.NotificationTemplateService{
+					CreateNotificationTemplateF: func(ctx context.Context, t *influxdb.NotificationTemplate) error {
+						return nil
+					},
+				},
+			},
+			args: args{
+				orgID: 10,
+				permission: influxdb.Permission{
+					Action: "write",
+					Resource: influxdb.Resource{
+						Type:  influxdb.NotificationTemplateResourceType,
+						OrgID: influxdbtesting.IDPtr(1),
+					},
+				},
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Msg:  "write:orgs/000000000000000a/notificationTemplates is unauthorized",
+					Code: influxdb.EUnauthorized,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := authorizer.NewNotificationTemplateService(tt.fields.NotificationTemplateService)
+
+			ctx := context.Background()
+			ctx = influxdbcontext.SetAuthorizer(ctx, &Authorizer{[]influxdb.Permission{tt.args.permission}})
+
+			err := s.CreateNotificationTemplate(ctx, &influxdb.NotificationTemplate{OrgID: tt.args.orgID})
+			influxdbtesting.ErrorsEqual(t, err, tt.wants.err)
+		})
+	}
+}
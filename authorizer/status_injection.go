@@ -0,0 +1,39 @@
+package authorizer
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.StatusInjectionService = (*StatusInjectionService)(nil)
+
+// StatusInjectionService wraps a influxdb.StatusInjectionService and authorizes actions
+// against it appropriately. Injecting a status is authorized as writing its check.
+type StatusInjectionService struct {
+	s            influxdb.StatusInjectionService
+	checkService influxdb.CheckService
+}
+
+// NewStatusInjectionService constructs an instance of an authorizing status injection service.
+// The unauthorized check service finds the organization of the checks.
+func NewStatusInjectionService(s influxdb.StatusInjectionService, checkService influxdb.CheckService) *StatusInjectionService {
+	return &StatusInjectionService{
+		s:            s,
+		checkService: checkService,
+	}
+}
+
+// InjectStatus checks to see if the authorizer on context has write access to the check of the status.
+func (s *StatusInjectionService) InjectStatus(ctx context.Context, i *influxdb.StatusInjection) (*influxdb.StatusTrace, error) {
+	c, err := s.checkService.FindCheckByID(ctx, i.CheckID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeWriteCheck(ctx, c.GetOrgID(), c.GetID()); err != nil {
+		return nil, err
+	}
+
+	return s.s.InjectStatus(ctx, i)
+}
@@ -0,0 +1,45 @@
+package authorizer
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.StatusTraceService = (*StatusTraceService)(nil)
+
+// StatusTraceService wraps a influxdb.StatusTraceService and authorizes actions
+// against it appropriately. The trace of a status is authorized as its check.
+type StatusTraceService struct {
+	s influxdb.StatusTraceService
+}
+
+// NewStatusTraceService constructs an instance of an authorizing status trace service.
+func NewStatusTraceService(s influxdb.StatusTraceService) *StatusTraceService {
+	return &StatusTraceService{
+		s: s,
+	}
+}
+
+// FindStatusTrace checks to see if the authorizer on context has read access to the check of the status.
+func (s *StatusTraceService) FindStatusTrace(ctx context.Context, statusID influxdb.ID) (*influxdb.StatusTrace, error) {
+	t, err := s.s.FindStatusTrace(ctx, statusID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeReadCheck(ctx, t.OrgID, t.CheckID); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// CreateStatusTrace checks to see if the authorizer on context has write access to the check of the status.
+func (s *StatusTraceService) CreateStatusTrace(ctx context.Context, t *influxdb.StatusTrace) error {
+	if err := authorizeWriteCheck(ctx, t.OrgID, t.CheckID); err != nil {
+		return err
+	}
+
+	return s.s.CreateStatusTrace(ctx, t)
+}
@@ -48,6 +48,12 @@ const (
 	ReadAction Action = "read" // 1
 	// WriteAction is the action for writing.
 	WriteAction Action = "write" // 2
+	// CreateAction is the action for creating, granted by WriteAction.
+	CreateAction Action = "create" // 3
+	// UpdateAction is the action for updating, granted by WriteAction.
+	UpdateAction Action = "update" // 4
+	// DeleteAction is the action for deleting, granted by WriteAction.
+	DeleteAction Action = "delete" // 5
 )
 
 var actions = []Action{
@@ -60,6 +66,9 @@ func (a Action) Valid() (err error) {
 	switch a {
 	case ReadAction: // 1
 	case WriteAction: // 2
+	case CreateAction: // 3
+	case UpdateAction: // 4
+	case DeleteAction: // 5
 	default:
 		err = ErrInvalidAction
 	}
@@ -67,6 +76,24 @@ func (a Action) Valid() (err error) {
 	return err
 }
 
+// Grants returns whether the action grants another one, write granting
+// the create, update and delete actions.
+func (a Action) Grants(b Action) bool {
+	if a == b {
+		return true
+	}
+	if a != WriteAction {
+		return false
+	}
+	return b == CreateAction || b == UpdateAction || b == DeleteAction
+}
+
+// fineGrainedAction returns whether the action is one of the create, update
+// and delete actions, which are only known to the fine grained resource types.
+func fineGrainedAction(a Action) bool {
+	return a == CreateAction || a == UpdateAction || a == DeleteAction
+}
+
 // ResourceType is an enum defining all resource types that have a permission model in platform
 type ResourceType string
 
@@ -127,6 +154,10 @@ const (
 	NotificationRuleResourceType = ResourceType("notificationRules") // 14
 	// NotificationEndpointResourceType gives permission to one or more notificationEndpoints.
 	NotificationEndpointResourceType = ResourceType("notificationEndpoints") // 15
+	// NotificationTemplateResourceType gives permission to one or more notificationTemplates.
+	NotificationTemplateResourceType = ResourceType("notificationTemplates") // 16
+	// ChecksResourceType gives permission to one or more checks.
+	ChecksResourceType = ResourceType("checks") // 17
 )
 
 // AllResourceTypes is the list of all known resource types.
@@ -147,6 +178,8 @@ var AllResourceTypes = []ResourceType{
 	DocumentsResourceType,            // 13
 	NotificationRuleResourceType,     // 14
 	NotificationEndpointResourceType, // 15
+	NotificationTemplateResourceType, // 16
+	ChecksResourceType,               // 17
 	// NOTE: when modifying this list, please update the swagger for components.schemas.Permission resource enum.
 }
 
@@ -163,6 +196,8 @@ var OrgResourceTypes = []ResourceType{
 	DocumentsResourceType,            // 13
 	NotificationRuleResourceType,     // 14
 	NotificationEndpointResourceType, // 15
+	NotificationTemplateResourceType, // 16
+	ChecksResourceType,               // 17
 }
 
 // Valid checks if the resource type is a member of the ResourceType enum.
@@ -189,6 +224,8 @@ func (t ResourceType) Valid() (err error) {
 	case DocumentsResourceType: // 13
 	case NotificationRuleResourceType: // 14
 	case NotificationEndpointResourceType: // 15
+	case NotificationTemplateResourceType: // 16
+	case ChecksResourceType: // 17
 	default:
 		err = ErrInvalidResourceType
 	}
@@ -196,6 +233,24 @@ func (t ResourceType) Valid() (err error) {
 	return err
 }
 
+// FineGrainedResourceTypes is the list of the resource types whose permissions
+// may grant the create, update and delete actions besides read and write.
+var FineGrainedResourceTypes = []ResourceType{
+	NotificationRuleResourceType,     // 14
+	NotificationEndpointResourceType, // 15
+	ChecksResourceType,               // 17
+}
+
+// FineGrained returns whether the resource type is a member of FineGrainedResourceTypes.
+func (t ResourceType) FineGrained() bool {
+	for _, rt := range FineGrainedResourceTypes {
+		if t == rt {
+			return true
+		}
+	}
+	return false
+}
+
 // Permission defines an action and a resource.
 type Permission struct {
 	Action   Action   `json:"action"`
@@ -204,7 +259,7 @@ type Permission struct {
 
 // Matches returns whether or not one permission matches the other.
 func (p Permission) Matches(perm Permission) bool {
-	if p.Action != perm.Action {
+	if !p.Action.Grants(perm.Action) {
 		return false
 	}
 
@@ -261,6 +316,14 @@ func (p *Permission) Valid() error {
 		}
 	}
 
+	if fineGrainedAction(p.Action) && !p.Resource.Type.FineGrained() {
+		return &Error{
+			Code: EInvalid,
+			Err:  ErrInvalidAction,
+			Msg:  fmt.Sprintf("%s action is not supported by %s permissions", p.Action, p.Resource.Type),
+		}
+	}
+
 	if p.Resource.OrgID != nil && !(*p.Resource.OrgID).Valid() {
 		return &Error{
 			Code: EInvalid,
@@ -368,3 +431,29 @@ func MemberPermissions(orgID ID) []Permission {
 
 	return ps
 }
+
+// viewerResourceTypes are the resources a viewer of an organization can read.
+var viewerResourceTypes = []ResourceType{
+	BucketsResourceType,
+	DashboardsResourceType,
+	ViewsResourceType,
+	VariablesResourceType,
+	LabelsResourceType,
+	ChecksResourceType,
+	NotificationRuleResourceType,
+}
+
+// ViewerPermissions are the permissions of the viewers of an organization:
+// reading the organization, its dashboards and the resources they show,
+// such as its buckets and checks. The secrets, tasks and notification
+// endpoints of the organization aren't readable.
+func ViewerPermissions(orgID ID) []Permission {
+	ps := []Permission{
+		{Action: ReadAction, Resource: Resource{Type: OrgsResourceType, ID: &orgID}},
+	}
+	for _, r := range viewerResourceTypes {
+		ps = append(ps, Permission{Action: ReadAction, Resource: Resource{Type: r, OrgID: &orgID}})
+	}
+
+	return ps
+}
@@ -229,6 +229,68 @@ func TestAuthorizer_PermissionAllowed(t *testing.T) {
 			},
 			allowed: false,
 		},
+		{
+			name: "write grants delete",
+			permission: platform.Permission{
+				Action: platform.DeleteAction,
+				Resource: platform.Resource{
+					Type:  platform.ChecksResourceType,
+					OrgID: influxdbtesting.IDPtr(1),
+					ID:    influxdbtesting.IDPtr(1),
+				},
+			},
+			permissions: []platform.Permission{
+				{
+					Action: platform.WriteAction,
+					Resource: platform.Resource{
+						Type:  platform.ChecksResourceType,
+						OrgID: influxdbtesting.IDPtr(1),
+					},
+				},
+			},
+			allowed: true,
+		},
+		{
+			name: "update does not grant delete",
+			permission: platform.Permission{
+				Action: platform.DeleteAction,
+				Resource: platform.Resource{
+					Type:  platform.ChecksResourceType,
+					OrgID: influxdbtesting.IDPtr(1),
+					ID:    influxdbtesting.IDPtr(1),
+				},
+			},
+			permissions: []platform.Permission{
+				{
+					Action: platform.UpdateAction,
+					Resource: platform.Resource{
+						Type:  platform.ChecksResourceType,
+						OrgID: influxdbtesting.IDPtr(1),
+					},
+				},
+			},
+			allowed: false,
+		},
+		{
+			name: "create does not grant write",
+			permission: platform.Permission{
+				Action: platform.WriteAction,
+				Resource: platform.Resource{
+					Type:  platform.NotificationRuleResourceType,
+					OrgID: influxdbtesting.IDPtr(1),
+				},
+			},
+			permissions: []platform.Permission{
+				{
+					Action: platform.CreateAction,
+					Resource: platform.Resource{
+						Type:  platform.NotificationRuleResourceType,
+						OrgID: influxdbtesting.IDPtr(1),
+					},
+				},
+			},
+			allowed: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -302,6 +364,27 @@ func TestPermission_Valid(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "valid check permission to delete",
+			fields: fields{
+				Action: platform.DeleteAction,
+				Resource: platform.Resource{
+					Type:  platform.ChecksResourceType,
+					OrgID: influxdbtesting.IDPtr(1),
+				},
+			},
+		},
+		{
+			name: "invalid bucket permission to create",
+			fields: fields{
+				Action: platform.CreateAction,
+				Resource: platform.Resource{
+					Type:  platform.BucketsResourceType,
+					OrgID: influxdbtesting.IDPtr(1),
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -18,6 +18,13 @@ const (
 // InfiniteRetention is default infinite retention period.
 const InfiniteRetention = 0
 
+// MonitoringBucketName is the name of the bucket of an organization
+// the tasks of its checks write their statuses to.
+const MonitoringBucketName = "_monitoring"
+
+// MonitoringBucketRetention is the retention period of a monitoring bucket.
+const MonitoringBucketRetention = 7 * 24 * time.Hour
+
 // Bucket is a bucket. 🎉
 type Bucket struct {
 	ID                  ID            `json:"id,omitempty"`
@@ -0,0 +1,169 @@
+package influxdb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Check represents the information required to periodically query a bucket
+// and write the resulting statuses of the data.
+type Check interface {
+	Valid() error
+	Type() string
+	json.Marshaler
+	Updator
+	Getter
+}
+
+// CheckFilter represents a set of filters that restrict the returned checks.
+type CheckFilter struct {
+	ID *ID
+	// IDs restricts the checks to a set of checks, ignoring the ids of the
+	// checks that don't exist.
+	IDs   []*ID
+	Name  *string
+	OrgID *ID
+	Org   *string
+	// Archived returns only the archived checks, which are hidden otherwise.
+	Archived bool
+}
+
+// QueryParams Converts CheckFilter fields to url query params.
+func (f CheckFilter) QueryParams() map[string][]string {
+	qp := map[string][]string{}
+
+	if f.OrgID != nil {
+		qp["orgID"] = []string{f.OrgID.String()}
+	}
+
+	if f.Org != nil {
+		qp["org"] = []string{*f.Org}
+	}
+
+	for _, id := range f.IDs {
+		qp["id"] = append(qp["id"], id.String())
+	}
+
+	if f.Archived {
+		qp["archived"] = []string{"true"}
+	}
+
+	return qp
+}
+
+// consts of the fields checks can be sorted by.
+const (
+	CheckSortByName      = "name"
+	CheckSortByCreatedAt = "createdAt"
+	CheckSortByUpdatedAt = "updatedAt"
+)
+
+// CheckPriority is the priority class of a check. Under load, the checks
+// are evaluated and their notifications delivered in the order of their
+// priority, the longest waiting first within a priority.
+type CheckPriority string
+
+// consts of the priorities of checks, a check without a priority is normal.
+const (
+	CheckPriorityCritical CheckPriority = "critical"
+	CheckPriorityNormal   CheckPriority = "normal"
+	CheckPriorityLow      CheckPriority = "low"
+)
+
+// Valid returns an error if the priority isn't one of the priorities of checks.
+func (p CheckPriority) Valid() error {
+	switch p {
+	case "", CheckPriorityCritical, CheckPriorityNormal, CheckPriorityLow:
+		return nil
+	}
+	return &Error{
+		Code: EInvalid,
+		Msg:  fmt.Sprintf("invalid check priority %s, valid priorities are %s, %s and %s", p, CheckPriorityCritical, CheckPriorityNormal, CheckPriorityLow),
+	}
+}
+
+// Rank returns the order of the priority, the critical priority first.
+func (p CheckPriority) Rank() int {
+	switch p {
+	case CheckPriorityCritical:
+		return 0
+	case CheckPriorityLow:
+		return 2
+	default:
+		return 1
+	}
+}
+
+// CheckUpdate are properties than can be updated on a check
+type CheckUpdate struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+	Status      *Status `json:"status,omitempty"`
+}
+
+// Valid returns err is the update is invalid.
+func (n *CheckUpdate) Valid() error {
+	if n.Name != nil && *n.Name == "" {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "Check Name can't be empty",
+		}
+	}
+
+	if n.Description != nil && *n.Description == "" {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "Check Description can't be empty",
+		}
+	}
+
+	if n.Status != nil {
+		if err := n.Status.Valid(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CheckService represents a service for managing checks.
+type CheckService interface {
+	// FindCheckByID returns a single check by ID.
+	FindCheckByID(ctx context.Context, id ID) (Check, error)
+
+	// FindCheck returns the first check that matches filter.
+	FindCheck(ctx context.Context, filter CheckFilter) (Check, error)
+
+	// FindChecks returns a list of checks that match filter and the total count of matching checks.
+	// Additional options provide pagination & sorting.
+	FindChecks(ctx context.Context, filter CheckFilter, opt ...FindOptions) ([]Check, int, error)
+
+	// CreateCheck creates a new check and sets c.ID with the new identifier.
+	CreateCheck(ctx context.Context, c Check, userID ID) error
+
+	// UpdateCheck updates the whole check.
+	// Returns the new check state after update.
+	UpdateCheck(ctx context.Context, id ID, c Check) (Check, error)
+
+	// PatchCheck updates a single check with changeset.
+	// Returns the new check state after update.
+	PatchCheck(ctx context.Context, id ID, upd CheckUpdate) (Check, error)
+
+	// DeleteCheck removes a check by ID.
+	DeleteCheck(ctx context.Context, id ID) error
+
+	// ArchiveCheck hides a check from the checks found by default and stops
+	// its task, keeping its configuration until it is unarchived.
+	ArchiveCheck(ctx context.Context, id ID) (Check, error)
+
+	// UnarchiveCheck restores an archived check, running its task again if the check is active.
+	UnarchiveCheck(ctx context.Context, id ID) (Check, error)
+}
+
+// CheckPauseNotifier tells the owners of the checks set inactive
+// automatically, such as when a bucket read by their query is deleted.
+type CheckPauseNotifier interface {
+	// NotifyCheckPaused tells an owner of a paused check why it was paused.
+	NotifyCheckPaused(ctx context.Context, c Check, ownerID ID) error
+}
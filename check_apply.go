@@ -0,0 +1,74 @@
+package influxdb
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// maxFieldManagerLength is the maximum length of the name of a field manager.
+const maxFieldManagerLength = 128
+
+// CheckApply is the configuration of a check applied by a field manager,
+// such as a controller reconciling the checks of a GitOps repository, to the
+// check of an organization with its name.
+type CheckApply struct {
+	OrgID ID
+	Name  string
+	// FieldManager is the name of the manager applying the configuration.
+	FieldManager string
+	// Force takes the fields of the configuration over from the other field
+	// managers and the manual edits, rather than conflicting.
+	Force bool
+	// Config is the json of the fields of the check the manager applies,
+	// without the fields set by the server, such as id, orgID or taskID.
+	Config json.RawMessage
+}
+
+// Valid returns an error if the check apply is invalid.
+func (a CheckApply) Valid() error {
+	if a.Name == "" {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "the name of the applied check is required",
+		}
+	}
+	if a.FieldManager == "" || len(a.FieldManager) > maxFieldManagerLength {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "fieldManager is required and must be at most 128 characters",
+		}
+	}
+	return nil
+}
+
+// CheckManagedField is a field of a check and the manager which applied it last.
+type CheckManagedField struct {
+	Field   string    `json:"field"`
+	Manager string    `json:"manager"`
+	Time    time.Time `json:"time"`
+}
+
+// CheckApplyResult is the check a configuration was applied to.
+type CheckApplyResult struct {
+	Check   Check
+	Created bool
+	// ManagedFields are the fields of the check owned by a manager, sorted
+	// by field.
+	ManagedFields []CheckManagedField
+}
+
+// CheckApplyService creates or updates the checks of an organization by name
+// with the configurations of field managers, so the controllers managing
+// some fields of a check don't overwrite the fields managed by the other
+// controllers or edited manually.
+type CheckApplyService interface {
+	// ApplyCheck creates the check with the configuration, owned by userID,
+	// if the organization has no check with its name, or updates the fields
+	// of the configuration. A field applied last by another manager, or
+	// edited since it was applied or set before any apply, conflicts if the
+	// configuration changes it, unless the apply is forced. The fields the
+	// manager applied before but no longer applies keep their value and are
+	// no longer managed.
+	ApplyCheck(ctx context.Context, a CheckApply, userID ID) (*CheckApplyResult, error)
+}
@@ -0,0 +1,71 @@
+package influxdb
+
+import (
+	"context"
+	"fmt"
+)
+
+// MaxCheckBulkCreate is the maximum number of checks created at once.
+const MaxCheckBulkCreate = 1000
+
+// CheckBulkCreate is a set of checks created at once in an organization.
+type CheckBulkCreate struct {
+	OrgID  ID
+	Checks []Check
+}
+
+// Valid returns an error if the bulk creation has no organization, no
+// checks or too many of them, or checks of another organization.
+func (c CheckBulkCreate) Valid() error {
+	if !c.OrgID.Valid() {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "check bulk create orgID is invalid",
+		}
+	}
+	if len(c.Checks) == 0 {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "check bulk create requires checks",
+		}
+	}
+	if len(c.Checks) > MaxCheckBulkCreate {
+		return &Error{
+			Code: EInvalid,
+			Msg:  fmt.Sprintf("at most %d checks are created at once", MaxCheckBulkCreate),
+		}
+	}
+	for i, ch := range c.Checks {
+		if orgID := ch.GetOrgID(); orgID.Valid() && orgID != c.OrgID {
+			return &Error{
+				Code: EInvalid,
+				Msg:  fmt.Sprintf("check %d belongs to another organization", i),
+			}
+		}
+	}
+	return nil
+}
+
+// BulkCheckError returns the error of the check at index i of a bulk
+// operation, with the code of err. The decoding errors only wrap the error
+// of the json, whose text is the message.
+func BulkCheckError(i int, err error) error {
+	msg := ErrorMessage(err)
+	if e, ok := err.(*Error); ok && e.Msg == "" && e.Err != nil {
+		if _, ok := e.Err.(*Error); !ok {
+			msg = e.Err.Error()
+		}
+	}
+	return &Error{
+		Code: ErrorCode(err),
+		Msg:  fmt.Sprintf("check %d: %s", i, msg),
+		Err:  err,
+	}
+}
+
+// CheckBulkCreateService creates many checks of an organization at once.
+type CheckBulkCreateService interface {
+	// CreateChecks creates every check in the organization, owned by userID,
+	// or none of them if any check fails to be created.
+	CreateChecks(ctx context.Context, c CheckBulkCreate, userID ID) error
+}
@@ -0,0 +1,80 @@
+package influxdb
+
+import "context"
+
+// CheckBulkUpdate is a partial update of every check of an organization
+// matching a filter, for tuning a fleet of checks at once.
+type CheckBulkUpdate struct {
+	Filter CheckBulkFilter `json:"filter"`
+	Update CheckBulkPatch  `json:"update"`
+	// DryRun returns the checks the update would change without changing them.
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// CheckBulkFilter selects the checks of a bulk update. A check matches if it
+// has every label and every tag of the filter. Archived checks never match.
+type CheckBulkFilter struct {
+	OrgID ID `json:"orgID"`
+	// Labels are the names of the labels of the checks.
+	Labels []string          `json:"labels,omitempty"`
+	Tags   map[string]string `json:"tags,omitempty"`
+	// Every restricts the update to the checks run at this interval.
+	Every *Duration `json:"every,omitempty"`
+}
+
+// CheckBulkPatch are the properties a bulk update sets on each check.
+type CheckBulkPatch struct {
+	// Every runs the checks at this interval, replacing their cron.
+	Every  *Duration `json:"every,omitempty"`
+	Offset *Duration `json:"offset,omitempty"`
+	Status *Status   `json:"status,omitempty"`
+}
+
+// Valid returns an error if the check bulk update is invalid.
+func (u CheckBulkUpdate) Valid() error {
+	if !u.Filter.OrgID.Valid() {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "check bulk update requires a valid orgID",
+		}
+	}
+	p := u.Update
+	if p.Every == nil && p.Offset == nil && p.Status == nil {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "check bulk update must update every, offset or status",
+		}
+	}
+	if p.Every != nil && p.Every.Duration <= 0 {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "check bulk update every must be positive",
+		}
+	}
+	if p.Offset != nil && p.Offset.Duration < 0 {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "check bulk update offset can't be negative",
+		}
+	}
+	if p.Status != nil {
+		if err := p.Status.Valid(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CheckBulkUpdateResult is the checks changed by a bulk update, or the
+// checks it would change for a dry run.
+type CheckBulkUpdateResult struct {
+	DryRun bool    `json:"dryRun"`
+	Checks []Check `json:"checks"`
+}
+
+// CheckBulkUpdateService updates the checks matching a filter at once.
+type CheckBulkUpdateService interface {
+	// BulkUpdateChecks applies the update to every matching check, or to none
+	// of them if any updated check is invalid.
+	BulkUpdateChecks(ctx context.Context, u CheckBulkUpdate) (*CheckBulkUpdateResult, error)
+}
@@ -0,0 +1,41 @@
+package influxdb
+
+import "context"
+
+// CheckClone duplicates a check: its query, tags, thresholds and labels.
+type CheckClone struct {
+	// Name is the name of the copy of the check.
+	Name string `json:"name"`
+	// OrgID is the organization of the copy, the organization of the check
+	// if unset. The labels of the check are mapped to the labels with the
+	// same name in the organization, which are created if it has none.
+	OrgID ID `json:"orgID,omitempty"`
+	// Bucket is the bucket the query of the copy reads instead of the
+	// buckets of the query of the check, if set.
+	Bucket string `json:"bucket,omitempty"`
+}
+
+// Valid returns an error if the check clone is invalid.
+func (c CheckClone) Valid() error {
+	if c.Name == "" {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "check clone requires a name",
+		}
+	}
+	return nil
+}
+
+// TargetOrgID returns the organization of the copy of a check of orgID.
+func (c CheckClone) TargetOrgID(orgID ID) ID {
+	if c.OrgID.Valid() {
+		return c.OrgID
+	}
+	return orgID
+}
+
+// CheckCloneService duplicates checks, keeping their label mappings.
+type CheckCloneService interface {
+	// CloneCheck creates a copy of a check and of its label mappings, owned by userID.
+	CloneCheck(ctx context.Context, id ID, c CheckClone, userID ID) (Check, error)
+}
@@ -0,0 +1,40 @@
+package influxdb
+
+import "context"
+
+// CheckCoverageReport cross-references the checks of an organization with
+// the measurements written to its buckets.
+type CheckCoverageReport struct {
+	OrgID   ID               `json:"orgID"`
+	Buckets []BucketCoverage `json:"buckets"`
+}
+
+// BucketCoverage is the coverage of the measurements of a bucket by checks.
+type BucketCoverage struct {
+	BucketID   ID     `json:"bucketID"`
+	BucketName string `json:"bucketName"`
+	// MonitoredMeasurements are the measurements queried by at least one check.
+	MonitoredMeasurements []MonitoredMeasurement `json:"monitoredMeasurements"`
+	// UnmonitoredMeasurements are the measurements no check queries.
+	UnmonitoredMeasurements []string `json:"unmonitoredMeasurements"`
+}
+
+// MonitoredMeasurement is a measurement and the checks querying it.
+type MonitoredMeasurement struct {
+	Name     string `json:"name"`
+	CheckIDs []ID   `json:"checkIDs"`
+}
+
+// CheckCoverageService reports the coverage of the data of an organization by checks.
+type CheckCoverageService interface {
+	// FindCheckCoverage returns the coverage of the measurements of the buckets
+	// by the checks of an organization, every bucket of the organization if
+	// bucketIDs is empty.
+	FindCheckCoverage(ctx context.Context, orgID ID, bucketIDs []ID) (*CheckCoverageReport, error)
+}
+
+// MeasurementService lists the measurements written to buckets.
+type MeasurementService interface {
+	// FindMeasurements returns the names of the measurements of a bucket in order.
+	FindMeasurements(ctx context.Context, orgID, bucketID ID) ([]string, error)
+}
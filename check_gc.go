@@ -0,0 +1,136 @@
+package influxdb
+
+import (
+	"context"
+	"time"
+)
+
+// consts of the defaults of CheckGCPolicy
+const (
+	// DefaultCheckGCGracePeriod is the default time between the notification
+	// of the owners of a check and its deletion.
+	DefaultCheckGCGracePeriod = 14 * 24 * time.Hour
+	// DefaultCheckGCExcludeLabel is the default name of the label sparing
+	// the checks from the garbage collection.
+	DefaultCheckGCExcludeLabel = "gc-exclude"
+	// DefaultCheckGCInterval is the default time between the runs of the
+	// garbage collection of the checks.
+	DefaultCheckGCInterval = time.Hour
+)
+
+// MaxCheckGCEvents is the number of the events of the garbage collection of
+// the checks kept, the oldest events are dropped first.
+const MaxCheckGCEvents = 10000
+
+// CheckGCPolicy is which checks the garbage collection deletes, so long
+// lived instances don't accumulate the checks nobody uses. A check inactive
+// and unmodified for InactiveFor is marked and its owners are notified, it
+// is deleted once GracePeriod has passed if it is still eligible.
+type CheckGCPolicy struct {
+	// InactiveFor is how long a check must be inactive or archived and
+	// unmodified to be collected, the garbage collection is off when 0.
+	InactiveFor time.Duration
+	// GracePeriod is the time between the notification of the owners of a
+	// check and its deletion, DefaultCheckGCGracePeriod when 0.
+	GracePeriod time.Duration
+	// ExcludeLabel is the name of the label sparing the checks it is
+	// applied to, no check is spared when empty.
+	ExcludeLabel string
+	// DryRun reports what the garbage collection would do, without
+	// notifying, deleting nor recording anything.
+	DryRun bool
+}
+
+// Valid returns an error if the policy is invalid.
+func (p CheckGCPolicy) Valid() error {
+	if p.InactiveFor < 0 || p.GracePeriod < 0 {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "check gc durations can't be negative",
+		}
+	}
+	return nil
+}
+
+// Enabled returns whether the policy collects any check.
+func (p CheckGCPolicy) Enabled() bool {
+	return p.InactiveFor > 0
+}
+
+// Grace returns the grace period of the policy.
+func (p CheckGCPolicy) Grace() time.Duration {
+	if p.GracePeriod == 0 {
+		return DefaultCheckGCGracePeriod
+	}
+	return p.GracePeriod
+}
+
+// consts of the actions of the garbage collection of the checks.
+const (
+	// CheckGCNotified is the action of a check marked for deletion, whose
+	// owners were notified.
+	CheckGCNotified = "notified"
+	// CheckGCDeleted is the action of a marked check deleted after the
+	// grace period.
+	CheckGCDeleted = "deleted"
+	// CheckGCSpared is the action of a marked check which isn't eligible
+	// anymore, and whose mark was cleared.
+	CheckGCSpared = "spared"
+	// CheckGCFailed is the action of a marked check which failed to be
+	// deleted.
+	CheckGCFailed = "failed"
+)
+
+// CheckGCEvent is an action of the garbage collection on a check, the
+// events form the audit trail of the garbage collection.
+type CheckGCEvent struct {
+	Time    time.Time `json:"time"`
+	CheckID ID        `json:"checkID"`
+	OrgID   ID        `json:"orgID"`
+	Name    string    `json:"name"`
+	Action  string    `json:"action"`
+	Reason  string    `json:"reason"`
+	// DeleteAfter is when a marked check is deleted if it is still eligible.
+	DeleteAfter *time.Time `json:"deleteAfter,omitempty"`
+	// Notified are the owners of a marked check who were notified.
+	Notified []ID   `json:"notified,omitempty"`
+	DryRun   bool   `json:"dryRun,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// CheckGCRun is the result of a run of the garbage collection of the checks.
+type CheckGCRun struct {
+	StartedAt  time.Time      `json:"startedAt"`
+	FinishedAt time.Time      `json:"finishedAt"`
+	DryRun     bool           `json:"dryRun,omitempty"`
+	Events     []CheckGCEvent `json:"events"`
+}
+
+// CheckGCEventFilter restricts the events of the garbage collection of the
+// checks found.
+type CheckGCEventFilter struct {
+	OrgID   *ID
+	CheckID *ID
+	// Action restricts the events to one action.
+	Action string
+}
+
+// CheckGCService collects the checks nobody uses.
+type CheckGCService interface {
+	// RunCheckGC marks the checks eligible to p and notifies their owners,
+	// deletes the marked checks past the grace period and clears the marks
+	// of the checks which aren't eligible anymore, recording every action.
+	RunCheckGC(ctx context.Context, p CheckGCPolicy) (*CheckGCRun, error)
+
+	// FindCheckGCEvents returns the recorded events of the garbage
+	// collection matching filter, from the oldest to the latest.
+	FindCheckGCEvents(ctx context.Context, filter CheckGCEventFilter) ([]*CheckGCEvent, error)
+}
+
+// CheckGCNotifier tells the owners of the checks about to be deleted by the
+// garbage collection.
+type CheckGCNotifier interface {
+	// NotifyCheckGC tells an owner of a check that it will be deleted after
+	// deleteAfter unless it is modified, activated or labeled to be spared.
+	NotifyCheckGC(ctx context.Context, c Check, ownerID ID, deleteAfter time.Time, excludeLabel string) error
+}
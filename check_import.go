@@ -0,0 +1,61 @@
+package influxdb
+
+import "context"
+
+// consts of the strategies of a check import for the checks whose name is
+// already used by a check of the organization.
+const (
+	// ImportOnConflictSkip keeps the check of the organization.
+	ImportOnConflictSkip = "skip"
+	// ImportOnConflictOverwrite replaces the check of the organization with the imported check.
+	ImportOnConflictOverwrite = "overwrite"
+	// ImportOnConflictRename imports the check with the first free name
+	// suffixed with a number, such as "cpu (2)".
+	ImportOnConflictRename = "rename-with-suffix"
+)
+
+// consts of what a check import did with each check.
+const (
+	CheckImportCreated     = "created"
+	CheckImportSkipped     = "skipped"
+	CheckImportOverwritten = "overwritten"
+	CheckImportRenamed     = "renamed"
+)
+
+// CheckImport is a set of checks imported to an organization.
+type CheckImport struct {
+	Checks []Check
+	// OnConflict is the strategy for the checks whose name is already used by
+	// a check of the organization. The import fails with a conflict by default.
+	OnConflict string
+}
+
+// Valid returns an error if the check import is invalid.
+func (i CheckImport) Valid() error {
+	switch i.OnConflict {
+	case "", ImportOnConflictSkip, ImportOnConflictOverwrite, ImportOnConflictRename:
+	default:
+		return &Error{
+			Code: EInvalid,
+			Msg:  "check import onConflict must be skip, overwrite or rename-with-suffix",
+		}
+	}
+	return nil
+}
+
+// CheckImportResult is what a check import did with one of its checks.
+type CheckImportResult struct {
+	// Name is the name of the imported check.
+	Name   string `json:"name"`
+	Action string `json:"action"`
+	// Check is the check created or overwritten, or the check of the
+	// organization if the imported check was skipped.
+	Check Check `json:"check"`
+}
+
+// CheckImportService imports checks, such as the checks of a template, into an organization.
+type CheckImportService interface {
+	// ImportChecks creates the checks of an import in an organization, owned by userID,
+	// applying its strategy to the checks whose name is already used.
+	ImportChecks(ctx context.Context, orgID ID, imp CheckImport, userID ID) ([]*CheckImportResult, error)
+}
@@ -0,0 +1,65 @@
+package influxdb
+
+import (
+	"context"
+	"time"
+)
+
+// MaxCheckLagSamples is the number of the latest evaluations of a check whose
+// lag is kept.
+const MaxCheckLagSamples = 100
+
+// MaxCheckLagSummaryChecks is the number of the checks of the highest lag of
+// the lag summary of an organization.
+const MaxCheckLagSummaryChecks = 10
+
+// CheckLagSample is a scheduled evaluation of a check: when it was due and
+// when it started. The first evaluation of a check isn't sampled, the check
+// is due when the alerting engine first sees it.
+type CheckLagSample struct {
+	ScheduledFor time.Time `json:"scheduledFor"`
+	StartedAt    time.Time `json:"startedAt"`
+	// Lag is how long after it was due the evaluation started.
+	Lag Duration `json:"lag"`
+}
+
+// CheckLagStats are the percentiles of the lag of evaluations.
+type CheckLagStats struct {
+	Evaluations int      `json:"evaluations"`
+	P50         Duration `json:"p50"`
+	P90         Duration `json:"p90"`
+	P99         Duration `json:"p99"`
+	Max         Duration `json:"max"`
+}
+
+// CheckLag is the lag of the latest evaluations of a check, an early warning
+// of an alerting engine which can't keep up with its checks.
+type CheckLag struct {
+	CheckID ID `json:"checkID"`
+	OrgID   ID `json:"orgID"`
+	CheckLagStats
+	// Samples are the latest evaluations of the check, the latest first.
+	Samples []CheckLagSample `json:"samples,omitempty"`
+}
+
+// CheckLagSummary is the lag of the latest evaluations of the checks of an
+// organization.
+type CheckLagSummary struct {
+	OrgID ID `json:"orgID"`
+	// Checks is the number of the checks with evaluations.
+	Checks int `json:"checks"`
+	CheckLagStats
+	// Laggiest are the checks of the highest p90 lag, without their samples.
+	Laggiest []*CheckLag `json:"laggiest"`
+}
+
+// CheckLagService finds the lag of the evaluations of checks, which are kept
+// by the alerting engine evaluating them.
+type CheckLagService interface {
+	// FindCheckLag returns the lag of the latest evaluations of a check.
+	FindCheckLag(ctx context.Context, checkID ID) (*CheckLag, error)
+
+	// FindCheckLagSummary returns the lag of the latest evaluations of the
+	// checks of an organization.
+	FindCheckLagSummary(ctx context.Context, orgID ID) (*CheckLagSummary, error)
+}
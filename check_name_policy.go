@@ -0,0 +1,75 @@
+package influxdb
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// CheckNamePolicy constrains the names of the checks, so operators can enforce
+// naming conventions. The zero value accepts any name.
+type CheckNamePolicy struct {
+	// MaxLength is the maximum number of characters of a name, unlimited if 0.
+	MaxLength int
+	// Pattern is a regular expression the whole name must match, such as
+	// [a-z0-9_-]+ to restrict the charset. Any name matches an empty pattern.
+	Pattern string
+	// ReservedPrefixes are the prefixes names can't start with, such as _system.
+	ReservedPrefixes []string
+}
+
+// Valid returns an error if the policy is invalid.
+func (p CheckNamePolicy) Valid() error {
+	if p.MaxLength < 0 {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "check name max length can't be negative",
+		}
+	}
+	if _, err := p.pattern(); err != nil {
+		return &Error{
+			Code: EInvalid,
+			Msg:  fmt.Sprintf("check name pattern %s is invalid", p.Pattern),
+			Err:  err,
+		}
+	}
+	return nil
+}
+
+// pattern returns the pattern anchored to match whole names, nil if the policy has none.
+func (p CheckNamePolicy) pattern() (*regexp.Regexp, error) {
+	if p.Pattern == "" {
+		return nil, nil
+	}
+	return regexp.Compile("^(?:" + p.Pattern + ")$")
+}
+
+// ValidateName returns an EInvalid error if name doesn't follow the policy.
+func (p CheckNamePolicy) ValidateName(name string) error {
+	if p.MaxLength > 0 && utf8.RuneCountInString(name) > p.MaxLength {
+		return &Error{
+			Code: EInvalid,
+			Msg:  fmt.Sprintf("check name %q is longer than %d characters", name, p.MaxLength),
+		}
+	}
+	for _, prefix := range p.ReservedPrefixes {
+		if prefix != "" && strings.HasPrefix(name, prefix) {
+			return &Error{
+				Code: EInvalid,
+				Msg:  fmt.Sprintf("check name %q starts with the reserved prefix %s", name, prefix),
+			}
+		}
+	}
+	re, err := p.pattern()
+	if err != nil {
+		return err
+	}
+	if re != nil && !re.MatchString(name) {
+		return &Error{
+			Code: EInvalid,
+			Msg:  fmt.Sprintf("check name %q doesn't match the pattern %s", name, p.Pattern),
+		}
+	}
+	return nil
+}
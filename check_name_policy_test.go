@@ -0,0 +1,74 @@
+package influxdb_test
+
+import (
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	influxdbtesting "github.com/influxdata/influxdb/testing"
+)
+
+func TestCheckNamePolicy_ValidateName(t *testing.T) {
+	policy := influxdb.CheckNamePolicy{
+		MaxLength:        12,
+		Pattern:          `[a-z0-9_-]+`,
+		ReservedPrefixes: []string{"_system"},
+	}
+	tests := []struct {
+		name   string
+		policy influxdb.CheckNamePolicy
+		check  string
+		err    error
+	}{
+		{
+			name:   "any name follows the zero policy",
+			policy: influxdb.CheckNamePolicy{},
+			check:  "_system CPU usage is high",
+		},
+		{
+			name:   "name following the policy",
+			policy: policy,
+			check:  "cpu-usage",
+		},
+		{
+			name:   "name too long",
+			policy: policy,
+			check:  "cpu-usage-is-high",
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  `check name "cpu-usage-is-high" is longer than 12 characters`,
+			},
+		},
+		{
+			name:   "name with a reserved prefix",
+			policy: policy,
+			check:  "_system_cpu",
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  `check name "_system_cpu" starts with the reserved prefix _system`,
+			},
+		},
+		{
+			name:   "name out of the charset",
+			policy: policy,
+			check:  "CPU usage",
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  `check name "CPU usage" doesn't match the pattern [a-z0-9_-]+`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			influxdbtesting.ErrorsEqual(t, tt.policy.ValidateName(tt.check), tt.err)
+		})
+	}
+}
+
+func TestCheckNamePolicy_Valid(t *testing.T) {
+	if err := (influxdb.CheckNamePolicy{Pattern: `[a-z`}).Valid(); influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Errorf("expected an invalid pattern to be invalid, got %v", err)
+	}
+	if err := (influxdb.CheckNamePolicy{MaxLength: -1}).Valid(); influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Errorf("expected a negative max length to be invalid, got %v", err)
+	}
+}
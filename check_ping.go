@@ -0,0 +1,10 @@
+package influxdb
+
+import "context"
+
+// CheckPingService records the pings of the heartbeat checks, which write a
+// status when the pings stop.
+type CheckPingService interface {
+	// PingCheck records a ping of a heartbeat check.
+	PingCheck(ctx context.Context, checkID ID) error
+}
@@ -0,0 +1,31 @@
+package influxdb
+
+import (
+	"context"
+	"time"
+)
+
+// CheckSeries is a series of the data of the query of a check, such as a
+// table of the result of a flux query, and its values in time order.
+type CheckSeries struct {
+	Tags   map[string]string `json:"tags"`
+	Values []float64         `json:"values"`
+	Times  []time.Time       `json:"times"`
+}
+
+// CheckStagePreview is the data of a stage of the query of a check.
+type CheckStagePreview struct {
+	// Stage is empty for the whole query of the check.
+	Stage string `json:"stage,omitempty"`
+	// Query is the query of the check up to the stage.
+	Query  string        `json:"query"`
+	Series []CheckSeries `json:"series"`
+}
+
+// CheckPreviewService previews the data of the queries of checks, stage by
+// stage, while they are edited.
+type CheckPreviewService interface {
+	// PreviewCheckStage returns the data of a stage of the query of a check,
+	// which needn't be created, or of its whole query if stage is empty.
+	PreviewCheckStage(ctx context.Context, c Check, stage string) (*CheckStagePreview, error)
+}
@@ -0,0 +1,49 @@
+package influxdb
+
+import (
+	"context"
+	"time"
+)
+
+// CheckRelatedDeliveries is the number of the most recent deliveries of a
+// check returned with its related resources.
+const CheckRelatedDeliveries = 20
+
+// CheckRelated is a check and the resources linked to it, so that they can be
+// shown together.
+type CheckRelated struct {
+	Check Check `json:"check"`
+	// Task is the task running the check, nil when the check is evaluated
+	// without a task.
+	Task *Task `json:"task,omitempty"`
+	// NotificationRules are the rules of the organization of the check whose
+	// tag rules match the tags of the check.
+	NotificationRules []NotificationRule `json:"notificationRules"`
+	// NotificationEndpoints are the existing endpoints the rules send to.
+	NotificationEndpoints []NotificationEndpoint `json:"notificationEndpoints"`
+	// Deliveries are the most recent notifications of the statuses of the
+	// check, the latest first.
+	Deliveries []CheckDelivery `json:"deliveries"`
+	Labels     []*Label        `json:"labels"`
+}
+
+// CheckDelivery is a notification of a status of a check sent, or failed to
+// be sent, by a notification rule.
+type CheckDelivery struct {
+	StatusID   ID           `json:"statusID"`
+	Time       time.Time    `json:"time"`
+	Level      string       `json:"level"`
+	RuleID     ID           `json:"ruleID"`
+	RuleName   string       `json:"ruleName"`
+	EndpointID *ID          `json:"endpointID,omitempty"`
+	Decision   RuleDecision `json:"decision"`
+	Reason     string       `json:"reason,omitempty"`
+}
+
+// CheckRelatedService finds the resources linked to a check.
+type CheckRelatedService interface {
+	// FindCheckRelated returns a check with its task, the notification rules
+	// matching it, the endpoints of those rules, its recent deliveries and
+	// its labels.
+	FindCheckRelated(ctx context.Context, checkID ID) (*CheckRelated, error)
+}
@@ -0,0 +1,69 @@
+package influxdb
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// CheckRunSummaryLogPrefix prefixes the log message of the summary of a run
+// of the task of a check.
+const CheckRunSummaryLogPrefix = "Check run summary: "
+
+// CheckRunSummary is the outcome of a run of the task of a check: the
+// statuses it produced and what the notification rules did with them.
+type CheckRunSummary struct {
+	// Statuses is the number of the statuses of the run by level.
+	Statuses map[string]int `json:"statuses"`
+	// Notifications is the number of the notifications sent.
+	Notifications int `json:"notifications"`
+	// Suppressions is the number of the notifications which weren't sent by
+	// decision: muted, deduplicated or deferred.
+	Suppressions map[RuleDecision]int `json:"suppressions"`
+	// Failures is the number of the notifications which failed to be sent.
+	Failures int `json:"failures"`
+}
+
+// NewCheckRunSummary returns the summary of the statuses of a run and of
+// their decision traces.
+func NewCheckRunSummary(traces []*StatusTrace) *CheckRunSummary {
+	s := &CheckRunSummary{
+		Statuses:     make(map[string]int),
+		Suppressions: make(map[RuleDecision]int),
+	}
+	for _, t := range traces {
+		s.Statuses[t.Level]++
+		for _, rt := range t.Rules {
+			switch rt.Decision {
+			case RuleNotified:
+				s.Notifications++
+			case RuleMuted, RuleDeduplicated, RuleDeferred:
+				s.Suppressions[rt.Decision]++
+			case RuleFailed:
+				s.Failures++
+			}
+		}
+	}
+	return s
+}
+
+// LogMessage returns the message of the summary in the log of the run.
+func (s *CheckRunSummary) LogMessage() (string, error) {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return "", err
+	}
+	return CheckRunSummaryLogPrefix + string(b), nil
+}
+
+// ParseCheckRunSummary returns the summary of a log message of a run, and
+// whether the message is one.
+func ParseCheckRunSummary(message string) (*CheckRunSummary, bool) {
+	if !strings.HasPrefix(message, CheckRunSummaryLogPrefix) {
+		return nil, false
+	}
+	s := &CheckRunSummary{}
+	if err := json.Unmarshal([]byte(strings.TrimPrefix(message, CheckRunSummaryLogPrefix)), s); err != nil {
+		return nil, false
+	}
+	return s, true
+}
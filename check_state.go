@@ -0,0 +1,57 @@
+package influxdb
+
+import (
+	"context"
+	"time"
+)
+
+// CheckState is the state the alerting engine keeps of a check between its
+// runs. The engines of the servers of a cluster share it, for the engine
+// taking a check over to carry on where the engine which ran it stopped.
+type CheckState struct {
+	CheckID ID `json:"checkID"`
+	// LastRun is the latest scheduled time of the check.
+	LastRun time.Time `json:"lastRun"`
+	// Series are the states of the series of the statuses of the check, by
+	// the key of their series.
+	Series map[string]SeriesState `json:"series,omitempty"`
+	// Sent is when each notification rule with a limit sent the latest
+	// notifications of the statuses of the check.
+	Sent map[ID][]time.Time `json:"sent,omitempty"`
+	// Lags are the latest lag samples of the check.
+	Lags []CheckLagSample `json:"lags,omitempty"`
+}
+
+// SeriesState is the state of a series of statuses of a check.
+type SeriesState struct {
+	// Level is the latest level of the series.
+	Level string `json:"level,omitempty"`
+	// Pending is the level the series is evaluated at, which isn't its level
+	// yet, and PendingCount for how many consecutive evaluations.
+	Pending      string `json:"pending,omitempty"`
+	PendingCount int    `json:"pendingCount,omitempty"`
+	// IncidentStart is when the series left the ok level, nil when it's ok.
+	IncidentStart *time.Time `json:"incidentStart,omitempty"`
+	// Notified is the level each notification rule last notified the series
+	// at, and when, by the id of the rule.
+	Notified map[ID]NotifiedLevel `json:"notified,omitempty"`
+}
+
+// NotifiedLevel is the level a notification rule notified a series at.
+type NotifiedLevel struct {
+	Level string    `json:"level"`
+	At    time.Time `json:"at"`
+}
+
+// CheckStateService persists the state of the checks run by the alerting
+// engine.
+type CheckStateService interface {
+	// FindCheckState returns the state of a check, ENotFound if it has none.
+	FindCheckState(ctx context.Context, checkID ID) (*CheckState, error)
+
+	// PutCheckState saves the state of a check.
+	PutCheckState(ctx context.Context, s *CheckState) error
+
+	// DeleteCheckState removes the state of a check.
+	DeleteCheckState(ctx context.Context, checkID ID) error
+}
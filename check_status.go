@@ -0,0 +1,103 @@
+package influxdb
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// MaxCheckStatusIDs is the maximum number of checks whose statuses are found at once.
+const MaxCheckStatusIDs = 100
+
+const (
+	// DefaultCheckStatusHistoryLimit is the number of statuses of the history
+	// of a check found when the filter has no limit.
+	DefaultCheckStatusHistoryLimit = 100
+	// MaxCheckStatusHistoryLimit is the maximum number of statuses of the
+	// history of a check found at once.
+	MaxCheckStatusHistoryLimit = 1000
+)
+
+// CheckStatus is the current status of a check, from its latest status
+// traced by the alerting engine.
+type CheckStatus struct {
+	CheckID ID `json:"checkID"`
+	OrgID   ID `json:"orgID"`
+	// Level is the level of the latest status, empty when the check has no
+	// status yet.
+	Level   string `json:"level,omitempty"`
+	Message string `json:"message,omitempty"`
+	// Time is the time of the latest status.
+	Time *time.Time `json:"time,omitempty"`
+	// LastChange is the time of the first status at the level of the latest
+	// status, since the check has been at that level.
+	LastChange *time.Time `json:"lastChange,omitempty"`
+}
+
+// CheckStatusRecord is a status a check has produced.
+type CheckStatusRecord struct {
+	StatusID ID        `json:"statusID"`
+	Level    string    `json:"level"`
+	Message  string    `json:"message,omitempty"`
+	Time     time.Time `json:"time"`
+}
+
+// CheckStatusHistory are the statuses a check has produced, the latest first.
+type CheckStatusHistory struct {
+	CheckID  ID                   `json:"checkID"`
+	OrgID    ID                   `json:"orgID"`
+	Statuses []*CheckStatusRecord `json:"statuses"`
+}
+
+// CheckStatusHistoryFilter selects the statuses of the history of a check.
+type CheckStatusHistoryFilter struct {
+	// Start is the time of the oldest status, inclusive.
+	Start *time.Time
+	// Stop is the time the statuses are before, exclusive.
+	Stop *time.Time
+	// Limit is the number of the latest statuses found,
+	// DefaultCheckStatusHistoryLimit when 0.
+	Limit int
+}
+
+// Valid returns an error if the time range or the limit of the filter are invalid.
+func (f CheckStatusHistoryFilter) Valid() error {
+	if f.Start != nil && f.Stop != nil && !f.Start.Before(*f.Stop) {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "start must be before stop",
+		}
+	}
+	if f.Limit < 0 || f.Limit > MaxCheckStatusHistoryLimit {
+		return &Error{
+			Code: EInvalid,
+			Msg:  fmt.Sprintf("limit must be between 1 and %d", MaxCheckStatusHistoryLimit),
+		}
+	}
+	return nil
+}
+
+// CheckQuery is the query of the data of a check, and the flux script
+// generated to run it if the check is run by a task.
+type CheckQuery struct {
+	CheckID   ID     `json:"checkID"`
+	OrgID     ID     `json:"orgID"`
+	QueryType string `json:"queryType"`
+	Query     string `json:"query"`
+	// Flux is the script of the task of the check, empty if the alerting
+	// engine evaluates the check.
+	Flux string `json:"flux,omitempty"`
+}
+
+// CheckStatusService finds the current statuses of checks and their history.
+type CheckStatusService interface {
+	// FindCheckStatuses returns the current status of each check, in the
+	// order of the checkIDs.
+	FindCheckStatuses(ctx context.Context, checkIDs []ID) ([]*CheckStatus, error)
+
+	// FindCheckStatusHistory returns the statuses a check has produced.
+	FindCheckStatusHistory(ctx context.Context, checkID ID, filter CheckStatusHistoryFilter) (*CheckStatusHistory, error)
+
+	// FindCheckQuery returns the query of a check and its generated flux.
+	FindCheckQuery(ctx context.Context, checkID ID) (*CheckQuery, error)
+}
@@ -0,0 +1,91 @@
+package influxdb
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// CheckTaskReconcilePolicy is what the reconciliation of the checks and
+// their tasks does with the inconsistencies it finds.
+type CheckTaskReconcilePolicy string
+
+// consts of CheckTaskReconcilePolicy
+const (
+	// CheckTaskReconcileRepair recreates the missing tasks of the checks and
+	// deletes the tasks of the deleted checks.
+	CheckTaskReconcileRepair CheckTaskReconcilePolicy = "repair"
+	// CheckTaskReconcileReport only reports the inconsistencies.
+	CheckTaskReconcileReport CheckTaskReconcilePolicy = "report"
+	// CheckTaskReconcileOff doesn't reconcile the checks and their tasks.
+	CheckTaskReconcileOff CheckTaskReconcilePolicy = "off"
+)
+
+// Valid returns an error if p is not a known policy.
+func (p CheckTaskReconcilePolicy) Valid() error {
+	switch p {
+	case CheckTaskReconcileRepair, CheckTaskReconcileReport, CheckTaskReconcileOff:
+		return nil
+	default:
+		return &Error{
+			Code: EInvalid,
+			Msg:  fmt.Sprintf("unknown check task reconcile policy %q, expected %s, %s or %s", p, CheckTaskReconcileRepair, CheckTaskReconcileReport, CheckTaskReconcileOff),
+		}
+	}
+}
+
+// consts of the inconsistencies between the checks and their tasks.
+const (
+	// ReconcileCheckMissingTask is the reason of a check without a task.
+	ReconcileCheckMissingTask = "the check has no task"
+	// ReconcileCheckDanglingTask is the reason of a check whose task doesn't exist.
+	ReconcileCheckDanglingTask = "the task of the check doesn't exist"
+	// ReconcileTaskOrphaned is the reason of a task of a check which doesn't exist.
+	ReconcileTaskOrphaned = "the check of the task doesn't exist"
+)
+
+// consts of the actions taken on the inconsistencies.
+const (
+	// ReconcileReported is the action of an inconsistency left as is.
+	ReconcileReported = "reported"
+	// ReconcileRecreated is the action of a check whose task was recreated.
+	ReconcileRecreated = "recreated"
+	// ReconcileDeleted is the action of an orphaned task which was deleted.
+	ReconcileDeleted = "deleted"
+	// ReconcileFailed is the action of an inconsistency which failed to be repaired.
+	ReconcileFailed = "failed"
+)
+
+// CheckTaskReconciliation is the result of a reconciliation of the checks
+// and their tasks.
+type CheckTaskReconciliation struct {
+	Policy     CheckTaskReconcilePolicy `json:"policy"`
+	StartedAt  time.Time                `json:"startedAt"`
+	FinishedAt time.Time                `json:"finishedAt"`
+	// Checks are the checks whose task is missing.
+	Checks []ReconciledResource `json:"checks"`
+	// Tasks are the tasks of the checks which don't exist.
+	Tasks []ReconciledResource `json:"tasks"`
+}
+
+// ReconciledResource is a check or a task found inconsistent by a reconciliation.
+type ReconciledResource struct {
+	ID     ID     `json:"id"`
+	OrgID  ID     `json:"orgID"`
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+	Action string `json:"action"`
+	// TaskID is the task recreated for a check.
+	TaskID *ID    `json:"taskID,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// CheckTaskReconciler reconciles the checks and their tasks after partial failures.
+type CheckTaskReconciler interface {
+	// ReconcileCheckTasks finds the checks whose task is missing and the tasks
+	// of the checks which don't exist, applies p to them and records the result.
+	ReconcileCheckTasks(ctx context.Context, p CheckTaskReconcilePolicy) (*CheckTaskReconciliation, error)
+
+	// FindCheckTaskReconciliation returns the result of the latest reconciliation.
+	FindCheckTaskReconciliation(ctx context.Context) (*CheckTaskReconciliation, error)
+}
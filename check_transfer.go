@@ -0,0 +1,57 @@
+package influxdb
+
+import "context"
+
+// consts of the strategies of a check transfer when the target organization
+// already has a check with the same name.
+const (
+	// TransferNameCollisionFail fails the transfer.
+	TransferNameCollisionFail = "fail"
+	// TransferNameCollisionRename renames the check to the first free name
+	// suffixed with a number, such as "cpu (2)".
+	TransferNameCollisionRename = "rename"
+)
+
+// CheckTransfer moves a check to another organization.
+type CheckTransfer struct {
+	// OrgID is the organization the check is moved to.
+	OrgID ID `json:"orgID"`
+	// IncludeRules also moves the notification rules of the organization
+	// of the check whose tag rules match the tags of the check.
+	IncludeRules bool `json:"includeRules"`
+	// OnNameCollision is the strategy when the organization already has a
+	// check with the same name, TransferNameCollisionFail by default.
+	OnNameCollision string `json:"onNameCollision,omitempty"`
+}
+
+// Valid returns an error if the check transfer is invalid.
+func (t CheckTransfer) Valid() error {
+	if !t.OrgID.Valid() {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "check transfer requires a valid orgID",
+		}
+	}
+	switch t.OnNameCollision {
+	case "", TransferNameCollisionFail, TransferNameCollisionRename:
+	default:
+		return &Error{
+			Code: EInvalid,
+			Msg:  "check transfer onNameCollision must be fail or rename",
+		}
+	}
+	return nil
+}
+
+// CheckTransferResult is the check and notification rules moved by a check transfer.
+type CheckTransferResult struct {
+	Check             Check              `json:"check"`
+	NotificationRules []NotificationRule `json:"notificationRules"`
+}
+
+// CheckTransferService moves checks between organizations, for operators
+// consolidating organizations.
+type CheckTransferService interface {
+	// TransferCheck moves a check, and optionally its notification rules, to another organization.
+	TransferCheck(ctx context.Context, id ID, t CheckTransfer) (*CheckTransferResult, error)
+}
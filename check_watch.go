@@ -0,0 +1,58 @@
+package influxdb
+
+import (
+	"context"
+	"time"
+)
+
+// MaxCheckEvents is the number of the latest events of the checks which are
+// kept, the watches from an older resource version have to list the checks
+// again.
+const MaxCheckEvents = 10000
+
+// CheckEventType is the change of a check an event records.
+type CheckEventType string
+
+// consts of CheckEventType
+const (
+	CheckCreated CheckEventType = "created"
+	CheckUpdated CheckEventType = "updated"
+	// CheckDeleted is also the event of a check transferred out of the
+	// organization, which has a created event in its new organization.
+	CheckDeleted CheckEventType = "deleted"
+)
+
+// CheckEvent is a change of a check, at a resource version which increases
+// with every change of every check.
+type CheckEvent struct {
+	ResourceVersion uint64         `json:"resourceVersion"`
+	Type            CheckEventType `json:"type"`
+	CheckID         ID             `json:"checkID"`
+	OrgID           ID             `json:"orgID"`
+	Time            time.Time      `json:"time"`
+	// Check is the check after the change, nil for a deleted check.
+	Check Check `json:"check,omitempty"`
+}
+
+// CheckEventFilter selects the events of the checks of an organization
+// following a resource version.
+type CheckEventFilter struct {
+	OrgID ID
+	// Since is the resource version the events follow, the latest one when
+	// nil, so the watch only returns the events to come.
+	Since *uint64
+	// Limit is the maximum number of events returned, every event when 0.
+	Limit int
+}
+
+// CheckWatchService finds the changes of the checks, so the controllers
+// reconciling the checks, such as a Kubernetes operator, watch them rather
+// than list them all repeatedly.
+type CheckWatchService interface {
+	// FindCheckEvents returns the events of the checks of an organization
+	// following a resource version, the oldest first, and the resource
+	// version to watch from next. It returns a conflict error if the events
+	// following the resource version were dropped, the checks have to be
+	// listed again.
+	FindCheckEvents(ctx context.Context, filter CheckEventFilter) ([]*CheckEvent, uint64, error)
+}
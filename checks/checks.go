@@ -0,0 +1,90 @@
+// Package checks builds the checks of the alerting engine in Go, for the
+// provisioning tools and the tests creating checks without assembling their JSON:
+//
+//	c, err := checks.NewThreshold("cpu").
+//		Org(orgID).
+//		Query(`from(bucket: "telegraf") |> range(start: -1m) |> filter(fn: (r) => r._field == "usage_user")`).
+//		Every("1m").
+//		Crit(checks.Greater(90)).
+//		Warn(checks.Within(75, 90)).
+//		Build()
+//
+// The errors of the builders, such as an invalid duration, are returned by Build,
+// along with the errors of the validation of the check.
+package checks
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/check"
+)
+
+// baseBuilder sets the fields shared by every type of check, and keeps the
+// first error of the calls to return it from Build.
+type baseBuilder struct {
+	b   *check.Base
+	err error
+}
+
+func newBaseBuilder(b *check.Base, name string) baseBuilder {
+	b.Name = name
+	b.Status = influxdb.Active
+	return baseBuilder{b: b}
+}
+
+func (bb *baseBuilder) fail(err error) {
+	if bb.err == nil {
+		bb.err = err
+	}
+}
+
+func (bb *baseBuilder) duration(field, d string) time.Duration {
+	v, err := time.ParseDuration(d)
+	if err != nil {
+		bb.fail(&influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("check %s %q is not a duration, such as 1m", field, d),
+		})
+	}
+	return v
+}
+
+func (bb *baseBuilder) every(d string) {
+	bb.b.Every = influxdb.Duration{Duration: bb.duration("every", d)}
+	bb.b.Cron = ""
+}
+
+func (bb *baseBuilder) cron(c string) {
+	bb.b.Cron = c
+	bb.b.Every = influxdb.Duration{}
+}
+
+func (bb *baseBuilder) offset(d string) {
+	bb.b.Offset = influxdb.Duration{Duration: bb.duration("offset", d)}
+}
+
+func (bb *baseBuilder) tag(key, value string) {
+	bb.b.Tags = append(bb.b.Tags, notification.Tag{Key: key, Value: value})
+}
+
+// build returns the first error of the builder, or the error of the validation
+// of c. The checks without an ID yet, given by their creation, are validated
+// with a placeholder ID.
+func (bb *baseBuilder) build(c influxdb.Check) (influxdb.Check, error) {
+	if bb.err != nil {
+		return nil, bb.err
+	}
+	id := bb.b.ID
+	if !id.Valid() {
+		bb.b.ID = influxdb.ID(1)
+	}
+	err := c.Valid()
+	bb.b.ID = id
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
@@ -0,0 +1,141 @@
+package checks_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/checks"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/check"
+)
+
+const query = `from(bucket: "telegraf") |> range(start: -1m)`
+
+func TestThresholdBuilder(t *testing.T) {
+	c, err := checks.NewThreshold("cpu").
+		Org(influxdb.ID(10)).
+		Description("usage of the cpus").
+		Query(query).
+		Every("1m").
+		Offset("10s").
+		Tag("team", "infra").
+		Crit(checks.Recover(85, checks.Greater(90))).
+		Warn(checks.RecoverRange(70, 95, checks.AllValues(checks.Within(75, 90)))).
+		Ok(checks.Lesser(75)).
+		Build()
+	if err != nil {
+		t.Fatalf("unexpected error building check: %v", err)
+	}
+
+	f := func(v float64) *float64 { return &v }
+	want := &check.Threshold{
+		Base: check.Base{
+			Name:        "cpu",
+			Description: "usage of the cpus",
+			OrgID:       influxdb.ID(10),
+			Query:       influxdb.DashboardQuery{Text: query},
+			Status:      influxdb.Active,
+			Every:       influxdb.Duration{Duration: time.Minute},
+			Offset:      influxdb.Duration{Duration: 10 * time.Second},
+			Tags:        []notification.Tag{{Key: "team", Value: "infra"}},
+		},
+		Thresholds: []check.ThresholdConfig{
+			&check.Greater{ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Critical}, Value: 90, Recover: f(85)},
+			&check.Range{ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Warn, AllValues: true}, Min: 75, Max: 90, Within: true, RecoverMin: f(70), RecoverMax: f(95)},
+			&check.Lesser{ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Ok}, Value: 75},
+		},
+	}
+	if diff := cmp.Diff(want, c); diff != "" {
+		t.Errorf("unexpected check -want/+got\n%s", diff)
+	}
+}
+
+func TestDeadmanBuilder(t *testing.T) {
+	c, err := checks.NewDeadman("heartbeat").
+		Org(influxdb.ID(10)).
+		Query(query).
+		Cron("*/5 * * * *").
+		TimeSince("90s").
+		Level(notification.Warn).
+		Inactive().
+		Build()
+	if err != nil {
+		t.Fatalf("unexpected error building check: %v", err)
+	}
+
+	want := &check.Deadman{
+		Base: check.Base{
+			Name:   "heartbeat",
+			OrgID:  influxdb.ID(10),
+			Query:  influxdb.DashboardQuery{Text: query},
+			Status: influxdb.Inactive,
+			Cron:   "*/5 * * * *",
+		},
+		TimeSince: 90,
+		Level:     notification.Warn,
+	}
+	if diff := cmp.Diff(want, c); diff != "" {
+		t.Errorf("unexpected check -want/+got\n%s", diff)
+	}
+}
+
+func TestBuilder_errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		build   func() (influxdb.Check, error)
+		wantErr string
+	}{
+		{
+			name: "invalid duration",
+			build: func() (influxdb.Check, error) {
+				return checks.NewThreshold("cpu").Org(10).Query(query).Every("a minute").Crit(checks.Greater(90)).Build()
+			},
+			wantErr: `check every "a minute" is not a duration, such as 1m`,
+		},
+		{
+			name: "threshold without threshold",
+			build: func() (influxdb.Check, error) {
+				return checks.NewThreshold("cpu").Org(10).Query(query).Every("1m").Build()
+			},
+			wantErr: "threshold check requires at least one threshold",
+		},
+		{
+			name: "invalid range",
+			build: func() (influxdb.Check, error) {
+				return checks.NewThreshold("cpu").Org(10).Query(query).Every("1m").Crit(checks.Outside(90, 10)).Build()
+			},
+			wantErr: "range threshold min can't be larger than max",
+		},
+		{
+			name: "check without org",
+			build: func() (influxdb.Check, error) {
+				return checks.NewDeadman("heartbeat").Query(query).Every("1m").TimeSince("1m").Build()
+			},
+			wantErr: "Check OrgID is invalid",
+		},
+		{
+			name: "deadman without time since",
+			build: func() (influxdb.Check, error) {
+				return checks.NewDeadman("heartbeat").Org(10).Query(query).Every("1m").Build()
+			},
+			wantErr: "deadman check timeSince must be larger than 0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := tt.build()
+			if err == nil {
+				t.Fatalf("expected an error, got check %v", c)
+			}
+			if msg := influxdb.ErrorMessage(err); msg != tt.wantErr {
+				t.Errorf("got error %q, want %q", msg, tt.wantErr)
+			}
+			if influxdb.ErrorCode(err) != influxdb.EInvalid {
+				t.Errorf("got error code %q, want invalid", influxdb.ErrorCode(err))
+			}
+		})
+	}
+}
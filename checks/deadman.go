@@ -0,0 +1,106 @@
+package checks
+
+import (
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/check"
+)
+
+// DeadmanBuilder builds a deadman check.
+type DeadmanBuilder struct {
+	baseBuilder
+	c *check.Deadman
+}
+
+// NewDeadman returns a builder of an active deadman check named name,
+// writing critical statuses.
+func NewDeadman(name string) *DeadmanBuilder {
+	c := &check.Deadman{Level: notification.Critical}
+	return &DeadmanBuilder{
+		baseBuilder: newBaseBuilder(&c.Base, name),
+		c:           c,
+	}
+}
+
+// ID sets the ID of the check, to update it.
+func (b *DeadmanBuilder) ID(id influxdb.ID) *DeadmanBuilder {
+	b.c.ID = id
+	return b
+}
+
+// Org sets the organization of the check.
+func (b *DeadmanBuilder) Org(orgID influxdb.ID) *DeadmanBuilder {
+	b.c.OrgID = orgID
+	return b
+}
+
+// Description sets the description of the check.
+func (b *DeadmanBuilder) Description(desc string) *DeadmanBuilder {
+	b.c.Description = desc
+	return b
+}
+
+// Query sets the flux query of the data of the check.
+func (b *DeadmanBuilder) Query(flux string) *DeadmanBuilder {
+	b.c.Query = influxdb.DashboardQuery{Text: flux}
+	return b
+}
+
+// Every runs the check every interval d, such as 1m.
+func (b *DeadmanBuilder) Every(d string) *DeadmanBuilder {
+	b.every(d)
+	return b
+}
+
+// Cron runs the check on the cron expression c.
+func (b *DeadmanBuilder) Cron(c string) *DeadmanBuilder {
+	b.cron(c)
+	return b
+}
+
+// Offset delays the runs of the check by d.
+func (b *DeadmanBuilder) Offset(d string) *DeadmanBuilder {
+	b.offset(d)
+	return b
+}
+
+// Tag adds a tag written to each status of the check.
+func (b *DeadmanBuilder) Tag(key, value string) *DeadmanBuilder {
+	b.tag(key, value)
+	return b
+}
+
+// StatusMessage sets the template of the messages of the statuses.
+func (b *DeadmanBuilder) StatusMessage(tmpl string) *DeadmanBuilder {
+	b.c.StatusMessageTemplate = tmpl
+	return b
+}
+
+// Inactive builds an inactive check.
+func (b *DeadmanBuilder) Inactive() *DeadmanBuilder {
+	b.c.Status = influxdb.Inactive
+	return b
+}
+
+// TimeSince triggers the check once no data arrived for d, rounded down to the second.
+func (b *DeadmanBuilder) TimeSince(d string) *DeadmanBuilder {
+	b.c.TimeSince = int(b.duration("timeSince", d).Seconds())
+	return b
+}
+
+// ReportZero also triggers the check when only zero values arrived.
+func (b *DeadmanBuilder) ReportZero() *DeadmanBuilder {
+	b.c.ReportZero = true
+	return b
+}
+
+// Level sets the level of the statuses written when the check triggers.
+func (b *DeadmanBuilder) Level(level notification.CheckLevel) *DeadmanBuilder {
+	b.c.Level = level
+	return b
+}
+
+// Build returns the deadman check, or the first error of the builder or of its validation.
+func (b *DeadmanBuilder) Build() (influxdb.Check, error) {
+	return b.build(b.c)
+}
@@ -0,0 +1,178 @@
+package checks
+
+import (
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/check"
+)
+
+// Condition is a threshold crossed by the values of a check, written at a level.
+type Condition func(base check.ThresholdConfigBase) check.ThresholdConfig
+
+// Greater is crossed by the values above v.
+func Greater(v float64) Condition {
+	return func(base check.ThresholdConfigBase) check.ThresholdConfig {
+		return &check.Greater{ThresholdConfigBase: base, Value: v}
+	}
+}
+
+// Lesser is crossed by the values below v.
+func Lesser(v float64) Condition {
+	return func(base check.ThresholdConfigBase) check.ThresholdConfig {
+		return &check.Lesser{ThresholdConfigBase: base, Value: v}
+	}
+}
+
+// Within is crossed by the values between min and max.
+func Within(min, max float64) Condition {
+	return func(base check.ThresholdConfigBase) check.ThresholdConfig {
+		return &check.Range{ThresholdConfigBase: base, Min: min, Max: max, Within: true}
+	}
+}
+
+// Outside is crossed by the values below min or above max.
+func Outside(min, max float64) Condition {
+	return func(base check.ThresholdConfigBase) check.ThresholdConfig {
+		return &check.Range{ThresholdConfigBase: base, Min: min, Max: max}
+	}
+}
+
+// AllValues is crossed once all the values of a run of the check cross c.
+func AllValues(c Condition) Condition {
+	return func(base check.ThresholdConfigBase) check.ThresholdConfig {
+		base.AllValues = true
+		return c(base)
+	}
+}
+
+// Recover holds c, once crossed, until the values fall below v for a greater
+// condition, or rise above v for a lesser one.
+func Recover(v float64, c Condition) Condition {
+	return func(base check.ThresholdConfigBase) check.ThresholdConfig {
+		t := c(base)
+		switch t := t.(type) {
+		case *check.Greater:
+			t.Recover = &v
+		case *check.Lesser:
+			t.Recover = &v
+		}
+		return t
+	}
+}
+
+// RecoverRange holds a within or outside condition c, once crossed, until
+// the values leave, or enter, min and max.
+func RecoverRange(min, max float64, c Condition) Condition {
+	return func(base check.ThresholdConfigBase) check.ThresholdConfig {
+		t := c(base)
+		if r, ok := t.(*check.Range); ok {
+			r.RecoverMin, r.RecoverMax = &min, &max
+		}
+		return t
+	}
+}
+
+// ThresholdBuilder builds a threshold check.
+type ThresholdBuilder struct {
+	baseBuilder
+	c *check.Threshold
+}
+
+// NewThreshold returns a builder of an active threshold check named name.
+func NewThreshold(name string) *ThresholdBuilder {
+	c := &check.Threshold{}
+	return &ThresholdBuilder{
+		baseBuilder: newBaseBuilder(&c.Base, name),
+		c:           c,
+	}
+}
+
+// ID sets the ID of the check, to update it.
+func (b *ThresholdBuilder) ID(id influxdb.ID) *ThresholdBuilder {
+	b.c.ID = id
+	return b
+}
+
+// Org sets the organization of the check.
+func (b *ThresholdBuilder) Org(orgID influxdb.ID) *ThresholdBuilder {
+	b.c.OrgID = orgID
+	return b
+}
+
+// Description sets the description of the check.
+func (b *ThresholdBuilder) Description(desc string) *ThresholdBuilder {
+	b.c.Description = desc
+	return b
+}
+
+// Query sets the flux query of the data of the check.
+func (b *ThresholdBuilder) Query(flux string) *ThresholdBuilder {
+	b.c.Query = influxdb.DashboardQuery{Text: flux}
+	return b
+}
+
+// Every runs the check every interval d, such as 1m.
+func (b *ThresholdBuilder) Every(d string) *ThresholdBuilder {
+	b.every(d)
+	return b
+}
+
+// Cron runs the check on the cron expression c.
+func (b *ThresholdBuilder) Cron(c string) *ThresholdBuilder {
+	b.cron(c)
+	return b
+}
+
+// Offset delays the runs of the check by d.
+func (b *ThresholdBuilder) Offset(d string) *ThresholdBuilder {
+	b.offset(d)
+	return b
+}
+
+// Tag adds a tag written to each status of the check.
+func (b *ThresholdBuilder) Tag(key, value string) *ThresholdBuilder {
+	b.tag(key, value)
+	return b
+}
+
+// StatusMessage sets the template of the messages of the statuses.
+func (b *ThresholdBuilder) StatusMessage(tmpl string) *ThresholdBuilder {
+	b.c.StatusMessageTemplate = tmpl
+	return b
+}
+
+// Inactive builds an inactive check.
+func (b *ThresholdBuilder) Inactive() *ThresholdBuilder {
+	b.c.Status = influxdb.Inactive
+	return b
+}
+
+func (b *ThresholdBuilder) threshold(level notification.CheckLevel, c Condition) *ThresholdBuilder {
+	b.c.Thresholds = append(b.c.Thresholds, c(check.ThresholdConfigBase{Level: level}))
+	return b
+}
+
+// Crit writes a critical status when the values cross c.
+func (b *ThresholdBuilder) Crit(c Condition) *ThresholdBuilder {
+	return b.threshold(notification.Critical, c)
+}
+
+// Warn writes a warning status when the values cross c.
+func (b *ThresholdBuilder) Warn(c Condition) *ThresholdBuilder {
+	return b.threshold(notification.Warn, c)
+}
+
+// Info writes an info status when the values cross c.
+func (b *ThresholdBuilder) Info(c Condition) *ThresholdBuilder {
+	return b.threshold(notification.Info, c)
+}
+
+// Ok writes an ok status when the values cross c.
+func (b *ThresholdBuilder) Ok(c Condition) *ThresholdBuilder {
+	return b.threshold(notification.Ok, c)
+}
+
+// Build returns the threshold check, or the first error of the builder or of its validation.
+func (b *ThresholdBuilder) Build() (influxdb.Check, error) {
+	return b.build(b.c)
+}
@@ -0,0 +1,359 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/alerting"
+	"github.com/influxdata/influxdb/inmem"
+	"github.com/influxdata/influxdb/kv"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/check"
+	"github.com/influxdata/influxdb/notification/endpoint"
+	"github.com/influxdata/influxdb/notification/rule"
+	"go.uber.org/zap"
+)
+
+// every is the simulated interval of the checks, the clock of the engine
+// moves by an interval every run so that every check is due.
+const every = time.Minute
+
+// Config is a load test of the alerting pipeline.
+type Config struct {
+	// Checks are provisioned across Orgs organizations, each with a slack
+	// endpoint and a notification rule sending every change of level to it.
+	Checks int
+	Orgs   int
+	// Rate is the target of statuses evaluated per second.
+	Rate     int
+	Duration time.Duration
+	// FlapPercent is the chance a check changes level at an evaluation,
+	// which is notified.
+	FlapPercent int
+	// EndpointLatency delays the responses of the mock endpoints, and
+	// EndpointErrorPercent is the chance they fail a notification.
+	EndpointLatency      time.Duration
+	EndpointErrorPercent int
+	Seed                 int64
+}
+
+// Valid returns an error if the load test can't be run.
+func (c Config) Valid() error {
+	switch {
+	case c.Checks < 1:
+		return fmt.Errorf("checks must be positive")
+	case c.Orgs < 1 || c.Orgs > c.Checks:
+		return fmt.Errorf("orgs must be between 1 and the number of checks")
+	case c.Rate < 1:
+		return fmt.Errorf("rate must be positive")
+	case c.Duration <= 0:
+		return fmt.Errorf("duration must be positive")
+	case c.FlapPercent < 0 || c.FlapPercent > 100:
+		return fmt.Errorf("flap-percent must be between 0 and 100")
+	case c.EndpointErrorPercent < 0 || c.EndpointErrorPercent > 100:
+		return fmt.Errorf("endpoint-error-percent must be between 0 and 100")
+	}
+	return nil
+}
+
+// Report is the outcome of a load test.
+type Report struct {
+	Elapsed time.Duration `json:"elapsed"`
+	// Runs are the runs of the engine, each evaluating every check.
+	Runs       int     `json:"runs"`
+	FailedRuns int     `json:"failedRuns"`
+	Statuses   int     `json:"statuses"`
+	TargetRate float64 `json:"targetRate"`
+	StatusRate float64 `json:"statusRate"`
+	// Notifications are the statuses the rules decided to notify, they are
+	// either delivered to the mock endpoints or dropped.
+	Notifications    int     `json:"notifications"`
+	Delivered        int     `json:"delivered"`
+	Dropped          int     `json:"dropped"`
+	DropPercent      float64 `json:"dropPercent"`
+	NotificationRate float64 `json:"notificationRate"`
+	// The latencies are from the evaluation of a check to the delivery of
+	// its notification, in nanoseconds in json.
+	LatencyP50 time.Duration `json:"latencyP50"`
+	LatencyP95 time.Duration `json:"latencyP95"`
+	LatencyP99 time.Duration `json:"latencyP99"`
+	LatencyMax time.Duration `json:"latencyMax"`
+}
+
+// WriteTo writes the report as text.
+func (r *Report) WriteTo(w io.Writer) (int64, error) {
+	n, err := fmt.Fprintf(w, `elapsed:        %s
+runs:           %d (%d failed)
+statuses:       %d (%.1f/s, target %.1f/s)
+notifications:  %d (%.1f/s)
+delivered:      %d
+dropped:        %d (%.2f%%)
+latency:        p50 %s, p95 %s, p99 %s, max %s
+`,
+		r.Elapsed.Round(time.Millisecond),
+		r.Runs, r.FailedRuns,
+		r.Statuses, r.StatusRate, r.TargetRate,
+		r.Notifications, r.NotificationRate,
+		r.Delivered,
+		r.Dropped, r.DropPercent,
+		r.LatencyP50, r.LatencyP95, r.LatencyP99, r.LatencyMax)
+	return int64(n), err
+}
+
+// loadTest is the state of a running load test.
+type loadTest struct {
+	cfg Config
+
+	mu   sync.Mutex
+	rand *rand.Rand
+	// critical is whether each check, by name, is currently critical.
+	critical map[string]bool
+	// evaluated is when each check, by name, was last evaluated.
+	evaluated map[string]time.Time
+	latencies []time.Duration
+	statuses  int
+	notified  int
+	failed    int
+	delivered int
+}
+
+// Run provisions the checks of cfg in an in-memory store and runs the
+// alerting engine at the rate of cfg for its duration, with a data source
+// simulating the levels of the checks and mock slack endpoints.
+func Run(ctx context.Context, cfg Config) (*Report, error) {
+	if err := cfg.Valid(); err != nil {
+		return nil, err
+	}
+	lt := &loadTest{
+		cfg:       cfg,
+		rand:      rand.New(rand.NewSource(cfg.Seed)),
+		critical:  make(map[string]bool),
+		evaluated: make(map[string]time.Time),
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(lt.serveEndpoint))
+	defer srv.Close()
+
+	svc := kv.NewService(inmem.NewKVStore())
+	if err := svc.Initialize(ctx); err != nil {
+		return nil, err
+	}
+	if err := lt.provision(ctx, svc, srv.URL); err != nil {
+		return nil, err
+	}
+
+	e := alerting.NewEngine(svc, nil, lt)
+	e.DataSources[check.QueryTypeInfluxQL] = lt
+	e.StatusTraceService = lt
+	e.Logger = zap.NewNop()
+
+	// every check is due every run, the runs are paced to evaluate the
+	// target rate of statuses.
+	period := time.Duration(float64(time.Second) * float64(cfg.Checks) / float64(cfg.Rate))
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+
+	r := &Report{TargetRate: float64(cfg.Rate)}
+	now := time.Now().Truncate(every)
+	start := time.Now()
+	for time.Since(start) < cfg.Duration {
+		e.TimeGenerator = clock(now)
+		if err := e.Run(ctx); err != nil {
+			r.FailedRuns++
+		}
+		r.Runs++
+		now = now.Add(every)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+	r.Elapsed = time.Since(start)
+
+	lt.report(r)
+	return r, nil
+}
+
+// provision creates the checks, endpoints and rules of the load test.
+func (lt *loadTest) provision(ctx context.Context, svc *kv.Service, url string) error {
+	user := &influxdb.User{Name: "loadgen"}
+	if err := svc.CreateUser(ctx, user); err != nil {
+		return err
+	}
+	orgs := make([]*influxdb.Organization, lt.cfg.Orgs)
+	for i := range orgs {
+		orgs[i] = &influxdb.Organization{Name: "loadgen-" + strconv.Itoa(i)}
+		if err := svc.CreateOrganization(ctx, orgs[i]); err != nil {
+			return err
+		}
+		edp := &endpoint.Slack{
+			Base: endpoint.Base{Name: "loadgen", OrgID: orgs[i].ID, Status: influxdb.Active},
+			URL:  url,
+		}
+		if err := svc.CreateNotificationEndpoint(ctx, edp, user.ID); err != nil {
+			return err
+		}
+		nr := &rule.Slack{
+			Base: rule.Base{
+				Name:            "loadgen",
+				OrgID:           orgs[i].ID,
+				EndpointID:      &edp.ID,
+				AuthorizationID: edp.ID,
+				Status:          influxdb.Active,
+			},
+			// the endpoint finds the evaluation of the check by its name.
+			MessageTemplate: "${r._check_name}",
+		}
+		if err := svc.CreateNotificationRule(ctx, nr, user.ID); err != nil {
+			return err
+		}
+	}
+
+	for i := 0; i < lt.cfg.Checks; i++ {
+		name := "loadgen-" + strconv.Itoa(i)
+		c := &check.Threshold{
+			Base: check.Base{
+				Name:   name,
+				OrgID:  orgs[i%len(orgs)].ID,
+				Status: influxdb.Active,
+				Every:  influxdb.Duration{Duration: every},
+				// the data source finds the check by its query, which isn't
+				// flux so the check isn't run by a task but by the engine.
+				QueryType: check.QueryTypeInfluxQL,
+				Query:     influxdb.DashboardQuery{Text: name},
+			},
+			Thresholds: []check.ThresholdConfig{
+				&check.Greater{ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Critical}, Value: 90},
+			},
+		}
+		if err := svc.CreateCheck(ctx, c, user.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// QuerySeries implements alerting.DataSource, a check stays at its level
+// or flaps to the other at random.
+func (lt *loadTest) QuerySeries(ctx context.Context, q alerting.SeriesQuery) ([]*alerting.Series, error) {
+	lt.mu.Lock()
+	defer lt.mu.Unlock()
+	if lt.rand.Intn(100) < lt.cfg.FlapPercent {
+		lt.critical[q.Text] = !lt.critical[q.Text]
+	}
+	lt.evaluated[q.Text] = time.Now()
+	value := 10.0
+	if lt.critical[q.Text] {
+		value = 95
+	}
+	return []*alerting.Series{{
+		Tags:   map[string]string{"host": q.Text},
+		Values: []float64{value},
+		Times:  []time.Time{q.Now},
+	}}, nil
+}
+
+// Write implements influxdb.WriteService, the statuses are discarded.
+func (lt *loadTest) Write(ctx context.Context, orgID, bucketID influxdb.ID, r io.Reader) error {
+	_, err := io.Copy(ioutil.Discard, r)
+	return err
+}
+
+// FindStatusTrace implements influxdb.StatusTraceService, the traces aren't kept.
+func (lt *loadTest) FindStatusTrace(ctx context.Context, statusID influxdb.ID) (*influxdb.StatusTrace, error) {
+	return nil, &influxdb.Error{
+		Code: influxdb.ENotFound,
+		Msg:  "status trace not found",
+	}
+}
+
+// CreateStatusTrace implements influxdb.StatusTraceService, it counts the
+// statuses and the decisions to notify them.
+func (lt *loadTest) CreateStatusTrace(ctx context.Context, t *influxdb.StatusTrace) error {
+	lt.mu.Lock()
+	defer lt.mu.Unlock()
+	lt.statuses++
+	for _, rt := range t.Rules {
+		switch rt.Decision {
+		case influxdb.RuleNotified:
+			lt.notified++
+		case influxdb.RuleFailed:
+			lt.failed++
+		}
+	}
+	return nil
+}
+
+// serveEndpoint is the mock slack endpoint, it records the latency of the
+// notifications it accepts.
+func (lt *loadTest) serveEndpoint(w http.ResponseWriter, r *http.Request) {
+	received := time.Now()
+	var msg struct {
+		Text string `json:"text"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	time.Sleep(lt.cfg.EndpointLatency)
+
+	lt.mu.Lock()
+	defer lt.mu.Unlock()
+	if lt.rand.Intn(100) < lt.cfg.EndpointErrorPercent {
+		http.Error(w, "mock endpoint failure", http.StatusInternalServerError)
+		return
+	}
+	lt.delivered++
+	if at, ok := lt.evaluated[msg.Text]; ok {
+		lt.latencies = append(lt.latencies, received.Sub(at))
+	}
+}
+
+// report fills r with the counts and latencies of the load test.
+func (lt *loadTest) report(r *Report) {
+	lt.mu.Lock()
+	defer lt.mu.Unlock()
+	secs := r.Elapsed.Seconds()
+	r.Statuses = lt.statuses
+	r.StatusRate = float64(lt.statuses) / secs
+	r.Notifications = lt.notified + lt.failed
+	r.NotificationRate = float64(r.Notifications) / secs
+	r.Delivered = lt.delivered
+	r.Dropped = r.Notifications - lt.delivered
+	if r.Notifications > 0 {
+		r.DropPercent = 100 * float64(r.Dropped) / float64(r.Notifications)
+	}
+
+	ls := lt.latencies
+	if len(ls) == 0 {
+		return
+	}
+	sort.Slice(ls, func(i, j int) bool { return ls[i] < ls[j] })
+	percentile := func(p int) time.Duration {
+		return ls[(len(ls)-1)*p/100]
+	}
+	r.LatencyP50 = percentile(50)
+	r.LatencyP95 = percentile(95)
+	r.LatencyP99 = percentile(99)
+	r.LatencyMax = ls[len(ls)-1]
+}
+
+// clock is the simulated time of the engine.
+type clock time.Time
+
+// Now implements influxdb.TimeGenerator.
+func (c clock) Now() time.Time {
+	return time.Time(c)
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name          string
+		errorPercent  int
+		wantDelivered bool
+	}{
+		{name: "every notification is delivered", wantDelivered: true},
+		{name: "failed notifications are dropped", errorPercent: 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := Run(context.Background(), Config{
+				Checks:               20,
+				Orgs:                 2,
+				Rate:                 400,
+				Duration:             500 * time.Millisecond,
+				FlapPercent:          100,
+				EndpointErrorPercent: tt.errorPercent,
+				Seed:                 1,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if r.Runs < 2 || r.FailedRuns != 0 {
+				t.Fatalf("expected several successful runs, got %d runs and %d failed", r.Runs, r.FailedRuns)
+			}
+			if r.Statuses != r.Runs*20 {
+				t.Errorf("expected every check to be evaluated every run, got %d statuses in %d runs", r.Statuses, r.Runs)
+			}
+			// every check flaps at every evaluation, critical first.
+			if want := r.Runs * 20; r.Notifications != want {
+				t.Errorf("expected %d notifications, got %d", want, r.Notifications)
+			}
+			if tt.wantDelivered {
+				if r.Delivered != r.Notifications || r.Dropped != 0 {
+					t.Errorf("expected every notification to be delivered, got %d delivered and %d dropped", r.Delivered, r.Dropped)
+				}
+				if r.LatencyMax <= 0 || r.LatencyP50 > r.LatencyP99 {
+					t.Errorf("unexpected latencies %+v", r)
+				}
+			} else if r.Delivered != 0 || r.Dropped != r.Notifications || r.DropPercent != 100 {
+				t.Errorf("expected every notification to be dropped, got %d delivered and %d dropped", r.Delivered, r.Dropped)
+			}
+		})
+	}
+}
+
+func TestConfig_Valid(t *testing.T) {
+	valid := Config{Checks: 10, Orgs: 2, Rate: 10, Duration: time.Second}
+	if err := valid.Valid(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	invalid := valid
+	invalid.Orgs = 11
+	if err := invalid.Valid(); err == nil {
+		t.Error("expected more organizations than checks to be invalid")
+	}
+}
@@ -0,0 +1,106 @@
+// Command alert-loadgen load tests the alerting pipeline: it provisions
+// checks across organizations, simulates their statuses at a target rate,
+// dispatches their notifications to mock endpoints and reports the
+// throughput, latency and drops of the pipeline. It fails when the pipeline
+// regresses past the thresholds of its flags.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/influxdata/influxdb/kit/cli"
+	influxlogger "github.com/influxdata/influxdb/logger"
+	"go.uber.org/zap"
+)
+
+var (
+	logger = influxlogger.New(os.Stderr)
+	cfg    Config
+	seed   int
+
+	jsonOutput     bool
+	maxP99Latency  time.Duration
+	maxDropPercent int
+	minRatePercent int
+)
+
+func main() {
+	prog := &cli.Program{
+		Run:  run,
+		Name: "alert-loadgen",
+		Opts: []cli.Opt{
+			{DestP: &cfg.Checks, Flag: "checks", Default: 1000, Desc: "number of checks to provision"},
+			{DestP: &cfg.Orgs, Flag: "orgs", Default: 10, Desc: "number of organizations the checks are spread across"},
+			{DestP: &cfg.Rate, Flag: "rate", Default: 500, Desc: "target number of statuses evaluated per second"},
+			{DestP: &cfg.Duration, Flag: "duration", Default: 30 * time.Second, Desc: "duration of the load test"},
+			{DestP: &cfg.FlapPercent, Flag: "flap-percent", Default: 20, Desc: "chance in percent a check changes level at an evaluation, which is notified"},
+			{DestP: &cfg.EndpointLatency, Flag: "endpoint-latency", Default: time.Duration(0), Desc: "response delay of the mock endpoints"},
+			{DestP: &cfg.EndpointErrorPercent, Flag: "endpoint-error-percent", Default: 0, Desc: "chance in percent the mock endpoints fail a notification"},
+			{DestP: &seed, Flag: "seed", Default: 1, Desc: "seed of the simulated levels and endpoint failures"},
+			{DestP: &jsonOutput, Flag: "json", Default: false, Desc: "write the report as json"},
+			{DestP: &maxP99Latency, Flag: "max-p99-latency", Default: time.Duration(0), Desc: "fail when the p99 notification latency exceeds it, 0 disables the threshold"},
+			{DestP: &maxDropPercent, Flag: "max-drop-percent", Default: 100, Desc: "fail when more notifications are dropped, in percent"},
+			{DestP: &minRatePercent, Flag: "min-rate-percent", Default: 0, Desc: "fail when fewer statuses are evaluated per second, in percent of the target rate"},
+		},
+	}
+	cmd := cli.NewCommand(prog)
+	// a regression isn't a misuse of the flags.
+	cmd.SilenceUsage = true
+
+	var exitCode int
+	if err := cmd.Execute(); err != nil {
+		exitCode = 1
+		logger.Error("Command returned error", zap.Error(err))
+	}
+
+	if err := logger.Sync(); err != nil {
+		exitCode = 1
+		fmt.Fprintf(os.Stderr, "Error syncing logs: %v\n", err)
+	}
+	time.Sleep(10 * time.Millisecond)
+	os.Exit(exitCode)
+}
+
+func run() error {
+	cfg.Seed = int64(seed)
+	logger.Info("starting load test",
+		zap.Int("checks", cfg.Checks),
+		zap.Int("orgs", cfg.Orgs),
+		zap.Int("rate", cfg.Rate),
+		zap.Duration("duration", cfg.Duration))
+
+	r, err := Run(context.Background(), cfg)
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(r); err != nil {
+			return err
+		}
+	} else if _, err := r.WriteTo(os.Stdout); err != nil {
+		return err
+	}
+	return checkThresholds(r)
+}
+
+// checkThresholds returns an error if the report regresses past the
+// thresholds of the flags.
+func checkThresholds(r *Report) error {
+	if maxP99Latency > 0 && r.LatencyP99 > maxP99Latency {
+		return fmt.Errorf("p99 latency %s exceeds %s", r.LatencyP99, maxP99Latency)
+	}
+	if r.DropPercent > float64(maxDropPercent) {
+		return fmt.Errorf("%.2f%% of the notifications were dropped, more than %d%%", r.DropPercent, maxDropPercent)
+	}
+	if min := r.TargetRate * float64(minRatePercent) / 100; r.StatusRate < min {
+		return fmt.Errorf("%.1f statuses were evaluated per second, fewer than %.1f", r.StatusRate, min)
+	}
+	return nil
+}
@@ -45,8 +45,23 @@ type AuthorizationCreateFlags struct {
 	writeDashboardsPermission bool
 	readDashboardsPermission  bool
 
-	writeNotificationRulePermission bool
-	readNotificationRulePermission  bool
+	writeNotificationRulePermission  bool
+	readNotificationRulePermission   bool
+	createNotificationRulePermission bool
+	updateNotificationRulePermission bool
+	deleteNotificationRulePermission bool
+
+	writeNotificationEndpointPermission  bool
+	readNotificationEndpointPermission   bool
+	createNotificationEndpointPermission bool
+	updateNotificationEndpointPermission bool
+	deleteNotificationEndpointPermission bool
+
+	writeChecksPermission  bool
+	readChecksPermission   bool
+	createChecksPermission bool
+	updateChecksPermission bool
+	deleteChecksPermission bool
 }
 
 var authorizationCreateFlags AuthorizationCreateFlags
@@ -86,6 +101,21 @@ func init() {
 
 	authorizationCreateCmd.Flags().BoolVarP(&authorizationCreateFlags.writeNotificationRulePermission, "write-notificationRules", "", false, "Grants the permission to create notificationRules")
 	authorizationCreateCmd.Flags().BoolVarP(&authorizationCreateFlags.readNotificationRulePermission, "read-notificationRules", "", false, "Grants the permission to read notificationRules")
+	authorizationCreateCmd.Flags().BoolVarP(&authorizationCreateFlags.createNotificationRulePermission, "create-notificationRules", "", false, "Grants the permission to create notificationRules")
+	authorizationCreateCmd.Flags().BoolVarP(&authorizationCreateFlags.updateNotificationRulePermission, "update-notificationRules", "", false, "Grants the permission to update notificationRules")
+	authorizationCreateCmd.Flags().BoolVarP(&authorizationCreateFlags.deleteNotificationRulePermission, "delete-notificationRules", "", false, "Grants the permission to delete notificationRules")
+
+	authorizationCreateCmd.Flags().BoolVarP(&authorizationCreateFlags.writeNotificationEndpointPermission, "write-notificationEndpoints", "", false, "Grants the permission to create, update and delete notificationEndpoints")
+	authorizationCreateCmd.Flags().BoolVarP(&authorizationCreateFlags.readNotificationEndpointPermission, "read-notificationEndpoints", "", false, "Grants the permission to read notificationEndpoints")
+	authorizationCreateCmd.Flags().BoolVarP(&authorizationCreateFlags.createNotificationEndpointPermission, "create-notificationEndpoints", "", false, "Grants the permission to create notificationEndpoints")
+	authorizationCreateCmd.Flags().BoolVarP(&authorizationCreateFlags.updateNotificationEndpointPermission, "update-notificationEndpoints", "", false, "Grants the permission to update notificationEndpoints")
+	authorizationCreateCmd.Flags().BoolVarP(&authorizationCreateFlags.deleteNotificationEndpointPermission, "delete-notificationEndpoints", "", false, "Grants the permission to delete notificationEndpoints")
+
+	authorizationCreateCmd.Flags().BoolVarP(&authorizationCreateFlags.writeChecksPermission, "write-checks", "", false, "Grants the permission to create, update and delete checks")
+	authorizationCreateCmd.Flags().BoolVarP(&authorizationCreateFlags.readChecksPermission, "read-checks", "", false, "Grants the permission to read checks")
+	authorizationCreateCmd.Flags().BoolVarP(&authorizationCreateFlags.createChecksPermission, "create-checks", "", false, "Grants the permission to create checks")
+	authorizationCreateCmd.Flags().BoolVarP(&authorizationCreateFlags.updateChecksPermission, "update-checks", "", false, "Grants the permission to update checks")
+	authorizationCreateCmd.Flags().BoolVarP(&authorizationCreateFlags.deleteChecksPermission, "delete-checks", "", false, "Grants the permission to delete checks")
 
 	authorizationCmd.AddCommand(authorizationCreateCmd)
 }
@@ -227,16 +257,32 @@ func authorizationCreateF(cmd *cobra.Command, args []string) error {
 		permissions = append(permissions, *p)
 	}
 
-	if authorizationCreateFlags.writeNotificationRulePermission {
-		p, err := platform.NewPermission(platform.WriteAction, platform.NotificationRuleResourceType, o.ID)
-		if err != nil {
-			return err
+	alertingPermissions := []struct {
+		granted bool
+		action  platform.Action
+		rt      platform.ResourceType
+	}{
+		{authorizationCreateFlags.writeNotificationRulePermission, platform.WriteAction, platform.NotificationRuleResourceType},
+		{authorizationCreateFlags.readNotificationRulePermission, platform.ReadAction, platform.NotificationRuleResourceType},
+		{authorizationCreateFlags.createNotificationRulePermission, platform.CreateAction, platform.NotificationRuleResourceType},
+		{authorizationCreateFlags.updateNotificationRulePermission, platform.UpdateAction, platform.NotificationRuleResourceType},
+		{authorizationCreateFlags.deleteNotificationRulePermission, platform.DeleteAction, platform.NotificationRuleResourceType},
+		{authorizationCreateFlags.writeNotificationEndpointPermission, platform.WriteAction, platform.NotificationEndpointResourceType},
+		{authorizationCreateFlags.readNotificationEndpointPermission, platform.ReadAction, platform.NotificationEndpointResourceType},
+		{authorizationCreateFlags.createNotificationEndpointPermission, platform.CreateAction, platform.NotificationEndpointResourceType},
+		{authorizationCreateFlags.updateNotificationEndpointPermission, platform.UpdateAction, platform.NotificationEndpointResourceType},
+		{authorizationCreateFlags.deleteNotificationEndpointPermission, platform.DeleteAction, platform.NotificationEndpointResourceType},
+		{authorizationCreateFlags.writeChecksPermission, platform.WriteAction, platform.ChecksResourceType},
+		{authorizationCreateFlags.readChecksPermission, platform.ReadAction, platform.ChecksResourceType},
+		{authorizationCreateFlags.createChecksPermission, platform.CreateAction, platform.ChecksResourceType},
+		{authorizationCreateFlags.updateChecksPermission, platform.UpdateAction, platform.ChecksResourceType},
+		{authorizationCreateFlags.deleteChecksPermission, platform.DeleteAction, platform.ChecksResourceType},
+	}
+	for _, ap := range alertingPermissions {
+		if !ap.granted {
+			continue
 		}
-		permissions = append(permissions, *p)
-	}
-
-	if authorizationCreateFlags.readNotificationRulePermission {
-		p, err := platform.NewPermission(platform.ReadAction, platform.NotificationRuleResourceType, o.ID)
+		p, err := platform.NewPermission(ap.action, ap.rt, o.ID)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	platform "github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/alerting/validate"
+	"github.com/influxdata/influxdb/cmd/influx/internal"
+	"github.com/influxdata/influxdb/http"
+	"github.com/spf13/cobra"
+)
+
+// Check Command
+var checkCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Check management commands",
+	Run:   checkF,
+}
+
+func checkF(cmd *cobra.Command, args []string) {
+	cmd.Usage()
+}
+
+// CheckCreateFlags define the Create Command
+type CheckCreateFlags struct {
+	file   string
+	dryRun bool
+}
+
+var checkCreateFlags CheckCreateFlags
+
+func init() {
+	checkCreateCmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create check",
+		RunE:  wrapCheckSetup(checkCreateF),
+	}
+
+	checkCreateCmd.Flags().StringVarP(&checkCreateFlags.file, "file", "f", "", "Path to the json of the check")
+	checkCreateCmd.Flags().BoolVarP(&checkCreateFlags.dryRun, "dry-run", "", false, "Validate the check as the server does without creating it")
+	checkCreateCmd.MarkFlagRequired("file")
+
+	checkCmd.AddCommand(checkCreateCmd)
+}
+
+// readResourceFile reads the json of a check, notification endpoint or
+// notification rule.
+func readResourceFile(path string) ([]byte, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", path, err)
+	}
+	return b, nil
+}
+
+// writeResources writes the identity of checks, notification endpoints or
+// notification rules.
+func writeResources(rs ...platform.Getter) {
+	w := internal.NewTabWriter(os.Stdout)
+	w.WriteHeaders(
+		"ID",
+		"Name",
+		"OrgID",
+		"Status",
+	)
+	for _, r := range rs {
+		w.Write(map[string]interface{}{
+			"ID":     r.GetID().String(),
+			"Name":   r.GetName(),
+			"OrgID":  r.GetOrgID().String(),
+			"Status": r.GetStatus(),
+		})
+	}
+	w.Flush()
+}
+
+func checkCreateF(cmd *cobra.Command, args []string) error {
+	if flags.local {
+		return fmt.Errorf("local flag not supported for check command")
+	}
+
+	b, err := readResourceFile(checkCreateFlags.file)
+	if err != nil {
+		return err
+	}
+	// the check is decoded as the server does, which accepts its older shapes.
+	c, deprecations, err := http.UnmarshalCheckJSON(b)
+	if err != nil {
+		return fmt.Errorf("failed to decode check: %v", err)
+	}
+	for _, d := range deprecations {
+		fmt.Fprintf(os.Stderr, "warning: %s\n", d)
+	}
+	if err := validate.Check(c, validate.Create); err != nil {
+		return err
+	}
+	if checkCreateFlags.dryRun {
+		fmt.Printf("check %q is valid\n", c.GetName())
+		return nil
+	}
+
+	s := &http.CheckService{
+		Addr:  flags.host,
+		Token: flags.token,
+	}
+	if err := s.CreateCheck(context.Background(), c, 0); err != nil {
+		return err
+	}
+
+	writeResources(c)
+	return nil
+}
+
+// CheckListFlags define the List Command
+type CheckListFlags struct {
+	orgID  string
+	org    string
+	format string
+}
+
+var checkListFlags CheckListFlags
+
+func init() {
+	checkListCmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"find"},
+		Short:   "List checks",
+		RunE:    wrapCheckSetup(checkListF),
+	}
+
+	checkListCmd.Flags().StringVarP(&checkListFlags.orgID, "org-id", "", "", "The check organization ID")
+	checkListCmd.Flags().StringVarP(&checkListFlags.org, "org", "o", "", "The check organization name")
+	checkListCmd.Flags().StringVarP(&checkListFlags.format, "format", "", "table", "Output format: table, csv or json")
+
+	checkCmd.AddCommand(checkListCmd)
+}
+
+func checkListF(cmd *cobra.Command, args []string) error {
+	if flags.local {
+		return fmt.Errorf("local flag not supported for check command")
+	}
+
+	filter := platform.CheckFilter{}
+	if checkListFlags.orgID != "" && checkListFlags.org != "" {
+		return fmt.Errorf("must specify at most one of org and org-id")
+	}
+	if checkListFlags.orgID != "" {
+		orgID, err := platform.IDFromString(checkListFlags.orgID)
+		if err != nil {
+			return fmt.Errorf("failed to decode org id %q: %v", checkListFlags.orgID, err)
+		}
+		filter.OrgID = orgID
+	}
+	if checkListFlags.org != "" {
+		filter.Org = &checkListFlags.org
+	}
+
+	s := &http.CheckService{
+		Addr:  flags.host,
+		Token: flags.token,
+	}
+	cs, _, err := s.FindChecks(context.Background(), filter)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve checks: %v", err)
+	}
+
+	switch checkListFlags.format {
+	case "table":
+		writeChecksTable(cs)
+		return nil
+	case "csv":
+		// the columns are the ones of the csv listings of the server.
+		return http.WriteChecksCSV(os.Stdout, cs)
+	case "json":
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(cs)
+	}
+	return fmt.Errorf("invalid format %q, valid formats are table, csv and json", checkListFlags.format)
+}
+
+// writeChecksTable writes the csv columns of checks but their description
+// and times as a table.
+func writeChecksTable(cs []platform.Check) {
+	headers := []string{"ID", "Name", "OrgID", "Type", "Status", "Every", "Cron", "Offset"}
+	w := internal.NewTabWriter(os.Stdout)
+	w.WriteHeaders(headers...)
+	for _, c := range cs {
+		row := http.CheckCSVRow(c)
+		m := make(map[string]interface{}, len(headers))
+		for i, h := range headers {
+			m[h] = row[i]
+		}
+		w.Write(m)
+	}
+	w.Flush()
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/influxdata/influxdb/alerting/validate"
+	"github.com/influxdata/influxdb/http"
+	"github.com/influxdata/influxdb/notification/rule"
+	"github.com/spf13/cobra"
+)
+
+// Notification Rule Command
+var notificationRuleCmd = &cobra.Command{
+	Use:   "notification-rule",
+	Short: "Notification rule management commands",
+	Run:   notificationRuleF,
+}
+
+func notificationRuleF(cmd *cobra.Command, args []string) {
+	cmd.Usage()
+}
+
+// NotificationRuleCreateFlags define the Create Command
+type NotificationRuleCreateFlags struct {
+	file   string
+	dryRun bool
+}
+
+var notificationRuleCreateFlags NotificationRuleCreateFlags
+
+func init() {
+	notificationRuleCreateCmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create notification rule",
+		RunE:  wrapCheckSetup(notificationRuleCreateF),
+	}
+
+	notificationRuleCreateCmd.Flags().StringVarP(&notificationRuleCreateFlags.file, "file", "f", "", "Path to the json of the notification rule")
+	notificationRuleCreateCmd.Flags().BoolVarP(&notificationRuleCreateFlags.dryRun, "dry-run", "", false, "Validate the notification rule as the server does without creating it")
+	notificationRuleCreateCmd.MarkFlagRequired("file")
+
+	notificationRuleCmd.AddCommand(notificationRuleCreateCmd)
+}
+
+func notificationRuleCreateF(cmd *cobra.Command, args []string) error {
+	if flags.local {
+		return fmt.Errorf("local flag not supported for notification-rule command")
+	}
+
+	b, err := readResourceFile(notificationRuleCreateFlags.file)
+	if err != nil {
+		return err
+	}
+	nr, err := rule.UnmarshalJSON(b)
+	if err != nil {
+		return fmt.Errorf("failed to decode notification rule: %v", err)
+	}
+	if err := validate.NotificationRule(nr, validate.Create); err != nil {
+		return err
+	}
+	if notificationRuleCreateFlags.dryRun {
+		fmt.Printf("notification rule %q is valid\n", nr.GetName())
+		return nil
+	}
+
+	s := &http.NotificationRuleService{
+		Addr:  flags.host,
+		Token: flags.token,
+	}
+	if err := s.CreateNotificationRule(context.Background(), nr, 0); err != nil {
+		return err
+	}
+
+	writeResources(nr)
+	return nil
+}
@@ -0,0 +1,157 @@
+package inspect
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	bolt "github.com/coreos/bbolt"
+	"github.com/influxdata/influxdb"
+	ibolt "github.com/influxdata/influxdb/bolt"
+	"github.com/influxdata/influxdb/http"
+	"github.com/influxdata/influxdb/internal/fs"
+	"github.com/influxdata/influxdb/kv"
+	"github.com/spf13/cobra"
+)
+
+// alertingDoctorFlags defines the `alerting-doctor` Command.
+var alertingDoctorFlags = struct {
+	boltPath   string
+	host       string
+	token      string
+	skipVerify bool
+	orgID      string
+	json       bool
+}{}
+
+func NewAlertingDoctorCommand() *cobra.Command {
+	alertingDoctorCommand := &cobra.Command{
+		Use:   "alerting-doctor",
+		Short: "Diagnose the checks, notification rules and notification endpoints",
+		Long: `
+This command checks the whole alerting stack of the organizations of an
+instance, and prints the problems it finds, the most urgent first, with their
+fix. It reports:
+
+	* The checks whose task is missing or inactive;
+	* The notification rules without an existing notification endpoint;
+	* The notification endpoints whose secrets aren't stored;
+	* The rules sending to inactive endpoints, and the endpoints whose user
+	  suppresses notifications nearly all day long;
+	* The checks whose task last completed more than two intervals ago; and
+	* The checks and endpoints which never lead to a notification.
+
+By default the command reads the bolt file of an instance which is stopped.
+The bolt file of a running instance is locked, use the --host and --token
+flags to diagnose it through its API instead. The scheduler lag of a stopped
+instance is the time since it stopped.`,
+		RunE: inspectAlertingDoctor,
+	}
+
+	dir, err := fs.InfluxDir()
+	if err != nil {
+		panic(err)
+	}
+	path := filepath.Join(dir, "influxd.bolt")
+	alertingDoctorCommand.Flags().StringVarP(&alertingDoctorFlags.boltPath, "bolt-path", "", path, fmt.Sprintf("use provided bolt file of a stopped instance (defaults to %s).", path))
+	alertingDoctorCommand.Flags().StringVarP(&alertingDoctorFlags.host, "host", "", "", "diagnose the running instance at the HTTP address instead of a bolt file.")
+	alertingDoctorCommand.Flags().StringVarP(&alertingDoctorFlags.token, "token", "", "", "token of the requests to the running instance.")
+	alertingDoctorCommand.Flags().BoolVarP(&alertingDoctorFlags.skipVerify, "skip-verify", "", false, "skip the verification of the TLS certificate of the running instance.")
+	alertingDoctorCommand.Flags().StringVarP(&alertingDoctorFlags.orgID, "org-id", "", "", "diagnose only the organization ID.")
+	alertingDoctorCommand.Flags().BoolVarP(&alertingDoctorFlags.json, "json", "", false, "print the diagnoses as JSON.")
+
+	return alertingDoctorCommand
+}
+
+// inspectAlertingDoctor runs the alerting-doctor tool.
+func inspectAlertingDoctor(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	var (
+		diagnostics influxdb.AlertingDiagnosticsService
+		orgs        influxdb.OrganizationService
+	)
+	if alertingDoctorFlags.host != "" {
+		diagnostics = &http.AlertingDiagnosticsService{
+			Addr:               alertingDoctorFlags.host,
+			Token:              alertingDoctorFlags.token,
+			InsecureSkipVerify: alertingDoctorFlags.skipVerify,
+		}
+		orgs = &http.OrganizationService{
+			Addr:               alertingDoctorFlags.host,
+			Token:              alertingDoctorFlags.token,
+			InsecureSkipVerify: alertingDoctorFlags.skipVerify,
+		}
+	} else {
+		db, err := bolt.Open(alertingDoctorFlags.boltPath, 0600, &bolt.Options{ReadOnly: true, Timeout: time.Second})
+		if err != nil {
+			return fmt.Errorf("unable to open %s, if influxd is running use --host and --token: %v", alertingDoctorFlags.boltPath, err)
+		}
+		defer db.Close()
+
+		store := ibolt.NewKVStore(alertingDoctorFlags.boltPath)
+		store.WithDB(db)
+		svc := kv.NewService(store)
+		diagnostics, orgs = svc, svc
+	}
+
+	var orgIDs []influxdb.ID
+	if alertingDoctorFlags.orgID != "" {
+		orgID, err := influxdb.IDFromString(alertingDoctorFlags.orgID)
+		if err != nil {
+			return err
+		}
+		orgIDs = append(orgIDs, *orgID)
+	} else {
+		found, _, err := orgs.FindOrganizations(ctx, influxdb.OrganizationFilter{})
+		if err != nil {
+			return err
+		}
+		for _, o := range found {
+			orgIDs = append(orgIDs, o.ID)
+		}
+	}
+
+	var diagnoses []*influxdb.AlertingDiagnosis
+	for _, orgID := range orgIDs {
+		d, err := diagnostics.DiagnoseAlerting(ctx, orgID)
+		if err != nil {
+			return fmt.Errorf("unable to diagnose the organization %s: %v", orgID, err)
+		}
+		diagnoses = append(diagnoses, d)
+	}
+
+	if alertingDoctorFlags.json {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(diagnoses)
+	}
+	printAlertingDiagnoses(os.Stdout, diagnoses)
+	return nil
+}
+
+var alertingFindingPriorities = map[int]string{
+	influxdb.AlertingFindingCritical: "critical",
+	influxdb.AlertingFindingWarning:  "warning",
+	influxdb.AlertingFindingInfo:     "info",
+}
+
+// printAlertingDiagnoses prints the fix list of each organization.
+func printAlertingDiagnoses(w io.Writer, diagnoses []*influxdb.AlertingDiagnosis) {
+	for _, d := range diagnoses {
+		if len(d.Findings) == 0 {
+			fmt.Fprintf(w, "Organization %s: no problem found\n\n", d.OrgID)
+			continue
+		}
+		fmt.Fprintf(w, "Organization %s: %d problems found\n", d.OrgID, len(d.Findings))
+		for i, f := range d.Findings {
+			fmt.Fprintf(w, "%3d. [%s] %s %s %q (%s): %s\n", i+1, alertingFindingPriorities[f.Priority], f.Kind, f.ResourceType, f.Name, f.ID, f.Problem)
+			fmt.Fprintf(w, "     fix: %s\n", f.Fix)
+		}
+		fmt.Fprintln(w)
+	}
+}
@@ -0,0 +1,198 @@
+package launcher_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	nethttp "net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb/cmd/influxd/launcher"
+	"github.com/influxdata/influxdb/http"
+)
+
+// doOrFail sends a request and returns the status code and body of its response.
+func doOrFail(t *testing.T, r *nethttp.Request) (int, []byte) {
+	t.Helper()
+	resp, err := nethttp.DefaultClient.Do(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, body
+}
+
+// The browser UI authenticates with the cookie of a session, the automations
+// with a token, both must have the same access to the alerting routes.
+func TestLauncher_AlertingSessionAndTokenParity(t *testing.T) {
+	l := launcher.RunTestLauncherOrFail(t, ctx)
+	l.SetupOrFail(t)
+	defer l.ShutdownOrFail(t, ctx)
+
+	session := l.SignInOrFail(t)
+	requests := map[string]func(method, rawurl, body string) *nethttp.Request{
+		"session": func(method, rawurl, body string) *nethttp.Request {
+			return l.NewSessionHTTPRequestOrFail(t, method, rawurl, session, body)
+		},
+		"token": func(method, rawurl, body string) *nethttp.Request {
+			return l.NewHTTPRequestOrFail(t, method, rawurl, l.Auth.Token, body)
+		},
+	}
+
+	for _, scheme := range []string{"session", "token"} {
+		t.Run(scheme, func(t *testing.T) {
+			newRequest := requests[scheme]
+
+			body := fmt.Sprintf(`{"type": "deadman", "name": "heartbeat by %s", "orgID": "%s", "status": "active", "every": "1m", "timeSince": 60, "level": "CRIT", "query": {"text": "from(bucket: \"BUCKET\") |> range(start: -1m)"}}`, scheme, l.Org.ID)
+			code, resp := doOrFail(t, newRequest("POST", "/api/v2/checks", body))
+			if code != nethttp.StatusCreated {
+				t.Fatalf("unexpected status code creating a check: %d, body: %s", code, resp)
+			}
+			var c struct {
+				ID string `json:"id"`
+			}
+			if err := json.Unmarshal(resp, &c); err != nil {
+				t.Fatalf("unexpected error unmarshaling check: %v", err)
+			}
+
+			for _, rawurl := range []string{
+				"/api/v2/checks?orgID=" + l.Org.ID.String(),
+				"/api/v2/checks/" + c.ID,
+				"/api/v2/notificationEndpoints?orgID=" + l.Org.ID.String(),
+				"/api/v2/notificationRules?orgID=" + l.Org.ID.String(),
+				"/api/v2/usage/alerting?orgID=" + l.Org.ID.String(),
+			} {
+				if code, resp := doOrFail(t, newRequest("GET", rawurl, "")); code != nethttp.StatusOK {
+					t.Errorf("unexpected status code of GET %s: %d, body: %s", rawurl, code, resp)
+				}
+			}
+
+			code, resp = doOrFail(t, newRequest("PATCH", "/api/v2/checks/"+c.ID, `{"status": "inactive"}`))
+			if code != nethttp.StatusOK {
+				t.Errorf("unexpected status code updating a check: %d, body: %s", code, resp)
+			}
+			code, resp = doOrFail(t, newRequest("DELETE", "/api/v2/checks/"+c.ID, ""))
+			if code != nethttp.StatusNoContent {
+				t.Errorf("unexpected status code deleting a check: %d, body: %s", code, resp)
+			}
+		})
+	}
+}
+
+func TestLauncher_AlertingSignout(t *testing.T) {
+	l := launcher.RunTestLauncherOrFail(t, ctx)
+	l.SetupOrFail(t)
+	defer l.ShutdownOrFail(t, ctx)
+
+	body := fmt.Sprintf(`{"type": "deadman", "name": "heartbeat", "orgID": "%s", "status": "active", "every": "1m", "timeSince": 60, "level": "CRIT", "query": {"text": "from(bucket: \"BUCKET\") |> range(start: -1m)"}}`, l.Org.ID)
+	code, resp := doOrFail(t, l.NewHTTPRequestOrFail(t, "POST", "/api/v2/checks", l.Auth.Token, body))
+	if code != nethttp.StatusCreated {
+		t.Fatalf("unexpected status code creating a check: %d, body: %s", code, resp)
+	}
+	var c struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(resp, &c); err != nil {
+		t.Fatalf("unexpected error unmarshaling check: %v", err)
+	}
+
+	session := l.SignInOrFail(t)
+	if code, resp := doOrFail(t, l.NewSessionHTTPRequestOrFail(t, "POST", "/api/v2/signout", session, "")); code != nethttp.StatusNoContent {
+		t.Fatalf("unexpected status code signing out: %d, body: %s", code, resp)
+	}
+
+	mutations := []struct {
+		method string
+		rawurl string
+		body   string
+	}{
+		{method: "POST", rawurl: "/api/v2/checks", body: body},
+		{method: "PUT", rawurl: "/api/v2/checks/" + c.ID, body: body},
+		{method: "PATCH", rawurl: "/api/v2/checks/" + c.ID, body: `{"status": "inactive"}`},
+		{method: "POST", rawurl: "/api/v2/checks/bulk-update", body: fmt.Sprintf(`{"filter": {"orgID": "%s"}, "update": {"status": "inactive"}}`, l.Org.ID)},
+		{method: "DELETE", rawurl: "/api/v2/checks/" + c.ID},
+	}
+	for _, m := range mutations {
+		code, resp := doOrFail(t, l.NewSessionHTTPRequestOrFail(t, m.method, m.rawurl, session, m.body))
+		if code != nethttp.StatusUnauthorized {
+			t.Errorf("expected %s %s to be unauthorized with the session signed out, got %d, body: %s", m.method, m.rawurl, code, resp)
+		}
+	}
+
+	// the check is untouched, and the token still has access to it.
+	code, resp = doOrFail(t, l.NewHTTPRequestOrFail(t, "GET", "/api/v2/checks/"+c.ID, l.Auth.Token, ""))
+	if code != nethttp.StatusOK {
+		t.Fatalf("unexpected status code finding the check: %d, body: %s", code, resp)
+	}
+	var got struct {
+		Status string `json:"status"`
+	}
+	if err := json.Unmarshal(resp, &got); err != nil {
+		t.Fatalf("unexpected error unmarshaling check: %v", err)
+	}
+	if got.Status != "active" {
+		t.Errorf("expected the check to stay active, got %s", got.Status)
+	}
+}
+
+// The heartbeat checks have no task, the alerting engine of influxd writes
+// the status of a check whose pings stopped.
+func TestLauncher_AlertingHeartbeat(t *testing.T) {
+	l := launcher.RunTestLauncherOrFail(t, ctx, "--alerting-engine-interval", "100ms")
+	l.SetupOrFail(t)
+	defer l.ShutdownOrFail(t, ctx)
+
+	body := fmt.Sprintf(`{"type": "heartbeat", "name": "backup", "orgID": "%s", "status": "active", "every": "1s", "level": "CRIT"}`, l.Org.ID)
+	code, resp := doOrFail(t, l.NewHTTPRequestOrFail(t, "POST", "/api/v2/checks", l.Auth.Token, body))
+	if code != nethttp.StatusCreated {
+		t.Fatalf("unexpected status code creating a check: %d, body: %s", code, resp)
+	}
+	var c struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(resp, &c); err != nil {
+		t.Fatalf("unexpected error unmarshaling check: %v", err)
+	}
+
+	// the monitoring bucket is created by the first status written.
+	q := fmt.Sprintf(`from(bucket: "_monitoring") |> range(start: -1h) |> filter(fn: (r) => r._check_id == "%s" and r._level == "crit")`, c.ID)
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		b, err := http.SimpleQuery(l.URL(), q, l.Org.Name, l.Auth.Token)
+		if err == nil && strings.Contains(string(b), c.ID) {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected a crit status of the heartbeat check, got %s, error: %v", b, err)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+// The garbage collection of the checks runs on the leader of the servers
+// sharing the store.
+func TestLauncher_CheckGCLeader(t *testing.T) {
+	l := launcher.RunTestLauncherOrFail(t, ctx, "--check-gc-inactive-for", "4320h", "--check-gc-interval", "100ms")
+	l.SetupOrFail(t)
+	defer l.ShutdownOrFail(t, ctx)
+
+	const want = `alerting_leader_is_leader{election="alerting/check-gc"} 1`
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		code, body := doOrFail(t, l.MustNewHTTPRequest("GET", "/metrics", ""))
+		if code == nethttp.StatusOK && strings.Contains(string(body), want) {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the server to be the leader of the check gc, got %d: %s", code, body)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
@@ -15,6 +15,9 @@ import (
 
 	"github.com/influxdata/flux/execute"
 	platform "github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/admission"
+	"github.com/influxdata/influxdb/alerting"
+	"github.com/influxdata/influxdb/alerting/otlp"
 	"github.com/influxdata/influxdb/authorizer"
 	"github.com/influxdata/influxdb/bolt"
 	"github.com/influxdata/influxdb/chronograf/server"
@@ -27,8 +30,11 @@ import (
 	"github.com/influxdata/influxdb/kit/signals"
 	"github.com/influxdata/influxdb/kit/tracing"
 	"github.com/influxdata/influxdb/kv"
+	"github.com/influxdata/influxdb/leader"
 	influxlogger "github.com/influxdata/influxdb/logger"
 	"github.com/influxdata/influxdb/nats"
+	"github.com/influxdata/influxdb/notification/check"
+	"github.com/influxdata/influxdb/notification/sender"
 	infprom "github.com/influxdata/influxdb/prometheus"
 	"github.com/influxdata/influxdb/query"
 	"github.com/influxdata/influxdb/query/control"
@@ -186,6 +192,196 @@ func buildLauncherCommand(l *Launcher, cmd *cobra.Command) {
 			Default: false,
 			Desc:    "disables automatically extending session ttl on request",
 		},
+		{
+			DestP:   &l.checkViewerRedactionDisabled,
+			Flag:    "check-viewer-redaction-disabled",
+			Default: false,
+			Desc:    "returns the query of the checks to the tokens and viewers allowed to read them but not to write them",
+		},
+		{
+			DestP: &l.notificationExec.AllowedCommands,
+			Flag:  "notification-exec-allowed-commands",
+			Desc:  "command lines exec notification endpoints may run, the absolute path of a command followed by its exact arguments separated by spaces; exec endpoints are disabled when empty",
+		},
+		{
+			DestP:   &l.notificationExec.Timeout,
+			Flag:    "notification-exec-timeout",
+			Default: sender.DefaultExecTimeout,
+			Desc:    "maximum time the command of an exec notification endpoint may run for",
+		},
+		{
+			DestP:   &l.notificationExec.MaxConcurrency,
+			Flag:    "notification-exec-max-concurrency",
+			Default: sender.DefaultExecMaxConcurrency,
+			Desc:    "maximum number of exec notification endpoint commands running at once",
+		},
+		{
+			DestP: &l.notificationMessages.maxSize,
+			Flag:  "notification-message-max-size",
+			Desc:  "maximum size in bytes of the messages of the notifications to the endpoint types without a default size, slack 4000 and pagerduty 1024, nor a size in notification-message-max-sizes; unlimited when 0",
+		},
+		{
+			DestP: &l.notificationMessages.maxSizes,
+			Flag:  "notification-message-max-sizes",
+			Desc:  "type=size maximum sizes in bytes of the messages of the notifications by endpoint type, such as slack=3000; the messages of a type of size 0 are unlimited",
+		},
+		{
+			DestP:   &l.notificationMessages.truncation,
+			Flag:    "notification-message-truncation",
+			Default: string(sender.TruncateEllipsis),
+			Desc:    "how the messages over the maximum size of their endpoint are cut: ellipsis keeps their start followed by an ellipsis, head keeps their start and tail keeps their end",
+		},
+		{
+			DestP: &l.checkNamePolicy.MaxLength,
+			Flag:  "check-name-max-length",
+			Desc:  "maximum number of characters of the names of checks; unlimited when 0",
+		},
+		{
+			DestP: &l.checkNamePolicy.Pattern,
+			Flag:  "check-name-pattern",
+			Desc:  "regular expression the whole name of a check must match, such as [a-z0-9_-]+",
+		},
+		{
+			DestP: &l.checkNamePolicy.ReservedPrefixes,
+			Flag:  "check-name-reserved-prefixes",
+			Desc:  "prefixes the names of checks can't start with, such as _system",
+		},
+		{
+			DestP:   &l.alertingEngineInterval,
+			Flag:    "alerting-engine-interval",
+			Default: alerting.DefaultInterval,
+			Desc:    "how often the alerting engine evaluates the checks which aren't run by tasks and sends the notifications deferred by quiet hours",
+		},
+		{
+			DestP: &l.alertingQuotas.Checks,
+			Flag:  "alerting-quota-checks",
+			Desc:  "maximum number of the checks of an organization, the archived checks aren't counted; unlimited when 0",
+		},
+		{
+			DestP: &l.alertingQuotas.NotificationRules,
+			Flag:  "alerting-quota-notification-rules",
+			Desc:  "maximum number of the notification rules of an organization; unlimited when 0",
+		},
+		{
+			DestP: &l.alertingQuotas.NotificationEndpoints,
+			Flag:  "alerting-quota-notification-endpoints",
+			Desc:  "maximum number of the notification endpoints of an organization; unlimited when 0",
+		},
+		{
+			DestP:   &l.alertingQuotas.WarnPercent,
+			Flag:    "alerting-quota-warn-percent",
+			Default: platform.DefaultAlertingQuotaWarnPercent,
+			Desc:    "percentage of an alerting quota past which the create and list responses of the resources carry warnings",
+		},
+		{
+			DestP: &l.checkGC.policy.InactiveFor,
+			Flag:  "check-gc-inactive-for",
+			Desc:  "how long a check must be inactive or archived and unmodified for its owners to be notified and for it to be deleted after the grace period, such as 4320h for 180 days; the checks are never collected when 0",
+		},
+		{
+			DestP:   &l.checkGC.policy.GracePeriod,
+			Flag:    "check-gc-grace-period",
+			Default: platform.DefaultCheckGCGracePeriod,
+			Desc:    "time between the notification of the owners of an unused check and its deletion",
+		},
+		{
+			DestP:   &l.checkGC.policy.ExcludeLabel,
+			Flag:    "check-gc-exclude-label",
+			Default: platform.DefaultCheckGCExcludeLabel,
+			Desc:    "name of the label sparing the checks it is applied to from the garbage collection; no check is spared when empty",
+		},
+		{
+			DestP:   &l.checkGC.policy.DryRun,
+			Flag:    "check-gc-dry-run",
+			Default: false,
+			Desc:    "logs what the garbage collection of the checks would do, without notifying, deleting nor recording anything",
+		},
+		{
+			DestP:   &l.checkGC.interval,
+			Flag:    "check-gc-interval",
+			Default: platform.DefaultCheckGCInterval,
+			Desc:    "how often the garbage collection of the checks runs",
+		},
+		{
+			DestP: &l.alertingCORS.AllowedOrigins,
+			Flag:  "alerting-cors-allowed-origins",
+			Desc:  "origins of the web applications allowed to call the check and notification APIs from a browser, such as https://grafana.example.com; every origin is allowed when empty",
+		},
+		{
+			DestP: &l.alertingCORS.AllowedHeaders,
+			Flag:  "alerting-cors-allowed-headers",
+			Desc:  "request headers allowed to the cross-origin calls of the check and notification APIs besides the default ones",
+		},
+		{
+			DestP: &l.alertingCache.MaxAge,
+			Flag:  "alerting-cache-max-age",
+			Desc:  "how long the clients may reuse the responses to the reads of the check and notification APIs from their private cache; the responses bearing secrets are never stored",
+		},
+		{
+			DestP: &l.validationWebhook.url,
+			Flag:  "alerting-validation-webhook-url",
+			Desc:  "url of an external validator reviewing, and possibly rejecting or mutating, every mutation of the checks and notification rules before it is persisted",
+		},
+		{
+			DestP:   &l.validationWebhook.timeout,
+			Flag:    "alerting-validation-webhook-timeout",
+			Default: admission.DefaultTimeout,
+			Desc:    "maximum time the validation webhook responds in",
+		},
+		{
+			DestP:   &l.validationWebhook.failurePolicy,
+			Flag:    "alerting-validation-webhook-failure-policy",
+			Default: string(admission.FailClosed),
+			Desc:    "whether the mutations the validation webhook failed to review are rejected (fail-closed) or persisted (fail-open)",
+		},
+		{
+			DestP:   &l.checkTaskReconcilePolicy,
+			Flag:    "check-task-reconcile-policy",
+			Default: string(platform.CheckTaskReconcileRepair),
+			Desc:    "what the startup reconciliation does with the checks whose task is missing and the tasks of deleted checks: repair, report or off",
+		},
+		{
+			DestP: &l.alertingOrgCheckConcurrency,
+			Flag:  "alerting-org-check-concurrency",
+			Desc:  "maximum number of check tasks of an organization running at once, the runs over it are queued; unlimited when 0",
+		},
+		{
+			DestP:   &l.alertingMetricsOrgLimit,
+			Flag:    "alerting-metrics-org-limit",
+			Default: alerting.DefaultCheckMetricsOrgLimit,
+			Desc:    "number of organizations with the most checks the check metrics are split out by on /metrics, the checks of the others are counted in the \"other\" org; every organization is split out when 0",
+		},
+		{
+			DestP: &l.alertingRequestRecording,
+			Flag:  "alerting-request-recording",
+			Desc:  "number of the latest requests to the alerting APIs recorded, with their credentials redacted, for support bundles at " + http.DebugAlertingRequestsPath + "; requests aren't recorded when 0",
+		},
+		{
+			DestP: &l.alertingOTLP.endpoint,
+			Flag:  "alerting-otlp-endpoint",
+			Desc:  "base URL of an OpenTelemetry collector the metrics and the spans of the alerting engine are exported to over OTLP/HTTP, such as http://localhost:4318; not exported when empty",
+		},
+		{
+			DestP: &l.alertingOTLP.headers,
+			Flag:  "alerting-otlp-headers",
+			Desc:  "key=value headers sent with each export to the OpenTelemetry collector, such as api-key=secret",
+		},
+		{
+			DestP:   &l.alertingOTLP.interval,
+			Flag:    "alerting-otlp-interval",
+			Default: otlp.DefaultInterval,
+			Desc:    "how often the alerting metrics and spans are exported to the OpenTelemetry collector",
+		},
+		{
+			DestP: &l.secretProviders.names,
+			Flag:  "secret-providers",
+			Desc:  "external stores the secret fields of notification endpoints may refer to, such as vault://secret/data/slack#token: vault, configured with the standard vault environment variables, and awssm, configured with the standard aws environment variables",
+		},
+		{
+			DestP: &l.secretProviders.prefix,
+			Flag:  "secret-provider-prefix",
+			Desc:  "prefix of the paths of the secrets the endpoints may refer to, {orgID} is replaced by the organization of the endpoint, such as secret/data/influxdb/{orgID}/; any secret readable by influxd may be referred to when empty",
+		},
 	}
 
 	cli.BindOptions(cmd, opts)
@@ -203,10 +399,60 @@ type Launcher struct {
 	sessionLength        int // in minutes
 	sessionRenewDisabled bool
 
+	checkViewerRedactionDisabled bool
+
 	logLevel          string
 	tracingType       string
 	reportingDisabled bool
 
+	notificationExec sender.ExecConfig
+	checkNamePolicy  platform.CheckNamePolicy
+	alertingQuotas   platform.AlertingQuotas
+	alertingCORS     http.CORSConfig
+	alertingCache    http.CacheConfig
+
+	checkTaskReconcilePolicy    string
+	alertingRequestRecording    int
+	alertingOrgCheckConcurrency int
+	alertingMetricsOrgLimit     int
+
+	alertingEngineInterval time.Duration
+	alertingEngine         *alerting.Engine
+
+	// node identifies the server among the servers sharing the store, in
+	// the leases of the checks and the elections of the singleton jobs.
+	node          string
+	leaderMetrics *leader.Metrics
+
+	alertingOTLP struct {
+		endpoint string
+		headers  []string
+		interval time.Duration
+		exporter *otlp.Exporter
+	}
+
+	checkGC struct {
+		policy   platform.CheckGCPolicy
+		interval time.Duration
+	}
+
+	secretProviders struct {
+		names  []string
+		prefix string
+	}
+
+	notificationMessages struct {
+		maxSize    int
+		maxSizes   []string
+		truncation string
+	}
+
+	validationWebhook struct {
+		url           string
+		timeout       time.Duration
+		failurePolicy string
+	}
+
 	httpBindAddress string
 	boltPath        string
 	enginePath      string
@@ -285,6 +531,11 @@ func (m *Launcher) Shutdown(ctx context.Context) {
 	m.logger.Info("Stopping", zap.String("service", "task"))
 	m.scheduler.Stop()
 
+	m.logger.Info("Stopping", zap.String("service", "alerting"))
+	if err := m.alertingEngine.Close(); err != nil {
+		m.logger.Info("failed closing alerting engine", zap.Error(err))
+	}
+
 	m.logger.Info("Stopping", zap.String("service", "nats"))
 	m.natsServer.Close()
 
@@ -311,6 +562,12 @@ func (m *Launcher) Shutdown(ctx context.Context) {
 		}
 	}
 
+	if m.alertingOTLP.exporter != nil {
+		if err := m.alertingOTLP.exporter.Close(); err != nil {
+			m.logger.Warn("failed to close alerting OTLP exporter", zap.Error(err))
+		}
+	}
+
 	m.logger.Sync()
 }
 
@@ -395,8 +652,41 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 		return err
 	}
 
+	if err := m.checkNamePolicy.Valid(); err != nil {
+		m.logger.Error("invalid check name policy", zap.Error(err))
+		return err
+	}
+
+	if err := m.alertingQuotas.Valid(); err != nil {
+		m.logger.Error("invalid alerting quotas", zap.Error(err))
+		return err
+	}
+
+	if err := m.checkGC.policy.Valid(); err != nil {
+		m.logger.Error("invalid check gc policy", zap.Error(err))
+		return err
+	}
+	if m.checkGC.policy.Enabled() && m.checkGC.interval <= 0 {
+		err := fmt.Errorf("check gc interval must be positive")
+		m.logger.Error("invalid check gc interval", zap.Error(err))
+		return err
+	}
+
+	if err := platform.CheckTaskReconcilePolicy(m.checkTaskReconcilePolicy).Valid(); err != nil {
+		m.logger.Error("invalid check task reconcile policy", zap.Error(err))
+		return err
+	}
+
+	if err := admission.FailurePolicy(m.validationWebhook.failurePolicy).Valid(); err != nil {
+		m.logger.Error("invalid validation webhook failure policy", zap.Error(err))
+		return err
+	}
+
 	serviceConfig := kv.ServiceConfig{
-		SessionLength: time.Duration(m.sessionLength) * time.Minute,
+		SessionLength:            time.Duration(m.sessionLength) * time.Minute,
+		NotificationExecCommands: m.notificationExec.AllowedCommands,
+		CheckNamePolicy:          m.checkNamePolicy,
+		AlertingQuotas:           m.alertingQuotas,
 	}
 
 	var flusher http.Flusher
@@ -431,31 +721,54 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 		prometheus.NewGoCollector(),
 		infprom.NewInfluxCollector(m.boltClient, info),
 	)
+
+	m.node = snowflake.NewIDGenerator().ID().String()
+	m.leaderMetrics = leader.NewMetrics()
+	m.reg.MustRegister(m.leaderMetrics.PrometheusCollectors()...)
+	m.reconcileCheckTasks(ctx)
 	m.reg.WithLogger(m.logger)
 	m.reg.MustRegister(m.boltClient)
 
 	var (
-		orgSvc              platform.OrganizationService             = m.kvService
-		authSvc             platform.AuthorizationService            = m.kvService
-		userSvc             platform.UserService                     = m.kvService
-		variableSvc         platform.VariableService                 = m.kvService
-		bucketSvc           platform.BucketService                   = m.kvService
-		sourceSvc           platform.SourceService                   = m.kvService
-		sessionSvc          platform.SessionService                  = m.kvService
-		passwdsSvc          platform.PasswordsService                = m.kvService
-		dashboardSvc        platform.DashboardService                = m.kvService
-		dashboardLogSvc     platform.DashboardOperationLogService    = m.kvService
-		userLogSvc          platform.UserOperationLogService         = m.kvService
-		bucketLogSvc        platform.BucketOperationLogService       = m.kvService
-		orgLogSvc           platform.OrganizationOperationLogService = m.kvService
-		onboardingSvc       platform.OnboardingService               = m.kvService
-		scraperTargetSvc    platform.ScraperTargetStoreService       = m.kvService
-		telegrafSvc         platform.TelegrafConfigStore             = m.kvService
-		userResourceSvc     platform.UserResourceMappingService      = m.kvService
-		labelSvc            platform.LabelService                    = m.kvService
-		secretSvc           platform.SecretService                   = m.kvService
-		lookupSvc           platform.LookupService                   = m.kvService
-		notificationRuleSvc platform.NotificationRuleStore           = m.kvService
+		orgSvc                  platform.OrganizationService             = m.kvService
+		authSvc                 platform.AuthorizationService            = m.kvService
+		userSvc                 platform.UserService                     = m.kvService
+		variableSvc             platform.VariableService                 = m.kvService
+		bucketSvc               platform.BucketService                   = m.kvService
+		sourceSvc               platform.SourceService                   = m.kvService
+		sessionSvc              platform.SessionService                  = m.kvService
+		passwdsSvc              platform.PasswordsService                = m.kvService
+		dashboardSvc            platform.DashboardService                = m.kvService
+		dashboardLogSvc         platform.DashboardOperationLogService    = m.kvService
+		userLogSvc              platform.UserOperationLogService         = m.kvService
+		bucketLogSvc            platform.BucketOperationLogService       = m.kvService
+		orgLogSvc               platform.OrganizationOperationLogService = m.kvService
+		onboardingSvc           platform.OnboardingService               = m.kvService
+		scraperTargetSvc        platform.ScraperTargetStoreService       = m.kvService
+		telegrafSvc             platform.TelegrafConfigStore             = m.kvService
+		userResourceSvc         platform.UserResourceMappingService      = m.kvService
+		labelSvc                platform.LabelService                    = m.kvService
+		secretSvc               platform.SecretService                   = m.kvService
+		lookupSvc               platform.LookupService                   = m.kvService
+		notificationRuleSvc     platform.NotificationRuleStore           = m.kvService
+		notificationEndpointSvc platform.NotificationEndpointService     = m.kvService
+		notificationTemplateSvc platform.NotificationTemplateService     = m.kvService
+		notificationPrefsSvc    platform.NotificationPreferencesService  = m.kvService
+		notificationBudgetSvc   platform.NotificationBudgetService       = m.kvService
+		silenceSvc              platform.SilenceService                  = m.kvService
+		checkSvc                platform.CheckService                    = m.kvService
+		alertingDiagnosticsSvc  platform.AlertingDiagnosticsService      = m.kvService
+		alertingSettingsSvc     platform.AlertingSettingsService         = m.kvService
+		alertingPauseSvc        platform.AlertingPauseService            = m.kvService
+		checkTransferSvc        platform.CheckTransferService            = m.kvService
+		checkCloneSvc           platform.CheckCloneService               = m.kvService
+		checkImportSvc          platform.CheckImportService              = m.kvService
+		checkBulkUpdateSvc      platform.CheckBulkUpdateService          = m.kvService
+		checkBulkCreateSvc      platform.CheckBulkCreateService          = m.kvService
+		statusTraceSvc          platform.StatusTraceService              = m.kvService
+		alertingUsageSvc        platform.AlertingUsageService            = m.kvService
+		alertingQuotaSvc        platform.AlertingQuotaService            = m.kvService
+		monitoringTemplateSvc   platform.MonitoringTemplateService       = m.kvService
 	)
 
 	switch m.secretStore {
@@ -476,6 +789,36 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 		return err
 	}
 
+	secretProviders := make(map[string]platform.SecretProvider)
+	for _, name := range m.secretProviders.names {
+		var (
+			p   platform.SecretProvider
+			err error
+		)
+		switch name {
+		case platform.SecretProviderVault:
+			p, err = vault.NewSecretProvider(m.secretProviders.prefix)
+		case platform.SecretProviderAWSSecretsManager:
+			p, err = sender.NewAWSSecretsManager(m.secretProviders.prefix)
+		default:
+			err = fmt.Errorf("unknown secret provider %q, expected \"vault\" or \"awssm\"", name)
+		}
+		if err != nil {
+			m.logger.Error("failed initializing secret provider", zap.String("provider", name), zap.Error(err))
+			return err
+		}
+		secretProviders[name] = p
+	}
+
+	if m.validationWebhook.url != "" {
+		webhook := admission.NewWebhook(m.validationWebhook.url, m.logger.With(zap.String("service", "validation_webhook")))
+		webhook.Timeout = m.validationWebhook.timeout
+		webhook.FailurePolicy = admission.FailurePolicy(m.validationWebhook.failurePolicy)
+		checkSvc = admission.NewCheckService(checkSvc, webhook)
+		checkBulkCreateSvc = admission.NewCheckBulkCreateService(checkBulkCreateSvc, webhook)
+		notificationRuleSvc = admission.NewNotificationRuleStore(notificationRuleSvc, webhook)
+	}
+
 	chronografSvc, err := server.NewServiceV2(ctx, m.boltClient.DB())
 	if err != nil {
 		m.logger.Error("failed creating chronograf service", zap.Error(err))
@@ -530,6 +873,84 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 		m.reg.MustRegister(m.queryController.PrometheusCollectors()...)
 	}
 
+	// the alerting engine evaluates the checks which aren't run by tasks,
+	// and sends the notifications deferred by quiet hours. It dispatches the
+	// statuses of the runs of the tasks of the other checks, and the
+	// statuses injected to test the rules.
+	alertingEngine := alerting.NewEngine(m.kvService, query.QueryServiceBridge{AsyncQueryService: m.queryController}, pointsWriteService{pointsWriter})
+	alertingEngine.Logger = m.logger.With(zap.String("service", "alerting"))
+	alertingEngine.Interval = m.alertingEngineInterval
+	alertingEngine.Evaluates = func(c platform.Check) bool { return !kv.HasCheckTask(c) }
+	// the servers sharing the store share the checks of the engine by their
+	// leases, each check runs on one of them.
+	alertingEngine.LeaseService = m.kvService
+	alertingEngine.CheckStateService = m.kvService
+	alertingEngine.Node = m.node
+	m.alertingEngine = alertingEngine
+	truncation, err := sender.ParseTruncation(m.notificationMessages.truncation)
+	if err != nil {
+		m.logger.Error("invalid notification message truncation", zap.Error(err))
+		return err
+	}
+	messageSizes, err := sender.ParseMessageSizes(m.notificationMessages.maxSizes)
+	if err != nil {
+		m.logger.Error("invalid notification message max sizes", zap.Error(err))
+		return err
+	}
+	alertingEngine.SenderConfig = sender.Config{
+		SecretService:   secretSvc,
+		SecretProviders: secretProviders,
+		Exec:            &m.notificationExec,
+		MessageLimits: sender.MessageLimits{
+			MaxSize:    m.notificationMessages.maxSize,
+			MaxSizes:   messageSizes,
+			Truncation: truncation,
+		},
+	}
+	alertingEngine.NotificationTemplateService = notificationTemplateSvc
+	alertingEngine.Preferences = &sender.Preferences{
+		PreferencesService: notificationPrefsSvc,
+		EndpointService:    notificationEndpointSvc,
+	}
+	alertingEngine.StatusTraceService = statusTraceSvc
+	alertingEngine.NotificationBudgetService = notificationBudgetSvc
+	alertingEngine.SilenceService = silenceSvc
+	alertingEngine.LabelService = labelSvc
+	alertingEngine.AlertingSettingsService = alertingSettingsSvc
+	alertingEngine.AlertingPauseService = alertingPauseSvc
+	m.kvService.CheckPauseNotifier = alertingEngine
+	m.kvService.CheckGCNotifier = alertingEngine
+	m.reg.MustRegister(alertingEngine.PrometheusCollectors()...)
+
+	checkCollector := alerting.NewCheckCollector(checkSvc, m.kvService)
+	checkCollector.OrgLimit = m.alertingMetricsOrgLimit
+	checkCollector.Logger = m.logger.With(zap.String("service", "alerting-metrics"))
+	m.reg.MustRegister(checkCollector)
+
+	if m.alertingOTLP.endpoint != "" {
+		headers, err := otlp.ParseHeaders(m.alertingOTLP.headers)
+		if err != nil {
+			m.logger.Error("invalid alerting OTLP headers", zap.Error(err))
+			return err
+		}
+		// only the alerting metrics are exported, the rest of the registry
+		// stays on /metrics.
+		reg := prom.NewRegistry()
+		reg.MustRegister(alertingEngine.PrometheusCollectors()...)
+		exporter := otlp.NewExporter(otlp.Config{
+			Endpoint: m.alertingOTLP.endpoint,
+			Headers:  headers,
+			Interval: m.alertingOTLP.interval,
+		}, reg)
+		exporter.Logger = m.logger.With(zap.String("service", "alerting-otlp"))
+		if err := exporter.Open(ctx); err != nil {
+			m.logger.Error("failed to open alerting OTLP exporter", zap.Error(err))
+			return err
+		}
+		alertingEngine.Tracer = exporter.Tracer()
+		m.alertingOTLP.exporter = exporter
+	}
+
 	var storageQueryService = readservice.NewProxyQueryService(m.queryController)
 	var taskSvc platform.TaskService
 	{
@@ -539,10 +960,10 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 
 		// define the executor and build analytical storage middleware
 		combinedTaskService := taskbackend.NewAnalyticalStorage(m.logger.With(zap.String("service", "task-analytical-store")), m.kvService, m.kvService, pointsWriter, query.QueryServiceBridge{AsyncQueryService: m.queryController})
-		executor := taskexecutor.NewAsyncQueryServiceExecutor(m.logger.With(zap.String("service", "task-executor")), m.queryController, authSvc, combinedTaskService)
+		executor := taskexecutor.NewAsyncQueryServiceExecutor(m.logger.With(zap.String("service", "task-executor")), m.queryController, authSvc, combinedTaskService, taskexecutor.WithRunSummarizer(alertingEngine, kv.IsCheckTask))
 
 		// create the scheduler
-		m.scheduler = taskbackend.NewScheduler(combinedTaskService, executor, time.Now().UTC().Unix(), taskbackend.WithTicker(ctx, 100*time.Millisecond), taskbackend.WithLogger(m.logger))
+		m.scheduler = taskbackend.NewScheduler(combinedTaskService, executor, time.Now().UTC().Unix(), taskbackend.WithTicker(ctx, 100*time.Millisecond), taskbackend.WithLogger(m.logger), taskbackend.WithOrgConcurrency(m.alertingOrgCheckConcurrency, kv.IsCheckTask))
 		m.scheduler.Start(ctx)
 		m.reg.MustRegister(m.scheduler.PrometheusCollectors()...)
 
@@ -583,25 +1004,41 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 		return err
 	}
 
-	m.wg.Add(1)
-	go func(logger *zap.Logger) {
-		defer m.wg.Done()
-		logger = logger.With(zap.String("service", "scraper"))
-		if err := scraperScheduler.Run(ctx); err != nil {
-			logger.Error("failed scraper service", zap.Error(err))
-		}
-		logger.Info("Stopping")
-	}(m.logger)
+	// the leader of the servers sharing the store requests the scrapes of
+	// the targets, each target is scraped once every interval.
+	m.runElected(ctx, "scraper/gather", scraperScheduler.Interval, m.logger.With(zap.String("service", "scraper")), scraperScheduler.Gather)
+
+	if err := alertingEngine.Open(ctx); err != nil {
+		m.logger.Error("failed to open the alerting engine", zap.Error(err))
+		return err
+	}
+
+	if m.checkGC.policy.Enabled() {
+		// the leader of the servers sharing the store collects the checks,
+		// every interval.
+		m.runElected(ctx, "alerting/check-gc", m.checkGC.interval, m.logger.With(zap.String("service", "check_gc")), m.collectChecks)
+	}
 
 	m.httpServer = &nethttp.Server{
 		Addr: m.httpBindAddress,
 	}
 
+	checkCoverageSvc := &check.CoverageService{
+		CheckService:       checkSvc,
+		BucketService:      bucketSvc,
+		MeasurementService: m.engine,
+	}
+
 	m.apibackend = &http.APIBackend{
 		AssetsPath:           m.assetsPath,
 		HTTPErrorHandler:     http.ErrorHandler(0),
 		Logger:               m.logger,
 		SessionRenewDisabled: m.sessionRenewDisabled,
+		AlertingCORS:         m.alertingCORS,
+		AlertingCache:        m.alertingCache,
+
+		CheckViewerRedactionDisabled: m.checkViewerRedactionDisabled,
+
 		NewBucketService:     source.NewBucketService,
 		NewQueryService:      source.NewQueryService,
 		PointsWriter:         pointsWriter,
@@ -627,6 +1064,40 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 		TaskService:                     taskSvc,
 		TelegrafService:                 telegrafSvc,
 		NotificationRuleStore:           notificationRuleSvc,
+		NotificationRuleTestService:     alertingEngine,
+		NotificationEndpointService:     notificationEndpointSvc,
+		NotificationTemplateService:     notificationTemplateSvc,
+		NotificationPreferencesService:  notificationPrefsSvc,
+		NotificationBudgetService:       notificationBudgetSvc,
+		NotificationEndpointCascader:    m.kvService,
+		NotificationEndpointReassigner:  m.kvService,
+		SilenceService:                  silenceSvc,
+		CheckService:                    checkSvc,
+		AlertingDiagnosticsService:      alertingDiagnosticsSvc,
+		CheckCoverageService:            checkCoverageSvc,
+		AlertingSettingsService:         alertingSettingsSvc,
+		AlertingPauseService:            alertingPauseSvc,
+		CheckTransferService:            checkTransferSvc,
+		CheckCloneService:               checkCloneSvc,
+		CheckImportService:              checkImportSvc,
+		CheckBulkUpdateService:          checkBulkUpdateSvc,
+		CheckBulkCreateService:          checkBulkCreateSvc,
+		CheckTaskReconciler:             m.kvService,
+		CheckGCService:                  m.kvService,
+		StatusTraceService:              statusTraceSvc,
+		StatusInjectionService:          alertingEngine,
+		ExternalStatusService:           alertingEngine,
+		CheckPingService:                alertingEngine,
+		CheckPreviewService:             alertingEngine,
+		CheckRelatedService:             m.kvService,
+		CheckStatusService:              m.kvService,
+		CheckLagService:                 alertingEngine,
+		CheckWatchService:               m.kvService,
+		CheckApplyService:               m.kvService,
+		AlertingUsageService:            alertingUsageSvc,
+		AlertingQuotaService:            alertingQuotaSvc,
+		LabelBulkService:                m.kvService,
+		MonitoringTemplateService:       monitoringTemplateSvc,
 		ScraperTargetStoreService:       scraperTargetSvc,
 		ChronografService:               chronografSvc,
 		SecretService:                   secretSvc,
@@ -647,6 +1118,11 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 	h := http.NewHandlerFromRegistry("platform", m.reg)
 	h.Handler = platformHandler
 	h.Logger = httpLogger
+	if m.alertingRequestRecording > 0 {
+		recorder := http.NewRequestRecorder(m.alertingRequestRecording)
+		h.Handler = recorder.Record(platformHandler)
+		h.DebugHandler = http.DebugAlertingRequests(h.DebugHandler, recorder)
+	}
 
 	m.httpServer.Handler = h
 	// If we are in testing mode we allow all data to be flushed and removed.
@@ -679,6 +1155,90 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 	return nil
 }
 
+// reconcileCheckTasks repairs or reports the checks whose task is missing and
+// the tasks of deleted checks, left by partial failures. A single one of the
+// servers sharing the store and starting together reconciles them. A failed
+// reconciliation is logged without stopping the server.
+func (m *Launcher) reconcileCheckTasks(ctx context.Context) {
+	p := platform.CheckTaskReconcilePolicy(m.checkTaskReconcilePolicy)
+	if p == platform.CheckTaskReconcileOff {
+		return
+	}
+	logger := m.logger.With(zap.String("service", "check_task_reconciliation"))
+	e := leader.NewElector(m.kvService, "alerting/check-task-reconciliation", m.node)
+	e.Metrics = m.leaderMetrics
+	e.Logger = logger
+	var r *platform.CheckTaskReconciliation
+	ran, err := e.Once(ctx, func(ctx context.Context) (err error) {
+		r, err = m.kvService.ReconcileCheckTasks(ctx, p)
+		return err
+	})
+	if err != nil {
+		logger.Error("failed to reconcile the checks and their tasks", zap.Error(err))
+		return
+	}
+	if !ran {
+		logger.Info("another server reconciles the checks and their tasks")
+		return
+	}
+	for _, c := range append(r.Checks, r.Tasks...) {
+		fields := []zap.Field{
+			zap.String("id", c.ID.String()),
+			zap.String("orgID", c.OrgID.String()),
+			zap.String("name", c.Name),
+			zap.String("reason", c.Reason),
+			zap.String("action", c.Action),
+		}
+		if c.Error != "" {
+			fields = append(fields, zap.String("error", c.Error))
+		}
+		logger.Warn("reconciled inconsistent check or task", fields...)
+	}
+	logger.Info("reconciled the checks and their tasks",
+		zap.String("policy", string(p)),
+		zap.Int("checks", len(r.Checks)),
+		zap.Int("tasks", len(r.Tasks)))
+}
+
+// runElected runs job every interval on the server elected the leader of
+// key among the servers sharing the store, until ctx is done.
+func (m *Launcher) runElected(ctx context.Context, key string, interval time.Duration, logger *zap.Logger, job leader.Job) {
+	e := leader.NewElector(m.kvService, key, m.node)
+	e.Interval = interval
+	e.Metrics = m.leaderMetrics
+	e.Logger = logger
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		e.Run(ctx, job)
+	}()
+}
+
+// collectChecks runs the garbage collection of the checks once, and logs
+// every action.
+func (m *Launcher) collectChecks(ctx context.Context) error {
+	r, err := m.kvService.RunCheckGC(ctx, m.checkGC.policy)
+	if err != nil {
+		return err
+	}
+	logger := m.logger.With(zap.String("service", "check_gc"))
+	for _, ev := range r.Events {
+		fields := []zap.Field{
+			zap.String("checkID", ev.CheckID.String()),
+			zap.String("orgID", ev.OrgID.String()),
+			zap.String("name", ev.Name),
+			zap.String("action", ev.Action),
+			zap.String("reason", ev.Reason),
+			zap.Bool("dryRun", ev.DryRun),
+		}
+		if ev.Error != "" {
+			fields = append(fields, zap.String("error", ev.Error))
+		}
+		logger.Info("collected unused check", fields...)
+	}
+	return nil
+}
+
 // OrganizationService returns the internal organization service.
 func (m *Launcher) OrganizationService() platform.OrganizationService {
 	return m.apibackend.OrganizationService
@@ -282,6 +282,44 @@ func (tl *TestLauncher) NewHTTPRequestOrFail(tb testing.TB, method, rawurl, toke
 	return req
 }
 
+// SignInOrFail signs in the user created by SetupOrFail, and returns the
+// cookie of its session. Fail on error.
+func (tl *TestLauncher) SignInOrFail(tb testing.TB) *nethttp.Cookie {
+	tb.Helper()
+	req, err := nethttp.NewRequest("POST", tl.URL()+"/api/v2/signin", nil)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	req.SetBasicAuth("USER", "PASSWORD")
+
+	resp, err := nethttp.DefaultClient.Do(req)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != nethttp.StatusNoContent {
+		tb.Fatalf("unexpected status code signing in: %d", resp.StatusCode)
+	}
+
+	cookies := resp.Cookies()
+	if len(cookies) != 1 {
+		tb.Fatalf("expected 1 cookie but received %d", len(cookies))
+	}
+	return cookies[0]
+}
+
+// NewSessionHTTPRequestOrFail returns a new nethttp.Request with base URL and the cookie of a session attached. Fail on error.
+func (tl *TestLauncher) NewSessionHTTPRequestOrFail(tb testing.TB, method, rawurl string, session *nethttp.Cookie, body string) *nethttp.Request {
+	tb.Helper()
+	req, err := nethttp.NewRequest(method, tl.URL()+rawurl, strings.NewReader(body))
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	req.AddCookie(session)
+	return req
+}
+
 // Services
 
 func (tl *TestLauncher) FluxService() *http.FluxService {
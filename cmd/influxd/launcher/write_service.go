@@ -0,0 +1,41 @@
+package launcher
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"time"
+
+	platform "github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/storage"
+	"github.com/influxdata/influxdb/tsdb"
+)
+
+// pointsWriteService writes line protocol to the buckets of the storage
+// engine, for the services of the server writing data without the HTTP API.
+type pointsWriteService struct {
+	storage.PointsWriter
+}
+
+var _ platform.WriteService = pointsWriteService{}
+
+// Write parses the line protocol of r and writes its points to a bucket.
+func (s pointsWriteService) Write(ctx context.Context, orgID, bucketID platform.ID, r io.Reader) error {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	encoded := tsdb.EncodeName(orgID, bucketID)
+	mm := models.EscapeMeasurement(encoded[:])
+	points, err := models.ParsePointsWithPrecision(data, mm, time.Now(), "ns")
+	if err != nil {
+		return &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "unable to parse points",
+			Err:  err,
+		}
+	}
+	return s.WritePoints(ctx, points)
+}
@@ -11,6 +11,7 @@ type contextKey string
 
 const (
 	authorizerCtxKey = contextKey("influx/authorizer/v1")
+	dryRunCtxKey     = contextKey("influx/dryrun/v1")
 )
 
 // SetAuthorizer sets an authorizer on context.
@@ -31,6 +32,18 @@ func GetAuthorizer(ctx context.Context) (platform.Authorizer, error) {
 	return a, nil
 }
 
+// SetDryRun sets a dry run on context, the mutations run with the context
+// record their side effects in it instead of persisting them.
+func SetDryRun(ctx context.Context, d *platform.DryRun) context.Context {
+	return context.WithValue(ctx, dryRunCtxKey, d)
+}
+
+// GetDryRun retrieves the dry run of a context, nil if it isn't a dry run.
+func GetDryRun(ctx context.Context) *platform.DryRun {
+	d, _ := ctx.Value(dryRunCtxKey).(*platform.DryRun)
+	return d
+}
+
 // GetToken retrieves a token from the context; errors if no token.
 func GetToken(ctx context.Context) (string, error) {
 	a, ok := ctx.Value(authorizerCtxKey).(platform.Authorizer)
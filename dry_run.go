@@ -0,0 +1,29 @@
+package influxdb
+
+// DryRun is the side effects of the mutations run with it, which are
+// validated as usual but never persisted.
+type DryRun struct {
+	// Tasks are the tasks the mutations would create or update.
+	Tasks []*Task `json:"tasks"`
+	// DeletedTasks are the IDs of the tasks they would delete.
+	DeletedTasks []ID `json:"deletedTasks"`
+	// Secrets are the keys of the secrets they would store, never their values.
+	Secrets []string `json:"secrets"`
+	// DeletedSecrets are the keys of the secrets they would delete.
+	DeletedSecrets []string `json:"deletedSecrets"`
+	// NotificationRules are the IDs of the existing notification rules they
+	// would change, delete, or whose notifications they would change, such
+	// as the rules matching a check or sending to an endpoint.
+	NotificationRules []ID `json:"notificationRules"`
+}
+
+// NewDryRun returns a dry run without side effects yet.
+func NewDryRun() *DryRun {
+	return &DryRun{
+		Tasks:             []*Task{},
+		DeletedTasks:      []ID{},
+		Secrets:           []string{},
+		DeletedSecrets:    []string{},
+		NotificationRules: []ID{},
+	}
+}
@@ -0,0 +1,39 @@
+package influxdb
+
+import (
+	"context"
+	"fmt"
+)
+
+// ExternalStatus is a status of an external check, reported by the external
+// system the check stands for.
+type ExternalStatus struct {
+	// Level is the level of the status, such as CRIT.
+	Level string `json:"level"`
+	// Message replaces the message rendered from the template of the check.
+	Message string   `json:"message,omitempty"`
+	Value   *float64 `json:"value,omitempty"`
+	// Tags are added to the tags of the check, they identify the series of
+	// the status.
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// Valid returns error if some configuration is invalid
+func (s ExternalStatus) Valid() error {
+	if NotificationLevelRank(s.Level) < 0 {
+		return &Error{
+			Code: EInvalid,
+			Msg:  fmt.Sprintf("invalid level %s, valid levels are %v", s.Level, notificationLevels),
+		}
+	}
+	return nil
+}
+
+// ExternalStatusService writes the statuses external systems report for
+// their external checks.
+type ExternalStatusService interface {
+	// WriteExternalStatus writes a status of an external check, dispatches
+	// it to the notification rules of its organization like the statuses
+	// of the other checks, and returns its decision trace.
+	WriteExternalStatus(ctx context.Context, checkID ID, s *ExternalStatus) (*StatusTrace, error)
+}
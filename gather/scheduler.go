@@ -90,6 +90,13 @@ func (s *Scheduler) Run(ctx context.Context) error {
 	return s.run(ctx)
 }
 
+// Gather publishes the scrape requests of the targets once. The servers
+// sharing the targets gather them on a single one of them, every interval.
+func (s *Scheduler) Gather(ctx context.Context) error {
+	s.doGather(ctx)
+	return nil
+}
+
 func (s *Scheduler) run(ctx context.Context) error {
 	for {
 		select {
@@ -0,0 +1,226 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/kit/tracing"
+	"go.uber.org/zap"
+)
+
+type orphanedAlertingReportLinks struct {
+	Self string `json:"self"`
+	Org  string `json:"org"`
+}
+
+type orphanedAlertingReportResponse struct {
+	*influxdb.OrphanedAlertingReport
+	Links orphanedAlertingReportLinks `json:"links"`
+}
+
+func newOrphanedAlertingReportResponse(r *influxdb.OrphanedAlertingReport) *orphanedAlertingReportResponse {
+	return &orphanedAlertingReportResponse{
+		OrphanedAlertingReport: r,
+		Links: orphanedAlertingReportLinks{
+			Self: fmt.Sprintf("/api/v2/orgs/%s/alerting/orphans", r.OrgID),
+			Org:  fmt.Sprintf("/api/v2/orgs/%s", r.OrgID),
+		},
+	}
+}
+
+// handleGetOrphanedAlertingResources is the HTTP handler for the GET /api/v2/orgs/:id/alerting/orphans route.
+func (h *OrgHandler) handleGetOrphanedAlertingResources(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "orphaned alerting resources retrieve request", r)
+	req, err := decodeGetOrgRequest(ctx, r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	report, err := h.AlertingDiagnosticsService.FindOrphanedAlertingResources(ctx, req.OrgID)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	debugResult(h.Logger, "orphaned alerting resources retrieved", "report", report)
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newOrphanedAlertingReportResponse(report)); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+type checkCoverageReportLinks struct {
+	Self string `json:"self"`
+	Org  string `json:"org"`
+}
+
+type checkCoverageReportResponse struct {
+	*influxdb.CheckCoverageReport
+	Links checkCoverageReportLinks `json:"links"`
+}
+
+func newCheckCoverageReportResponse(r *influxdb.CheckCoverageReport) *checkCoverageReportResponse {
+	return &checkCoverageReportResponse{
+		CheckCoverageReport: r,
+		Links: checkCoverageReportLinks{
+			Self: fmt.Sprintf("/api/v2/orgs/%s/alerting/coverage", r.OrgID),
+			Org:  fmt.Sprintf("/api/v2/orgs/%s", r.OrgID),
+		},
+	}
+}
+
+type getCheckCoverageRequest struct {
+	OrgID     influxdb.ID
+	BucketIDs []influxdb.ID
+}
+
+func decodeGetCheckCoverageRequest(ctx context.Context, r *http.Request) (*getCheckCoverageRequest, error) {
+	orgReq, err := decodeGetOrgRequest(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	req := &getCheckCoverageRequest{
+		OrgID: orgReq.OrgID,
+	}
+	for _, s := range r.URL.Query()["bucketID"] {
+		id, err := influxdb.IDFromString(s)
+		if err != nil {
+			return nil, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "bucketID is invalid",
+				Err:  err,
+			}
+		}
+		req.BucketIDs = append(req.BucketIDs, *id)
+	}
+	return req, nil
+}
+
+// handleGetCheckCoverage is the HTTP handler for the GET /api/v2/orgs/:id/alerting/coverage route.
+func (h *OrgHandler) handleGetCheckCoverage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "check coverage retrieve request", r)
+	req, err := decodeGetCheckCoverageRequest(ctx, r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	report, err := h.CheckCoverageService.FindCheckCoverage(ctx, req.OrgID, req.BucketIDs)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	debugResult(h.Logger, "check coverage retrieved", "report", report)
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newCheckCoverageReportResponse(report)); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+type alertingDiagnosisLinks struct {
+	Self string `json:"self"`
+	Org  string `json:"org"`
+}
+
+type alertingDiagnosisResponse struct {
+	*influxdb.AlertingDiagnosis
+	Links alertingDiagnosisLinks `json:"links"`
+}
+
+func newAlertingDiagnosisResponse(d *influxdb.AlertingDiagnosis) *alertingDiagnosisResponse {
+	return &alertingDiagnosisResponse{
+		AlertingDiagnosis: d,
+		Links: alertingDiagnosisLinks{
+			Self: fmt.Sprintf("/api/v2/orgs/%s/alerting/doctor", d.OrgID),
+			Org:  fmt.Sprintf("/api/v2/orgs/%s", d.OrgID),
+		},
+	}
+}
+
+// handleGetAlertingDiagnosis is the HTTP handler for the GET /api/v2/orgs/:id/alerting/doctor route.
+func (h *OrgHandler) handleGetAlertingDiagnosis(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "alerting diagnosis retrieve request", r)
+	req, err := decodeGetOrgRequest(ctx, r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	d, err := h.AlertingDiagnosticsService.DiagnoseAlerting(ctx, req.OrgID)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	h.Logger.Debug("alerting diagnosis retrieved", zap.Int("findings", len(d.Findings)))
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newAlertingDiagnosisResponse(d)); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+// AlertingDiagnosticsService connects to Influx via HTTP using tokens to
+// diagnose the alerting resources of organizations.
+type AlertingDiagnosticsService struct {
+	Addr               string
+	Token              string
+	InsecureSkipVerify bool
+}
+
+var _ influxdb.AlertingDiagnosticsService = (*AlertingDiagnosticsService)(nil)
+
+// FindOrphanedAlertingResources returns the checks, notification rules and
+// notification endpoints of an organization which never lead to a notification.
+func (s *AlertingDiagnosticsService) FindOrphanedAlertingResources(ctx context.Context, orgID influxdb.ID) (*influxdb.OrphanedAlertingReport, error) {
+	var res orphanedAlertingReportResponse
+	if err := s.get(ctx, fmt.Sprintf("/api/v2/orgs/%s/alerting/orphans", orgID), &res); err != nil {
+		return nil, err
+	}
+	return res.OrphanedAlertingReport, nil
+}
+
+// DiagnoseAlerting returns the problems of the alerting resources of an
+// organization, the most urgent first.
+func (s *AlertingDiagnosticsService) DiagnoseAlerting(ctx context.Context, orgID influxdb.ID) (*influxdb.AlertingDiagnosis, error) {
+	var res alertingDiagnosisResponse
+	if err := s.get(ctx, fmt.Sprintf("/api/v2/orgs/%s/alerting/doctor", orgID), &res); err != nil {
+		return nil, err
+	}
+	return res.AlertingDiagnosis, nil
+}
+
+func (s *AlertingDiagnosticsService) get(ctx context.Context, p string, v interface{}) error {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	u, err := NewURL(s.Addr, p)
+	if err != nil {
+		return tracing.LogError(span, err)
+	}
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return tracing.LogError(span, err)
+	}
+	SetToken(s.Token, req)
+
+	hc := NewClient(u.Scheme, s.InsecureSkipVerify)
+	resp, err := hc.Do(req.WithContext(ctx))
+	if err != nil {
+		return tracing.LogError(span, err)
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return tracing.LogError(span, err)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
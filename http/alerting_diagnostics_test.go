@@ -0,0 +1,188 @@
+package http
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
+)
+
+func TestOrgHandler_handleGetOrphanedAlertingResources(t *testing.T) {
+	b := NewMockOrgBackend()
+	b.HTTPErrorHandler = ErrorHandler(0)
+	b.AlertingDiagnosticsService = &mock.AlertingDiagnosticsService{
+		FindOrphanedAlertingResourcesF: func(ctx context.Context, orgID influxdb.ID) (*influxdb.OrphanedAlertingReport, error) {
+			if orgID != influxdb.ID(2) {
+				return nil, &influxdb.Error{
+					Code: influxdb.ENotFound,
+					Msg:  "organization not found",
+				}
+			}
+			return &influxdb.OrphanedAlertingReport{
+				OrgID:  orgID,
+				Checks: []influxdb.OrphanedResource{},
+				NotificationRules: []influxdb.OrphanedResource{
+					{
+						ID:           influxdb.ID(1),
+						Name:         "page ops",
+						Reason:       influxdb.OrphanedRuleNoEndpoint,
+						SuggestedFix: "set the endpointID of the rule to a notification endpoint of the organization",
+					},
+				},
+				NotificationEndpoints: []influxdb.OrphanedResource{},
+			}, nil
+		},
+	}
+	h := NewOrgHandler(b)
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/api/v2/orgs/0000000000000002/alerting/orphans", nil))
+	res := w.Result()
+	body, _ := ioutil.ReadAll(res.Body)
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, res.StatusCode, body)
+	}
+	want := `{
+  "orgID": "0000000000000002",
+  "checks": [],
+  "notificationRules": [
+    {
+      "id": "0000000000000001",
+      "name": "page ops",
+      "reason": "the notification rule has no notification endpoint",
+      "suggestedFix": "set the endpointID of the rule to a notification endpoint of the organization"
+    }
+  ],
+  "notificationEndpoints": [],
+  "links": {
+    "self": "/api/v2/orgs/0000000000000002/alerting/orphans",
+    "org": "/api/v2/orgs/0000000000000002"
+  }
+}`
+	if eq, diff, _ := jsonEqual(string(body), want); !eq {
+		t.Errorf("handleGetOrphanedAlertingResources() = ***%s***", diff)
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/api/v2/orgs/0000000000000003/alerting/orphans", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestOrgHandler_handleGetCheckCoverage(t *testing.T) {
+	b := NewMockOrgBackend()
+	b.HTTPErrorHandler = ErrorHandler(0)
+	b.CheckCoverageService = &mock.CheckCoverageService{
+		FindCheckCoverageF: func(ctx context.Context, orgID influxdb.ID, bucketIDs []influxdb.ID) (*influxdb.CheckCoverageReport, error) {
+			if len(bucketIDs) != 1 || bucketIDs[0] != influxdb.ID(3) {
+				t.Errorf("expected bucketIDs [3], got %v", bucketIDs)
+			}
+			return &influxdb.CheckCoverageReport{
+				OrgID: orgID,
+				Buckets: []influxdb.BucketCoverage{
+					{
+						BucketID:   influxdb.ID(3),
+						BucketName: "telegraf",
+						MonitoredMeasurements: []influxdb.MonitoredMeasurement{
+							{
+								Name:     "cpu",
+								CheckIDs: []influxdb.ID{influxdb.ID(1)},
+							},
+						},
+						UnmonitoredMeasurements: []string{"disk"},
+					},
+				},
+			}, nil
+		},
+	}
+	h := NewOrgHandler(b)
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/api/v2/orgs/0000000000000002/alerting/coverage?bucketID=0000000000000003", nil))
+	res := w.Result()
+	body, _ := ioutil.ReadAll(res.Body)
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, res.StatusCode, body)
+	}
+	want := `{
+  "orgID": "0000000000000002",
+  "buckets": [
+    {
+      "bucketID": "0000000000000003",
+      "bucketName": "telegraf",
+      "monitoredMeasurements": [
+        {
+          "name": "cpu",
+          "checkIDs": ["0000000000000001"]
+        }
+      ],
+      "unmonitoredMeasurements": ["disk"]
+    }
+  ],
+  "links": {
+    "self": "/api/v2/orgs/0000000000000002/alerting/coverage",
+    "org": "/api/v2/orgs/0000000000000002"
+  }
+}`
+	if eq, diff, _ := jsonEqual(string(body), want); !eq {
+		t.Errorf("handleGetCheckCoverage() = ***%s***", diff)
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/api/v2/orgs/0000000000000002/alerting/coverage?bucketID=nope", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestAlertingDiagnosticsService_DiagnoseAlerting(t *testing.T) {
+	diagnosedAt := time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC)
+	b := NewMockOrgBackend()
+	b.HTTPErrorHandler = ErrorHandler(0)
+	b.AlertingDiagnosticsService = &mock.AlertingDiagnosticsService{
+		DiagnoseAlertingF: func(ctx context.Context, orgID influxdb.ID) (*influxdb.AlertingDiagnosis, error) {
+			if orgID != influxdb.ID(2) {
+				return nil, &influxdb.Error{
+					Code: influxdb.ENotFound,
+					Msg:  "organization not found",
+				}
+			}
+			return &influxdb.AlertingDiagnosis{
+				OrgID:       orgID,
+				DiagnosedAt: diagnosedAt,
+				Findings: []influxdb.AlertingFinding{
+					{
+						Priority:     influxdb.AlertingFindingCritical,
+						Kind:         influxdb.AlertingFindingDanglingTask,
+						ResourceType: influxdb.ChecksResourceType,
+						ID:           influxdb.ID(1),
+						Name:         "cpu",
+						Problem:      influxdb.ReconcileCheckDanglingTask,
+						Fix:          "restart influxd with --check-task-reconcile-policy repair to recreate the task of the check",
+					},
+				},
+			}, nil
+		},
+	}
+	srv := httptest.NewServer(NewOrgHandler(b))
+	defer srv.Close()
+
+	s := &AlertingDiagnosticsService{Addr: srv.URL}
+	d, err := s.DiagnoseAlerting(context.Background(), influxdb.ID(2))
+	if err != nil {
+		t.Fatalf("failed to diagnose alerting: %v", err)
+	}
+	if d.OrgID != influxdb.ID(2) || !d.DiagnosedAt.Equal(diagnosedAt) || len(d.Findings) != 1 || d.Findings[0].Name != "cpu" || d.Findings[0].Kind != influxdb.AlertingFindingDanglingTask {
+		t.Errorf("unexpected diagnosis %+v", d)
+	}
+
+	if _, err := s.DiagnoseAlerting(context.Background(), influxdb.ID(3)); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Errorf("expected not found error for a missing org, got %v", err)
+	}
+}
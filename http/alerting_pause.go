@@ -0,0 +1,128 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/influxdata/influxdb"
+	"go.uber.org/zap"
+)
+
+type alertingPauseLinks struct {
+	Self   string `json:"self"`
+	Resume string `json:"resume"`
+	Org    string `json:"org"`
+}
+
+type alertingPauseResponse struct {
+	*influxdb.AlertingPause
+	Links alertingPauseLinks `json:"links"`
+}
+
+func newAlertingPauseResponse(p *influxdb.AlertingPause) *alertingPauseResponse {
+	return &alertingPauseResponse{
+		AlertingPause: p,
+		Links: alertingPauseLinks{
+			Self:   fmt.Sprintf("/api/v2/orgs/%s/alerting/pause", p.OrgID),
+			Resume: fmt.Sprintf("/api/v2/orgs/%s/alerting/resume", p.OrgID),
+			Org:    fmt.Sprintf("/api/v2/orgs/%s", p.OrgID),
+		},
+	}
+}
+
+// handleGetAlertingPause is the HTTP handler for the GET /api/v2/orgs/:id/alerting/pause route.
+func (h *OrgHandler) handleGetAlertingPause(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "alerting pause retrieve request", r)
+	req, err := decodeGetOrgRequest(ctx, r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	p, err := h.AlertingPauseService.FindAlertingPause(ctx, req.OrgID)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	debugResult(h.Logger, "alerting pause retrieved", "alertingPause", p)
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newAlertingPauseResponse(p)); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+type postAlertingPauseRequest struct {
+	OrgID  influxdb.ID
+	Reason string `json:"reason"`
+}
+
+func decodePostAlertingPauseRequest(ctx context.Context, r *http.Request) (*postAlertingPauseRequest, error) {
+	req, err := decodeGetOrgRequest(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	pr := &postAlertingPauseRequest{}
+	// the reason is optional, so is the body.
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(pr); err != nil {
+			return nil, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  err.Error(),
+			}
+		}
+	}
+	pr.OrgID = req.OrgID
+	return pr, nil
+}
+
+// handlePostAlertingPause is the HTTP handler for the POST /api/v2/orgs/:id/alerting/pause route.
+func (h *OrgHandler) handlePostAlertingPause(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "alerting pause request", r)
+	req, err := decodePostAlertingPauseRequest(ctx, r)
+	if err != nil {
+		h.Logger.Debug("failed to decode request", zap.Error(err))
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	p, err := h.AlertingPauseService.PauseAlerting(ctx, req.OrgID, req.Reason)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	debugResult(h.Logger, "alerting paused", "alertingPause", p)
+
+	if err := encodeResponse(ctx, w, http.StatusCreated, newAlertingPauseResponse(p)); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+// handlePostAlertingResume is the HTTP handler for the POST /api/v2/orgs/:id/alerting/resume route.
+func (h *OrgHandler) handlePostAlertingResume(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "alerting resume request", r)
+	req, err := decodeGetOrgRequest(ctx, r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	p, err := h.AlertingPauseService.ResumeAlerting(ctx, req.OrgID)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	debugResult(h.Logger, "alerting resumed", "alertingPause", p)
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newAlertingPauseResponse(p)); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
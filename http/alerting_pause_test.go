@@ -0,0 +1,97 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
+	"go.uber.org/zap"
+)
+
+func TestOrgHandler_handlePostAlertingPause(t *testing.T) {
+	pausedAt := time.Date(2019, 10, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		body       string
+		statusCode int
+		want       string
+	}{
+		{
+			name:       "pause the alerting of an org",
+			body:       `{"reason":"database migration"}`,
+			statusCode: 201,
+			want: `{
+  "orgID": "0000000000000003",
+  "userID": "0000000000000006",
+  "pausedAt": "2019-10-01T12:00:00Z",
+  "reason": "database migration",
+  "checkIDs": ["0000000000000001"],
+  "links": {
+    "self": "/api/v2/orgs/0000000000000003/alerting/pause",
+    "resume": "/api/v2/orgs/0000000000000003/alerting/resume",
+    "org": "/api/v2/orgs/0000000000000003"
+  }
+}`,
+		},
+		{
+			name:       "pause without a reason",
+			statusCode: 201,
+			want: `{
+  "orgID": "0000000000000003",
+  "userID": "0000000000000006",
+  "pausedAt": "2019-10-01T12:00:00Z",
+  "checkIDs": ["0000000000000001"],
+  "links": {
+    "self": "/api/v2/orgs/0000000000000003/alerting/pause",
+    "resume": "/api/v2/orgs/0000000000000003/alerting/resume",
+    "org": "/api/v2/orgs/0000000000000003"
+  }
+}`,
+		},
+		{
+			name:       "invalid body",
+			body:       `{"reason":`,
+			statusCode: 400,
+			want:       `{"code":"invalid","message":"unexpected EOF"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &OrgBackend{
+				HTTPErrorHandler: ErrorHandler(0),
+				Logger:           zap.NewNop().With(zap.String("handler", "org")),
+				AlertingPauseService: &mock.AlertingPauseService{
+					PauseAlertingF: func(ctx context.Context, orgID influxdb.ID, reason string) (*influxdb.AlertingPause, error) {
+						return &influxdb.AlertingPause{
+							OrgID:    orgID,
+							UserID:   6,
+							PausedAt: pausedAt,
+							Reason:   reason,
+							CheckIDs: []influxdb.ID{1},
+						}, nil
+					},
+				},
+			}
+			h := NewOrgHandler(b)
+
+			r := httptest.NewRequest("POST", "http://any.url/api/v2/orgs/0000000000000003/alerting/pause", bytes.NewReader([]byte(tt.body)))
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			res := w.Result()
+			body, _ := ioutil.ReadAll(res.Body)
+			if res.StatusCode != tt.statusCode {
+				t.Errorf("got status %d, want %d: %s", res.StatusCode, tt.statusCode, body)
+			}
+			if eq, diff, _ := jsonEqual(string(body), tt.want); !eq {
+				t.Errorf("handlePostAlertingPause() = ***%s***", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package http
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// quotaWarningsTotal counts the quota warnings in the responses, by resource type.
+var quotaWarningsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "influxdb",
+	Subsystem: "alerting",
+	Name:      "quota_warnings_total",
+	Help:      "Number of the warnings of the alerting quotas in the responses",
+}, []string{"resource"})
+
+// findQuotaWarnings returns the warnings of the alerting quota of a resource
+// type of an organization, to set in a response, and counts them. A failure
+// to find the warnings doesn't fail the request, so it is only logged.
+func findQuotaWarnings(ctx context.Context, s influxdb.AlertingQuotaService, log *zap.Logger, orgID influxdb.ID, rt influxdb.ResourceType) []influxdb.QuotaWarning {
+	if s == nil {
+		return nil
+	}
+	ws, err := s.FindAlertingQuotaWarnings(ctx, orgID, rt)
+	if err != nil {
+		log.Info("failed to find alerting quota warnings", zap.String("orgID", orgID.String()), zap.Error(err))
+		return nil
+	}
+	for _, w := range ws {
+		quotaWarningsTotal.WithLabelValues(string(w.Resource)).Inc()
+	}
+	return ws
+}
+
+// findFilterQuotaWarnings returns the warnings of the alerting quota of a
+// resource type of the organization a list is filtered by, by id or by name.
+// The lists of every organization have none.
+func findFilterQuotaWarnings(ctx context.Context, s influxdb.AlertingQuotaService, orgs influxdb.OrganizationService, log *zap.Logger, orgID *influxdb.ID, org *string, rt influxdb.ResourceType) []influxdb.QuotaWarning {
+	if s == nil {
+		return nil
+	}
+	if orgID == nil && org != nil && orgs != nil {
+		o, err := orgs.FindOrganization(ctx, influxdb.OrganizationFilter{Name: org})
+		if err != nil {
+			log.Info("failed to find the organization of the alerting quota warnings", zap.String("org", *org), zap.Error(err))
+			return nil
+		}
+		orgID = &o.ID
+	}
+	if orgID == nil {
+		return nil
+	}
+	return findQuotaWarnings(ctx, s, log, *orgID, rt)
+}
@@ -0,0 +1,93 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/check"
+)
+
+func TestCheckHandler_handleGetChecksQuotaWarnings(t *testing.T) {
+	warning := influxdb.QuotaWarning{
+		Resource: influxdb.ChecksResourceType,
+		Used:     9,
+		Quota:    10,
+		Message:  "the organization uses 9 of its quota of 10 checks",
+	}
+	b := NewMockCheckBackend()
+	b.CheckService = &mock.CheckService{
+		FindChecksF: func(ctx context.Context, filter influxdb.CheckFilter, opts ...influxdb.FindOptions) ([]influxdb.Check, int, error) {
+			return []influxdb.Check{
+				&check.Deadman{
+					Base: check.Base{
+						ID:     influxdb.ID(1),
+						OrgID:  influxdb.ID(2),
+						Name:   "heartbeat",
+						Status: influxdb.Active,
+						Every:  influxdb.Duration{Duration: time.Minute},
+						Query:  influxdb.DashboardQuery{Text: `from(bucket: "telegraf") |> range(start: -5m)`},
+					},
+					TimeSince: 90,
+					Level:     notification.Critical,
+				},
+			}, 1, nil
+		},
+	}
+	b.AlertingQuotaService = &mock.AlertingQuotaService{
+		FindAlertingQuotaWarningsF: func(ctx context.Context, orgID influxdb.ID, rts ...influxdb.ResourceType) ([]influxdb.QuotaWarning, error) {
+			if orgID != influxdb.ID(2) || len(rts) != 1 || rts[0] != influxdb.ChecksResourceType {
+				t.Errorf("expected the warnings of the checks of org 2, got org %s and %v", orgID, rts)
+			}
+			return []influxdb.QuotaWarning{warning}, nil
+		},
+	}
+	b.OrganizationService = &mock.OrganizationService{
+		FindOrganizationF: func(ctx context.Context, filter influxdb.OrganizationFilter) (*influxdb.Organization, error) {
+			return &influxdb.Organization{ID: influxdb.ID(2), Name: *filter.Name}, nil
+		},
+	}
+	h := NewCheckHandler(b)
+
+	tests := []struct {
+		name string
+		path string
+		want []influxdb.QuotaWarning
+	}{
+		{
+			name: "checks of an org",
+			path: "/api/v2/checks?orgID=0000000000000002",
+			want: []influxdb.QuotaWarning{warning},
+		},
+		{
+			name: "checks of an org by name",
+			path: "/api/v2/checks?org=theorg",
+			want: []influxdb.QuotaWarning{warning},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest("GET", tt.path, nil))
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+			}
+			var got struct {
+				Warnings []influxdb.QuotaWarning `json:"warnings"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got.Warnings); diff != "" {
+				t.Errorf("unexpected warnings -want/+got\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/influxdata/influxdb"
+	"go.uber.org/zap"
+)
+
+type alertingSettingsLinks struct {
+	Self string `json:"self"`
+	Logs string `json:"logs"`
+	Org  string `json:"org"`
+}
+
+type alertingSettingsResponse struct {
+	*influxdb.AlertingSettings
+	Links alertingSettingsLinks `json:"links"`
+}
+
+func newAlertingSettingsResponse(s *influxdb.AlertingSettings) *alertingSettingsResponse {
+	return &alertingSettingsResponse{
+		AlertingSettings: s,
+		Links: alertingSettingsLinks{
+			Self: fmt.Sprintf("/api/v2/orgs/%s/alerting/settings", s.OrgID),
+			Logs: fmt.Sprintf("/api/v2/orgs/%s/alerting/settings/logs", s.OrgID),
+			Org:  fmt.Sprintf("/api/v2/orgs/%s", s.OrgID),
+		},
+	}
+}
+
+// handleGetAlertingSettings is the HTTP handler for the GET /api/v2/orgs/:id/alerting/settings route.
+func (h *OrgHandler) handleGetAlertingSettings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "alerting settings retrieve request", r)
+	req, err := decodeGetOrgRequest(ctx, r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	s, err := h.AlertingSettingsService.FindAlertingSettings(ctx, req.OrgID)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	debugResult(h.Logger, "alerting settings retrieved", "alertingSettings", s)
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newAlertingSettingsResponse(s)); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+func decodePutAlertingSettingsRequest(ctx context.Context, r *http.Request) (*influxdb.AlertingSettings, error) {
+	req, err := decodeGetOrgRequest(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &influxdb.AlertingSettings{}
+	if err := json.NewDecoder(r.Body).Decode(s); err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  err.Error(),
+		}
+	}
+	s.OrgID = req.OrgID
+	if err := s.Valid(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// handlePutAlertingSettings is the HTTP handler for the PUT /api/v2/orgs/:id/alerting/settings route.
+func (h *OrgHandler) handlePutAlertingSettings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "alerting settings replace request", r)
+	s, err := decodePutAlertingSettingsRequest(ctx, r)
+	if err != nil {
+		h.Logger.Debug("failed to decode request", zap.Error(err))
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	if err := h.AlertingSettingsService.PutAlertingSettings(ctx, s); err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	debugResult(h.Logger, "alerting settings replaced", "alertingSettings", s)
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newAlertingSettingsResponse(s)); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+// handleDeleteAlertingSettings is the HTTP handler for the DELETE /api/v2/orgs/:id/alerting/settings route.
+func (h *OrgHandler) handleDeleteAlertingSettings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "alerting settings delete request", r)
+	req, err := decodeGetOrgRequest(ctx, r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	if err := h.AlertingSettingsService.DeleteAlertingSettings(ctx, req.OrgID); err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	h.Logger.Debug("alerting settings deleted", zap.Stringer("orgID", req.OrgID))
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleGetAlertingSettingsLog is the HTTP handler for the GET /api/v2/orgs/:id/alerting/settings/logs route.
+func (h *OrgHandler) handleGetAlertingSettingsLog(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "alerting settings log retrieve request", r)
+	req, err := decodeGetOrganizationLogRequest(ctx, r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	log, _, err := h.AlertingSettingsService.GetAlertingSettingsOperationLog(ctx, req.OrganizationID, req.opts)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	debugResult(h.Logger, "alerting settings logs retrieved", "log", log)
+
+	res := newOrganizationLogResponse(req.OrganizationID, log)
+	res.Links["self"] = fmt.Sprintf("/api/v2/orgs/%s/alerting/settings/logs", req.OrganizationID)
+	if err := encodeResponse(ctx, w, http.StatusOK, res); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
@@ -0,0 +1,78 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
+	"go.uber.org/zap"
+)
+
+func TestOrgHandler_handlePutAlertingSettings(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		statusCode int
+		want       string
+	}{
+		{
+			name:       "replace the alerting settings of an org",
+			body:       `{"orgID":"0000000000000007","defaultEndpointID":"0000000000000001","defaultEvery":"5m","statusBucket":"statuses","quietHours":{"start":"22:00","end":"07:00","action":"suppress"},"dedupWindow":"1h"}`,
+			statusCode: 200,
+			want: `{
+  "orgID": "0000000000000003",
+  "defaultEndpointID": "0000000000000001",
+  "defaultEvery": "5m0s",
+  "defaultOffset": "0s",
+  "statusBucket": "statuses",
+  "quietHours": {"start": "22:00", "end": "07:00", "action": "suppress"},
+  "dedupWindow": "1h0m0s",
+  "createdAt": "0001-01-01T00:00:00Z",
+  "updatedAt": "0001-01-01T00:00:00Z",
+  "links": {
+    "self": "/api/v2/orgs/0000000000000003/alerting/settings",
+    "logs": "/api/v2/orgs/0000000000000003/alerting/settings/logs",
+    "org": "/api/v2/orgs/0000000000000003"
+  }
+}`,
+		},
+		{
+			name:       "negative dedup window",
+			body:       `{"dedupWindow":"-1h"}`,
+			statusCode: 400,
+			want:       `{"code":"invalid","message":"alerting settings durations can't be negative"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &OrgBackend{
+				HTTPErrorHandler: ErrorHandler(0),
+				Logger:           zap.NewNop().With(zap.String("handler", "org")),
+				AlertingSettingsService: &mock.AlertingSettingsService{
+					PutAlertingSettingsF: func(ctx context.Context, s *influxdb.AlertingSettings) error {
+						return nil
+					},
+				},
+			}
+			h := NewOrgHandler(b)
+
+			r := httptest.NewRequest("PUT", "http://any.url/api/v2/orgs/0000000000000003/alerting/settings", bytes.NewReader([]byte(tt.body)))
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			res := w.Result()
+			body, _ := ioutil.ReadAll(res.Body)
+			if res.StatusCode != tt.statusCode {
+				t.Errorf("got status %d, want %d: %s", res.StatusCode, tt.statusCode, body)
+			}
+			if eq, diff, _ := jsonEqual(string(body), tt.want); !eq {
+				t.Errorf("handlePutAlertingSettings() = ***%s***", diff)
+			}
+		})
+	}
+}
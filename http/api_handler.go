@@ -18,26 +18,38 @@ import (
 // APIHandler is a collection of all the service handlers.
 type APIHandler struct {
 	influxdb.HTTPErrorHandler
-	BucketHandler           *BucketHandler
-	UserHandler             *UserHandler
-	OrgHandler              *OrgHandler
-	AuthorizationHandler    *AuthorizationHandler
-	DashboardHandler        *DashboardHandler
-	LabelHandler            *LabelHandler
-	AssetHandler            *AssetHandler
-	ChronografHandler       *ChronografHandler
-	ScraperHandler          *ScraperHandler
-	SourceHandler           *SourceHandler
-	VariableHandler         *VariableHandler
-	TaskHandler             *TaskHandler
-	TelegrafHandler         *TelegrafHandler
-	QueryHandler            *FluxHandler
-	WriteHandler            *WriteHandler
-	DocumentHandler         *DocumentHandler
-	SetupHandler            *SetupHandler
-	SessionHandler          *SessionHandler
-	SwaggerHandler          http.Handler
-	NotificationRuleHandler *NotificationRuleHandler
+	BucketHandler               *BucketHandler
+	UserHandler                 *UserHandler
+	OrgHandler                  *OrgHandler
+	AuthorizationHandler        *AuthorizationHandler
+	DashboardHandler            *DashboardHandler
+	LabelHandler                *LabelHandler
+	AssetHandler                *AssetHandler
+	ChronografHandler           *ChronografHandler
+	ScraperHandler              *ScraperHandler
+	SourceHandler               *SourceHandler
+	VariableHandler             *VariableHandler
+	TaskHandler                 *TaskHandler
+	TelegrafHandler             *TelegrafHandler
+	QueryHandler                *FluxHandler
+	WriteHandler                *WriteHandler
+	DocumentHandler             *DocumentHandler
+	SetupHandler                *SetupHandler
+	SessionHandler              *SessionHandler
+	SwaggerHandler              http.Handler
+	NotificationRuleHandler     *NotificationRuleHandler
+	NotificationEndpointHandler *NotificationEndpointHandler
+	NotificationTemplateHandler *NotificationTemplateHandler
+	SilenceHandler              *SilenceHandler
+	MonitoringTemplateHandler   *MonitoringTemplateHandler
+	CheckHandler                *CheckHandler
+	StatusHandler               *StatusHandler
+	UsageHandler                *UsageHandler
+	// AlertingCORS is the CORS config of the routes of checks and notifications.
+	AlertingCORS CORSConfig
+	// AlertingCache is the caching config of the routes of checks and
+	// notifications.
+	AlertingCache CacheConfig
 }
 
 // APIBackend is all services and associated parameters required to construct
@@ -47,6 +59,14 @@ type APIBackend struct {
 	Logger     *zap.Logger
 	influxdb.HTTPErrorHandler
 	SessionRenewDisabled bool
+	// AlertingCORS is the CORS config of the routes of checks and notifications.
+	AlertingCORS CORSConfig
+	// AlertingCache is the caching config of the routes of checks and
+	// notifications.
+	AlertingCache CacheConfig
+	// CheckViewerRedactionDisabled returns the checks with their query logic
+	// to the users allowed to read them but not to write them.
+	CheckViewerRedactionDisabled bool
 
 	NewBucketService func(*influxdb.Source) (influxdb.BucketService, error)
 	NewQueryService  func(*influxdb.Source) (query.ProxyQueryService, error)
@@ -82,6 +102,40 @@ type APIBackend struct {
 	OrgLookupService                authorizer.OrganizationService
 	DocumentService                 influxdb.DocumentService
 	NotificationRuleStore           influxdb.NotificationRuleStore
+	NotificationRuleTestService     influxdb.NotificationRuleTestService
+	NotificationEndpointService     influxdb.NotificationEndpointService
+	NotificationTemplateService     influxdb.NotificationTemplateService
+	NotificationPreferencesService  influxdb.NotificationPreferencesService
+	NotificationBudgetService       influxdb.NotificationBudgetService
+	NotificationEndpointCascader    influxdb.NotificationEndpointCascader
+	NotificationEndpointReassigner  influxdb.NotificationEndpointReassigner
+	SilenceService                  influxdb.SilenceService
+	CheckService                    influxdb.CheckService
+	AlertingDiagnosticsService      influxdb.AlertingDiagnosticsService
+	CheckCoverageService            influxdb.CheckCoverageService
+	AlertingSettingsService         influxdb.AlertingSettingsService
+	AlertingPauseService            influxdb.AlertingPauseService
+	CheckTransferService            influxdb.CheckTransferService
+	CheckCloneService               influxdb.CheckCloneService
+	CheckImportService              influxdb.CheckImportService
+	CheckBulkUpdateService          influxdb.CheckBulkUpdateService
+	CheckBulkCreateService          influxdb.CheckBulkCreateService
+	CheckTaskReconciler             influxdb.CheckTaskReconciler
+	CheckGCService                  influxdb.CheckGCService
+	StatusTraceService              influxdb.StatusTraceService
+	StatusInjectionService          influxdb.StatusInjectionService
+	ExternalStatusService           influxdb.ExternalStatusService
+	CheckPingService                influxdb.CheckPingService
+	CheckPreviewService             influxdb.CheckPreviewService
+	CheckRelatedService             influxdb.CheckRelatedService
+	CheckStatusService              influxdb.CheckStatusService
+	CheckLagService                 influxdb.CheckLagService
+	CheckWatchService               influxdb.CheckWatchService
+	CheckApplyService               influxdb.CheckApplyService
+	AlertingUsageService            influxdb.AlertingUsageService
+	AlertingQuotaService            influxdb.AlertingQuotaService
+	LabelBulkService                influxdb.LabelBulkService
+	MonitoringTemplateService       influxdb.MonitoringTemplateService
 }
 
 // PrometheusCollectors exposes the prometheus collectors associated with an APIBackend.
@@ -96,6 +150,8 @@ func (b *APIBackend) PrometheusCollectors() []prometheus.Collector {
 		cs = append(cs, pc.PrometheusCollectors()...)
 	}
 
+	cs = append(cs, quotaWarningsTotal)
+
 	return cs
 }
 
@@ -103,6 +159,8 @@ func (b *APIBackend) PrometheusCollectors() []prometheus.Collector {
 func NewAPIHandler(b *APIBackend) *APIHandler {
 	h := &APIHandler{
 		HTTPErrorHandler: b.HTTPErrorHandler,
+		AlertingCORS:     b.AlertingCORS,
+		AlertingCache:    b.AlertingCache,
 	}
 
 	internalURM := b.UserResourceMappingService
@@ -120,10 +178,16 @@ func NewAPIHandler(b *APIBackend) *APIHandler {
 
 	orgBackend := NewOrgBackend(b)
 	orgBackend.OrganizationService = authorizer.NewOrgService(b.OrganizationService)
+	orgBackend.AlertingDiagnosticsService = authorizer.NewAlertingDiagnosticsService(b.AlertingDiagnosticsService)
+	orgBackend.CheckImportService = authorizer.NewCheckImportService(b.CheckImportService)
+	orgBackend.CheckCoverageService = authorizer.NewCheckCoverageService(b.CheckCoverageService)
+	orgBackend.AlertingSettingsService = authorizer.NewAlertingSettingsService(b.AlertingSettingsService)
+	orgBackend.AlertingPauseService = authorizer.NewAlertingPauseService(b.AlertingPauseService)
 	h.OrgHandler = NewOrgHandler(orgBackend)
 
 	userBackend := NewUserBackend(b)
 	userBackend.UserService = authorizer.NewUserService(b.UserService)
+	userBackend.NotificationPreferencesService = authorizer.NewNotificationPreferencesService(b.NotificationPreferencesService)
 	h.UserHandler = NewUserHandler(userBackend)
 
 	dashboardBackend := NewDashboardBackend(b)
@@ -163,8 +227,62 @@ func NewAPIHandler(b *APIBackend) *APIHandler {
 	notificationRuleBackend := NewNotificationRuleBackend(b)
 	notificationRuleBackend.NotificationRuleStore = authorizer.NewNotificationRuleStore(b.NotificationRuleStore,
 		b.UserResourceMappingService, b.OrganizationService)
+	notificationRuleBackend.NotificationRuleTestService = authorizer.NewNotificationRuleTestService(b.NotificationRuleTestService, b.NotificationRuleStore)
+	notificationRuleBackend.AlertingQuotaService = authorizer.NewAlertingQuotaService(b.AlertingQuotaService)
 	h.NotificationRuleHandler = NewNotificationRuleHandler(notificationRuleBackend)
 
+	notificationEndpointBackend := NewNotificationEndpointBackend(b)
+	notificationEndpointBackend.NotificationEndpointService = authorizer.NewNotificationEndpointService(b.NotificationEndpointService,
+		b.UserResourceMappingService, b.OrganizationService)
+	notificationEndpointBackend.NotificationBudgetService = authorizer.NewNotificationBudgetService(b.NotificationBudgetService,
+		b.NotificationEndpointService)
+	notificationEndpointBackend.NotificationEndpointCascader = authorizer.NewNotificationEndpointCascader(b.NotificationEndpointCascader,
+		b.NotificationEndpointService)
+	notificationEndpointBackend.NotificationEndpointReassigner = authorizer.NewNotificationEndpointReassigner(b.NotificationEndpointReassigner,
+		b.NotificationEndpointService)
+	notificationEndpointBackend.AlertingQuotaService = authorizer.NewAlertingQuotaService(b.AlertingQuotaService)
+	h.NotificationEndpointHandler = NewNotificationEndpointHandler(notificationEndpointBackend)
+
+	notificationTemplateBackend := NewNotificationTemplateBackend(b)
+	notificationTemplateBackend.NotificationTemplateService = authorizer.NewNotificationTemplateService(b.NotificationTemplateService)
+	h.NotificationTemplateHandler = NewNotificationTemplateHandler(notificationTemplateBackend)
+
+	silenceBackend := NewSilenceBackend(b)
+	silenceBackend.SilenceService = authorizer.NewSilenceService(b.SilenceService)
+	h.SilenceHandler = NewSilenceHandler(silenceBackend)
+
+	monitoringTemplateBackend := NewMonitoringTemplateBackend(b)
+	monitoringTemplateBackend.MonitoringTemplateService = authorizer.NewMonitoringTemplateService(b.MonitoringTemplateService)
+	h.MonitoringTemplateHandler = NewMonitoringTemplateHandler(monitoringTemplateBackend)
+
+	checkBackend := NewCheckBackend(b)
+	checkBackend.CheckService = authorizer.NewCheckService(b.CheckService)
+	checkBackend.CheckTransferService = authorizer.NewCheckTransferService(b.CheckTransferService)
+	checkBackend.CheckCloneService = authorizer.NewCheckCloneService(b.CheckCloneService, b.CheckService, b.LabelService)
+	checkBackend.CheckBulkUpdateService = authorizer.NewCheckBulkUpdateService(b.CheckBulkUpdateService)
+	checkBackend.CheckBulkCreateService = authorizer.NewCheckBulkCreateService(b.CheckBulkCreateService)
+	checkBackend.CheckTaskReconciler = authorizer.NewCheckTaskReconciler(b.CheckTaskReconciler)
+	checkBackend.CheckGCService = authorizer.NewCheckGCService(b.CheckGCService)
+	checkBackend.ExternalStatusService = authorizer.NewExternalStatusService(b.ExternalStatusService, b.CheckService)
+	checkBackend.CheckPingService = authorizer.NewCheckPingService(b.CheckPingService, b.CheckService)
+	checkBackend.CheckPreviewService = authorizer.NewCheckPreviewService(b.CheckPreviewService, b.BucketService)
+	checkBackend.CheckRelatedService = authorizer.NewCheckRelatedService(b.CheckRelatedService)
+	checkBackend.CheckStatusService = authorizer.NewCheckStatusService(b.CheckStatusService)
+	checkBackend.CheckLagService = authorizer.NewCheckLagService(b.CheckLagService)
+	checkBackend.CheckWatchService = authorizer.NewCheckWatchService(b.CheckWatchService)
+	checkBackend.CheckApplyService = authorizer.NewCheckApplyService(b.CheckApplyService)
+	checkBackend.AlertingQuotaService = authorizer.NewAlertingQuotaService(b.AlertingQuotaService)
+	h.CheckHandler = NewCheckHandler(checkBackend)
+
+	statusBackend := NewStatusBackend(b)
+	statusBackend.StatusTraceService = authorizer.NewStatusTraceService(b.StatusTraceService)
+	statusBackend.StatusInjectionService = authorizer.NewStatusInjectionService(b.StatusInjectionService, b.CheckService)
+	h.StatusHandler = NewStatusHandler(statusBackend)
+
+	h.UsageHandler = NewUsageHandler(b.HTTPErrorHandler)
+	h.UsageHandler.Logger = b.Logger.With(zap.String("handler", "usage"))
+	h.UsageHandler.AlertingUsageService = authorizer.NewAlertingUsageService(b.AlertingUsageService)
+
 	writeBackend := NewWriteBackend(b)
 	h.WriteHandler = NewWriteHandler(writeBackend)
 
@@ -174,6 +292,7 @@ func NewAPIHandler(b *APIBackend) *APIHandler {
 	h.ChronografHandler = NewChronografHandler(b.ChronografService, b.HTTPErrorHandler)
 	h.SwaggerHandler = newSwaggerLoader(b.Logger.With(zap.String("service", "swagger-loader")), b.HTTPErrorHandler)
 	h.LabelHandler = NewLabelHandler(authorizer.NewLabelService(b.LabelService), b.HTTPErrorHandler)
+	h.LabelHandler.LabelBulkService = authorizer.NewLabelBulkService(b.LabelBulkService, b.LabelService)
 
 	return h
 }
@@ -183,15 +302,29 @@ var apiLinks = map[string]interface{}{
 	// as this makes it easier to verify values against the swagger document.
 	"authorizations": "/api/v2/authorizations",
 	"buckets":        "/api/v2/buckets",
-	"dashboards":     "/api/v2/dashboards",
+	"checks": map[string]string{
+		"self":             "/api/v2/checks",
+		"bulk":             "/api/v2/checks/bulk",
+		"bulkUpdate":       "/api/v2/checks/bulk-update",
+		"exportPrometheus": "/api/v2/checks/export/prometheus",
+		"lag":              "/api/v2/checks/lag",
+		"preview":          "/api/v2/checks/preview",
+		"reconciliation":   "/api/v2/checks/reconciliation",
+		"statuses":         "/api/v2/checks/statuses",
+		"watch":            "/api/v2/checks/watch",
+	},
+	"dashboards": "/api/v2/dashboards",
 	"external": map[string]string{
 		"statusFeed": "https://www.influxdata.com/feed/json",
 	},
-	"labels":            "/api/v2/labels",
-	"variables":         "/api/v2/variables",
-	"me":                "/api/v2/me",
-	"notificationRules": "/api/v2/notificationRules",
-	"orgs":              "/api/v2/orgs",
+	"labels":                "/api/v2/labels",
+	"variables":             "/api/v2/variables",
+	"me":                    "/api/v2/me",
+	"monitoringTemplates":   "/api/v2/monitoringTemplates",
+	"notificationEndpoints": "/api/v2/notificationEndpoints",
+	"notificationRules":     "/api/v2/notificationRules",
+	"notificationTemplates": "/api/v2/notificationTemplates",
+	"orgs":                  "/api/v2/orgs",
 	"query": map[string]string{
 		"self":        "/api/v2/query",
 		"ast":         "/api/v2/query/ast",
@@ -201,6 +334,7 @@ var apiLinks = map[string]interface{}{
 	"setup":    "/api/v2/setup",
 	"signin":   "/api/v2/signin",
 	"signout":  "/api/v2/signout",
+	"silences": "/api/v2/silences",
 	"sources":  "/api/v2/sources",
 	"scrapers": "/api/v2/scrapers",
 	"swagger":  "/api/v2/swagger.json",
@@ -211,8 +345,11 @@ var apiLinks = map[string]interface{}{
 	},
 	"tasks":     "/api/v2/tasks",
 	"telegrafs": "/api/v2/telegrafs",
-	"users":     "/api/v2/users",
-	"write":     "/api/v2/write",
+	"templates": map[string]string{
+		"install": "/api/v2/templates/install",
+	},
+	"users": "/api/v2/users",
+	"write": "/api/v2/write",
 }
 
 func (h *APIHandler) serveLinks(w http.ResponseWriter, r *http.Request) {
@@ -224,10 +361,11 @@ func (h *APIHandler) serveLinks(w http.ResponseWriter, r *http.Request) {
 
 // ServeHTTP delegates a request to the appropriate subhandler.
 func (h *APIHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	setCORSResponseHeaders(w, r)
+	setCORSResponseHeaders(w, r, h.AlertingCORS)
 	if r.Method == "OPTIONS" {
 		return
 	}
+	w = withCacheControl(w, r, h.AlertingCache)
 
 	// Serve the links base links for the API.
 	if r.URL.Path == "/api/v2/" || r.URL.Path == "/api/v2" {
@@ -315,6 +453,42 @@ func (h *APIHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if strings.HasPrefix(r.URL.Path, "/api/v2/notificationEndpoints") {
+		h.NotificationEndpointHandler.ServeHTTP(w, r)
+		return
+	}
+
+	if strings.HasPrefix(r.URL.Path, "/api/v2/notificationTemplates") {
+		h.NotificationTemplateHandler.ServeHTTP(w, r)
+		return
+	}
+
+	if strings.HasPrefix(r.URL.Path, "/api/v2/silences") {
+		h.SilenceHandler.ServeHTTP(w, r)
+		return
+	}
+
+	if strings.HasPrefix(r.URL.Path, "/api/v2/monitoringTemplates") || strings.HasPrefix(r.URL.Path, "/api/v2/templates") {
+		h.MonitoringTemplateHandler.ServeHTTP(w, r)
+		return
+	}
+
+	if strings.HasPrefix(r.URL.Path, "/api/v2/checks") {
+		h.CheckHandler.ServeHTTP(w, r)
+		return
+	}
+
+	if strings.HasPrefix(r.URL.Path, "/api/v2/statuses") {
+		h.StatusHandler.ServeHTTP(w, r)
+		return
+	}
+
+	// only the usage of alerting is served, the server has no usage service.
+	if strings.HasPrefix(r.URL.Path, "/api/v2/usage/alerting") {
+		h.UsageHandler.ServeHTTP(w, r)
+		return
+	}
+
 	if strings.HasPrefix(r.URL.Path, "/api/v2/variables") {
 		h.VariableHandler.ServeHTTP(w, r)
 		return
@@ -1,6 +1,7 @@
 package http
 
 import (
+	"encoding/json"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
@@ -75,3 +76,48 @@ func TestAPIHandler_NotFound(t *testing.T) {
 		})
 	}
 }
+
+func TestAPIHandler_links(t *testing.T) {
+	b := &APIBackend{
+		HTTPErrorHandler: ErrorHandler(0),
+	}
+	b.Logger = zap.NewNop()
+	h := NewAPIHandler(b)
+
+	r := httptest.NewRequest("GET", "/api/v2", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var links struct {
+		Checks struct {
+			Self     string `json:"self"`
+			Statuses string `json:"statuses"`
+			Watch    string `json:"watch"`
+		} `json:"checks"`
+		NotificationEndpoints string `json:"notificationEndpoints"`
+		NotificationRules     string `json:"notificationRules"`
+		Silences              string `json:"silences"`
+		Templates             struct {
+			Install string `json:"install"`
+		} `json:"templates"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &links); err != nil {
+		t.Fatalf("failed to decode links: %v", err)
+	}
+	for want, got := range map[string]string{
+		checksPath:                links.Checks.Self,
+		checksStatusesPath:        links.Checks.Statuses,
+		checksWatchPath:           links.Checks.Watch,
+		notificationEndpointsPath: links.NotificationEndpoints,
+		notificationRulesPath:     links.NotificationRules,
+		silencesPath:              links.Silences,
+		templatesInstallPath:      links.Templates.Install,
+	} {
+		if got != want {
+			t.Errorf("got link %q, want %q", got, want)
+		}
+	}
+}
@@ -186,7 +186,7 @@ func newAuthsResponse(as []*authResponse) *authsResponse {
 func (h *AuthorizationHandler) handlePostAuthorization(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	h.Logger.Debug("create auth request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "create auth request", r)
 
 	req, err := decodePostAuthorizationRequest(ctx, r)
 	if err != nil {
@@ -224,7 +224,7 @@ func (h *AuthorizationHandler) handlePostAuthorization(w http.ResponseWriter, r
 		return
 	}
 
-	h.Logger.Debug("auth created ", zap.String("auth", fmt.Sprint(auth)))
+	h.Logger.Debug("auth created", zap.Stringer("authID", auth.ID))
 
 	if err := encodeResponse(ctx, w, http.StatusCreated, newAuthResponse(auth, org, user, perms)); err != nil {
 		logEncodingError(h.Logger, r, err)
@@ -327,7 +327,7 @@ func decodePostAuthorizationRequest(ctx context.Context, r *http.Request) (*post
 // handleGetAuthorizations is the HTTP handler for the GET /api/v2/authorizations route.
 func (h *AuthorizationHandler) handleGetAuthorizations(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.Logger.Debug("get auths request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "get auths request", r)
 
 	req, err := decodeGetAuthorizationsRequest(ctx, r)
 	if err != nil {
@@ -366,7 +366,7 @@ func (h *AuthorizationHandler) handleGetAuthorizations(w http.ResponseWriter, r
 		auths = append(auths, newAuthResponse(a, o, u, ps))
 	}
 
-	h.Logger.Debug("auths retrieved ", zap.String("auths", fmt.Sprint(auths)))
+	debugResult(h.Logger, "auths retrieved ", "auths", auths)
 
 	if err := encodeResponse(ctx, w, http.StatusOK, newAuthsResponse(auths)); err != nil {
 		h.HandleHTTPError(ctx, err, w)
@@ -427,7 +427,7 @@ func decodeGetAuthorizationsRequest(ctx context.Context, r *http.Request) (*getA
 func (h *AuthorizationHandler) handleGetAuthorization(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	h.Logger.Debug("get auth request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "get auth request", r)
 	req, err := decodeGetAuthorizationRequest(ctx, r)
 	if err != nil {
 		h.Logger.Info("failed to decode request", zap.String("handler", "getAuthorization"), zap.Error(err))
@@ -460,7 +460,7 @@ func (h *AuthorizationHandler) handleGetAuthorization(w http.ResponseWriter, r *
 		return
 	}
 
-	h.Logger.Debug("auth retrieved ", zap.String("auth", fmt.Sprint(a)))
+	debugResult(h.Logger, "auth retrieved ", "auth", a)
 
 	if err := encodeResponse(ctx, w, http.StatusOK, newAuthResponse(a, o, u, ps)); err != nil {
 		h.HandleHTTPError(ctx, err, w)
@@ -496,7 +496,7 @@ func decodeGetAuthorizationRequest(ctx context.Context, r *http.Request) (*getAu
 func (h *AuthorizationHandler) handleUpdateAuthorization(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	h.Logger.Debug("update auth request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "update auth request", r)
 	req, err := decodeUpdateAuthorizationRequest(ctx, r)
 	if err != nil {
 		h.Logger.Info("failed to decode request", zap.String("handler", "updateAuthorization"), zap.Error(err))
@@ -533,7 +533,7 @@ func (h *AuthorizationHandler) handleUpdateAuthorization(w http.ResponseWriter,
 		h.HandleHTTPError(ctx, err, w)
 		return
 	}
-	h.Logger.Debug("auth updated", zap.String("auth", fmt.Sprint(a)))
+	debugResult(h.Logger, "auth updated", "auth", a)
 
 	if err := encodeResponse(ctx, w, http.StatusOK, newAuthResponse(a, o, u, ps)); err != nil {
 		h.HandleHTTPError(ctx, err, w)
@@ -576,7 +576,7 @@ func decodeUpdateAuthorizationRequest(ctx context.Context, r *http.Request) (*up
 func (h *AuthorizationHandler) handleDeleteAuthorization(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	h.Logger.Debug("delete auth request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "delete auth request", r)
 
 	req, err := decodeDeleteAuthorizationRequest(ctx, r)
 	if err != nil {
@@ -591,7 +591,7 @@ func (h *AuthorizationHandler) handleDeleteAuthorization(w http.ResponseWriter,
 		return
 	}
 
-	h.Logger.Debug("auth deleted", zap.String("authID", fmt.Sprint(req.ID)))
+	h.Logger.Debug("auth deleted", zap.Stringer("authID", req.ID))
 
 	w.WriteHeader(http.StatusNoContent)
 }
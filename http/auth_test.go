@@ -559,6 +559,8 @@ func TestService_handlePostAuthorization(t *testing.T) {
 	type args struct {
 		session       *platform.Authorization
 		authorization *platform.Authorization
+		// body is sent instead of the authorization when set.
+		body string
 	}
 	type wants struct {
 		statusCode  int
@@ -676,6 +678,33 @@ func TestService_handlePostAuthorization(t *testing.T) {
   "user": "u1",
   "userID": "aaaaaaaaaaaaaaaa"
 }
+`,
+			},
+		},
+		{
+			name: "create an authorization to create buckets",
+			args: args{
+				session: &platform.Authorization{
+					Token:  "session-token",
+					ID:     platformtesting.MustIDBase16("020f755c3c082000"),
+					UserID: platformtesting.MustIDBase16("aaaaaaaaaaaaaaaa"),
+					OrgID:  platformtesting.MustIDBase16("020f755c3c083000"),
+				},
+				body: `{"orgID":"020f755c3c083000","permissions":[{"action":"create","resource":{"type":"buckets","orgID":"020f755c3c083000"}}]}`,
+			},
+			wants: wants{
+				statusCode:  http.StatusBadRequest,
+				contentType: "application/json; charset=utf-8",
+				body: `
+{
+  "code": "invalid",
+  "message": "create action is not supported by buckets permissions",
+  "error": {
+    "code": "invalid",
+    "message": "create action is not supported by buckets permissions",
+    "error": "unknown action for permission"
+  }
+}
 `,
 			},
 		},
@@ -691,13 +720,16 @@ func TestService_handlePostAuthorization(t *testing.T) {
 			authorizationBackend.LookupService = tt.fields.LookupService
 			h := NewAuthorizationHandler(authorizationBackend)
 
-			req, err := newPostAuthorizationRequest(tt.args.authorization)
-			if err != nil {
-				t.Fatalf("failed to create new authorization request: %v", err)
-			}
-			b, err := json.Marshal(req)
-			if err != nil {
-				t.Fatalf("failed to unmarshal authorization: %v", err)
+			b := []byte(tt.args.body)
+			if tt.args.authorization != nil {
+				req, err := newPostAuthorizationRequest(tt.args.authorization)
+				if err != nil {
+					t.Fatalf("failed to create new authorization request: %v", err)
+				}
+				b, err = json.Marshal(req)
+				if err != nil {
+					t.Fatalf("failed to unmarshal authorization: %v", err)
+				}
 			}
 
 			r := httptest.NewRequest("GET", "http://any.url", bytes.NewReader(b))
@@ -302,7 +302,7 @@ func newBucketsResponse(ctx context.Context, opts influxdb.FindOptions, f influx
 func (h *BucketHandler) handlePostBucket(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	h.Logger.Debug("create bucket request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "create bucket request", r)
 	req, err := decodePostBucketRequest(ctx, r)
 	if err != nil {
 		h.HandleHTTPError(ctx, err, w)
@@ -313,7 +313,7 @@ func (h *BucketHandler) handlePostBucket(w http.ResponseWriter, r *http.Request)
 		h.HandleHTTPError(ctx, err, w)
 		return
 	}
-	h.Logger.Debug("bucket created", zap.String("bucket", fmt.Sprint(req.Bucket)))
+	debugResult(h.Logger, "bucket created", "bucket", req.Bucket)
 
 	if err := encodeResponse(ctx, w, http.StatusCreated, newBucketResponse(req.Bucket, []*influxdb.Label{})); err != nil {
 		logEncodingError(h.Logger, r, err)
@@ -354,7 +354,7 @@ func decodePostBucketRequest(ctx context.Context, r *http.Request) (*postBucketR
 func (h *BucketHandler) handleGetBucket(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	h.Logger.Debug("retrieve bucket request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "retrieve bucket request", r)
 
 	req, err := decodeGetBucketRequest(ctx, r)
 	if err != nil {
@@ -374,7 +374,7 @@ func (h *BucketHandler) handleGetBucket(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	h.Logger.Debug("bucket retrieved", zap.String("bucket", fmt.Sprint(b)))
+	debugResult(h.Logger, "bucket retrieved", "bucket", b)
 
 	if err := encodeResponse(ctx, w, http.StatusOK, newBucketResponse(b, labels)); err != nil {
 		logEncodingError(h.Logger, r, err)
@@ -410,7 +410,7 @@ func decodeGetBucketRequest(ctx context.Context, r *http.Request) (*getBucketReq
 // handleDeleteBucket is the HTTP handler for the DELETE /api/v2/buckets/:id route.
 func (h *BucketHandler) handleDeleteBucket(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.Logger.Debug("delete bucket request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "delete bucket request", r)
 
 	req, err := decodeDeleteBucketRequest(ctx, r)
 	if err != nil {
@@ -459,7 +459,7 @@ func (h *BucketHandler) handleGetBuckets(w http.ResponseWriter, r *http.Request)
 	defer span.Finish()
 
 	ctx := r.Context()
-	h.Logger.Debug("retrieve buckets request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "retrieve buckets request", r)
 
 	req, err := decodeGetBucketsRequest(ctx, r)
 	if err != nil {
@@ -472,7 +472,7 @@ func (h *BucketHandler) handleGetBuckets(w http.ResponseWriter, r *http.Request)
 		h.HandleHTTPError(ctx, err, w)
 		return
 	}
-	h.Logger.Debug("buckets retrieved", zap.String("buckets", fmt.Sprint(bs)))
+	debugResult(h.Logger, "buckets retrieved", "buckets", bs)
 
 	if err := encodeResponse(ctx, w, http.StatusOK, newBucketsResponse(ctx, req.opts, req.filter, bs, h.LabelService)); err != nil {
 		logEncodingError(h.Logger, r, err)
@@ -526,7 +526,7 @@ func decodeGetBucketsRequest(ctx context.Context, r *http.Request) (*getBucketsR
 // handlePatchBucket is the HTTP handler for the PATCH /api/v2/buckets route.
 func (h *BucketHandler) handlePatchBucket(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.Logger.Debug("update bucket request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "update bucket request", r)
 
 	req, err := decodePatchBucketRequest(ctx, r)
 	if err != nil {
@@ -545,7 +545,7 @@ func (h *BucketHandler) handlePatchBucket(w http.ResponseWriter, r *http.Request
 		h.HandleHTTPError(ctx, err, w)
 		return
 	}
-	h.Logger.Debug("bucket updated", zap.String("bucket", fmt.Sprint(b)))
+	debugResult(h.Logger, "bucket updated", "bucket", b)
 
 	if err := encodeResponse(ctx, w, http.StatusOK, newBucketResponse(b, labels)); err != nil {
 		logEncodingError(h.Logger, r, err)
@@ -860,7 +860,7 @@ func bucketIDPath(id influxdb.ID) string {
 // hanldeGetBucketLog retrieves a bucket log by the buckets ID.
 func (h *BucketHandler) handleGetBucketLog(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.Logger.Debug("retrieve bucket log request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "retrieve bucket log request", r)
 
 	req, err := decodeGetBucketLogRequest(ctx, r)
 	if err != nil {
@@ -874,7 +874,7 @@ func (h *BucketHandler) handleGetBucketLog(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	h.Logger.Debug("bucket log retrived", zap.String("bucket", fmt.Sprint(log)))
+	debugResult(h.Logger, "bucket log retrived", "bucket", log)
 
 	if err := encodeResponse(ctx, w, http.StatusOK, newBucketLogResponse(req.BucketID, log)); err != nil {
 		logEncodingError(h.Logger, r, err)
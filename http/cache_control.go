@@ -0,0 +1,104 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// noStorePrefixes are the routes whose responses may bear secrets, such as
+// tokens or the secret fields of notification endpoints, which no cache
+// may store.
+var noStorePrefixes = []string{
+	"/api/v2/notificationEndpoints",
+	"/api/v2/authorizations",
+}
+
+// CacheConfig is the caching policy of the responses of a set of routes,
+// for the proxies and the browsers in front of the API.
+type CacheConfig struct {
+	// MaxAge is how long the successful responses to reads may be reused by
+	// the private cache of their client. They are revalidated when zero.
+	MaxAge time.Duration
+}
+
+// cacheControl returns the Cache-Control header of the response to a request
+// with a status code.
+func (c CacheConfig) cacheControl(r *http.Request, statusCode int) string {
+	if noStore(r.URL.Path) {
+		return "no-store"
+	}
+	if r.Method != "GET" && r.Method != "HEAD" {
+		return "no-store"
+	}
+	if statusCode < 200 || statusCode > 299 {
+		return "no-store"
+	}
+	return fmt.Sprintf("private, max-age=%d", int(c.MaxAge/time.Second))
+}
+
+// noStore returns whether the responses of the route of a path may bear secrets.
+func noStore(path string) bool {
+	for _, prefix := range noStorePrefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	// the secrets of an organization are under /api/v2/orgs/:id/secrets.
+	return strings.HasPrefix(path, "/api/v2/orgs/") && strings.Contains(path, "/secrets")
+}
+
+// cachingRoute returns whether the caching config applies to the route of a
+// path, that is the routes of checks and notifications and the ones bearing
+// secrets.
+func cachingRoute(path string) bool {
+	return alertingRoute(path) || noStore(path)
+}
+
+// cacheControlResponseWriter sets the Cache-Control header of a response, once
+// its status is known, unless the handler already set one.
+type cacheControlResponseWriter struct {
+	http.ResponseWriter
+	r      *http.Request
+	config CacheConfig
+	wrote  bool
+}
+
+func (w *cacheControlResponseWriter) WriteHeader(statusCode int) {
+	if !w.wrote {
+		w.wrote = true
+		if w.Header().Get("Cache-Control") == "" {
+			w.Header().Set("Cache-Control", w.config.cacheControl(w.r, statusCode))
+		}
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *cacheControlResponseWriter) Write(b []byte) (int, error) {
+	if !w.wrote {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush sends the buffered response to the client, for the streaming routes
+// such as the watch of the checks.
+func (w *cacheControlResponseWriter) Flush() {
+	if !w.wrote {
+		w.WriteHeader(http.StatusOK)
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// withCacheControl returns the response writer setting the Cache-Control
+// header of the response to a request, with the config for the routes of
+// checks and notifications and the ones bearing secrets.
+func withCacheControl(w http.ResponseWriter, r *http.Request, c CacheConfig) http.ResponseWriter {
+	if !cachingRoute(r.URL.Path) {
+		return w
+	}
+	return &cacheControlResponseWriter{ResponseWriter: w, r: r, config: c}
+}
@@ -0,0 +1,111 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWithCacheControl(t *testing.T) {
+	c := CacheConfig{MaxAge: 30 * time.Second}
+	tests := []struct {
+		name         string
+		method       string
+		path         string
+		status       int
+		handlerCache string
+		want         string
+	}{
+		{
+			name:   "list of checks",
+			method: "GET",
+			path:   "/api/v2/checks",
+			status: http.StatusOK,
+			want:   "private, max-age=30",
+		},
+		{
+			name:   "read of a rule",
+			method: "GET",
+			path:   "/api/v2/notificationRules/0000000000000001",
+			status: http.StatusOK,
+			want:   "private, max-age=30",
+		},
+		{
+			name:   "update of a check",
+			method: "PATCH",
+			path:   "/api/v2/checks/0000000000000001",
+			status: http.StatusOK,
+			want:   "no-store",
+		},
+		{
+			name:   "missing check",
+			method: "GET",
+			path:   "/api/v2/checks/0000000000000001",
+			status: http.StatusNotFound,
+			want:   "no-store",
+		},
+		{
+			name:   "read of an endpoint",
+			method: "GET",
+			path:   "/api/v2/notificationEndpoints/0000000000000001",
+			status: http.StatusOK,
+			want:   "no-store",
+		},
+		{
+			name:   "secrets of an organization",
+			method: "GET",
+			path:   "/api/v2/orgs/0000000000000001/secrets",
+			status: http.StatusOK,
+			want:   "no-store",
+		},
+		{
+			name:         "header set by the handler",
+			method:       "GET",
+			path:         "/api/v2/checks/statuses",
+			status:       http.StatusOK,
+			handlerCache: "private, max-age=5",
+			want:         "private, max-age=5",
+		},
+		{
+			name:   "other routes",
+			method: "GET",
+			path:   "/api/v2/buckets",
+			status: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, "http://any.url"+tt.path, nil)
+			rec := httptest.NewRecorder()
+			w := withCacheControl(rec, r, c)
+			if tt.handlerCache != "" {
+				w.Header().Set("Cache-Control", tt.handlerCache)
+			}
+			w.WriteHeader(tt.status)
+			w.Write([]byte("{}"))
+
+			if got := rec.Result().Header.Get("Cache-Control"); got != tt.want {
+				t.Errorf("got Cache-Control %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithCacheControl_Flush(t *testing.T) {
+	r := httptest.NewRequest("GET", "http://any.url/api/v2/checks/watch", nil)
+	rec := httptest.NewRecorder()
+	w := withCacheControl(rec, r, CacheConfig{MaxAge: 30 * time.Second})
+	f, ok := w.(http.Flusher)
+	if !ok {
+		t.Fatal("expected the response writer to flush")
+	}
+	f.Flush()
+	if !rec.Flushed {
+		t.Error("expected the response to be flushed")
+	}
+	if got := rec.Result().Header.Get("Cache-Control"); got != "private, max-age=30" {
+		t.Errorf("got Cache-Control %q, want %q", got, "private, max-age=30")
+	}
+}
@@ -0,0 +1,46 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/influxdata/influxdb"
+)
+
+// handlePostCheckActivate is the HTTP handler for the POST /api/v2/checks/:id/activate route.
+func (h *CheckHandler) handlePostCheckActivate(w http.ResponseWriter, r *http.Request) {
+	h.handleSetCheckStatus(w, r, influxdb.Active)
+}
+
+// handlePostCheckDeactivate is the HTTP handler for the POST /api/v2/checks/:id/deactivate route.
+// The task of the check is suspended, its configuration and history are kept.
+func (h *CheckHandler) handlePostCheckDeactivate(w http.ResponseWriter, r *http.Request) {
+	h.handleSetCheckStatus(w, r, influxdb.Inactive)
+}
+
+func (h *CheckHandler) handleSetCheckStatus(w http.ResponseWriter, r *http.Request, status influxdb.Status) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "check status request", r)
+	id, err := decodeGetCheckRequest(ctx, r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	c, err := h.CheckService.PatchCheck(ctx, id, influxdb.CheckUpdate{Status: &status})
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	labels, err := h.LabelService.FindResourceLabels(ctx, influxdb.LabelMappingFilter{ResourceID: c.GetID()})
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	debugResult(h.Logger, "check status updated", "check", c)
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newCheckResponse(c, labels)); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
@@ -0,0 +1,141 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/influxdata/influxdb"
+	pctx "github.com/influxdata/influxdb/context"
+	"go.uber.org/zap"
+)
+
+type putCheckByNameRequest struct {
+	// Org is the name of the organization of the check.
+	Org   string
+	Apply influxdb.CheckApply
+}
+
+func decodePutCheckByNameRequest(ctx context.Context, r *http.Request) (*putCheckByNameRequest, error) {
+	// the names are unescaped once split, so they may have an escaped slash.
+	parts := strings.Split(strings.TrimPrefix(r.URL.EscapedPath(), checksByNamePath), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "the path must be /api/v2/checks/byname/:org/:name",
+		}
+	}
+	org, err := url.PathUnescape(parts[0])
+	if err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "the name of the org is invalid",
+			Err:  err,
+		}
+	}
+	name, err := url.PathUnescape(parts[1])
+	if err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "the name of the check is invalid",
+			Err:  err,
+		}
+	}
+
+	qp := r.URL.Query()
+	req := &putCheckByNameRequest{
+		Org: org,
+		Apply: influxdb.CheckApply{
+			Name:         name,
+			FieldManager: qp.Get("fieldManager"),
+		},
+	}
+	if s := qp.Get("force"); s != "" {
+		if req.Apply.Force, err = strconv.ParseBool(s); err != nil {
+			return nil, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "force is invalid",
+			}
+		}
+	}
+	b, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  err.Error(),
+		}
+	}
+	if !json.Valid(b) {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "the applied configuration is invalid json",
+		}
+	}
+	req.Apply.Config = b
+	if err := req.Apply.Valid(); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// handlePutCheckByName is the HTTP handler for the PUT /api/v2/checks/byname/:org/:name route.
+func (h *CheckHandler) handlePutCheckByName(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "check apply request", r)
+	ctx, dryRun, err := decodeDryRun(ctx, r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	req, err := decodePutCheckByNameRequest(ctx, r)
+	if err != nil {
+		h.Logger.Debug("failed to decode request", zap.Error(err))
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	org, err := h.OrganizationService.FindOrganization(ctx, influxdb.OrganizationFilter{Name: &req.Org})
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	req.Apply.OrgID = org.ID
+	auth, err := pctx.GetAuthorizer(ctx)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	res, err := h.CheckApplyService.ApplyCheck(ctx, req.Apply, auth.GetUserID())
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	labels, err := h.LabelService.FindResourceLabels(ctx, influxdb.LabelMappingFilter{ResourceID: res.Check.GetID()})
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	resp := newCheckResponse(res.Check, labels)
+	resp.ManagedFields = res.ManagedFields
+	if dryRun != nil {
+		if err := encodeDryRunResponse(ctx, w, resp, dryRun); err != nil {
+			logEncodingError(h.Logger, r, err)
+		}
+		return
+	}
+	debugResult(h.Logger, "check applied", "check", res.Check, zap.Bool("created", res.Created))
+
+	code := http.StatusOK
+	if res.Created {
+		code = http.StatusCreated
+	}
+	if err := encodeResponse(ctx, w, code, resp); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
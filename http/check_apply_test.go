@@ -0,0 +1,109 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	pcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/notification/check"
+)
+
+func TestCheckHandler_handlePutCheckByName(t *testing.T) {
+	var applied influxdb.CheckApply
+	b := NewMockCheckBackend()
+	b.OrganizationService = &mock.OrganizationService{
+		FindOrganizationF: func(ctx context.Context, filter influxdb.OrganizationFilter) (*influxdb.Organization, error) {
+			if filter.Name == nil || *filter.Name != "the org" {
+				return nil, &influxdb.Error{
+					Code: influxdb.ENotFound,
+					Msg:  "organization not found",
+				}
+			}
+			return &influxdb.Organization{ID: 2, Name: *filter.Name}, nil
+		},
+	}
+	b.CheckApplyService = &mock.CheckApplyService{
+		ApplyCheckF: func(ctx context.Context, a influxdb.CheckApply, userID influxdb.ID) (*influxdb.CheckApplyResult, error) {
+			applied = a
+			if !a.Force && a.FieldManager != "operator" {
+				return nil, &influxdb.Error{
+					Code: influxdb.EConflict,
+					Msg:  "every of the check is managed by operator",
+				}
+			}
+			return &influxdb.CheckApplyResult{
+				Check: &check.Deadman{Base: check.Base{
+					ID:     1,
+					OrgID:  a.OrgID,
+					Name:   a.Name,
+					Status: influxdb.Active,
+					Every:  influxdb.Duration{Duration: time.Minute},
+				}},
+				Created:       userID == 6,
+				ManagedFields: []influxdb.CheckManagedField{{Field: "every", Manager: a.FieldManager}},
+			}, nil
+		},
+	}
+	h := NewCheckHandler(b)
+
+	put := func(target, body string, userID influxdb.ID) *httptest.ResponseRecorder {
+		r := httptest.NewRequest("PUT", target, bytes.NewBufferString(body))
+		r = r.WithContext(pcontext.SetAuthorizer(r.Context(), &influxdb.Session{UserID: userID}))
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	w := put("/api/v2/checks/byname/the%20org/cpu%2Fusage?fieldManager=operator", `{"type": "deadman", "every": "1m"}`, 6)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	if applied.OrgID != 2 || applied.Name != "cpu/usage" || applied.FieldManager != "operator" || applied.Force || string(applied.Config) != `{"type": "deadman", "every": "1m"}` {
+		t.Errorf("unexpected apply %+v", applied)
+	}
+	var res struct {
+		ID            string `json:"id"`
+		Name          string `json:"name"`
+		ManagedFields []struct {
+			Field   string `json:"field"`
+			Manager string `json:"manager"`
+		} `json:"managedFields"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&res); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if res.Name != "cpu/usage" || len(res.ManagedFields) != 1 || res.ManagedFields[0].Manager != "operator" {
+		t.Errorf("expected the check with its managed fields, got %+v", res)
+	}
+
+	if w := put("/api/v2/checks/byname/the%20org/cpu?fieldManager=operator", `{}`, 7); w.Code != http.StatusOK {
+		t.Errorf("expected the update of the check to be ok, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := put("/api/v2/checks/byname/the%20org/cpu?fieldManager=gitops&force=true", `{}`, 7); w.Code != http.StatusOK || !applied.Force {
+		t.Errorf("expected the forced apply to be ok, got %d: %s", w.Code, w.Body.String())
+	}
+
+	for _, tt := range []struct {
+		target string
+		body   string
+		want   int
+	}{
+		{"/api/v2/checks/byname/the%20org/cpu?fieldManager=gitops", `{}`, http.StatusUnprocessableEntity},
+		{"/api/v2/checks/byname/the%20org/cpu", `{}`, http.StatusBadRequest},
+		{"/api/v2/checks/byname/the%20org/cpu?fieldManager=operator&force=x", `{}`, http.StatusBadRequest},
+		{"/api/v2/checks/byname/the%20org/cpu?fieldManager=operator", `{`, http.StatusBadRequest},
+		{"/api/v2/checks/byname/the%20org?fieldManager=operator", `{}`, http.StatusBadRequest},
+		{"/api/v2/checks/byname/other/cpu?fieldManager=operator", `{}`, http.StatusNotFound},
+	} {
+		if w := put(tt.target, tt.body, 7); w.Code != tt.want {
+			t.Errorf("%s: expected status %d, got %d", tt.target, tt.want, w.Code)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package http
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/influxdata/influxdb"
+)
+
+// handlePostCheckArchive is the HTTP handler for the POST /api/v2/checks/:id/archive route.
+func (h *CheckHandler) handlePostCheckArchive(w http.ResponseWriter, r *http.Request) {
+	h.handleSetCheckArchived(w, r, h.CheckService.ArchiveCheck)
+}
+
+// handlePostCheckUnarchive is the HTTP handler for the POST /api/v2/checks/:id/unarchive route.
+func (h *CheckHandler) handlePostCheckUnarchive(w http.ResponseWriter, r *http.Request) {
+	h.handleSetCheckArchived(w, r, h.CheckService.UnarchiveCheck)
+}
+
+func (h *CheckHandler) handleSetCheckArchived(w http.ResponseWriter, r *http.Request, set func(ctx context.Context, id influxdb.ID) (influxdb.Check, error)) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "check archive request", r)
+	id, err := decodeGetCheckRequest(ctx, r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	c, err := set(ctx, id)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	labels, err := h.LabelService.FindResourceLabels(ctx, influxdb.LabelMappingFilter{ResourceID: c.GetID()})
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	debugResult(h.Logger, "check archived state updated", "check", c)
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newCheckResponse(c, labels)); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
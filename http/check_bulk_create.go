@@ -0,0 +1,110 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/alerting/validate"
+	pctx "github.com/influxdata/influxdb/context"
+	"go.uber.org/zap"
+)
+
+type checksBulkCreateResponse struct {
+	Checks []*checkResponse `json:"checks"`
+	// Warnings are only set when the organization is near its quota of checks.
+	Warnings []influxdb.QuotaWarning `json:"warnings,omitempty"`
+}
+
+func newChecksBulkCreateResponse(cs []influxdb.Check) *checksBulkCreateResponse {
+	resp := &checksBulkCreateResponse{
+		Checks: make([]*checkResponse, 0, len(cs)),
+	}
+	for _, c := range cs {
+		resp.Checks = append(resp.Checks, newCheckResponse(c, []*influxdb.Label{}))
+	}
+	return resp
+}
+
+type postChecksBulkCreateRequest struct {
+	Create influxdb.CheckBulkCreate
+	// Deprecations are the older shapes of the created checks.
+	Deprecations []string
+}
+
+func decodePostChecksBulkCreateRequest(ctx context.Context, r *http.Request) (*postChecksBulkCreateRequest, error) {
+	var body struct {
+		OrgID  influxdb.ID       `json:"orgID"`
+		Checks []json.RawMessage `json:"checks"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  err.Error(),
+		}
+	}
+
+	req := &postChecksBulkCreateRequest{
+		Create: influxdb.CheckBulkCreate{
+			OrgID:  body.OrgID,
+			Checks: make([]influxdb.Check, 0, len(body.Checks)),
+		},
+	}
+	seen := make(map[string]bool)
+	for i, b := range body.Checks {
+		c, deprecations, err := UnmarshalCheckJSON(b)
+		if err != nil {
+			return nil, influxdb.BulkCheckError(i, err)
+		}
+		if !c.GetOrgID().Valid() {
+			c.SetOrgID(body.OrgID)
+		}
+		if err := validate.Check(c, validate.Create); err != nil {
+			return nil, influxdb.BulkCheckError(i, invalidResourceError(err))
+		}
+		for _, d := range deprecations {
+			if !seen[d] {
+				seen[d] = true
+				req.Deprecations = append(req.Deprecations, d)
+			}
+		}
+		req.Create.Checks = append(req.Create.Checks, c)
+	}
+	if err := req.Create.Valid(); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// handlePostChecksBulkCreate is the HTTP handler for the POST /api/v2/checks/bulk route.
+// Every check is created or none of them is.
+func (h *CheckHandler) handlePostChecksBulkCreate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "checks bulk create request", r)
+	req, err := decodePostChecksBulkCreateRequest(ctx, r)
+	if err != nil {
+		h.Logger.Debug("failed to decode request", zap.Error(err))
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	setDeprecationHeaders(w, req.Deprecations)
+	auth, err := pctx.GetAuthorizer(ctx)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	if err := h.CheckBulkCreateService.CreateChecks(ctx, req.Create, auth.GetUserID()); err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	h.Logger.Debug("checks bulk created", zap.String("orgID", req.Create.OrgID.String()), zap.Int("checks", len(req.Create.Checks)))
+
+	resp := newChecksBulkCreateResponse(req.Create.Checks)
+	resp.Warnings = findQuotaWarnings(ctx, h.AlertingQuotaService, h.Logger, req.Create.OrgID, influxdb.ChecksResourceType)
+	if err := encodeResponse(ctx, w, http.StatusCreated, resp); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
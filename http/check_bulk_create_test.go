@@ -0,0 +1,102 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	pctx "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/mock"
+)
+
+func TestCheckHandler_handlePostChecksBulkCreate(t *testing.T) {
+	b := NewMockCheckBackend()
+	var created []influxdb.Check
+	b.CheckBulkCreateService = &mock.CheckBulkCreateService{
+		CreateChecksF: func(ctx context.Context, c influxdb.CheckBulkCreate, userID influxdb.ID) error {
+			for i, ch := range c.Checks {
+				ch.SetID(influxdb.ID(10 + i))
+			}
+			created = c.Checks
+			return nil
+		},
+	}
+	h := NewCheckHandler(b)
+
+	deadman := func(name string) string {
+		return `{"type": "deadman", "name": "` + name + `", "status": "active", "every": "1m", "timeSince": 90, "level": "CRIT",
+			"query": {"text": "from(bucket: \"telegraf\") |> range(start: -1m)"}}`
+	}
+	tests := []struct {
+		name     string
+		body     string
+		wantCode int
+		wantMsg  string
+	}{
+		{
+			name:     "checks",
+			body:     `{"orgID": "0000000000000002", "checks": [` + deadman("cpu") + `, ` + deadman("mem") + `]}`,
+			wantCode: http.StatusCreated,
+		},
+		{
+			name:     "invalid check",
+			body:     `{"orgID": "0000000000000002", "checks": [` + deadman("cpu") + `, ` + deadman("") + `]}`,
+			wantCode: http.StatusBadRequest,
+			wantMsg:  "check 1: ",
+		},
+		{
+			name:     "no checks",
+			body:     `{"orgID": "0000000000000002", "checks": []}`,
+			wantCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			created = nil
+			r := httptest.NewRequest("POST", "/api/v2/checks/bulk", strings.NewReader(tt.body))
+			r = r.WithContext(pctx.SetAuthorizer(r.Context(), &influxdb.Session{UserID: influxdb.ID(6)}))
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantCode, w.Code, w.Body.String())
+			}
+			if tt.wantCode != http.StatusCreated {
+				var herr influxdb.Error
+				if err := json.Unmarshal(w.Body.Bytes(), &herr); err != nil {
+					t.Fatalf("failed to decode error: %v", err)
+				}
+				if !strings.HasPrefix(herr.Msg, tt.wantMsg) {
+					t.Errorf("expected error message starting with %q, got %q", tt.wantMsg, herr.Msg)
+				}
+				if created != nil {
+					t.Errorf("expected no checks to be created, got %d", len(created))
+				}
+				return
+			}
+
+			var resp struct {
+				Checks []struct {
+					ID    influxdb.ID `json:"id"`
+					Name  string      `json:"name"`
+					OrgID influxdb.ID `json:"orgID"`
+				} `json:"checks"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if len(resp.Checks) != 2 {
+				t.Fatalf("expected 2 checks, got %d", len(resp.Checks))
+			}
+			for i, c := range resp.Checks {
+				if c.ID != influxdb.ID(10+i) || c.OrgID != 2 || c.Name != created[i].GetName() {
+					t.Errorf("unexpected check %d %+v", i, c)
+				}
+			}
+		})
+	}
+}
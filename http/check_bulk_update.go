@@ -0,0 +1,64 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/influxdata/influxdb"
+	"go.uber.org/zap"
+)
+
+type checksBulkUpdateResponse struct {
+	DryRun bool             `json:"dryRun"`
+	Checks []*checkResponse `json:"checks"`
+}
+
+func newChecksBulkUpdateResponse(res *influxdb.CheckBulkUpdateResult) *checksBulkUpdateResponse {
+	resp := &checksBulkUpdateResponse{
+		DryRun: res.DryRun,
+		Checks: make([]*checkResponse, 0, len(res.Checks)),
+	}
+	for _, c := range res.Checks {
+		resp.Checks = append(resp.Checks, newCheckResponse(c, []*influxdb.Label{}))
+	}
+	return resp
+}
+
+func decodePostChecksBulkUpdateRequest(ctx context.Context, r *http.Request) (*influxdb.CheckBulkUpdate, error) {
+	u := &influxdb.CheckBulkUpdate{}
+	if err := json.NewDecoder(r.Body).Decode(u); err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  err.Error(),
+		}
+	}
+	if err := u.Valid(); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// handlePostChecksBulkUpdate is the HTTP handler for the POST /api/v2/checks/bulk-update route.
+func (h *CheckHandler) handlePostChecksBulkUpdate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "checks bulk update request", r)
+	u, err := decodePostChecksBulkUpdateRequest(ctx, r)
+	if err != nil {
+		h.Logger.Debug("failed to decode request", zap.Error(err))
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	res, err := h.CheckBulkUpdateService.BulkUpdateChecks(ctx, *u)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	h.Logger.Debug("checks bulk updated", zap.Int("checks", len(res.Checks)), zap.Bool("dryRun", res.DryRun))
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newChecksBulkUpdateResponse(res)); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
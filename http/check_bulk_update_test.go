@@ -0,0 +1,84 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/notification/check"
+)
+
+func TestCheckHandler_handlePostChecksBulkUpdate(t *testing.T) {
+	b := NewMockCheckBackend()
+	b.CheckBulkUpdateService = &mock.CheckBulkUpdateService{
+		BulkUpdateChecksF: func(ctx context.Context, u influxdb.CheckBulkUpdate) (*influxdb.CheckBulkUpdateResult, error) {
+			if u.Filter.OrgID != influxdb.ID(2) || len(u.Filter.Labels) != 1 || u.Filter.Tags["team"] != "ops" || u.Filter.Every.Duration != time.Minute {
+				t.Errorf("unexpected filter %+v", u.Filter)
+			}
+			if u.Update.Every.Duration != 5*time.Minute || *u.Update.Status != influxdb.Inactive || !u.DryRun {
+				t.Errorf("unexpected update %+v", u)
+			}
+			return &influxdb.CheckBulkUpdateResult{
+				DryRun: u.DryRun,
+				Checks: []influxdb.Check{
+					&check.Deadman{
+						Base: check.Base{
+							ID:     influxdb.ID(1),
+							OrgID:  u.Filter.OrgID,
+							Name:   "heartbeat",
+							Status: *u.Update.Status,
+							Every:  *u.Update.Every,
+							Query: influxdb.DashboardQuery{
+								Text: `from(bucket: "telegraf") |> range(start: -5m)`,
+							},
+						},
+						TimeSince: 90,
+					},
+				},
+			}, nil
+		},
+	}
+	h := NewCheckHandler(b)
+
+	w := httptest.NewRecorder()
+	body := `{
+		"filter": {"orgID": "0000000000000002", "labels": ["fleet"], "tags": {"team": "ops"}, "every": "1m"},
+		"update": {"every": "5m", "status": "inactive"},
+		"dryRun": true
+	}`
+	h.ServeHTTP(w, httptest.NewRequest("POST", "/api/v2/checks/bulk-update", strings.NewReader(body)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var got struct {
+		DryRun bool `json:"dryRun"`
+		Checks []struct {
+			ID     string     `json:"id"`
+			Every  string     `json:"every"`
+			Status string     `json:"status"`
+			Links  checkLinks `json:"links"`
+		} `json:"checks"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if !got.DryRun || len(got.Checks) != 1 {
+		t.Fatalf("unexpected response %+v", got)
+	}
+	if c := got.Checks[0]; c.ID != "0000000000000001" || c.Every != "5m0s" || c.Status != "inactive" || c.Links.Self != "/api/v2/checks/0000000000000001" {
+		t.Errorf("unexpected check %+v", c)
+	}
+
+	w = httptest.NewRecorder()
+	body = `{"filter": {"orgID": "0000000000000002"}, "update": {}}`
+	h.ServeHTTP(w, httptest.NewRequest("POST", "/api/v2/checks/bulk-update", strings.NewReader(body)))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
@@ -0,0 +1,78 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/influxdata/influxdb"
+	pctx "github.com/influxdata/influxdb/context"
+	"go.uber.org/zap"
+)
+
+type postCheckCloneRequest struct {
+	ID    influxdb.ID
+	Clone influxdb.CheckClone
+}
+
+func decodePostCheckCloneRequest(ctx context.Context, r *http.Request) (*postCheckCloneRequest, error) {
+	id, err := decodeGetCheckRequest(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	var c influxdb.CheckClone
+	if err := json.NewDecoder(r.Body).Decode(&c); err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  err.Error(),
+		}
+	}
+	if err := c.Valid(); err != nil {
+		return nil, err
+	}
+
+	return &postCheckCloneRequest{
+		ID:    id,
+		Clone: c,
+	}, nil
+}
+
+// handlePostCheckClone is the HTTP handler for the POST /api/v2/checks/:id/clone route.
+func (h *CheckHandler) handlePostCheckClone(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "check clone request", r)
+	req, err := decodePostCheckCloneRequest(ctx, r)
+	if err != nil {
+		h.Logger.Debug("failed to decode request", zap.Error(err))
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	auth, err := pctx.GetAuthorizer(ctx)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	c, err := h.CheckCloneService.CloneCheck(ctx, req.ID, req.Clone, auth.GetUserID())
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	labels, err := h.LabelService.FindResourceLabels(ctx, influxdb.LabelMappingFilter{ResourceID: c.GetID()})
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	debugResult(h.Logger, "check cloned", "check", c, zap.Stringer("checkID", req.ID))
+	resp := newCheckResponse(c, labels)
+	codec := responseCheckCodec(r)
+	codec.apply(resp)
+	resp.Warnings = findQuotaWarnings(ctx, h.AlertingQuotaService, h.Logger, c.GetOrgID(), influxdb.ChecksResourceType)
+
+	if err := encodeCheckResponse(ctx, w, codec, http.StatusCreated, resp); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
@@ -0,0 +1,85 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	pcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/notification/check"
+)
+
+func TestCheckHandler_handlePostCheckClone(t *testing.T) {
+	b := NewMockCheckBackend()
+	b.CheckCloneService = &mock.CheckCloneService{
+		CloneCheckF: func(ctx context.Context, id influxdb.ID, cl influxdb.CheckClone, userID influxdb.ID) (influxdb.Check, error) {
+			if id != influxdb.ID(1) || cl.Name != "cpu staging" || cl.OrgID != influxdb.ID(3) || cl.Bucket != "staging" || userID != influxdb.ID(6) {
+				t.Errorf("unexpected clone of check %s %+v by %s", id, cl, userID)
+			}
+			return &check.Deadman{
+				Base: check.Base{
+					ID:     influxdb.ID(4),
+					OrgID:  cl.OrgID,
+					Name:   cl.Name,
+					Status: influxdb.Active,
+					Every:  influxdb.Duration{Duration: time.Minute},
+					Query: influxdb.DashboardQuery{
+						Text: `from(bucket: "staging") |> range(start: -5m)`,
+					},
+				},
+				TimeSince: 90,
+			}, nil
+		},
+	}
+	labels := mock.NewLabelService()
+	labels.FindResourceLabelsFn = func(ctx context.Context, f influxdb.LabelMappingFilter) ([]*influxdb.Label, error) {
+		if f.ResourceID != influxdb.ID(4) {
+			t.Errorf("unexpected labels of resource %s", f.ResourceID)
+		}
+		return []*influxdb.Label{{ID: 5, OrgID: 3, Name: "prod"}}, nil
+	}
+	b.LabelService = labels
+	h := NewCheckHandler(b)
+
+	w := httptest.NewRecorder()
+	body := `{"name": "cpu staging", "orgID": "0000000000000003", "bucket": "staging"}`
+	r := httptest.NewRequest("POST", "/api/v2/checks/0000000000000001/clone", strings.NewReader(body))
+	r = r.WithContext(pcontext.SetAuthorizer(r.Context(), &influxdb.Session{UserID: influxdb.ID(6)}))
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	var got struct {
+		ID     string            `json:"id"`
+		OrgID  string            `json:"orgID"`
+		Name   string            `json:"name"`
+		Labels []*influxdb.Label `json:"labels"`
+		Links  checkLinks        `json:"links"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if got.ID != "0000000000000004" || got.OrgID != "0000000000000003" || got.Name != "cpu staging" {
+		t.Errorf("unexpected check %+v", got)
+	}
+	if len(got.Labels) != 1 || got.Labels[0].Name != "prod" {
+		t.Errorf("expected the labels of the copy, got %+v", got.Labels)
+	}
+	if got.Links.Self != "/api/v2/checks/0000000000000004" {
+		t.Errorf("unexpected links %+v", got.Links)
+	}
+
+	w = httptest.NewRecorder()
+	r = httptest.NewRequest("POST", "/api/v2/checks/0000000000000001/clone", strings.NewReader(`{"orgID": "0000000000000003"}`))
+	r = r.WithContext(pcontext.SetAuthorizer(r.Context(), &influxdb.Session{UserID: influxdb.ID(6)}))
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
@@ -0,0 +1,269 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification"
+)
+
+// The checks are exchanged in versioned media types, so the check model can
+// evolve while the clients written against its older shapes keep working:
+//   - application/vnd.influx.check.v1+json is the older shape of the checks,
+//     with everySeconds and offsetSeconds in the style of the retentionRules
+//     of the buckets, tags as an object and lowerBound and upperBound
+//     thresholds. It is upcast to the current model when decoded, and the
+//     checks are downcast to it when encoded.
+//   - application/vnd.influx.check.v2+json is the current model, strictly:
+//     the older shapes are rejected.
+//   - application/json, or no media type, is the current model, which also
+//     accepts the older shapes with deprecation warnings.
+//
+// The media type of a request is its Content-Type, the media type of its
+// response is the first check media type of its Accept header, else the
+// media type of the request.
+
+const (
+	checkMediaTypeV1 = "application/vnd.influx.check.v1+json"
+	checkMediaTypeV2 = "application/vnd.influx.check.v2+json"
+
+	// checkMediaTypePrefix is the prefix of the check media types, including
+	// the versions this server doesn't support.
+	checkMediaTypePrefix = "application/vnd.influx.check."
+)
+
+// checkCodec converts the json of the checks of a media type from and to
+// the current check model.
+type checkCodec struct {
+	mediaType string
+	// upcast returns the json of a check of the media type in the current
+	// model, and the deprecations of the shapes it was upcast from.
+	upcast func([]byte) ([]byte, []string, error)
+	// downcast returns the json of a check of the current model in the
+	// media type, nil if the media type is the current model.
+	downcast func([]byte) ([]byte, error)
+}
+
+var (
+	jsonCheckCodec = &checkCodec{
+		mediaType: "application/json",
+		upcast:    upcastCheckJSON,
+	}
+	checkCodecV1 = &checkCodec{
+		mediaType: checkMediaTypeV1,
+		upcast:    upcastCheckJSONV1,
+		downcast:  downcastCheckJSONV1,
+	}
+	checkCodecV2 = &checkCodec{
+		mediaType: checkMediaTypeV2,
+		upcast:    upcastCheckJSONV2,
+	}
+)
+
+// apply sets the codec encoding the checks of the responses.
+func (c *checkCodec) apply(resps ...*checkResponse) {
+	for _, resp := range resps {
+		resp.codec = c
+	}
+}
+
+// checkCodecOf returns the codec of a check media type, nil for the other
+// media types.
+func checkCodecOf(mediaType string) (*checkCodec, error) {
+	switch mediaType {
+	case checkMediaTypeV1:
+		return checkCodecV1, nil
+	case checkMediaTypeV2:
+		return checkCodecV2, nil
+	}
+	if strings.HasPrefix(mediaType, checkMediaTypePrefix) {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("check media type %s is not supported, use %s or %s", mediaType, checkMediaTypeV1, checkMediaTypeV2),
+		}
+	}
+	return nil, nil
+}
+
+// requestCheckCodec returns the codec of the check sent by r.
+func requestCheckCodec(r *http.Request) (*checkCodec, error) {
+	ct := r.Header.Get("Content-Type")
+	if ct == "" {
+		return jsonCheckCodec, nil
+	}
+	mt, _, err := mime.ParseMediaType(ct)
+	if err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "Content-Type is invalid",
+			Err:  err,
+		}
+	}
+	c, err := checkCodecOf(mt)
+	if err != nil {
+		return nil, err
+	}
+	if c == nil {
+		return jsonCheckCodec, nil
+	}
+	return c, nil
+}
+
+// responseCheckCodec returns the codec of the checks of the response to r.
+// The check media types of the Accept header this server doesn't support
+// are skipped.
+func responseCheckCodec(r *http.Request) *checkCodec {
+	for _, accept := range strings.Split(r.Header.Get("Accept"), ",") {
+		mt, _, err := mime.ParseMediaType(strings.TrimSpace(accept))
+		if err != nil {
+			continue
+		}
+		if c, err := checkCodecOf(mt); err == nil && c != nil {
+			return c
+		}
+	}
+	if c, err := requestCheckCodec(r); err == nil {
+		return c
+	}
+	return jsonCheckCodec
+}
+
+// encodeCheckResponse responds with checks encoded by a codec, in its media type.
+func encodeCheckResponse(ctx context.Context, w http.ResponseWriter, codec *checkCodec, code int, res interface{}) error {
+	w.Header().Add("Vary", "Accept")
+	w.Header().Set("Content-Type", codec.mediaType+"; charset=utf-8")
+	w.WriteHeader(code)
+
+	return json.NewEncoder(w).Encode(res)
+}
+
+// upcastCheckJSONV1 upcasts a check of the v1 media type, which is
+// deprecated as a whole rather than by its fields.
+func upcastCheckJSONV1(b []byte) ([]byte, []string, error) {
+	b, _, err := upcastCheckJSON(b)
+	if err != nil {
+		return nil, nil, err
+	}
+	return b, []string{fmt.Sprintf("%s is deprecated, use %s", checkMediaTypeV1, checkMediaTypeV2)}, nil
+}
+
+// upcastCheckJSONV2 rejects the older shapes in a check of the v2 media type.
+func upcastCheckJSONV2(b []byte) ([]byte, []string, error) {
+	_, deprecations, err := upcastCheckJSON(b)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(deprecations) > 0 {
+		return nil, nil, fmt.Errorf("%s doesn't accept the older shapes of checks: %s", checkMediaTypeV2, strings.Join(deprecations, "; "))
+	}
+	return b, nil, nil
+}
+
+// downcastCheckJSONV1 returns the json of a check in the v1 media type. The
+// thresholds which the lowerBound and upperBound can't express, those with
+// recovery values or outside of a range, keep their type.
+func downcastCheckJSONV1(b []byte) ([]byte, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil, err
+	}
+
+	for _, f := range []struct{ field, legacy string }{
+		{"every", "everySeconds"},
+		{"offset", "offsetSeconds"},
+	} {
+		v, ok := raw[f.field]
+		if !ok {
+			continue
+		}
+		var s string
+		if err := json.Unmarshal(v, &s); err != nil {
+			continue
+		}
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			continue
+		}
+		delete(raw, f.field)
+		raw[f.legacy], _ = json.Marshal(int64(d / time.Second))
+	}
+
+	if v, ok := raw["tags"]; ok {
+		var tags []notification.Tag
+		if err := json.Unmarshal(v, &tags); err == nil {
+			m := make(map[string]string, len(tags))
+			for _, t := range tags {
+				m[t.Key] = t.Value
+			}
+			raw["tags"], _ = json.Marshal(m)
+		}
+	}
+
+	if v, ok := raw["thresholds"]; ok {
+		thresholds, err := downcastThresholds(v)
+		if err != nil {
+			return nil, err
+		}
+		raw["thresholds"] = thresholds
+	}
+
+	return json.Marshal(raw)
+}
+
+// typedThreshold is a greater, lesser or range threshold.
+type typedThreshold struct {
+	Type       string                  `json:"type"`
+	Level      notification.CheckLevel `json:"level"`
+	AllValues  bool                    `json:"allValues"`
+	Value      float64                 `json:"value"`
+	Recover    *float64                `json:"recover"`
+	Min        float64                 `json:"min"`
+	Max        float64                 `json:"max"`
+	Within     bool                    `json:"within"`
+	RecoverMin *float64                `json:"recoverMin"`
+	RecoverMax *float64                `json:"recoverMax"`
+}
+
+// downcastThresholds converts the thresholds to their lowerBound and
+// upperBound, where they can express them.
+func downcastThresholds(b json.RawMessage) (json.RawMessage, error) {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return b, nil
+	}
+	for i, rt := range raw {
+		var t typedThreshold
+		if err := json.Unmarshal(rt, &t); err != nil {
+			continue
+		}
+		var lower, upper *float64
+		switch {
+		case t.Type == "greater" && t.Recover == nil:
+			lower = &t.Value
+		case t.Type == "lesser" && t.Recover == nil:
+			upper = &t.Value
+		case t.Type == "range" && t.Within && t.RecoverMin == nil && t.RecoverMax == nil:
+			lower, upper = &t.Min, &t.Max
+		default:
+			continue
+		}
+		raw[i], _ = json.Marshal(struct {
+			Level      notification.CheckLevel `json:"level"`
+			AllValues  bool                    `json:"allValues"`
+			LowerBound *float64                `json:"lowerBound,omitempty"`
+			UpperBound *float64                `json:"upperBound,omitempty"`
+		}{
+			Level:      t.Level,
+			AllValues:  t.AllValues,
+			LowerBound: lower,
+			UpperBound: upper,
+		})
+	}
+	return json.Marshal(raw)
+}
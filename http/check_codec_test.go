@@ -0,0 +1,204 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	pcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/check"
+)
+
+func TestCheckHandler_handlePostCheck_mediaTypes(t *testing.T) {
+	const (
+		legacy  = `{"type": "threshold", "name": "cpu", "orgID": "0000000000000002", "status": "active", "query": {"text": "from(bucket: \"telegraf\")"}, "everySeconds": 60, "tags": {"env": "prod"}, "thresholds": [{"level": "CRIT", "lowerBound": 90}]}`
+		current = `{"type": "threshold", "name": "cpu", "orgID": "0000000000000002", "status": "active", "query": {"text": "from(bucket: \"telegraf\")"}, "every": "1m", "tags": [{"key": "env", "value": "prod"}], "thresholds": [{"type": "greater", "level": "CRIT", "value": 90}]}`
+	)
+	tests := []struct {
+		name            string
+		contentType     string
+		accept          string
+		body            string
+		wantCode        int
+		wantContentType string
+		// wantLegacy is whether the check of the response is in the v1 shape.
+		wantLegacy     bool
+		wantDeprecated bool
+	}{
+		{
+			name:            "v1",
+			contentType:     checkMediaTypeV1,
+			body:            legacy,
+			wantCode:        http.StatusCreated,
+			wantContentType: checkMediaTypeV1 + "; charset=utf-8",
+			wantLegacy:      true,
+			wantDeprecated:  true,
+		},
+		{
+			name:            "v1 accepting v2",
+			contentType:     checkMediaTypeV1,
+			accept:          checkMediaTypeV2,
+			body:            legacy,
+			wantCode:        http.StatusCreated,
+			wantContentType: checkMediaTypeV2 + "; charset=utf-8",
+			wantDeprecated:  true,
+		},
+		{
+			name:            "v2",
+			contentType:     checkMediaTypeV2 + "; charset=utf-8",
+			body:            current,
+			wantCode:        http.StatusCreated,
+			wantContentType: checkMediaTypeV2 + "; charset=utf-8",
+		},
+		{
+			name:            "v2 accepting v1",
+			contentType:     checkMediaTypeV2,
+			accept:          "application/json, " + checkMediaTypeV1,
+			body:            current,
+			wantCode:        http.StatusCreated,
+			wantContentType: checkMediaTypeV1 + "; charset=utf-8",
+			wantLegacy:      true,
+		},
+		{
+			name:        "v2 rejects the older shapes",
+			contentType: checkMediaTypeV2,
+			body:        legacy,
+			wantCode:    http.StatusBadRequest,
+		},
+		{
+			name:        "unsupported version",
+			contentType: "application/vnd.influx.check.v3+json",
+			body:        current,
+			wantCode:    http.StatusBadRequest,
+		},
+		{
+			name:            "json upcasts the older shapes",
+			contentType:     "application/json",
+			body:            legacy,
+			wantCode:        http.StatusCreated,
+			wantContentType: "application/json; charset=utf-8",
+			wantDeprecated:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewMockCheckBackend()
+			var created influxdb.Check
+			b.CheckService = &mock.CheckService{
+				CreateCheckF: func(ctx context.Context, c influxdb.Check, userID influxdb.ID) error {
+					c.SetID(influxdb.ID(1))
+					created = c
+					return nil
+				},
+			}
+			h := NewCheckHandler(b)
+
+			r := httptest.NewRequest("POST", "/api/v2/checks", bytes.NewBufferString(tt.body))
+			r.Header.Set("Content-Type", tt.contentType)
+			if tt.accept != "" {
+				r.Header.Set("Accept", tt.accept)
+			}
+			r = r.WithContext(pcontext.SetAuthorizer(r.Context(), &influxdb.Session{UserID: influxdb.ID(6)}))
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantCode, w.Code, w.Body.String())
+			}
+			if tt.wantCode != http.StatusCreated {
+				return
+			}
+			if got := w.Header().Get("Content-Type"); got != tt.wantContentType {
+				t.Errorf("got content type %q, want %q", got, tt.wantContentType)
+			}
+			if got := w.Header().Get("Deprecation") == "true"; got != tt.wantDeprecated {
+				t.Errorf("expected the response to be marked deprecated %v, got %v", tt.wantDeprecated, got)
+			}
+
+			c := created.(*check.Threshold)
+			if c.Every.Duration != time.Minute || len(c.Tags) != 1 || len(c.Thresholds) != 1 {
+				t.Fatalf("expected the check to be created in the current model, got %+v", c)
+			}
+			if _, ok := c.Thresholds[0].(*check.Greater); !ok {
+				t.Errorf("expected a greater threshold, got %T", c.Thresholds[0])
+			}
+
+			var got map[string]json.RawMessage
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			_, everySeconds := got["everySeconds"]
+			_, every := got["every"]
+			if everySeconds != tt.wantLegacy || every == tt.wantLegacy {
+				t.Errorf("expected the check of the response in the v1 shape %v, got %s", tt.wantLegacy, w.Body.String())
+			}
+			if _, ok := got["links"]; !ok {
+				t.Errorf("expected the links of the check, got %s", w.Body.String())
+			}
+		})
+	}
+}
+
+func TestDowncastCheckJSONV1(t *testing.T) {
+	recoverAt := 80.0
+	c := &check.Threshold{
+		Base: check.Base{
+			ID:     influxdb.ID(1),
+			Name:   "cpu",
+			Every:  influxdb.Duration{Duration: time.Minute},
+			Offset: influxdb.Duration{Duration: 10 * time.Second},
+			Tags:   []notification.Tag{{Key: "env", Value: "prod"}},
+		},
+		Thresholds: []check.ThresholdConfig{
+			&check.Greater{Value: 90},
+			&check.Lesser{Value: 10},
+			&check.Range{Min: 10, Max: 90, Within: true},
+			&check.Greater{Value: 95, Recover: &recoverAt},
+			&check.Range{Min: 10, Max: 90},
+		},
+	}
+	b, err := json.Marshal(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err = downcastCheckJSONV1(b)
+	if err != nil {
+		t.Fatalf("failed to downcast: %v", err)
+	}
+	var v1 struct {
+		EverySeconds  *int64                   `json:"everySeconds"`
+		OffsetSeconds *int64                   `json:"offsetSeconds"`
+		Thresholds    []map[string]interface{} `json:"thresholds"`
+	}
+	if err := json.Unmarshal(b, &v1); err != nil {
+		t.Fatal(err)
+	}
+	if v1.EverySeconds == nil || *v1.EverySeconds != 60 || v1.OffsetSeconds == nil || *v1.OffsetSeconds != 10 {
+		t.Errorf("expected everySeconds 60 and offsetSeconds 10, got %s", b)
+	}
+	for i, typed := range []bool{false, false, false, true, true} {
+		if _, ok := v1.Thresholds[i]["type"]; ok != typed {
+			t.Errorf("expected threshold %d to keep its type %v, got %v", i, typed, v1.Thresholds[i])
+		}
+	}
+
+	// the v1 shape upcasts to the same check.
+	got, _, err := unmarshalCheckJSON(checkCodecV1, b)
+	if err != nil {
+		t.Fatalf("failed to upcast: %v", err)
+	}
+	want, err := json.Marshal(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := json.Marshal(got); !bytes.Equal(b, want) {
+		t.Errorf("got %s, want %s", b, want)
+	}
+}
@@ -0,0 +1,96 @@
+package http
+
+import (
+	"encoding/csv"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/golang/gddo/httputil"
+	"github.com/influxdata/influxdb"
+)
+
+// checkCSVMediaType is the media type of the csv listings of checks.
+const checkCSVMediaType = "text/csv"
+
+// CheckCSVColumns are the columns of the csv listings of checks, in order.
+// Columns are only ever appended, so the listings can be parsed by position.
+var CheckCSVColumns = []string{
+	"id",
+	"name",
+	"orgID",
+	"type",
+	"status",
+	"every",
+	"cron",
+	"offset",
+	"description",
+	"createdAt",
+	"updatedAt",
+}
+
+// scheduledCheck is a check run on a schedule.
+type scheduledCheck interface {
+	GetEvery() time.Duration
+	GetCron() string
+	GetOffset() time.Duration
+}
+
+// CheckCSVRow returns the values of the CheckCSVColumns of a check. The
+// durations and times the check doesn't have are empty.
+func CheckCSVRow(c influxdb.Check) []string {
+	var every, cron, offset string
+	if sc, ok := c.(scheduledCheck); ok {
+		every, cron, offset = csvDuration(sc.GetEvery()), sc.GetCron(), csvDuration(sc.GetOffset())
+	}
+	log := c.GetCRUDLog()
+	return []string{
+		c.GetID().String(),
+		c.GetName(),
+		c.GetOrgID().String(),
+		c.Type(),
+		string(c.GetStatus()),
+		every,
+		cron,
+		offset,
+		c.GetDescription(),
+		csvTime(log.CreatedAt),
+		csvTime(log.UpdatedAt),
+	}
+}
+
+// WriteChecksCSV writes the csv listing of checks, with a header row.
+func WriteChecksCSV(w io.Writer, cs []influxdb.Check) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(CheckCSVColumns); err != nil {
+		return err
+	}
+	for _, c := range cs {
+		if err := cw.Write(CheckCSVRow(c)); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func csvDuration(d time.Duration) string {
+	if d == 0 {
+		return ""
+	}
+	return d.String()
+}
+
+func csvTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// acceptsChecksCSV returns whether a request prefers the csv listing of
+// checks to their json.
+func acceptsChecksCSV(r *http.Request) bool {
+	offers := []string{"application/json", checkMediaTypeV1, checkMediaTypeV2, checkCSVMediaType}
+	return httputil.NegotiateContentType(r, offers, "application/json") == checkCSVMediaType
+}
@@ -0,0 +1,77 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	pcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/notification/check"
+)
+
+func TestCheckHandler_handleGetChecks_csv(t *testing.T) {
+	created := time.Date(2019, 10, 1, 12, 0, 0, 0, time.UTC)
+	b := NewMockCheckBackend()
+	b.CheckService = &mock.CheckService{
+		FindChecksF: func(ctx context.Context, filter influxdb.CheckFilter, opt ...influxdb.FindOptions) ([]influxdb.Check, int, error) {
+			return []influxdb.Check{
+				&check.Deadman{Base: check.Base{
+					ID:          1,
+					Name:        "cpu, prod",
+					OrgID:       2,
+					Status:      influxdb.Active,
+					Description: `says "hi"`,
+					Every:       influxdb.Duration{Duration: time.Minute},
+					CRUDLog:     influxdb.CRUDLog{CreatedAt: created, UpdatedAt: created},
+				}},
+				&check.Threshold{Base: check.Base{
+					ID:     3,
+					Name:   "mem",
+					OrgID:  2,
+					Status: influxdb.Inactive,
+					Cron:   "0 * * * *",
+					Offset: influxdb.Duration{Duration: 10 * time.Second},
+				}},
+			}, 2, nil
+		},
+	}
+	h := NewCheckHandler(b)
+
+	for _, tt := range []struct {
+		accept          string
+		wantContentType string
+	}{
+		{accept: "text/csv", wantContentType: "text/csv; charset=utf-8"},
+		{accept: "text/csv;q=0.5, application/json", wantContentType: "application/json; charset=utf-8"},
+		{accept: "", wantContentType: "application/json; charset=utf-8"},
+	} {
+		r := httptest.NewRequest("GET", "/api/v2/checks?orgID=0000000000000002", nil)
+		if tt.accept != "" {
+			r.Header.Set("Accept", tt.accept)
+		}
+		r = r.WithContext(pcontext.SetAuthorizer(r.Context(), &influxdb.Session{UserID: influxdb.ID(6)}))
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+		if got := w.Header().Get("Content-Type"); got != tt.wantContentType {
+			t.Errorf("accepting %q, got content type %q, want %q", tt.accept, got, tt.wantContentType)
+		}
+		if tt.wantContentType != "text/csv; charset=utf-8" {
+			continue
+		}
+		want := `id,name,orgID,type,status,every,cron,offset,description,createdAt,updatedAt
+0000000000000001,"cpu, prod",0000000000000002,deadman,active,1m0s,,,"says ""hi""",2019-10-01T12:00:00Z,2019-10-01T12:00:00Z
+0000000000000003,mem,0000000000000002,threshold,inactive,,0 * * * *,10s,,,
+`
+		if got := w.Body.String(); got != want {
+			t.Errorf("unexpected csv\ngot  %s\nwant %s", got, want)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/ghodss/yaml"
+	"github.com/golang/gddo/httputil"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification/check"
+	"go.uber.org/zap"
+)
+
+func decodeGetChecksPrometheusRulesRequest(ctx context.Context, r *http.Request) (*influxdb.CheckFilter, error) {
+	filter, _, err := decodeCheckFilter(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	return filter, nil
+}
+
+// handleGetChecksPrometheusRules is the HTTP handler for the GET /api/v2/checks/export/prometheus route.
+func (h *CheckHandler) handleGetChecksPrometheusRules(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "checks prometheus rules request", r)
+	filter, err := decodeGetChecksPrometheusRulesRequest(ctx, r)
+	if err != nil {
+		h.Logger.Debug("failed to decode request", zap.Error(err))
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	cs, _, err := h.CheckService.FindChecks(ctx, *filter)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	rs := check.ExportPrometheusRules(h.viewChecks(ctx, cs))
+	h.Logger.Debug("checks exported as prometheus rules", zap.Int("groups", len(rs.Groups)), zap.Int("skipped", len(rs.Skipped)))
+
+	offers := []string{"application/x-yaml", "application/json"}
+	defaultOffer := "application/x-yaml"
+	switch httputil.NegotiateContentType(r, offers, defaultOffer) {
+	case "application/json":
+		if err := encodeResponse(ctx, w, http.StatusOK, rs); err != nil {
+			logEncodingError(h.Logger, r, err)
+			return
+		}
+	case "application/x-yaml":
+		b, err := prometheusRulesYAML(rs)
+		if err != nil {
+			h.HandleHTTPError(ctx, err, w)
+			return
+		}
+		w.Header().Set("Content-Type", "application/x-yaml; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		w.Write(b)
+	}
+}
+
+// prometheusRulesYAML returns the Prometheus rule file of the rules, which
+// lists the skipped checks in its leading comments.
+func prometheusRulesYAML(rs *check.PrometheusRules) ([]byte, error) {
+	b, err := yaml.Marshal(struct {
+		Groups []check.PrometheusRuleGroup `json:"groups"`
+	}{rs.Groups})
+	if err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInternal,
+			Err:  err,
+		}
+	}
+
+	var buf bytes.Buffer
+	for _, s := range rs.Skipped {
+		fmt.Fprintf(&buf, "# skipped check %s (%s): %s\n", s.Name, s.ID, s.Reason)
+	}
+	buf.Write(b)
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,123 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	pctx "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/check"
+)
+
+func TestCheckHandler_handleGetChecksPrometheusRules(t *testing.T) {
+	b := NewMockCheckBackend()
+	b.CheckService = &mock.CheckService{
+		FindChecksF: func(ctx context.Context, filter influxdb.CheckFilter, opts ...influxdb.FindOptions) ([]influxdb.Check, int, error) {
+			if filter.OrgID == nil || *filter.OrgID != influxdb.ID(2) || len(opts) != 0 {
+				t.Errorf("unexpected filter %+v and options %v", filter, opts)
+			}
+			return []influxdb.Check{
+				&check.Threshold{
+					Base: check.Base{
+						ID:    influxdb.ID(1),
+						OrgID: influxdb.ID(2),
+						Name:  "cpu",
+						Every: influxdb.Duration{Duration: time.Minute},
+						Query: influxdb.DashboardQuery{
+							Text: `from(bucket: "telegraf") |> range(start: -1m) |> filter(fn: (r) => r._measurement == "cpu" and r._field == "usage_user")`,
+						},
+					},
+					Thresholds: []check.ThresholdConfig{
+						&check.Greater{ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Critical}, Value: 90},
+					},
+				},
+				&check.Deadman{
+					Base: check.Base{
+						ID:    influxdb.ID(3),
+						OrgID: influxdb.ID(2),
+						Name:  "heartbeat",
+						Every: influxdb.Duration{Duration: time.Minute},
+					},
+					TimeSince: 90,
+				},
+			}, 2, nil
+		},
+	}
+	h := NewCheckHandler(b)
+	orgID := influxdb.ID(2)
+	newRequest := func(target string, permissions []influxdb.Permission) *http.Request {
+		r := httptest.NewRequest("GET", target, nil)
+		return r.WithContext(pctx.SetAuthorizer(r.Context(), &influxdb.Authorization{
+			Status:      influxdb.Active,
+			OrgID:       orgID,
+			Permissions: permissions,
+		}))
+	}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, newRequest("/api/v2/checks/export/prometheus?orgID=0000000000000002", influxdb.OwnerPermissions(orgID)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/x-yaml; charset=utf-8" {
+		t.Errorf("unexpected content type %s", ct)
+	}
+	want := `# skipped check heartbeat (0000000000000003): deadman checks can't be expressed as Prometheus rules
+groups:
+- interval: 1m
+  name: influxdb_1m
+  rules:
+  - alert: cpu
+    annotations:
+      influxdb_check_id: "0000000000000001"
+    expr: cpu_usage_user > 90
+    labels:
+      severity: crit
+`
+	if got := w.Body.String(); got != want {
+		t.Errorf("unexpected rules\nwant %s\ngot  %s", want, got)
+	}
+
+	w = httptest.NewRecorder()
+	r := newRequest("/api/v2/checks/export/prometheus?orgID=0000000000000002", influxdb.OwnerPermissions(orgID))
+	r.Header.Set("Accept", "application/json")
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var got check.PrometheusRules
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(got.Groups) != 1 || len(got.Skipped) != 1 || got.Skipped[0].ID != influxdb.ID(3) {
+		t.Errorf("unexpected rules %+v", got)
+	}
+
+	// the queries of the checks are hidden from the viewers.
+	w = httptest.NewRecorder()
+	r = newRequest("/api/v2/checks/export/prometheus?orgID=0000000000000002", influxdb.ViewerPermissions(orgID))
+	r.Header.Set("Accept", "application/json")
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	got = check.PrometheusRules{}
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(got.Groups) != 0 || len(got.Skipped) != 2 || got.Skipped[0].ID != influxdb.ID(1) {
+		t.Errorf("expected the redacted checks to be skipped, got %+v", got)
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/api/v2/checks/export/prometheus", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
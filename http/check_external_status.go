@@ -0,0 +1,62 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/influxdata/influxdb"
+	"go.uber.org/zap"
+)
+
+type postCheckExternalStatusRequest struct {
+	ID     influxdb.ID
+	Status influxdb.ExternalStatus
+}
+
+func decodePostCheckExternalStatusRequest(ctx context.Context, r *http.Request) (*postCheckExternalStatusRequest, error) {
+	id, err := decodeGetCheckRequest(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	var s influxdb.ExternalStatus
+	if err := json.NewDecoder(r.Body).Decode(&s); err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  err.Error(),
+		}
+	}
+	if err := s.Valid(); err != nil {
+		return nil, err
+	}
+
+	return &postCheckExternalStatusRequest{
+		ID:     id,
+		Status: s,
+	}, nil
+}
+
+// handlePostCheckExternalStatus is the HTTP handler for the POST /api/v2/checks/:id/external-status route.
+func (h *CheckHandler) handlePostCheckExternalStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "check external status request", r)
+	req, err := decodePostCheckExternalStatusRequest(ctx, r)
+	if err != nil {
+		h.Logger.Debug("failed to decode request", zap.Error(err))
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	t, err := h.ExternalStatusService.WriteExternalStatus(ctx, req.ID, &req.Status)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	debugResult(h.Logger, "external status written", "statusTrace", t)
+
+	if err := encodeResponse(ctx, w, http.StatusCreated, newStatusTraceResponse(t)); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
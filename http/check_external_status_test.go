@@ -0,0 +1,55 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
+)
+
+func TestCheckHandler_handlePostCheckExternalStatus(t *testing.T) {
+	b := NewMockCheckBackend()
+	b.ExternalStatusService = &mock.ExternalStatusService{
+		WriteExternalStatusF: func(ctx context.Context, checkID influxdb.ID, s *influxdb.ExternalStatus) (*influxdb.StatusTrace, error) {
+			if s.Level != "CRIT" || s.Message != "build failed" || s.Tags["pipeline"] != "deploy" {
+				t.Errorf("unexpected external status %+v", s)
+			}
+			return &influxdb.StatusTrace{
+				StatusID: influxdb.ID(100),
+				CheckID:  checkID,
+				OrgID:    influxdb.ID(10),
+				Level:    s.Level,
+				Rules:    []influxdb.RuleTrace{},
+			}, nil
+		},
+	}
+	h := NewCheckHandler(b)
+
+	w := httptest.NewRecorder()
+	body := `{"level": "CRIT", "message": "build failed", "tags": {"pipeline": "deploy"}}`
+	h.ServeHTTP(w, httptest.NewRequest("POST", "/api/v2/checks/0000000000000001/external-status", strings.NewReader(body)))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	var got statusTraceResponse
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if got.CheckID != influxdb.ID(1) || got.Level != "CRIT" {
+		t.Errorf("unexpected status trace %+v", got.StatusTrace)
+	}
+	if got.Links.Self != "/api/v2/statuses/0000000000000064/trace" || got.Links.Check != "/api/v2/checks/0000000000000001" {
+		t.Errorf("unexpected links %+v", got.Links)
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("POST", "/api/v2/checks/0000000000000001/external-status", strings.NewReader(`{"level": "BAD"}`)))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
@@ -0,0 +1,81 @@
+package http
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/influxdata/influxdb"
+	"go.uber.org/zap"
+)
+
+type checkGCEventsResponse struct {
+	Events []*influxdb.CheckGCEvent `json:"events"`
+	Links  map[string]string        `json:"links"`
+}
+
+func decodeGetCheckGCEventsRequest(ctx context.Context, r *http.Request) (*influxdb.CheckGCEventFilter, error) {
+	q := r.URL.Query()
+	filter := &influxdb.CheckGCEventFilter{}
+	for _, p := range []struct {
+		name string
+		dst  **influxdb.ID
+	}{
+		{"orgID", &filter.OrgID},
+		{"checkID", &filter.CheckID},
+	} {
+		s := q.Get(p.name)
+		if s == "" {
+			continue
+		}
+		id, err := influxdb.IDFromString(s)
+		if err != nil {
+			return nil, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  p.name + " is invalid",
+				Err:  err,
+			}
+		}
+		*p.dst = id
+	}
+
+	switch a := q.Get("action"); a {
+	case "", influxdb.CheckGCNotified, influxdb.CheckGCDeleted, influxdb.CheckGCSpared, influxdb.CheckGCFailed:
+		filter.Action = a
+	default:
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "action must be one of notified, deleted, spared or failed",
+		}
+	}
+	return filter, nil
+}
+
+// handleGetCheckGCEvents is the HTTP handler for the GET /api/v2/checks/gc route.
+func (h *CheckHandler) handleGetCheckGCEvents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "check gc events retrieve request", r)
+	filter, err := decodeGetCheckGCEventsRequest(ctx, r)
+	if err != nil {
+		h.Logger.Debug("failed to decode request", zap.Error(err))
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	events, err := h.CheckGCService.FindCheckGCEvents(ctx, *filter)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	h.Logger.Debug("check gc events retrieved", zap.Int("events", len(events)))
+
+	res := &checkGCEventsResponse{
+		Events: events,
+		Links: map[string]string{
+			"self": checksGCPath,
+		},
+	}
+	if err := encodeResponse(ctx, w, http.StatusOK, res); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
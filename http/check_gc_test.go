@@ -0,0 +1,56 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
+)
+
+func TestCheckHandler_handleGetCheckGCEvents(t *testing.T) {
+	var got influxdb.CheckGCEventFilter
+	b := NewMockCheckBackend()
+	b.CheckGCService = &mock.CheckGCService{
+		FindCheckGCEventsF: func(ctx context.Context, filter influxdb.CheckGCEventFilter) ([]*influxdb.CheckGCEvent, error) {
+			got = filter
+			return []*influxdb.CheckGCEvent{
+				{CheckID: 1, OrgID: 2, Name: "cpu", Action: influxdb.CheckGCDeleted, Reason: "the check is inactive"},
+			}, nil
+		},
+	}
+	h := NewCheckHandler(b)
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/api/v2/checks/gc?orgID=0000000000000002&action=deleted", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if got.OrgID == nil || *got.OrgID != 2 || got.CheckID != nil || got.Action != influxdb.CheckGCDeleted {
+		t.Errorf("unexpected filter %+v", got)
+	}
+	var res struct {
+		Events []struct {
+			CheckID string `json:"checkID"`
+			Action  string `json:"action"`
+		} `json:"events"`
+		Links map[string]string `json:"links"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&res); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(res.Events) != 1 || res.Events[0].CheckID != "0000000000000001" || res.Events[0].Action != "deleted" || res.Links["self"] != "/api/v2/checks/gc" {
+		t.Errorf("unexpected check gc events %+v", res)
+	}
+
+	for _, q := range []string{"?checkID=nope", "?action=archived"} {
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest("GET", "/api/v2/checks/gc"+q, nil))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d for %s, got %d", http.StatusBadRequest, q, w.Code)
+		}
+	}
+}
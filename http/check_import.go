@@ -0,0 +1,116 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/influxdata/influxdb"
+	pctx "github.com/influxdata/influxdb/context"
+	"go.uber.org/zap"
+)
+
+type checkImportResultResponse struct {
+	Name   string         `json:"name"`
+	Action string         `json:"action"`
+	Check  *checkResponse `json:"check"`
+}
+
+type checkImportResponse struct {
+	Results []*checkImportResultResponse `json:"results"`
+}
+
+func newCheckImportResponse(rs []*influxdb.CheckImportResult) *checkImportResponse {
+	resp := &checkImportResponse{
+		Results: make([]*checkImportResultResponse, 0, len(rs)),
+	}
+	for _, r := range rs {
+		resp.Results = append(resp.Results, &checkImportResultResponse{
+			Name:   r.Name,
+			Action: r.Action,
+			Check:  newCheckResponse(r.Check, []*influxdb.Label{}),
+		})
+	}
+	return resp
+}
+
+type postCheckImportRequest struct {
+	OrgID  influxdb.ID
+	Import influxdb.CheckImport
+	// Deprecations are the older shapes of the imported checks.
+	Deprecations []string
+}
+
+func decodePostCheckImportRequest(ctx context.Context, r *http.Request) (*postCheckImportRequest, error) {
+	orgReq, err := decodeGetOrgRequest(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	var body struct {
+		Checks     []json.RawMessage `json:"checks"`
+		OnConflict string            `json:"onConflict"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  err.Error(),
+		}
+	}
+
+	req := &postCheckImportRequest{
+		OrgID: orgReq.OrgID,
+		Import: influxdb.CheckImport{
+			Checks:     make([]influxdb.Check, 0, len(body.Checks)),
+			OnConflict: body.OnConflict,
+		},
+	}
+	seen := make(map[string]bool)
+	for _, b := range body.Checks {
+		c, deprecations, err := UnmarshalCheckJSON(b)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range deprecations {
+			if !seen[d] {
+				seen[d] = true
+				req.Deprecations = append(req.Deprecations, d)
+			}
+		}
+		req.Import.Checks = append(req.Import.Checks, c)
+	}
+	if err := req.Import.Valid(); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// handlePostCheckImport is the HTTP handler for the POST /api/v2/orgs/:id/checks/import route.
+func (h *OrgHandler) handlePostCheckImport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "check import request", r)
+	req, err := decodePostCheckImportRequest(ctx, r)
+	if err != nil {
+		h.Logger.Debug("failed to decode request", zap.Error(err))
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	setDeprecationHeaders(w, req.Deprecations)
+	auth, err := pctx.GetAuthorizer(ctx)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	rs, err := h.CheckImportService.ImportChecks(ctx, req.OrgID, req.Import, auth.GetUserID())
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	debugResult(h.Logger, "checks imported", "results", rs)
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newCheckImportResponse(rs)); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
@@ -0,0 +1,81 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	pcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/notification/check"
+)
+
+func TestOrgHandler_handlePostCheckImport(t *testing.T) {
+	b := NewMockOrgBackend()
+	b.HTTPErrorHandler = ErrorHandler(0)
+	b.CheckImportService = &mock.CheckImportService{
+		ImportChecksF: func(ctx context.Context, orgID influxdb.ID, imp influxdb.CheckImport, userID influxdb.ID) ([]*influxdb.CheckImportResult, error) {
+			if orgID != influxdb.ID(2) || userID != influxdb.ID(6) {
+				t.Errorf("expected checks imported in org 2 by user 6, got %s and %s", orgID, userID)
+			}
+			if imp.OnConflict != influxdb.ImportOnConflictRename || len(imp.Checks) != 1 {
+				t.Fatalf("expected a check imported with rename-with-suffix, got %d with %s", len(imp.Checks), imp.OnConflict)
+			}
+			c := imp.Checks[0].(*check.Deadman)
+			c.ID = influxdb.ID(1)
+			c.OrgID = orgID
+			c.Name = "cpu (2)"
+			return []*influxdb.CheckImportResult{
+				{Name: "cpu", Action: influxdb.CheckImportRenamed, Check: c},
+			}, nil
+		},
+	}
+	h := NewOrgHandler(b)
+
+	post := func(body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest("POST", "/api/v2/orgs/0000000000000002/checks/import", bytes.NewBufferString(body))
+		r = r.WithContext(pcontext.SetAuthorizer(r.Context(), &influxdb.Session{UserID: influxdb.ID(6)}))
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	w := post(`{"onConflict": "rename-with-suffix", "checks": [{"type": "deadman", "name": "cpu", "every": "1m", "timeSince": 60}]}`)
+	res := w.Result()
+	body, _ := ioutil.ReadAll(res.Body)
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, res.StatusCode, body)
+	}
+	var resp struct {
+		Results []struct {
+			Name   string `json:"name"`
+			Action string `json:"action"`
+			Check  struct {
+				ID   string `json:"id"`
+				Name string `json:"name"`
+			} `json:"check"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if len(resp.Results) != 1 {
+		t.Fatalf("expected a result for the imported check, got %s", body)
+	}
+	r := resp.Results[0]
+	if r.Name != "cpu" || r.Action != "renamed" || r.Check.ID != "0000000000000001" || r.Check.Name != "cpu (2)" {
+		t.Errorf("unexpected import result %s", body)
+	}
+
+	if w := post(`{"onConflict": "replace", "checks": []}`); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d for an invalid strategy, got %d", http.StatusBadRequest, w.Code)
+	}
+	if w := post(`{"checks": [{"type": "unknown", "name": "cpu"}]}`); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d for an invalid check, got %d", http.StatusBadRequest, w.Code)
+	}
+}
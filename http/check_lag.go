@@ -0,0 +1,98 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/influxdata/influxdb"
+	"go.uber.org/zap"
+)
+
+type checkLagResponse struct {
+	*influxdb.CheckLag
+	Links map[string]string `json:"links"`
+}
+
+type checkLagSummaryResponse struct {
+	*influxdb.CheckLagSummary
+	Links map[string]string `json:"links"`
+}
+
+// handleGetCheckLag is the HTTP handler for the GET /api/v2/checks/:id/lag route.
+func (h *CheckHandler) handleGetCheckLag(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "check lag retrieve request", r)
+	id, err := decodeGetCheckRequest(ctx, r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	l, err := h.CheckLagService.FindCheckLag(ctx, id)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	h.Logger.Debug("check lag retrieved", zap.String("checkID", id.String()), zap.Int("evaluations", l.Evaluations))
+
+	res := &checkLagResponse{
+		CheckLag: l,
+		Links: map[string]string{
+			"self":  fmt.Sprintf("/api/v2/checks/%s/lag", id),
+			"check": fmt.Sprintf("/api/v2/checks/%s", id),
+		},
+	}
+	if err := encodeResponse(ctx, w, http.StatusOK, res); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+func decodeGetCheckLagSummaryRequest(ctx context.Context, r *http.Request) (influxdb.ID, error) {
+	var orgID influxdb.ID
+	s := r.URL.Query().Get("orgID")
+	if s == "" {
+		return orgID, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "orgID is required",
+		}
+	}
+	if err := orgID.DecodeFromString(s); err != nil {
+		return orgID, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "orgID is invalid",
+			Err:  err,
+		}
+	}
+	return orgID, nil
+}
+
+// handleGetCheckLagSummary is the HTTP handler for the GET /api/v2/checks/lag route.
+func (h *CheckHandler) handleGetCheckLagSummary(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "check lag summary retrieve request", r)
+	orgID, err := decodeGetCheckLagSummaryRequest(ctx, r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	sum, err := h.CheckLagService.FindCheckLagSummary(ctx, orgID)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	h.Logger.Debug("check lag summary retrieved", zap.String("orgID", orgID.String()), zap.Int("checks", sum.Checks))
+
+	res := &checkLagSummaryResponse{
+		CheckLagSummary: sum,
+		Links: map[string]string{
+			"self": fmt.Sprintf("%s?orgID=%s", checksLagPath, orgID),
+		},
+	}
+	if err := encodeResponse(ctx, w, http.StatusOK, res); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
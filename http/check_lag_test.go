@@ -0,0 +1,83 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
+)
+
+func TestCheckHandler_handleGetCheckLag(t *testing.T) {
+	b := NewMockCheckBackend()
+	b.CheckLagService = &mock.CheckLagService{
+		FindCheckLagF: func(ctx context.Context, checkID influxdb.ID) (*influxdb.CheckLag, error) {
+			if checkID != 1 {
+				return nil, &influxdb.Error{
+					Code: influxdb.ENotFound,
+					Msg:  "check not found",
+				}
+			}
+			return &influxdb.CheckLag{
+				CheckID:       checkID,
+				OrgID:         2,
+				CheckLagStats: influxdb.CheckLagStats{Evaluations: 1, P50: influxdb.Duration{Duration: 3 * time.Second}},
+				Samples:       []influxdb.CheckLagSample{{Lag: influxdb.Duration{Duration: 3 * time.Second}}},
+			}, nil
+		},
+		FindCheckLagSummaryF: func(ctx context.Context, orgID influxdb.ID) (*influxdb.CheckLagSummary, error) {
+			return &influxdb.CheckLagSummary{OrgID: orgID, Checks: 4, Laggiest: []*influxdb.CheckLag{}}, nil
+		},
+	}
+	h := NewCheckHandler(b)
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/api/v2/checks/0000000000000001/lag", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var lag struct {
+		CheckID string            `json:"checkID"`
+		P50     string            `json:"p50"`
+		Samples []json.RawMessage `json:"samples"`
+		Links   map[string]string `json:"links"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&lag); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if lag.CheckID != "0000000000000001" || lag.P50 != "3s" || len(lag.Samples) != 1 || lag.Links["self"] != "/api/v2/checks/0000000000000001/lag" {
+		t.Errorf("unexpected check lag %+v", lag)
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/api/v2/checks/0000000000000002/lag", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/api/v2/checks/lag?orgID=0000000000000002", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var sum struct {
+		OrgID  string `json:"orgID"`
+		Checks int    `json:"checks"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&sum); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if sum.OrgID != "0000000000000002" || sum.Checks != 4 {
+		t.Errorf("unexpected lag summary %+v", sum)
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/api/v2/checks/lag", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected a summary without an org to be rejected, got status %d", w.Code)
+	}
+}
@@ -0,0 +1,124 @@
+package http
+
+import (
+	"context"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	pcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/inmem"
+	"github.com/influxdata/influxdb/kv"
+	platformtesting "github.com/influxdata/influxdb/testing"
+	"go.uber.org/zap"
+)
+
+var (
+	liveHost     = flag.String("host", "", "address of a running server to run TestLiveCheckAPI against, such as http://localhost:9999")
+	liveToken    = flag.String("token", "", "token of the user running TestLiveCheckAPI")
+	liveOrg      = flag.String("org", "", "name of the organization TestLiveCheckAPI creates its resources in")
+	liveBucket   = flag.String("bucket", "", "name of a bucket of the organization queried by the checks of TestLiveCheckAPI")
+	liveInsecure = flag.Bool("insecure", false, "skip the verification of the TLS certificate of the server")
+)
+
+// TestLiveCheckAPI runs the live check API tests against a running server:
+//
+//	go test ./http -run TestLiveCheckAPI -args -host http://localhost:9999 -token ... -org ... -bucket ...
+func TestLiveCheckAPI(t *testing.T) {
+	if *liveHost == "" {
+		t.Skip("live check API tests require -host")
+	}
+
+	orgs := &OrganizationService{
+		Addr:               *liveHost,
+		Token:              *liveToken,
+		InsecureSkipVerify: *liveInsecure,
+	}
+	org, err := orgs.FindOrganization(context.Background(), influxdb.OrganizationFilter{Name: liveOrg})
+	if err != nil {
+		t.Fatalf("failed to find organization %s: %v", *liveOrg, err)
+	}
+
+	platformtesting.LiveCheckAPI(platformtesting.LiveFields{
+		CheckService: &CheckService{
+			Addr:               *liveHost,
+			Token:              *liveToken,
+			InsecureSkipVerify: *liveInsecure,
+		},
+		NotificationEndpointService: NewNotificationEndpointService(*liveHost, *liveToken, *liveInsecure),
+		NotificationRuleStore:       NewNotificationRuleService(*liveHost, *liveToken, *liveInsecure),
+		OrgID:                       org.ID,
+		Bucket:                      *liveBucket,
+	}, t)
+}
+
+// TestCheckAPI runs the live check API tests against the check, notification
+// endpoint and notification rule handlers, through their HTTP clients.
+func TestCheckAPI(t *testing.T) {
+	ctx := context.Background()
+	svc := kv.NewService(inmem.NewKVStore())
+	if err := svc.Initialize(ctx); err != nil {
+		t.Fatalf("error initializing kv service: %v", err)
+	}
+
+	user := &influxdb.User{Name: "theuser"}
+	if err := svc.CreateUser(ctx, user); err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	org := &influxdb.Organization{Name: "theorg"}
+	if err := svc.CreateOrganization(ctx, org); err != nil {
+		t.Fatalf("failed to create org: %v", err)
+	}
+	if err := svc.CreateBucket(ctx, &influxdb.Bucket{OrgID: org.ID, Name: "telegraf"}); err != nil {
+		t.Fatalf("failed to create bucket: %v", err)
+	}
+
+	checkBackend := NewMockCheckBackend()
+	checkBackend.CheckService = svc
+	checkBackend.LabelService = svc
+	checkBackend.OrganizationService = svc
+	checkHandler := NewCheckHandler(checkBackend)
+	ruleHandler := NewNotificationRuleHandler(&NotificationRuleBackend{
+		HTTPErrorHandler:           ErrorHandler(0),
+		Logger:                     zap.NewNop(),
+		NotificationRuleStore:      svc,
+		UserResourceMappingService: svc,
+		LabelService:               svc,
+		UserService:                svc,
+		OrganizationService:        svc,
+	})
+	endpointHandler := NewNotificationEndpointHandler(&NotificationEndpointBackend{
+		HTTPErrorHandler:            ErrorHandler(0),
+		Logger:                      zap.NewNop(),
+		NotificationEndpointService: svc,
+		UserResourceMappingService:  svc,
+		LabelService:                svc,
+		UserService:                 svc,
+		OrganizationService:         svc,
+	})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r = r.WithContext(pcontext.SetAuthorizer(r.Context(), &influxdb.Session{UserID: user.ID}))
+		switch {
+		case strings.HasPrefix(r.URL.Path, checksPath):
+			checkHandler.ServeHTTP(w, r)
+		case strings.HasPrefix(r.URL.Path, notificationRulesPath):
+			ruleHandler.ServeHTTP(w, r)
+		case strings.HasPrefix(r.URL.Path, notificationEndpointsPath):
+			endpointHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	platformtesting.LiveCheckAPI(platformtesting.LiveFields{
+		CheckService:                &CheckService{Addr: server.URL},
+		NotificationEndpointService: NewNotificationEndpointService(server.URL, "", false),
+		NotificationRuleStore:       NewNotificationRuleService(server.URL, "", false),
+		OrgID:                       org.ID,
+		Bucket:                      "telegraf",
+	}, t)
+}
@@ -0,0 +1,26 @@
+package http
+
+import (
+	"net/http"
+
+	"go.uber.org/zap"
+)
+
+// handlePostCheckPing is the HTTP handler for the POST /api/v2/checks/:id/ping route.
+func (h *CheckHandler) handlePostCheckPing(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "check ping request", r)
+	id, err := decodeGetCheckRequest(ctx, r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	if err := h.CheckPingService.PingCheck(ctx, id); err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	h.Logger.Debug("check pinged", zap.String("checkID", id.String()))
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,44 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
+)
+
+func TestCheckHandler_handlePostCheckPing(t *testing.T) {
+	var pinged []influxdb.ID
+	b := NewMockCheckBackend()
+	b.CheckPingService = &mock.CheckPingService{
+		PingCheckF: func(ctx context.Context, checkID influxdb.ID) error {
+			if checkID != influxdb.ID(1) {
+				return &influxdb.Error{
+					Code: influxdb.EInvalid,
+					Msg:  "only heartbeat checks accept pings",
+				}
+			}
+			pinged = append(pinged, checkID)
+			return nil
+		},
+	}
+	h := NewCheckHandler(b)
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("POST", "/api/v2/checks/0000000000000001/ping", nil))
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusNoContent, w.Code, w.Body.String())
+	}
+	if len(pinged) != 1 {
+		t.Errorf("expected the check to be pinged once, got %v", pinged)
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("POST", "/api/v2/checks/0000000000000002/ping", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
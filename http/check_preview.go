@@ -0,0 +1,33 @@
+package http
+
+import (
+	"net/http"
+
+	"go.uber.org/zap"
+)
+
+// handlePostCheckPreview is the HTTP handler for the POST /api/v2/checks/preview route.
+// It returns the data of the stage of the query of the check in the body, or of its
+// whole query without a stage parameter.
+func (h *CheckHandler) handlePostCheckPreview(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "check preview request", r)
+	c, _, err := decodeCheckBody(ctx, r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	stage := r.URL.Query().Get("stage")
+	p, err := h.CheckPreviewService.PreviewCheckStage(ctx, c, stage)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	h.Logger.Debug("check previewed", zap.String("stage", stage), zap.Int("series", len(p.Series)))
+
+	if err := encodeResponse(ctx, w, http.StatusOK, p); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
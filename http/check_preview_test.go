@@ -0,0 +1,65 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/notification/check"
+)
+
+func TestCheckHandler_handlePostCheckPreview(t *testing.T) {
+	b := NewMockCheckBackend()
+	b.CheckPreviewService = &mock.CheckPreviewService{
+		PreviewCheckStageF: func(ctx context.Context, c influxdb.Check, stage string) (*influxdb.CheckStagePreview, error) {
+			q, err := c.(*check.Threshold).StageQuery(stage)
+			if err != nil {
+				return nil, err
+			}
+			return &influxdb.CheckStagePreview{
+				Stage: stage,
+				Query: q.Text,
+				Series: []influxdb.CheckSeries{{
+					Tags:   map[string]string{"host": "a"},
+					Values: []float64{1.5},
+					Times:  []time.Time{time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC)},
+				}},
+			}, nil
+		},
+	}
+	h := NewCheckHandler(b)
+
+	body := `{
+		"type": "threshold",
+		"orgID": "000000000000000a",
+		"stages": [
+			{"name": "fetch", "text": "from(bucket: \"telegraf\") |> range(start: -1h)"},
+			{"name": "normalize", "text": "fetch |> aggregateWindow(every: 5m, fn: mean)"}
+		]
+	}`
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("POST", "/api/v2/checks/preview?stage=fetch", strings.NewReader(body)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var got influxdb.CheckStagePreview
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	want := "fetch = from(bucket: \"telegraf\") |> range(start: -1h)\n\nfetch\n\t|> yield(name: \"fetch\")\n"
+	if got.Stage != "fetch" || got.Query != want || len(got.Series) != 1 || got.Series[0].Values[0] != 1.5 {
+		t.Errorf("unexpected preview %+v", got)
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("POST", "/api/v2/checks/preview?stage=compare", strings.NewReader(body)))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+}
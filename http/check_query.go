@@ -0,0 +1,53 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/influxdata/influxdb"
+	"go.uber.org/zap"
+)
+
+type checkQueryResponse struct {
+	*influxdb.CheckQuery
+	Links map[string]string `json:"links"`
+}
+
+// handleGetCheckQuery is the HTTP handler for the GET /api/v2/checks/:id/query route.
+// The query is forbidden to the authorizers the query logic of the check is
+// redacted for.
+func (h *CheckHandler) handleGetCheckQuery(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "check query retrieve request", r)
+	id, err := decodeGetCheckRequest(ctx, r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	q, err := h.CheckStatusService.FindCheckQuery(ctx, id)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	if h.redactsCheck(ctx, q.OrgID, q.CheckID) {
+		h.HandleHTTPError(ctx, &influxdb.Error{
+			Code: influxdb.EForbidden,
+			Msg:  "the query of the check requires write access to the check",
+		}, w)
+		return
+	}
+	h.Logger.Debug("check query retrieved", zap.String("checkID", id.String()))
+
+	res := &checkQueryResponse{
+		CheckQuery: q,
+		Links: map[string]string{
+			"self":  fmt.Sprintf("/api/v2/checks/%s/query", id),
+			"check": fmt.Sprintf("/api/v2/checks/%s", id),
+		},
+	}
+	if err := encodeResponse(ctx, w, http.StatusOK, res); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
@@ -0,0 +1,92 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	pctx "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/mock"
+)
+
+func TestCheckHandler_handleGetCheckQuery(t *testing.T) {
+	orgID := influxdb.ID(2)
+	b := NewMockCheckBackend()
+	b.CheckStatusService = &mock.CheckStatusService{
+		FindCheckQueryF: func(ctx context.Context, checkID influxdb.ID) (*influxdb.CheckQuery, error) {
+			if checkID != 1 {
+				return nil, &influxdb.Error{
+					Code: influxdb.ENotFound,
+					Msg:  "check not found",
+				}
+			}
+			return &influxdb.CheckQuery{
+				CheckID:   checkID,
+				OrgID:     orgID,
+				QueryType: "flux",
+				Query:     `from(bucket: "telegraf")`,
+				Flux:      `option task = {name: "cpu", every: 1m}`,
+			}, nil
+		},
+	}
+	h := NewCheckHandler(b)
+
+	tests := []struct {
+		name        string
+		path        string
+		permissions []influxdb.Permission
+		wantCode    int
+	}{
+		{
+			name:        "owner",
+			path:        "/api/v2/checks/0000000000000001/query",
+			permissions: influxdb.OwnerPermissions(orgID),
+			wantCode:    http.StatusOK,
+		},
+		{
+			name:        "viewer",
+			path:        "/api/v2/checks/0000000000000001/query",
+			permissions: influxdb.ViewerPermissions(orgID),
+			wantCode:    http.StatusForbidden,
+		},
+		{
+			name:        "missing check",
+			path:        "/api/v2/checks/0000000000000003/query",
+			permissions: influxdb.OwnerPermissions(orgID),
+			wantCode:    http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", tt.path, nil)
+			r = r.WithContext(pctx.SetAuthorizer(r.Context(), &influxdb.Authorization{
+				Status:      influxdb.Active,
+				OrgID:       orgID,
+				Permissions: tt.permissions,
+			}))
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+			if w.Code != tt.wantCode {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantCode, w.Code, w.Body.String())
+			}
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+			var got struct {
+				CheckID string            `json:"checkID"`
+				Query   string            `json:"query"`
+				Flux    string            `json:"flux"`
+				Links   map[string]string `json:"links"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if got.CheckID != "0000000000000001" || got.Query == "" || got.Flux == "" || got.Links["self"] != "/api/v2/checks/0000000000000001/query" {
+				t.Errorf("unexpected check query %+v", got)
+			}
+		})
+	}
+}
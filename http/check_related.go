@@ -0,0 +1,92 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/influxdata/influxdb"
+	"go.uber.org/zap"
+)
+
+type checkRelatedLinks struct {
+	Self  string `json:"self"`
+	Check string `json:"check"`
+}
+
+// checkRelatedResponse is a check and the resources linked to it.
+type checkRelatedResponse struct {
+	Check                 *checkResponse                  `json:"check"`
+	Task                  *checkTaskResponse              `json:"task,omitempty"`
+	NotificationRules     []*notificationRuleResponse     `json:"notificationRules"`
+	NotificationEndpoints []*notificationEndpointResponse `json:"notificationEndpoints"`
+	Deliveries            []influxdb.CheckDelivery        `json:"deliveries"`
+	Labels                []*influxdb.Label               `json:"labels"`
+	Links                 checkRelatedLinks               `json:"links"`
+}
+
+func (h *CheckHandler) newCheckRelatedResponse(ctx context.Context, r *influxdb.CheckRelated) (*checkRelatedResponse, error) {
+	id := r.Check.GetID()
+	resp := &checkRelatedResponse{
+		Check:                 newCheckResponse(h.viewCheck(ctx, r.Check), r.Labels),
+		NotificationRules:     make([]*notificationRuleResponse, len(r.NotificationRules)),
+		NotificationEndpoints: make([]*notificationEndpointResponse, len(r.NotificationEndpoints)),
+		Deliveries:            r.Deliveries,
+		Labels:                r.Labels,
+		Links: checkRelatedLinks{
+			Self:  fmt.Sprintf("/api/v2/checks/%s/related", id),
+			Check: fmt.Sprintf("/api/v2/checks/%s", id),
+		},
+	}
+	if r.Task != nil {
+		errs, err := h.findTaskErrors(ctx, r.Task.ID)
+		if err != nil {
+			return nil, err
+		}
+		resp.Task = &checkTaskResponse{
+			ID:              r.Task.ID,
+			Status:          r.Task.Status,
+			Every:           r.Task.Every,
+			Cron:            r.Task.Cron,
+			LatestCompleted: r.Task.LatestCompleted,
+			Errors:          errs,
+		}
+	}
+	for i, nr := range r.NotificationRules {
+		labels, _ := h.LabelService.FindResourceLabels(ctx, influxdb.LabelMappingFilter{ResourceID: nr.GetID()})
+		resp.NotificationRules[i] = newNotificationRuleResponse(nr, labels)
+	}
+	for i, edp := range r.NotificationEndpoints {
+		labels, _ := h.LabelService.FindResourceLabels(ctx, influxdb.LabelMappingFilter{ResourceID: edp.GetID()})
+		resp.NotificationEndpoints[i] = newNotificationEndpointResponse(edp, labels)
+	}
+	return resp, nil
+}
+
+// handleGetCheckRelated is the HTTP handler for the GET /api/v2/checks/:id/related route.
+func (h *CheckHandler) handleGetCheckRelated(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "check related retrieve request", r)
+	id, err := decodeGetCheckRequest(ctx, r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	related, err := h.CheckRelatedService.FindCheckRelated(ctx, id)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	resp, err := h.newCheckRelatedResponse(ctx, related)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	h.Logger.Debug("check related retrieved", zap.String("checkID", id.String()))
+
+	if err := encodeResponse(ctx, w, http.StatusOK, resp); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
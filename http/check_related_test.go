@@ -0,0 +1,120 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/notification/check"
+	"github.com/influxdata/influxdb/notification/endpoint"
+	"github.com/influxdata/influxdb/notification/rule"
+)
+
+func TestCheckHandler_handleGetCheckRelated(t *testing.T) {
+	endpointID := influxdb.ID(3)
+	b := NewMockCheckBackend()
+	b.TaskService = &mock.TaskService{
+		FindRunsFn: func(ctx context.Context, f influxdb.RunFilter) ([]*influxdb.Run, int, error) {
+			return nil, 0, nil
+		},
+	}
+	b.CheckRelatedService = &mock.CheckRelatedService{
+		FindCheckRelatedF: func(ctx context.Context, checkID influxdb.ID) (*influxdb.CheckRelated, error) {
+			if checkID != influxdb.ID(1) {
+				return nil, &influxdb.Error{
+					Code: influxdb.ENotFound,
+					Msg:  "check not found",
+				}
+			}
+			return &influxdb.CheckRelated{
+				Check: &check.Deadman{
+					Base: check.Base{ID: checkID, Name: "cpu", OrgID: 10, Status: influxdb.Active},
+				},
+				Task: &influxdb.Task{ID: 2, OrganizationID: 10, Status: "active", Every: "1m"},
+				NotificationRules: []influxdb.NotificationRule{
+					&rule.Slack{Base: rule.Base{ID: 4, Name: "page ops", OrgID: 10, EndpointID: &endpointID}},
+				},
+				NotificationEndpoints: []influxdb.NotificationEndpoint{
+					&endpoint.Slack{Base: endpoint.Base{ID: endpointID, Name: "slack", OrgID: 10}},
+				},
+				Deliveries: []influxdb.CheckDelivery{
+					{
+						StatusID:   5,
+						Time:       time.Date(2019, 10, 1, 12, 0, 0, 0, time.UTC),
+						Level:      "crit",
+						RuleID:     4,
+						RuleName:   "page ops",
+						EndpointID: &endpointID,
+						Decision:   influxdb.RuleNotified,
+					},
+				},
+				Labels: []*influxdb.Label{{ID: 6, OrgID: 10, Name: "prod"}},
+			}, nil
+		},
+	}
+	h := NewCheckHandler(b)
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/api/v2/checks/0000000000000001/related", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var resp struct {
+		Check struct {
+			ID     string `json:"id"`
+			Labels []struct {
+				Name string `json:"name"`
+			} `json:"labels"`
+		} `json:"check"`
+		Task struct {
+			ID     string   `json:"id"`
+			Errors []string `json:"errors"`
+		} `json:"task"`
+		NotificationRules []struct {
+			ID    string `json:"id"`
+			Links struct {
+				Self string `json:"self"`
+			} `json:"links"`
+		} `json:"notificationRules"`
+		NotificationEndpoints []struct {
+			ID string `json:"id"`
+		} `json:"notificationEndpoints"`
+		Deliveries []influxdb.CheckDelivery `json:"deliveries"`
+		Labels     []influxdb.Label         `json:"labels"`
+		Links      struct {
+			Self string `json:"self"`
+		} `json:"links"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Check.ID != "0000000000000001" || len(resp.Check.Labels) != 1 || resp.Check.Labels[0].Name != "prod" {
+		t.Errorf("unexpected check %+v", resp.Check)
+	}
+	if resp.Task.ID != "0000000000000002" || resp.Task.Errors == nil {
+		t.Errorf("unexpected task %+v", resp.Task)
+	}
+	if len(resp.NotificationRules) != 1 || resp.NotificationRules[0].Links.Self != "/api/v2/notificationRules/0000000000000004" {
+		t.Errorf("unexpected notification rules %+v", resp.NotificationRules)
+	}
+	if len(resp.NotificationEndpoints) != 1 || resp.NotificationEndpoints[0].ID != "0000000000000003" {
+		t.Errorf("unexpected notification endpoints %+v", resp.NotificationEndpoints)
+	}
+	if len(resp.Deliveries) != 1 || resp.Deliveries[0].StatusID != 5 || *resp.Deliveries[0].EndpointID != endpointID {
+		t.Errorf("unexpected deliveries %+v", resp.Deliveries)
+	}
+	if len(resp.Labels) != 1 || resp.Links.Self != "/api/v2/checks/0000000000000001/related" {
+		t.Errorf("unexpected labels %+v or links %+v", resp.Labels, resp.Links)
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/api/v2/checks/0000000000000009/related", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+}
@@ -0,0 +1,122 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/influxdata/influxdb"
+	"go.uber.org/zap"
+)
+
+// checkRunResponse is a run of the task of a check, with the summary of its
+// outcome.
+type checkRunResponse struct {
+	ID           influxdb.ID `json:"id"`
+	Status       string      `json:"status"`
+	ScheduledFor string      `json:"scheduledFor"`
+	StartedAt    string      `json:"startedAt,omitempty"`
+	FinishedAt   string      `json:"finishedAt,omitempty"`
+	// Summary is the outcome of the run, nil for the runs which didn't
+	// succeed or weren't summarized.
+	Summary *influxdb.CheckRunSummary `json:"summary,omitempty"`
+	Log     []influxdb.Log            `json:"log"`
+}
+
+type checkRunsResponse struct {
+	CheckID influxdb.ID `json:"checkID"`
+	// TaskID is the task running the check, nil for the checks evaluated by
+	// the alerting engine, which have no run.
+	TaskID *influxdb.ID        `json:"taskID,omitempty"`
+	Runs   []*checkRunResponse `json:"runs"`
+	Links  map[string]string   `json:"links"`
+}
+
+func newCheckRunResponse(r *influxdb.Run) *checkRunResponse {
+	res := &checkRunResponse{
+		ID:           r.ID,
+		Status:       r.Status,
+		ScheduledFor: r.ScheduledFor,
+		StartedAt:    r.StartedAt,
+		FinishedAt:   r.FinishedAt,
+		Log:          []influxdb.Log{},
+	}
+	for _, l := range r.Log {
+		if s, ok := influxdb.ParseCheckRunSummary(l.Message); ok {
+			res.Summary = s
+		}
+		res.Log = append(res.Log, l)
+	}
+	return res
+}
+
+func decodeGetCheckRunsLimit(r *http.Request) (int, error) {
+	s := r.URL.Query().Get("limit")
+	if s == "" {
+		return 0, nil
+	}
+	limit, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "limit is invalid",
+			Err:  err,
+		}
+	}
+	return limit, nil
+}
+
+// handleGetCheckRuns is the HTTP handler for the GET /api/v2/checks/:id/logs
+// route. It returns the latest runs of the task of a check, the latest
+// first, with their logs and the summary of their outcome.
+func (h *CheckHandler) handleGetCheckRuns(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "check logs retrieve request", r)
+	id, err := decodeGetCheckRequest(ctx, r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	limit, err := decodeGetCheckRunsLimit(r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	c, err := h.CheckService.FindCheckByID(ctx, id)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	res := &checkRunsResponse{
+		CheckID: id,
+		Runs:    []*checkRunResponse{},
+		Links: map[string]string{
+			"self":  fmt.Sprintf("/api/v2/checks/%s/logs", id),
+			"check": fmt.Sprintf("/api/v2/checks/%s", id),
+		},
+	}
+	if tc, ok := c.(tasked); ok && tc.GetTaskID().Valid() {
+		taskID := tc.GetTaskID()
+		runs, _, err := h.TaskService.FindRuns(ctx, influxdb.RunFilter{Task: taskID, Limit: limit})
+		if err != nil {
+			h.HandleHTTPError(ctx, err, w)
+			return
+		}
+		sort.SliceStable(runs, func(i, j int) bool {
+			return runs[i].ScheduledFor > runs[j].ScheduledFor
+		})
+		for _, run := range runs {
+			res.Runs = append(res.Runs, newCheckRunResponse(run))
+		}
+		res.TaskID = &taskID
+		res.Links["task"] = fmt.Sprintf("/api/v2/tasks/%s", taskID)
+	}
+	h.Logger.Debug("check logs retrieved", zap.String("checkID", id.String()), zap.Int("runs", len(res.Runs)))
+
+	if err := encodeResponse(ctx, w, http.StatusOK, res); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
@@ -0,0 +1,111 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/notification/check"
+)
+
+func TestCheckHandler_handleGetCheckRuns(t *testing.T) {
+	summary, err := (&influxdb.CheckRunSummary{
+		Statuses:      map[string]int{"CRIT": 2},
+		Notifications: 1,
+		Suppressions:  map[influxdb.RuleDecision]int{influxdb.RuleDeduplicated: 1},
+	}).LogMessage()
+	if err != nil {
+		t.Fatalf("failed to encode the summary: %v", err)
+	}
+
+	b := NewMockCheckBackend()
+	b.CheckService = &mock.CheckService{
+		FindCheckByIDF: func(ctx context.Context, id influxdb.ID) (influxdb.Check, error) {
+			switch id {
+			case 1:
+				return &check.Threshold{Base: check.Base{ID: id, OrgID: 2, Name: "cpu", TaskID: 3}}, nil
+			case 4:
+				return &check.Heartbeat{Base: check.Base{ID: id, OrgID: 2, Name: "job"}}, nil
+			}
+			return nil, &influxdb.Error{
+				Code: influxdb.ENotFound,
+				Msg:  "check not found",
+			}
+		},
+	}
+	var filter influxdb.RunFilter
+	b.TaskService = &mock.TaskService{
+		FindRunsFn: func(ctx context.Context, f influxdb.RunFilter) ([]*influxdb.Run, int, error) {
+			filter = f
+			return []*influxdb.Run{
+				{ID: 5, TaskID: 3, Status: "failed", ScheduledFor: "2019-10-01T00:00:00Z", Log: []influxdb.Log{{Message: "Failed"}}},
+				{ID: 6, TaskID: 3, Status: "success", ScheduledFor: "2019-10-01T00:01:00Z", Log: []influxdb.Log{{Message: summary}, {Message: "Completed successfully"}}},
+			}, 2, nil
+		},
+	}
+	h := NewCheckHandler(b)
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/api/v2/checks/0000000000000001/logs?limit=10", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var res struct {
+		TaskID string `json:"taskID"`
+		Runs   []struct {
+			ID      string                    `json:"id"`
+			Summary *influxdb.CheckRunSummary `json:"summary"`
+			Log     []influxdb.Log            `json:"log"`
+		} `json:"runs"`
+		Links map[string]string `json:"links"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&res); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if filter.Task != 3 || filter.Limit != 10 {
+		t.Errorf("unexpected run filter %+v", filter)
+	}
+	if res.TaskID != "0000000000000003" || len(res.Runs) != 2 || res.Links["task"] != "/api/v2/tasks/0000000000000003" {
+		t.Fatalf("unexpected check runs %+v", res)
+	}
+	latest := res.Runs[0]
+	if latest.ID != "0000000000000006" || latest.Summary == nil || latest.Summary.Statuses["CRIT"] != 2 ||
+		latest.Summary.Notifications != 1 || latest.Summary.Suppressions[influxdb.RuleDeduplicated] != 1 || len(latest.Log) != 2 {
+		t.Errorf("unexpected latest run %+v", latest)
+	}
+	if res.Runs[1].Summary != nil {
+		t.Errorf("expected the failed run not to be summarized, got %+v", res.Runs[1].Summary)
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/api/v2/checks/0000000000000004/logs", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var empty struct {
+		TaskID *string           `json:"taskID"`
+		Runs   []json.RawMessage `json:"runs"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&empty); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if empty.TaskID != nil || empty.Runs == nil || len(empty.Runs) != 0 {
+		t.Errorf("expected a check without a task to have no run, got %+v", empty)
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/api/v2/checks/0000000000000002/logs", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/api/v2/checks/0000000000000001/logs?limit=x", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
@@ -0,0 +1,1082 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/alerting/validate"
+	pctx "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/kit/tracing"
+	"github.com/influxdata/influxdb/notification/check"
+	"github.com/julienschmidt/httprouter"
+	"go.uber.org/zap"
+)
+
+// CheckBackend is all services and associated parameters required to construct
+// the CheckBackendHandler.
+type CheckBackend struct {
+	influxdb.HTTPErrorHandler
+	Logger *zap.Logger
+
+	CheckService               influxdb.CheckService
+	CheckTransferService       influxdb.CheckTransferService
+	CheckCloneService          influxdb.CheckCloneService
+	CheckBulkUpdateService     influxdb.CheckBulkUpdateService
+	CheckBulkCreateService     influxdb.CheckBulkCreateService
+	CheckTaskReconciler        influxdb.CheckTaskReconciler
+	CheckGCService             influxdb.CheckGCService
+	ExternalStatusService      influxdb.ExternalStatusService
+	CheckPingService           influxdb.CheckPingService
+	CheckPreviewService        influxdb.CheckPreviewService
+	CheckRelatedService        influxdb.CheckRelatedService
+	CheckStatusService         influxdb.CheckStatusService
+	CheckLagService            influxdb.CheckLagService
+	CheckWatchService          influxdb.CheckWatchService
+	CheckApplyService          influxdb.CheckApplyService
+	AlertingQuotaService       influxdb.AlertingQuotaService
+	UserResourceMappingService influxdb.UserResourceMappingService
+	LabelService               influxdb.LabelService
+	UserService                influxdb.UserService
+	OrganizationService        influxdb.OrganizationService
+	TaskService                influxdb.TaskService
+
+	// ViewerRedactionDisabled returns the checks with their query logic to
+	// the users allowed to read them but not to write them.
+	ViewerRedactionDisabled bool
+}
+
+// NewCheckBackend returns a new instance of CheckBackend.
+func NewCheckBackend(b *APIBackend) *CheckBackend {
+	return &CheckBackend{
+		HTTPErrorHandler: b.HTTPErrorHandler,
+		Logger:           b.Logger.With(zap.String("handler", "check")),
+
+		CheckService:               b.CheckService,
+		CheckTransferService:       b.CheckTransferService,
+		CheckCloneService:          b.CheckCloneService,
+		CheckBulkUpdateService:     b.CheckBulkUpdateService,
+		CheckBulkCreateService:     b.CheckBulkCreateService,
+		CheckTaskReconciler:        b.CheckTaskReconciler,
+		CheckGCService:             b.CheckGCService,
+		ExternalStatusService:      b.ExternalStatusService,
+		CheckPingService:           b.CheckPingService,
+		CheckPreviewService:        b.CheckPreviewService,
+		CheckRelatedService:        b.CheckRelatedService,
+		CheckStatusService:         b.CheckStatusService,
+		CheckLagService:            b.CheckLagService,
+		CheckWatchService:          b.CheckWatchService,
+		CheckApplyService:          b.CheckApplyService,
+		AlertingQuotaService:       b.AlertingQuotaService,
+		UserResourceMappingService: b.UserResourceMappingService,
+		LabelService:               b.LabelService,
+		UserService:                b.UserService,
+		OrganizationService:        b.OrganizationService,
+		TaskService:                b.TaskService,
+
+		ViewerRedactionDisabled: b.CheckViewerRedactionDisabled,
+	}
+}
+
+// CheckHandler is the handler for the check service
+type CheckHandler struct {
+	*httprouter.Router
+	influxdb.HTTPErrorHandler
+	Logger *zap.Logger
+
+	CheckService               influxdb.CheckService
+	CheckTransferService       influxdb.CheckTransferService
+	CheckCloneService          influxdb.CheckCloneService
+	CheckBulkUpdateService     influxdb.CheckBulkUpdateService
+	CheckBulkCreateService     influxdb.CheckBulkCreateService
+	CheckTaskReconciler        influxdb.CheckTaskReconciler
+	CheckGCService             influxdb.CheckGCService
+	ExternalStatusService      influxdb.ExternalStatusService
+	CheckPingService           influxdb.CheckPingService
+	CheckPreviewService        influxdb.CheckPreviewService
+	CheckRelatedService        influxdb.CheckRelatedService
+	CheckStatusService         influxdb.CheckStatusService
+	CheckLagService            influxdb.CheckLagService
+	CheckWatchService          influxdb.CheckWatchService
+	CheckApplyService          influxdb.CheckApplyService
+	AlertingQuotaService       influxdb.AlertingQuotaService
+	UserResourceMappingService influxdb.UserResourceMappingService
+	LabelService               influxdb.LabelService
+	UserService                influxdb.UserService
+	OrganizationService        influxdb.OrganizationService
+	TaskService                influxdb.TaskService
+
+	ViewerRedactionDisabled bool
+
+	checkStatuses *checkStatusesCache
+}
+
+const (
+	checksPath                 = "/api/v2/checks"
+	checksIDPath               = "/api/v2/checks/:id"
+	checksIDMembersPath        = "/api/v2/checks/:id/members"
+	checksIDMembersIDPath      = "/api/v2/checks/:id/members/:userID"
+	checksIDOwnersPath         = "/api/v2/checks/:id/owners"
+	checksIDOwnersIDPath       = "/api/v2/checks/:id/owners/:userID"
+	checksIDLabelsPath         = "/api/v2/checks/:id/labels"
+	checksIDLabelsIDPath       = "/api/v2/checks/:id/labels/:lid"
+	checksIDTransferPath       = "/api/v2/checks/:id/transfer"
+	checksIDClonePath          = "/api/v2/checks/:id/clone"
+	checksIDArchivePath        = "/api/v2/checks/:id/archive"
+	checksIDUnarchivePath      = "/api/v2/checks/:id/unarchive"
+	checksIDActivatePath       = "/api/v2/checks/:id/activate"
+	checksIDDeactivatePath     = "/api/v2/checks/:id/deactivate"
+	checksIDExternalStatusPath = "/api/v2/checks/:id/external-status"
+	checksIDPingPath           = "/api/v2/checks/:id/ping"
+	checksIDRelatedPath        = "/api/v2/checks/:id/related"
+	checksIDLagPath            = "/api/v2/checks/:id/lag"
+	checksIDLogsPath           = "/api/v2/checks/:id/logs"
+	checksIDQueryPath          = "/api/v2/checks/:id/query"
+	checksIDStatusesPath       = "/api/v2/checks/:id/statuses"
+	checksBulkUpdatePath       = "/api/v2/checks/bulk-update"
+	checksBulkCreatePath       = "/api/v2/checks/bulk"
+	checksExportPromPath       = "/api/v2/checks/export/prometheus"
+	checksPreviewPath          = "/api/v2/checks/preview"
+	checksReconcilePath        = "/api/v2/checks/reconciliation"
+	checksGCPath               = "/api/v2/checks/gc"
+	checksStatusesPath         = "/api/v2/checks/statuses"
+	checksLagPath              = "/api/v2/checks/lag"
+	checksWatchPath            = "/api/v2/checks/watch"
+	checksByNamePath           = "/api/v2/checks/byname/"
+)
+
+// NewCheckHandler returns a new instance of CheckHandler.
+func NewCheckHandler(b *CheckBackend) *CheckHandler {
+	h := &CheckHandler{
+		Router:           NewRouter(b.HTTPErrorHandler),
+		HTTPErrorHandler: b.HTTPErrorHandler,
+		Logger:           b.Logger,
+
+		CheckService:               b.CheckService,
+		CheckTransferService:       b.CheckTransferService,
+		CheckCloneService:          b.CheckCloneService,
+		CheckBulkUpdateService:     b.CheckBulkUpdateService,
+		CheckBulkCreateService:     b.CheckBulkCreateService,
+		CheckTaskReconciler:        b.CheckTaskReconciler,
+		CheckGCService:             b.CheckGCService,
+		ExternalStatusService:      b.ExternalStatusService,
+		CheckPingService:           b.CheckPingService,
+		CheckPreviewService:        b.CheckPreviewService,
+		CheckRelatedService:        b.CheckRelatedService,
+		CheckStatusService:         b.CheckStatusService,
+		CheckLagService:            b.CheckLagService,
+		CheckWatchService:          b.CheckWatchService,
+		CheckApplyService:          b.CheckApplyService,
+		AlertingQuotaService:       b.AlertingQuotaService,
+		UserResourceMappingService: b.UserResourceMappingService,
+		LabelService:               b.LabelService,
+		UserService:                b.UserService,
+		OrganizationService:        b.OrganizationService,
+		TaskService:                b.TaskService,
+
+		ViewerRedactionDisabled: b.ViewerRedactionDisabled,
+
+		checkStatuses: newCheckStatusesCache(),
+	}
+	h.HandlerFunc("POST", checksPath, h.handlePostCheck)
+	h.HandlerFunc("GET", checksPath, h.handleGetChecks)
+	h.HandlerFunc("GET", checksIDPath, h.handleGetCheck)
+	h.HandlerFunc("DELETE", checksIDPath, h.handleDeleteCheck)
+	h.HandlerFunc("PUT", checksIDPath, h.handlePutCheck)
+	h.HandlerFunc("PATCH", checksIDPath, h.handlePatchCheck)
+	h.HandlerFunc("POST", checksIDTransferPath, h.handlePostCheckTransfer)
+	h.HandlerFunc("POST", checksIDClonePath, h.handlePostCheckClone)
+	h.HandlerFunc("POST", checksIDArchivePath, h.handlePostCheckArchive)
+	h.HandlerFunc("POST", checksIDUnarchivePath, h.handlePostCheckUnarchive)
+	h.HandlerFunc("POST", checksIDActivatePath, h.handlePostCheckActivate)
+	h.HandlerFunc("POST", checksIDDeactivatePath, h.handlePostCheckDeactivate)
+	h.HandlerFunc("POST", checksIDExternalStatusPath, h.handlePostCheckExternalStatus)
+	h.HandlerFunc("POST", checksIDPingPath, h.handlePostCheckPing)
+	h.HandlerFunc("GET", checksIDRelatedPath, h.handleGetCheckRelated)
+	h.HandlerFunc("GET", checksIDLagPath, h.handleGetCheckLag)
+	h.HandlerFunc("GET", checksIDLogsPath, h.handleGetCheckRuns)
+	h.HandlerFunc("GET", checksIDQueryPath, h.handleGetCheckQuery)
+	h.HandlerFunc("GET", checksIDStatusesPath, h.handleGetCheckStatusHistory)
+
+	memberBackend := MemberBackend{
+		HTTPErrorHandler:           b.HTTPErrorHandler,
+		Logger:                     b.Logger.With(zap.String("handler", "member")),
+		ResourceType:               influxdb.ChecksResourceType,
+		UserType:                   influxdb.Member,
+		UserResourceMappingService: b.UserResourceMappingService,
+		UserService:                b.UserService,
+	}
+	h.HandlerFunc("POST", checksIDMembersPath, newPostMemberHandler(memberBackend))
+	h.HandlerFunc("GET", checksIDMembersPath, newGetMembersHandler(memberBackend))
+	h.HandlerFunc("DELETE", checksIDMembersIDPath, newDeleteMemberHandler(memberBackend))
+
+	ownerBackend := MemberBackend{
+		HTTPErrorHandler:           b.HTTPErrorHandler,
+		Logger:                     b.Logger.With(zap.String("handler", "member")),
+		ResourceType:               influxdb.ChecksResourceType,
+		UserType:                   influxdb.Owner,
+		UserResourceMappingService: b.UserResourceMappingService,
+		UserService:                b.UserService,
+	}
+	h.HandlerFunc("POST", checksIDOwnersPath, newPostMemberHandler(ownerBackend))
+	h.HandlerFunc("GET", checksIDOwnersPath, newGetMembersHandler(ownerBackend))
+	h.HandlerFunc("DELETE", checksIDOwnersIDPath, newDeleteMemberHandler(ownerBackend))
+
+	labelBackend := &LabelBackend{
+		HTTPErrorHandler: b.HTTPErrorHandler,
+		Logger:           b.Logger.With(zap.String("handler", "label")),
+		LabelService:     b.LabelService,
+		ResourceType:     influxdb.ChecksResourceType,
+	}
+	h.HandlerFunc("GET", checksIDLabelsPath, newGetLabelsHandler(labelBackend))
+	h.HandlerFunc("POST", checksIDLabelsPath, newPostLabelHandler(labelBackend))
+	h.HandlerFunc("DELETE", checksIDLabelsIDPath, newDeleteLabelHandler(labelBackend))
+
+	return h
+}
+
+// ServeHTTP serves the static routes of the checks before the router, whose
+// routes of the checks already have a wildcard where these paths are static.
+func (h *CheckHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Method == "POST" && r.URL.Path == checksBulkUpdatePath:
+		h.handlePostChecksBulkUpdate(w, r)
+	case r.Method == "POST" && r.URL.Path == checksBulkCreatePath:
+		h.handlePostChecksBulkCreate(w, r)
+	case r.Method == "GET" && r.URL.Path == checksExportPromPath:
+		h.handleGetChecksPrometheusRules(w, r)
+	case r.Method == "GET" && r.URL.Path == checksReconcilePath:
+		h.handleGetCheckTaskReconciliation(w, r)
+	case r.Method == "GET" && r.URL.Path == checksGCPath:
+		h.handleGetCheckGCEvents(w, r)
+	case r.Method == "POST" && r.URL.Path == checksPreviewPath:
+		h.handlePostCheckPreview(w, r)
+	case r.Method == "GET" && r.URL.Path == checksStatusesPath:
+		h.handleGetCheckStatuses(w, r)
+	case r.Method == "GET" && r.URL.Path == checksLagPath:
+		h.handleGetCheckLagSummary(w, r)
+	case r.Method == "GET" && r.URL.Path == checksWatchPath:
+		h.handleGetChecksWatch(w, r)
+	case r.Method == "PUT" && strings.HasPrefix(r.URL.Path, checksByNamePath):
+		h.handlePutCheckByName(w, r)
+	default:
+		h.Router.ServeHTTP(w, r)
+	}
+}
+
+type checkLinks struct {
+	Self     string `json:"self"`
+	Labels   string `json:"labels"`
+	Members  string `json:"members"`
+	Owners   string `json:"owners"`
+	Query    string `json:"query"`
+	Statuses string `json:"statuses"`
+}
+
+type checkResponse struct {
+	influxdb.Check
+	Labels []influxdb.Label `json:"labels"`
+	Links  checkLinks       `json:"links"`
+	// Task is only set if the request includes the task of the check.
+	Task *checkTaskResponse `json:"task,omitempty"`
+	// ManagedFields are only set by an apply of the check.
+	ManagedFields []influxdb.CheckManagedField `json:"managedFields,omitempty"`
+	// Warnings are only set by a create of a check of an organization near
+	// its quota of checks.
+	Warnings []influxdb.QuotaWarning `json:"warnings,omitempty"`
+	// codec encodes the check in the media type of the response, nil for
+	// the current model.
+	codec *checkCodec
+}
+
+func (resp checkResponse) MarshalJSON() ([]byte, error) {
+	b1, err := json.Marshal(resp.Check)
+	if err != nil {
+		return nil, err
+	}
+	if resp.codec != nil && resp.codec.downcast != nil {
+		if b1, err = resp.codec.downcast(b1); err != nil {
+			return nil, err
+		}
+	}
+
+	b2, err := json.Marshal(struct {
+		Labels        []influxdb.Label             `json:"labels"`
+		Links         checkLinks                   `json:"links"`
+		Task          *checkTaskResponse           `json:"task,omitempty"`
+		ManagedFields []influxdb.CheckManagedField `json:"managedFields,omitempty"`
+		Warnings      []influxdb.QuotaWarning      `json:"warnings,omitempty"`
+	}{
+		Links:         resp.Links,
+		Labels:        resp.Labels,
+		Task:          resp.Task,
+		ManagedFields: resp.ManagedFields,
+		Warnings:      resp.Warnings,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(string(b1[:len(b1)-1]) + ", " + string(b2[1:])), nil
+}
+
+type checksResponse struct {
+	Checks []*checkResponse      `json:"checks"`
+	Links  *influxdb.PagingLinks `json:"links"`
+	// TotalCount is the number of the checks matching the filter, across
+	// every page.
+	TotalCount int `json:"totalCount"`
+	// Warnings are only set for the checks of an organization near its
+	// quota of checks.
+	Warnings []influxdb.QuotaWarning `json:"warnings,omitempty"`
+}
+
+func newCheckResponse(c influxdb.Check, labels []*influxdb.Label) *checkResponse {
+	res := &checkResponse{
+		Check: c,
+		Links: checkLinks{
+			Self:     fmt.Sprintf("/api/v2/checks/%s", c.GetID()),
+			Labels:   fmt.Sprintf("/api/v2/checks/%s/labels", c.GetID()),
+			Members:  fmt.Sprintf("/api/v2/checks/%s/members", c.GetID()),
+			Owners:   fmt.Sprintf("/api/v2/checks/%s/owners", c.GetID()),
+			Query:    fmt.Sprintf("/api/v2/checks/%s/query", c.GetID()),
+			Statuses: fmt.Sprintf("/api/v2/checks/%s/statuses", c.GetID()),
+		},
+		Labels: []influxdb.Label{},
+	}
+
+	for _, l := range labels {
+		res.Labels = append(res.Labels, *l)
+	}
+
+	return res
+}
+
+// newChecksResponse returns a page of the n checks matching a filter, which
+// links to the next page only if there are checks past it.
+func newChecksResponse(ctx context.Context, cs []influxdb.Check, n int, labelService influxdb.LabelService, f influxdb.PagingFilter, opts influxdb.FindOptions) *checksResponse {
+	resp := &checksResponse{
+		Checks:     make([]*checkResponse, len(cs)),
+		Links:      newPagingLinks(checksPath, opts, f, len(cs)),
+		TotalCount: n,
+	}
+	if opts.Offset+len(cs) >= n {
+		resp.Links.Next = ""
+	}
+	for i, c := range cs {
+		labels, _ := labelService.FindResourceLabels(ctx, influxdb.LabelMappingFilter{ResourceID: c.GetID()})
+		resp.Checks[i] = newCheckResponse(c, labels)
+	}
+	return resp
+}
+
+func decodeGetCheckRequest(ctx context.Context, r *http.Request) (i influxdb.ID, err error) {
+	params := httprouter.ParamsFromContext(ctx)
+	id := params.ByName("id")
+	if id == "" {
+		return i, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "url missing id",
+		}
+	}
+
+	if err := i.DecodeFromString(id); err != nil {
+		return i, err
+	}
+	return i, nil
+}
+
+func (h *CheckHandler) handleGetChecks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "checks retrieve request", r)
+	filter, opts, err := decodeCheckFilter(ctx, r)
+	if err != nil {
+		h.Logger.Debug("failed to decode request", zap.Error(err))
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	includeTask, err := decodeCheckInclude(r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	cs, n, err := h.CheckService.FindChecks(ctx, *filter, *opts)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	debugResult(h.Logger, "checks retrieved", "checks", cs, zap.Int("total", n))
+
+	if acceptsChecksCSV(r) {
+		w.Header().Add("Vary", "Accept")
+		w.Header().Set("Content-Type", checkCSVMediaType+"; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		if err := WriteChecksCSV(w, h.viewChecks(ctx, cs)); err != nil {
+			logEncodingError(h.Logger, r, err)
+		}
+		return
+	}
+
+	resp := newChecksResponse(ctx, h.viewChecks(ctx, cs), n, h.LabelService, filter, *opts)
+	resp.Warnings = findFilterQuotaWarnings(ctx, h.AlertingQuotaService, h.OrganizationService, h.Logger, filter.OrgID, filter.Org, influxdb.ChecksResourceType)
+	if includeTask {
+		if err := h.decorateCheckTasks(ctx, resp.Checks); err != nil {
+			h.HandleHTTPError(ctx, err, w)
+			return
+		}
+	}
+	codec := responseCheckCodec(r)
+	codec.apply(resp.Checks...)
+	if err := encodeCheckResponse(ctx, w, codec, http.StatusOK, resp); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+func (h *CheckHandler) handleGetCheck(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "check retrieve request", r)
+	id, err := decodeGetCheckRequest(ctx, r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	includeTask, err := decodeCheckInclude(r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	c, err := h.CheckService.FindCheckByID(ctx, id)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	debugResult(h.Logger, "check retrieved", "check", c)
+
+	labels, err := h.LabelService.FindResourceLabels(ctx, influxdb.LabelMappingFilter{ResourceID: c.GetID()})
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	resp := newCheckResponse(h.viewCheck(ctx, c), labels)
+	if includeTask {
+		if err := h.decorateCheckTasks(ctx, []*checkResponse{resp}); err != nil {
+			h.HandleHTTPError(ctx, err, w)
+			return
+		}
+	}
+	codec := responseCheckCodec(r)
+	codec.apply(resp)
+	if err := encodeCheckResponse(ctx, w, codec, http.StatusOK, resp); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+func decodeCheckFilter(ctx context.Context, r *http.Request) (*influxdb.CheckFilter, *influxdb.FindOptions, error) {
+	f := &influxdb.CheckFilter{}
+
+	opts, err := decodeFindOptions(ctx, r)
+	if err != nil {
+		return f, nil, err
+	}
+
+	q := r.URL.Query()
+	if orgIDStr := q.Get("orgID"); orgIDStr != "" {
+		orgID, err := influxdb.IDFromString(orgIDStr)
+		if err != nil {
+			return f, opts, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "orgID is invalid",
+				Err:  err,
+			}
+		}
+		f.OrgID = orgID
+	} else if orgNameStr := q.Get("org"); orgNameStr != "" {
+		f.Org = &orgNameStr
+	} else {
+		return f, opts, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "Please provide either orgID or org",
+		}
+	}
+	if name := q.Get("name"); name != "" {
+		f.Name = &name
+	}
+	if archived := q.Get("archived"); archived != "" {
+		a, err := strconv.ParseBool(archived)
+		if err != nil {
+			return f, opts, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "archived is invalid",
+			}
+		}
+		f.Archived = a
+	}
+	for _, idStr := range q["id"] {
+		id, err := influxdb.IDFromString(idStr)
+		if err != nil {
+			return f, opts, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "id is invalid",
+				Err:  err,
+			}
+		}
+		f.IDs = append(f.IDs, id)
+	}
+	return f, opts, nil
+}
+
+// decodeCheckBody decodes the check of the request in its media type,
+// upcasting the older shapes of checks, and returns the deprecations of
+// the shapes.
+func decodeCheckBody(ctx context.Context, r *http.Request) (influxdb.Check, []string, error) {
+	codec, err := requestCheckCodec(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	buf := new(bytes.Buffer)
+	if _, err := buf.ReadFrom(r.Body); err != nil {
+		return nil, nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Err:  err,
+		}
+	}
+	defer r.Body.Close()
+	c, deprecations, err := unmarshalCheckJSON(codec, buf.Bytes())
+	if err != nil {
+		return nil, nil, err
+	}
+	return c, deprecations, nil
+}
+
+// UnmarshalCheckJSON converts the json of a check in the current model or
+// in one of its older shapes, and returns the deprecations of the shapes.
+func UnmarshalCheckJSON(b []byte) (influxdb.Check, []string, error) {
+	return unmarshalCheckJSON(jsonCheckCodec, b)
+}
+
+// unmarshalCheckJSON converts the json of a check of the media type of a codec.
+func unmarshalCheckJSON(codec *checkCodec, b []byte) (influxdb.Check, []string, error) {
+	b, deprecations, err := codec.upcast(b)
+	if err != nil {
+		return nil, nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Err:  err,
+		}
+	}
+	c, err := check.UnmarshalJSON(b)
+	if err != nil {
+		return nil, nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Err:  err,
+		}
+	}
+	return c, deprecations, nil
+}
+
+// invalidResourceError converts the error of the validation of a submitted
+// check, notification endpoint or notification rule.
+func invalidResourceError(err error) error {
+	e := &influxdb.Error{
+		Code: influxdb.EInvalid,
+		Err:  err,
+	}
+	if ve, ok := err.(*validate.Error); ok {
+		e.Msg = ve.Msg
+	}
+	return e
+}
+
+func decodePostCheckRequest(ctx context.Context, r *http.Request) (influxdb.Check, []string, error) {
+	c, deprecations, err := decodeCheckBody(ctx, r)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := validate.Check(c, validate.Create); err != nil {
+		return nil, nil, invalidResourceError(err)
+	}
+	return c, deprecations, nil
+}
+
+func decodePutCheckRequest(ctx context.Context, r *http.Request) (influxdb.Check, []string, error) {
+	c, deprecations, err := decodeCheckBody(ctx, r)
+	if err != nil {
+		return nil, nil, err
+	}
+	i, err := decodeGetCheckRequest(ctx, r)
+	if err != nil {
+		return nil, nil, err
+	}
+	c.SetID(i)
+	if err := validate.Check(c, validate.Update); err != nil {
+		return nil, nil, invalidResourceError(err)
+	}
+	return c, deprecations, nil
+}
+
+type patchCheckRequest struct {
+	influxdb.ID
+	Update influxdb.CheckUpdate
+}
+
+func decodePatchCheckRequest(ctx context.Context, r *http.Request) (*patchCheckRequest, error) {
+	i, err := decodeGetCheckRequest(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	upd := &influxdb.CheckUpdate{}
+	if err := json.NewDecoder(r.Body).Decode(upd); err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  err.Error(),
+		}
+	}
+	if err := upd.Valid(); err != nil {
+		return nil, err
+	}
+
+	return &patchCheckRequest{
+		ID:     i,
+		Update: *upd,
+	}, nil
+}
+
+// handlePostCheck is the HTTP handler for the POST /api/v2/checks route.
+func (h *CheckHandler) handlePostCheck(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "check create request", r)
+	ctx, dryRun, err := decodeDryRun(ctx, r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	c, deprecations, err := decodePostCheckRequest(ctx, r)
+	if err != nil {
+		h.Logger.Debug("failed to decode request", zap.Error(err))
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	setDeprecationHeaders(w, deprecations)
+	auth, err := pctx.GetAuthorizer(ctx)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	if err := h.CheckService.CreateCheck(ctx, c, auth.GetUserID()); err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	resp := newCheckResponse(c, []*influxdb.Label{})
+	codec := responseCheckCodec(r)
+	codec.apply(resp)
+	if dryRun != nil {
+		if err := encodeDryRunResponse(ctx, w, resp, dryRun); err != nil {
+			logEncodingError(h.Logger, r, err)
+		}
+		return
+	}
+	debugResult(h.Logger, "check created", "check", c)
+	resp.Warnings = findQuotaWarnings(ctx, h.AlertingQuotaService, h.Logger, c.GetOrgID(), influxdb.ChecksResourceType)
+
+	if err := encodeCheckResponse(ctx, w, codec, http.StatusCreated, resp); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+// handlePutCheck is the HTTP handler for the PUT /api/v2/checks/:id route.
+func (h *CheckHandler) handlePutCheck(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "check update request", r)
+	ctx, dryRun, err := decodeDryRun(ctx, r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	c, deprecations, err := decodePutCheckRequest(ctx, r)
+	if err != nil {
+		h.Logger.Debug("failed to decode request", zap.Error(err))
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	setDeprecationHeaders(w, deprecations)
+
+	c, err = h.CheckService.UpdateCheck(ctx, c.GetID(), c)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	labels, err := h.LabelService.FindResourceLabels(ctx, influxdb.LabelMappingFilter{ResourceID: c.GetID()})
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	resp := newCheckResponse(c, labels)
+	codec := responseCheckCodec(r)
+	codec.apply(resp)
+	if dryRun != nil {
+		if err := encodeDryRunResponse(ctx, w, resp, dryRun); err != nil {
+			logEncodingError(h.Logger, r, err)
+		}
+		return
+	}
+	debugResult(h.Logger, "check updated", "check", c)
+
+	if err := encodeCheckResponse(ctx, w, codec, http.StatusOK, resp); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+// handlePatchCheck is the HTTP handler for the PATCH /api/v2/checks/:id route.
+func (h *CheckHandler) handlePatchCheck(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "check patch request", r)
+	ctx, dryRun, err := decodeDryRun(ctx, r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	req, err := decodePatchCheckRequest(ctx, r)
+	if err != nil {
+		h.Logger.Debug("failed to decode request", zap.Error(err))
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	c, err := h.CheckService.PatchCheck(ctx, req.ID, req.Update)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	labels, err := h.LabelService.FindResourceLabels(ctx, influxdb.LabelMappingFilter{ResourceID: c.GetID()})
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	resp := newCheckResponse(c, labels)
+	codec := responseCheckCodec(r)
+	codec.apply(resp)
+	if dryRun != nil {
+		if err := encodeDryRunResponse(ctx, w, resp, dryRun); err != nil {
+			logEncodingError(h.Logger, r, err)
+		}
+		return
+	}
+	debugResult(h.Logger, "check patch", "check", c)
+
+	if err := encodeCheckResponse(ctx, w, codec, http.StatusOK, resp); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+func (h *CheckHandler) handleDeleteCheck(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "check delete request", r)
+	ctx, dryRun, err := decodeDryRun(ctx, r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	i, err := decodeGetCheckRequest(ctx, r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	if err = h.CheckService.DeleteCheck(ctx, i); err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	if dryRun != nil {
+		if err := encodeDryRunResponse(ctx, w, nil, dryRun); err != nil {
+			logEncodingError(h.Logger, r, err)
+		}
+		return
+	}
+	h.Logger.Debug("check deleted", zap.Stringer("checkID", i))
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// CheckService connects to Influx via HTTP using tokens to manage checks.
+type CheckService struct {
+	Addr               string
+	Token              string
+	InsecureSkipVerify bool
+	// Options configures the retries, circuit breaking, timeout and
+	// transport of the calls, each request is sent once by default.
+	Options ClientOptions
+}
+
+var _ influxdb.CheckService = (*CheckService)(nil)
+
+// FindCheckByID returns a single check by ID.
+func (s *CheckService) FindCheckByID(ctx context.Context, id influxdb.ID) (influxdb.Check, error) {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	u, err := NewURL(s.Addr, checkIDPath(id))
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	SetToken(s.Token, req)
+
+	resp, err := s.Options.do(ctx, u.Scheme, s.InsecureSkipVerify, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return decodeCheckResponse(resp)
+}
+
+// FindCheck returns the first check that matches filter.
+func (s *CheckService) FindCheck(ctx context.Context, filter influxdb.CheckFilter) (influxdb.Check, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	if filter.ID != nil {
+		return s.FindCheckByID(ctx, *filter.ID)
+	}
+
+	cs, n, err := s.FindChecks(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if n == 0 {
+		return nil, &influxdb.Error{
+			Code: influxdb.ENotFound,
+			Msg:  "check not found",
+		}
+	}
+	return cs[0], nil
+}
+
+// FindChecks returns a list of checks that match filter and the total count of matching checks.
+// Additional options provide pagination & sorting.
+func (s *CheckService) FindChecks(ctx context.Context, filter influxdb.CheckFilter, opt ...influxdb.FindOptions) ([]influxdb.Check, int, error) {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	u, err := NewURL(s.Addr, checksPath)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := u.Query()
+	for k, vs := range filter.QueryParams() {
+		for _, v := range vs {
+			query.Add(k, v)
+		}
+	}
+	if filter.Name != nil {
+		query.Add("name", *filter.Name)
+	}
+	if len(opt) > 0 {
+		for k, vs := range opt[0].QueryParams() {
+			for _, v := range vs {
+				query.Add(k, v)
+			}
+		}
+	}
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	req.URL.RawQuery = query.Encode()
+	SetToken(s.Token, req)
+
+	resp, err := s.Options.do(ctx, u.Scheme, s.InsecureSkipVerify, req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return nil, 0, err
+	}
+
+	var cr struct {
+		Checks     []json.RawMessage `json:"checks"`
+		TotalCount *int              `json:"totalCount"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&cr); err != nil {
+		return nil, 0, err
+	}
+
+	cs := make([]influxdb.Check, 0, len(cr.Checks))
+	for _, b := range cr.Checks {
+		c, err := check.UnmarshalJSON(b)
+		if err != nil {
+			return nil, 0, err
+		}
+		cs = append(cs, c)
+	}
+	// the servers predating the total count only return the page.
+	if cr.TotalCount == nil {
+		return cs, len(cs), nil
+	}
+	return cs, *cr.TotalCount, nil
+}
+
+// CreateCheck creates a new check and sets c.ID with the new identifier.
+// The check is owned by the user of the token, userID is ignored.
+func (s *CheckService) CreateCheck(ctx context.Context, c influxdb.Check, userID influxdb.ID) error {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	u, err := NewURL(s.Addr, checksPath)
+	if err != nil {
+		return err
+	}
+
+	octets, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", u.String(), bytes.NewReader(octets))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	SetToken(s.Token, req)
+
+	resp, err := s.Options.do(ctx, u.Scheme, s.InsecureSkipVerify, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return err
+	}
+	// the check is decoded in place to set the fields set by the server, such as its ID.
+	return json.NewDecoder(resp.Body).Decode(c)
+}
+
+// UpdateCheck updates the whole check.
+// Returns the new check state after update.
+func (s *CheckService) UpdateCheck(ctx context.Context, id influxdb.ID, c influxdb.Check) (influxdb.Check, error) {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	octets, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	return s.sendCheck(ctx, "PUT", checkIDPath(id), octets)
+}
+
+// PatchCheck updates a single check with changeset.
+// Returns the new check state after update.
+func (s *CheckService) PatchCheck(ctx context.Context, id influxdb.ID, upd influxdb.CheckUpdate) (influxdb.Check, error) {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	octets, err := json.Marshal(upd)
+	if err != nil {
+		return nil, err
+	}
+	return s.sendCheck(ctx, "PATCH", checkIDPath(id), octets)
+}
+
+// ArchiveCheck hides a check from the checks found by default and stops its task.
+func (s *CheckService) ArchiveCheck(ctx context.Context, id influxdb.ID) (influxdb.Check, error) {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	return s.sendCheck(ctx, "POST", path.Join(checkIDPath(id), "archive"), nil)
+}
+
+// UnarchiveCheck restores an archived check.
+func (s *CheckService) UnarchiveCheck(ctx context.Context, id influxdb.ID) (influxdb.Check, error) {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	return s.sendCheck(ctx, "POST", path.Join(checkIDPath(id), "unarchive"), nil)
+}
+
+// DeleteCheck removes a check by ID.
+func (s *CheckService) DeleteCheck(ctx context.Context, id influxdb.ID) error {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	u, err := NewURL(s.Addr, checkIDPath(id))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("DELETE", u.String(), nil)
+	if err != nil {
+		return err
+	}
+	SetToken(s.Token, req)
+
+	resp, err := s.Options.do(ctx, u.Scheme, s.InsecureSkipVerify, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return CheckError(resp)
+}
+
+// sendCheck sends a request with a JSON body to a check route returning the check.
+func (s *CheckService) sendCheck(ctx context.Context, method, p string, octets []byte) (influxdb.Check, error) {
+	u, err := NewURL(s.Addr, p)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(method, u.String(), bytes.NewReader(octets))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	SetToken(s.Token, req)
+
+	resp, err := s.Options.do(ctx, u.Scheme, s.InsecureSkipVerify, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return decodeCheckResponse(resp)
+}
+
+// decodeCheckResponse decodes the check of a response, ignoring its labels and links.
+func decodeCheckResponse(resp *http.Response) (influxdb.Check, error) {
+	if err := CheckError(resp); err != nil {
+		return nil, err
+	}
+	buf := new(bytes.Buffer)
+	if _, err := buf.ReadFrom(resp.Body); err != nil {
+		return nil, err
+	}
+	return check.UnmarshalJSON(buf.Bytes())
+}
+
+func checkIDPath(id influxdb.ID) string {
+	return path.Join(checksPath, id.String())
+}
@@ -0,0 +1,546 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb"
+	pcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/check"
+	"go.uber.org/zap"
+)
+
+// NewMockCheckBackend returns a CheckBackend with mock services.
+func NewMockCheckBackend() *CheckBackend {
+	return &CheckBackend{
+		HTTPErrorHandler: ErrorHandler(0),
+		Logger:           zap.NewNop().With(zap.String("handler", "check")),
+
+		CheckService:               &mock.CheckService{},
+		UserResourceMappingService: mock.NewUserResourceMappingService(),
+		LabelService:               mock.NewLabelService(),
+		UserService:                mock.NewUserService(),
+		OrganizationService:        mock.NewOrganizationService(),
+		TaskService:                &mock.TaskService{},
+	}
+}
+
+func TestCheckHandler_handleGetCheck(t *testing.T) {
+	b := NewMockCheckBackend()
+	b.CheckService = &mock.CheckService{
+		FindCheckByIDF: func(ctx context.Context, id influxdb.ID) (influxdb.Check, error) {
+			if id != influxdb.ID(1) {
+				return nil, &influxdb.Error{
+					Code: influxdb.ENotFound,
+					Msg:  "check not found",
+				}
+			}
+			return &check.Deadman{
+				Base: check.Base{
+					ID:     influxdb.ID(1),
+					OrgID:  influxdb.ID(2),
+					Name:   "heartbeat",
+					Status: influxdb.Active,
+					Every:  influxdb.Duration{Duration: time.Minute},
+					Query: influxdb.DashboardQuery{
+						Text: `from(bucket: "telegraf") |> range(start: -5m)`,
+					},
+				},
+				TimeSince: 90,
+				Level:     notification.Critical,
+			}, nil
+		},
+	}
+	h := NewCheckHandler(b)
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/api/v2/checks/0000000000000001", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var got struct {
+		ID     string           `json:"id"`
+		Type   string           `json:"type"`
+		Level  string           `json:"level"`
+		Labels []influxdb.Label `json:"labels"`
+		Links  checkLinks       `json:"links"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if got.ID != "0000000000000001" || got.Type != "deadman" || got.Level != "CRIT" {
+		t.Errorf("unexpected check %+v", got)
+	}
+	if got.Labels == nil {
+		t.Errorf("expected labels to be an empty list")
+	}
+	if got.Links.Self != "/api/v2/checks/0000000000000001" || got.Links.Labels != "/api/v2/checks/0000000000000001/labels" {
+		t.Errorf("unexpected links %+v", got.Links)
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/api/v2/checks/0000000000000003", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestCheckHandler_handleGetChecks_requiresOrg(t *testing.T) {
+	h := NewCheckHandler(NewMockCheckBackend())
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/api/v2/checks", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestCheckHandler_handleGetChecks_byIDs(t *testing.T) {
+	b := NewMockCheckBackend()
+	var got influxdb.CheckFilter
+	b.CheckService = &mock.CheckService{
+		FindChecksF: func(ctx context.Context, filter influxdb.CheckFilter, opt ...influxdb.FindOptions) ([]influxdb.Check, int, error) {
+			got = filter
+			return []influxdb.Check{}, 0, nil
+		},
+	}
+	h := NewCheckHandler(b)
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/api/v2/checks?orgID=0000000000000002&id=0000000000000001&id=0000000000000003", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if len(got.IDs) != 2 || *got.IDs[0] != influxdb.ID(1) || *got.IDs[1] != influxdb.ID(3) {
+		t.Errorf("expected the checks to be filtered by ids 1 and 3, got %v", got.IDs)
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/api/v2/checks?orgID=0000000000000002&id=nope", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestCheckHandler_handleGetChecks_paging(t *testing.T) {
+	b := NewMockCheckBackend()
+	var got influxdb.FindOptions
+	b.CheckService = &mock.CheckService{
+		FindChecksF: func(ctx context.Context, filter influxdb.CheckFilter, opt ...influxdb.FindOptions) ([]influxdb.Check, int, error) {
+			got = opt[0]
+			cs := make([]influxdb.Check, 0, got.Limit)
+			for i := got.Offset; i < got.Offset+got.Limit && i < 5; i++ {
+				cs = append(cs, &check.Deadman{Base: check.Base{ID: influxdb.ID(i + 1), OrgID: 2, Name: "heartbeat"}})
+			}
+			return cs, 5, nil
+		},
+	}
+	h := NewCheckHandler(b)
+
+	tests := []struct {
+		query string
+		opts  influxdb.FindOptions
+		num   int
+		links influxdb.PagingLinks
+	}{
+		{
+			query: "orgID=0000000000000002&offset=2&limit=2&sortBy=name&descending=true",
+			opts:  influxdb.FindOptions{Offset: 2, Limit: 2, SortBy: "name", Descending: true},
+			num:   2,
+			links: influxdb.PagingLinks{
+				Prev: "/api/v2/checks?descending=true&limit=2&offset=0&orgID=0000000000000002&sortBy=name",
+				Self: "/api/v2/checks?descending=true&limit=2&offset=2&orgID=0000000000000002&sortBy=name",
+				Next: "/api/v2/checks?descending=true&limit=2&offset=4&orgID=0000000000000002&sortBy=name",
+			},
+		},
+		{
+			query: "orgID=0000000000000002&offset=3&limit=2",
+			opts:  influxdb.FindOptions{Offset: 3, Limit: 2},
+			num:   2,
+			links: influxdb.PagingLinks{
+				Prev: "/api/v2/checks?descending=false&limit=2&offset=1&orgID=0000000000000002",
+				Self: "/api/v2/checks?descending=false&limit=2&offset=3&orgID=0000000000000002",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest("GET", "/api/v2/checks?"+tt.query, nil))
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+			}
+			if got != tt.opts {
+				t.Errorf("expected find options %+v, got %+v", tt.opts, got)
+			}
+			var res struct {
+				Checks     []json.RawMessage    `json:"checks"`
+				Links      influxdb.PagingLinks `json:"links"`
+				TotalCount int                  `json:"totalCount"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&res); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if len(res.Checks) != tt.num || res.TotalCount != 5 {
+				t.Errorf("expected %d of 5 checks, got %d of %d", tt.num, len(res.Checks), res.TotalCount)
+			}
+			if diff := cmp.Diff(tt.links, res.Links); diff != "" {
+				t.Errorf("unexpected links -want/+got\n%s", diff)
+			}
+		})
+	}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/api/v2/checks?orgID=0000000000000002&offset=x", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestCheckHandler_handleGetCheck_includeTask(t *testing.T) {
+	b := NewMockCheckBackend()
+	b.CheckService = &mock.CheckService{
+		FindCheckByIDF: func(ctx context.Context, id influxdb.ID) (influxdb.Check, error) {
+			return &check.Deadman{
+				Base: check.Base{
+					ID:     influxdb.ID(1),
+					OrgID:  influxdb.ID(2),
+					Name:   "heartbeat",
+					Status: influxdb.Active,
+					Every:  influxdb.Duration{Duration: time.Minute},
+					Query: influxdb.DashboardQuery{
+						Text: `from(bucket: "telegraf") |> range(start: -5m)`,
+					},
+					TaskID: influxdb.ID(3),
+				},
+				TimeSince: 90,
+				Level:     notification.Critical,
+			}, nil
+		},
+	}
+	b.TaskService = &mock.TaskService{
+		FindTasksFn: func(ctx context.Context, filter influxdb.TaskFilter) ([]*influxdb.Task, int, error) {
+			if filter.OrganizationID == nil || *filter.OrganizationID != influxdb.ID(2) {
+				t.Errorf("expected the tasks of org 2 to be found, got %v", filter.OrganizationID)
+			}
+			return []*influxdb.Task{
+				{ID: influxdb.ID(3), Status: "active", Every: "1m", LatestCompleted: "2019-07-01T00:00:00Z"},
+				{ID: influxdb.ID(4), Status: "active"},
+			}, 2, nil
+		},
+		FindRunsFn: func(ctx context.Context, filter influxdb.RunFilter) ([]*influxdb.Run, int, error) {
+			return []*influxdb.Run{
+				{TaskID: filter.Task, Status: "failed", ScheduledFor: "2019-07-01T00:01:00Z", Log: []influxdb.Log{{Message: "bucket not found"}}},
+				{TaskID: filter.Task, Status: "success", ScheduledFor: "2019-07-01T00:00:00Z"},
+			}, 2, nil
+		},
+	}
+	h := NewCheckHandler(b)
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/api/v2/checks/0000000000000001?include=task", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var got struct {
+		Task *checkTaskResponse `json:"task"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	want := &checkTaskResponse{
+		ID:              influxdb.ID(3),
+		Status:          "active",
+		Every:           "1m",
+		LatestCompleted: "2019-07-01T00:00:00Z",
+		Errors:          []string{"bucket not found"},
+	}
+	if diff := cmp.Diff(got.Task, want); diff != "" {
+		t.Errorf("task is different -got/+want\ndiff %s", diff)
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/api/v2/checks/0000000000000001?include=runs", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestCheckHandler_handlePostCheck_invalid(t *testing.T) {
+	b := NewMockCheckBackend()
+	b.CheckService = &mock.CheckService{
+		CreateCheckF: func(ctx context.Context, c influxdb.Check, userID influxdb.ID) error {
+			t.Fatal("expected the invalid check not to be created")
+			return nil
+		},
+	}
+	h := NewCheckHandler(b)
+
+	body := `{"type": "deadman", "name": "heartbeat", "orgID": "0000000000000002", "status": "active", "every": "1m", "timeSince": 90}`
+	r := httptest.NewRequest("POST", "/api/v2/checks", bytes.NewBufferString(body))
+	r = r.WithContext(pcontext.SetAuthorizer(r.Context(), &influxdb.Session{UserID: influxdb.ID(6)}))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusBadRequest, w.Code, w.Body.String())
+	}
+	var got struct {
+		Message string `json:"message"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if got.Message != "Check Query can't be empty" {
+		t.Errorf("unexpected error message %q", got.Message)
+	}
+}
+
+func TestCheckHandler_handlePostCheck_typed(t *testing.T) {
+	var created influxdb.Check
+	b := NewMockCheckBackend()
+	b.CheckService = &mock.CheckService{
+		CreateCheckF: func(ctx context.Context, c influxdb.Check, userID influxdb.ID) error {
+			c.SetID(influxdb.ID(1))
+			created = c
+			return nil
+		},
+	}
+	h := NewCheckHandler(b)
+
+	tests := []struct {
+		name  string
+		body  string
+		check influxdb.Check
+	}{
+		{
+			name: "threshold",
+			body: `{"type": "threshold", "name": "cpu", "orgID": "0000000000000002", "status": "active", "every": "1m",
+				"query": {"text": "from(bucket: \"telegraf\") |> range(start: -1m)"}, "statusMessageTemplate": "cpu is ${r._level}",
+				"thresholds": [{"type": "greater", "level": "CRIT", "value": 90}, {"type": "range", "level": "WARN", "min": 70, "max": 90, "within": true}]}`,
+			check: &check.Threshold{
+				Base: check.Base{
+					ID:                    influxdb.ID(1),
+					OrgID:                 influxdb.ID(2),
+					Name:                  "cpu",
+					Status:                influxdb.Active,
+					Every:                 influxdb.Duration{Duration: time.Minute},
+					Query:                 influxdb.DashboardQuery{Text: `from(bucket: "telegraf") |> range(start: -1m)`},
+					StatusMessageTemplate: "cpu is ${r._level}",
+				},
+				Thresholds: []check.ThresholdConfig{
+					&check.Greater{ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Critical}, Value: 90},
+					&check.Range{ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Warn}, Min: 70, Max: 90, Within: true},
+				},
+			},
+		},
+		{
+			name: "deadman",
+			body: `{"type": "deadman", "name": "heartbeat", "orgID": "0000000000000002", "status": "active", "every": "1m",
+				"query": {"text": "from(bucket: \"telegraf\") |> range(start: -5m)"}, "statusMessageTemplate": "no data",
+				"timeSince": 90, "reportZero": true, "level": "CRIT"}`,
+			check: &check.Deadman{
+				Base: check.Base{
+					ID:                    influxdb.ID(1),
+					OrgID:                 influxdb.ID(2),
+					Name:                  "heartbeat",
+					Status:                influxdb.Active,
+					Every:                 influxdb.Duration{Duration: time.Minute},
+					Query:                 influxdb.DashboardQuery{Text: `from(bucket: "telegraf") |> range(start: -5m)`},
+					StatusMessageTemplate: "no data",
+				},
+				TimeSince:  90,
+				ReportZero: true,
+				Level:      notification.Critical,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			created = nil
+			r := httptest.NewRequest("POST", "/api/v2/checks", bytes.NewBufferString(tt.body))
+			r = r.WithContext(pcontext.SetAuthorizer(r.Context(), &influxdb.Session{UserID: influxdb.ID(6)}))
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+			if w.Code != http.StatusCreated {
+				t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+			}
+			if diff := cmp.Diff(created, tt.check); diff != "" {
+				t.Errorf("created checks are different -got/+want\ndiff %s", diff)
+			}
+
+			// the response decodes to the same concrete check.
+			got, err := check.UnmarshalJSON(w.Body.Bytes())
+			if err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if diff := cmp.Diff(got, tt.check); diff != "" {
+				t.Errorf("returned checks are different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+func TestCheckHandler_handlePostCheckArchive(t *testing.T) {
+	b := NewMockCheckBackend()
+	var archived, unarchived influxdb.ID
+	newCheck := func(id influxdb.ID, a bool) influxdb.Check {
+		return &check.Deadman{
+			Base: check.Base{
+				ID:       id,
+				OrgID:    influxdb.ID(2),
+				Name:     "heartbeat",
+				Status:   influxdb.Active,
+				Every:    influxdb.Duration{Duration: time.Minute},
+				Query:    influxdb.DashboardQuery{Text: `from(bucket: "telegraf") |> range(start: -5m)`},
+				Archived: a,
+			},
+			TimeSince: 90,
+			Level:     notification.Critical,
+		}
+	}
+	b.CheckService = &mock.CheckService{
+		ArchiveCheckF: func(ctx context.Context, id influxdb.ID) (influxdb.Check, error) {
+			archived = id
+			return newCheck(id, true), nil
+		},
+		UnarchiveCheckF: func(ctx context.Context, id influxdb.ID) (influxdb.Check, error) {
+			unarchived = id
+			return newCheck(id, false), nil
+		},
+	}
+	h := NewCheckHandler(b)
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("POST", "/api/v2/checks/0000000000000001/archive", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var got struct {
+		Archived bool `json:"archived"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if archived != influxdb.ID(1) || !got.Archived {
+		t.Errorf("expected check 1 to be archived, got %s archived %v", archived, got.Archived)
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("POST", "/api/v2/checks/0000000000000001/unarchive", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if unarchived != influxdb.ID(1) {
+		t.Errorf("expected check 1 to be unarchived, got %s", unarchived)
+	}
+}
+
+func TestCheckHandler_handlePostCheckActivate(t *testing.T) {
+	b := NewMockCheckBackend()
+	b.CheckService = &mock.CheckService{
+		PatchCheckF: func(ctx context.Context, id influxdb.ID, upd influxdb.CheckUpdate) (influxdb.Check, error) {
+			if upd.Name != nil || upd.Description != nil || upd.Status == nil {
+				t.Errorf("expected only the status to be updated, got %+v", upd)
+			}
+			return &check.Deadman{
+				Base: check.Base{
+					ID:     id,
+					OrgID:  influxdb.ID(2),
+					Name:   "heartbeat",
+					Status: *upd.Status,
+					Every:  influxdb.Duration{Duration: time.Minute},
+					Query:  influxdb.DashboardQuery{Text: `from(bucket: "telegraf") |> range(start: -5m)`},
+				},
+				TimeSince: 90,
+				Level:     notification.Critical,
+			}, nil
+		},
+	}
+	h := NewCheckHandler(b)
+
+	for path, want := range map[string]influxdb.Status{
+		"/api/v2/checks/0000000000000001/deactivate": influxdb.Inactive,
+		"/api/v2/checks/0000000000000001/activate":   influxdb.Active,
+	} {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest("POST", path, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+		var got struct {
+			ID     influxdb.ID     `json:"id"`
+			Status influxdb.Status `json:"status"`
+		}
+		if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if got.ID != influxdb.ID(1) || got.Status != want {
+			t.Errorf("%s: expected check 1 to be %s, got %s %s", path, want, got.ID, got.Status)
+		}
+	}
+}
+
+func TestCheckHandler_labels(t *testing.T) {
+	label := &influxdb.Label{ID: influxdb.ID(2), OrgID: influxdb.ID(3), Name: "paging"}
+	var mappings []influxdb.LabelMapping
+	b := NewMockCheckBackend()
+	ls := mock.NewLabelService()
+	ls.FindLabelByIDFn = func(ctx context.Context, id influxdb.ID) (*influxdb.Label, error) {
+		return label, nil
+	}
+	ls.FindResourceLabelsFn = func(ctx context.Context, f influxdb.LabelMappingFilter) ([]*influxdb.Label, error) {
+		if f.ResourceID != influxdb.ID(1) || f.ResourceType != influxdb.ChecksResourceType {
+			t.Errorf("expected the labels of check 1, got %+v", f)
+		}
+		return []*influxdb.Label{label}, nil
+	}
+	ls.CreateLabelMappingFn = func(ctx context.Context, m *influxdb.LabelMapping) error {
+		mappings = append(mappings, *m)
+		return nil
+	}
+	ls.DeleteLabelMappingFn = func(ctx context.Context, m *influxdb.LabelMapping) error {
+		mappings = mappings[:0]
+		return nil
+	}
+	b.LabelService = ls
+	h := NewCheckHandler(b)
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("POST", "/api/v2/checks/0000000000000001/labels", bytes.NewBufferString(`{"labelID": "0000000000000002"}`)))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	want := []influxdb.LabelMapping{{LabelID: label.ID, ResourceID: influxdb.ID(1), ResourceType: influxdb.ChecksResourceType}}
+	if diff := cmp.Diff(want, mappings); diff != "" {
+		t.Errorf("unexpected label mappings -want/+got\n%s", diff)
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/api/v2/checks/0000000000000001/labels", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var got struct {
+		Labels []influxdb.Label `json:"labels"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(got.Labels) != 1 || got.Labels[0].Name != "paging" {
+		t.Errorf("expected the label of the check, got %+v", got.Labels)
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("DELETE", "/api/v2/checks/0000000000000001/labels/0000000000000002", nil))
+	if w.Code != http.StatusNoContent || len(mappings) != 0 {
+		t.Errorf("expected the label to be removed, got status %d: %s", w.Code, w.Body.String())
+	}
+}
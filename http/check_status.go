@@ -0,0 +1,259 @@
+package http
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	pctx "github.com/influxdata/influxdb/context"
+	"go.uber.org/zap"
+)
+
+const (
+	// checkStatusesCacheTTL is how long the statuses of checks are served
+	// from the cache, the dashboard cells showing them refresh often.
+	checkStatusesCacheTTL = 5 * time.Second
+	// checkStatusesCacheSize is the maximum number of cached responses.
+	checkStatusesCacheSize = 1024
+)
+
+type checkStatusesResponse struct {
+	Statuses []*influxdb.CheckStatus `json:"statuses"`
+}
+
+type checkStatusHistoryResponse struct {
+	*influxdb.CheckStatusHistory
+	Links map[string]string `json:"links"`
+}
+
+// checkStatusesEntry is a cached response of the statuses of checks, encoded.
+type checkStatusesEntry struct {
+	body    []byte
+	etag    string
+	expires time.Time
+}
+
+// checkStatusesCache caches the statuses of checks for a short time, by the
+// authorizer of the request and the checks, so a response is only served to
+// the authorizer it was authorized for.
+type checkStatusesCache struct {
+	mu      sync.Mutex
+	entries map[string]checkStatusesEntry
+}
+
+func newCheckStatusesCache() *checkStatusesCache {
+	return &checkStatusesCache{entries: make(map[string]checkStatusesEntry)}
+}
+
+func (c *checkStatusesCache) get(key string, now time.Time) (checkStatusesEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok || !now.Before(e.expires) {
+		return checkStatusesEntry{}, false
+	}
+	return e, true
+}
+
+func (c *checkStatusesCache) put(key string, e checkStatusesEntry, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.entries) >= checkStatusesCacheSize {
+		for k, old := range c.entries {
+			if !now.Before(old.expires) {
+				delete(c.entries, k)
+			}
+		}
+		if len(c.entries) >= checkStatusesCacheSize {
+			c.entries = make(map[string]checkStatusesEntry)
+		}
+	}
+	c.entries[key] = e
+}
+
+// checkStatusesCacheKey is the key of the statuses of the checks requested by the
+// authorizer on context, whatever the order of the checks.
+func checkStatusesCacheKey(ctx context.Context, ids []influxdb.ID) string {
+	var b strings.Builder
+	if auth, err := pctx.GetAuthorizer(ctx); err == nil {
+		fmt.Fprintf(&b, "%s:%s", auth.Kind(), auth.Identifier())
+	}
+	sorted := append([]influxdb.ID(nil), ids...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	for _, id := range sorted {
+		b.WriteString(",")
+		b.WriteString(id.String())
+	}
+	return b.String()
+}
+
+// decodeGetCheckStatusesRequest decodes the checkIDs, repeated or comma separated.
+func decodeGetCheckStatusesRequest(ctx context.Context, r *http.Request) ([]influxdb.ID, error) {
+	var ids []influxdb.ID
+	seen := make(map[influxdb.ID]bool)
+	for _, v := range r.URL.Query()["checkIDs"] {
+		for _, s := range strings.Split(v, ",") {
+			id, err := influxdb.IDFromString(strings.TrimSpace(s))
+			if err != nil {
+				return nil, &influxdb.Error{
+					Code: influxdb.EInvalid,
+					Msg:  "checkIDs are invalid",
+					Err:  err,
+				}
+			}
+			if !seen[*id] {
+				seen[*id] = true
+				ids = append(ids, *id)
+			}
+		}
+	}
+	if len(ids) == 0 {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "checkIDs are required",
+		}
+	}
+	if len(ids) > influxdb.MaxCheckStatusIDs {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("at most %d checkIDs are allowed", influxdb.MaxCheckStatusIDs),
+		}
+	}
+	return ids, nil
+}
+
+// handleGetCheckStatuses is the HTTP handler for the GET /api/v2/checks/statuses route.
+// The response has an ETag, a request with a matching If-None-Match is answered
+// with a 304.
+func (h *CheckHandler) handleGetCheckStatuses(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "check statuses retrieve request", r)
+	ids, err := decodeGetCheckStatusesRequest(ctx, r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	key := checkStatusesCacheKey(ctx, ids)
+	now := time.Now()
+	e, ok := h.checkStatuses.get(key, now)
+	if !ok {
+		sts, err := h.CheckStatusService.FindCheckStatuses(ctx, ids)
+		if err != nil {
+			h.HandleHTTPError(ctx, err, w)
+			return
+		}
+		body, err := json.Marshal(&checkStatusesResponse{Statuses: sts})
+		if err != nil {
+			h.HandleHTTPError(ctx, err, w)
+			return
+		}
+		sum := sha256.Sum256(body)
+		e = checkStatusesEntry{
+			body:    body,
+			etag:    `"` + hex.EncodeToString(sum[:16]) + `"`,
+			expires: now.Add(checkStatusesCacheTTL),
+		}
+		h.checkStatuses.put(key, e, now)
+	}
+	h.Logger.Debug("check statuses retrieved", zap.Int("checks", len(ids)), zap.Bool("cached", ok))
+
+	w.Header().Set("ETag", e.etag)
+	w.Header().Set("Cache-Control", fmt.Sprintf("private, max-age=%d", int(checkStatusesCacheTTL/time.Second)))
+	if r.Header.Get("If-None-Match") == e.etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(e.body); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+// decodeGetCheckStatusHistoryRequest decodes the check and the start, stop
+// and limit of the statuses. The times are RFC3339.
+func decodeGetCheckStatusHistoryRequest(ctx context.Context, r *http.Request) (influxdb.ID, influxdb.CheckStatusHistoryFilter, error) {
+	var filter influxdb.CheckStatusHistoryFilter
+	id, err := decodeGetCheckRequest(ctx, r)
+	if err != nil {
+		return id, filter, err
+	}
+
+	qp := r.URL.Query()
+	for _, p := range []struct {
+		name string
+		t    **time.Time
+	}{
+		{name: "start", t: &filter.Start},
+		{name: "stop", t: &filter.Stop},
+	} {
+		s := qp.Get(p.name)
+		if s == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return id, filter, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  fmt.Sprintf("%s is not an RFC3339 time", p.name),
+				Err:  err,
+			}
+		}
+		*p.t = &t
+	}
+	if s := qp.Get("limit"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 1 {
+			return id, filter, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  fmt.Sprintf("limit must be between 1 and %d", influxdb.MaxCheckStatusHistoryLimit),
+			}
+		}
+		filter.Limit = n
+	}
+	if err := filter.Valid(); err != nil {
+		return id, filter, err
+	}
+	return id, filter, nil
+}
+
+// handleGetCheckStatusHistory is the HTTP handler for the GET /api/v2/checks/:id/statuses route.
+func (h *CheckHandler) handleGetCheckStatusHistory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "check status history retrieve request", r)
+	id, filter, err := decodeGetCheckStatusHistoryRequest(ctx, r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	sh, err := h.CheckStatusService.FindCheckStatusHistory(ctx, id, filter)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	h.Logger.Debug("check status history retrieved", zap.String("checkID", id.String()), zap.Int("statuses", len(sh.Statuses)))
+
+	res := &checkStatusHistoryResponse{
+		CheckStatusHistory: sh,
+		Links: map[string]string{
+			"self":  fmt.Sprintf("/api/v2/checks/%s/statuses", id),
+			"check": fmt.Sprintf("/api/v2/checks/%s", id),
+		},
+	}
+	if err := encodeResponse(ctx, w, http.StatusOK, res); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
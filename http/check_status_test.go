@@ -0,0 +1,143 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
+)
+
+func TestCheckHandler_handleGetCheckStatuses(t *testing.T) {
+	changed := time.Date(2019, 10, 1, 12, 0, 0, 0, time.UTC)
+	calls := 0
+	b := NewMockCheckBackend()
+	b.CheckStatusService = &mock.CheckStatusService{
+		FindCheckStatusesF: func(ctx context.Context, checkIDs []influxdb.ID) ([]*influxdb.CheckStatus, error) {
+			calls++
+			sts := make([]*influxdb.CheckStatus, 0, len(checkIDs))
+			for _, id := range checkIDs {
+				if id == influxdb.ID(9) {
+					return nil, &influxdb.Error{
+						Code: influxdb.ENotFound,
+						Msg:  "check not found",
+					}
+				}
+				sts = append(sts, &influxdb.CheckStatus{
+					CheckID:    id,
+					OrgID:      10,
+					Level:      "crit",
+					Message:    "cpu is high",
+					Time:       &changed,
+					LastChange: &changed,
+				})
+			}
+			return sts, nil
+		},
+	}
+	h := NewCheckHandler(b)
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/api/v2/checks/statuses?checkIDs=0000000000000002,0000000000000001&checkIDs=0000000000000002", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var resp checkStatusesResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Statuses) != 2 || resp.Statuses[0].CheckID != 2 || resp.Statuses[1].CheckID != 1 {
+		t.Fatalf("expected a status per check, got %+v", resp.Statuses)
+	}
+	if st := resp.Statuses[0]; st.Level != "crit" || st.Message != "cpu is high" || !st.LastChange.Equal(changed) {
+		t.Errorf("unexpected status %+v", st)
+	}
+	etag := w.Header().Get("ETag")
+	if etag == "" {
+		t.Fatalf("expected an ETag")
+	}
+
+	r := httptest.NewRequest("GET", "/api/v2/checks/statuses?checkIDs=0000000000000001,0000000000000002", nil)
+	r.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusNotModified {
+		t.Errorf("expected status %d, got %d", http.StatusNotModified, w.Code)
+	}
+	if calls != 1 {
+		t.Errorf("expected the statuses to be cached, got %d calls", calls)
+	}
+
+	for _, query := range []string{"", "?checkIDs=x"} {
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest("GET", "/api/v2/checks/statuses"+query, nil))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d for %q, got %d", http.StatusBadRequest, query, w.Code)
+		}
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/api/v2/checks/statuses?checkIDs=0000000000000009", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestCheckHandler_handleGetCheckStatusHistory(t *testing.T) {
+	now := time.Date(2019, 10, 1, 12, 0, 0, 0, time.UTC)
+	var gotFilter influxdb.CheckStatusHistoryFilter
+	b := NewMockCheckBackend()
+	b.CheckStatusService = &mock.CheckStatusService{
+		FindCheckStatusHistoryF: func(ctx context.Context, checkID influxdb.ID, filter influxdb.CheckStatusHistoryFilter) (*influxdb.CheckStatusHistory, error) {
+			gotFilter = filter
+			return &influxdb.CheckStatusHistory{
+				CheckID: checkID,
+				OrgID:   10,
+				Statuses: []*influxdb.CheckStatusRecord{
+					{StatusID: 2, Level: "crit", Time: now},
+					{StatusID: 1, Level: "ok", Time: now.Add(-time.Minute)},
+				},
+			}, nil
+		},
+	}
+	h := NewCheckHandler(b)
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/api/v2/checks/0000000000000001/statuses?start=2019-10-01T11:00:00Z&limit=2", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if gotFilter.Start == nil || !gotFilter.Start.Equal(now.Add(-time.Hour)) || gotFilter.Stop != nil || gotFilter.Limit != 2 {
+		t.Errorf("unexpected filter %+v", gotFilter)
+	}
+	var got struct {
+		CheckID  string `json:"checkID"`
+		Statuses []struct {
+			Level string `json:"level"`
+		} `json:"statuses"`
+		Links map[string]string `json:"links"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if got.CheckID != "0000000000000001" || len(got.Statuses) != 2 || got.Statuses[0].Level != "crit" || got.Links["self"] != "/api/v2/checks/0000000000000001/statuses" {
+		t.Errorf("unexpected status history %+v", got)
+	}
+
+	for _, q := range []string{
+		"start=yesterday",
+		"limit=0",
+		"limit=1001",
+		"start=2019-10-01T12:00:00Z&stop=2019-10-01T11:00:00Z",
+	} {
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest("GET", "/api/v2/checks/0000000000000001/statuses?"+q, nil))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d for %q, got %d", http.StatusBadRequest, q, w.Code)
+		}
+	}
+}
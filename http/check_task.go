@@ -0,0 +1,125 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/task/backend"
+)
+
+// checkIncludeTask is the include query param value decorating checks with their task.
+const checkIncludeTask = "task"
+
+// checkTaskResponse is the task running a check.
+type checkTaskResponse struct {
+	ID              influxdb.ID `json:"id"`
+	Status          string      `json:"status"`
+	Every           string      `json:"every,omitempty"`
+	Cron            string      `json:"cron,omitempty"`
+	LatestCompleted string      `json:"latestCompleted,omitempty"`
+	// Errors are the log messages of the latest run of the task, if it failed.
+	Errors []string `json:"errors"`
+}
+
+// tasked is a check run by a task.
+type tasked interface {
+	GetTaskID() influxdb.ID
+}
+
+// decodeCheckInclude returns whether the checks of a request include their task.
+func decodeCheckInclude(r *http.Request) (bool, error) {
+	includeTask := false
+	for _, include := range r.URL.Query()["include"] {
+		if include != checkIncludeTask {
+			return false, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  fmt.Sprintf("include %s is invalid", include),
+			}
+		}
+		includeTask = true
+	}
+	return includeTask, nil
+}
+
+// decorateCheckTasks sets the task of each check response, finding the tasks of
+// the checks of an organization in a single paged lookup. The checks whose task
+// can't be found are left without one.
+func (h *CheckHandler) decorateCheckTasks(ctx context.Context, rs []*checkResponse) error {
+	ids := make(map[influxdb.ID]map[influxdb.ID]bool)
+	for _, r := range rs {
+		tc, ok := r.Check.(tasked)
+		if !ok || !tc.GetTaskID().Valid() {
+			continue
+		}
+		orgID := r.Check.GetOrgID()
+		if ids[orgID] == nil {
+			ids[orgID] = make(map[influxdb.ID]bool)
+		}
+		ids[orgID][tc.GetTaskID()] = true
+	}
+
+	tasks := make(map[influxdb.ID]*checkTaskResponse)
+	for orgID, taskIDs := range ids {
+		orgID := orgID
+		filter := influxdb.TaskFilter{OrganizationID: &orgID, Limit: influxdb.TaskMaxPageSize}
+		for {
+			ts, _, err := h.TaskService.FindTasks(ctx, filter)
+			if err != nil {
+				return err
+			}
+			for _, t := range ts {
+				if !taskIDs[t.ID] {
+					continue
+				}
+				errs, err := h.findTaskErrors(ctx, t.ID)
+				if err != nil {
+					return err
+				}
+				tasks[t.ID] = &checkTaskResponse{
+					ID:              t.ID,
+					Status:          t.Status,
+					Every:           t.Every,
+					Cron:            t.Cron,
+					LatestCompleted: t.LatestCompleted,
+					Errors:          errs,
+				}
+			}
+			if len(ts) < filter.Limit {
+				break
+			}
+			filter.After = &ts[len(ts)-1].ID
+		}
+	}
+
+	for _, r := range rs {
+		if tc, ok := r.Check.(tasked); ok {
+			r.Task = tasks[tc.GetTaskID()]
+		}
+	}
+	return nil
+}
+
+// findTaskErrors returns the log messages of the latest run of a task if it failed.
+func (h *CheckHandler) findTaskErrors(ctx context.Context, taskID influxdb.ID) ([]string, error) {
+	errs := []string{}
+	runs, _, err := h.TaskService.FindRuns(ctx, influxdb.RunFilter{Task: taskID})
+	if err != nil {
+		return nil, err
+	}
+
+	var latest *influxdb.Run
+	for _, r := range runs {
+		if latest == nil || r.ScheduledFor > latest.ScheduledFor {
+			latest = r
+		}
+	}
+	if latest == nil || latest.Status != backend.RunFail.String() {
+		return errs, nil
+	}
+	for _, l := range latest.Log {
+		errs = append(errs, l.Message)
+	}
+	return errs, nil
+}
@@ -0,0 +1,40 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/influxdata/influxdb"
+	"go.uber.org/zap"
+)
+
+type checkTaskReconciliationResponse struct {
+	*influxdb.CheckTaskReconciliation
+	Links map[string]string `json:"links"`
+}
+
+func newCheckTaskReconciliationResponse(r *influxdb.CheckTaskReconciliation) *checkTaskReconciliationResponse {
+	return &checkTaskReconciliationResponse{
+		CheckTaskReconciliation: r,
+		Links: map[string]string{
+			"self": checksReconcilePath,
+		},
+	}
+}
+
+// handleGetCheckTaskReconciliation is the HTTP handler for the GET /api/v2/checks/reconciliation route.
+func (h *CheckHandler) handleGetCheckTaskReconciliation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "check task reconciliation retrieve request", r)
+
+	rec, err := h.CheckTaskReconciler.FindCheckTaskReconciliation(ctx)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	h.Logger.Debug("check task reconciliation retrieved", zap.Int("checks", len(rec.Checks)), zap.Int("tasks", len(rec.Tasks)))
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newCheckTaskReconciliationResponse(rec)); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
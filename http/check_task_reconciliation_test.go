@@ -0,0 +1,60 @@
+package http
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
+)
+
+func TestCheckHandler_handleGetCheckTaskReconciliation(t *testing.T) {
+	b := NewMockCheckBackend()
+	b.CheckTaskReconciler = &mock.CheckTaskReconciler{
+		FindCheckTaskReconciliationF: func(ctx context.Context) (*influxdb.CheckTaskReconciliation, error) {
+			taskID := influxdb.ID(3)
+			return &influxdb.CheckTaskReconciliation{
+				Policy:     influxdb.CheckTaskReconcileRepair,
+				StartedAt:  time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC),
+				FinishedAt: time.Date(2019, 10, 1, 0, 0, 1, 0, time.UTC),
+				Checks: []influxdb.ReconciledResource{
+					{ID: 1, OrgID: 2, Name: "api availability", Reason: influxdb.ReconcileCheckDanglingTask, Action: influxdb.ReconcileRecreated, TaskID: &taskID},
+				},
+				Tasks: []influxdb.ReconciledResource{},
+			}, nil
+		},
+	}
+	h := NewCheckHandler(b)
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/api/v2/checks/reconciliation", nil))
+	res := w.Result()
+	body, _ := ioutil.ReadAll(res.Body)
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, res.StatusCode, body)
+	}
+	want := `{
+  "policy": "repair",
+  "startedAt": "2019-10-01T00:00:00Z",
+  "finishedAt": "2019-10-01T00:00:01Z",
+  "checks": [
+    {
+      "id": "0000000000000001",
+      "orgID": "0000000000000002",
+      "name": "api availability",
+      "reason": "the task of the check doesn't exist",
+      "action": "recreated",
+      "taskID": "0000000000000003"
+    }
+  ],
+  "tasks": [],
+  "links": {"self": "/api/v2/checks/reconciliation"}
+}`
+	if eq, diff, _ := jsonEqual(string(body), want); !eq {
+		t.Errorf("handleGetCheckTaskReconciliation() = ***%s***", diff)
+	}
+}
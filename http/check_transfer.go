@@ -0,0 +1,76 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/influxdata/influxdb"
+	"go.uber.org/zap"
+)
+
+type checkTransferResponse struct {
+	Check             *checkResponse              `json:"check"`
+	NotificationRules []influxdb.NotificationRule `json:"notificationRules"`
+}
+
+type postCheckTransferRequest struct {
+	ID       influxdb.ID
+	Transfer influxdb.CheckTransfer
+}
+
+func decodePostCheckTransferRequest(ctx context.Context, r *http.Request) (*postCheckTransferRequest, error) {
+	id, err := decodeGetCheckRequest(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	var t influxdb.CheckTransfer
+	if err := json.NewDecoder(r.Body).Decode(&t); err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  err.Error(),
+		}
+	}
+	if err := t.Valid(); err != nil {
+		return nil, err
+	}
+
+	return &postCheckTransferRequest{
+		ID:       id,
+		Transfer: t,
+	}, nil
+}
+
+// handlePostCheckTransfer is the HTTP handler for the POST /api/v2/checks/:id/transfer route.
+func (h *CheckHandler) handlePostCheckTransfer(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "check transfer request", r)
+	req, err := decodePostCheckTransferRequest(ctx, r)
+	if err != nil {
+		h.Logger.Debug("failed to decode request", zap.Error(err))
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	res, err := h.CheckTransferService.TransferCheck(ctx, req.ID, req.Transfer)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	labels, err := h.LabelService.FindResourceLabels(ctx, influxdb.LabelMappingFilter{ResourceID: res.Check.GetID()})
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	debugResult(h.Logger, "check transferred", "check", res.Check)
+
+	if err := encodeResponse(ctx, w, http.StatusOK, &checkTransferResponse{
+		Check:             newCheckResponse(res.Check, labels),
+		NotificationRules: res.NotificationRules,
+	}); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
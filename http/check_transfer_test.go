@@ -0,0 +1,78 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/notification/check"
+)
+
+func TestCheckHandler_handlePostCheckTransfer(t *testing.T) {
+	b := NewMockCheckBackend()
+	b.CheckTransferService = &mock.CheckTransferService{
+		TransferCheckF: func(ctx context.Context, id influxdb.ID, tr influxdb.CheckTransfer) (*influxdb.CheckTransferResult, error) {
+			if tr.OrgID != influxdb.ID(3) || !tr.IncludeRules || tr.OnNameCollision != influxdb.TransferNameCollisionRename {
+				t.Errorf("unexpected transfer %+v", tr)
+			}
+			return &influxdb.CheckTransferResult{
+				Check: &check.Deadman{
+					Base: check.Base{
+						ID:     id,
+						OrgID:  tr.OrgID,
+						Name:   "heartbeat (2)",
+						Status: influxdb.Active,
+						Every:  influxdb.Duration{Duration: time.Minute},
+						Query: influxdb.DashboardQuery{
+							Text: `from(bucket: "telegraf") |> range(start: -5m)`,
+						},
+					},
+					TimeSince: 90,
+				},
+				NotificationRules: []influxdb.NotificationRule{},
+			}, nil
+		},
+	}
+	h := NewCheckHandler(b)
+
+	w := httptest.NewRecorder()
+	body := `{"orgID": "0000000000000003", "includeRules": true, "onNameCollision": "rename"}`
+	h.ServeHTTP(w, httptest.NewRequest("POST", "/api/v2/checks/0000000000000001/transfer", strings.NewReader(body)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var got struct {
+		Check struct {
+			ID    string     `json:"id"`
+			OrgID string     `json:"orgID"`
+			Name  string     `json:"name"`
+			Links checkLinks `json:"links"`
+		} `json:"check"`
+		NotificationRules []json.RawMessage `json:"notificationRules"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if got.Check.ID != "0000000000000001" || got.Check.OrgID != "0000000000000003" || got.Check.Name != "heartbeat (2)" {
+		t.Errorf("unexpected check %+v", got.Check)
+	}
+	if got.Check.Links.Self != "/api/v2/checks/0000000000000001" {
+		t.Errorf("unexpected links %+v", got.Check.Links)
+	}
+	if got.NotificationRules == nil {
+		t.Errorf("expected notification rules to be an empty list")
+	}
+
+	w = httptest.NewRecorder()
+	body = `{"orgID": "0000000000000003", "onNameCollision": "overwrite"}`
+	h.ServeHTTP(w, httptest.NewRequest("POST", "/api/v2/checks/0000000000000001/transfer", strings.NewReader(body)))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
@@ -0,0 +1,168 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/influxdata/influxdb/notification"
+)
+
+// The older clients send checks in shapes which predate the current check
+// model. They are upcast to the current model when the checks are decoded,
+// and the responses warn the clients about the deprecated fields they sent,
+// so they keep working until they are upgraded.
+//
+// The deprecated shapes are:
+//   - everySeconds and offsetSeconds, integers of seconds in the style of the
+//     retentionRules of the buckets, in place of the every and offset durations.
+//   - tags as an object of keys and values, in place of a list of tags.
+//   - thresholds with a lowerBound and/or an upperBound and no type, in place
+//     of the greater, lesser and range thresholds.
+
+// deprecationHeader marks the responses to requests using deprecated fields.
+const deprecationHeader = "Deprecation"
+
+// upcastCheckJSON returns the json of a check in the current model, and the
+// deprecations of the older shapes it was upcast from.
+func upcastCheckJSON(b []byte) ([]byte, []string, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		// not an object, let the check decoding report it.
+		return b, nil, nil
+	}
+
+	var deprecations []string
+	for _, f := range []struct{ legacy, field string }{
+		{"everySeconds", "every"},
+		{"offsetSeconds", "offset"},
+	} {
+		v, ok := raw[f.legacy]
+		if !ok {
+			continue
+		}
+		delete(raw, f.legacy)
+		deprecations = append(deprecations, fmt.Sprintf("%s is deprecated, use %s", f.legacy, f.field))
+		if _, ok := raw[f.field]; ok {
+			continue
+		}
+		var secs int64
+		if err := json.Unmarshal(v, &secs); err != nil {
+			return nil, nil, fmt.Errorf("%s must be an integer of seconds: %v", f.legacy, err)
+		}
+		raw[f.field], _ = json.Marshal((time.Duration(secs) * time.Second).String())
+	}
+
+	if v, ok := raw["tags"]; ok && isJSONObject(v) {
+		var m map[string]string
+		if err := json.Unmarshal(v, &m); err != nil {
+			return nil, nil, fmt.Errorf("tags must be a list of tags: %v", err)
+		}
+		tags := make([]notification.Tag, 0, len(m))
+		for k, v := range m {
+			tags = append(tags, notification.Tag{Key: k, Value: v})
+		}
+		sort.Slice(tags, func(i, j int) bool { return tags[i].Key < tags[j].Key })
+		raw["tags"], _ = json.Marshal(tags)
+		deprecations = append(deprecations, "tags as an object is deprecated, use a list of tags")
+	}
+
+	if v, ok := raw["thresholds"]; ok {
+		thresholds, upcast, err := upcastThresholds(v)
+		if err != nil {
+			return nil, nil, err
+		}
+		if upcast {
+			raw["thresholds"] = thresholds
+			deprecations = append(deprecations, "lowerBound and upperBound thresholds are deprecated, use greater, lesser and range thresholds")
+		}
+	}
+
+	if len(deprecations) == 0 {
+		return b, nil, nil
+	}
+	b, err := json.Marshal(raw)
+	if err != nil {
+		return nil, nil, err
+	}
+	return b, deprecations, nil
+}
+
+// legacyThreshold is a threshold bounded by its lowerBound and/or upperBound.
+type legacyThreshold struct {
+	Type       string                  `json:"type"`
+	Level      notification.CheckLevel `json:"level"`
+	AllValues  bool                    `json:"allValues"`
+	LowerBound *float64                `json:"lowerBound"`
+	UpperBound *float64                `json:"upperBound"`
+}
+
+// upcastThresholds converts the thresholds bounded by a lowerBound and/or an
+// upperBound, and reports whether there were any.
+func upcastThresholds(b json.RawMessage) (json.RawMessage, bool, error) {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		// not a list, let the check decoding report it.
+		return b, false, nil
+	}
+	var upcast bool
+	for i, rt := range raw {
+		var t legacyThreshold
+		if err := json.Unmarshal(rt, &t); err != nil || t.Type != "" {
+			continue
+		}
+		base := map[string]interface{}{
+			"level":     t.Level,
+			"allValues": t.AllValues,
+		}
+		switch {
+		case t.LowerBound != nil && t.UpperBound != nil:
+			base["type"] = "range"
+			base["min"] = *t.LowerBound
+			base["max"] = *t.UpperBound
+			base["within"] = true
+		case t.LowerBound != nil:
+			base["type"] = "greater"
+			base["value"] = *t.LowerBound
+		case t.UpperBound != nil:
+			base["type"] = "lesser"
+			base["value"] = *t.UpperBound
+		default:
+			return nil, false, fmt.Errorf("threshold %d requires a type, or a lowerBound or upperBound", i)
+		}
+		raw[i], _ = json.Marshal(base)
+		upcast = true
+	}
+	if !upcast {
+		return b, false, nil
+	}
+	b, err := json.Marshal(raw)
+	return b, true, err
+}
+
+func isJSONObject(b json.RawMessage) bool {
+	for _, c := range b {
+		switch c {
+		case ' ', '\t', '\r', '\n':
+			continue
+		case '{':
+			return true
+		default:
+			return false
+		}
+	}
+	return false
+}
+
+// setDeprecationHeaders warns the client about the deprecated fields of its request.
+func setDeprecationHeaders(w http.ResponseWriter, deprecations []string) {
+	if len(deprecations) == 0 {
+		return
+	}
+	w.Header().Set(deprecationHeader, "true")
+	for _, d := range deprecations {
+		w.Header().Add("Warning", fmt.Sprintf("299 - %q", d))
+	}
+}
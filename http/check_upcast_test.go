@@ -0,0 +1,162 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb"
+	pcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/check"
+)
+
+func TestUnmarshalCheckJSON_upcast(t *testing.T) {
+	tests := []struct {
+		name         string
+		json         string
+		want         influxdb.Check
+		deprecations int
+		wantErr      bool
+	}{
+		{
+			name: "current model",
+			json: `{"type": "deadman", "name": "heartbeat", "every": "1m", "offset": "10s", "tags": [{"key": "env", "value": "prod"}], "timeSince": 90}`,
+			want: &check.Deadman{
+				Base: check.Base{
+					Name:   "heartbeat",
+					Every:  influxdb.Duration{Duration: time.Minute},
+					Offset: influxdb.Duration{Duration: 10 * time.Second},
+					Tags:   []notification.Tag{{Key: "env", Value: "prod"}},
+				},
+				TimeSince: 90,
+			},
+		},
+		{
+			name: "seconds and tags object",
+			json: `{"type": "deadman", "name": "heartbeat", "everySeconds": 60, "offsetSeconds": 10, "tags": {"region": "eu", "env": "prod"}, "timeSince": 90}`,
+			want: &check.Deadman{
+				Base: check.Base{
+					Name:   "heartbeat",
+					Every:  influxdb.Duration{Duration: time.Minute},
+					Offset: influxdb.Duration{Duration: 10 * time.Second},
+					Tags:   []notification.Tag{{Key: "env", Value: "prod"}, {Key: "region", Value: "eu"}},
+				},
+				TimeSince: 90,
+			},
+			deprecations: 3,
+		},
+		{
+			name: "every wins over everySeconds",
+			json: `{"type": "deadman", "name": "heartbeat", "every": "5m", "everySeconds": 60, "timeSince": 90}`,
+			want: &check.Deadman{
+				Base: check.Base{
+					Name:  "heartbeat",
+					Every: influxdb.Duration{Duration: 5 * time.Minute},
+				},
+				TimeSince: 90,
+			},
+			deprecations: 1,
+		},
+		{
+			name: "bounded thresholds",
+			json: `{"type": "threshold", "name": "cpu", "every": "1m", "thresholds": [
+				{"level": "CRIT", "lowerBound": 90},
+				{"level": "INFO", "upperBound": 10, "allValues": true},
+				{"level": "OK", "lowerBound": 10, "upperBound": 90},
+				{"type": "greater", "level": "WARN", "value": 80}
+			]}`,
+			want: &check.Threshold{
+				Base: check.Base{
+					Name:  "cpu",
+					Every: influxdb.Duration{Duration: time.Minute},
+				},
+				Thresholds: []check.ThresholdConfig{
+					&check.Greater{ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Critical}, Value: 90},
+					&check.Lesser{ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Info, AllValues: true}, Value: 10},
+					&check.Range{ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Ok}, Min: 10, Max: 90, Within: true},
+					&check.Greater{ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Warn}, Value: 80},
+				},
+			},
+			deprecations: 1,
+		},
+		{
+			name:    "threshold without type nor bounds",
+			json:    `{"type": "threshold", "name": "cpu", "every": "1m", "thresholds": [{"level": "CRIT"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid everySeconds",
+			json:    `{"type": "deadman", "name": "heartbeat", "everySeconds": "1m", "timeSince": 90}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, deprecations, err := UnmarshalCheckJSON([]byte(tt.json))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UnmarshalCheckJSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if influxdb.ErrorCode(err) != influxdb.EInvalid {
+					t.Errorf("expected an invalid error, got %v", err)
+				}
+				return
+			}
+			if diff := cmp.Diff(tt.want, c); diff != "" {
+				t.Errorf("unexpected check -want/+got:\n%s", diff)
+			}
+			if len(deprecations) != tt.deprecations {
+				t.Errorf("expected %d deprecations, got %v", tt.deprecations, deprecations)
+			}
+		})
+	}
+}
+
+func TestCheckHandler_handlePostCheck_deprecated(t *testing.T) {
+	b := NewMockCheckBackend()
+	var created influxdb.Check
+	b.CheckService = &mock.CheckService{
+		CreateCheckF: func(ctx context.Context, c influxdb.Check, userID influxdb.ID) error {
+			c.SetID(influxdb.ID(1))
+			created = c
+			return nil
+		},
+	}
+	h := NewCheckHandler(b)
+
+	post := func(body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest("POST", "/api/v2/checks", bytes.NewBufferString(body))
+		r = r.WithContext(pcontext.SetAuthorizer(r.Context(), &influxdb.Session{UserID: influxdb.ID(6)}))
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	w := post(`{"type": "deadman", "name": "heartbeat", "orgID": "0000000000000002", "status": "active", "query": {"text": "from(bucket: \"telegraf\")"}, "everySeconds": 60, "timeSince": 90}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	if created.(*check.Deadman).Every.Duration != time.Minute {
+		t.Errorf("expected the check to be created every minute, got %s", created.(*check.Deadman).Every.Duration)
+	}
+	if got := w.Header().Get("Deprecation"); got != "true" {
+		t.Errorf("expected the response to be marked deprecated, got %q", got)
+	}
+	if got, want := w.Header().Get("Warning"), `299 - "everySeconds is deprecated, use every"`; got != want {
+		t.Errorf("got warning %q, want %q", got, want)
+	}
+
+	w = post(`{"type": "deadman", "name": "heartbeat", "orgID": "0000000000000002", "status": "active", "query": {"text": "from(bucket: \"telegraf\")"}, "every": "1m", "timeSince": 90}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	if got := w.Header().Get("Deprecation"); got != "" {
+		t.Errorf("expected the response not to be marked deprecated, got %q", got)
+	}
+}
@@ -0,0 +1,43 @@
+package http
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+	pctx "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/notification/check"
+)
+
+// viewCheck returns a check as the authorizer of a request may see it. The
+// checks the authorizer can read but not write, such as the checks of the
+// organizations it is a viewer of, are returned without their query logic
+// nor the fields adjacent to their secrets, unless the redaction is disabled.
+func (h *CheckHandler) viewCheck(ctx context.Context, c influxdb.Check) influxdb.Check {
+	if !h.redactsCheck(ctx, c.GetOrgID(), c.GetID()) {
+		return c
+	}
+	return check.Redact(c)
+}
+
+// redactsCheck returns whether the query logic of a check is hidden from the
+// authorizer of a request. It is hidden from a request without authorizer.
+func (h *CheckHandler) redactsCheck(ctx context.Context, orgID, id influxdb.ID) bool {
+	if h.ViewerRedactionDisabled {
+		return false
+	}
+	a, err := pctx.GetAuthorizer(ctx)
+	if err != nil {
+		return true
+	}
+	p, err := influxdb.NewPermissionAtID(id, influxdb.WriteAction, influxdb.ChecksResourceType, orgID)
+	return err == nil && !a.Allowed(*p)
+}
+
+// viewChecks returns the checks as the authorizer of a request may see them.
+func (h *CheckHandler) viewChecks(ctx context.Context, cs []influxdb.Check) []influxdb.Check {
+	vs := make([]influxdb.Check, len(cs))
+	for i, c := range cs {
+		vs[i] = h.viewCheck(ctx, c)
+	}
+	return vs
+}
@@ -0,0 +1,101 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	pctx "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/notification/check"
+)
+
+func TestCheckHandler_viewerRedaction(t *testing.T) {
+	orgID := influxdb.ID(2)
+	b := NewMockCheckBackend()
+	b.CheckService = &mock.CheckService{
+		FindCheckByIDF: func(ctx context.Context, id influxdb.ID) (influxdb.Check, error) {
+			return &check.Deadman{
+				Base: check.Base{
+					ID:              id,
+					OrgID:           orgID,
+					Name:            "heartbeat",
+					Status:          influxdb.Active,
+					Every:           influxdb.Duration{Duration: time.Minute},
+					Query:           influxdb.DashboardQuery{Name: "cpu", Text: `from(bucket: "telegraf")`},
+					AuthorizationID: 5,
+				},
+			}, nil
+		},
+	}
+
+	tests := []struct {
+		name        string
+		permissions []influxdb.Permission
+		anonymous   bool
+		disabled    bool
+		redacted    bool
+	}{
+		{
+			name:        "viewer",
+			permissions: influxdb.ViewerPermissions(orgID),
+			redacted:    true,
+		},
+		{
+			name:        "read-only token",
+			permissions: []influxdb.Permission{{Action: influxdb.ReadAction, Resource: influxdb.Resource{Type: influxdb.ChecksResourceType, OrgID: &orgID}}},
+			redacted:    true,
+		},
+		{
+			name:        "owner",
+			permissions: influxdb.OwnerPermissions(orgID),
+		},
+		{
+			name:      "no authorizer",
+			anonymous: true,
+			redacted:  true,
+		},
+		{
+			name:        "redaction disabled",
+			permissions: influxdb.ViewerPermissions(orgID),
+			disabled:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b.ViewerRedactionDisabled = tt.disabled
+			h := NewCheckHandler(b)
+
+			r := httptest.NewRequest("GET", "/api/v2/checks/0000000000000001", nil)
+			if !tt.anonymous {
+				r = r.WithContext(pctx.SetAuthorizer(r.Context(), &influxdb.Authorization{
+					Status:      influxdb.Active,
+					OrgID:       orgID,
+					Permissions: tt.permissions,
+				}))
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+			}
+			var got struct {
+				Query           influxdb.DashboardQuery `json:"query"`
+				AuthorizationID string                  `json:"authorizationID"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if got.Query.Name != "cpu" {
+				t.Errorf("expected the name of the query to be kept, got %q", got.Query.Name)
+			}
+			if redacted := got.Query.Text == "" && got.AuthorizationID == ""; redacted != tt.redacted {
+				t.Errorf("expected the check to be redacted %v, got query %q and authorization %q", tt.redacted, got.Query.Text, got.AuthorizationID)
+			}
+		})
+	}
+}
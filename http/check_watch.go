@@ -0,0 +1,231 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultCheckWatchTimeout = 30 * time.Second
+	maxCheckWatchTimeout     = 5 * time.Minute
+	defaultCheckWatchLimit   = 100
+	maxCheckWatchLimit       = 1000
+
+	eventStreamMediaType = "text/event-stream"
+)
+
+// checkWatchPollInterval is the interval the watches of the checks look for
+// new events at.
+var checkWatchPollInterval = 500 * time.Millisecond
+
+type checkEventsResponse struct {
+	Events []*influxdb.CheckEvent `json:"events"`
+	// ResourceVersion is the resource version to watch from next.
+	ResourceVersion uint64            `json:"resourceVersion"`
+	Links           map[string]string `json:"links"`
+}
+
+type watchChecksRequest struct {
+	filter  influxdb.CheckEventFilter
+	timeout time.Duration
+	stream  bool
+}
+
+func decodeWatchChecksRequest(ctx context.Context, r *http.Request) (*watchChecksRequest, error) {
+	orgID, err := decodeGetCheckLagSummaryRequest(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	req := &watchChecksRequest{
+		filter: influxdb.CheckEventFilter{
+			OrgID: orgID,
+			Limit: defaultCheckWatchLimit,
+		},
+		timeout: defaultCheckWatchTimeout,
+		stream:  r.Header.Get("Accept") == eventStreamMediaType,
+	}
+
+	qp := r.URL.Query()
+	since := qp.Get("since")
+	// an event stream resumed by the client follows its last event.
+	if id := r.Header.Get("Last-Event-ID"); req.stream && id != "" {
+		since = id
+	}
+	if since != "" {
+		v, err := strconv.ParseUint(since, 10, 64)
+		if err != nil {
+			return nil, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "since is invalid",
+				Err:  err,
+			}
+		}
+		req.filter.Since = &v
+	}
+	if s := qp.Get("limit"); s != "" {
+		l, err := strconv.Atoi(s)
+		if err != nil || l < 1 || l > maxCheckWatchLimit {
+			return nil, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  fmt.Sprintf("limit must be between 1 and %d", maxCheckWatchLimit),
+			}
+		}
+		req.filter.Limit = l
+	}
+	if s := qp.Get("timeout"); s != "" {
+		d, err := time.ParseDuration(s)
+		if err != nil || d < 0 || d > maxCheckWatchTimeout {
+			return nil, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  fmt.Sprintf("timeout must be a duration up to %s", maxCheckWatchTimeout),
+			}
+		}
+		req.timeout = d
+	}
+	return req, nil
+}
+
+// viewCheckEvents returns the events with their checks as the authorizer of
+// a request may see them.
+func (h *CheckHandler) viewCheckEvents(ctx context.Context, events []*influxdb.CheckEvent) []*influxdb.CheckEvent {
+	for _, e := range events {
+		if e.Check != nil {
+			e.Check = h.viewCheck(ctx, e.Check)
+		}
+	}
+	return events
+}
+
+// handleGetChecksWatch is the HTTP handler for the GET /api/v2/checks/watch
+// route. It waits for the events of the checks following the resource
+// version up to the timeout, returning them as soon as there are any, or
+// streams them as server-sent events until the timeout if the request
+// accepts an event stream.
+func (h *CheckHandler) handleGetChecksWatch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "checks watch request", r)
+	req, err := decodeWatchChecksRequest(ctx, r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	events, version, err := h.CheckWatchService.FindCheckEvents(ctx, req.filter)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	req.filter.Since = &version
+
+	ctx, cancel := context.WithTimeout(ctx, req.timeout)
+	defer cancel()
+	if req.stream {
+		h.streamCheckEvents(ctx, w, r, req, events)
+		return
+	}
+
+	ticker := time.NewTicker(checkWatchPollInterval)
+	defer ticker.Stop()
+	for len(events) == 0 && ctx.Err() == nil {
+		select {
+		case <-ctx.Done():
+			continue
+		case <-ticker.C:
+		}
+		if events, version, err = h.CheckWatchService.FindCheckEvents(ctx, req.filter); err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			h.HandleHTTPError(ctx, err, w)
+			return
+		}
+		req.filter.Since = &version
+	}
+	version = *req.filter.Since
+	h.Logger.Debug("checks watched", zap.Int("events", len(events)), zap.Uint64("resourceVersion", version))
+
+	res := &checkEventsResponse{
+		Events:          h.viewCheckEvents(ctx, events),
+		ResourceVersion: version,
+		Links: map[string]string{
+			"next": fmt.Sprintf("/api/v2/checks/watch?orgID=%s&since=%d", req.filter.OrgID, version),
+		},
+	}
+	// the response is encoded with the context of the request, the one of
+	// the watch may be done.
+	if err := encodeResponse(r.Context(), w, http.StatusOK, res); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+// streamCheckEvents writes the events of the checks as server-sent events
+// until the context is done, ending with a bookmark event of the resource
+// version to resume the watch from.
+func (h *CheckHandler) streamCheckEvents(ctx context.Context, w http.ResponseWriter, r *http.Request, req *watchChecksRequest, events []*influxdb.CheckEvent) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		h.HandleHTTPError(ctx, &influxdb.Error{
+			Code: influxdb.EInternal,
+			Msg:  "streaming the events of the checks is unsupported",
+		}, w)
+		return
+	}
+	w.Header().Set("Content-Type", eventStreamMediaType)
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+
+	write := func(id uint64, event string, v interface{}) bool {
+		b, err := json.Marshal(v)
+		if err != nil {
+			logEncodingError(h.Logger, r, err)
+			return false
+		}
+		if _, err := fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", id, event, b); err != nil {
+			return false
+		}
+		flusher.Flush()
+		return true
+	}
+
+	ticker := time.NewTicker(checkWatchPollInterval)
+	defer ticker.Stop()
+	for {
+		for _, e := range h.viewCheckEvents(ctx, events) {
+			if !write(e.ResourceVersion, string(e.Type), e) {
+				return
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			if r.Context().Err() == nil {
+				write(*req.filter.Since, "bookmark", map[string]uint64{"resourceVersion": *req.filter.Since})
+			}
+			return
+		case <-ticker.C:
+		}
+
+		var (
+			version uint64
+			err     error
+		)
+		if events, version, err = h.CheckWatchService.FindCheckEvents(ctx, req.filter); err != nil {
+			if ctx.Err() == nil {
+				write(*req.filter.Since, "error", map[string]string{
+					"code":    influxdb.ErrorCode(err),
+					"message": influxdb.ErrorMessage(err),
+				})
+			}
+			return
+		}
+		req.filter.Since = &version
+	}
+}
@@ -0,0 +1,185 @@
+package http
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/influxdata/influxdb"
+	pcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/notification/check"
+)
+
+func TestCheckHandler_handleGetChecksWatch(t *testing.T) {
+	defer func(d time.Duration) { checkWatchPollInterval = d }(checkWatchPollInterval)
+	checkWatchPollInterval = time.Millisecond
+
+	// the events of the org 2 follow version 3 after 2 polls.
+	var (
+		polls  int
+		latest uint64 = 3
+	)
+	b := NewMockCheckBackend()
+	b.CheckWatchService = &mock.CheckWatchService{
+		FindCheckEventsF: func(ctx context.Context, filter influxdb.CheckEventFilter) ([]*influxdb.CheckEvent, uint64, error) {
+			if filter.Since == nil {
+				return []*influxdb.CheckEvent{}, latest, nil
+			}
+			if *filter.Since < 2 {
+				return nil, 0, &influxdb.Error{
+					Code: influxdb.EConflict,
+					Msg:  "the events were dropped",
+				}
+			}
+			if *filter.Since >= 4 || filter.OrgID != 2 {
+				return []*influxdb.CheckEvent{}, *filter.Since, nil
+			}
+			if polls++; polls < 3 {
+				return []*influxdb.CheckEvent{}, 3, nil
+			}
+			latest = 4
+			return []*influxdb.CheckEvent{
+				{
+					ResourceVersion: 4,
+					Type:            influxdb.CheckCreated,
+					CheckID:         1,
+					OrgID:           2,
+					Check: &check.Deadman{Base: check.Base{
+						ID:    1,
+						OrgID: 2,
+						Name:  "cpu",
+						Every: influxdb.Duration{Duration: time.Minute},
+					}},
+				},
+			}, 4, nil
+		},
+	}
+	h := NewCheckHandler(b)
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/api/v2/checks/watch?orgID=0000000000000002&since=3", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var res struct {
+		Events []struct {
+			ResourceVersion uint64 `json:"resourceVersion"`
+			Type            string `json:"type"`
+			Check           struct {
+				Name string `json:"name"`
+				Type string `json:"type"`
+			} `json:"check"`
+		} `json:"events"`
+		ResourceVersion uint64            `json:"resourceVersion"`
+		Links           map[string]string `json:"links"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&res); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(res.Events) != 1 || res.Events[0].ResourceVersion != 4 || res.Events[0].Type != "created" || res.Events[0].Check.Name != "cpu" || res.Events[0].Check.Type != "deadman" {
+		t.Errorf("expected the watch to wait for the created check, got %+v", res.Events)
+	}
+	if res.ResourceVersion != 4 || res.Links["next"] != "/api/v2/checks/watch?orgID=0000000000000002&since=4" {
+		t.Errorf("expected the watch to continue from version 4, got %d and %v", res.ResourceVersion, res.Links)
+	}
+
+	// a watch without events returns the version to watch from at the timeout.
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/api/v2/checks/watch?orgID=0000000000000002&timeout=10ms", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	res.Events = nil
+	if err := json.NewDecoder(w.Body).Decode(&res); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(res.Events) != 0 || res.ResourceVersion != 4 {
+		t.Errorf("expected no events from the latest version, got %+v at %d", res.Events, res.ResourceVersion)
+	}
+
+	for _, tt := range []struct {
+		target string
+		want   int
+	}{
+		{"/api/v2/checks/watch", http.StatusBadRequest},
+		{"/api/v2/checks/watch?orgID=0000000000000002&since=x", http.StatusBadRequest},
+		{"/api/v2/checks/watch?orgID=0000000000000002&timeout=1h", http.StatusBadRequest},
+		{"/api/v2/checks/watch?orgID=0000000000000002&limit=0", http.StatusBadRequest},
+		{"/api/v2/checks/watch?orgID=0000000000000002&since=1", http.StatusUnprocessableEntity},
+	} {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest("GET", tt.target, nil))
+		if w.Code != tt.want {
+			t.Errorf("%s: expected status %d, got %d", tt.target, tt.want, w.Code)
+		}
+	}
+
+	// an event stream resumes from the last event id, ending with a bookmark.
+	polls = 0
+	r := httptest.NewRequest("GET", "/api/v2/checks/watch?orgID=0000000000000002&timeout=50ms", nil)
+	r.Header.Set("Accept", "text/event-stream")
+	r.Header.Set("Last-Event-ID", "3")
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("expected an event stream, got %d %q: %s", w.Code, w.Header().Get("Content-Type"), w.Body.String())
+	}
+	var ids, events []string
+	scanner := bufio.NewScanner(w.Body)
+	for scanner.Scan() {
+		switch l := scanner.Text(); {
+		case strings.HasPrefix(l, "id: "):
+			ids = append(ids, strings.TrimPrefix(l, "id: "))
+		case strings.HasPrefix(l, "event: "):
+			events = append(events, strings.TrimPrefix(l, "event: "))
+		}
+	}
+	if strings.Join(events, ",") != "created,bookmark" || strings.Join(ids, ",") != "4,4" {
+		t.Errorf("expected the created event and a bookmark at version 4, got events %v with ids %v", events, ids)
+	}
+}
+
+func TestCheckHandler_handleGetChecksWatch_stack(t *testing.T) {
+	b := &APIBackend{
+		HTTPErrorHandler: ErrorHandler(0),
+		AlertingCache:    CacheConfig{MaxAge: time.Minute},
+		CheckWatchService: &mock.CheckWatchService{
+			FindCheckEventsF: func(ctx context.Context, filter influxdb.CheckEventFilter) ([]*influxdb.CheckEvent, uint64, error) {
+				return []*influxdb.CheckEvent{}, 3, nil
+			},
+		},
+	}
+	b.Logger = zap.NewNop()
+
+	// the events are streamed through the writers wrapping the responses of
+	// the server: the status of the handler, the recording of the alerting
+	// requests and the Cache-Control.
+	h := NewHandler("platform")
+	h.Handler = NewRequestRecorder(1).Record(NewAPIHandler(b))
+
+	r := httptest.NewRequest("GET", "/api/v2/checks/watch?orgID=0000000000000002&timeout=10ms", nil)
+	r.Header.Set("Accept", "text/event-stream")
+	r = r.WithContext(pcontext.SetAuthorizer(r.Context(), &influxdb.Authorization{
+		Status:      influxdb.Active,
+		Permissions: influxdb.OperPermissions(),
+	}))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("expected an event stream, got %d %q: %s", w.Code, w.Header().Get("Content-Type"), w.Body.String())
+	}
+	if !w.Flushed {
+		t.Error("expected the events to be flushed")
+	}
+	if !strings.Contains(w.Body.String(), "event: bookmark") {
+		t.Errorf("expected a bookmark, got %s", w.Body.String())
+	}
+}
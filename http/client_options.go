@@ -0,0 +1,239 @@
+package http
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/influxdata/influxdb"
+)
+
+const (
+	// DefaultMinBackoff is the delay before the first retry of a request.
+	DefaultMinBackoff = 100 * time.Millisecond
+	// DefaultMaxBackoff is the longest delay before a retry of a request.
+	DefaultMaxBackoff = 5 * time.Second
+)
+
+// ErrCircuitOpen is returned by the calls failed fast by an open circuit breaker.
+var ErrCircuitOpen = &influxdb.Error{
+	Code: influxdb.EUnavailable,
+	Msg:  "circuit breaker is open, the server keeps failing",
+}
+
+// ClientOptions configures how the HTTP clients of the checks, notification
+// endpoints and notification rules send their calls. The zero value sends
+// each request once, without timeout, like the other clients.
+type ClientOptions struct {
+	// Transport sends the requests in place of the pooled transport of
+	// NewClient, to add headers or metrics for instance. The trace of the
+	// call is injected in the requests before they reach the transport.
+	Transport http.RoundTripper
+	// Timeout bounds each call, its retries included, and the read of its
+	// response. The deadline of the context of the call still applies.
+	Timeout time.Duration
+	// MaxRetries is how many times the idempotent requests, GET, HEAD, PUT
+	// and DELETE, are retried after a network error or a 429, 502, 503 or
+	// 504 response. The other requests are sent once, since a failed
+	// attempt may have changed the resource.
+	MaxRetries int
+	// MinBackoff and MaxBackoff bound the exponential delay before each
+	// retry, they default to DefaultMinBackoff and DefaultMaxBackoff. A
+	// response with a Retry-After header in seconds is retried after the
+	// header, up to MaxBackoff.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// CircuitBreaker fails the calls fast while the server keeps failing,
+	// the clients of a server may share it.
+	CircuitBreaker *CircuitBreaker
+	// OnRetry is called before each retry of a request with the attempt
+	// which failed, counted from 1, and its error.
+	OnRetry func(r *http.Request, attempt int, err error)
+}
+
+// do sends a request of a call, retrying it when it is idempotent. The
+// timeout of the call ends when the body of the response is closed.
+func (o ClientOptions) do(ctx context.Context, scheme string, insecure bool, req *http.Request) (*http.Response, error) {
+	cancel := context.CancelFunc(func() {})
+	if o.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, o.Timeout)
+	}
+	req = req.WithContext(ctx)
+
+	hc := NewClient(scheme, insecure)
+	if o.Transport != nil {
+		hc.Transport = o.Transport
+	}
+	retries := 0
+	if isIdempotent(req.Method) {
+		retries = o.MaxRetries
+	}
+	for attempt := 1; ; attempt++ {
+		resp, err := o.send(hc, req)
+		if attempt > retries || !shouldRetry(resp, err) || ctx.Err() != nil {
+			if err != nil {
+				cancel()
+				return nil, err
+			}
+			resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+			return resp, nil
+		}
+
+		wait := o.backoff(attempt, resp)
+		if resp != nil {
+			err = CheckError(resp)
+			resp.Body.Close()
+		}
+		if o.OnRetry != nil {
+			o.OnRetry(req, attempt, err)
+		}
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				cancel()
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			cancel()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// send sends a request once, through the circuit breaker if any.
+func (o ClientOptions) send(hc *traceClient, req *http.Request) (*http.Response, error) {
+	if o.CircuitBreaker == nil {
+		return hc.Do(req)
+	}
+	if err := o.CircuitBreaker.allow(); err != nil {
+		return nil, err
+	}
+	resp, err := hc.Do(req)
+	o.CircuitBreaker.record(err == nil && resp.StatusCode < http.StatusInternalServerError)
+	return resp, err
+}
+
+// backoff returns the delay before retrying a request after its attempt.
+func (o ClientOptions) backoff(attempt int, resp *http.Response) time.Duration {
+	min, max := o.MinBackoff, o.MaxBackoff
+	if min <= 0 {
+		min = DefaultMinBackoff
+	}
+	if max <= 0 {
+		max = DefaultMaxBackoff
+	}
+	if resp != nil {
+		if s, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && s >= 0 {
+			if d := time.Duration(s) * time.Second; d < max {
+				return d
+			}
+			return max
+		}
+	}
+	d := min << uint(attempt-1)
+	if d <= 0 || d > max {
+		d = max
+	}
+	// half of the delay is random, so the clients failing together don't
+	// retry together.
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case "GET", "HEAD", "PUT", "DELETE", "OPTIONS":
+		return true
+	}
+	return false
+}
+
+// shouldRetry returns whether an attempt failed in a way a retry may fix.
+func shouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		return err != ErrCircuitOpen
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// cancelBody cancels the context of a call when its response body is closed.
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// CircuitBreaker opens after consecutive failed requests to a server and
+// fails the calls fast during its cooldown. It then lets a request through
+// to probe the server, and closes if the request succeeds or opens again
+// otherwise. A request fails with a network error or a 5xx response.
+type CircuitBreaker struct {
+	// TimeGenerator is the clock of the cooldowns.
+	TimeGenerator influxdb.TimeGenerator
+
+	threshold int
+	cooldown  time.Duration
+
+	mu       sync.Mutex
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// NewCircuitBreaker returns a circuit breaker opening after threshold
+// consecutive failed requests, for cooldown.
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		TimeGenerator: influxdb.RealTimeGenerator{},
+		threshold:     threshold,
+		cooldown:      cooldown,
+	}
+}
+
+// allow returns ErrCircuitOpen if a request can't be sent.
+func (b *CircuitBreaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.failures < b.threshold {
+		return nil
+	}
+	if b.probing || b.TimeGenerator.Now().Sub(b.openedAt) < b.cooldown {
+		return ErrCircuitOpen
+	}
+	b.probing = true
+	return nil
+}
+
+// record records whether a request succeeded.
+func (b *CircuitBreaker) record(ok bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+	if ok {
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.failures >= b.threshold {
+		b.openedAt = b.TimeGenerator.Now()
+	}
+}
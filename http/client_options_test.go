@@ -0,0 +1,172 @@
+package http
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
+)
+
+// failingServer responds to the first failures requests with status, and
+// then with 200 and the body of the request.
+func failingServer(t *testing.T, failures int32, status int, calls *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("failed to read request: %v", err)
+		}
+		if atomic.AddInt32(calls, 1) <= failures {
+			w.WriteHeader(status)
+			return
+		}
+		w.Write(body)
+	}))
+}
+
+func TestClientOptions_Retries(t *testing.T) {
+	tests := []struct {
+		name      string
+		method    string
+		failures  int32
+		status    int
+		wantCalls int32
+		wantCode  int
+	}{
+		{name: "get retried until it succeeds", method: "GET", failures: 2, status: http.StatusServiceUnavailable, wantCalls: 3, wantCode: http.StatusOK},
+		{name: "put retried with its body", method: "PUT", failures: 1, status: http.StatusBadGateway, wantCalls: 2, wantCode: http.StatusOK},
+		{name: "get retried until the retries run out", method: "GET", failures: 5, status: http.StatusTooManyRequests, wantCalls: 3, wantCode: http.StatusTooManyRequests},
+		{name: "post not retried", method: "POST", failures: 1, status: http.StatusServiceUnavailable, wantCalls: 1, wantCode: http.StatusServiceUnavailable},
+		{name: "client error not retried", method: "GET", failures: 1, status: http.StatusNotFound, wantCalls: 1, wantCode: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int32
+			srv := failingServer(t, tt.failures, tt.status, &calls)
+			defer srv.Close()
+
+			var retried []int
+			o := ClientOptions{
+				MaxRetries: 2,
+				MinBackoff: time.Millisecond,
+				MaxBackoff: time.Millisecond,
+				OnRetry: func(r *http.Request, attempt int, err error) {
+					retried = append(retried, attempt)
+				},
+			}
+			req, err := http.NewRequest(tt.method, srv.URL, strings.NewReader("check"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := o.do(context.Background(), "http", false, req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.wantCode {
+				t.Errorf("expected status %d, got %d", tt.wantCode, resp.StatusCode)
+			}
+			if calls != tt.wantCalls || len(retried) != int(tt.wantCalls)-1 {
+				t.Errorf("expected %d calls, got %d calls and retries %v", tt.wantCalls, calls, retried)
+			}
+			if resp.StatusCode == http.StatusOK {
+				if body, _ := ioutil.ReadAll(resp.Body); string(body) != "check" {
+					t.Errorf("expected the body of the request to be sent again, got %q", body)
+				}
+			}
+		})
+	}
+}
+
+func TestClientOptions_Timeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	o := ClientOptions{Timeout: 20 * time.Millisecond}
+	req, err := http.NewRequest("GET", srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := o.do(context.Background(), "http", false, req); err == nil {
+		t.Errorf("expected the call to time out")
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	var calls int32
+	srv := failingServer(t, 2, http.StatusInternalServerError, &calls)
+	defer srv.Close()
+
+	now := time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC)
+	b := NewCircuitBreaker(2, time.Minute)
+	b.TimeGenerator = mock.TimeGenerator{FakeValue: now}
+	o := ClientOptions{CircuitBreaker: b}
+	call := func() error {
+		req, err := http.NewRequest("GET", srv.URL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := o.do(context.Background(), "http", false, req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		return CheckError(resp)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := call(); influxdb.ErrorCode(err) != influxdb.EInternal {
+			t.Fatalf("expected the server to fail, got %v", err)
+		}
+	}
+	if err := call(); err != ErrCircuitOpen {
+		t.Errorf("expected the circuit to be open, got %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("expected the open circuit to fail fast, got %d calls", calls)
+	}
+
+	// the request probing the server after the cooldown closes the circuit.
+	b.TimeGenerator = mock.TimeGenerator{FakeValue: now.Add(time.Minute)}
+	for i := 0; i < 2; i++ {
+		if err := call(); err != nil {
+			t.Fatalf("expected the circuit to be closed, got %v", err)
+		}
+	}
+}
+
+func TestCheckService_Retries(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"id": "0000000000000001", "orgID": "0000000000000002", "name": "heartbeat", "type": "heartbeat", "every": "1h", "status": "active", "level": "CRIT"}`))
+	}))
+	defer srv.Close()
+
+	s := &CheckService{
+		Addr: srv.URL,
+		Options: ClientOptions{
+			MaxRetries: 1,
+			MinBackoff: time.Millisecond,
+		},
+	}
+	c, err := s.FindCheckByID(context.Background(), influxdb.ID(1))
+	if err != nil {
+		t.Fatalf("failed to find check: %v", err)
+	}
+	if c.GetName() != "heartbeat" || calls != 2 {
+		t.Errorf("unexpected check %v after %d calls", c, calls)
+	}
+}
@@ -0,0 +1,144 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+)
+
+// corsMethods are the methods allowed to cross-origin requests.
+const corsMethods = "POST, GET, OPTIONS, PUT, PATCH, DELETE"
+
+// corsHeaders are the request headers allowed to every cross-origin request.
+var corsHeaders = []string{"Accept", "Content-Type", "Content-Length", "Accept-Encoding", "Authorization"}
+
+// alertingCORSPrefixes are the routes of the checks and the notifications,
+// which apply the alerting CORS config. A segment starting with a colon
+// matches any segment, like the parameters of the routes.
+var alertingCORSPrefixes = []string{
+	"/api/v2/checks",
+	"/api/v2/notificationRules",
+	"/api/v2/notificationEndpoints",
+	"/api/v2/notificationTemplates",
+	"/api/v2/silences",
+	"/api/v2/monitoringTemplates",
+	"/api/v2/templates",
+	"/api/v2/statuses",
+	"/api/v2/usage/alerting",
+	"/api/v2/orgs/:id/alerting",
+	"/api/v2/orgs/:id/checks",
+	"/api/v2/me/notificationPreferences",
+	"/api/v2/users/:id/notificationPreferences",
+	"/api/v2/labels/:id/apply",
+	"/api/v2/labels/:id/remove",
+}
+
+// alertingRoute returns whether the route of a path is one of the routes of
+// the checks and the notifications.
+func alertingRoute(path string) bool {
+	for _, prefix := range alertingCORSPrefixes {
+		if hasRoutePrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasRoutePrefix returns whether a path starts with the segments of a route
+// prefix, whose parameters match any segment. The path matches on segment
+// boundaries only, /api/v2/checksX doesn't start with /api/v2/checks.
+func hasRoutePrefix(path, prefix string) bool {
+	segments := strings.Split(path, "/")
+	prefixSegments := strings.Split(prefix, "/")
+	if len(segments) < len(prefixSegments) {
+		return false
+	}
+	for i, ps := range prefixSegments {
+		if strings.HasPrefix(ps, ":") {
+			if segments[i] == "" {
+				return false
+			}
+			continue
+		}
+		if segments[i] != ps {
+			return false
+		}
+	}
+	return true
+}
+
+// CORSConfig is the cross-origin resource sharing policy of a set of routes,
+// which lets the web applications of other origins call them from a browser.
+type CORSConfig struct {
+	// AllowedOrigins are the origins allowed to call the routes, such as
+	// https://grafana.example.com. Every origin is allowed when empty or
+	// when it includes *, but only the listed origins may send the session
+	// cookie of their user.
+	AllowedOrigins []string
+	// AllowedHeaders are the request headers allowed besides the default ones.
+	AllowedHeaders []string
+}
+
+// allowOrigin returns whether an origin is allowed, and whether it is listed.
+func (c CORSConfig) allowOrigin(origin string) (allowed bool, listed bool) {
+	if len(c.AllowedOrigins) == 0 {
+		return true, false
+	}
+	for _, o := range c.AllowedOrigins {
+		if strings.EqualFold(o, origin) {
+			return true, true
+		}
+		if o == "*" {
+			allowed = true
+		}
+	}
+	return allowed, false
+}
+
+// setResponseHeaders sets the CORS headers of the response to a request
+// from an allowed origin.
+func (c CORSConfig) setResponseHeaders(w http.ResponseWriter, r *http.Request) {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return
+	}
+	if !varyOrigin(w.Header()) {
+		w.Header().Add("Vary", "Origin")
+	}
+	allowed, listed := c.allowOrigin(origin)
+	if !allowed {
+		return
+	}
+	w.Header().Set("Access-Control-Allow-Origin", origin)
+	w.Header().Set("Access-Control-Allow-Methods", corsMethods)
+	headers := append(append([]string{}, corsHeaders...), c.AllowedHeaders...)
+	w.Header().Set("Access-Control-Allow-Headers", strings.Join(headers, ", "))
+	if listed {
+		w.Header().Set("Access-Control-Allow-Credentials", "true")
+	}
+}
+
+// varyOrigin returns whether the response already varies with the origin.
+func varyOrigin(h http.Header) bool {
+	for _, v := range h["Vary"] {
+		if v == "Origin" {
+			return true
+		}
+	}
+	return false
+}
+
+// corsConfig returns the CORS config of the route of a path, the alerting
+// config for the routes of the checks and notifications, and a config
+// allowing every origin otherwise.
+func corsConfig(path string, alerting CORSConfig) CORSConfig {
+	if alertingRoute(path) {
+		return alerting
+	}
+	return CORSConfig{}
+}
+
+// setCORSResponseHeaders sets the CORS headers of the response to a request,
+// with the alerting config for the routes of checks and notifications.
+func setCORSResponseHeaders(w http.ResponseWriter, r *http.Request, alerting CORSConfig) {
+	corsConfig(r.URL.Path, alerting).setResponseHeaders(w, r)
+}
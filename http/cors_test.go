@@ -0,0 +1,118 @@
+package http
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPIHandler_CORS(t *testing.T) {
+	alerting := CORSConfig{
+		AllowedOrigins: []string{"https://grafana.example.com"},
+		AllowedHeaders: []string{"X-Dashboard"},
+	}
+	tests := []struct {
+		name        string
+		path        string
+		origin      string
+		origins     []string
+		allowOrigin string
+		headers     string
+		credentials string
+	}{
+		{
+			name:        "listed origin of an alerting route",
+			path:        "/api/v2/checks/0000000000000001",
+			origin:      "https://grafana.example.com",
+			allowOrigin: "https://grafana.example.com",
+			headers:     "Accept, Content-Type, Content-Length, Accept-Encoding, Authorization, X-Dashboard",
+			credentials: "true",
+		},
+		{
+			name:   "unlisted origin of an alerting route",
+			path:   "/api/v2/notificationRules",
+			origin: "https://evil.example.com",
+		},
+		{
+			name:        "any origin of the other routes",
+			path:        "/api/v2/buckets",
+			origin:      "https://evil.example.com",
+			allowOrigin: "https://evil.example.com",
+			headers:     "Accept, Content-Type, Content-Length, Accept-Encoding, Authorization",
+		},
+		{
+			name:        "wildcard origin",
+			path:        "/api/v2/notificationEndpoints",
+			origin:      "https://other.example.com",
+			origins:     []string{"*"},
+			allowOrigin: "https://other.example.com",
+			headers:     "Accept, Content-Type, Content-Length, Accept-Encoding, Authorization, X-Dashboard",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := alerting
+			if tt.origins != nil {
+				c.AllowedOrigins = tt.origins
+			}
+			h := &APIHandler{AlertingCORS: c}
+
+			r := httptest.NewRequest("OPTIONS", "http://any.url"+tt.path, nil)
+			r.Header.Set("Origin", tt.origin)
+			r.Header.Set("Access-Control-Request-Method", "PATCH")
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			res := w.Result()
+			if res.StatusCode != 200 {
+				t.Errorf("got status %d, want 200", res.StatusCode)
+			}
+			if got := res.Header.Get("Access-Control-Allow-Origin"); got != tt.allowOrigin {
+				t.Errorf("got allowed origin %q, want %q", got, tt.allowOrigin)
+			}
+			if got := res.Header.Get("Access-Control-Allow-Headers"); got != tt.headers {
+				t.Errorf("got allowed headers %q, want %q", got, tt.headers)
+			}
+			if got := res.Header.Get("Access-Control-Allow-Credentials"); got != tt.credentials {
+				t.Errorf("got allowed credentials %q, want %q", got, tt.credentials)
+			}
+			if tt.allowOrigin != "" && res.Header.Get("Access-Control-Allow-Methods") != corsMethods {
+				t.Errorf("got allowed methods %q", res.Header.Get("Access-Control-Allow-Methods"))
+			}
+			if got := res.Header.Get("Vary"); got != "Origin" {
+				t.Errorf("got vary %q, want Origin", got)
+			}
+		})
+	}
+}
+
+func TestCORSConfig_alertingRoutes(t *testing.T) {
+	alerting := CORSConfig{AllowedOrigins: []string{"https://grafana.example.com"}}
+	tests := []struct {
+		path     string
+		alerting bool
+	}{
+		{path: "/api/v2/checks/0000000000000001/labels", alerting: true},
+		{path: "/api/v2/notificationEndpoints/0000000000000001/budget/usage", alerting: true},
+		{path: "/api/v2/orgs/0000000000000001/alerting/orphans", alerting: true},
+		{path: "/api/v2/orgs/0000000000000001/alerting/coverage", alerting: true},
+		{path: "/api/v2/orgs/0000000000000001/checks/import", alerting: true},
+		{path: "/api/v2/me/notificationPreferences", alerting: true},
+		{path: "/api/v2/users/0000000000000001/notificationPreferences", alerting: true},
+		{path: "/api/v2/labels/0000000000000001/apply", alerting: true},
+		{path: "/api/v2/labels/0000000000000001/remove", alerting: true},
+		{path: "/api/v2/orgs/0000000000000001/members"},
+		{path: "/api/v2/orgs//alerting/orphans"},
+		{path: "/api/v2/checksX"},
+		{path: "/api/v2/statusesX/0000000000000001"},
+		{path: "/api/v2/orgs/0000000000000001/alertingX"},
+		{path: "/api/v2/labels/0000000000000001"},
+		{path: "/api/v2/users/0000000000000001/password"},
+	}
+	for _, tt := range tests {
+		c := corsConfig(tt.path, alerting)
+		if got := len(c.AllowedOrigins) > 0; got != tt.alerting {
+			t.Errorf("%s: got alerting config %t, want %t", tt.path, got, tt.alerting)
+		}
+	}
+}
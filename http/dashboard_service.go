@@ -315,7 +315,7 @@ func newOperationLogEntryResponse(e *platform.OperationLogEntry) *operationLogEn
 // handleGetDashboards returns all dashboards within the store.
 func (h *DashboardHandler) handleGetDashboards(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.Logger.Debug("get dashboards request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "get dashboards request", r)
 
 	req, err := decodeGetDashboardsRequest(ctx, r)
 	if err != nil {
@@ -351,7 +351,7 @@ func (h *DashboardHandler) handleGetDashboards(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	h.Logger.Debug("dashboards retrieved", zap.String("dashboards", fmt.Sprint(dashboards)))
+	debugResult(h.Logger, "dashboards retrieved", "dashboards", dashboards)
 
 	if err := encodeResponse(ctx, w, http.StatusOK, newGetDashboardsResponse(ctx, dashboards, req.filter, req.opts, h.LabelService)); err != nil {
 		logEncodingError(h.Logger, r, err)
@@ -434,7 +434,7 @@ func newGetDashboardsResponse(ctx context.Context, dashboards []*platform.Dashbo
 // handlePostDashboard creates a new dashboard.
 func (h *DashboardHandler) handlePostDashboard(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.Logger.Debug("create dashboard request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "create dashboard request", r)
 
 	req, err := decodePostDashboardRequest(ctx, r)
 	if err != nil {
@@ -469,7 +469,7 @@ func decodePostDashboardRequest(ctx context.Context, r *http.Request) (*postDash
 // hanldeGetDashboard retrieves a dashboard by ID.
 func (h *DashboardHandler) handleGetDashboard(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.Logger.Debug("get dashboard request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "get dashboard request", r)
 
 	req, err := decodeGetDashboardRequest(ctx, r)
 	if err != nil {
@@ -489,7 +489,7 @@ func (h *DashboardHandler) handleGetDashboard(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	h.Logger.Debug("dashboard retrieved", zap.String("dashboard", fmt.Sprint(dashboard)))
+	debugResult(h.Logger, "dashboard retrieved", "dashboard", dashboard)
 
 	if err := encodeResponse(ctx, w, http.StatusOK, newDashboardResponse(dashboard, labels)); err != nil {
 		logEncodingError(h.Logger, r, err)
@@ -524,7 +524,7 @@ func decodeGetDashboardRequest(ctx context.Context, r *http.Request) (*getDashbo
 // hanldeGetDashboardLog retrieves a dashboard log by the dashboards ID.
 func (h *DashboardHandler) handleGetDashboardLog(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.Logger.Debug("get dashboard log request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "get dashboard log request", r)
 
 	req, err := decodeGetDashboardLogRequest(ctx, r)
 	if err != nil {
@@ -538,7 +538,7 @@ func (h *DashboardHandler) handleGetDashboardLog(w http.ResponseWriter, r *http.
 		return
 	}
 
-	h.Logger.Debug("dashboard log retrieved", zap.String("log", fmt.Sprint(log)))
+	debugResult(h.Logger, "dashboard log retrieved", "log", log)
 
 	if err := encodeResponse(ctx, w, http.StatusOK, newDashboardLogResponse(req.DashboardID, log)); err != nil {
 		logEncodingError(h.Logger, r, err)
@@ -580,7 +580,7 @@ func decodeGetDashboardLogRequest(ctx context.Context, r *http.Request) (*getDas
 // handleDeleteDashboard removes a dashboard by ID.
 func (h *DashboardHandler) handleDeleteDashboard(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.Logger.Debug("delete dashboard request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "delete dashboard request", r)
 
 	req, err := decodeDeleteDashboardRequest(ctx, r)
 	if err != nil {
@@ -625,7 +625,7 @@ func decodeDeleteDashboardRequest(ctx context.Context, r *http.Request) (*delete
 // handlePatchDashboard updates a dashboard.
 func (h *DashboardHandler) handlePatchDashboard(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.Logger.Debug("update dashboard request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "update dashboard request", r)
 
 	req, err := decodePatchDashboardRequest(ctx, r)
 	if err != nil {
@@ -644,7 +644,7 @@ func (h *DashboardHandler) handlePatchDashboard(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	h.Logger.Debug("dashboard updated", zap.String("dashboard", fmt.Sprint(dashboard)))
+	debugResult(h.Logger, "dashboard updated", "dashboard", dashboard)
 
 	if err := encodeResponse(ctx, w, http.StatusOK, newDashboardResponse(dashboard, labels)); err != nil {
 		logEncodingError(h.Logger, r, err)
@@ -744,7 +744,7 @@ func decodePostDashboardCellRequest(ctx context.Context, r *http.Request) (*post
 // handlePostDashboardCell creates a dashboard cell.
 func (h *DashboardHandler) handlePostDashboardCell(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.Logger.Debug("create dashboard cell request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "create dashboard cell request", r)
 
 	req, err := decodePostDashboardCellRequest(ctx, r)
 	if err != nil {
@@ -784,7 +784,7 @@ func (h *DashboardHandler) handlePostDashboardCell(w http.ResponseWriter, r *htt
 		return
 	}
 
-	h.Logger.Debug("dashboard cell created", zap.String("dashboardID", req.dashboardID.String()), zap.String("cell", fmt.Sprint(cell)))
+	debugResult(h.Logger, "dashboard cell created", "cell", cell, zap.Stringer("dashboardID", req.dashboardID))
 
 	if err := encodeResponse(ctx, w, http.StatusCreated, newDashboardCellResponse(req.dashboardID, cell)); err != nil {
 		logEncodingError(h.Logger, r, err)
@@ -823,7 +823,7 @@ func decodePutDashboardCellRequest(ctx context.Context, r *http.Request) (*putDa
 // handlePutDashboardCells replaces a dashboards cells.
 func (h *DashboardHandler) handlePutDashboardCells(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.Logger.Debug("replace dashboard cell request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "replace dashboard cell request", r)
 
 	req, err := decodePutDashboardCellRequest(ctx, r)
 	if err != nil {
@@ -836,7 +836,7 @@ func (h *DashboardHandler) handlePutDashboardCells(w http.ResponseWriter, r *htt
 		return
 	}
 
-	h.Logger.Debug("dashboard cell replaced", zap.String("dashboardID", req.dashboardID.String()), zap.String("cells", fmt.Sprint(req.cells)))
+	debugResult(h.Logger, "dashboard cell replaced", "cells", req.cells, zap.Stringer("dashboardID", req.dashboardID))
 
 	if err := encodeResponse(ctx, w, http.StatusCreated, newDashboardCellsResponse(req.dashboardID, req.cells)); err != nil {
 		logEncodingError(h.Logger, r, err)
@@ -908,7 +908,7 @@ func decodeGetDashboardCellViewRequest(ctx context.Context, r *http.Request) (*g
 
 func (h *DashboardHandler) handleGetDashboardCellView(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.Logger.Debug("get dashboard cell view request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "get dashboard cell view request", r)
 
 	req, err := decodeGetDashboardCellViewRequest(ctx, r)
 	if err != nil {
@@ -922,7 +922,7 @@ func (h *DashboardHandler) handleGetDashboardCellView(w http.ResponseWriter, r *
 		return
 	}
 
-	h.Logger.Debug("dashboard cell view retrieved", zap.String("dashboardID", req.dashboardID.String()), zap.String("cellID", req.cellID.String()), zap.String("view", fmt.Sprint(view)))
+	debugResult(h.Logger, "dashboard cell view retrieved", "view", view, zap.Stringer("dashboardID", req.dashboardID), zap.Stringer("cellID", req.cellID))
 
 	if err := encodeResponse(ctx, w, http.StatusOK, newDashboardCellViewResponse(req.dashboardID, req.cellID, view)); err != nil {
 		logEncodingError(h.Logger, r, err)
@@ -965,7 +965,7 @@ func decodePatchDashboardCellViewRequest(ctx context.Context, r *http.Request) (
 
 func (h *DashboardHandler) handlePatchDashboardCellView(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.Logger.Debug("update dashboard cell view request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "update dashboard cell view request", r)
 
 	req, err := decodePatchDashboardCellViewRequest(ctx, r)
 	if err != nil {
@@ -978,7 +978,7 @@ func (h *DashboardHandler) handlePatchDashboardCellView(w http.ResponseWriter, r
 		h.HandleHTTPError(ctx, err, w)
 		return
 	}
-	h.Logger.Debug("dashboard cell view updated", zap.String("dashboardID", req.dashboardID.String()), zap.String("cellID", req.cellID.String()), zap.String("view", fmt.Sprint(view)))
+	debugResult(h.Logger, "dashboard cell view updated", "view", view, zap.Stringer("dashboardID", req.dashboardID), zap.Stringer("cellID", req.cellID))
 
 	if err := encodeResponse(ctx, w, http.StatusOK, newDashboardCellViewResponse(req.dashboardID, req.cellID, view)); err != nil {
 		logEncodingError(h.Logger, r, err)
@@ -989,7 +989,7 @@ func (h *DashboardHandler) handlePatchDashboardCellView(w http.ResponseWriter, r
 // handleDeleteDashboardCell deletes a dashboard cell.
 func (h *DashboardHandler) handleDeleteDashboardCell(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.Logger.Debug("delete dashboard cell request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "delete dashboard cell request", r)
 
 	req, err := decodeDeleteDashboardCellRequest(ctx, r)
 	if err != nil {
@@ -1054,7 +1054,7 @@ func decodePatchDashboardCellRequest(ctx context.Context, r *http.Request) (*pat
 // handlePatchDashboardCell updates a dashboard cell.
 func (h *DashboardHandler) handlePatchDashboardCell(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.Logger.Debug("update dashboard cell request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "update dashboard cell request", r)
 
 	req, err := decodePatchDashboardCellRequest(ctx, r)
 	if err != nil {
@@ -1067,7 +1067,7 @@ func (h *DashboardHandler) handlePatchDashboardCell(w http.ResponseWriter, r *ht
 		return
 	}
 
-	h.Logger.Debug("dashboard cell updated", zap.String("dashboardID", req.dashboardID.String()), zap.String("cell", fmt.Sprint(cell)))
+	debugResult(h.Logger, "dashboard cell updated", "cell", cell, zap.Stringer("dashboardID", req.dashboardID))
 
 	if err := encodeResponse(ctx, w, http.StatusOK, newDashboardCellResponse(req.dashboardID, cell)); err != nil {
 		logEncodingError(h.Logger, r, err)
@@ -110,7 +110,7 @@ func newDocumentsResponse(ns string, docs []*influxdb.Document) *documentsRespon
 // handlePostDocument is the HTTP handler for the POST /api/v2/documents/:ns route.
 func (h *DocumentHandler) handlePostDocument(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.Logger.Debug("document create request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "document create request", r)
 
 	req, err := decodePostDocumentRequest(ctx, r)
 	if err != nil {
@@ -145,7 +145,7 @@ func (h *DocumentHandler) handlePostDocument(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	h.Logger.Debug("document created", zap.String("document", fmt.Sprint(req.Document)))
+	debugResult(h.Logger, "document created", "document", req.Document)
 
 	if err := encodeResponse(ctx, w, http.StatusCreated, newDocumentResponse(req.Namespace, req.Document)); err != nil {
 		logEncodingError(h.Logger, r, err)
@@ -193,7 +193,7 @@ func decodePostDocumentRequest(ctx context.Context, r *http.Request) (*postDocum
 // handleGetDocuments is the HTTP handler for the GET /api/v2/documents/:ns route.
 func (h *DocumentHandler) handleGetDocuments(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.Logger.Debug("documents retrieve request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "documents retrieve request", r)
 
 	req, err := decodeGetDocumentsRequest(ctx, r)
 	if err != nil {
@@ -233,7 +233,7 @@ func (h *DocumentHandler) handleGetDocuments(w http.ResponseWriter, r *http.Requ
 		h.HandleHTTPError(ctx, err, w)
 		return
 	}
-	h.Logger.Debug("documents retrieved", zap.String("documents", fmt.Sprint(ds)))
+	debugResult(h.Logger, "documents retrieved", "documents", ds)
 
 	if err := encodeResponse(ctx, w, http.StatusOK, newDocumentsResponse(req.Namespace, ds)); err != nil {
 		logEncodingError(h.Logger, r, err)
@@ -279,7 +279,7 @@ func decodeGetDocumentsRequest(ctx context.Context, r *http.Request) (*getDocume
 
 func (h *DocumentHandler) handlePostDocumentLabel(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.Logger.Debug("document label create request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "document label create request", r)
 	_, _, err := h.getDocument(w, r)
 	if err != nil {
 		h.HandleHTTPError(ctx, err, w)
@@ -306,7 +306,7 @@ func (h *DocumentHandler) handlePostDocumentLabel(w http.ResponseWriter, r *http
 		h.HandleHTTPError(ctx, err, w)
 		return
 	}
-	h.Logger.Debug("document label created", zap.String("label", fmt.Sprint(label)))
+	debugResult(h.Logger, "document label created", "label", label)
 
 	if err := encodeResponse(ctx, w, http.StatusCreated, newLabelResponse(label)); err != nil {
 		logEncodingError(h.Logger, r, err)
@@ -318,7 +318,7 @@ func (h *DocumentHandler) handlePostDocumentLabel(w http.ResponseWriter, r *http
 // then remove that label.
 func (h *DocumentHandler) handleDeleteDocumentLabel(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.Logger.Debug("document label delete request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "document label delete request", r)
 	req, err := decodeDeleteLabelMappingRequest(ctx, r)
 	if err != nil {
 		h.HandleHTTPError(ctx, err, w)
@@ -347,20 +347,20 @@ func (h *DocumentHandler) handleDeleteDocumentLabel(w http.ResponseWriter, r *ht
 		h.HandleHTTPError(ctx, err, w)
 		return
 	}
-	h.Logger.Debug("document label deleted", zap.String("mapping", fmt.Sprint(mapping)))
+	debugResult(h.Logger, "document label deleted", "mapping", mapping)
 
 	w.WriteHeader(http.StatusNoContent)
 }
 
 func (h *DocumentHandler) handleGetDocumentLabel(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.Logger.Debug("document label retrieve request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "document label retrieve request", r)
 	d, _, err := h.getDocument(w, r)
 	if err != nil {
 		h.HandleHTTPError(ctx, err, w)
 		return
 	}
-	h.Logger.Debug("document label retrieved", zap.String("labels", fmt.Sprint(d.Labels)))
+	debugResult(h.Logger, "document label retrieved", "labels", d.Labels)
 
 	if err := encodeResponse(ctx, w, http.StatusOK, newLabelsResponse(d.Labels)); err != nil {
 		logEncodingError(h.Logger, r, err)
@@ -400,14 +400,14 @@ func (h *DocumentHandler) getDocument(w http.ResponseWriter, r *http.Request) (*
 // handleGetDocument is the HTTP handler for the GET /api/v2/documents/:ns/:id route.
 func (h *DocumentHandler) handleGetDocument(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.Logger.Debug("document retrieve request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "document retrieve request", r)
 
 	d, namspace, err := h.getDocument(w, r)
 	if err != nil {
 		h.HandleHTTPError(ctx, err, w)
 		return
 	}
-	h.Logger.Debug("document retrieved", zap.String("document", fmt.Sprint(d)))
+	debugResult(h.Logger, "document retrieved", "document", d)
 
 	if err := encodeResponse(ctx, w, http.StatusOK, newDocumentResponse(namspace, d)); err != nil {
 		logEncodingError(h.Logger, r, err)
@@ -455,7 +455,7 @@ func decodeGetDocumentRequest(ctx context.Context, r *http.Request) (*getDocumen
 // handleDeleteDocument is the HTTP handler for the DELETE /api/v2/documents/:ns/:id route.
 func (h *DocumentHandler) handleDeleteDocument(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.Logger.Debug("document delete request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "document delete request", r)
 
 	req, err := decodeDeleteDocumentRequest(ctx, r)
 	if err != nil {
@@ -480,7 +480,7 @@ func (h *DocumentHandler) handleDeleteDocument(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	h.Logger.Debug("document deleted", zap.String("documentID", fmt.Sprint(req.ID)))
+	h.Logger.Debug("document deleted", zap.Stringer("documentID", req.ID))
 
 	w.WriteHeader(http.StatusNoContent)
 }
@@ -525,7 +525,7 @@ func decodeDeleteDocumentRequest(ctx context.Context, r *http.Request) (*deleteD
 // handlePutDocument is the HTTP handler for the PUT /api/v2/documents/:ns/:id route.
 func (h *DocumentHandler) handlePutDocument(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.Logger.Debug("document update request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "document update request", r)
 
 	req, err := decodePutDocumentRequest(ctx, r)
 	if err != nil {
@@ -567,7 +567,7 @@ func (h *DocumentHandler) handlePutDocument(w http.ResponseWriter, r *http.Reque
 
 	d := ds[0]
 
-	h.Logger.Debug("document updated", zap.String("document", fmt.Sprint(d)))
+	debugResult(h.Logger, "document updated", "document", d)
 
 	if err := encodeResponse(ctx, w, http.StatusOK, newDocumentResponse(req.Namespace, d)); err != nil {
 		logEncodingError(h.Logger, r, err)
@@ -0,0 +1,51 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/influxdata/influxdb"
+	pctx "github.com/influxdata/influxdb/context"
+)
+
+// dryRunResponse is the response to a mutation run with ?dryRun=true: the
+// resource it would respond with, and its side effects.
+type dryRunResponse struct {
+	DryRun bool `json:"dryRun"`
+	// Resource is the created or updated resource, nil for a deletion.
+	Resource interface{}      `json:"resource,omitempty"`
+	Effects  *influxdb.DryRun `json:"effects"`
+}
+
+// decodeDryRun returns the context of a mutation run by r, which records its
+// side effects in the returned dry run instead of persisting them if r is a
+// dry run. The dry run is nil otherwise.
+func decodeDryRun(ctx context.Context, r *http.Request) (context.Context, *influxdb.DryRun, error) {
+	s := r.URL.Query().Get("dryRun")
+	if s == "" {
+		return ctx, nil, nil
+	}
+	dryRun, err := strconv.ParseBool(s)
+	if err != nil {
+		return ctx, nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "dryRun is invalid",
+		}
+	}
+	if !dryRun {
+		return ctx, nil, nil
+	}
+	d := influxdb.NewDryRun()
+	return pctx.SetDryRun(ctx, d), d, nil
+}
+
+// encodeDryRunResponse responds to a dry run with the resource the mutation
+// would respond with, and its side effects.
+func encodeDryRunResponse(ctx context.Context, w http.ResponseWriter, resource interface{}, d *influxdb.DryRun) error {
+	return encodeResponse(ctx, w, http.StatusOK, dryRunResponse{
+		DryRun:   true,
+		Resource: resource,
+		Effects:  d,
+	})
+}
@@ -0,0 +1,91 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	pctx "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/notification/check"
+)
+
+func TestCheckHandler_dryRun(t *testing.T) {
+	var patched, deleted bool
+	b := NewMockCheckBackend()
+	b.CheckService = &mock.CheckService{
+		PatchCheckF: func(ctx context.Context, id influxdb.ID, upd influxdb.CheckUpdate) (influxdb.Check, error) {
+			d := pctx.GetDryRun(ctx)
+			if d == nil {
+				patched = true
+			} else {
+				d.Tasks = append(d.Tasks, &influxdb.Task{ID: 3, OrganizationID: 2, AuthorizationID: 5, Status: string(*upd.Status)})
+				d.NotificationRules = append(d.NotificationRules, 4)
+			}
+			return &check.Deadman{
+				Base: check.Base{ID: id, OrgID: 2, Name: "heartbeat", Status: *upd.Status, TaskID: 3},
+			}, nil
+		},
+		DeleteCheckF: func(ctx context.Context, id influxdb.ID) error {
+			d := pctx.GetDryRun(ctx)
+			if d == nil {
+				deleted = true
+			} else {
+				d.DeletedTasks = append(d.DeletedTasks, 3)
+			}
+			return nil
+		},
+	}
+	h := NewCheckHandler(b)
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("PATCH", "/api/v2/checks/0000000000000001?dryRun=true", strings.NewReader(`{"status": "inactive"}`)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var got struct {
+		DryRun   bool `json:"dryRun"`
+		Resource struct {
+			ID     string `json:"id"`
+			Status string `json:"status"`
+		} `json:"resource"`
+		Effects influxdb.DryRun `json:"effects"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if !got.DryRun || got.Resource.ID != "0000000000000001" || got.Resource.Status != "inactive" {
+		t.Errorf("unexpected response %+v", got)
+	}
+	if len(got.Effects.Tasks) != 1 || got.Effects.Tasks[0].Status != "inactive" || len(got.Effects.NotificationRules) != 1 {
+		t.Errorf("unexpected side effects %+v", got.Effects)
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("DELETE", "/api/v2/checks/0000000000000001?dryRun=true", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if want := `{"dryRun":true,"effects":{"tasks":[],"deletedTasks":["0000000000000003"],"secrets":[],"deletedSecrets":[],"notificationRules":[]}}`; strings.TrimSpace(w.Body.String()) != want {
+		t.Errorf("unexpected response %s, want %s", w.Body.String(), want)
+	}
+	if patched || deleted {
+		t.Errorf("expected the dry runs not to patch nor delete the check")
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("DELETE", "/api/v2/checks/0000000000000001?dryRun=maybe", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected an invalid dryRun to be rejected, got status %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("DELETE", "/api/v2/checks/0000000000000001?dryRun=false", nil))
+	if w.Code != http.StatusNoContent || !deleted {
+		t.Errorf("expected the check to be deleted, got status %d", w.Code)
+	}
+}
@@ -0,0 +1,74 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/influxdata/influxdb"
+	"go.uber.org/zap"
+)
+
+const (
+	labelsIDApplyPath  = "/api/v2/labels/:id/apply"
+	labelsIDRemovePath = "/api/v2/labels/:id/remove"
+)
+
+type postLabelBulkRequest struct {
+	LabelID   influxdb.ID
+	Operation influxdb.LabelBulkOperation
+}
+
+func decodePostLabelBulkRequest(ctx context.Context, r *http.Request) (*postLabelBulkRequest, error) {
+	req, err := decodeGetLabelRequest(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	var o influxdb.LabelBulkOperation
+	if err := json.NewDecoder(r.Body).Decode(&o); err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "unable to decode label bulk operation",
+			Err:  err,
+		}
+	}
+	if err := o.Valid(); err != nil {
+		return nil, err
+	}
+	return &postLabelBulkRequest{
+		LabelID:   req.LabelID,
+		Operation: o,
+	}, nil
+}
+
+// handlePostLabelApply is the HTTP handler for the POST /api/v2/labels/:id/apply route.
+func (h *LabelHandler) handlePostLabelApply(w http.ResponseWriter, r *http.Request) {
+	h.handlePostLabelBulk(w, r, "apply", h.LabelBulkService.ApplyLabel)
+}
+
+// handlePostLabelRemove is the HTTP handler for the POST /api/v2/labels/:id/remove route.
+func (h *LabelHandler) handlePostLabelRemove(w http.ResponseWriter, r *http.Request) {
+	h.handlePostLabelBulk(w, r, "remove", h.LabelBulkService.RemoveLabel)
+}
+
+func (h *LabelHandler) handlePostLabelBulk(w http.ResponseWriter, r *http.Request, op string, fn func(context.Context, influxdb.ID, influxdb.LabelBulkOperation) (*influxdb.LabelBulkResult, error)) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "label bulk "+op+" request", r)
+	req, err := decodePostLabelBulkRequest(ctx, r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	res, err := fn(ctx, req.LabelID, req.Operation)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	h.Logger.Debug("label bulk "+op, zap.String("labelID", req.LabelID.String()), zap.Int("changed", len(res.Changed)), zap.Bool("dryRun", res.DryRun))
+
+	if err := encodeResponse(ctx, w, http.StatusOK, res); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
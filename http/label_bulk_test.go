@@ -0,0 +1,66 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
+)
+
+func TestLabelHandler_handlePostLabelBulk(t *testing.T) {
+	result := func(labelID influxdb.ID, o influxdb.LabelBulkOperation) *influxdb.LabelBulkResult {
+		return &influxdb.LabelBulkResult{
+			LabelID:      labelID,
+			ResourceType: o.ResourceType,
+			DryRun:       o.DryRun,
+			Matched:      2,
+			Changed:      []influxdb.ID{3},
+			Unchanged:    1,
+		}
+	}
+	var removed bool
+	h := NewLabelHandler(mock.NewLabelService(), ErrorHandler(0))
+	h.LabelBulkService = &mock.LabelBulkService{
+		ApplyLabelF: func(ctx context.Context, labelID influxdb.ID, o influxdb.LabelBulkOperation) (*influxdb.LabelBulkResult, error) {
+			if o.Filter.OrgID != influxdb.ID(2) || o.Filter.Tags["team"] != "ops" {
+				t.Errorf("unexpected filter %+v", o.Filter)
+			}
+			return result(labelID, o), nil
+		},
+		RemoveLabelF: func(ctx context.Context, labelID influxdb.ID, o influxdb.LabelBulkOperation) (*influxdb.LabelBulkResult, error) {
+			removed = true
+			return result(labelID, o), nil
+		},
+	}
+
+	body := `{"resourceType": "checks", "filter": {"orgID": "0000000000000002", "tags": {"team": "ops"}}}`
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("POST", "/api/v2/labels/0000000000000001/apply", bytes.NewBufferString(body)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var got influxdb.LabelBulkResult
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if got.LabelID != influxdb.ID(1) || got.Matched != 2 || len(got.Changed) != 1 || got.Unchanged != 1 {
+		t.Errorf("unexpected result %+v", got)
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("POST", "/api/v2/labels/0000000000000001/remove", bytes.NewBufferString(body)))
+	if w.Code != http.StatusOK || !removed {
+		t.Errorf("expected the label to be removed, got status %d: %s", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("POST", "/api/v2/labels/0000000000000001/apply", bytes.NewBufferString(`{"resourceType": "dashboards", "filter": {"orgID": "0000000000000002"}}`)))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d for dashboards, got %d: %s", http.StatusBadRequest, w.Code, w.Body.String())
+	}
+}
@@ -21,6 +21,8 @@ type LabelHandler struct {
 	Logger *zap.Logger
 
 	LabelService influxdb.LabelService
+	// LabelBulkService applies the labels to many resources at once.
+	LabelBulkService influxdb.LabelBulkService
 }
 
 const (
@@ -43,6 +45,8 @@ func NewLabelHandler(s influxdb.LabelService, he influxdb.HTTPErrorHandler) *Lab
 	h.HandlerFunc("GET", labelsIDPath, h.handleGetLabel)
 	h.HandlerFunc("PATCH", labelsIDPath, h.handlePatchLabel)
 	h.HandlerFunc("DELETE", labelsIDPath, h.handleDeleteLabel)
+	h.HandlerFunc("POST", labelsIDApplyPath, h.handlePostLabelApply)
+	h.HandlerFunc("POST", labelsIDRemovePath, h.handlePostLabelRemove)
 
 	return h
 }
@@ -50,7 +54,7 @@ func NewLabelHandler(s influxdb.LabelService, he influxdb.HTTPErrorHandler) *Lab
 // handlePostLabel is the HTTP handler for the POST /api/v2/labels route.
 func (h *LabelHandler) handlePostLabel(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.Logger.Debug("label create request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "label create request", r)
 
 	req, err := decodePostLabelRequest(ctx, r)
 	if err != nil {
@@ -62,7 +66,7 @@ func (h *LabelHandler) handlePostLabel(w http.ResponseWriter, r *http.Request) {
 		h.HandleHTTPError(ctx, err, w)
 		return
 	}
-	h.Logger.Debug("label created", zap.String("label", fmt.Sprint(req.Label)))
+	debugResult(h.Logger, "label created", "label", req.Label)
 	if err := encodeResponse(ctx, w, http.StatusCreated, newLabelResponse(req.Label)); err != nil {
 		logEncodingError(h.Logger, r, err)
 		return
@@ -110,7 +114,7 @@ func decodePostLabelRequest(ctx context.Context, r *http.Request) (*postLabelReq
 // handleGetLabels is the HTTP handler for the GET /api/v2/labels route.
 func (h *LabelHandler) handleGetLabels(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.Logger.Debug("labels retrieve request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "labels retrieve request", r)
 
 	req, err := decodeGetLabelsRequest(ctx, r)
 	if err != nil {
@@ -123,7 +127,7 @@ func (h *LabelHandler) handleGetLabels(w http.ResponseWriter, r *http.Request) {
 		h.HandleHTTPError(ctx, err, w)
 		return
 	}
-	h.Logger.Debug("labels retrived", zap.String("labels", fmt.Sprint(labels)))
+	debugResult(h.Logger, "labels retrived", "labels", labels)
 	err = encodeResponse(ctx, w, http.StatusOK, newLabelsResponse(labels))
 	if err != nil {
 		h.HandleHTTPError(ctx, err, w)
@@ -153,7 +157,7 @@ func decodeGetLabelsRequest(ctx context.Context, r *http.Request) (*getLabelsReq
 // handleGetLabel is the HTTP handler for the GET /api/v2/labels/id route.
 func (h *LabelHandler) handleGetLabel(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.Logger.Debug("label retrieve request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "label retrieve request", r)
 
 	req, err := decodeGetLabelRequest(ctx, r)
 	if err != nil {
@@ -166,7 +170,7 @@ func (h *LabelHandler) handleGetLabel(w http.ResponseWriter, r *http.Request) {
 		h.HandleHTTPError(ctx, err, w)
 		return
 	}
-	h.Logger.Debug("label retrieved", zap.String("label", fmt.Sprint(l)))
+	debugResult(h.Logger, "label retrieved", "label", l)
 	if err := encodeResponse(ctx, w, http.StatusOK, newLabelResponse(l)); err != nil {
 		logEncodingError(h.Logger, r, err)
 		return
@@ -201,7 +205,7 @@ func decodeGetLabelRequest(ctx context.Context, r *http.Request) (*getLabelReque
 // handleDeleteLabel is the HTTP handler for the DELETE /api/v2/labels/:id route.
 func (h *LabelHandler) handleDeleteLabel(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.Logger.Debug("label delete request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "label delete request", r)
 
 	req, err := decodeDeleteLabelRequest(ctx, r)
 	if err != nil {
@@ -213,7 +217,7 @@ func (h *LabelHandler) handleDeleteLabel(w http.ResponseWriter, r *http.Request)
 		h.HandleHTTPError(ctx, err, w)
 		return
 	}
-	h.Logger.Debug("label deleted", zap.String("labelID", fmt.Sprint(req.LabelID)))
+	h.Logger.Debug("label deleted", zap.Stringer("labelID", req.LabelID))
 	w.WriteHeader(http.StatusNoContent)
 }
 
@@ -245,7 +249,7 @@ func decodeDeleteLabelRequest(ctx context.Context, r *http.Request) (*deleteLabe
 // handlePatchLabel is the HTTP handler for the PATCH /api/v2/labels route.
 func (h *LabelHandler) handlePatchLabel(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.Logger.Debug("label update request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "label update request", r)
 
 	req, err := decodePatchLabelRequest(ctx, r)
 	if err != nil {
@@ -258,7 +262,7 @@ func (h *LabelHandler) handlePatchLabel(w http.ResponseWriter, r *http.Request)
 		h.HandleHTTPError(ctx, err, w)
 		return
 	}
-	h.Logger.Debug("label updated", zap.String("label", fmt.Sprint(l)))
+	debugResult(h.Logger, "label updated", "label", l)
 	if err := encodeResponse(ctx, w, http.StatusOK, newLabelResponse(l)); err != nil {
 		logEncodingError(h.Logger, r, err)
 		return
@@ -0,0 +1,375 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/influxdata/influxdb"
+	pctx "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/notification/check"
+	"github.com/influxdata/influxdb/notification/endpoint"
+	"github.com/influxdata/influxdb/notification/rule"
+	"github.com/julienschmidt/httprouter"
+	"go.uber.org/zap"
+)
+
+// MonitoringTemplateBackend is all services and associated parameters required to construct
+// the MonitoringTemplateHandler.
+type MonitoringTemplateBackend struct {
+	influxdb.HTTPErrorHandler
+	Logger *zap.Logger
+
+	MonitoringTemplateService influxdb.MonitoringTemplateService
+	MonitoringTemplateFetcher *MonitoringTemplateFetcher
+}
+
+// NewMonitoringTemplateBackend returns a new instance of MonitoringTemplateBackend.
+func NewMonitoringTemplateBackend(b *APIBackend) *MonitoringTemplateBackend {
+	return &MonitoringTemplateBackend{
+		HTTPErrorHandler: b.HTTPErrorHandler,
+		Logger:           b.Logger.With(zap.String("handler", "monitoring_template")),
+
+		MonitoringTemplateService: b.MonitoringTemplateService,
+		MonitoringTemplateFetcher: NewMonitoringTemplateFetcher(),
+	}
+}
+
+// MonitoringTemplateHandler is the handler for the monitoring template service
+type MonitoringTemplateHandler struct {
+	*httprouter.Router
+	influxdb.HTTPErrorHandler
+	Logger *zap.Logger
+
+	MonitoringTemplateService influxdb.MonitoringTemplateService
+	MonitoringTemplateFetcher *MonitoringTemplateFetcher
+}
+
+const (
+	monitoringTemplatesPath   = "/api/v2/monitoringTemplates"
+	monitoringTemplatesIDPath = "/api/v2/monitoringTemplates/:id"
+)
+
+// NewMonitoringTemplateHandler returns a new instance of MonitoringTemplateHandler.
+func NewMonitoringTemplateHandler(b *MonitoringTemplateBackend) *MonitoringTemplateHandler {
+	h := &MonitoringTemplateHandler{
+		Router:           NewRouter(b.HTTPErrorHandler),
+		HTTPErrorHandler: b.HTTPErrorHandler,
+		Logger:           b.Logger,
+
+		MonitoringTemplateService: b.MonitoringTemplateService,
+		MonitoringTemplateFetcher: b.MonitoringTemplateFetcher,
+	}
+
+	h.HandlerFunc("POST", monitoringTemplatesPath, h.handlePostMonitoringTemplate)
+	h.HandlerFunc("GET", monitoringTemplatesPath, h.handleGetMonitoringTemplates)
+	h.HandlerFunc("GET", monitoringTemplatesIDPath, h.handleGetMonitoringTemplate)
+	h.HandlerFunc("PUT", monitoringTemplatesIDPath, h.handlePutMonitoringTemplate)
+	h.HandlerFunc("DELETE", monitoringTemplatesIDPath, h.handleDeleteMonitoringTemplate)
+	h.HandlerFunc("POST", templatesInstallPath, h.handlePostTemplateInstall)
+	return h
+}
+
+type monitoringTemplateLinks struct {
+	Self string `json:"self"`
+	Org  string `json:"org"`
+}
+
+type monitoringTemplateResponse struct {
+	*influxdb.MonitoringTemplateManifest
+	Links monitoringTemplateLinks `json:"links"`
+}
+
+func newMonitoringTemplateResponse(m *influxdb.MonitoringTemplateManifest) *monitoringTemplateResponse {
+	return &monitoringTemplateResponse{
+		MonitoringTemplateManifest: m,
+		Links: monitoringTemplateLinks{
+			Self: fmt.Sprintf("/api/v2/monitoringTemplates/%s", m.ID),
+			Org:  fmt.Sprintf("/api/v2/orgs/%s", m.OrgID),
+		},
+	}
+}
+
+type monitoringTemplatesResponse struct {
+	MonitoringTemplates []*monitoringTemplateResponse `json:"monitoringTemplates"`
+	Links               *influxdb.PagingLinks         `json:"links"`
+}
+
+func newMonitoringTemplatesResponse(ms []*influxdb.MonitoringTemplateManifest) *monitoringTemplatesResponse {
+	resp := &monitoringTemplatesResponse{
+		MonitoringTemplates: make([]*monitoringTemplateResponse, len(ms)),
+		Links: &influxdb.PagingLinks{
+			Self: monitoringTemplatesPath,
+		},
+	}
+	for i, m := range ms {
+		resp.MonitoringTemplates[i] = newMonitoringTemplateResponse(m)
+	}
+	return resp
+}
+
+func decodeGetMonitoringTemplateRequest(ctx context.Context, r *http.Request) (i influxdb.ID, err error) {
+	params := httprouter.ParamsFromContext(ctx)
+	id := params.ByName("id")
+	if id == "" {
+		return i, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "url missing id",
+		}
+	}
+
+	if err := i.DecodeFromString(id); err != nil {
+		return i, err
+	}
+	return i, nil
+}
+
+// monitoringTemplateRequest is the JSON format of a template, its checks, endpoints
+// and rules have the JSON format of their routes, the rules also have the name
+// of the endpoint they send to.
+type monitoringTemplateRequest struct {
+	Name        string                                   `json:"name"`
+	Version     string                                   `json:"version"`
+	Description string                                   `json:"description"`
+	Requires    []influxdb.MonitoringTemplateRequirement `json:"requires"`
+	Labels      []influxdb.MonitoringTemplateLabel       `json:"labels"`
+	Checks      []json.RawMessage                        `json:"checks"`
+	Endpoints   []json.RawMessage                        `json:"endpoints"`
+	Rules       []json.RawMessage                        `json:"rules"`
+	Dashboards  []string                                 `json:"dashboards"`
+}
+
+func (tr *monitoringTemplateRequest) toMonitoringTemplate() (*influxdb.MonitoringTemplate, error) {
+	t := &influxdb.MonitoringTemplate{
+		Name:        tr.Name,
+		Version:     tr.Version,
+		Description: tr.Description,
+		Requires:    tr.Requires,
+		Labels:      tr.Labels,
+		Dashboards:  tr.Dashboards,
+	}
+	for _, b := range tr.Checks {
+		c, err := check.UnmarshalJSON(b)
+		if err != nil {
+			return nil, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Err:  err,
+			}
+		}
+		t.Checks = append(t.Checks, c)
+	}
+	for _, b := range tr.Endpoints {
+		edp, err := endpoint.UnmarshalJSON(b)
+		if err != nil {
+			return nil, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Err:  err,
+			}
+		}
+		t.Endpoints = append(t.Endpoints, edp)
+	}
+	for _, b := range tr.Rules {
+		nr, err := rule.UnmarshalJSON(b)
+		if err != nil {
+			return nil, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Err:  err,
+			}
+		}
+		var raw struct {
+			EndpointName string `json:"endpointName"`
+		}
+		if err := json.Unmarshal(b, &raw); err != nil {
+			return nil, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Err:  err,
+			}
+		}
+		t.Rules = append(t.Rules, influxdb.MonitoringTemplateRule{
+			Rule:         nr,
+			EndpointName: raw.EndpointName,
+		})
+	}
+	if err := t.Valid(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+type monitoringTemplateInstallRequest struct {
+	OrgID            influxdb.ID               `json:"orgID"`
+	Template         monitoringTemplateRequest `json:"template"`
+	Secrets          map[string]string         `json:"secrets"`
+	AuthorizationID  influxdb.ID               `json:"authorizationID"`
+	DeterministicIDs bool                      `json:"deterministicIDs"`
+}
+
+// decodeMonitoringTemplateInstallRequest decodes the install of a template, the rules
+// are sent with the authorization of the request by default.
+func decodeMonitoringTemplateInstallRequest(ctx context.Context, r *http.Request) (*monitoringTemplateInstallRequest, *influxdb.MonitoringTemplateInstall, error) {
+	req := &monitoringTemplateInstallRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		return nil, nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  err.Error(),
+		}
+	}
+	t, err := req.Template.toMonitoringTemplate()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	install := &influxdb.MonitoringTemplateInstall{
+		Template:         t,
+		Secrets:          req.Secrets,
+		AuthorizationID:  req.AuthorizationID,
+		DeterministicIDs: req.DeterministicIDs,
+	}
+	if !install.AuthorizationID.Valid() {
+		if auth, err := pctx.GetAuthorizer(ctx); err == nil && auth.Kind() == influxdb.AuthorizationKind {
+			install.AuthorizationID = auth.Identifier()
+		}
+	}
+	return req, install, nil
+}
+
+func (h *MonitoringTemplateHandler) handleGetMonitoringTemplates(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "monitoring templates retrieve request", r)
+	orgID, err := influxdb.IDFromString(r.URL.Query().Get("orgID"))
+	if err != nil {
+		h.HandleHTTPError(ctx, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "orgID is invalid",
+			Err:  err,
+		}, w)
+		return
+	}
+
+	ms, err := h.MonitoringTemplateService.FindMonitoringTemplateManifests(ctx, *orgID)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	debugResult(h.Logger, "monitoring templates retrieved", "monitoringTemplates", ms)
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newMonitoringTemplatesResponse(ms)); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+func (h *MonitoringTemplateHandler) handleGetMonitoringTemplate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "monitoring template retrieve request", r)
+	id, err := decodeGetMonitoringTemplateRequest(ctx, r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	m, err := h.MonitoringTemplateService.FindMonitoringTemplateManifestByID(ctx, id)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	debugResult(h.Logger, "monitoring template retrieved", "monitoringTemplate", m)
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newMonitoringTemplateResponse(m)); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+// handlePostMonitoringTemplate is the HTTP handler for the POST /api/v2/monitoringTemplates route,
+// installing a template into an organization.
+func (h *MonitoringTemplateHandler) handlePostMonitoringTemplate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "monitoring template install request", r)
+	req, install, err := decodeMonitoringTemplateInstallRequest(ctx, r)
+	if err != nil {
+		h.Logger.Debug("failed to decode request", zap.Error(err))
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	if !req.OrgID.Valid() {
+		h.HandleHTTPError(ctx, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "orgID is invalid",
+		}, w)
+		return
+	}
+	auth, err := pctx.GetAuthorizer(ctx)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	m, err := h.MonitoringTemplateService.InstallMonitoringTemplate(ctx, req.OrgID, *install, auth.GetUserID())
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	debugResult(h.Logger, "monitoring template installed", "monitoringTemplate", m)
+
+	if err := encodeResponse(ctx, w, http.StatusCreated, newMonitoringTemplateResponse(m)); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+// handlePutMonitoringTemplate is the HTTP handler for the PUT /api/v2/monitoringTemplates/:id route,
+// upgrading an installed template.
+func (h *MonitoringTemplateHandler) handlePutMonitoringTemplate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "monitoring template upgrade request", r)
+	id, err := decodeGetMonitoringTemplateRequest(ctx, r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	_, install, err := decodeMonitoringTemplateInstallRequest(ctx, r)
+	if err != nil {
+		h.Logger.Debug("failed to decode request", zap.Error(err))
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	auth, err := pctx.GetAuthorizer(ctx)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	m, err := h.MonitoringTemplateService.UpgradeMonitoringTemplate(ctx, id, *install, auth.GetUserID())
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	debugResult(h.Logger, "monitoring template upgraded", "monitoringTemplate", m)
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newMonitoringTemplateResponse(m)); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+// handleDeleteMonitoringTemplate is the HTTP handler for the DELETE /api/v2/monitoringTemplates/:id route,
+// uninstalling a template.
+func (h *MonitoringTemplateHandler) handleDeleteMonitoringTemplate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "monitoring template uninstall request", r)
+	id, err := decodeGetMonitoringTemplateRequest(ctx, r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	if err := h.MonitoringTemplateService.UninstallMonitoringTemplate(ctx, id); err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	h.Logger.Debug("monitoring template uninstalled", zap.Stringer("monitoringTemplateID", id))
+
+	w.WriteHeader(http.StatusNoContent)
+}
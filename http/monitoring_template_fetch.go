@@ -0,0 +1,256 @@
+package http
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	pctx "github.com/influxdata/influxdb/context"
+	"go.uber.org/zap"
+)
+
+const (
+	templatesInstallPath = "/api/v2/templates/install"
+
+	// maxMonitoringTemplateSize is the size of the largest template fetched from a url.
+	maxMonitoringTemplateSize = 1 << 20
+	// monitoringTemplateChecksumPrefix is the optional prefix of the checksums.
+	monitoringTemplateChecksumPrefix = "sha256:"
+)
+
+// MonitoringTemplateFetcher fetches the templates published at a url, such as
+// the templates of a community library hosted on GitHub.
+type MonitoringTemplateFetcher struct {
+	Client *http.Client
+}
+
+// NewMonitoringTemplateFetcher returns a fetcher giving up on the templates not fetched within 10 seconds.
+func NewMonitoringTemplateFetcher() *MonitoringTemplateFetcher {
+	return &MonitoringTemplateFetcher{
+		Client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// githubRawURL returns the raw url of a file viewed on GitHub, such as
+// https://github.com/owner/repo/blob/master/redis.json, or u unchanged.
+func githubRawURL(u *url.URL) *url.URL {
+	if u.Host != "github.com" {
+		return u
+	}
+	parts := strings.SplitN(strings.TrimPrefix(u.Path, "/"), "/", 4)
+	if len(parts) != 4 || parts[2] != "blob" {
+		return u
+	}
+	raw := *u
+	raw.Host = "raw.githubusercontent.com"
+	raw.Path = "/" + parts[0] + "/" + parts[1] + "/" + parts[3]
+	raw.RawPath = ""
+	return &raw
+}
+
+// Fetch returns the template published at rawurl and the sha256 checksum of its
+// content. The content must match checksum, a hex encoded sha256 optionally
+// prefixed by sha256:, unless checksum is empty.
+func (f *MonitoringTemplateFetcher) Fetch(ctx context.Context, rawurl, checksum string) (*influxdb.MonitoringTemplate, string, error) {
+	u, err := url.Parse(rawurl)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, "", &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("template url %q must be an http or https url", rawurl),
+		}
+	}
+	u = githubRawURL(u)
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, "", &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Err:  err,
+		}
+	}
+	resp, err := f.Client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, "", &influxdb.Error{
+			Code: influxdb.EUnavailable,
+			Msg:  fmt.Sprintf("unable to fetch the template at %s", u),
+			Err:  err,
+		}
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("fetching the template at %s returned status %d", u, resp.StatusCode),
+		}
+	}
+
+	b, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxMonitoringTemplateSize+1))
+	if err != nil {
+		return nil, "", &influxdb.Error{
+			Code: influxdb.EUnavailable,
+			Msg:  fmt.Sprintf("unable to fetch the template at %s", u),
+			Err:  err,
+		}
+	}
+	if len(b) > maxMonitoringTemplateSize {
+		return nil, "", &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("template at %s is larger than %d bytes", u, maxMonitoringTemplateSize),
+		}
+	}
+
+	sum := sha256.Sum256(b)
+	got := hex.EncodeToString(sum[:])
+	if checksum != "" {
+		want := strings.ToLower(strings.TrimPrefix(checksum, monitoringTemplateChecksumPrefix))
+		if want != got {
+			return nil, "", &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  fmt.Sprintf("checksum of the template at %s is %s%s, not %s", u, monitoringTemplateChecksumPrefix, got, checksum),
+			}
+		}
+	}
+
+	tr := &monitoringTemplateRequest{}
+	if err := json.Unmarshal(b, tr); err != nil {
+		return nil, "", &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("template at %s is not a monitoring template: %v", u, err),
+		}
+	}
+	t, err := tr.toMonitoringTemplate()
+	if err != nil {
+		return nil, "", err
+	}
+	return t, monitoringTemplateChecksumPrefix + got, nil
+}
+
+// templateInstallRequest is the install of the template published at a url.
+// The template is only installed once it matches the checksum, a preview
+// returns the checksum of the template to pin.
+type templateInstallRequest struct {
+	URL              string            `json:"url"`
+	Checksum         string            `json:"checksum"`
+	OrgID            influxdb.ID       `json:"orgID"`
+	Secrets          map[string]string `json:"secrets"`
+	AuthorizationID  influxdb.ID       `json:"authorizationID"`
+	DeterministicIDs bool              `json:"deterministicIDs"`
+	Preview          bool              `json:"preview"`
+}
+
+type templatePreviewResponse struct {
+	Name        string                                   `json:"name"`
+	Version     string                                   `json:"version"`
+	Description string                                   `json:"description,omitempty"`
+	Requires    []influxdb.MonitoringTemplateRequirement `json:"requires,omitempty"`
+	Checksum    string                                   `json:"checksum"`
+	SecretKeys  []string                                 `json:"secretKeys,omitempty"`
+	Resources   []influxdb.MonitoringTemplateResource    `json:"resources"`
+}
+
+func decodeTemplateInstallRequest(ctx context.Context, r *http.Request) (*templateInstallRequest, error) {
+	req := &templateInstallRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  err.Error(),
+		}
+	}
+	if req.URL == "" {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "template url is required",
+		}
+	}
+	if !req.OrgID.Valid() {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "orgID is invalid",
+		}
+	}
+	if !req.Preview && req.Checksum == "" {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "checksum of the template is required to install it, preview the template to get it",
+		}
+	}
+	return req, nil
+}
+
+// handlePostTemplateInstall is the HTTP handler for the POST /api/v2/templates/install route,
+// installing or previewing the template published at a url.
+func (h *MonitoringTemplateHandler) handlePostTemplateInstall(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "template install request", r)
+	req, err := decodeTemplateInstallRequest(ctx, r)
+	if err != nil {
+		h.Logger.Debug("failed to decode request", zap.Error(err))
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	auth, err := pctx.GetAuthorizer(ctx)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	t, checksum, err := h.MonitoringTemplateFetcher.Fetch(ctx, req.URL, req.Checksum)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	install := influxdb.MonitoringTemplateInstall{
+		Template:         t,
+		Secrets:          req.Secrets,
+		AuthorizationID:  req.AuthorizationID,
+		DeterministicIDs: req.DeterministicIDs,
+	}
+	if !install.AuthorizationID.Valid() && auth.Kind() == influxdb.AuthorizationKind {
+		install.AuthorizationID = auth.Identifier()
+	}
+
+	if req.Preview {
+		rs, err := h.MonitoringTemplateService.PreviewMonitoringTemplate(ctx, req.OrgID, install)
+		if err != nil {
+			h.HandleHTTPError(ctx, err, w)
+			return
+		}
+		debugResult(h.Logger, "template previewed", "resources", rs)
+
+		resp := &templatePreviewResponse{
+			Name:        t.Name,
+			Version:     t.Version,
+			Description: t.Description,
+			Requires:    t.Requires,
+			Checksum:    checksum,
+			SecretKeys:  t.SecretKeys(),
+			Resources:   rs,
+		}
+		if err := encodeResponse(ctx, w, http.StatusOK, resp); err != nil {
+			logEncodingError(h.Logger, r, err)
+			return
+		}
+		return
+	}
+
+	m, err := h.MonitoringTemplateService.InstallMonitoringTemplate(ctx, req.OrgID, install, auth.GetUserID())
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	debugResult(h.Logger, "template installed", "monitoringTemplate", m)
+
+	if err := encodeResponse(ctx, w, http.StatusCreated, newMonitoringTemplateResponse(m)); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
@@ -0,0 +1,165 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	pcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/mock"
+	"go.uber.org/zap"
+)
+
+func TestMonitoringTemplateHandler_handlePostTemplateInstall(t *testing.T) {
+	tmpl := []byte(`{
+  "name": "redis",
+  "version": "1.0.0",
+  "checks": [{"type": "deadman", "name": "redis heartbeat", "every": "1m", "timeSince": 60}],
+  "endpoints": [{"type": "slack", "name": "oncall", "url": "https://hooks.slack.com/services/1", "token": "secret: oncall-token"}]
+}`)
+	sum := sha256.Sum256(tmpl)
+	checksum := "sha256:" + hex.EncodeToString(sum[:])
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/templates/redis.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write(tmpl)
+	}))
+	defer srv.Close()
+
+	var installed bool
+	b := &MonitoringTemplateBackend{
+		HTTPErrorHandler:          ErrorHandler(0),
+		Logger:                    zap.NewNop().With(zap.String("handler", "monitoring_template")),
+		MonitoringTemplateFetcher: NewMonitoringTemplateFetcher(),
+		MonitoringTemplateService: &mock.MonitoringTemplateService{
+			PreviewMonitoringTemplateF: func(ctx context.Context, orgID influxdb.ID, install influxdb.MonitoringTemplateInstall) ([]influxdb.MonitoringTemplateResource, error) {
+				return []influxdb.MonitoringTemplateResource{
+					{Type: influxdb.NotificationEndpointResourceType, Name: "oncall", Created: true},
+					{Type: influxdb.ChecksResourceType, Name: "redis heartbeat", Created: true},
+				}, nil
+			},
+			InstallMonitoringTemplateF: func(ctx context.Context, orgID influxdb.ID, install influxdb.MonitoringTemplateInstall, userID influxdb.ID) (*influxdb.MonitoringTemplateManifest, error) {
+				installed = true
+				if install.Template.Name != "redis" || install.Secrets["oncall-token"] != "xoxb" || install.AuthorizationID != influxdb.ID(5) {
+					t.Errorf("unexpected install %+v", install)
+				}
+				return &influxdb.MonitoringTemplateManifest{ID: 1, OrgID: orgID, Name: "redis", Version: "1.0.0"}, nil
+			},
+		},
+	}
+	h := NewMonitoringTemplateHandler(b)
+
+	post := func(body string) (int, []byte) {
+		r := httptest.NewRequest("POST", "/api/v2/templates/install", bytes.NewBufferString(body))
+		r = r.WithContext(pcontext.SetAuthorizer(r.Context(), &influxdb.Authorization{ID: influxdb.ID(5), UserID: influxdb.ID(6)}))
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		res := w.Result()
+		b, _ := ioutil.ReadAll(res.Body)
+		return res.StatusCode, b
+	}
+	templateURL := srv.URL + "/templates/redis.json"
+
+	code, body := post(fmt.Sprintf(`{"url": %q, "orgID": "0000000000000002", "preview": true}`, templateURL))
+	if code != http.StatusOK {
+		t.Fatalf("expected status %d for a preview, got %d: %s", http.StatusOK, code, body)
+	}
+	want := fmt.Sprintf(`{
+  "name": "redis",
+  "version": "1.0.0",
+  "checksum": %q,
+  "secretKeys": ["oncall-token"],
+  "resources": [
+    {"type": "notificationEndpoints", "name": "oncall", "created": true},
+    {"type": "checks", "name": "redis heartbeat", "created": true}
+  ]
+}`, checksum)
+	if eq, diff, _ := jsonEqual(string(body), want); !eq {
+		t.Errorf("handlePostTemplateInstall() preview = ***%s***", diff)
+	}
+	if installed {
+		t.Errorf("expected the preview not to install the template")
+	}
+
+	tests := []struct {
+		name       string
+		body       string
+		statusCode int
+	}{
+		{
+			name:       "install without checksum",
+			body:       fmt.Sprintf(`{"url": %q, "orgID": "0000000000000002"}`, templateURL),
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "install with another checksum",
+			body:       fmt.Sprintf(`{"url": %q, "orgID": "0000000000000002", "checksum": "sha256:%x"}`, templateURL, sha256.Sum256([]byte("other"))),
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "template not found",
+			body:       fmt.Sprintf(`{"url": %q, "orgID": "0000000000000002", "checksum": %q}`, srv.URL+"/templates/missing.json", checksum),
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "url of another scheme",
+			body:       fmt.Sprintf(`{"url": "file:///etc/passwd", "orgID": "0000000000000002", "checksum": %q}`, checksum),
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "install",
+			body:       fmt.Sprintf(`{"url": %q, "orgID": "0000000000000002", "checksum": %q, "secrets": {"oncall-token": "xoxb"}}`, templateURL, checksum),
+			statusCode: http.StatusCreated,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, body := post(tt.body)
+			if code != tt.statusCode {
+				t.Errorf("got status %d, want %d: %s", code, tt.statusCode, body)
+			}
+		})
+	}
+	if !installed {
+		t.Errorf("expected the template matching its checksum to be installed")
+	}
+}
+
+func TestGithubRawURL(t *testing.T) {
+	tests := []struct {
+		rawurl string
+		want   string
+	}{
+		{
+			rawurl: "https://github.com/influxdata/community-templates/blob/master/redis/redis.json",
+			want:   "https://raw.githubusercontent.com/influxdata/community-templates/master/redis/redis.json",
+		},
+		{
+			rawurl: "https://raw.githubusercontent.com/influxdata/community-templates/master/redis/redis.json",
+			want:   "https://raw.githubusercontent.com/influxdata/community-templates/master/redis/redis.json",
+		},
+		{
+			rawurl: "https://github.com/influxdata/community-templates",
+			want:   "https://github.com/influxdata/community-templates",
+		},
+	}
+	for _, tt := range tests {
+		u, err := url.Parse(tt.rawurl)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := githubRawURL(u).String(); got != tt.want {
+			t.Errorf("githubRawURL(%s) = %s, want %s", tt.rawurl, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	pcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/notification/endpoint"
+	"go.uber.org/zap"
+)
+
+func TestMonitoringTemplateHandler_handlePostMonitoringTemplate(t *testing.T) {
+	b := &MonitoringTemplateBackend{
+		HTTPErrorHandler: ErrorHandler(0),
+		Logger:           zap.NewNop().With(zap.String("handler", "monitoring_template")),
+		MonitoringTemplateService: &mock.MonitoringTemplateService{
+			InstallMonitoringTemplateF: func(ctx context.Context, orgID influxdb.ID, install influxdb.MonitoringTemplateInstall, userID influxdb.ID) (*influxdb.MonitoringTemplateManifest, error) {
+				if orgID != influxdb.ID(2) || userID != influxdb.ID(6) {
+					t.Errorf("expected the template installed in org 2 by user 6, got %s and %s", orgID, userID)
+				}
+				if install.AuthorizationID != influxdb.ID(5) {
+					t.Errorf("expected the rules to default to the authorization of the request, got %s", install.AuthorizationID)
+				}
+				tmpl := install.Template
+				if len(tmpl.Checks) != 1 || len(tmpl.Endpoints) != 1 || len(tmpl.Rules) != 1 {
+					t.Fatalf("unexpected template %+v", tmpl)
+				}
+				if key := tmpl.Endpoints[0].(*endpoint.Slack).Token.Key; key != "oncall-token" {
+					t.Errorf("expected the token of the endpoint to be a placeholder, got %q", key)
+				}
+				if tmpl.Rules[0].EndpointName != "oncall" {
+					t.Errorf("expected the rule to send to the endpoint oncall, got %q", tmpl.Rules[0].EndpointName)
+				}
+				return &influxdb.MonitoringTemplateManifest{
+					ID:      influxdb.ID(1),
+					OrgID:   orgID,
+					Name:    tmpl.Name,
+					Version: tmpl.Version,
+					Resources: []influxdb.MonitoringTemplateResource{
+						{Type: influxdb.ChecksResourceType, ID: influxdb.ID(3), Name: "heartbeat", Created: true},
+					},
+					SecretKeys: []string{"oncall-token"},
+				}, nil
+			},
+		},
+	}
+	h := NewMonitoringTemplateHandler(b)
+
+	post := func(body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest("POST", "/api/v2/monitoringTemplates", bytes.NewBufferString(body))
+		r = r.WithContext(pcontext.SetAuthorizer(r.Context(), &influxdb.Authorization{ID: influxdb.ID(5), UserID: influxdb.ID(6)}))
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	w := post(`{
+  "orgID": "0000000000000002",
+  "secrets": {"oncall-token": "xoxb"},
+  "template": {
+    "name": "host",
+    "version": "1.0.0",
+    "checks": [{"type": "deadman", "name": "heartbeat", "every": "1m", "timeSince": 60}],
+    "endpoints": [{"type": "slack", "name": "oncall", "url": "https://hooks.slack.com/services/1", "token": "secret: oncall-token"}],
+    "rules": [{"type": "slack", "name": "page oncall", "every": "1m", "messageTemplate": "down", "endpointName": "oncall"}]
+  }
+}`)
+	res := w.Result()
+	body, _ := ioutil.ReadAll(res.Body)
+	if res.StatusCode != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, res.StatusCode, body)
+	}
+	want := `{
+  "id": "0000000000000001",
+  "orgID": "0000000000000002",
+  "name": "host",
+  "version": "1.0.0",
+  "resources": [{"type": "checks", "id": "0000000000000003", "name": "heartbeat", "created": true}],
+  "secretKeys": ["oncall-token"],
+  "createdAt": "0001-01-01T00:00:00Z",
+  "updatedAt": "0001-01-01T00:00:00Z",
+  "links": {
+    "self": "/api/v2/monitoringTemplates/0000000000000001",
+    "org": "/api/v2/orgs/0000000000000002"
+  }
+}`
+	if eq, diff, _ := jsonEqual(string(body), want); !eq {
+		t.Errorf("handlePostMonitoringTemplate() = ***%s***", diff)
+	}
+
+	invalid := `{"orgID": "0000000000000002", "template": {"name": "host", "version": "1.0.0",
+  "endpoints": [{"type": "slack", "name": "oncall", "url": "https://hooks.slack.com/services/1", "token": "xoxb"}]}}`
+	if w := post(invalid); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d for an endpoint without a secret placeholder, got %d", http.StatusBadRequest, w.Code)
+	}
+	if w := post(`{"orgID": "0000000000000002", "template": {"name": "host", "version": "one"}}`); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d for an invalid version, got %d", http.StatusBadRequest, w.Code)
+	}
+}
@@ -0,0 +1,166 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"go.uber.org/zap"
+)
+
+type notificationBudgetLinks struct {
+	Self     string `json:"self"`
+	Endpoint string `json:"endpoint"`
+	Usage    string `json:"usage"`
+}
+
+type notificationBudgetResponse struct {
+	*influxdb.NotificationBudget
+	Links notificationBudgetLinks `json:"links"`
+}
+
+func newNotificationBudgetResponse(b *influxdb.NotificationBudget) *notificationBudgetResponse {
+	return &notificationBudgetResponse{
+		NotificationBudget: b,
+		Links: notificationBudgetLinks{
+			Self:     fmt.Sprintf("/api/v2/notificationEndpoints/%s/budget", b.EndpointID),
+			Endpoint: fmt.Sprintf("/api/v2/notificationEndpoints/%s", b.EndpointID),
+			Usage:    fmt.Sprintf("/api/v2/notificationEndpoints/%s/budget/usage", b.EndpointID),
+		},
+	}
+}
+
+// handleGetNotificationBudget is the HTTP handler for the GET /api/v2/notificationEndpoints/:id/budget route.
+func (h *NotificationEndpointHandler) handleGetNotificationBudget(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "notification budget retrieve request", r)
+	endpointID, err := decodeGetNotificationEndpointRequest(ctx, r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	b, err := h.NotificationBudgetService.FindNotificationBudget(ctx, endpointID)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	debugResult(h.Logger, "notification budget retrieved", "notificationBudget", b)
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newNotificationBudgetResponse(b)); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+func decodePutNotificationBudgetRequest(ctx context.Context, r *http.Request) (*influxdb.NotificationBudget, error) {
+	endpointID, err := decodeGetNotificationEndpointRequest(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &influxdb.NotificationBudget{}
+	if err := json.NewDecoder(r.Body).Decode(b); err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  err.Error(),
+		}
+	}
+	b.EndpointID = endpointID
+	if err := b.Valid(); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// handlePutNotificationBudget is the HTTP handler for the PUT /api/v2/notificationEndpoints/:id/budget route.
+func (h *NotificationEndpointHandler) handlePutNotificationBudget(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "notification budget replace request", r)
+	b, err := decodePutNotificationBudgetRequest(ctx, r)
+	if err != nil {
+		h.Logger.Debug("failed to decode request", zap.Error(err))
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	if err := h.NotificationBudgetService.PutNotificationBudget(ctx, b); err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	debugResult(h.Logger, "notification budget replaced", "notificationBudget", b)
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newNotificationBudgetResponse(b)); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+// handleDeleteNotificationBudget is the HTTP handler for the DELETE /api/v2/notificationEndpoints/:id/budget route.
+func (h *NotificationEndpointHandler) handleDeleteNotificationBudget(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "notification budget delete request", r)
+	endpointID, err := decodeGetNotificationEndpointRequest(ctx, r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	if err := h.NotificationBudgetService.DeleteNotificationBudget(ctx, endpointID); err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	h.Logger.Debug("notification budget deleted", zap.Stringer("endpointID", endpointID))
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// decodeGetNotificationBudgetUsageRequest returns the endpoint of the url and
+// the start of the month of the month query parameter, formatted as 2006-01,
+// the current month by default.
+func decodeGetNotificationBudgetUsageRequest(ctx context.Context, r *http.Request) (influxdb.ID, time.Time, error) {
+	endpointID, err := decodeGetNotificationEndpointRequest(ctx, r)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+
+	month := r.URL.Query().Get("month")
+	if month == "" {
+		return endpointID, time.Now().UTC(), nil
+	}
+	t, err := time.Parse(influxdb.NotificationBudgetMonthFormat, month)
+	if err != nil {
+		return 0, time.Time{}, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("month %q is not formatted as %s", month, influxdb.NotificationBudgetMonthFormat),
+		}
+	}
+	return endpointID, t, nil
+}
+
+// handleGetNotificationBudgetUsage is the HTTP handler for the GET /api/v2/notificationEndpoints/:id/budget/usage route.
+func (h *NotificationEndpointHandler) handleGetNotificationBudgetUsage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "notification budget usage retrieve request", r)
+	endpointID, t, err := decodeGetNotificationBudgetUsageRequest(ctx, r)
+	if err != nil {
+		h.Logger.Debug("failed to decode request", zap.Error(err))
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	u, err := h.NotificationBudgetService.FindNotificationBudgetUsage(ctx, endpointID, t)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	debugResult(h.Logger, "notification budget usage retrieved", "notificationBudgetUsage", u)
+
+	if err := encodeResponse(ctx, w, http.StatusOK, u); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
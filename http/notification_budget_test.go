@@ -0,0 +1,136 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
+	"go.uber.org/zap"
+)
+
+func TestNotificationEndpointHandler_handlePutNotificationBudget(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		statusCode int
+		want       string
+	}{
+		{
+			name:       "replace the budget of an endpoint",
+			body:       `{"monthly":500,"fallbackEndpointID":"0000000000000002","endpointID":"0000000000000009"}`,
+			statusCode: 200,
+			want: `{
+  "endpointID": "0000000000000001",
+  "orgID": "000000000000000a",
+  "monthly": 500,
+  "fallbackEndpointID": "0000000000000002",
+  "createdAt": "0001-01-01T00:00:00Z",
+  "updatedAt": "0001-01-01T00:00:00Z",
+  "links": {
+    "self": "/api/v2/notificationEndpoints/0000000000000001/budget",
+    "endpoint": "/api/v2/notificationEndpoints/0000000000000001",
+    "usage": "/api/v2/notificationEndpoints/0000000000000001/budget/usage"
+  }
+}`,
+		},
+		{
+			name:       "budget without notifications",
+			body:       `{"monthly":0}`,
+			statusCode: 400,
+			want:       `{"code":"invalid","message":"Notification Budget monthly must be positive"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &NotificationEndpointBackend{
+				HTTPErrorHandler: ErrorHandler(0),
+				Logger:           zap.NewNop().With(zap.String("handler", "notification_endpoint")),
+				NotificationBudgetService: &mock.NotificationBudgetService{
+					PutNotificationBudgetF: func(ctx context.Context, b *influxdb.NotificationBudget) error {
+						b.OrgID = influxdb.ID(10)
+						return nil
+					},
+				},
+			}
+			h := NewNotificationEndpointHandler(b)
+
+			r := httptest.NewRequest("PUT", "http://any.url/api/v2/notificationEndpoints/0000000000000001/budget", bytes.NewReader([]byte(tt.body)))
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			res := w.Result()
+			body, _ := ioutil.ReadAll(res.Body)
+			if res.StatusCode != tt.statusCode {
+				t.Errorf("got status %d, want %d: %s", res.StatusCode, tt.statusCode, body)
+			}
+			if eq, diff, _ := jsonEqual(string(body), tt.want); !eq {
+				t.Errorf("handlePutNotificationBudget() = ***%s***", diff)
+			}
+		})
+	}
+}
+
+func TestNotificationEndpointHandler_handleGetNotificationBudgetUsage(t *testing.T) {
+	tests := []struct {
+		name       string
+		month      string
+		statusCode int
+		want       string
+	}{
+		{
+			name:       "usage of a month",
+			month:      "2019-10",
+			statusCode: 200,
+			want: `{
+  "endpointID": "0000000000000001",
+  "orgID": "000000000000000a",
+  "month": "2019-10",
+  "monthly": 500,
+  "notifications": 503,
+  "remaining": 0,
+  "overage": 3
+}`,
+		},
+		{
+			name:       "invalid month",
+			month:      "october",
+			statusCode: 400,
+			want:       `{"code":"invalid","message":"month \"october\" is not formatted as 2006-01"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &NotificationEndpointBackend{
+				HTTPErrorHandler: ErrorHandler(0),
+				Logger:           zap.NewNop().With(zap.String("handler", "notification_endpoint")),
+				NotificationBudgetService: &mock.NotificationBudgetService{
+					FindNotificationBudgetUsageF: func(ctx context.Context, endpointID influxdb.ID, t time.Time) (*influxdb.NotificationBudgetUsage, error) {
+						b := &influxdb.NotificationBudget{EndpointID: endpointID, OrgID: influxdb.ID(10), Monthly: 500}
+						return influxdb.NewNotificationBudgetUsage(b, t, 503), nil
+					},
+				},
+			}
+			h := NewNotificationEndpointHandler(b)
+
+			r := httptest.NewRequest("GET", "http://any.url/api/v2/notificationEndpoints/0000000000000001/budget/usage?month="+tt.month, nil)
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			res := w.Result()
+			body, _ := ioutil.ReadAll(res.Body)
+			if res.StatusCode != tt.statusCode {
+				t.Errorf("got status %d, want %d: %s", res.StatusCode, tt.statusCode, body)
+			}
+			if eq, diff, _ := jsonEqual(string(body), tt.want); !eq {
+				t.Errorf("handleGetNotificationBudgetUsage() = ***%s***", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,1014 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"strconv"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/alerting/validate"
+	pctx "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/kit/tracing"
+	"github.com/influxdata/influxdb/notification/endpoint"
+	"github.com/julienschmidt/httprouter"
+	"go.uber.org/zap"
+)
+
+// NotificationEndpointBackend is all services and associated parameters required to construct
+// the NotificationEndpointBackendHandler.
+type NotificationEndpointBackend struct {
+	influxdb.HTTPErrorHandler
+	Logger *zap.Logger
+
+	NotificationEndpointService influxdb.NotificationEndpointService
+	UserResourceMappingService  influxdb.UserResourceMappingService
+	LabelService                influxdb.LabelService
+	UserService                 influxdb.UserService
+	OrganizationService         influxdb.OrganizationService
+	NotificationBudgetService   influxdb.NotificationBudgetService
+	AlertingQuotaService        influxdb.AlertingQuotaService
+	// NotificationEndpointCascader deletes the endpoints with the rules
+	// sending to them, when the deletion is forced.
+	NotificationEndpointCascader influxdb.NotificationEndpointCascader
+	// NotificationEndpointReassigner moves the rules sending to an endpoint
+	// to another one.
+	NotificationEndpointReassigner influxdb.NotificationEndpointReassigner
+}
+
+// NewNotificationEndpointBackend returns a new instance of NotificationEndpointBackend.
+func NewNotificationEndpointBackend(b *APIBackend) *NotificationEndpointBackend {
+	return &NotificationEndpointBackend{
+		HTTPErrorHandler: b.HTTPErrorHandler,
+		Logger:           b.Logger.With(zap.String("handler", "notification_endpoint")),
+
+		NotificationEndpointService: b.NotificationEndpointService,
+		UserResourceMappingService:  b.UserResourceMappingService,
+		LabelService:                b.LabelService,
+		UserService:                 b.UserService,
+		OrganizationService:         b.OrganizationService,
+		NotificationBudgetService:   b.NotificationBudgetService,
+		AlertingQuotaService:        b.AlertingQuotaService,
+
+		NotificationEndpointCascader:   b.NotificationEndpointCascader,
+		NotificationEndpointReassigner: b.NotificationEndpointReassigner,
+	}
+}
+
+// NotificationEndpointHandler is the handler for the notification endpoint service
+type NotificationEndpointHandler struct {
+	*httprouter.Router
+	influxdb.HTTPErrorHandler
+	Logger *zap.Logger
+
+	NotificationEndpointService influxdb.NotificationEndpointService
+	UserResourceMappingService  influxdb.UserResourceMappingService
+	LabelService                influxdb.LabelService
+	UserService                 influxdb.UserService
+	OrganizationService         influxdb.OrganizationService
+	NotificationBudgetService   influxdb.NotificationBudgetService
+	AlertingQuotaService        influxdb.AlertingQuotaService
+	// NotificationEndpointCascader deletes the endpoints with the rules
+	// sending to them, when the deletion is forced.
+	NotificationEndpointCascader influxdb.NotificationEndpointCascader
+	// NotificationEndpointReassigner moves the rules sending to an endpoint
+	// to another one.
+	NotificationEndpointReassigner influxdb.NotificationEndpointReassigner
+}
+
+const (
+	notificationEndpointsPath              = "/api/v2/notificationEndpoints"
+	notificationEndpointsIDPath            = "/api/v2/notificationEndpoints/:id"
+	notificationEndpointsIDMembersPath     = "/api/v2/notificationEndpoints/:id/members"
+	notificationEndpointsIDMembersIDPath   = "/api/v2/notificationEndpoints/:id/members/:userID"
+	notificationEndpointsIDOwnersPath      = "/api/v2/notificationEndpoints/:id/owners"
+	notificationEndpointsIDOwnersIDPath    = "/api/v2/notificationEndpoints/:id/owners/:userID"
+	notificationEndpointsIDLabelsPath      = "/api/v2/notificationEndpoints/:id/labels"
+	notificationEndpointsIDLabelsIDPath    = "/api/v2/notificationEndpoints/:id/labels/:lid"
+	notificationEndpointsIDBudgetPath      = "/api/v2/notificationEndpoints/:id/budget"
+	notificationEndpointsIDBudgetUsagePath = "/api/v2/notificationEndpoints/:id/budget/usage"
+	notificationEndpointsIDReassignPath    = "/api/v2/notificationEndpoints/:id/reassign"
+)
+
+// NewNotificationEndpointHandler returns a new instance of NotificationEndpointHandler.
+func NewNotificationEndpointHandler(b *NotificationEndpointBackend) *NotificationEndpointHandler {
+	h := &NotificationEndpointHandler{
+		Router:           NewRouter(b.HTTPErrorHandler),
+		HTTPErrorHandler: b.HTTPErrorHandler,
+		Logger:           b.Logger,
+
+		NotificationEndpointService: b.NotificationEndpointService,
+		UserResourceMappingService:  b.UserResourceMappingService,
+		LabelService:                b.LabelService,
+		UserService:                 b.UserService,
+		OrganizationService:         b.OrganizationService,
+		NotificationBudgetService:   b.NotificationBudgetService,
+		AlertingQuotaService:        b.AlertingQuotaService,
+
+		NotificationEndpointCascader:   b.NotificationEndpointCascader,
+		NotificationEndpointReassigner: b.NotificationEndpointReassigner,
+	}
+	h.HandlerFunc("POST", notificationEndpointsPath, h.handlePostNotificationEndpoint)
+	h.HandlerFunc("GET", notificationEndpointsPath, h.handleGetNotificationEndpoints)
+	h.HandlerFunc("GET", notificationEndpointsIDPath, h.handleGetNotificationEndpoint)
+	h.HandlerFunc("DELETE", notificationEndpointsIDPath, h.handleDeleteNotificationEndpoint)
+	h.HandlerFunc("PUT", notificationEndpointsIDPath, h.handlePutNotificationEndpoint)
+	h.HandlerFunc("PATCH", notificationEndpointsIDPath, h.handlePatchNotificationEndpoint)
+	h.HandlerFunc("GET", notificationEndpointsIDBudgetPath, h.handleGetNotificationBudget)
+	h.HandlerFunc("PUT", notificationEndpointsIDBudgetPath, h.handlePutNotificationBudget)
+	h.HandlerFunc("DELETE", notificationEndpointsIDBudgetPath, h.handleDeleteNotificationBudget)
+	h.HandlerFunc("GET", notificationEndpointsIDBudgetUsagePath, h.handleGetNotificationBudgetUsage)
+	h.HandlerFunc("POST", notificationEndpointsIDReassignPath, h.handlePostNotificationEndpointReassign)
+
+	memberBackend := MemberBackend{
+		HTTPErrorHandler:           b.HTTPErrorHandler,
+		Logger:                     b.Logger.With(zap.String("handler", "member")),
+		ResourceType:               influxdb.NotificationEndpointResourceType,
+		UserType:                   influxdb.Member,
+		UserResourceMappingService: b.UserResourceMappingService,
+		UserService:                b.UserService,
+	}
+	h.HandlerFunc("POST", notificationEndpointsIDMembersPath, newPostMemberHandler(memberBackend))
+	h.HandlerFunc("GET", notificationEndpointsIDMembersPath, newGetMembersHandler(memberBackend))
+	h.HandlerFunc("DELETE", notificationEndpointsIDMembersIDPath, newDeleteMemberHandler(memberBackend))
+
+	ownerBackend := MemberBackend{
+		HTTPErrorHandler:           b.HTTPErrorHandler,
+		Logger:                     b.Logger.With(zap.String("handler", "member")),
+		ResourceType:               influxdb.NotificationEndpointResourceType,
+		UserType:                   influxdb.Owner,
+		UserResourceMappingService: b.UserResourceMappingService,
+		UserService:                b.UserService,
+	}
+	h.HandlerFunc("POST", notificationEndpointsIDOwnersPath, newPostMemberHandler(ownerBackend))
+	h.HandlerFunc("GET", notificationEndpointsIDOwnersPath, newGetMembersHandler(ownerBackend))
+	h.HandlerFunc("DELETE", notificationEndpointsIDOwnersIDPath, newDeleteMemberHandler(ownerBackend))
+
+	labelBackend := &LabelBackend{
+		HTTPErrorHandler: b.HTTPErrorHandler,
+		Logger:           b.Logger.With(zap.String("handler", "label")),
+		LabelService:     b.LabelService,
+		ResourceType:     influxdb.NotificationEndpointResourceType,
+	}
+	h.HandlerFunc("GET", notificationEndpointsIDLabelsIDPath, newGetLabelsHandler(labelBackend))
+	h.HandlerFunc("POST", notificationEndpointsIDLabelsPath, newPostLabelHandler(labelBackend))
+	h.HandlerFunc("DELETE", notificationEndpointsIDLabelsIDPath, newDeleteLabelHandler(labelBackend))
+
+	return h
+}
+
+type notificationEndpointLinks struct {
+	Self    string `json:"self"`
+	Labels  string `json:"labels"`
+	Members string `json:"members"`
+	Owners  string `json:"owners"`
+}
+
+type notificationEndpointResponse struct {
+	influxdb.NotificationEndpoint
+	Labels []influxdb.Label          `json:"labels"`
+	Links  notificationEndpointLinks `json:"links"`
+	// Warnings are only set by a create of a notification endpoint of an
+	// organization near its quota of notification endpoints.
+	Warnings []influxdb.QuotaWarning `json:"warnings,omitempty"`
+}
+
+func (resp notificationEndpointResponse) MarshalJSON() ([]byte, error) {
+	b1, err := json.Marshal(resp.NotificationEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	b2, err := json.Marshal(struct {
+		Labels   []influxdb.Label          `json:"labels"`
+		Links    notificationEndpointLinks `json:"links"`
+		Warnings []influxdb.QuotaWarning   `json:"warnings,omitempty"`
+	}{
+		Links:    resp.Links,
+		Labels:   resp.Labels,
+		Warnings: resp.Warnings,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(string(b1[:len(b1)-1]) + ", " + string(b2[1:])), nil
+}
+
+type notificationEndpointsResponse struct {
+	NotificationEndpoints []*notificationEndpointResponse `json:"notificationEndpoints"`
+	Links                 *influxdb.PagingLinks           `json:"links"`
+	// Warnings are only set for the notification endpoints of an
+	// organization near its quota of notification endpoints.
+	Warnings []influxdb.QuotaWarning `json:"warnings,omitempty"`
+}
+
+func newNotificationEndpointResponse(edp influxdb.NotificationEndpoint, labels []*influxdb.Label) *notificationEndpointResponse {
+	res := &notificationEndpointResponse{
+		NotificationEndpoint: edp,
+		Links: notificationEndpointLinks{
+			Self:    fmt.Sprintf("/api/v2/notificationEndpoints/%s", edp.GetID()),
+			Labels:  fmt.Sprintf("/api/v2/notificationEndpoints/%s/labels", edp.GetID()),
+			Members: fmt.Sprintf("/api/v2/notificationEndpoints/%s/members", edp.GetID()),
+			Owners:  fmt.Sprintf("/api/v2/notificationEndpoints/%s/owners", edp.GetID()),
+		},
+		Labels: []influxdb.Label{},
+	}
+
+	for _, l := range labels {
+		res.Labels = append(res.Labels, *l)
+	}
+
+	return res
+}
+
+func newNotificationEndpointsResponse(ctx context.Context, edps []influxdb.NotificationEndpoint, labelService influxdb.LabelService, f influxdb.PagingFilter, opts influxdb.FindOptions) *notificationEndpointsResponse {
+	resp := &notificationEndpointsResponse{
+		NotificationEndpoints: make([]*notificationEndpointResponse, len(edps)),
+		Links:                 newPagingLinks(notificationEndpointsPath, opts, f, len(edps)),
+	}
+	for i, edp := range edps {
+		labels, _ := labelService.FindResourceLabels(ctx, influxdb.LabelMappingFilter{ResourceID: edp.GetID()})
+		resp.NotificationEndpoints[i] = newNotificationEndpointResponse(edp, labels)
+	}
+	return resp
+}
+
+func decodeGetNotificationEndpointRequest(ctx context.Context, r *http.Request) (i influxdb.ID, err error) {
+	params := httprouter.ParamsFromContext(ctx)
+	id := params.ByName("id")
+	if id == "" {
+		return i, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "url missing id",
+		}
+	}
+
+	if err := i.DecodeFromString(id); err != nil {
+		return i, err
+	}
+	return i, nil
+}
+
+func (h *NotificationEndpointHandler) handleGetNotificationEndpoints(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "notification endpoints retrieve request", r)
+	filter, opts, err := decodeNotificationEndpointFilter(ctx, r)
+	if err != nil {
+		h.Logger.Debug("failed to decode request", zap.Error(err))
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	edps, _, err := h.NotificationEndpointService.FindNotificationEndpoints(ctx, *filter, *opts)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	debugResult(h.Logger, "notification endpoints retrieved", "notificationEndpoints", edps)
+
+	resp := newNotificationEndpointsResponse(ctx, edps, h.LabelService, filter, *opts)
+	resp.Warnings = findFilterQuotaWarnings(ctx, h.AlertingQuotaService, h.OrganizationService, h.Logger, filter.OrgID, filter.Organization, influxdb.NotificationEndpointResourceType)
+	if err := encodeResponse(ctx, w, http.StatusOK, resp); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+func (h *NotificationEndpointHandler) handleGetNotificationEndpoint(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "notification endpoint retrieve request", r)
+	id, err := decodeGetNotificationEndpointRequest(ctx, r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	edp, err := h.NotificationEndpointService.FindNotificationEndpointByID(ctx, id)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	debugResult(h.Logger, "notification endpoint retrieved", "notificationEndpoint", edp)
+
+	labels, err := h.LabelService.FindResourceLabels(ctx, influxdb.LabelMappingFilter{ResourceID: edp.GetID()})
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newNotificationEndpointResponse(edp, labels)); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+func decodeNotificationEndpointFilter(ctx context.Context, r *http.Request) (*influxdb.NotificationEndpointFilter, *influxdb.FindOptions, error) {
+	f := &influxdb.NotificationEndpointFilter{}
+	urm, err := decodeUserResourceMappingFilter(ctx, r, influxdb.NotificationEndpointResourceType)
+	if err == nil {
+		f.UserResourceMappingFilter = *urm
+	}
+
+	opts, err := decodeFindOptions(ctx, r)
+	if err != nil {
+		return f, nil, err
+	}
+
+	q := r.URL.Query()
+	if orgIDStr := q.Get("orgID"); orgIDStr != "" {
+		orgID, err := influxdb.IDFromString(orgIDStr)
+		if err != nil {
+			return f, opts, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "orgID is invalid",
+				Err:  err,
+			}
+		}
+		f.OrgID = orgID
+	} else if orgNameStr := q.Get("org"); orgNameStr != "" {
+		f.Organization = &orgNameStr
+	}
+	return f, opts, err
+}
+
+func decodePostNotificationEndpointRequest(ctx context.Context, r *http.Request) (influxdb.NotificationEndpoint, error) {
+	buf := new(bytes.Buffer)
+	_, err := buf.ReadFrom(r.Body)
+	if err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Err:  err,
+		}
+	}
+	defer r.Body.Close()
+	edp, err := endpoint.UnmarshalJSON(buf.Bytes())
+	if err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Err:  err,
+		}
+	}
+	if err := validate.NotificationEndpoint(edp, validate.Create); err != nil {
+		return nil, invalidResourceError(err)
+	}
+	return edp, nil
+}
+
+func decodePutNotificationEndpointRequest(ctx context.Context, r *http.Request) (influxdb.NotificationEndpoint, error) {
+	buf := new(bytes.Buffer)
+	_, err := buf.ReadFrom(r.Body)
+	if err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Err:  err,
+		}
+	}
+	defer r.Body.Close()
+	edp, err := endpoint.UnmarshalJSON(buf.Bytes())
+	if err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Err:  err,
+		}
+	}
+	params := httprouter.ParamsFromContext(ctx)
+	id := params.ByName("id")
+	if id == "" {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "url missing id",
+		}
+	}
+	i := new(influxdb.ID)
+	if err := i.DecodeFromString(id); err != nil {
+		return nil, err
+	}
+	edp.SetID(*i)
+	if err := validate.NotificationEndpoint(edp, validate.Update); err != nil {
+		return nil, invalidResourceError(err)
+	}
+	return edp, nil
+}
+
+type patchNotificationEndpointRequest struct {
+	influxdb.ID
+	Update influxdb.NotificationEndpointUpdate
+}
+
+func decodePatchNotificationEndpointRequest(ctx context.Context, r *http.Request) (*patchNotificationEndpointRequest, error) {
+	req := &patchNotificationEndpointRequest{}
+	params := httprouter.ParamsFromContext(ctx)
+	id := params.ByName("id")
+	if id == "" {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "url missing id",
+		}
+	}
+
+	var i influxdb.ID
+	if err := i.DecodeFromString(id); err != nil {
+		return nil, err
+	}
+	req.ID = i
+
+	upd := &influxdb.NotificationEndpointUpdate{}
+	if err := json.NewDecoder(r.Body).Decode(upd); err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  err.Error(),
+		}
+	}
+	if err := upd.Valid(); err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  err.Error(),
+		}
+	}
+
+	req.Update = *upd
+	return req, nil
+}
+
+// handlePostNotificationEndpoint is the HTTP handler for the POST /api/v2/notificationEndpoints route.
+func (h *NotificationEndpointHandler) handlePostNotificationEndpoint(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "notification endpoint create request", r)
+	ctx, dryRun, err := decodeDryRun(ctx, r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	edp, err := decodePostNotificationEndpointRequest(ctx, r)
+	if err != nil {
+		h.Logger.Debug("failed to decode request", zap.Error(err))
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	auth, err := pctx.GetAuthorizer(ctx)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	if err := h.NotificationEndpointService.CreateNotificationEndpoint(ctx, edp, auth.GetUserID()); err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	if dryRun != nil {
+		if err := encodeDryRunResponse(ctx, w, newNotificationEndpointResponse(edp, []*influxdb.Label{}), dryRun); err != nil {
+			logEncodingError(h.Logger, r, err)
+		}
+		return
+	}
+	debugResult(h.Logger, "notification endpoint created", "notificationEndpoint", edp)
+
+	resp := newNotificationEndpointResponse(edp, []*influxdb.Label{})
+	resp.Warnings = findQuotaWarnings(ctx, h.AlertingQuotaService, h.Logger, edp.GetOrgID(), influxdb.NotificationEndpointResourceType)
+	if err := encodeResponse(ctx, w, http.StatusCreated, resp); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+// handlePutNotificationEndpoint is the HTTP handler for the PUT /api/v2/notificationEndpoint route.
+func (h *NotificationEndpointHandler) handlePutNotificationEndpoint(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "notification endpoint update request", r)
+	ctx, dryRun, err := decodeDryRun(ctx, r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	edp, err := decodePutNotificationEndpointRequest(ctx, r)
+	if err != nil {
+		h.Logger.Debug("failed to decode request", zap.Error(err))
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	auth, err := pctx.GetAuthorizer(ctx)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	edp, err = h.NotificationEndpointService.UpdateNotificationEndpoint(ctx, edp.GetID(), edp, auth.GetUserID())
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	labels, err := h.LabelService.FindResourceLabels(ctx, influxdb.LabelMappingFilter{ResourceID: edp.GetID()})
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	if dryRun != nil {
+		if err := encodeDryRunResponse(ctx, w, newNotificationEndpointResponse(edp, labels), dryRun); err != nil {
+			logEncodingError(h.Logger, r, err)
+		}
+		return
+	}
+	debugResult(h.Logger, "notification endpoint updated", "notificationEndpoint", edp)
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newNotificationEndpointResponse(edp, labels)); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+// handlePatchNotificationEndpoint is the HTTP handler for the PATCH /api/v2/notificationEndpoint/:id route.
+func (h *NotificationEndpointHandler) handlePatchNotificationEndpoint(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "notification endpoint patch request", r)
+	ctx, dryRun, err := decodeDryRun(ctx, r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	req, err := decodePatchNotificationEndpointRequest(ctx, r)
+	if err != nil {
+		h.Logger.Debug("failed to decode request", zap.Error(err))
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	edp, err := h.NotificationEndpointService.PatchNotificationEndpoint(ctx, req.ID, req.Update)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	labels, err := h.LabelService.FindResourceLabels(ctx, influxdb.LabelMappingFilter{ResourceID: edp.GetID()})
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	if dryRun != nil {
+		if err := encodeDryRunResponse(ctx, w, newNotificationEndpointResponse(edp, labels), dryRun); err != nil {
+			logEncodingError(h.Logger, r, err)
+		}
+		return
+	}
+	debugResult(h.Logger, "notification endpoint patch", "notificationEndpoint", edp)
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newNotificationEndpointResponse(edp, labels)); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+// notificationEndpointRulesResponse lists the notification rules blocking,
+// or deleted by, the deletion of a notification endpoint.
+type notificationEndpointRulesResponse struct {
+	Code                string        `json:"code,omitempty"`
+	Message             string        `json:"message,omitempty"`
+	NotificationRuleIDs []influxdb.ID `json:"notificationRuleIDs"`
+}
+
+type deleteNotificationEndpointRequest struct {
+	ID    influxdb.ID
+	Force bool
+}
+
+func decodeDeleteNotificationEndpointRequest(ctx context.Context, r *http.Request) (*deleteNotificationEndpointRequest, error) {
+	i, err := decodeGetNotificationEndpointRequest(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	req := &deleteNotificationEndpointRequest{ID: i}
+	if force := r.URL.Query().Get("force"); force != "" {
+		if req.Force, err = strconv.ParseBool(force); err != nil {
+			return nil, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "force is invalid",
+			}
+		}
+	}
+	return req, nil
+}
+
+// handleDeleteNotificationEndpoint is the HTTP handler for the DELETE /api/v2/notificationEndpoints/:id route.
+// The notification rules sending to the endpoint block its deletion, unless it is forced,
+// which deletes them too.
+func (h *NotificationEndpointHandler) handleDeleteNotificationEndpoint(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "notification endpoint delete request", r)
+	ctx, dryRun, err := decodeDryRun(ctx, r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	req, err := decodeDeleteNotificationEndpointRequest(ctx, r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	if req.Force {
+		ids, err := h.NotificationEndpointCascader.DeleteNotificationEndpointCascade(ctx, req.ID)
+		if err != nil {
+			h.HandleHTTPError(ctx, err, w)
+			return
+		}
+		if dryRun != nil {
+			if err := encodeDryRunResponse(ctx, w, nil, dryRun); err != nil {
+				logEncodingError(h.Logger, r, err)
+			}
+			return
+		}
+		h.Logger.Debug("notification endpoint deleted with its notification rules", zap.Stringer("notificationEndpointID", req.ID), zap.Int("notificationRules", len(ids)))
+
+		if ids == nil {
+			ids = []influxdb.ID{}
+		}
+		if err := encodeResponse(ctx, w, http.StatusOK, notificationEndpointRulesResponse{NotificationRuleIDs: ids}); err != nil {
+			logEncodingError(h.Logger, r, err)
+		}
+		return
+	}
+
+	if err = h.NotificationEndpointService.DeleteNotificationEndpoint(ctx, req.ID); err != nil {
+		if ids, ok := influxdb.NotificationEndpointInUse(err); ok {
+			w.Header().Set(PlatformErrorCodeHeader, influxdb.EConflict)
+			if err := encodeResponse(ctx, w, http.StatusUnprocessableEntity, notificationEndpointRulesResponse{
+				Code:                influxdb.EConflict,
+				Message:             influxdb.ErrorMessage(err),
+				NotificationRuleIDs: ids,
+			}); err != nil {
+				logEncodingError(h.Logger, r, err)
+			}
+			return
+		}
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	if dryRun != nil {
+		if err := encodeDryRunResponse(ctx, w, nil, dryRun); err != nil {
+			logEncodingError(h.Logger, r, err)
+		}
+		return
+	}
+	h.Logger.Debug("notification endpoint deleted", zap.Stringer("notificationEndpointID", req.ID))
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type postNotificationEndpointReassignRequest struct {
+	ID       influxdb.ID
+	Reassign influxdb.NotificationEndpointReassign
+}
+
+func decodePostNotificationEndpointReassignRequest(ctx context.Context, r *http.Request) (*postNotificationEndpointReassignRequest, error) {
+	i, err := decodeGetNotificationEndpointRequest(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	req := &postNotificationEndpointReassignRequest{ID: i}
+	if err := json.NewDecoder(r.Body).Decode(&req.Reassign); err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "unable to decode notification endpoint reassignment",
+			Err:  err,
+		}
+	}
+	if err := req.Reassign.Valid(); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// handlePostNotificationEndpointReassign is the HTTP handler for the POST /api/v2/notificationEndpoints/:id/reassign route.
+// The notification rules sending to the endpoint are moved to the target endpoint, then the endpoint
+// is deleted, or made inactive.
+func (h *NotificationEndpointHandler) handlePostNotificationEndpointReassign(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "notification endpoint reassign request", r)
+	ctx, dryRun, err := decodeDryRun(ctx, r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	req, err := decodePostNotificationEndpointReassignRequest(ctx, r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	res, err := h.NotificationEndpointReassigner.ReassignNotificationEndpoint(ctx, req.ID, req.Reassign)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	if dryRun != nil {
+		if err := encodeDryRunResponse(ctx, w, res, dryRun); err != nil {
+			logEncodingError(h.Logger, r, err)
+		}
+		return
+	}
+	h.Logger.Debug("notification endpoint reassigned", zap.Stringer("notificationEndpointID", req.ID), zap.Stringer("targetEndpointID", req.Reassign.TargetEndpointID), zap.Int("notificationRules", len(res.NotificationRuleIDs)))
+
+	if err := encodeResponse(ctx, w, http.StatusOK, res); err != nil {
+		logEncodingError(h.Logger, r, err)
+	}
+}
+
+// NotificationEndpointService connects to Influx via HTTP using tokens to manage notification endpoints.
+type NotificationEndpointService struct {
+	Addr               string
+	Token              string
+	InsecureSkipVerify bool
+	// Options configures the retries, circuit breaking, timeout and
+	// transport of the calls, each request is sent once by default.
+	Options ClientOptions
+
+	*UserResourceMappingService
+	*OrganizationService
+}
+
+var _ influxdb.NotificationEndpointService = (*NotificationEndpointService)(nil)
+var _ influxdb.NotificationEndpointCascader = (*NotificationEndpointService)(nil)
+var _ influxdb.NotificationEndpointReassigner = (*NotificationEndpointService)(nil)
+
+// NewNotificationEndpointService returns a NotificationEndpointService connecting to addr with token.
+func NewNotificationEndpointService(addr, token string, insecureSkipVerify bool) *NotificationEndpointService {
+	return &NotificationEndpointService{
+		Addr:               addr,
+		Token:              token,
+		InsecureSkipVerify: insecureSkipVerify,
+		UserResourceMappingService: &UserResourceMappingService{
+			Addr:               addr,
+			Token:              token,
+			InsecureSkipVerify: insecureSkipVerify,
+		},
+		OrganizationService: &OrganizationService{
+			Addr:               addr,
+			Token:              token,
+			InsecureSkipVerify: insecureSkipVerify,
+		},
+	}
+}
+
+// FindNotificationEndpointByID returns a single notification endpoint by ID.
+func (s *NotificationEndpointService) FindNotificationEndpointByID(ctx context.Context, id influxdb.ID) (influxdb.NotificationEndpoint, error) {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	return s.sendNotificationEndpoint(ctx, "GET", notificationEndpointIDPath(id), nil)
+}
+
+// FindNotificationEndpoints returns a list of notification endpoints that match filter and the total count of matching notification endpoints.
+// Additional options provide pagination & sorting.
+func (s *NotificationEndpointService) FindNotificationEndpoints(ctx context.Context, filter influxdb.NotificationEndpointFilter, opt ...influxdb.FindOptions) ([]influxdb.NotificationEndpoint, int, error) {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	u, err := NewURL(s.Addr, notificationEndpointsPath)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := u.Query()
+	for k, vs := range filter.QueryParams() {
+		for _, v := range vs {
+			query.Add(k, v)
+		}
+	}
+	if len(opt) > 0 {
+		for k, vs := range opt[0].QueryParams() {
+			for _, v := range vs {
+				query.Add(k, v)
+			}
+		}
+	}
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	req.URL.RawQuery = query.Encode()
+	SetToken(s.Token, req)
+
+	resp, err := s.Options.do(ctx, u.Scheme, s.InsecureSkipVerify, req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return nil, 0, err
+	}
+
+	var r struct {
+		NotificationEndpoints []json.RawMessage `json:"notificationEndpoints"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&r); err != nil {
+		return nil, 0, err
+	}
+
+	edps := make([]influxdb.NotificationEndpoint, 0, len(r.NotificationEndpoints))
+	for _, b := range r.NotificationEndpoints {
+		edp, err := endpoint.UnmarshalJSON(b)
+		if err != nil {
+			return nil, 0, err
+		}
+		edps = append(edps, edp)
+	}
+	return edps, len(edps), nil
+}
+
+// CreateNotificationEndpoint creates a new notification endpoint and sets its ID with the new identifier.
+// The notification endpoint is owned by the user of the token, userID is ignored.
+func (s *NotificationEndpointService) CreateNotificationEndpoint(ctx context.Context, edp influxdb.NotificationEndpoint, userID influxdb.ID) error {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	u, err := NewURL(s.Addr, notificationEndpointsPath)
+	if err != nil {
+		return err
+	}
+
+	octets, err := json.Marshal(edp)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", u.String(), bytes.NewReader(octets))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	SetToken(s.Token, req)
+
+	resp, err := s.Options.do(ctx, u.Scheme, s.InsecureSkipVerify, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return err
+	}
+	// the notification endpoint is decoded in place to set the fields set by the server, such as its ID.
+	return json.NewDecoder(resp.Body).Decode(edp)
+}
+
+// UpdateNotificationEndpoint updates a single notification endpoint.
+// The notification endpoint is updated by the user of the token, userID is ignored.
+func (s *NotificationEndpointService) UpdateNotificationEndpoint(ctx context.Context, id influxdb.ID, edp influxdb.NotificationEndpoint, userID influxdb.ID) (influxdb.NotificationEndpoint, error) {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	octets, err := json.Marshal(edp)
+	if err != nil {
+		return nil, err
+	}
+	return s.sendNotificationEndpoint(ctx, "PUT", notificationEndpointIDPath(id), octets)
+}
+
+// PatchNotificationEndpoint updates a single notification endpoint with changeset.
+func (s *NotificationEndpointService) PatchNotificationEndpoint(ctx context.Context, id influxdb.ID, upd influxdb.NotificationEndpointUpdate) (influxdb.NotificationEndpoint, error) {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	octets, err := json.Marshal(upd)
+	if err != nil {
+		return nil, err
+	}
+	return s.sendNotificationEndpoint(ctx, "PATCH", notificationEndpointIDPath(id), octets)
+}
+
+// DeleteNotificationEndpoint removes a notification endpoint by ID.
+func (s *NotificationEndpointService) DeleteNotificationEndpoint(ctx context.Context, id influxdb.ID) error {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	u, err := NewURL(s.Addr, notificationEndpointIDPath(id))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("DELETE", u.String(), nil)
+	if err != nil {
+		return err
+	}
+	SetToken(s.Token, req)
+
+	resp, err := s.Options.do(ctx, u.Scheme, s.InsecureSkipVerify, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return CheckError(resp)
+}
+
+// DeleteNotificationEndpointCascade removes a notification endpoint by ID and the notification
+// rules sending to it, and returns the removed notification rules.
+func (s *NotificationEndpointService) DeleteNotificationEndpointCascade(ctx context.Context, id influxdb.ID) ([]influxdb.ID, error) {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	u, err := NewURL(s.Addr, notificationEndpointIDPath(id))
+	if err != nil {
+		return nil, err
+	}
+	q := u.Query()
+	q.Set("force", "true")
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequest("DELETE", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	SetToken(s.Token, req)
+
+	resp, err := s.Options.do(ctx, u.Scheme, s.InsecureSkipVerify, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return nil, err
+	}
+	var res notificationEndpointRulesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return nil, err
+	}
+	return res.NotificationRuleIDs, nil
+}
+
+// ReassignNotificationEndpoint moves the notification rules sending to a notification endpoint
+// to another one, then deletes or disables the notification endpoint.
+func (s *NotificationEndpointService) ReassignNotificationEndpoint(ctx context.Context, id influxdb.ID, r influxdb.NotificationEndpointReassign) (*influxdb.NotificationEndpointReassignResult, error) {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	u, err := NewURL(s.Addr, path.Join(notificationEndpointIDPath(id), "reassign"))
+	if err != nil {
+		return nil, err
+	}
+	octets, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", u.String(), bytes.NewReader(octets))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	SetToken(s.Token, req)
+
+	resp, err := s.Options.do(ctx, u.Scheme, s.InsecureSkipVerify, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return nil, err
+	}
+	var res influxdb.NotificationEndpointReassignResult
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// sendNotificationEndpoint sends a request with a JSON body to a notification endpoint route returning the notification endpoint.
+func (s *NotificationEndpointService) sendNotificationEndpoint(ctx context.Context, method, p string, octets []byte) (influxdb.NotificationEndpoint, error) {
+	u, err := NewURL(s.Addr, p)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(method, u.String(), bytes.NewReader(octets))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	SetToken(s.Token, req)
+
+	resp, err := s.Options.do(ctx, u.Scheme, s.InsecureSkipVerify, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return nil, err
+	}
+	buf := new(bytes.Buffer)
+	if _, err := buf.ReadFrom(resp.Body); err != nil {
+		return nil, err
+	}
+	return endpoint.UnmarshalJSON(buf.Bytes())
+}
+
+func notificationEndpointIDPath(id influxdb.ID) string {
+	return path.Join(notificationEndpointsPath, id.String())
+}
@@ -0,0 +1,268 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/notification/endpoint"
+	influxTesting "github.com/influxdata/influxdb/testing"
+	"go.uber.org/zap"
+)
+
+func Test_newNotificationEndpointResponses(t *testing.T) {
+	type args struct {
+		opt    influxdb.FindOptions
+		filter influxdb.NotificationEndpointFilter
+		edps   []influxdb.NotificationEndpoint
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			args: args{
+				opt: influxdb.FindOptions{
+					Limit:      50,
+					Offset:     0,
+					Descending: true,
+				},
+				filter: influxdb.NotificationEndpointFilter{
+					OrgID: influxTesting.IDPtr(influxdb.ID(2)),
+				},
+				edps: []influxdb.NotificationEndpoint{
+					&endpoint.Slack{
+						Base: endpoint.Base{
+							ID:          influxdb.ID(1),
+							OrgID:       influxdb.ID(2),
+							Name:        "name1",
+							Description: "desc1",
+							Status:      influxdb.Active,
+						},
+						URL: "https://hooks.slack.com/services/1",
+						Token: influxdb.SecretField{
+							Key: "0000000000000001-token",
+						},
+					},
+				},
+			},
+			want: `{
+  "links": {
+    "self": "/api/v2/notificationEndpoints?descending=true&limit=50&offset=0&orgID=0000000000000002"
+  },
+  "notificationEndpoints": [
+    {
+      "id": "0000000000000001",
+      "orgID": "0000000000000002",
+      "name": "name1",
+      "description": "desc1",
+      "status": "active",
+      "type": "slack",
+      "url": "https://hooks.slack.com/services/1",
+      "token": "secret: 0000000000000001-token",
+      "createdAt": "0001-01-01T00:00:00Z",
+      "updatedAt": "0001-01-01T00:00:00Z",
+      "labels": [],
+      "links": {
+        "labels": "/api/v2/notificationEndpoints/0000000000000001/labels",
+        "members": "/api/v2/notificationEndpoints/0000000000000001/members",
+        "owners": "/api/v2/notificationEndpoints/0000000000000001/owners",
+        "self": "/api/v2/notificationEndpoints/0000000000000001"
+      }
+    }
+  ]
+}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			res := newNotificationEndpointsResponse(ctx, tt.args.edps, mock.NewLabelService(), tt.args.filter, tt.args.opt)
+			got, err := json.Marshal(res)
+			if err != nil {
+				t.Fatalf("newNotificationEndpointsResponse() JSON marshal %v", err)
+			}
+			if eq, diff, _ := jsonEqual(string(got), tt.want); tt.want != "" && !eq {
+				t.Errorf("%q. newNotificationEndpointsResponse() = ***%s***", tt.name, diff)
+			}
+		})
+	}
+}
+
+func Test_newNotificationEndpointResponse(t *testing.T) {
+	value := "token-value"
+	edp := &endpoint.Slack{
+		Base: endpoint.Base{
+			ID:     influxdb.ID(1),
+			OrgID:  influxdb.ID(2),
+			Name:   "name1",
+			Status: influxdb.Inactive,
+		},
+		URL: "https://hooks.slack.com/services/1",
+		Token: influxdb.SecretField{
+			Key:   "0000000000000001-token",
+			Value: &value,
+		},
+	}
+	want := `{
+  "id": "0000000000000001",
+  "orgID": "0000000000000002",
+  "name": "name1",
+  "status": "inactive",
+  "type": "slack",
+  "url": "https://hooks.slack.com/services/1",
+  "token": "secret: 0000000000000001-token",
+  "createdAt": "0001-01-01T00:00:00Z",
+  "updatedAt": "0001-01-01T00:00:00Z",
+  "labels": [],
+  "links": {
+    "labels": "/api/v2/notificationEndpoints/0000000000000001/labels",
+    "members": "/api/v2/notificationEndpoints/0000000000000001/members",
+    "owners": "/api/v2/notificationEndpoints/0000000000000001/owners",
+    "self": "/api/v2/notificationEndpoints/0000000000000001"
+  }
+}`
+
+	res := newNotificationEndpointResponse(edp, []*influxdb.Label{})
+	got, err := json.Marshal(res)
+	if err != nil {
+		t.Fatalf("newNotificationEndpointResponse() JSON marshal %v", err)
+	}
+	if eq, diff, _ := jsonEqual(string(got), want); !eq {
+		t.Errorf("newNotificationEndpointResponse() = ***%s***", diff)
+	}
+}
+
+func TestNotificationEndpointHandler_handleDeleteNotificationEndpoint(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		statusCode int
+		want       string
+	}{
+		{
+			name:       "endpoint used by notification rules",
+			statusCode: 422,
+			want:       `{"code":"conflict","message":"notification endpoint is used by notification rules, delete them first or force the deletion to delete them too","notificationRuleIDs":["0000000000000002","0000000000000003"]}`,
+		},
+		{
+			name:       "forced deletion",
+			query:      "?force=true",
+			statusCode: 200,
+			want:       `{"notificationRuleIDs":["0000000000000002","0000000000000003"]}`,
+		},
+		{
+			name:       "invalid force",
+			query:      "?force=yes",
+			statusCode: 400,
+			want:       `{"code":"invalid","message":"force is invalid"}`,
+		},
+	}
+
+	ruleIDs := []influxdb.ID{2, 3}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &NotificationEndpointBackend{
+				HTTPErrorHandler: ErrorHandler(0),
+				Logger:           zap.NewNop().With(zap.String("handler", "notification_endpoint")),
+				NotificationEndpointService: &mock.NotificationEndpointService{
+					DeleteNotificationEndpointF: func(ctx context.Context, id influxdb.ID) error {
+						return &influxdb.Error{
+							Code: influxdb.EConflict,
+							Msg:  "notification endpoint is used by notification rules, delete them first or force the deletion to delete them too",
+							Err:  &influxdb.NotificationEndpointInUseError{EndpointID: id, RuleIDs: ruleIDs},
+						}
+					},
+				},
+				NotificationEndpointCascader: &mock.NotificationEndpointCascader{
+					DeleteNotificationEndpointCascadeF: func(ctx context.Context, id influxdb.ID) ([]influxdb.ID, error) {
+						return ruleIDs, nil
+					},
+				},
+			}
+			h := NewNotificationEndpointHandler(b)
+
+			r := httptest.NewRequest("DELETE", "http://any.url/api/v2/notificationEndpoints/0000000000000001"+tt.query, nil)
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			res := w.Result()
+			body, _ := ioutil.ReadAll(res.Body)
+			if res.StatusCode != tt.statusCode {
+				t.Errorf("got status %d, want %d: %s", res.StatusCode, tt.statusCode, body)
+			}
+			if eq, diff, _ := jsonEqual(string(body), tt.want); !eq {
+				t.Errorf("handleDeleteNotificationEndpoint() = ***%s***", diff)
+			}
+		})
+	}
+}
+
+func TestNotificationEndpointHandler_handlePostNotificationEndpointReassign(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		statusCode int
+		want       string
+	}{
+		{
+			name:       "reassign and disable",
+			body:       `{"targetEndpointID":"0000000000000004"}`,
+			statusCode: 200,
+			want:       `{"notificationRuleIDs":["0000000000000002","0000000000000003"],"deleted":false}`,
+		},
+		{
+			name:       "reassign and delete",
+			body:       `{"targetEndpointID":"0000000000000004","delete":true}`,
+			statusCode: 200,
+			want:       `{"notificationRuleIDs":["0000000000000002","0000000000000003"],"deleted":true}`,
+		},
+		{
+			name:       "missing target endpoint",
+			body:       `{}`,
+			statusCode: 400,
+			want:       `{"code":"invalid","message":"notification endpoint reassignment requires a valid targetEndpointID"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &NotificationEndpointBackend{
+				HTTPErrorHandler: ErrorHandler(0),
+				Logger:           zap.NewNop().With(zap.String("handler", "notification_endpoint")),
+				NotificationEndpointReassigner: &mock.NotificationEndpointReassigner{
+					ReassignNotificationEndpointF: func(ctx context.Context, id influxdb.ID, r influxdb.NotificationEndpointReassign) (*influxdb.NotificationEndpointReassignResult, error) {
+						if id != 1 || r.TargetEndpointID != 4 {
+							t.Errorf("unexpected reassignment of %s to %s", id, r.TargetEndpointID)
+						}
+						return &influxdb.NotificationEndpointReassignResult{
+							NotificationRuleIDs: []influxdb.ID{2, 3},
+							Deleted:             r.Delete,
+						}, nil
+					},
+				},
+			}
+			h := NewNotificationEndpointHandler(b)
+
+			r := httptest.NewRequest("POST", "http://any.url/api/v2/notificationEndpoints/0000000000000001/reassign", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			res := w.Result()
+			body, _ := ioutil.ReadAll(res.Body)
+			if res.StatusCode != tt.statusCode {
+				t.Errorf("got status %d, want %d: %s", res.StatusCode, tt.statusCode, body)
+			}
+			if eq, diff, _ := jsonEqual(string(body), tt.want); !eq {
+				t.Errorf("handlePostNotificationEndpointReassign() = ***%s***", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/influxdata/influxdb"
+	icontext "github.com/influxdata/influxdb/context"
+	"github.com/julienschmidt/httprouter"
+	"go.uber.org/zap"
+)
+
+type notificationPreferencesLinks struct {
+	Self string `json:"self"`
+	User string `json:"user"`
+}
+
+type notificationPreferencesResponse struct {
+	*influxdb.NotificationPreferences
+	Links notificationPreferencesLinks `json:"links"`
+}
+
+func newNotificationPreferencesResponse(p *influxdb.NotificationPreferences) *notificationPreferencesResponse {
+	return &notificationPreferencesResponse{
+		NotificationPreferences: p,
+		Links: notificationPreferencesLinks{
+			Self: fmt.Sprintf("/api/v2/users/%s/notificationPreferences", p.UserID),
+			User: fmt.Sprintf("/api/v2/users/%s", p.UserID),
+		},
+	}
+}
+
+// decodeNotificationPreferencesUserID returns the user of the url, or the
+// user of the authorizer for the /api/v2/me/notificationPreferences route.
+func decodeNotificationPreferencesUserID(ctx context.Context) (influxdb.ID, error) {
+	id := httprouter.ParamsFromContext(ctx).ByName("id")
+	if id == "" {
+		a, err := icontext.GetAuthorizer(ctx)
+		if err != nil {
+			return 0, err
+		}
+		return a.GetUserID(), nil
+	}
+
+	var i influxdb.ID
+	if err := i.DecodeFromString(id); err != nil {
+		return 0, err
+	}
+	return i, nil
+}
+
+// handleGetNotificationPreferences is the HTTP handler for the GET /api/v2/users/:id/notificationPreferences
+// and GET /api/v2/me/notificationPreferences routes.
+func (h *UserHandler) handleGetNotificationPreferences(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "notification preferences retrieve request", r)
+	userID, err := decodeNotificationPreferencesUserID(ctx)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	p, err := h.NotificationPreferencesService.FindNotificationPreferences(ctx, userID)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	debugResult(h.Logger, "notification preferences retrieved", "notificationPreferences", p)
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newNotificationPreferencesResponse(p)); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+func decodePutNotificationPreferencesRequest(ctx context.Context, r *http.Request) (*influxdb.NotificationPreferences, error) {
+	userID, err := decodeNotificationPreferencesUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &influxdb.NotificationPreferences{}
+	if err := json.NewDecoder(r.Body).Decode(p); err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  err.Error(),
+		}
+	}
+	p.UserID = userID
+	if err := p.Valid(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// handlePutNotificationPreferences is the HTTP handler for the PUT /api/v2/users/:id/notificationPreferences
+// and PUT /api/v2/me/notificationPreferences routes.
+func (h *UserHandler) handlePutNotificationPreferences(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "notification preferences replace request", r)
+	p, err := decodePutNotificationPreferencesRequest(ctx, r)
+	if err != nil {
+		h.Logger.Debug("failed to decode request", zap.Error(err))
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	if err := h.NotificationPreferencesService.PutNotificationPreferences(ctx, p); err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	debugResult(h.Logger, "notification preferences replaced", "notificationPreferences", p)
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newNotificationPreferencesResponse(p)); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+// handleDeleteNotificationPreferences is the HTTP handler for the DELETE /api/v2/users/:id/notificationPreferences
+// and DELETE /api/v2/me/notificationPreferences routes.
+func (h *UserHandler) handleDeleteNotificationPreferences(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "notification preferences delete request", r)
+	userID, err := decodeNotificationPreferencesUserID(ctx)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	if err := h.NotificationPreferencesService.DeleteNotificationPreferences(ctx, userID); err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	h.Logger.Debug("notification preferences deleted", zap.Stringer("userID", userID))
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,94 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	icontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/mock"
+	"go.uber.org/zap"
+)
+
+func TestUserHandler_handlePutNotificationPreferences(t *testing.T) {
+	tests := []struct {
+		name       string
+		url        string
+		body       string
+		statusCode int
+		want       string
+	}{
+		{
+			name:       "replace the preferences of the authorized user",
+			url:        "http://any.url/api/v2/me/notificationPreferences",
+			body:       `{"quietHours":{"start":"22:00","end":"07:00","timezone":"Europe/Berlin","action":"defer"},"minLevel":"WARN"}`,
+			statusCode: 200,
+			want: `{
+  "userID": "0000000000000007",
+  "quietHours": {"start": "22:00", "end": "07:00", "timezone": "Europe/Berlin", "action": "defer"},
+  "minLevel": "WARN",
+  "createdAt": "0001-01-01T00:00:00Z",
+  "updatedAt": "0001-01-01T00:00:00Z",
+  "links": {
+    "self": "/api/v2/users/0000000000000007/notificationPreferences",
+    "user": "/api/v2/users/0000000000000007"
+  }
+}`,
+		},
+		{
+			name:       "replace the preferences of a user",
+			url:        "http://any.url/api/v2/users/0000000000000003/notificationPreferences",
+			body:       `{"preferredEndpointID":"0000000000000001","userID":"0000000000000007"}`,
+			statusCode: 200,
+			want: `{
+  "userID": "0000000000000003",
+  "preferredEndpointID": "0000000000000001",
+  "createdAt": "0001-01-01T00:00:00Z",
+  "updatedAt": "0001-01-01T00:00:00Z",
+  "links": {
+    "self": "/api/v2/users/0000000000000003/notificationPreferences",
+    "user": "/api/v2/users/0000000000000003"
+  }
+}`,
+		},
+		{
+			name:       "invalid quiet hours",
+			url:        "http://any.url/api/v2/me/notificationPreferences",
+			body:       `{"quietHours":{"start":"22:00","end":"07:00","action":"snooze"}}`,
+			statusCode: 400,
+			want:       `{"code":"invalid","message":"quiet hours action must be defer or suppress"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &UserBackend{
+				HTTPErrorHandler: ErrorHandler(0),
+				Logger:           zap.NewNop().With(zap.String("handler", "user")),
+				NotificationPreferencesService: &mock.NotificationPreferencesService{
+					PutNotificationPreferencesF: func(ctx context.Context, p *influxdb.NotificationPreferences) error {
+						return nil
+					},
+				},
+			}
+			h := NewUserHandler(b)
+
+			r := httptest.NewRequest("PUT", tt.url, bytes.NewReader([]byte(tt.body)))
+			r = r.WithContext(icontext.SetAuthorizer(r.Context(), &influxdb.Authorization{UserID: influxdb.ID(7)}))
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			res := w.Result()
+			body, _ := ioutil.ReadAll(res.Body)
+			if res.StatusCode != tt.statusCode {
+				t.Errorf("got status %d, want %d: %s", res.StatusCode, tt.statusCode, body)
+			}
+			if eq, diff, _ := jsonEqual(string(body), tt.want); !eq {
+				t.Errorf("handlePutNotificationPreferences() = ***%s***", diff)
+			}
+		})
+	}
+}
@@ -6,9 +6,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"path"
 
 	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/alerting/validate"
 	pctx "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/kit/tracing"
 	"github.com/influxdata/influxdb/notification/rule"
 	"github.com/julienschmidt/httprouter"
 	"go.uber.org/zap"
@@ -20,11 +23,13 @@ type NotificationRuleBackend struct {
 	influxdb.HTTPErrorHandler
 	Logger *zap.Logger
 
-	NotificationRuleStore      influxdb.NotificationRuleStore
-	UserResourceMappingService influxdb.UserResourceMappingService
-	LabelService               influxdb.LabelService
-	UserService                influxdb.UserService
-	OrganizationService        influxdb.OrganizationService
+	NotificationRuleStore       influxdb.NotificationRuleStore
+	NotificationRuleTestService influxdb.NotificationRuleTestService
+	AlertingQuotaService        influxdb.AlertingQuotaService
+	UserResourceMappingService  influxdb.UserResourceMappingService
+	LabelService                influxdb.LabelService
+	UserService                 influxdb.UserService
+	OrganizationService         influxdb.OrganizationService
 }
 
 // NewNotificationRuleBackend returns a new instance of NotificationRuleBackend.
@@ -33,11 +38,13 @@ func NewNotificationRuleBackend(b *APIBackend) *NotificationRuleBackend {
 		HTTPErrorHandler: b.HTTPErrorHandler,
 		Logger:           b.Logger.With(zap.String("handler", "notification_rule")),
 
-		NotificationRuleStore:      b.NotificationRuleStore,
-		UserResourceMappingService: b.UserResourceMappingService,
-		LabelService:               b.LabelService,
-		UserService:                b.UserService,
-		OrganizationService:        b.OrganizationService,
+		NotificationRuleStore:       b.NotificationRuleStore,
+		NotificationRuleTestService: b.NotificationRuleTestService,
+		AlertingQuotaService:        b.AlertingQuotaService,
+		UserResourceMappingService:  b.UserResourceMappingService,
+		LabelService:                b.LabelService,
+		UserService:                 b.UserService,
+		OrganizationService:         b.OrganizationService,
 	}
 }
 
@@ -47,11 +54,13 @@ type NotificationRuleHandler struct {
 	influxdb.HTTPErrorHandler
 	Logger *zap.Logger
 
-	NotificationRuleStore      influxdb.NotificationRuleStore
-	UserResourceMappingService influxdb.UserResourceMappingService
-	LabelService               influxdb.LabelService
-	UserService                influxdb.UserService
-	OrganizationService        influxdb.OrganizationService
+	NotificationRuleStore       influxdb.NotificationRuleStore
+	NotificationRuleTestService influxdb.NotificationRuleTestService
+	AlertingQuotaService        influxdb.AlertingQuotaService
+	UserResourceMappingService  influxdb.UserResourceMappingService
+	LabelService                influxdb.LabelService
+	UserService                 influxdb.UserService
+	OrganizationService         influxdb.OrganizationService
 }
 
 const (
@@ -63,6 +72,7 @@ const (
 	notificationRulesIDOwnersIDPath  = "/api/v2/notificationRules/:id/owners/:userID"
 	notificationRulesIDLabelsPath    = "/api/v2/notificationRules/:id/labels"
 	notificationRulesIDLabelsIDPath  = "/api/v2/notificationRules/:id/labels/:lid"
+	notificationRulesIDTestPath      = "/api/v2/notificationRules/:id/test"
 )
 
 // NewNotificationRuleHandler returns a new instance of NotificationRuleHandler.
@@ -72,11 +82,13 @@ func NewNotificationRuleHandler(b *NotificationRuleBackend) *NotificationRuleHan
 		HTTPErrorHandler: b.HTTPErrorHandler,
 		Logger:           b.Logger,
 
-		NotificationRuleStore:      b.NotificationRuleStore,
-		UserResourceMappingService: b.UserResourceMappingService,
-		LabelService:               b.LabelService,
-		UserService:                b.UserService,
-		OrganizationService:        b.OrganizationService,
+		NotificationRuleStore:       b.NotificationRuleStore,
+		NotificationRuleTestService: b.NotificationRuleTestService,
+		AlertingQuotaService:        b.AlertingQuotaService,
+		UserResourceMappingService:  b.UserResourceMappingService,
+		LabelService:                b.LabelService,
+		UserService:                 b.UserService,
+		OrganizationService:         b.OrganizationService,
 	}
 	h.HandlerFunc("POST", notificationRulesPath, h.handlePostNotificationRule)
 	h.HandlerFunc("GET", notificationRulesPath, h.handleGetNotificationRules)
@@ -84,6 +96,7 @@ func NewNotificationRuleHandler(b *NotificationRuleBackend) *NotificationRuleHan
 	h.HandlerFunc("DELETE", notificationRulesIDPath, h.handleDeleteNotificationRule)
 	h.HandlerFunc("PUT", notificationRulesIDPath, h.handlePutNotificationRule)
 	h.HandlerFunc("PATCH", notificationRulesIDPath, h.handlePatchNotificationRule)
+	h.HandlerFunc("POST", notificationRulesIDTestPath, h.handlePostNotificationRuleTest)
 
 	memberBackend := MemberBackend{
 		HTTPErrorHandler:           b.HTTPErrorHandler,
@@ -133,6 +146,9 @@ type notificationRuleResponse struct {
 	influxdb.NotificationRule
 	Labels []influxdb.Label      `json:"labels"`
 	Links  notificationRuleLinks `json:"links"`
+	// Warnings are only set by a create of a notification rule of an
+	// organization near its quota of notification rules.
+	Warnings []influxdb.QuotaWarning `json:"warnings,omitempty"`
 }
 
 func (resp notificationRuleResponse) MarshalJSON() ([]byte, error) {
@@ -142,11 +158,13 @@ func (resp notificationRuleResponse) MarshalJSON() ([]byte, error) {
 	}
 
 	b2, err := json.Marshal(struct {
-		Labels []influxdb.Label      `json:"labels"`
-		Links  notificationRuleLinks `json:"links"`
+		Labels   []influxdb.Label        `json:"labels"`
+		Links    notificationRuleLinks   `json:"links"`
+		Warnings []influxdb.QuotaWarning `json:"warnings,omitempty"`
 	}{
-		Links:  resp.Links,
-		Labels: resp.Labels,
+		Links:    resp.Links,
+		Labels:   resp.Labels,
+		Warnings: resp.Warnings,
 	})
 	if err != nil {
 		return nil, err
@@ -158,6 +176,9 @@ func (resp notificationRuleResponse) MarshalJSON() ([]byte, error) {
 type notificationRulesResponse struct {
 	NotificationRules []*notificationRuleResponse `json:"notificationRules"`
 	Links             *influxdb.PagingLinks       `json:"links"`
+	// Warnings are only set for the notification rules of an
+	// organization near its quota of notification rules.
+	Warnings []influxdb.QuotaWarning `json:"warnings,omitempty"`
 }
 
 func newNotificationRuleResponse(nr influxdb.NotificationRule, labels []*influxdb.Label) *notificationRuleResponse {
@@ -209,7 +230,7 @@ func decodeGetNotificationRuleRequest(ctx context.Context, r *http.Request) (i i
 
 func (h *NotificationRuleHandler) handleGetNotificationRules(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.Logger.Debug("notification rules retrieve request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "notification rules retrieve request", r)
 	filter, opts, err := decodeNotificationRuleFilter(ctx, r)
 	if err != nil {
 		h.Logger.Debug("failed to decode request", zap.Error(err))
@@ -221,9 +242,11 @@ func (h *NotificationRuleHandler) handleGetNotificationRules(w http.ResponseWrit
 		h.HandleHTTPError(ctx, err, w)
 		return
 	}
-	h.Logger.Debug("notification rules retrieved", zap.String("notificationRules", fmt.Sprint(nrs)))
+	debugResult(h.Logger, "notification rules retrieved", "notificationRules", nrs)
 
-	if err := encodeResponse(ctx, w, http.StatusOK, newNotificationRulesResponse(ctx, nrs, h.LabelService, filter, *opts)); err != nil {
+	resp := newNotificationRulesResponse(ctx, nrs, h.LabelService, filter, *opts)
+	resp.Warnings = findFilterQuotaWarnings(ctx, h.AlertingQuotaService, h.OrganizationService, h.Logger, filter.OrgID, filter.Organization, influxdb.NotificationRuleResourceType)
+	if err := encodeResponse(ctx, w, http.StatusOK, resp); err != nil {
 		logEncodingError(h.Logger, r, err)
 		return
 	}
@@ -231,7 +254,7 @@ func (h *NotificationRuleHandler) handleGetNotificationRules(w http.ResponseWrit
 
 func (h *NotificationRuleHandler) handleGetNotificationRule(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.Logger.Debug("notification rule retrieve request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "notification rule retrieve request", r)
 	id, err := decodeGetNotificationRuleRequest(ctx, r)
 	if err != nil {
 		h.HandleHTTPError(ctx, err, w)
@@ -242,7 +265,7 @@ func (h *NotificationRuleHandler) handleGetNotificationRule(w http.ResponseWrite
 		h.HandleHTTPError(ctx, err, w)
 		return
 	}
-	h.Logger.Debug("notification rule retrieved", zap.String("notificationRule", fmt.Sprint(nr)))
+	debugResult(h.Logger, "notification rule retrieved", "notificationRule", nr)
 
 	labels, err := h.LabelService.FindResourceLabels(ctx, influxdb.LabelMappingFilter{ResourceID: nr.GetID()})
 	if err != nil {
@@ -325,6 +348,9 @@ func decodePostNotificationRuleRequest(ctx context.Context, r *http.Request) (in
 			Err:  err,
 		}
 	}
+	if err := validate.NotificationRule(nr, validate.Create); err != nil {
+		return nil, invalidResourceError(err)
+	}
 	return nr, nil
 }
 
@@ -358,6 +384,9 @@ func decodePutNotificationRuleRequest(ctx context.Context, r *http.Request) (inf
 		return nil, err
 	}
 	nr.SetID(*i)
+	if err := validate.NotificationRule(nr, validate.Update); err != nil {
+		return nil, invalidResourceError(err)
+	}
 	return nr, nil
 }
 
@@ -404,7 +433,12 @@ func decodePatchNotificationRuleRequest(ctx context.Context, r *http.Request) (*
 // handlePostNotificationRule is the HTTP handler for the POST /api/v2/notificationRules route.
 func (h *NotificationRuleHandler) handlePostNotificationRule(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.Logger.Debug("notification rule create request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "notification rule create request", r)
+	ctx, dryRun, err := decodeDryRun(ctx, r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
 	nr, err := decodePostNotificationRuleRequest(ctx, r)
 	if err != nil {
 		h.Logger.Debug("failed to decode request", zap.Error(err))
@@ -421,9 +455,17 @@ func (h *NotificationRuleHandler) handlePostNotificationRule(w http.ResponseWrit
 		h.HandleHTTPError(ctx, err, w)
 		return
 	}
-	h.Logger.Debug("notification rule created", zap.String("notificationRule", fmt.Sprint(nr)))
+	if dryRun != nil {
+		if err := encodeDryRunResponse(ctx, w, newNotificationRuleResponse(nr, []*influxdb.Label{}), dryRun); err != nil {
+			logEncodingError(h.Logger, r, err)
+		}
+		return
+	}
+	debugResult(h.Logger, "notification rule created", "notificationRule", nr)
 
-	if err := encodeResponse(ctx, w, http.StatusCreated, newNotificationRuleResponse(nr, []*influxdb.Label{})); err != nil {
+	resp := newNotificationRuleResponse(nr, []*influxdb.Label{})
+	resp.Warnings = findQuotaWarnings(ctx, h.AlertingQuotaService, h.Logger, nr.GetOrgID(), influxdb.NotificationRuleResourceType)
+	if err := encodeResponse(ctx, w, http.StatusCreated, resp); err != nil {
 		logEncodingError(h.Logger, r, err)
 		return
 	}
@@ -432,7 +474,12 @@ func (h *NotificationRuleHandler) handlePostNotificationRule(w http.ResponseWrit
 // handlePutNotificationRule is the HTTP handler for the PUT /api/v2/notificationRule route.
 func (h *NotificationRuleHandler) handlePutNotificationRule(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.Logger.Debug("notification rule update request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "notification rule update request", r)
+	ctx, dryRun, err := decodeDryRun(ctx, r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
 	nr, err := decodePutNotificationRuleRequest(ctx, r)
 	if err != nil {
 		h.Logger.Debug("failed to decode request", zap.Error(err))
@@ -456,7 +503,13 @@ func (h *NotificationRuleHandler) handlePutNotificationRule(w http.ResponseWrite
 		h.HandleHTTPError(ctx, err, w)
 		return
 	}
-	h.Logger.Debug("notification rule updated", zap.String("notificationRule", fmt.Sprint(nr)))
+	if dryRun != nil {
+		if err := encodeDryRunResponse(ctx, w, newNotificationRuleResponse(nr, labels), dryRun); err != nil {
+			logEncodingError(h.Logger, r, err)
+		}
+		return
+	}
+	debugResult(h.Logger, "notification rule updated", "notificationRule", nr)
 
 	if err := encodeResponse(ctx, w, http.StatusOK, newNotificationRuleResponse(nr, labels)); err != nil {
 		logEncodingError(h.Logger, r, err)
@@ -467,7 +520,12 @@ func (h *NotificationRuleHandler) handlePutNotificationRule(w http.ResponseWrite
 // handlePatchNotificationRule is the HTTP handler for the PATCH /api/v2/notificationRule/:id route.
 func (h *NotificationRuleHandler) handlePatchNotificationRule(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.Logger.Debug("notification rule patch request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "notification rule patch request", r)
+	ctx, dryRun, err := decodeDryRun(ctx, r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
 	req, err := decodePatchNotificationRuleRequest(ctx, r)
 	if err != nil {
 		h.Logger.Debug("failed to decode request", zap.Error(err))
@@ -486,7 +544,13 @@ func (h *NotificationRuleHandler) handlePatchNotificationRule(w http.ResponseWri
 		h.HandleHTTPError(ctx, err, w)
 		return
 	}
-	h.Logger.Debug("notification rule patch", zap.String("notificationRule", fmt.Sprint(nr)))
+	if dryRun != nil {
+		if err := encodeDryRunResponse(ctx, w, newNotificationRuleResponse(nr, labels), dryRun); err != nil {
+			logEncodingError(h.Logger, r, err)
+		}
+		return
+	}
+	debugResult(h.Logger, "notification rule patch", "notificationRule", nr)
 
 	if err := encodeResponse(ctx, w, http.StatusOK, newNotificationRuleResponse(nr, labels)); err != nil {
 		logEncodingError(h.Logger, r, err)
@@ -496,7 +560,12 @@ func (h *NotificationRuleHandler) handlePatchNotificationRule(w http.ResponseWri
 
 func (h *NotificationRuleHandler) handleDeleteNotificationRule(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.Logger.Debug("notification rule delete request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "notification rule delete request", r)
+	ctx, dryRun, err := decodeDryRun(ctx, r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
 	i, err := decodeGetNotificationRuleRequest(ctx, r)
 	if err != nil {
 		h.HandleHTTPError(ctx, err, w)
@@ -507,7 +576,235 @@ func (h *NotificationRuleHandler) handleDeleteNotificationRule(w http.ResponseWr
 		h.HandleHTTPError(ctx, err, w)
 		return
 	}
-	h.Logger.Debug("notification rule deleted", zap.String("notificationRuleID", fmt.Sprint(i)))
+	if dryRun != nil {
+		if err := encodeDryRunResponse(ctx, w, nil, dryRun); err != nil {
+			logEncodingError(h.Logger, r, err)
+		}
+		return
+	}
+	h.Logger.Debug("notification rule deleted", zap.Stringer("notificationRuleID", i))
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// NotificationRuleService connects to Influx via HTTP using tokens to manage notification rules.
+type NotificationRuleService struct {
+	Addr               string
+	Token              string
+	InsecureSkipVerify bool
+	// Options configures the retries, circuit breaking, timeout and
+	// transport of the calls, each request is sent once by default.
+	Options ClientOptions
+
+	*UserResourceMappingService
+	*OrganizationService
+}
+
+var _ influxdb.NotificationRuleStore = (*NotificationRuleService)(nil)
+
+// NewNotificationRuleService returns a NotificationRuleService connecting to addr with token.
+func NewNotificationRuleService(addr, token string, insecureSkipVerify bool) *NotificationRuleService {
+	return &NotificationRuleService{
+		Addr:               addr,
+		Token:              token,
+		InsecureSkipVerify: insecureSkipVerify,
+		UserResourceMappingService: &UserResourceMappingService{
+			Addr:               addr,
+			Token:              token,
+			InsecureSkipVerify: insecureSkipVerify,
+		},
+		OrganizationService: &OrganizationService{
+			Addr:               addr,
+			Token:              token,
+			InsecureSkipVerify: insecureSkipVerify,
+		},
+	}
+}
+
+// FindNotificationRuleByID returns a single notification rule by ID.
+func (s *NotificationRuleService) FindNotificationRuleByID(ctx context.Context, id influxdb.ID) (influxdb.NotificationRule, error) {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	return s.sendNotificationRule(ctx, "GET", notificationRuleIDPath(id), nil)
+}
+
+// FindNotificationRules returns a list of notification rules that match filter and the total count of matching notification rules.
+// Additional options provide pagination & sorting.
+func (s *NotificationRuleService) FindNotificationRules(ctx context.Context, filter influxdb.NotificationRuleFilter, opt ...influxdb.FindOptions) ([]influxdb.NotificationRule, int, error) {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	u, err := NewURL(s.Addr, notificationRulesPath)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := u.Query()
+	for k, vs := range filter.QueryParams() {
+		for _, v := range vs {
+			query.Add(k, v)
+		}
+	}
+	if len(opt) > 0 {
+		for k, vs := range opt[0].QueryParams() {
+			for _, v := range vs {
+				query.Add(k, v)
+			}
+		}
+	}
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	req.URL.RawQuery = query.Encode()
+	SetToken(s.Token, req)
+
+	resp, err := s.Options.do(ctx, u.Scheme, s.InsecureSkipVerify, req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return nil, 0, err
+	}
+
+	var r struct {
+		NotificationRules []json.RawMessage `json:"notificationRules"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&r); err != nil {
+		return nil, 0, err
+	}
+
+	nrs := make([]influxdb.NotificationRule, 0, len(r.NotificationRules))
+	for _, b := range r.NotificationRules {
+		nr, err := rule.UnmarshalJSON(b)
+		if err != nil {
+			return nil, 0, err
+		}
+		nrs = append(nrs, nr)
+	}
+	return nrs, len(nrs), nil
+}
+
+// CreateNotificationRule creates a new notification rule and sets its ID with the new identifier.
+// The notification rule is owned by the user of the token, userID is ignored.
+func (s *NotificationRuleService) CreateNotificationRule(ctx context.Context, nr influxdb.NotificationRule, userID influxdb.ID) error {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	u, err := NewURL(s.Addr, notificationRulesPath)
+	if err != nil {
+		return err
+	}
+
+	octets, err := json.Marshal(nr)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", u.String(), bytes.NewReader(octets))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	SetToken(s.Token, req)
+
+	resp, err := s.Options.do(ctx, u.Scheme, s.InsecureSkipVerify, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return err
+	}
+	// the notification rule is decoded in place to set the fields set by the server, such as its ID.
+	return json.NewDecoder(resp.Body).Decode(nr)
+}
+
+// UpdateNotificationRule updates a single notification rule.
+// The notification rule is updated by the user of the token, userID is ignored.
+func (s *NotificationRuleService) UpdateNotificationRule(ctx context.Context, id influxdb.ID, nr influxdb.NotificationRule, userID influxdb.ID) (influxdb.NotificationRule, error) {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	octets, err := json.Marshal(nr)
+	if err != nil {
+		return nil, err
+	}
+	return s.sendNotificationRule(ctx, "PUT", notificationRuleIDPath(id), octets)
+}
+
+// PatchNotificationRule updates a single notification rule with changeset.
+func (s *NotificationRuleService) PatchNotificationRule(ctx context.Context, id influxdb.ID, upd influxdb.NotificationRuleUpdate) (influxdb.NotificationRule, error) {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	octets, err := json.Marshal(upd)
+	if err != nil {
+		return nil, err
+	}
+	return s.sendNotificationRule(ctx, "PATCH", notificationRuleIDPath(id), octets)
+}
+
+// DeleteNotificationRule removes a notification rule by ID.
+func (s *NotificationRuleService) DeleteNotificationRule(ctx context.Context, id influxdb.ID) error {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	u, err := NewURL(s.Addr, notificationRuleIDPath(id))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("DELETE", u.String(), nil)
+	if err != nil {
+		return err
+	}
+	SetToken(s.Token, req)
+
+	resp, err := s.Options.do(ctx, u.Scheme, s.InsecureSkipVerify, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return CheckError(resp)
+}
+
+// sendNotificationRule sends a request with a JSON body to a notification rule route returning the notification rule.
+func (s *NotificationRuleService) sendNotificationRule(ctx context.Context, method, p string, octets []byte) (influxdb.NotificationRule, error) {
+	u, err := NewURL(s.Addr, p)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(method, u.String(), bytes.NewReader(octets))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	SetToken(s.Token, req)
+
+	resp, err := s.Options.do(ctx, u.Scheme, s.InsecureSkipVerify, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return nil, err
+	}
+	buf := new(bytes.Buffer)
+	if _, err := buf.ReadFrom(resp.Body); err != nil {
+		return nil, err
+	}
+	return rule.UnmarshalJSON(buf.Bytes())
+}
+
+func notificationRuleIDPath(id influxdb.ID) string {
+	return path.Join(notificationRulesPath, id.String())
+}
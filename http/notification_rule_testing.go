@@ -0,0 +1,61 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/influxdata/influxdb"
+	"go.uber.org/zap"
+)
+
+type postNotificationRuleTestRequest struct {
+	RuleID influxdb.ID
+	Test   influxdb.NotificationRuleTest
+}
+
+// decodePostNotificationRuleTestRequest decodes the test of a rule, the body
+// is optional.
+func decodePostNotificationRuleTestRequest(ctx context.Context, r *http.Request) (*postNotificationRuleTestRequest, error) {
+	id, err := decodeGetNotificationRuleRequest(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	req := &postNotificationRuleTestRequest{RuleID: id}
+	if err := json.NewDecoder(r.Body).Decode(&req.Test); err != nil && err != io.EOF {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  err.Error(),
+		}
+	}
+	if err := req.Test.Valid(); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// handlePostNotificationRuleTest is the HTTP handler for the POST /api/v2/notificationRules/:id/test route.
+// Nothing is sent.
+func (h *NotificationRuleHandler) handlePostNotificationRuleTest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "notification rule test request", r)
+	req, err := decodePostNotificationRuleTestRequest(ctx, r)
+	if err != nil {
+		h.Logger.Debug("failed to decode request", zap.Error(err))
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	res, err := h.NotificationRuleTestService.TestNotificationRule(ctx, req.RuleID, req.Test)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	h.Logger.Debug("notification rule tested", zap.String("ruleID", req.RuleID.String()), zap.Int("cases", len(res.Cases)))
+
+	if err := encodeResponse(ctx, w, http.StatusOK, res); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
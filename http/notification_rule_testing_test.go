@@ -0,0 +1,91 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
+	"go.uber.org/zap"
+)
+
+func TestNotificationRuleHandler_handlePostNotificationRuleTest(t *testing.T) {
+	var got influxdb.NotificationRuleTest
+	h := NewNotificationRuleHandler(&NotificationRuleBackend{
+		HTTPErrorHandler: ErrorHandler(0),
+		Logger:           zap.NewNop(),
+		NotificationRuleTestService: &mock.NotificationRuleTestService{
+			TestNotificationRuleF: func(ctx context.Context, ruleID influxdb.ID, test influxdb.NotificationRuleTest) (*influxdb.NotificationRuleTestResult, error) {
+				if ruleID != 1 {
+					return nil, &influxdb.Error{
+						Code: influxdb.ENotFound,
+						Msg:  "notification rule not found",
+					}
+				}
+				got = test
+				return &influxdb.NotificationRuleTestResult{
+					RuleID: ruleID,
+					Cases: []influxdb.NotificationRuleTestCase{
+						{Level: "CRIT", Tags: map[string]string{"host": "db"}, Notify: true, Decision: influxdb.RuleNotified, Message: "db is CRIT"},
+					},
+				}, nil
+			},
+		},
+	})
+
+	tests := []struct {
+		name        string
+		path        string
+		body        string
+		wantCode    int
+		wantTagSets int
+	}{
+		{
+			name:     "sample tag sets",
+			path:     "/api/v2/notificationRules/0000000000000001/test",
+			wantCode: http.StatusOK,
+		},
+		{
+			name:        "tag sets",
+			path:        "/api/v2/notificationRules/0000000000000001/test",
+			body:        `{"tagSets": [{"host": "db"}, {"host": "web"}]}`,
+			wantCode:    http.StatusOK,
+			wantTagSets: 2,
+		},
+		{
+			name:     "too many tag sets",
+			path:     "/api/v2/notificationRules/0000000000000001/test",
+			body:     `{"tagSets": [` + strings.Repeat(`{},`, influxdb.MaxNotificationRuleTestTagSets) + `{}]}`,
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "missing rule",
+			path:     "/api/v2/notificationRules/0000000000000002/test",
+			wantCode: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got = influxdb.NotificationRuleTest{}
+			r := httptest.NewRequest("POST", tt.path, strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantCode, w.Code, w.Body.String())
+			}
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+			if len(got.TagSets) != tt.wantTagSets {
+				t.Errorf("expected %d tag sets, got %v", tt.wantTagSets, got.TagSets)
+			}
+			if !strings.Contains(w.Body.String(), `"message":"db is CRIT"`) {
+				t.Errorf("unexpected response %s", w.Body.String())
+			}
+		})
+	}
+}
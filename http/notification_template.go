@@ -0,0 +1,368 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/julienschmidt/httprouter"
+	"go.uber.org/zap"
+)
+
+// NotificationTemplateBackend is all services and associated parameters required to construct
+// the NotificationTemplateHandler.
+type NotificationTemplateBackend struct {
+	influxdb.HTTPErrorHandler
+	Logger *zap.Logger
+
+	NotificationTemplateService influxdb.NotificationTemplateService
+}
+
+// NewNotificationTemplateBackend returns a new instance of NotificationTemplateBackend.
+func NewNotificationTemplateBackend(b *APIBackend) *NotificationTemplateBackend {
+	return &NotificationTemplateBackend{
+		HTTPErrorHandler: b.HTTPErrorHandler,
+		Logger:           b.Logger.With(zap.String("handler", "notification_template")),
+
+		NotificationTemplateService: b.NotificationTemplateService,
+	}
+}
+
+// NotificationTemplateHandler is the handler for the notification template service
+type NotificationTemplateHandler struct {
+	*httprouter.Router
+	influxdb.HTTPErrorHandler
+	Logger *zap.Logger
+
+	NotificationTemplateService influxdb.NotificationTemplateService
+}
+
+const (
+	notificationTemplatesPath        = "/api/v2/notificationTemplates"
+	notificationTemplatesIDPath      = "/api/v2/notificationTemplates/:id"
+	notificationTemplatesPreviewPath = "/api/v2/notificationTemplates/preview"
+)
+
+// NewNotificationTemplateHandler returns a new instance of NotificationTemplateHandler.
+func NewNotificationTemplateHandler(b *NotificationTemplateBackend) *NotificationTemplateHandler {
+	h := &NotificationTemplateHandler{
+		Router:           NewRouter(b.HTTPErrorHandler),
+		HTTPErrorHandler: b.HTTPErrorHandler,
+		Logger:           b.Logger,
+
+		NotificationTemplateService: b.NotificationTemplateService,
+	}
+
+	h.HandlerFunc("POST", notificationTemplatesPath, h.handlePostNotificationTemplate)
+	h.HandlerFunc("GET", notificationTemplatesPath, h.handleGetNotificationTemplates)
+	h.HandlerFunc("POST", notificationTemplatesPreviewPath, h.handlePreviewNotificationTemplate)
+	h.HandlerFunc("GET", notificationTemplatesIDPath, h.handleGetNotificationTemplate)
+	h.HandlerFunc("PATCH", notificationTemplatesIDPath, h.handlePatchNotificationTemplate)
+	h.HandlerFunc("DELETE", notificationTemplatesIDPath, h.handleDeleteNotificationTemplate)
+	return h
+}
+
+type notificationTemplateLinks struct {
+	Self string `json:"self"`
+	Org  string `json:"org"`
+}
+
+type notificationTemplateResponse struct {
+	*influxdb.NotificationTemplate
+	Links notificationTemplateLinks `json:"links"`
+}
+
+func newNotificationTemplateResponse(t *influxdb.NotificationTemplate) *notificationTemplateResponse {
+	return &notificationTemplateResponse{
+		NotificationTemplate: t,
+		Links: notificationTemplateLinks{
+			Self: fmt.Sprintf("/api/v2/notificationTemplates/%s", t.ID),
+			Org:  fmt.Sprintf("/api/v2/orgs/%s", t.OrgID),
+		},
+	}
+}
+
+type notificationTemplatesResponse struct {
+	NotificationTemplates []*notificationTemplateResponse `json:"notificationTemplates"`
+	Links                 *influxdb.PagingLinks           `json:"links"`
+}
+
+func newNotificationTemplatesResponse(ts []*influxdb.NotificationTemplate, f influxdb.PagingFilter, opts influxdb.FindOptions) *notificationTemplatesResponse {
+	resp := &notificationTemplatesResponse{
+		NotificationTemplates: make([]*notificationTemplateResponse, len(ts)),
+		Links:                 newPagingLinks(notificationTemplatesPath, opts, f, len(ts)),
+	}
+	for i, t := range ts {
+		resp.NotificationTemplates[i] = newNotificationTemplateResponse(t)
+	}
+	return resp
+}
+
+func decodeGetNotificationTemplateRequest(ctx context.Context, r *http.Request) (i influxdb.ID, err error) {
+	params := httprouter.ParamsFromContext(ctx)
+	id := params.ByName("id")
+	if id == "" {
+		return i, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "url missing id",
+		}
+	}
+
+	if err := i.DecodeFromString(id); err != nil {
+		return i, err
+	}
+	return i, nil
+}
+
+func decodeNotificationTemplateFilter(ctx context.Context, r *http.Request) (*influxdb.NotificationTemplateFilter, *influxdb.FindOptions, error) {
+	f := &influxdb.NotificationTemplateFilter{}
+
+	opts, err := decodeFindOptions(ctx, r)
+	if err != nil {
+		return f, nil, err
+	}
+
+	q := r.URL.Query()
+	if orgIDStr := q.Get("orgID"); orgIDStr != "" {
+		orgID, err := influxdb.IDFromString(orgIDStr)
+		if err != nil {
+			return f, opts, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "orgID is invalid",
+				Err:  err,
+			}
+		}
+		f.OrgID = orgID
+	} else if orgNameStr := q.Get("org"); orgNameStr != "" {
+		f.Organization = &orgNameStr
+	}
+	if name := q.Get("name"); name != "" {
+		f.Name = &name
+	}
+	return f, opts, nil
+}
+
+func (h *NotificationTemplateHandler) handleGetNotificationTemplates(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "notification templates retrieve request", r)
+	filter, opts, err := decodeNotificationTemplateFilter(ctx, r)
+	if err != nil {
+		h.Logger.Debug("failed to decode request", zap.Error(err))
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	ts, _, err := h.NotificationTemplateService.FindNotificationTemplates(ctx, *filter, *opts)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	debugResult(h.Logger, "notification templates retrieved", "notificationTemplates", ts)
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newNotificationTemplatesResponse(ts, filter, *opts)); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+func (h *NotificationTemplateHandler) handleGetNotificationTemplate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "notification template retrieve request", r)
+	id, err := decodeGetNotificationTemplateRequest(ctx, r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	t, err := h.NotificationTemplateService.FindNotificationTemplateByID(ctx, id)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	debugResult(h.Logger, "notification template retrieved", "notificationTemplate", t)
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newNotificationTemplateResponse(t)); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+func decodePostNotificationTemplateRequest(ctx context.Context, r *http.Request) (*influxdb.NotificationTemplate, error) {
+	t := &influxdb.NotificationTemplate{}
+	if err := json.NewDecoder(r.Body).Decode(t); err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  err.Error(),
+		}
+	}
+	if err := t.Valid(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// handlePostNotificationTemplate is the HTTP handler for the POST /api/v2/notificationTemplates route.
+func (h *NotificationTemplateHandler) handlePostNotificationTemplate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "notification template create request", r)
+	t, err := decodePostNotificationTemplateRequest(ctx, r)
+	if err != nil {
+		h.Logger.Debug("failed to decode request", zap.Error(err))
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	if err := h.NotificationTemplateService.CreateNotificationTemplate(ctx, t); err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	debugResult(h.Logger, "notification template created", "notificationTemplate", t)
+
+	if err := encodeResponse(ctx, w, http.StatusCreated, newNotificationTemplateResponse(t)); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+type patchNotificationTemplateRequest struct {
+	influxdb.ID
+	Update influxdb.NotificationTemplateUpdate
+}
+
+func decodePatchNotificationTemplateRequest(ctx context.Context, r *http.Request) (*patchNotificationTemplateRequest, error) {
+	req := &patchNotificationTemplateRequest{}
+	id, err := decodeGetNotificationTemplateRequest(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	req.ID = id
+
+	upd := &influxdb.NotificationTemplateUpdate{}
+	if err := json.NewDecoder(r.Body).Decode(upd); err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  err.Error(),
+		}
+	}
+	if err := upd.Valid(); err != nil {
+		return nil, err
+	}
+
+	req.Update = *upd
+	return req, nil
+}
+
+// handlePatchNotificationTemplate is the HTTP handler for the PATCH /api/v2/notificationTemplates/:id route.
+func (h *NotificationTemplateHandler) handlePatchNotificationTemplate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "notification template patch request", r)
+	req, err := decodePatchNotificationTemplateRequest(ctx, r)
+	if err != nil {
+		h.Logger.Debug("failed to decode request", zap.Error(err))
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	t, err := h.NotificationTemplateService.UpdateNotificationTemplate(ctx, req.ID, req.Update)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	debugResult(h.Logger, "notification template patch", "notificationTemplate", t)
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newNotificationTemplateResponse(t)); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+func (h *NotificationTemplateHandler) handleDeleteNotificationTemplate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "notification template delete request", r)
+	i, err := decodeGetNotificationTemplateRequest(ctx, r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	if err = h.NotificationTemplateService.DeleteNotificationTemplate(ctx, i); err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	h.Logger.Debug("notification template deleted", zap.Stringer("notificationTemplateID", i))
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// previewNotificationTemplateRequest is a message template rendered
+// with the notification templates of an org for an example status.
+type previewNotificationTemplateRequest struct {
+	OrgID    influxdb.ID          `json:"orgID"`
+	Template string               `json:"template"`
+	Status   *notification.Status `json:"status,omitempty"`
+}
+
+type previewNotificationTemplateResponse struct {
+	Message string `json:"message"`
+}
+
+func decodePreviewNotificationTemplateRequest(ctx context.Context, r *http.Request) (*previewNotificationTemplateRequest, error) {
+	req := &previewNotificationTemplateRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  err.Error(),
+		}
+	}
+	if !req.OrgID.Valid() {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "orgID is invalid",
+		}
+	}
+	return req, nil
+}
+
+// handlePreviewNotificationTemplate is the HTTP handler for the POST /api/v2/notificationTemplates/preview route.
+// The example status defaults to a critical status of a check named example.
+func (h *NotificationTemplateHandler) handlePreviewNotificationTemplate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "notification template preview request", r)
+	req, err := decodePreviewNotificationTemplateRequest(ctx, r)
+	if err != nil {
+		h.Logger.Debug("failed to decode request", zap.Error(err))
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	ts, _, err := h.NotificationTemplateService.FindNotificationTemplates(ctx, influxdb.NotificationTemplateFilter{OrgID: &req.OrgID})
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	partials := make(map[string]string, len(ts))
+	for _, t := range ts {
+		partials[t.Name] = t.Template
+	}
+
+	st := notification.Status{
+		CheckName: "example",
+		OrgID:     req.OrgID,
+		Level:     notification.Critical,
+	}
+	if req.Status != nil {
+		st = *req.Status
+	}
+
+	msg, err := notification.RenderMessage(req.Template, partials, st)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, previewNotificationTemplateResponse{Message: msg}); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
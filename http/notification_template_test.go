@@ -0,0 +1,149 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
+	influxTesting "github.com/influxdata/influxdb/testing"
+	"go.uber.org/zap"
+)
+
+// NewMockNotificationTemplateBackend returns a NotificationTemplateBackend with mock services.
+func NewMockNotificationTemplateBackend() *NotificationTemplateBackend {
+	return &NotificationTemplateBackend{
+		Logger:                      zap.NewNop().With(zap.String("handler", "notification_template")),
+		NotificationTemplateService: &mock.NotificationTemplateService{},
+	}
+}
+
+func Test_newNotificationTemplatesResponse(t *testing.T) {
+	res := newNotificationTemplatesResponse(
+		[]*influxdb.NotificationTemplate{
+			{
+				ID:       influxdb.ID(1),
+				OrgID:    influxdb.ID(2),
+				Name:     "footer",
+				Template: "-- sent by influxdb",
+			},
+		},
+		influxdb.NotificationTemplateFilter{
+			OrgID: influxTesting.IDPtr(influxdb.ID(2)),
+		},
+		influxdb.FindOptions{
+			Limit:  50,
+			Offset: 0,
+		},
+	)
+	got, err := json.Marshal(res)
+	if err != nil {
+		t.Fatalf("newNotificationTemplatesResponse() JSON marshal %v", err)
+	}
+	want := `{
+  "links": {
+    "self": "/api/v2/notificationTemplates?descending=false&limit=50&offset=0&orgID=0000000000000002"
+  },
+  "notificationTemplates": [
+    {
+      "id": "0000000000000001",
+      "orgID": "0000000000000002",
+      "name": "footer",
+      "template": "-- sent by influxdb",
+      "createdAt": "0001-01-01T00:00:00Z",
+      "updatedAt": "0001-01-01T00:00:00Z",
+      "links": {
+        "self": "/api/v2/notificationTemplates/0000000000000001",
+        "org": "/api/v2/orgs/0000000000000002"
+      }
+    }
+  ]
+}`
+	if eq, diff, _ := jsonEqual(string(got), want); !eq {
+		t.Errorf("newNotificationTemplatesResponse() = ***%s***", diff)
+	}
+}
+
+func TestNotificationTemplateHandler_handlePreviewNotificationTemplate(t *testing.T) {
+	svc := &mock.NotificationTemplateService{
+		FindNotificationTemplatesF: func(ctx context.Context, filter influxdb.NotificationTemplateFilter, opt ...influxdb.FindOptions) ([]*influxdb.NotificationTemplate, int, error) {
+			if filter.OrgID == nil || *filter.OrgID != influxdb.ID(2) {
+				t.Fatalf("unexpected filter %v", filter)
+			}
+			return []*influxdb.NotificationTemplate{
+				{
+					ID:       influxdb.ID(1),
+					OrgID:    influxdb.ID(2),
+					Name:     "footer",
+					Template: "-- see ${partial.runbook}",
+				},
+				{
+					ID:       influxdb.ID(3),
+					OrgID:    influxdb.ID(2),
+					Name:     "runbook",
+					Template: "https://wiki.example.com/${r._check_name}",
+				},
+			}, 2, nil
+		},
+	}
+
+	tests := []struct {
+		name       string
+		body       string
+		statusCode int
+		want       string
+	}{
+		{
+			name:       "renders partials with the example status",
+			body:       `{"orgID":"0000000000000002","template":"${r._check_name} is ${r._level} ${partial.footer}"}`,
+			statusCode: 200,
+			want:       `{"message":"example is CRIT -- see https://wiki.example.com/example"}`,
+		},
+		{
+			name:       "renders partials with a status",
+			body:       `{"orgID":"0000000000000002","template":"${r._check_name}: ${partial.runbook}","status":{"checkName":"cpu","level":"WARN"}}`,
+			statusCode: 200,
+			want:       `{"message":"cpu: https://wiki.example.com/cpu"}`,
+		},
+		{
+			name:       "missing partial",
+			body:       `{"orgID":"0000000000000002","template":"${partial.header}"}`,
+			statusCode: 400,
+			want:       `{"code":"invalid","message":"notification template \"header\" not found"}`,
+		},
+		{
+			name:       "missing org",
+			body:       `{"template":"${partial.footer}"}`,
+			statusCode: 400,
+			want:       `{"code":"invalid","message":"orgID is invalid"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewMockNotificationTemplateBackend()
+			b.HTTPErrorHandler = ErrorHandler(0)
+			b.NotificationTemplateService = svc
+			h := NewNotificationTemplateHandler(b)
+
+			r := httptest.NewRequest("POST", "http://any.url/api/v2/notificationTemplates/preview", bytes.NewReader([]byte(tt.body)))
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			res := w.Result()
+			body, _ := ioutil.ReadAll(res.Body)
+			if res.StatusCode != tt.statusCode {
+				t.Errorf("got status %d, want %d: %s", res.StatusCode, tt.statusCode, body)
+			}
+			if eq, diff, err := jsonEqual(string(body), tt.want); err != nil {
+				t.Errorf("error unmarshaling json %v", err)
+			} else if !eq {
+				t.Errorf("***%s***", diff)
+			}
+		})
+	}
+}
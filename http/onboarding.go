@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"fmt"
 	"net/http"
 
 	platform "github.com/influxdata/influxdb"
@@ -62,14 +61,14 @@ type isOnboardingResponse struct {
 // isOnboarding is the HTTP handler for the GET /api/v2/setup route.
 func (h *SetupHandler) isOnboarding(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.Logger.Debug("onboarding eligibility request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "onboarding eligibility request", r)
 
 	result, err := h.OnboardingService.IsOnboarding(ctx)
 	if err != nil {
 		h.HandleHTTPError(ctx, err, w)
 		return
 	}
-	h.Logger.Debug("onboarding eligibility check finished", zap.String("result", fmt.Sprint(result)))
+	debugResult(h.Logger, "onboarding eligibility check finished", "result", result)
 
 	if err := encodeResponse(ctx, w, http.StatusOK, isOnboardingResponse{result}); err != nil {
 		logEncodingError(h.Logger, r, err)
@@ -80,7 +79,7 @@ func (h *SetupHandler) isOnboarding(w http.ResponseWriter, r *http.Request) {
 // isOnboarding is the HTTP handler for the POST /api/v2/setup route.
 func (h *SetupHandler) handlePostSetup(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.Logger.Debug("onboarding setup request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "onboarding setup request", r)
 	req, err := decodePostSetupRequest(ctx, r)
 	if err != nil {
 		h.HandleHTTPError(ctx, err, w)
@@ -91,7 +90,7 @@ func (h *SetupHandler) handlePostSetup(w http.ResponseWriter, r *http.Request) {
 		h.HandleHTTPError(ctx, err, w)
 		return
 	}
-	h.Logger.Debug("onboarding setup completed", zap.String("results", fmt.Sprint(results)))
+	debugResult(h.Logger, "onboarding setup completed", "results", results)
 
 	if err := encodeResponse(ctx, w, http.StatusCreated, newOnboardingResponse(results)); err != nil {
 		logEncodingError(h.Logger, r, err)
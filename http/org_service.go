@@ -27,6 +27,11 @@ type OrgBackend struct {
 	SecretService                   influxdb.SecretService
 	LabelService                    influxdb.LabelService
 	UserService                     influxdb.UserService
+	AlertingDiagnosticsService      influxdb.AlertingDiagnosticsService
+	CheckCoverageService            influxdb.CheckCoverageService
+	CheckImportService              influxdb.CheckImportService
+	AlertingSettingsService         influxdb.AlertingSettingsService
+	AlertingPauseService            influxdb.AlertingPauseService
 }
 
 // NewOrgBackend is a datasource used by the org handler.
@@ -41,6 +46,11 @@ func NewOrgBackend(b *APIBackend) *OrgBackend {
 		SecretService:                   b.SecretService,
 		LabelService:                    b.LabelService,
 		UserService:                     b.UserService,
+		AlertingDiagnosticsService:      b.AlertingDiagnosticsService,
+		CheckCoverageService:            b.CheckCoverageService,
+		CheckImportService:              b.CheckImportService,
+		AlertingSettingsService:         b.AlertingSettingsService,
+		AlertingPauseService:            b.AlertingPauseService,
 	}
 }
 
@@ -56,6 +66,11 @@ type OrgHandler struct {
 	SecretService                   influxdb.SecretService
 	LabelService                    influxdb.LabelService
 	UserService                     influxdb.UserService
+	AlertingDiagnosticsService      influxdb.AlertingDiagnosticsService
+	CheckCoverageService            influxdb.CheckCoverageService
+	CheckImportService              influxdb.CheckImportService
+	AlertingSettingsService         influxdb.AlertingSettingsService
+	AlertingPauseService            influxdb.AlertingPauseService
 }
 
 const (
@@ -66,11 +81,21 @@ const (
 	organizationsIDMembersIDPath = "/api/v2/orgs/:id/members/:userID"
 	organizationsIDOwnersPath    = "/api/v2/orgs/:id/owners"
 	organizationsIDOwnersIDPath  = "/api/v2/orgs/:id/owners/:userID"
+	organizationsIDViewersPath   = "/api/v2/orgs/:id/viewers"
+	organizationsIDViewersIDPath = "/api/v2/orgs/:id/viewers/:userID"
 	organizationsIDSecretsPath   = "/api/v2/orgs/:id/secrets"
 	// TODO(desa): need a way to specify which secrets to delete. this should work for now
-	organizationsIDSecretsDeletePath = "/api/v2/orgs/:id/secrets/delete"
-	organizationsIDLabelsPath        = "/api/v2/orgs/:id/labels"
-	organizationsIDLabelsIDPath      = "/api/v2/orgs/:id/labels/:lid"
+	organizationsIDSecretsDeletePath    = "/api/v2/orgs/:id/secrets/delete"
+	organizationsIDLabelsPath           = "/api/v2/orgs/:id/labels"
+	organizationsIDLabelsIDPath         = "/api/v2/orgs/:id/labels/:lid"
+	organizationsIDAlertingOrphans      = "/api/v2/orgs/:id/alerting/orphans"
+	organizationsIDAlertingCoverage     = "/api/v2/orgs/:id/alerting/coverage"
+	organizationsIDAlertingDoctor       = "/api/v2/orgs/:id/alerting/doctor"
+	organizationsIDAlertingSettings     = "/api/v2/orgs/:id/alerting/settings"
+	organizationsIDAlertingSettingsLogs = "/api/v2/orgs/:id/alerting/settings/logs"
+	organizationsIDAlertingPause        = "/api/v2/orgs/:id/alerting/pause"
+	organizationsIDAlertingResume       = "/api/v2/orgs/:id/alerting/resume"
+	organizationsIDChecksImportPath     = "/api/v2/orgs/:id/checks/import"
 )
 
 // NewOrgHandler returns a new instance of OrgHandler.
@@ -86,6 +111,11 @@ func NewOrgHandler(b *OrgBackend) *OrgHandler {
 		SecretService:                   b.SecretService,
 		LabelService:                    b.LabelService,
 		UserService:                     b.UserService,
+		AlertingDiagnosticsService:      b.AlertingDiagnosticsService,
+		CheckCoverageService:            b.CheckCoverageService,
+		CheckImportService:              b.CheckImportService,
+		AlertingSettingsService:         b.AlertingSettingsService,
+		AlertingPauseService:            b.AlertingPauseService,
 	}
 
 	h.HandlerFunc("POST", organizationsPath, h.handlePostOrg)
@@ -119,6 +149,18 @@ func NewOrgHandler(b *OrgBackend) *OrgHandler {
 	h.HandlerFunc("GET", organizationsIDOwnersPath, newGetMembersHandler(ownerBackend))
 	h.HandlerFunc("DELETE", organizationsIDOwnersIDPath, newDeleteMemberHandler(ownerBackend))
 
+	viewerBackend := MemberBackend{
+		HTTPErrorHandler:           b.HTTPErrorHandler,
+		Logger:                     b.Logger.With(zap.String("handler", "member")),
+		ResourceType:               influxdb.OrgsResourceType,
+		UserType:                   influxdb.Viewer,
+		UserResourceMappingService: b.UserResourceMappingService,
+		UserService:                b.UserService,
+	}
+	h.HandlerFunc("POST", organizationsIDViewersPath, newPostMemberHandler(viewerBackend))
+	h.HandlerFunc("GET", organizationsIDViewersPath, newGetMembersHandler(viewerBackend))
+	h.HandlerFunc("DELETE", organizationsIDViewersIDPath, newDeleteMemberHandler(viewerBackend))
+
 	h.HandlerFunc("GET", organizationsIDSecretsPath, h.handleGetSecrets)
 	h.HandlerFunc("PATCH", organizationsIDSecretsPath, h.handlePatchSecrets)
 	// TODO(desa): need a way to specify which secrets to delete. this should work for now
@@ -134,6 +176,18 @@ func NewOrgHandler(b *OrgBackend) *OrgHandler {
 	h.HandlerFunc("POST", organizationsIDLabelsPath, newPostLabelHandler(labelBackend))
 	h.HandlerFunc("DELETE", organizationsIDLabelsIDPath, newDeleteLabelHandler(labelBackend))
 
+	h.HandlerFunc("GET", organizationsIDAlertingOrphans, h.handleGetOrphanedAlertingResources)
+	h.HandlerFunc("GET", organizationsIDAlertingCoverage, h.handleGetCheckCoverage)
+	h.HandlerFunc("GET", organizationsIDAlertingDoctor, h.handleGetAlertingDiagnosis)
+	h.HandlerFunc("GET", organizationsIDAlertingSettings, h.handleGetAlertingSettings)
+	h.HandlerFunc("PUT", organizationsIDAlertingSettings, h.handlePutAlertingSettings)
+	h.HandlerFunc("DELETE", organizationsIDAlertingSettings, h.handleDeleteAlertingSettings)
+	h.HandlerFunc("GET", organizationsIDAlertingSettingsLogs, h.handleGetAlertingSettingsLog)
+	h.HandlerFunc("GET", organizationsIDAlertingPause, h.handleGetAlertingPause)
+	h.HandlerFunc("POST", organizationsIDAlertingPause, h.handlePostAlertingPause)
+	h.HandlerFunc("POST", organizationsIDAlertingResume, h.handlePostAlertingResume)
+	h.HandlerFunc("POST", organizationsIDChecksImportPath, h.handlePostCheckImport)
+
 	return h
 }
 
@@ -203,7 +257,7 @@ func newSecretsResponse(orgID influxdb.ID, ks []string) *secretsResponse {
 // handlePostOrg is the HTTP handler for the POST /api/v2/orgs route.
 func (h *OrgHandler) handlePostOrg(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.Logger.Debug("org create request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "org create request", r)
 	req, err := decodePostOrgRequest(ctx, r)
 	if err != nil {
 		h.HandleHTTPError(ctx, err, w)
@@ -214,7 +268,7 @@ func (h *OrgHandler) handlePostOrg(w http.ResponseWriter, r *http.Request) {
 		h.HandleHTTPError(ctx, err, w)
 		return
 	}
-	h.Logger.Debug("org created", zap.String("org", fmt.Sprint(req.Org)))
+	debugResult(h.Logger, "org created", "org", req.Org)
 
 	if err := encodeResponse(ctx, w, http.StatusCreated, newOrgResponse(req.Org)); err != nil {
 		logEncodingError(h.Logger, r, err)
@@ -240,7 +294,7 @@ func decodePostOrgRequest(ctx context.Context, r *http.Request) (*postOrgRequest
 // handleGetOrg is the HTTP handler for the GET /api/v2/orgs/:id route.
 func (h *OrgHandler) handleGetOrg(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.Logger.Debug("org retrieve request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "org retrieve request", r)
 
 	req, err := decodeGetOrgRequest(ctx, r)
 	if err != nil {
@@ -253,7 +307,7 @@ func (h *OrgHandler) handleGetOrg(w http.ResponseWriter, r *http.Request) {
 		h.HandleHTTPError(ctx, err, w)
 		return
 	}
-	h.Logger.Debug("org retrieved", zap.String("org", fmt.Sprint(b)))
+	debugResult(h.Logger, "org retrieved", "org", b)
 
 	if err := encodeResponse(ctx, w, http.StatusOK, newOrgResponse(b)); err != nil {
 		logEncodingError(h.Logger, r, err)
@@ -290,7 +344,7 @@ func decodeGetOrgRequest(ctx context.Context, r *http.Request) (*getOrgRequest,
 // handleGetOrgs is the HTTP handler for the GET /api/v2/orgs route.
 func (h *OrgHandler) handleGetOrgs(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.Logger.Debug("orgs retrieve request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "orgs retrieve request", r)
 
 	req, err := decodeGetOrgsRequest(ctx, r)
 	if err != nil {
@@ -303,7 +357,7 @@ func (h *OrgHandler) handleGetOrgs(w http.ResponseWriter, r *http.Request) {
 		h.HandleHTTPError(ctx, err, w)
 		return
 	}
-	h.Logger.Debug("orgs retrieved", zap.String("org", fmt.Sprint(orgs)))
+	debugResult(h.Logger, "orgs retrieved", "org", orgs)
 
 	if err := encodeResponse(ctx, w, http.StatusOK, newOrgsResponse(orgs)); err != nil {
 		logEncodingError(h.Logger, r, err)
@@ -337,7 +391,7 @@ func decodeGetOrgsRequest(ctx context.Context, r *http.Request) (*getOrgsRequest
 // handleDeleteOrganization is the HTTP handler for the DELETE /api/v2/orgs/:id route.
 func (h *OrgHandler) handleDeleteOrg(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.Logger.Debug("org delete request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "org delete request", r)
 
 	req, err := decodeDeleteOrganizationRequest(ctx, r)
 	if err != nil {
@@ -349,7 +403,7 @@ func (h *OrgHandler) handleDeleteOrg(w http.ResponseWriter, r *http.Request) {
 		h.HandleHTTPError(ctx, err, w)
 		return
 	}
-	h.Logger.Debug("org deleted", zap.String("orgID", fmt.Sprint(req.OrganizationID)))
+	h.Logger.Debug("org deleted", zap.Stringer("orgID", req.OrganizationID))
 
 	w.WriteHeader(http.StatusNoContent)
 }
@@ -386,7 +440,7 @@ func decodeDeleteOrganizationRequest(ctx context.Context, r *http.Request) (*del
 // handlePatchOrg is the HTTP handler for the PATH /api/v2/orgs route.
 func (h *OrgHandler) handlePatchOrg(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.Logger.Debug("org update request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "org update request", r)
 
 	req, err := decodePatchOrgRequest(ctx, r)
 	if err != nil {
@@ -399,7 +453,7 @@ func (h *OrgHandler) handlePatchOrg(w http.ResponseWriter, r *http.Request) {
 		h.HandleHTTPError(ctx, err, w)
 		return
 	}
-	h.Logger.Debug("org updated", zap.String("org", fmt.Sprint(o)))
+	debugResult(h.Logger, "org updated", "org", o)
 
 	if err := encodeResponse(ctx, w, http.StatusOK, newOrgResponse(o)); err != nil {
 		logEncodingError(h.Logger, r, err)
@@ -830,7 +884,7 @@ func organizationIDPath(id influxdb.ID) string {
 // hanldeGetOrganizationLog retrieves a organization log by the organizations ID.
 func (h *OrgHandler) handleGetOrgLog(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.Logger.Debug("org log retrieve request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "org log retrieve request", r)
 
 	req, err := decodeGetOrganizationLogRequest(ctx, r)
 	if err != nil {
@@ -843,7 +897,7 @@ func (h *OrgHandler) handleGetOrgLog(w http.ResponseWriter, r *http.Request) {
 		h.HandleHTTPError(ctx, err, w)
 		return
 	}
-	h.Logger.Debug("org logs retrieved", zap.String("log", fmt.Sprint(log)))
+	debugResult(h.Logger, "org logs retrieved", "log", log)
 
 	if err := encodeResponse(ctx, w, http.StatusOK, newOrganizationLogResponse(req.OrganizationID, log)); err != nil {
 		logEncodingError(h.Logger, r, err)
@@ -12,14 +12,8 @@ type PlatformHandler struct {
 	AssetHandler *AssetHandler
 	DocsHandler  http.HandlerFunc
 	APIHandler   http.Handler
-}
-
-func setCORSResponseHeaders(w http.ResponseWriter, r *http.Request) {
-	if origin := r.Header.Get("Origin"); origin != "" {
-		w.Header().Set("Access-Control-Allow-Origin", origin)
-		w.Header().Set("Access-Control-Allow-Methods", "POST, GET, OPTIONS, PUT, DELETE")
-		w.Header().Set("Access-Control-Allow-Headers", "Accept, Content-Type, Content-Length, Accept-Encoding, Authorization")
-	}
+	// AlertingCORS is the CORS config of the routes of checks and notifications.
+	AlertingCORS CORSConfig
 }
 
 // NewPlatformHandler returns a platform handler that serves the API and associated assets.
@@ -44,12 +38,13 @@ func NewPlatformHandler(b *APIBackend) *PlatformHandler {
 		AssetHandler: assetHandler,
 		DocsHandler:  Redoc("/api/v2/swagger.json"),
 		APIHandler:   h,
+		AlertingCORS: b.AlertingCORS,
 	}
 }
 
 // ServeHTTP delegates a request to the appropriate subhandler.
 func (h *PlatformHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	setCORSResponseHeaders(w, r)
+	setCORSResponseHeaders(w, r, h.AlertingCORS)
 	if r.Method == "OPTIONS" {
 		return
 	}
@@ -0,0 +1,82 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+	"go.uber.org/zap"
+)
+
+// redacted replaces the sensitive values in the logs.
+const redacted = "REDACTED"
+
+// isSensitive returns whether a query parameter or header may hold a
+// credential, such as a token or a password.
+func isSensitive(key string) bool {
+	key = strings.ToLower(key)
+	switch key {
+	case "authorization", "cookie", "set-cookie", "p":
+		return true
+	}
+	return strings.Contains(key, "token") || strings.Contains(key, "password") || strings.Contains(key, "secret")
+}
+
+// redactQuery returns a copy of a query with its sensitive values redacted.
+func redactQuery(q url.Values) url.Values {
+	cp := make(url.Values, len(q))
+	for k, vs := range q {
+		if isSensitive(k) {
+			cp[k] = []string{redacted}
+			continue
+		}
+		cp[k] = vs
+	}
+	return cp
+}
+
+// debugRequest logs a request at the debug level, with the fields of
+// requestFields. The fields are only built when the debug level is enabled.
+func debugRequest(logger *zap.Logger, msg string, r *http.Request) {
+	if ce := logger.Check(zap.DebugLevel, msg); ce != nil {
+		ce.Write(requestFields(r)...)
+	}
+}
+
+// requestFields returns the structured fields of a request for the logs:
+// its method, path, redacted query, organization, the parameters of its
+// route, such as the id of its resource, and the size of its body. The
+// headers, which hold the token of the request, aren't logged.
+func requestFields(r *http.Request) []zap.Field {
+	fields := []zap.Field{
+		zap.String("method", r.Method),
+		zap.String("path", r.URL.Path),
+	}
+	q := r.URL.Query()
+	if len(q) > 0 {
+		fields = append(fields, zap.String("query", redactQuery(q).Encode()))
+	}
+	if org := q.Get("orgID"); org != "" {
+		fields = append(fields, zap.String("orgID", org))
+	} else if org := q.Get("org"); org != "" {
+		fields = append(fields, zap.String("org", org))
+	}
+	for _, p := range httprouter.ParamsFromContext(r.Context()) {
+		fields = append(fields, zap.String(p.Key, p.Value))
+	}
+	if r.ContentLength >= 0 {
+		fields = append(fields, zap.Int64("bodySize", r.ContentLength))
+	}
+	return fields
+}
+
+// debugResult logs the result of a request at the debug level, as the
+// value of key followed by fields. The value is only formatted when the
+// debug level is enabled.
+func debugResult(logger *zap.Logger, msg, key string, v interface{}, fields ...zap.Field) {
+	if ce := logger.Check(zap.DebugLevel, msg); ce != nil {
+		ce.Write(append([]zap.Field{zap.String(key, fmt.Sprint(v))}, fields...)...)
+	}
+}
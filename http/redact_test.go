@@ -0,0 +1,83 @@
+package http
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestDebugRequest(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+	logger := zap.New(core)
+
+	r := httptest.NewRequest("POST", "/api/v2/checks/0000000000000001/ping?orgID=0000000000000002&token=secret", strings.NewReader("{}"))
+	r.Header.Set("Authorization", "Token secret")
+	r = r.WithContext(context.WithValue(r.Context(), httprouter.ParamsKey, httprouter.Params{{Key: "id", Value: "0000000000000001"}}))
+	debugRequest(logger, "check ping request", r)
+
+	entries := logs.AllUntimed()
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 log entry, got %d", len(entries))
+	}
+	got := entries[0].ContextMap()
+	want := map[string]interface{}{
+		"method":   "POST",
+		"path":     "/api/v2/checks/0000000000000001/ping",
+		"query":    "orgID=0000000000000002&token=REDACTED",
+		"orgID":    "0000000000000002",
+		"id":       "0000000000000001",
+		"bodySize": int64(2),
+	}
+	if len(got) != len(want) {
+		t.Errorf("unexpected fields %v", got)
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("unexpected field %s %v, want %v", k, got[k], v)
+		}
+	}
+
+	// the fields aren't built above the debug level.
+	core, logs = observer.New(zapcore.InfoLevel)
+	debugRequest(zap.New(core), "check ping request", r)
+	if logs.Len() != 0 {
+		t.Errorf("expected no log entry at the info level")
+	}
+}
+
+// countingStringer counts how many times it is formatted.
+type countingStringer struct{ n *int }
+
+func (s countingStringer) String() string {
+	*s.n++
+	return "check"
+}
+
+func TestDebugResult(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+	var n int
+	debugResult(zap.New(core), "check retrieved", "check", countingStringer{&n}, zap.Int("total", 1))
+
+	entries := logs.AllUntimed()
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 log entry, got %d", len(entries))
+	}
+	got := entries[0].ContextMap()
+	if got["check"] != "check" || got["total"] != int64(1) || len(got) != 2 {
+		t.Errorf("unexpected fields %v", got)
+	}
+
+	// the value isn't formatted above the debug level.
+	core, logs = observer.New(zapcore.InfoLevel)
+	n = 0
+	debugResult(zap.New(core), "check retrieved", "check", countingStringer{&n})
+	if logs.Len() != 0 || n != 0 {
+		t.Errorf("expected the check not to be formatted at the info level, formatted %d times", n)
+	}
+}
@@ -0,0 +1,269 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DebugAlertingRequestsPath serves the requests recorded by a RequestRecorder.
+	DebugAlertingRequestsPath = "/debug/alerting/requests"
+	// maxRecordedBody is the size of the largest body recorded.
+	maxRecordedBody = 64 * 1024
+)
+
+// alertingPrefixes are the prefixes of the paths of the alerting APIs.
+var alertingPrefixes = []string{
+	checksPath,
+	notificationEndpointsPath,
+	notificationRulesPath,
+	notificationTemplatesPath,
+	monitoringTemplatesPath,
+	"/api/v2/statuses",
+}
+
+// isAlertingPath returns whether a path is a route of the alerting APIs.
+func isAlertingPath(p string) bool {
+	for _, prefix := range alertingPrefixes {
+		if p == prefix || strings.HasPrefix(p, prefix+"/") {
+			return true
+		}
+	}
+	return strings.Contains(p, "/alerting/") || strings.HasSuffix(p, "/notificationPreferences")
+}
+
+// RecordedRequest is a request to the alerting APIs and its response, with
+// their credentials redacted. A body is only recorded if it is JSON and
+// smaller than 64KB, its sensitive values redacted, it is described otherwise.
+type RecordedRequest struct {
+	Time            time.Time       `json:"time"`
+	Duration        time.Duration   `json:"durationNs"`
+	Method          string          `json:"method"`
+	Path            string          `json:"path"`
+	Query           string          `json:"query,omitempty"`
+	RequestHeaders  http.Header     `json:"requestHeaders"`
+	RequestBody     json.RawMessage `json:"requestBody,omitempty"`
+	Status          int             `json:"status"`
+	ResponseHeaders http.Header     `json:"responseHeaders"`
+	ResponseBody    json.RawMessage `json:"responseBody,omitempty"`
+}
+
+// RequestRecorder records the latest requests to the alerting APIs and
+// their responses in a ring buffer, for the support bundles of the users
+// reporting a misbehavior of the APIs.
+type RequestRecorder struct {
+	mu       sync.Mutex
+	requests []RecordedRequest
+	next     int
+	full     bool
+}
+
+// NewRequestRecorder returns a recorder of the latest size requests.
+func NewRequestRecorder(size int) *RequestRecorder {
+	return &RequestRecorder{
+		requests: make([]RecordedRequest, size),
+	}
+}
+
+// Record returns a handler recording the requests to the alerting APIs
+// served by next.
+func (rr *RequestRecorder) Record(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !isAlertingPath(r.URL.Path) || len(rr.requests) == 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		rec := RecordedRequest{
+			Time:           time.Now().UTC(),
+			Method:         r.Method,
+			Path:           r.URL.Path,
+			Query:          redactQuery(r.URL.Query()).Encode(),
+			RequestHeaders: redactHeader(r.Header),
+		}
+		if r.Body != nil {
+			head, err := ioutil.ReadAll(io.LimitReader(r.Body, maxRecordedBody+1))
+			if err != nil {
+				rec.RequestBody = describeBody(fmt.Sprintf("failed to read body: %v", err))
+			} else {
+				rec.RequestBody = recordBody(head, len(head) > maxRecordedBody, r.Header.Get("Content-Type"))
+			}
+			r.Body = struct {
+				io.Reader
+				io.Closer
+			}{io.MultiReader(bytes.NewReader(head), r.Body), r.Body}
+		}
+
+		rw := &recordingResponseWriter{ResponseWriter: w}
+		next.ServeHTTP(rw, r)
+
+		rec.Duration = time.Since(rec.Time)
+		rec.Status = rw.code()
+		rec.ResponseHeaders = redactHeader(w.Header())
+		rec.ResponseBody = recordBody(rw.body, rw.truncated, w.Header().Get("Content-Type"))
+		rr.add(rec)
+	})
+}
+
+func (rr *RequestRecorder) add(rec RecordedRequest) {
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+	rr.requests[rr.next] = rec
+	rr.next = (rr.next + 1) % len(rr.requests)
+	rr.full = rr.full || rr.next == 0
+}
+
+// Requests returns the recorded requests, the oldest first.
+func (rr *RequestRecorder) Requests() []RecordedRequest {
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+	if !rr.full {
+		return append([]RecordedRequest{}, rr.requests[:rr.next]...)
+	}
+	return append(append([]RecordedRequest{}, rr.requests[rr.next:]...), rr.requests[:rr.next]...)
+}
+
+// DebugAlertingRequests serves the requests recorded by rr at
+// DebugAlertingRequestsPath, and the other requests with next.
+func DebugAlertingRequests(next http.Handler, rr *RequestRecorder) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != DebugAlertingRequestsPath {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if r.Method != "GET" {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		res := struct {
+			Requests []RecordedRequest `json:"requests"`
+		}{Requests: rr.Requests()}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(res)
+	})
+}
+
+// recordingResponseWriter captures the status and the head of the body of a response.
+type recordingResponseWriter struct {
+	http.ResponseWriter
+	statusCode int
+	body       []byte
+	truncated  bool
+}
+
+func (w *recordingResponseWriter) Write(b []byte) (int, error) {
+	if room := maxRecordedBody - len(w.body); room > 0 {
+		if len(b) > room {
+			w.body = append(w.body, b[:room]...)
+			w.truncated = true
+		} else {
+			w.body = append(w.body, b...)
+		}
+	} else if len(b) > 0 {
+		w.truncated = true
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *recordingResponseWriter) WriteHeader(statusCode int) {
+	w.statusCode = statusCode
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+// Flush sends the buffered response to the client, for the streaming routes
+// such as the watch of the checks.
+func (w *recordingResponseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (w *recordingResponseWriter) code() int {
+	if w.statusCode == 0 {
+		return http.StatusOK
+	}
+	return w.statusCode
+}
+
+// redactHeader returns a copy of a header with its sensitive values redacted.
+func redactHeader(h http.Header) http.Header {
+	cp := make(http.Header, len(h))
+	for k, vs := range h {
+		if isSensitive(k) {
+			cp[k] = []string{redacted}
+			continue
+		}
+		cp[k] = vs
+	}
+	return cp
+}
+
+// recordBody returns the body to record: the body redacted if it is JSON,
+// and a description of the body otherwise. A body truncated to
+// maxRecordedBody is only described.
+func recordBody(b []byte, truncated bool, contentType string) json.RawMessage {
+	if len(b) == 0 {
+		return nil
+	}
+	if truncated {
+		return describeBody(fmt.Sprintf("body larger than %d bytes not recorded", maxRecordedBody))
+	}
+	var v interface{}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return describeBody(fmt.Sprintf("%d bytes of %s not recorded", len(b), contentType))
+	}
+	octets, err := json.Marshal(redactJSON(v))
+	if err != nil {
+		return describeBody(fmt.Sprintf("failed to record body: %v", err))
+	}
+	return octets
+}
+
+func describeBody(s string) json.RawMessage {
+	octets, _ := json.Marshal(s)
+	return octets
+}
+
+// redactJSON redacts the values of the sensitive keys of a decoded JSON
+// value, such as the urls, keys and tokens of the notification endpoints.
+func redactJSON(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, e := range v {
+			if isSensitiveBodyKey(k) {
+				v[k] = redacted
+				continue
+			}
+			v[k] = redactJSON(e)
+		}
+	case []interface{}:
+		for i, e := range v {
+			v[i] = redactJSON(e)
+		}
+	}
+	return v
+}
+
+// isSensitiveBodyKey returns whether the value of a key of a JSON body may
+// hold a credential. The url of an endpoint may embed one, such as the url
+// of a Slack webhook.
+func isSensitiveBodyKey(k string) bool {
+	lk := strings.ToLower(k)
+	switch {
+	case isSensitive(k), lk == "url", lk == "headers", lk == "clientcert", lk == "username":
+		return true
+	case lk != "key" && strings.HasSuffix(lk, "key"):
+		return true
+	}
+	return false
+}
@@ -0,0 +1,63 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRequestRecorder(t *testing.T) {
+	rr := NewRequestRecorder(2)
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("expected the handler to read the whole body: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id": "0000000000000001", "name": "slack", "url": "https://hooks.slack.com/services/secret"}`))
+	})
+	h := DebugAlertingRequests(rr.Record(next), rr)
+
+	for _, p := range []string{
+		"/api/v2/notificationEndpoints",
+		"/api/v2/buckets",
+		"/api/v2/notificationEndpoints?token=secret",
+		"/api/v2/checks",
+	} {
+		r := httptest.NewRequest("POST", p, strings.NewReader(`{"name": "slack", "url": "https://hooks.slack.com/services/secret", "tags": [{"key": "env", "value": "prod"}]}`))
+		r.Header.Set("Authorization", "Token secret")
+		h.ServeHTTP(httptest.NewRecorder(), r)
+	}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", DebugAlertingRequestsPath, nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	if strings.Contains(w.Body.String(), "secret") {
+		t.Errorf("expected the credentials to be redacted, got %s", w.Body.String())
+	}
+	var got struct {
+		Requests []RecordedRequest `json:"requests"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	// the buckets aren't an alerting API, and only the latest two requests are kept.
+	if len(got.Requests) != 2 || got.Requests[0].Query != "token=REDACTED" || got.Requests[1].Path != "/api/v2/checks" {
+		t.Fatalf("unexpected recorded requests %+v", got.Requests)
+	}
+	rec := got.Requests[1]
+	if rec.Method != "POST" || rec.Status != http.StatusCreated || rec.RequestHeaders.Get("Authorization") != redacted {
+		t.Errorf("unexpected recorded request %+v", rec)
+	}
+	if want := `{"name":"slack","tags":[{"key":"env","value":"prod"}],"url":"REDACTED"}`; string(rec.RequestBody) != want {
+		t.Errorf("unexpected request body %s, want %s", rec.RequestBody, want)
+	}
+	if want := `{"id":"0000000000000001","name":"slack","url":"REDACTED"}`; string(rec.ResponseBody) != want {
+		t.Errorf("unexpected response body %s, want %s", rec.ResponseBody, want)
+	}
+}
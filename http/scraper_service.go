@@ -125,7 +125,7 @@ func NewScraperHandler(b *ScraperBackend) *ScraperHandler {
 // handlePostScraperTarget is HTTP handler for the POST /api/v2/scrapers route.
 func (h *ScraperHandler) handlePostScraperTarget(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.Logger.Debug("scraper create request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "scraper create request", r)
 
 	req, err := decodeScraperTargetAddRequest(ctx, r)
 	if err != nil {
@@ -143,7 +143,7 @@ func (h *ScraperHandler) handlePostScraperTarget(w http.ResponseWriter, r *http.
 		h.HandleHTTPError(ctx, err, w)
 		return
 	}
-	h.Logger.Debug("scraper created", zap.String("scraper", fmt.Sprint(req)))
+	debugResult(h.Logger, "scraper created", "scraper", req)
 
 	resp, err := h.newTargetResponse(ctx, *req)
 	if err != nil {
@@ -159,7 +159,7 @@ func (h *ScraperHandler) handlePostScraperTarget(w http.ResponseWriter, r *http.
 // handleDeleteScraperTarget is the HTTP handler for the DELETE /api/v2/scrapers/:id route.
 func (h *ScraperHandler) handleDeleteScraperTarget(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.Logger.Debug("scraper delete request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "scraper delete request", r)
 
 	id, err := decodeScraperTargetIDRequest(ctx, r)
 	if err != nil {
@@ -171,7 +171,7 @@ func (h *ScraperHandler) handleDeleteScraperTarget(w http.ResponseWriter, r *htt
 		h.HandleHTTPError(ctx, err, w)
 		return
 	}
-	h.Logger.Debug("scraper deleted", zap.String("scraperTargetID", fmt.Sprint(id)))
+	h.Logger.Debug("scraper deleted", zap.Stringer("scraperTargetID", id))
 
 	w.WriteHeader(http.StatusNoContent)
 }
@@ -179,7 +179,7 @@ func (h *ScraperHandler) handleDeleteScraperTarget(w http.ResponseWriter, r *htt
 // handlePatchScraperTarget is the HTTP handler for the PATCH /api/v2/scrapers/:id route.
 func (h *ScraperHandler) handlePatchScraperTarget(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.Logger.Debug("scraper update request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "scraper update request", r)
 
 	update, err := decodeScraperTargetUpdateRequest(ctx, r)
 	if err != nil {
@@ -198,7 +198,7 @@ func (h *ScraperHandler) handlePatchScraperTarget(w http.ResponseWriter, r *http
 		h.HandleHTTPError(ctx, err, w)
 		return
 	}
-	h.Logger.Debug("scraper updated", zap.String("scraper", fmt.Sprint(target)))
+	debugResult(h.Logger, "scraper updated", "scraper", target)
 
 	resp, err := h.newTargetResponse(ctx, *target)
 	if err != nil {
@@ -214,7 +214,7 @@ func (h *ScraperHandler) handlePatchScraperTarget(w http.ResponseWriter, r *http
 
 func (h *ScraperHandler) handleGetScraperTarget(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.Logger.Debug("scraper retrieve request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "scraper retrieve request", r)
 
 	id, err := decodeScraperTargetIDRequest(ctx, r)
 	if err != nil {
@@ -226,7 +226,7 @@ func (h *ScraperHandler) handleGetScraperTarget(w http.ResponseWriter, r *http.R
 		h.HandleHTTPError(ctx, err, w)
 		return
 	}
-	h.Logger.Debug("scraper retrieved", zap.String("scraper", fmt.Sprint(target)))
+	debugResult(h.Logger, "scraper retrieved", "scraper", target)
 
 	resp, err := h.newTargetResponse(ctx, *target)
 	if err != nil {
@@ -278,7 +278,7 @@ func decodeScraperTargetsRequest(ctx context.Context, r *http.Request) (*getScra
 // handleGetScraperTargets is the HTTP handler for the GET /api/v2/scrapers route.
 func (h *ScraperHandler) handleGetScraperTargets(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.Logger.Debug("scrapers retrieve request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "scrapers retrieve request", r)
 
 	req, err := decodeScraperTargetsRequest(ctx, r)
 	if err != nil {
@@ -290,7 +290,7 @@ func (h *ScraperHandler) handleGetScraperTargets(w http.ResponseWriter, r *http.
 		h.HandleHTTPError(ctx, err, w)
 		return
 	}
-	h.Logger.Debug("scrapers retrieved", zap.String("scrapers", fmt.Sprint(targets)))
+	debugResult(h.Logger, "scrapers retrieved", "scrapers", targets)
 
 	resp, err := h.newListTargetsResponse(ctx, targets)
 	if err != nil {
@@ -0,0 +1,253 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/influxdata/influxdb"
+	"github.com/julienschmidt/httprouter"
+	"go.uber.org/zap"
+)
+
+// SilenceBackend is all services and associated parameters required to construct
+// the SilenceHandler.
+type SilenceBackend struct {
+	influxdb.HTTPErrorHandler
+	Logger *zap.Logger
+
+	SilenceService influxdb.SilenceService
+}
+
+// NewSilenceBackend returns a new instance of SilenceBackend.
+func NewSilenceBackend(b *APIBackend) *SilenceBackend {
+	return &SilenceBackend{
+		HTTPErrorHandler: b.HTTPErrorHandler,
+		Logger:           b.Logger.With(zap.String("handler", "silence")),
+
+		SilenceService: b.SilenceService,
+	}
+}
+
+// SilenceHandler is the handler for the silence service
+type SilenceHandler struct {
+	*httprouter.Router
+	influxdb.HTTPErrorHandler
+	Logger *zap.Logger
+
+	SilenceService influxdb.SilenceService
+}
+
+const (
+	silencesPath   = "/api/v2/silences"
+	silencesIDPath = "/api/v2/silences/:id"
+)
+
+// NewSilenceHandler returns a new instance of SilenceHandler.
+func NewSilenceHandler(b *SilenceBackend) *SilenceHandler {
+	h := &SilenceHandler{
+		Router:           NewRouter(b.HTTPErrorHandler),
+		HTTPErrorHandler: b.HTTPErrorHandler,
+		Logger:           b.Logger,
+
+		SilenceService: b.SilenceService,
+	}
+
+	h.HandlerFunc("POST", silencesPath, h.handlePostSilence)
+	h.HandlerFunc("GET", silencesPath, h.handleGetSilences)
+	h.HandlerFunc("GET", silencesIDPath, h.handleGetSilence)
+	h.HandlerFunc("DELETE", silencesIDPath, h.handleDeleteSilence)
+	return h
+}
+
+type silenceLinks struct {
+	Self  string `json:"self"`
+	Org   string `json:"org"`
+	Label string `json:"label"`
+}
+
+type silenceResponse struct {
+	*influxdb.Silence
+	Links silenceLinks `json:"links"`
+}
+
+func newSilenceResponse(s *influxdb.Silence) *silenceResponse {
+	return &silenceResponse{
+		Silence: s,
+		Links: silenceLinks{
+			Self:  fmt.Sprintf("/api/v2/silences/%s", s.ID),
+			Org:   fmt.Sprintf("/api/v2/orgs/%s", s.OrgID),
+			Label: fmt.Sprintf("/api/v2/labels/%s", s.LabelID),
+		},
+	}
+}
+
+type silencesResponse struct {
+	Silences []*silenceResponse    `json:"silences"`
+	Links    *influxdb.PagingLinks `json:"links"`
+}
+
+func newSilencesResponse(sls []*influxdb.Silence, f influxdb.PagingFilter, opts influxdb.FindOptions) *silencesResponse {
+	resp := &silencesResponse{
+		Silences: make([]*silenceResponse, len(sls)),
+		Links:    newPagingLinks(silencesPath, opts, f, len(sls)),
+	}
+	for i, s := range sls {
+		resp.Silences[i] = newSilenceResponse(s)
+	}
+	return resp
+}
+
+func decodeGetSilenceRequest(ctx context.Context, r *http.Request) (i influxdb.ID, err error) {
+	params := httprouter.ParamsFromContext(ctx)
+	id := params.ByName("id")
+	if id == "" {
+		return i, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "url missing id",
+		}
+	}
+
+	if err := i.DecodeFromString(id); err != nil {
+		return i, err
+	}
+	return i, nil
+}
+
+func decodeSilenceFilter(ctx context.Context, r *http.Request) (*influxdb.SilenceFilter, *influxdb.FindOptions, error) {
+	f := &influxdb.SilenceFilter{}
+
+	opts, err := decodeFindOptions(ctx, r)
+	if err != nil {
+		return f, nil, err
+	}
+
+	q := r.URL.Query()
+	orgIDStr := q.Get("orgID")
+	if orgIDStr == "" {
+		return f, opts, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "orgID is required",
+		}
+	}
+	orgID, err := influxdb.IDFromString(orgIDStr)
+	if err != nil {
+		return f, opts, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "orgID is invalid",
+			Err:  err,
+		}
+	}
+	f.OrgID = orgID
+	if labelIDStr := q.Get("labelID"); labelIDStr != "" {
+		labelID, err := influxdb.IDFromString(labelIDStr)
+		if err != nil {
+			return f, opts, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "labelID is invalid",
+				Err:  err,
+			}
+		}
+		f.LabelID = labelID
+	}
+	return f, opts, nil
+}
+
+func (h *SilenceHandler) handleGetSilences(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "silences retrieve request", r)
+	filter, opts, err := decodeSilenceFilter(ctx, r)
+	if err != nil {
+		h.Logger.Debug("failed to decode request", zap.Error(err))
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	sls, _, err := h.SilenceService.FindSilences(ctx, *filter, *opts)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	debugResult(h.Logger, "silences retrieved", "silences", sls)
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newSilencesResponse(sls, filter, *opts)); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+func (h *SilenceHandler) handleGetSilence(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "silence retrieve request", r)
+	id, err := decodeGetSilenceRequest(ctx, r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	s, err := h.SilenceService.FindSilenceByID(ctx, id)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	debugResult(h.Logger, "silence retrieved", "silence", s)
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newSilenceResponse(s)); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+func decodePostSilenceRequest(ctx context.Context, r *http.Request) (*influxdb.Silence, error) {
+	s := &influxdb.Silence{}
+	if err := json.NewDecoder(r.Body).Decode(s); err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  err.Error(),
+		}
+	}
+	if err := s.Valid(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// handlePostSilence is the HTTP handler for the POST /api/v2/silences route.
+func (h *SilenceHandler) handlePostSilence(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "silence create request", r)
+	s, err := decodePostSilenceRequest(ctx, r)
+	if err != nil {
+		h.Logger.Debug("failed to decode request", zap.Error(err))
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	if err := h.SilenceService.CreateSilence(ctx, s); err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	debugResult(h.Logger, "silence created", "silence", s)
+
+	if err := encodeResponse(ctx, w, http.StatusCreated, newSilenceResponse(s)); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+func (h *SilenceHandler) handleDeleteSilence(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "silence delete request", r)
+	i, err := decodeGetSilenceRequest(ctx, r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	if err = h.SilenceService.DeleteSilence(ctx, i); err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	h.Logger.Debug("silence deleted", zap.Stringer("silenceID", i))
+
+	w.WriteHeader(http.StatusNoContent)
+}
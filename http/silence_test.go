@@ -0,0 +1,100 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
+	influxTesting "github.com/influxdata/influxdb/testing"
+	"go.uber.org/zap"
+)
+
+func Test_newSilencesResponse(t *testing.T) {
+	res := newSilencesResponse(
+		[]*influxdb.Silence{
+			{
+				ID:       influxdb.ID(1),
+				OrgID:    influxdb.ID(2),
+				LabelID:  influxdb.ID(3),
+				Comment:  "payments maintenance",
+				StartsAt: time.Date(2019, 10, 1, 12, 0, 0, 0, time.UTC),
+				EndsAt:   time.Date(2019, 10, 1, 14, 0, 0, 0, time.UTC),
+			},
+		},
+		influxdb.SilenceFilter{
+			OrgID: influxTesting.IDPtr(influxdb.ID(2)),
+		},
+		influxdb.FindOptions{
+			Limit:  50,
+			Offset: 0,
+		},
+	)
+	got, err := json.Marshal(res)
+	if err != nil {
+		t.Fatalf("newSilencesResponse() JSON marshal %v", err)
+	}
+	want := `{
+  "links": {
+    "self": "/api/v2/silences?descending=false&limit=50&offset=0&orgID=0000000000000002"
+  },
+  "silences": [
+    {
+      "id": "0000000000000001",
+      "orgID": "0000000000000002",
+      "labelID": "0000000000000003",
+      "comment": "payments maintenance",
+      "startsAt": "2019-10-01T12:00:00Z",
+      "endsAt": "2019-10-01T14:00:00Z",
+      "createdAt": "0001-01-01T00:00:00Z",
+      "updatedAt": "0001-01-01T00:00:00Z",
+      "links": {
+        "self": "/api/v2/silences/0000000000000001",
+        "org": "/api/v2/orgs/0000000000000002",
+        "label": "/api/v2/labels/0000000000000003"
+      }
+    }
+  ]
+}`
+	if eq, diff, _ := jsonEqual(string(got), want); !eq {
+		t.Errorf("newSilencesResponse() = ***%s***", diff)
+	}
+}
+
+func TestSilenceHandler_handlePostSilence(t *testing.T) {
+	var created *influxdb.Silence
+	h := NewSilenceHandler(&SilenceBackend{
+		HTTPErrorHandler: ErrorHandler(0),
+		Logger:           zap.NewNop(),
+		SilenceService: &mock.SilenceService{
+			CreateSilenceF: func(ctx context.Context, s *influxdb.Silence) error {
+				s.ID = influxdb.ID(1)
+				created = s
+				return nil
+			},
+		},
+	})
+
+	r := httptest.NewRequest("POST", "/api/v2/silences", bytes.NewBufferString(`{"orgID": "0000000000000002", "labelID": "0000000000000003"}`))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != 400 || created != nil {
+		t.Errorf("expected a silence without end to be rejected, got %d", w.Code)
+	}
+
+	r = httptest.NewRequest("POST", "/api/v2/silences", bytes.NewBufferString(`{"orgID": "0000000000000002", "labelID": "0000000000000003", "endsAt": "2019-10-01T14:00:00Z"}`))
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	body, _ := ioutil.ReadAll(w.Result().Body)
+	if w.Code != 201 {
+		t.Fatalf("unexpected status %d: %s", w.Code, body)
+	}
+	if created == nil || created.LabelID != influxdb.ID(3) || !created.EndsAt.Equal(time.Date(2019, 10, 1, 14, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected created silence %+v", created)
+	}
+}
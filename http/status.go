@@ -26,6 +26,14 @@ func (w *statusResponseWriter) WriteHeader(statusCode int) {
 	w.ResponseWriter.WriteHeader(statusCode)
 }
 
+// Flush sends the buffered response to the client, if the wrapped response
+// writer flushes.
+func (w *statusResponseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
 func (w *statusResponseWriter) code() int {
 	code := w.statusCode
 	if code == 0 {
@@ -0,0 +1,161 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/influxdata/influxdb"
+	"github.com/julienschmidt/httprouter"
+	"go.uber.org/zap"
+)
+
+// StatusBackend is all services and associated parameters required to construct
+// the StatusHandler.
+type StatusBackend struct {
+	influxdb.HTTPErrorHandler
+	Logger *zap.Logger
+
+	StatusTraceService     influxdb.StatusTraceService
+	StatusInjectionService influxdb.StatusInjectionService
+}
+
+// NewStatusBackend returns a new instance of StatusBackend.
+func NewStatusBackend(b *APIBackend) *StatusBackend {
+	return &StatusBackend{
+		HTTPErrorHandler: b.HTTPErrorHandler,
+		Logger:           b.Logger.With(zap.String("handler", "status")),
+
+		StatusTraceService:     b.StatusTraceService,
+		StatusInjectionService: b.StatusInjectionService,
+	}
+}
+
+// StatusHandler is the handler for the statuses of the checks.
+type StatusHandler struct {
+	*httprouter.Router
+	influxdb.HTTPErrorHandler
+	Logger *zap.Logger
+
+	StatusTraceService     influxdb.StatusTraceService
+	StatusInjectionService influxdb.StatusInjectionService
+}
+
+const (
+	statusesInjectPath  = "/api/v2/statuses/inject"
+	statusesIDTracePath = "/api/v2/statuses/:id/trace"
+)
+
+// NewStatusHandler returns a new instance of StatusHandler.
+func NewStatusHandler(b *StatusBackend) *StatusHandler {
+	h := &StatusHandler{
+		Router:           NewRouter(b.HTTPErrorHandler),
+		HTTPErrorHandler: b.HTTPErrorHandler,
+		Logger:           b.Logger,
+
+		StatusTraceService:     b.StatusTraceService,
+		StatusInjectionService: b.StatusInjectionService,
+	}
+
+	h.HandlerFunc("POST", statusesInjectPath, h.handlePostStatusInjection)
+	h.HandlerFunc("GET", statusesIDTracePath, h.handleGetStatusTrace)
+	return h
+}
+
+type statusTraceLinks struct {
+	Self  string `json:"self"`
+	Check string `json:"check"`
+}
+
+type statusTraceResponse struct {
+	*influxdb.StatusTrace
+	Links statusTraceLinks `json:"links"`
+}
+
+func newStatusTraceResponse(t *influxdb.StatusTrace) *statusTraceResponse {
+	return &statusTraceResponse{
+		StatusTrace: t,
+		Links: statusTraceLinks{
+			Self:  fmt.Sprintf("/api/v2/statuses/%s/trace", t.StatusID),
+			Check: fmt.Sprintf("/api/v2/checks/%s", t.CheckID),
+		},
+	}
+}
+
+func decodeGetStatusRequest(ctx context.Context, r *http.Request) (i influxdb.ID, err error) {
+	params := httprouter.ParamsFromContext(ctx)
+	id := params.ByName("id")
+	if id == "" {
+		return i, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "url missing id",
+		}
+	}
+
+	if err := i.DecodeFromString(id); err != nil {
+		return i, err
+	}
+	return i, nil
+}
+
+// handleGetStatusTrace is the HTTP handler for the GET /api/v2/statuses/:id/trace route.
+func (h *StatusHandler) handleGetStatusTrace(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "status trace retrieve request", r)
+	id, err := decodeGetStatusRequest(ctx, r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	t, err := h.StatusTraceService.FindStatusTrace(ctx, id)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	debugResult(h.Logger, "status trace retrieved", "statusTrace", t)
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newStatusTraceResponse(t)); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+func decodePostStatusInjectionRequest(ctx context.Context, r *http.Request) (*influxdb.StatusInjection, error) {
+	i := &influxdb.StatusInjection{}
+	if err := json.NewDecoder(r.Body).Decode(i); err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  err.Error(),
+		}
+	}
+	if err := i.Valid(); err != nil {
+		return nil, err
+	}
+	return i, nil
+}
+
+// handlePostStatusInjection is the HTTP handler for the POST /api/v2/statuses/inject route.
+func (h *StatusHandler) handlePostStatusInjection(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	debugRequest(h.Logger, "status injection request", r)
+	i, err := decodePostStatusInjectionRequest(ctx, r)
+	if err != nil {
+		h.Logger.Debug("failed to decode request", zap.Error(err))
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	t, err := h.StatusInjectionService.InjectStatus(ctx, i)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	debugResult(h.Logger, "status injected", "statusTrace", t)
+
+	if err := encodeResponse(ctx, w, http.StatusCreated, newStatusTraceResponse(t)); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
@@ -0,0 +1,180 @@
+package http
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
+	"go.uber.org/zap"
+)
+
+// NewMockStatusBackend returns a StatusBackend with mock services.
+func NewMockStatusBackend() *StatusBackend {
+	return &StatusBackend{
+		Logger:                 zap.NewNop().With(zap.String("handler", "status")),
+		StatusTraceService:     &mock.StatusTraceService{},
+		StatusInjectionService: &mock.StatusInjectionService{},
+	}
+}
+
+func TestStatusHandler_handleGetStatusTrace(t *testing.T) {
+	b := NewMockStatusBackend()
+	b.HTTPErrorHandler = ErrorHandler(0)
+	b.StatusTraceService = &mock.StatusTraceService{
+		FindStatusTraceF: func(ctx context.Context, statusID influxdb.ID) (*influxdb.StatusTrace, error) {
+			if statusID != influxdb.ID(1) {
+				return nil, &influxdb.Error{
+					Code: influxdb.ENotFound,
+					Msg:  "status trace not found",
+				}
+			}
+			endpointID := influxdb.ID(5)
+			return &influxdb.StatusTrace{
+				StatusID: statusID,
+				CheckID:  2,
+				OrgID:    3,
+				Level:    "CRIT",
+				Time:     time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC),
+				Rules: []influxdb.RuleTrace{
+					{
+						RuleID:     4,
+						RuleName:   "crit to slack",
+						Decision:   influxdb.RuleNotified,
+						EndpointID: &endpointID,
+					},
+					{
+						RuleID:   6,
+						RuleName: "prod to pagerduty",
+						Decision: influxdb.RuleUnmatched,
+						Reason:   "the tags of the status don't match the tag rules",
+					},
+				},
+			}, nil
+		},
+	}
+	h := NewStatusHandler(b)
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/api/v2/statuses/0000000000000001/trace", nil))
+	res := w.Result()
+	body, _ := ioutil.ReadAll(res.Body)
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, res.StatusCode, body)
+	}
+	want := `{
+  "statusID": "0000000000000001",
+  "checkID": "0000000000000002",
+  "orgID": "0000000000000003",
+  "level": "CRIT",
+  "time": "2019-10-01T00:00:00Z",
+  "rules": [
+    {
+      "ruleID": "0000000000000004",
+      "ruleName": "crit to slack",
+      "decision": "notified",
+      "endpointID": "0000000000000005"
+    },
+    {
+      "ruleID": "0000000000000006",
+      "ruleName": "prod to pagerduty",
+      "decision": "unmatched",
+      "reason": "the tags of the status don't match the tag rules"
+    }
+  ],
+  "links": {
+    "self": "/api/v2/statuses/0000000000000001/trace",
+    "check": "/api/v2/checks/0000000000000002"
+  }
+}`
+	if eq, diff, _ := jsonEqual(string(body), want); !eq {
+		t.Errorf("handleGetStatusTrace() = ***%s***", diff)
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/api/v2/statuses/0000000000000007/trace", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestStatusHandler_handlePostStatusInjection(t *testing.T) {
+	b := NewMockStatusBackend()
+	b.HTTPErrorHandler = ErrorHandler(0)
+	var injected *influxdb.StatusInjection
+	b.StatusInjectionService = &mock.StatusInjectionService{
+		InjectStatusF: func(ctx context.Context, i *influxdb.StatusInjection) (*influxdb.StatusTrace, error) {
+			injected = i
+			return &influxdb.StatusTrace{
+				StatusID: 1,
+				CheckID:  i.CheckID,
+				OrgID:    3,
+				Level:    i.Level,
+				Time:     time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC),
+				Rules: []influxdb.RuleTrace{
+					{
+						RuleID:   4,
+						RuleName: "crit to slack",
+						Decision: influxdb.RuleMuted,
+						Reason:   "the rule is inactive",
+					},
+				},
+			}, nil
+		},
+	}
+	h := NewStatusHandler(b)
+
+	w := httptest.NewRecorder()
+	body := `{"checkID": "0000000000000002", "level": "CRIT", "value": 95, "tags": {"host": "a"}}`
+	h.ServeHTTP(w, httptest.NewRequest("POST", "/api/v2/statuses/inject", strings.NewReader(body)))
+	res := w.Result()
+	got, _ := ioutil.ReadAll(res.Body)
+	if res.StatusCode != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, res.StatusCode, got)
+	}
+	value := 95.0
+	want := &influxdb.StatusInjection{
+		CheckID: 2,
+		Level:   "CRIT",
+		Value:   &value,
+		Tags:    map[string]string{"host": "a"},
+	}
+	if !reflect.DeepEqual(injected, want) {
+		t.Errorf("unexpected status injection %+v", injected)
+	}
+	wantBody := `{
+  "statusID": "0000000000000001",
+  "checkID": "0000000000000002",
+  "orgID": "0000000000000003",
+  "level": "CRIT",
+  "time": "2019-10-01T00:00:00Z",
+  "rules": [
+    {
+      "ruleID": "0000000000000004",
+      "ruleName": "crit to slack",
+      "decision": "muted",
+      "reason": "the rule is inactive"
+    }
+  ],
+  "links": {
+    "self": "/api/v2/statuses/0000000000000001/trace",
+    "check": "/api/v2/checks/0000000000000002"
+  }
+}`
+	if eq, diff, _ := jsonEqual(string(got), wantBody); !eq {
+		t.Errorf("handlePostStatusInjection() = ***%s***", diff)
+	}
+
+	w = httptest.NewRecorder()
+	body = `{"checkID": "0000000000000002", "level": "BAD"}`
+	h.ServeHTTP(w, httptest.NewRequest("POST", "/api/v2/statuses/inject", strings.NewReader(body)))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d for an invalid level, got %d", http.StatusBadRequest, w.Code)
+	}
+}
@@ -270,7 +270,7 @@ func newRunsResponse(rs []*platform.Run, taskID platform.ID) runsResponse {
 
 func (h *TaskHandler) handleGetTasks(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.logger.Debug("tasks retrieve request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.logger, "tasks retrieve request", r)
 	req, err := decodeGetTasksRequest(ctx, r, h.OrganizationService)
 	if err != nil {
 		err = &platform.Error{
@@ -291,7 +291,7 @@ func (h *TaskHandler) handleGetTasks(w http.ResponseWriter, r *http.Request) {
 		h.HandleHTTPError(ctx, err, w)
 		return
 	}
-	h.logger.Debug("tasks retrived", zap.String("tasks", fmt.Sprint(tasks)))
+	debugResult(h.logger, "tasks retrived", "tasks", tasks)
 	if err := encodeResponse(ctx, w, http.StatusOK, newTasksResponse(ctx, tasks, req.filter, h.LabelService)); err != nil {
 		logEncodingError(h.logger, r, err)
 		return
@@ -367,7 +367,7 @@ func decodeGetTasksRequest(ctx context.Context, r *http.Request, orgs platform.O
 
 func (h *TaskHandler) handlePostTask(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.logger.Debug("task create request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.logger, "task create request", r)
 
 	req, err := decodePostTaskRequest(ctx, r)
 	if err != nil {
@@ -443,7 +443,7 @@ func decodePostTaskRequest(ctx context.Context, r *http.Request) (*postTaskReque
 
 func (h *TaskHandler) handleGetTask(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.logger.Debug("task retrieve request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.logger, "task retrieve request", r)
 	req, err := decodeGetTaskRequest(ctx, r)
 	if err != nil {
 		err = &platform.Error{
@@ -475,7 +475,7 @@ func (h *TaskHandler) handleGetTask(w http.ResponseWriter, r *http.Request) {
 		h.HandleHTTPError(ctx, err, w)
 		return
 	}
-	h.logger.Debug("task retrived", zap.String("tasks", fmt.Sprint(task)))
+	debugResult(h.logger, "task retrived", "tasks", task)
 	if err := encodeResponse(ctx, w, http.StatusOK, newTaskResponse(*task, labels)); err != nil {
 		logEncodingError(h.logger, r, err)
 		return
@@ -510,7 +510,7 @@ func decodeGetTaskRequest(ctx context.Context, r *http.Request) (*getTaskRequest
 
 func (h *TaskHandler) handleUpdateTask(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.logger.Debug("task update request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.logger, "task update request", r)
 	req, err := decodeUpdateTaskRequest(ctx, r)
 	if err != nil {
 		err = &platform.Error{
@@ -543,7 +543,7 @@ func (h *TaskHandler) handleUpdateTask(w http.ResponseWriter, r *http.Request) {
 		h.HandleHTTPError(ctx, err, w)
 		return
 	}
-	h.logger.Debug("tasks updated", zap.String("task", fmt.Sprint(task)))
+	debugResult(h.logger, "tasks updated", "task", task)
 	if err := encodeResponse(ctx, w, http.StatusOK, newTaskResponse(*task, labels)); err != nil {
 		logEncodingError(h.logger, r, err)
 		return
@@ -587,7 +587,7 @@ func decodeUpdateTaskRequest(ctx context.Context, r *http.Request) (*updateTaskR
 
 func (h *TaskHandler) handleDeleteTask(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.logger.Debug("task delete request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.logger, "task delete request", r)
 	req, err := decodeDeleteTaskRequest(ctx, r)
 	if err != nil {
 		err = &platform.Error{
@@ -610,7 +610,7 @@ func (h *TaskHandler) handleDeleteTask(w http.ResponseWriter, r *http.Request) {
 		h.HandleHTTPError(ctx, err, w)
 		return
 	}
-	h.logger.Debug("tasks deleted", zap.String("taskID", fmt.Sprint(req.TaskID)))
+	h.logger.Debug("tasks deleted", zap.Stringer("taskID", req.TaskID))
 	w.WriteHeader(http.StatusNoContent)
 }
 
@@ -236,7 +236,7 @@ func decodeGetTelegrafRequest(ctx context.Context, r *http.Request) (i platform.
 
 func (h *TelegrafHandler) handleGetTelegrafs(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.Logger.Debug("telegrafs retrieve request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "telegrafs retrieve request", r)
 	filter, err := decodeTelegrafConfigFilter(ctx, r)
 	if err != nil {
 		h.Logger.Debug("failed to decode request", zap.Error(err))
@@ -248,7 +248,7 @@ func (h *TelegrafHandler) handleGetTelegrafs(w http.ResponseWriter, r *http.Requ
 		h.HandleHTTPError(ctx, err, w)
 		return
 	}
-	h.Logger.Debug("telegrafs retrieved", zap.String("telegrafs", fmt.Sprint(tcs)))
+	debugResult(h.Logger, "telegrafs retrieved", "telegrafs", tcs)
 
 	if err := encodeResponse(ctx, w, http.StatusOK, newTelegrafResponses(ctx, tcs, h.LabelService)); err != nil {
 		logEncodingError(h.Logger, r, err)
@@ -258,7 +258,7 @@ func (h *TelegrafHandler) handleGetTelegrafs(w http.ResponseWriter, r *http.Requ
 
 func (h *TelegrafHandler) handleGetTelegraf(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.Logger.Debug("telegraf retrieve request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "telegraf retrieve request", r)
 	id, err := decodeGetTelegrafRequest(ctx, r)
 	if err != nil {
 		h.HandleHTTPError(ctx, err, w)
@@ -269,7 +269,7 @@ func (h *TelegrafHandler) handleGetTelegraf(w http.ResponseWriter, r *http.Reque
 		h.HandleHTTPError(ctx, err, w)
 		return
 	}
-	h.Logger.Debug("telegraf retrieved", zap.String("telegraf", fmt.Sprint(tc)))
+	debugResult(h.Logger, "telegraf retrieved", "telegraf", tc)
 
 	offers := []string{"application/toml", "application/json", "application/octet-stream"}
 	defaultOffer := "application/toml"
@@ -352,7 +352,7 @@ func decodePutTelegrafRequest(ctx context.Context, r *http.Request) (*platform.T
 // handlePostTelegraf is the HTTP handler for the POST /api/v2/telegrafs route.
 func (h *TelegrafHandler) handlePostTelegraf(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.Logger.Debug("telegraf create request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "telegraf create request", r)
 	tc, err := decodePostTelegrafRequest(ctx, r)
 	if err != nil {
 		h.Logger.Debug("failed to decode request", zap.Error(err))
@@ -369,7 +369,7 @@ func (h *TelegrafHandler) handlePostTelegraf(w http.ResponseWriter, r *http.Requ
 		h.HandleHTTPError(ctx, err, w)
 		return
 	}
-	h.Logger.Debug("telegraf created", zap.String("telegraf", fmt.Sprint(tc)))
+	debugResult(h.Logger, "telegraf created", "telegraf", tc)
 
 	if err := encodeResponse(ctx, w, http.StatusCreated, newTelegrafResponse(tc, []*platform.Label{})); err != nil {
 		logEncodingError(h.Logger, r, err)
@@ -380,7 +380,7 @@ func (h *TelegrafHandler) handlePostTelegraf(w http.ResponseWriter, r *http.Requ
 // handlePutTelegraf is the HTTP handler for the POST /api/v2/telegrafs route.
 func (h *TelegrafHandler) handlePutTelegraf(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.Logger.Debug("telegraf update request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "telegraf update request", r)
 	tc, err := decodePutTelegrafRequest(ctx, r)
 	if err != nil {
 		h.Logger.Debug("failed to decode request", zap.Error(err))
@@ -404,7 +404,7 @@ func (h *TelegrafHandler) handlePutTelegraf(w http.ResponseWriter, r *http.Reque
 		h.HandleHTTPError(ctx, err, w)
 		return
 	}
-	h.Logger.Debug("telegraf updated", zap.String("telegraf", fmt.Sprint(tc)))
+	debugResult(h.Logger, "telegraf updated", "telegraf", tc)
 
 	if err := encodeResponse(ctx, w, http.StatusOK, newTelegrafResponse(tc, labels)); err != nil {
 		logEncodingError(h.Logger, r, err)
@@ -414,7 +414,7 @@ func (h *TelegrafHandler) handlePutTelegraf(w http.ResponseWriter, r *http.Reque
 
 func (h *TelegrafHandler) handleDeleteTelegraf(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.Logger.Debug("telegraf delete request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "telegraf delete request", r)
 	i, err := decodeGetTelegrafRequest(ctx, r)
 	if err != nil {
 		h.HandleHTTPError(ctx, err, w)
@@ -425,7 +425,7 @@ func (h *TelegrafHandler) handleDeleteTelegraf(w http.ResponseWriter, r *http.Re
 		h.HandleHTTPError(ctx, err, w)
 		return
 	}
-	h.Logger.Debug("telegraf deleted", zap.String("telegrafID", fmt.Sprint(i)))
+	h.Logger.Debug("telegraf deleted", zap.Stringer("telegrafID", i))
 
 	w.WriteHeader(http.StatusNoContent)
 }
@@ -17,17 +17,20 @@ type UsageHandler struct {
 	platform.HTTPErrorHandler
 	Logger *zap.Logger
 
-	UsageService platform.UsageService
+	UsageService         platform.UsageService
+	AlertingUsageService platform.AlertingUsageService
 }
 
 // NewUsageHandler returns a new instance of UsageHandler.
 func NewUsageHandler(he platform.HTTPErrorHandler) *UsageHandler {
 	h := &UsageHandler{
-		Router: NewRouter(he),
-		Logger: zap.NewNop(),
+		Router:           NewRouter(he),
+		HTTPErrorHandler: he,
+		Logger:           zap.NewNop(),
 	}
 
 	h.HandlerFunc("GET", "/api/v2/usage", h.handleGetUsage)
+	h.HandlerFunc("GET", "/api/v2/usage/alerting", h.handleGetAlertingUsage)
 	return h
 }
 
@@ -53,6 +56,31 @@ func (h *UsageHandler) handleGetUsage(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// handleGetAlertingUsage is the HTTP handler for the GET /api/v2/usage/alerting route.
+func (h *UsageHandler) handleGetAlertingUsage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodeGetUsageRequest(ctx, r)
+	if err != nil {
+		h.HandleHTTPError(ctx, &platform.Error{
+			Code: platform.EInvalid,
+			Err:  err,
+		}, w)
+		return
+	}
+
+	u, err := h.AlertingUsageService.GetAlertingUsage(ctx, req.filter)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, u); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
 type getUsageRequest struct {
 	filter platform.UsageFilter
 }
@@ -0,0 +1,62 @@
+package http
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
+)
+
+func TestUsageHandler_handleGetAlertingUsage(t *testing.T) {
+	h := NewUsageHandler(ErrorHandler(0))
+	h.AlertingUsageService = &mock.AlertingUsageService{
+		GetAlertingUsageF: func(ctx context.Context, filter influxdb.UsageFilter) (*influxdb.AlertingUsage, error) {
+			start := time.Date(2019, 9, 1, 0, 0, 0, 0, time.UTC)
+			if filter.OrgID == nil || *filter.OrgID != influxdb.ID(2) || !filter.Range.Start.Equal(start) || !filter.Range.Stop.Equal(start.Add(48*time.Hour)) {
+				t.Errorf("unexpected filter %+v", filter)
+			}
+			return &influxdb.AlertingUsage{
+				OrgID: filter.OrgID,
+				Checks: influxdb.AlertingResourceUsage{
+					Total:    1,
+					ByType:   map[string]int{"deadman": 1},
+					ByStatus: map[influxdb.Status]int{influxdb.Active: 1},
+				},
+				NotificationEndpoints: influxdb.AlertingResourceUsage{ByType: map[string]int{}, ByStatus: map[influxdb.Status]int{}},
+				NotificationRules:     influxdb.AlertingResourceUsage{ByType: map[string]int{}, ByStatus: map[influxdb.Status]int{}},
+				Notifications: []influxdb.DailyUsage{
+					{Day: "2019-09-01", Count: 3},
+					{Day: "2019-09-02", Count: 0},
+				},
+			}, nil
+		},
+	}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/api/v2/usage/alerting?orgID=0000000000000002&start=2019-09-01T00:00:00Z&stop=2019-09-03T00:00:00Z", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	body, _ := ioutil.ReadAll(w.Body)
+	want := `{
+		"orgID": "0000000000000002",
+		"checks": {"total": 1, "byType": {"deadman": 1}, "byStatus": {"active": 1}},
+		"notificationEndpoints": {"total": 0, "byType": {}, "byStatus": {}},
+		"notificationRules": {"total": 0, "byType": {}, "byStatus": {}},
+		"notifications": [{"day": "2019-09-01", "count": 3}, {"day": "2019-09-02", "count": 0}]
+	}`
+	if eq, diff, _ := jsonEqual(string(body), want); !eq {
+		t.Errorf("unexpected alerting usage: %s", diff)
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/api/v2/usage/alerting?orgID=0000000000000002&start=2019-09-01T00:00:00Z", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
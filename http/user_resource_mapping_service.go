@@ -70,7 +70,7 @@ type MemberBackend struct {
 func newPostMemberHandler(b MemberBackend) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
-		b.Logger.Debug("member/owner create request", zap.String("r", fmt.Sprint(r)))
+		debugRequest(b.Logger, "member/owner create request", r)
 		req, err := decodePostMemberRequest(ctx, r)
 		if err != nil {
 			b.HandleHTTPError(ctx, err, w)
@@ -94,7 +94,7 @@ func newPostMemberHandler(b MemberBackend) http.HandlerFunc {
 			b.HandleHTTPError(ctx, err, w)
 			return
 		}
-		b.Logger.Debug("member/owner created", zap.String("mapping", fmt.Sprint(mapping)))
+		debugResult(b.Logger, "member/owner created", "mapping", mapping)
 
 		if err := encodeResponse(ctx, w, http.StatusCreated, newResourceUserResponse(user, b.UserType)); err != nil {
 			b.HandleHTTPError(ctx, err, w)
@@ -145,7 +145,7 @@ func decodePostMemberRequest(ctx context.Context, r *http.Request) (*postMemberR
 func newGetMembersHandler(b MemberBackend) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
-		b.Logger.Debug("members/owners retrieve request", zap.String("r", fmt.Sprint(r)))
+		debugRequest(b.Logger, "members/owners retrieve request", r)
 
 		req, err := decodeGetMembersRequest(ctx, r)
 		if err != nil {
@@ -179,7 +179,7 @@ func newGetMembersHandler(b MemberBackend) http.HandlerFunc {
 
 			users = append(users, user)
 		}
-		b.Logger.Debug("members/owners retrieved", zap.String("users", fmt.Sprint(users)))
+		debugResult(b.Logger, "members/owners retrieved", "users", users)
 
 		if err := encodeResponse(ctx, w, http.StatusOK, newResourceUsersResponse(opts, filter, users)); err != nil {
 			b.HandleHTTPError(ctx, err, w)
@@ -219,7 +219,7 @@ func decodeGetMembersRequest(ctx context.Context, r *http.Request) (*getMembersR
 func newDeleteMemberHandler(b MemberBackend) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
-		b.Logger.Debug("member delete request", zap.String("r", fmt.Sprint(r)))
+		debugRequest(b.Logger, "member delete request", r)
 
 		req, err := decodeDeleteMemberRequest(ctx, r)
 		if err != nil {
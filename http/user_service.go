@@ -22,6 +22,8 @@ type UserBackend struct {
 	UserService             influxdb.UserService
 	UserOperationLogService influxdb.UserOperationLogService
 	PasswordsService        influxdb.PasswordsService
+
+	NotificationPreferencesService influxdb.NotificationPreferencesService
 }
 
 // NewUserBackend creates a UserBackend using information in the APIBackend.
@@ -32,6 +34,8 @@ func NewUserBackend(b *APIBackend) *UserBackend {
 		UserService:             b.UserService,
 		UserOperationLogService: b.UserOperationLogService,
 		PasswordsService:        b.PasswordsService,
+
+		NotificationPreferencesService: b.NotificationPreferencesService,
 	}
 }
 
@@ -43,6 +47,8 @@ type UserHandler struct {
 	UserService             influxdb.UserService
 	UserOperationLogService influxdb.UserOperationLogService
 	PasswordsService        influxdb.PasswordsService
+
+	NotificationPreferencesService influxdb.NotificationPreferencesService
 }
 
 const (
@@ -52,6 +58,9 @@ const (
 	usersIDPath       = "/api/v2/users/:id"
 	usersPasswordPath = "/api/v2/users/:id/password"
 	usersLogPath      = "/api/v2/users/:id/logs"
+
+	meNotificationPreferencesPath    = "/api/v2/me/notificationPreferences"
+	usersNotificationPreferencesPath = "/api/v2/users/:id/notificationPreferences"
 )
 
 // NewUserHandler returns a new instance of UserHandler.
@@ -64,6 +73,8 @@ func NewUserHandler(b *UserBackend) *UserHandler {
 		UserService:             b.UserService,
 		UserOperationLogService: b.UserOperationLogService,
 		PasswordsService:        b.PasswordsService,
+
+		NotificationPreferencesService: b.NotificationPreferencesService,
 	}
 
 	h.HandlerFunc("POST", usersPath, h.handlePostUser)
@@ -77,6 +88,13 @@ func NewUserHandler(b *UserBackend) *UserHandler {
 	h.HandlerFunc("GET", mePath, h.handleGetMe)
 	h.HandlerFunc("PUT", mePasswordPath, h.handlePutUserPassword)
 
+	h.HandlerFunc("GET", meNotificationPreferencesPath, h.handleGetNotificationPreferences)
+	h.HandlerFunc("PUT", meNotificationPreferencesPath, h.handlePutNotificationPreferences)
+	h.HandlerFunc("DELETE", meNotificationPreferencesPath, h.handleDeleteNotificationPreferences)
+	h.HandlerFunc("GET", usersNotificationPreferencesPath, h.handleGetNotificationPreferences)
+	h.HandlerFunc("PUT", usersNotificationPreferencesPath, h.handlePutNotificationPreferences)
+	h.HandlerFunc("DELETE", usersNotificationPreferencesPath, h.handleDeleteNotificationPreferences)
+
 	return h
 }
 
@@ -97,7 +115,7 @@ func (h *UserHandler) putPassword(ctx context.Context, w http.ResponseWriter, r
 // handlePutPassword is the HTTP handler for the PUT /api/v2/users/:id/password
 func (h *UserHandler) handlePutUserPassword(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.Logger.Debug("user update password request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "user update password request", r)
 	_, err := h.putPassword(ctx, w, r)
 	if err != nil {
 		h.HandleHTTPError(ctx, err, w)
@@ -142,7 +160,7 @@ func decodePasswordResetRequest(ctx context.Context, r *http.Request) (*password
 // handlePostUser is the HTTP handler for the POST /api/v2/users route.
 func (h *UserHandler) handlePostUser(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.Logger.Debug("user create request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "user create request", r)
 	req, err := decodePostUserRequest(ctx, r)
 	if err != nil {
 		h.HandleHTTPError(ctx, err, w)
@@ -153,7 +171,7 @@ func (h *UserHandler) handlePostUser(w http.ResponseWriter, r *http.Request) {
 		h.HandleHTTPError(ctx, err, w)
 		return
 	}
-	h.Logger.Debug("user created", zap.String("user", fmt.Sprint(req.User)))
+	debugResult(h.Logger, "user created", "user", req.User)
 
 	if err := encodeResponse(ctx, w, http.StatusCreated, newUserResponse(req.User)); err != nil {
 		h.HandleHTTPError(ctx, err, w)
@@ -202,7 +220,7 @@ func (h *UserHandler) handleGetMe(w http.ResponseWriter, r *http.Request) {
 // handleGetUser is the HTTP handler for the GET /api/v2/users/:id route.
 func (h *UserHandler) handleGetUser(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.Logger.Debug("user retrieve request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "user retrieve request", r)
 	req, err := decodeGetUserRequest(ctx, r)
 	if err != nil {
 		h.HandleHTTPError(ctx, err, w)
@@ -214,7 +232,7 @@ func (h *UserHandler) handleGetUser(w http.ResponseWriter, r *http.Request) {
 		h.HandleHTTPError(ctx, err, w)
 		return
 	}
-	h.Logger.Debug("user retrieved", zap.String("user", fmt.Sprint(b)))
+	debugResult(h.Logger, "user retrieved", "user", b)
 
 	if err := encodeResponse(ctx, w, http.StatusOK, newUserResponse(b)); err != nil {
 		h.HandleHTTPError(ctx, err, w)
@@ -251,7 +269,7 @@ func decodeGetUserRequest(ctx context.Context, r *http.Request) (*getUserRequest
 // handleDeleteUser is the HTTP handler for the DELETE /api/v2/users/:id route.
 func (h *UserHandler) handleDeleteUser(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.Logger.Debug("user delete request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "user delete request", r)
 	req, err := decodeDeleteUserRequest(ctx, r)
 	if err != nil {
 		h.HandleHTTPError(ctx, err, w)
@@ -262,7 +280,7 @@ func (h *UserHandler) handleDeleteUser(w http.ResponseWriter, r *http.Request) {
 		h.HandleHTTPError(ctx, err, w)
 		return
 	}
-	h.Logger.Debug("user deleted", zap.String("userID", fmt.Sprint(req.UserID)))
+	h.Logger.Debug("user deleted", zap.Stringer("userID", req.UserID))
 
 	w.WriteHeader(http.StatusNoContent)
 }
@@ -335,7 +353,7 @@ func newUserResponse(u *influxdb.User) *userResponse {
 // handleGetUsers is the HTTP handler for the GET /api/v2/users route.
 func (h *UserHandler) handleGetUsers(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.Logger.Debug("users retrieve request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "users retrieve request", r)
 	req, err := decodeGetUsersRequest(ctx, r)
 	if err != nil {
 		h.HandleHTTPError(ctx, err, w)
@@ -347,7 +365,7 @@ func (h *UserHandler) handleGetUsers(w http.ResponseWriter, r *http.Request) {
 		h.HandleHTTPError(ctx, err, w)
 		return
 	}
-	h.Logger.Debug("users retrieved", zap.String("users", fmt.Sprint(users)))
+	debugResult(h.Logger, "users retrieved", "users", users)
 
 	err = encodeResponse(ctx, w, http.StatusOK, newUsersResponse(users))
 	if err != nil {
@@ -382,7 +400,7 @@ func decodeGetUsersRequest(ctx context.Context, r *http.Request) (*getUsersReque
 // handlePatchUser is the HTTP handler for the PATCH /api/v2/users/:id route.
 func (h *UserHandler) handlePatchUser(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.Logger.Debug("user update request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "user update request", r)
 	req, err := decodePatchUserRequest(ctx, r)
 	if err != nil {
 		h.HandleHTTPError(ctx, err, w)
@@ -394,7 +412,7 @@ func (h *UserHandler) handlePatchUser(w http.ResponseWriter, r *http.Request) {
 		h.HandleHTTPError(ctx, err, w)
 		return
 	}
-	h.Logger.Debug("users updated", zap.String("user", fmt.Sprint(b)))
+	debugResult(h.Logger, "users updated", "user", b)
 
 	if err := encodeResponse(ctx, w, http.StatusOK, newUserResponse(b)); err != nil {
 		h.HandleHTTPError(ctx, err, w)
@@ -687,7 +705,7 @@ func userIDPath(id influxdb.ID) string {
 // hanldeGetUserLog retrieves a user log by the users ID.
 func (h *UserHandler) handleGetUserLog(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	h.Logger.Debug("user log retrieve request", zap.String("r", fmt.Sprint(r)))
+	debugRequest(h.Logger, "user log retrieve request", r)
 	req, err := decodeGetUserLogRequest(ctx, r)
 	if err != nil {
 		h.HandleHTTPError(ctx, err, w)
@@ -699,7 +717,7 @@ func (h *UserHandler) handleGetUserLog(w http.ResponseWriter, r *http.Request) {
 		h.HandleHTTPError(ctx, err, w)
 		return
 	}
-	h.Logger.Debug("user log retrieved", zap.String("log", fmt.Sprint(log)))
+	debugResult(h.Logger, "user log retrieved", "log", log)
 
 	if err := encodeResponse(ctx, w, http.StatusOK, newUserLogResponse(req.UserID, log)); err != nil {
 		h.HandleHTTPError(ctx, err, w)
@@ -1,6 +1,7 @@
 package influxdb
 
 import (
+	"crypto/sha1"
 	"encoding/binary"
 	"encoding/hex"
 	"reflect"
@@ -53,6 +54,29 @@ func InvalidID() ID {
 	return 0
 }
 
+// nameIDNamespace is hashed before the names of a NameID, as the namespace of
+// a name-based UUID.
+const nameIDNamespace = "influxdb/id"
+
+// NameID derives an ID from names, the same names always derive the same ID.
+// As a name-based (version 5) UUID, it is the leading bytes of the SHA-1 hash
+// of a namespace and the names.
+func NameID(names ...string) ID {
+	h := sha1.New()
+	h.Write([]byte(nameIDNamespace))
+	var n [binary.MaxVarintLen64]byte
+	for _, name := range names {
+		// the length of each name is hashed, so that the names
+		// ("ab", "c") and ("a", "bc") derive different IDs.
+		h.Write(n[:binary.PutUvarint(n[:], uint64(len(name)))])
+		h.Write([]byte(name))
+	}
+	if id := ID(binary.BigEndian.Uint64(h.Sum(nil))); id.Valid() {
+		return id
+	}
+	return 1
+}
+
 // Decode parses b as a hex-encoded byte-slice-string.
 //
 // It errors if the input byte slice does not have the correct length
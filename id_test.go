@@ -217,6 +217,22 @@ func TestValid(t *testing.T) {
 	}
 }
 
+func TestNameID(t *testing.T) {
+	id := platform.NameID("theorg", "host", "checks", "heartbeat")
+	if !id.Valid() {
+		t.Fatalf("expected a valid ID, got %v", id)
+	}
+	if other := platform.NameID("theorg", "host", "checks", "heartbeat"); other != id {
+		t.Errorf("expected the same names to derive the same ID, got %v and %v", id, other)
+	}
+	if other := platform.NameID("theorg", "host", "checks", "disk"); other == id {
+		t.Errorf("expected other names to derive another ID, got %v", other)
+	}
+	if platform.NameID("ab", "c") == platform.NameID("a", "bc") {
+		t.Errorf("expected the split of the names to derive another ID")
+	}
+}
+
 func TestID_GoString(t *testing.T) {
 	type idGoStringTester struct {
 		ID platform.ID
@@ -0,0 +1,189 @@
+package kv
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification"
+)
+
+var _ influxdb.AlertingDiagnosticsService = (*Service)(nil)
+
+// taggedCheck is a check writing tags to its statuses.
+type taggedCheck interface {
+	GetTags() []notification.Tag
+}
+
+// routedNotificationRule is a notification rule matching statuses by their
+// tags and sending them to an endpoint, or to the endpoints of its routes.
+type routedNotificationRule interface {
+	GetEndpointID() *influxdb.ID
+	GetEndpointIDs() []influxdb.ID
+	GetTagRules() []notification.TagRule
+}
+
+// FindOrphanedAlertingResources returns the checks, notification rules and
+// notification endpoints of an organization which never lead to a notification.
+func (s *Service) FindOrphanedAlertingResources(ctx context.Context, orgID influxdb.ID) (*influxdb.OrphanedAlertingReport, error) {
+	var (
+		r   *influxdb.OrphanedAlertingReport
+		err error
+	)
+	err = s.kv.View(ctx, func(tx Tx) error {
+		r, err = s.findOrphanedAlertingResources(ctx, tx, orgID)
+		return err
+	})
+	return r, err
+}
+
+func (s *Service) findOrphanedAlertingResources(ctx context.Context, tx Tx, orgID influxdb.ID) (*influxdb.OrphanedAlertingReport, error) {
+	if _, err := s.findOrganizationByID(ctx, tx, orgID); err != nil {
+		return nil, err
+	}
+
+	var rules []influxdb.NotificationRule
+	err := s.forEachNotificationRule(ctx, tx, false, func(nr influxdb.NotificationRule) bool {
+		if nr.GetOrgID() == orgID {
+			rules = append(rules, nr)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var endpoints []influxdb.NotificationEndpoint
+	err = s.forEachNotificationEndpoint(ctx, tx, false, func(edp influxdb.NotificationEndpoint) bool {
+		if edp.GetOrgID() == orgID {
+			endpoints = append(endpoints, edp)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var checks []influxdb.Check
+	err = s.forEachCheck(ctx, tx, &orgID, func(c influxdb.Check) bool {
+		checks = append(checks, c)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return orphanedAlertingReport(orgID, checks, rules, endpoints), nil
+}
+
+func orphanedAlertingReport(orgID influxdb.ID, checks []influxdb.Check, rules []influxdb.NotificationRule, endpoints []influxdb.NotificationEndpoint) *influxdb.OrphanedAlertingReport {
+	r := &influxdb.OrphanedAlertingReport{
+		OrgID:                 orgID,
+		Checks:                []influxdb.OrphanedResource{},
+		NotificationRules:     []influxdb.OrphanedResource{},
+		NotificationEndpoints: []influxdb.OrphanedResource{},
+	}
+
+	endpointIDs := make(map[influxdb.ID]bool, len(endpoints))
+	for _, edp := range endpoints {
+		endpointIDs[edp.GetID()] = true
+	}
+
+	referenced := make(map[influxdb.ID]bool)
+	for _, nr := range rules {
+		routed, ok := nr.(routedNotificationRule)
+		if !ok {
+			continue
+		}
+		ids := routed.GetEndpointIDs()
+		if len(ids) == 0 {
+			r.NotificationRules = append(r.NotificationRules, influxdb.OrphanedResource{
+				ID:           nr.GetID(),
+				Name:         nr.GetName(),
+				Reason:       influxdb.OrphanedRuleNoEndpoint,
+				SuggestedFix: "set the endpointID of the rule to a notification endpoint of the organization",
+			})
+			continue
+		}
+		for _, id := range ids {
+			if !endpointIDs[id] {
+				r.NotificationRules = append(r.NotificationRules, influxdb.OrphanedResource{
+					ID:           nr.GetID(),
+					Name:         nr.GetName(),
+					Reason:       influxdb.OrphanedRuleMissingEndpoint,
+					SuggestedFix: fmt.Sprintf("update the rule to send to an existing notification endpoint instead of %s", id),
+				})
+				break
+			}
+			referenced[id] = true
+		}
+	}
+
+	for _, edp := range endpoints {
+		if referenced[edp.GetID()] {
+			continue
+		}
+		r.NotificationEndpoints = append(r.NotificationEndpoints, influxdb.OrphanedResource{
+			ID:           edp.GetID(),
+			Name:         edp.GetName(),
+			Reason:       influxdb.OrphanedEndpointNoRule,
+			SuggestedFix: "create a notification rule sending to the endpoint, or delete the endpoint",
+		})
+	}
+
+	for _, c := range checks {
+		if c.GetStatus() != influxdb.Active {
+			continue
+		}
+		var tags []notification.Tag
+		if tc, ok := c.(taggedCheck); ok {
+			tags = tc.GetTags()
+		}
+
+		var inactive influxdb.NotificationRule
+		matched := false
+		for _, nr := range rules {
+			routed, ok := nr.(routedNotificationRule)
+			if !ok || !notification.MatchTagRules(routed.GetTagRules(), tags) {
+				continue
+			}
+			// the rules without an existing endpoint never notify.
+			if !hasEndpoint(routed.GetEndpointIDs(), endpointIDs) {
+				continue
+			}
+			if nr.GetStatus() == influxdb.Active {
+				matched = true
+				break
+			}
+			if inactive == nil {
+				inactive = nr
+			}
+		}
+		if matched {
+			continue
+		}
+
+		fix := "create a notification rule whose tag rules match the tags of the check"
+		if inactive != nil {
+			fix = fmt.Sprintf("activate the notification rule %s, which matches the tags of the check", inactive.GetName())
+		}
+		r.Checks = append(r.Checks, influxdb.OrphanedResource{
+			ID:           c.GetID(),
+			Name:         c.GetName(),
+			Reason:       influxdb.OrphanedCheckNoRule,
+			SuggestedFix: fix,
+		})
+	}
+
+	return r
+}
+
+// hasEndpoint returns whether one of ids is an existing endpoint.
+func hasEndpoint(ids []influxdb.ID, endpointIDs map[influxdb.ID]bool) bool {
+	for _, id := range ids {
+		if endpointIDs[id] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,276 @@
+package kv_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/check"
+	"github.com/influxdata/influxdb/notification/endpoint"
+	"github.com/influxdata/influxdb/notification/rule"
+)
+
+func TestService_FindOrphanedAlertingResources(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestService(t)
+
+	orgID := influxdb.ID(1)
+	if err := svc.PutOrganization(ctx, &influxdb.Organization{ID: orgID, Name: "theorg"}); err != nil {
+		t.Fatalf("failed to populate org: %v", err)
+	}
+
+	newCheck := func(id influxdb.ID, name string, status influxdb.Status, tags ...notification.Tag) influxdb.Check {
+		return &check.Deadman{
+			Base: check.Base{
+				ID:     id,
+				Name:   name,
+				OrgID:  orgID,
+				Status: status,
+				Every:  influxdb.Duration{Duration: time.Minute},
+				Query:  influxdb.DashboardQuery{Text: `from(bucket: "telegraf") |> range(start: -1m)`},
+				Tags:   tags,
+			},
+			TimeSince: 60,
+		}
+	}
+	newRule := func(id influxdb.ID, name string, status influxdb.Status, endpointID *influxdb.ID, tagRules ...notification.TagRule) influxdb.NotificationRule {
+		return &rule.Slack{
+			Base: rule.Base{
+				ID:              id,
+				Name:            name,
+				OrgID:           orgID,
+				AuthorizationID: influxdb.ID(99),
+				Status:          status,
+				EndpointID:      endpointID,
+				TagRules:        tagRules,
+			},
+			MessageTemplate: "msg",
+		}
+	}
+	newEndpoint := func(id influxdb.ID, name string) influxdb.NotificationEndpoint {
+		return &endpoint.Slack{
+			Base: endpoint.Base{
+				ID:     id,
+				Name:   name,
+				OrgID:  orgID,
+				Status: influxdb.Active,
+			},
+			URL: "https://hooks.slack.com/services/1",
+		}
+	}
+	ops := notification.TagRule{Tag: notification.Tag{Key: "team", Value: "ops"}, Operator: notification.Equal}
+	dev := notification.TagRule{Tag: notification.Tag{Key: "team", Value: "dev"}, Operator: notification.Equal}
+	endpointID, deletedID := influxdb.ID(30), influxdb.ID(39)
+
+	checks := []influxdb.Check{
+		newCheck(10, "cpu", influxdb.Active, notification.Tag{Key: "team", Value: "ops"}),
+		newCheck(11, "disk", influxdb.Active, notification.Tag{Key: "team", Value: "dev"}),
+		newCheck(12, "mem", influxdb.Active, notification.Tag{Key: "team", Value: "qa"}),
+		// inactive checks write no statuses.
+		newCheck(13, "net", influxdb.Inactive),
+	}
+	rules := []influxdb.NotificationRule{
+		newRule(20, "page ops", influxdb.Active, &endpointID, ops),
+		newRule(21, "page dev", influxdb.Inactive, &endpointID, dev),
+		newRule(22, "page nobody", influxdb.Active, nil, dev),
+		newRule(23, "page deleted", influxdb.Inactive, &deletedID, dev),
+	}
+	endpoints := []influxdb.NotificationEndpoint{
+		newEndpoint(endpointID, "slack"),
+		newEndpoint(31, "unused"),
+	}
+	for _, c := range checks {
+		if err := svc.PutCheck(ctx, c); err != nil {
+			t.Fatalf("failed to populate check: %v", err)
+		}
+	}
+	for _, nr := range rules {
+		if err := svc.PutNotificationRule(ctx, nr); err != nil {
+			t.Fatalf("failed to populate notification rule: %v", err)
+		}
+	}
+	for _, edp := range endpoints {
+		if err := svc.PutNotificationEndpoint(ctx, edp); err != nil {
+			t.Fatalf("failed to populate notification endpoint: %v", err)
+		}
+	}
+
+	r, err := svc.FindOrphanedAlertingResources(ctx, orgID)
+	if err != nil {
+		t.Fatalf("failed to find orphaned alerting resources: %v", err)
+	}
+	want := &influxdb.OrphanedAlertingReport{
+		OrgID: orgID,
+		Checks: []influxdb.OrphanedResource{
+			{
+				ID:           11,
+				Name:         "disk",
+				Reason:       influxdb.OrphanedCheckNoRule,
+				SuggestedFix: "activate the notification rule page dev, which matches the tags of the check",
+			},
+			{
+				ID:           12,
+				Name:         "mem",
+				Reason:       influxdb.OrphanedCheckNoRule,
+				SuggestedFix: "create a notification rule whose tag rules match the tags of the check",
+			},
+		},
+		NotificationRules: []influxdb.OrphanedResource{
+			{
+				ID:           22,
+				Name:         "page nobody",
+				Reason:       influxdb.OrphanedRuleNoEndpoint,
+				SuggestedFix: "set the endpointID of the rule to a notification endpoint of the organization",
+			},
+			{
+				ID:           23,
+				Name:         "page deleted",
+				Reason:       influxdb.OrphanedRuleMissingEndpoint,
+				SuggestedFix: "update the rule to send to an existing notification endpoint instead of 0000000000000027",
+			},
+		},
+		NotificationEndpoints: []influxdb.OrphanedResource{
+			{
+				ID:           31,
+				Name:         "unused",
+				Reason:       influxdb.OrphanedEndpointNoRule,
+				SuggestedFix: "create a notification rule sending to the endpoint, or delete the endpoint",
+			},
+		},
+	}
+	if diff := cmp.Diff(r, want); diff != "" {
+		t.Errorf("orphaned alerting report is different -got/+want\ndiff %s", diff)
+	}
+
+	if _, err := svc.FindOrphanedAlertingResources(ctx, influxdb.ID(2)); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Errorf("expected not found error for a missing org, got %v", err)
+	}
+}
+
+func TestService_DiagnoseAlerting(t *testing.T) {
+	ctx := context.Background()
+	svc, user, org := newTestServiceWithOrg(t)
+	now := time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC)
+	svc.TimeGenerator = mock.TimeGenerator{FakeValue: now}
+	newCheck := func(name string) *check.SLO {
+		c := &check.SLO{
+			Base: check.Base{
+				Name:   name,
+				OrgID:  org.ID,
+				Status: influxdb.Active,
+				Every:  influxdb.Duration{Duration: time.Minute},
+				Query: influxdb.DashboardQuery{
+					Text: `from(bucket: "telegraf") |> filter(fn: (r) => r._measurement == "http")`,
+				},
+				Tags: []notification.Tag{{Key: "team", Value: "ops"}},
+			},
+			Objective:  0.999,
+			Window:     influxdb.Duration{Duration: 30 * 24 * time.Hour},
+			Indicator:  check.ErrorRatioIndicator,
+			ErrorField: "errors",
+			TotalField: "requests",
+		}
+		if err := svc.CreateCheck(ctx, c, user.ID); err != nil {
+			t.Fatalf("failed to create check: %v", err)
+		}
+		return c
+	}
+
+	dangling := newCheck("dangling")
+	if err := svc.DeleteTask(ctx, dangling.TaskID); err != nil {
+		t.Fatalf("failed to delete task: %v", err)
+	}
+	newCheck("late")
+
+	endpoints := []influxdb.NotificationEndpoint{
+		&endpoint.PagerDuty{
+			Base:       endpoint.Base{ID: 30, Name: "pagerduty", OrgID: org.ID, Status: influxdb.Active},
+			RoutingKey: influxdb.SecretField{Key: "30-routing-key"},
+		},
+		&endpoint.Slack{
+			Base: endpoint.Base{ID: 31, Name: "disabled", OrgID: org.ID, Status: influxdb.Inactive},
+			URL:  "https://hooks.slack.com/services/1",
+		},
+		&endpoint.Slack{
+			Base: endpoint.Base{ID: 32, Name: "asleep", OrgID: org.ID, Status: influxdb.Active, UserID: &user.ID},
+			URL:  "https://hooks.slack.com/services/2",
+		},
+	}
+	for _, edp := range endpoints {
+		if err := svc.PutNotificationEndpoint(ctx, edp); err != nil {
+			t.Fatalf("failed to populate notification endpoint: %v", err)
+		}
+	}
+	err := svc.PutNotificationPreferences(ctx, &influxdb.NotificationPreferences{
+		UserID: user.ID,
+		QuietHours: &influxdb.QuietHours{
+			Start:  "07:30",
+			End:    "07:00",
+			Action: influxdb.QuietHoursSuppress,
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to populate notification preferences: %v", err)
+	}
+
+	newRule := func(id influxdb.ID, name string, endpointID *influxdb.ID) influxdb.NotificationRule {
+		return &rule.Slack{
+			Base: rule.Base{
+				ID:              id,
+				Name:            name,
+				OrgID:           org.ID,
+				AuthorizationID: influxdb.ID(99),
+				Status:          influxdb.Active,
+				EndpointID:      endpointID,
+				TagRules:        []notification.TagRule{{Tag: notification.Tag{Key: "team", Value: "ops"}, Operator: notification.Equal}},
+			},
+			MessageTemplate: "msg",
+		}
+	}
+	pagerdutyID, disabledID, asleepID := influxdb.ID(30), influxdb.ID(31), influxdb.ID(32)
+	for _, nr := range []influxdb.NotificationRule{
+		newRule(20, "page ops", &pagerdutyID),
+		newRule(21, "muted", &disabledID),
+		newRule(22, "page nobody", nil),
+		newRule(23, "wake up", &asleepID),
+	} {
+		if err := svc.PutNotificationRule(ctx, nr); err != nil {
+			t.Fatalf("failed to populate notification rule: %v", err)
+		}
+	}
+
+	// the task of late last completed when it was created.
+	svc.TimeGenerator = mock.TimeGenerator{FakeValue: now.Add(10 * time.Minute)}
+	d, err := svc.DiagnoseAlerting(ctx, org.ID)
+	if err != nil {
+		t.Fatalf("failed to diagnose alerting: %v", err)
+	}
+
+	type finding struct {
+		Priority int
+		Kind     string
+		Name     string
+	}
+	var got []finding
+	for _, f := range d.Findings {
+		got = append(got, finding{Priority: f.Priority, Kind: f.Kind, Name: f.Name})
+	}
+	want := []finding{
+		{Priority: influxdb.AlertingFindingCritical, Kind: influxdb.AlertingFindingRuleWithoutEndpoint, Name: "page nobody"},
+		{Priority: influxdb.AlertingFindingCritical, Kind: influxdb.AlertingFindingDanglingTask, Name: "dangling"},
+		{Priority: influxdb.AlertingFindingCritical, Kind: influxdb.AlertingFindingMissingSecret, Name: "pagerduty"},
+		{Priority: influxdb.AlertingFindingCritical, Kind: influxdb.AlertingFindingMutedForever, Name: "muted"},
+		{Priority: influxdb.AlertingFindingWarning, Kind: influxdb.AlertingFindingSchedulerLag, Name: "late"},
+		{Priority: influxdb.AlertingFindingWarning, Kind: influxdb.AlertingFindingMutedForever, Name: "asleep"},
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("alerting findings are different -got/+want\ndiff %s", diff)
+	}
+	if d.OrgID != org.ID || !d.DiagnosedAt.Equal(now.Add(10*time.Minute)) {
+		t.Errorf("unexpected diagnosis %+v", d)
+	}
+}
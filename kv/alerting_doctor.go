@@ -0,0 +1,236 @@
+package kv
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/influxdata/influxdb"
+)
+
+// mutedForeverQuietHours is the length of the daily quiet hours which mute
+// the notifications of a user for good.
+const mutedForeverQuietHours = 23 * time.Hour
+
+// userAddressedEndpoint is a notification endpoint addressed to a user,
+// whose notification preferences apply to it.
+type userAddressedEndpoint interface {
+	GetUserID() *influxdb.ID
+}
+
+// DiagnoseAlerting checks the whole alerting stack of an organization and
+// returns its problems, the most urgent first.
+func (s *Service) DiagnoseAlerting(ctx context.Context, orgID influxdb.ID) (*influxdb.AlertingDiagnosis, error) {
+	var (
+		d   *influxdb.AlertingDiagnosis
+		err error
+	)
+	err = s.kv.View(ctx, func(tx Tx) error {
+		d, err = s.diagnoseAlerting(ctx, tx, orgID)
+		return err
+	})
+	return d, err
+}
+
+func (s *Service) diagnoseAlerting(ctx context.Context, tx Tx, orgID influxdb.ID) (*influxdb.AlertingDiagnosis, error) {
+	report, err := s.findOrphanedAlertingResources(ctx, tx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &influxdb.AlertingDiagnosis{
+		OrgID:       orgID,
+		DiagnosedAt: s.TimeGenerator.Now().UTC(),
+		Findings:    []influxdb.AlertingFinding{},
+	}
+	add := func(priority int, kind string, rt influxdb.ResourceType, id influxdb.ID, name, problem, fix string) {
+		d.Findings = append(d.Findings, influxdb.AlertingFinding{
+			Priority:     priority,
+			Kind:         kind,
+			ResourceType: rt,
+			ID:           id,
+			Name:         name,
+			Problem:      problem,
+			Fix:          fix,
+		})
+	}
+
+	for _, r := range report.NotificationRules {
+		add(influxdb.AlertingFindingCritical, influxdb.AlertingFindingRuleWithoutEndpoint, influxdb.NotificationRuleResourceType, r.ID, r.Name, r.Reason, r.SuggestedFix)
+	}
+	for _, r := range report.Checks {
+		add(influxdb.AlertingFindingInfo, influxdb.AlertingFindingOrphaned, influxdb.ChecksResourceType, r.ID, r.Name, r.Reason, r.SuggestedFix)
+	}
+	for _, r := range report.NotificationEndpoints {
+		add(influxdb.AlertingFindingInfo, influxdb.AlertingFindingOrphaned, influxdb.NotificationEndpointResourceType, r.ID, r.Name, r.Reason, r.SuggestedFix)
+	}
+
+	dangling, _, err := s.findCheckTaskInconsistencies(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range dangling {
+		if c.OrgID != orgID {
+			continue
+		}
+		add(influxdb.AlertingFindingCritical, influxdb.AlertingFindingDanglingTask, influxdb.ChecksResourceType, c.ID, c.Name, c.Reason,
+			"restart influxd with --check-task-reconcile-policy repair to recreate the task of the check")
+	}
+
+	if err := s.diagnoseSchedulerLag(ctx, tx, orgID, d.DiagnosedAt, add); err != nil {
+		return nil, err
+	}
+	if err := s.diagnoseEndpoints(ctx, tx, orgID, add); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(d.Findings, func(i, j int) bool {
+		return d.Findings[i].Priority < d.Findings[j].Priority
+	})
+	return d, nil
+}
+
+type addFindingFunc func(priority int, kind string, rt influxdb.ResourceType, id influxdb.ID, name, problem, fix string)
+
+// diagnoseSchedulerLag finds the active checks whose task last completed
+// more than two intervals ago.
+func (s *Service) diagnoseSchedulerLag(ctx context.Context, tx Tx, orgID influxdb.ID, now time.Time, add addFindingFunc) error {
+	var err error
+	ferr := s.forEachCheck(ctx, tx, &orgID, func(c influxdb.Check) bool {
+		tc, ok := c.(taskCheck)
+		if !ok || c.GetStatus() != influxdb.Active || isArchivedCheck(c) || !tc.GetTaskID().Valid() {
+			return true
+		}
+		sc, ok := c.(scheduledCheck)
+		if !ok || sc.GetEvery() <= 0 {
+			return true
+		}
+
+		var t *influxdb.Task
+		t, err = s.findTaskByID(ctx, tx, tc.GetTaskID())
+		if influxdb.ErrorCode(err) == influxdb.ENotFound {
+			// reported as a dangling task.
+			err = nil
+			return true
+		}
+		if err != nil {
+			return false
+		}
+		if t.Status != influxdb.TaskStatusActive {
+			add(influxdb.AlertingFindingCritical, influxdb.AlertingFindingSchedulerLag, influxdb.ChecksResourceType, c.GetID(), c.GetName(),
+				"the check is active but its task is inactive",
+				"update the check to active again, which activates its task")
+			return true
+		}
+		latest, perr := time.Parse(time.RFC3339, t.LatestCompleted)
+		if perr != nil {
+			return true
+		}
+		lag := now.Sub(latest)
+		if lag <= 2*sc.GetEvery() {
+			return true
+		}
+		add(influxdb.AlertingFindingWarning, influxdb.AlertingFindingSchedulerLag, influxdb.ChecksResourceType, c.GetID(), c.GetName(),
+			fmt.Sprintf("the task of the check last completed %s ago, every %s", lag.Truncate(time.Second), sc.GetEvery()),
+			"check the logs of the task scheduler and the runs of the task, the scheduler may be overloaded or stopped")
+		return true
+	})
+	if ferr != nil {
+		return ferr
+	}
+	return err
+}
+
+// diagnoseEndpoints finds the endpoints whose secrets aren't stored, and the
+// rules and endpoints whose notifications are always muted.
+func (s *Service) diagnoseEndpoints(ctx context.Context, tx Tx, orgID influxdb.ID, add addFindingFunc) error {
+	endpoints := make(map[influxdb.ID]influxdb.NotificationEndpoint)
+	err := s.forEachNotificationEndpoint(ctx, tx, false, func(edp influxdb.NotificationEndpoint) bool {
+		if edp.GetOrgID() == orgID {
+			endpoints[edp.GetID()] = edp
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	ids := make([]influxdb.ID, 0, len(endpoints))
+	for id := range endpoints {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	for _, id := range ids {
+		edp := endpoints[id]
+		for _, sf := range edp.SecretFields() {
+			if _, ok := sf.Reference(); ok || sf.Key == "" {
+				// the secrets of external stores are resolved when sending.
+				continue
+			}
+			_, err := s.loadSecret(ctx, tx, orgID, sf.Key)
+			if err == nil {
+				continue
+			}
+			if influxdb.ErrorCode(err) != influxdb.ENotFound {
+				return err
+			}
+			add(influxdb.AlertingFindingCritical, influxdb.AlertingFindingMissingSecret, influxdb.NotificationEndpointResourceType, edp.GetID(), edp.GetName(),
+				fmt.Sprintf("the secret %s of the endpoint isn't stored", sf.Key),
+				"update the endpoint with the value of its secret")
+		}
+
+		u, ok := edp.(userAddressedEndpoint)
+		if !ok || u.GetUserID() == nil || edp.GetStatus() != influxdb.Active {
+			continue
+		}
+		prefs, err := s.findNotificationPreferences(ctx, tx, *u.GetUserID())
+		if influxdb.ErrorCode(err) == influxdb.ENotFound {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		q := prefs.QuietHours
+		if q == nil || q.Action != influxdb.QuietHoursSuppress {
+			continue
+		}
+		if span, ok := quietHoursSpan(*q); ok && span >= mutedForeverQuietHours {
+			add(influxdb.AlertingFindingWarning, influxdb.AlertingFindingMutedForever, influxdb.NotificationEndpointResourceType, edp.GetID(), edp.GetName(),
+				fmt.Sprintf("the quiet hours of the user %s suppress the notifications of the endpoint %s a day, from %s to %s", *u.GetUserID(), span, q.Start, q.End),
+				"shorten the quiet hours of the user, or defer the notifications instead of suppressing them")
+		}
+	}
+
+	return s.forEachNotificationRule(ctx, tx, false, func(nr influxdb.NotificationRule) bool {
+		routed, ok := nr.(routedNotificationRule)
+		if !ok || nr.GetOrgID() != orgID || nr.GetStatus() != influxdb.Active || routed.GetEndpointID() == nil {
+			return true
+		}
+		edp, ok := endpoints[*routed.GetEndpointID()]
+		if !ok || edp.GetStatus() == influxdb.Active {
+			return true
+		}
+		add(influxdb.AlertingFindingCritical, influxdb.AlertingFindingMutedForever, influxdb.NotificationRuleResourceType, nr.GetID(), nr.GetName(),
+			fmt.Sprintf("the rule is active but its notification endpoint %s is inactive, its notifications are muted", edp.GetName()),
+			"activate the notification endpoint, or update the rule to send to an active endpoint")
+		return true
+	})
+}
+
+// quietHoursSpan returns the length of the daily quiet hours.
+func quietHoursSpan(q influxdb.QuietHours) (time.Duration, bool) {
+	start, err := time.Parse("15:04", q.Start)
+	if err != nil {
+		return 0, false
+	}
+	end, err := time.Parse("15:04", q.End)
+	if err != nil {
+		return 0, false
+	}
+	span := end.Sub(start)
+	if span <= 0 {
+		span += 24 * time.Hour
+	}
+	return span, true
+}
@@ -0,0 +1,235 @@
+package kv
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/influxdata/influxdb"
+	icontext "github.com/influxdata/influxdb/context"
+)
+
+var (
+	alertingPauseBucket = []byte("alertingpausesv1")
+)
+
+var _ influxdb.AlertingPauseService = (*Service)(nil)
+
+func (s *Service) initializeAlertingPauses(ctx context.Context, tx Tx) error {
+	if _, err := tx.Bucket(alertingPauseBucket); err != nil {
+		return err
+	}
+	return nil
+}
+
+const (
+	alertingPausedEvent  = "Alerting Paused"
+	alertingResumedEvent = "Alerting Resumed"
+)
+
+// FindAlertingPause returns the pause of the alerting of an organization.
+func (s *Service) FindAlertingPause(ctx context.Context, orgID influxdb.ID) (*influxdb.AlertingPause, error) {
+	var (
+		p   *influxdb.AlertingPause
+		err error
+	)
+	err = s.kv.View(ctx, func(tx Tx) error {
+		p, err = s.findAlertingPause(ctx, tx, orgID)
+		return err
+	})
+	return p, err
+}
+
+func (s *Service) findAlertingPause(ctx context.Context, tx Tx, orgID influxdb.ID) (*influxdb.AlertingPause, error) {
+	encID, err := orgID.Encode()
+	if err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Err:  err,
+		}
+	}
+	bucket, err := tx.Bucket(alertingPauseBucket)
+	if err != nil {
+		return nil, UnavailableAlertingSettingsStoreError(err)
+	}
+	v, err := bucket.Get(encID)
+	if IsNotFound(err) {
+		return nil, &influxdb.Error{
+			Code: influxdb.ENotFound,
+			Msg:  "the alerting of the organization isn't paused",
+		}
+	}
+	if err != nil {
+		return nil, InternalAlertingSettingsStoreError(err)
+	}
+	p := &influxdb.AlertingPause{}
+	if err := json.Unmarshal(v, p); err != nil {
+		return nil, InternalAlertingSettingsStoreError(err)
+	}
+	return p, nil
+}
+
+// PauseAlerting sets inactive the active checks of an organization, and
+// their tasks, in a single transaction. The notifications of the
+// organization are suppressed by the alerting engine while it is paused.
+func (s *Service) PauseAlerting(ctx context.Context, orgID influxdb.ID, reason string) (*influxdb.AlertingPause, error) {
+	var p *influxdb.AlertingPause
+	err := s.kv.Update(ctx, func(tx Tx) error {
+		var err error
+		p, err = s.pauseAlerting(ctx, tx, orgID, reason)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (s *Service) pauseAlerting(ctx context.Context, tx Tx, orgID influxdb.ID, reason string) (*influxdb.AlertingPause, error) {
+	if _, err := s.findOrganizationByID(ctx, tx, orgID); err != nil {
+		return nil, err
+	}
+	if _, err := s.findAlertingPause(ctx, tx, orgID); err == nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EConflict,
+			Msg:  "the alerting of the organization is already paused",
+		}
+	} else if influxdb.ErrorCode(err) != influxdb.ENotFound {
+		return nil, err
+	}
+
+	now := s.TimeGenerator.Now()
+	p := &influxdb.AlertingPause{
+		OrgID:    orgID,
+		PausedAt: now,
+		Reason:   reason,
+		CheckIDs: []influxdb.ID{},
+	}
+	if a, err := icontext.GetAuthorizer(ctx); err == nil {
+		p.UserID = a.GetUserID()
+	}
+
+	var paused []influxdb.Check
+	err := s.forEachCheck(ctx, tx, &orgID, func(c influxdb.Check) bool {
+		if _, ok := c.(pausableCheck); ok && c.GetStatus() == influxdb.Active && !isArchivedCheck(c) {
+			paused = append(paused, c)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range paused {
+		c.SetStatus(influxdb.Inactive)
+		c.(pausableCheck).SetPauseReason(p.CheckPauseReason())
+		c.SetUpdatedAt(now)
+		if err := s.updateCheckTaskStatus(ctx, tx, c); err != nil {
+			return nil, err
+		}
+		if err := s.putCheck(ctx, tx, c); err != nil {
+			return nil, err
+		}
+		p.CheckIDs = append(p.CheckIDs, c.GetID())
+	}
+
+	if err := s.putAlertingPause(ctx, tx, p); err != nil {
+		return nil, err
+	}
+	event := alertingPausedEvent
+	if reason != "" {
+		event += ": " + reason
+	}
+	if err := s.appendAlertingSettingsEventToLog(ctx, tx, orgID, event, now); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (s *Service) putAlertingPause(ctx context.Context, tx Tx, p *influxdb.AlertingPause) error {
+	encID, err := p.OrgID.Encode()
+	if err != nil {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Err:  err,
+		}
+	}
+	v, err := json.Marshal(p)
+	if err != nil {
+		return InternalAlertingSettingsStoreError(err)
+	}
+	bucket, err := tx.Bucket(alertingPauseBucket)
+	if err != nil {
+		return UnavailableAlertingSettingsStoreError(err)
+	}
+	if err := bucket.Put(encID, v); err != nil {
+		return UnavailableAlertingSettingsStoreError(err)
+	}
+	return nil
+}
+
+// ResumeAlerting reactivates the checks set inactive by the pause of the
+// alerting of an organization which are still inactive with the reason of
+// the pause, so the checks changed during the pause keep their change. A
+// check whose query reads a bucket deleted during the pause stays inactive,
+// with the reason it can't be reactivated.
+func (s *Service) ResumeAlerting(ctx context.Context, orgID influxdb.ID) (*influxdb.AlertingPause, error) {
+	var p *influxdb.AlertingPause
+	err := s.kv.Update(ctx, func(tx Tx) error {
+		var err error
+		p, err = s.resumeAlerting(ctx, tx, orgID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (s *Service) resumeAlerting(ctx context.Context, tx Tx, orgID influxdb.ID) (*influxdb.AlertingPause, error) {
+	p, err := s.findAlertingPause(ctx, tx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.TimeGenerator.Now()
+	for _, id := range p.CheckIDs {
+		c, err := s.findCheckByID(ctx, tx, id)
+		if influxdb.ErrorCode(err) == influxdb.ENotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		pc, ok := c.(pausableCheck)
+		if !ok || c.GetStatus() != influxdb.Inactive || pc.GetPauseReason() != p.CheckPauseReason() {
+			continue
+		}
+		c.SetStatus(influxdb.Active)
+		if err := s.resumeCheck(ctx, tx, pc.GetPauseReason(), c); err != nil {
+			if influxdb.ErrorCode(err) != influxdb.EInvalid {
+				return nil, err
+			}
+			c.SetStatus(influxdb.Inactive)
+			pc.SetPauseReason(influxdb.ErrorMessage(err))
+		}
+		c.SetUpdatedAt(now)
+		if err := s.updateCheckTaskStatus(ctx, tx, c); err != nil {
+			return nil, err
+		}
+		if err := s.putCheck(ctx, tx, c); err != nil {
+			return nil, err
+		}
+	}
+
+	encID, _ := orgID.Encode()
+	bucket, err := tx.Bucket(alertingPauseBucket)
+	if err != nil {
+		return nil, UnavailableAlertingSettingsStoreError(err)
+	}
+	if err := bucket.Delete(encID); err != nil {
+		return nil, UnavailableAlertingSettingsStoreError(err)
+	}
+	if err := s.appendAlertingSettingsEventToLog(ctx, tx, orgID, alertingResumedEvent, now); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
@@ -0,0 +1,124 @@
+package kv_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	icontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/notification/check"
+)
+
+func TestService_PauseAlerting(t *testing.T) {
+	ctx := context.Background()
+	svc, user, org := newTestServiceWithOrg(t)
+	ctx = icontext.SetAuthorizer(ctx, &influxdb.Authorization{UserID: user.ID, OrgID: org.ID})
+
+	newCheck := func(name string, status influxdb.Status) *check.Deadman {
+		c := &check.Deadman{
+			Base: check.Base{
+				Name:   name,
+				OrgID:  org.ID,
+				Status: status,
+				Every:  influxdb.Duration{Duration: time.Minute},
+				Query:  influxdb.DashboardQuery{Text: `from(bucket: "telegraf") |> range(start: -1m)`},
+			},
+			TimeSince: 90,
+		}
+		if err := svc.CreateCheck(ctx, c, user.ID); err != nil {
+			t.Fatalf("failed to create check: %v", err)
+		}
+		return c
+	}
+	cpu := newCheck("cpu", influxdb.Active)
+	mem := newCheck("mem", influxdb.Active)
+	disk := newCheck("disk", influxdb.Inactive)
+
+	if _, err := svc.FindAlertingPause(ctx, org.ID); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Fatalf("expected the alerting not to be paused, got %v", err)
+	}
+	if _, err := svc.ResumeAlerting(ctx, org.ID); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Fatalf("expected resuming the alerting which isn't paused not to be found, got %v", err)
+	}
+
+	p, err := svc.PauseAlerting(ctx, org.ID, "database migration")
+	if err != nil {
+		t.Fatalf("failed to pause alerting: %v", err)
+	}
+	if p.UserID != user.ID || p.Reason != "database migration" || p.PausedAt.IsZero() {
+		t.Errorf("expected the pause to record who paused it, when and why, got %+v", p)
+	}
+	if len(p.CheckIDs) != 2 {
+		t.Errorf("expected the 2 active checks to be paused, got %v", p.CheckIDs)
+	}
+	for _, c := range []*check.Deadman{cpu, mem, disk} {
+		got, err := svc.FindCheckByID(ctx, c.ID)
+		if err != nil {
+			t.Fatalf("failed to find check: %v", err)
+		}
+		if got.GetStatus() != influxdb.Inactive {
+			t.Errorf("expected check %s to be inactive, got %s", c.Name, got.GetStatus())
+		}
+		if !c.TaskID.Valid() {
+			continue
+		}
+		task, err := svc.FindTaskByID(ctx, c.TaskID)
+		if err != nil {
+			t.Fatalf("failed to find the task of the check: %v", err)
+		}
+		if task.Status != string(influxdb.Inactive) {
+			t.Errorf("expected the task of check %s to be inactive, got %s", c.Name, task.Status)
+		}
+	}
+	if _, err := svc.PauseAlerting(ctx, org.ID, ""); influxdb.ErrorCode(err) != influxdb.EConflict {
+		t.Fatalf("expected pausing the paused alerting to conflict, got %v", err)
+	}
+
+	// a check reactivated and set inactive again during the pause keeps its state.
+	active, inactive := influxdb.Active, influxdb.Inactive
+	if _, err := svc.PatchCheck(ctx, mem.ID, influxdb.CheckUpdate{Status: &active}); err != nil {
+		t.Fatalf("failed to reactivate check: %v", err)
+	}
+	if _, err := svc.PatchCheck(ctx, mem.ID, influxdb.CheckUpdate{Status: &inactive}); err != nil {
+		t.Fatalf("failed to deactivate check: %v", err)
+	}
+
+	if _, err := svc.ResumeAlerting(ctx, org.ID); err != nil {
+		t.Fatalf("failed to resume alerting: %v", err)
+	}
+	for _, tt := range []struct {
+		c    *check.Deadman
+		want influxdb.Status
+	}{
+		{cpu, influxdb.Active},
+		{mem, influxdb.Inactive},
+		{disk, influxdb.Inactive},
+	} {
+		got, err := svc.FindCheckByID(ctx, tt.c.ID)
+		if err != nil {
+			t.Fatalf("failed to find check: %v", err)
+		}
+		if got.GetStatus() != tt.want || got.(*check.Deadman).PauseReason != "" {
+			t.Errorf("expected check %s to be %s without a pause reason, got %s and %q", tt.c.Name, tt.want, got.GetStatus(), got.(*check.Deadman).PauseReason)
+		}
+	}
+	if _, err := svc.FindAlertingPause(ctx, org.ID); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Fatalf("expected the alerting to be resumed, got %v", err)
+	}
+
+	log, _, err := svc.GetAlertingSettingsOperationLog(ctx, org.ID, influxdb.FindOptions{})
+	if err != nil {
+		t.Fatalf("failed to get the operation log: %v", err)
+	}
+	var events []string
+	for _, e := range log {
+		events = append(events, e.Description)
+		if e.UserID != user.ID {
+			t.Errorf("expected event %q by the user, got %s", e.Description, e.UserID)
+		}
+	}
+	if len(events) != 2 || events[0] != "Alerting Paused: database migration" || events[1] != "Alerting Resumed" {
+		t.Errorf("expected the pause and resume to be logged, got %v", events)
+	}
+}
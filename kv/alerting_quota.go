@@ -0,0 +1,91 @@
+package kv
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.AlertingQuotaService = (*Service)(nil)
+
+// alertingQuotaResourceTypes are the resource types with an alerting quota.
+var alertingQuotaResourceTypes = []influxdb.ResourceType{
+	influxdb.ChecksResourceType,
+	influxdb.NotificationRuleResourceType,
+	influxdb.NotificationEndpointResourceType,
+}
+
+// FindAlertingQuotaWarnings returns the warnings of the alerting quotas of
+// the resource types an organization is near or at, of every type if none.
+func (s *Service) FindAlertingQuotaWarnings(ctx context.Context, orgID influxdb.ID, rts ...influxdb.ResourceType) ([]influxdb.QuotaWarning, error) {
+	if len(rts) == 0 {
+		rts = alertingQuotaResourceTypes
+	}
+	var ws []influxdb.QuotaWarning
+	err := s.kv.View(ctx, func(tx Tx) error {
+		if _, err := s.findOrganizationByID(ctx, tx, orgID); err != nil {
+			return err
+		}
+		for _, rt := range rts {
+			if s.Config.AlertingQuotas.Quota(rt) == 0 {
+				continue
+			}
+			used, err := s.countAlertingResources(ctx, tx, orgID, rt)
+			if err != nil {
+				return err
+			}
+			if w := s.Config.AlertingQuotas.Warning(rt, used); w != nil {
+				ws = append(ws, *w)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ws, nil
+}
+
+// enforceAlertingQuota returns an error if an organization can't create n
+// more resources of a type within its quota.
+func (s *Service) enforceAlertingQuota(ctx context.Context, tx Tx, orgID influxdb.ID, rt influxdb.ResourceType, n int) error {
+	if s.Config.AlertingQuotas.Quota(rt) == 0 {
+		return nil
+	}
+	used, err := s.countAlertingResources(ctx, tx, orgID, rt)
+	if err != nil {
+		return err
+	}
+	return s.Config.AlertingQuotas.Enforce(rt, used+n-1)
+}
+
+// countAlertingResources returns the number of the resources of a type of an
+// organization counted against its quota.
+func (s *Service) countAlertingResources(ctx context.Context, tx Tx, orgID influxdb.ID, rt influxdb.ResourceType) (int, error) {
+	n := 0
+	var err error
+	switch rt {
+	case influxdb.ChecksResourceType:
+		err = s.forEachCheck(ctx, tx, &orgID, func(c influxdb.Check) bool {
+			if !isArchivedCheck(c) {
+				n++
+			}
+			return true
+		})
+	case influxdb.NotificationRuleResourceType:
+		err = s.forEachNotificationRule(ctx, tx, false, func(nr influxdb.NotificationRule) bool {
+			if nr.GetOrgID() == orgID {
+				n++
+			}
+			return true
+		})
+	case influxdb.NotificationEndpointResourceType:
+		err = s.forEachNotificationEndpoint(ctx, tx, false, func(edp influxdb.NotificationEndpoint) bool {
+			if edp.GetOrgID() == orgID {
+				n++
+			}
+			return true
+		})
+	}
+	return n, err
+}
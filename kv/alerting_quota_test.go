@@ -0,0 +1,102 @@
+package kv_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/kv"
+	"github.com/influxdata/influxdb/notification/endpoint"
+)
+
+func TestService_AlertingQuotas(t *testing.T) {
+	ctx := context.Background()
+	svc, user, org := newTestServiceWithOrg(t, kv.ServiceConfig{
+		AlertingQuotas: influxdb.AlertingQuotas{
+			Checks:                4,
+			NotificationEndpoints: 2,
+			WarnPercent:           50,
+		},
+	})
+
+	cpu := newDeadman(org.ID, "cpu")
+	if err := svc.CreateCheck(ctx, cpu, user.ID); err != nil {
+		t.Fatalf("failed to create check: %v", err)
+	}
+	ws, err := svc.FindAlertingQuotaWarnings(ctx, org.ID)
+	if err != nil {
+		t.Fatalf("failed to find the quota warnings: %v", err)
+	}
+	if len(ws) != 0 {
+		t.Errorf("expected no warnings under the warn percent, got %v", ws)
+	}
+
+	if err := svc.CreateCheck(ctx, newDeadman(org.ID, "mem"), user.ID); err != nil {
+		t.Fatalf("failed to create check: %v", err)
+	}
+	if err := svc.CreateCheck(ctx, newDeadman(org.ID, "disk"), user.ID); err != nil {
+		t.Fatalf("failed to create check: %v", err)
+	}
+	ws, err = svc.FindAlertingQuotaWarnings(ctx, org.ID)
+	if err != nil {
+		t.Fatalf("failed to find the quota warnings: %v", err)
+	}
+	want := []influxdb.QuotaWarning{{
+		Resource: influxdb.ChecksResourceType,
+		Used:     3,
+		Quota:    4,
+		Message:  "the organization uses 3 of its quota of 4 checks",
+	}}
+	if diff := cmp.Diff(want, ws); diff != "" {
+		t.Errorf("unexpected quota warnings -want/+got\n%s", diff)
+	}
+
+	// the bulk creation over the quota creates nothing.
+	err = svc.CreateChecks(ctx, influxdb.CheckBulkCreate{
+		OrgID:  org.ID,
+		Checks: []influxdb.Check{newDeadman(org.ID, "net"), newDeadman(org.ID, "swap")},
+	}, user.ID)
+	if influxdb.ErrorCode(err) != influxdb.EForbidden {
+		t.Errorf("expected the bulk creation over the quota to be forbidden, got %v", err)
+	}
+	if err := svc.CreateCheck(ctx, newDeadman(org.ID, "net"), user.ID); err != nil {
+		t.Fatalf("failed to create the last check of the quota: %v", err)
+	}
+	if err := svc.CreateCheck(ctx, newDeadman(org.ID, "swap"), user.ID); influxdb.ErrorCode(err) != influxdb.EForbidden {
+		t.Errorf("expected the check over the quota to be forbidden, got %v", err)
+	}
+
+	// the archived checks aren't counted.
+	if _, err := svc.ArchiveCheck(ctx, cpu.ID); err != nil {
+		t.Fatalf("failed to archive check: %v", err)
+	}
+	swap := newDeadman(org.ID, "swap")
+	if err := svc.CreateCheck(ctx, swap, user.ID); err != nil {
+		t.Fatalf("failed to create check in place of an archived one: %v", err)
+	}
+	if _, err := svc.UnarchiveCheck(ctx, cpu.ID); influxdb.ErrorCode(err) != influxdb.EForbidden {
+		t.Errorf("expected the unarchive over the quota to be forbidden, got %v", err)
+	}
+
+	for i, name := range []string{"slack", "ops", "oncall"} {
+		edp := &endpoint.Slack{
+			Base: endpoint.Base{Name: name, OrgID: org.ID, Status: influxdb.Active},
+			URL:  "https://hooks.slack.com/services/1",
+		}
+		err := svc.CreateNotificationEndpoint(ctx, edp, user.ID)
+		if i < 2 && err != nil {
+			t.Fatalf("failed to create notification endpoint: %v", err)
+		}
+		if i == 2 && influxdb.ErrorCode(err) != influxdb.EForbidden {
+			t.Errorf("expected the notification endpoint over the quota to be forbidden, got %v", err)
+		}
+	}
+	ws, err = svc.FindAlertingQuotaWarnings(ctx, org.ID, influxdb.NotificationEndpointResourceType)
+	if err != nil {
+		t.Fatalf("failed to find the quota warnings: %v", err)
+	}
+	if len(ws) != 1 || ws[0].Resource != influxdb.NotificationEndpointResourceType || ws[0].Used != 2 {
+		t.Errorf("expected a warning of the notification endpoints at their quota, got %v", ws)
+	}
+}
@@ -0,0 +1,302 @@
+package kv
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	icontext "github.com/influxdata/influxdb/context"
+)
+
+var (
+	alertingSettingsBucket = []byte("alertingsettingsv1")
+)
+
+var _ influxdb.AlertingSettingsService = (*Service)(nil)
+
+func (s *Service) initializeAlertingSettings(ctx context.Context, tx Tx) error {
+	if _, err := tx.Bucket(alertingSettingsBucket); err != nil {
+		return err
+	}
+	return nil
+}
+
+// UnavailableAlertingSettingsStoreError is used if we aren't able to interact with the
+// store, it means the store is not available at the moment (e.g. network).
+func UnavailableAlertingSettingsStoreError(err error) *influxdb.Error {
+	return &influxdb.Error{
+		Code: influxdb.EInternal,
+		Msg:  fmt.Sprintf("Unable to connect to alerting settings store service. Please try again; Err: %v", err),
+		Op:   "kv/alertingSettings",
+	}
+}
+
+// InternalAlertingSettingsStoreError is used when the error comes from an
+// internal system.
+func InternalAlertingSettingsStoreError(err error) *influxdb.Error {
+	return &influxdb.Error{
+		Code: influxdb.EInternal,
+		Msg:  fmt.Sprintf("Unknown internal alerting settings data error; Err: %v", err),
+		Op:   "kv/alertingSettings",
+	}
+}
+
+// FindAlertingSettings returns the alerting settings of an organization.
+func (s *Service) FindAlertingSettings(ctx context.Context, orgID influxdb.ID) (*influxdb.AlertingSettings, error) {
+	var (
+		as  *influxdb.AlertingSettings
+		err error
+	)
+	err = s.kv.View(ctx, func(tx Tx) error {
+		if _, err := s.findOrganizationByID(ctx, tx, orgID); err != nil {
+			return err
+		}
+		as, err = s.findAlertingSettings(ctx, tx, orgID)
+		return err
+	})
+	return as, err
+}
+
+// findAlertingSettings returns the alerting settings of an organization, the
+// zero settings if they were never set.
+func (s *Service) findAlertingSettings(ctx context.Context, tx Tx, orgID influxdb.ID) (*influxdb.AlertingSettings, error) {
+	encID, err := orgID.Encode()
+	if err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Err:  err,
+		}
+	}
+
+	bucket, err := tx.Bucket(alertingSettingsBucket)
+	if err != nil {
+		return nil, UnavailableAlertingSettingsStoreError(err)
+	}
+
+	v, err := bucket.Get(encID)
+	if IsNotFound(err) {
+		return &influxdb.AlertingSettings{OrgID: orgID}, nil
+	}
+	if err != nil {
+		return nil, InternalAlertingSettingsStoreError(err)
+	}
+
+	as := &influxdb.AlertingSettings{}
+	if err := json.Unmarshal(v, as); err != nil {
+		return nil, InternalAlertingSettingsStoreError(err)
+	}
+	return as, nil
+}
+
+// PutAlertingSettings creates or replaces the alerting settings of an organization.
+// The default endpoint and the status bucket must belong to the organization.
+func (s *Service) PutAlertingSettings(ctx context.Context, as *influxdb.AlertingSettings) error {
+	return s.kv.Update(ctx, func(tx Tx) error {
+		return s.putAlertingSettings(ctx, tx, as)
+	})
+}
+
+func (s *Service) putAlertingSettings(ctx context.Context, tx Tx, as *influxdb.AlertingSettings) error {
+	if err := as.Valid(); err != nil {
+		return err
+	}
+	if _, err := s.findOrganizationByID(ctx, tx, as.OrgID); err != nil {
+		return err
+	}
+	if as.DefaultEndpointID != nil {
+		edp, err := s.findNotificationEndpointByID(ctx, tx, *as.DefaultEndpointID)
+		if influxdb.ErrorCode(err) == influxdb.ENotFound || err == nil && edp.GetOrgID() != as.OrgID {
+			return &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "default notification endpoint not found in the organization",
+				Err:  err,
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if as.StatusBucket != "" {
+		if _, err := s.findBucketByName(ctx, tx, as.OrgID, as.StatusBucket); err != nil {
+			return &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  fmt.Sprintf("status bucket %s not found in the organization", as.StatusBucket),
+				Err:  err,
+			}
+		}
+	}
+
+	old, err := s.findAlertingSettings(ctx, tx, as.OrgID)
+	if err != nil {
+		return err
+	}
+	now := s.TimeGenerator.Now()
+	as.CreatedAt = now
+	if !old.CreatedAt.IsZero() {
+		as.CreatedAt = old.CreatedAt
+	}
+	as.UpdatedAt = now
+
+	encID, _ := as.OrgID.Encode()
+	v, err := json.Marshal(as)
+	if err != nil {
+		return InternalAlertingSettingsStoreError(err)
+	}
+	bucket, err := tx.Bucket(alertingSettingsBucket)
+	if err != nil {
+		return UnavailableAlertingSettingsStoreError(err)
+	}
+	if err := bucket.Put(encID, v); err != nil {
+		return UnavailableAlertingSettingsStoreError(err)
+	}
+
+	changes := as.Changes(*old)
+	if len(changes) == 0 {
+		return nil
+	}
+	return s.appendAlertingSettingsEventToLog(ctx, tx, as.OrgID, influxdb.AlertingSettingsChangedDescription(changes), now)
+}
+
+// DeleteAlertingSettings resets the alerting settings of an organization.
+func (s *Service) DeleteAlertingSettings(ctx context.Context, orgID influxdb.ID) error {
+	return s.kv.Update(ctx, func(tx Tx) error {
+		if _, err := s.findOrganizationByID(ctx, tx, orgID); err != nil {
+			return err
+		}
+		return s.deleteAlertingSettings(ctx, tx, orgID)
+	})
+}
+
+func (s *Service) deleteAlertingSettings(ctx context.Context, tx Tx, orgID influxdb.ID) error {
+	encID, err := orgID.Encode()
+	if err != nil {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Err:  err,
+		}
+	}
+	bucket, err := tx.Bucket(alertingSettingsBucket)
+	if err != nil {
+		return UnavailableAlertingSettingsStoreError(err)
+	}
+	if _, err := bucket.Get(encID); IsNotFound(err) {
+		return nil
+	}
+	if err := bucket.Delete(encID); err != nil {
+		return UnavailableAlertingSettingsStoreError(err)
+	}
+	return s.appendAlertingSettingsEventToLog(ctx, tx, orgID, alertingSettingsResetEvent, s.TimeGenerator.Now())
+}
+
+// GetAlertingSettingsOperationLog retrieves the operation log of the alerting settings of an organization.
+func (s *Service) GetAlertingSettingsOperationLog(ctx context.Context, orgID influxdb.ID, opts influxdb.FindOptions) ([]*influxdb.OperationLogEntry, int, error) {
+	log := []*influxdb.OperationLogEntry{}
+
+	err := s.kv.View(ctx, func(tx Tx) error {
+		key, err := encodeAlertingSettingsOperationLogKey(orgID)
+		if err != nil {
+			return err
+		}
+
+		return s.forEachLogEntry(ctx, tx, key, opts, func(v []byte, t time.Time) error {
+			e := &influxdb.OperationLogEntry{}
+			if err := json.Unmarshal(v, e); err != nil {
+				return err
+			}
+			e.Time = t
+
+			log = append(log, e)
+
+			return nil
+		})
+	})
+
+	if err != nil && err != errKeyValueLogBoundsNotFound {
+		return nil, 0, err
+	}
+
+	return log, len(log), nil
+}
+
+const alertingSettingsResetEvent = "Alerting Settings Reset"
+
+const alertingSettingsOperationLogKeyPrefix = "alertingsettings"
+
+func encodeAlertingSettingsOperationLogKey(orgID influxdb.ID) ([]byte, error) {
+	buf, err := orgID.Encode()
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(alertingSettingsOperationLogKeyPrefix), buf...), nil
+}
+
+// appendAlertingSettingsEventToLog records a change of the alerting settings
+// of an organization by the user of the authorizer on context, if any.
+func (s *Service) appendAlertingSettingsEventToLog(ctx context.Context, tx Tx, orgID influxdb.ID, st string, t time.Time) error {
+	e := &influxdb.OperationLogEntry{
+		Description: st,
+	}
+	if a, err := icontext.GetAuthorizer(ctx); err == nil {
+		e.UserID = a.GetUserID()
+	}
+
+	v, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	k, err := encodeAlertingSettingsOperationLogKey(orgID)
+	if err != nil {
+		return err
+	}
+
+	return s.addLogEntry(ctx, tx, k, v, t)
+}
+
+// defaultedCheck is a check scheduled by the alerting settings of its
+// organization when created without a schedule.
+type defaultedCheck interface {
+	scheduledCheck
+	GetCron() string
+	GetOffset() time.Duration
+}
+
+// applyAlertingSettingsToCheck schedules a check created without an interval
+// nor a cron, and without an offset, with the defaults of its organization.
+func (s *Service) applyAlertingSettingsToCheck(ctx context.Context, tx Tx, c influxdb.Check) error {
+	dc, ok := c.(defaultedCheck)
+	if !ok {
+		return nil
+	}
+	as, err := s.findAlertingSettings(ctx, tx, c.GetOrgID())
+	if err != nil {
+		return err
+	}
+	if dc.GetEvery() == 0 && dc.GetCron() == "" && as.DefaultEvery.Duration > 0 {
+		dc.SetEvery(as.DefaultEvery.Duration)
+	}
+	if dc.GetOffset() == 0 && as.DefaultOffset.Duration > 0 {
+		dc.SetOffset(as.DefaultOffset.Duration)
+	}
+	return nil
+}
+
+// applyAlertingSettingsToNotificationRule sends a notification rule created
+// without an endpoint nor routes to the default endpoint of its organization.
+func (s *Service) applyAlertingSettingsToNotificationRule(ctx context.Context, tx Tx, nr influxdb.NotificationRule) error {
+	rr, ok := nr.(reassignableNotificationRule)
+	if !ok || rr.GetEndpointID() != nil || len(rr.GetRoutes()) != 0 {
+		return nil
+	}
+	as, err := s.findAlertingSettings(ctx, tx, nr.GetOrgID())
+	if err != nil {
+		return err
+	}
+	if as.DefaultEndpointID != nil {
+		id := *as.DefaultEndpointID
+		rr.SetEndpointID(&id)
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package kv_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb"
+	icontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/check"
+	"github.com/influxdata/influxdb/notification/endpoint"
+	"github.com/influxdata/influxdb/notification/rule"
+	influxdbtesting "github.com/influxdata/influxdb/testing"
+)
+
+func TestService_AlertingSettings(t *testing.T) {
+	ctx := context.Background()
+	svc, user, org := newTestServiceWithOrg(t)
+	other := &influxdb.Organization{Name: "otherorg"}
+	if err := svc.CreateOrganization(ctx, other); err != nil {
+		t.Fatalf("failed to create org: %v", err)
+	}
+	if err := svc.CreateBucket(ctx, &influxdb.Bucket{OrgID: org.ID, Name: "statuses"}); err != nil {
+		t.Fatalf("failed to create bucket: %v", err)
+	}
+	token := "xoxb-secret"
+	edp := &endpoint.Slack{
+		Base:  endpoint.Base{Name: "slack", OrgID: org.ID, Status: influxdb.Active},
+		URL:   "https://hooks.slack.com/services/1",
+		Token: influxdb.SecretField{Value: &token},
+	}
+	if err := svc.CreateNotificationEndpoint(ctx, edp, user.ID); err != nil {
+		t.Fatalf("failed to create notification endpoint: %v", err)
+	}
+
+	ctx = icontext.SetAuthorizer(ctx, &influxdb.Authorization{UserID: user.ID, OrgID: org.ID})
+
+	got, err := svc.FindAlertingSettings(ctx, org.ID)
+	if err != nil {
+		t.Fatalf("failed to find the alerting settings: %v", err)
+	}
+	if diff := cmp.Diff(&influxdb.AlertingSettings{OrgID: org.ID}, got); diff != "" {
+		t.Errorf("expected the zero settings before they are set -want/+got\n%s", diff)
+	}
+
+	for _, as := range []*influxdb.AlertingSettings{
+		{OrgID: org.ID, DefaultEndpointID: influxdbtesting.IDPtr(edp.ID + 100)},
+		{OrgID: other.ID, DefaultEndpointID: &edp.ID},
+		{OrgID: org.ID, StatusBucket: "missing"},
+		{OrgID: org.ID, DedupWindow: influxdb.Duration{Duration: -time.Minute}},
+	} {
+		if err := svc.PutAlertingSettings(ctx, as); influxdb.ErrorCode(err) != influxdb.EInvalid {
+			t.Errorf("expected the settings %+v to be invalid, got %v", as, err)
+		}
+	}
+
+	now := time.Date(2019, 12, 1, 0, 0, 0, 0, time.UTC)
+	svc.TimeGenerator = mock.TimeGenerator{FakeValue: now}
+	settings := &influxdb.AlertingSettings{
+		OrgID:             org.ID,
+		DefaultEndpointID: &edp.ID,
+		DefaultEvery:      influxdb.Duration{Duration: 5 * time.Minute},
+		DefaultOffset:     influxdb.Duration{Duration: 30 * time.Second},
+		StatusBucket:      "statuses",
+		DedupWindow:       influxdb.Duration{Duration: time.Hour},
+	}
+	if err := svc.PutAlertingSettings(ctx, settings); err != nil {
+		t.Fatalf("failed to put the alerting settings: %v", err)
+	}
+	svc.TimeGenerator = mock.TimeGenerator{FakeValue: now.Add(time.Minute)}
+	settings.QuietHours = &influxdb.QuietHours{Start: "22:00", End: "07:00", Action: influxdb.QuietHoursDefer}
+	if err := svc.PutAlertingSettings(ctx, settings); err != nil {
+		t.Fatalf("failed to put the alerting settings: %v", err)
+	}
+	if got, err = svc.FindAlertingSettings(ctx, org.ID); err != nil {
+		t.Fatalf("failed to find the alerting settings: %v", err)
+	}
+	if !got.CreatedAt.Equal(now) || !got.UpdatedAt.Equal(now.Add(time.Minute)) {
+		t.Errorf("unexpected creation and update times %v and %v", got.CreatedAt, got.UpdatedAt)
+	}
+	if diff := cmp.Diff(settings, got); diff != "" {
+		t.Errorf("unexpected alerting settings -want/+got\n%s", diff)
+	}
+
+	c := &check.SLO{
+		Base: check.Base{
+			Name:  "cpu",
+			OrgID: org.ID,
+			Query: influxdb.DashboardQuery{Text: `from(bucket: "telegraf") |> filter(fn: (r) => r._measurement == "http")`},
+		},
+		Objective:        0.99,
+		Window:           influxdb.Duration{Duration: 7 * 24 * time.Hour},
+		Indicator:        check.LatencyIndicator,
+		LatencyThreshold: 0.3,
+	}
+	if err := svc.CreateCheck(ctx, c, user.ID); err != nil {
+		t.Fatalf("failed to create check without a schedule: %v", err)
+	}
+	if c.Every.Duration != 5*time.Minute || c.Offset.Duration != 30*time.Second {
+		t.Errorf("expected the check to be scheduled by the defaults, got every %v and offset %v", c.Every, c.Offset)
+	}
+
+	nr := &rule.Slack{
+		Base: rule.Base{
+			Name:            "ops",
+			OrgID:           org.ID,
+			AuthorizationID: 30,
+			Status:          influxdb.Active,
+			Every:           influxdb.Duration{Duration: time.Minute},
+			TagRules: []notification.TagRule{
+				{Tag: notification.Tag{Key: "team", Value: "ops"}, Operator: notification.Equal},
+			},
+		},
+		Channel:         "#ops",
+		MessageTemplate: "{{ .Level }}",
+	}
+	if err := svc.CreateNotificationRule(ctx, nr, user.ID); err != nil {
+		t.Fatalf("failed to create notification rule without an endpoint: %v", err)
+	}
+	if nr.EndpointID == nil || *nr.EndpointID != edp.ID {
+		t.Errorf("expected the notification rule to send to the default endpoint, got %v", nr.EndpointID)
+	}
+
+	svc.TimeGenerator = mock.TimeGenerator{FakeValue: now.Add(2 * time.Minute)}
+	if err := svc.DeleteAlertingSettings(ctx, org.ID); err != nil {
+		t.Fatalf("failed to delete the alerting settings: %v", err)
+	}
+	if got, err = svc.FindAlertingSettings(ctx, org.ID); err != nil {
+		t.Fatalf("failed to find the alerting settings: %v", err)
+	}
+	if diff := cmp.Diff(&influxdb.AlertingSettings{OrgID: org.ID}, got); diff != "" {
+		t.Errorf("expected the settings to be reset -want/+got\n%s", diff)
+	}
+
+	log, n, err := svc.GetAlertingSettingsOperationLog(ctx, org.ID, influxdb.DefaultOperationLogFindOptions)
+	if err != nil {
+		t.Fatalf("failed to get the operation log of the alerting settings: %v", err)
+	}
+	want := []*influxdb.OperationLogEntry{
+		{Description: "Alerting Settings Reset", UserID: user.ID, Time: now.Add(2 * time.Minute)},
+		{Description: "Alerting Settings Updated: quietHours", UserID: user.ID, Time: now.Add(time.Minute)},
+		{Description: "Alerting Settings Updated: defaultEndpointID, defaultEvery, defaultOffset, statusBucket, dedupWindow", UserID: user.ID, Time: now},
+	}
+	if n != len(want) {
+		t.Errorf("expected %d log entries, got %d", len(want), n)
+	}
+	if diff := cmp.Diff(want, log); diff != "" {
+		t.Errorf("unexpected operation log -want/+got\n%s", diff)
+	}
+
+	if _, err := svc.FindAlertingSettings(ctx, 1); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Errorf("expected the settings of a missing org not to be found, got %v", err)
+	}
+}
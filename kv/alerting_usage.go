@@ -0,0 +1,136 @@
+package kv
+
+import (
+	"context"
+	"time"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.AlertingUsageService = (*Service)(nil)
+
+// usageDayFormat is the format of the days of the usage.
+const usageDayFormat = "2006-01-02"
+
+// GetAlertingUsage counts the alerting resources of the organization of the
+// filter, or of every organization, and the notifications sent within the
+// range of the filter.
+func (s *Service) GetAlertingUsage(ctx context.Context, filter influxdb.UsageFilter) (*influxdb.AlertingUsage, error) {
+	var (
+		u   *influxdb.AlertingUsage
+		err error
+	)
+	err = s.kv.View(ctx, func(tx Tx) error {
+		u, err = s.getAlertingUsage(ctx, tx, filter)
+		return err
+	})
+	return u, err
+}
+
+func (s *Service) getAlertingUsage(ctx context.Context, tx Tx, filter influxdb.UsageFilter) (*influxdb.AlertingUsage, error) {
+	if filter.OrgID != nil {
+		if _, err := s.findOrganizationByID(ctx, tx, *filter.OrgID); err != nil {
+			return nil, err
+		}
+	}
+	inOrg := func(orgID influxdb.ID) bool {
+		return filter.OrgID == nil || orgID == *filter.OrgID
+	}
+
+	u := &influxdb.AlertingUsage{
+		OrgID:                 filter.OrgID,
+		Checks:                newAlertingResourceUsage(),
+		NotificationEndpoints: newAlertingResourceUsage(),
+		NotificationRules:     newAlertingResourceUsage(),
+	}
+	err := s.forEachCheck(ctx, tx, filter.OrgID, func(c influxdb.Check) bool {
+		if !isArchivedCheck(c) {
+			u.Checks.Add(c.Type(), c.GetStatus())
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = s.forEachNotificationEndpoint(ctx, tx, false, func(edp influxdb.NotificationEndpoint) bool {
+		if inOrg(edp.GetOrgID()) {
+			u.NotificationEndpoints.Add(edp.Type(), edp.GetStatus())
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = s.forEachNotificationRule(ctx, tx, false, func(nr influxdb.NotificationRule) bool {
+		if inOrg(nr.GetOrgID()) {
+			u.NotificationRules.Add(nr.Type(), nr.GetStatus())
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	days := make(map[string]int)
+	err = s.forEachStatusTrace(ctx, tx, func(t *influxdb.StatusTrace) bool {
+		if !inOrg(t.OrgID) || !inTimespan(t.Time, filter.Range) {
+			return true
+		}
+		for _, r := range t.Rules {
+			if r.Decision == influxdb.RuleNotified {
+				days[t.Time.UTC().Format(usageDayFormat)]++
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	u.Notifications = dailyUsage(days, filter.Range)
+	return u, nil
+}
+
+func newAlertingResourceUsage() influxdb.AlertingResourceUsage {
+	return influxdb.AlertingResourceUsage{
+		ByType:   map[string]int{},
+		ByStatus: map[influxdb.Status]int{},
+	}
+}
+
+// inTimespan returns whether t is within span, or true if there is no span.
+func inTimespan(t time.Time, span *influxdb.Timespan) bool {
+	if span == nil {
+		return true
+	}
+	return !t.Before(span.Start) && t.Before(span.Stop)
+}
+
+// dailyUsage returns the counts of the days of span in order, zero for the
+// days without count, or of the days with a count if there is no span.
+func dailyUsage(days map[string]int, span *influxdb.Timespan) []influxdb.DailyUsage {
+	var first, last time.Time
+	if span != nil {
+		first, last = span.Start.UTC(), span.Stop.UTC()
+	} else {
+		for day := range days {
+			t, _ := time.Parse(usageDayFormat, day)
+			if first.IsZero() || t.Before(first) {
+				first = t
+			}
+			if t.Add(24 * time.Hour).After(last) {
+				last = t.Add(24 * time.Hour)
+			}
+		}
+	}
+
+	us := []influxdb.DailyUsage{}
+	if first.IsZero() {
+		return us
+	}
+	first = first.Truncate(24 * time.Hour)
+	for d := first; d.Before(last); d = d.AddDate(0, 0, 1) {
+		day := d.Format(usageDayFormat)
+		us = append(us, influxdb.DailyUsage{Day: day, Count: days[day]})
+	}
+	return us
+}
@@ -0,0 +1,150 @@
+package kv_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/check"
+	"github.com/influxdata/influxdb/notification/endpoint"
+	"github.com/influxdata/influxdb/notification/rule"
+)
+
+func TestService_GetAlertingUsage(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestService(t)
+
+	orgID, otherOrgID := influxdb.ID(1), influxdb.ID(2)
+	for _, o := range []*influxdb.Organization{{ID: orgID, Name: "theorg"}, {ID: otherOrgID, Name: "otherorg"}} {
+		if err := svc.PutOrganization(ctx, o); err != nil {
+			t.Fatalf("failed to populate org: %v", err)
+		}
+	}
+
+	base := func(id influxdb.ID, name string, status influxdb.Status) check.Base {
+		return check.Base{
+			ID:     id,
+			Name:   name,
+			OrgID:  orgID,
+			Status: status,
+			Every:  influxdb.Duration{Duration: time.Minute},
+			Query:  influxdb.DashboardQuery{Text: `from(bucket: "telegraf") |> range(start: -1m)`},
+		}
+	}
+	archived := base(13, "net", influxdb.Active)
+	archived.Archived = true
+	checks := []influxdb.Check{
+		&check.Deadman{Base: base(10, "cpu", influxdb.Active), TimeSince: 60},
+		&check.Deadman{Base: base(11, "disk", influxdb.Inactive), TimeSince: 60},
+		&check.Threshold{
+			Base: base(12, "mem", influxdb.Active),
+			Thresholds: []check.ThresholdConfig{
+				&check.Greater{ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Critical}, Value: 90},
+			},
+		},
+		&check.Deadman{Base: archived, TimeSince: 60},
+	}
+	for _, c := range checks {
+		if err := svc.PutCheck(ctx, c); err != nil {
+			t.Fatalf("failed to populate check: %v", err)
+		}
+	}
+	for i, status := range []influxdb.Status{influxdb.Active, influxdb.Inactive} {
+		edp := &endpoint.Slack{
+			Base: endpoint.Base{
+				ID:     influxdb.ID(30 + i),
+				Name:   "slack",
+				OrgID:  orgID,
+				Status: status,
+			},
+			URL: "https://hooks.slack.com/services/1",
+		}
+		if err := svc.PutNotificationEndpoint(ctx, edp); err != nil {
+			t.Fatalf("failed to populate notification endpoint: %v", err)
+		}
+	}
+	endpointID := influxdb.ID(30)
+	if err := svc.PutNotificationRule(ctx, &rule.Slack{
+		Base: rule.Base{
+			ID:              20,
+			Name:            "page ops",
+			OrgID:           orgID,
+			AuthorizationID: influxdb.ID(99),
+			Status:          influxdb.Active,
+			EndpointID:      &endpointID,
+		},
+		MessageTemplate: "msg",
+	}); err != nil {
+		t.Fatalf("failed to populate notification rule: %v", err)
+	}
+
+	day := time.Date(2019, 9, 1, 0, 0, 0, 0, time.UTC)
+	notified := influxdb.RuleTrace{RuleID: 20, Decision: influxdb.RuleNotified}
+	muted := influxdb.RuleTrace{RuleID: 21, Decision: influxdb.RuleMuted}
+	traces := []*influxdb.StatusTrace{
+		{StatusID: 100, CheckID: 10, OrgID: orgID, Time: day.Add(time.Hour), Rules: []influxdb.RuleTrace{notified, notified, muted}},
+		{StatusID: 101, CheckID: 10, OrgID: orgID, Time: day.Add(50 * time.Hour), Rules: []influxdb.RuleTrace{notified}},
+		{StatusID: 102, CheckID: 10, OrgID: otherOrgID, Time: day.Add(2 * time.Hour), Rules: []influxdb.RuleTrace{notified}},
+		// out of the range of the usage.
+		{StatusID: 103, CheckID: 10, OrgID: orgID, Time: day.Add(-time.Hour), Rules: []influxdb.RuleTrace{notified}},
+	}
+	for _, tr := range traces {
+		if err := svc.CreateStatusTrace(ctx, tr); err != nil {
+			t.Fatalf("failed to populate status trace: %v", err)
+		}
+	}
+
+	span := &influxdb.Timespan{Start: day, Stop: day.Add(72 * time.Hour)}
+	u, err := svc.GetAlertingUsage(ctx, influxdb.UsageFilter{OrgID: &orgID, Range: span})
+	if err != nil {
+		t.Fatalf("failed to get alerting usage: %v", err)
+	}
+	want := &influxdb.AlertingUsage{
+		OrgID: &orgID,
+		Checks: influxdb.AlertingResourceUsage{
+			Total:    3,
+			ByType:   map[string]int{"deadman": 2, "threshold": 1},
+			ByStatus: map[influxdb.Status]int{influxdb.Active: 2, influxdb.Inactive: 1},
+		},
+		NotificationEndpoints: influxdb.AlertingResourceUsage{
+			Total:    2,
+			ByType:   map[string]int{"slack": 2},
+			ByStatus: map[influxdb.Status]int{influxdb.Active: 1, influxdb.Inactive: 1},
+		},
+		NotificationRules: influxdb.AlertingResourceUsage{
+			Total:    1,
+			ByType:   map[string]int{"slack": 1},
+			ByStatus: map[influxdb.Status]int{influxdb.Active: 1},
+		},
+		Notifications: []influxdb.DailyUsage{
+			{Day: "2019-09-01", Count: 2},
+			{Day: "2019-09-02", Count: 0},
+			{Day: "2019-09-03", Count: 1},
+		},
+	}
+	if diff := cmp.Diff(want, u); diff != "" {
+		t.Errorf("unexpected alerting usage (-want +got):\n%s", diff)
+	}
+
+	u, err = svc.GetAlertingUsage(ctx, influxdb.UsageFilter{})
+	if err != nil {
+		t.Fatalf("failed to get alerting usage: %v", err)
+	}
+	wantDays := []influxdb.DailyUsage{
+		{Day: "2019-08-31", Count: 1},
+		{Day: "2019-09-01", Count: 3},
+		{Day: "2019-09-02", Count: 0},
+		{Day: "2019-09-03", Count: 1},
+	}
+	if diff := cmp.Diff(wantDays, u.Notifications); diff != "" {
+		t.Errorf("unexpected notifications of every organization (-want +got):\n%s", diff)
+	}
+
+	missingID := influxdb.ID(9)
+	if _, err := svc.GetAlertingUsage(ctx, influxdb.UsageFilter{OrgID: &missingID}); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Errorf("expected the organization not to be found, got %v", err)
+	}
+}
@@ -633,13 +633,23 @@ func (s *Service) updateBucket(ctx context.Context, tx Tx, id influxdb.ID, upd i
 
 // DeleteBucket deletes a bucket and prunes it from the index.
 func (s *Service) DeleteBucket(ctx context.Context, id influxdb.ID) error {
-	return s.kv.Update(ctx, func(tx Tx) error {
-		var err error
-		if pe := s.deleteBucket(ctx, tx, id); pe != nil {
-			err = pe
+	var paused []influxdb.Check
+	err := s.kv.Update(ctx, func(tx Tx) error {
+		b, err := s.findBucketByID(ctx, tx, id)
+		if err != nil {
+			return err
 		}
+		if err := s.deleteBucket(ctx, tx, id); err != nil {
+			return err
+		}
+		paused, err = s.pauseBucketChecks(ctx, tx, b)
 		return err
 	})
+	if err != nil {
+		return err
+	}
+	s.notifyPausedChecks(ctx, paused)
+	return nil
 }
 
 func (s *Service) deleteBucket(ctx context.Context, tx Tx, id influxdb.ID) error {
@@ -0,0 +1,637 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/alerting/page"
+	"github.com/influxdata/influxdb/notification/check"
+)
+
+var (
+	checkBucket = []byte("checksv1")
+	// checkIndex maps the org id and name of a check to its id.
+	checkIndex = []byte("checkindexv1")
+
+	// ErrCheckNotFound is used when the check is not found.
+	ErrCheckNotFound = &influxdb.Error{
+		Msg:  "check not found",
+		Code: influxdb.ENotFound,
+	}
+
+	// ErrInvalidCheckID is used when the service was provided
+	// an invalid ID format.
+	ErrInvalidCheckID = &influxdb.Error{
+		Code: influxdb.EInvalid,
+		Msg:  "provided check ID has invalid format",
+	}
+)
+
+var _ influxdb.CheckService = (*Service)(nil)
+
+func (s *Service) initializeChecks(ctx context.Context, tx Tx) error {
+	if _, err := tx.Bucket(checkBucket); err != nil {
+		return err
+	}
+	if _, err := tx.Bucket(checkIndex); err != nil {
+		return err
+	}
+	return nil
+}
+
+// UnavailableCheckStoreError is used if we aren't able to interact with the
+// store, it means the store is not available at the moment (e.g. network).
+func UnavailableCheckStoreError(err error) *influxdb.Error {
+	return &influxdb.Error{
+		Code: influxdb.EInternal,
+		Msg:  fmt.Sprintf("Unable to connect to check store service. Please try again; Err: %v", err),
+		Op:   "kv/check",
+	}
+}
+
+// InternalCheckStoreError is used when the error comes from an
+// internal system.
+func InternalCheckStoreError(err error) *influxdb.Error {
+	return &influxdb.Error{
+		Code: influxdb.EInternal,
+		Msg:  fmt.Sprintf("Unknown internal check data error; Err: %v", err),
+		Op:   "kv/check",
+	}
+}
+
+func checkIndexKey(orgID influxdb.ID, name string) ([]byte, error) {
+	encOrgID, err := orgID.Encode()
+	if err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Err:  err,
+		}
+	}
+	k := make([]byte, 0, influxdb.IDLength+len(name))
+	k = append(k, encOrgID...)
+	return append(k, name...), nil
+}
+
+// FindCheckByID returns a single check by ID.
+func (s *Service) FindCheckByID(ctx context.Context, id influxdb.ID) (influxdb.Check, error) {
+	var (
+		c   influxdb.Check
+		err error
+	)
+
+	err = s.kv.View(ctx, func(tx Tx) error {
+		c, err = s.findCheckByID(ctx, tx, id)
+		return err
+	})
+
+	return c, err
+}
+
+func (s *Service) findCheckByID(ctx context.Context, tx Tx, id influxdb.ID) (influxdb.Check, error) {
+	encID, err := id.Encode()
+	if err != nil {
+		return nil, ErrInvalidCheckID
+	}
+
+	bucket, err := tx.Bucket(checkBucket)
+	if err != nil {
+		return nil, UnavailableCheckStoreError(err)
+	}
+
+	v, err := bucket.Get(encID)
+	if IsNotFound(err) {
+		return nil, ErrCheckNotFound
+	}
+	if err != nil {
+		return nil, InternalCheckStoreError(err)
+	}
+
+	c, err := check.UnmarshalJSON(v)
+	if err != nil {
+		return nil, InternalCheckStoreError(err)
+	}
+	return c, nil
+}
+
+// findCheckByName returns the check of an org with a name, archived or not.
+func (s *Service) findCheckByName(ctx context.Context, tx Tx, orgID influxdb.ID, name string) (influxdb.Check, error) {
+	key, err := checkIndexKey(orgID, name)
+	if err != nil {
+		return nil, err
+	}
+	idx, err := tx.Bucket(checkIndex)
+	if err != nil {
+		return nil, UnavailableCheckStoreError(err)
+	}
+	v, err := idx.Get(key)
+	if IsNotFound(err) {
+		return nil, ErrCheckNotFound
+	}
+	if err != nil {
+		return nil, InternalCheckStoreError(err)
+	}
+
+	var id influxdb.ID
+	if err := id.Decode(v); err != nil {
+		return nil, InternalCheckStoreError(err)
+	}
+	return s.findCheckByID(ctx, tx, id)
+}
+
+// FindCheck returns the first check that matches filter.
+func (s *Service) FindCheck(ctx context.Context, filter influxdb.CheckFilter) (influxdb.Check, error) {
+	if filter.ID != nil {
+		return s.FindCheckByID(ctx, *filter.ID)
+	}
+
+	cs, n, err := s.FindChecks(ctx, filter, influxdb.FindOptions{Limit: 1})
+	if err != nil {
+		return nil, err
+	}
+	if n == 0 {
+		return nil, ErrCheckNotFound
+	}
+	return cs[0], nil
+}
+
+// FindChecks returns a list of checks that match filter and the total count of matching checks.
+// Additional options provide pagination & sorting.
+func (s *Service) FindChecks(ctx context.Context, filter influxdb.CheckFilter, opt ...influxdb.FindOptions) (cs []influxdb.Check, n int, err error) {
+	err = s.kv.View(ctx, func(tx Tx) error {
+		cs, n, err = s.findChecks(ctx, tx, filter, opt...)
+		return err
+	})
+	return cs, n, err
+}
+
+func (s *Service) findChecks(ctx context.Context, tx Tx, filter influxdb.CheckFilter, opt ...influxdb.FindOptions) ([]influxdb.Check, int, error) {
+	if filter.OrgID == nil && filter.Org != nil {
+		o, err := s.findOrganizationByName(ctx, tx, *filter.Org)
+		if err != nil {
+			return nil, 0, err
+		}
+		filter.OrgID = &o.ID
+	}
+
+	var opts influxdb.FindOptions
+	if len(opt) > 0 {
+		opts = opt[0]
+	}
+	it := func(fn func(influxdb.Getter) bool) error {
+		return s.forEachCheck(ctx, tx, filter.OrgID, func(c influxdb.Check) bool { return fn(c) })
+	}
+	if len(filter.IDs) > 0 {
+		it = func(fn func(influxdb.Getter) bool) error {
+			return s.forEachCheckByID(ctx, tx, filter.IDs, func(c influxdb.Check) bool { return fn(c) })
+		}
+	}
+	filters := []page.Filter{
+		func(r influxdb.Getter) bool {
+			return isArchivedCheck(r.(influxdb.Check)) == filter.Archived
+		},
+	}
+	if filter.ID != nil {
+		filters = append(filters, page.ID(*filter.ID))
+	}
+	if filter.OrgID != nil {
+		filters = append(filters, page.OrgID(*filter.OrgID))
+	}
+	if filter.Name != nil {
+		filters = append(filters, page.Name(*filter.Name))
+	}
+	rs, n, err := page.FindCount(it, opts, filters...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	cs := make([]influxdb.Check, len(rs))
+	for i, r := range rs {
+		cs[i] = r.(influxdb.Check)
+	}
+	return cs, n, nil
+}
+
+// forEachCheck iterates through the checks of an org,
+// or all checks if orgID is nil, while fn returns true.
+func (s *Service) forEachCheck(ctx context.Context, tx Tx, orgID *influxdb.ID, fn func(influxdb.Check) bool) error {
+	if orgID == nil {
+		bkt, err := tx.Bucket(checkBucket)
+		if err != nil {
+			return UnavailableCheckStoreError(err)
+		}
+		cur, err := bkt.Cursor()
+		if err != nil {
+			return UnavailableCheckStoreError(err)
+		}
+		for k, v := cur.First(); k != nil; k, v = cur.Next() {
+			c, err := check.UnmarshalJSON(v)
+			if err != nil {
+				return InternalCheckStoreError(err)
+			}
+			if !fn(c) {
+				break
+			}
+		}
+		return nil
+	}
+
+	prefix, err := orgID.Encode()
+	if err != nil {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Err:  err,
+		}
+	}
+	idx, err := tx.Bucket(checkIndex)
+	if err != nil {
+		return UnavailableCheckStoreError(err)
+	}
+	cur, err := idx.Cursor()
+	if err != nil {
+		return UnavailableCheckStoreError(err)
+	}
+	for k, v := cur.Seek(prefix); bytes.HasPrefix(k, prefix); k, v = cur.Next() {
+		var id influxdb.ID
+		if err := id.Decode(v); err != nil {
+			return InternalCheckStoreError(err)
+		}
+		c, err := s.findCheckByID(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		if !fn(c) {
+			break
+		}
+	}
+	return nil
+}
+
+// forEachCheckByID iterates through the checks of ids while fn returns true,
+// skipping the ids of checks that don't exist and the repeated ids.
+func (s *Service) forEachCheckByID(ctx context.Context, tx Tx, ids []*influxdb.ID, fn func(influxdb.Check) bool) error {
+	seen := make(map[influxdb.ID]bool, len(ids))
+	for _, id := range ids {
+		if id == nil || seen[*id] {
+			continue
+		}
+		seen[*id] = true
+		c, err := s.findCheckByID(ctx, tx, *id)
+		if influxdb.ErrorCode(err) == influxdb.ENotFound {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if !fn(c) {
+			break
+		}
+	}
+	return nil
+}
+
+// CreateCheck creates a new check and sets c.ID with the new identifier.
+func (s *Service) CreateCheck(ctx context.Context, c influxdb.Check, userID influxdb.ID) error {
+	return s.kv.Update(ctx, func(tx Tx) error {
+		return s.createCheck(ctx, tx, c, s.IDGenerator.ID(), userID)
+	})
+}
+
+func (s *Service) createCheck(ctx context.Context, tx Tx, c influxdb.Check, id, userID influxdb.ID) error {
+	if _, err := s.findOrganizationByID(ctx, tx, c.GetOrgID()); err != nil {
+		return err
+	}
+	if err := s.enforceAlertingQuota(ctx, tx, c.GetOrgID(), influxdb.ChecksResourceType, 1); err != nil {
+		return err
+	}
+	if c.GetStatus() == "" {
+		c.SetStatus(influxdb.Active)
+	}
+
+	if ac, ok := c.(archivableCheck); ok {
+		ac.SetArchived(false)
+	}
+	if pc, ok := c.(pausableCheck); ok {
+		pc.SetPauseReason("")
+	}
+
+	if err := s.applyAlertingSettingsToCheck(ctx, tx, c); err != nil {
+		return err
+	}
+
+	c.SetID(id)
+	now := s.TimeGenerator.Now()
+	c.SetCreatedAt(now)
+	c.SetUpdatedAt(now)
+	if err := c.Valid(); err != nil {
+		return err
+	}
+	if err := s.validateCheckSandbox(ctx, tx, c); err != nil {
+		return err
+	}
+	if err := s.Config.CheckNamePolicy.ValidateName(c.GetName()); err != nil {
+		return err
+	}
+	if err := s.uniqueCheckName(ctx, tx, c); err != nil {
+		return err
+	}
+	if err := s.createCheckTask(ctx, tx, c, userID); err != nil {
+		return err
+	}
+
+	if err := s.putCheckIndex(ctx, tx, c); err != nil {
+		return err
+	}
+	if err := s.putCheck(ctx, tx, c); err != nil {
+		return err
+	}
+
+	urm := &influxdb.UserResourceMapping{
+		ResourceID:   c.GetID(),
+		UserID:       userID,
+		UserType:     influxdb.Owner,
+		ResourceType: influxdb.ChecksResourceType,
+	}
+	return s.createUserResourceMapping(ctx, tx, urm)
+}
+
+// uniqueCheckName returns a conflict error if another check of the org has the same name.
+func (s *Service) uniqueCheckName(ctx context.Context, tx Tx, c influxdb.Check) error {
+	key, err := checkIndexKey(c.GetOrgID(), c.GetName())
+	if err != nil {
+		return err
+	}
+	idx, err := tx.Bucket(checkIndex)
+	if err != nil {
+		return UnavailableCheckStoreError(err)
+	}
+	_, err = idx.Get(key)
+	if IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return InternalCheckStoreError(err)
+	}
+	return &influxdb.Error{
+		Code: influxdb.EConflict,
+		Msg:  fmt.Sprintf("check with name %s already exists", c.GetName()),
+	}
+}
+
+// PutCheck puts a check to storage.
+func (s *Service) PutCheck(ctx context.Context, c influxdb.Check) error {
+	return s.kv.Update(ctx, func(tx Tx) error {
+		if err := c.Valid(); err != nil {
+			return err
+		}
+		if err := s.putCheckIndex(ctx, tx, c); err != nil {
+			return err
+		}
+		return s.putCheck(ctx, tx, c)
+	})
+}
+
+func (s *Service) putCheckIndex(ctx context.Context, tx Tx, c influxdb.Check) error {
+	key, err := checkIndexKey(c.GetOrgID(), c.GetName())
+	if err != nil {
+		return err
+	}
+	encID, err := c.GetID().Encode()
+	if err != nil {
+		return ErrInvalidCheckID
+	}
+	idx, err := tx.Bucket(checkIndex)
+	if err != nil {
+		return UnavailableCheckStoreError(err)
+	}
+	if err := idx.Put(key, encID); err != nil {
+		return UnavailableCheckStoreError(err)
+	}
+	return nil
+}
+
+func (s *Service) deleteCheckIndex(ctx context.Context, tx Tx, orgID influxdb.ID, name string) error {
+	key, err := checkIndexKey(orgID, name)
+	if err != nil {
+		return err
+	}
+	idx, err := tx.Bucket(checkIndex)
+	if err != nil {
+		return UnavailableCheckStoreError(err)
+	}
+	if err := idx.Delete(key); err != nil {
+		return UnavailableCheckStoreError(err)
+	}
+	return nil
+}
+
+func (s *Service) putCheck(ctx context.Context, tx Tx, c influxdb.Check) error {
+	encID, err := c.GetID().Encode()
+	if err != nil {
+		return ErrInvalidCheckID
+	}
+
+	v, err := json.Marshal(c)
+	if err != nil {
+		return InternalCheckStoreError(err)
+	}
+
+	bucket, err := tx.Bucket(checkBucket)
+	if err != nil {
+		return UnavailableCheckStoreError(err)
+	}
+	prev, err := bucket.Get(encID)
+	if err != nil && !IsNotFound(err) {
+		return UnavailableCheckStoreError(err)
+	}
+	if err := bucket.Put(encID, v); err != nil {
+		return UnavailableCheckStoreError(err)
+	}
+	return s.appendCheckPutEvents(ctx, tx, c, prev, v)
+}
+
+// UpdateCheck updates the whole check.
+// Returns the new check state after update.
+func (s *Service) UpdateCheck(ctx context.Context, id influxdb.ID, c influxdb.Check) (influxdb.Check, error) {
+	var err error
+	err = s.kv.Update(ctx, func(tx Tx) error {
+		c, err = s.updateCheck(ctx, tx, id, c)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (s *Service) updateCheck(ctx context.Context, tx Tx, id influxdb.ID, c influxdb.Check) (influxdb.Check, error) {
+	current, err := s.findCheckByID(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// ID and OrganizationID can not be updated
+	c.SetID(current.GetID())
+	c.SetOrgID(current.GetOrgID())
+	c.SetCreatedAt(current.GetCRUDLog().CreatedAt)
+	c.SetUpdatedAt(s.TimeGenerator.Now())
+	// a check is only archived and unarchived explicitly.
+	if ac, ok := c.(archivableCheck); ok {
+		ac.SetArchived(isArchivedCheck(current))
+	}
+	if err := c.Valid(); err != nil {
+		return nil, err
+	}
+	if err := s.validateCheckSandbox(ctx, tx, c); err != nil {
+		return nil, err
+	}
+	if err := s.resumeCheck(ctx, tx, checkPauseReason(current), c); err != nil {
+		return nil, err
+	}
+
+	if err := s.renameCheck(ctx, tx, current, c); err != nil {
+		return nil, err
+	}
+	if err := s.replaceCheckTask(ctx, tx, current, c); err != nil {
+		return nil, err
+	}
+	if err := s.putCheck(ctx, tx, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// renameCheck moves the index of a check to its new name, if it was renamed
+// to a name following the check name policy.
+func (s *Service) renameCheck(ctx context.Context, tx Tx, current, c influxdb.Check) error {
+	if current.GetName() == c.GetName() {
+		return nil
+	}
+	if err := s.Config.CheckNamePolicy.ValidateName(c.GetName()); err != nil {
+		return err
+	}
+	if err := s.uniqueCheckName(ctx, tx, c); err != nil {
+		return err
+	}
+	if err := s.deleteCheckIndex(ctx, tx, current.GetOrgID(), current.GetName()); err != nil {
+		return err
+	}
+	return s.putCheckIndex(ctx, tx, c)
+}
+
+// PatchCheck updates a single check with changeset.
+// Returns the new check state after update.
+func (s *Service) PatchCheck(ctx context.Context, id influxdb.ID, upd influxdb.CheckUpdate) (influxdb.Check, error) {
+	var c influxdb.Check
+	err := s.kv.Update(ctx, func(tx Tx) (err error) {
+		c, err = s.patchCheck(ctx, tx, id, upd)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (s *Service) patchCheck(ctx context.Context, tx Tx, id influxdb.ID, upd influxdb.CheckUpdate) (influxdb.Check, error) {
+	c, err := s.findCheckByID(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	oldName := c.GetName()
+	pauseReason := checkPauseReason(c)
+
+	if upd.Name != nil {
+		c.SetName(*upd.Name)
+	}
+	if upd.Description != nil {
+		c.SetDescription(*upd.Description)
+	}
+	if upd.Status != nil {
+		c.SetStatus(*upd.Status)
+	}
+	c.SetUpdatedAt(s.TimeGenerator.Now())
+	if err := c.Valid(); err != nil {
+		return nil, err
+	}
+	if err := s.resumeCheck(ctx, tx, pauseReason, c); err != nil {
+		return nil, err
+	}
+
+	if c.GetName() != oldName {
+		if err := s.Config.CheckNamePolicy.ValidateName(c.GetName()); err != nil {
+			return nil, err
+		}
+		if err := s.uniqueCheckName(ctx, tx, c); err != nil {
+			return nil, err
+		}
+		if err := s.deleteCheckIndex(ctx, tx, c.GetOrgID(), oldName); err != nil {
+			return nil, err
+		}
+		if err := s.putCheckIndex(ctx, tx, c); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.rotateCheckTask(ctx, tx, c); err != nil {
+		return nil, err
+	}
+	if err := s.putCheck(ctx, tx, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// DeleteCheck removes a check by ID.
+func (s *Service) DeleteCheck(ctx context.Context, id influxdb.ID) error {
+	return s.kv.Update(ctx, func(tx Tx) error {
+		return s.deleteCheck(ctx, tx, id)
+	})
+}
+
+func (s *Service) deleteCheck(ctx context.Context, tx Tx, id influxdb.ID) error {
+	c, err := s.findCheckByID(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.deleteCheckIndex(ctx, tx, c.GetOrgID(), c.GetName()); err != nil {
+		return err
+	}
+	if err := s.deleteCheckTask(ctx, tx, c); err != nil {
+		return err
+	}
+	if err := s.deleteCheckState(ctx, tx, id); err != nil {
+		return err
+	}
+	if err := s.deleteCheckPing(ctx, tx, id); err != nil {
+		return err
+	}
+
+	encID, err := id.Encode()
+	if err != nil {
+		return ErrInvalidCheckID
+	}
+	bucket, err := tx.Bucket(checkBucket)
+	if err != nil {
+		return UnavailableCheckStoreError(err)
+	}
+	if err := bucket.Delete(encID); err != nil {
+		return UnavailableCheckStoreError(err)
+	}
+	if err := s.appendCheckEvent(ctx, tx, influxdb.CheckDeleted, id, c.GetOrgID(), nil); err != nil {
+		return err
+	}
+	if err := s.deleteCheckManagedFields(ctx, tx, id); err != nil {
+		return err
+	}
+	if err := s.deleteCheckGCMark(ctx, tx, id); err != nil {
+		return err
+	}
+
+	return s.deleteUserResourceMappings(ctx, tx, influxdb.UserResourceMappingFilter{
+		ResourceID:   id,
+		ResourceType: influxdb.ChecksResourceType,
+	})
+}
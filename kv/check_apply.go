@@ -0,0 +1,370 @@
+package kv
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification/check"
+)
+
+var (
+	checkManagedFieldBucket = []byte("checkmanagedfieldsv1")
+)
+
+var _ influxdb.CheckApplyService = (*Service)(nil)
+
+func (s *Service) initializeCheckManagedFields(ctx context.Context, tx Tx) error {
+	if _, err := tx.Bucket(checkManagedFieldBucket); err != nil {
+		return err
+	}
+	return nil
+}
+
+// serverCheckFields are the fields of a check set by the server, which a
+// configuration can't apply.
+var serverCheckFields = map[string]bool{
+	"id":              true,
+	"orgID":           true,
+	"taskID":          true,
+	"authorizationID": true,
+	"archived":        true,
+	"pauseReason":     true,
+	"createdAt":       true,
+	"updatedAt":       true,
+}
+
+// managedCheckField is the manager which applied a field of a check last,
+// with the value it applied, so a manual edit of the field since is known.
+// The fields of a check created by an apply which no manager applies have
+// no manager, with their default value.
+type managedCheckField struct {
+	Manager string          `json:"manager"`
+	Value   json.RawMessage `json:"value"`
+	Time    time.Time       `json:"time"`
+}
+
+// ApplyCheck creates or updates the check of an organization with the
+// configuration of a field manager.
+func (s *Service) ApplyCheck(ctx context.Context, a influxdb.CheckApply, userID influxdb.ID) (*influxdb.CheckApplyResult, error) {
+	if err := a.Valid(); err != nil {
+		return nil, err
+	}
+	var res *influxdb.CheckApplyResult
+	err := s.kv.Update(ctx, func(tx Tx) error {
+		var err error
+		res, err = s.applyCheck(ctx, tx, a, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (s *Service) applyCheck(ctx context.Context, tx Tx, a influxdb.CheckApply, userID influxdb.ID) (*influxdb.CheckApplyResult, error) {
+	var config map[string]json.RawMessage
+	if err := json.Unmarshal(a.Config, &config); err != nil || config == nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "the applied configuration must be a json object",
+		}
+	}
+	for f := range config {
+		if serverCheckFields[f] {
+			return nil, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  fmt.Sprintf("%s is set by the server and can't be applied", f),
+			}
+		}
+	}
+	name, _ := json.Marshal(a.Name)
+	if v, ok := config["name"]; ok && !jsonEqual(v, name) {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "the name of the configuration must be the name of the applied check",
+		}
+	}
+	config["name"] = name
+
+	now := s.TimeGenerator.Now()
+	current, err := s.findCheckByName(ctx, tx, a.OrgID, a.Name)
+	if influxdb.ErrorCode(err) == influxdb.ENotFound {
+		return s.createAppliedCheck(ctx, tx, a, config, userID, now)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	fields, err := checkFields(current)
+	if err != nil {
+		return nil, InternalCheckStoreError(err)
+	}
+	if v, ok := config["type"]; ok && !jsonEqual(v, fields["type"]) {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "the type of a check can't be changed by an apply",
+		}
+	}
+	managed, err := s.findCheckManagedFields(ctx, tx, current.GetID())
+	if err != nil {
+		return nil, err
+	}
+
+	// the applied fields are compared as the check encodes them, so a
+	// configuration applied again changes nothing.
+	merged := make(map[string]json.RawMessage, len(fields))
+	for f, v := range fields {
+		merged[f] = v
+	}
+	for f, v := range config {
+		merged[f] = v
+	}
+	c, err := unmarshalCheckFields(merged)
+	if err != nil {
+		return nil, err
+	}
+	next, err := checkFields(c)
+	if err != nil {
+		return nil, InternalCheckStoreError(err)
+	}
+
+	var changed bool
+	for f := range config {
+		m, ok := managed[f]
+		if jsonEqual(next[f], fields[f]) {
+			// a field applied with the same value by another manager stays its own.
+			if !ok || m.Manager == "" || m.Manager == a.FieldManager || !jsonEqual(m.Value, fields[f]) {
+				managed[f] = managedCheckField{Manager: a.FieldManager, Value: next[f], Time: now}
+			}
+			continue
+		}
+		if !a.Force {
+			switch {
+			case ok && m.Manager != "" && m.Manager != a.FieldManager:
+				return nil, &influxdb.Error{
+					Code: influxdb.EConflict,
+					Msg:  fmt.Sprintf("%s of the check is managed by %s", f, m.Manager),
+				}
+			case ok && !jsonEqual(m.Value, fields[f]):
+				return nil, &influxdb.Error{
+					Code: influxdb.EConflict,
+					Msg:  fmt.Sprintf("%s of the check was edited since it was applied", f),
+				}
+			case !ok && !zeroJSON(fields[f]):
+				return nil, &influxdb.Error{
+					Code: influxdb.EConflict,
+					Msg:  fmt.Sprintf("%s of the check was set before it was applied", f),
+				}
+			}
+		}
+		managed[f] = managedCheckField{Manager: a.FieldManager, Value: next[f], Time: now}
+		changed = true
+	}
+	for f, m := range managed {
+		if _, ok := config[f]; !ok && m.Manager == a.FieldManager {
+			m.Manager = ""
+			managed[f] = m
+		}
+	}
+
+	if changed {
+		if c, err = s.updateCheck(ctx, tx, current.GetID(), c); err != nil {
+			return nil, err
+		}
+	} else {
+		c = current
+	}
+	if err := s.putCheckManagedFields(ctx, tx, c.GetID(), managed); err != nil {
+		return nil, err
+	}
+	return &influxdb.CheckApplyResult{
+		Check:         c,
+		ManagedFields: checkManagedFields(managed),
+	}, nil
+}
+
+func (s *Service) createAppliedCheck(ctx context.Context, tx Tx, a influxdb.CheckApply, config map[string]json.RawMessage, userID influxdb.ID, now time.Time) (*influxdb.CheckApplyResult, error) {
+	fields := make(map[string]json.RawMessage, len(config)+1)
+	for f, v := range config {
+		fields[f] = v
+	}
+	fields["orgID"], _ = json.Marshal(a.OrgID)
+	c, err := unmarshalCheckFields(fields)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.createCheck(ctx, tx, c, s.IDGenerator.ID(), userID); err != nil {
+		return nil, err
+	}
+
+	next, err := checkFields(c)
+	if err != nil {
+		return nil, InternalCheckStoreError(err)
+	}
+	managed := make(map[string]managedCheckField, len(next))
+	for f, v := range next {
+		if serverCheckFields[f] {
+			continue
+		}
+		m := managedCheckField{Value: v, Time: now}
+		if _, ok := config[f]; ok {
+			m.Manager = a.FieldManager
+		}
+		managed[f] = m
+	}
+	if err := s.putCheckManagedFields(ctx, tx, c.GetID(), managed); err != nil {
+		return nil, err
+	}
+	return &influxdb.CheckApplyResult{
+		Check:         c,
+		Created:       true,
+		ManagedFields: checkManagedFields(managed),
+	}, nil
+}
+
+// checkFields returns the json of the fields of a check.
+func checkFields(c influxdb.Check) (map[string]json.RawMessage, error) {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// unmarshalCheckFields returns the check of the json of its fields.
+func unmarshalCheckFields(fields map[string]json.RawMessage) (influxdb.Check, error) {
+	b, err := json.Marshal(fields)
+	if err != nil {
+		return nil, InternalCheckStoreError(err)
+	}
+	c, err := check.UnmarshalJSON(b)
+	if err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Err:  err,
+		}
+	}
+	return c, nil
+}
+
+func (s *Service) findCheckManagedFields(ctx context.Context, tx Tx, id influxdb.ID) (map[string]managedCheckField, error) {
+	encID, err := id.Encode()
+	if err != nil {
+		return nil, ErrInvalidCheckID
+	}
+	bucket, err := tx.Bucket(checkManagedFieldBucket)
+	if err != nil {
+		return nil, UnavailableCheckStoreError(err)
+	}
+	managed := make(map[string]managedCheckField)
+	v, err := bucket.Get(encID)
+	if IsNotFound(err) {
+		return managed, nil
+	}
+	if err != nil {
+		return nil, InternalCheckStoreError(err)
+	}
+	if err := json.Unmarshal(v, &managed); err != nil {
+		return nil, InternalCheckStoreError(err)
+	}
+	return managed, nil
+}
+
+func (s *Service) putCheckManagedFields(ctx context.Context, tx Tx, id influxdb.ID, managed map[string]managedCheckField) error {
+	encID, err := id.Encode()
+	if err != nil {
+		return ErrInvalidCheckID
+	}
+	bucket, err := tx.Bucket(checkManagedFieldBucket)
+	if err != nil {
+		return UnavailableCheckStoreError(err)
+	}
+	if len(managed) == 0 {
+		if err := bucket.Delete(encID); err != nil && !IsNotFound(err) {
+			return UnavailableCheckStoreError(err)
+		}
+		return nil
+	}
+	v, err := json.Marshal(managed)
+	if err != nil {
+		return InternalCheckStoreError(err)
+	}
+	if err := bucket.Put(encID, v); err != nil {
+		return UnavailableCheckStoreError(err)
+	}
+	return nil
+}
+
+// deleteCheckManagedFields deletes the managers of the fields of a deleted check.
+func (s *Service) deleteCheckManagedFields(ctx context.Context, tx Tx, id influxdb.ID) error {
+	return s.putCheckManagedFields(ctx, tx, id, nil)
+}
+
+func checkManagedFields(managed map[string]managedCheckField) []influxdb.CheckManagedField {
+	fs := make([]influxdb.CheckManagedField, 0, len(managed))
+	for f, m := range managed {
+		if m.Manager == "" {
+			continue
+		}
+		fs = append(fs, influxdb.CheckManagedField{
+			Field:   f,
+			Manager: m.Manager,
+			Time:    m.Time,
+		})
+	}
+	sort.Slice(fs, func(i, j int) bool {
+		return fs[i].Field < fs[j].Field
+	})
+	return fs
+}
+
+// jsonEqual returns whether two json values are equal, a missing value being null.
+func jsonEqual(a, b json.RawMessage) bool {
+	var va, vb interface{}
+	if len(a) > 0 {
+		if err := json.Unmarshal(a, &va); err != nil {
+			return false
+		}
+	}
+	if len(b) > 0 {
+		if err := json.Unmarshal(b, &vb); err != nil {
+			return false
+		}
+	}
+	return reflect.DeepEqual(va, vb)
+}
+
+// zeroJSON returns whether a json value is missing, null or the zero value of its type.
+func zeroJSON(v json.RawMessage) bool {
+	if len(v) == 0 {
+		return true
+	}
+	var x interface{}
+	if err := json.Unmarshal(v, &x); err != nil {
+		return false
+	}
+	switch x := x.(type) {
+	case nil:
+		return true
+	case bool:
+		return !x
+	case float64:
+		return x == 0
+	case string:
+		return x == ""
+	case []interface{}:
+		return len(x) == 0
+	case map[string]interface{}:
+		return len(x) == 0
+	}
+	return false
+}
@@ -0,0 +1,124 @@
+package kv_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification/check"
+)
+
+func TestService_ApplyCheck(t *testing.T) {
+	ctx := context.Background()
+	svc, user, org := newTestServiceWithOrg(t)
+
+	apply := func(manager, config string, force bool) (*influxdb.CheckApplyResult, error) {
+		return svc.ApplyCheck(ctx, influxdb.CheckApply{
+			OrgID:        org.ID,
+			Name:         "cpu",
+			FieldManager: manager,
+			Force:        force,
+			Config:       []byte(config),
+		}, user.ID)
+	}
+	const config = `{"type": "deadman", "every": "1m", "timeSince": 90, "query": {"text": "from(bucket: \"telegraf\") |> range(start: -1m)"}}`
+
+	res, err := apply("operator", config, false)
+	if err != nil {
+		t.Fatalf("failed to apply check: %v", err)
+	}
+	if !res.Created || res.Check.GetName() != "cpu" || res.Check.GetOrgID() != org.ID {
+		t.Fatalf("expected the check to be created in the org, got %+v", res)
+	}
+	id := res.Check.GetID()
+	want := map[string]string{"every": "operator", "name": "operator", "query": "operator", "timeSince": "operator", "type": "operator"}
+	if got := managers(res.ManagedFields); !equalManagers(got, want) {
+		t.Errorf("expected the fields of the configuration to be managed, got %v", got)
+	}
+
+	// applying the configuration again changes nothing.
+	res, err = apply("operator", config, false)
+	if err != nil {
+		t.Fatalf("failed to apply check again: %v", err)
+	}
+	if res.Created || res.Check.GetID() != id || !res.Check.GetCRUDLog().UpdatedAt.Equal(res.Check.GetCRUDLog().CreatedAt) {
+		t.Errorf("expected the check not to be updated, got %+v", res.Check)
+	}
+
+	// another manager applies its own field, without the fields of the operator.
+	if res, err = apply("alerts-team", `{"description": "cpu usage", "level": "CRIT"}`, false); err != nil {
+		t.Fatalf("failed to apply the fields of another manager: %v", err)
+	}
+	if res.Check.GetDescription() != "cpu usage" || res.Check.(*check.Deadman).TimeSince != 90 {
+		t.Errorf("expected the description to be applied and the fields of the operator kept, got %+v", res.Check)
+	}
+	if _, err := apply("alerts-team", `{"timeSince": 120}`, false); influxdb.ErrorCode(err) != influxdb.EConflict {
+		t.Errorf("expected changing a field of the operator to conflict, got %v", err)
+	}
+
+	// a manual edit isn't overwritten by the manager which applied the field.
+	status := influxdb.Inactive
+	if _, err := svc.PatchCheck(ctx, id, influxdb.CheckUpdate{Description: func(s string) *string { return &s }("edited")}); err != nil {
+		t.Fatalf("failed to patch check: %v", err)
+	}
+	if _, err := apply("alerts-team", `{"description": "cpu usage again"}`, false); influxdb.ErrorCode(err) != influxdb.EConflict {
+		t.Errorf("expected overwriting the manual edit to conflict, got %v", err)
+	}
+	if _, err := svc.PatchCheck(ctx, id, influxdb.CheckUpdate{Status: &status}); err != nil {
+		t.Fatalf("failed to patch check: %v", err)
+	}
+	if _, err := apply("operator", `{"type": "deadman", "status": "active"}`, false); influxdb.ErrorCode(err) != influxdb.EConflict {
+		t.Errorf("expected overwriting a field set before it was applied to conflict, got %v", err)
+	}
+
+	// a forced apply takes the fields over.
+	if res, err = apply("operator", config[:len(config)-1]+`, "status": "active", "description": "forced"}`, true); err != nil {
+		t.Fatalf("failed to force apply: %v", err)
+	}
+	if res.Check.GetStatus() != influxdb.Active || res.Check.GetDescription() != "forced" {
+		t.Errorf("expected the forced fields to be applied, got %+v", res.Check)
+	}
+	want["status"], want["description"], want["level"] = "operator", "operator", "alerts-team"
+	if got := managers(res.ManagedFields); !equalManagers(got, want) {
+		t.Errorf("expected the forced fields to be managed by the operator, got %v", got)
+	}
+
+	for _, bad := range []string{`[]`, `{"id": "0000000000000001"}`, `{"name": "mem"}`, `{"type": "threshold"}`} {
+		if _, err := apply("operator", bad, false); influxdb.ErrorCode(err) != influxdb.EInvalid {
+			t.Errorf("expected applying %s to be invalid, got %v", bad, err)
+		}
+	}
+
+	if err := svc.DeleteCheck(ctx, id); err != nil {
+		t.Fatalf("failed to delete check: %v", err)
+	}
+	if res, err = apply("operator", config, false); err != nil || !res.Created {
+		t.Fatalf("expected the deleted check to be created again, got %v", err)
+	}
+	delete(want, "status")
+	delete(want, "description")
+	delete(want, "level")
+	if got := managers(res.ManagedFields); !equalManagers(got, want) {
+		t.Errorf("expected the managers of the deleted check to be dropped, got %v", got)
+	}
+}
+
+func managers(fs []influxdb.CheckManagedField) map[string]string {
+	m := make(map[string]string, len(fs))
+	for _, f := range fs {
+		m[f.Field] = f.Manager
+	}
+	return m
+}
+
+func equalManagers(got, want map[string]string) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for f, m := range want {
+		if got[f] != m {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,87 @@
+package kv
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+// archivableCheck is a check that can be archived.
+type archivableCheck interface {
+	IsArchived() bool
+	SetArchived(bool)
+}
+
+// isArchivedCheck returns whether a check is archived.
+func isArchivedCheck(c influxdb.Check) bool {
+	ac, ok := c.(archivableCheck)
+	return ok && ac.IsArchived()
+}
+
+// ArchiveCheck hides a check from the checks found by default and stops its task.
+func (s *Service) ArchiveCheck(ctx context.Context, id influxdb.ID) (influxdb.Check, error) {
+	var c influxdb.Check
+	err := s.kv.Update(ctx, func(tx Tx) (err error) {
+		c, err = s.setCheckArchived(ctx, tx, id, true)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// UnarchiveCheck restores an archived check, running its task again if the check is active.
+func (s *Service) UnarchiveCheck(ctx context.Context, id influxdb.ID) (influxdb.Check, error) {
+	var c influxdb.Check
+	err := s.kv.Update(ctx, func(tx Tx) (err error) {
+		c, err = s.setCheckArchived(ctx, tx, id, false)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (s *Service) setCheckArchived(ctx context.Context, tx Tx, id influxdb.ID, archived bool) (influxdb.Check, error) {
+	c, err := s.findCheckByID(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	ac, ok := c.(archivableCheck)
+	if !ok {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "check can't be archived",
+		}
+	}
+	if ac.IsArchived() == archived {
+		msg := "check is not archived"
+		if archived {
+			msg = "check is already archived"
+		}
+		return nil, &influxdb.Error{
+			Code: influxdb.EConflict,
+			Msg:  msg,
+		}
+	}
+
+	// the archived checks aren't counted against the quota of the
+	// organization, restoring one creates it again.
+	if !archived {
+		if err := s.enforceAlertingQuota(ctx, tx, c.GetOrgID(), influxdb.ChecksResourceType, 1); err != nil {
+			return nil, err
+		}
+	}
+
+	ac.SetArchived(archived)
+	c.SetUpdatedAt(s.TimeGenerator.Now())
+	if err := s.updateCheckTaskStatus(ctx, tx, c); err != nil {
+		return nil, err
+	}
+	if err := s.putCheck(ctx, tx, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
@@ -0,0 +1,70 @@
+package kv
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.CheckBulkCreateService = (*Service)(nil)
+
+// CreateChecks creates every check in the organization, owned by userID,
+// or none of them if any check fails to be created.
+func (s *Service) CreateChecks(ctx context.Context, c influxdb.CheckBulkCreate, userID influxdb.ID) error {
+	return s.kv.Update(ctx, func(tx Tx) error {
+		return s.createChecks(ctx, tx, c, userID)
+	})
+}
+
+func (s *Service) createChecks(ctx context.Context, tx Tx, c influxdb.CheckBulkCreate, userID influxdb.ID) error {
+	if err := c.Valid(); err != nil {
+		return err
+	}
+	if _, err := s.findOrganizationByID(ctx, tx, c.OrgID); err != nil {
+		return err
+	}
+	if err := s.enforceAlertingQuota(ctx, tx, c.OrgID, influxdb.ChecksResourceType, len(c.Checks)); err != nil {
+		return err
+	}
+
+	// the names are checked before any check is created, the stores without
+	// transactions can't roll back a partial creation.
+	names := make(map[string]int, len(c.Checks))
+	for i, ch := range c.Checks {
+		ch.SetOrgID(c.OrgID)
+		if j, ok := names[ch.GetName()]; ok {
+			return &influxdb.Error{
+				Code: influxdb.EConflict,
+				Msg:  fmt.Sprintf("checks %d and %d have the same name %s", j, i, ch.GetName()),
+			}
+		}
+		names[ch.GetName()] = i
+		if err := s.Config.CheckNamePolicy.ValidateName(ch.GetName()); err != nil {
+			return influxdb.BulkCheckError(i, err)
+		}
+		if _, err := s.availableCheckName(ctx, tx, c.OrgID, ch.GetName(), false); err != nil {
+			return influxdb.BulkCheckError(i, err)
+		}
+	}
+
+	created := make([]influxdb.ID, 0, len(c.Checks))
+	for i, ch := range c.Checks {
+		if err := s.createCheck(ctx, tx, ch, s.IDGenerator.ID(), userID); err != nil {
+			// the checks created before the failure are deleted with their
+			// tasks, the stores without transactions don't roll them back.
+			for _, id := range created {
+				if derr := s.deleteCheck(ctx, tx, id); derr != nil {
+					return influxdb.BulkCheckError(i, &influxdb.Error{
+						Code: influxdb.EInternal,
+						Msg:  fmt.Sprintf("failed to delete the checks created before the failure: %s", influxdb.ErrorMessage(derr)),
+						Err:  err,
+					})
+				}
+			}
+			return influxdb.BulkCheckError(i, err)
+		}
+		created = append(created, ch.GetID())
+	}
+	return nil
+}
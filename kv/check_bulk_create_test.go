@@ -0,0 +1,114 @@
+package kv_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification/check"
+)
+
+func TestService_CreateChecks(t *testing.T) {
+	ctx := context.Background()
+	svc, user, org := newTestServiceWithOrg(t)
+
+	existing := newDeadman(org.ID, "cpu")
+	if err := svc.CreateCheck(ctx, existing, user.ID); err != nil {
+		t.Fatalf("failed to create check: %v", err)
+	}
+
+	// the creation fails on a conflicting name, and creates nothing.
+	tests := []struct {
+		name   string
+		checks []influxdb.Check
+		code   string
+		msg    string
+	}{
+		{
+			name:   "name of a check of the org",
+			checks: []influxdb.Check{newDeadman(org.ID, "mem"), newDeadman(org.ID, "cpu")},
+			code:   influxdb.EConflict,
+			msg:    "check 1: check with name cpu already exists",
+		},
+		{
+			name:   "same names",
+			checks: []influxdb.Check{newDeadman(org.ID, "mem"), newDeadman(org.ID, "disk"), newDeadman(org.ID, "mem")},
+			code:   influxdb.EConflict,
+			msg:    "checks 0 and 2 have the same name mem",
+		},
+		{
+			name:   "no checks",
+			checks: []influxdb.Check{},
+			code:   influxdb.EInvalid,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := svc.CreateChecks(ctx, influxdb.CheckBulkCreate{OrgID: org.ID, Checks: tt.checks}, user.ID)
+			if influxdb.ErrorCode(err) != tt.code || !strings.Contains(influxdb.ErrorMessage(err), tt.msg) {
+				t.Fatalf("expected a %s error %q, got %v", tt.code, tt.msg, err)
+			}
+			mem := "mem"
+			if _, err := svc.FindCheck(ctx, influxdb.CheckFilter{OrgID: &org.ID, Name: &mem}); influxdb.ErrorCode(err) != influxdb.ENotFound {
+				t.Errorf("expected the failed creation to create no check, got %v", err)
+			}
+		})
+	}
+
+	cs := []influxdb.Check{newDeadman(org.ID, "mem"), newDeadman(org.ID, "disk")}
+	if err := svc.CreateChecks(ctx, influxdb.CheckBulkCreate{OrgID: org.ID, Checks: cs}, user.ID); err != nil {
+		t.Fatalf("failed to create checks: %v", err)
+	}
+	for _, c := range cs {
+		got, err := svc.FindCheckByID(ctx, c.GetID())
+		if err != nil {
+			t.Fatalf("failed to find created check: %v", err)
+		}
+		if got.GetName() != c.GetName() || got.GetOrgID() != org.ID {
+			t.Errorf("unexpected created check %+v", got)
+		}
+		if got.(*check.Deadman).TaskID == 0 {
+			t.Errorf("expected the check %s to have a task", got.GetName())
+		}
+	}
+}
+
+func TestService_CreateChecks_rollback(t *testing.T) {
+	ctx := context.Background()
+	svc, user, org := newTestServiceWithOrg(t)
+
+	// the authorization of the task of the third check fails on its missing
+	// bucket, after the first two checks were created.
+	missing := newDeadman(org.ID, "cpu")
+	missing.Query.Text = `from(bucket: "missing") |> range(start: -1m)`
+	cs := []influxdb.Check{newDeadman(org.ID, "mem"), newDeadman(org.ID, "disk"), missing}
+	err := svc.CreateChecks(ctx, influxdb.CheckBulkCreate{OrgID: org.ID, Checks: cs}, user.ID)
+	if influxdb.ErrorCode(err) != influxdb.ENotFound || !strings.HasPrefix(influxdb.ErrorMessage(err), "check 2: ") {
+		t.Fatalf("expected a not found error of check 2, got %v", err)
+	}
+
+	if _, n, err := svc.FindChecks(ctx, influxdb.CheckFilter{OrgID: &org.ID}); err != nil || n != 0 {
+		t.Errorf("expected the failed creation to leave no check, got %d: %v", n, err)
+	}
+	ts, _, err := svc.FindTasks(ctx, influxdb.TaskFilter{OrganizationID: &org.ID})
+	if err != nil {
+		t.Fatalf("failed to find tasks: %v", err)
+	}
+	if len(ts) != 0 {
+		t.Errorf("expected the failed creation to leave no task, got %d", len(ts))
+	}
+	as, _, err := svc.FindAuthorizations(ctx, influxdb.AuthorizationFilter{OrgID: &org.ID})
+	if err != nil {
+		t.Fatalf("failed to find authorizations: %v", err)
+	}
+	if len(as) != 0 {
+		t.Errorf("expected the failed creation to leave no authorization, got %d", len(as))
+	}
+
+	// the names of the deleted checks are available again.
+	cs = []influxdb.Check{newDeadman(org.ID, "mem"), newDeadman(org.ID, "disk")}
+	if err := svc.CreateChecks(ctx, influxdb.CheckBulkCreate{OrgID: org.ID, Checks: cs}, user.ID); err != nil {
+		t.Fatalf("failed to create checks: %v", err)
+	}
+}
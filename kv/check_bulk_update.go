@@ -0,0 +1,161 @@
+package kv
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.CheckBulkUpdateService = (*Service)(nil)
+
+// scheduledCheck is a check whose schedule can be changed.
+type scheduledCheck interface {
+	GetEvery() time.Duration
+	SetEvery(time.Duration)
+	SetOffset(time.Duration)
+}
+
+// BulkUpdateChecks applies the update to every matching check, or to none
+// of them if any updated check is invalid.
+func (s *Service) BulkUpdateChecks(ctx context.Context, u influxdb.CheckBulkUpdate) (*influxdb.CheckBulkUpdateResult, error) {
+	var (
+		r   *influxdb.CheckBulkUpdateResult
+		err error
+	)
+	update := s.kv.Update
+	if u.DryRun {
+		update = s.kv.View
+	}
+	err = update(ctx, func(tx Tx) error {
+		r, err = s.bulkUpdateChecks(ctx, tx, u)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (s *Service) bulkUpdateChecks(ctx context.Context, tx Tx, u influxdb.CheckBulkUpdate) (*influxdb.CheckBulkUpdateResult, error) {
+	if err := u.Valid(); err != nil {
+		return nil, err
+	}
+	if _, err := s.findOrganizationByID(ctx, tx, u.Filter.OrgID); err != nil {
+		return nil, err
+	}
+
+	cs, _, err := s.findChecks(ctx, tx, influxdb.CheckFilter{OrgID: &u.Filter.OrgID})
+	if err != nil {
+		return nil, err
+	}
+
+	// every check is updated and validated before any is stored, the stores
+	// without transactions can't roll back a partial update.
+	r := &influxdb.CheckBulkUpdateResult{
+		DryRun: u.DryRun,
+		Checks: make([]influxdb.Check, 0, len(cs)),
+	}
+	now := s.TimeGenerator.Now()
+	for _, c := range cs {
+		ok, err := s.matchCheckBulkFilter(ctx, tx, c, u.Filter)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		pauseReason := checkPauseReason(c)
+		if err := patchCheckBulk(c, u.Update); err != nil {
+			return nil, err
+		}
+		c.SetUpdatedAt(now)
+		if err := c.Valid(); err != nil {
+			return nil, err
+		}
+		if err := s.resumeCheck(ctx, tx, pauseReason, c); err != nil {
+			return nil, err
+		}
+		r.Checks = append(r.Checks, c)
+	}
+	if u.DryRun {
+		return r, nil
+	}
+
+	for _, c := range r.Checks {
+		if err := s.rotateCheckTask(ctx, tx, c); err != nil {
+			return nil, err
+		}
+		if err := s.putCheck(ctx, tx, c); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// matchCheckBulkFilter returns whether a check has every label and tag of a filter.
+func (s *Service) matchCheckBulkFilter(ctx context.Context, tx Tx, c influxdb.Check, f influxdb.CheckBulkFilter) (bool, error) {
+	if f.Every != nil {
+		sc, ok := c.(scheduledCheck)
+		if !ok || sc.GetEvery() != f.Every.Duration {
+			return false, nil
+		}
+	}
+
+	if len(f.Tags) > 0 {
+		tc, ok := c.(taggedCheck)
+		if !ok {
+			return false, nil
+		}
+		tags := make(map[string]string)
+		for _, t := range tc.GetTags() {
+			tags[t.Key] = t.Value
+		}
+		for k, v := range f.Tags {
+			if tv, ok := tags[k]; !ok || tv != v {
+				return false, nil
+			}
+		}
+	}
+
+	if len(f.Labels) > 0 {
+		ls, err := s.checkLabels(ctx, tx, c)
+		if err != nil {
+			return false, err
+		}
+		names := make(map[string]bool, len(ls))
+		for _, l := range ls {
+			names[l.Name] = true
+		}
+		for _, name := range f.Labels {
+			if !names[name] {
+				return false, nil
+			}
+		}
+	}
+	return true, nil
+}
+
+// patchCheckBulk sets the properties of a bulk update on a check.
+func patchCheckBulk(c influxdb.Check, p influxdb.CheckBulkPatch) error {
+	if p.Every != nil || p.Offset != nil {
+		sc, ok := c.(scheduledCheck)
+		if !ok {
+			return &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  fmt.Sprintf("check %s has no schedule", c.GetName()),
+			}
+		}
+		if p.Every != nil {
+			sc.SetEvery(p.Every.Duration)
+		}
+		if p.Offset != nil {
+			sc.SetOffset(p.Offset.Duration)
+		}
+	}
+	if p.Status != nil {
+		c.SetStatus(*p.Status)
+	}
+	return nil
+}
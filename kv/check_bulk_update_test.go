@@ -0,0 +1,135 @@
+package kv_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/check"
+)
+
+func TestService_BulkUpdateChecks(t *testing.T) {
+	ctx := context.Background()
+	svc, user, org := newTestServiceWithOrg(t)
+	label := &influxdb.Label{OrgID: org.ID, Name: "fleet"}
+	if err := svc.CreateLabel(ctx, label); err != nil {
+		t.Fatalf("failed to create label: %v", err)
+	}
+
+	newSLO := func(name, team string) *check.SLO {
+		return &check.SLO{
+			Base: check.Base{
+				Name:   name,
+				OrgID:  org.ID,
+				Status: influxdb.Active,
+				Every:  influxdb.Duration{Duration: time.Minute},
+				Query:  influxdb.DashboardQuery{Text: `from(bucket: "telegraf") |> filter(fn: (r) => r._measurement == "http")`},
+				Tags:   []notification.Tag{{Key: "team", Value: team}},
+			},
+			Objective:        0.99,
+			Window:           influxdb.Duration{Duration: 7 * 24 * time.Hour},
+			Indicator:        check.LatencyIndicator,
+			LatencyThreshold: 0.3,
+		}
+	}
+	cpu, mem, disk := newSLO("cpu", "ops"), newSLO("mem", "ops"), newSLO("disk", "dev")
+	for _, c := range []*check.SLO{cpu, mem, disk} {
+		if err := svc.CreateCheck(ctx, c, user.ID); err != nil {
+			t.Fatalf("failed to create check: %v", err)
+		}
+	}
+	for _, c := range []*check.SLO{cpu, disk} {
+		m := &influxdb.LabelMapping{LabelID: label.ID, ResourceID: c.ID, ResourceType: influxdb.ChecksResourceType}
+		if err := svc.CreateLabelMapping(ctx, m); err != nil {
+			t.Fatalf("failed to create label mapping: %v", err)
+		}
+	}
+
+	inactive := influxdb.Inactive
+	u := influxdb.CheckBulkUpdate{
+		Filter: influxdb.CheckBulkFilter{
+			OrgID:  org.ID,
+			Labels: []string{"fleet"},
+			Tags:   map[string]string{"team": "ops"},
+			Every:  &influxdb.Duration{Duration: time.Minute},
+		},
+		Update: influxdb.CheckBulkPatch{
+			Every:  &influxdb.Duration{Duration: 5 * time.Minute},
+			Status: &inactive,
+		},
+		DryRun: true,
+	}
+
+	r, err := svc.BulkUpdateChecks(ctx, u)
+	if err != nil {
+		t.Fatalf("failed to dry run bulk update: %v", err)
+	}
+	if !r.DryRun || len(r.Checks) != 1 || r.Checks[0].GetID() != cpu.ID {
+		t.Fatalf("expected the dry run to match the cpu check, got %v", r.Checks)
+	}
+	c, err := svc.FindCheckByID(ctx, cpu.ID)
+	if err != nil {
+		t.Fatalf("failed to find check: %v", err)
+	}
+	if c.(*check.SLO).Every.Duration != time.Minute || c.GetStatus() != influxdb.Active {
+		t.Errorf("expected the dry run not to update the check, got every %s and status %s", c.(*check.SLO).Every, c.GetStatus())
+	}
+
+	u.DryRun = false
+	r, err = svc.BulkUpdateChecks(ctx, u)
+	if err != nil {
+		t.Fatalf("failed to bulk update: %v", err)
+	}
+	if r.DryRun || len(r.Checks) != 1 || r.Checks[0].GetID() != cpu.ID {
+		t.Fatalf("expected the bulk update to update the cpu check, got %v", r.Checks)
+	}
+	c, err = svc.FindCheckByID(ctx, cpu.ID)
+	if err != nil {
+		t.Fatalf("failed to find check: %v", err)
+	}
+	if c.(*check.SLO).Every.Duration != 5*time.Minute || c.GetStatus() != influxdb.Inactive {
+		t.Errorf("expected the check to be updated, got every %s and status %s", c.(*check.SLO).Every, c.GetStatus())
+	}
+	task, err := svc.FindTaskByID(ctx, cpu.TaskID)
+	if err != nil {
+		t.Fatalf("failed to find the task of the check: %v", err)
+	}
+	if task.Every != "5m" || task.Status != string(influxdb.Inactive) {
+		t.Errorf("expected the task to follow the check, got every %s and status %s", task.Every, task.Status)
+	}
+	for _, id := range []influxdb.ID{mem.ID, disk.ID} {
+		c, err := svc.FindCheckByID(ctx, id)
+		if err != nil {
+			t.Fatalf("failed to find check: %v", err)
+		}
+		if c.GetStatus() != influxdb.Active {
+			t.Errorf("expected the check %s not matching the filter to be left alone", c.GetName())
+		}
+	}
+
+	// the checks run on a cron are moved to the interval of the update.
+	net := newSLO("net", "ops")
+	net.Every = influxdb.Duration{}
+	net.Cron = "0 * * * *"
+	if err := svc.CreateCheck(ctx, net, user.ID); err != nil {
+		t.Fatalf("failed to create check: %v", err)
+	}
+	u.Filter = influxdb.CheckBulkFilter{OrgID: org.ID, Tags: map[string]string{"team": "ops"}}
+	u.Update = influxdb.CheckBulkPatch{Every: &influxdb.Duration{Duration: 2 * time.Minute}}
+	r, err = svc.BulkUpdateChecks(ctx, u)
+	if err != nil {
+		t.Fatalf("failed to bulk update: %v", err)
+	}
+	if len(r.Checks) != 3 {
+		t.Fatalf("expected the bulk update to update the checks of the ops team, got %v", r.Checks)
+	}
+	c, err = svc.FindCheckByID(ctx, net.ID)
+	if err != nil {
+		t.Fatalf("failed to find check: %v", err)
+	}
+	if d := c.(*check.SLO); d.Cron != "" || d.Every.Duration != 2*time.Minute {
+		t.Errorf("expected the cron of the check to be replaced, got cron %q and every %s", d.Cron, d.Every)
+	}
+}
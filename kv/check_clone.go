@@ -0,0 +1,119 @@
+package kv
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification/check"
+)
+
+var _ influxdb.CheckCloneService = (*Service)(nil)
+
+// retargetableCheck is a check whose query can read another bucket.
+type retargetableCheck interface {
+	SetQueryBucket(bucket string) error
+}
+
+// CloneCheck creates a copy of a check and of its label mappings, owned by userID.
+func (s *Service) CloneCheck(ctx context.Context, id influxdb.ID, cl influxdb.CheckClone, userID influxdb.ID) (influxdb.Check, error) {
+	var (
+		c   influxdb.Check
+		err error
+	)
+	err = s.kv.Update(ctx, func(tx Tx) error {
+		c, err = s.cloneCheck(ctx, tx, id, cl, userID)
+		return err
+	})
+	return c, err
+}
+
+func (s *Service) cloneCheck(ctx context.Context, tx Tx, id influxdb.ID, cl influxdb.CheckClone, userID influxdb.ID) (influxdb.Check, error) {
+	if err := cl.Valid(); err != nil {
+		return nil, err
+	}
+	src, err := s.findCheckByID(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	c, err := check.Copy(src)
+	if err != nil {
+		return nil, err
+	}
+	orgID := cl.TargetOrgID(src.GetOrgID())
+	c.SetOrgID(orgID)
+	c.SetName(cl.Name)
+	// the copy gets its own task, and its own authorization scoped to the
+	// buckets of its query.
+	if tc, ok := c.(taskCheck); ok {
+		tc.SetTaskID(0)
+		tc.SetAuthorizationID(0)
+	}
+	if cl.Bucket != "" {
+		rc, ok := c.(retargetableCheck)
+		if !ok {
+			return nil, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "the query of the check can't read another bucket",
+			}
+		}
+		if _, err := s.findBucketByName(ctx, tx, orgID, cl.Bucket); err != nil {
+			return nil, err
+		}
+		if err := rc.SetQueryBucket(cl.Bucket); err != nil {
+			return nil, err
+		}
+	}
+
+	labels, err := s.checkLabels(ctx, tx, src)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.createCheck(ctx, tx, c, s.IDGenerator.ID(), userID); err != nil {
+		return nil, err
+	}
+	for _, l := range labels {
+		if l.OrgID != orgID {
+			if l, err = s.findOrCreateOrgLabel(ctx, tx, orgID, l); err != nil {
+				return nil, err
+			}
+		}
+		if err := s.createLabelMapping(ctx, tx, &influxdb.LabelMapping{
+			LabelID:      l.ID,
+			ResourceID:   c.GetID(),
+			ResourceType: influxdb.ChecksResourceType,
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// findOrCreateOrgLabel returns the label of the organization with the name
+// of a label of another organization, created with its properties if the
+// organization has none.
+func (s *Service) findOrCreateOrgLabel(ctx context.Context, tx Tx, orgID influxdb.ID, l *influxdb.Label) (*influxdb.Label, error) {
+	ls, err := s.findLabels(ctx, tx, influxdb.LabelFilter{Name: l.Name, OrgID: &orgID})
+	if err != nil {
+		return nil, err
+	}
+	if len(ls) > 0 {
+		return ls[0], nil
+	}
+
+	nl := &influxdb.Label{
+		ID:         s.IDGenerator.ID(),
+		OrgID:      orgID,
+		Name:       l.Name,
+		Properties: make(map[string]string, len(l.Properties)),
+	}
+	for k, v := range l.Properties {
+		nl.Properties[k] = v
+	}
+	if err := s.putLabel(ctx, tx, nl); err != nil {
+		return nil, err
+	}
+	if err := s.createLabelUserResourceMappings(ctx, tx, nl); err != nil {
+		return nil, err
+	}
+	return nl, nil
+}
@@ -0,0 +1,129 @@
+package kv_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/check"
+)
+
+func TestService_CloneCheck(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestService(t)
+
+	user := &influxdb.User{Name: "theuser"}
+	if err := svc.CreateUser(ctx, user); err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	from, to := &influxdb.Organization{Name: "from"}, &influxdb.Organization{Name: "to"}
+	for _, o := range []*influxdb.Organization{from, to} {
+		if err := svc.CreateOrganization(ctx, o); err != nil {
+			t.Fatalf("failed to create org: %v", err)
+		}
+		if err := svc.CreateBucket(ctx, &influxdb.Bucket{OrgID: o.ID, Name: "telegraf"}); err != nil {
+			t.Fatalf("failed to create bucket: %v", err)
+		}
+	}
+	if err := svc.CreateBucket(ctx, &influxdb.Bucket{OrgID: to.ID, Name: "staging"}); err != nil {
+		t.Fatalf("failed to create bucket: %v", err)
+	}
+
+	src := &check.Threshold{
+		Base: check.Base{
+			Name:   "cpu",
+			OrgID:  from.ID,
+			Status: influxdb.Active,
+			Every:  influxdb.Duration{Duration: time.Minute},
+			Query:  influxdb.DashboardQuery{Text: `from(bucket: "telegraf") |> range(start: -1m) |> filter(fn: (r) => r._measurement == "cpu")`},
+			Tags:   []notification.Tag{{Key: "team", Value: "ops"}},
+		},
+		Thresholds: []check.ThresholdConfig{
+			&check.Greater{ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Critical}, Value: 90},
+		},
+	}
+	if err := svc.CreateCheck(ctx, src, user.ID); err != nil {
+		t.Fatalf("failed to create check: %v", err)
+	}
+	prod := &influxdb.Label{OrgID: from.ID, Name: "prod", Properties: map[string]string{"color": "red"}}
+	if err := svc.CreateLabel(ctx, prod); err != nil {
+		t.Fatalf("failed to create label: %v", err)
+	}
+	if err := svc.CreateLabelMapping(ctx, &influxdb.LabelMapping{LabelID: prod.ID, ResourceID: src.ID, ResourceType: influxdb.ChecksResourceType}); err != nil {
+		t.Fatalf("failed to create label mapping: %v", err)
+	}
+
+	if _, err := svc.CloneCheck(ctx, src.ID, influxdb.CheckClone{Name: "cpu"}, user.ID); influxdb.ErrorCode(err) != influxdb.EConflict {
+		t.Fatalf("expected a conflict on the name of the check, got %v", err)
+	}
+	if _, err := svc.CloneCheck(ctx, src.ID, influxdb.CheckClone{Name: "cpu staging", OrgID: from.ID, Bucket: "staging"}, user.ID); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Fatalf("expected the bucket not to be found in the org, got %v", err)
+	}
+
+	// a copy in the same organization keeps the labels of the check.
+	c, err := svc.CloneCheck(ctx, src.ID, influxdb.CheckClone{Name: "cpu copy"}, user.ID)
+	if err != nil {
+		t.Fatalf("failed to clone check: %v", err)
+	}
+	th := c.(*check.Threshold)
+	if th.ID == src.ID || th.OrgID != from.ID || th.Query.Text != src.Query.Text || len(th.Thresholds) != 1 || th.Tags[0] != src.Tags[0] {
+		t.Errorf("unexpected copy of the check %+v", th)
+	}
+	if !th.TaskID.Valid() || th.TaskID == src.TaskID || th.AuthorizationID == src.AuthorizationID {
+		t.Errorf("expected the copy to have its own task, got task %s and authorization %s", th.TaskID, th.AuthorizationID)
+	}
+	labels, err := svc.FindResourceLabels(ctx, influxdb.LabelMappingFilter{ResourceID: th.ID, ResourceType: influxdb.ChecksResourceType})
+	if err != nil {
+		t.Fatalf("failed to find the labels of the copy: %v", err)
+	}
+	if len(labels) != 1 || labels[0].ID != prod.ID {
+		t.Errorf("expected the copy to have the label of the check, got %v", labels)
+	}
+	taskLabels, err := svc.FindResourceLabels(ctx, influxdb.LabelMappingFilter{ResourceID: th.TaskID, ResourceType: influxdb.TasksResourceType})
+	if err != nil {
+		t.Fatalf("failed to find the labels of the task of the copy: %v", err)
+	}
+	if len(taskLabels) != 1 {
+		t.Errorf("expected the label to be propagated to the task of the copy, got %v", taskLabels)
+	}
+
+	// a copy in another organization gets the labels of the same name.
+	c, err = svc.CloneCheck(ctx, src.ID, influxdb.CheckClone{Name: "cpu staging", OrgID: to.ID, Bucket: "staging"}, user.ID)
+	if err != nil {
+		t.Fatalf("failed to clone check: %v", err)
+	}
+	th = c.(*check.Threshold)
+	if th.OrgID != to.ID || th.Query.Text != `from(bucket: "staging") |> range(start: -1m) |> filter(fn: (r) => r._measurement == "cpu")` {
+		t.Errorf("expected the copy to read the bucket of the other org, got %s in %s", th.Query.Text, th.OrgID)
+	}
+	task, err := svc.FindTaskByID(ctx, th.TaskID)
+	if err != nil {
+		t.Fatalf("failed to find the task of the copy: %v", err)
+	}
+	if task.OrganizationID != to.ID {
+		t.Errorf("expected the task of the copy to be in the other org, got %s", task.OrganizationID)
+	}
+	labels, err = svc.FindResourceLabels(ctx, influxdb.LabelMappingFilter{ResourceID: th.ID, ResourceType: influxdb.ChecksResourceType})
+	if err != nil {
+		t.Fatalf("failed to find the labels of the copy: %v", err)
+	}
+	if len(labels) != 1 || labels[0].OrgID != to.ID || labels[0].Name != "prod" || labels[0].Properties["color"] != "red" {
+		t.Fatalf("expected the copy to have a prod label of the other org, got %v", labels)
+	}
+	created := labels[0]
+
+	// the labels of the organization are reused.
+	c, err = svc.CloneCheck(ctx, src.ID, influxdb.CheckClone{Name: "cpu 2", OrgID: to.ID}, user.ID)
+	if err != nil {
+		t.Fatalf("failed to clone check: %v", err)
+	}
+	labels, err = svc.FindResourceLabels(ctx, influxdb.LabelMappingFilter{ResourceID: c.GetID(), ResourceType: influxdb.ChecksResourceType})
+	if err != nil {
+		t.Fatalf("failed to find the labels of the copy: %v", err)
+	}
+	if len(labels) != 1 || labels[0].ID != created.ID {
+		t.Errorf("expected the copy to reuse the label %s, got %v", created.ID, labels)
+	}
+}
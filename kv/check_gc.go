@@ -0,0 +1,404 @@
+package kv
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"go.uber.org/zap"
+)
+
+var (
+	checkGCMarkBucket  = []byte("checkgcmarksv1")
+	checkGCEventBucket = []byte("checkgceventsv1")
+)
+
+var _ influxdb.CheckGCService = (*Service)(nil)
+
+func (s *Service) initializeCheckGC(ctx context.Context, tx Tx) error {
+	if _, err := tx.Bucket(checkGCMarkBucket); err != nil {
+		return err
+	}
+	if _, err := tx.Bucket(checkGCEventBucket); err != nil {
+		return err
+	}
+	return nil
+}
+
+// checkGCMark is the mark of a check whose owners were notified that it
+// will be deleted by the garbage collection, keyed by the id of the check.
+type checkGCMark struct {
+	NotifiedAt  time.Time `json:"notifiedAt"`
+	DeleteAfter time.Time `json:"deleteAfter"`
+}
+
+// RunCheckGC marks the checks eligible to p and notifies their owners,
+// deletes the marked checks past the grace period and clears the marks of
+// the checks which aren't eligible anymore. Each check is a transaction of
+// its own, checked again before it is deleted, a failure is recorded
+// without stopping the others.
+func (s *Service) RunCheckGC(ctx context.Context, p influxdb.CheckGCPolicy) (*influxdb.CheckGCRun, error) {
+	if err := p.Valid(); err != nil {
+		return nil, err
+	}
+	r := &influxdb.CheckGCRun{
+		StartedAt: s.TimeGenerator.Now(),
+		DryRun:    p.DryRun,
+		Events:    []influxdb.CheckGCEvent{},
+	}
+	if !p.Enabled() {
+		r.FinishedAt = r.StartedAt
+		return r, nil
+	}
+
+	var ids []influxdb.ID
+	err := s.kv.View(ctx, func(tx Tx) (err error) {
+		ids, err = s.findCheckGCCandidates(ctx, tx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range ids {
+		ev, err := s.collectCheck(ctx, p, r.StartedAt, id)
+		if err != nil {
+			s.Logger.Info("failed to collect check", zap.String("checkID", id.String()), zap.Error(err))
+			continue
+		}
+		if ev != nil {
+			r.Events = append(r.Events, *ev)
+		}
+	}
+	r.FinishedAt = s.TimeGenerator.Now()
+	return r, nil
+}
+
+// findCheckGCCandidates returns the ids of the inactive or archived checks
+// and of the marked checks.
+func (s *Service) findCheckGCCandidates(ctx context.Context, tx Tx) ([]influxdb.ID, error) {
+	var ids []influxdb.ID
+	seen := make(map[influxdb.ID]bool)
+	err := s.forEachCheck(ctx, tx, nil, func(c influxdb.Check) bool {
+		if c.GetStatus() == influxdb.Inactive || isArchivedCheck(c) {
+			ids = append(ids, c.GetID())
+			seen[c.GetID()] = true
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := tx.Bucket(checkGCMarkBucket)
+	if err != nil {
+		return nil, UnavailableCheckStoreError(err)
+	}
+	cur, err := b.Cursor()
+	if err != nil {
+		return nil, UnavailableCheckStoreError(err)
+	}
+	for k, _ := cur.First(); k != nil; k, _ = cur.Next() {
+		var id influxdb.ID
+		if err := id.Decode(k); err != nil {
+			return nil, InternalCheckStoreError(err)
+		}
+		if !seen[id] {
+			ids = append(ids, id)
+			seen[id] = true
+		}
+	}
+	return ids, nil
+}
+
+// collectCheck applies the policy to a check and records what was done,
+// it returns the event of the action, nil if nothing was done.
+func (s *Service) collectCheck(ctx context.Context, p influxdb.CheckGCPolicy, now time.Time, id influxdb.ID) (*influxdb.CheckGCEvent, error) {
+	if p.DryRun {
+		var ev *influxdb.CheckGCEvent
+		err := s.kv.View(ctx, func(tx Tx) (err error) {
+			ev, _, err = s.planCheckGC(ctx, tx, p, now, id)
+			return err
+		})
+		if ev != nil {
+			ev.DryRun = true
+		}
+		return ev, err
+	}
+
+	var (
+		ev *influxdb.CheckGCEvent
+		c  influxdb.Check
+	)
+	err := s.kv.Update(ctx, func(tx Tx) (err error) {
+		ev, c, err = s.planCheckGC(ctx, tx, p, now, id)
+		if err != nil || ev == nil || ev.Action == influxdb.CheckGCNotified {
+			return err
+		}
+		// the deletion of the check clears its mark.
+		if ev.Action == influxdb.CheckGCDeleted {
+			err = s.deleteCheck(ctx, tx, id)
+		} else {
+			err = s.deleteCheckGCMark(ctx, tx, id)
+		}
+		if err != nil {
+			return err
+		}
+		return s.appendCheckGCEvent(ctx, tx, ev)
+	})
+	if err != nil {
+		if ev == nil || ev.Action != influxdb.CheckGCDeleted {
+			return nil, err
+		}
+		ev.Action, ev.Error = influxdb.CheckGCFailed, err.Error()
+		return ev, s.kv.Update(ctx, func(tx Tx) error {
+			return s.appendCheckGCEvent(ctx, tx, ev)
+		})
+	}
+	if ev == nil || ev.Action != influxdb.CheckGCNotified {
+		return ev, nil
+	}
+
+	ev.Notified, ev.Error = s.notifyCheckGC(ctx, c, *ev.DeleteAfter, p.ExcludeLabel)
+	err = s.kv.Update(ctx, func(tx Tx) error {
+		if err := s.putCheckGCMark(ctx, tx, id, checkGCMark{
+			NotifiedAt:  now,
+			DeleteAfter: *ev.DeleteAfter,
+		}); err != nil {
+			return err
+		}
+		return s.appendCheckGCEvent(ctx, tx, ev)
+	})
+	return ev, err
+}
+
+// planCheckGC returns the action the policy takes on a check, nil if none:
+// an eligible check is marked, or deleted once it was marked for the grace
+// period, and a marked check which isn't eligible anymore is spared. A check
+// which doesn't exist has no action.
+func (s *Service) planCheckGC(ctx context.Context, tx Tx, p influxdb.CheckGCPolicy, now time.Time, id influxdb.ID) (*influxdb.CheckGCEvent, influxdb.Check, error) {
+	c, err := s.findCheckByID(ctx, tx, id)
+	if influxdb.ErrorCode(err) == influxdb.ENotFound {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	mark, err := s.findCheckGCMark(ctx, tx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	eligible, reason, err := s.checkGCEligibility(ctx, tx, p, now, c, mark)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ev := &influxdb.CheckGCEvent{
+		Time:    now,
+		CheckID: c.GetID(),
+		OrgID:   c.GetOrgID(),
+		Name:    c.GetName(),
+		Reason:  reason,
+	}
+	switch {
+	case mark == nil && !eligible:
+		return nil, c, nil
+	case mark == nil:
+		deleteAfter := now.Add(p.Grace())
+		ev.Action, ev.DeleteAfter = influxdb.CheckGCNotified, &deleteAfter
+	case !eligible:
+		ev.Action = influxdb.CheckGCSpared
+	case now.Before(mark.DeleteAfter):
+		return nil, c, nil
+	default:
+		deleteAfter := mark.DeleteAfter
+		ev.Action, ev.DeleteAfter = influxdb.CheckGCDeleted, &deleteAfter
+	}
+	return ev, c, nil
+}
+
+// checkGCEligibility returns whether a check is eligible to the garbage
+// collection, and why it is or isn't. A marked check modified since its
+// owners were notified isn't eligible anymore.
+func (s *Service) checkGCEligibility(ctx context.Context, tx Tx, p influxdb.CheckGCPolicy, now time.Time, c influxdb.Check, mark *checkGCMark) (bool, string, error) {
+	state := "inactive"
+	if isArchivedCheck(c) {
+		state = "archived"
+	} else if c.GetStatus() != influxdb.Inactive {
+		return false, "the check is active", nil
+	}
+	updatedAt := c.GetCRUDLog().UpdatedAt
+	if now.Sub(updatedAt) < p.InactiveFor || (mark != nil && updatedAt.After(mark.NotifiedAt)) {
+		return false, fmt.Sprintf("the check was modified at %s", updatedAt.Format(time.RFC3339)), nil
+	}
+	if p.ExcludeLabel != "" {
+		ls, err := s.checkLabels(ctx, tx, c)
+		if err != nil {
+			return false, "", err
+		}
+		for _, l := range ls {
+			if l.Name == p.ExcludeLabel {
+				return false, fmt.Sprintf("the check has the label %s", p.ExcludeLabel), nil
+			}
+		}
+	}
+	return true, fmt.Sprintf("the check is %s and unmodified since %s", state, updatedAt.Format(time.RFC3339)), nil
+}
+
+// notifyCheckGC tells the owners of a marked check when it will be deleted,
+// and returns the owners notified and the failures.
+func (s *Service) notifyCheckGC(ctx context.Context, c influxdb.Check, deleteAfter time.Time, excludeLabel string) ([]influxdb.ID, string) {
+	if s.CheckGCNotifier == nil {
+		return nil, ""
+	}
+	urms, _, err := s.FindUserResourceMappings(ctx, influxdb.UserResourceMappingFilter{
+		ResourceID:   c.GetID(),
+		ResourceType: influxdb.ChecksResourceType,
+		UserType:     influxdb.Owner,
+	})
+	if err != nil {
+		return nil, fmt.Sprintf("failed to find the owners of the check: %v", err)
+	}
+	var (
+		notified []influxdb.ID
+		failures []string
+	)
+	for _, urm := range urms {
+		if err := s.CheckGCNotifier.NotifyCheckGC(ctx, c, urm.UserID, deleteAfter, excludeLabel); err != nil {
+			failures = append(failures, fmt.Sprintf("failed to notify owner %s: %v", urm.UserID, err))
+			continue
+		}
+		notified = append(notified, urm.UserID)
+	}
+	return notified, strings.Join(failures, "; ")
+}
+
+func (s *Service) findCheckGCMark(ctx context.Context, tx Tx, id influxdb.ID) (*checkGCMark, error) {
+	encID, err := id.Encode()
+	if err != nil {
+		return nil, ErrInvalidCheckID
+	}
+	b, err := tx.Bucket(checkGCMarkBucket)
+	if err != nil {
+		return nil, UnavailableCheckStoreError(err)
+	}
+	v, err := b.Get(encID)
+	if IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, InternalCheckStoreError(err)
+	}
+	m := &checkGCMark{}
+	if err := json.Unmarshal(v, m); err != nil {
+		return nil, InternalCheckStoreError(err)
+	}
+	return m, nil
+}
+
+func (s *Service) putCheckGCMark(ctx context.Context, tx Tx, id influxdb.ID, m checkGCMark) error {
+	encID, err := id.Encode()
+	if err != nil {
+		return ErrInvalidCheckID
+	}
+	v, err := json.Marshal(m)
+	if err != nil {
+		return InternalCheckStoreError(err)
+	}
+	b, err := tx.Bucket(checkGCMarkBucket)
+	if err != nil {
+		return UnavailableCheckStoreError(err)
+	}
+	if err := b.Put(encID, v); err != nil {
+		return UnavailableCheckStoreError(err)
+	}
+	return nil
+}
+
+// deleteCheckGCMark clears the mark of a check, if any.
+func (s *Service) deleteCheckGCMark(ctx context.Context, tx Tx, id influxdb.ID) error {
+	encID, err := id.Encode()
+	if err != nil {
+		return ErrInvalidCheckID
+	}
+	b, err := tx.Bucket(checkGCMarkBucket)
+	if err != nil {
+		return UnavailableCheckStoreError(err)
+	}
+	if err := b.Delete(encID); err != nil && !IsNotFound(err) {
+		return UnavailableCheckStoreError(err)
+	}
+	return nil
+}
+
+// appendCheckGCEvent records an event of the garbage collection after the
+// latest one, dropping the event MaxCheckGCEvents older.
+func (s *Service) appendCheckGCEvent(ctx context.Context, tx Tx, ev *influxdb.CheckGCEvent) error {
+	b, err := tx.Bucket(checkGCEventBucket)
+	if err != nil {
+		return UnavailableCheckStoreError(err)
+	}
+	cur, err := b.Cursor()
+	if err != nil {
+		return UnavailableCheckStoreError(err)
+	}
+	var seq uint64 = 1
+	if k, _ := cur.Last(); len(k) == 8 {
+		seq = binary.BigEndian.Uint64(k) + 1
+	}
+
+	v, err := json.Marshal(ev)
+	if err != nil {
+		return InternalCheckStoreError(err)
+	}
+	if err := b.Put(encodeResourceVersion(seq), v); err != nil {
+		return UnavailableCheckStoreError(err)
+	}
+	if seq > influxdb.MaxCheckGCEvents {
+		if err := b.Delete(encodeResourceVersion(seq - influxdb.MaxCheckGCEvents)); err != nil {
+			return UnavailableCheckStoreError(err)
+		}
+	}
+	return nil
+}
+
+// FindCheckGCEvents returns the recorded events of the garbage collection of
+// the checks matching filter, from the oldest to the latest.
+func (s *Service) FindCheckGCEvents(ctx context.Context, filter influxdb.CheckGCEventFilter) ([]*influxdb.CheckGCEvent, error) {
+	events := []*influxdb.CheckGCEvent{}
+	err := s.kv.View(ctx, func(tx Tx) error {
+		b, err := tx.Bucket(checkGCEventBucket)
+		if err != nil {
+			return UnavailableCheckStoreError(err)
+		}
+		cur, err := b.Cursor()
+		if err != nil {
+			return UnavailableCheckStoreError(err)
+		}
+		for k, v := cur.First(); k != nil; k, v = cur.Next() {
+			ev := &influxdb.CheckGCEvent{}
+			if err := json.Unmarshal(v, ev); err != nil {
+				return InternalCheckStoreError(err)
+			}
+			if filter.OrgID != nil && ev.OrgID != *filter.OrgID {
+				continue
+			}
+			if filter.CheckID != nil && ev.CheckID != *filter.CheckID {
+				continue
+			}
+			if filter.Action != "" && ev.Action != filter.Action {
+				continue
+			}
+			events = append(events, ev)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
@@ -0,0 +1,200 @@
+package kv_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/notification/check"
+)
+
+// checkGCNotifierFunc is a check gc notifier calling a function.
+type checkGCNotifierFunc func(ctx context.Context, c influxdb.Check, ownerID influxdb.ID, deleteAfter time.Time, excludeLabel string) error
+
+func (f checkGCNotifierFunc) NotifyCheckGC(ctx context.Context, c influxdb.Check, ownerID influxdb.ID, deleteAfter time.Time, excludeLabel string) error {
+	return f(ctx, c, ownerID, deleteAfter, excludeLabel)
+}
+
+func TestService_RunCheckGC(t *testing.T) {
+	ctx := context.Background()
+	svc, user, org := newTestServiceWithOrg(t)
+	notified := map[influxdb.ID]time.Time{}
+	svc.CheckGCNotifier = checkGCNotifierFunc(func(ctx context.Context, c influxdb.Check, ownerID influxdb.ID, deleteAfter time.Time, excludeLabel string) error {
+		notified[c.GetID()] = deleteAfter
+		return nil
+	})
+
+	day := 24 * time.Hour
+	now := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	svc.TimeGenerator = mock.TimeGenerator{FakeValue: now}
+
+	checks := make(map[string]*check.Deadman)
+	for _, name := range []string{"stale", "revived", "kept", "running"} {
+		status := influxdb.Inactive
+		if name == "running" {
+			status = influxdb.Active
+		}
+		c := &check.Deadman{
+			Base: check.Base{
+				Name:   name,
+				OrgID:  org.ID,
+				Status: status,
+				Every:  influxdb.Duration{Duration: time.Minute},
+				Query:  influxdb.DashboardQuery{Text: `from(bucket: "telegraf") |> range(start: -1m)`},
+			},
+			TimeSince: 60,
+		}
+		if err := svc.CreateCheck(ctx, c, user.ID); err != nil {
+			t.Fatalf("failed to create check: %v", err)
+		}
+		checks[name] = c
+	}
+	label := &influxdb.Label{Name: influxdb.DefaultCheckGCExcludeLabel, OrgID: org.ID}
+	if err := svc.CreateLabel(ctx, label); err != nil {
+		t.Fatalf("failed to create label: %v", err)
+	}
+	if err := svc.CreateLabelMapping(ctx, &influxdb.LabelMapping{
+		LabelID:      label.ID,
+		ResourceID:   checks["kept"].ID,
+		ResourceType: influxdb.ChecksResourceType,
+	}); err != nil {
+		t.Fatalf("failed to create label mapping: %v", err)
+	}
+
+	p := influxdb.CheckGCPolicy{
+		InactiveFor:  180 * day,
+		GracePeriod:  14 * day,
+		ExcludeLabel: influxdb.DefaultCheckGCExcludeLabel,
+	}
+	actions := func(r *influxdb.CheckGCRun) map[string]string {
+		got := make(map[string]string, len(r.Events))
+		for _, ev := range r.Events {
+			got[ev.Name] = ev.Action
+		}
+		return got
+	}
+
+	// the checks aren't inactive for long enough yet.
+	svc.TimeGenerator = mock.TimeGenerator{FakeValue: now.Add(100 * day)}
+	r, err := svc.RunCheckGC(ctx, p)
+	if err != nil {
+		t.Fatalf("failed to run check gc: %v", err)
+	}
+	if len(r.Events) != 0 {
+		t.Fatalf("expected no event, got %+v", r.Events)
+	}
+
+	marked := now.Add(200 * day)
+	svc.TimeGenerator = mock.TimeGenerator{FakeValue: marked}
+	r, err = svc.RunCheckGC(ctx, p)
+	if err != nil {
+		t.Fatalf("failed to run check gc: %v", err)
+	}
+	if got := actions(r); len(got) != 2 || got["stale"] != influxdb.CheckGCNotified || got["revived"] != influxdb.CheckGCNotified {
+		t.Fatalf("expected the stale and revived checks to be marked, got %v", got)
+	}
+	if len(notified) != 2 || !notified[checks["stale"].ID].Equal(marked.Add(14*day)) {
+		t.Errorf("expected the owners to be notified of the deletion after the grace period, got %v", notified)
+	}
+	if ids := r.Events[0].Notified; len(ids) != 1 || ids[0] != user.ID {
+		t.Errorf("expected the owner to be recorded as notified, got %v", ids)
+	}
+
+	// a marked check modified during the grace period is spared.
+	svc.TimeGenerator = mock.TimeGenerator{FakeValue: now.Add(205 * day)}
+	desc := "still needed"
+	if _, err := svc.PatchCheck(ctx, checks["revived"].ID, influxdb.CheckUpdate{Description: &desc}); err != nil {
+		t.Fatalf("failed to patch check: %v", err)
+	}
+
+	// the grace period isn't over.
+	svc.TimeGenerator = mock.TimeGenerator{FakeValue: now.Add(210 * day)}
+	dry := p
+	dry.DryRun = true
+	if r, err = svc.RunCheckGC(ctx, dry); err != nil {
+		t.Fatalf("failed to run check gc: %v", err)
+	}
+	if got := actions(r); len(got) != 1 || got["revived"] != influxdb.CheckGCSpared {
+		t.Fatalf("expected only the revived check to be spared, got %v", got)
+	}
+
+	svc.TimeGenerator = mock.TimeGenerator{FakeValue: now.Add(215 * day)}
+	if r, err = svc.RunCheckGC(ctx, dry); err != nil {
+		t.Fatalf("failed to run check gc: %v", err)
+	}
+	if got := actions(r); len(got) != 2 || got["stale"] != influxdb.CheckGCDeleted || !r.Events[0].DryRun {
+		t.Fatalf("expected the dry run to report the stale check as deleted, got %+v", r.Events)
+	}
+	if _, err := svc.FindCheckByID(ctx, checks["stale"].ID); err != nil {
+		t.Fatalf("expected the dry run to keep the check, got %v", err)
+	}
+
+	if r, err = svc.RunCheckGC(ctx, p); err != nil {
+		t.Fatalf("failed to run check gc: %v", err)
+	}
+	if got := actions(r); len(got) != 2 || got["stale"] != influxdb.CheckGCDeleted || got["revived"] != influxdb.CheckGCSpared {
+		t.Fatalf("expected the stale check to be deleted and the revived one spared, got %v", got)
+	}
+	if _, err := svc.FindCheckByID(ctx, checks["stale"].ID); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Fatalf("expected the stale check to be deleted, got %v", err)
+	}
+	for _, name := range []string{"revived", "kept", "running"} {
+		if _, err := svc.FindCheckByID(ctx, checks[name].ID); err != nil {
+			t.Errorf("expected check %s to be kept, got %v", name, err)
+		}
+	}
+
+	// the spared check isn't marked again until it is unmodified for long enough.
+	if r, err = svc.RunCheckGC(ctx, p); err != nil {
+		t.Fatalf("failed to run check gc: %v", err)
+	}
+	if len(r.Events) != 0 {
+		t.Fatalf("expected no event, got %+v", r.Events)
+	}
+
+	events, err := svc.FindCheckGCEvents(ctx, influxdb.CheckGCEventFilter{OrgID: &org.ID})
+	if err != nil {
+		t.Fatalf("failed to find check gc events: %v", err)
+	}
+	var got []string
+	for _, ev := range events {
+		got = append(got, ev.Name+" "+ev.Action)
+	}
+	want := []string{"stale notified", "revived notified", "stale deleted", "revived spared"}
+	if len(got) != len(want) {
+		t.Fatalf("unexpected audit trail, got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("unexpected audit trail, got %v, want %v", got, want)
+		}
+	}
+	if events[3].Reason == "" {
+		t.Errorf("expected the spared check to have a reason")
+	}
+
+	events, err = svc.FindCheckGCEvents(ctx, influxdb.CheckGCEventFilter{Action: influxdb.CheckGCDeleted})
+	if err != nil {
+		t.Fatalf("failed to find check gc events: %v", err)
+	}
+	if len(events) != 1 || events[0].CheckID != checks["stale"].ID {
+		t.Errorf("expected the deletion of the stale check, got %+v", events)
+	}
+}
+
+func TestService_RunCheckGC_disabled(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestService(t)
+	r, err := svc.RunCheckGC(ctx, influxdb.CheckGCPolicy{})
+	if err != nil {
+		t.Fatalf("failed to run check gc: %v", err)
+	}
+	if len(r.Events) != 0 {
+		t.Errorf("expected no event, got %+v", r.Events)
+	}
+	if _, err := svc.RunCheckGC(ctx, influxdb.CheckGCPolicy{InactiveFor: -time.Hour}); influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Errorf("expected a negative duration to be invalid, got %v", err)
+	}
+}
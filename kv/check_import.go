@@ -0,0 +1,96 @@
+package kv
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.CheckImportService = (*Service)(nil)
+
+// ImportChecks creates the checks of an import in an organization, owned by userID,
+// applying its strategy to the checks whose name is already used.
+func (s *Service) ImportChecks(ctx context.Context, orgID influxdb.ID, imp influxdb.CheckImport, userID influxdb.ID) ([]*influxdb.CheckImportResult, error) {
+	var (
+		rs  []*influxdb.CheckImportResult
+		err error
+	)
+	err = s.kv.Update(ctx, func(tx Tx) error {
+		rs, err = s.importChecks(ctx, tx, orgID, imp, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rs, nil
+}
+
+func (s *Service) importChecks(ctx context.Context, tx Tx, orgID influxdb.ID, imp influxdb.CheckImport, userID influxdb.ID) ([]*influxdb.CheckImportResult, error) {
+	if err := imp.Valid(); err != nil {
+		return nil, err
+	}
+	if _, err := s.findOrganizationByID(ctx, tx, orgID); err != nil {
+		return nil, err
+	}
+
+	// without a strategy the conflicts are found before any check is created,
+	// the stores without transactions can't roll back a partial import.
+	if imp.OnConflict == "" {
+		for _, c := range imp.Checks {
+			if _, err := s.availableCheckName(ctx, tx, orgID, c.GetName(), false); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	rs := make([]*influxdb.CheckImportResult, 0, len(imp.Checks))
+	for _, c := range imp.Checks {
+		r, err := s.importCheck(ctx, tx, orgID, c, imp.OnConflict, userID)
+		if err != nil {
+			return nil, err
+		}
+		rs = append(rs, r)
+	}
+	return rs, nil
+}
+
+func (s *Service) importCheck(ctx context.Context, tx Tx, orgID influxdb.ID, c influxdb.Check, onConflict string, userID influxdb.ID) (*influxdb.CheckImportResult, error) {
+	r := &influxdb.CheckImportResult{
+		Name:   c.GetName(),
+		Action: influxdb.CheckImportCreated,
+		Check:  c,
+	}
+	current, err := s.findCheckByName(ctx, tx, orgID, c.GetName())
+	if err != nil && influxdb.ErrorCode(err) != influxdb.ENotFound {
+		return nil, err
+	}
+
+	c.SetOrgID(orgID)
+	if current != nil {
+		switch onConflict {
+		case influxdb.ImportOnConflictSkip:
+			r.Action = influxdb.CheckImportSkipped
+			r.Check = current
+			return r, nil
+		case influxdb.ImportOnConflictOverwrite:
+			r.Action = influxdb.CheckImportOverwritten
+			r.Check, err = s.updateCheck(ctx, tx, current.GetID(), c)
+			if err != nil {
+				return nil, err
+			}
+			return r, nil
+		}
+
+		name, err := s.availableCheckName(ctx, tx, orgID, c.GetName(), onConflict == influxdb.ImportOnConflictRename)
+		if err != nil {
+			return nil, err
+		}
+		r.Action = influxdb.CheckImportRenamed
+		c.SetName(name)
+	}
+
+	if err := s.createCheck(ctx, tx, c, s.IDGenerator.ID(), userID); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
@@ -0,0 +1,79 @@
+package kv_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification/check"
+)
+
+func TestService_ImportChecks(t *testing.T) {
+	ctx := context.Background()
+	svc, user, org := newTestServiceWithOrg(t)
+
+	withTimeSince := func(c *check.Deadman, timeSince int) *check.Deadman {
+		c.TimeSince = timeSince
+		return c
+	}
+	existing := newDeadman(org.ID, "cpu")
+	if err := svc.CreateCheck(ctx, existing, user.ID); err != nil {
+		t.Fatalf("failed to create check: %v", err)
+	}
+
+	// the import fails with a conflict without a strategy, and creates nothing.
+	_, err := svc.ImportChecks(ctx, org.ID, influxdb.CheckImport{
+		Checks: []influxdb.Check{newDeadman(org.ID, "mem"), withTimeSince(newDeadman(org.ID, "cpu"), 120)},
+	}, user.ID)
+	if influxdb.ErrorCode(err) != influxdb.EConflict {
+		t.Fatalf("expected a conflict on the name of the check, got %v", err)
+	}
+	mem := "mem"
+	if _, err := svc.FindCheck(ctx, influxdb.CheckFilter{OrgID: &org.ID, Name: &mem}); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Errorf("expected the failed import to create no check, got %v", err)
+	}
+
+	if _, err := svc.ImportChecks(ctx, org.ID, influxdb.CheckImport{OnConflict: "replace"}, user.ID); influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Errorf("expected an invalid strategy to fail, got %v", err)
+	}
+
+	tests := []struct {
+		onConflict string
+		action     string
+		name       string
+		timeSince  int
+	}{
+		{onConflict: influxdb.ImportOnConflictSkip, action: influxdb.CheckImportSkipped, name: "cpu", timeSince: 60},
+		{onConflict: influxdb.ImportOnConflictRename, action: influxdb.CheckImportRenamed, name: "cpu (2)", timeSince: 120},
+		{onConflict: influxdb.ImportOnConflictOverwrite, action: influxdb.CheckImportOverwritten, name: "cpu", timeSince: 120},
+	}
+	for _, tt := range tests {
+		t.Run(tt.onConflict, func(t *testing.T) {
+			rs, err := svc.ImportChecks(ctx, org.ID, influxdb.CheckImport{
+				Checks:     []influxdb.Check{withTimeSince(newDeadman(org.ID, "cpu"), 120)},
+				OnConflict: tt.onConflict,
+			}, user.ID)
+			if err != nil {
+				t.Fatalf("failed to import checks: %v", err)
+			}
+			if len(rs) != 1 {
+				t.Fatalf("expected a result for the imported check, got %d", len(rs))
+			}
+			r := rs[0]
+			if r.Name != "cpu" || r.Action != tt.action {
+				t.Errorf("expected check cpu to be %s, got %s %s", tt.action, r.Name, r.Action)
+			}
+			c := r.Check.(*check.Deadman)
+			if c.Name != tt.name || c.TimeSince != tt.timeSince || c.OrgID != org.ID {
+				t.Errorf("expected check %s with timeSince %d, got %s with %d", tt.name, tt.timeSince, c.Name, c.TimeSince)
+			}
+			if tt.action != influxdb.CheckImportRenamed && c.ID != existing.ID {
+				t.Errorf("expected the check of the organization %s, got %s", existing.ID, c.ID)
+			}
+		})
+	}
+
+	if rs, err := svc.ImportChecks(ctx, org.ID, influxdb.CheckImport{Checks: []influxdb.Check{newDeadman(org.ID, "mem")}}, user.ID); err != nil || rs[0].Action != influxdb.CheckImportCreated {
+		t.Errorf("expected check mem to be created, got %v", err)
+	}
+}
@@ -0,0 +1,41 @@
+package kv_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/kv"
+)
+
+func TestService_CheckNamePolicy(t *testing.T) {
+	ctx := context.Background()
+	svc, user, org := newTestServiceWithOrg(t, kv.ServiceConfig{
+		CheckNamePolicy: influxdb.CheckNamePolicy{
+			MaxLength:        20,
+			Pattern:          `[a-z0-9 _-]+`,
+			ReservedPrefixes: []string{"_system"},
+		},
+	})
+
+	if err := svc.CreateCheck(ctx, newDeadman(org.ID, "_system heartbeat"), user.ID); influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Errorf("expected a check with a reserved prefix to be invalid, got %v", err)
+	}
+	c := newDeadman(org.ID, "heartbeat")
+	if err := svc.CreateCheck(ctx, c, user.ID); err != nil {
+		t.Fatalf("failed to create check: %v", err)
+	}
+
+	name := "Heartbeat"
+	if _, err := svc.PatchCheck(ctx, c.ID, influxdb.CheckUpdate{Name: &name}); influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Errorf("expected renaming a check out of the charset to be invalid, got %v", err)
+	}
+	upd := newDeadman(org.ID, "heartbeat of the api servers")
+	if _, err := svc.UpdateCheck(ctx, c.ID, upd); influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Errorf("expected renaming a check to a long name to be invalid, got %v", err)
+	}
+	upd = newDeadman(org.ID, "api heartbeat")
+	if _, err := svc.UpdateCheck(ctx, c.ID, upd); err != nil {
+		t.Errorf("failed to rename check: %v", err)
+	}
+}
@@ -0,0 +1,126 @@
+package kv
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification/check"
+	"go.uber.org/zap"
+)
+
+// pausableCheck is a check that can be paused automatically.
+type pausableCheck interface {
+	GetQuery() influxdb.DashboardQuery
+	GetPauseReason() string
+	SetPauseReason(string)
+}
+
+// checkPauseReason returns why a check was paused, empty if it wasn't.
+func checkPauseReason(c influxdb.Check) string {
+	if pc, ok := c.(pausableCheck); ok {
+		return pc.GetPauseReason()
+	}
+	return ""
+}
+
+// pauseBucketChecks sets inactive the active checks of the organization of
+// a deleted bucket whose query reads it, rather than letting them fail
+// every interval, and returns them. The checks are annotated with the
+// reason they were paused.
+func (s *Service) pauseBucketChecks(ctx context.Context, tx Tx, b *influxdb.Bucket) ([]influxdb.Check, error) {
+	var paused []influxdb.Check
+	orgID := b.OrgID
+	err := s.forEachCheck(ctx, tx, &orgID, func(c influxdb.Check) bool {
+		pc, ok := c.(pausableCheck)
+		if !ok || c.GetStatus() != influxdb.Active || isArchivedCheck(c) {
+			return true
+		}
+		scope, err := check.ParseQueryScope(pc.GetQuery())
+		if err != nil {
+			return true
+		}
+		for _, name := range scope.Buckets {
+			if name == b.Name {
+				paused = append(paused, c)
+				break
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.TimeGenerator.Now()
+	for _, c := range paused {
+		c.SetStatus(influxdb.Inactive)
+		c.(pausableCheck).SetPauseReason(fmt.Sprintf("the bucket %s read by the query of the check was deleted", b.Name))
+		c.SetUpdatedAt(now)
+		if err := s.updateCheckTaskStatus(ctx, tx, c); err != nil {
+			return nil, err
+		}
+		if err := s.putCheck(ctx, tx, c); err != nil {
+			return nil, err
+		}
+	}
+	return paused, nil
+}
+
+// resumeCheck keeps the reason a check was paused while it is inactive. A
+// paused check is only reactivated once every bucket read by its query
+// exists, which clears the reason.
+func (s *Service) resumeCheck(ctx context.Context, tx Tx, reason string, c influxdb.Check) error {
+	pc, ok := c.(pausableCheck)
+	if !ok {
+		return nil
+	}
+	if reason == "" || c.GetStatus() != influxdb.Active {
+		pc.SetPauseReason(reason)
+		return nil
+	}
+
+	if scope, err := check.ParseQueryScope(pc.GetQuery()); err == nil {
+		for _, name := range scope.Buckets {
+			_, err := s.findBucketByName(ctx, tx, c.GetOrgID(), name)
+			if influxdb.ErrorCode(err) == influxdb.ENotFound {
+				return &influxdb.Error{
+					Code: influxdb.EInvalid,
+					Msg:  fmt.Sprintf("check can't be reactivated, the bucket %s read by its query doesn't exist", name),
+				}
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	pc.SetPauseReason("")
+	return nil
+}
+
+// notifyPausedChecks tells the owners of paused checks why they were paused.
+// The failures are logged.
+func (s *Service) notifyPausedChecks(ctx context.Context, paused []influxdb.Check) {
+	if s.CheckPauseNotifier == nil {
+		return
+	}
+	for _, c := range paused {
+		urms, _, err := s.FindUserResourceMappings(ctx, influxdb.UserResourceMappingFilter{
+			ResourceID:   c.GetID(),
+			ResourceType: influxdb.ChecksResourceType,
+			UserType:     influxdb.Owner,
+		})
+		if err != nil {
+			s.Logger.Info("failed to find the owners of paused check", zap.String("checkID", c.GetID().String()), zap.Error(err))
+			continue
+		}
+		for _, urm := range urms {
+			if err := s.CheckPauseNotifier.NotifyCheckPaused(ctx, c, urm.UserID); err != nil {
+				s.Logger.Info("failed to notify the owner of paused check",
+					zap.String("checkID", c.GetID().String()),
+					zap.String("userID", urm.UserID.String()),
+					zap.Error(err))
+			}
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package kv_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/check"
+)
+
+// checkPauseNotifierFunc is a check pause notifier calling a function.
+type checkPauseNotifierFunc func(ctx context.Context, c influxdb.Check, ownerID influxdb.ID) error
+
+func (f checkPauseNotifierFunc) NotifyCheckPaused(ctx context.Context, c influxdb.Check, ownerID influxdb.ID) error {
+	return f(ctx, c, ownerID)
+}
+
+func TestService_PauseBucketChecks(t *testing.T) {
+	ctx := context.Background()
+	svc, user, org := newTestServiceWithOrg(t)
+	notified := map[influxdb.ID]influxdb.ID{}
+	svc.CheckPauseNotifier = checkPauseNotifierFunc(func(ctx context.Context, c influxdb.Check, ownerID influxdb.ID) error {
+		notified[c.GetID()] = ownerID
+		return nil
+	})
+	telegraf, err := svc.FindBucketByName(ctx, org.ID, "telegraf")
+	if err != nil {
+		t.Fatalf("failed to find bucket: %v", err)
+	}
+	if err := svc.CreateBucket(ctx, &influxdb.Bucket{OrgID: org.ID, Name: "system"}); err != nil {
+		t.Fatalf("failed to create bucket: %v", err)
+	}
+
+	slo := &check.SLO{
+		Base: check.Base{
+			Name:   "api latency",
+			OrgID:  org.ID,
+			Status: influxdb.Active,
+			Every:  influxdb.Duration{Duration: time.Minute},
+			Query:  influxdb.DashboardQuery{Text: `from(bucket: "telegraf") |> filter(fn: (r) => r._measurement == "http")`},
+		},
+		Objective:        0.99,
+		Window:           influxdb.Duration{Duration: 7 * 24 * time.Hour},
+		Indicator:        check.LatencyIndicator,
+		LatencyThreshold: 0.3,
+	}
+	threshold := &check.Threshold{
+		Base: check.Base{
+			Name:   "cpu",
+			OrgID:  org.ID,
+			Status: influxdb.Active,
+			Every:  influxdb.Duration{Duration: time.Minute},
+			Query:  influxdb.DashboardQuery{Text: `from(bucket: "system") |> range(start: -1m)`},
+		},
+		Thresholds: []check.ThresholdConfig{
+			&check.Greater{ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Critical}, Value: 90},
+		},
+	}
+	for _, c := range []influxdb.Check{slo, threshold} {
+		if err := svc.CreateCheck(ctx, c, user.ID); err != nil {
+			t.Fatalf("failed to create check: %v", err)
+		}
+	}
+
+	if err := svc.DeleteBucket(ctx, telegraf.ID); err != nil {
+		t.Fatalf("failed to delete bucket: %v", err)
+	}
+
+	c, err := svc.FindCheckByID(ctx, slo.ID)
+	if err != nil {
+		t.Fatalf("failed to find check: %v", err)
+	}
+	want := "the bucket telegraf read by the query of the check was deleted"
+	if c.GetStatus() != influxdb.Inactive || c.(*check.SLO).PauseReason != want {
+		t.Errorf("expected the check reading the bucket to be paused, got status %s and reason %q", c.GetStatus(), c.(*check.SLO).PauseReason)
+	}
+	task, err := svc.FindTaskByID(ctx, slo.TaskID)
+	if err != nil {
+		t.Fatalf("failed to find the task of the check: %v", err)
+	}
+	if task.Status != string(influxdb.Inactive) {
+		t.Errorf("expected the task of the paused check to be inactive, got %s", task.Status)
+	}
+	if c, err := svc.FindCheckByID(ctx, threshold.ID); err != nil || c.GetStatus() != influxdb.Active {
+		t.Errorf("expected the check reading another bucket to stay active, got %v, %v", c, err)
+	}
+	if len(notified) != 1 || notified[slo.ID] != user.ID {
+		t.Errorf("expected the owner of the paused check to be notified, got %v", notified)
+	}
+
+	// the check isn't reactivated until the bucket exists again.
+	active := influxdb.Active
+	if _, err := svc.PatchCheck(ctx, slo.ID, influxdb.CheckUpdate{Status: &active}); influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Fatalf("expected reactivating the check to be invalid, got %v", err)
+	}
+	if err := svc.CreateBucket(ctx, &influxdb.Bucket{OrgID: org.ID, Name: "telegraf"}); err != nil {
+		t.Fatalf("failed to create bucket: %v", err)
+	}
+	c, err = svc.PatchCheck(ctx, slo.ID, influxdb.CheckUpdate{Status: &active})
+	if err != nil {
+		t.Fatalf("failed to reactivate check: %v", err)
+	}
+	if c.GetStatus() != influxdb.Active || c.(*check.SLO).PauseReason != "" {
+		t.Errorf("expected the check to be reactivated without a pause reason, got status %s and reason %q", c.GetStatus(), c.(*check.SLO).PauseReason)
+	}
+}
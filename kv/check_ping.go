@@ -0,0 +1,135 @@
+package kv
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/influxdata/influxdb"
+)
+
+var (
+	checkPingBucket = []byte("checkpingsv1")
+
+	// ErrCheckPingNotFound is used when the check was never pinged.
+	ErrCheckPingNotFound = &influxdb.Error{
+		Msg:  "check ping not found",
+		Code: influxdb.ENotFound,
+	}
+)
+
+func (s *Service) initializeCheckPings(ctx context.Context, tx Tx) error {
+	if _, err := tx.Bucket(checkPingBucket); err != nil {
+		return err
+	}
+	return nil
+}
+
+// UnavailableCheckPingStoreError is used if we aren't able to interact with the
+// store, it means the store is not available at the moment (e.g. network).
+func UnavailableCheckPingStoreError(err error) *influxdb.Error {
+	return &influxdb.Error{
+		Code: influxdb.EInternal,
+		Msg:  fmt.Sprintf("Unable to connect to check ping store service. Please try again; Err: %v", err),
+		Op:   "kv/checkPing",
+	}
+}
+
+// InternalCheckPingStoreError is used when the error comes from an
+// internal system.
+func InternalCheckPingStoreError(err error) *influxdb.Error {
+	return &influxdb.Error{
+		Code: influxdb.EInternal,
+		Msg:  fmt.Sprintf("Unknown internal check ping data error; Err: %v", err),
+		Op:   "kv/checkPing",
+	}
+}
+
+// FindCheckPing returns when a check was last pinged.
+func (s *Service) FindCheckPing(ctx context.Context, checkID influxdb.ID) (time.Time, error) {
+	var (
+		at  time.Time
+		err error
+	)
+	err = s.kv.View(ctx, func(tx Tx) error {
+		at, err = s.findCheckPing(ctx, tx, checkID)
+		return err
+	})
+	return at, err
+}
+
+func (s *Service) findCheckPing(ctx context.Context, tx Tx, checkID influxdb.ID) (time.Time, error) {
+	encID, err := checkID.Encode()
+	if err != nil {
+		return time.Time{}, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Err:  err,
+		}
+	}
+
+	bucket, err := tx.Bucket(checkPingBucket)
+	if err != nil {
+		return time.Time{}, UnavailableCheckPingStoreError(err)
+	}
+
+	v, err := bucket.Get(encID)
+	if IsNotFound(err) {
+		return time.Time{}, ErrCheckPingNotFound
+	}
+	if err != nil {
+		return time.Time{}, InternalCheckPingStoreError(err)
+	}
+
+	var at time.Time
+	if err := at.UnmarshalBinary(v); err != nil {
+		return time.Time{}, InternalCheckPingStoreError(err)
+	}
+	return at, nil
+}
+
+// PutCheckPing records that a check was pinged at a time.
+func (s *Service) PutCheckPing(ctx context.Context, checkID influxdb.ID, at time.Time) error {
+	return s.kv.Update(ctx, func(tx Tx) error {
+		return s.putCheckPing(ctx, tx, checkID, at)
+	})
+}
+
+func (s *Service) putCheckPing(ctx context.Context, tx Tx, checkID influxdb.ID, at time.Time) error {
+	encID, err := checkID.Encode()
+	if err != nil {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Err:  err,
+		}
+	}
+	v, err := at.MarshalBinary()
+	if err != nil {
+		return InternalCheckPingStoreError(err)
+	}
+	bucket, err := tx.Bucket(checkPingBucket)
+	if err != nil {
+		return UnavailableCheckPingStoreError(err)
+	}
+	if err := bucket.Put(encID, v); err != nil {
+		return UnavailableCheckPingStoreError(err)
+	}
+	return nil
+}
+
+func (s *Service) deleteCheckPing(ctx context.Context, tx Tx, checkID influxdb.ID) error {
+	encID, err := checkID.Encode()
+	if err != nil {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Err:  err,
+		}
+	}
+	bucket, err := tx.Bucket(checkPingBucket)
+	if err != nil {
+		return UnavailableCheckPingStoreError(err)
+	}
+	if err := bucket.Delete(encID); err != nil {
+		return UnavailableCheckPingStoreError(err)
+	}
+	return nil
+}
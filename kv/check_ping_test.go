@@ -0,0 +1,42 @@
+package kv_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+)
+
+func TestService_CheckPing(t *testing.T) {
+	ctx := context.Background()
+	svc, user, org := newTestServiceWithOrg(t)
+
+	c := newDeadman(org.ID, "heartbeat")
+	if err := svc.CreateCheck(ctx, c, user.ID); err != nil {
+		t.Fatalf("failed to create check: %v", err)
+	}
+	if _, err := svc.FindCheckPing(ctx, c.ID); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Fatalf("expected a check never pinged not to be found, got %v", err)
+	}
+
+	at := time.Date(2019, 10, 1, 0, 1, 0, 0, time.UTC)
+	if err := svc.PutCheckPing(ctx, c.ID, at); err != nil {
+		t.Fatalf("failed to put check ping: %v", err)
+	}
+	got, err := svc.FindCheckPing(ctx, c.ID)
+	if err != nil {
+		t.Fatalf("failed to find check ping: %v", err)
+	}
+	if !got.Equal(at) {
+		t.Errorf("expected the check to be pinged at %v, got %v", at, got)
+	}
+
+	// the ping is deleted with its check.
+	if err := svc.DeleteCheck(ctx, c.ID); err != nil {
+		t.Fatalf("failed to delete check: %v", err)
+	}
+	if _, err := svc.FindCheckPing(ctx, c.ID); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Errorf("expected the ping of the deleted check to be deleted, got %v", err)
+	}
+}
@@ -0,0 +1,130 @@
+package kv
+
+import (
+	"context"
+	"sort"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification"
+)
+
+var _ influxdb.CheckRelatedService = (*Service)(nil)
+
+// FindCheckRelated returns a check with its task, the notification rules
+// matching it, the endpoints of those rules, its recent deliveries and its
+// labels.
+func (s *Service) FindCheckRelated(ctx context.Context, checkID influxdb.ID) (*influxdb.CheckRelated, error) {
+	var (
+		r   *influxdb.CheckRelated
+		err error
+	)
+	err = s.kv.View(ctx, func(tx Tx) error {
+		r, err = s.findCheckRelated(ctx, tx, checkID)
+		return err
+	})
+	return r, err
+}
+
+func (s *Service) findCheckRelated(ctx context.Context, tx Tx, checkID influxdb.ID) (*influxdb.CheckRelated, error) {
+	c, err := s.findCheckByID(ctx, tx, checkID)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &influxdb.CheckRelated{
+		Check:                 c,
+		NotificationRules:     []influxdb.NotificationRule{},
+		NotificationEndpoints: []influxdb.NotificationEndpoint{},
+		Deliveries:            []influxdb.CheckDelivery{},
+		Labels:                []*influxdb.Label{},
+	}
+
+	if tc, ok := c.(taskCheck); ok && tc.GetTaskID().Valid() {
+		t, err := s.findTaskByID(ctx, tx, tc.GetTaskID())
+		if err != nil && influxdb.ErrorCode(err) != influxdb.ENotFound {
+			return nil, err
+		}
+		r.Task = t
+	}
+
+	var tags []notification.Tag
+	if tc, ok := c.(taggedCheck); ok {
+		tags = tc.GetTags()
+	}
+	var endpointIDs []influxdb.ID
+	seen := make(map[influxdb.ID]bool)
+	err = s.forEachNotificationRule(ctx, tx, false, func(nr influxdb.NotificationRule) bool {
+		if nr.GetOrgID() != c.GetOrgID() {
+			return true
+		}
+		routed, ok := nr.(routedNotificationRule)
+		if !ok || !notification.MatchTagRules(routed.GetTagRules(), tags) {
+			return true
+		}
+		r.NotificationRules = append(r.NotificationRules, nr)
+		for _, id := range routed.GetEndpointIDs() {
+			if !seen[id] {
+				seen[id] = true
+				endpointIDs = append(endpointIDs, id)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range endpointIDs {
+		edp, err := s.findNotificationEndpointByID(ctx, tx, id)
+		if influxdb.ErrorCode(err) == influxdb.ENotFound {
+			// the rules referencing a deleted endpoint are reported by the
+			// orphaned alerting resources.
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		r.NotificationEndpoints = append(r.NotificationEndpoints, edp)
+	}
+
+	err = s.forEachStatusTrace(ctx, tx, func(t *influxdb.StatusTrace) bool {
+		if t.CheckID != checkID {
+			return true
+		}
+		for _, rt := range t.Rules {
+			if rt.Decision != influxdb.RuleNotified && rt.Decision != influxdb.RuleFailed {
+				continue
+			}
+			r.Deliveries = append(r.Deliveries, influxdb.CheckDelivery{
+				StatusID:   t.StatusID,
+				Time:       t.Time,
+				Level:      t.Level,
+				RuleID:     rt.RuleID,
+				RuleName:   rt.RuleName,
+				EndpointID: rt.EndpointID,
+				Decision:   rt.Decision,
+				Reason:     rt.Reason,
+			})
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(r.Deliveries, func(i, j int) bool {
+		return r.Deliveries[i].Time.After(r.Deliveries[j].Time)
+	})
+	if len(r.Deliveries) > influxdb.CheckRelatedDeliveries {
+		r.Deliveries = r.Deliveries[:influxdb.CheckRelatedDeliveries]
+	}
+
+	filter := influxdb.LabelMappingFilter{
+		ResourceID:   checkID,
+		ResourceType: influxdb.ChecksResourceType,
+	}
+	if err := s.findResourceLabels(ctx, tx, filter, &r.Labels); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
@@ -0,0 +1,140 @@
+package kv_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/check"
+	"github.com/influxdata/influxdb/notification/endpoint"
+	"github.com/influxdata/influxdb/notification/rule"
+)
+
+func TestService_FindCheckRelated(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestService(t)
+
+	orgID := influxdb.ID(1)
+	if err := svc.PutOrganization(ctx, &influxdb.Organization{ID: orgID, Name: "theorg"}); err != nil {
+		t.Fatalf("failed to populate org: %v", err)
+	}
+
+	c := &check.Deadman{
+		Base: check.Base{
+			ID:     10,
+			Name:   "cpu",
+			OrgID:  orgID,
+			Status: influxdb.Active,
+			Every:  influxdb.Duration{Duration: time.Minute},
+			Query:  influxdb.DashboardQuery{Text: `from(bucket: "telegraf") |> range(start: -1m)`},
+			Tags:   []notification.Tag{{Key: "team", Value: "ops"}},
+		},
+		TimeSince: 60,
+	}
+	if err := svc.PutCheck(ctx, c); err != nil {
+		t.Fatalf("failed to populate check: %v", err)
+	}
+
+	endpointID, deletedID := influxdb.ID(30), influxdb.ID(39)
+	newRule := func(id influxdb.ID, name string, endpointID *influxdb.ID, value string) influxdb.NotificationRule {
+		return &rule.Slack{
+			Base: rule.Base{
+				ID:              id,
+				Name:            name,
+				OrgID:           orgID,
+				AuthorizationID: influxdb.ID(99),
+				Status:          influxdb.Active,
+				EndpointID:      endpointID,
+				TagRules: []notification.TagRule{
+					{Tag: notification.Tag{Key: "team", Value: value}, Operator: notification.Equal},
+				},
+			},
+			MessageTemplate: "msg",
+		}
+	}
+	for _, nr := range []influxdb.NotificationRule{
+		newRule(20, "page ops", &endpointID, "ops"),
+		newRule(21, "page dev", &endpointID, "dev"),
+		newRule(22, "page deleted", &deletedID, "ops"),
+	} {
+		if err := svc.PutNotificationRule(ctx, nr); err != nil {
+			t.Fatalf("failed to populate notification rule: %v", err)
+		}
+	}
+	for _, id := range []influxdb.ID{endpointID, 31} {
+		edp := &endpoint.Slack{
+			Base: endpoint.Base{ID: id, Name: id.String(), OrgID: orgID, Status: influxdb.Active},
+			URL:  "https://hooks.slack.com/services/1",
+		}
+		if err := svc.PutNotificationEndpoint(ctx, edp); err != nil {
+			t.Fatalf("failed to populate notification endpoint: %v", err)
+		}
+	}
+
+	now := time.Date(2019, 10, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < influxdb.CheckRelatedDeliveries+5; i++ {
+		trace := &influxdb.StatusTrace{
+			StatusID: influxdb.ID(100 + i),
+			CheckID:  c.ID,
+			OrgID:    orgID,
+			Level:    "crit",
+			Time:     now.Add(time.Duration(i) * time.Minute),
+			Rules: []influxdb.RuleTrace{
+				{RuleID: 20, RuleName: "page ops", Decision: influxdb.RuleNotified, EndpointID: &endpointID},
+				{RuleID: 21, RuleName: "page dev", Decision: influxdb.RuleUnmatched},
+			},
+		}
+		if err := svc.CreateStatusTrace(ctx, trace); err != nil {
+			t.Fatalf("failed to create status trace: %v", err)
+		}
+	}
+	other := &influxdb.StatusTrace{
+		StatusID: influxdb.ID(200),
+		CheckID:  influxdb.ID(11),
+		OrgID:    orgID,
+		Time:     now.Add(time.Hour),
+		Rules:    []influxdb.RuleTrace{{RuleID: 20, Decision: influxdb.RuleNotified}},
+	}
+	if err := svc.CreateStatusTrace(ctx, other); err != nil {
+		t.Fatalf("failed to create status trace: %v", err)
+	}
+
+	l := &influxdb.Label{OrgID: orgID, Name: "prod"}
+	if err := svc.CreateLabel(ctx, l); err != nil {
+		t.Fatalf("failed to create label: %v", err)
+	}
+	m := &influxdb.LabelMapping{LabelID: l.ID, ResourceID: c.ID, ResourceType: influxdb.ChecksResourceType}
+	if err := svc.CreateLabelMapping(ctx, m); err != nil {
+		t.Fatalf("failed to create label mapping: %v", err)
+	}
+
+	r, err := svc.FindCheckRelated(ctx, c.ID)
+	if err != nil {
+		t.Fatalf("failed to find the resources related to the check: %v", err)
+	}
+	if r.Check.GetID() != c.ID || r.Task != nil {
+		t.Errorf("expected the check without a task, got %v and task %v", r.Check, r.Task)
+	}
+	if len(r.NotificationRules) != 2 || r.NotificationRules[0].GetID() != 20 || r.NotificationRules[1].GetID() != 22 {
+		t.Errorf("expected the rules matching the tags of the check, got %v", r.NotificationRules)
+	}
+	if len(r.NotificationEndpoints) != 1 || r.NotificationEndpoints[0].GetID() != endpointID {
+		t.Errorf("expected the existing endpoint of the rules, got %v", r.NotificationEndpoints)
+	}
+	if len(r.Deliveries) != influxdb.CheckRelatedDeliveries {
+		t.Fatalf("expected %d deliveries, got %d", influxdb.CheckRelatedDeliveries, len(r.Deliveries))
+	}
+	latest := r.Deliveries[0]
+	if latest.StatusID != influxdb.ID(100+influxdb.CheckRelatedDeliveries+4) || latest.RuleID != 20 || latest.Decision != influxdb.RuleNotified {
+		t.Errorf("expected the latest delivery first, got %+v", latest)
+	}
+	if len(r.Labels) != 1 || r.Labels[0].ID != l.ID {
+		t.Errorf("expected the label of the check, got %v", r.Labels)
+	}
+
+	if _, err := svc.FindCheckRelated(ctx, influxdb.ID(12)); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Errorf("expected not found error for a missing check, got %v", err)
+	}
+}
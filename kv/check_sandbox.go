@@ -0,0 +1,43 @@
+package kv
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification/check"
+)
+
+// sandboxedCheck is a check whose query may be flux.
+type sandboxedCheck interface {
+	GetQuery() influxdb.DashboardQuery
+	GetQueryType() string
+}
+
+// validateCheckSandbox returns a forbidden error if the flux of the query of
+// a check writes to another bucket than the status bucket of its
+// organization, or calls http.post when the organization doesn't allow it.
+// The check must belong to an existing organization.
+func (s *Service) validateCheckSandbox(ctx context.Context, tx Tx, c influxdb.Check) error {
+	sc, ok := c.(sandboxedCheck)
+	if !ok || sc.GetQueryType() != check.QueryTypeFlux {
+		return nil
+	}
+	as, err := s.findAlertingSettings(ctx, tx, c.GetOrgID())
+	if err != nil {
+		return err
+	}
+	o, err := s.findOrganizationByID(ctx, tx, c.GetOrgID())
+	if err != nil {
+		return err
+	}
+	sandbox := check.Sandbox{
+		StatusBucket:  influxdb.MonitoringBucketName,
+		AllowHTTPPost: as.AllowHTTPPost,
+		Org:           o.Name,
+		OrgID:         o.ID,
+	}
+	if as.StatusBucket != "" {
+		sandbox.StatusBucket = as.StatusBucket
+	}
+	return sandbox.Validate(sc.GetQuery())
+}
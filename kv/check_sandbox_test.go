@@ -0,0 +1,60 @@
+package kv_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification/check"
+)
+
+func TestService_CheckSandbox(t *testing.T) {
+	ctx := context.Background()
+	svc, user, org := newTestServiceWithOrg(t)
+	if err := svc.CreateBucket(ctx, &influxdb.Bucket{OrgID: org.ID, Name: "statuses"}); err != nil {
+		t.Fatalf("failed to create bucket: %v", err)
+	}
+
+	newCheck := func(name, text string) *check.Deadman {
+		return &check.Deadman{
+			Base: check.Base{
+				Name:   name,
+				OrgID:  org.ID,
+				Status: influxdb.Active,
+				Every:  influxdb.Duration{Duration: time.Minute},
+				Query:  influxdb.DashboardQuery{Text: text},
+			},
+			TimeSince: 90,
+		}
+	}
+	const (
+		toMonitoring = `from(bucket: "telegraf") |> range(start: -1m) |> to(bucket: "_monitoring", org: "theorg")`
+		toStatuses   = `from(bucket: "telegraf") |> range(start: -1m) |> to(bucket: "statuses", org: "theorg")`
+		post         = `import "http"` + "\n" + `from(bucket: "telegraf") |> range(start: -1m) |> map(fn: (r) => ({r with code: http.post(url: "https://example.com")}))`
+	)
+
+	c := newCheck("cpu", toMonitoring)
+	if err := svc.CreateCheck(ctx, c, user.ID); err != nil {
+		t.Fatalf("expected writing to the monitoring bucket to be allowed, got %v", err)
+	}
+	if err := svc.CreateCheck(ctx, newCheck("mem", toStatuses), user.ID); influxdb.ErrorCode(err) != influxdb.EForbidden {
+		t.Errorf("expected writing to another bucket to be forbidden, got %v", err)
+	}
+	if err := svc.CreateCheck(ctx, newCheck("disk", post), user.ID); influxdb.ErrorCode(err) != influxdb.EForbidden {
+		t.Errorf("expected http.post to be forbidden, got %v", err)
+	}
+
+	if err := svc.PutAlertingSettings(ctx, &influxdb.AlertingSettings{OrgID: org.ID, StatusBucket: "statuses", AllowHTTPPost: true}); err != nil {
+		t.Fatalf("failed to put alerting settings: %v", err)
+	}
+	if err := svc.CreateCheck(ctx, newCheck("disk", post), user.ID); err != nil {
+		t.Errorf("expected http.post allowed by the org to be allowed, got %v", err)
+	}
+	if _, err := svc.UpdateCheck(ctx, c.ID, newCheck("cpu", toMonitoring)); influxdb.ErrorCode(err) != influxdb.EForbidden {
+		t.Errorf("expected writing to the monitoring bucket which isn't the status bucket to be forbidden, got %v", err)
+	}
+	if _, err := svc.UpdateCheck(ctx, c.ID, newCheck("cpu", toStatuses)); err != nil {
+		t.Errorf("expected writing to the status bucket to be allowed, got %v", err)
+	}
+}
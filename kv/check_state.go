@@ -0,0 +1,144 @@
+package kv
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/influxdata/influxdb"
+)
+
+var (
+	checkStateBucket = []byte("checkstatesv1")
+
+	// ErrCheckStateNotFound is used when the check has no state.
+	ErrCheckStateNotFound = &influxdb.Error{
+		Msg:  "check state not found",
+		Code: influxdb.ENotFound,
+	}
+)
+
+var _ influxdb.CheckStateService = (*Service)(nil)
+
+func (s *Service) initializeCheckStates(ctx context.Context, tx Tx) error {
+	if _, err := tx.Bucket(checkStateBucket); err != nil {
+		return err
+	}
+	return nil
+}
+
+// UnavailableCheckStateStoreError is used if we aren't able to interact with the
+// store, it means the store is not available at the moment (e.g. network).
+func UnavailableCheckStateStoreError(err error) *influxdb.Error {
+	return &influxdb.Error{
+		Code: influxdb.EInternal,
+		Msg:  fmt.Sprintf("Unable to connect to check state store service. Please try again; Err: %v", err),
+		Op:   "kv/checkState",
+	}
+}
+
+// InternalCheckStateStoreError is used when the error comes from an
+// internal system.
+func InternalCheckStateStoreError(err error) *influxdb.Error {
+	return &influxdb.Error{
+		Code: influxdb.EInternal,
+		Msg:  fmt.Sprintf("Unknown internal check state data error; Err: %v", err),
+		Op:   "kv/checkState",
+	}
+}
+
+// FindCheckState returns the state of a check.
+func (s *Service) FindCheckState(ctx context.Context, checkID influxdb.ID) (*influxdb.CheckState, error) {
+	var (
+		st  *influxdb.CheckState
+		err error
+	)
+	err = s.kv.View(ctx, func(tx Tx) error {
+		st, err = s.findCheckState(ctx, tx, checkID)
+		return err
+	})
+	return st, err
+}
+
+func (s *Service) findCheckState(ctx context.Context, tx Tx, checkID influxdb.ID) (*influxdb.CheckState, error) {
+	encID, err := checkID.Encode()
+	if err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Err:  err,
+		}
+	}
+
+	bucket, err := tx.Bucket(checkStateBucket)
+	if err != nil {
+		return nil, UnavailableCheckStateStoreError(err)
+	}
+
+	v, err := bucket.Get(encID)
+	if IsNotFound(err) {
+		return nil, ErrCheckStateNotFound
+	}
+	if err != nil {
+		return nil, InternalCheckStateStoreError(err)
+	}
+
+	st := &influxdb.CheckState{}
+	if err := json.Unmarshal(v, st); err != nil {
+		return nil, InternalCheckStateStoreError(err)
+	}
+	return st, nil
+}
+
+// PutCheckState saves the state of a check.
+func (s *Service) PutCheckState(ctx context.Context, st *influxdb.CheckState) error {
+	return s.kv.Update(ctx, func(tx Tx) error {
+		return s.putCheckState(ctx, tx, st)
+	})
+}
+
+func (s *Service) putCheckState(ctx context.Context, tx Tx, st *influxdb.CheckState) error {
+	encID, err := st.CheckID.Encode()
+	if err != nil {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Err:  err,
+		}
+	}
+	v, err := json.Marshal(st)
+	if err != nil {
+		return InternalCheckStateStoreError(err)
+	}
+	bucket, err := tx.Bucket(checkStateBucket)
+	if err != nil {
+		return UnavailableCheckStateStoreError(err)
+	}
+	if err := bucket.Put(encID, v); err != nil {
+		return UnavailableCheckStateStoreError(err)
+	}
+	return nil
+}
+
+// DeleteCheckState removes the state of a check.
+func (s *Service) DeleteCheckState(ctx context.Context, checkID influxdb.ID) error {
+	return s.kv.Update(ctx, func(tx Tx) error {
+		return s.deleteCheckState(ctx, tx, checkID)
+	})
+}
+
+func (s *Service) deleteCheckState(ctx context.Context, tx Tx, checkID influxdb.ID) error {
+	encID, err := checkID.Encode()
+	if err != nil {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Err:  err,
+		}
+	}
+	bucket, err := tx.Bucket(checkStateBucket)
+	if err != nil {
+		return UnavailableCheckStateStoreError(err)
+	}
+	if err := bucket.Delete(encID); err != nil {
+		return UnavailableCheckStateStoreError(err)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package kv_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb"
+)
+
+func TestService_CheckState(t *testing.T) {
+	ctx := context.Background()
+	svc, user, org := newTestServiceWithOrg(t)
+
+	c := newDeadman(org.ID, "heartbeat")
+	if err := svc.CreateCheck(ctx, c, user.ID); err != nil {
+		t.Fatalf("failed to create check: %v", err)
+	}
+	if _, err := svc.FindCheckState(ctx, c.ID); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Fatalf("expected a check without state not to be found, got %v", err)
+	}
+
+	at := time.Date(2019, 10, 1, 0, 1, 0, 0, time.UTC)
+	want := &influxdb.CheckState{
+		CheckID: c.ID,
+		LastRun: at,
+		Series: map[string]influxdb.SeriesState{
+			c.ID.String() + ",host=a": {
+				Level:         "CRIT",
+				IncidentStart: &at,
+				Notified: map[influxdb.ID]influxdb.NotifiedLevel{
+					influxdb.ID(10): {Level: "CRIT", At: at},
+				},
+			},
+			c.ID.String() + ",host=b": {Level: "OK", Pending: "WARN", PendingCount: 2},
+		},
+		Sent: map[influxdb.ID][]time.Time{
+			influxdb.ID(10): {at},
+		},
+		Lags: []influxdb.CheckLagSample{
+			{ScheduledFor: at, StartedAt: at.Add(2 * time.Second), Lag: influxdb.Duration{Duration: 2 * time.Second}},
+		},
+	}
+	if err := svc.PutCheckState(ctx, want); err != nil {
+		t.Fatalf("failed to put check state: %v", err)
+	}
+	got, err := svc.FindCheckState(ctx, c.ID)
+	if err != nil {
+		t.Fatalf("failed to find check state: %v", err)
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("check states are different -got/+want\ndiff %s", diff)
+	}
+
+	// the state is deleted with its check.
+	if err := svc.DeleteCheck(ctx, c.ID); err != nil {
+		t.Fatalf("failed to delete check: %v", err)
+	}
+	if _, err := svc.FindCheckState(ctx, c.ID); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Errorf("expected the state of the deleted check to be deleted, got %v", err)
+	}
+}
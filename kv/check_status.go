@@ -0,0 +1,176 @@
+package kv
+
+import (
+	"context"
+	"sort"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification/check"
+)
+
+var _ influxdb.CheckStatusService = (*Service)(nil)
+
+// FindCheckStatuses returns the current status of each check, in the order
+// of the checkIDs, from the status traces of the checks.
+func (s *Service) FindCheckStatuses(ctx context.Context, checkIDs []influxdb.ID) ([]*influxdb.CheckStatus, error) {
+	var (
+		sts []*influxdb.CheckStatus
+		err error
+	)
+	err = s.kv.View(ctx, func(tx Tx) error {
+		sts, err = s.findCheckStatuses(ctx, tx, checkIDs)
+		return err
+	})
+	return sts, err
+}
+
+func (s *Service) findCheckStatuses(ctx context.Context, tx Tx, checkIDs []influxdb.ID) ([]*influxdb.CheckStatus, error) {
+	sts := make([]*influxdb.CheckStatus, 0, len(checkIDs))
+	byID := make(map[influxdb.ID]*influxdb.CheckStatus, len(checkIDs))
+	for _, id := range checkIDs {
+		c, err := s.findCheckByID(ctx, tx, id)
+		if err != nil {
+			return nil, err
+		}
+		st := &influxdb.CheckStatus{CheckID: id, OrgID: c.GetOrgID()}
+		sts = append(sts, st)
+		byID[id] = st
+	}
+
+	traces := make(map[influxdb.ID][]*influxdb.StatusTrace)
+	err := s.forEachStatusTrace(ctx, tx, func(t *influxdb.StatusTrace) bool {
+		if _, ok := byID[t.CheckID]; ok {
+			traces[t.CheckID] = append(traces[t.CheckID], t)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for id, ts := range traces {
+		sort.SliceStable(ts, func(i, j int) bool {
+			return ts[i].Time.After(ts[j].Time)
+		})
+		latest := ts[0]
+		st := byID[id]
+		st.Level = latest.Level
+		st.Message = latest.Message
+		st.Time = &latest.Time
+		// the check has been at the level since the oldest status of the
+		// latest statuses at that level.
+		changed := latest
+		for _, t := range ts[1:] {
+			if t.Level != latest.Level {
+				break
+			}
+			changed = t
+		}
+		st.LastChange = &changed.Time
+	}
+	return sts, nil
+}
+
+// FindCheckStatusHistory returns the statuses a check has produced, the
+// latest first, from the status traces of the check.
+func (s *Service) FindCheckStatusHistory(ctx context.Context, checkID influxdb.ID, filter influxdb.CheckStatusHistoryFilter) (*influxdb.CheckStatusHistory, error) {
+	if err := filter.Valid(); err != nil {
+		return nil, err
+	}
+	var (
+		h   *influxdb.CheckStatusHistory
+		err error
+	)
+	err = s.kv.View(ctx, func(tx Tx) error {
+		h, err = s.findCheckStatusHistory(ctx, tx, checkID, filter)
+		return err
+	})
+	return h, err
+}
+
+func (s *Service) findCheckStatusHistory(ctx context.Context, tx Tx, checkID influxdb.ID, filter influxdb.CheckStatusHistoryFilter) (*influxdb.CheckStatusHistory, error) {
+	c, err := s.findCheckByID(ctx, tx, checkID)
+	if err != nil {
+		return nil, err
+	}
+
+	h := &influxdb.CheckStatusHistory{
+		CheckID:  checkID,
+		OrgID:    c.GetOrgID(),
+		Statuses: []*influxdb.CheckStatusRecord{},
+	}
+	err = s.forEachStatusTrace(ctx, tx, func(t *influxdb.StatusTrace) bool {
+		if t.CheckID != checkID {
+			return true
+		}
+		if filter.Start != nil && t.Time.Before(*filter.Start) {
+			return true
+		}
+		if filter.Stop != nil && !t.Time.Before(*filter.Stop) {
+			return true
+		}
+		h.Statuses = append(h.Statuses, &influxdb.CheckStatusRecord{
+			StatusID: t.StatusID,
+			Level:    t.Level,
+			Message:  t.Message,
+			Time:     t.Time,
+		})
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(h.Statuses, func(i, j int) bool {
+		return h.Statuses[i].Time.After(h.Statuses[j].Time)
+	})
+	limit := filter.Limit
+	if limit == 0 {
+		limit = influxdb.DefaultCheckStatusHistoryLimit
+	}
+	if len(h.Statuses) > limit {
+		h.Statuses = h.Statuses[:limit]
+	}
+	return h, nil
+}
+
+// FindCheckQuery returns the query of a check, and the flux of its task
+// generated with its current labels if the check is run by a task.
+func (s *Service) FindCheckQuery(ctx context.Context, checkID influxdb.ID) (*influxdb.CheckQuery, error) {
+	var (
+		q   *influxdb.CheckQuery
+		err error
+	)
+	err = s.kv.View(ctx, func(tx Tx) error {
+		q, err = s.findCheckQuery(ctx, tx, checkID)
+		return err
+	})
+	return q, err
+}
+
+func (s *Service) findCheckQuery(ctx context.Context, tx Tx, checkID influxdb.ID) (*influxdb.CheckQuery, error) {
+	c, err := s.findCheckByID(ctx, tx, checkID)
+	if err != nil {
+		return nil, err
+	}
+
+	q := &influxdb.CheckQuery{
+		CheckID:   checkID,
+		OrgID:     c.GetOrgID(),
+		QueryType: check.QueryTypeFlux,
+	}
+	if qc, ok := c.(sandboxedCheck); ok {
+		q.QueryType = qc.GetQueryType()
+		q.Query = qc.GetQuery().Text
+	}
+	if tc, ok := asTaskCheck(c); ok {
+		labels, err := s.checkLabels(ctx, tx, c)
+		if err != nil {
+			return nil, err
+		}
+		if q.Flux, err = tc.GenerateFlux(labels); err != nil {
+			return nil, err
+		}
+	}
+	return q, nil
+}
@@ -0,0 +1,221 @@
+package kv_test
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification/check"
+)
+
+func TestService_FindCheckStatuses(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestService(t)
+
+	orgID := influxdb.ID(1)
+	if err := svc.PutOrganization(ctx, &influxdb.Organization{ID: orgID, Name: "theorg"}); err != nil {
+		t.Fatalf("failed to populate org: %v", err)
+	}
+	for _, id := range []influxdb.ID{10, 11} {
+		c := &check.Deadman{
+			Base: check.Base{
+				ID:     id,
+				Name:   id.String(),
+				OrgID:  orgID,
+				Status: influxdb.Active,
+				Every:  influxdb.Duration{Duration: time.Minute},
+				Query:  influxdb.DashboardQuery{Text: `from(bucket: "telegraf") |> range(start: -1m)`},
+			},
+			TimeSince: 60,
+		}
+		if err := svc.PutCheck(ctx, c); err != nil {
+			t.Fatalf("failed to populate check: %v", err)
+		}
+	}
+
+	now := time.Date(2019, 10, 1, 12, 0, 0, 0, time.UTC)
+	for i, level := range []string{"ok", "crit", "crit", "crit"} {
+		trace := &influxdb.StatusTrace{
+			StatusID: influxdb.ID(100 + i),
+			CheckID:  10,
+			OrgID:    orgID,
+			Level:    level,
+			Message:  level + " message",
+			Time:     now.Add(time.Duration(i) * time.Minute),
+			Rules:    []influxdb.RuleTrace{},
+		}
+		if err := svc.CreateStatusTrace(ctx, trace); err != nil {
+			t.Fatalf("failed to create status trace: %v", err)
+		}
+	}
+
+	sts, err := svc.FindCheckStatuses(ctx, []influxdb.ID{11, 10})
+	if err != nil {
+		t.Fatalf("failed to find check statuses: %v", err)
+	}
+	if len(sts) != 2 || sts[0].CheckID != 11 || sts[1].CheckID != 10 {
+		t.Fatalf("expected the statuses in the order of the checks, got %+v", sts)
+	}
+	if st := sts[0]; st.Level != "" || st.Time != nil || st.LastChange != nil {
+		t.Errorf("expected the check without status to have no level, got %+v", st)
+	}
+	st := sts[1]
+	if st.Level != "crit" || st.Message != "crit message" || st.OrgID != orgID {
+		t.Errorf("expected the latest status of the check, got %+v", st)
+	}
+	if st.Time == nil || !st.Time.Equal(now.Add(3*time.Minute)) {
+		t.Errorf("expected the time of the latest status, got %v", st.Time)
+	}
+	if st.LastChange == nil || !st.LastChange.Equal(now.Add(time.Minute)) {
+		t.Errorf("expected the time of the change to crit, got %v", st.LastChange)
+	}
+
+	if _, err := svc.FindCheckStatuses(ctx, []influxdb.ID{12}); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Errorf("expected not found error for a missing check, got %v", err)
+	}
+}
+
+func TestService_FindCheckStatusHistory(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestService(t)
+
+	orgID := influxdb.ID(1)
+	if err := svc.PutOrganization(ctx, &influxdb.Organization{ID: orgID, Name: "theorg"}); err != nil {
+		t.Fatalf("failed to populate org: %v", err)
+	}
+	for _, id := range []influxdb.ID{10, 11} {
+		c := &check.Deadman{
+			Base: check.Base{
+				ID:     id,
+				Name:   id.String(),
+				OrgID:  orgID,
+				Status: influxdb.Active,
+				Every:  influxdb.Duration{Duration: time.Minute},
+				Query:  influxdb.DashboardQuery{Text: `from(bucket: "telegraf") |> range(start: -1m)`},
+			},
+			TimeSince: 60,
+		}
+		if err := svc.PutCheck(ctx, c); err != nil {
+			t.Fatalf("failed to populate check: %v", err)
+		}
+	}
+
+	now := time.Date(2019, 10, 1, 12, 0, 0, 0, time.UTC)
+	for i, level := range []string{"ok", "warn", "crit", "ok", "info"} {
+		checkID := influxdb.ID(10)
+		if i == 4 {
+			checkID = 11
+		}
+		trace := &influxdb.StatusTrace{
+			StatusID: influxdb.ID(100 + i),
+			CheckID:  checkID,
+			OrgID:    orgID,
+			Level:    level,
+			Time:     now.Add(time.Duration(i) * time.Minute),
+			Rules:    []influxdb.RuleTrace{},
+		}
+		if err := svc.CreateStatusTrace(ctx, trace); err != nil {
+			t.Fatalf("failed to create status trace: %v", err)
+		}
+	}
+
+	levels := func(h *influxdb.CheckStatusHistory) []string {
+		ls := []string{}
+		for _, st := range h.Statuses {
+			ls = append(ls, st.Level)
+		}
+		return ls
+	}
+	start, stop := now.Add(time.Minute), now.Add(3*time.Minute)
+	tests := []struct {
+		name   string
+		filter influxdb.CheckStatusHistoryFilter
+		want   []string
+	}{
+		{
+			name: "every status",
+			want: []string{"ok", "crit", "warn", "ok"},
+		},
+		{
+			name:   "time range",
+			filter: influxdb.CheckStatusHistoryFilter{Start: &start, Stop: &stop},
+			want:   []string{"crit", "warn"},
+		},
+		{
+			name:   "limit",
+			filter: influxdb.CheckStatusHistoryFilter{Limit: 1},
+			want:   []string{"ok"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, err := svc.FindCheckStatusHistory(ctx, 10, tt.filter)
+			if err != nil {
+				t.Fatalf("failed to find check status history: %v", err)
+			}
+			if h.OrgID != orgID {
+				t.Errorf("expected the org of the check, got %s", h.OrgID)
+			}
+			if got := levels(h); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected statuses %v, got %v", tt.want, got)
+			}
+		})
+	}
+
+	if _, err := svc.FindCheckStatusHistory(ctx, 12, influxdb.CheckStatusHistoryFilter{}); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Errorf("expected not found error for a missing check, got %v", err)
+	}
+	if _, err := svc.FindCheckStatusHistory(ctx, 10, influxdb.CheckStatusHistoryFilter{Start: &stop, Stop: &start}); influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Errorf("expected invalid error for an empty time range, got %v", err)
+	}
+}
+
+func TestService_FindCheckQuery(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestService(t)
+
+	orgID := influxdb.ID(1)
+	if err := svc.PutOrganization(ctx, &influxdb.Organization{ID: orgID, Name: "theorg"}); err != nil {
+		t.Fatalf("failed to populate org: %v", err)
+	}
+	text := `from(bucket: "telegraf") |> range(start: -1m)`
+	for id, queryType := range map[influxdb.ID]string{10: check.QueryTypeFlux, 11: check.QueryTypeInfluxQL} {
+		c := &check.Deadman{
+			Base: check.Base{
+				ID:        id,
+				Name:      id.String(),
+				OrgID:     orgID,
+				Status:    influxdb.Active,
+				Every:     influxdb.Duration{Duration: time.Minute},
+				Query:     influxdb.DashboardQuery{Text: text},
+				QueryType: queryType,
+			},
+			TimeSince: 60,
+		}
+		if err := svc.PutCheck(ctx, c); err != nil {
+			t.Fatalf("failed to populate check: %v", err)
+		}
+	}
+
+	q, err := svc.FindCheckQuery(ctx, 10)
+	if err != nil {
+		t.Fatalf("failed to find check query: %v", err)
+	}
+	if q.OrgID != orgID || q.QueryType != check.QueryTypeFlux || q.Query != text {
+		t.Errorf("unexpected check query %+v", q)
+	}
+	if !strings.Contains(q.Flux, "option task = ") || !strings.Contains(q.Flux, text) {
+		t.Errorf("expected the flux of the task of the check, got %q", q.Flux)
+	}
+
+	q, err = svc.FindCheckQuery(ctx, 11)
+	if err != nil {
+		t.Fatalf("failed to find check query: %v", err)
+	}
+	if q.QueryType != check.QueryTypeInfluxQL || q.Query != text || q.Flux != "" {
+		t.Errorf("expected the influxql check to have no flux, got %+v", q)
+	}
+}
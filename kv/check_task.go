@@ -0,0 +1,383 @@
+package kv
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/influxdata/influxdb"
+	icontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/notification/check"
+)
+
+// checkTaskDescription is the prefix of the description of the tasks of the
+// checks, which the reconciliation looks for to find the tasks of deleted checks.
+const checkTaskDescription = "runs the check "
+
+// IsCheckTask returns whether t is the task of a check.
+func IsCheckTask(t *influxdb.Task) bool {
+	return strings.HasPrefix(t.Description, checkTaskDescription)
+}
+
+// HasCheckTask returns whether c is run by a task rather than by the alerting
+// engine.
+func HasCheckTask(c influxdb.Check) bool {
+	_, ok := asTaskCheck(c)
+	return ok
+}
+
+// taskCheck is a check run by a task generated from its flux.
+type taskCheck interface {
+	GenerateFlux(labels []*influxdb.Label) (string, error)
+	GetQuery() influxdb.DashboardQuery
+	GetTaskID() influxdb.ID
+	SetTaskID(influxdb.ID)
+	GetAuthorizationID() influxdb.ID
+	SetAuthorizationID(influxdb.ID)
+}
+
+// asTaskCheck returns a check as a check run by a task, if it generates flux
+// and queries its data with flux. The checks querying other data sources
+// are evaluated by the alerting engine.
+func asTaskCheck(c influxdb.Check) (taskCheck, bool) {
+	tc, ok := c.(taskCheck)
+	if !ok {
+		return nil, false
+	}
+	if qc, ok := c.(interface{ GetQueryType() string }); ok && qc.GetQueryType() != check.QueryTypeFlux {
+		return nil, false
+	}
+	return tc, true
+}
+
+// createCheckTask creates the task running a check, if the check generates
+// flux, with an authorization owned by userID.
+func (s *Service) createCheckTask(ctx context.Context, tx Tx, c influxdb.Check, userID influxdb.ID) error {
+	tc, ok := asTaskCheck(c)
+	if !ok {
+		return nil
+	}
+	labels, err := s.checkLabels(ctx, tx, c)
+	if err != nil {
+		return err
+	}
+	script, err := tc.GenerateFlux(labels)
+	if err != nil {
+		return err
+	}
+	auth, err := s.createCheckAuthorization(ctx, tx, c, userID)
+	if err != nil {
+		return err
+	}
+
+	// the task is owned by the owner of its authorization.
+	t, err := s.createTask(icontext.SetAuthorizer(ctx, auth), tx, influxdb.TaskCreate{
+		Flux:           script,
+		Description:    checkTaskDescription + c.GetName(),
+		Status:         checkTaskStatus(c),
+		OrganizationID: c.GetOrgID(),
+		Token:          auth.Token,
+	})
+	if err != nil {
+		return err
+	}
+	for _, l := range labels {
+		if err := s.putLabelMapping(ctx, tx, &influxdb.LabelMapping{
+			LabelID:      l.ID,
+			ResourceID:   t.ID,
+			ResourceType: influxdb.TasksResourceType,
+		}); err != nil {
+			return err
+		}
+	}
+	tc.SetTaskID(t.ID)
+	tc.SetAuthorizationID(auth.ID)
+	return nil
+}
+
+// checkLabels returns the labels of a check. A check without an id has none.
+func (s *Service) checkLabels(ctx context.Context, tx Tx, c influxdb.Check) ([]*influxdb.Label, error) {
+	ls := []*influxdb.Label{}
+	if !c.GetID().Valid() {
+		return ls, nil
+	}
+	if err := s.findResourceLabels(ctx, tx, influxdb.LabelMappingFilter{
+		ResourceID:   c.GetID(),
+		ResourceType: influxdb.ChecksResourceType,
+	}, &ls); err != nil {
+		return nil, err
+	}
+	return ls, nil
+}
+
+// createCheckAuthorization mints the authorization of the task of a check,
+// limited to reading the buckets queried by the check and writing the
+// monitoring bucket of its organization.
+func (s *Service) createCheckAuthorization(ctx context.Context, tx Tx, c influxdb.Check, userID influxdb.ID) (*influxdb.Authorization, error) {
+	scope, err := check.ParseQueryScope(c.(taskCheck).GetQuery())
+	if err != nil {
+		return nil, err
+	}
+
+	orgID := c.GetOrgID()
+	ps := make([]influxdb.Permission, 0, len(scope.Buckets)+1)
+	seen := make(map[influxdb.ID]bool, len(scope.Buckets))
+	for _, name := range scope.Buckets {
+		b, err := s.findBucketByName(ctx, tx, orgID, name)
+		if err != nil {
+			return nil, err
+		}
+		if seen[b.ID] {
+			continue
+		}
+		seen[b.ID] = true
+		p, err := influxdb.NewPermissionAtID(b.ID, influxdb.ReadAction, influxdb.BucketsResourceType, orgID)
+		if err != nil {
+			return nil, err
+		}
+		ps = append(ps, *p)
+	}
+
+	mb, err := s.findOrCreateMonitoringBucket(ctx, tx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	p, err := influxdb.NewPermissionAtID(mb.ID, influxdb.WriteAction, influxdb.BucketsResourceType, orgID)
+	if err != nil {
+		return nil, err
+	}
+	ps = append(ps, *p)
+
+	auth := &influxdb.Authorization{
+		OrgID:       orgID,
+		UserID:      userID,
+		Status:      influxdb.Active,
+		Description: fmt.Sprintf("task of the check %s", c.GetName()),
+		Permissions: ps,
+	}
+	if err := s.createAuthorization(ctx, tx, auth); err != nil {
+		return nil, err
+	}
+	return auth, nil
+}
+
+// findOrCreateMonitoringBucket returns the monitoring bucket of an org,
+// creating it the first time a check of the org needs it.
+func (s *Service) findOrCreateMonitoringBucket(ctx context.Context, tx Tx, orgID influxdb.ID) (*influxdb.Bucket, error) {
+	b, err := s.findBucketByName(ctx, tx, orgID, influxdb.MonitoringBucketName)
+	if err == nil {
+		return b, nil
+	}
+	if influxdb.ErrorCode(err) != influxdb.ENotFound {
+		return nil, err
+	}
+
+	b = &influxdb.Bucket{
+		OrgID:           orgID,
+		Name:            influxdb.MonitoringBucketName,
+		Description:     "statuses written by the checks of the organization",
+		RetentionPeriod: influxdb.MonitoringBucketRetention,
+	}
+	if err := s.createBucket(ctx, tx, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// rotateCheckTask updates the task of a check to its current flux and status,
+// with a new authorization replacing the previous one.
+func (s *Service) rotateCheckTask(ctx context.Context, tx Tx, c influxdb.Check) error {
+	tc, ok := asTaskCheck(c)
+	if !ok {
+		return nil
+	}
+	userID, err := s.checkTaskOwner(ctx, tx, c)
+	if err != nil {
+		return err
+	}
+	if !tc.GetTaskID().Valid() {
+		return s.createCheckTask(ctx, tx, c, userID)
+	}
+
+	labels, err := s.checkLabels(ctx, tx, c)
+	if err != nil {
+		return err
+	}
+	script, err := tc.GenerateFlux(labels)
+	if err != nil {
+		return err
+	}
+	auth, err := s.createCheckAuthorization(ctx, tx, c, userID)
+	if err != nil {
+		return err
+	}
+	status := checkTaskStatus(c)
+	if _, err := s.updateTask(ctx, tx, tc.GetTaskID(), influxdb.TaskUpdate{
+		Flux:   &script,
+		Status: &status,
+		Token:  auth.Token,
+	}); err != nil {
+		return err
+	}
+
+	if err := s.deleteCheckAuthorization(ctx, tx, tc.GetAuthorizationID()); err != nil {
+		return err
+	}
+	tc.SetAuthorizationID(auth.ID)
+	return nil
+}
+
+// checkTaskStatus returns the status of the task of a check, inactive if the
+// check is archived.
+func checkTaskStatus(c influxdb.Check) string {
+	if isArchivedCheck(c) {
+		return string(influxdb.Inactive)
+	}
+	return string(c.GetStatus())
+}
+
+// updateCheckTaskStatus updates the status of the task of a check, if it has one.
+func (s *Service) updateCheckTaskStatus(ctx context.Context, tx Tx, c influxdb.Check) error {
+	tc, ok := c.(taskCheck)
+	if !ok || !tc.GetTaskID().Valid() {
+		return nil
+	}
+	status := checkTaskStatus(c)
+	_, err := s.updateTask(ctx, tx, tc.GetTaskID(), influxdb.TaskUpdate{Status: &status})
+	return err
+}
+
+// replaceCheckTask moves the task of the current check to the check replacing
+// it, deleting the task if the new check isn't run by a task.
+func (s *Service) replaceCheckTask(ctx context.Context, tx Tx, current, c influxdb.Check) error {
+	tc, ok := asTaskCheck(c)
+	if !ok {
+		return s.deleteCheckTask(ctx, tx, current)
+	}
+	if cur, ok := current.(taskCheck); ok {
+		tc.SetTaskID(cur.GetTaskID())
+		tc.SetAuthorizationID(cur.GetAuthorizationID())
+	}
+	return s.rotateCheckTask(ctx, tx, c)
+}
+
+// deleteCheckTask deletes the task of a check and its authorization.
+func (s *Service) deleteCheckTask(ctx context.Context, tx Tx, c influxdb.Check) error {
+	tc, ok := c.(taskCheck)
+	if !ok {
+		return nil
+	}
+	if id := tc.GetTaskID(); id.Valid() {
+		if err := s.deleteTask(ctx, tx, id); err != nil && influxdb.ErrorCode(err) != influxdb.ENotFound {
+			return err
+		}
+		if err := s.deleteCheckTaskLabels(ctx, tx, id); err != nil {
+			return err
+		}
+	}
+	return s.deleteCheckAuthorization(ctx, tx, tc.GetAuthorizationID())
+}
+
+// deleteCheckTaskLabels deletes the label mappings of a deleted task of a check.
+func (s *Service) deleteCheckTaskLabels(ctx context.Context, tx Tx, taskID influxdb.ID) error {
+	ls := []*influxdb.Label{}
+	if err := s.findResourceLabels(ctx, tx, influxdb.LabelMappingFilter{
+		ResourceID:   taskID,
+		ResourceType: influxdb.TasksResourceType,
+	}, &ls); err != nil {
+		return err
+	}
+	for _, l := range ls {
+		if err := s.deleteLabelMapping(ctx, tx, &influxdb.LabelMapping{
+			LabelID:      l.ID,
+			ResourceID:   taskID,
+			ResourceType: influxdb.TasksResourceType,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// propagateCheckLabelMapping mirrors a label mapping of a check created or
+// deleted onto the task of the check, and regenerates the flux of the task
+// so its statuses are tagged with the labels of the check.
+func (s *Service) propagateCheckLabelMapping(ctx context.Context, tx Tx, m *influxdb.LabelMapping, deleted bool) error {
+	c, err := s.findCheckByID(ctx, tx, m.ResourceID)
+	if influxdb.ErrorCode(err) == influxdb.ENotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	tc, ok := c.(taskCheck)
+	if !ok || !tc.GetTaskID().Valid() {
+		return nil
+	}
+
+	tm := &influxdb.LabelMapping{
+		LabelID:      m.LabelID,
+		ResourceID:   tc.GetTaskID(),
+		ResourceType: influxdb.TasksResourceType,
+	}
+	if deleted {
+		err = s.deleteLabelMapping(ctx, tx, tm)
+	} else {
+		err = s.putLabelMapping(ctx, tx, tm)
+	}
+	if err != nil {
+		return err
+	}
+
+	labels, err := s.checkLabels(ctx, tx, c)
+	if err != nil {
+		return err
+	}
+	script, err := tc.GenerateFlux(labels)
+	if err != nil {
+		return err
+	}
+	_, err = s.updateTask(ctx, tx, tc.GetTaskID(), influxdb.TaskUpdate{Flux: &script})
+	return err
+}
+
+// deleteCheckAuthorization deletes the authorization of the task of a check, if it still exists.
+func (s *Service) deleteCheckAuthorization(ctx context.Context, tx Tx, id influxdb.ID) error {
+	if !id.Valid() {
+		return nil
+	}
+	if err := s.deleteAuthorization(ctx, tx, id); err != nil && influxdb.ErrorCode(err) != influxdb.ENotFound {
+		return err
+	}
+	return nil
+}
+
+// checkTaskOwner returns the user owning the authorization of the task of a
+// check, the first owner of the check if it has none.
+func (s *Service) checkTaskOwner(ctx context.Context, tx Tx, c influxdb.Check) (influxdb.ID, error) {
+	if id := c.(taskCheck).GetAuthorizationID(); id.Valid() {
+		a, err := s.findAuthorizationByID(ctx, tx, id)
+		if err == nil {
+			return a.UserID, nil
+		}
+		if influxdb.ErrorCode(err) != influxdb.ENotFound {
+			return 0, err
+		}
+	}
+
+	urms, err := s.findUserResourceMappings(ctx, tx, influxdb.UserResourceMappingFilter{
+		ResourceID:   c.GetID(),
+		ResourceType: influxdb.ChecksResourceType,
+		UserType:     influxdb.Owner,
+	})
+	if err != nil {
+		return 0, err
+	}
+	if len(urms) == 0 {
+		return 0, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("check %s has no owner to run its task", c.GetName()),
+		}
+	}
+	return urms[0].UserID, nil
+}
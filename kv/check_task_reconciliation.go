@@ -0,0 +1,253 @@
+package kv
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/influxdata/influxdb"
+)
+
+var (
+	checkTaskReconciliationBucket = []byte("checktaskreconciliationsv1")
+	latestReconciliationKey       = []byte("latest")
+
+	// ErrCheckTaskReconciliationNotFound is used when the checks and their tasks were never reconciled.
+	ErrCheckTaskReconciliationNotFound = &influxdb.Error{
+		Msg:  "check task reconciliation not found",
+		Code: influxdb.ENotFound,
+	}
+)
+
+var _ influxdb.CheckTaskReconciler = (*Service)(nil)
+
+func (s *Service) initializeCheckTaskReconciliations(ctx context.Context, tx Tx) error {
+	if _, err := tx.Bucket(checkTaskReconciliationBucket); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ReconcileCheckTasks finds the checks whose task is missing and the tasks of
+// the checks which don't exist, applies p to them and records the result.
+// Each repair is a transaction of its own, a failed repair is reported
+// without stopping the others.
+func (s *Service) ReconcileCheckTasks(ctx context.Context, p influxdb.CheckTaskReconcilePolicy) (*influxdb.CheckTaskReconciliation, error) {
+	if err := p.Valid(); err != nil {
+		return nil, err
+	}
+	r := &influxdb.CheckTaskReconciliation{
+		Policy:    p,
+		StartedAt: s.TimeGenerator.Now(),
+		Checks:    []influxdb.ReconciledResource{},
+		Tasks:     []influxdb.ReconciledResource{},
+	}
+	if p == influxdb.CheckTaskReconcileOff {
+		r.FinishedAt = r.StartedAt
+		return r, nil
+	}
+
+	err := s.kv.View(ctx, func(tx Tx) (err error) {
+		r.Checks, r.Tasks, err = s.findCheckTaskInconsistencies(ctx, tx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range r.Checks {
+		rc := &r.Checks[i]
+		if p != influxdb.CheckTaskReconcileRepair {
+			rc.Action = influxdb.ReconcileReported
+			continue
+		}
+		err := s.kv.Update(ctx, func(tx Tx) error {
+			return s.recreateCheckTask(ctx, tx, rc)
+		})
+		if err != nil {
+			rc.Action, rc.Error = influxdb.ReconcileFailed, err.Error()
+		}
+	}
+	for i := range r.Tasks {
+		rt := &r.Tasks[i]
+		if p != influxdb.CheckTaskReconcileRepair {
+			rt.Action = influxdb.ReconcileReported
+			continue
+		}
+		err := s.kv.Update(ctx, func(tx Tx) error {
+			return s.deleteOrphanedCheckTask(ctx, tx, rt.ID)
+		})
+		if err != nil {
+			rt.Action, rt.Error = influxdb.ReconcileFailed, err.Error()
+			continue
+		}
+		rt.Action = influxdb.ReconcileDeleted
+	}
+
+	r.FinishedAt = s.TimeGenerator.Now()
+	if err := s.kv.Update(ctx, func(tx Tx) error {
+		return s.putCheckTaskReconciliation(ctx, tx, r)
+	}); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// findCheckTaskInconsistencies returns the checks whose task is missing, and
+// the tasks of the checks which don't exist.
+func (s *Service) findCheckTaskInconsistencies(ctx context.Context, tx Tx) ([]influxdb.ReconciledResource, []influxdb.ReconciledResource, error) {
+	checks := []influxdb.ReconciledResource{}
+	taskIDs := make(map[influxdb.ID]bool)
+	var err error
+	ferr := s.forEachCheck(ctx, tx, nil, func(c influxdb.Check) bool {
+		tc, ok := asTaskCheck(c)
+		if !ok {
+			return true
+		}
+		reason := influxdb.ReconcileCheckMissingTask
+		if id := tc.GetTaskID(); id.Valid() {
+			taskIDs[id] = true
+			_, err = s.findTaskByID(ctx, tx, id)
+			if err == nil {
+				return true
+			}
+			if influxdb.ErrorCode(err) != influxdb.ENotFound {
+				return false
+			}
+			err, reason = nil, influxdb.ReconcileCheckDanglingTask
+		}
+		checks = append(checks, influxdb.ReconciledResource{
+			ID:     c.GetID(),
+			OrgID:  c.GetOrgID(),
+			Name:   c.GetName(),
+			Reason: reason,
+		})
+		return true
+	})
+	if ferr != nil {
+		return nil, nil, ferr
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tasks := []influxdb.ReconciledResource{}
+	b, err := tx.Bucket(taskBucket)
+	if err != nil {
+		return nil, nil, influxdb.ErrUnexpectedTaskBucketErr(err)
+	}
+	cur, err := b.Cursor()
+	if err != nil {
+		return nil, nil, influxdb.ErrUnexpectedTaskBucketErr(err)
+	}
+	for k, v := cur.First(); k != nil; k, v = cur.Next() {
+		t := &influxdb.Task{}
+		if err := json.Unmarshal(v, t); err != nil {
+			return nil, nil, influxdb.ErrInternalTaskServiceError(err)
+		}
+		if taskIDs[t.ID] || !IsCheckTask(t) {
+			continue
+		}
+		tasks = append(tasks, influxdb.ReconciledResource{
+			ID:     t.ID,
+			OrgID:  t.OrganizationID,
+			Name:   t.Name,
+			Reason: influxdb.ReconcileTaskOrphaned,
+		})
+	}
+	return checks, tasks, nil
+}
+
+// recreateCheckTask creates a new task for a check whose task is missing,
+// replacing the authorization of the missing task.
+func (s *Service) recreateCheckTask(ctx context.Context, tx Tx, rc *influxdb.ReconciledResource) error {
+	c, err := s.findCheckByID(ctx, tx, rc.ID)
+	if err != nil {
+		return err
+	}
+	tc := c.(taskCheck)
+	if id := tc.GetTaskID(); id.Valid() {
+		if _, err := s.findTaskByID(ctx, tx, id); err == nil {
+			return &influxdb.Error{
+				Code: influxdb.EConflict,
+				Msg:  "the task of the check was recreated since the reconciliation started",
+			}
+		}
+	}
+
+	// the owner of the new task is the user of the previous authorization.
+	authID := tc.GetAuthorizationID()
+	tc.SetTaskID(0)
+	if err := s.rotateCheckTask(ctx, tx, c); err != nil {
+		return err
+	}
+	if authID != tc.GetAuthorizationID() {
+		if err := s.deleteCheckAuthorization(ctx, tx, authID); err != nil {
+			return err
+		}
+	}
+	if err := s.putCheck(ctx, tx, c); err != nil {
+		return err
+	}
+
+	taskID := tc.GetTaskID()
+	rc.Action, rc.TaskID = influxdb.ReconcileRecreated, &taskID
+	return nil
+}
+
+// deleteOrphanedCheckTask deletes a task of a check which doesn't exist, and
+// its authorization.
+func (s *Service) deleteOrphanedCheckTask(ctx context.Context, tx Tx, id influxdb.ID) error {
+	t, err := s.findTaskByID(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.deleteTask(ctx, tx, id); err != nil {
+		return err
+	}
+	if err := s.deleteCheckTaskLabels(ctx, tx, id); err != nil {
+		return err
+	}
+	return s.deleteCheckAuthorization(ctx, tx, t.AuthorizationID)
+}
+
+// FindCheckTaskReconciliation returns the result of the latest reconciliation.
+func (s *Service) FindCheckTaskReconciliation(ctx context.Context) (*influxdb.CheckTaskReconciliation, error) {
+	var r *influxdb.CheckTaskReconciliation
+	err := s.kv.View(ctx, func(tx Tx) error {
+		b, err := tx.Bucket(checkTaskReconciliationBucket)
+		if err != nil {
+			return UnavailableCheckStoreError(err)
+		}
+		v, err := b.Get(latestReconciliationKey)
+		if IsNotFound(err) {
+			return ErrCheckTaskReconciliationNotFound
+		}
+		if err != nil {
+			return InternalCheckStoreError(err)
+		}
+		r = &influxdb.CheckTaskReconciliation{}
+		if err := json.Unmarshal(v, r); err != nil {
+			return InternalCheckStoreError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (s *Service) putCheckTaskReconciliation(ctx context.Context, tx Tx, r *influxdb.CheckTaskReconciliation) error {
+	v, err := json.Marshal(r)
+	if err != nil {
+		return InternalCheckStoreError(err)
+	}
+	b, err := tx.Bucket(checkTaskReconciliationBucket)
+	if err != nil {
+		return UnavailableCheckStoreError(err)
+	}
+	if err := b.Put(latestReconciliationKey, v); err != nil {
+		return UnavailableCheckStoreError(err)
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package kv_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	icontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/notification/check"
+)
+
+func TestService_ReconcileCheckTasks(t *testing.T) {
+	ctx := context.Background()
+	svc, user, org := newTestServiceWithOrg(t)
+	now := time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC)
+	svc.TimeGenerator = mock.TimeGenerator{FakeValue: now}
+	newCheck := func(name string) *check.SLO {
+		c := &check.SLO{
+			Base: check.Base{
+				Name:   name,
+				OrgID:  org.ID,
+				Status: influxdb.Active,
+				Every:  influxdb.Duration{Duration: time.Minute},
+				Query: influxdb.DashboardQuery{
+					Text: `from(bucket: "telegraf") |> filter(fn: (r) => r._measurement == "http")`,
+				},
+			},
+			Objective:  0.999,
+			Window:     influxdb.Duration{Duration: 30 * 24 * time.Hour},
+			Indicator:  check.ErrorRatioIndicator,
+			ErrorField: "errors",
+			TotalField: "requests",
+		}
+		if err := svc.CreateCheck(ctx, c, user.ID); err != nil {
+			t.Fatalf("failed to create check: %v", err)
+		}
+		return c
+	}
+
+	if _, err := svc.FindCheckTaskReconciliation(ctx); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Fatalf("expected no reconciliation before the first one, got %v", err)
+	}
+
+	// the task of dangling is deleted without its check.
+	dangling := newCheck("dangling")
+	if err := svc.DeleteTask(ctx, dangling.TaskID); err != nil {
+		t.Fatalf("failed to delete task: %v", err)
+	}
+
+	// orphaned is a task of a check which doesn't exist.
+	consistent := newCheck("consistent")
+	task, err := svc.FindTaskByID(ctx, consistent.TaskID)
+	if err != nil {
+		t.Fatalf("failed to find task: %v", err)
+	}
+	auth, err := svc.FindAuthorizationByID(ctx, consistent.AuthorizationID)
+	if err != nil {
+		t.Fatalf("failed to find authorization: %v", err)
+	}
+	orphaned, err := svc.CreateTask(icontext.SetAuthorizer(ctx, auth), influxdb.TaskCreate{
+		Flux:           task.Flux,
+		Description:    "runs the check deleted",
+		OrganizationID: org.ID,
+		Token:          auth.Token,
+	})
+	if err != nil {
+		t.Fatalf("failed to create task: %v", err)
+	}
+
+	r, err := svc.ReconcileCheckTasks(ctx, influxdb.CheckTaskReconcileReport)
+	if err != nil {
+		t.Fatalf("failed to reconcile check tasks: %v", err)
+	}
+	if len(r.Checks) != 1 || r.Checks[0].ID != dangling.ID || r.Checks[0].Reason != influxdb.ReconcileCheckDanglingTask || r.Checks[0].Action != influxdb.ReconcileReported {
+		t.Errorf("unexpected reconciled checks %+v", r.Checks)
+	}
+	if len(r.Tasks) != 1 || r.Tasks[0].ID != orphaned.ID || r.Tasks[0].Action != influxdb.ReconcileReported {
+		t.Errorf("unexpected reconciled tasks %+v", r.Tasks)
+	}
+	if _, err := svc.FindTaskByID(ctx, orphaned.ID); err != nil {
+		t.Errorf("expected the report not to delete the orphaned task: %v", err)
+	}
+
+	r, err = svc.ReconcileCheckTasks(ctx, influxdb.CheckTaskReconcileRepair)
+	if err != nil {
+		t.Fatalf("failed to reconcile check tasks: %v", err)
+	}
+	if len(r.Checks) != 1 || r.Checks[0].Action != influxdb.ReconcileRecreated || r.Checks[0].TaskID == nil {
+		t.Fatalf("expected the task of the check to be recreated, got %+v", r.Checks)
+	}
+	if len(r.Tasks) != 1 || r.Tasks[0].Action != influxdb.ReconcileDeleted {
+		t.Fatalf("expected the orphaned task to be deleted, got %+v", r.Tasks)
+	}
+
+	c, err := svc.FindCheckByID(ctx, dangling.ID)
+	if err != nil {
+		t.Fatalf("failed to find check: %v", err)
+	}
+	taskID := c.(*check.SLO).TaskID
+	if taskID != *r.Checks[0].TaskID {
+		t.Errorf("expected the check to reference its new task %s, got %s", *r.Checks[0].TaskID, taskID)
+	}
+	if _, err := svc.FindTaskByID(ctx, taskID); err != nil {
+		t.Errorf("failed to find the recreated task: %v", err)
+	}
+	if _, err := svc.FindTaskByID(ctx, orphaned.ID); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Errorf("expected the orphaned task to be deleted, got %v", err)
+	}
+	if _, err := svc.FindTaskByID(ctx, consistent.TaskID); err != nil {
+		t.Errorf("expected the task of a consistent check to be kept: %v", err)
+	}
+
+	latest, err := svc.FindCheckTaskReconciliation(ctx)
+	if err != nil {
+		t.Fatalf("failed to find the latest reconciliation: %v", err)
+	}
+	if latest.Policy != influxdb.CheckTaskReconcileRepair || len(latest.Checks) != 1 {
+		t.Errorf("unexpected latest reconciliation %+v", latest)
+	}
+
+	r, err = svc.ReconcileCheckTasks(ctx, influxdb.CheckTaskReconcileRepair)
+	if err != nil {
+		t.Fatalf("failed to reconcile check tasks: %v", err)
+	}
+	if len(r.Checks) != 0 || len(r.Tasks) != 0 {
+		t.Errorf("expected the repaired checks and tasks to be consistent, got %+v", r)
+	}
+}
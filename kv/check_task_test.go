@@ -0,0 +1,246 @@
+package kv_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/check"
+)
+
+func TestService_CheckTask(t *testing.T) {
+	ctx := context.Background()
+	svc, user, org := newTestServiceWithOrg(t)
+	now := time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC)
+	svc.TimeGenerator = mock.TimeGenerator{FakeValue: now}
+	source, err := svc.FindBucketByName(ctx, org.ID, "telegraf")
+	if err != nil {
+		t.Fatalf("failed to find bucket: %v", err)
+	}
+
+	c := &check.SLO{
+		Base: check.Base{
+			Name:   "api availability",
+			OrgID:  org.ID,
+			Status: influxdb.Active,
+			Every:  influxdb.Duration{Duration: time.Minute},
+			Query: influxdb.DashboardQuery{
+				Text: `from(bucket: "telegraf") |> filter(fn: (r) => r._measurement == "http")`,
+			},
+		},
+		Objective:  0.999,
+		Window:     influxdb.Duration{Duration: 30 * 24 * time.Hour},
+		Indicator:  check.ErrorRatioIndicator,
+		ErrorField: "errors",
+		TotalField: "requests",
+	}
+	if err := svc.CreateCheck(ctx, c, user.ID); err != nil {
+		t.Fatalf("failed to create check: %v", err)
+	}
+	if !c.TaskID.Valid() || !c.AuthorizationID.Valid() {
+		t.Fatalf("expected the check to have a task and an authorization, got %s and %s", c.TaskID, c.AuthorizationID)
+	}
+
+	monitoring, err := svc.FindBucketByName(ctx, org.ID, influxdb.MonitoringBucketName)
+	if err != nil {
+		t.Fatalf("failed to find the monitoring bucket: %v", err)
+	}
+	auth, err := svc.FindAuthorizationByID(ctx, c.AuthorizationID)
+	if err != nil {
+		t.Fatalf("failed to find the authorization of the check: %v", err)
+	}
+	if auth.UserID != user.ID {
+		t.Errorf("expected the authorization to be owned by %s, got %s", user.ID, auth.UserID)
+	}
+	want := []influxdb.Permission{
+		{
+			Action: influxdb.ReadAction,
+			Resource: influxdb.Resource{
+				Type:  influxdb.BucketsResourceType,
+				ID:    &source.ID,
+				OrgID: &org.ID,
+			},
+		},
+		{
+			Action: influxdb.WriteAction,
+			Resource: influxdb.Resource{
+				Type:  influxdb.BucketsResourceType,
+				ID:    &monitoring.ID,
+				OrgID: &org.ID,
+			},
+		},
+	}
+	if diff := cmp.Diff(auth.Permissions, want); diff != "" {
+		t.Errorf("authorization permissions are different -got/+want\ndiff %s", diff)
+	}
+
+	task, err := svc.FindTaskByID(ctx, c.TaskID)
+	if err != nil {
+		t.Fatalf("failed to find the task of the check: %v", err)
+	}
+	script, _ := c.GenerateFlux(nil)
+	if task.Flux != script {
+		t.Errorf("expected the task to run the flux of the check, got %s", task.Flux)
+	}
+	if task.AuthorizationID != c.AuthorizationID {
+		t.Errorf("expected the task to run with authorization %s, got %s", c.AuthorizationID, task.AuthorizationID)
+	}
+	if created := now.Format(time.RFC3339); task.CreatedAt != created || task.LatestCompleted != created {
+		t.Errorf("expected the task to be created at the time of the service, got %s and latest completed %s", task.CreatedAt, task.LatestCompleted)
+	}
+
+	// patching the check rotates the authorization of its task.
+	name, status := "api errors", influxdb.Inactive
+	patched, err := svc.PatchCheck(ctx, c.ID, influxdb.CheckUpdate{Name: &name, Status: &status})
+	if err != nil {
+		t.Fatalf("failed to patch check: %v", err)
+	}
+	p := patched.(*check.SLO)
+	if p.TaskID != c.TaskID {
+		t.Errorf("expected the check to keep its task %s, got %s", c.TaskID, p.TaskID)
+	}
+	if p.AuthorizationID == c.AuthorizationID {
+		t.Errorf("expected the authorization of the check to be rotated")
+	}
+	if _, err := svc.FindAuthorizationByID(ctx, c.AuthorizationID); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Errorf("expected the previous authorization to be deleted, got %v", err)
+	}
+	task, err = svc.FindTaskByID(ctx, c.TaskID)
+	if err != nil {
+		t.Fatalf("failed to find the task of the check: %v", err)
+	}
+	if task.Name != name || task.Status != string(influxdb.Inactive) || task.AuthorizationID != p.AuthorizationID {
+		t.Errorf("expected the task to follow the check, got name %s, status %s and authorization %s", task.Name, task.Status, task.AuthorizationID)
+	}
+
+	// the labels of the check are propagated to its task and statuses.
+	label := &influxdb.Label{OrgID: org.ID, Name: "prod"}
+	if err := svc.CreateLabel(ctx, label); err != nil {
+		t.Fatalf("failed to create label: %v", err)
+	}
+	m := &influxdb.LabelMapping{LabelID: label.ID, ResourceID: c.ID, ResourceType: influxdb.ChecksResourceType}
+	if err := svc.CreateLabelMapping(ctx, m); err != nil {
+		t.Fatalf("failed to create label mapping: %v", err)
+	}
+	taskLabels, err := svc.FindResourceLabels(ctx, influxdb.LabelMappingFilter{ResourceID: c.TaskID, ResourceType: influxdb.TasksResourceType})
+	if err != nil {
+		t.Fatalf("failed to find the labels of the task: %v", err)
+	}
+	if len(taskLabels) != 1 || taskLabels[0].ID != label.ID {
+		t.Errorf("expected the task to have the label of the check, got %v", taskLabels)
+	}
+	task, err = svc.FindTaskByID(ctx, c.TaskID)
+	if err != nil {
+		t.Fatalf("failed to find the task of the check: %v", err)
+	}
+	script, _ = p.GenerateFlux([]*influxdb.Label{label})
+	if task.Flux != script {
+		t.Errorf("expected the statuses of the task to be tagged with the label, got %s", task.Flux)
+	}
+	if err := svc.DeleteLabelMapping(ctx, m); err != nil {
+		t.Fatalf("failed to delete label mapping: %v", err)
+	}
+	taskLabels, err = svc.FindResourceLabels(ctx, influxdb.LabelMappingFilter{ResourceID: c.TaskID, ResourceType: influxdb.TasksResourceType})
+	if err != nil {
+		t.Fatalf("failed to find the labels of the task: %v", err)
+	}
+	if len(taskLabels) != 0 {
+		t.Errorf("expected the label to be removed from the task, got %v", taskLabels)
+	}
+
+	// archiving the check stops its task, until it is unarchived.
+	if _, err := svc.UnarchiveCheck(ctx, c.ID); influxdb.ErrorCode(err) != influxdb.EConflict {
+		t.Errorf("expected a conflict unarchiving a check that isn't archived, got %v", err)
+	}
+	active := influxdb.Active
+	if _, err := svc.PatchCheck(ctx, c.ID, influxdb.CheckUpdate{Status: &active}); err != nil {
+		t.Fatalf("failed to patch check: %v", err)
+	}
+	if _, err := svc.ArchiveCheck(ctx, c.ID); err != nil {
+		t.Fatalf("failed to archive check: %v", err)
+	}
+	// the task of an archived check stays inactive when the check is updated.
+	patched, err = svc.PatchCheck(ctx, c.ID, influxdb.CheckUpdate{Status: &active})
+	if err != nil {
+		t.Fatalf("failed to patch check: %v", err)
+	}
+	p = patched.(*check.SLO)
+	task, err = svc.FindTaskByID(ctx, c.TaskID)
+	if err != nil {
+		t.Fatalf("failed to find the task of the check: %v", err)
+	}
+	if task.Status != string(influxdb.Inactive) {
+		t.Errorf("expected the task of the archived check to be inactive, got %s", task.Status)
+	}
+	if _, err := svc.UnarchiveCheck(ctx, c.ID); err != nil {
+		t.Fatalf("failed to unarchive check: %v", err)
+	}
+	task, err = svc.FindTaskByID(ctx, c.TaskID)
+	if err != nil {
+		t.Fatalf("failed to find the task of the check: %v", err)
+	}
+	if task.Status != string(influxdb.Active) {
+		t.Errorf("expected the task of the unarchived check to be active, got %s", task.Status)
+	}
+
+	// deleting the check deletes its task and authorization.
+	if err := svc.DeleteCheck(ctx, c.ID); err != nil {
+		t.Fatalf("failed to delete check: %v", err)
+	}
+	if _, err := svc.FindTaskByID(ctx, c.TaskID); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Errorf("expected the task to be deleted, got %v", err)
+	}
+	if _, err := svc.FindAuthorizationByID(ctx, p.AuthorizationID); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Errorf("expected the authorization to be deleted, got %v", err)
+	}
+}
+
+func TestService_CheckTaskQueryType(t *testing.T) {
+	ctx := context.Background()
+	svc, user, org := newTestServiceWithOrg(t)
+
+	newCheck := func(queryType, text string) *check.Threshold {
+		return &check.Threshold{
+			Base: check.Base{
+				Name:      "cpu",
+				OrgID:     org.ID,
+				Status:    influxdb.Active,
+				Every:     influxdb.Duration{Duration: time.Minute},
+				QueryType: queryType,
+				Query:     influxdb.DashboardQuery{Text: text},
+			},
+			Thresholds: []check.ThresholdConfig{
+				&check.Greater{ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Critical}, Value: 90},
+			},
+		}
+	}
+
+	// a flux threshold check is run by the task of its flux.
+	c := newCheck(check.QueryTypeFlux, `from(bucket: "telegraf") |> range(start: -1m) |> filter(fn: (r) => r._measurement == "cpu")`)
+	if err := svc.CreateCheck(ctx, c, user.ID); err != nil {
+		t.Fatalf("failed to create check: %v", err)
+	}
+	task, err := svc.FindTaskByID(ctx, c.TaskID)
+	if err != nil {
+		t.Fatalf("failed to find the task of the check: %v", err)
+	}
+	if script, _ := c.GenerateFlux(nil); task.Flux != script {
+		t.Errorf("expected the task to run the flux of the check, got %s", task.Flux)
+	}
+
+	// an InfluxQL check is evaluated by the alerting engine, so its task is deleted.
+	upd, err := svc.UpdateCheck(ctx, c.ID, newCheck(check.QueryTypeInfluxQL, `SELECT usage_user FROM cpu`))
+	if err != nil {
+		t.Fatalf("failed to update check: %v", err)
+	}
+	if _, err := svc.FindTaskByID(ctx, c.TaskID); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Errorf("expected the task to be deleted, got %v", err)
+	}
+	if id := upd.(*check.Threshold).TaskID; id.Valid() {
+		t.Errorf("expected the InfluxQL check to have no task, got %s", id)
+	}
+}
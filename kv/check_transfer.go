@@ -0,0 +1,166 @@
+package kv
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification"
+)
+
+var _ influxdb.CheckTransferService = (*Service)(nil)
+
+// transferredNotificationRule is a notification rule moved with the checks it matches.
+type transferredNotificationRule interface {
+	routedNotificationRule
+	SetEndpointID(*influxdb.ID)
+	SetRoutes([]influxdb.NotificationRoute)
+}
+
+// TransferCheck moves a check, and optionally its notification rules, to another organization.
+func (s *Service) TransferCheck(ctx context.Context, id influxdb.ID, t influxdb.CheckTransfer) (*influxdb.CheckTransferResult, error) {
+	var (
+		r   *influxdb.CheckTransferResult
+		err error
+	)
+	err = s.kv.Update(ctx, func(tx Tx) error {
+		r, err = s.transferCheck(ctx, tx, id, t)
+		return err
+	})
+	return r, err
+}
+
+func (s *Service) transferCheck(ctx context.Context, tx Tx, id influxdb.ID, t influxdb.CheckTransfer) (*influxdb.CheckTransferResult, error) {
+	if err := t.Valid(); err != nil {
+		return nil, err
+	}
+	c, err := s.findCheckByID(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	from := c.GetOrgID()
+	if from == t.OrgID {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "check already belongs to the organization",
+		}
+	}
+	if _, err := s.findOrganizationByID(ctx, tx, t.OrgID); err != nil {
+		return nil, err
+	}
+	if !isArchivedCheck(c) {
+		if err := s.enforceAlertingQuota(ctx, tx, t.OrgID, influxdb.ChecksResourceType, 1); err != nil {
+			return nil, err
+		}
+	}
+	name, err := s.availableCheckName(ctx, tx, t.OrgID, c.GetName(), t.OnNameCollision == influxdb.TransferNameCollisionRename)
+	if err != nil {
+		return nil, err
+	}
+
+	// the task of the check is created again in the new organization,
+	// with an authorization scoped to its buckets.
+	tc, hasTask := asTaskCheck(c)
+	var ownerID influxdb.ID
+	if hasTask {
+		if ownerID, err = s.checkTaskOwner(ctx, tx, c); err != nil {
+			return nil, err
+		}
+		if err := s.deleteCheckTask(ctx, tx, c); err != nil {
+			return nil, err
+		}
+		tc.SetTaskID(0)
+		tc.SetAuthorizationID(0)
+	}
+
+	if err := s.deleteCheckIndex(ctx, tx, from, c.GetName()); err != nil {
+		return nil, err
+	}
+	now := s.TimeGenerator.Now()
+	c.SetOrgID(t.OrgID)
+	c.SetName(name)
+	c.SetUpdatedAt(now)
+	if err := c.Valid(); err != nil {
+		return nil, err
+	}
+	if hasTask {
+		if err := s.createCheckTask(ctx, tx, c, ownerID); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.putCheckIndex(ctx, tx, c); err != nil {
+		return nil, err
+	}
+	if err := s.putCheck(ctx, tx, c); err != nil {
+		return nil, err
+	}
+
+	r := &influxdb.CheckTransferResult{
+		Check:             c,
+		NotificationRules: []influxdb.NotificationRule{},
+	}
+	if !t.IncludeRules {
+		return r, nil
+	}
+
+	var tags []notification.Tag
+	if tc, ok := c.(taggedCheck); ok {
+		tags = tc.GetTags()
+	}
+	err = s.forEachNotificationRule(ctx, tx, false, func(nr influxdb.NotificationRule) bool {
+		if nr.GetOrgID() != from {
+			return true
+		}
+		if tr, ok := nr.(transferredNotificationRule); ok && notification.MatchTagRules(tr.GetTagRules(), tags) {
+			r.NotificationRules = append(r.NotificationRules, nr)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, nr := range r.NotificationRules {
+		if err := notificationRuleIndex.rename(tx, from, nr.GetName(), t.OrgID, nr.GetName(), nr.GetID()); err != nil {
+			return nil, err
+		}
+		nr.SetOrgID(t.OrgID)
+		// the endpoints stay in their organization.
+		nr.(transferredNotificationRule).SetEndpointID(nil)
+		nr.(transferredNotificationRule).SetRoutes(nil)
+		nr.SetUpdatedAt(now)
+		if err := s.putNotificationRule(ctx, tx, nr); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// availableCheckName returns name if the org has no check with the name, the
+// first free name suffixed with a number if rename is set, or a conflict error.
+func (s *Service) availableCheckName(ctx context.Context, tx Tx, orgID influxdb.ID, name string, rename bool) (string, error) {
+	idx, err := tx.Bucket(checkIndex)
+	if err != nil {
+		return "", UnavailableCheckStoreError(err)
+	}
+	candidate := name
+	for i := 2; ; i++ {
+		key, err := checkIndexKey(orgID, candidate)
+		if err != nil {
+			return "", err
+		}
+		_, err = idx.Get(key)
+		if IsNotFound(err) {
+			return candidate, nil
+		}
+		if err != nil {
+			return "", InternalCheckStoreError(err)
+		}
+		if !rename {
+			return "", &influxdb.Error{
+				Code: influxdb.EConflict,
+				Msg:  fmt.Sprintf("check with name %s already exists", name),
+			}
+		}
+		candidate = fmt.Sprintf("%s (%d)", name, i)
+	}
+}
@@ -0,0 +1,149 @@
+package kv_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/check"
+	"github.com/influxdata/influxdb/notification/rule"
+)
+
+func TestService_TransferCheck(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestService(t)
+
+	user := &influxdb.User{Name: "theuser"}
+	if err := svc.CreateUser(ctx, user); err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	from, to := &influxdb.Organization{Name: "from"}, &influxdb.Organization{Name: "to"}
+	for _, o := range []*influxdb.Organization{from, to} {
+		if err := svc.CreateOrganization(ctx, o); err != nil {
+			t.Fatalf("failed to create org: %v", err)
+		}
+		if err := svc.CreateBucket(ctx, &influxdb.Bucket{OrgID: o.ID, Name: "telegraf"}); err != nil {
+			t.Fatalf("failed to create bucket: %v", err)
+		}
+	}
+
+	newOpsDeadman := func(orgID influxdb.ID, name string) *check.Deadman {
+		c := newDeadman(orgID, name)
+		c.Tags = []notification.Tag{{Key: "team", Value: "ops"}}
+		return c
+	}
+	moved := newOpsDeadman(from.ID, "cpu")
+	for _, c := range []influxdb.Check{moved, newOpsDeadman(to.ID, "cpu")} {
+		if err := svc.CreateCheck(ctx, c, user.ID); err != nil {
+			t.Fatalf("failed to create check: %v", err)
+		}
+	}
+
+	endpointID := influxdb.ID(30)
+	newRule := func(id influxdb.ID, team string) influxdb.NotificationRule {
+		return &rule.Slack{
+			Base: rule.Base{
+				ID:              id,
+				Name:            "page " + team,
+				OrgID:           from.ID,
+				AuthorizationID: influxdb.ID(99),
+				Status:          influxdb.Active,
+				EndpointID:      &endpointID,
+				TagRules: []notification.TagRule{
+					{Tag: notification.Tag{Key: "team", Value: team}, Operator: notification.Equal},
+				},
+			},
+			MessageTemplate: "msg",
+		}
+	}
+	for _, nr := range []influxdb.NotificationRule{newRule(20, "ops"), newRule(21, "dev")} {
+		if err := svc.PutNotificationRule(ctx, nr); err != nil {
+			t.Fatalf("failed to populate notification rule: %v", err)
+		}
+	}
+
+	_, err := svc.TransferCheck(ctx, moved.ID, influxdb.CheckTransfer{OrgID: to.ID})
+	if influxdb.ErrorCode(err) != influxdb.EConflict {
+		t.Fatalf("expected a conflict on the name of the check, got %v", err)
+	}
+
+	r, err := svc.TransferCheck(ctx, moved.ID, influxdb.CheckTransfer{
+		OrgID:           to.ID,
+		IncludeRules:    true,
+		OnNameCollision: influxdb.TransferNameCollisionRename,
+	})
+	if err != nil {
+		t.Fatalf("failed to transfer check: %v", err)
+	}
+	if r.Check.GetOrgID() != to.ID || r.Check.GetName() != "cpu (2)" {
+		t.Errorf("expected the check to be renamed cpu (2) in the new org, got %s in %s", r.Check.GetName(), r.Check.GetOrgID())
+	}
+	if _, err := svc.FindCheck(ctx, influxdb.CheckFilter{OrgID: &from.ID}); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Errorf("expected the check to be removed from its org, got %v", err)
+	}
+	name := "cpu (2)"
+	if _, err := svc.FindCheck(ctx, influxdb.CheckFilter{OrgID: &to.ID, Name: &name}); err != nil {
+		t.Errorf("failed to find the check in its new org: %v", err)
+	}
+
+	if len(r.NotificationRules) != 1 || r.NotificationRules[0].GetID() != influxdb.ID(20) {
+		t.Fatalf("expected the matching notification rule to be moved, got %v", r.NotificationRules)
+	}
+	nr, err := svc.FindNotificationRuleByID(ctx, influxdb.ID(20))
+	if err != nil {
+		t.Fatalf("failed to find notification rule: %v", err)
+	}
+	if nr.GetOrgID() != to.ID || nr.(*rule.Slack).EndpointID != nil {
+		t.Errorf("expected the rule to be moved without its endpoint, got org %s and endpoint %v", nr.GetOrgID(), nr.(*rule.Slack).EndpointID)
+	}
+	nr, err = svc.FindNotificationRuleByID(ctx, influxdb.ID(21))
+	if err != nil {
+		t.Fatalf("failed to find notification rule: %v", err)
+	}
+	if nr.GetOrgID() != from.ID {
+		t.Errorf("expected the rule not matching the check to stay in its org, got %s", nr.GetOrgID())
+	}
+
+	// the task of a check is run with an authorization of the new org.
+	slo := &check.SLO{
+		Base: check.Base{
+			Name:   "api latency",
+			OrgID:  from.ID,
+			Status: influxdb.Active,
+			Every:  influxdb.Duration{Duration: time.Minute},
+			Query:  influxdb.DashboardQuery{Text: `from(bucket: "telegraf") |> filter(fn: (r) => r._measurement == "http")`},
+		},
+		Objective:        0.99,
+		Window:           influxdb.Duration{Duration: 7 * 24 * time.Hour},
+		Indicator:        check.LatencyIndicator,
+		LatencyThreshold: 0.3,
+	}
+	if err := svc.CreateCheck(ctx, slo, user.ID); err != nil {
+		t.Fatalf("failed to create check: %v", err)
+	}
+	oldTaskID, oldAuthID := slo.TaskID, slo.AuthorizationID
+	r, err = svc.TransferCheck(ctx, slo.ID, influxdb.CheckTransfer{OrgID: to.ID})
+	if err != nil {
+		t.Fatalf("failed to transfer check: %v", err)
+	}
+	c := r.Check.(*check.SLO)
+	if _, err := svc.FindTaskByID(ctx, oldTaskID); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Errorf("expected the task of the old org to be deleted, got %v", err)
+	}
+	if _, err := svc.FindAuthorizationByID(ctx, oldAuthID); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Errorf("expected the authorization of the old org to be deleted, got %v", err)
+	}
+	task, err := svc.FindTaskByID(ctx, c.TaskID)
+	if err != nil {
+		t.Fatalf("failed to find the task of the check: %v", err)
+	}
+	auth, err := svc.FindAuthorizationByID(ctx, c.AuthorizationID)
+	if err != nil {
+		t.Fatalf("failed to find the authorization of the check: %v", err)
+	}
+	if task.OrganizationID != to.ID || auth.OrgID != to.ID || auth.UserID != user.ID {
+		t.Errorf("expected the task and authorization to be in the new org, got %s and %s", task.OrganizationID, auth.OrgID)
+	}
+}
@@ -0,0 +1,177 @@
+package kv
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification/check"
+)
+
+var (
+	checkEventBucket = []byte("checkeventsv1")
+)
+
+var _ influxdb.CheckWatchService = (*Service)(nil)
+
+func (s *Service) initializeCheckEvents(ctx context.Context, tx Tx) error {
+	if _, err := tx.Bucket(checkEventBucket); err != nil {
+		return err
+	}
+	return nil
+}
+
+// checkEvent is a stored event of a check, keyed by its resource version.
+type checkEvent struct {
+	ResourceVersion uint64                  `json:"resourceVersion"`
+	Type            influxdb.CheckEventType `json:"type"`
+	CheckID         influxdb.ID             `json:"checkID"`
+	OrgID           influxdb.ID             `json:"orgID"`
+	Time            time.Time               `json:"time"`
+	Check           json.RawMessage         `json:"check,omitempty"`
+}
+
+func encodeResourceVersion(v uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, v)
+	return b
+}
+
+// appendCheckEvent appends an event of a check at the resource version
+// following the latest one, v is the json of the check after the change,
+// nil for a deleted check. The resource versions are contiguous, so the
+// event MaxCheckEvents versions older is the one dropped.
+func (s *Service) appendCheckEvent(ctx context.Context, tx Tx, typ influxdb.CheckEventType, checkID, orgID influxdb.ID, v []byte) error {
+	bucket, err := tx.Bucket(checkEventBucket)
+	if err != nil {
+		return UnavailableCheckStoreError(err)
+	}
+	cur, err := bucket.Cursor()
+	if err != nil {
+		return UnavailableCheckStoreError(err)
+	}
+	var version uint64 = 1
+	if k, _ := cur.Last(); len(k) == 8 {
+		version = binary.BigEndian.Uint64(k) + 1
+	}
+
+	e, err := json.Marshal(checkEvent{
+		ResourceVersion: version,
+		Type:            typ,
+		CheckID:         checkID,
+		OrgID:           orgID,
+		Time:            s.TimeGenerator.Now(),
+		Check:           v,
+	})
+	if err != nil {
+		return InternalCheckStoreError(err)
+	}
+	if err := bucket.Put(encodeResourceVersion(version), e); err != nil {
+		return UnavailableCheckStoreError(err)
+	}
+	if version > influxdb.MaxCheckEvents {
+		if err := bucket.Delete(encodeResourceVersion(version - influxdb.MaxCheckEvents)); err != nil {
+			return UnavailableCheckStoreError(err)
+		}
+	}
+	return nil
+}
+
+// appendCheckPutEvents appends the events of a check put over its previous
+// json, nil if the check is created. A check transferred to another
+// organization is deleted from the old one and created in the new one.
+func (s *Service) appendCheckPutEvents(ctx context.Context, tx Tx, c influxdb.Check, prev, v []byte) error {
+	if prev == nil {
+		return s.appendCheckEvent(ctx, tx, influxdb.CheckCreated, c.GetID(), c.GetOrgID(), v)
+	}
+	var old struct {
+		OrgID influxdb.ID `json:"orgID"`
+	}
+	if err := json.Unmarshal(prev, &old); err == nil && old.OrgID.Valid() && old.OrgID != c.GetOrgID() {
+		if err := s.appendCheckEvent(ctx, tx, influxdb.CheckDeleted, c.GetID(), old.OrgID, nil); err != nil {
+			return err
+		}
+		return s.appendCheckEvent(ctx, tx, influxdb.CheckCreated, c.GetID(), c.GetOrgID(), v)
+	}
+	return s.appendCheckEvent(ctx, tx, influxdb.CheckUpdated, c.GetID(), c.GetOrgID(), v)
+}
+
+// FindCheckEvents returns the events of the checks of an organization
+// following a resource version.
+func (s *Service) FindCheckEvents(ctx context.Context, filter influxdb.CheckEventFilter) ([]*influxdb.CheckEvent, uint64, error) {
+	var (
+		events  []*influxdb.CheckEvent
+		version uint64
+	)
+	err := s.kv.View(ctx, func(tx Tx) error {
+		var err error
+		events, version, err = s.findCheckEvents(ctx, tx, filter)
+		return err
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return events, version, nil
+}
+
+func (s *Service) findCheckEvents(ctx context.Context, tx Tx, filter influxdb.CheckEventFilter) ([]*influxdb.CheckEvent, uint64, error) {
+	bucket, err := tx.Bucket(checkEventBucket)
+	if err != nil {
+		return nil, 0, UnavailableCheckStoreError(err)
+	}
+	cur, err := bucket.Cursor()
+	if err != nil {
+		return nil, 0, UnavailableCheckStoreError(err)
+	}
+
+	events := []*influxdb.CheckEvent{}
+	var latest uint64
+	if k, _ := cur.Last(); len(k) == 8 {
+		latest = binary.BigEndian.Uint64(k)
+	}
+	if filter.Since == nil {
+		return events, latest, nil
+	}
+	since := *filter.Since
+	if since >= latest {
+		return events, since, nil
+	}
+	if k, _ := cur.First(); len(k) == 8 && binary.BigEndian.Uint64(k) > since+1 {
+		return nil, 0, &influxdb.Error{
+			Code: influxdb.EConflict,
+			Msg:  fmt.Sprintf("the events following resource version %d were dropped, list the checks again", since),
+		}
+	}
+
+	version := since
+	for k, v := cur.Seek(encodeResourceVersion(since + 1)); k != nil; k, v = cur.Next() {
+		e := &checkEvent{}
+		if err := json.Unmarshal(v, e); err != nil {
+			return nil, 0, InternalCheckStoreError(err)
+		}
+		version = e.ResourceVersion
+		if e.OrgID != filter.OrgID {
+			continue
+		}
+		ce := &influxdb.CheckEvent{
+			ResourceVersion: e.ResourceVersion,
+			Type:            e.Type,
+			CheckID:         e.CheckID,
+			OrgID:           e.OrgID,
+			Time:            e.Time,
+		}
+		if len(e.Check) > 0 {
+			if ce.Check, err = check.UnmarshalJSON(e.Check); err != nil {
+				return nil, 0, InternalCheckStoreError(err)
+			}
+		}
+		events = append(events, ce)
+		if filter.Limit > 0 && len(events) >= filter.Limit {
+			break
+		}
+	}
+	return events, version, nil
+}
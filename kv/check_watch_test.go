@@ -0,0 +1,121 @@
+package kv_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification/check"
+)
+
+func TestService_FindCheckEvents(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestService(t)
+
+	user := &influxdb.User{Name: "theuser"}
+	if err := svc.CreateUser(ctx, user); err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	from, to := &influxdb.Organization{Name: "from"}, &influxdb.Organization{Name: "to"}
+	for _, o := range []*influxdb.Organization{from, to} {
+		if err := svc.CreateOrganization(ctx, o); err != nil {
+			t.Fatalf("failed to create org: %v", err)
+		}
+		if err := svc.CreateBucket(ctx, &influxdb.Bucket{OrgID: o.ID, Name: "telegraf"}); err != nil {
+			t.Fatalf("failed to create bucket: %v", err)
+		}
+	}
+
+	_, latest, err := svc.FindCheckEvents(ctx, influxdb.CheckEventFilter{OrgID: from.ID})
+	if err != nil {
+		t.Fatalf("failed to find the latest resource version: %v", err)
+	}
+
+	newCheck := func(name string) *check.Deadman {
+		c := &check.Deadman{
+			Base: check.Base{
+				Name:   name,
+				OrgID:  from.ID,
+				Status: influxdb.Active,
+				Every:  influxdb.Duration{Duration: time.Minute},
+				Query:  influxdb.DashboardQuery{Text: `from(bucket: "telegraf") |> range(start: -1m)`},
+			},
+			TimeSince: 90,
+		}
+		if err := svc.CreateCheck(ctx, c, user.ID); err != nil {
+			t.Fatalf("failed to create check: %v", err)
+		}
+		return c
+	}
+	cpu, mem := newCheck("cpu"), newCheck("mem")
+	desc := "the cpu usage"
+	if _, err := svc.PatchCheck(ctx, cpu.ID, influxdb.CheckUpdate{Description: &desc}); err != nil {
+		t.Fatalf("failed to patch check: %v", err)
+	}
+	if _, err := svc.TransferCheck(ctx, mem.ID, influxdb.CheckTransfer{OrgID: to.ID}); err != nil {
+		t.Fatalf("failed to transfer check: %v", err)
+	}
+	if err := svc.DeleteCheck(ctx, cpu.ID); err != nil {
+		t.Fatalf("failed to delete check: %v", err)
+	}
+
+	type event struct {
+		typ     influxdb.CheckEventType
+		checkID influxdb.ID
+	}
+	watch := func(orgID influxdb.ID, since uint64, limit int) ([]event, uint64) {
+		t.Helper()
+		es, version, err := svc.FindCheckEvents(ctx, influxdb.CheckEventFilter{OrgID: orgID, Since: &since, Limit: limit})
+		if err != nil {
+			t.Fatalf("failed to find check events: %v", err)
+		}
+		var got []event
+		for i, e := range es {
+			got = append(got, event{e.Type, e.CheckID})
+			if i > 0 && e.ResourceVersion <= es[i-1].ResourceVersion {
+				t.Errorf("expected the resource versions to increase, got %d after %d", e.ResourceVersion, es[i-1].ResourceVersion)
+			}
+			if (e.Type == influxdb.CheckDeleted) != (e.Check == nil) {
+				t.Errorf("expected only the %s event of check %s not to have the check", e.Type, e.CheckID)
+			}
+		}
+		return got, version
+	}
+	equal := func(got, want []event) bool {
+		if len(got) != len(want) {
+			return false
+		}
+		for i := range got {
+			if got[i] != want[i] {
+				return false
+			}
+		}
+		return true
+	}
+
+	got, version := watch(from.ID, latest, 0)
+	want := []event{
+		{influxdb.CheckCreated, cpu.ID},
+		{influxdb.CheckCreated, mem.ID},
+		{influxdb.CheckUpdated, cpu.ID},
+		{influxdb.CheckDeleted, mem.ID},
+		{influxdb.CheckDeleted, cpu.ID},
+	}
+	if !equal(got, want) {
+		t.Errorf("expected the events of the org %v, got %v", want, got)
+	}
+	if got, next := watch(from.ID, version, 0); len(got) != 0 || next != version {
+		t.Errorf("expected no events following the latest version %d, got %v at %d", version, got, next)
+	}
+
+	got, _ = watch(to.ID, latest, 0)
+	if !equal(got, []event{{influxdb.CheckCreated, mem.ID}}) {
+		t.Errorf("expected the transferred check to be created in its new org, got %v", got)
+	}
+
+	got, next := watch(from.ID, latest, 1)
+	if !equal(got, []event{{influxdb.CheckCreated, cpu.ID}}) || next != latest+1 {
+		t.Errorf("expected the first event at version %d, got %v at %d", latest+1, got, next)
+	}
+}
@@ -0,0 +1,264 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"sort"
+
+	"github.com/influxdata/influxdb"
+	icontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/notification"
+)
+
+// dryRunStore runs the updates of the contexts of dry runs in read
+// transactions whose writes are buffered, and recorded in the dry run instead
+// of being committed. The mutations of a dry run hence go through the
+// validations and the side effects of the real ones.
+type dryRunStore struct {
+	Store
+	service *Service
+}
+
+// Update runs fn in a transaction never committed if ctx is a dry run.
+func (s *dryRunStore) Update(ctx context.Context, fn func(Tx) error) error {
+	d := icontext.GetDryRun(ctx)
+	if d == nil {
+		return s.Store.Update(ctx, fn)
+	}
+	return s.Store.View(ctx, func(tx Tx) error {
+		dtx := &dryRunTx{Tx: tx, buckets: make(map[string]*dryRunBucket)}
+		if err := fn(dtx); err != nil {
+			return err
+		}
+		return s.service.recordDryRun(ctx, dtx, d)
+	})
+}
+
+// dryRunTx buffers the writes to the buckets of a transaction.
+type dryRunTx struct {
+	Tx
+	buckets map[string]*dryRunBucket
+}
+
+// Bucket returns the bucket b, reading its buffered writes over the ones of the transaction.
+func (tx *dryRunTx) Bucket(b []byte) (Bucket, error) {
+	if bkt, ok := tx.buckets[string(b)]; ok {
+		return bkt, nil
+	}
+	bkt, err := tx.Tx.Bucket(b)
+	if err != nil {
+		return nil, err
+	}
+	dbkt := &dryRunBucket{Bucket: bkt, writes: make(map[string][]byte)}
+	tx.buckets[string(b)] = dbkt
+	return dbkt, nil
+}
+
+// dryRunBucket buffers the writes to a bucket, a deleted key is buffered as nil.
+type dryRunBucket struct {
+	Bucket
+	writes map[string][]byte
+}
+
+func (b *dryRunBucket) Get(key []byte) ([]byte, error) {
+	if v, ok := b.writes[string(key)]; ok {
+		if v == nil {
+			return nil, ErrKeyNotFound
+		}
+		return v, nil
+	}
+	return b.Bucket.Get(key)
+}
+
+// Cursor returns a cursor over the pairs of the bucket with its buffered writes.
+func (b *dryRunBucket) Cursor() (Cursor, error) {
+	c, err := b.Bucket.Cursor()
+	if err != nil {
+		return nil, err
+	}
+	var pairs []Pair
+	for k, v := c.First(); k != nil; k, v = c.Next() {
+		if _, ok := b.writes[string(k)]; ok {
+			continue
+		}
+		pairs = append(pairs, Pair{Key: k, Value: v})
+	}
+	for k, v := range b.writes {
+		if v != nil {
+			pairs = append(pairs, Pair{Key: []byte(k), Value: v})
+		}
+	}
+	return NewStaticCursor(pairs), nil
+}
+
+func (b *dryRunBucket) Put(key, value []byte) error {
+	b.writes[string(key)] = append([]byte{}, value...)
+	return nil
+}
+
+func (b *dryRunBucket) Delete(key []byte) error {
+	b.writes[string(key)] = nil
+	return nil
+}
+
+// keys returns the keys written to the bucket, in order, and the deleted ones.
+func (b *dryRunBucket) keys() (put, deleted [][]byte) {
+	for k, v := range b.writes {
+		if v == nil {
+			deleted = append(deleted, []byte(k))
+		} else {
+			put = append(put, []byte(k))
+		}
+	}
+	sortKeys(put)
+	sortKeys(deleted)
+	return put, deleted
+}
+
+func sortKeys(keys [][]byte) {
+	sort.Slice(keys, func(i, j int) bool {
+		return bytes.Compare(keys[i], keys[j]) < 0
+	})
+}
+
+// recordDryRun records in d the side effects of the writes buffered by tx.
+func (s *Service) recordDryRun(ctx context.Context, tx *dryRunTx, d *influxdb.DryRun) error {
+	rules := make(map[influxdb.ID]bool)
+
+	if b, ok := tx.buckets[string(taskBucket)]; ok {
+		put, deleted := b.keys()
+		for _, k := range put {
+			var id influxdb.ID
+			if err := id.Decode(k); err != nil {
+				continue
+			}
+			t, err := s.findTaskByID(ctx, tx, id)
+			if err != nil {
+				return err
+			}
+			d.Tasks = append(d.Tasks, t)
+		}
+		for _, k := range deleted {
+			var id influxdb.ID
+			if err := id.Decode(k); err == nil {
+				d.DeletedTasks = append(d.DeletedTasks, id)
+			}
+		}
+	}
+
+	if b, ok := tx.buckets[string(secretBucket)]; ok {
+		put, deleted := b.keys()
+		for _, k := range put {
+			if _, key, err := decodeSecretKey(k); err == nil {
+				d.Secrets = append(d.Secrets, key)
+			}
+		}
+		for _, k := range deleted {
+			if _, key, err := decodeSecretKey(k); err == nil {
+				d.DeletedSecrets = append(d.DeletedSecrets, key)
+			}
+		}
+	}
+
+	// the rules changed or deleted, except the created ones.
+	if b, ok := tx.buckets[string(notificationRuleBucket)]; ok {
+		put, deleted := b.keys()
+		for _, k := range append(put, deleted...) {
+			var id influxdb.ID
+			if err := id.Decode(k); err != nil {
+				continue
+			}
+			if _, err := b.Bucket.Get(k); err == nil {
+				rules[id] = true
+			}
+		}
+	}
+
+	// the rules sending to the endpoints changed or deleted.
+	if b, ok := tx.buckets[string(notificationEndpointBucket)]; ok {
+		put, deleted := b.keys()
+		for _, k := range append(put, deleted...) {
+			var id influxdb.ID
+			if err := id.Decode(k); err != nil {
+				continue
+			}
+			ids, err := s.findNotificationEndpointRules(ctx, tx.Tx, id)
+			if err != nil {
+				return err
+			}
+			for _, id := range ids {
+				rules[id] = true
+			}
+		}
+	}
+
+	// the rules matching the checks changed or deleted, before or after the change.
+	if b, ok := tx.buckets[string(checkBucket)]; ok {
+		put, deleted := b.keys()
+		var checks []influxdb.Check
+		for _, k := range put {
+			var id influxdb.ID
+			if err := id.Decode(k); err != nil {
+				continue
+			}
+			c, err := s.findCheckByID(ctx, tx, id)
+			if err != nil {
+				return err
+			}
+			checks = append(checks, c)
+		}
+		for _, k := range append(put, deleted...) {
+			var id influxdb.ID
+			if err := id.Decode(k); err != nil {
+				continue
+			}
+			c, err := s.findCheckByID(ctx, tx.Tx, id)
+			if influxdb.ErrorCode(err) == influxdb.ENotFound {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			checks = append(checks, c)
+		}
+		if err := s.findCheckRules(ctx, tx.Tx, checks, rules); err != nil {
+			return err
+		}
+	}
+
+	for id := range rules {
+		d.NotificationRules = append(d.NotificationRules, id)
+	}
+	sort.Slice(d.NotificationRules, func(i, j int) bool {
+		return d.NotificationRules[i] < d.NotificationRules[j]
+	})
+	return nil
+}
+
+// findCheckRules adds to rules the IDs of the notification rules matching the
+// tags of any of the checks.
+func (s *Service) findCheckRules(ctx context.Context, tx Tx, checks []influxdb.Check, rules map[influxdb.ID]bool) error {
+	if len(checks) == 0 {
+		return nil
+	}
+	return s.forEachNotificationRule(ctx, tx, false, func(nr influxdb.NotificationRule) bool {
+		routed, ok := nr.(routedNotificationRule)
+		if !ok {
+			return true
+		}
+		for _, c := range checks {
+			if nr.GetOrgID() != c.GetOrgID() {
+				continue
+			}
+			var tags []notification.Tag
+			if tc, ok := c.(taggedCheck); ok {
+				tags = tc.GetTags()
+			}
+			if notification.MatchTagRules(routed.GetTagRules(), tags) {
+				rules[nr.GetID()] = true
+				break
+			}
+		}
+		return true
+	})
+}
@@ -0,0 +1,143 @@
+package kv_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb"
+	icontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/check"
+	"github.com/influxdata/influxdb/notification/endpoint"
+	"github.com/influxdata/influxdb/notification/rule"
+)
+
+func TestService_DryRun(t *testing.T) {
+	ctx := context.Background()
+	svc, user, org := newTestServiceWithOrg(t)
+
+	token := "xoxb-secret"
+	edp := &endpoint.Slack{
+		Base:  endpoint.Base{Name: "slack", OrgID: org.ID, Status: influxdb.Active},
+		URL:   "https://hooks.slack.com/services/1",
+		Token: influxdb.SecretField{Value: &token},
+	}
+	if err := svc.CreateNotificationEndpoint(ctx, edp, user.ID); err != nil {
+		t.Fatalf("failed to create notification endpoint: %v", err)
+	}
+	nr := &rule.Slack{
+		Base: rule.Base{
+			Name:            "ops",
+			OrgID:           org.ID,
+			AuthorizationID: 30,
+			EndpointID:      &edp.ID,
+			Status:          influxdb.Active,
+			Every:           influxdb.Duration{Duration: time.Minute},
+			TagRules: []notification.TagRule{
+				{Tag: notification.Tag{Key: "team", Value: "ops"}, Operator: notification.Equal},
+			},
+		},
+		Channel:         "#ops",
+		MessageTemplate: "{{ .Level }}",
+	}
+	if err := svc.CreateNotificationRule(ctx, nr, user.ID); err != nil {
+		t.Fatalf("failed to create notification rule: %v", err)
+	}
+
+	t.Run("create check", func(t *testing.T) {
+		c := &check.SLO{
+			Base: check.Base{
+				Name:   "cpu",
+				OrgID:  org.ID,
+				Status: influxdb.Active,
+				Every:  influxdb.Duration{Duration: time.Minute},
+				Query:  influxdb.DashboardQuery{Text: `from(bucket: "telegraf") |> filter(fn: (r) => r._measurement == "http")`},
+				Tags:   []notification.Tag{{Key: "team", Value: "ops"}},
+			},
+			Objective:        0.99,
+			Window:           influxdb.Duration{Duration: 7 * 24 * time.Hour},
+			Indicator:        check.LatencyIndicator,
+			LatencyThreshold: 0.3,
+		}
+		d := influxdb.NewDryRun()
+		if err := svc.CreateCheck(icontext.SetDryRun(ctx, d), c, user.ID); err != nil {
+			t.Fatalf("failed to dry run the creation of the check: %v", err)
+		}
+		if !c.ID.Valid() || !c.TaskID.Valid() {
+			t.Errorf("expected the dry run to return the check it would create, got %v", c)
+		}
+		if len(d.Tasks) != 1 || d.Tasks[0].ID != c.TaskID || d.Tasks[0].Flux == "" {
+			t.Errorf("expected the dry run to return the task of the check, got %v", d.Tasks)
+		}
+		if diff := cmp.Diff([]influxdb.ID{nr.ID}, d.NotificationRules); diff != "" {
+			t.Errorf("unexpected notification rules -want/+got\n%s", diff)
+		}
+
+		if _, err := svc.FindCheckByID(ctx, c.ID); influxdb.ErrorCode(err) != influxdb.ENotFound {
+			t.Errorf("expected the check not to be created, got %v", err)
+		}
+		if _, err := svc.FindTaskByID(ctx, c.TaskID); err == nil {
+			t.Errorf("expected the task of the check not to be created")
+		}
+	})
+
+	t.Run("update endpoint", func(t *testing.T) {
+		rotated := "xoxb-rotated"
+		upd := &endpoint.Slack{
+			Base:  endpoint.Base{Name: "slack", Status: influxdb.Active},
+			URL:   "https://hooks.slack.com/services/2",
+			Token: influxdb.SecretField{Value: &rotated},
+		}
+		d := influxdb.NewDryRun()
+		if _, err := svc.UpdateNotificationEndpoint(icontext.SetDryRun(ctx, d), edp.ID, upd, user.ID); err != nil {
+			t.Fatalf("failed to dry run the update of the endpoint: %v", err)
+		}
+		if diff := cmp.Diff([]string{edp.Token.Key}, d.Secrets); diff != "" {
+			t.Errorf("unexpected secrets -want/+got\n%s", diff)
+		}
+		if diff := cmp.Diff([]influxdb.ID{nr.ID}, d.NotificationRules); diff != "" {
+			t.Errorf("unexpected notification rules -want/+got\n%s", diff)
+		}
+
+		got, err := svc.FindNotificationEndpointByID(ctx, edp.ID)
+		if err != nil {
+			t.Fatalf("failed to find endpoint: %v", err)
+		}
+		if got.(*endpoint.Slack).URL != edp.URL {
+			t.Errorf("expected the endpoint not to be updated, got url %s", got.(*endpoint.Slack).URL)
+		}
+		secret, err := svc.LoadSecret(ctx, org.ID, edp.Token.Key)
+		if err != nil {
+			t.Fatalf("failed to load the secret of the endpoint: %v", err)
+		}
+		if secret != token {
+			t.Errorf("expected the secret not to be rotated, got %s", secret)
+		}
+	})
+
+	t.Run("delete endpoint and its rules", func(t *testing.T) {
+		d := influxdb.NewDryRun()
+		ids, err := svc.DeleteNotificationEndpointCascade(icontext.SetDryRun(ctx, d), edp.ID)
+		if err != nil {
+			t.Fatalf("failed to dry run the deletion of the endpoint: %v", err)
+		}
+		if diff := cmp.Diff([]influxdb.ID{nr.ID}, ids); diff != "" {
+			t.Errorf("unexpected deleted notification rules -want/+got\n%s", diff)
+		}
+		if diff := cmp.Diff([]string{edp.Token.Key}, d.DeletedSecrets); diff != "" {
+			t.Errorf("unexpected deleted secrets -want/+got\n%s", diff)
+		}
+		if diff := cmp.Diff([]influxdb.ID{nr.ID}, d.NotificationRules); diff != "" {
+			t.Errorf("unexpected notification rules -want/+got\n%s", diff)
+		}
+
+		if _, err := svc.FindNotificationEndpointByID(ctx, edp.ID); err != nil {
+			t.Errorf("expected the endpoint not to be deleted, got %v", err)
+		}
+		if _, err := svc.FindNotificationRuleByID(ctx, nr.ID); err != nil {
+			t.Errorf("expected the notification rule not to be deleted, got %v", err)
+		}
+	})
+}
@@ -5,10 +5,14 @@ import (
 	"errors"
 	"io/ioutil"
 	"os"
+	"testing"
+	"time"
 
+	"github.com/influxdata/influxdb"
 	"github.com/influxdata/influxdb/bolt"
 	"github.com/influxdata/influxdb/inmem"
 	"github.com/influxdata/influxdb/kv"
+	"github.com/influxdata/influxdb/notification/check"
 )
 
 func NewTestBoltStore() (kv.Store, func(), error) {
@@ -35,3 +39,53 @@ func NewTestBoltStore() (kv.Store, func(), error) {
 func NewTestInmemStore() (kv.Store, func(), error) {
 	return inmem.NewKVStore(), func() {}, nil
 }
+
+// newTestService returns a service initialized on an in-memory store.
+func newTestService(t *testing.T, configs ...kv.ServiceConfig) *kv.Service {
+	t.Helper()
+	s, _, err := NewTestInmemStore()
+	if err != nil {
+		t.Fatalf("failed to create new kv store: %v", err)
+	}
+	svc := kv.NewService(s, configs...)
+	if err := svc.Initialize(context.Background()); err != nil {
+		t.Fatalf("error initializing kv service: %v", err)
+	}
+	return svc
+}
+
+// newTestServiceWithOrg returns a service initialized on an in-memory store,
+// with the user "theuser", and the organization "theorg" and its bucket
+// "telegraf".
+func newTestServiceWithOrg(t *testing.T, configs ...kv.ServiceConfig) (*kv.Service, *influxdb.User, *influxdb.Organization) {
+	t.Helper()
+	ctx := context.Background()
+	svc := newTestService(t, configs...)
+	user := &influxdb.User{Name: "theuser"}
+	if err := svc.CreateUser(ctx, user); err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	org := &influxdb.Organization{Name: "theorg"}
+	if err := svc.CreateOrganization(ctx, org); err != nil {
+		t.Fatalf("failed to create org: %v", err)
+	}
+	if err := svc.CreateBucket(ctx, &influxdb.Bucket{OrgID: org.ID, Name: "telegraf"}); err != nil {
+		t.Fatalf("failed to create bucket: %v", err)
+	}
+	return svc, user, org
+}
+
+// newDeadman returns an active deadman check of the organization orgID,
+// querying the bucket "telegraf" every minute.
+func newDeadman(orgID influxdb.ID, name string) *check.Deadman {
+	return &check.Deadman{
+		Base: check.Base{
+			Name:   name,
+			OrgID:  orgID,
+			Status: influxdb.Active,
+			Every:  influxdb.Duration{Duration: time.Minute},
+			Query:  influxdb.DashboardQuery{Text: `from(bucket: "telegraf") |> range(start: -1m)`},
+		},
+		TimeSince: 60,
+	}
+}
@@ -207,6 +207,10 @@ func (s *Service) createLabelMapping(ctx context.Context, tx Tx, m *influxdb.Lab
 		return err
 	}
 
+	if m.ResourceType == influxdb.ChecksResourceType {
+		return s.propagateCheckLabelMapping(ctx, tx, m, false)
+	}
+
 	return nil
 }
 
@@ -242,6 +246,10 @@ func (s *Service) deleteLabelMapping(ctx context.Context, tx Tx, m *influxdb.Lab
 		}
 	}
 
+	if m.ResourceType == influxdb.ChecksResourceType {
+		return s.propagateCheckLabelMapping(ctx, tx, m, true)
+	}
+
 	return nil
 }
 
@@ -0,0 +1,122 @@
+package kv
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.LabelBulkService = (*Service)(nil)
+
+// ApplyLabel applies a label to every check matching the filter of the
+// operation, in one transaction.
+func (s *Service) ApplyLabel(ctx context.Context, labelID influxdb.ID, o influxdb.LabelBulkOperation) (*influxdb.LabelBulkResult, error) {
+	return s.bulkLabel(ctx, labelID, o, false)
+}
+
+// RemoveLabel removes a label from every check matching the filter of the
+// operation, in one transaction.
+func (s *Service) RemoveLabel(ctx context.Context, labelID influxdb.ID, o influxdb.LabelBulkOperation) (*influxdb.LabelBulkResult, error) {
+	return s.bulkLabel(ctx, labelID, o, true)
+}
+
+func (s *Service) bulkLabel(ctx context.Context, labelID influxdb.ID, o influxdb.LabelBulkOperation, remove bool) (*influxdb.LabelBulkResult, error) {
+	var (
+		r   *influxdb.LabelBulkResult
+		err error
+	)
+	update := s.kv.Update
+	if o.DryRun {
+		update = s.kv.View
+	}
+	err = update(ctx, func(tx Tx) error {
+		r, err = s.bulkLabelChecks(ctx, tx, labelID, o, remove)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (s *Service) bulkLabelChecks(ctx context.Context, tx Tx, labelID influxdb.ID, o influxdb.LabelBulkOperation, remove bool) (*influxdb.LabelBulkResult, error) {
+	if err := o.Valid(); err != nil {
+		return nil, err
+	}
+	l, err := s.findLabelByID(ctx, tx, labelID)
+	if err != nil {
+		return nil, err
+	}
+	if l.OrgID != o.Filter.OrgID {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "label belongs to another organization than the checks",
+		}
+	}
+	cs, _, err := s.findChecks(ctx, tx, influxdb.CheckFilter{OrgID: &o.Filter.OrgID})
+	if err != nil {
+		return nil, err
+	}
+
+	// every check is matched before any mapping is changed, the stores
+	// without transactions can't roll back a partial operation.
+	r := &influxdb.LabelBulkResult{
+		LabelID:      labelID,
+		ResourceType: o.ResourceType,
+		DryRun:       o.DryRun,
+		Changed:      []influxdb.ID{},
+	}
+	for _, c := range cs {
+		ok, err := s.matchCheckBulkFilter(ctx, tx, c, o.Filter)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		r.Matched++
+		labeled, err := s.hasCheckLabel(ctx, tx, c, labelID)
+		if err != nil {
+			return nil, err
+		}
+		if labeled == remove {
+			r.Changed = append(r.Changed, c.GetID())
+		} else {
+			r.Unchanged++
+		}
+	}
+	if o.DryRun {
+		return r, nil
+	}
+
+	for _, id := range r.Changed {
+		m := &influxdb.LabelMapping{
+			LabelID:      labelID,
+			ResourceID:   id,
+			ResourceType: influxdb.ChecksResourceType,
+		}
+		if remove {
+			err = s.deleteLabelMapping(ctx, tx, m)
+		} else {
+			err = s.createLabelMapping(ctx, tx, m)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// hasCheckLabel returns whether a label is applied to a check.
+func (s *Service) hasCheckLabel(ctx context.Context, tx Tx, c influxdb.Check, labelID influxdb.ID) (bool, error) {
+	ls, err := s.checkLabels(ctx, tx, c)
+	if err != nil {
+		return false, err
+	}
+	for _, l := range ls {
+		if l.ID == labelID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
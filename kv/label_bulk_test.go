@@ -0,0 +1,108 @@
+package kv_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/check"
+)
+
+func TestService_BulkLabel(t *testing.T) {
+	ctx := context.Background()
+	svc, user, org := newTestServiceWithOrg(t)
+	other := &influxdb.Organization{Name: "otherorg"}
+	if err := svc.CreateOrganization(ctx, other); err != nil {
+		t.Fatalf("failed to create org: %v", err)
+	}
+
+	checks := make(map[string]*check.Deadman)
+	for name, team := range map[string]string{"cpu": "ops", "mem": "ops", "disk": "dev"} {
+		c := &check.Deadman{
+			Base: check.Base{
+				Name:   name,
+				OrgID:  org.ID,
+				Status: influxdb.Active,
+				Every:  influxdb.Duration{Duration: time.Minute},
+				Query:  influxdb.DashboardQuery{Text: `from(bucket: "telegraf") |> range(start: -1m)`},
+				Tags:   []notification.Tag{{Key: "team", Value: team}},
+			},
+			TimeSince: 60,
+		}
+		if err := svc.CreateCheck(ctx, c, user.ID); err != nil {
+			t.Fatalf("failed to create check: %v", err)
+		}
+		checks[name] = c
+	}
+	label := &influxdb.Label{Name: "paging", OrgID: org.ID}
+	if err := svc.CreateLabel(ctx, label); err != nil {
+		t.Fatalf("failed to create label: %v", err)
+	}
+	// cpu already has the label.
+	if err := svc.CreateLabelMapping(ctx, &influxdb.LabelMapping{
+		LabelID:      label.ID,
+		ResourceID:   checks["cpu"].ID,
+		ResourceType: influxdb.ChecksResourceType,
+	}); err != nil {
+		t.Fatalf("failed to create label mapping: %v", err)
+	}
+
+	ops := influxdb.LabelBulkOperation{
+		ResourceType: influxdb.ChecksResourceType,
+		Filter: influxdb.CheckBulkFilter{
+			OrgID: org.ID,
+			Tags:  map[string]string{"team": "ops"},
+		},
+	}
+	labeled := func(name string) bool {
+		ls, err := svc.FindResourceLabels(ctx, influxdb.LabelMappingFilter{ResourceID: checks[name].ID, ResourceType: influxdb.ChecksResourceType})
+		if err != nil {
+			t.Fatalf("failed to find the labels of check %s: %v", name, err)
+		}
+		return len(ls) == 1 && ls[0].ID == label.ID
+	}
+
+	r, err := svc.ApplyLabel(ctx, label.ID, ops)
+	if err != nil {
+		t.Fatalf("failed to apply the label: %v", err)
+	}
+	if r.Matched != 2 || r.Unchanged != 1 || len(r.Changed) != 1 || r.Changed[0] != checks["mem"].ID {
+		t.Errorf("expected the label to be applied to mem only, got %+v", r)
+	}
+	if !labeled("cpu") || !labeled("mem") || labeled("disk") {
+		t.Errorf("expected cpu and mem to have the label, and disk not")
+	}
+
+	dryRun := ops
+	dryRun.DryRun = true
+	r, err = svc.RemoveLabel(ctx, label.ID, dryRun)
+	if err != nil {
+		t.Fatalf("failed to dry run the removal of the label: %v", err)
+	}
+	if !r.DryRun || len(r.Changed) != 2 || !labeled("cpu") || !labeled("mem") {
+		t.Errorf("expected a dry run to report 2 checks and change none, got %+v", r)
+	}
+	r, err = svc.RemoveLabel(ctx, label.ID, ops)
+	if err != nil {
+		t.Fatalf("failed to remove the label: %v", err)
+	}
+	if len(r.Changed) != 2 || labeled("cpu") || labeled("mem") {
+		t.Errorf("expected the label to be removed from cpu and mem, got %+v", r)
+	}
+
+	otherLabel := &influxdb.Label{Name: "paging", OrgID: other.ID}
+	if err := svc.CreateLabel(ctx, otherLabel); err != nil {
+		t.Fatalf("failed to create label: %v", err)
+	}
+	if _, err := svc.ApplyLabel(ctx, otherLabel.ID, ops); influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Errorf("expected a label of another org to be invalid, got %v", err)
+	}
+	if _, err := svc.ApplyLabel(ctx, label.ID, influxdb.LabelBulkOperation{
+		ResourceType: influxdb.DashboardsResourceType,
+		Filter:       influxdb.CheckBulkFilter{OrgID: org.ID},
+	}); influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Errorf("expected dashboards to be invalid, got %v", err)
+	}
+}
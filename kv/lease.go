@@ -0,0 +1,135 @@
+package kv
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/influxdata/influxdb"
+)
+
+var leaseBucket = []byte("leasesv1")
+
+var _ influxdb.LeaseService = (*Service)(nil)
+
+func (s *Service) initializeLeases(ctx context.Context, tx Tx) error {
+	if _, err := tx.Bucket(leaseBucket); err != nil {
+		return err
+	}
+	return nil
+}
+
+// UnavailableLeaseStoreError is used if we aren't able to interact with the
+// store, it means the store is not available at the moment (e.g. network).
+func UnavailableLeaseStoreError(err error) *influxdb.Error {
+	return &influxdb.Error{
+		Code: influxdb.EInternal,
+		Msg:  fmt.Sprintf("Unable to connect to lease store service. Please try again; Err: %v", err),
+		Op:   "kv/lease",
+	}
+}
+
+// InternalLeaseStoreError is used when the error comes from an
+// internal system.
+func InternalLeaseStoreError(err error) *influxdb.Error {
+	return &influxdb.Error{
+		Code: influxdb.EInternal,
+		Msg:  fmt.Sprintf("Unknown internal lease data error; Err: %v", err),
+		Op:   "kv/lease",
+	}
+}
+
+// AcquireLease claims key for owner during ttl, renewing the lease if owner
+// already holds it. The leases expire at the time of the service.
+func (s *Service) AcquireLease(ctx context.Context, key, owner string, ttl time.Duration) (*influxdb.Lease, error) {
+	if key == "" || owner == "" || ttl <= 0 {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "a lease requires a key, an owner and a positive ttl",
+		}
+	}
+	var l *influxdb.Lease
+	err := s.kv.Update(ctx, func(tx Tx) (err error) {
+		l, err = s.acquireLease(ctx, tx, key, owner, ttl)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+func (s *Service) acquireLease(ctx context.Context, tx Tx, key, owner string, ttl time.Duration) (*influxdb.Lease, error) {
+	now := s.TimeGenerator.Now()
+	l, err := s.findLease(ctx, tx, key)
+	if err != nil {
+		return nil, err
+	}
+	if l != nil && l.Owner != owner && l.Expires.After(now) {
+		return l, nil
+	}
+
+	l = &influxdb.Lease{
+		Key:     key,
+		Owner:   owner,
+		Expires: now.Add(ttl),
+	}
+	if err := s.putLease(ctx, tx, l); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// ReleaseLease ends the lease of owner on key, if owner holds it.
+func (s *Service) ReleaseLease(ctx context.Context, key, owner string) error {
+	return s.kv.Update(ctx, func(tx Tx) error {
+		l, err := s.findLease(ctx, tx, key)
+		if err != nil || l == nil || l.Owner != owner {
+			return err
+		}
+		b, err := tx.Bucket(leaseBucket)
+		if err != nil {
+			return UnavailableLeaseStoreError(err)
+		}
+		if err := b.Delete([]byte(key)); err != nil {
+			return InternalLeaseStoreError(err)
+		}
+		return nil
+	})
+}
+
+// findLease returns the lease on key, or nil if key was never claimed or released.
+func (s *Service) findLease(ctx context.Context, tx Tx, key string) (*influxdb.Lease, error) {
+	b, err := tx.Bucket(leaseBucket)
+	if err != nil {
+		return nil, UnavailableLeaseStoreError(err)
+	}
+	v, err := b.Get([]byte(key))
+	if IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, InternalLeaseStoreError(err)
+	}
+	l := &influxdb.Lease{}
+	if err := json.Unmarshal(v, l); err != nil {
+		return nil, InternalLeaseStoreError(err)
+	}
+	return l, nil
+}
+
+func (s *Service) putLease(ctx context.Context, tx Tx, l *influxdb.Lease) error {
+	v, err := json.Marshal(l)
+	if err != nil {
+		return InternalLeaseStoreError(err)
+	}
+	b, err := tx.Bucket(leaseBucket)
+	if err != nil {
+		return UnavailableLeaseStoreError(err)
+	}
+	if err := b.Put([]byte(l.Key), v); err != nil {
+		return UnavailableLeaseStoreError(err)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package kv_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
+)
+
+func TestService_Lease(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestService(t)
+	now := time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) {
+		svc.TimeGenerator = mock.TimeGenerator{FakeValue: now.Add(d)}
+	}
+	acquire := func(owner string) *influxdb.Lease {
+		t.Helper()
+		l, err := svc.AcquireLease(ctx, "alerting/checks/1", owner, time.Minute)
+		if err != nil {
+			t.Fatalf("failed to acquire lease: %v", err)
+		}
+		return l
+	}
+
+	at(0)
+	if l := acquire("a"); !l.Held("a", now) || !l.Expires.Equal(now.Add(time.Minute)) {
+		t.Fatalf("expected a to acquire the lease, got %+v", l)
+	}
+	at(30 * time.Second)
+	if l := acquire("b"); l.Owner != "a" {
+		t.Fatalf("expected the lease to stay held by a, got %+v", l)
+	}
+	if l := acquire("a"); !l.Expires.Equal(now.Add(90 * time.Second)) {
+		t.Fatalf("expected a to renew the lease, got %+v", l)
+	}
+
+	at(2 * time.Minute)
+	if l := acquire("b"); l.Owner != "b" {
+		t.Fatalf("expected b to acquire the expired lease, got %+v", l)
+	}
+
+	if err := svc.ReleaseLease(ctx, "alerting/checks/1", "a"); err != nil {
+		t.Fatalf("failed to release lease: %v", err)
+	}
+	if l := acquire("a"); l.Owner != "b" {
+		t.Fatalf("expected a not to release the lease of b, got %+v", l)
+	}
+	if err := svc.ReleaseLease(ctx, "alerting/checks/1", "b"); err != nil {
+		t.Fatalf("failed to release lease: %v", err)
+	}
+	if l := acquire("a"); l.Owner != "a" {
+		t.Fatalf("expected a to acquire the released lease, got %+v", l)
+	}
+
+	if _, err := svc.AcquireLease(ctx, "alerting/checks/1", "", time.Minute); influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Errorf("expected a lease without owner to be invalid, got %v", err)
+	}
+}
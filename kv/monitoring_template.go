@@ -0,0 +1,969 @@
+package kv
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/influxdata/influxdb"
+)
+
+var (
+	monitoringTemplateManifestBucket = []byte("monitoringTemplateManifestsv1")
+	monitoringTemplateManifestIndex  = []byte("monitoringTemplateManifestIndexv1")
+
+	// ErrMonitoringTemplateManifestNotFound is used when the monitoring template is not installed.
+	ErrMonitoringTemplateManifestNotFound = &influxdb.Error{
+		Msg:  "monitoring template manifest not found",
+		Code: influxdb.ENotFound,
+	}
+)
+
+var _ influxdb.MonitoringTemplateService = (*Service)(nil)
+
+func (s *Service) initializeMonitoringTemplates(ctx context.Context, tx Tx) error {
+	if _, err := tx.Bucket(monitoringTemplateManifestBucket); err != nil {
+		return err
+	}
+	if _, err := tx.Bucket(monitoringTemplateManifestIndex); err != nil {
+		return err
+	}
+	return nil
+}
+
+// UnavailableMonitoringTemplateStoreError is used if we aren't able to interact with the
+// store, it means the store is not available at the moment (e.g. network).
+func UnavailableMonitoringTemplateStoreError(err error) *influxdb.Error {
+	return &influxdb.Error{
+		Code: influxdb.EInternal,
+		Msg:  fmt.Sprintf("Unable to connect to monitoring template store service. Please try again; Err: %v", err),
+		Op:   "kv/monitoringTemplate",
+	}
+}
+
+// InternalMonitoringTemplateStoreError is used when the error comes from an
+// internal system.
+func InternalMonitoringTemplateStoreError(err error) *influxdb.Error {
+	return &influxdb.Error{
+		Code: influxdb.EInternal,
+		Msg:  fmt.Sprintf("Unknown internal monitoring template data error; Err: %v", err),
+		Op:   "kv/monitoringTemplate",
+	}
+}
+
+func monitoringTemplateManifestIndexKey(orgID influxdb.ID, name string) ([]byte, error) {
+	encOrgID, err := orgID.Encode()
+	if err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Err:  err,
+		}
+	}
+	return append(encOrgID, []byte(name)...), nil
+}
+
+// FindMonitoringTemplateManifestByID returns the manifest of an installed template.
+func (s *Service) FindMonitoringTemplateManifestByID(ctx context.Context, id influxdb.ID) (*influxdb.MonitoringTemplateManifest, error) {
+	var (
+		m   *influxdb.MonitoringTemplateManifest
+		err error
+	)
+	err = s.kv.View(ctx, func(tx Tx) error {
+		m, err = s.findMonitoringTemplateManifestByID(ctx, tx, id)
+		return err
+	})
+	return m, err
+}
+
+func (s *Service) findMonitoringTemplateManifestByID(ctx context.Context, tx Tx, id influxdb.ID) (*influxdb.MonitoringTemplateManifest, error) {
+	encID, err := id.Encode()
+	if err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Err:  err,
+		}
+	}
+
+	bucket, err := tx.Bucket(monitoringTemplateManifestBucket)
+	if err != nil {
+		return nil, UnavailableMonitoringTemplateStoreError(err)
+	}
+
+	v, err := bucket.Get(encID)
+	if IsNotFound(err) {
+		return nil, ErrMonitoringTemplateManifestNotFound
+	}
+	if err != nil {
+		return nil, InternalMonitoringTemplateStoreError(err)
+	}
+
+	m := &influxdb.MonitoringTemplateManifest{}
+	if err := json.Unmarshal(v, m); err != nil {
+		return nil, InternalMonitoringTemplateStoreError(err)
+	}
+	return m, nil
+}
+
+func (s *Service) findMonitoringTemplateManifestByName(ctx context.Context, tx Tx, orgID influxdb.ID, name string) (*influxdb.MonitoringTemplateManifest, error) {
+	key, err := monitoringTemplateManifestIndexKey(orgID, name)
+	if err != nil {
+		return nil, err
+	}
+
+	idx, err := tx.Bucket(monitoringTemplateManifestIndex)
+	if err != nil {
+		return nil, UnavailableMonitoringTemplateStoreError(err)
+	}
+
+	v, err := idx.Get(key)
+	if IsNotFound(err) {
+		return nil, ErrMonitoringTemplateManifestNotFound
+	}
+	if err != nil {
+		return nil, InternalMonitoringTemplateStoreError(err)
+	}
+
+	var id influxdb.ID
+	if err := id.Decode(v); err != nil {
+		return nil, InternalMonitoringTemplateStoreError(err)
+	}
+	return s.findMonitoringTemplateManifestByID(ctx, tx, id)
+}
+
+// FindMonitoringTemplateManifests returns the manifests of the templates installed in an organization.
+func (s *Service) FindMonitoringTemplateManifests(ctx context.Context, orgID influxdb.ID) ([]*influxdb.MonitoringTemplateManifest, error) {
+	var (
+		ms  []*influxdb.MonitoringTemplateManifest
+		err error
+	)
+	err = s.kv.View(ctx, func(tx Tx) error {
+		ms, err = s.findMonitoringTemplateManifests(ctx, tx, orgID)
+		return err
+	})
+	return ms, err
+}
+
+func (s *Service) findMonitoringTemplateManifests(ctx context.Context, tx Tx, orgID influxdb.ID) ([]*influxdb.MonitoringTemplateManifest, error) {
+	bucket, err := tx.Bucket(monitoringTemplateManifestBucket)
+	if err != nil {
+		return nil, UnavailableMonitoringTemplateStoreError(err)
+	}
+
+	cur, err := bucket.Cursor()
+	if err != nil {
+		return nil, UnavailableMonitoringTemplateStoreError(err)
+	}
+
+	ms := []*influxdb.MonitoringTemplateManifest{}
+	for k, v := cur.First(); k != nil; k, v = cur.Next() {
+		m := &influxdb.MonitoringTemplateManifest{}
+		if err := json.Unmarshal(v, m); err != nil {
+			return nil, InternalMonitoringTemplateStoreError(err)
+		}
+		if m.OrgID == orgID {
+			ms = append(ms, m)
+		}
+	}
+	return ms, nil
+}
+
+func (s *Service) putMonitoringTemplateManifest(ctx context.Context, tx Tx, m *influxdb.MonitoringTemplateManifest) error {
+	encID, err := m.ID.Encode()
+	if err != nil {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Err:  err,
+		}
+	}
+	key, err := monitoringTemplateManifestIndexKey(m.OrgID, m.Name)
+	if err != nil {
+		return err
+	}
+
+	v, err := json.Marshal(m)
+	if err != nil {
+		return InternalMonitoringTemplateStoreError(err)
+	}
+
+	bucket, err := tx.Bucket(monitoringTemplateManifestBucket)
+	if err != nil {
+		return UnavailableMonitoringTemplateStoreError(err)
+	}
+	if err := bucket.Put(encID, v); err != nil {
+		return UnavailableMonitoringTemplateStoreError(err)
+	}
+
+	idx, err := tx.Bucket(monitoringTemplateManifestIndex)
+	if err != nil {
+		return UnavailableMonitoringTemplateStoreError(err)
+	}
+	if err := idx.Put(key, encID); err != nil {
+		return UnavailableMonitoringTemplateStoreError(err)
+	}
+	return nil
+}
+
+func (s *Service) deleteMonitoringTemplateManifest(ctx context.Context, tx Tx, m *influxdb.MonitoringTemplateManifest) error {
+	encID, err := m.ID.Encode()
+	if err != nil {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Err:  err,
+		}
+	}
+	key, err := monitoringTemplateManifestIndexKey(m.OrgID, m.Name)
+	if err != nil {
+		return err
+	}
+
+	bucket, err := tx.Bucket(monitoringTemplateManifestBucket)
+	if err != nil {
+		return UnavailableMonitoringTemplateStoreError(err)
+	}
+	if err := bucket.Delete(encID); err != nil {
+		return UnavailableMonitoringTemplateStoreError(err)
+	}
+
+	idx, err := tx.Bucket(monitoringTemplateManifestIndex)
+	if err != nil {
+		return UnavailableMonitoringTemplateStoreError(err)
+	}
+	if err := idx.Delete(key); err != nil {
+		return UnavailableMonitoringTemplateStoreError(err)
+	}
+	return nil
+}
+
+// InstallMonitoringTemplate creates the resources of a template in an organization,
+// owned by userID, once its required templates are installed.
+func (s *Service) InstallMonitoringTemplate(ctx context.Context, orgID influxdb.ID, install influxdb.MonitoringTemplateInstall, userID influxdb.ID) (*influxdb.MonitoringTemplateManifest, error) {
+	var (
+		m   *influxdb.MonitoringTemplateManifest
+		err error
+	)
+	err = s.kv.Update(ctx, func(tx Tx) error {
+		m, err = s.installMonitoringTemplate(ctx, tx, orgID, install, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (s *Service) installMonitoringTemplate(ctx context.Context, tx Tx, orgID influxdb.ID, install influxdb.MonitoringTemplateInstall, userID influxdb.ID) (*influxdb.MonitoringTemplateManifest, error) {
+	if err := validMonitoringTemplateInstall(install); err != nil {
+		return nil, err
+	}
+	o, err := s.findOrganizationByID(ctx, tx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.notInstalledMonitoringTemplate(ctx, tx, orgID, install.Template.Name); err != nil {
+		return nil, err
+	}
+
+	now := s.TimeGenerator.Now()
+	m := &influxdb.MonitoringTemplateManifest{
+		ID:               s.IDGenerator.ID(),
+		OrgID:            orgID,
+		Name:             install.Template.Name,
+		Resources:        []influxdb.MonitoringTemplateResource{},
+		DeterministicIDs: install.DeterministicIDs,
+		CRUDLog:          influxdb.CRUDLog{CreatedAt: now},
+	}
+	if m.DeterministicIDs {
+		m.ID = influxdb.NameID(o.Name, m.Name)
+	}
+	if err := s.applyMonitoringTemplate(ctx, tx, m, install, userID); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// notInstalledMonitoringTemplate returns a conflict if the template named name is installed in the organization.
+func (s *Service) notInstalledMonitoringTemplate(ctx context.Context, tx Tx, orgID influxdb.ID, name string) error {
+	_, err := s.findMonitoringTemplateManifestByName(ctx, tx, orgID, name)
+	if err == nil {
+		return &influxdb.Error{
+			Code: influxdb.EConflict,
+			Msg:  fmt.Sprintf("monitoring template %q is already installed", name),
+		}
+	}
+	if influxdb.ErrorCode(err) != influxdb.ENotFound {
+		return err
+	}
+	return nil
+}
+
+// PreviewMonitoringTemplate returns the resources the install of a template
+// in an organization would create or reuse, without installing it.
+func (s *Service) PreviewMonitoringTemplate(ctx context.Context, orgID influxdb.ID, install influxdb.MonitoringTemplateInstall) ([]influxdb.MonitoringTemplateResource, error) {
+	var (
+		rs  []influxdb.MonitoringTemplateResource
+		err error
+	)
+	err = s.kv.View(ctx, func(tx Tx) error {
+		rs, err = s.previewMonitoringTemplate(ctx, tx, orgID, install)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rs, nil
+}
+
+func (s *Service) previewMonitoringTemplate(ctx context.Context, tx Tx, orgID influxdb.ID, install influxdb.MonitoringTemplateInstall) ([]influxdb.MonitoringTemplateResource, error) {
+	if err := validMonitoringTemplateInstall(install); err != nil {
+		return nil, err
+	}
+	if _, err := s.findOrganizationByID(ctx, tx, orgID); err != nil {
+		return nil, err
+	}
+	if err := s.notInstalledMonitoringTemplate(ctx, tx, orgID, install.Template.Name); err != nil {
+		return nil, err
+	}
+
+	t := install.Template
+	m := &influxdb.MonitoringTemplateManifest{OrgID: orgID, Name: t.Name, DeterministicIDs: install.DeterministicIDs}
+	p, err := s.planMonitoringTemplate(ctx, tx, m, install)
+	if err != nil {
+		return nil, err
+	}
+
+	// the resources to create have an ID only if it is derived from their name.
+	created := func(rt influxdb.ResourceType, name string) influxdb.MonitoringTemplateResource {
+		r := influxdb.MonitoringTemplateResource{Type: rt, Name: name, Created: true}
+		if m.DeterministicIDs {
+			r.ID = s.newMonitoringTemplateID(p, m, rt, name)
+		}
+		return r
+	}
+	rs := []influxdb.MonitoringTemplateResource{}
+	for _, tl := range t.Labels {
+		r := created(influxdb.LabelsResourceType, tl.Name)
+		if l, ok := p.labels[tl.Name]; ok {
+			r.ID = l.ID
+			r.Created = false
+		}
+		rs = append(rs, r)
+	}
+	for _, edp := range t.Endpoints {
+		rs = append(rs, created(influxdb.NotificationEndpointResourceType, edp.GetName()))
+	}
+	for _, c := range t.Checks {
+		rs = append(rs, created(influxdb.ChecksResourceType, c.GetName()))
+	}
+	for _, r := range t.Rules {
+		rs = append(rs, created(influxdb.NotificationRuleResourceType, r.Rule.GetName()))
+	}
+	for _, name := range t.Dashboards {
+		rs = append(rs, influxdb.MonitoringTemplateResource{Type: influxdb.DashboardsResourceType, ID: p.dashboards[name].ID, Name: name})
+	}
+	return rs, nil
+}
+
+// UpgradeMonitoringTemplate upgrades an installed template to a greater version,
+// updating the resources of the same name, creating the new ones and deleting the others.
+func (s *Service) UpgradeMonitoringTemplate(ctx context.Context, id influxdb.ID, install influxdb.MonitoringTemplateInstall, userID influxdb.ID) (*influxdb.MonitoringTemplateManifest, error) {
+	var (
+		m   *influxdb.MonitoringTemplateManifest
+		err error
+	)
+	err = s.kv.Update(ctx, func(tx Tx) error {
+		m, err = s.upgradeMonitoringTemplate(ctx, tx, id, install, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (s *Service) upgradeMonitoringTemplate(ctx context.Context, tx Tx, id influxdb.ID, install influxdb.MonitoringTemplateInstall, userID influxdb.ID) (*influxdb.MonitoringTemplateManifest, error) {
+	if err := validMonitoringTemplateInstall(install); err != nil {
+		return nil, err
+	}
+	m, err := s.findMonitoringTemplateManifestByID(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	t := install.Template
+	if t.Name != m.Name {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("monitoring template %q can't be upgraded to the template %q", m.Name, t.Name),
+		}
+	}
+	if influxdb.CompareMonitoringTemplateVersions(t.Version, m.Version) <= 0 {
+		return nil, &influxdb.Error{
+			Code: influxdb.EConflict,
+			Msg:  fmt.Sprintf("monitoring template %q %s is installed, it can only be upgraded to a greater version", m.Name, m.Version),
+		}
+	}
+	if err := s.applyMonitoringTemplate(ctx, tx, m, install, userID); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// UninstallMonitoringTemplate deletes the resources created by the install of
+// a template, unless another installed template requires it.
+func (s *Service) UninstallMonitoringTemplate(ctx context.Context, id influxdb.ID) error {
+	return s.kv.Update(ctx, func(tx Tx) error {
+		return s.uninstallMonitoringTemplate(ctx, tx, id)
+	})
+}
+
+func (s *Service) uninstallMonitoringTemplate(ctx context.Context, tx Tx, id influxdb.ID) error {
+	m, err := s.findMonitoringTemplateManifestByID(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+
+	ms, err := s.findMonitoringTemplateManifests(ctx, tx, m.OrgID)
+	if err != nil {
+		return err
+	}
+	for _, other := range ms {
+		for _, r := range other.Requires {
+			if r.Name == m.Name {
+				return &influxdb.Error{
+					Code: influxdb.EConflict,
+					Msg:  fmt.Sprintf("monitoring template %q is required by the installed template %q", m.Name, other.Name),
+				}
+			}
+		}
+	}
+
+	if err := s.deleteMonitoringTemplateResources(ctx, tx, m, m.Resources); err != nil {
+		return err
+	}
+	for _, k := range m.SecretKeys {
+		if err := s.deleteSecret(ctx, tx, m.OrgID, k); err != nil {
+			return err
+		}
+	}
+	return s.deleteMonitoringTemplateManifest(ctx, tx, m)
+}
+
+func validMonitoringTemplateInstall(install influxdb.MonitoringTemplateInstall) error {
+	if install.Template == nil {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "monitoring template is required",
+		}
+	}
+	return install.Template.Valid()
+}
+
+// monitoringTemplatePlan is what the install of a template does with the
+// resources of the organization, found before any of them is written since
+// the stores without transactions can't roll back a partial install.
+type monitoringTemplatePlan struct {
+	// current are the resources of the manifest, by type and name.
+	current map[influxdb.ResourceType]map[string]influxdb.MonitoringTemplateResource
+	// labels are the labels of the organization reused by the template, by name.
+	labels map[string]*influxdb.Label
+	// dashboards are the dashboards the template refers to, by name.
+	dashboards map[string]*influxdb.Dashboard
+	// endpoints are the endpoints of the organization the rules send to, by name.
+	endpoints map[string]influxdb.ID
+	// orgName is the name of the organization, the deterministic IDs derive from it.
+	orgName string
+}
+
+func (p *monitoringTemplatePlan) currentID(rt influxdb.ResourceType, name string) (influxdb.ID, bool) {
+	r, ok := p.current[rt][name]
+	return r.ID, ok
+}
+
+// newMonitoringTemplateID returns the ID of a resource created by the template of the manifest,
+// derived from the names of the organization, the template and the resource if the manifest
+// has deterministic IDs, so that the install in a fresh instance creates the same IDs.
+func (s *Service) newMonitoringTemplateID(p *monitoringTemplatePlan, m *influxdb.MonitoringTemplateManifest, rt influxdb.ResourceType, name string) influxdb.ID {
+	if !m.DeterministicIDs {
+		return s.IDGenerator.ID()
+	}
+	return influxdb.NameID(p.orgName, m.Name, string(rt), name)
+}
+
+func (s *Service) planMonitoringTemplate(ctx context.Context, tx Tx, m *influxdb.MonitoringTemplateManifest, install influxdb.MonitoringTemplateInstall) (*monitoringTemplatePlan, error) {
+	t := install.Template
+	p := &monitoringTemplatePlan{
+		current:    map[influxdb.ResourceType]map[string]influxdb.MonitoringTemplateResource{},
+		labels:     map[string]*influxdb.Label{},
+		dashboards: map[string]*influxdb.Dashboard{},
+		endpoints:  map[string]influxdb.ID{},
+	}
+	o, err := s.findOrganizationByID(ctx, tx, m.OrgID)
+	if err != nil {
+		return nil, err
+	}
+	p.orgName = o.Name
+	for _, r := range m.Resources {
+		if p.current[r.Type] == nil {
+			p.current[r.Type] = map[string]influxdb.MonitoringTemplateResource{}
+		}
+		p.current[r.Type][r.Name] = r
+	}
+
+	if err := s.requiredMonitoringTemplates(ctx, tx, m.OrgID, t); err != nil {
+		return nil, err
+	}
+
+	placeholders := map[string]bool{}
+	for _, k := range t.SecretKeys() {
+		placeholders[k] = true
+		if _, ok := install.Secrets[k]; ok {
+			continue
+		}
+		if _, err := s.loadSecret(ctx, tx, m.OrgID, k); err != nil {
+			if influxdb.ErrorCode(err) == influxdb.ENotFound {
+				return nil, &influxdb.Error{
+					Code: influxdb.EInvalid,
+					Msg:  fmt.Sprintf("the value of the secret %q of the monitoring template is missing", k),
+				}
+			}
+			return nil, err
+		}
+	}
+	for k := range install.Secrets {
+		if !placeholders[k] {
+			return nil, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  fmt.Sprintf("secret %q is not a placeholder of the monitoring template", k),
+			}
+		}
+	}
+
+	if len(t.Dashboards) > 0 {
+		ds, err := s.findOrganizationDashboards(ctx, tx, m.OrgID)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range ds {
+			p.dashboards[d.Name] = d
+		}
+		for _, name := range t.Dashboards {
+			if _, ok := p.dashboards[name]; !ok {
+				return nil, &influxdb.Error{
+					Code: influxdb.ENotFound,
+					Msg:  fmt.Sprintf("dashboard %q the monitoring template refers to is not found", name),
+				}
+			}
+		}
+	}
+
+	if len(t.Labels) > 0 {
+		ls, err := s.findLabels(ctx, tx, influxdb.LabelFilter{OrgID: &m.OrgID})
+		if err != nil {
+			return nil, err
+		}
+		for _, l := range ls {
+			p.labels[l.Name] = l
+		}
+	}
+
+	templateEndpoints := map[string]bool{}
+	for _, edp := range t.Endpoints {
+		templateEndpoints[edp.GetName()] = true
+		if err := s.notificationEndpointAllowed(edp); err != nil {
+			return nil, err
+		}
+		status := influxdb.Active
+		if id, ok := p.currentID(influxdb.NotificationEndpointResourceType, edp.GetName()); ok {
+			current, err := s.findNotificationEndpointByID(ctx, tx, id)
+			if err != nil {
+				return nil, err
+			}
+			status = current.GetStatus()
+		}
+		if err := validMonitoringTemplateResource(edp, m.OrgID, s.IDGenerator.ID(), status); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, c := range t.Checks {
+		status := influxdb.Active
+		if id, ok := p.currentID(influxdb.ChecksResourceType, c.GetName()); ok {
+			current, err := s.findCheckByID(ctx, tx, id)
+			if err != nil {
+				return nil, err
+			}
+			status = current.GetStatus()
+		} else {
+			if err := s.Config.CheckNamePolicy.ValidateName(c.GetName()); err != nil {
+				return nil, err
+			}
+			if _, err := s.availableCheckName(ctx, tx, m.OrgID, c.GetName(), false); err != nil {
+				return nil, err
+			}
+		}
+		if err := validMonitoringTemplateResource(c, m.OrgID, s.IDGenerator.ID(), status); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.forEachNotificationEndpoint(ctx, tx, false, func(edp influxdb.NotificationEndpoint) bool {
+		if edp.GetOrgID() == m.OrgID {
+			p.endpoints[edp.GetName()] = edp.GetID()
+		}
+		return true
+	}); err != nil {
+		return nil, err
+	}
+	for _, r := range t.Rules {
+		if r.EndpointName != "" && !templateEndpoints[r.EndpointName] {
+			id, ok := p.endpoints[r.EndpointName]
+			if !ok {
+				return nil, &influxdb.Error{
+					Code: influxdb.EInvalid,
+					Msg:  fmt.Sprintf("notification endpoint %q of the rule %q of the monitoring template is not found", r.EndpointName, r.Rule.GetName()),
+				}
+			}
+			setMonitoringTemplateRuleEndpoint(r.Rule, id)
+		} else if r.EndpointName != "" {
+			setMonitoringTemplateRuleEndpoint(r.Rule, s.IDGenerator.ID())
+		}
+		if ar, ok := r.Rule.(authorizedNotificationRule); ok && !ar.GetAuthorizationID().Valid() {
+			ar.SetAuthorizationID(install.AuthorizationID)
+		}
+		status := influxdb.Active
+		if id, ok := p.currentID(influxdb.NotificationRuleResourceType, r.Rule.GetName()); ok {
+			current, err := s.findNotificationRuleByID(ctx, tx, id)
+			if err != nil {
+				return nil, err
+			}
+			status = current.GetStatus()
+		}
+		if err := validMonitoringTemplateResource(r.Rule, m.OrgID, s.IDGenerator.ID(), status); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// requiredMonitoringTemplates returns an error if a template required by t
+// is not installed in the organization, or at a lower version.
+func (s *Service) requiredMonitoringTemplates(ctx context.Context, tx Tx, orgID influxdb.ID, t *influxdb.MonitoringTemplate) error {
+	for _, r := range t.Requires {
+		required, err := s.findMonitoringTemplateManifestByName(ctx, tx, orgID, r.Name)
+		if influxdb.ErrorCode(err) == influxdb.ENotFound {
+			return &influxdb.Error{
+				Code: influxdb.EConflict,
+				Msg:  fmt.Sprintf("monitoring template %q requires the template %q to be installed first", t.Name, r.Name),
+			}
+		}
+		if err != nil {
+			return err
+		}
+		if r.Version != "" && influxdb.CompareMonitoringTemplateVersions(required.Version, r.Version) < 0 {
+			return &influxdb.Error{
+				Code: influxdb.EConflict,
+				Msg:  fmt.Sprintf("monitoring template %q requires the template %q %s or greater, %s is installed", t.Name, r.Name, r.Version, required.Version),
+			}
+		}
+	}
+	return nil
+}
+
+// monitoringTemplateResource is a check, a notification endpoint or a notification rule.
+type monitoringTemplateResource interface {
+	Valid() error
+	influxdb.Updator
+	influxdb.Getter
+}
+
+// validMonitoringTemplateResource validates a resource of a template as if it was
+// written to the organization with the id, with the status if the template has none.
+func validMonitoringTemplateResource(r monitoringTemplateResource, orgID, id influxdb.ID, status influxdb.Status) error {
+	r.SetID(id)
+	r.SetOrgID(orgID)
+	if r.GetStatus() == "" {
+		r.SetStatus(status)
+	}
+	return r.Valid()
+}
+
+type authorizedNotificationRule interface {
+	GetAuthorizationID() influxdb.ID
+	SetAuthorizationID(influxdb.ID)
+}
+
+func setMonitoringTemplateRuleEndpoint(nr influxdb.NotificationRule, id influxdb.ID) {
+	if rr, ok := nr.(transferredNotificationRule); ok {
+		rr.SetEndpointID(&id)
+	}
+}
+
+// applyMonitoringTemplate writes the resources of a template to the organization of the
+// manifest: the resources of the manifest with the name of a resource of the template are
+// updated, the others are deleted, and the new resources of the template are created.
+func (s *Service) applyMonitoringTemplate(ctx context.Context, tx Tx, m *influxdb.MonitoringTemplateManifest, install influxdb.MonitoringTemplateInstall, userID influxdb.ID) error {
+	t := install.Template
+	p, err := s.planMonitoringTemplate(ctx, tx, m, install)
+	if err != nil {
+		return err
+	}
+
+	// the resources dropped by the template are deleted first, the rules before
+	// the endpoints they send to.
+	names := map[influxdb.ResourceType]map[string]bool{
+		influxdb.LabelsResourceType:               {},
+		influxdb.ChecksResourceType:               {},
+		influxdb.NotificationEndpointResourceType: {},
+		influxdb.NotificationRuleResourceType:     {},
+		influxdb.DashboardsResourceType:           {},
+	}
+	for _, l := range t.Labels {
+		names[influxdb.LabelsResourceType][l.Name] = true
+	}
+	for _, c := range t.Checks {
+		names[influxdb.ChecksResourceType][c.GetName()] = true
+	}
+	for _, edp := range t.Endpoints {
+		names[influxdb.NotificationEndpointResourceType][edp.GetName()] = true
+	}
+	for _, r := range t.Rules {
+		names[influxdb.NotificationRuleResourceType][r.Rule.GetName()] = true
+	}
+	for _, name := range t.Dashboards {
+		names[influxdb.DashboardsResourceType][name] = true
+	}
+	var dropped []influxdb.MonitoringTemplateResource
+	for _, r := range m.Resources {
+		if !names[r.Type][r.Name] {
+			dropped = append(dropped, r)
+		}
+	}
+	if err := s.deleteMonitoringTemplateResources(ctx, tx, m, dropped); err != nil {
+		return err
+	}
+
+	secretKeys := map[string]bool{}
+	for _, k := range m.SecretKeys {
+		secretKeys[k] = true
+	}
+	for k, v := range install.Secrets {
+		if err := s.putSecret(ctx, tx, m.OrgID, k, v); err != nil {
+			return err
+		}
+		if !secretKeys[k] {
+			secretKeys[k] = true
+			m.SecretKeys = append(m.SecretKeys, k)
+		}
+	}
+
+	rs := []influxdb.MonitoringTemplateResource{}
+	var labels []influxdb.MonitoringTemplateResource
+	for _, tl := range t.Labels {
+		r := influxdb.MonitoringTemplateResource{Type: influxdb.LabelsResourceType, Name: tl.Name}
+		if current, ok := p.current[influxdb.LabelsResourceType][tl.Name]; ok {
+			r = current
+		} else if l, ok := p.labels[tl.Name]; ok {
+			r.ID = l.ID
+		} else {
+			l := &influxdb.Label{
+				ID:         s.newMonitoringTemplateID(p, m, influxdb.LabelsResourceType, tl.Name),
+				OrgID:      m.OrgID,
+				Name:       tl.Name,
+				Properties: tl.Properties,
+			}
+			if err := s.putLabel(ctx, tx, l); err != nil {
+				return err
+			}
+			if err := s.createLabelUserResourceMappings(ctx, tx, l); err != nil {
+				return err
+			}
+			r.ID = l.ID
+			r.Created = true
+		}
+		labels = append(labels, r)
+		rs = append(rs, r)
+	}
+
+	for _, edp := range t.Endpoints {
+		r := influxdb.MonitoringTemplateResource{Type: influxdb.NotificationEndpointResourceType, Name: edp.GetName(), Created: true}
+		if id, ok := p.currentID(influxdb.NotificationEndpointResourceType, edp.GetName()); ok {
+			if err := s.keepOrganizationSecrets(ctx, tx, m, id, func() error {
+				_, err := s.updateNotificationEndpoint(ctx, tx, id, edp, userID)
+				return err
+			}); err != nil {
+				return err
+			}
+		} else {
+			edp.SetOrgID(m.OrgID)
+			if err := s.createNotificationEndpoint(ctx, tx, edp, s.newMonitoringTemplateID(p, m, influxdb.NotificationEndpointResourceType, edp.GetName()), userID); err != nil {
+				return err
+			}
+		}
+		r.ID = edp.GetID()
+		p.endpoints[edp.GetName()] = edp.GetID()
+		rs = append(rs, r)
+	}
+
+	for _, c := range t.Checks {
+		r := influxdb.MonitoringTemplateResource{Type: influxdb.ChecksResourceType, Name: c.GetName(), Created: true}
+		if id, ok := p.currentID(influxdb.ChecksResourceType, c.GetName()); ok {
+			if _, err := s.updateCheck(ctx, tx, id, c); err != nil {
+				return err
+			}
+		} else {
+			c.SetOrgID(m.OrgID)
+			if err := s.createCheck(ctx, tx, c, s.newMonitoringTemplateID(p, m, influxdb.ChecksResourceType, c.GetName()), userID); err != nil {
+				return err
+			}
+		}
+		r.ID = c.GetID()
+		rs = append(rs, r)
+	}
+
+	for _, tr := range t.Rules {
+		nr := tr.Rule
+		if tr.EndpointName != "" {
+			setMonitoringTemplateRuleEndpoint(nr, p.endpoints[tr.EndpointName])
+		}
+		r := influxdb.MonitoringTemplateResource{Type: influxdb.NotificationRuleResourceType, Name: nr.GetName(), Created: true}
+		if id, ok := p.currentID(influxdb.NotificationRuleResourceType, nr.GetName()); ok {
+			if _, err := s.updateNotificationRule(ctx, tx, id, nr, userID); err != nil {
+				return err
+			}
+		} else {
+			nr.SetOrgID(m.OrgID)
+			if err := s.createNotificationRule(ctx, tx, nr, s.newMonitoringTemplateID(p, m, influxdb.NotificationRuleResourceType, nr.GetName()), userID); err != nil {
+				return err
+			}
+		}
+		r.ID = nr.GetID()
+		rs = append(rs, r)
+	}
+
+	for _, r := range rs {
+		if r.Type == influxdb.LabelsResourceType {
+			continue
+		}
+		for _, l := range labels {
+			if err := s.createLabelMapping(ctx, tx, &influxdb.LabelMapping{
+				LabelID:      l.ID,
+				ResourceID:   r.ID,
+				ResourceType: r.Type,
+			}); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, name := range t.Dashboards {
+		rs = append(rs, influxdb.MonitoringTemplateResource{
+			Type: influxdb.DashboardsResourceType,
+			ID:   p.dashboards[name].ID,
+			Name: name,
+		})
+	}
+
+	m.Version = t.Version
+	m.Description = t.Description
+	m.Requires = t.Requires
+	m.Resources = rs
+	m.UpdatedAt = s.TimeGenerator.Now()
+	return s.putMonitoringTemplateManifest(ctx, tx, m)
+}
+
+// deleteMonitoringTemplateResources deletes the resources created by the install
+// of a template, the rules before the endpoints they send to, once the labels of
+// the template are unset from the deleted resources and the deleted labels unset
+// from the resources of the template.
+func (s *Service) deleteMonitoringTemplateResources(ctx context.Context, tx Tx, m *influxdb.MonitoringTemplateManifest, rs []influxdb.MonitoringTemplateResource) error {
+	for _, r := range rs {
+		if r.Type == influxdb.DashboardsResourceType {
+			continue
+		}
+		for _, other := range m.Resources {
+			var lm *influxdb.LabelMapping
+			switch {
+			case r.Type == influxdb.LabelsResourceType && other.Type != influxdb.LabelsResourceType && other.Type != influxdb.DashboardsResourceType:
+				lm = &influxdb.LabelMapping{LabelID: r.ID, ResourceID: other.ID, ResourceType: other.Type}
+			case r.Type != influxdb.LabelsResourceType && other.Type == influxdb.LabelsResourceType && r.Created:
+				lm = &influxdb.LabelMapping{LabelID: other.ID, ResourceID: r.ID, ResourceType: r.Type}
+			default:
+				continue
+			}
+			if err := s.deleteLabelMapping(ctx, tx, lm); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, rt := range []influxdb.ResourceType{
+		influxdb.NotificationRuleResourceType,
+		influxdb.ChecksResourceType,
+		influxdb.NotificationEndpointResourceType,
+		influxdb.LabelsResourceType,
+	} {
+		for _, r := range rs {
+			if r.Type != rt || !r.Created {
+				continue
+			}
+			var err error
+			switch rt {
+			case influxdb.NotificationRuleResourceType:
+				err = s.deleteNotificationRule(ctx, tx, r.ID)
+			case influxdb.ChecksResourceType:
+				err = s.deleteCheck(ctx, tx, r.ID)
+			case influxdb.NotificationEndpointResourceType:
+				err = s.keepOrganizationSecrets(ctx, tx, m, r.ID, func() error {
+					return s.deleteNotificationEndpoint(ctx, tx, r.ID)
+				})
+			case influxdb.LabelsResourceType:
+				err = s.deleteLabel(ctx, tx, r.ID)
+			}
+			// the resources deleted since the install are skipped.
+			if err != nil && influxdb.ErrorCode(err) != influxdb.ENotFound {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// keepOrganizationSecrets restores the secrets of the organization an endpoint of a template
+// refers to once fn replaced or deleted the endpoint, which deletes the secrets of the endpoint,
+// only the secrets stored by the install of the template can be deleted with it.
+func (s *Service) keepOrganizationSecrets(ctx context.Context, tx Tx, m *influxdb.MonitoringTemplateManifest, endpointID influxdb.ID, fn func() error) error {
+	edp, err := s.findNotificationEndpointByID(ctx, tx, endpointID)
+	if err != nil {
+		return err
+	}
+
+	installed := map[string]bool{}
+	for _, k := range m.SecretKeys {
+		installed[k] = true
+	}
+	kept := map[string]string{}
+	for _, fld := range edp.SecretFields() {
+		if fld.Key == "" || installed[fld.Key] {
+			continue
+		}
+		v, err := s.loadSecret(ctx, tx, m.OrgID, fld.Key)
+		if influxdb.ErrorCode(err) == influxdb.ENotFound {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		kept[fld.Key] = v
+	}
+
+	if err := fn(); err != nil {
+		return err
+	}
+	for k, v := range kept {
+		if err := s.putSecret(ctx, tx, m.OrgID, k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
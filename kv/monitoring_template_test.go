@@ -0,0 +1,251 @@
+package kv_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification/check"
+	"github.com/influxdata/influxdb/notification/endpoint"
+	"github.com/influxdata/influxdb/notification/rule"
+)
+
+func TestService_MonitoringTemplates(t *testing.T) {
+	ctx := context.Background()
+	svc, user, org := newTestServiceWithOrg(t)
+	if err := svc.PutSecret(ctx, org.ID, "pager-key", "shared"); err != nil {
+		t.Fatalf("failed to put secret: %v", err)
+	}
+
+	// the checks of the templates have no status, an upgrade keeps the
+	// status of the installed checks.
+	newTemplateDeadman := func(name string, timeSince int) *check.Deadman {
+		c := newDeadman(0, name)
+		c.Status = ""
+		c.TimeSince = timeSince
+		return c
+	}
+	newSlack := func(name, tokenKey string) *endpoint.Slack {
+		return &endpoint.Slack{
+			Base:  endpoint.Base{Name: name},
+			URL:   "https://hooks.slack.com/services/1",
+			Token: influxdb.SecretField{Key: tokenKey},
+		}
+	}
+	newRule := func(name, endpointName string) influxdb.MonitoringTemplateRule {
+		return influxdb.MonitoringTemplateRule{
+			Rule: &rule.Slack{
+				Base: rule.Base{
+					Name:  name,
+					Every: influxdb.Duration{Duration: time.Minute},
+				},
+				MessageTemplate: "${r._check_name} is ${r._level}",
+			},
+			EndpointName: endpointName,
+		}
+	}
+	hostV1 := func() *influxdb.MonitoringTemplate {
+		return &influxdb.MonitoringTemplate{
+			Name:      "host",
+			Version:   "1.0.0",
+			Labels:    []influxdb.MonitoringTemplateLabel{{Name: "host"}},
+			Checks:    []influxdb.Check{newTemplateDeadman("heartbeat", 60)},
+			Endpoints: []influxdb.NotificationEndpoint{newSlack("oncall", "oncall-token"), newSlack("pager", "pager-key")},
+			Rules:     []influxdb.MonitoringTemplateRule{newRule("page oncall", "oncall")},
+		}
+	}
+	db := &influxdb.MonitoringTemplate{
+		Name:     "db",
+		Version:  "1.0",
+		Requires: []influxdb.MonitoringTemplateRequirement{{Name: "host", Version: "1"}},
+		Checks:   []influxdb.Check{newTemplateDeadman("db heartbeat", 60)},
+	}
+	authID := influxdb.ID(100)
+
+	if _, err := svc.InstallMonitoringTemplate(ctx, org.ID, influxdb.MonitoringTemplateInstall{Template: db}, user.ID); influxdb.ErrorCode(err) != influxdb.EConflict {
+		t.Errorf("expected the template to require the host template, got %v", err)
+	}
+	_, err := svc.InstallMonitoringTemplate(ctx, org.ID, influxdb.MonitoringTemplateInstall{Template: hostV1(), AuthorizationID: authID}, user.ID)
+	if influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Errorf("expected the secret placeholder without a value to be rejected, got %v", err)
+	}
+	heartbeat := "heartbeat"
+	if _, err := svc.FindCheck(ctx, influxdb.CheckFilter{OrgID: &org.ID, Name: &heartbeat}); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Errorf("expected the failed install to create no check, got %v", err)
+	}
+	preview, err := svc.PreviewMonitoringTemplate(ctx, org.ID, influxdb.MonitoringTemplateInstall{
+		Template:        hostV1(),
+		Secrets:         map[string]string{"oncall-token": "xoxb"},
+		AuthorizationID: authID,
+	})
+	if err != nil {
+		t.Fatalf("failed to preview monitoring template: %v", err)
+	}
+	if len(preview) != 5 || preview[0].Type != influxdb.LabelsResourceType || !preview[0].Created || preview[0].ID.Valid() {
+		t.Errorf("unexpected preview %+v", preview)
+	}
+	if ms, err := svc.FindMonitoringTemplateManifests(ctx, org.ID); err != nil || len(ms) != 0 {
+		t.Errorf("expected the preview to install nothing, got %v, %v", ms, err)
+	}
+
+	host, err := svc.InstallMonitoringTemplate(ctx, org.ID, influxdb.MonitoringTemplateInstall{
+		Template:        hostV1(),
+		Secrets:         map[string]string{"oncall-token": "xoxb"},
+		AuthorizationID: authID,
+	}, user.ID)
+	if err != nil {
+		t.Fatalf("failed to install monitoring template: %v", err)
+	}
+	resources := map[influxdb.ResourceType]map[string]influxdb.ID{}
+	for _, r := range host.Resources {
+		if resources[r.Type] == nil {
+			resources[r.Type] = map[string]influxdb.ID{}
+		}
+		resources[r.Type][r.Name] = r.ID
+	}
+	if len(host.Resources) != 5 || len(host.SecretKeys) != 1 {
+		t.Fatalf("unexpected manifest %+v", host)
+	}
+	nr, err := svc.FindNotificationRuleByID(ctx, resources[influxdb.NotificationRuleResourceType]["page oncall"])
+	if err != nil {
+		t.Fatalf("failed to find notification rule: %v", err)
+	}
+	if id := nr.(*rule.Slack).EndpointID; id == nil || *id != resources[influxdb.NotificationEndpointResourceType]["oncall"] {
+		t.Errorf("expected the rule to send to the endpoint of the template, got %v", id)
+	}
+	if nr.(*rule.Slack).AuthorizationID != authID {
+		t.Errorf("expected the rule to use the authorization of the install, got %s", nr.(*rule.Slack).AuthorizationID)
+	}
+	ls, err := svc.FindResourceLabels(ctx, influxdb.LabelMappingFilter{
+		ResourceID:   resources[influxdb.ChecksResourceType]["heartbeat"],
+		ResourceType: influxdb.ChecksResourceType,
+	})
+	if err != nil || len(ls) != 1 || ls[0].Name != "host" {
+		t.Errorf("expected the check to have the label of the template, got %v, %v", ls, err)
+	}
+	if v, err := svc.LoadSecret(ctx, org.ID, "oncall-token"); err != nil || v != "xoxb" {
+		t.Errorf("expected the secret of the install to be stored, got %q, %v", v, err)
+	}
+
+	if _, err := svc.InstallMonitoringTemplate(ctx, org.ID, influxdb.MonitoringTemplateInstall{Template: hostV1(), AuthorizationID: authID}, user.ID); influxdb.ErrorCode(err) != influxdb.EConflict {
+		t.Errorf("expected the template to be installed once, got %v", err)
+	}
+	dbm, err := svc.InstallMonitoringTemplate(ctx, org.ID, influxdb.MonitoringTemplateInstall{Template: db}, user.ID)
+	if err != nil {
+		t.Fatalf("failed to install the template requiring the host template: %v", err)
+	}
+	if err := svc.UninstallMonitoringTemplate(ctx, host.ID); influxdb.ErrorCode(err) != influxdb.EConflict {
+		t.Errorf("expected the required template not to be uninstalled, got %v", err)
+	}
+
+	if _, err := svc.UpgradeMonitoringTemplate(ctx, host.ID, influxdb.MonitoringTemplateInstall{Template: hostV1(), AuthorizationID: authID}, user.ID); influxdb.ErrorCode(err) != influxdb.EConflict {
+		t.Errorf("expected the upgrade to the same version to fail, got %v", err)
+	}
+	inactive := influxdb.Inactive
+	if _, err := svc.PatchCheck(ctx, resources[influxdb.ChecksResourceType]["heartbeat"], influxdb.CheckUpdate{Status: &inactive}); err != nil {
+		t.Fatalf("failed to patch check: %v", err)
+	}
+	hostV2 := hostV1()
+	hostV2.Version = "1.1.0"
+	hostV2.Checks = []influxdb.Check{newTemplateDeadman("heartbeat", 120), newTemplateDeadman("disk", 60)}
+	hostV2.Rules = nil
+	host, err = svc.UpgradeMonitoringTemplate(ctx, host.ID, influxdb.MonitoringTemplateInstall{Template: hostV2, AuthorizationID: authID}, user.ID)
+	if err != nil {
+		t.Fatalf("failed to upgrade monitoring template: %v", err)
+	}
+	if host.Version != "1.1.0" || len(host.Resources) != 5 {
+		t.Errorf("unexpected manifest of the upgrade %+v", host)
+	}
+	c, err := svc.FindCheckByID(ctx, resources[influxdb.ChecksResourceType]["heartbeat"])
+	if err != nil {
+		t.Fatalf("expected the check of the same name to be updated: %v", err)
+	}
+	if c.(*check.Deadman).TimeSince != 120 || c.GetStatus() != influxdb.Inactive {
+		t.Errorf("expected the check to be updated and keep its status, got %+v", c)
+	}
+	if _, err := svc.FindNotificationRuleByID(ctx, resources[influxdb.NotificationRuleResourceType]["page oncall"]); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Errorf("expected the rule dropped by the template to be deleted, got %v", err)
+	}
+
+	if err := svc.UninstallMonitoringTemplate(ctx, dbm.ID); err != nil {
+		t.Fatalf("failed to uninstall monitoring template: %v", err)
+	}
+	if err := svc.UninstallMonitoringTemplate(ctx, host.ID); err != nil {
+		t.Fatalf("failed to uninstall monitoring template: %v", err)
+	}
+	for _, r := range host.Resources {
+		var err error
+		switch r.Type {
+		case influxdb.ChecksResourceType:
+			_, err = svc.FindCheckByID(ctx, r.ID)
+		case influxdb.NotificationEndpointResourceType:
+			_, err = svc.FindNotificationEndpointByID(ctx, r.ID)
+		case influxdb.LabelsResourceType:
+			_, err = svc.FindLabelByID(ctx, r.ID)
+		}
+		if influxdb.ErrorCode(err) != influxdb.ENotFound {
+			t.Errorf("expected the %s %q to be deleted, got %v", r.Type, r.Name, err)
+		}
+	}
+	if _, err := svc.LoadSecret(ctx, org.ID, "oncall-token"); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Errorf("expected the secret of the install to be deleted, got %v", err)
+	}
+	if v, err := svc.LoadSecret(ctx, org.ID, "pager-key"); err != nil || v != "shared" {
+		t.Errorf("expected the secret of the organization to be kept, got %q, %v", v, err)
+	}
+	if ms, err := svc.FindMonitoringTemplateManifests(ctx, org.ID); err != nil || len(ms) != 0 {
+		t.Errorf("expected no installed template, got %v, %v", ms, err)
+	}
+}
+
+func TestService_MonitoringTemplatesDeterministicIDs(t *testing.T) {
+	ctx := context.Background()
+	install := func() *influxdb.MonitoringTemplateManifest {
+		svc, user, org := newTestServiceWithOrg(t)
+
+		tmpl := &influxdb.MonitoringTemplate{
+			Name:    "host",
+			Version: "1.0.0",
+			Labels:  []influxdb.MonitoringTemplateLabel{{Name: "host"}},
+			Checks: []influxdb.Check{&check.Deadman{
+				Base: check.Base{
+					Name:  "heartbeat",
+					Every: influxdb.Duration{Duration: time.Minute},
+					Query: influxdb.DashboardQuery{Text: `from(bucket: "telegraf") |> range(start: -1m)`},
+				},
+				TimeSince: 60,
+			}},
+		}
+		preview, err := svc.PreviewMonitoringTemplate(ctx, org.ID, influxdb.MonitoringTemplateInstall{Template: tmpl, DeterministicIDs: true})
+		if err != nil {
+			t.Fatalf("failed to preview monitoring template: %v", err)
+		}
+		m, err := svc.InstallMonitoringTemplate(ctx, org.ID, influxdb.MonitoringTemplateInstall{Template: tmpl, DeterministicIDs: true}, user.ID)
+		if err != nil {
+			t.Fatalf("failed to install monitoring template: %v", err)
+		}
+		if !m.DeterministicIDs || len(m.Resources) != len(preview) {
+			t.Fatalf("unexpected manifest %+v", m)
+		}
+		for i, r := range m.Resources {
+			if r.ID != preview[i].ID {
+				t.Errorf("expected the preview of the %s %q to have the ID %v, got %v", r.Type, r.Name, r.ID, preview[i].ID)
+			}
+		}
+		if _, err := svc.FindCheckByID(ctx, influxdb.NameID("theorg", "host", string(influxdb.ChecksResourceType), "heartbeat")); err != nil {
+			t.Errorf("expected the check to have the ID derived from its name: %v", err)
+		}
+		return m
+	}
+
+	m1, m2 := install(), install()
+	if m1.ID != m2.ID {
+		t.Errorf("expected the installs to have the same manifest ID, got %v and %v", m1.ID, m2.ID)
+	}
+	for i := range m1.Resources {
+		if m1.Resources[i].ID != m2.Resources[i].ID {
+			t.Errorf("expected the installs to create the %s %q with the same ID, got %v and %v", m1.Resources[i].Type, m1.Resources[i].Name, m1.Resources[i].ID, m2.Resources[i].ID)
+		}
+	}
+}
@@ -0,0 +1,250 @@
+package kv
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/influxdata/influxdb"
+)
+
+var (
+	notificationBudgetBucket      = []byte("notificationBudgetsv1")
+	notificationBudgetUsageBucket = []byte("notificationBudgetUsagev1")
+
+	// ErrNotificationBudgetNotFound is used when the endpoint has no notification budget.
+	ErrNotificationBudgetNotFound = &influxdb.Error{
+		Msg:  "notification budget not found",
+		Code: influxdb.ENotFound,
+	}
+)
+
+var _ influxdb.NotificationBudgetService = (*Service)(nil)
+
+func (s *Service) initializeNotificationBudgets(ctx context.Context, tx Tx) error {
+	if _, err := tx.Bucket(notificationBudgetBucket); err != nil {
+		return err
+	}
+	if _, err := tx.Bucket(notificationBudgetUsageBucket); err != nil {
+		return err
+	}
+	return nil
+}
+
+// UnavailableNotificationBudgetStoreError is used if we aren't able to interact with the
+// store, it means the store is not available at the moment (e.g. network).
+func UnavailableNotificationBudgetStoreError(err error) *influxdb.Error {
+	return &influxdb.Error{
+		Code: influxdb.EInternal,
+		Msg:  fmt.Sprintf("Unable to connect to notification budget store service. Please try again; Err: %v", err),
+		Op:   "kv/notificationBudget",
+	}
+}
+
+// InternalNotificationBudgetStoreError is used when the error comes from an
+// internal system.
+func InternalNotificationBudgetStoreError(err error) *influxdb.Error {
+	return &influxdb.Error{
+		Code: influxdb.EInternal,
+		Msg:  fmt.Sprintf("Unknown internal notification budget data error; Err: %v", err),
+		Op:   "kv/notificationBudget",
+	}
+}
+
+// FindNotificationBudget returns the notification budget of an endpoint.
+func (s *Service) FindNotificationBudget(ctx context.Context, endpointID influxdb.ID) (*influxdb.NotificationBudget, error) {
+	var (
+		b   *influxdb.NotificationBudget
+		err error
+	)
+	err = s.kv.View(ctx, func(tx Tx) error {
+		b, err = s.findNotificationBudget(ctx, tx, endpointID)
+		return err
+	})
+	return b, err
+}
+
+func (s *Service) findNotificationBudget(ctx context.Context, tx Tx, endpointID influxdb.ID) (*influxdb.NotificationBudget, error) {
+	encID, err := endpointID.Encode()
+	if err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Err:  err,
+		}
+	}
+
+	bucket, err := tx.Bucket(notificationBudgetBucket)
+	if err != nil {
+		return nil, UnavailableNotificationBudgetStoreError(err)
+	}
+
+	v, err := bucket.Get(encID)
+	if IsNotFound(err) {
+		return nil, ErrNotificationBudgetNotFound
+	}
+	if err != nil {
+		return nil, InternalNotificationBudgetStoreError(err)
+	}
+
+	b := &influxdb.NotificationBudget{}
+	if err := json.Unmarshal(v, b); err != nil {
+		return nil, InternalNotificationBudgetStoreError(err)
+	}
+	return b, nil
+}
+
+// PutNotificationBudget creates or replaces the notification budget of an endpoint.
+// The budget belongs to the organization of the endpoint, its admin and fallback
+// endpoints must belong to the same organization.
+func (s *Service) PutNotificationBudget(ctx context.Context, b *influxdb.NotificationBudget) error {
+	return s.kv.Update(ctx, func(tx Tx) error {
+		return s.putNotificationBudget(ctx, tx, b)
+	})
+}
+
+func (s *Service) putNotificationBudget(ctx context.Context, tx Tx, b *influxdb.NotificationBudget) error {
+	if err := b.Valid(); err != nil {
+		return err
+	}
+	edp, err := s.findNotificationEndpointByID(ctx, tx, b.EndpointID)
+	if err != nil {
+		return err
+	}
+	b.OrgID = edp.GetOrgID()
+	for _, id := range []*influxdb.ID{b.AdminEndpointID, b.FallbackEndpointID} {
+		if id == nil {
+			continue
+		}
+		other, err := s.findNotificationEndpointByID(ctx, tx, *id)
+		if err != nil {
+			return &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "admin or fallback notification endpoint not found",
+				Err:  err,
+			}
+		}
+		if other.GetOrgID() != b.OrgID {
+			return &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "admin and fallback notification endpoints must belong to the organization of the endpoint",
+			}
+		}
+	}
+
+	now := s.TimeGenerator.Now()
+	b.CreatedAt = now
+	if old, err := s.findNotificationBudget(ctx, tx, b.EndpointID); err == nil {
+		b.CreatedAt = old.CreatedAt
+	}
+	b.UpdatedAt = now
+
+	encID, _ := b.EndpointID.Encode()
+	v, err := json.Marshal(b)
+	if err != nil {
+		return InternalNotificationBudgetStoreError(err)
+	}
+	bucket, err := tx.Bucket(notificationBudgetBucket)
+	if err != nil {
+		return UnavailableNotificationBudgetStoreError(err)
+	}
+	if err := bucket.Put(encID, v); err != nil {
+		return UnavailableNotificationBudgetStoreError(err)
+	}
+	return nil
+}
+
+// DeleteNotificationBudget removes the notification budget of an endpoint.
+// The usage of the endpoint is kept.
+func (s *Service) DeleteNotificationBudget(ctx context.Context, endpointID influxdb.ID) error {
+	return s.kv.Update(ctx, func(tx Tx) error {
+		if _, err := s.findNotificationBudget(ctx, tx, endpointID); err != nil {
+			return err
+		}
+		encID, _ := endpointID.Encode()
+		bucket, err := tx.Bucket(notificationBudgetBucket)
+		if err != nil {
+			return UnavailableNotificationBudgetStoreError(err)
+		}
+		if err := bucket.Delete(encID); err != nil {
+			return UnavailableNotificationBudgetStoreError(err)
+		}
+		return nil
+	})
+}
+
+// FindNotificationBudgetUsage returns the usage of the budget of an endpoint in the month of t.
+func (s *Service) FindNotificationBudgetUsage(ctx context.Context, endpointID influxdb.ID, t time.Time) (*influxdb.NotificationBudgetUsage, error) {
+	var u *influxdb.NotificationBudgetUsage
+	err := s.kv.View(ctx, func(tx Tx) error {
+		b, err := s.findNotificationBudget(ctx, tx, endpointID)
+		if err != nil {
+			return err
+		}
+		n, err := s.findNotificationBudgetCount(ctx, tx, endpointID, t)
+		if err != nil {
+			return err
+		}
+		u = influxdb.NewNotificationBudgetUsage(b, t, n)
+		return nil
+	})
+	return u, err
+}
+
+// SpendNotificationBudget counts a notification routed to an endpoint at t,
+// and returns the usage of the budget of its month. The notifications of the
+// endpoints without a budget aren't counted.
+func (s *Service) SpendNotificationBudget(ctx context.Context, endpointID influxdb.ID, t time.Time) (*influxdb.NotificationBudgetUsage, error) {
+	var u *influxdb.NotificationBudgetUsage
+	err := s.kv.Update(ctx, func(tx Tx) error {
+		b, err := s.findNotificationBudget(ctx, tx, endpointID)
+		if err != nil {
+			return err
+		}
+		n, err := s.findNotificationBudgetCount(ctx, tx, endpointID, t)
+		if err != nil {
+			return err
+		}
+		n++
+
+		bucket, err := tx.Bucket(notificationBudgetUsageBucket)
+		if err != nil {
+			return UnavailableNotificationBudgetStoreError(err)
+		}
+		if err := bucket.Put(notificationBudgetUsageKey(endpointID, t), []byte(strconv.Itoa(n))); err != nil {
+			return UnavailableNotificationBudgetStoreError(err)
+		}
+		u = influxdb.NewNotificationBudgetUsage(b, t, n)
+		return nil
+	})
+	return u, err
+}
+
+// findNotificationBudgetCount returns the number of notifications routed to
+// an endpoint in the month of t.
+func (s *Service) findNotificationBudgetCount(ctx context.Context, tx Tx, endpointID influxdb.ID, t time.Time) (int, error) {
+	bucket, err := tx.Bucket(notificationBudgetUsageBucket)
+	if err != nil {
+		return 0, UnavailableNotificationBudgetStoreError(err)
+	}
+	v, err := bucket.Get(notificationBudgetUsageKey(endpointID, t))
+	if IsNotFound(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, InternalNotificationBudgetStoreError(err)
+	}
+	n, err := strconv.Atoi(string(v))
+	if err != nil {
+		return 0, InternalNotificationBudgetStoreError(err)
+	}
+	return n, nil
+}
+
+// notificationBudgetUsageKey is the encoded id of an endpoint followed by the
+// month of t, such as 2019-10.
+func notificationBudgetUsageKey(endpointID influxdb.ID, t time.Time) []byte {
+	encID, _ := endpointID.Encode()
+	return append(encID, t.UTC().Format(influxdb.NotificationBudgetMonthFormat)...)
+}
@@ -0,0 +1,83 @@
+package kv_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification/endpoint"
+)
+
+func TestService_SpendNotificationBudget(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestService(t)
+
+	slack := func(id, orgID influxdb.ID, name string) influxdb.NotificationEndpoint {
+		return &endpoint.Slack{
+			Base: endpoint.Base{ID: id, Name: name, OrgID: orgID, Status: influxdb.Active},
+			URL:  "https://hooks.slack.com/services/1",
+		}
+	}
+	edps := []influxdb.NotificationEndpoint{
+		slack(1, 10, "pagerduty"),
+		slack(2, 10, "fallback"),
+		slack(3, 11, "other org"),
+	}
+	for _, edp := range edps {
+		if err := svc.PutNotificationEndpoint(ctx, edp); err != nil {
+			t.Fatalf("failed to populate notification endpoint: %v", err)
+		}
+	}
+
+	otherOrgEndpointID := influxdb.ID(3)
+	err := svc.PutNotificationBudget(ctx, &influxdb.NotificationBudget{EndpointID: 1, Monthly: 2, FallbackEndpointID: &otherOrgEndpointID})
+	if influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Errorf("expected a fallback of another org to be rejected, got %v", err)
+	}
+
+	fallbackID := influxdb.ID(2)
+	b := &influxdb.NotificationBudget{EndpointID: 1, Monthly: 2, FallbackEndpointID: &fallbackID}
+	if err := svc.PutNotificationBudget(ctx, b); err != nil {
+		t.Fatalf("failed to put notification budget: %v", err)
+	}
+	if b.OrgID != 10 {
+		t.Errorf("expected the budget to belong to the org of its endpoint, got %s", b.OrgID)
+	}
+
+	if _, err := svc.SpendNotificationBudget(ctx, 2, time.Now()); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Errorf("expected the endpoints without a budget not to be counted, got %v", err)
+	}
+
+	october := time.Date(2019, 10, 31, 23, 0, 0, 0, time.UTC)
+	var u *influxdb.NotificationBudgetUsage
+	for i := 0; i < 3; i++ {
+		if u, err = svc.SpendNotificationBudget(ctx, 1, october); err != nil {
+			t.Fatalf("failed to spend notification budget: %v", err)
+		}
+	}
+	want := &influxdb.NotificationBudgetUsage{
+		EndpointID:    1,
+		OrgID:         10,
+		Month:         "2019-10",
+		Monthly:       2,
+		Notifications: 3,
+		Overage:       1,
+	}
+	if diff := cmp.Diff(want, u); diff != "" {
+		t.Errorf("unexpected usage of october -want/+got\n%s", diff)
+	}
+	if !u.Exceeded() {
+		t.Errorf("expected the budget of october to be exceeded")
+	}
+
+	november := october.Add(2 * time.Hour)
+	u, err = svc.FindNotificationBudgetUsage(ctx, 1, november)
+	if err != nil {
+		t.Fatalf("failed to find notification budget usage: %v", err)
+	}
+	if u.Month != "2019-11" || u.Notifications != 0 || u.Remaining != 2 {
+		t.Errorf("expected the budget to be renewed in november, got %+v", u)
+	}
+}
@@ -0,0 +1,460 @@
+package kv
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/alerting/page"
+	"github.com/influxdata/influxdb/notification/endpoint"
+)
+
+var (
+	notificationEndpointBucket = []byte("notificationEndpointv1")
+
+	// ErrNotificationEndpointNotFound is used when the notification endpoint is not found.
+	ErrNotificationEndpointNotFound = &influxdb.Error{
+		Msg:  "notification endpoint not found",
+		Code: influxdb.ENotFound,
+	}
+
+	// ErrInvalidNotificationEndpointID is used when the service was provided
+	// an invalid ID format.
+	ErrInvalidNotificationEndpointID = &influxdb.Error{
+		Code: influxdb.EInvalid,
+		Msg:  "provided notification endpoint ID has invalid format",
+	}
+)
+
+var _ influxdb.NotificationEndpointService = (*Service)(nil)
+
+func (s *Service) initializeNotificationEndpoint(ctx context.Context, tx Tx) error {
+	if _, err := s.notificationEndpointBucket(tx); err != nil {
+		return err
+	}
+	return notificationEndpointIndex.initialize(tx, func(fn func(orgID, id influxdb.ID, name string) bool) error {
+		return s.forEachNotificationEndpoint(ctx, tx, false, func(edp influxdb.NotificationEndpoint) bool {
+			return fn(edp.GetOrgID(), edp.GetID(), edp.GetName())
+		})
+	})
+}
+
+// UnavailableNotificationEndpointStoreError is used if we aren't able to interact with the
+// store, it means the store is not available at the moment (e.g. network).
+func UnavailableNotificationEndpointStoreError(err error) *influxdb.Error {
+	return &influxdb.Error{
+		Code: influxdb.EInternal,
+		Msg:  fmt.Sprintf("Unable to connect to notification endpoint store service. Please try again; Err: %v", err),
+		Op:   "kv/notificationEndpoint",
+	}
+}
+
+// InternalNotificationEndpointStoreError is used when the error comes from an
+// internal system.
+func InternalNotificationEndpointStoreError(err error) *influxdb.Error {
+	return &influxdb.Error{
+		Code: influxdb.EInternal,
+		Msg:  fmt.Sprintf("Unknown internal notification endpoint data error; Err: %v", err),
+		Op:   "kv/notificationEndpoint",
+	}
+}
+
+func (s *Service) notificationEndpointBucket(tx Tx) (Bucket, error) {
+	b, err := tx.Bucket(notificationEndpointBucket)
+	if err != nil {
+		return nil, UnavailableNotificationEndpointStoreError(err)
+	}
+	return b, nil
+}
+
+// CreateNotificationEndpoint creates a new notification endpoint and sets b.ID with the new identifier.
+func (s *Service) CreateNotificationEndpoint(ctx context.Context, edp influxdb.NotificationEndpoint, userID influxdb.ID) error {
+	return s.kv.Update(ctx, func(tx Tx) error {
+		return s.createNotificationEndpoint(ctx, tx, edp, s.IDGenerator.ID(), userID)
+	})
+}
+
+func (s *Service) createNotificationEndpoint(ctx context.Context, tx Tx, edp influxdb.NotificationEndpoint, id, userID influxdb.ID) error {
+	if err := s.notificationEndpointAllowed(edp); err != nil {
+		return err
+	}
+	if err := s.enforceAlertingQuota(ctx, tx, edp.GetOrgID(), influxdb.NotificationEndpointResourceType, 1); err != nil {
+		return err
+	}
+
+	edp.SetID(id)
+	now := s.TimeGenerator.Now()
+	edp.SetCreatedAt(now)
+	edp.SetUpdatedAt(now)
+	edp.BackfillSecretKeys()
+
+	if err := notificationEndpointIndex.unique(tx, edp.GetOrgID(), edp.GetName(), id); err != nil {
+		return err
+	}
+	if err := s.putNotificationEndpointSecrets(ctx, tx, edp); err != nil {
+		return err
+	}
+
+	if err := notificationEndpointIndex.put(tx, edp.GetOrgID(), edp.GetName(), id); err != nil {
+		return err
+	}
+	if err := s.putNotificationEndpoint(ctx, tx, edp); err != nil {
+		return err
+	}
+
+	urm := &influxdb.UserResourceMapping{
+		ResourceID:   id,
+		UserID:       userID,
+		UserType:     influxdb.Owner,
+		ResourceType: influxdb.NotificationEndpointResourceType,
+	}
+	return s.createUserResourceMapping(ctx, tx, urm)
+}
+
+// notificationEndpointAllowed returns an error if the server is not configured
+// to allow the endpoint, exec endpoints can only run the commands allowed by the operator.
+func (s *Service) notificationEndpointAllowed(edp influxdb.NotificationEndpoint) error {
+	if e, ok := edp.(*endpoint.Exec); ok {
+		return e.CommandAllowed(s.Config.NotificationExecCommands)
+	}
+	return nil
+}
+
+// putNotificationEndpointSecrets stores the values of secret fields in the secret store,
+// the endpoint itself only ever persists the keys. The secrets of external stores the
+// fields refer to are resolved when notifications are sent, and never stored.
+func (s *Service) putNotificationEndpointSecrets(ctx context.Context, tx Tx, edp influxdb.NotificationEndpoint) error {
+	for _, fld := range edp.SecretFields() {
+		if ref, ok := fld.Reference(); ok {
+			if err := ref.Valid(); err != nil {
+				return err
+			}
+			continue
+		}
+		if fld.Value == nil {
+			continue
+		}
+		if err := s.putSecret(ctx, tx, edp.GetOrgID(), fld.Key, *fld.Value); err != nil {
+			return InternalNotificationEndpointStoreError(err)
+		}
+	}
+	return nil
+}
+
+func (s *Service) deleteNotificationEndpointSecrets(ctx context.Context, tx Tx, edp influxdb.NotificationEndpoint, keep []influxdb.SecretField) error {
+	kept := make(map[string]bool, len(keep))
+	for _, fld := range keep {
+		kept[fld.Key] = true
+	}
+	for _, fld := range edp.SecretFields() {
+		if _, ok := fld.Reference(); ok || kept[fld.Key] {
+			continue
+		}
+		if err := s.deleteSecret(ctx, tx, edp.GetOrgID(), fld.Key); err != nil {
+			return InternalNotificationEndpointStoreError(err)
+		}
+	}
+	return nil
+}
+
+// UpdateNotificationEndpoint updates a single notification endpoint.
+// Returns the new notification endpoint after update.
+func (s *Service) UpdateNotificationEndpoint(ctx context.Context, id influxdb.ID, edp influxdb.NotificationEndpoint, userID influxdb.ID) (influxdb.NotificationEndpoint, error) {
+	var err error
+	err = s.kv.Update(ctx, func(tx Tx) error {
+		edp, err = s.updateNotificationEndpoint(ctx, tx, id, edp, userID)
+		return err
+	})
+	return edp, err
+}
+
+func (s *Service) updateNotificationEndpoint(ctx context.Context, tx Tx, id influxdb.ID, edp influxdb.NotificationEndpoint, userID influxdb.ID) (influxdb.NotificationEndpoint, error) {
+	if err := s.notificationEndpointAllowed(edp); err != nil {
+		return nil, err
+	}
+
+	current, err := s.findNotificationEndpointByID(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// ID and OrganizationID can not be updated
+	edp.SetID(current.GetID())
+	edp.SetOrgID(current.GetOrgID())
+	edp.SetCreatedAt(current.GetCRUDLog().CreatedAt)
+	edp.SetUpdatedAt(s.TimeGenerator.Now())
+	edp.BackfillSecretKeys()
+
+	if err := notificationEndpointIndex.rename(tx, current.GetOrgID(), current.GetName(), edp.GetOrgID(), edp.GetName(), id); err != nil {
+		return nil, err
+	}
+	if err := s.putNotificationEndpointSecrets(ctx, tx, edp); err != nil {
+		return nil, err
+	}
+	if err := s.deleteNotificationEndpointSecrets(ctx, tx, current, edp.SecretFields()); err != nil {
+		return nil, err
+	}
+
+	err = s.putNotificationEndpoint(ctx, tx, edp)
+	return edp, err
+}
+
+// PatchNotificationEndpoint updates a single  notification endpoint with changeset.
+// Returns the new notification endpoint state after update.
+func (s *Service) PatchNotificationEndpoint(ctx context.Context, id influxdb.ID, upd influxdb.NotificationEndpointUpdate) (influxdb.NotificationEndpoint, error) {
+	var edp influxdb.NotificationEndpoint
+	if err := s.kv.Update(ctx, func(tx Tx) (err error) {
+		edp, err = s.patchNotificationEndpoint(ctx, tx, id, upd)
+		if err != nil {
+			return err
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return edp, nil
+}
+
+func (s *Service) patchNotificationEndpoint(ctx context.Context, tx Tx, id influxdb.ID, upd influxdb.NotificationEndpointUpdate) (influxdb.NotificationEndpoint, error) {
+	edp, err := s.findNotificationEndpointByID(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if upd.Name != nil {
+		if err := notificationEndpointIndex.rename(tx, edp.GetOrgID(), edp.GetName(), edp.GetOrgID(), *upd.Name, id); err != nil {
+			return nil, err
+		}
+		edp.SetName(*upd.Name)
+	}
+	if upd.Description != nil {
+		edp.SetDescription(*upd.Description)
+	}
+	if upd.Status != nil {
+		edp.SetStatus(*upd.Status)
+	}
+	edp.SetUpdatedAt(s.TimeGenerator.Now())
+	err = s.putNotificationEndpoint(ctx, tx, edp)
+	if err != nil {
+		return nil, err
+	}
+
+	return edp, nil
+}
+
+// PutNotificationEndpoint put a notification endpoint to storage.
+func (s *Service) PutNotificationEndpoint(ctx context.Context, edp influxdb.NotificationEndpoint) error {
+	return s.kv.Update(ctx, func(tx Tx) (err error) {
+		if err := notificationEndpointIndex.put(tx, edp.GetOrgID(), edp.GetName(), edp.GetID()); err != nil {
+			return err
+		}
+		return s.putNotificationEndpoint(ctx, tx, edp)
+	})
+}
+
+func (s *Service) putNotificationEndpoint(ctx context.Context, tx Tx, edp influxdb.NotificationEndpoint) error {
+	if err := edp.Valid(); err != nil {
+		return err
+	}
+	encodedID, _ := edp.GetID().Encode()
+
+	v, err := json.Marshal(edp)
+	if err != nil {
+		return err
+	}
+
+	bucket, err := s.notificationEndpointBucket(tx)
+	if err != nil {
+		return err
+	}
+
+	if err := bucket.Put(encodedID, v); err != nil {
+		return UnavailableNotificationEndpointStoreError(err)
+	}
+	return nil
+}
+
+// FindNotificationEndpointByID returns a single notification endpoint by ID.
+func (s *Service) FindNotificationEndpointByID(ctx context.Context, id influxdb.ID) (influxdb.NotificationEndpoint, error) {
+	var (
+		edp influxdb.NotificationEndpoint
+		err error
+	)
+
+	err = s.kv.View(ctx, func(tx Tx) error {
+		edp, err = s.findNotificationEndpointByID(ctx, tx, id)
+		return err
+	})
+
+	return edp, err
+}
+
+func (s *Service) findNotificationEndpointByID(ctx context.Context, tx Tx, id influxdb.ID) (influxdb.NotificationEndpoint, error) {
+	encID, err := id.Encode()
+	if err != nil {
+		return nil, ErrInvalidNotificationEndpointID
+	}
+
+	bucket, err := s.notificationEndpointBucket(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := bucket.Get(encID)
+	if IsNotFound(err) {
+		return nil, ErrNotificationEndpointNotFound
+	}
+	if err != nil {
+		return nil, InternalNotificationEndpointStoreError(err)
+	}
+
+	return endpoint.UnmarshalJSON(v)
+}
+
+// FindNotificationEndpoints returns a list of notification endpoints that match filter and the total count of matching notification endpoints.
+// Additional options provide pagination & sorting.
+func (s *Service) FindNotificationEndpoints(ctx context.Context, filter influxdb.NotificationEndpointFilter, opt ...influxdb.FindOptions) (edps []influxdb.NotificationEndpoint, n int, err error) {
+	err = s.kv.View(ctx, func(tx Tx) error {
+		edps, n, err = s.findNotificationEndpoints(ctx, tx, filter, opt...)
+		return err
+	})
+	return edps, n, err
+}
+
+func (s *Service) findNotificationEndpoints(ctx context.Context, tx Tx, filter influxdb.NotificationEndpointFilter, opt ...influxdb.FindOptions) ([]influxdb.NotificationEndpoint, int, error) {
+	edps := make([]influxdb.NotificationEndpoint, 0)
+
+	m, err := s.findUserResourceMappings(ctx, tx, filter.UserResourceMappingFilter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if len(m) == 0 {
+		return edps, 0, nil
+	}
+
+	ids := make(map[influxdb.ID]bool, len(m))
+	for _, item := range m {
+		ids[item.ResourceID] = true
+	}
+
+	if filter.OrgID != nil || filter.Organization != nil {
+		o, err := s.FindOrganization(ctx, influxdb.OrganizationFilter{
+			ID:   filter.OrgID,
+			Name: filter.Organization,
+		})
+
+		if err != nil {
+			return edps, 0, err
+		}
+		filter.OrgID = &o.ID
+	}
+
+	var opts influxdb.FindOptions
+	if len(opt) > 0 {
+		opts = opt[0]
+	}
+	it := func(fn func(influxdb.Getter) bool) error {
+		return s.forEachNotificationEndpoint(ctx, tx, false, func(edp influxdb.NotificationEndpoint) bool { return fn(edp) })
+	}
+	filters := []page.Filter{page.IDs(ids)}
+	if filter.OrgID != nil {
+		filters = append(filters, page.OrgID(*filter.OrgID))
+	}
+	rs, err := page.Find(it, opts, filters...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for _, r := range rs {
+		edps = append(edps, r.(influxdb.NotificationEndpoint))
+	}
+	return edps, len(edps), nil
+}
+
+// forEachNotificationEndpoint will iterate through all notification endpoints while fn returns true.
+func (s *Service) forEachNotificationEndpoint(ctx context.Context, tx Tx, descending bool, fn func(influxdb.NotificationEndpoint) bool) error {
+
+	bkt, err := s.notificationEndpointBucket(tx)
+	if err != nil {
+		return err
+	}
+
+	cur, err := bkt.Cursor()
+	if err != nil {
+		return err
+	}
+
+	var k, v []byte
+	if descending {
+		k, v = cur.Last()
+	} else {
+		k, v = cur.First()
+	}
+
+	for k != nil {
+		edp, err := endpoint.UnmarshalJSON(v)
+		if err != nil {
+			return err
+		}
+		if !fn(edp) {
+			break
+		}
+
+		if descending {
+			k, v = cur.Prev()
+		} else {
+			k, v = cur.Next()
+		}
+	}
+
+	return nil
+}
+
+// DeleteNotificationEndpoint removes a notification endpoint by ID, unless
+// notification rules send to it.
+func (s *Service) DeleteNotificationEndpoint(ctx context.Context, id influxdb.ID) error {
+	return s.kv.Update(ctx, func(tx Tx) error {
+		return s.deleteNotificationEndpoint(ctx, tx, id)
+	})
+}
+
+func (s *Service) deleteNotificationEndpoint(ctx context.Context, tx Tx, id influxdb.ID) error {
+	edp, err := s.findNotificationEndpointByID(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+
+	// the notification rules sending to the endpoint are deleted with it only by a cascade.
+	if err := s.notificationEndpointInUse(ctx, tx, id); err != nil {
+		return err
+	}
+
+	encodedID, err := id.Encode()
+	if err != nil {
+		return ErrInvalidNotificationEndpointID
+	}
+
+	bucket, err := s.notificationEndpointBucket(tx)
+	if err != nil {
+		return err
+	}
+
+	if err := bucket.Delete(encodedID); err != nil {
+		return InternalNotificationEndpointStoreError(err)
+	}
+	if err := notificationEndpointIndex.delete(tx, edp.GetOrgID(), edp.GetName(), id); err != nil {
+		return err
+	}
+
+	if err := s.deleteNotificationEndpointSecrets(ctx, tx, edp, nil); err != nil {
+		return err
+	}
+
+	return s.deleteUserResourceMappings(ctx, tx, influxdb.UserResourceMappingFilter{
+		ResourceID:   id,
+		ResourceType: influxdb.NotificationEndpointResourceType,
+	})
+}
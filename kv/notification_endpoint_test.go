@@ -0,0 +1,205 @@
+package kv_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/kv"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/notification/endpoint"
+	influxdbtesting "github.com/influxdata/influxdb/testing"
+)
+
+func TestBoltNotificationEndpointService(t *testing.T) {
+	influxdbtesting.NotificationEndpointService(initBoltNotificationEndpointService, t)
+}
+
+func TestNotificationEndpointService(t *testing.T) {
+	influxdbtesting.NotificationEndpointService(initInmemNotificationEndpointService, t)
+}
+
+func TestNotificationEndpointServiceExecCommands(t *testing.T) {
+	s, closeStore, err := NewTestInmemStore()
+	if err != nil {
+		t.Fatalf("failed to create new kv store: %v", err)
+	}
+	defer closeStore()
+
+	svc := kv.NewService(s, kv.ServiceConfig{
+		NotificationExecCommands: []string{"/usr/local/bin/notify"},
+	})
+	svc.IDGenerator = mock.NewIDGenerator("020f755c3c082000", t)
+	ctx := context.Background()
+	if err := svc.Initialize(ctx); err != nil {
+		t.Fatalf("error initializing service: %v", err)
+	}
+
+	newExec := func(cmd string) *endpoint.Exec {
+		return &endpoint.Exec{
+			Base: endpoint.Base{
+				Name:   "name1",
+				OrgID:  influxdbtesting.MustIDBase16("020f755c3c082001"),
+				Status: influxdb.Active,
+			},
+			Command: cmd,
+		}
+	}
+	userID := influxdbtesting.MustIDBase16("020f755c3c082002")
+
+	edp := newExec("/usr/local/bin/notify")
+	if err := svc.CreateNotificationEndpoint(ctx, edp, userID); err != nil {
+		t.Fatalf("expected allowed command to be created: %v", err)
+	}
+	if err := svc.CreateNotificationEndpoint(ctx, newExec("/bin/rm"), userID); influxdb.ErrorCode(err) != influxdb.EForbidden {
+		t.Fatalf("expected forbidden error creating a disallowed command, got %v", err)
+	}
+	if _, err := svc.UpdateNotificationEndpoint(ctx, edp.GetID(), newExec("/bin/rm"), userID); influxdb.ErrorCode(err) != influxdb.EForbidden {
+		t.Fatalf("expected forbidden error updating to a disallowed command, got %v", err)
+	}
+}
+
+func TestNotificationEndpointServiceSecretReferences(t *testing.T) {
+	s, closeStore, err := NewTestInmemStore()
+	if err != nil {
+		t.Fatalf("failed to create new kv store: %v", err)
+	}
+	defer closeStore()
+
+	svc := kv.NewService(s)
+	svc.IDGenerator = mock.NewIDGenerator("020f755c3c082000", t)
+	ctx := context.Background()
+	if err := svc.Initialize(ctx); err != nil {
+		t.Fatalf("error initializing service: %v", err)
+	}
+
+	orgID := influxdbtesting.MustIDBase16("020f755c3c082001")
+	userID := influxdbtesting.MustIDBase16("020f755c3c082002")
+	newSlack := func(token influxdb.SecretField) *endpoint.Slack {
+		return &endpoint.Slack{
+			Base: endpoint.Base{
+				Name:   "name1",
+				OrgID:  orgID,
+				Status: influxdb.Active,
+			},
+			URL:   "http://localhost:7777",
+			Token: token,
+		}
+	}
+
+	if err := svc.CreateNotificationEndpoint(ctx, newSlack(influxdb.SecretField{Key: "vault://#token"}), userID); influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Fatalf("expected a reference without a path to be rejected, got %v", err)
+	}
+
+	edp := newSlack(influxdb.SecretField{Key: "vault://secret/data/slack#token"})
+	if err := svc.CreateNotificationEndpoint(ctx, edp, userID); err != nil {
+		t.Fatalf("failed to create endpoint: %v", err)
+	}
+	keys, err := svc.GetSecretKeys(ctx, orgID)
+	if influxdb.ErrorCode(err) != influxdb.ENotFound && (err != nil || len(keys) != 0) {
+		t.Fatalf("expected the reference not to be stored, got %v, %v", keys, err)
+	}
+
+	value := "token1"
+	updated, err := svc.UpdateNotificationEndpoint(ctx, edp.GetID(), newSlack(influxdb.SecretField{Value: &value}), userID)
+	if err != nil {
+		t.Fatalf("failed to update endpoint: %v", err)
+	}
+	key := updated.SecretFields()[0].Key
+	if got, err := svc.LoadSecret(ctx, orgID, key); err != nil || got != value {
+		t.Fatalf("expected the value to be stored at %s, got %q, %v", key, got, err)
+	}
+	if _, err := svc.UpdateNotificationEndpoint(ctx, edp.GetID(), newSlack(influxdb.SecretField{Key: "awssm://slack#token"}), userID); err != nil {
+		t.Fatalf("failed to update endpoint: %v", err)
+	}
+	if _, err := svc.LoadSecret(ctx, orgID, key); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Errorf("expected the replaced secret to be deleted, got %v", err)
+	}
+	if err := svc.DeleteNotificationEndpoint(ctx, edp.GetID()); err != nil {
+		t.Fatalf("failed to delete endpoint: %v", err)
+	}
+}
+
+func initBoltNotificationEndpointService(f influxdbtesting.NotificationEndpointFields, t *testing.T) (influxdb.NotificationEndpointService, influxdb.SecretService, func()) {
+	s, closeBolt, err := NewTestBoltStore()
+	if err != nil {
+		t.Fatalf("failed to create new kv store: %v", err)
+	}
+
+	svc, secretSVC, closeSvc := initNotificationEndpointService(s, f, t)
+	return svc, secretSVC, func() {
+		closeSvc()
+		closeBolt()
+	}
+}
+
+func initInmemNotificationEndpointService(f influxdbtesting.NotificationEndpointFields, t *testing.T) (influxdb.NotificationEndpointService, influxdb.SecretService, func()) {
+	s, closeBolt, err := NewTestInmemStore()
+	if err != nil {
+		t.Fatalf("failed to create new kv store: %v", err)
+	}
+
+	svc, secretSVC, closeSvc := initNotificationEndpointService(s, f, t)
+	return svc, secretSVC, func() {
+		closeSvc()
+		closeBolt()
+	}
+}
+
+func initNotificationEndpointService(s kv.Store, f influxdbtesting.NotificationEndpointFields, t *testing.T) (influxdb.NotificationEndpointService, influxdb.SecretService, func()) {
+	svc := kv.NewService(s)
+	svc.IDGenerator = f.IDGenerator
+	svc.TimeGenerator = f.TimeGenerator
+	if f.TimeGenerator == nil {
+		svc.TimeGenerator = influxdb.RealTimeGenerator{}
+	}
+
+	ctx := context.Background()
+	if err := svc.Initialize(ctx); err != nil {
+		t.Fatalf("error initializing user service: %v", err)
+	}
+
+	for _, edp := range f.NotificationEndpoints {
+		if err := svc.PutNotificationEndpoint(ctx, edp); err != nil {
+			t.Fatalf("failed to populate notification endpoint: %v", err)
+		}
+	}
+
+	for _, m := range f.UserResourceMappings {
+		if err := svc.CreateUserResourceMapping(ctx, m); err != nil {
+			t.Fatalf("failed to populate user resource mapping: %v", err)
+		}
+	}
+
+	for _, o := range f.Orgs {
+		if err := svc.PutOrganization(ctx, o); err != nil {
+			t.Fatalf("failed to populate org: %v", err)
+		}
+	}
+
+	for _, sc := range f.Secrets {
+		for k, v := range sc.Env {
+			if err := svc.PutSecret(ctx, sc.OrganizationID, k, v); err != nil {
+				t.Fatalf("failed to populate secrets: %v", err)
+			}
+		}
+	}
+
+	return svc, svc, func() {
+		for _, edp := range f.NotificationEndpoints {
+			if err := svc.DeleteNotificationEndpoint(ctx, edp.GetID()); err != nil && influxdb.ErrorCode(err) != influxdb.ENotFound {
+				t.Logf("failed to remove notification endpoint: %v", err)
+			}
+		}
+		for _, urm := range f.UserResourceMappings {
+			if err := svc.DeleteUserResourceMapping(ctx, urm.ResourceID, urm.UserID); err != nil && influxdb.ErrorCode(err) != influxdb.ENotFound {
+				t.Logf("failed to remove urm rule: %v", err)
+			}
+		}
+		for _, o := range f.Orgs {
+			if err := svc.DeleteOrganization(ctx, o.ID); err != nil {
+				t.Fatalf("failed to remove org: %v", err)
+			}
+		}
+	}
+}
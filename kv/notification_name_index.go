@@ -0,0 +1,148 @@
+package kv
+
+import (
+	"fmt"
+
+	"github.com/influxdata/influxdb"
+)
+
+// nameIndex maps the org id and name of a resource to its id, so the names
+// are unique in their organization, as the names of the checks.
+type nameIndex struct {
+	bucket      []byte
+	resource    string
+	unavailable func(error) *influxdb.Error
+	internal    func(error) *influxdb.Error
+}
+
+var (
+	notificationEndpointIndex = nameIndex{
+		bucket:      []byte("notificationendpointindexv1"),
+		resource:    "notification endpoint",
+		unavailable: UnavailableNotificationEndpointStoreError,
+		internal:    InternalNotificationEndpointStoreError,
+	}
+	notificationRuleIndex = nameIndex{
+		bucket:      []byte("notificationruleindexv1"),
+		resource:    "notification rule",
+		unavailable: UnavailableNotificationRuleStoreError,
+		internal:    InternalNotificationRuleStoreError,
+	}
+)
+
+// initialize creates the bucket of the index, and indexes the resources
+// stored before it existed. The first resource of a name keeps it.
+func (x nameIndex) initialize(tx Tx, forEach func(fn func(orgID, id influxdb.ID, name string) bool) error) error {
+	if _, err := tx.Bucket(x.bucket); err != nil {
+		return err
+	}
+	var err error
+	if ferr := forEach(func(orgID, id influxdb.ID, name string) bool {
+		var taken bool
+		taken, err = x.taken(tx, orgID, name, id)
+		if err == nil && !taken {
+			err = x.put(tx, orgID, name, id)
+		}
+		return err == nil
+	}); ferr != nil {
+		return ferr
+	}
+	return err
+}
+
+// taken returns whether a resource other than id has the name in the org.
+func (x nameIndex) taken(tx Tx, orgID influxdb.ID, name string, id influxdb.ID) (bool, error) {
+	key, err := checkIndexKey(orgID, name)
+	if err != nil {
+		return false, err
+	}
+	idx, err := tx.Bucket(x.bucket)
+	if err != nil {
+		return false, x.unavailable(err)
+	}
+	v, err := idx.Get(key)
+	if IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, x.internal(err)
+	}
+	var current influxdb.ID
+	if err := current.Decode(v); err != nil {
+		return false, x.internal(err)
+	}
+	return current != id, nil
+}
+
+// unique returns a conflict error if a resource other than id has the name in the org.
+func (x nameIndex) unique(tx Tx, orgID influxdb.ID, name string, id influxdb.ID) error {
+	taken, err := x.taken(tx, orgID, name, id)
+	if err != nil {
+		return err
+	}
+	if taken {
+		return &influxdb.Error{
+			Code: influxdb.EConflict,
+			Msg:  fmt.Sprintf("%s with name %s already exists", x.resource, name),
+		}
+	}
+	return nil
+}
+
+func (x nameIndex) put(tx Tx, orgID influxdb.ID, name string, id influxdb.ID) error {
+	key, err := checkIndexKey(orgID, name)
+	if err != nil {
+		return err
+	}
+	encID, err := id.Encode()
+	if err != nil {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Err:  err,
+		}
+	}
+	idx, err := tx.Bucket(x.bucket)
+	if err != nil {
+		return x.unavailable(err)
+	}
+	if err := idx.Put(key, encID); err != nil {
+		return x.unavailable(err)
+	}
+	return nil
+}
+
+// delete removes the name of the resource id from the index, unless another
+// resource has it.
+func (x nameIndex) delete(tx Tx, orgID influxdb.ID, name string, id influxdb.ID) error {
+	taken, err := x.taken(tx, orgID, name, id)
+	if err != nil || taken {
+		return err
+	}
+	key, err := checkIndexKey(orgID, name)
+	if err != nil {
+		return err
+	}
+	idx, err := tx.Bucket(x.bucket)
+	if err != nil {
+		return x.unavailable(err)
+	}
+	if err := idx.Delete(key); err != nil {
+		return x.unavailable(err)
+	}
+	return nil
+}
+
+// rename moves the index of the resource id from the name of the org it had
+// to its new name and org, if it is free.
+func (x nameIndex) rename(tx Tx, oldOrgID influxdb.ID, oldName string, orgID influxdb.ID, name string, id influxdb.ID) error {
+	if oldOrgID == orgID && oldName == name {
+		return nil
+	}
+	if err := x.unique(tx, orgID, name, id); err != nil {
+		return err
+	}
+	if err := x.delete(tx, oldOrgID, oldName, id); err != nil {
+		return err
+	}
+	return x.put(tx, orgID, name, id)
+}
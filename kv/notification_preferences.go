@@ -0,0 +1,165 @@
+package kv
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/influxdata/influxdb"
+)
+
+var (
+	notificationPreferencesBucket = []byte("notificationPreferencesv1")
+
+	// ErrNotificationPreferencesNotFound is used when the user has no notification preferences.
+	ErrNotificationPreferencesNotFound = &influxdb.Error{
+		Msg:  "notification preferences not found",
+		Code: influxdb.ENotFound,
+	}
+)
+
+var _ influxdb.NotificationPreferencesService = (*Service)(nil)
+
+func (s *Service) initializeNotificationPreferences(ctx context.Context, tx Tx) error {
+	if _, err := tx.Bucket(notificationPreferencesBucket); err != nil {
+		return err
+	}
+	return nil
+}
+
+// UnavailableNotificationPreferencesStoreError is used if we aren't able to interact with the
+// store, it means the store is not available at the moment (e.g. network).
+func UnavailableNotificationPreferencesStoreError(err error) *influxdb.Error {
+	return &influxdb.Error{
+		Code: influxdb.EInternal,
+		Msg:  fmt.Sprintf("Unable to connect to notification preferences store service. Please try again; Err: %v", err),
+		Op:   "kv/notificationPreferences",
+	}
+}
+
+// InternalNotificationPreferencesStoreError is used when the error comes from an
+// internal system.
+func InternalNotificationPreferencesStoreError(err error) *influxdb.Error {
+	return &influxdb.Error{
+		Code: influxdb.EInternal,
+		Msg:  fmt.Sprintf("Unknown internal notification preferences data error; Err: %v", err),
+		Op:   "kv/notificationPreferences",
+	}
+}
+
+// FindNotificationPreferences returns the notification preferences of a user.
+func (s *Service) FindNotificationPreferences(ctx context.Context, userID influxdb.ID) (*influxdb.NotificationPreferences, error) {
+	var (
+		p   *influxdb.NotificationPreferences
+		err error
+	)
+	err = s.kv.View(ctx, func(tx Tx) error {
+		p, err = s.findNotificationPreferences(ctx, tx, userID)
+		return err
+	})
+	return p, err
+}
+
+func (s *Service) findNotificationPreferences(ctx context.Context, tx Tx, userID influxdb.ID) (*influxdb.NotificationPreferences, error) {
+	encID, err := userID.Encode()
+	if err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Err:  err,
+		}
+	}
+
+	bucket, err := tx.Bucket(notificationPreferencesBucket)
+	if err != nil {
+		return nil, UnavailableNotificationPreferencesStoreError(err)
+	}
+
+	v, err := bucket.Get(encID)
+	if IsNotFound(err) {
+		return nil, ErrNotificationPreferencesNotFound
+	}
+	if err != nil {
+		return nil, InternalNotificationPreferencesStoreError(err)
+	}
+
+	p := &influxdb.NotificationPreferences{}
+	if err := json.Unmarshal(v, p); err != nil {
+		return nil, InternalNotificationPreferencesStoreError(err)
+	}
+	return p, nil
+}
+
+// PutNotificationPreferences creates or replaces the notification preferences of a user.
+// The preferred endpoint must exist.
+func (s *Service) PutNotificationPreferences(ctx context.Context, p *influxdb.NotificationPreferences) error {
+	return s.kv.Update(ctx, func(tx Tx) error {
+		return s.putNotificationPreferences(ctx, tx, p)
+	})
+}
+
+func (s *Service) putNotificationPreferences(ctx context.Context, tx Tx, p *influxdb.NotificationPreferences) error {
+	if err := p.Valid(); err != nil {
+		return err
+	}
+	if _, err := s.findUserByID(ctx, tx, p.UserID); err != nil {
+		return err
+	}
+	if p.PreferredEndpointID != nil {
+		if _, err := s.findNotificationEndpointByID(ctx, tx, *p.PreferredEndpointID); err != nil {
+			return &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "preferred notification endpoint not found",
+				Err:  err,
+			}
+		}
+	}
+
+	now := s.TimeGenerator.Now()
+	p.CreatedAt = now
+	if old, err := s.findNotificationPreferences(ctx, tx, p.UserID); err == nil {
+		p.CreatedAt = old.CreatedAt
+	}
+	p.UpdatedAt = now
+
+	encID, _ := p.UserID.Encode()
+	v, err := json.Marshal(p)
+	if err != nil {
+		return InternalNotificationPreferencesStoreError(err)
+	}
+	bucket, err := tx.Bucket(notificationPreferencesBucket)
+	if err != nil {
+		return UnavailableNotificationPreferencesStoreError(err)
+	}
+	if err := bucket.Put(encID, v); err != nil {
+		return UnavailableNotificationPreferencesStoreError(err)
+	}
+	return nil
+}
+
+// DeleteNotificationPreferences removes the notification preferences of a user.
+func (s *Service) DeleteNotificationPreferences(ctx context.Context, userID influxdb.ID) error {
+	return s.kv.Update(ctx, func(tx Tx) error {
+		if _, err := s.findNotificationPreferences(ctx, tx, userID); err != nil {
+			return err
+		}
+		return s.deleteNotificationPreferences(ctx, tx, userID)
+	})
+}
+
+func (s *Service) deleteNotificationPreferences(ctx context.Context, tx Tx, userID influxdb.ID) error {
+	encID, err := userID.Encode()
+	if err != nil {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Err:  err,
+		}
+	}
+	bucket, err := tx.Bucket(notificationPreferencesBucket)
+	if err != nil {
+		return UnavailableNotificationPreferencesStoreError(err)
+	}
+	if err := bucket.Delete(encID); err != nil {
+		return UnavailableNotificationPreferencesStoreError(err)
+	}
+	return nil
+}
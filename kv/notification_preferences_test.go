@@ -0,0 +1,87 @@
+package kv_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/kv"
+	"github.com/influxdata/influxdb/mock"
+	influxdbtesting "github.com/influxdata/influxdb/testing"
+)
+
+func TestBoltNotificationPreferencesService(t *testing.T) {
+	influxdbtesting.NotificationPreferencesService(initBoltNotificationPreferencesService, t)
+}
+
+func TestInmemNotificationPreferencesService(t *testing.T) {
+	influxdbtesting.NotificationPreferencesService(initInmemNotificationPreferencesService, t)
+}
+
+func initBoltNotificationPreferencesService(f influxdbtesting.NotificationPreferencesFields, t *testing.T) (influxdb.NotificationPreferencesService, func()) {
+	s, closeBolt, err := NewTestBoltStore()
+	if err != nil {
+		t.Fatalf("failed to create new kv store: %v", err)
+	}
+
+	svc, closeSvc := initNotificationPreferencesService(s, f, t)
+	return svc, func() {
+		closeSvc()
+		closeBolt()
+	}
+}
+
+func initInmemNotificationPreferencesService(f influxdbtesting.NotificationPreferencesFields, t *testing.T) (influxdb.NotificationPreferencesService, func()) {
+	s, closeBolt, err := NewTestInmemStore()
+	if err != nil {
+		t.Fatalf("failed to create new kv store: %v", err)
+	}
+
+	svc, closeSvc := initNotificationPreferencesService(s, f, t)
+	return svc, func() {
+		closeSvc()
+		closeBolt()
+	}
+}
+
+func initNotificationPreferencesService(s kv.Store, f influxdbtesting.NotificationPreferencesFields, t *testing.T) (influxdb.NotificationPreferencesService, func()) {
+	svc := kv.NewService(s)
+
+	ctx := context.Background()
+	if err := svc.Initialize(ctx); err != nil {
+		t.Fatalf("error initializing notification preferences service: %v", err)
+	}
+
+	for _, u := range f.Users {
+		if err := svc.PutUser(ctx, u); err != nil {
+			t.Fatalf("failed to populate user: %v", err)
+		}
+	}
+
+	for _, edp := range f.NotificationEndpoints {
+		if err := svc.PutNotificationEndpoint(ctx, edp); err != nil {
+			t.Fatalf("failed to populate notification endpoint: %v", err)
+		}
+	}
+
+	// prepopulated preferences are stored at the time of their creation.
+	for _, p := range f.NotificationPreferences {
+		svc.TimeGenerator = mock.TimeGenerator{FakeValue: p.CreatedAt}
+		if err := svc.PutNotificationPreferences(ctx, p); err != nil {
+			t.Fatalf("failed to populate notification preferences: %v", err)
+		}
+	}
+
+	svc.TimeGenerator = f.TimeGenerator
+	if f.TimeGenerator == nil {
+		svc.TimeGenerator = influxdb.RealTimeGenerator{}
+	}
+
+	return svc, func() {
+		for _, u := range f.Users {
+			if err := svc.DeleteUser(ctx, u.ID); err != nil {
+				t.Logf("failed to remove user: %v", err)
+			}
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/influxdata/influxdb/notification/rule"
 
 	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/alerting/page"
 )
 
 var (
@@ -33,7 +34,11 @@ func (s *Service) initializeNotificationRule(ctx context.Context, tx Tx) error {
 	if _, err := s.notificationRuleBucket(tx); err != nil {
 		return err
 	}
-	return nil
+	return notificationRuleIndex.initialize(tx, func(fn func(orgID, id influxdb.ID, name string) bool) error {
+		return s.forEachNotificationRule(ctx, tx, false, func(nr influxdb.NotificationRule) bool {
+			return fn(nr.GetOrgID(), nr.GetID(), nr.GetName())
+		})
+	})
 }
 
 // UnavailableNotificationRuleStoreError is used if we aren't able to interact with the
@@ -67,16 +72,30 @@ func (s *Service) notificationRuleBucket(tx Tx) (Bucket, error) {
 // CreateNotificationRule creates a new notification rule and sets b.ID with the new identifier.
 func (s *Service) CreateNotificationRule(ctx context.Context, nr influxdb.NotificationRule, userID influxdb.ID) error {
 	return s.kv.Update(ctx, func(tx Tx) error {
-		return s.createNotificationRule(ctx, tx, nr, userID)
+		return s.createNotificationRule(ctx, tx, nr, s.IDGenerator.ID(), userID)
 	})
 }
 
-func (s *Service) createNotificationRule(ctx context.Context, tx Tx, nr influxdb.NotificationRule, userID influxdb.ID) error {
-	id := s.IDGenerator.ID()
+func (s *Service) createNotificationRule(ctx context.Context, tx Tx, nr influxdb.NotificationRule, id, userID influxdb.ID) error {
+	if err := s.enforceAlertingQuota(ctx, tx, nr.GetOrgID(), influxdb.NotificationRuleResourceType, 1); err != nil {
+		return err
+	}
+	if err := s.applyAlertingSettingsToNotificationRule(ctx, tx, nr); err != nil {
+		return err
+	}
+	if err := s.validNotificationRuleEndpoint(ctx, tx, nr); err != nil {
+		return err
+	}
 	nr.SetID(id)
 	now := s.TimeGenerator.Now()
 	nr.SetCreatedAt(now)
 	nr.SetUpdatedAt(now)
+	if err := notificationRuleIndex.unique(tx, nr.GetOrgID(), nr.GetName(), id); err != nil {
+		return err
+	}
+	if err := notificationRuleIndex.put(tx, nr.GetOrgID(), nr.GetName(), id); err != nil {
+		return err
+	}
 	if err := s.putNotificationRule(ctx, tx, nr); err != nil {
 		return err
 	}
@@ -110,6 +129,12 @@ func (s *Service) updateNotificationRule(ctx context.Context, tx Tx, id influxdb
 	// ID and OrganizationID can not be updated
 	nr.SetID(current.GetID())
 	nr.SetOrgID(current.GetOrgID())
+	if err := s.validNotificationRuleEndpoint(ctx, tx, nr); err != nil {
+		return nil, err
+	}
+	if err := notificationRuleIndex.rename(tx, current.GetOrgID(), current.GetName(), nr.GetOrgID(), nr.GetName(), id); err != nil {
+		return nil, err
+	}
 	nr.SetCreatedAt(current.GetCRUDLog().CreatedAt)
 	nr.SetUpdatedAt(s.TimeGenerator.Now())
 	err = s.putNotificationRule(ctx, tx, nr)
@@ -140,6 +165,9 @@ func (s *Service) patchNotificationRule(ctx context.Context, tx Tx, id influxdb.
 	}
 
 	if upd.Name != nil {
+		if err := notificationRuleIndex.rename(tx, nr.GetOrgID(), nr.GetName(), nr.GetOrgID(), *upd.Name, id); err != nil {
+			return nil, err
+		}
 		nr.SetName(*upd.Name)
 	}
 	if upd.Description != nil {
@@ -160,6 +188,9 @@ func (s *Service) patchNotificationRule(ctx context.Context, tx Tx, id influxdb.
 // PutNotificationRule put a notification rule to storage.
 func (s *Service) PutNotificationRule(ctx context.Context, nr influxdb.NotificationRule) error {
 	return s.kv.Update(ctx, func(tx Tx) (err error) {
+		if err := notificationRuleIndex.put(tx, nr.GetOrgID(), nr.GetName(), nr.GetID()); err != nil {
+			return err
+		}
 		return s.putNotificationRule(ctx, tx, nr)
 	})
 }
@@ -245,9 +276,9 @@ func (s *Service) findNotificationRules(ctx context.Context, tx Tx, filter influ
 		return nrs, 0, nil
 	}
 
-	idMap := make(map[influxdb.ID]bool)
+	ids := make(map[influxdb.ID]bool, len(m))
 	for _, item := range m {
-		idMap[item.ResourceID] = false
+		ids[item.ResourceID] = true
 	}
 
 	if filter.OrgID != nil || filter.Organization != nil {
@@ -262,30 +293,26 @@ func (s *Service) findNotificationRules(ctx context.Context, tx Tx, filter influ
 		filter.OrgID = &o.ID
 	}
 
-	var offset, limit, count int
-	var descending bool
+	var opts influxdb.FindOptions
 	if len(opt) > 0 {
-		offset = opt[0].Offset
-		limit = opt[0].Limit
-		descending = opt[0].Descending
-	}
-	filterFn := filterNotificationRulesFn(idMap, filter)
-	err = s.forEachNotificationRule(ctx, tx, descending, func(nr influxdb.NotificationRule) bool {
-		if filterFn(nr) {
-			if count >= offset {
-				nrs = append(nrs, nr)
-			}
-			count++
-		}
-
-		if limit > 0 && len(nrs) >= limit {
-			return false
-		}
-
-		return true
-	})
+		opts = opt[0]
+	}
+	it := func(fn func(influxdb.Getter) bool) error {
+		return s.forEachNotificationRule(ctx, tx, false, func(nr influxdb.NotificationRule) bool { return fn(nr) })
+	}
+	filters := []page.Filter{page.IDs(ids)}
+	if filter.OrgID != nil {
+		filters = append(filters, page.OrgID(*filter.OrgID))
+	}
+	rs, err := page.Find(it, opts, filters...)
+	if err != nil {
+		return nil, 0, err
+	}
 
-	return nrs, len(nrs), err
+	for _, r := range rs {
+		nrs = append(nrs, r.(influxdb.NotificationRule))
+	}
+	return nrs, len(nrs), nil
 }
 
 // forEachNotificationRule will iterate through all notification rules while fn returns true.
@@ -327,22 +354,6 @@ func (s *Service) forEachNotificationRule(ctx context.Context, tx Tx, descending
 	return nil
 }
 
-func filterNotificationRulesFn(
-	idMap map[influxdb.ID]bool,
-	filter influxdb.NotificationRuleFilter) func(nr influxdb.NotificationRule) bool {
-	if filter.OrgID != nil {
-		return func(nr influxdb.NotificationRule) bool {
-			_, ok := idMap[nr.GetID()]
-			return nr.GetOrgID() == *filter.OrgID && ok
-		}
-	}
-
-	return func(nr influxdb.NotificationRule) bool {
-		_, ok := idMap[nr.GetID()]
-		return ok
-	}
-}
-
 // DeleteNotificationRule removes a notification rule by ID.
 func (s *Service) DeleteNotificationRule(ctx context.Context, id influxdb.ID) error {
 	return s.kv.Update(ctx, func(tx Tx) error {
@@ -361,17 +372,24 @@ func (s *Service) deleteNotificationRule(ctx context.Context, tx Tx, id influxdb
 		return err
 	}
 
-	_, err = bucket.Get(encodedID)
+	v, err := bucket.Get(encodedID)
 	if IsNotFound(err) {
 		return ErrNotificationRuleNotFound
 	}
 	if err != nil {
 		return InternalNotificationRuleStoreError(err)
 	}
+	nr, err := rule.UnmarshalJSON(v)
+	if err != nil {
+		return err
+	}
 
 	if err := bucket.Delete(encodedID); err != nil {
 		return InternalNotificationRuleStoreError(err)
 	}
+	if err := notificationRuleIndex.delete(tx, nr.GetOrgID(), nr.GetName(), id); err != nil {
+		return err
+	}
 
 	return s.deleteUserResourceMappings(ctx, tx, influxdb.UserResourceMappingFilter{
 		ResourceID:   id,
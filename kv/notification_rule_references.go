@@ -0,0 +1,238 @@
+package kv
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.NotificationEndpointCascader = (*Service)(nil)
+
+// endpointNotificationRule is a notification rule sending to endpoints,
+// its own and the ones of its routes.
+type endpointNotificationRule interface {
+	GetEndpointIDs() []influxdb.ID
+}
+
+// validNotificationRuleEndpoint returns an error if a notification endpoint
+// nr sends to doesn't exist or belongs to another organization.
+func (s *Service) validNotificationRuleEndpoint(ctx context.Context, tx Tx, nr influxdb.NotificationRule) error {
+	r, ok := nr.(endpointNotificationRule)
+	if !ok {
+		return nil
+	}
+	for _, id := range r.GetEndpointIDs() {
+		edp, err := s.findNotificationEndpointByID(ctx, tx, id)
+		if influxdb.ErrorCode(err) == influxdb.ENotFound {
+			return &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "notification endpoint of the notification rule not found",
+				Err:  err,
+			}
+		}
+		if err != nil {
+			return err
+		}
+		if edp.GetOrgID() != nr.GetOrgID() {
+			return &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "notification endpoint of the notification rule must belong to the organization of the rule",
+			}
+		}
+	}
+	return nil
+}
+
+// findNotificationEndpointRules returns the notification rules sending to the endpoint.
+func (s *Service) findNotificationEndpointRules(ctx context.Context, tx Tx, endpointID influxdb.ID) ([]influxdb.ID, error) {
+	var ids []influxdb.ID
+	err := s.forEachNotificationRule(ctx, tx, false, func(nr influxdb.NotificationRule) bool {
+		r, ok := nr.(endpointNotificationRule)
+		if !ok {
+			return true
+		}
+		for _, id := range r.GetEndpointIDs() {
+			if id == endpointID {
+				ids = append(ids, nr.GetID())
+				break
+			}
+		}
+		return true
+	})
+	return ids, err
+}
+
+// notificationEndpointInUse returns the conflict of deleting a notification
+// endpoint which notification rules send to, if any.
+func (s *Service) notificationEndpointInUse(ctx context.Context, tx Tx, endpointID influxdb.ID) error {
+	ids, err := s.findNotificationEndpointRules(ctx, tx, endpointID)
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	return &influxdb.Error{
+		Code: influxdb.EConflict,
+		Msg:  "notification endpoint is used by notification rules, delete them first or force the deletion to delete them too",
+		Err: &influxdb.NotificationEndpointInUseError{
+			EndpointID: endpointID,
+			RuleIDs:    ids,
+		},
+	}
+}
+
+// DeleteNotificationEndpointCascade removes a notification endpoint by ID and
+// the notification rules sending to it, and returns the removed notification rules.
+func (s *Service) DeleteNotificationEndpointCascade(ctx context.Context, id influxdb.ID) ([]influxdb.ID, error) {
+	var ids []influxdb.ID
+	err := s.kv.Update(ctx, func(tx Tx) error {
+		var err error
+		ids, err = s.deleteNotificationEndpointCascade(ctx, tx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (s *Service) deleteNotificationEndpointCascade(ctx context.Context, tx Tx, id influxdb.ID) ([]influxdb.ID, error) {
+	if _, err := s.findNotificationEndpointByID(ctx, tx, id); err != nil {
+		return nil, err
+	}
+	ids, err := s.findNotificationEndpointRules(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	for _, ruleID := range ids {
+		if err := s.deleteNotificationRule(ctx, tx, ruleID); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.deleteNotificationEndpoint(ctx, tx, id); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+var _ influxdb.NotificationEndpointReassigner = (*Service)(nil)
+
+// reassignableNotificationRule is a notification rule whose endpoint and
+// routes can be repointed to another endpoint.
+type reassignableNotificationRule interface {
+	GetEndpointID() *influxdb.ID
+	SetEndpointID(*influxdb.ID)
+	GetRoutes() []influxdb.NotificationRoute
+	SetRoutes([]influxdb.NotificationRoute)
+}
+
+// ReassignNotificationEndpoint repoints every notification rule sending to
+// the endpoint, by itself or by its routes, to the target endpoint, then
+// deletes or deactivates the endpoint, all at once.
+func (s *Service) ReassignNotificationEndpoint(ctx context.Context, id influxdb.ID, r influxdb.NotificationEndpointReassign) (*influxdb.NotificationEndpointReassignResult, error) {
+	var res *influxdb.NotificationEndpointReassignResult
+	err := s.kv.Update(ctx, func(tx Tx) error {
+		var err error
+		res, err = s.reassignNotificationEndpoint(ctx, tx, id, r)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (s *Service) reassignNotificationEndpoint(ctx context.Context, tx Tx, id influxdb.ID, r influxdb.NotificationEndpointReassign) (*influxdb.NotificationEndpointReassignResult, error) {
+	if err := r.Valid(); err != nil {
+		return nil, err
+	}
+	if r.TargetEndpointID == id {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "notification endpoint can't be reassigned to itself",
+		}
+	}
+	edp, err := s.findNotificationEndpointByID(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	target, err := s.findNotificationEndpointByID(ctx, tx, r.TargetEndpointID)
+	if influxdb.ErrorCode(err) == influxdb.ENotFound {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "target notification endpoint of the reassignment not found",
+			Err:  err,
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if target.GetOrgID() != edp.GetOrgID() {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "target notification endpoint must belong to the organization of the reassigned endpoint",
+		}
+	}
+	// the rules of a type only send to the endpoints of their type.
+	if target.Type() != edp.Type() {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("target notification endpoint must be a %s endpoint like the reassigned one, not a %s one", edp.Type(), target.Type()),
+		}
+	}
+
+	ids, err := s.findNotificationEndpointRules(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	now := s.TimeGenerator.Now()
+	for _, ruleID := range ids {
+		nr, err := s.findNotificationRuleByID(ctx, tx, ruleID)
+		if err != nil {
+			return nil, err
+		}
+		rr, ok := nr.(reassignableNotificationRule)
+		if !ok {
+			continue
+		}
+		if eid := rr.GetEndpointID(); eid != nil && *eid == id {
+			targetID := r.TargetEndpointID
+			rr.SetEndpointID(&targetID)
+		}
+		routes := make([]influxdb.NotificationRoute, len(rr.GetRoutes()))
+		for i, route := range rr.GetRoutes() {
+			if route.EndpointID == id {
+				route.EndpointID = r.TargetEndpointID
+			}
+			routes[i] = route
+		}
+		if len(routes) > 0 {
+			rr.SetRoutes(routes)
+		}
+		nr.SetUpdatedAt(now)
+		if err := s.putNotificationRule(ctx, tx, nr); err != nil {
+			return nil, err
+		}
+	}
+
+	res := &influxdb.NotificationEndpointReassignResult{
+		NotificationRuleIDs: ids,
+		Deleted:             r.Delete,
+	}
+	if res.NotificationRuleIDs == nil {
+		res.NotificationRuleIDs = []influxdb.ID{}
+	}
+	if r.Delete {
+		if err := s.deleteNotificationEndpoint(ctx, tx, id); err != nil {
+			return nil, err
+		}
+		return res, nil
+	}
+	inactive := influxdb.Inactive
+	if _, err := s.patchNotificationEndpoint(ctx, tx, id, influxdb.NotificationEndpointUpdate{Status: &inactive}); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
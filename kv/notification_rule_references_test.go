@@ -0,0 +1,235 @@
+package kv_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification/endpoint"
+	"github.com/influxdata/influxdb/notification/rule"
+)
+
+func TestService_DeleteNotificationEndpoint_inUse(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestService(t)
+
+	for _, edp := range []influxdb.NotificationEndpoint{
+		&endpoint.Slack{
+			Base: endpoint.Base{ID: 1, Name: "slack", OrgID: 10, Status: influxdb.Active},
+			URL:  "https://hooks.slack.com/services/1",
+		},
+		&endpoint.Slack{
+			Base: endpoint.Base{ID: 2, Name: "unused", OrgID: 10, Status: influxdb.Active},
+			URL:  "https://hooks.slack.com/services/2",
+		},
+	} {
+		if err := svc.PutNotificationEndpoint(ctx, edp); err != nil {
+			t.Fatalf("failed to populate notification endpoint: %v", err)
+		}
+	}
+
+	var ruleIDs []influxdb.ID
+	for i, name := range []string{"cpu", "disk"} {
+		endpointID := influxdb.ID(1)
+		nr := &rule.Slack{
+			Base: rule.Base{
+				ID:              influxdb.ID(20 + i),
+				Name:            name,
+				OrgID:           10,
+				AuthorizationID: 30,
+				EndpointID:      &endpointID,
+				Status:          influxdb.Active,
+				Every:           influxdb.Duration{Duration: time.Minute},
+			},
+			Channel:         "#ops",
+			MessageTemplate: "{{ .Level }}",
+		}
+		if name == "disk" {
+			// a rule routing to the endpoint uses it too.
+			nr.EndpointID = nil
+			nr.Routes = []influxdb.NotificationRoute{{EndpointID: endpointID, Days: []string{"sat", "sun"}}}
+		}
+		if err := svc.PutNotificationRule(ctx, nr); err != nil {
+			t.Fatalf("failed to populate notification rule: %v", err)
+		}
+		ruleIDs = append(ruleIDs, nr.ID)
+	}
+
+	err := svc.DeleteNotificationEndpoint(ctx, 1)
+	if influxdb.ErrorCode(err) != influxdb.EConflict {
+		t.Fatalf("expected the deletion of an endpoint in use to conflict, got %v", err)
+	}
+	blocking, ok := influxdb.NotificationEndpointInUse(err)
+	if !ok {
+		t.Fatalf("expected the conflict to list the blocking notification rules, got %v", err)
+	}
+	if diff := cmp.Diff(ruleIDs, blocking); diff != "" {
+		t.Errorf("unexpected blocking notification rules -want/+got\n%s", diff)
+	}
+	if _, err := svc.FindNotificationEndpointByID(ctx, 1); err != nil {
+		t.Errorf("expected the endpoint in use to be kept, got %v", err)
+	}
+
+	if err := svc.DeleteNotificationEndpoint(ctx, 2); err != nil {
+		t.Errorf("expected the unused endpoint to be deleted, got %v", err)
+	}
+
+	deleted, err := svc.DeleteNotificationEndpointCascade(ctx, 1)
+	if err != nil {
+		t.Fatalf("failed to delete the endpoint and its notification rules: %v", err)
+	}
+	if diff := cmp.Diff(ruleIDs, deleted); diff != "" {
+		t.Errorf("unexpected deleted notification rules -want/+got\n%s", diff)
+	}
+	if _, err := svc.FindNotificationEndpointByID(ctx, 1); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Errorf("expected the endpoint to be deleted, got %v", err)
+	}
+	for _, id := range ruleIDs {
+		if _, err := svc.FindNotificationRuleByID(ctx, id); influxdb.ErrorCode(err) != influxdb.ENotFound {
+			t.Errorf("expected notification rule %s to be deleted, got %v", id, err)
+		}
+	}
+}
+
+func TestService_UpdateNotificationRule_endpointOfAnotherOrg(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestService(t)
+
+	edp := &endpoint.Slack{
+		Base: endpoint.Base{ID: 1, Name: "slack", OrgID: 11, Status: influxdb.Active},
+		URL:  "https://hooks.slack.com/services/1",
+	}
+	if err := svc.PutNotificationEndpoint(ctx, edp); err != nil {
+		t.Fatalf("failed to populate notification endpoint: %v", err)
+	}
+	nr := &rule.Slack{
+		Base: rule.Base{
+			ID:              20,
+			Name:            "cpu",
+			OrgID:           10,
+			AuthorizationID: 30,
+			Status:          influxdb.Active,
+			Every:           influxdb.Duration{Duration: time.Minute},
+		},
+		Channel:         "#ops",
+		MessageTemplate: "{{ .Level }}",
+	}
+	if err := svc.PutNotificationRule(ctx, nr); err != nil {
+		t.Fatalf("failed to populate notification rule: %v", err)
+	}
+
+	endpointID := influxdb.ID(1)
+	nr.EndpointID = &endpointID
+	if _, err := svc.UpdateNotificationRule(ctx, 20, nr, 40); influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Errorf("expected the rule not to send to an endpoint of another org, got %v", err)
+	}
+}
+
+func TestService_ReassignNotificationEndpoint(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestService(t)
+
+	for _, edp := range []influxdb.NotificationEndpoint{
+		&endpoint.Slack{
+			Base: endpoint.Base{ID: 1, Name: "old workspace", OrgID: 10, Status: influxdb.Active},
+			URL:  "https://hooks.slack.com/services/1",
+		},
+		&endpoint.Slack{
+			Base: endpoint.Base{ID: 2, Name: "new workspace", OrgID: 10, Status: influxdb.Active},
+			URL:  "https://hooks.slack.com/services/2",
+		},
+		&endpoint.PagerDuty{
+			Base:       endpoint.Base{ID: 3, Name: "pagerduty", OrgID: 10, Status: influxdb.Active},
+			ClientURL:  "https://events.pagerduty.com/v2/enqueue",
+			RoutingKey: influxdb.SecretField{Key: "3-routing-key"},
+		},
+	} {
+		if err := svc.PutNotificationEndpoint(ctx, edp); err != nil {
+			t.Fatalf("failed to populate notification endpoint: %v", err)
+		}
+	}
+
+	oldID, otherID := influxdb.ID(1), influxdb.ID(4)
+	rules := []*rule.Slack{
+		{
+			Base: rule.Base{ID: 20, Name: "cpu", EndpointID: &oldID},
+		},
+		{
+			// a rule routing to the endpoint on weekends.
+			Base: rule.Base{ID: 21, Name: "disk", Routes: []influxdb.NotificationRoute{
+				{EndpointID: otherID, Days: []string{"mon"}},
+				{EndpointID: oldID, Days: []string{"sat", "sun"}},
+			}},
+		},
+		{
+			Base: rule.Base{ID: 22, Name: "mem", EndpointID: &otherID},
+		},
+	}
+	for _, nr := range rules {
+		nr.OrgID = 10
+		nr.AuthorizationID = 30
+		nr.Status = influxdb.Active
+		nr.Every = influxdb.Duration{Duration: time.Minute}
+		nr.Channel = "#ops"
+		nr.MessageTemplate = "{{ .Level }}"
+		if err := svc.PutNotificationRule(ctx, nr); err != nil {
+			t.Fatalf("failed to populate notification rule: %v", err)
+		}
+	}
+
+	for _, tt := range []struct {
+		name   string
+		target influxdb.ID
+	}{
+		{name: "itself", target: 1},
+		{name: "missing endpoint", target: 9},
+		{name: "endpoint of another type", target: 3},
+	} {
+		_, err := svc.ReassignNotificationEndpoint(ctx, 1, influxdb.NotificationEndpointReassign{TargetEndpointID: tt.target})
+		if influxdb.ErrorCode(err) != influxdb.EInvalid {
+			t.Errorf("expected the reassignment to %s to be invalid, got %v", tt.name, err)
+		}
+	}
+
+	res, err := svc.ReassignNotificationEndpoint(ctx, 1, influxdb.NotificationEndpointReassign{TargetEndpointID: 2})
+	if err != nil {
+		t.Fatalf("failed to reassign notification endpoint: %v", err)
+	}
+	if diff := cmp.Diff(&influxdb.NotificationEndpointReassignResult{NotificationRuleIDs: []influxdb.ID{20, 21}}, res); diff != "" {
+		t.Errorf("unexpected reassignment -want/+got\n%s", diff)
+	}
+	nr, err := svc.FindNotificationRuleByID(ctx, 20)
+	if err != nil {
+		t.Fatalf("failed to find notification rule: %v", err)
+	}
+	if id := nr.(*rule.Slack).EndpointID; id == nil || *id != 2 {
+		t.Errorf("expected the rule to send to the target endpoint, got %v", id)
+	}
+	nr, err = svc.FindNotificationRuleByID(ctx, 21)
+	if err != nil {
+		t.Fatalf("failed to find notification rule: %v", err)
+	}
+	if routes := nr.(*rule.Slack).Routes; routes[0].EndpointID != otherID || routes[1].EndpointID != 2 {
+		t.Errorf("expected the weekend route to send to the target endpoint, got %v", routes)
+	}
+	edp, err := svc.FindNotificationEndpointByID(ctx, 1)
+	if err != nil {
+		t.Fatalf("failed to find notification endpoint: %v", err)
+	}
+	if edp.GetStatus() != influxdb.Inactive {
+		t.Errorf("expected the reassigned endpoint to be deactivated, got %s", edp.GetStatus())
+	}
+
+	res, err = svc.ReassignNotificationEndpoint(ctx, 1, influxdb.NotificationEndpointReassign{TargetEndpointID: 2, Delete: true})
+	if err != nil {
+		t.Fatalf("failed to reassign notification endpoint: %v", err)
+	}
+	if !res.Deleted || len(res.NotificationRuleIDs) != 0 {
+		t.Errorf("unexpected reassignment %+v", res)
+	}
+	if _, err := svc.FindNotificationEndpointByID(ctx, 1); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Errorf("expected the reassigned endpoint to be deleted, got %v", err)
+	}
+}
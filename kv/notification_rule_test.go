@@ -56,6 +56,12 @@ func initNotificationRuleStore(s kv.Store, f influxdbtesting.NotificationRuleFie
 		t.Fatalf("error initializing user service: %v", err)
 	}
 
+	for _, edp := range f.NotificationEndpoints {
+		if err := svc.PutNotificationEndpoint(ctx, edp); err != nil {
+			t.Fatalf("failed to populate notification endpoint: %v", err)
+		}
+	}
+
 	for _, nr := range f.NotificationRules {
 		if err := svc.PutNotificationRule(ctx, nr); err != nil {
 			t.Fatalf("failed to populate notification rule: %v", err)
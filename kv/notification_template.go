@@ -0,0 +1,455 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification"
+)
+
+var (
+	notificationTemplateBucket = []byte("notificationTemplatev1")
+	// notificationTemplateIndex maps the org id and name of a template to its id,
+	// names are unique within an org since templates are referenced by name.
+	notificationTemplateIndex = []byte("notificationTemplateIndexv1")
+
+	// ErrNotificationTemplateNotFound is used when the notification template is not found.
+	ErrNotificationTemplateNotFound = &influxdb.Error{
+		Msg:  "notification template not found",
+		Code: influxdb.ENotFound,
+	}
+
+	// ErrInvalidNotificationTemplateID is used when the service was provided
+	// an invalid ID format.
+	ErrInvalidNotificationTemplateID = &influxdb.Error{
+		Code: influxdb.EInvalid,
+		Msg:  "provided notification template ID has invalid format",
+	}
+)
+
+var _ influxdb.NotificationTemplateService = (*Service)(nil)
+
+func (s *Service) initializeNotificationTemplate(ctx context.Context, tx Tx) error {
+	if _, err := tx.Bucket(notificationTemplateBucket); err != nil {
+		return err
+	}
+	if _, err := tx.Bucket(notificationTemplateIndex); err != nil {
+		return err
+	}
+	return nil
+}
+
+// UnavailableNotificationTemplateStoreError is used if we aren't able to interact with the
+// store, it means the store is not available at the moment (e.g. network).
+func UnavailableNotificationTemplateStoreError(err error) *influxdb.Error {
+	return &influxdb.Error{
+		Code: influxdb.EInternal,
+		Msg:  fmt.Sprintf("Unable to connect to notification template store service. Please try again; Err: %v", err),
+		Op:   "kv/notificationTemplate",
+	}
+}
+
+// InternalNotificationTemplateStoreError is used when the error comes from an
+// internal system.
+func InternalNotificationTemplateStoreError(err error) *influxdb.Error {
+	return &influxdb.Error{
+		Code: influxdb.EInternal,
+		Msg:  fmt.Sprintf("Unknown internal notification template data error; Err: %v", err),
+		Op:   "kv/notificationTemplate",
+	}
+}
+
+func notificationTemplateIndexKey(orgID influxdb.ID, name string) ([]byte, error) {
+	encOrgID, err := orgID.Encode()
+	if err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Err:  err,
+		}
+	}
+	k := make([]byte, 0, influxdb.IDLength+len(name))
+	k = append(k, encOrgID...)
+	return append(k, name...), nil
+}
+
+// FindNotificationTemplateByID returns a single notification template by ID.
+func (s *Service) FindNotificationTemplateByID(ctx context.Context, id influxdb.ID) (*influxdb.NotificationTemplate, error) {
+	var (
+		t   *influxdb.NotificationTemplate
+		err error
+	)
+
+	err = s.kv.View(ctx, func(tx Tx) error {
+		t, err = s.findNotificationTemplateByID(ctx, tx, id)
+		return err
+	})
+
+	return t, err
+}
+
+func (s *Service) findNotificationTemplateByID(ctx context.Context, tx Tx, id influxdb.ID) (*influxdb.NotificationTemplate, error) {
+	encID, err := id.Encode()
+	if err != nil {
+		return nil, ErrInvalidNotificationTemplateID
+	}
+
+	bucket, err := tx.Bucket(notificationTemplateBucket)
+	if err != nil {
+		return nil, UnavailableNotificationTemplateStoreError(err)
+	}
+
+	v, err := bucket.Get(encID)
+	if IsNotFound(err) {
+		return nil, ErrNotificationTemplateNotFound
+	}
+	if err != nil {
+		return nil, InternalNotificationTemplateStoreError(err)
+	}
+
+	t := &influxdb.NotificationTemplate{}
+	if err := json.Unmarshal(v, t); err != nil {
+		return nil, InternalNotificationTemplateStoreError(err)
+	}
+	return t, nil
+}
+
+// FindNotificationTemplates returns a list of notification templates that match filter and the total count of matching notification templates.
+// Additional options provide pagination & sorting.
+func (s *Service) FindNotificationTemplates(ctx context.Context, filter influxdb.NotificationTemplateFilter, opt ...influxdb.FindOptions) (ts []*influxdb.NotificationTemplate, n int, err error) {
+	err = s.kv.View(ctx, func(tx Tx) error {
+		ts, n, err = s.findNotificationTemplates(ctx, tx, filter, opt...)
+		return err
+	})
+	return ts, n, err
+}
+
+func (s *Service) findNotificationTemplates(ctx context.Context, tx Tx, filter influxdb.NotificationTemplateFilter, opt ...influxdb.FindOptions) ([]*influxdb.NotificationTemplate, int, error) {
+	ts := make([]*influxdb.NotificationTemplate, 0)
+
+	if filter.OrgID == nil && filter.Organization != nil {
+		o, err := s.findOrganizationByName(ctx, tx, *filter.Organization)
+		if err != nil {
+			return nil, 0, err
+		}
+		filter.OrgID = &o.ID
+	}
+
+	var offset, limit, count int
+	if len(opt) > 0 {
+		offset = opt[0].Offset
+		limit = opt[0].Limit
+	}
+	err := s.forEachNotificationTemplate(ctx, tx, filter.OrgID, func(t *influxdb.NotificationTemplate) bool {
+		if filter.Name != nil && t.Name != *filter.Name {
+			return true
+		}
+		if count >= offset {
+			ts = append(ts, t)
+		}
+		count++
+		return limit <= 0 || len(ts) < limit
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return ts, len(ts), nil
+}
+
+// forEachNotificationTemplate iterates through the notification templates of an org,
+// or all notification templates if orgID is nil, while fn returns true.
+func (s *Service) forEachNotificationTemplate(ctx context.Context, tx Tx, orgID *influxdb.ID, fn func(*influxdb.NotificationTemplate) bool) error {
+	if orgID == nil {
+		bkt, err := tx.Bucket(notificationTemplateBucket)
+		if err != nil {
+			return UnavailableNotificationTemplateStoreError(err)
+		}
+		cur, err := bkt.Cursor()
+		if err != nil {
+			return UnavailableNotificationTemplateStoreError(err)
+		}
+		for k, v := cur.First(); k != nil; k, v = cur.Next() {
+			t := &influxdb.NotificationTemplate{}
+			if err := json.Unmarshal(v, t); err != nil {
+				return InternalNotificationTemplateStoreError(err)
+			}
+			if !fn(t) {
+				break
+			}
+		}
+		return nil
+	}
+
+	prefix, err := orgID.Encode()
+	if err != nil {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Err:  err,
+		}
+	}
+	idx, err := tx.Bucket(notificationTemplateIndex)
+	if err != nil {
+		return UnavailableNotificationTemplateStoreError(err)
+	}
+	cur, err := idx.Cursor()
+	if err != nil {
+		return UnavailableNotificationTemplateStoreError(err)
+	}
+	for k, v := cur.Seek(prefix); bytes.HasPrefix(k, prefix); k, v = cur.Next() {
+		var id influxdb.ID
+		if err := id.Decode(v); err != nil {
+			return InternalNotificationTemplateStoreError(err)
+		}
+		t, err := s.findNotificationTemplateByID(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		if !fn(t) {
+			break
+		}
+	}
+	return nil
+}
+
+// CreateNotificationTemplate creates a new notification template and sets t.ID with the new identifier.
+func (s *Service) CreateNotificationTemplate(ctx context.Context, t *influxdb.NotificationTemplate) error {
+	return s.kv.Update(ctx, func(tx Tx) error {
+		return s.createNotificationTemplate(ctx, tx, t)
+	})
+}
+
+func (s *Service) createNotificationTemplate(ctx context.Context, tx Tx, t *influxdb.NotificationTemplate) error {
+	if err := t.Valid(); err != nil {
+		return err
+	}
+	if err := s.uniqueNotificationTemplateName(ctx, tx, t); err != nil {
+		return err
+	}
+	if err := s.checkNotificationTemplatePartials(ctx, tx, t.OrgID, t, ""); err != nil {
+		return err
+	}
+
+	t.ID = s.IDGenerator.ID()
+	now := s.TimeGenerator.Now()
+	t.CreatedAt = now
+	t.UpdatedAt = now
+
+	if err := s.putNotificationTemplateIndex(ctx, tx, t); err != nil {
+		return err
+	}
+	return s.putNotificationTemplate(ctx, tx, t)
+}
+
+// uniqueNotificationTemplateName returns a conflict error if another template of the org has the same name.
+func (s *Service) uniqueNotificationTemplateName(ctx context.Context, tx Tx, t *influxdb.NotificationTemplate) error {
+	key, err := notificationTemplateIndexKey(t.OrgID, t.Name)
+	if err != nil {
+		return err
+	}
+	idx, err := tx.Bucket(notificationTemplateIndex)
+	if err != nil {
+		return UnavailableNotificationTemplateStoreError(err)
+	}
+	_, err = idx.Get(key)
+	if IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return InternalNotificationTemplateStoreError(err)
+	}
+	return &influxdb.Error{
+		Code: influxdb.EConflict,
+		Msg:  fmt.Sprintf("notification template with name %s already exists", t.Name),
+	}
+}
+
+// checkNotificationTemplatePartials checks the templates of an org would not reference
+// missing templates or each other in a cycle, once t is stored and the template
+// named removed is deleted.
+func (s *Service) checkNotificationTemplatePartials(ctx context.Context, tx Tx, orgID influxdb.ID, t *influxdb.NotificationTemplate, removed string) error {
+	partials := map[string]string{}
+	err := s.forEachNotificationTemplate(ctx, tx, &orgID, func(p *influxdb.NotificationTemplate) bool {
+		if t == nil || p.ID != t.ID {
+			partials[p.Name] = p.Template
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	delete(partials, removed)
+	if t != nil {
+		partials[t.Name] = t.Template
+	}
+	return notification.CheckPartials(partials)
+}
+
+// PutNotificationTemplate puts a notification template to storage.
+func (s *Service) PutNotificationTemplate(ctx context.Context, t *influxdb.NotificationTemplate) error {
+	return s.kv.Update(ctx, func(tx Tx) error {
+		if err := t.Valid(); err != nil {
+			return err
+		}
+		if err := s.putNotificationTemplateIndex(ctx, tx, t); err != nil {
+			return err
+		}
+		return s.putNotificationTemplate(ctx, tx, t)
+	})
+}
+
+func (s *Service) putNotificationTemplateIndex(ctx context.Context, tx Tx, t *influxdb.NotificationTemplate) error {
+	key, err := notificationTemplateIndexKey(t.OrgID, t.Name)
+	if err != nil {
+		return err
+	}
+	encID, err := t.ID.Encode()
+	if err != nil {
+		return ErrInvalidNotificationTemplateID
+	}
+	idx, err := tx.Bucket(notificationTemplateIndex)
+	if err != nil {
+		return UnavailableNotificationTemplateStoreError(err)
+	}
+	if err := idx.Put(key, encID); err != nil {
+		return UnavailableNotificationTemplateStoreError(err)
+	}
+	return nil
+}
+
+func (s *Service) deleteNotificationTemplateIndex(ctx context.Context, tx Tx, t *influxdb.NotificationTemplate) error {
+	key, err := notificationTemplateIndexKey(t.OrgID, t.Name)
+	if err != nil {
+		return err
+	}
+	idx, err := tx.Bucket(notificationTemplateIndex)
+	if err != nil {
+		return UnavailableNotificationTemplateStoreError(err)
+	}
+	if err := idx.Delete(key); err != nil {
+		return UnavailableNotificationTemplateStoreError(err)
+	}
+	return nil
+}
+
+func (s *Service) putNotificationTemplate(ctx context.Context, tx Tx, t *influxdb.NotificationTemplate) error {
+	encID, err := t.ID.Encode()
+	if err != nil {
+		return ErrInvalidNotificationTemplateID
+	}
+
+	v, err := json.Marshal(t)
+	if err != nil {
+		return InternalNotificationTemplateStoreError(err)
+	}
+
+	bucket, err := tx.Bucket(notificationTemplateBucket)
+	if err != nil {
+		return UnavailableNotificationTemplateStoreError(err)
+	}
+	if err := bucket.Put(encID, v); err != nil {
+		return UnavailableNotificationTemplateStoreError(err)
+	}
+	return nil
+}
+
+// UpdateNotificationTemplate updates a single notification template with changeset.
+// Returns the new notification template state after update.
+func (s *Service) UpdateNotificationTemplate(ctx context.Context, id influxdb.ID, upd influxdb.NotificationTemplateUpdate) (*influxdb.NotificationTemplate, error) {
+	var t *influxdb.NotificationTemplate
+	err := s.kv.Update(ctx, func(tx Tx) (err error) {
+		t, err = s.updateNotificationTemplate(ctx, tx, id, upd)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (s *Service) updateNotificationTemplate(ctx context.Context, tx Tx, id influxdb.ID, upd influxdb.NotificationTemplateUpdate) (*influxdb.NotificationTemplate, error) {
+	t, err := s.findNotificationTemplateByID(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	oldName := t.Name
+	upd.Apply(t)
+	if err := t.Valid(); err != nil {
+		return nil, err
+	}
+
+	renamed := t.Name != oldName
+	if renamed {
+		if err := s.uniqueNotificationTemplateName(ctx, tx, t); err != nil {
+			return nil, err
+		}
+	}
+	removed := ""
+	if renamed {
+		// templates still referencing the old name would break.
+		removed = oldName
+	}
+	if err := s.checkNotificationTemplatePartials(ctx, tx, t.OrgID, t, removed); err != nil {
+		return nil, err
+	}
+
+	if renamed {
+		old := *t
+		old.Name = oldName
+		if err := s.deleteNotificationTemplateIndex(ctx, tx, &old); err != nil {
+			return nil, err
+		}
+		if err := s.putNotificationTemplateIndex(ctx, tx, t); err != nil {
+			return nil, err
+		}
+	}
+
+	t.UpdatedAt = s.TimeGenerator.Now()
+	if err := s.putNotificationTemplate(ctx, tx, t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// DeleteNotificationTemplate removes a notification template by ID.
+// Templates referenced by other templates of the org can't be removed.
+func (s *Service) DeleteNotificationTemplate(ctx context.Context, id influxdb.ID) error {
+	return s.kv.Update(ctx, func(tx Tx) error {
+		return s.deleteNotificationTemplate(ctx, tx, id)
+	})
+}
+
+func (s *Service) deleteNotificationTemplate(ctx context.Context, tx Tx, id influxdb.ID) error {
+	t, err := s.findNotificationTemplateByID(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.checkNotificationTemplatePartials(ctx, tx, t.OrgID, nil, t.Name); err != nil {
+		return &influxdb.Error{
+			Code: influxdb.EConflict,
+			Msg:  fmt.Sprintf("notification template %s is used by other notification templates", t.Name),
+			Err:  err,
+		}
+	}
+
+	if err := s.deleteNotificationTemplateIndex(ctx, tx, t); err != nil {
+		return err
+	}
+
+	encID, err := id.Encode()
+	if err != nil {
+		return ErrInvalidNotificationTemplateID
+	}
+	bucket, err := tx.Bucket(notificationTemplateBucket)
+	if err != nil {
+		return UnavailableNotificationTemplateStoreError(err)
+	}
+	if err := bucket.Delete(encID); err != nil {
+		return UnavailableNotificationTemplateStoreError(err)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package kv_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/kv"
+	influxdbtesting "github.com/influxdata/influxdb/testing"
+)
+
+func TestBoltNotificationTemplateService(t *testing.T) {
+	influxdbtesting.NotificationTemplateService(initBoltNotificationTemplateService, t)
+}
+
+func TestInmemNotificationTemplateService(t *testing.T) {
+	influxdbtesting.NotificationTemplateService(initInmemNotificationTemplateService, t)
+}
+
+func initBoltNotificationTemplateService(f influxdbtesting.NotificationTemplateFields, t *testing.T) (influxdb.NotificationTemplateService, func()) {
+	s, closeBolt, err := NewTestBoltStore()
+	if err != nil {
+		t.Fatalf("failed to create new kv store: %v", err)
+	}
+
+	svc, closeSvc := initNotificationTemplateService(s, f, t)
+	return svc, func() {
+		closeSvc()
+		closeBolt()
+	}
+}
+
+func initInmemNotificationTemplateService(f influxdbtesting.NotificationTemplateFields, t *testing.T) (influxdb.NotificationTemplateService, func()) {
+	s, closeBolt, err := NewTestInmemStore()
+	if err != nil {
+		t.Fatalf("failed to create new kv store: %v", err)
+	}
+
+	svc, closeSvc := initNotificationTemplateService(s, f, t)
+	return svc, func() {
+		closeSvc()
+		closeBolt()
+	}
+}
+
+func initNotificationTemplateService(s kv.Store, f influxdbtesting.NotificationTemplateFields, t *testing.T) (influxdb.NotificationTemplateService, func()) {
+	svc := kv.NewService(s)
+	svc.IDGenerator = f.IDGenerator
+	svc.TimeGenerator = f.TimeGenerator
+	if f.TimeGenerator == nil {
+		svc.TimeGenerator = influxdb.RealTimeGenerator{}
+	}
+
+	ctx := context.Background()
+	if err := svc.Initialize(ctx); err != nil {
+		t.Fatalf("error initializing notification template service: %v", err)
+	}
+
+	for _, o := range f.Orgs {
+		if err := svc.PutOrganization(ctx, o); err != nil {
+			t.Fatalf("failed to populate org: %v", err)
+		}
+	}
+
+	for _, tmpl := range f.NotificationTemplates {
+		if err := svc.PutNotificationTemplate(ctx, tmpl); err != nil {
+			t.Fatalf("failed to populate notification template: %v", err)
+		}
+	}
+
+	return svc, func() {
+		for _, o := range f.Orgs {
+			if err := svc.DeleteOrganization(ctx, o.ID); err != nil {
+				t.Logf("failed to remove org: %v", err)
+			}
+		}
+	}
+}
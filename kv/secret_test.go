@@ -60,3 +60,33 @@ func initSecretService(s kv.Store, f influxdbtesting.SecretServiceFields, t *tes
 
 	return svc, func() {}
 }
+
+func TestService_LoadSecret_ValueLength(t *testing.T) {
+	s, closeStore, err := NewTestInmemStore()
+	if err != nil {
+		t.Fatalf("failed to create new kv store: %v", err)
+	}
+	defer closeStore()
+
+	ctx := context.Background()
+	svc := kv.NewService(s)
+	if err := svc.Initialize(ctx); err != nil {
+		t.Fatalf("error initializing secret service: %v", err)
+	}
+
+	// the base64 of the values of these lengths is padded, the decoded
+	// values must not keep the NUL bytes of the padding.
+	orgID := influxdb.ID(1)
+	for _, v := range []string{"a", "ab", "abc", "abcd", "xoxb-token"} {
+		if err := svc.PutSecret(ctx, orgID, "key", v); err != nil {
+			t.Fatalf("failed to put secret: %v", err)
+		}
+		got, err := svc.LoadSecret(ctx, orgID, "key")
+		if err != nil {
+			t.Fatalf("failed to load secret: %v", err)
+		}
+		if got != v {
+			t.Errorf("expected secret %q, got %q", v, got)
+		}
+	}
+}
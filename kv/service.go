@@ -28,6 +28,13 @@ type Service struct {
 	TokenGenerator influxdb.TokenGenerator
 	influxdb.TimeGenerator
 	Hash Crypt
+
+	// CheckPauseNotifier tells the owners of the checks paused by the
+	// deletion of a bucket, they aren't told when nil.
+	CheckPauseNotifier influxdb.CheckPauseNotifier
+	// CheckGCNotifier tells the owners of the checks marked by the garbage
+	// collection, they aren't told when nil.
+	CheckGCNotifier influxdb.CheckGCNotifier
 }
 
 // NewService returns an instance of a Service.
@@ -37,9 +44,9 @@ func NewService(kv Store, configs ...ServiceConfig) *Service {
 		IDGenerator:    snowflake.NewIDGenerator(),
 		TokenGenerator: rand.NewTokenGenerator(64),
 		Hash:           &Bcrypt{},
-		kv:             kv,
 		TimeGenerator:  influxdb.RealTimeGenerator{},
 	}
+	s.kv = &dryRunStore{Store: kv, service: s}
 
 	if len(configs) > 0 {
 		s.Config = configs[0]
@@ -53,6 +60,14 @@ func NewService(kv Store, configs ...ServiceConfig) *Service {
 // ServiceConfig allows us to configure Services
 type ServiceConfig struct {
 	SessionLength time.Duration
+	// NotificationExecCommands are the command lines exec notification
+	// endpoints are allowed to run, exec endpoints are disabled when empty.
+	NotificationExecCommands []string
+	// CheckNamePolicy constrains the names of the checks created or renamed.
+	CheckNamePolicy influxdb.CheckNamePolicy
+	// AlertingQuotas bound the numbers of checks, notification rules and
+	// notification endpoints of each organization.
+	AlertingQuotas influxdb.AlertingQuotas
 }
 
 // Initialize creates Buckets needed.
@@ -130,6 +145,74 @@ func (s *Service) Initialize(ctx context.Context) error {
 			return err
 		}
 
+		if err := s.initializeNotificationEndpoint(ctx, tx); err != nil {
+			return err
+		}
+
+		if err := s.initializeNotificationTemplate(ctx, tx); err != nil {
+			return err
+		}
+
+		if err := s.initializeNotificationPreferences(ctx, tx); err != nil {
+			return err
+		}
+
+		if err := s.initializeNotificationBudgets(ctx, tx); err != nil {
+			return err
+		}
+
+		if err := s.initializeAlertingSettings(ctx, tx); err != nil {
+			return err
+		}
+
+		if err := s.initializeAlertingPauses(ctx, tx); err != nil {
+			return err
+		}
+
+		if err := s.initializeSilences(ctx, tx); err != nil {
+			return err
+		}
+
+		if err := s.initializeMonitoringTemplates(ctx, tx); err != nil {
+			return err
+		}
+
+		if err := s.initializeChecks(ctx, tx); err != nil {
+			return err
+		}
+
+		if err := s.initializeCheckEvents(ctx, tx); err != nil {
+			return err
+		}
+
+		if err := s.initializeCheckManagedFields(ctx, tx); err != nil {
+			return err
+		}
+
+		if err := s.initializeStatusTraces(ctx, tx); err != nil {
+			return err
+		}
+
+		if err := s.initializeLeases(ctx, tx); err != nil {
+			return err
+		}
+
+		if err := s.initializeCheckStates(ctx, tx); err != nil {
+			return err
+		}
+
+		if err := s.initializeCheckPings(ctx, tx); err != nil {
+			return err
+		}
+
+		if err := s.initializeCheckTaskReconciliations(ctx, tx); err != nil {
+			return err
+		}
+
+		if err := s.initializeCheckGC(ctx, tx); err != nil {
+			return err
+		}
+
 		return s.initializeUsers(ctx, tx)
 	})
 }
@@ -137,5 +220,5 @@ func (s *Service) Initialize(ctx context.Context) error {
 // WithStore sets kv store for the service.
 // Should only be used in tests for mocking.
 func (s *Service) WithStore(store Store) {
-	s.kv = store
+	s.kv = &dryRunStore{Store: store, service: s}
 }
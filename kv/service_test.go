@@ -2,6 +2,7 @@ package kv_test
 
 import (
 	"context"
+	"reflect"
 	"testing"
 	"time"
 
@@ -33,7 +34,7 @@ func TestNewService(t *testing.T) {
 
 	s = kv.NewService(mockStore{}, config)
 
-	if s.Config != config {
+	if !reflect.DeepEqual(s.Config, config) {
 		t.Errorf("Service config not set by constructor")
 	}
 }
@@ -0,0 +1,228 @@
+package kv
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/influxdata/influxdb"
+)
+
+var (
+	silenceBucket = []byte("silencesv1")
+
+	// ErrSilenceNotFound is used when the silence is not found.
+	ErrSilenceNotFound = &influxdb.Error{
+		Msg:  "silence not found",
+		Code: influxdb.ENotFound,
+	}
+
+	// ErrInvalidSilenceID is used when the service was provided
+	// an invalid ID format.
+	ErrInvalidSilenceID = &influxdb.Error{
+		Code: influxdb.EInvalid,
+		Msg:  "provided silence ID has invalid format",
+	}
+)
+
+var _ influxdb.SilenceService = (*Service)(nil)
+
+func (s *Service) initializeSilences(ctx context.Context, tx Tx) error {
+	if _, err := tx.Bucket(silenceBucket); err != nil {
+		return err
+	}
+	return nil
+}
+
+// UnavailableSilenceStoreError is used if we aren't able to interact with the
+// store, it means the store is not available at the moment (e.g. network).
+func UnavailableSilenceStoreError(err error) *influxdb.Error {
+	return &influxdb.Error{
+		Code: influxdb.EInternal,
+		Msg:  fmt.Sprintf("Unable to connect to silence store service. Please try again; Err: %v", err),
+		Op:   "kv/silence",
+	}
+}
+
+// InternalSilenceStoreError is used when the error comes from an
+// internal system.
+func InternalSilenceStoreError(err error) *influxdb.Error {
+	return &influxdb.Error{
+		Code: influxdb.EInternal,
+		Msg:  fmt.Sprintf("Unknown internal silence data error; Err: %v", err),
+		Op:   "kv/silence",
+	}
+}
+
+// FindSilenceByID returns a single silence by ID.
+func (s *Service) FindSilenceByID(ctx context.Context, id influxdb.ID) (*influxdb.Silence, error) {
+	var (
+		sl  *influxdb.Silence
+		err error
+	)
+
+	err = s.kv.View(ctx, func(tx Tx) error {
+		sl, err = s.findSilenceByID(ctx, tx, id)
+		return err
+	})
+
+	return sl, err
+}
+
+func (s *Service) findSilenceByID(ctx context.Context, tx Tx, id influxdb.ID) (*influxdb.Silence, error) {
+	encID, err := id.Encode()
+	if err != nil {
+		return nil, ErrInvalidSilenceID
+	}
+
+	bucket, err := tx.Bucket(silenceBucket)
+	if err != nil {
+		return nil, UnavailableSilenceStoreError(err)
+	}
+
+	v, err := bucket.Get(encID)
+	if IsNotFound(err) {
+		return nil, ErrSilenceNotFound
+	}
+	if err != nil {
+		return nil, InternalSilenceStoreError(err)
+	}
+
+	sl := &influxdb.Silence{}
+	if err := json.Unmarshal(v, sl); err != nil {
+		return nil, InternalSilenceStoreError(err)
+	}
+	return sl, nil
+}
+
+// FindSilences returns a list of silences that match filter and the total count of matching silences.
+// Additional options provide pagination & sorting.
+func (s *Service) FindSilences(ctx context.Context, filter influxdb.SilenceFilter, opt ...influxdb.FindOptions) (sls []*influxdb.Silence, n int, err error) {
+	err = s.kv.View(ctx, func(tx Tx) error {
+		sls, n, err = s.findSilences(ctx, tx, filter, opt...)
+		return err
+	})
+	return sls, n, err
+}
+
+func (s *Service) findSilences(ctx context.Context, tx Tx, filter influxdb.SilenceFilter, opt ...influxdb.FindOptions) ([]*influxdb.Silence, int, error) {
+	sls := make([]*influxdb.Silence, 0)
+
+	var offset, limit, count int
+	if len(opt) > 0 {
+		offset = opt[0].Offset
+		limit = opt[0].Limit
+	}
+
+	bucket, err := tx.Bucket(silenceBucket)
+	if err != nil {
+		return nil, 0, UnavailableSilenceStoreError(err)
+	}
+	cur, err := bucket.Cursor()
+	if err != nil {
+		return nil, 0, UnavailableSilenceStoreError(err)
+	}
+	for k, v := cur.First(); k != nil; k, v = cur.Next() {
+		sl := &influxdb.Silence{}
+		if err := json.Unmarshal(v, sl); err != nil {
+			return nil, 0, InternalSilenceStoreError(err)
+		}
+		if filter.OrgID != nil && sl.OrgID != *filter.OrgID {
+			continue
+		}
+		if filter.LabelID != nil && sl.LabelID != *filter.LabelID {
+			continue
+		}
+		if count >= offset {
+			sls = append(sls, sl)
+		}
+		count++
+		if limit > 0 && len(sls) >= limit {
+			break
+		}
+	}
+
+	return sls, len(sls), nil
+}
+
+// CreateSilence creates a new silence and sets sl.ID with the new identifier.
+// The label of the silence must belong to its organization, the silence
+// starts at its creation unless it has a start.
+func (s *Service) CreateSilence(ctx context.Context, sl *influxdb.Silence) error {
+	return s.kv.Update(ctx, func(tx Tx) error {
+		return s.createSilence(ctx, tx, sl)
+	})
+}
+
+func (s *Service) createSilence(ctx context.Context, tx Tx, sl *influxdb.Silence) error {
+	now := s.TimeGenerator.Now()
+	if sl.StartsAt.IsZero() {
+		sl.StartsAt = now
+	}
+	if err := sl.Valid(); err != nil {
+		return err
+	}
+
+	l, err := s.findLabelByID(ctx, tx, sl.LabelID)
+	if err != nil {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "label of the silence not found",
+			Err:  err,
+		}
+	}
+	if l.OrgID != sl.OrgID {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "label of the silence must belong to the organization of the silence",
+		}
+	}
+
+	sl.ID = s.IDGenerator.ID()
+	sl.CreatedAt = now
+	sl.UpdatedAt = now
+	return s.putSilence(ctx, tx, sl)
+}
+
+func (s *Service) putSilence(ctx context.Context, tx Tx, sl *influxdb.Silence) error {
+	encID, err := sl.ID.Encode()
+	if err != nil {
+		return ErrInvalidSilenceID
+	}
+
+	v, err := json.Marshal(sl)
+	if err != nil {
+		return InternalSilenceStoreError(err)
+	}
+
+	bucket, err := tx.Bucket(silenceBucket)
+	if err != nil {
+		return UnavailableSilenceStoreError(err)
+	}
+	if err := bucket.Put(encID, v); err != nil {
+		return UnavailableSilenceStoreError(err)
+	}
+	return nil
+}
+
+// DeleteSilence removes a silence by ID.
+func (s *Service) DeleteSilence(ctx context.Context, id influxdb.ID) error {
+	return s.kv.Update(ctx, func(tx Tx) error {
+		if _, err := s.findSilenceByID(ctx, tx, id); err != nil {
+			return err
+		}
+
+		encID, err := id.Encode()
+		if err != nil {
+			return ErrInvalidSilenceID
+		}
+		bucket, err := tx.Bucket(silenceBucket)
+		if err != nil {
+			return UnavailableSilenceStoreError(err)
+		}
+		if err := bucket.Delete(encID); err != nil {
+			return UnavailableSilenceStoreError(err)
+		}
+		return nil
+	})
+}
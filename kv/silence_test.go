@@ -0,0 +1,86 @@
+package kv_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/kv"
+	"github.com/influxdata/influxdb/mock"
+)
+
+func TestService_Silences(t *testing.T) {
+	s, closeStore, err := NewTestInmemStore()
+	if err != nil {
+		t.Fatalf("failed to create new kv store: %v", err)
+	}
+	defer closeStore()
+
+	ctx := context.Background()
+	now := time.Date(2019, 10, 1, 12, 0, 0, 0, time.UTC)
+	svc := kv.NewService(s)
+	svc.IDGenerator = mock.NewIDGenerator("020f755c3c082000", t)
+	svc.TimeGenerator = mock.TimeGenerator{FakeValue: now}
+	if err := svc.Initialize(ctx); err != nil {
+		t.Fatalf("error initializing kv service: %v", err)
+	}
+
+	for _, l := range []*influxdb.Label{
+		{ID: 1, OrgID: 10, Name: "team:payments"},
+		{ID: 2, OrgID: 11, Name: "team:payments"},
+	} {
+		if err := svc.PutLabel(ctx, l); err != nil {
+			t.Fatalf("failed to populate label: %v", err)
+		}
+	}
+
+	err = svc.CreateSilence(ctx, &influxdb.Silence{OrgID: 10, LabelID: 2, EndsAt: now.Add(time.Hour)})
+	if influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Errorf("expected the label of another organization to be rejected, got %v", err)
+	}
+	err = svc.CreateSilence(ctx, &influxdb.Silence{OrgID: 10, LabelID: 3, EndsAt: now.Add(time.Hour)})
+	if influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Errorf("expected a missing label to be rejected, got %v", err)
+	}
+	err = svc.CreateSilence(ctx, &influxdb.Silence{OrgID: 10, LabelID: 1, EndsAt: now.Add(-time.Hour)})
+	if influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Errorf("expected a silence ending before it starts to be rejected, got %v", err)
+	}
+
+	sl := &influxdb.Silence{OrgID: 10, LabelID: 1, Comment: "payments maintenance", EndsAt: now.Add(time.Hour)}
+	if err := svc.CreateSilence(ctx, sl); err != nil {
+		t.Fatalf("failed to create silence: %v", err)
+	}
+	if !sl.ID.Valid() || !sl.StartsAt.Equal(now) {
+		t.Errorf("expected the silence to have an id and to start at its creation, got %+v", sl)
+	}
+
+	got, err := svc.FindSilenceByID(ctx, sl.ID)
+	if err != nil {
+		t.Fatalf("failed to find silence: %v", err)
+	}
+	if got.Comment != sl.Comment || !got.Active(now) || got.Active(now.Add(time.Hour)) {
+		t.Errorf("unexpected silence %+v", got)
+	}
+
+	orgID := influxdb.ID(10)
+	sls, n, err := svc.FindSilences(ctx, influxdb.SilenceFilter{OrgID: &orgID})
+	if err != nil {
+		t.Fatalf("failed to find silences: %v", err)
+	}
+	if n != 1 || sls[0].ID != sl.ID {
+		t.Errorf("unexpected silences %v", sls)
+	}
+	otherOrgID := influxdb.ID(11)
+	if sls, _, err := svc.FindSilences(ctx, influxdb.SilenceFilter{OrgID: &otherOrgID}); err != nil || len(sls) != 0 {
+		t.Errorf("expected no silence of another organization, got %v, %v", sls, err)
+	}
+
+	if err := svc.DeleteSilence(ctx, sl.ID); err != nil {
+		t.Fatalf("failed to delete silence: %v", err)
+	}
+	if _, err := svc.FindSilenceByID(ctx, sl.ID); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Errorf("expected the silence to be deleted, got %v", err)
+	}
+}
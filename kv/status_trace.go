@@ -0,0 +1,139 @@
+package kv
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/influxdata/influxdb"
+)
+
+var (
+	statusTraceBucket = []byte("statustracesv1")
+
+	// ErrStatusTraceNotFound is used when the status has no decision trace.
+	ErrStatusTraceNotFound = &influxdb.Error{
+		Msg:  "status trace not found",
+		Code: influxdb.ENotFound,
+	}
+)
+
+var _ influxdb.StatusTraceService = (*Service)(nil)
+
+func (s *Service) initializeStatusTraces(ctx context.Context, tx Tx) error {
+	if _, err := tx.Bucket(statusTraceBucket); err != nil {
+		return err
+	}
+	return nil
+}
+
+// UnavailableStatusTraceStoreError is used if we aren't able to interact with the
+// store, it means the store is not available at the moment (e.g. network).
+func UnavailableStatusTraceStoreError(err error) *influxdb.Error {
+	return &influxdb.Error{
+		Code: influxdb.EInternal,
+		Msg:  fmt.Sprintf("Unable to connect to status trace store service. Please try again; Err: %v", err),
+		Op:   "kv/statusTrace",
+	}
+}
+
+// InternalStatusTraceStoreError is used when the error comes from an
+// internal system.
+func InternalStatusTraceStoreError(err error) *influxdb.Error {
+	return &influxdb.Error{
+		Code: influxdb.EInternal,
+		Msg:  fmt.Sprintf("Unknown internal status trace data error; Err: %v", err),
+		Op:   "kv/statusTrace",
+	}
+}
+
+// FindStatusTrace returns the decision trace of a status.
+func (s *Service) FindStatusTrace(ctx context.Context, statusID influxdb.ID) (*influxdb.StatusTrace, error) {
+	var (
+		t   *influxdb.StatusTrace
+		err error
+	)
+	err = s.kv.View(ctx, func(tx Tx) error {
+		t, err = s.findStatusTrace(ctx, tx, statusID)
+		return err
+	})
+	return t, err
+}
+
+func (s *Service) findStatusTrace(ctx context.Context, tx Tx, statusID influxdb.ID) (*influxdb.StatusTrace, error) {
+	encID, err := statusID.Encode()
+	if err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Err:  err,
+		}
+	}
+
+	bucket, err := tx.Bucket(statusTraceBucket)
+	if err != nil {
+		return nil, UnavailableStatusTraceStoreError(err)
+	}
+
+	v, err := bucket.Get(encID)
+	if IsNotFound(err) {
+		return nil, ErrStatusTraceNotFound
+	}
+	if err != nil {
+		return nil, InternalStatusTraceStoreError(err)
+	}
+
+	t := &influxdb.StatusTrace{}
+	if err := json.Unmarshal(v, t); err != nil {
+		return nil, InternalStatusTraceStoreError(err)
+	}
+	return t, nil
+}
+
+// CreateStatusTrace records the decision trace of a status.
+func (s *Service) CreateStatusTrace(ctx context.Context, t *influxdb.StatusTrace) error {
+	return s.kv.Update(ctx, func(tx Tx) error {
+		return s.createStatusTrace(ctx, tx, t)
+	})
+}
+
+func (s *Service) createStatusTrace(ctx context.Context, tx Tx, t *influxdb.StatusTrace) error {
+	if err := t.Valid(); err != nil {
+		return err
+	}
+
+	encID, _ := t.StatusID.Encode()
+	v, err := json.Marshal(t)
+	if err != nil {
+		return InternalStatusTraceStoreError(err)
+	}
+	bucket, err := tx.Bucket(statusTraceBucket)
+	if err != nil {
+		return UnavailableStatusTraceStoreError(err)
+	}
+	if err := bucket.Put(encID, v); err != nil {
+		return UnavailableStatusTraceStoreError(err)
+	}
+	return nil
+}
+
+// forEachStatusTrace iterates through the decision traces of every status while fn returns true.
+func (s *Service) forEachStatusTrace(ctx context.Context, tx Tx, fn func(*influxdb.StatusTrace) bool) error {
+	bucket, err := tx.Bucket(statusTraceBucket)
+	if err != nil {
+		return UnavailableStatusTraceStoreError(err)
+	}
+	cur, err := bucket.Cursor()
+	if err != nil {
+		return UnavailableStatusTraceStoreError(err)
+	}
+	for k, v := cur.First(); k != nil; k, v = cur.Next() {
+		t := &influxdb.StatusTrace{}
+		if err := json.Unmarshal(v, t); err != nil {
+			return InternalStatusTraceStoreError(err)
+		}
+		if !fn(t) {
+			break
+		}
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package kv_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+)
+
+func TestService_StatusTrace(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestService(t)
+
+	statusID := influxdb.ID(1)
+	if _, err := svc.FindStatusTrace(ctx, statusID); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Errorf("expected a missing status trace not to be found, got %v", err)
+	}
+	if err := svc.CreateStatusTrace(ctx, &influxdb.StatusTrace{StatusID: statusID}); influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Errorf("expected a status trace without check to be invalid, got %v", err)
+	}
+
+	endpointID := influxdb.ID(4)
+	trace := &influxdb.StatusTrace{
+		StatusID: statusID,
+		CheckID:  2,
+		OrgID:    3,
+		Level:    "CRIT",
+		Time:     time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC),
+		Rules: []influxdb.RuleTrace{
+			{RuleID: 5, RuleName: "crit to slack", Decision: influxdb.RuleNotified, EndpointID: &endpointID},
+			{RuleID: 6, RuleName: "prod to pagerduty", Decision: influxdb.RuleUnmatched, Reason: "the tags of the status don't match the tag rules"},
+		},
+	}
+	if err := svc.CreateStatusTrace(ctx, trace); err != nil {
+		t.Fatalf("failed to create status trace: %v", err)
+	}
+	got, err := svc.FindStatusTrace(ctx, statusID)
+	if err != nil {
+		t.Fatalf("failed to find status trace: %v", err)
+	}
+	if !reflect.DeepEqual(got, trace) {
+		t.Errorf("unexpected status trace\ngot  %+v\nwant %+v", got, trace)
+	}
+}
@@ -488,7 +488,7 @@ func (s *Service) createTask(ctx context.Context, tx Tx, tc influxdb.TaskCreate)
 		tc.Status = string(backend.TaskActive)
 	}
 
-	createdAt := time.Now().UTC().Format(time.RFC3339)
+	createdAt := s.TimeGenerator.Now().UTC().Format(time.RFC3339)
 	task := &influxdb.Task{
 		ID:              s.IDGenerator.ID(),
 		OrganizationID:  org.ID,
@@ -620,7 +620,7 @@ func (s *Service) updateTask(ctx context.Context, tx Tx, id influxdb.ID, upd inf
 		task.LatestCompleted = *upd.LatestCompleted
 	}
 
-	task.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+	task.UpdatedAt = s.TimeGenerator.Now().UTC().Format(time.RFC3339)
 	// save the updated task
 	bucket, err := tx.Bucket(taskBucket)
 	if err != nil {
@@ -1008,7 +1008,7 @@ func (s *Service) forceRun(ctx context.Context, tx Tx, taskID influxdb.ID, sched
 		ID:           s.IDGenerator.ID(),
 		TaskID:       taskID,
 		Status:       backend.RunScheduled.String(),
-		RequestedAt:  time.Now().UTC().Format(time.RFC3339),
+		RequestedAt:  s.TimeGenerator.Now().UTC().Format(time.RFC3339),
 		ScheduledFor: t.Format(time.RFC3339),
 		Log:          []influxdb.Log{},
 	}
@@ -1134,7 +1134,7 @@ func (s *Service) createNextRun(ctx context.Context, tx Tx, taskID influxdb.ID,
 			Created: backend.QueuedRun{
 				TaskID: taskID,
 				RunID:  mRun.ID,
-				DueAt:  time.Now().UTC().Unix(),
+				DueAt:  s.TimeGenerator.Now().UTC().Unix(),
 				Now:    schedFor.Unix(),
 			},
 			NextDue:  nextDue,
@@ -426,6 +426,10 @@ func (s *Service) deleteUser(ctx context.Context, tx Tx, id influxdb.ID) error {
 		return err
 	}
 
+	if err := s.deleteNotificationPreferences(ctx, tx, id); err != nil {
+		return err
+	}
+
 	return nil
 }
 
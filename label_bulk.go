@@ -0,0 +1,55 @@
+package influxdb
+
+import "context"
+
+// LabelBulkOperation applies a label to, or removes it from, every resource
+// of an organization matching a filter at once.
+type LabelBulkOperation struct {
+	// ResourceType is the type of the labeled resources, only checks are
+	// supported.
+	ResourceType ResourceType    `json:"resourceType"`
+	Filter       CheckBulkFilter `json:"filter"`
+	// DryRun returns the resources the operation would change without
+	// changing them.
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// Valid returns an error if the label bulk operation is invalid.
+func (o LabelBulkOperation) Valid() error {
+	if o.ResourceType != ChecksResourceType {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "label bulk operations only support the checks resource type",
+		}
+	}
+	if !o.Filter.OrgID.Valid() {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "label bulk operation requires a valid orgID",
+		}
+	}
+	return nil
+}
+
+// LabelBulkResult summarizes a label bulk operation.
+type LabelBulkResult struct {
+	LabelID      ID           `json:"labelID"`
+	ResourceType ResourceType `json:"resourceType"`
+	DryRun       bool         `json:"dryRun"`
+	// Matched is the number of the resources matching the filter.
+	Matched int `json:"matched"`
+	// Changed are the resources the label was applied to or removed from.
+	Changed []ID `json:"changed"`
+	// Unchanged is the number of the matching resources which already had
+	// the label applied, or didn't have it for a removal.
+	Unchanged int `json:"unchanged"`
+}
+
+// LabelBulkService applies labels to, and removes them from, the resources
+// matching a filter at once.
+type LabelBulkService interface {
+	// ApplyLabel applies a label to every matching resource in one operation.
+	ApplyLabel(ctx context.Context, labelID ID, o LabelBulkOperation) (*LabelBulkResult, error)
+	// RemoveLabel removes a label from every matching resource in one operation.
+	RemoveLabel(ctx context.Context, labelID ID, o LabelBulkOperation) (*LabelBulkResult, error)
+}
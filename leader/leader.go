@@ -0,0 +1,215 @@
+// Package leader elects a leader among the servers of a cluster sharing a
+// lease service, to run the singleton background jobs, such as requesting
+// the scrapes of the targets, on a single server at once.
+//
+// An elector campaigns for the lease of its key every interval, and runs its
+// job while it holds the lease. The lease of a leader which stopped expires
+// after its ttl, and the next elector campaigning for it becomes the leader.
+package leader
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// DefaultInterval is how often an elector campaigns for its lease and runs
+// its job when the operator doesn't configure one.
+const DefaultInterval = 10 * time.Second
+
+// Job is a singleton background job, run by the leader only.
+type Job func(ctx context.Context) error
+
+// Metrics are the metrics of the leadership of electors.
+// A single Metrics is shared by the electors of a server.
+type Metrics struct {
+	leader  *prometheus.GaugeVec
+	changes *prometheus.CounterVec
+}
+
+// NewMetrics returns the metrics of the leadership of electors.
+func NewMetrics() *Metrics {
+	const namespace = "alerting"
+	const subsystem = "leader"
+
+	return &Metrics{
+		leader: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "is_leader",
+			Help:      "Whether the server is the leader of the election, 1 if it is and 0 otherwise.",
+		}, []string{"election"}),
+
+		changes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "changes_total",
+			Help:      "Total number of times the server became or stopped being the leader of the election.",
+		}, []string{"election", "change"}),
+	}
+}
+
+// PrometheusCollectors satisfies the prom.PrometheusCollector interface.
+func (m *Metrics) PrometheusCollectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		m.leader,
+		m.changes,
+	}
+}
+
+// Elector elects the leader of the servers sharing its key.
+type Elector struct {
+	LeaseService influxdb.LeaseService
+	// Key is the key of the lease of the election, the name of the election
+	// in the metrics.
+	Key string
+	// Node identifies the server among the candidates.
+	Node string
+	// Interval is how often the elector campaigns for its lease and runs its job.
+	Interval time.Duration
+	// TTL is how long a leader which stopped campaigning stays the leader,
+	// it defaults to three intervals.
+	TTL     time.Duration
+	Metrics *Metrics
+	Logger  *zap.Logger
+
+	mu     sync.Mutex
+	leader bool
+}
+
+// NewElector returns an elector of the leader of the servers campaigning
+// for key with ls, as node.
+func NewElector(ls influxdb.LeaseService, key, node string) *Elector {
+	return &Elector{
+		LeaseService: ls,
+		Key:          key,
+		Node:         node,
+		Interval:     DefaultInterval,
+		Metrics:      NewMetrics(),
+		Logger:       zap.NewNop(),
+	}
+}
+
+func (e *Elector) interval() time.Duration {
+	if e.Interval <= 0 {
+		return DefaultInterval
+	}
+	return e.Interval
+}
+
+func (e *Elector) ttl() time.Duration {
+	if e.TTL <= 0 {
+		return 3 * e.interval()
+	}
+	return e.TTL
+}
+
+// IsLeader returns whether the elector was the leader at its latest campaign.
+func (e *Elector) IsLeader() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.leader
+}
+
+// Campaign acquires or renews the lease of the election, and returns
+// whether the elector is the leader. An elector failing to reach the lease
+// service isn't the leader, rather than risking two leaders.
+func (e *Elector) Campaign(ctx context.Context) (bool, error) {
+	l, err := e.LeaseService.AcquireLease(ctx, e.Key, e.Node, e.ttl())
+	leader := err == nil && l.Owner == e.Node
+	e.setLeader(leader)
+	return leader, err
+}
+
+// Resign releases the lease of the election, if the elector holds it, for
+// another server to become the leader right away.
+func (e *Elector) Resign(ctx context.Context) error {
+	if !e.IsLeader() {
+		return nil
+	}
+	e.setLeader(false)
+	return e.LeaseService.ReleaseLease(ctx, e.Key, e.Node)
+}
+
+func (e *Elector) setLeader(leader bool) {
+	e.mu.Lock()
+	changed := e.leader != leader
+	e.leader = leader
+	e.mu.Unlock()
+
+	if e.Metrics != nil {
+		v := 0.0
+		if leader {
+			v = 1
+		}
+		e.Metrics.leader.WithLabelValues(e.Key).Set(v)
+	}
+	if !changed {
+		return
+	}
+	change := "lost"
+	if leader {
+		change = "elected"
+	}
+	if e.Metrics != nil {
+		e.Metrics.changes.WithLabelValues(e.Key, change).Inc()
+	}
+	e.Logger.Info("leadership changed",
+		zap.String("election", e.Key),
+		zap.String("node", e.Node),
+		zap.String("change", change))
+}
+
+// Run campaigns for the lease of the election every interval, and runs job
+// after each campaign the elector won, until ctx is done. It then resigns.
+// The errors of the campaigns and of job are logged.
+func (e *Elector) Run(ctx context.Context, job Job) {
+	ticker := time.NewTicker(e.interval())
+	defer ticker.Stop()
+	for {
+		e.runOnce(ctx, job)
+		select {
+		case <-ctx.Done():
+			if err := e.Resign(context.Background()); err != nil {
+				e.Logger.Info("failed to resign", zap.String("election", e.Key), zap.Error(err))
+			}
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Once campaigns for the lease of the election once, and runs job and
+// resigns if the elector won, for the jobs run once at startup by a single
+// one of the servers starting together. It returns whether job ran, and the
+// error of the campaign or of job.
+func (e *Elector) Once(ctx context.Context, job Job) (bool, error) {
+	leader, err := e.Campaign(ctx)
+	if err != nil || !leader {
+		return false, err
+	}
+	defer func() {
+		if err := e.Resign(context.Background()); err != nil {
+			e.Logger.Info("failed to resign", zap.String("election", e.Key), zap.Error(err))
+		}
+	}()
+	return true, job(ctx)
+}
+
+func (e *Elector) runOnce(ctx context.Context, job Job) {
+	leader, err := e.Campaign(ctx)
+	if err != nil {
+		e.Logger.Info("failed to campaign", zap.String("election", e.Key), zap.Error(err))
+		return
+	}
+	if !leader {
+		return
+	}
+	if err := job(ctx); err != nil {
+		e.Logger.Info("failed to run job", zap.String("election", e.Key), zap.Error(err))
+	}
+}
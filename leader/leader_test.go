@@ -0,0 +1,159 @@
+package leader_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb/inmem"
+	"github.com/influxdata/influxdb/kv"
+	"github.com/influxdata/influxdb/leader"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestElector_Campaign(t *testing.T) {
+	ctx := context.Background()
+	svc := kv.NewService(inmem.NewKVStore())
+	if err := svc.Initialize(ctx); err != nil {
+		t.Fatalf("error initializing kv service: %v", err)
+	}
+	now := time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) {
+		svc.TimeGenerator = mock.TimeGenerator{FakeValue: now.Add(d)}
+	}
+
+	metrics := leader.NewMetrics()
+	newElector := func(node string) *leader.Elector {
+		e := leader.NewElector(svc, "alerting/digests", node)
+		e.Interval = time.Minute
+		e.Metrics = metrics
+		return e
+	}
+	a, b := newElector("a"), newElector("b")
+	campaign := func(e *leader.Elector, want bool) {
+		t.Helper()
+		got, err := e.Campaign(ctx)
+		if err != nil {
+			t.Fatalf("failed to campaign: %v", err)
+		}
+		if got != want || e.IsLeader() != want {
+			t.Fatalf("expected %s to be leader %v, got %v", e.Node, want, got)
+		}
+	}
+
+	at(0)
+	campaign(a, true)
+	campaign(b, false)
+
+	// a stops campaigning, b becomes the leader once its lease expires.
+	at(2 * time.Minute)
+	campaign(b, false)
+	at(3 * time.Minute)
+	campaign(b, true)
+	campaign(a, false)
+
+	if err := b.Resign(ctx); err != nil {
+		t.Fatalf("failed to resign: %v", err)
+	}
+	if b.IsLeader() {
+		t.Fatalf("expected b not to be the leader once it resigned")
+	}
+	campaign(a, true)
+
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(metrics.PrometheusCollectors()...)
+	mfs, err := reg.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	changes := make(map[string]float64)
+	for _, mf := range mfs {
+		if mf.GetName() != "alerting_leader_changes_total" {
+			continue
+		}
+		for _, m := range mf.Metric {
+			changes[label(m, "change")] += m.GetCounter().GetValue()
+		}
+	}
+	if changes["elected"] != 3 || changes["lost"] != 2 {
+		t.Errorf("unexpected leadership changes %v", changes)
+	}
+}
+
+func label(m *dto.Metric, name string) string {
+	for _, l := range m.Label {
+		if l.GetName() == name {
+			return l.GetValue()
+		}
+	}
+	return ""
+}
+
+func TestElector_Run(t *testing.T) {
+	svc := kv.NewService(inmem.NewKVStore())
+	if err := svc.Initialize(context.Background()); err != nil {
+		t.Fatalf("error initializing kv service: %v", err)
+	}
+	e := leader.NewElector(svc, "alerting/downsampling", "a")
+	e.Interval = time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ran := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		e.Run(ctx, func(ctx context.Context) error {
+			select {
+			case ran <- struct{}{}:
+			default:
+			}
+			return nil
+		})
+	}()
+	<-ran
+	cancel()
+	<-done
+	if e.IsLeader() {
+		t.Errorf("expected the elector to resign once stopped")
+	}
+}
+
+func TestElector_Once(t *testing.T) {
+	ctx := context.Background()
+	svc := kv.NewService(inmem.NewKVStore())
+	if err := svc.Initialize(ctx); err != nil {
+		t.Fatalf("error initializing kv service: %v", err)
+	}
+	a := leader.NewElector(svc, "alerting/reconciliation", "a")
+	b := leader.NewElector(svc, "alerting/reconciliation", "b")
+
+	var runs []string
+	var job func(node string) leader.Job
+	job = func(node string) leader.Job {
+		return func(ctx context.Context) error {
+			runs = append(runs, node)
+			// b starts while a runs the job, and doesn't run it.
+			if node == "a" {
+				if ran, err := b.Once(ctx, job("b")); err != nil || ran {
+					t.Errorf("expected b not to run the job held by a, got %v, %v", ran, err)
+				}
+			}
+			return nil
+		}
+	}
+	if ran, err := a.Once(ctx, job("a")); err != nil || !ran {
+		t.Fatalf("expected a to run the job, got %v, %v", ran, err)
+	}
+	if a.IsLeader() {
+		t.Errorf("expected a to resign once the job ran")
+	}
+	// a resigned, b runs the job when it starts again.
+	if ran, err := b.Once(ctx, job("b")); err != nil || !ran {
+		t.Fatalf("expected b to run the job, got %v, %v", ran, err)
+	}
+	if len(runs) != 2 || runs[0] != "a" || runs[1] != "b" {
+		t.Errorf("unexpected runs %v", runs)
+	}
+}
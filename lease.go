@@ -0,0 +1,32 @@
+package influxdb
+
+import (
+	"context"
+	"time"
+)
+
+// Lease is the claim of a server on a unit of work shared by the servers of
+// a cluster, such as the evaluation of a check, until it expires.
+type Lease struct {
+	Key     string    `json:"key"`
+	Owner   string    `json:"owner"`
+	Expires time.Time `json:"expires"`
+}
+
+// Held returns whether owner holds the lease at now.
+func (l *Lease) Held(owner string, now time.Time) bool {
+	return l != nil && l.Owner == owner && l.Expires.After(now)
+}
+
+// LeaseService claims units of work for the servers sharing a store, so a
+// unit is processed by a single server at once, and by another one once
+// its server stops renewing its lease.
+type LeaseService interface {
+	// AcquireLease claims key for owner during ttl, renewing the lease if
+	// owner already holds it. It returns the lease on key, which is held by
+	// another owner if its lease didn't expire yet.
+	AcquireLease(ctx context.Context, key, owner string, ttl time.Duration) (*Lease, error)
+
+	// ReleaseLease ends the lease of owner on key, if owner holds it.
+	ReleaseLease(ctx context.Context, key, owner string) error
+}
@@ -0,0 +1,25 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.AlertingDiagnosticsService = &AlertingDiagnosticsService{}
+
+// AlertingDiagnosticsService is a mock implementation of influxdb.AlertingDiagnosticsService.
+type AlertingDiagnosticsService struct {
+	FindOrphanedAlertingResourcesF func(ctx context.Context, orgID influxdb.ID) (*influxdb.OrphanedAlertingReport, error)
+	DiagnoseAlertingF              func(ctx context.Context, orgID influxdb.ID) (*influxdb.AlertingDiagnosis, error)
+}
+
+// FindOrphanedAlertingResources returns the alerting resources of an organization which never lead to a notification.
+func (s *AlertingDiagnosticsService) FindOrphanedAlertingResources(ctx context.Context, orgID influxdb.ID) (*influxdb.OrphanedAlertingReport, error) {
+	return s.FindOrphanedAlertingResourcesF(ctx, orgID)
+}
+
+// DiagnoseAlerting returns the problems of the alerting resources of an organization.
+func (s *AlertingDiagnosticsService) DiagnoseAlerting(ctx context.Context, orgID influxdb.ID) (*influxdb.AlertingDiagnosis, error) {
+	return s.DiagnoseAlertingF(ctx, orgID)
+}
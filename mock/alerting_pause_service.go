@@ -0,0 +1,31 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.AlertingPauseService = &AlertingPauseService{}
+
+// AlertingPauseService pauses and resumes the alerting of organizations.
+type AlertingPauseService struct {
+	FindAlertingPauseF func(ctx context.Context, orgID influxdb.ID) (*influxdb.AlertingPause, error)
+	PauseAlertingF     func(ctx context.Context, orgID influxdb.ID, reason string) (*influxdb.AlertingPause, error)
+	ResumeAlertingF    func(ctx context.Context, orgID influxdb.ID) (*influxdb.AlertingPause, error)
+}
+
+// FindAlertingPause returns the pause of the alerting of an organization.
+func (s *AlertingPauseService) FindAlertingPause(ctx context.Context, orgID influxdb.ID) (*influxdb.AlertingPause, error) {
+	return s.FindAlertingPauseF(ctx, orgID)
+}
+
+// PauseAlerting pauses the alerting of an organization.
+func (s *AlertingPauseService) PauseAlerting(ctx context.Context, orgID influxdb.ID, reason string) (*influxdb.AlertingPause, error) {
+	return s.PauseAlertingF(ctx, orgID, reason)
+}
+
+// ResumeAlerting resumes the alerting of an organization.
+func (s *AlertingPauseService) ResumeAlerting(ctx context.Context, orgID influxdb.ID) (*influxdb.AlertingPause, error) {
+	return s.ResumeAlertingF(ctx, orgID)
+}
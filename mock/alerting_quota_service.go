@@ -0,0 +1,19 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.AlertingQuotaService = &AlertingQuotaService{}
+
+// AlertingQuotaService represents a service finding the warnings of the alerting quotas.
+type AlertingQuotaService struct {
+	FindAlertingQuotaWarningsF func(ctx context.Context, orgID influxdb.ID, rts ...influxdb.ResourceType) ([]influxdb.QuotaWarning, error)
+}
+
+// FindAlertingQuotaWarnings returns the warnings of the alerting quotas of an organization.
+func (s *AlertingQuotaService) FindAlertingQuotaWarnings(ctx context.Context, orgID influxdb.ID, rts ...influxdb.ResourceType) ([]influxdb.QuotaWarning, error) {
+	return s.FindAlertingQuotaWarningsF(ctx, orgID, rts...)
+}
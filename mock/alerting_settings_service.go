@@ -0,0 +1,37 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.AlertingSettingsService = &AlertingSettingsService{}
+
+// AlertingSettingsService represents a service for managing the alerting settings of organizations.
+type AlertingSettingsService struct {
+	FindAlertingSettingsF            func(ctx context.Context, orgID influxdb.ID) (*influxdb.AlertingSettings, error)
+	PutAlertingSettingsF             func(ctx context.Context, s *influxdb.AlertingSettings) error
+	DeleteAlertingSettingsF          func(ctx context.Context, orgID influxdb.ID) error
+	GetAlertingSettingsOperationLogF func(ctx context.Context, orgID influxdb.ID, opts influxdb.FindOptions) ([]*influxdb.OperationLogEntry, int, error)
+}
+
+// FindAlertingSettings returns the alerting settings of an organization.
+func (s *AlertingSettingsService) FindAlertingSettings(ctx context.Context, orgID influxdb.ID) (*influxdb.AlertingSettings, error) {
+	return s.FindAlertingSettingsF(ctx, orgID)
+}
+
+// PutAlertingSettings creates or replaces the alerting settings of an organization.
+func (s *AlertingSettingsService) PutAlertingSettings(ctx context.Context, as *influxdb.AlertingSettings) error {
+	return s.PutAlertingSettingsF(ctx, as)
+}
+
+// DeleteAlertingSettings resets the alerting settings of an organization.
+func (s *AlertingSettingsService) DeleteAlertingSettings(ctx context.Context, orgID influxdb.ID) error {
+	return s.DeleteAlertingSettingsF(ctx, orgID)
+}
+
+// GetAlertingSettingsOperationLog retrieves the changes of the alerting settings of an organization.
+func (s *AlertingSettingsService) GetAlertingSettingsOperationLog(ctx context.Context, orgID influxdb.ID, opts influxdb.FindOptions) ([]*influxdb.OperationLogEntry, int, error) {
+	return s.GetAlertingSettingsOperationLogF(ctx, orgID, opts)
+}
@@ -0,0 +1,19 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.AlertingUsageService = &AlertingUsageService{}
+
+// AlertingUsageService is a mock implementation of influxdb.AlertingUsageService.
+type AlertingUsageService struct {
+	GetAlertingUsageF func(ctx context.Context, filter influxdb.UsageFilter) (*influxdb.AlertingUsage, error)
+}
+
+// GetAlertingUsage counts the alerting resources and notifications of an organization.
+func (s *AlertingUsageService) GetAlertingUsage(ctx context.Context, filter influxdb.UsageFilter) (*influxdb.AlertingUsage, error) {
+	return s.GetAlertingUsageF(ctx, filter)
+}
@@ -0,0 +1,19 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.CheckApplyService = &CheckApplyService{}
+
+// CheckApplyService is a mock implementation of influxdb.CheckApplyService.
+type CheckApplyService struct {
+	ApplyCheckF func(ctx context.Context, a influxdb.CheckApply, userID influxdb.ID) (*influxdb.CheckApplyResult, error)
+}
+
+// ApplyCheck creates or updates the check of an organization with the configuration of a field manager.
+func (s *CheckApplyService) ApplyCheck(ctx context.Context, a influxdb.CheckApply, userID influxdb.ID) (*influxdb.CheckApplyResult, error) {
+	return s.ApplyCheckF(ctx, a, userID)
+}
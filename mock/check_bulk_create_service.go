@@ -0,0 +1,19 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.CheckBulkCreateService = &CheckBulkCreateService{}
+
+// CheckBulkCreateService is a mock implementation of influxdb.CheckBulkCreateService.
+type CheckBulkCreateService struct {
+	CreateChecksF func(ctx context.Context, c influxdb.CheckBulkCreate, userID influxdb.ID) error
+}
+
+// CreateChecks creates many checks of an organization at once.
+func (s *CheckBulkCreateService) CreateChecks(ctx context.Context, c influxdb.CheckBulkCreate, userID influxdb.ID) error {
+	return s.CreateChecksF(ctx, c, userID)
+}
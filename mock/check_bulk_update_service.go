@@ -0,0 +1,19 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.CheckBulkUpdateService = &CheckBulkUpdateService{}
+
+// CheckBulkUpdateService is a mock implementation of influxdb.CheckBulkUpdateService.
+type CheckBulkUpdateService struct {
+	BulkUpdateChecksF func(ctx context.Context, u influxdb.CheckBulkUpdate) (*influxdb.CheckBulkUpdateResult, error)
+}
+
+// BulkUpdateChecks updates the checks matching a filter.
+func (s *CheckBulkUpdateService) BulkUpdateChecks(ctx context.Context, u influxdb.CheckBulkUpdate) (*influxdb.CheckBulkUpdateResult, error) {
+	return s.BulkUpdateChecksF(ctx, u)
+}
@@ -0,0 +1,19 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.CheckCloneService = &CheckCloneService{}
+
+// CheckCloneService is a mock implementation of influxdb.CheckCloneService.
+type CheckCloneService struct {
+	CloneCheckF func(ctx context.Context, id influxdb.ID, c influxdb.CheckClone, userID influxdb.ID) (influxdb.Check, error)
+}
+
+// CloneCheck creates a copy of a check.
+func (s *CheckCloneService) CloneCheck(ctx context.Context, id influxdb.ID, c influxdb.CheckClone, userID influxdb.ID) (influxdb.Check, error) {
+	return s.CloneCheckF(ctx, id, c, userID)
+}
@@ -0,0 +1,30 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.CheckCoverageService = &CheckCoverageService{}
+var _ influxdb.MeasurementService = &MeasurementService{}
+
+// CheckCoverageService is a mock implementation of influxdb.CheckCoverageService.
+type CheckCoverageService struct {
+	FindCheckCoverageF func(ctx context.Context, orgID influxdb.ID, bucketIDs []influxdb.ID) (*influxdb.CheckCoverageReport, error)
+}
+
+// FindCheckCoverage returns the coverage of the measurements of the buckets by the checks of an organization.
+func (s *CheckCoverageService) FindCheckCoverage(ctx context.Context, orgID influxdb.ID, bucketIDs []influxdb.ID) (*influxdb.CheckCoverageReport, error) {
+	return s.FindCheckCoverageF(ctx, orgID, bucketIDs)
+}
+
+// MeasurementService is a mock implementation of influxdb.MeasurementService.
+type MeasurementService struct {
+	FindMeasurementsF func(ctx context.Context, orgID, bucketID influxdb.ID) ([]string, error)
+}
+
+// FindMeasurements returns the names of the measurements of a bucket.
+func (s *MeasurementService) FindMeasurements(ctx context.Context, orgID, bucketID influxdb.ID) ([]string, error) {
+	return s.FindMeasurementsF(ctx, orgID, bucketID)
+}
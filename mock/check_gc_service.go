@@ -0,0 +1,25 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.CheckGCService = &CheckGCService{}
+
+// CheckGCService is a mock implementation of influxdb.CheckGCService.
+type CheckGCService struct {
+	RunCheckGCF        func(ctx context.Context, p influxdb.CheckGCPolicy) (*influxdb.CheckGCRun, error)
+	FindCheckGCEventsF func(ctx context.Context, filter influxdb.CheckGCEventFilter) ([]*influxdb.CheckGCEvent, error)
+}
+
+// RunCheckGC runs the garbage collection of the checks.
+func (s *CheckGCService) RunCheckGC(ctx context.Context, p influxdb.CheckGCPolicy) (*influxdb.CheckGCRun, error) {
+	return s.RunCheckGCF(ctx, p)
+}
+
+// FindCheckGCEvents returns the events of the garbage collection of the checks.
+func (s *CheckGCService) FindCheckGCEvents(ctx context.Context, filter influxdb.CheckGCEventFilter) ([]*influxdb.CheckGCEvent, error) {
+	return s.FindCheckGCEventsF(ctx, filter)
+}
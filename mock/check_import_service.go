@@ -0,0 +1,19 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.CheckImportService = &CheckImportService{}
+
+// CheckImportService is a mock implementation of influxdb.CheckImportService.
+type CheckImportService struct {
+	ImportChecksF func(ctx context.Context, orgID influxdb.ID, imp influxdb.CheckImport, userID influxdb.ID) ([]*influxdb.CheckImportResult, error)
+}
+
+// ImportChecks imports checks into an organization.
+func (s *CheckImportService) ImportChecks(ctx context.Context, orgID influxdb.ID, imp influxdb.CheckImport, userID influxdb.ID) ([]*influxdb.CheckImportResult, error) {
+	return s.ImportChecksF(ctx, orgID, imp, userID)
+}
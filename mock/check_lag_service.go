@@ -0,0 +1,25 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.CheckLagService = &CheckLagService{}
+
+// CheckLagService represents a service finding the lag of the evaluations of checks.
+type CheckLagService struct {
+	FindCheckLagF        func(ctx context.Context, checkID influxdb.ID) (*influxdb.CheckLag, error)
+	FindCheckLagSummaryF func(ctx context.Context, orgID influxdb.ID) (*influxdb.CheckLagSummary, error)
+}
+
+// FindCheckLag returns the lag of the latest evaluations of a check.
+func (s *CheckLagService) FindCheckLag(ctx context.Context, checkID influxdb.ID) (*influxdb.CheckLag, error) {
+	return s.FindCheckLagF(ctx, checkID)
+}
+
+// FindCheckLagSummary returns the lag of the latest evaluations of the checks of an organization.
+func (s *CheckLagService) FindCheckLagSummary(ctx context.Context, orgID influxdb.ID) (*influxdb.CheckLagSummary, error) {
+	return s.FindCheckLagSummaryF(ctx, orgID)
+}
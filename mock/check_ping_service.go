@@ -0,0 +1,19 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.CheckPingService = &CheckPingService{}
+
+// CheckPingService represents a service recording the pings of heartbeat checks.
+type CheckPingService struct {
+	PingCheckF func(ctx context.Context, checkID influxdb.ID) error
+}
+
+// PingCheck records a ping of a heartbeat check.
+func (s *CheckPingService) PingCheck(ctx context.Context, checkID influxdb.ID) error {
+	return s.PingCheckF(ctx, checkID)
+}
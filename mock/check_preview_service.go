@@ -0,0 +1,19 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.CheckPreviewService = &CheckPreviewService{}
+
+// CheckPreviewService represents a service previewing the queries of checks.
+type CheckPreviewService struct {
+	PreviewCheckStageF func(ctx context.Context, c influxdb.Check, stage string) (*influxdb.CheckStagePreview, error)
+}
+
+// PreviewCheckStage returns the data of a stage of the query of a check.
+func (s *CheckPreviewService) PreviewCheckStage(ctx context.Context, c influxdb.Check, stage string) (*influxdb.CheckStagePreview, error) {
+	return s.PreviewCheckStageF(ctx, c, stage)
+}
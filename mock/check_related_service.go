@@ -0,0 +1,19 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.CheckRelatedService = &CheckRelatedService{}
+
+// CheckRelatedService is a mock implementation of influxdb.CheckRelatedService.
+type CheckRelatedService struct {
+	FindCheckRelatedF func(ctx context.Context, checkID influxdb.ID) (*influxdb.CheckRelated, error)
+}
+
+// FindCheckRelated returns a check and the resources linked to it.
+func (s *CheckRelatedService) FindCheckRelated(ctx context.Context, checkID influxdb.ID) (*influxdb.CheckRelated, error) {
+	return s.FindCheckRelatedF(ctx, checkID)
+}
@@ -0,0 +1,67 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.CheckService = &CheckService{}
+
+// CheckService is a mock implementation of influxdb.CheckService.
+type CheckService struct {
+	FindCheckByIDF  func(ctx context.Context, id influxdb.ID) (influxdb.Check, error)
+	FindCheckF      func(ctx context.Context, filter influxdb.CheckFilter) (influxdb.Check, error)
+	FindChecksF     func(ctx context.Context, filter influxdb.CheckFilter, opt ...influxdb.FindOptions) ([]influxdb.Check, int, error)
+	CreateCheckF    func(ctx context.Context, c influxdb.Check, userID influxdb.ID) error
+	UpdateCheckF    func(ctx context.Context, id influxdb.ID, c influxdb.Check) (influxdb.Check, error)
+	PatchCheckF     func(ctx context.Context, id influxdb.ID, upd influxdb.CheckUpdate) (influxdb.Check, error)
+	DeleteCheckF    func(ctx context.Context, id influxdb.ID) error
+	ArchiveCheckF   func(ctx context.Context, id influxdb.ID) (influxdb.Check, error)
+	UnarchiveCheckF func(ctx context.Context, id influxdb.ID) (influxdb.Check, error)
+}
+
+// FindCheckByID returns a single check by ID.
+func (s *CheckService) FindCheckByID(ctx context.Context, id influxdb.ID) (influxdb.Check, error) {
+	return s.FindCheckByIDF(ctx, id)
+}
+
+// FindCheck returns the first check that matches filter.
+func (s *CheckService) FindCheck(ctx context.Context, filter influxdb.CheckFilter) (influxdb.Check, error) {
+	return s.FindCheckF(ctx, filter)
+}
+
+// FindChecks returns a list of checks that match filter and the total count of matching checks.
+func (s *CheckService) FindChecks(ctx context.Context, filter influxdb.CheckFilter, opt ...influxdb.FindOptions) ([]influxdb.Check, int, error) {
+	return s.FindChecksF(ctx, filter, opt...)
+}
+
+// CreateCheck creates a new check and sets c.ID with the new identifier.
+func (s *CheckService) CreateCheck(ctx context.Context, c influxdb.Check, userID influxdb.ID) error {
+	return s.CreateCheckF(ctx, c, userID)
+}
+
+// UpdateCheck updates the whole check.
+func (s *CheckService) UpdateCheck(ctx context.Context, id influxdb.ID, c influxdb.Check) (influxdb.Check, error) {
+	return s.UpdateCheckF(ctx, id, c)
+}
+
+// PatchCheck updates a single check with changeset.
+func (s *CheckService) PatchCheck(ctx context.Context, id influxdb.ID, upd influxdb.CheckUpdate) (influxdb.Check, error) {
+	return s.PatchCheckF(ctx, id, upd)
+}
+
+// DeleteCheck removes a check by ID.
+func (s *CheckService) DeleteCheck(ctx context.Context, id influxdb.ID) error {
+	return s.DeleteCheckF(ctx, id)
+}
+
+// ArchiveCheck hides a check and stops its task.
+func (s *CheckService) ArchiveCheck(ctx context.Context, id influxdb.ID) (influxdb.Check, error) {
+	return s.ArchiveCheckF(ctx, id)
+}
+
+// UnarchiveCheck restores an archived check.
+func (s *CheckService) UnarchiveCheck(ctx context.Context, id influxdb.ID) (influxdb.Check, error) {
+	return s.UnarchiveCheckF(ctx, id)
+}
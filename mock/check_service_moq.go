@@ -0,0 +1,575 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mock
+
+import (
+	"context"
+	"github.com/influxdata/influxdb"
+	"sync"
+)
+
+var (
+	lockCheckServiceMockArchiveCheck   sync.RWMutex
+	lockCheckServiceMockCreateCheck    sync.RWMutex
+	lockCheckServiceMockDeleteCheck    sync.RWMutex
+	lockCheckServiceMockFindCheck      sync.RWMutex
+	lockCheckServiceMockFindCheckByID  sync.RWMutex
+	lockCheckServiceMockFindChecks     sync.RWMutex
+	lockCheckServiceMockPatchCheck     sync.RWMutex
+	lockCheckServiceMockUnarchiveCheck sync.RWMutex
+	lockCheckServiceMockUpdateCheck    sync.RWMutex
+)
+
+// Ensure, that CheckServiceMock does implement influxdb.CheckService.
+// If this is not the case, regenerate this file with moq.
+var _ influxdb.CheckService = &CheckServiceMock{}
+
+// CheckServiceMock is a mock implementation of influxdb.CheckService.
+//
+//	func TestSomethingThatUsesCheckService(t *testing.T) {
+//
+//		// make and configure a mocked influxdb.CheckService
+//		mockedCheckService := &CheckServiceMock{
+//			ArchiveCheckFunc: func(ctx context.Context, id influxdb.ID) (influxdb.Check, error) {
+//				panic("mock out the ArchiveCheck method")
+//			},
+//			CreateCheckFunc: func(ctx context.Context, c influxdb.Check, userID influxdb.ID) error {
+//				panic("mock out the CreateCheck method")
+//			},
+//			DeleteCheckFunc: func(ctx context.Context, id influxdb.ID) error {
+//				panic("mock out the DeleteCheck method")
+//			},
+//			FindCheckFunc: func(ctx context.Context, filter influxdb.CheckFilter) (influxdb.Check, error) {
+//				panic("mock out the FindCheck method")
+//			},
+//			FindCheckByIDFunc: func(ctx context.Context, id influxdb.ID) (influxdb.Check, error) {
+//				panic("mock out the FindCheckByID method")
+//			},
+//			FindChecksFunc: func(ctx context.Context, filter influxdb.CheckFilter, opt ...influxdb.FindOptions) ([]influxdb.Check, int, error) {
+//				panic("mock out the FindChecks method")
+//			},
+//			PatchCheckFunc: func(ctx context.Context, id influxdb.ID, upd influxdb.CheckUpdate) (influxdb.Check, error) {
+//				panic("mock out the PatchCheck method")
+//			},
+//			UnarchiveCheckFunc: func(ctx context.Context, id influxdb.ID) (influxdb.Check, error) {
+//				panic("mock out the UnarchiveCheck method")
+//			},
+//			UpdateCheckFunc: func(ctx context.Context, id influxdb.ID, c influxdb.Check) (influxdb.Check, error) {
+//				panic("mock out the UpdateCheck method")
+//			},
+//		}
+//
+//		// use mockedCheckService in code that requires influxdb.CheckService
+//		// and then make assertions.
+//
+//	}
+type CheckServiceMock struct {
+	// ArchiveCheckFunc mocks the ArchiveCheck method.
+	ArchiveCheckFunc func(ctx context.Context, id influxdb.ID) (influxdb.Check, error)
+
+	// CreateCheckFunc mocks the CreateCheck method.
+	CreateCheckFunc func(ctx context.Context, c influxdb.Check, userID influxdb.ID) error
+
+	// DeleteCheckFunc mocks the DeleteCheck method.
+	DeleteCheckFunc func(ctx context.Context, id influxdb.ID) error
+
+	// FindCheckFunc mocks the FindCheck method.
+	FindCheckFunc func(ctx context.Context, filter influxdb.CheckFilter) (influxdb.Check, error)
+
+	// FindCheckByIDFunc mocks the FindCheckByID method.
+	FindCheckByIDFunc func(ctx context.Context, id influxdb.ID) (influxdb.Check, error)
+
+	// FindChecksFunc mocks the FindChecks method.
+	FindChecksFunc func(ctx context.Context, filter influxdb.CheckFilter, opt ...influxdb.FindOptions) ([]influxdb.Check, int, error)
+
+	// PatchCheckFunc mocks the PatchCheck method.
+	PatchCheckFunc func(ctx context.Context, id influxdb.ID, upd influxdb.CheckUpdate) (influxdb.Check, error)
+
+	// UnarchiveCheckFunc mocks the UnarchiveCheck method.
+	UnarchiveCheckFunc func(ctx context.Context, id influxdb.ID) (influxdb.Check, error)
+
+	// UpdateCheckFunc mocks the UpdateCheck method.
+	UpdateCheckFunc func(ctx context.Context, id influxdb.ID, c influxdb.Check) (influxdb.Check, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ArchiveCheck holds details about calls to the ArchiveCheck method.
+		ArchiveCheck []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Id is the id argument value.
+			Id influxdb.ID
+		}
+		// CreateCheck holds details about calls to the CreateCheck method.
+		CreateCheck []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// C is the c argument value.
+			C influxdb.Check
+			// UserID is the userID argument value.
+			UserID influxdb.ID
+		}
+		// DeleteCheck holds details about calls to the DeleteCheck method.
+		DeleteCheck []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Id is the id argument value.
+			Id influxdb.ID
+		}
+		// FindCheck holds details about calls to the FindCheck method.
+		FindCheck []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Filter is the filter argument value.
+			Filter influxdb.CheckFilter
+		}
+		// FindCheckByID holds details about calls to the FindCheckByID method.
+		FindCheckByID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Id is the id argument value.
+			Id influxdb.ID
+		}
+		// FindChecks holds details about calls to the FindChecks method.
+		FindChecks []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Filter is the filter argument value.
+			Filter influxdb.CheckFilter
+			// Opt is the opt argument value.
+			Opt []influxdb.FindOptions
+		}
+		// PatchCheck holds details about calls to the PatchCheck method.
+		PatchCheck []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Id is the id argument value.
+			Id influxdb.ID
+			// Upd is the upd argument value.
+			Upd influxdb.CheckUpdate
+		}
+		// UnarchiveCheck holds details about calls to the UnarchiveCheck method.
+		UnarchiveCheck []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Id is the id argument value.
+			Id influxdb.ID
+		}
+		// UpdateCheck holds details about calls to the UpdateCheck method.
+		UpdateCheck []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Id is the id argument value.
+			Id influxdb.ID
+			// C is the c argument value.
+			C influxdb.Check
+		}
+	}
+}
+
+// ArchiveCheck calls ArchiveCheckFunc.
+func (mock *CheckServiceMock) ArchiveCheck(ctx context.Context, id influxdb.ID) (influxdb.Check, error) {
+	if mock.ArchiveCheckFunc == nil {
+		panic("CheckServiceMock.ArchiveCheckFunc: method is nil but CheckService.ArchiveCheck was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Id is the id argument value.
+		Id influxdb.ID
+	}{
+		Ctx: ctx,
+		Id:  id,
+	}
+	lockCheckServiceMockArchiveCheck.Lock()
+	mock.calls.ArchiveCheck = append(mock.calls.ArchiveCheck, callInfo)
+	lockCheckServiceMockArchiveCheck.Unlock()
+	return mock.ArchiveCheckFunc(ctx, id)
+}
+
+// ArchiveCheckCalls gets all the calls that were made to ArchiveCheck.
+// Check the length with:
+//
+//	len(mockedCheckService.ArchiveCheckCalls())
+func (mock *CheckServiceMock) ArchiveCheckCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// Id is the id argument value.
+	Id influxdb.ID
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Id is the id argument value.
+		Id influxdb.ID
+	}
+	lockCheckServiceMockArchiveCheck.RLock()
+	calls = mock.calls.ArchiveCheck
+	lockCheckServiceMockArchiveCheck.RUnlock()
+	return calls
+}
+
+// CreateCheck calls CreateCheckFunc.
+func (mock *CheckServiceMock) CreateCheck(ctx context.Context, c influxdb.Check, userID influxdb.ID) error {
+	if mock.CreateCheckFunc == nil {
+		panic("CheckServiceMock.CreateCheckFunc: method is nil but CheckService.CreateCheck was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// C is the c argument value.
+		C influxdb.Check
+		// UserID is the userID argument value.
+		UserID influxdb.ID
+	}{
+		Ctx:    ctx,
+		C:      c,
+		UserID: userID,
+	}
+	lockCheckServiceMockCreateCheck.Lock()
+	mock.calls.CreateCheck = append(mock.calls.CreateCheck, callInfo)
+	lockCheckServiceMockCreateCheck.Unlock()
+	return mock.CreateCheckFunc(ctx, c, userID)
+}
+
+// CreateCheckCalls gets all the calls that were made to CreateCheck.
+// Check the length with:
+//
+//	len(mockedCheckService.CreateCheckCalls())
+func (mock *CheckServiceMock) CreateCheckCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// C is the c argument value.
+	C influxdb.Check
+	// UserID is the userID argument value.
+	UserID influxdb.ID
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// C is the c argument value.
+		C influxdb.Check
+		// UserID is the userID argument value.
+		UserID influxdb.ID
+	}
+	lockCheckServiceMockCreateCheck.RLock()
+	calls = mock.calls.CreateCheck
+	lockCheckServiceMockCreateCheck.RUnlock()
+	return calls
+}
+
+// DeleteCheck calls DeleteCheckFunc.
+func (mock *CheckServiceMock) DeleteCheck(ctx context.Context, id influxdb.ID) error {
+	if mock.DeleteCheckFunc == nil {
+		panic("CheckServiceMock.DeleteCheckFunc: method is nil but CheckService.DeleteCheck was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Id is the id argument value.
+		Id influxdb.ID
+	}{
+		Ctx: ctx,
+		Id:  id,
+	}
+	lockCheckServiceMockDeleteCheck.Lock()
+	mock.calls.DeleteCheck = append(mock.calls.DeleteCheck, callInfo)
+	lockCheckServiceMockDeleteCheck.Unlock()
+	return mock.DeleteCheckFunc(ctx, id)
+}
+
+// DeleteCheckCalls gets all the calls that were made to DeleteCheck.
+// Check the length with:
+//
+//	len(mockedCheckService.DeleteCheckCalls())
+func (mock *CheckServiceMock) DeleteCheckCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// Id is the id argument value.
+	Id influxdb.ID
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Id is the id argument value.
+		Id influxdb.ID
+	}
+	lockCheckServiceMockDeleteCheck.RLock()
+	calls = mock.calls.DeleteCheck
+	lockCheckServiceMockDeleteCheck.RUnlock()
+	return calls
+}
+
+// FindCheck calls FindCheckFunc.
+func (mock *CheckServiceMock) FindCheck(ctx context.Context, filter influxdb.CheckFilter) (influxdb.Check, error) {
+	if mock.FindCheckFunc == nil {
+		panic("CheckServiceMock.FindCheckFunc: method is nil but CheckService.FindCheck was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Filter is the filter argument value.
+		Filter influxdb.CheckFilter
+	}{
+		Ctx:    ctx,
+		Filter: filter,
+	}
+	lockCheckServiceMockFindCheck.Lock()
+	mock.calls.FindCheck = append(mock.calls.FindCheck, callInfo)
+	lockCheckServiceMockFindCheck.Unlock()
+	return mock.FindCheckFunc(ctx, filter)
+}
+
+// FindCheckCalls gets all the calls that were made to FindCheck.
+// Check the length with:
+//
+//	len(mockedCheckService.FindCheckCalls())
+func (mock *CheckServiceMock) FindCheckCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// Filter is the filter argument value.
+	Filter influxdb.CheckFilter
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Filter is the filter argument value.
+		Filter influxdb.CheckFilter
+	}
+	lockCheckServiceMockFindCheck.RLock()
+	calls = mock.calls.FindCheck
+	lockCheckServiceMockFindCheck.RUnlock()
+	return calls
+}
+
+// FindCheckByID calls FindCheckByIDFunc.
+func (mock *CheckServiceMock) FindCheckByID(ctx context.Context, id influxdb.ID) (influxdb.Check, error) {
+	if mock.FindCheckByIDFunc == nil {
+		panic("CheckServiceMock.FindCheckByIDFunc: method is nil but CheckService.FindCheckByID was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Id is the id argument value.
+		Id influxdb.ID
+	}{
+		Ctx: ctx,
+		Id:  id,
+	}
+	lockCheckServiceMockFindCheckByID.Lock()
+	mock.calls.FindCheckByID = append(mock.calls.FindCheckByID, callInfo)
+	lockCheckServiceMockFindCheckByID.Unlock()
+	return mock.FindCheckByIDFunc(ctx, id)
+}
+
+// FindCheckByIDCalls gets all the calls that were made to FindCheckByID.
+// Check the length with:
+//
+//	len(mockedCheckService.FindCheckByIDCalls())
+func (mock *CheckServiceMock) FindCheckByIDCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// Id is the id argument value.
+	Id influxdb.ID
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Id is the id argument value.
+		Id influxdb.ID
+	}
+	lockCheckServiceMockFindCheckByID.RLock()
+	calls = mock.calls.FindCheckByID
+	lockCheckServiceMockFindCheckByID.RUnlock()
+	return calls
+}
+
+// FindChecks calls FindChecksFunc.
+func (mock *CheckServiceMock) FindChecks(ctx context.Context, filter influxdb.CheckFilter, opt ...influxdb.FindOptions) ([]influxdb.Check, int, error) {
+	if mock.FindChecksFunc == nil {
+		panic("CheckServiceMock.FindChecksFunc: method is nil but CheckService.FindChecks was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Filter is the filter argument value.
+		Filter influxdb.CheckFilter
+		// Opt is the opt argument value.
+		Opt []influxdb.FindOptions
+	}{
+		Ctx:    ctx,
+		Filter: filter,
+		Opt:    opt,
+	}
+	lockCheckServiceMockFindChecks.Lock()
+	mock.calls.FindChecks = append(mock.calls.FindChecks, callInfo)
+	lockCheckServiceMockFindChecks.Unlock()
+	return mock.FindChecksFunc(ctx, filter, opt...)
+}
+
+// FindChecksCalls gets all the calls that were made to FindChecks.
+// Check the length with:
+//
+//	len(mockedCheckService.FindChecksCalls())
+func (mock *CheckServiceMock) FindChecksCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// Filter is the filter argument value.
+	Filter influxdb.CheckFilter
+	// Opt is the opt argument value.
+	Opt []influxdb.FindOptions
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Filter is the filter argument value.
+		Filter influxdb.CheckFilter
+		// Opt is the opt argument value.
+		Opt []influxdb.FindOptions
+	}
+	lockCheckServiceMockFindChecks.RLock()
+	calls = mock.calls.FindChecks
+	lockCheckServiceMockFindChecks.RUnlock()
+	return calls
+}
+
+// PatchCheck calls PatchCheckFunc.
+func (mock *CheckServiceMock) PatchCheck(ctx context.Context, id influxdb.ID, upd influxdb.CheckUpdate) (influxdb.Check, error) {
+	if mock.PatchCheckFunc == nil {
+		panic("CheckServiceMock.PatchCheckFunc: method is nil but CheckService.PatchCheck was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Id is the id argument value.
+		Id influxdb.ID
+		// Upd is the upd argument value.
+		Upd influxdb.CheckUpdate
+	}{
+		Ctx: ctx,
+		Id:  id,
+		Upd: upd,
+	}
+	lockCheckServiceMockPatchCheck.Lock()
+	mock.calls.PatchCheck = append(mock.calls.PatchCheck, callInfo)
+	lockCheckServiceMockPatchCheck.Unlock()
+	return mock.PatchCheckFunc(ctx, id, upd)
+}
+
+// PatchCheckCalls gets all the calls that were made to PatchCheck.
+// Check the length with:
+//
+//	len(mockedCheckService.PatchCheckCalls())
+func (mock *CheckServiceMock) PatchCheckCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// Id is the id argument value.
+	Id influxdb.ID
+	// Upd is the upd argument value.
+	Upd influxdb.CheckUpdate
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Id is the id argument value.
+		Id influxdb.ID
+		// Upd is the upd argument value.
+		Upd influxdb.CheckUpdate
+	}
+	lockCheckServiceMockPatchCheck.RLock()
+	calls = mock.calls.PatchCheck
+	lockCheckServiceMockPatchCheck.RUnlock()
+	return calls
+}
+
+// UnarchiveCheck calls UnarchiveCheckFunc.
+func (mock *CheckServiceMock) UnarchiveCheck(ctx context.Context, id influxdb.ID) (influxdb.Check, error) {
+	if mock.UnarchiveCheckFunc == nil {
+		panic("CheckServiceMock.UnarchiveCheckFunc: method is nil but CheckService.UnarchiveCheck was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Id is the id argument value.
+		Id influxdb.ID
+	}{
+		Ctx: ctx,
+		Id:  id,
+	}
+	lockCheckServiceMockUnarchiveCheck.Lock()
+	mock.calls.UnarchiveCheck = append(mock.calls.UnarchiveCheck, callInfo)
+	lockCheckServiceMockUnarchiveCheck.Unlock()
+	return mock.UnarchiveCheckFunc(ctx, id)
+}
+
+// UnarchiveCheckCalls gets all the calls that were made to UnarchiveCheck.
+// Check the length with:
+//
+//	len(mockedCheckService.UnarchiveCheckCalls())
+func (mock *CheckServiceMock) UnarchiveCheckCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// Id is the id argument value.
+	Id influxdb.ID
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Id is the id argument value.
+		Id influxdb.ID
+	}
+	lockCheckServiceMockUnarchiveCheck.RLock()
+	calls = mock.calls.UnarchiveCheck
+	lockCheckServiceMockUnarchiveCheck.RUnlock()
+	return calls
+}
+
+// UpdateCheck calls UpdateCheckFunc.
+func (mock *CheckServiceMock) UpdateCheck(ctx context.Context, id influxdb.ID, c influxdb.Check) (influxdb.Check, error) {
+	if mock.UpdateCheckFunc == nil {
+		panic("CheckServiceMock.UpdateCheckFunc: method is nil but CheckService.UpdateCheck was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Id is the id argument value.
+		Id influxdb.ID
+		// C is the c argument value.
+		C influxdb.Check
+	}{
+		Ctx: ctx,
+		Id:  id,
+		C:   c,
+	}
+	lockCheckServiceMockUpdateCheck.Lock()
+	mock.calls.UpdateCheck = append(mock.calls.UpdateCheck, callInfo)
+	lockCheckServiceMockUpdateCheck.Unlock()
+	return mock.UpdateCheckFunc(ctx, id, c)
+}
+
+// UpdateCheckCalls gets all the calls that were made to UpdateCheck.
+// Check the length with:
+//
+//	len(mockedCheckService.UpdateCheckCalls())
+func (mock *CheckServiceMock) UpdateCheckCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// Id is the id argument value.
+	Id influxdb.ID
+	// C is the c argument value.
+	C influxdb.Check
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Id is the id argument value.
+		Id influxdb.ID
+		// C is the c argument value.
+		C influxdb.Check
+	}
+	lockCheckServiceMockUpdateCheck.RLock()
+	calls = mock.calls.UpdateCheck
+	lockCheckServiceMockUpdateCheck.RUnlock()
+	return calls
+}
@@ -0,0 +1,31 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.CheckStateService = &CheckStateService{}
+
+// CheckStateService represents a service persisting the state of the checks of the alerting engine.
+type CheckStateService struct {
+	FindCheckStateF   func(ctx context.Context, checkID influxdb.ID) (*influxdb.CheckState, error)
+	PutCheckStateF    func(ctx context.Context, s *influxdb.CheckState) error
+	DeleteCheckStateF func(ctx context.Context, checkID influxdb.ID) error
+}
+
+// FindCheckState returns the state of a check.
+func (s *CheckStateService) FindCheckState(ctx context.Context, checkID influxdb.ID) (*influxdb.CheckState, error) {
+	return s.FindCheckStateF(ctx, checkID)
+}
+
+// PutCheckState saves the state of a check.
+func (s *CheckStateService) PutCheckState(ctx context.Context, st *influxdb.CheckState) error {
+	return s.PutCheckStateF(ctx, st)
+}
+
+// DeleteCheckState removes the state of a check.
+func (s *CheckStateService) DeleteCheckState(ctx context.Context, checkID influxdb.ID) error {
+	return s.DeleteCheckStateF(ctx, checkID)
+}
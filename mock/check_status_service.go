@@ -0,0 +1,31 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.CheckStatusService = &CheckStatusService{}
+
+// CheckStatusService is a mock implementation of influxdb.CheckStatusService.
+type CheckStatusService struct {
+	FindCheckStatusesF      func(ctx context.Context, checkIDs []influxdb.ID) ([]*influxdb.CheckStatus, error)
+	FindCheckStatusHistoryF func(ctx context.Context, checkID influxdb.ID, filter influxdb.CheckStatusHistoryFilter) (*influxdb.CheckStatusHistory, error)
+	FindCheckQueryF         func(ctx context.Context, checkID influxdb.ID) (*influxdb.CheckQuery, error)
+}
+
+// FindCheckStatuses returns the current statuses of checks.
+func (s *CheckStatusService) FindCheckStatuses(ctx context.Context, checkIDs []influxdb.ID) ([]*influxdb.CheckStatus, error) {
+	return s.FindCheckStatusesF(ctx, checkIDs)
+}
+
+// FindCheckStatusHistory returns the statuses a check has produced.
+func (s *CheckStatusService) FindCheckStatusHistory(ctx context.Context, checkID influxdb.ID, filter influxdb.CheckStatusHistoryFilter) (*influxdb.CheckStatusHistory, error) {
+	return s.FindCheckStatusHistoryF(ctx, checkID, filter)
+}
+
+// FindCheckQuery returns the query of a check and its generated flux.
+func (s *CheckStatusService) FindCheckQuery(ctx context.Context, checkID influxdb.ID) (*influxdb.CheckQuery, error) {
+	return s.FindCheckQueryF(ctx, checkID)
+}
@@ -0,0 +1,25 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.CheckTaskReconciler = &CheckTaskReconciler{}
+
+// CheckTaskReconciler is a mock implementation of influxdb.CheckTaskReconciler.
+type CheckTaskReconciler struct {
+	ReconcileCheckTasksF         func(ctx context.Context, p influxdb.CheckTaskReconcilePolicy) (*influxdb.CheckTaskReconciliation, error)
+	FindCheckTaskReconciliationF func(ctx context.Context) (*influxdb.CheckTaskReconciliation, error)
+}
+
+// ReconcileCheckTasks reconciles the checks and their tasks.
+func (s *CheckTaskReconciler) ReconcileCheckTasks(ctx context.Context, p influxdb.CheckTaskReconcilePolicy) (*influxdb.CheckTaskReconciliation, error) {
+	return s.ReconcileCheckTasksF(ctx, p)
+}
+
+// FindCheckTaskReconciliation returns the result of the latest reconciliation.
+func (s *CheckTaskReconciler) FindCheckTaskReconciliation(ctx context.Context) (*influxdb.CheckTaskReconciliation, error) {
+	return s.FindCheckTaskReconciliationF(ctx)
+}
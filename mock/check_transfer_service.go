@@ -0,0 +1,19 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.CheckTransferService = &CheckTransferService{}
+
+// CheckTransferService is a mock implementation of influxdb.CheckTransferService.
+type CheckTransferService struct {
+	TransferCheckF func(ctx context.Context, id influxdb.ID, t influxdb.CheckTransfer) (*influxdb.CheckTransferResult, error)
+}
+
+// TransferCheck moves a check to another organization.
+func (s *CheckTransferService) TransferCheck(ctx context.Context, id influxdb.ID, t influxdb.CheckTransfer) (*influxdb.CheckTransferResult, error) {
+	return s.TransferCheckF(ctx, id, t)
+}
@@ -0,0 +1,19 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.CheckWatchService = &CheckWatchService{}
+
+// CheckWatchService represents a service finding the changes of checks.
+type CheckWatchService struct {
+	FindCheckEventsF func(ctx context.Context, filter influxdb.CheckEventFilter) ([]*influxdb.CheckEvent, uint64, error)
+}
+
+// FindCheckEvents returns the events of the checks of an organization following a resource version.
+func (s *CheckWatchService) FindCheckEvents(ctx context.Context, filter influxdb.CheckEventFilter) ([]*influxdb.CheckEvent, uint64, error) {
+	return s.FindCheckEventsF(ctx, filter)
+}
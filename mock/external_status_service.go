@@ -0,0 +1,19 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.ExternalStatusService = &ExternalStatusService{}
+
+// ExternalStatusService represents a service writing the statuses of external checks.
+type ExternalStatusService struct {
+	WriteExternalStatusF func(ctx context.Context, checkID influxdb.ID, s *influxdb.ExternalStatus) (*influxdb.StatusTrace, error)
+}
+
+// WriteExternalStatus writes and dispatches a status of an external check.
+func (s *ExternalStatusService) WriteExternalStatus(ctx context.Context, checkID influxdb.ID, st *influxdb.ExternalStatus) (*influxdb.StatusTrace, error) {
+	return s.WriteExternalStatusF(ctx, checkID, st)
+}
@@ -0,0 +1,8 @@
+package mock
+
+// The generated mocks record the arguments of every call, so that a test
+// matches the calls it expects instead of counting them in its own closures.
+// moq must be installed, see https://github.com/matryer/moq.
+//go:generate moq -out check_service_moq.go -pkg mock .. CheckService
+//go:generate moq -out notification_endpoint_service_moq.go -pkg mock .. NotificationEndpointService
+//go:generate moq -out notification_rule_store_moq.go -pkg mock .. NotificationRuleStore
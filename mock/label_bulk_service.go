@@ -0,0 +1,25 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.LabelBulkService = &LabelBulkService{}
+
+// LabelBulkService represents a service applying labels to many resources at once.
+type LabelBulkService struct {
+	ApplyLabelF  func(ctx context.Context, labelID influxdb.ID, o influxdb.LabelBulkOperation) (*influxdb.LabelBulkResult, error)
+	RemoveLabelF func(ctx context.Context, labelID influxdb.ID, o influxdb.LabelBulkOperation) (*influxdb.LabelBulkResult, error)
+}
+
+// ApplyLabel applies a label to every matching resource.
+func (s *LabelBulkService) ApplyLabel(ctx context.Context, labelID influxdb.ID, o influxdb.LabelBulkOperation) (*influxdb.LabelBulkResult, error) {
+	return s.ApplyLabelF(ctx, labelID, o)
+}
+
+// RemoveLabel removes a label from every matching resource.
+func (s *LabelBulkService) RemoveLabel(ctx context.Context, labelID influxdb.ID, o influxdb.LabelBulkOperation) (*influxdb.LabelBulkResult, error) {
+	return s.RemoveLabelF(ctx, labelID, o)
+}
@@ -0,0 +1,26 @@
+package mock
+
+import (
+	"context"
+	"time"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.LeaseService = &LeaseService{}
+
+// LeaseService represents a service for claiming the units of work shared by servers.
+type LeaseService struct {
+	AcquireLeaseF func(ctx context.Context, key, owner string, ttl time.Duration) (*influxdb.Lease, error)
+	ReleaseLeaseF func(ctx context.Context, key, owner string) error
+}
+
+// AcquireLease claims key for owner during ttl.
+func (s *LeaseService) AcquireLease(ctx context.Context, key, owner string, ttl time.Duration) (*influxdb.Lease, error) {
+	return s.AcquireLeaseF(ctx, key, owner, ttl)
+}
+
+// ReleaseLease ends the lease of owner on key.
+func (s *LeaseService) ReleaseLease(ctx context.Context, key, owner string) error {
+	return s.ReleaseLeaseF(ctx, key, owner)
+}
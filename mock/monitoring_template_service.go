@@ -0,0 +1,49 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.MonitoringTemplateService = &MonitoringTemplateService{}
+
+// MonitoringTemplateService represents a service for installing monitoring templates.
+type MonitoringTemplateService struct {
+	FindMonitoringTemplateManifestByIDF func(ctx context.Context, id influxdb.ID) (*influxdb.MonitoringTemplateManifest, error)
+	FindMonitoringTemplateManifestsF    func(ctx context.Context, orgID influxdb.ID) ([]*influxdb.MonitoringTemplateManifest, error)
+	InstallMonitoringTemplateF          func(ctx context.Context, orgID influxdb.ID, install influxdb.MonitoringTemplateInstall, userID influxdb.ID) (*influxdb.MonitoringTemplateManifest, error)
+	PreviewMonitoringTemplateF          func(ctx context.Context, orgID influxdb.ID, install influxdb.MonitoringTemplateInstall) ([]influxdb.MonitoringTemplateResource, error)
+	UpgradeMonitoringTemplateF          func(ctx context.Context, id influxdb.ID, install influxdb.MonitoringTemplateInstall, userID influxdb.ID) (*influxdb.MonitoringTemplateManifest, error)
+	UninstallMonitoringTemplateF        func(ctx context.Context, id influxdb.ID) error
+}
+
+// FindMonitoringTemplateManifestByID returns the manifest of an installed template.
+func (s *MonitoringTemplateService) FindMonitoringTemplateManifestByID(ctx context.Context, id influxdb.ID) (*influxdb.MonitoringTemplateManifest, error) {
+	return s.FindMonitoringTemplateManifestByIDF(ctx, id)
+}
+
+// FindMonitoringTemplateManifests returns the manifests of the templates installed in an organization.
+func (s *MonitoringTemplateService) FindMonitoringTemplateManifests(ctx context.Context, orgID influxdb.ID) ([]*influxdb.MonitoringTemplateManifest, error) {
+	return s.FindMonitoringTemplateManifestsF(ctx, orgID)
+}
+
+// InstallMonitoringTemplate installs a template into an organization.
+func (s *MonitoringTemplateService) InstallMonitoringTemplate(ctx context.Context, orgID influxdb.ID, install influxdb.MonitoringTemplateInstall, userID influxdb.ID) (*influxdb.MonitoringTemplateManifest, error) {
+	return s.InstallMonitoringTemplateF(ctx, orgID, install, userID)
+}
+
+// PreviewMonitoringTemplate returns the resources the install of a template would create or reuse.
+func (s *MonitoringTemplateService) PreviewMonitoringTemplate(ctx context.Context, orgID influxdb.ID, install influxdb.MonitoringTemplateInstall) ([]influxdb.MonitoringTemplateResource, error) {
+	return s.PreviewMonitoringTemplateF(ctx, orgID, install)
+}
+
+// UpgradeMonitoringTemplate upgrades an installed template.
+func (s *MonitoringTemplateService) UpgradeMonitoringTemplate(ctx context.Context, id influxdb.ID, install influxdb.MonitoringTemplateInstall, userID influxdb.ID) (*influxdb.MonitoringTemplateManifest, error) {
+	return s.UpgradeMonitoringTemplateF(ctx, id, install, userID)
+}
+
+// UninstallMonitoringTemplate uninstalls an installed template.
+func (s *MonitoringTemplateService) UninstallMonitoringTemplate(ctx context.Context, id influxdb.ID) error {
+	return s.UninstallMonitoringTemplateF(ctx, id)
+}
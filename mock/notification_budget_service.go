@@ -0,0 +1,44 @@
+package mock
+
+import (
+	"context"
+	"time"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.NotificationBudgetService = &NotificationBudgetService{}
+
+// NotificationBudgetService represents a service for managing the notification budgets of endpoints.
+type NotificationBudgetService struct {
+	FindNotificationBudgetF      func(ctx context.Context, endpointID influxdb.ID) (*influxdb.NotificationBudget, error)
+	PutNotificationBudgetF       func(ctx context.Context, b *influxdb.NotificationBudget) error
+	DeleteNotificationBudgetF    func(ctx context.Context, endpointID influxdb.ID) error
+	FindNotificationBudgetUsageF func(ctx context.Context, endpointID influxdb.ID, t time.Time) (*influxdb.NotificationBudgetUsage, error)
+	SpendNotificationBudgetF     func(ctx context.Context, endpointID influxdb.ID, t time.Time) (*influxdb.NotificationBudgetUsage, error)
+}
+
+// FindNotificationBudget returns the notification budget of an endpoint.
+func (s *NotificationBudgetService) FindNotificationBudget(ctx context.Context, endpointID influxdb.ID) (*influxdb.NotificationBudget, error) {
+	return s.FindNotificationBudgetF(ctx, endpointID)
+}
+
+// PutNotificationBudget creates or replaces the notification budget of an endpoint.
+func (s *NotificationBudgetService) PutNotificationBudget(ctx context.Context, b *influxdb.NotificationBudget) error {
+	return s.PutNotificationBudgetF(ctx, b)
+}
+
+// DeleteNotificationBudget removes the notification budget of an endpoint.
+func (s *NotificationBudgetService) DeleteNotificationBudget(ctx context.Context, endpointID influxdb.ID) error {
+	return s.DeleteNotificationBudgetF(ctx, endpointID)
+}
+
+// FindNotificationBudgetUsage returns the usage of the budget of an endpoint in the month of t.
+func (s *NotificationBudgetService) FindNotificationBudgetUsage(ctx context.Context, endpointID influxdb.ID, t time.Time) (*influxdb.NotificationBudgetUsage, error) {
+	return s.FindNotificationBudgetUsageF(ctx, endpointID, t)
+}
+
+// SpendNotificationBudget counts a notification routed to an endpoint at t.
+func (s *NotificationBudgetService) SpendNotificationBudget(ctx context.Context, endpointID influxdb.ID, t time.Time) (*influxdb.NotificationBudgetUsage, error) {
+	return s.SpendNotificationBudgetF(ctx, endpointID, t)
+}
@@ -0,0 +1,80 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.NotificationEndpointService = &NotificationEndpointService{}
+
+// NotificationEndpointService represents a service for managing notification endpoint data.
+type NotificationEndpointService struct {
+	OrganizationService
+	UserResourceMappingService
+	FindNotificationEndpointByIDF func(ctx context.Context, id influxdb.ID) (influxdb.NotificationEndpoint, error)
+	FindNotificationEndpointsF    func(ctx context.Context, filter influxdb.NotificationEndpointFilter, opt ...influxdb.FindOptions) ([]influxdb.NotificationEndpoint, int, error)
+	CreateNotificationEndpointF   func(ctx context.Context, nr influxdb.NotificationEndpoint, userID influxdb.ID) error
+	UpdateNotificationEndpointF   func(ctx context.Context, id influxdb.ID, nr influxdb.NotificationEndpoint, userID influxdb.ID) (influxdb.NotificationEndpoint, error)
+	PatchNotificationEndpointF    func(ctx context.Context, id influxdb.ID, upd influxdb.NotificationEndpointUpdate) (influxdb.NotificationEndpoint, error)
+	DeleteNotificationEndpointF   func(ctx context.Context, id influxdb.ID) error
+}
+
+// FindNotificationEndpointByID returns a single notification endpoint by ID.
+func (s *NotificationEndpointService) FindNotificationEndpointByID(ctx context.Context, id influxdb.ID) (influxdb.NotificationEndpoint, error) {
+	return s.FindNotificationEndpointByIDF(ctx, id)
+}
+
+// FindNotificationEndpoints returns a list of notification endpoints that match filter and the total count of matching notification endpoints.
+// Additional options provide pagination & sorting.
+func (s *NotificationEndpointService) FindNotificationEndpoints(ctx context.Context, filter influxdb.NotificationEndpointFilter, opt ...influxdb.FindOptions) ([]influxdb.NotificationEndpoint, int, error) {
+	return s.FindNotificationEndpointsF(ctx, filter, opt...)
+}
+
+// CreateNotificationEndpoint creates a new notification endpoint and sets ID with the new identifier.
+func (s *NotificationEndpointService) CreateNotificationEndpoint(ctx context.Context, nr influxdb.NotificationEndpoint, userID influxdb.ID) error {
+	return s.CreateNotificationEndpointF(ctx, nr, userID)
+}
+
+// UpdateNotificationEndpoint updates a single notification endpoint.
+// Returns the new notification endpoint after update.
+func (s *NotificationEndpointService) UpdateNotificationEndpoint(ctx context.Context, id influxdb.ID, nr influxdb.NotificationEndpoint, userID influxdb.ID) (influxdb.NotificationEndpoint, error) {
+	return s.UpdateNotificationEndpointF(ctx, id, nr, userID)
+}
+
+// PatchNotificationEndpoint updates a single  notification endpoint with changeset.
+// Returns the new notification endpoint after update.
+func (s *NotificationEndpointService) PatchNotificationEndpoint(ctx context.Context, id influxdb.ID, upd influxdb.NotificationEndpointUpdate) (influxdb.NotificationEndpoint, error) {
+	return s.PatchNotificationEndpointF(ctx, id, upd)
+}
+
+// DeleteNotificationEndpoint removes a notification endpoint by ID.
+func (s *NotificationEndpointService) DeleteNotificationEndpoint(ctx context.Context, id influxdb.ID) error {
+	return s.DeleteNotificationEndpointF(ctx, id)
+}
+
+var _ influxdb.NotificationEndpointCascader = &NotificationEndpointCascader{}
+
+// NotificationEndpointCascader represents a service deleting the notification endpoints
+// along with the notification rules sending to them.
+type NotificationEndpointCascader struct {
+	DeleteNotificationEndpointCascadeF func(ctx context.Context, id influxdb.ID) ([]influxdb.ID, error)
+}
+
+// DeleteNotificationEndpointCascade removes a notification endpoint by ID and the notification rules sending to it.
+func (s *NotificationEndpointCascader) DeleteNotificationEndpointCascade(ctx context.Context, id influxdb.ID) ([]influxdb.ID, error) {
+	return s.DeleteNotificationEndpointCascadeF(ctx, id)
+}
+
+var _ influxdb.NotificationEndpointReassigner = &NotificationEndpointReassigner{}
+
+// NotificationEndpointReassigner represents a service moving the notification rules
+// of an endpoint to another one.
+type NotificationEndpointReassigner struct {
+	ReassignNotificationEndpointF func(ctx context.Context, id influxdb.ID, r influxdb.NotificationEndpointReassign) (*influxdb.NotificationEndpointReassignResult, error)
+}
+
+// ReassignNotificationEndpoint moves the notification rules of an endpoint to another one.
+func (s *NotificationEndpointReassigner) ReassignNotificationEndpoint(ctx context.Context, id influxdb.ID, r influxdb.NotificationEndpointReassign) (*influxdb.NotificationEndpointReassignResult, error) {
+	return s.ReassignNotificationEndpointF(ctx, id, r)
+}
@@ -0,0 +1,956 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mock
+
+import (
+	"context"
+	"github.com/influxdata/influxdb"
+	"sync"
+)
+
+var (
+	lockNotificationEndpointServiceMockCreateNotificationEndpoint   sync.RWMutex
+	lockNotificationEndpointServiceMockCreateOrganization           sync.RWMutex
+	lockNotificationEndpointServiceMockCreateUserResourceMapping    sync.RWMutex
+	lockNotificationEndpointServiceMockDeleteNotificationEndpoint   sync.RWMutex
+	lockNotificationEndpointServiceMockDeleteOrganization           sync.RWMutex
+	lockNotificationEndpointServiceMockDeleteUserResourceMapping    sync.RWMutex
+	lockNotificationEndpointServiceMockFindNotificationEndpointByID sync.RWMutex
+	lockNotificationEndpointServiceMockFindNotificationEndpoints    sync.RWMutex
+	lockNotificationEndpointServiceMockFindOrganization             sync.RWMutex
+	lockNotificationEndpointServiceMockFindOrganizationByID         sync.RWMutex
+	lockNotificationEndpointServiceMockFindOrganizations            sync.RWMutex
+	lockNotificationEndpointServiceMockFindUserResourceMappings     sync.RWMutex
+	lockNotificationEndpointServiceMockPatchNotificationEndpoint    sync.RWMutex
+	lockNotificationEndpointServiceMockUpdateNotificationEndpoint   sync.RWMutex
+	lockNotificationEndpointServiceMockUpdateOrganization           sync.RWMutex
+)
+
+// Ensure, that NotificationEndpointServiceMock does implement influxdb.NotificationEndpointService.
+// If this is not the case, regenerate this file with moq.
+var _ influxdb.NotificationEndpointService = &NotificationEndpointServiceMock{}
+
+// NotificationEndpointServiceMock is a mock implementation of influxdb.NotificationEndpointService.
+//
+//	func TestSomethingThatUsesNotificationEndpointService(t *testing.T) {
+//
+//		// make and configure a mocked influxdb.NotificationEndpointService
+//		mockedNotificationEndpointService := &NotificationEndpointServiceMock{
+//			CreateNotificationEndpointFunc: func(ctx context.Context, ne influxdb.NotificationEndpoint, userID influxdb.ID) error {
+//				panic("mock out the CreateNotificationEndpoint method")
+//			},
+//			CreateOrganizationFunc: func(ctx context.Context, b *influxdb.Organization) error {
+//				panic("mock out the CreateOrganization method")
+//			},
+//			CreateUserResourceMappingFunc: func(ctx context.Context, m *influxdb.UserResourceMapping) error {
+//				panic("mock out the CreateUserResourceMapping method")
+//			},
+//			DeleteNotificationEndpointFunc: func(ctx context.Context, id influxdb.ID) error {
+//				panic("mock out the DeleteNotificationEndpoint method")
+//			},
+//			DeleteOrganizationFunc: func(ctx context.Context, id influxdb.ID) error {
+//				panic("mock out the DeleteOrganization method")
+//			},
+//			DeleteUserResourceMappingFunc: func(ctx context.Context, resourceID influxdb.ID, userID influxdb.ID) error {
+//				panic("mock out the DeleteUserResourceMapping method")
+//			},
+//			FindNotificationEndpointByIDFunc: func(ctx context.Context, id influxdb.ID) (influxdb.NotificationEndpoint, error) {
+//				panic("mock out the FindNotificationEndpointByID method")
+//			},
+//			FindNotificationEndpointsFunc: func(ctx context.Context, filter influxdb.NotificationEndpointFilter, opt ...influxdb.FindOptions) ([]influxdb.NotificationEndpoint, int, error) {
+//				panic("mock out the FindNotificationEndpoints method")
+//			},
+//			FindOrganizationFunc: func(ctx context.Context, filter influxdb.OrganizationFilter) (*influxdb.Organization, error) {
+//				panic("mock out the FindOrganization method")
+//			},
+//			FindOrganizationByIDFunc: func(ctx context.Context, id influxdb.ID) (*influxdb.Organization, error) {
+//				panic("mock out the FindOrganizationByID method")
+//			},
+//			FindOrganizationsFunc: func(ctx context.Context, filter influxdb.OrganizationFilter, opt ...influxdb.FindOptions) ([]*influxdb.Organization, int, error) {
+//				panic("mock out the FindOrganizations method")
+//			},
+//			FindUserResourceMappingsFunc: func(ctx context.Context, filter influxdb.UserResourceMappingFilter, opt ...influxdb.FindOptions) ([]*influxdb.UserResourceMapping, int, error) {
+//				panic("mock out the FindUserResourceMappings method")
+//			},
+//			PatchNotificationEndpointFunc: func(ctx context.Context, id influxdb.ID, upd influxdb.NotificationEndpointUpdate) (influxdb.NotificationEndpoint, error) {
+//				panic("mock out the PatchNotificationEndpoint method")
+//			},
+//			UpdateNotificationEndpointFunc: func(ctx context.Context, id influxdb.ID, nr influxdb.NotificationEndpoint, userID influxdb.ID) (influxdb.NotificationEndpoint, error) {
+//				panic("mock out the UpdateNotificationEndpoint method")
+//			},
+//			UpdateOrganizationFunc: func(ctx context.Context, id influxdb.ID, upd influxdb.OrganizationUpdate) (*influxdb.Organization, error) {
+//				panic("mock out the UpdateOrganization method")
+//			},
+//		}
+//
+//		// use mockedNotificationEndpointService in code that requires influxdb.NotificationEndpointService
+//		// and then make assertions.
+//
+//	}
+type NotificationEndpointServiceMock struct {
+	// CreateNotificationEndpointFunc mocks the CreateNotificationEndpoint method.
+	CreateNotificationEndpointFunc func(ctx context.Context, ne influxdb.NotificationEndpoint, userID influxdb.ID) error
+
+	// CreateOrganizationFunc mocks the CreateOrganization method.
+	CreateOrganizationFunc func(ctx context.Context, b *influxdb.Organization) error
+
+	// CreateUserResourceMappingFunc mocks the CreateUserResourceMapping method.
+	CreateUserResourceMappingFunc func(ctx context.Context, m *influxdb.UserResourceMapping) error
+
+	// DeleteNotificationEndpointFunc mocks the DeleteNotificationEndpoint method.
+	DeleteNotificationEndpointFunc func(ctx context.Context, id influxdb.ID) error
+
+	// DeleteOrganizationFunc mocks the DeleteOrganization method.
+	DeleteOrganizationFunc func(ctx context.Context, id influxdb.ID) error
+
+	// DeleteUserResourceMappingFunc mocks the DeleteUserResourceMapping method.
+	DeleteUserResourceMappingFunc func(ctx context.Context, resourceID influxdb.ID, userID influxdb.ID) error
+
+	// FindNotificationEndpointByIDFunc mocks the FindNotificationEndpointByID method.
+	FindNotificationEndpointByIDFunc func(ctx context.Context, id influxdb.ID) (influxdb.NotificationEndpoint, error)
+
+	// FindNotificationEndpointsFunc mocks the FindNotificationEndpoints method.
+	FindNotificationEndpointsFunc func(ctx context.Context, filter influxdb.NotificationEndpointFilter, opt ...influxdb.FindOptions) ([]influxdb.NotificationEndpoint, int, error)
+
+	// FindOrganizationFunc mocks the FindOrganization method.
+	FindOrganizationFunc func(ctx context.Context, filter influxdb.OrganizationFilter) (*influxdb.Organization, error)
+
+	// FindOrganizationByIDFunc mocks the FindOrganizationByID method.
+	FindOrganizationByIDFunc func(ctx context.Context, id influxdb.ID) (*influxdb.Organization, error)
+
+	// FindOrganizationsFunc mocks the FindOrganizations method.
+	FindOrganizationsFunc func(ctx context.Context, filter influxdb.OrganizationFilter, opt ...influxdb.FindOptions) ([]*influxdb.Organization, int, error)
+
+	// FindUserResourceMappingsFunc mocks the FindUserResourceMappings method.
+	FindUserResourceMappingsFunc func(ctx context.Context, filter influxdb.UserResourceMappingFilter, opt ...influxdb.FindOptions) ([]*influxdb.UserResourceMapping, int, error)
+
+	// PatchNotificationEndpointFunc mocks the PatchNotificationEndpoint method.
+	PatchNotificationEndpointFunc func(ctx context.Context, id influxdb.ID, upd influxdb.NotificationEndpointUpdate) (influxdb.NotificationEndpoint, error)
+
+	// UpdateNotificationEndpointFunc mocks the UpdateNotificationEndpoint method.
+	UpdateNotificationEndpointFunc func(ctx context.Context, id influxdb.ID, nr influxdb.NotificationEndpoint, userID influxdb.ID) (influxdb.NotificationEndpoint, error)
+
+	// UpdateOrganizationFunc mocks the UpdateOrganization method.
+	UpdateOrganizationFunc func(ctx context.Context, id influxdb.ID, upd influxdb.OrganizationUpdate) (*influxdb.Organization, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// CreateNotificationEndpoint holds details about calls to the CreateNotificationEndpoint method.
+		CreateNotificationEndpoint []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Ne is the ne argument value.
+			Ne influxdb.NotificationEndpoint
+			// UserID is the userID argument value.
+			UserID influxdb.ID
+		}
+		// CreateOrganization holds details about calls to the CreateOrganization method.
+		CreateOrganization []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// B is the b argument value.
+			B *influxdb.Organization
+		}
+		// CreateUserResourceMapping holds details about calls to the CreateUserResourceMapping method.
+		CreateUserResourceMapping []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// M is the m argument value.
+			M *influxdb.UserResourceMapping
+		}
+		// DeleteNotificationEndpoint holds details about calls to the DeleteNotificationEndpoint method.
+		DeleteNotificationEndpoint []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Id is the id argument value.
+			Id influxdb.ID
+		}
+		// DeleteOrganization holds details about calls to the DeleteOrganization method.
+		DeleteOrganization []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Id is the id argument value.
+			Id influxdb.ID
+		}
+		// DeleteUserResourceMapping holds details about calls to the DeleteUserResourceMapping method.
+		DeleteUserResourceMapping []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ResourceID is the resourceID argument value.
+			ResourceID influxdb.ID
+			// UserID is the userID argument value.
+			UserID influxdb.ID
+		}
+		// FindNotificationEndpointByID holds details about calls to the FindNotificationEndpointByID method.
+		FindNotificationEndpointByID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Id is the id argument value.
+			Id influxdb.ID
+		}
+		// FindNotificationEndpoints holds details about calls to the FindNotificationEndpoints method.
+		FindNotificationEndpoints []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Filter is the filter argument value.
+			Filter influxdb.NotificationEndpointFilter
+			// Opt is the opt argument value.
+			Opt []influxdb.FindOptions
+		}
+		// FindOrganization holds details about calls to the FindOrganization method.
+		FindOrganization []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Filter is the filter argument value.
+			Filter influxdb.OrganizationFilter
+		}
+		// FindOrganizationByID holds details about calls to the FindOrganizationByID method.
+		FindOrganizationByID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Id is the id argument value.
+			Id influxdb.ID
+		}
+		// FindOrganizations holds details about calls to the FindOrganizations method.
+		FindOrganizations []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Filter is the filter argument value.
+			Filter influxdb.OrganizationFilter
+			// Opt is the opt argument value.
+			Opt []influxdb.FindOptions
+		}
+		// FindUserResourceMappings holds details about calls to the FindUserResourceMappings method.
+		FindUserResourceMappings []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Filter is the filter argument value.
+			Filter influxdb.UserResourceMappingFilter
+			// Opt is the opt argument value.
+			Opt []influxdb.FindOptions
+		}
+		// PatchNotificationEndpoint holds details about calls to the PatchNotificationEndpoint method.
+		PatchNotificationEndpoint []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Id is the id argument value.
+			Id influxdb.ID
+			// Upd is the upd argument value.
+			Upd influxdb.NotificationEndpointUpdate
+		}
+		// UpdateNotificationEndpoint holds details about calls to the UpdateNotificationEndpoint method.
+		UpdateNotificationEndpoint []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Id is the id argument value.
+			Id influxdb.ID
+			// Nr is the nr argument value.
+			Nr influxdb.NotificationEndpoint
+			// UserID is the userID argument value.
+			UserID influxdb.ID
+		}
+		// UpdateOrganization holds details about calls to the UpdateOrganization method.
+		UpdateOrganization []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Id is the id argument value.
+			Id influxdb.ID
+			// Upd is the upd argument value.
+			Upd influxdb.OrganizationUpdate
+		}
+	}
+}
+
+// CreateNotificationEndpoint calls CreateNotificationEndpointFunc.
+func (mock *NotificationEndpointServiceMock) CreateNotificationEndpoint(ctx context.Context, ne influxdb.NotificationEndpoint, userID influxdb.ID) error {
+	if mock.CreateNotificationEndpointFunc == nil {
+		panic("NotificationEndpointServiceMock.CreateNotificationEndpointFunc: method is nil but NotificationEndpointService.CreateNotificationEndpoint was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Ne is the ne argument value.
+		Ne influxdb.NotificationEndpoint
+		// UserID is the userID argument value.
+		UserID influxdb.ID
+	}{
+		Ctx:    ctx,
+		Ne:     ne,
+		UserID: userID,
+	}
+	lockNotificationEndpointServiceMockCreateNotificationEndpoint.Lock()
+	mock.calls.CreateNotificationEndpoint = append(mock.calls.CreateNotificationEndpoint, callInfo)
+	lockNotificationEndpointServiceMockCreateNotificationEndpoint.Unlock()
+	return mock.CreateNotificationEndpointFunc(ctx, ne, userID)
+}
+
+// CreateNotificationEndpointCalls gets all the calls that were made to CreateNotificationEndpoint.
+// Check the length with:
+//
+//	len(mockedNotificationEndpointService.CreateNotificationEndpointCalls())
+func (mock *NotificationEndpointServiceMock) CreateNotificationEndpointCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// Ne is the ne argument value.
+	Ne influxdb.NotificationEndpoint
+	// UserID is the userID argument value.
+	UserID influxdb.ID
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Ne is the ne argument value.
+		Ne influxdb.NotificationEndpoint
+		// UserID is the userID argument value.
+		UserID influxdb.ID
+	}
+	lockNotificationEndpointServiceMockCreateNotificationEndpoint.RLock()
+	calls = mock.calls.CreateNotificationEndpoint
+	lockNotificationEndpointServiceMockCreateNotificationEndpoint.RUnlock()
+	return calls
+}
+
+// CreateOrganization calls CreateOrganizationFunc.
+func (mock *NotificationEndpointServiceMock) CreateOrganization(ctx context.Context, b *influxdb.Organization) error {
+	if mock.CreateOrganizationFunc == nil {
+		panic("NotificationEndpointServiceMock.CreateOrganizationFunc: method is nil but NotificationEndpointService.CreateOrganization was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// B is the b argument value.
+		B *influxdb.Organization
+	}{
+		Ctx: ctx,
+		B:   b,
+	}
+	lockNotificationEndpointServiceMockCreateOrganization.Lock()
+	mock.calls.CreateOrganization = append(mock.calls.CreateOrganization, callInfo)
+	lockNotificationEndpointServiceMockCreateOrganization.Unlock()
+	return mock.CreateOrganizationFunc(ctx, b)
+}
+
+// CreateOrganizationCalls gets all the calls that were made to CreateOrganization.
+// Check the length with:
+//
+//	len(mockedNotificationEndpointService.CreateOrganizationCalls())
+func (mock *NotificationEndpointServiceMock) CreateOrganizationCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// B is the b argument value.
+	B *influxdb.Organization
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// B is the b argument value.
+		B *influxdb.Organization
+	}
+	lockNotificationEndpointServiceMockCreateOrganization.RLock()
+	calls = mock.calls.CreateOrganization
+	lockNotificationEndpointServiceMockCreateOrganization.RUnlock()
+	return calls
+}
+
+// CreateUserResourceMapping calls CreateUserResourceMappingFunc.
+func (mock *NotificationEndpointServiceMock) CreateUserResourceMapping(ctx context.Context, m *influxdb.UserResourceMapping) error {
+	if mock.CreateUserResourceMappingFunc == nil {
+		panic("NotificationEndpointServiceMock.CreateUserResourceMappingFunc: method is nil but NotificationEndpointService.CreateUserResourceMapping was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// M is the m argument value.
+		M *influxdb.UserResourceMapping
+	}{
+		Ctx: ctx,
+		M:   m,
+	}
+	lockNotificationEndpointServiceMockCreateUserResourceMapping.Lock()
+	mock.calls.CreateUserResourceMapping = append(mock.calls.CreateUserResourceMapping, callInfo)
+	lockNotificationEndpointServiceMockCreateUserResourceMapping.Unlock()
+	return mock.CreateUserResourceMappingFunc(ctx, m)
+}
+
+// CreateUserResourceMappingCalls gets all the calls that were made to CreateUserResourceMapping.
+// Check the length with:
+//
+//	len(mockedNotificationEndpointService.CreateUserResourceMappingCalls())
+func (mock *NotificationEndpointServiceMock) CreateUserResourceMappingCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// M is the m argument value.
+	M *influxdb.UserResourceMapping
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// M is the m argument value.
+		M *influxdb.UserResourceMapping
+	}
+	lockNotificationEndpointServiceMockCreateUserResourceMapping.RLock()
+	calls = mock.calls.CreateUserResourceMapping
+	lockNotificationEndpointServiceMockCreateUserResourceMapping.RUnlock()
+	return calls
+}
+
+// DeleteNotificationEndpoint calls DeleteNotificationEndpointFunc.
+func (mock *NotificationEndpointServiceMock) DeleteNotificationEndpoint(ctx context.Context, id influxdb.ID) error {
+	if mock.DeleteNotificationEndpointFunc == nil {
+		panic("NotificationEndpointServiceMock.DeleteNotificationEndpointFunc: method is nil but NotificationEndpointService.DeleteNotificationEndpoint was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Id is the id argument value.
+		Id influxdb.ID
+	}{
+		Ctx: ctx,
+		Id:  id,
+	}
+	lockNotificationEndpointServiceMockDeleteNotificationEndpoint.Lock()
+	mock.calls.DeleteNotificationEndpoint = append(mock.calls.DeleteNotificationEndpoint, callInfo)
+	lockNotificationEndpointServiceMockDeleteNotificationEndpoint.Unlock()
+	return mock.DeleteNotificationEndpointFunc(ctx, id)
+}
+
+// DeleteNotificationEndpointCalls gets all the calls that were made to DeleteNotificationEndpoint.
+// Check the length with:
+//
+//	len(mockedNotificationEndpointService.DeleteNotificationEndpointCalls())
+func (mock *NotificationEndpointServiceMock) DeleteNotificationEndpointCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// Id is the id argument value.
+	Id influxdb.ID
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Id is the id argument value.
+		Id influxdb.ID
+	}
+	lockNotificationEndpointServiceMockDeleteNotificationEndpoint.RLock()
+	calls = mock.calls.DeleteNotificationEndpoint
+	lockNotificationEndpointServiceMockDeleteNotificationEndpoint.RUnlock()
+	return calls
+}
+
+// DeleteOrganization calls DeleteOrganizationFunc.
+func (mock *NotificationEndpointServiceMock) DeleteOrganization(ctx context.Context, id influxdb.ID) error {
+	if mock.DeleteOrganizationFunc == nil {
+		panic("NotificationEndpointServiceMock.DeleteOrganizationFunc: method is nil but NotificationEndpointService.DeleteOrganization was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Id is the id argument value.
+		Id influxdb.ID
+	}{
+		Ctx: ctx,
+		Id:  id,
+	}
+	lockNotificationEndpointServiceMockDeleteOrganization.Lock()
+	mock.calls.DeleteOrganization = append(mock.calls.DeleteOrganization, callInfo)
+	lockNotificationEndpointServiceMockDeleteOrganization.Unlock()
+	return mock.DeleteOrganizationFunc(ctx, id)
+}
+
+// DeleteOrganizationCalls gets all the calls that were made to DeleteOrganization.
+// Check the length with:
+//
+//	len(mockedNotificationEndpointService.DeleteOrganizationCalls())
+func (mock *NotificationEndpointServiceMock) DeleteOrganizationCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// Id is the id argument value.
+	Id influxdb.ID
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Id is the id argument value.
+		Id influxdb.ID
+	}
+	lockNotificationEndpointServiceMockDeleteOrganization.RLock()
+	calls = mock.calls.DeleteOrganization
+	lockNotificationEndpointServiceMockDeleteOrganization.RUnlock()
+	return calls
+}
+
+// DeleteUserResourceMapping calls DeleteUserResourceMappingFunc.
+func (mock *NotificationEndpointServiceMock) DeleteUserResourceMapping(ctx context.Context, resourceID influxdb.ID, userID influxdb.ID) error {
+	if mock.DeleteUserResourceMappingFunc == nil {
+		panic("NotificationEndpointServiceMock.DeleteUserResourceMappingFunc: method is nil but NotificationEndpointService.DeleteUserResourceMapping was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// ResourceID is the resourceID argument value.
+		ResourceID influxdb.ID
+		// UserID is the userID argument value.
+		UserID influxdb.ID
+	}{
+		Ctx:        ctx,
+		ResourceID: resourceID,
+		UserID:     userID,
+	}
+	lockNotificationEndpointServiceMockDeleteUserResourceMapping.Lock()
+	mock.calls.DeleteUserResourceMapping = append(mock.calls.DeleteUserResourceMapping, callInfo)
+	lockNotificationEndpointServiceMockDeleteUserResourceMapping.Unlock()
+	return mock.DeleteUserResourceMappingFunc(ctx, resourceID, userID)
+}
+
+// DeleteUserResourceMappingCalls gets all the calls that were made to DeleteUserResourceMapping.
+// Check the length with:
+//
+//	len(mockedNotificationEndpointService.DeleteUserResourceMappingCalls())
+func (mock *NotificationEndpointServiceMock) DeleteUserResourceMappingCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// ResourceID is the resourceID argument value.
+	ResourceID influxdb.ID
+	// UserID is the userID argument value.
+	UserID influxdb.ID
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// ResourceID is the resourceID argument value.
+		ResourceID influxdb.ID
+		// UserID is the userID argument value.
+		UserID influxdb.ID
+	}
+	lockNotificationEndpointServiceMockDeleteUserResourceMapping.RLock()
+	calls = mock.calls.DeleteUserResourceMapping
+	lockNotificationEndpointServiceMockDeleteUserResourceMapping.RUnlock()
+	return calls
+}
+
+// FindNotificationEndpointByID calls FindNotificationEndpointByIDFunc.
+func (mock *NotificationEndpointServiceMock) FindNotificationEndpointByID(ctx context.Context, id influxdb.ID) (influxdb.NotificationEndpoint, error) {
+	if mock.FindNotificationEndpointByIDFunc == nil {
+		panic("NotificationEndpointServiceMock.FindNotificationEndpointByIDFunc: method is nil but NotificationEndpointService.FindNotificationEndpointByID was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Id is the id argument value.
+		Id influxdb.ID
+	}{
+		Ctx: ctx,
+		Id:  id,
+	}
+	lockNotificationEndpointServiceMockFindNotificationEndpointByID.Lock()
+	mock.calls.FindNotificationEndpointByID = append(mock.calls.FindNotificationEndpointByID, callInfo)
+	lockNotificationEndpointServiceMockFindNotificationEndpointByID.Unlock()
+	return mock.FindNotificationEndpointByIDFunc(ctx, id)
+}
+
+// FindNotificationEndpointByIDCalls gets all the calls that were made to FindNotificationEndpointByID.
+// Check the length with:
+//
+//	len(mockedNotificationEndpointService.FindNotificationEndpointByIDCalls())
+func (mock *NotificationEndpointServiceMock) FindNotificationEndpointByIDCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// Id is the id argument value.
+	Id influxdb.ID
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Id is the id argument value.
+		Id influxdb.ID
+	}
+	lockNotificationEndpointServiceMockFindNotificationEndpointByID.RLock()
+	calls = mock.calls.FindNotificationEndpointByID
+	lockNotificationEndpointServiceMockFindNotificationEndpointByID.RUnlock()
+	return calls
+}
+
+// FindNotificationEndpoints calls FindNotificationEndpointsFunc.
+func (mock *NotificationEndpointServiceMock) FindNotificationEndpoints(ctx context.Context, filter influxdb.NotificationEndpointFilter, opt ...influxdb.FindOptions) ([]influxdb.NotificationEndpoint, int, error) {
+	if mock.FindNotificationEndpointsFunc == nil {
+		panic("NotificationEndpointServiceMock.FindNotificationEndpointsFunc: method is nil but NotificationEndpointService.FindNotificationEndpoints was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Filter is the filter argument value.
+		Filter influxdb.NotificationEndpointFilter
+		// Opt is the opt argument value.
+		Opt []influxdb.FindOptions
+	}{
+		Ctx:    ctx,
+		Filter: filter,
+		Opt:    opt,
+	}
+	lockNotificationEndpointServiceMockFindNotificationEndpoints.Lock()
+	mock.calls.FindNotificationEndpoints = append(mock.calls.FindNotificationEndpoints, callInfo)
+	lockNotificationEndpointServiceMockFindNotificationEndpoints.Unlock()
+	return mock.FindNotificationEndpointsFunc(ctx, filter, opt...)
+}
+
+// FindNotificationEndpointsCalls gets all the calls that were made to FindNotificationEndpoints.
+// Check the length with:
+//
+//	len(mockedNotificationEndpointService.FindNotificationEndpointsCalls())
+func (mock *NotificationEndpointServiceMock) FindNotificationEndpointsCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// Filter is the filter argument value.
+	Filter influxdb.NotificationEndpointFilter
+	// Opt is the opt argument value.
+	Opt []influxdb.FindOptions
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Filter is the filter argument value.
+		Filter influxdb.NotificationEndpointFilter
+		// Opt is the opt argument value.
+		Opt []influxdb.FindOptions
+	}
+	lockNotificationEndpointServiceMockFindNotificationEndpoints.RLock()
+	calls = mock.calls.FindNotificationEndpoints
+	lockNotificationEndpointServiceMockFindNotificationEndpoints.RUnlock()
+	return calls
+}
+
+// FindOrganization calls FindOrganizationFunc.
+func (mock *NotificationEndpointServiceMock) FindOrganization(ctx context.Context, filter influxdb.OrganizationFilter) (*influxdb.Organization, error) {
+	if mock.FindOrganizationFunc == nil {
+		panic("NotificationEndpointServiceMock.FindOrganizationFunc: method is nil but NotificationEndpointService.FindOrganization was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Filter is the filter argument value.
+		Filter influxdb.OrganizationFilter
+	}{
+		Ctx:    ctx,
+		Filter: filter,
+	}
+	lockNotificationEndpointServiceMockFindOrganization.Lock()
+	mock.calls.FindOrganization = append(mock.calls.FindOrganization, callInfo)
+	lockNotificationEndpointServiceMockFindOrganization.Unlock()
+	return mock.FindOrganizationFunc(ctx, filter)
+}
+
+// FindOrganizationCalls gets all the calls that were made to FindOrganization.
+// Check the length with:
+//
+//	len(mockedNotificationEndpointService.FindOrganizationCalls())
+func (mock *NotificationEndpointServiceMock) FindOrganizationCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// Filter is the filter argument value.
+	Filter influxdb.OrganizationFilter
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Filter is the filter argument value.
+		Filter influxdb.OrganizationFilter
+	}
+	lockNotificationEndpointServiceMockFindOrganization.RLock()
+	calls = mock.calls.FindOrganization
+	lockNotificationEndpointServiceMockFindOrganization.RUnlock()
+	return calls
+}
+
+// FindOrganizationByID calls FindOrganizationByIDFunc.
+func (mock *NotificationEndpointServiceMock) FindOrganizationByID(ctx context.Context, id influxdb.ID) (*influxdb.Organization, error) {
+	if mock.FindOrganizationByIDFunc == nil {
+		panic("NotificationEndpointServiceMock.FindOrganizationByIDFunc: method is nil but NotificationEndpointService.FindOrganizationByID was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Id is the id argument value.
+		Id influxdb.ID
+	}{
+		Ctx: ctx,
+		Id:  id,
+	}
+	lockNotificationEndpointServiceMockFindOrganizationByID.Lock()
+	mock.calls.FindOrganizationByID = append(mock.calls.FindOrganizationByID, callInfo)
+	lockNotificationEndpointServiceMockFindOrganizationByID.Unlock()
+	return mock.FindOrganizationByIDFunc(ctx, id)
+}
+
+// FindOrganizationByIDCalls gets all the calls that were made to FindOrganizationByID.
+// Check the length with:
+//
+//	len(mockedNotificationEndpointService.FindOrganizationByIDCalls())
+func (mock *NotificationEndpointServiceMock) FindOrganizationByIDCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// Id is the id argument value.
+	Id influxdb.ID
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Id is the id argument value.
+		Id influxdb.ID
+	}
+	lockNotificationEndpointServiceMockFindOrganizationByID.RLock()
+	calls = mock.calls.FindOrganizationByID
+	lockNotificationEndpointServiceMockFindOrganizationByID.RUnlock()
+	return calls
+}
+
+// FindOrganizations calls FindOrganizationsFunc.
+func (mock *NotificationEndpointServiceMock) FindOrganizations(ctx context.Context, filter influxdb.OrganizationFilter, opt ...influxdb.FindOptions) ([]*influxdb.Organization, int, error) {
+	if mock.FindOrganizationsFunc == nil {
+		panic("NotificationEndpointServiceMock.FindOrganizationsFunc: method is nil but NotificationEndpointService.FindOrganizations was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Filter is the filter argument value.
+		Filter influxdb.OrganizationFilter
+		// Opt is the opt argument value.
+		Opt []influxdb.FindOptions
+	}{
+		Ctx:    ctx,
+		Filter: filter,
+		Opt:    opt,
+	}
+	lockNotificationEndpointServiceMockFindOrganizations.Lock()
+	mock.calls.FindOrganizations = append(mock.calls.FindOrganizations, callInfo)
+	lockNotificationEndpointServiceMockFindOrganizations.Unlock()
+	return mock.FindOrganizationsFunc(ctx, filter, opt...)
+}
+
+// FindOrganizationsCalls gets all the calls that were made to FindOrganizations.
+// Check the length with:
+//
+//	len(mockedNotificationEndpointService.FindOrganizationsCalls())
+func (mock *NotificationEndpointServiceMock) FindOrganizationsCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// Filter is the filter argument value.
+	Filter influxdb.OrganizationFilter
+	// Opt is the opt argument value.
+	Opt []influxdb.FindOptions
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Filter is the filter argument value.
+		Filter influxdb.OrganizationFilter
+		// Opt is the opt argument value.
+		Opt []influxdb.FindOptions
+	}
+	lockNotificationEndpointServiceMockFindOrganizations.RLock()
+	calls = mock.calls.FindOrganizations
+	lockNotificationEndpointServiceMockFindOrganizations.RUnlock()
+	return calls
+}
+
+// FindUserResourceMappings calls FindUserResourceMappingsFunc.
+func (mock *NotificationEndpointServiceMock) FindUserResourceMappings(ctx context.Context, filter influxdb.UserResourceMappingFilter, opt ...influxdb.FindOptions) ([]*influxdb.UserResourceMapping, int, error) {
+	if mock.FindUserResourceMappingsFunc == nil {
+		panic("NotificationEndpointServiceMock.FindUserResourceMappingsFunc: method is nil but NotificationEndpointService.FindUserResourceMappings was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Filter is the filter argument value.
+		Filter influxdb.UserResourceMappingFilter
+		// Opt is the opt argument value.
+		Opt []influxdb.FindOptions
+	}{
+		Ctx:    ctx,
+		Filter: filter,
+		Opt:    opt,
+	}
+	lockNotificationEndpointServiceMockFindUserResourceMappings.Lock()
+	mock.calls.FindUserResourceMappings = append(mock.calls.FindUserResourceMappings, callInfo)
+	lockNotificationEndpointServiceMockFindUserResourceMappings.Unlock()
+	return mock.FindUserResourceMappingsFunc(ctx, filter, opt...)
+}
+
+// FindUserResourceMappingsCalls gets all the calls that were made to FindUserResourceMappings.
+// Check the length with:
+//
+//	len(mockedNotificationEndpointService.FindUserResourceMappingsCalls())
+func (mock *NotificationEndpointServiceMock) FindUserResourceMappingsCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// Filter is the filter argument value.
+	Filter influxdb.UserResourceMappingFilter
+	// Opt is the opt argument value.
+	Opt []influxdb.FindOptions
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Filter is the filter argument value.
+		Filter influxdb.UserResourceMappingFilter
+		// Opt is the opt argument value.
+		Opt []influxdb.FindOptions
+	}
+	lockNotificationEndpointServiceMockFindUserResourceMappings.RLock()
+	calls = mock.calls.FindUserResourceMappings
+	lockNotificationEndpointServiceMockFindUserResourceMappings.RUnlock()
+	return calls
+}
+
+// PatchNotificationEndpoint calls PatchNotificationEndpointFunc.
+func (mock *NotificationEndpointServiceMock) PatchNotificationEndpoint(ctx context.Context, id influxdb.ID, upd influxdb.NotificationEndpointUpdate) (influxdb.NotificationEndpoint, error) {
+	if mock.PatchNotificationEndpointFunc == nil {
+		panic("NotificationEndpointServiceMock.PatchNotificationEndpointFunc: method is nil but NotificationEndpointService.PatchNotificationEndpoint was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Id is the id argument value.
+		Id influxdb.ID
+		// Upd is the upd argument value.
+		Upd influxdb.NotificationEndpointUpdate
+	}{
+		Ctx: ctx,
+		Id:  id,
+		Upd: upd,
+	}
+	lockNotificationEndpointServiceMockPatchNotificationEndpoint.Lock()
+	mock.calls.PatchNotificationEndpoint = append(mock.calls.PatchNotificationEndpoint, callInfo)
+	lockNotificationEndpointServiceMockPatchNotificationEndpoint.Unlock()
+	return mock.PatchNotificationEndpointFunc(ctx, id, upd)
+}
+
+// PatchNotificationEndpointCalls gets all the calls that were made to PatchNotificationEndpoint.
+// Check the length with:
+//
+//	len(mockedNotificationEndpointService.PatchNotificationEndpointCalls())
+func (mock *NotificationEndpointServiceMock) PatchNotificationEndpointCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// Id is the id argument value.
+	Id influxdb.ID
+	// Upd is the upd argument value.
+	Upd influxdb.NotificationEndpointUpdate
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Id is the id argument value.
+		Id influxdb.ID
+		// Upd is the upd argument value.
+		Upd influxdb.NotificationEndpointUpdate
+	}
+	lockNotificationEndpointServiceMockPatchNotificationEndpoint.RLock()
+	calls = mock.calls.PatchNotificationEndpoint
+	lockNotificationEndpointServiceMockPatchNotificationEndpoint.RUnlock()
+	return calls
+}
+
+// UpdateNotificationEndpoint calls UpdateNotificationEndpointFunc.
+func (mock *NotificationEndpointServiceMock) UpdateNotificationEndpoint(ctx context.Context, id influxdb.ID, nr influxdb.NotificationEndpoint, userID influxdb.ID) (influxdb.NotificationEndpoint, error) {
+	if mock.UpdateNotificationEndpointFunc == nil {
+		panic("NotificationEndpointServiceMock.UpdateNotificationEndpointFunc: method is nil but NotificationEndpointService.UpdateNotificationEndpoint was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Id is the id argument value.
+		Id influxdb.ID
+		// Nr is the nr argument value.
+		Nr influxdb.NotificationEndpoint
+		// UserID is the userID argument value.
+		UserID influxdb.ID
+	}{
+		Ctx:    ctx,
+		Id:     id,
+		Nr:     nr,
+		UserID: userID,
+	}
+	lockNotificationEndpointServiceMockUpdateNotificationEndpoint.Lock()
+	mock.calls.UpdateNotificationEndpoint = append(mock.calls.UpdateNotificationEndpoint, callInfo)
+	lockNotificationEndpointServiceMockUpdateNotificationEndpoint.Unlock()
+	return mock.UpdateNotificationEndpointFunc(ctx, id, nr, userID)
+}
+
+// UpdateNotificationEndpointCalls gets all the calls that were made to UpdateNotificationEndpoint.
+// Check the length with:
+//
+//	len(mockedNotificationEndpointService.UpdateNotificationEndpointCalls())
+func (mock *NotificationEndpointServiceMock) UpdateNotificationEndpointCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// Id is the id argument value.
+	Id influxdb.ID
+	// Nr is the nr argument value.
+	Nr influxdb.NotificationEndpoint
+	// UserID is the userID argument value.
+	UserID influxdb.ID
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Id is the id argument value.
+		Id influxdb.ID
+		// Nr is the nr argument value.
+		Nr influxdb.NotificationEndpoint
+		// UserID is the userID argument value.
+		UserID influxdb.ID
+	}
+	lockNotificationEndpointServiceMockUpdateNotificationEndpoint.RLock()
+	calls = mock.calls.UpdateNotificationEndpoint
+	lockNotificationEndpointServiceMockUpdateNotificationEndpoint.RUnlock()
+	return calls
+}
+
+// UpdateOrganization calls UpdateOrganizationFunc.
+func (mock *NotificationEndpointServiceMock) UpdateOrganization(ctx context.Context, id influxdb.ID, upd influxdb.OrganizationUpdate) (*influxdb.Organization, error) {
+	if mock.UpdateOrganizationFunc == nil {
+		panic("NotificationEndpointServiceMock.UpdateOrganizationFunc: method is nil but NotificationEndpointService.UpdateOrganization was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Id is the id argument value.
+		Id influxdb.ID
+		// Upd is the upd argument value.
+		Upd influxdb.OrganizationUpdate
+	}{
+		Ctx: ctx,
+		Id:  id,
+		Upd: upd,
+	}
+	lockNotificationEndpointServiceMockUpdateOrganization.Lock()
+	mock.calls.UpdateOrganization = append(mock.calls.UpdateOrganization, callInfo)
+	lockNotificationEndpointServiceMockUpdateOrganization.Unlock()
+	return mock.UpdateOrganizationFunc(ctx, id, upd)
+}
+
+// UpdateOrganizationCalls gets all the calls that were made to UpdateOrganization.
+// Check the length with:
+//
+//	len(mockedNotificationEndpointService.UpdateOrganizationCalls())
+func (mock *NotificationEndpointServiceMock) UpdateOrganizationCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// Id is the id argument value.
+	Id influxdb.ID
+	// Upd is the upd argument value.
+	Upd influxdb.OrganizationUpdate
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Id is the id argument value.
+		Id influxdb.ID
+		// Upd is the upd argument value.
+		Upd influxdb.OrganizationUpdate
+	}
+	lockNotificationEndpointServiceMockUpdateOrganization.RLock()
+	calls = mock.calls.UpdateOrganization
+	lockNotificationEndpointServiceMockUpdateOrganization.RUnlock()
+	return calls
+}
@@ -0,0 +1,31 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.NotificationPreferencesService = &NotificationPreferencesService{}
+
+// NotificationPreferencesService represents a service for managing the notification preferences of users.
+type NotificationPreferencesService struct {
+	FindNotificationPreferencesF   func(ctx context.Context, userID influxdb.ID) (*influxdb.NotificationPreferences, error)
+	PutNotificationPreferencesF    func(ctx context.Context, p *influxdb.NotificationPreferences) error
+	DeleteNotificationPreferencesF func(ctx context.Context, userID influxdb.ID) error
+}
+
+// FindNotificationPreferences returns the notification preferences of a user.
+func (s *NotificationPreferencesService) FindNotificationPreferences(ctx context.Context, userID influxdb.ID) (*influxdb.NotificationPreferences, error) {
+	return s.FindNotificationPreferencesF(ctx, userID)
+}
+
+// PutNotificationPreferences creates or replaces the notification preferences of a user.
+func (s *NotificationPreferencesService) PutNotificationPreferences(ctx context.Context, p *influxdb.NotificationPreferences) error {
+	return s.PutNotificationPreferencesF(ctx, p)
+}
+
+// DeleteNotificationPreferences removes the notification preferences of a user.
+func (s *NotificationPreferencesService) DeleteNotificationPreferences(ctx context.Context, userID influxdb.ID) error {
+	return s.DeleteNotificationPreferencesF(ctx, userID)
+}
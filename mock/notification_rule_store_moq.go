@@ -0,0 +1,956 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mock
+
+import (
+	"context"
+	"github.com/influxdata/influxdb"
+	"sync"
+)
+
+var (
+	lockNotificationRuleStoreMockCreateNotificationRule    sync.RWMutex
+	lockNotificationRuleStoreMockCreateOrganization        sync.RWMutex
+	lockNotificationRuleStoreMockCreateUserResourceMapping sync.RWMutex
+	lockNotificationRuleStoreMockDeleteNotificationRule    sync.RWMutex
+	lockNotificationRuleStoreMockDeleteOrganization        sync.RWMutex
+	lockNotificationRuleStoreMockDeleteUserResourceMapping sync.RWMutex
+	lockNotificationRuleStoreMockFindNotificationRuleByID  sync.RWMutex
+	lockNotificationRuleStoreMockFindNotificationRules     sync.RWMutex
+	lockNotificationRuleStoreMockFindOrganization          sync.RWMutex
+	lockNotificationRuleStoreMockFindOrganizationByID      sync.RWMutex
+	lockNotificationRuleStoreMockFindOrganizations         sync.RWMutex
+	lockNotificationRuleStoreMockFindUserResourceMappings  sync.RWMutex
+	lockNotificationRuleStoreMockPatchNotificationRule     sync.RWMutex
+	lockNotificationRuleStoreMockUpdateNotificationRule    sync.RWMutex
+	lockNotificationRuleStoreMockUpdateOrganization        sync.RWMutex
+)
+
+// Ensure, that NotificationRuleStoreMock does implement influxdb.NotificationRuleStore.
+// If this is not the case, regenerate this file with moq.
+var _ influxdb.NotificationRuleStore = &NotificationRuleStoreMock{}
+
+// NotificationRuleStoreMock is a mock implementation of influxdb.NotificationRuleStore.
+//
+//	func TestSomethingThatUsesNotificationRuleStore(t *testing.T) {
+//
+//		// make and configure a mocked influxdb.NotificationRuleStore
+//		mockedNotificationRuleStore := &NotificationRuleStoreMock{
+//			CreateNotificationRuleFunc: func(ctx context.Context, nr influxdb.NotificationRule, userID influxdb.ID) error {
+//				panic("mock out the CreateNotificationRule method")
+//			},
+//			CreateOrganizationFunc: func(ctx context.Context, b *influxdb.Organization) error {
+//				panic("mock out the CreateOrganization method")
+//			},
+//			CreateUserResourceMappingFunc: func(ctx context.Context, m *influxdb.UserResourceMapping) error {
+//				panic("mock out the CreateUserResourceMapping method")
+//			},
+//			DeleteNotificationRuleFunc: func(ctx context.Context, id influxdb.ID) error {
+//				panic("mock out the DeleteNotificationRule method")
+//			},
+//			DeleteOrganizationFunc: func(ctx context.Context, id influxdb.ID) error {
+//				panic("mock out the DeleteOrganization method")
+//			},
+//			DeleteUserResourceMappingFunc: func(ctx context.Context, resourceID influxdb.ID, userID influxdb.ID) error {
+//				panic("mock out the DeleteUserResourceMapping method")
+//			},
+//			FindNotificationRuleByIDFunc: func(ctx context.Context, id influxdb.ID) (influxdb.NotificationRule, error) {
+//				panic("mock out the FindNotificationRuleByID method")
+//			},
+//			FindNotificationRulesFunc: func(ctx context.Context, filter influxdb.NotificationRuleFilter, opt ...influxdb.FindOptions) ([]influxdb.NotificationRule, int, error) {
+//				panic("mock out the FindNotificationRules method")
+//			},
+//			FindOrganizationFunc: func(ctx context.Context, filter influxdb.OrganizationFilter) (*influxdb.Organization, error) {
+//				panic("mock out the FindOrganization method")
+//			},
+//			FindOrganizationByIDFunc: func(ctx context.Context, id influxdb.ID) (*influxdb.Organization, error) {
+//				panic("mock out the FindOrganizationByID method")
+//			},
+//			FindOrganizationsFunc: func(ctx context.Context, filter influxdb.OrganizationFilter, opt ...influxdb.FindOptions) ([]*influxdb.Organization, int, error) {
+//				panic("mock out the FindOrganizations method")
+//			},
+//			FindUserResourceMappingsFunc: func(ctx context.Context, filter influxdb.UserResourceMappingFilter, opt ...influxdb.FindOptions) ([]*influxdb.UserResourceMapping, int, error) {
+//				panic("mock out the FindUserResourceMappings method")
+//			},
+//			PatchNotificationRuleFunc: func(ctx context.Context, id influxdb.ID, upd influxdb.NotificationRuleUpdate) (influxdb.NotificationRule, error) {
+//				panic("mock out the PatchNotificationRule method")
+//			},
+//			UpdateNotificationRuleFunc: func(ctx context.Context, id influxdb.ID, nr influxdb.NotificationRule, userID influxdb.ID) (influxdb.NotificationRule, error) {
+//				panic("mock out the UpdateNotificationRule method")
+//			},
+//			UpdateOrganizationFunc: func(ctx context.Context, id influxdb.ID, upd influxdb.OrganizationUpdate) (*influxdb.Organization, error) {
+//				panic("mock out the UpdateOrganization method")
+//			},
+//		}
+//
+//		// use mockedNotificationRuleStore in code that requires influxdb.NotificationRuleStore
+//		// and then make assertions.
+//
+//	}
+type NotificationRuleStoreMock struct {
+	// CreateNotificationRuleFunc mocks the CreateNotificationRule method.
+	CreateNotificationRuleFunc func(ctx context.Context, nr influxdb.NotificationRule, userID influxdb.ID) error
+
+	// CreateOrganizationFunc mocks the CreateOrganization method.
+	CreateOrganizationFunc func(ctx context.Context, b *influxdb.Organization) error
+
+	// CreateUserResourceMappingFunc mocks the CreateUserResourceMapping method.
+	CreateUserResourceMappingFunc func(ctx context.Context, m *influxdb.UserResourceMapping) error
+
+	// DeleteNotificationRuleFunc mocks the DeleteNotificationRule method.
+	DeleteNotificationRuleFunc func(ctx context.Context, id influxdb.ID) error
+
+	// DeleteOrganizationFunc mocks the DeleteOrganization method.
+	DeleteOrganizationFunc func(ctx context.Context, id influxdb.ID) error
+
+	// DeleteUserResourceMappingFunc mocks the DeleteUserResourceMapping method.
+	DeleteUserResourceMappingFunc func(ctx context.Context, resourceID influxdb.ID, userID influxdb.ID) error
+
+	// FindNotificationRuleByIDFunc mocks the FindNotificationRuleByID method.
+	FindNotificationRuleByIDFunc func(ctx context.Context, id influxdb.ID) (influxdb.NotificationRule, error)
+
+	// FindNotificationRulesFunc mocks the FindNotificationRules method.
+	FindNotificationRulesFunc func(ctx context.Context, filter influxdb.NotificationRuleFilter, opt ...influxdb.FindOptions) ([]influxdb.NotificationRule, int, error)
+
+	// FindOrganizationFunc mocks the FindOrganization method.
+	FindOrganizationFunc func(ctx context.Context, filter influxdb.OrganizationFilter) (*influxdb.Organization, error)
+
+	// FindOrganizationByIDFunc mocks the FindOrganizationByID method.
+	FindOrganizationByIDFunc func(ctx context.Context, id influxdb.ID) (*influxdb.Organization, error)
+
+	// FindOrganizationsFunc mocks the FindOrganizations method.
+	FindOrganizationsFunc func(ctx context.Context, filter influxdb.OrganizationFilter, opt ...influxdb.FindOptions) ([]*influxdb.Organization, int, error)
+
+	// FindUserResourceMappingsFunc mocks the FindUserResourceMappings method.
+	FindUserResourceMappingsFunc func(ctx context.Context, filter influxdb.UserResourceMappingFilter, opt ...influxdb.FindOptions) ([]*influxdb.UserResourceMapping, int, error)
+
+	// PatchNotificationRuleFunc mocks the PatchNotificationRule method.
+	PatchNotificationRuleFunc func(ctx context.Context, id influxdb.ID, upd influxdb.NotificationRuleUpdate) (influxdb.NotificationRule, error)
+
+	// UpdateNotificationRuleFunc mocks the UpdateNotificationRule method.
+	UpdateNotificationRuleFunc func(ctx context.Context, id influxdb.ID, nr influxdb.NotificationRule, userID influxdb.ID) (influxdb.NotificationRule, error)
+
+	// UpdateOrganizationFunc mocks the UpdateOrganization method.
+	UpdateOrganizationFunc func(ctx context.Context, id influxdb.ID, upd influxdb.OrganizationUpdate) (*influxdb.Organization, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// CreateNotificationRule holds details about calls to the CreateNotificationRule method.
+		CreateNotificationRule []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Nr is the nr argument value.
+			Nr influxdb.NotificationRule
+			// UserID is the userID argument value.
+			UserID influxdb.ID
+		}
+		// CreateOrganization holds details about calls to the CreateOrganization method.
+		CreateOrganization []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// B is the b argument value.
+			B *influxdb.Organization
+		}
+		// CreateUserResourceMapping holds details about calls to the CreateUserResourceMapping method.
+		CreateUserResourceMapping []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// M is the m argument value.
+			M *influxdb.UserResourceMapping
+		}
+		// DeleteNotificationRule holds details about calls to the DeleteNotificationRule method.
+		DeleteNotificationRule []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Id is the id argument value.
+			Id influxdb.ID
+		}
+		// DeleteOrganization holds details about calls to the DeleteOrganization method.
+		DeleteOrganization []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Id is the id argument value.
+			Id influxdb.ID
+		}
+		// DeleteUserResourceMapping holds details about calls to the DeleteUserResourceMapping method.
+		DeleteUserResourceMapping []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ResourceID is the resourceID argument value.
+			ResourceID influxdb.ID
+			// UserID is the userID argument value.
+			UserID influxdb.ID
+		}
+		// FindNotificationRuleByID holds details about calls to the FindNotificationRuleByID method.
+		FindNotificationRuleByID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Id is the id argument value.
+			Id influxdb.ID
+		}
+		// FindNotificationRules holds details about calls to the FindNotificationRules method.
+		FindNotificationRules []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Filter is the filter argument value.
+			Filter influxdb.NotificationRuleFilter
+			// Opt is the opt argument value.
+			Opt []influxdb.FindOptions
+		}
+		// FindOrganization holds details about calls to the FindOrganization method.
+		FindOrganization []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Filter is the filter argument value.
+			Filter influxdb.OrganizationFilter
+		}
+		// FindOrganizationByID holds details about calls to the FindOrganizationByID method.
+		FindOrganizationByID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Id is the id argument value.
+			Id influxdb.ID
+		}
+		// FindOrganizations holds details about calls to the FindOrganizations method.
+		FindOrganizations []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Filter is the filter argument value.
+			Filter influxdb.OrganizationFilter
+			// Opt is the opt argument value.
+			Opt []influxdb.FindOptions
+		}
+		// FindUserResourceMappings holds details about calls to the FindUserResourceMappings method.
+		FindUserResourceMappings []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Filter is the filter argument value.
+			Filter influxdb.UserResourceMappingFilter
+			// Opt is the opt argument value.
+			Opt []influxdb.FindOptions
+		}
+		// PatchNotificationRule holds details about calls to the PatchNotificationRule method.
+		PatchNotificationRule []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Id is the id argument value.
+			Id influxdb.ID
+			// Upd is the upd argument value.
+			Upd influxdb.NotificationRuleUpdate
+		}
+		// UpdateNotificationRule holds details about calls to the UpdateNotificationRule method.
+		UpdateNotificationRule []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Id is the id argument value.
+			Id influxdb.ID
+			// Nr is the nr argument value.
+			Nr influxdb.NotificationRule
+			// UserID is the userID argument value.
+			UserID influxdb.ID
+		}
+		// UpdateOrganization holds details about calls to the UpdateOrganization method.
+		UpdateOrganization []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Id is the id argument value.
+			Id influxdb.ID
+			// Upd is the upd argument value.
+			Upd influxdb.OrganizationUpdate
+		}
+	}
+}
+
+// CreateNotificationRule calls CreateNotificationRuleFunc.
+func (mock *NotificationRuleStoreMock) CreateNotificationRule(ctx context.Context, nr influxdb.NotificationRule, userID influxdb.ID) error {
+	if mock.CreateNotificationRuleFunc == nil {
+		panic("NotificationRuleStoreMock.CreateNotificationRuleFunc: method is nil but NotificationRuleStore.CreateNotificationRule was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Nr is the nr argument value.
+		Nr influxdb.NotificationRule
+		// UserID is the userID argument value.
+		UserID influxdb.ID
+	}{
+		Ctx:    ctx,
+		Nr:     nr,
+		UserID: userID,
+	}
+	lockNotificationRuleStoreMockCreateNotificationRule.Lock()
+	mock.calls.CreateNotificationRule = append(mock.calls.CreateNotificationRule, callInfo)
+	lockNotificationRuleStoreMockCreateNotificationRule.Unlock()
+	return mock.CreateNotificationRuleFunc(ctx, nr, userID)
+}
+
+// CreateNotificationRuleCalls gets all the calls that were made to CreateNotificationRule.
+// Check the length with:
+//
+//	len(mockedNotificationRuleStore.CreateNotificationRuleCalls())
+func (mock *NotificationRuleStoreMock) CreateNotificationRuleCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// Nr is the nr argument value.
+	Nr influxdb.NotificationRule
+	// UserID is the userID argument value.
+	UserID influxdb.ID
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Nr is the nr argument value.
+		Nr influxdb.NotificationRule
+		// UserID is the userID argument value.
+		UserID influxdb.ID
+	}
+	lockNotificationRuleStoreMockCreateNotificationRule.RLock()
+	calls = mock.calls.CreateNotificationRule
+	lockNotificationRuleStoreMockCreateNotificationRule.RUnlock()
+	return calls
+}
+
+// CreateOrganization calls CreateOrganizationFunc.
+func (mock *NotificationRuleStoreMock) CreateOrganization(ctx context.Context, b *influxdb.Organization) error {
+	if mock.CreateOrganizationFunc == nil {
+		panic("NotificationRuleStoreMock.CreateOrganizationFunc: method is nil but NotificationRuleStore.CreateOrganization was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// B is the b argument value.
+		B *influxdb.Organization
+	}{
+		Ctx: ctx,
+		B:   b,
+	}
+	lockNotificationRuleStoreMockCreateOrganization.Lock()
+	mock.calls.CreateOrganization = append(mock.calls.CreateOrganization, callInfo)
+	lockNotificationRuleStoreMockCreateOrganization.Unlock()
+	return mock.CreateOrganizationFunc(ctx, b)
+}
+
+// CreateOrganizationCalls gets all the calls that were made to CreateOrganization.
+// Check the length with:
+//
+//	len(mockedNotificationRuleStore.CreateOrganizationCalls())
+func (mock *NotificationRuleStoreMock) CreateOrganizationCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// B is the b argument value.
+	B *influxdb.Organization
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// B is the b argument value.
+		B *influxdb.Organization
+	}
+	lockNotificationRuleStoreMockCreateOrganization.RLock()
+	calls = mock.calls.CreateOrganization
+	lockNotificationRuleStoreMockCreateOrganization.RUnlock()
+	return calls
+}
+
+// CreateUserResourceMapping calls CreateUserResourceMappingFunc.
+func (mock *NotificationRuleStoreMock) CreateUserResourceMapping(ctx context.Context, m *influxdb.UserResourceMapping) error {
+	if mock.CreateUserResourceMappingFunc == nil {
+		panic("NotificationRuleStoreMock.CreateUserResourceMappingFunc: method is nil but NotificationRuleStore.CreateUserResourceMapping was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// M is the m argument value.
+		M *influxdb.UserResourceMapping
+	}{
+		Ctx: ctx,
+		M:   m,
+	}
+	lockNotificationRuleStoreMockCreateUserResourceMapping.Lock()
+	mock.calls.CreateUserResourceMapping = append(mock.calls.CreateUserResourceMapping, callInfo)
+	lockNotificationRuleStoreMockCreateUserResourceMapping.Unlock()
+	return mock.CreateUserResourceMappingFunc(ctx, m)
+}
+
+// CreateUserResourceMappingCalls gets all the calls that were made to CreateUserResourceMapping.
+// Check the length with:
+//
+//	len(mockedNotificationRuleStore.CreateUserResourceMappingCalls())
+func (mock *NotificationRuleStoreMock) CreateUserResourceMappingCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// M is the m argument value.
+	M *influxdb.UserResourceMapping
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// M is the m argument value.
+		M *influxdb.UserResourceMapping
+	}
+	lockNotificationRuleStoreMockCreateUserResourceMapping.RLock()
+	calls = mock.calls.CreateUserResourceMapping
+	lockNotificationRuleStoreMockCreateUserResourceMapping.RUnlock()
+	return calls
+}
+
+// DeleteNotificationRule calls DeleteNotificationRuleFunc.
+func (mock *NotificationRuleStoreMock) DeleteNotificationRule(ctx context.Context, id influxdb.ID) error {
+	if mock.DeleteNotificationRuleFunc == nil {
+		panic("NotificationRuleStoreMock.DeleteNotificationRuleFunc: method is nil but NotificationRuleStore.DeleteNotificationRule was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Id is the id argument value.
+		Id influxdb.ID
+	}{
+		Ctx: ctx,
+		Id:  id,
+	}
+	lockNotificationRuleStoreMockDeleteNotificationRule.Lock()
+	mock.calls.DeleteNotificationRule = append(mock.calls.DeleteNotificationRule, callInfo)
+	lockNotificationRuleStoreMockDeleteNotificationRule.Unlock()
+	return mock.DeleteNotificationRuleFunc(ctx, id)
+}
+
+// DeleteNotificationRuleCalls gets all the calls that were made to DeleteNotificationRule.
+// Check the length with:
+//
+//	len(mockedNotificationRuleStore.DeleteNotificationRuleCalls())
+func (mock *NotificationRuleStoreMock) DeleteNotificationRuleCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// Id is the id argument value.
+	Id influxdb.ID
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Id is the id argument value.
+		Id influxdb.ID
+	}
+	lockNotificationRuleStoreMockDeleteNotificationRule.RLock()
+	calls = mock.calls.DeleteNotificationRule
+	lockNotificationRuleStoreMockDeleteNotificationRule.RUnlock()
+	return calls
+}
+
+// DeleteOrganization calls DeleteOrganizationFunc.
+func (mock *NotificationRuleStoreMock) DeleteOrganization(ctx context.Context, id influxdb.ID) error {
+	if mock.DeleteOrganizationFunc == nil {
+		panic("NotificationRuleStoreMock.DeleteOrganizationFunc: method is nil but NotificationRuleStore.DeleteOrganization was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Id is the id argument value.
+		Id influxdb.ID
+	}{
+		Ctx: ctx,
+		Id:  id,
+	}
+	lockNotificationRuleStoreMockDeleteOrganization.Lock()
+	mock.calls.DeleteOrganization = append(mock.calls.DeleteOrganization, callInfo)
+	lockNotificationRuleStoreMockDeleteOrganization.Unlock()
+	return mock.DeleteOrganizationFunc(ctx, id)
+}
+
+// DeleteOrganizationCalls gets all the calls that were made to DeleteOrganization.
+// Check the length with:
+//
+//	len(mockedNotificationRuleStore.DeleteOrganizationCalls())
+func (mock *NotificationRuleStoreMock) DeleteOrganizationCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// Id is the id argument value.
+	Id influxdb.ID
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Id is the id argument value.
+		Id influxdb.ID
+	}
+	lockNotificationRuleStoreMockDeleteOrganization.RLock()
+	calls = mock.calls.DeleteOrganization
+	lockNotificationRuleStoreMockDeleteOrganization.RUnlock()
+	return calls
+}
+
+// DeleteUserResourceMapping calls DeleteUserResourceMappingFunc.
+func (mock *NotificationRuleStoreMock) DeleteUserResourceMapping(ctx context.Context, resourceID influxdb.ID, userID influxdb.ID) error {
+	if mock.DeleteUserResourceMappingFunc == nil {
+		panic("NotificationRuleStoreMock.DeleteUserResourceMappingFunc: method is nil but NotificationRuleStore.DeleteUserResourceMapping was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// ResourceID is the resourceID argument value.
+		ResourceID influxdb.ID
+		// UserID is the userID argument value.
+		UserID influxdb.ID
+	}{
+		Ctx:        ctx,
+		ResourceID: resourceID,
+		UserID:     userID,
+	}
+	lockNotificationRuleStoreMockDeleteUserResourceMapping.Lock()
+	mock.calls.DeleteUserResourceMapping = append(mock.calls.DeleteUserResourceMapping, callInfo)
+	lockNotificationRuleStoreMockDeleteUserResourceMapping.Unlock()
+	return mock.DeleteUserResourceMappingFunc(ctx, resourceID, userID)
+}
+
+// DeleteUserResourceMappingCalls gets all the calls that were made to DeleteUserResourceMapping.
+// Check the length with:
+//
+//	len(mockedNotificationRuleStore.DeleteUserResourceMappingCalls())
+func (mock *NotificationRuleStoreMock) DeleteUserResourceMappingCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// ResourceID is the resourceID argument value.
+	ResourceID influxdb.ID
+	// UserID is the userID argument value.
+	UserID influxdb.ID
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// ResourceID is the resourceID argument value.
+		ResourceID influxdb.ID
+		// UserID is the userID argument value.
+		UserID influxdb.ID
+	}
+	lockNotificationRuleStoreMockDeleteUserResourceMapping.RLock()
+	calls = mock.calls.DeleteUserResourceMapping
+	lockNotificationRuleStoreMockDeleteUserResourceMapping.RUnlock()
+	return calls
+}
+
+// FindNotificationRuleByID calls FindNotificationRuleByIDFunc.
+func (mock *NotificationRuleStoreMock) FindNotificationRuleByID(ctx context.Context, id influxdb.ID) (influxdb.NotificationRule, error) {
+	if mock.FindNotificationRuleByIDFunc == nil {
+		panic("NotificationRuleStoreMock.FindNotificationRuleByIDFunc: method is nil but NotificationRuleStore.FindNotificationRuleByID was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Id is the id argument value.
+		Id influxdb.ID
+	}{
+		Ctx: ctx,
+		Id:  id,
+	}
+	lockNotificationRuleStoreMockFindNotificationRuleByID.Lock()
+	mock.calls.FindNotificationRuleByID = append(mock.calls.FindNotificationRuleByID, callInfo)
+	lockNotificationRuleStoreMockFindNotificationRuleByID.Unlock()
+	return mock.FindNotificationRuleByIDFunc(ctx, id)
+}
+
+// FindNotificationRuleByIDCalls gets all the calls that were made to FindNotificationRuleByID.
+// Check the length with:
+//
+//	len(mockedNotificationRuleStore.FindNotificationRuleByIDCalls())
+func (mock *NotificationRuleStoreMock) FindNotificationRuleByIDCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// Id is the id argument value.
+	Id influxdb.ID
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Id is the id argument value.
+		Id influxdb.ID
+	}
+	lockNotificationRuleStoreMockFindNotificationRuleByID.RLock()
+	calls = mock.calls.FindNotificationRuleByID
+	lockNotificationRuleStoreMockFindNotificationRuleByID.RUnlock()
+	return calls
+}
+
+// FindNotificationRules calls FindNotificationRulesFunc.
+func (mock *NotificationRuleStoreMock) FindNotificationRules(ctx context.Context, filter influxdb.NotificationRuleFilter, opt ...influxdb.FindOptions) ([]influxdb.NotificationRule, int, error) {
+	if mock.FindNotificationRulesFunc == nil {
+		panic("NotificationRuleStoreMock.FindNotificationRulesFunc: method is nil but NotificationRuleStore.FindNotificationRules was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Filter is the filter argument value.
+		Filter influxdb.NotificationRuleFilter
+		// Opt is the opt argument value.
+		Opt []influxdb.FindOptions
+	}{
+		Ctx:    ctx,
+		Filter: filter,
+		Opt:    opt,
+	}
+	lockNotificationRuleStoreMockFindNotificationRules.Lock()
+	mock.calls.FindNotificationRules = append(mock.calls.FindNotificationRules, callInfo)
+	lockNotificationRuleStoreMockFindNotificationRules.Unlock()
+	return mock.FindNotificationRulesFunc(ctx, filter, opt...)
+}
+
+// FindNotificationRulesCalls gets all the calls that were made to FindNotificationRules.
+// Check the length with:
+//
+//	len(mockedNotificationRuleStore.FindNotificationRulesCalls())
+func (mock *NotificationRuleStoreMock) FindNotificationRulesCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// Filter is the filter argument value.
+	Filter influxdb.NotificationRuleFilter
+	// Opt is the opt argument value.
+	Opt []influxdb.FindOptions
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Filter is the filter argument value.
+		Filter influxdb.NotificationRuleFilter
+		// Opt is the opt argument value.
+		Opt []influxdb.FindOptions
+	}
+	lockNotificationRuleStoreMockFindNotificationRules.RLock()
+	calls = mock.calls.FindNotificationRules
+	lockNotificationRuleStoreMockFindNotificationRules.RUnlock()
+	return calls
+}
+
+// FindOrganization calls FindOrganizationFunc.
+func (mock *NotificationRuleStoreMock) FindOrganization(ctx context.Context, filter influxdb.OrganizationFilter) (*influxdb.Organization, error) {
+	if mock.FindOrganizationFunc == nil {
+		panic("NotificationRuleStoreMock.FindOrganizationFunc: method is nil but NotificationRuleStore.FindOrganization was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Filter is the filter argument value.
+		Filter influxdb.OrganizationFilter
+	}{
+		Ctx:    ctx,
+		Filter: filter,
+	}
+	lockNotificationRuleStoreMockFindOrganization.Lock()
+	mock.calls.FindOrganization = append(mock.calls.FindOrganization, callInfo)
+	lockNotificationRuleStoreMockFindOrganization.Unlock()
+	return mock.FindOrganizationFunc(ctx, filter)
+}
+
+// FindOrganizationCalls gets all the calls that were made to FindOrganization.
+// Check the length with:
+//
+//	len(mockedNotificationRuleStore.FindOrganizationCalls())
+func (mock *NotificationRuleStoreMock) FindOrganizationCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// Filter is the filter argument value.
+	Filter influxdb.OrganizationFilter
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Filter is the filter argument value.
+		Filter influxdb.OrganizationFilter
+	}
+	lockNotificationRuleStoreMockFindOrganization.RLock()
+	calls = mock.calls.FindOrganization
+	lockNotificationRuleStoreMockFindOrganization.RUnlock()
+	return calls
+}
+
+// FindOrganizationByID calls FindOrganizationByIDFunc.
+func (mock *NotificationRuleStoreMock) FindOrganizationByID(ctx context.Context, id influxdb.ID) (*influxdb.Organization, error) {
+	if mock.FindOrganizationByIDFunc == nil {
+		panic("NotificationRuleStoreMock.FindOrganizationByIDFunc: method is nil but NotificationRuleStore.FindOrganizationByID was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Id is the id argument value.
+		Id influxdb.ID
+	}{
+		Ctx: ctx,
+		Id:  id,
+	}
+	lockNotificationRuleStoreMockFindOrganizationByID.Lock()
+	mock.calls.FindOrganizationByID = append(mock.calls.FindOrganizationByID, callInfo)
+	lockNotificationRuleStoreMockFindOrganizationByID.Unlock()
+	return mock.FindOrganizationByIDFunc(ctx, id)
+}
+
+// FindOrganizationByIDCalls gets all the calls that were made to FindOrganizationByID.
+// Check the length with:
+//
+//	len(mockedNotificationRuleStore.FindOrganizationByIDCalls())
+func (mock *NotificationRuleStoreMock) FindOrganizationByIDCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// Id is the id argument value.
+	Id influxdb.ID
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Id is the id argument value.
+		Id influxdb.ID
+	}
+	lockNotificationRuleStoreMockFindOrganizationByID.RLock()
+	calls = mock.calls.FindOrganizationByID
+	lockNotificationRuleStoreMockFindOrganizationByID.RUnlock()
+	return calls
+}
+
+// FindOrganizations calls FindOrganizationsFunc.
+func (mock *NotificationRuleStoreMock) FindOrganizations(ctx context.Context, filter influxdb.OrganizationFilter, opt ...influxdb.FindOptions) ([]*influxdb.Organization, int, error) {
+	if mock.FindOrganizationsFunc == nil {
+		panic("NotificationRuleStoreMock.FindOrganizationsFunc: method is nil but NotificationRuleStore.FindOrganizations was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Filter is the filter argument value.
+		Filter influxdb.OrganizationFilter
+		// Opt is the opt argument value.
+		Opt []influxdb.FindOptions
+	}{
+		Ctx:    ctx,
+		Filter: filter,
+		Opt:    opt,
+	}
+	lockNotificationRuleStoreMockFindOrganizations.Lock()
+	mock.calls.FindOrganizations = append(mock.calls.FindOrganizations, callInfo)
+	lockNotificationRuleStoreMockFindOrganizations.Unlock()
+	return mock.FindOrganizationsFunc(ctx, filter, opt...)
+}
+
+// FindOrganizationsCalls gets all the calls that were made to FindOrganizations.
+// Check the length with:
+//
+//	len(mockedNotificationRuleStore.FindOrganizationsCalls())
+func (mock *NotificationRuleStoreMock) FindOrganizationsCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// Filter is the filter argument value.
+	Filter influxdb.OrganizationFilter
+	// Opt is the opt argument value.
+	Opt []influxdb.FindOptions
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Filter is the filter argument value.
+		Filter influxdb.OrganizationFilter
+		// Opt is the opt argument value.
+		Opt []influxdb.FindOptions
+	}
+	lockNotificationRuleStoreMockFindOrganizations.RLock()
+	calls = mock.calls.FindOrganizations
+	lockNotificationRuleStoreMockFindOrganizations.RUnlock()
+	return calls
+}
+
+// FindUserResourceMappings calls FindUserResourceMappingsFunc.
+func (mock *NotificationRuleStoreMock) FindUserResourceMappings(ctx context.Context, filter influxdb.UserResourceMappingFilter, opt ...influxdb.FindOptions) ([]*influxdb.UserResourceMapping, int, error) {
+	if mock.FindUserResourceMappingsFunc == nil {
+		panic("NotificationRuleStoreMock.FindUserResourceMappingsFunc: method is nil but NotificationRuleStore.FindUserResourceMappings was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Filter is the filter argument value.
+		Filter influxdb.UserResourceMappingFilter
+		// Opt is the opt argument value.
+		Opt []influxdb.FindOptions
+	}{
+		Ctx:    ctx,
+		Filter: filter,
+		Opt:    opt,
+	}
+	lockNotificationRuleStoreMockFindUserResourceMappings.Lock()
+	mock.calls.FindUserResourceMappings = append(mock.calls.FindUserResourceMappings, callInfo)
+	lockNotificationRuleStoreMockFindUserResourceMappings.Unlock()
+	return mock.FindUserResourceMappingsFunc(ctx, filter, opt...)
+}
+
+// FindUserResourceMappingsCalls gets all the calls that were made to FindUserResourceMappings.
+// Check the length with:
+//
+//	len(mockedNotificationRuleStore.FindUserResourceMappingsCalls())
+func (mock *NotificationRuleStoreMock) FindUserResourceMappingsCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// Filter is the filter argument value.
+	Filter influxdb.UserResourceMappingFilter
+	// Opt is the opt argument value.
+	Opt []influxdb.FindOptions
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Filter is the filter argument value.
+		Filter influxdb.UserResourceMappingFilter
+		// Opt is the opt argument value.
+		Opt []influxdb.FindOptions
+	}
+	lockNotificationRuleStoreMockFindUserResourceMappings.RLock()
+	calls = mock.calls.FindUserResourceMappings
+	lockNotificationRuleStoreMockFindUserResourceMappings.RUnlock()
+	return calls
+}
+
+// PatchNotificationRule calls PatchNotificationRuleFunc.
+func (mock *NotificationRuleStoreMock) PatchNotificationRule(ctx context.Context, id influxdb.ID, upd influxdb.NotificationRuleUpdate) (influxdb.NotificationRule, error) {
+	if mock.PatchNotificationRuleFunc == nil {
+		panic("NotificationRuleStoreMock.PatchNotificationRuleFunc: method is nil but NotificationRuleStore.PatchNotificationRule was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Id is the id argument value.
+		Id influxdb.ID
+		// Upd is the upd argument value.
+		Upd influxdb.NotificationRuleUpdate
+	}{
+		Ctx: ctx,
+		Id:  id,
+		Upd: upd,
+	}
+	lockNotificationRuleStoreMockPatchNotificationRule.Lock()
+	mock.calls.PatchNotificationRule = append(mock.calls.PatchNotificationRule, callInfo)
+	lockNotificationRuleStoreMockPatchNotificationRule.Unlock()
+	return mock.PatchNotificationRuleFunc(ctx, id, upd)
+}
+
+// PatchNotificationRuleCalls gets all the calls that were made to PatchNotificationRule.
+// Check the length with:
+//
+//	len(mockedNotificationRuleStore.PatchNotificationRuleCalls())
+func (mock *NotificationRuleStoreMock) PatchNotificationRuleCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// Id is the id argument value.
+	Id influxdb.ID
+	// Upd is the upd argument value.
+	Upd influxdb.NotificationRuleUpdate
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Id is the id argument value.
+		Id influxdb.ID
+		// Upd is the upd argument value.
+		Upd influxdb.NotificationRuleUpdate
+	}
+	lockNotificationRuleStoreMockPatchNotificationRule.RLock()
+	calls = mock.calls.PatchNotificationRule
+	lockNotificationRuleStoreMockPatchNotificationRule.RUnlock()
+	return calls
+}
+
+// UpdateNotificationRule calls UpdateNotificationRuleFunc.
+func (mock *NotificationRuleStoreMock) UpdateNotificationRule(ctx context.Context, id influxdb.ID, nr influxdb.NotificationRule, userID influxdb.ID) (influxdb.NotificationRule, error) {
+	if mock.UpdateNotificationRuleFunc == nil {
+		panic("NotificationRuleStoreMock.UpdateNotificationRuleFunc: method is nil but NotificationRuleStore.UpdateNotificationRule was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Id is the id argument value.
+		Id influxdb.ID
+		// Nr is the nr argument value.
+		Nr influxdb.NotificationRule
+		// UserID is the userID argument value.
+		UserID influxdb.ID
+	}{
+		Ctx:    ctx,
+		Id:     id,
+		Nr:     nr,
+		UserID: userID,
+	}
+	lockNotificationRuleStoreMockUpdateNotificationRule.Lock()
+	mock.calls.UpdateNotificationRule = append(mock.calls.UpdateNotificationRule, callInfo)
+	lockNotificationRuleStoreMockUpdateNotificationRule.Unlock()
+	return mock.UpdateNotificationRuleFunc(ctx, id, nr, userID)
+}
+
+// UpdateNotificationRuleCalls gets all the calls that were made to UpdateNotificationRule.
+// Check the length with:
+//
+//	len(mockedNotificationRuleStore.UpdateNotificationRuleCalls())
+func (mock *NotificationRuleStoreMock) UpdateNotificationRuleCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// Id is the id argument value.
+	Id influxdb.ID
+	// Nr is the nr argument value.
+	Nr influxdb.NotificationRule
+	// UserID is the userID argument value.
+	UserID influxdb.ID
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Id is the id argument value.
+		Id influxdb.ID
+		// Nr is the nr argument value.
+		Nr influxdb.NotificationRule
+		// UserID is the userID argument value.
+		UserID influxdb.ID
+	}
+	lockNotificationRuleStoreMockUpdateNotificationRule.RLock()
+	calls = mock.calls.UpdateNotificationRule
+	lockNotificationRuleStoreMockUpdateNotificationRule.RUnlock()
+	return calls
+}
+
+// UpdateOrganization calls UpdateOrganizationFunc.
+func (mock *NotificationRuleStoreMock) UpdateOrganization(ctx context.Context, id influxdb.ID, upd influxdb.OrganizationUpdate) (*influxdb.Organization, error) {
+	if mock.UpdateOrganizationFunc == nil {
+		panic("NotificationRuleStoreMock.UpdateOrganizationFunc: method is nil but NotificationRuleStore.UpdateOrganization was just called")
+	}
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Id is the id argument value.
+		Id influxdb.ID
+		// Upd is the upd argument value.
+		Upd influxdb.OrganizationUpdate
+	}{
+		Ctx: ctx,
+		Id:  id,
+		Upd: upd,
+	}
+	lockNotificationRuleStoreMockUpdateOrganization.Lock()
+	mock.calls.UpdateOrganization = append(mock.calls.UpdateOrganization, callInfo)
+	lockNotificationRuleStoreMockUpdateOrganization.Unlock()
+	return mock.UpdateOrganizationFunc(ctx, id, upd)
+}
+
+// UpdateOrganizationCalls gets all the calls that were made to UpdateOrganization.
+// Check the length with:
+//
+//	len(mockedNotificationRuleStore.UpdateOrganizationCalls())
+func (mock *NotificationRuleStoreMock) UpdateOrganizationCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// Id is the id argument value.
+	Id influxdb.ID
+	// Upd is the upd argument value.
+	Upd influxdb.OrganizationUpdate
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Id is the id argument value.
+		Id influxdb.ID
+		// Upd is the upd argument value.
+		Upd influxdb.OrganizationUpdate
+	}
+	lockNotificationRuleStoreMockUpdateOrganization.RLock()
+	calls = mock.calls.UpdateOrganization
+	lockNotificationRuleStoreMockUpdateOrganization.RUnlock()
+	return calls
+}
@@ -0,0 +1,19 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.NotificationRuleTestService = &NotificationRuleTestService{}
+
+// NotificationRuleTestService represents a service testing notification rules.
+type NotificationRuleTestService struct {
+	TestNotificationRuleF func(ctx context.Context, ruleID influxdb.ID, t influxdb.NotificationRuleTest) (*influxdb.NotificationRuleTestResult, error)
+}
+
+// TestNotificationRule runs synthetic statuses through the matchers of a rule.
+func (s *NotificationRuleTestService) TestNotificationRule(ctx context.Context, ruleID influxdb.ID, t influxdb.NotificationRuleTest) (*influxdb.NotificationRuleTestResult, error) {
+	return s.TestNotificationRuleF(ctx, ruleID, t)
+}
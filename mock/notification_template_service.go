@@ -0,0 +1,45 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.NotificationTemplateService = &NotificationTemplateService{}
+
+// NotificationTemplateService represents a service for managing notification template data.
+type NotificationTemplateService struct {
+	FindNotificationTemplateByIDF func(ctx context.Context, id influxdb.ID) (*influxdb.NotificationTemplate, error)
+	FindNotificationTemplatesF    func(ctx context.Context, filter influxdb.NotificationTemplateFilter, opt ...influxdb.FindOptions) ([]*influxdb.NotificationTemplate, int, error)
+	CreateNotificationTemplateF   func(ctx context.Context, t *influxdb.NotificationTemplate) error
+	UpdateNotificationTemplateF   func(ctx context.Context, id influxdb.ID, upd influxdb.NotificationTemplateUpdate) (*influxdb.NotificationTemplate, error)
+	DeleteNotificationTemplateF   func(ctx context.Context, id influxdb.ID) error
+}
+
+// FindNotificationTemplateByID returns a single notification template by ID.
+func (s *NotificationTemplateService) FindNotificationTemplateByID(ctx context.Context, id influxdb.ID) (*influxdb.NotificationTemplate, error) {
+	return s.FindNotificationTemplateByIDF(ctx, id)
+}
+
+// FindNotificationTemplates returns a list of notification templates that match filter and the total count of matching notification templates.
+// Additional options provide pagination & sorting.
+func (s *NotificationTemplateService) FindNotificationTemplates(ctx context.Context, filter influxdb.NotificationTemplateFilter, opt ...influxdb.FindOptions) ([]*influxdb.NotificationTemplate, int, error) {
+	return s.FindNotificationTemplatesF(ctx, filter, opt...)
+}
+
+// CreateNotificationTemplate creates a new notification template and sets ID with the new identifier.
+func (s *NotificationTemplateService) CreateNotificationTemplate(ctx context.Context, t *influxdb.NotificationTemplate) error {
+	return s.CreateNotificationTemplateF(ctx, t)
+}
+
+// UpdateNotificationTemplate updates a single notification template with changeset.
+// Returns the new notification template after update.
+func (s *NotificationTemplateService) UpdateNotificationTemplate(ctx context.Context, id influxdb.ID, upd influxdb.NotificationTemplateUpdate) (*influxdb.NotificationTemplate, error) {
+	return s.UpdateNotificationTemplateF(ctx, id, upd)
+}
+
+// DeleteNotificationTemplate removes a notification template by ID.
+func (s *NotificationTemplateService) DeleteNotificationTemplate(ctx context.Context, id influxdb.ID) error {
+	return s.DeleteNotificationTemplateF(ctx, id)
+}
@@ -0,0 +1,38 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.SilenceService = &SilenceService{}
+
+// SilenceService represents a service for managing silence data.
+type SilenceService struct {
+	FindSilenceByIDF func(ctx context.Context, id influxdb.ID) (*influxdb.Silence, error)
+	FindSilencesF    func(ctx context.Context, filter influxdb.SilenceFilter, opt ...influxdb.FindOptions) ([]*influxdb.Silence, int, error)
+	CreateSilenceF   func(ctx context.Context, s *influxdb.Silence) error
+	DeleteSilenceF   func(ctx context.Context, id influxdb.ID) error
+}
+
+// FindSilenceByID returns a single silence by ID.
+func (s *SilenceService) FindSilenceByID(ctx context.Context, id influxdb.ID) (*influxdb.Silence, error) {
+	return s.FindSilenceByIDF(ctx, id)
+}
+
+// FindSilences returns a list of silences that match filter and the total count of matching silences.
+// Additional options provide pagination & sorting.
+func (s *SilenceService) FindSilences(ctx context.Context, filter influxdb.SilenceFilter, opt ...influxdb.FindOptions) ([]*influxdb.Silence, int, error) {
+	return s.FindSilencesF(ctx, filter, opt...)
+}
+
+// CreateSilence creates a new silence and sets ID with the new identifier.
+func (s *SilenceService) CreateSilence(ctx context.Context, sl *influxdb.Silence) error {
+	return s.CreateSilenceF(ctx, sl)
+}
+
+// DeleteSilence removes a silence by ID.
+func (s *SilenceService) DeleteSilence(ctx context.Context, id influxdb.ID) error {
+	return s.DeleteSilenceF(ctx, id)
+}
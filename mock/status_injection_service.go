@@ -0,0 +1,19 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.StatusInjectionService = &StatusInjectionService{}
+
+// StatusInjectionService represents a service injecting synthetic statuses.
+type StatusInjectionService struct {
+	InjectStatusF func(ctx context.Context, i *influxdb.StatusInjection) (*influxdb.StatusTrace, error)
+}
+
+// InjectStatus writes and dispatches a synthetic status.
+func (s *StatusInjectionService) InjectStatus(ctx context.Context, i *influxdb.StatusInjection) (*influxdb.StatusTrace, error) {
+	return s.InjectStatusF(ctx, i)
+}
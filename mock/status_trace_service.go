@@ -0,0 +1,25 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.StatusTraceService = &StatusTraceService{}
+
+// StatusTraceService represents a service for the decision traces of statuses.
+type StatusTraceService struct {
+	FindStatusTraceF   func(ctx context.Context, statusID influxdb.ID) (*influxdb.StatusTrace, error)
+	CreateStatusTraceF func(ctx context.Context, t *influxdb.StatusTrace) error
+}
+
+// FindStatusTrace returns the decision trace of a status.
+func (s *StatusTraceService) FindStatusTrace(ctx context.Context, statusID influxdb.ID) (*influxdb.StatusTrace, error) {
+	return s.FindStatusTraceF(ctx, statusID)
+}
+
+// CreateStatusTrace records the decision trace of a status.
+func (s *StatusTraceService) CreateStatusTrace(ctx context.Context, t *influxdb.StatusTrace) error {
+	return s.CreateStatusTraceF(ctx, t)
+}
@@ -0,0 +1,261 @@
+package influxdb
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MonitoringTemplate is a package bundling the checks, notification endpoints and
+// notification rules monitoring a system, such as a database or a message broker,
+// installed at once into an organization.
+type MonitoringTemplate struct {
+	Name        string
+	Version     string
+	Description string
+	// Requires are the templates which must be installed in the organization first.
+	Requires []MonitoringTemplateRequirement
+	// Labels are set on every check, endpoint and rule of the template, the
+	// labels of the organization with the same name are reused.
+	Labels []MonitoringTemplateLabel
+	Checks []Check
+	// Endpoints are the notification endpoints of the template, their secret
+	// fields are placeholders whose values are provided by the install.
+	Endpoints []NotificationEndpoint
+	Rules     []MonitoringTemplateRule
+	// Dashboards are the names of the dashboards of the organization the template refers to.
+	Dashboards []string
+}
+
+// MonitoringTemplateRequirement is a template required by another one, at a minimum version.
+type MonitoringTemplateRequirement struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+// MonitoringTemplateLabel is a label of a template.
+type MonitoringTemplateLabel struct {
+	Name       string            `json:"name"`
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+// MonitoringTemplateRule is a notification rule of a template, sending its
+// notifications to the endpoint named EndpointName, an endpoint of the template
+// or of the organization.
+type MonitoringTemplateRule struct {
+	Rule         NotificationRule
+	EndpointName string
+}
+
+// ParseMonitoringTemplateVersion parses a version of dot separated numbers, such as 1.2.0.
+func ParseMonitoringTemplateVersion(v string) ([]int, error) {
+	if v == "" {
+		return nil, &Error{
+			Code: EInvalid,
+			Msg:  "monitoring template version is required",
+		}
+	}
+	parts := strings.Split(v, ".")
+	nums := make([]int, len(parts))
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return nil, &Error{
+				Code: EInvalid,
+				Msg:  fmt.Sprintf("monitoring template version %q must be dot separated numbers, such as 1.2.0", v),
+			}
+		}
+		nums[i] = n
+	}
+	return nums, nil
+}
+
+// CompareMonitoringTemplateVersions returns -1, 0 or 1 if the version a is lower
+// than, equal to or greater than the version b, the missing numbers being 0.
+// The versions must be valid.
+func CompareMonitoringTemplateVersions(a, b string) int {
+	va, _ := ParseMonitoringTemplateVersion(a)
+	vb, _ := ParseMonitoringTemplateVersion(b)
+	for i := 0; i < len(va) || i < len(vb); i++ {
+		var na, nb int
+		if i < len(va) {
+			na = va[i]
+		}
+		if i < len(vb) {
+			nb = vb[i]
+		}
+		switch {
+		case na < nb:
+			return -1
+		case na > nb:
+			return 1
+		}
+	}
+	return 0
+}
+
+// Valid returns an error if the template is invalid.
+func (t *MonitoringTemplate) Valid() error {
+	if t.Name == "" {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "monitoring template name is required",
+		}
+	}
+	if _, err := ParseMonitoringTemplateVersion(t.Version); err != nil {
+		return err
+	}
+	for _, r := range t.Requires {
+		if r.Name == "" || r.Name == t.Name {
+			return &Error{
+				Code: EInvalid,
+				Msg:  fmt.Sprintf("monitoring template %q can't require a template without a name or itself", t.Name),
+			}
+		}
+		if r.Version == "" {
+			continue
+		}
+		if _, err := ParseMonitoringTemplateVersion(r.Version); err != nil {
+			return err
+		}
+	}
+
+	labels := map[string]bool{}
+	for _, l := range t.Labels {
+		if l.Name == "" || labels[l.Name] {
+			return &Error{
+				Code: EInvalid,
+				Msg:  fmt.Sprintf("monitoring template labels must have unique names, got %q", l.Name),
+			}
+		}
+		labels[l.Name] = true
+	}
+	checks := map[string]bool{}
+	for _, c := range t.Checks {
+		if c == nil || checks[c.GetName()] {
+			return &Error{
+				Code: EInvalid,
+				Msg:  "monitoring template checks must have unique names",
+			}
+		}
+		checks[c.GetName()] = true
+	}
+	endpoints := map[string]bool{}
+	for _, edp := range t.Endpoints {
+		if edp == nil || endpoints[edp.GetName()] {
+			return &Error{
+				Code: EInvalid,
+				Msg:  "monitoring template endpoints must have unique names",
+			}
+		}
+		endpoints[edp.GetName()] = true
+		// the secret values without a key are only listed once given one.
+		edp.BackfillSecretKeys()
+		for _, fld := range edp.SecretFields() {
+			if fld.Value != nil {
+				return &Error{
+					Code: EInvalid,
+					Msg:  fmt.Sprintf("monitoring template endpoint %q must refer to its secrets with placeholders", edp.GetName()),
+				}
+			}
+		}
+	}
+	rules := map[string]bool{}
+	for _, r := range t.Rules {
+		if r.Rule == nil || rules[r.Rule.GetName()] {
+			return &Error{
+				Code: EInvalid,
+				Msg:  "monitoring template rules must have unique names",
+			}
+		}
+		rules[r.Rule.GetName()] = true
+	}
+	return nil
+}
+
+// SecretKeys returns the keys of the secret placeholders of the endpoints of the template,
+// the references to the secrets of external stores aren't placeholders.
+func (t *MonitoringTemplate) SecretKeys() []string {
+	var keys []string
+	seen := map[string]bool{}
+	for _, edp := range t.Endpoints {
+		for _, fld := range edp.SecretFields() {
+			if _, ok := fld.Reference(); ok || fld.Key == "" || seen[fld.Key] {
+				continue
+			}
+			seen[fld.Key] = true
+			keys = append(keys, fld.Key)
+		}
+	}
+	return keys
+}
+
+// MonitoringTemplateInstall is the install of a template into an organization.
+type MonitoringTemplateInstall struct {
+	Template *MonitoringTemplate
+	// Secrets are the values of the secret placeholders of the endpoints, by key.
+	// The placeholders without a value must be secrets of the organization already.
+	Secrets map[string]string
+	// AuthorizationID is the authorization of the rules that don't have one.
+	AuthorizationID ID
+	// DeterministicIDs derives the IDs of the created resources and of the manifest
+	// from the names of the organization and of the resources, so that installing
+	// the same template in a fresh instance creates the same IDs.
+	DeterministicIDs bool
+}
+
+// MonitoringTemplateResource is a resource of an installed template, the
+// previewed resources to create have no ID yet, unless the install derives
+// deterministic IDs.
+type MonitoringTemplateResource struct {
+	Type ResourceType `json:"type"`
+	ID   ID           `json:"id,omitempty"`
+	Name string       `json:"name"`
+	// Created is whether the install created the resource, the resources of the
+	// organization the template refers to, such as its dashboards, are left by the uninstall.
+	Created bool `json:"created"`
+}
+
+// MonitoringTemplateManifest records the install of a template into an organization,
+// with the resources it created.
+type MonitoringTemplateManifest struct {
+	ID          ID                              `json:"id"`
+	OrgID       ID                              `json:"orgID"`
+	Name        string                          `json:"name"`
+	Version     string                          `json:"version"`
+	Description string                          `json:"description,omitempty"`
+	Requires    []MonitoringTemplateRequirement `json:"requires,omitempty"`
+	Resources   []MonitoringTemplateResource    `json:"resources"`
+	// SecretKeys are the keys of the secrets stored by the install.
+	SecretKeys []string `json:"secretKeys,omitempty"`
+	// DeterministicIDs is set if the install derived the IDs from the names,
+	// the upgrades derive the IDs of the resources they create too.
+	DeterministicIDs bool `json:"deterministicIDs,omitempty"`
+	CRUDLog
+}
+
+// MonitoringTemplateService installs monitoring templates into the organizations.
+type MonitoringTemplateService interface {
+	// FindMonitoringTemplateManifestByID returns the manifest of an installed template.
+	FindMonitoringTemplateManifestByID(ctx context.Context, id ID) (*MonitoringTemplateManifest, error)
+
+	// FindMonitoringTemplateManifests returns the manifests of the templates installed in an organization.
+	FindMonitoringTemplateManifests(ctx context.Context, orgID ID) ([]*MonitoringTemplateManifest, error)
+
+	// InstallMonitoringTemplate creates the resources of a template in an organization,
+	// owned by userID, once its required templates are installed.
+	InstallMonitoringTemplate(ctx context.Context, orgID ID, install MonitoringTemplateInstall, userID ID) (*MonitoringTemplateManifest, error)
+
+	// PreviewMonitoringTemplate returns the resources the install of a template
+	// in an organization would create or reuse, without installing it.
+	PreviewMonitoringTemplate(ctx context.Context, orgID ID, install MonitoringTemplateInstall) ([]MonitoringTemplateResource, error)
+
+	// UpgradeMonitoringTemplate upgrades an installed template to a greater version,
+	// updating the resources of the same name, creating the new ones and deleting the others.
+	UpgradeMonitoringTemplate(ctx context.Context, id ID, install MonitoringTemplateInstall, userID ID) (*MonitoringTemplateManifest, error)
+
+	// UninstallMonitoringTemplate deletes the resources created by the install of
+	// a template, unless another installed template requires it.
+	UninstallMonitoringTemplate(ctx context.Context, id ID) error
+}
@@ -0,0 +1,486 @@
+// Package check contains the types of checks, which periodically query a
+// bucket and write the statuses of the data for notification rules to match.
+package check
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification"
+)
+
+var typToCheck = map[string](func() influxdb.Check){
+	"deadman":   func() influxdb.Check { return &Deadman{} },
+	"threshold": func() influxdb.Check { return &Threshold{} },
+	"slo":       func() influxdb.Check { return &SLO{} },
+	"external":  func() influxdb.Check { return &External{} },
+	"heartbeat": func() influxdb.Check { return &Heartbeat{} },
+	"lag":       func() influxdb.Check { return &Lag{} },
+}
+
+// consts of the query types of checks, the data sources of their queries.
+const (
+	// QueryTypeFlux is a flux query, the default query type of a check.
+	QueryTypeFlux = "flux"
+	// QueryTypeInfluxQL is an InfluxQL query of the buckets mapped to the
+	// database and retention policy it reads.
+	QueryTypeInfluxQL = "influxql"
+	// QueryTypePrometheus is a metric selector, such as
+	// http_requests_total{code="500"}, evaluated on the metrics scraped
+	// from the ScrapeURL of the check, a Prometheus-compatible endpoint.
+	QueryTypePrometheus = "prometheus"
+)
+
+// consts of the formats of the descriptions of checks, hints for the UI.
+const (
+	DescriptionFormatPlain    = "plain"
+	DescriptionFormatMarkdown = "markdown"
+)
+
+type rawCheckJSON struct {
+	Typ string `json:"type"`
+}
+
+// UnmarshalJSON will convert the json of any type of check to the concrete
+// check of its type field. The json which isn't a check of a known type is
+// invalid.
+func UnmarshalJSON(b []byte) (influxdb.Check, error) {
+	var raw rawCheckJSON
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "unable to detect the check type from json",
+		}
+	}
+	if raw.Typ == "" {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "check type is missing",
+		}
+	}
+	convertedFunc, ok := typToCheck[raw.Typ]
+	if !ok {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("invalid check type %s", raw.Typ),
+		}
+	}
+	converted := convertedFunc()
+	if err := json.Unmarshal(b, converted); err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("invalid %s check", raw.Typ),
+			Err:  err,
+		}
+	}
+	return converted, nil
+}
+
+// Base is the embed struct of every check.
+type Base struct {
+	ID          influxdb.ID `json:"id,omitempty"`
+	Name        string      `json:"name"`
+	Description string      `json:"description,omitempty"`
+	// DescriptionFormat is how the description is rendered, as plain text
+	// if unset.
+	DescriptionFormat string `json:"descriptionFormat,omitempty"`
+	// RunbookURL links the statuses of the check to its runbook, message
+	// templates reference it as ${r._runbook}.
+	RunbookURL string                  `json:"runbookURL,omitempty"`
+	OrgID      influxdb.ID             `json:"orgID,omitempty"`
+	Query      influxdb.DashboardQuery `json:"query"`
+	Stages     []QueryStage            `json:"stages,omitempty"`
+	QueryType  string                  `json:"queryType,omitempty"`
+	ScrapeURL  string                  `json:"scrapeURL,omitempty"`
+	Status     influxdb.Status         `json:"status"`
+	Cron       string                  `json:"cron,omitempty"`
+	Every      influxdb.Duration       `json:"every,omitempty"`
+	// Offset represents a delay before execution.
+	// It gets marshalled from a string duration, i.e.: "10s" is 10 seconds
+	Offset influxdb.Duration `json:"offset,omitempty"`
+	// AlignToInterval aligns the windows evaluated by the check to the
+	// wall-clock boundaries of its every interval, since the Unix epoch,
+	// rather than to the time the check was created.
+	AlignToInterval bool `json:"alignToInterval,omitempty"`
+	// Occurrences is how many consecutive evaluations a series must have a
+	// level for the check to change the series to it, one if unset.
+	Occurrences int `json:"occurrences,omitempty"`
+	// Priority orders the evaluation of the check and the delivery of its
+	// notifications under load, normal if unset.
+	Priority influxdb.CheckPriority `json:"priority,omitempty"`
+	// Tags are written to each status of the check.
+	Tags                  []notification.Tag `json:"tags"`
+	StatusMessageTemplate string             `json:"statusMessageTemplate"`
+	// TaskID is the task running the generated flux of the check, and
+	// AuthorizationID the authorization scoped to the check the task runs with.
+	TaskID          influxdb.ID `json:"taskID,omitempty"`
+	AuthorizationID influxdb.ID `json:"authorizationID,omitempty"`
+	// Archived checks are hidden from the checks found by default and their
+	// task doesn't run, whatever their status.
+	Archived bool `json:"archived,omitempty"`
+	// PauseReason is why the check was set inactive automatically, such as
+	// the deletion of a bucket its query reads. It is cleared when the
+	// check is reactivated.
+	PauseReason string `json:"pauseReason,omitempty"`
+	influxdb.CRUDLog
+}
+
+func (b Base) valid() error {
+	if err := b.validIdentity(); err != nil {
+		return err
+	}
+	if len(b.Stages) > 0 {
+		if err := b.validStages(); err != nil {
+			return err
+		}
+	} else if b.Query.Text == "" {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "Check Query can't be empty",
+		}
+	}
+	if err := b.validQueryType(); err != nil {
+		return err
+	}
+	if err := b.validStatus(); err != nil {
+		return err
+	}
+	if err := b.validDescription(); err != nil {
+		return err
+	}
+	if (b.Cron == "") == (b.Every.Duration == 0) {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "Check requires either cron or every",
+		}
+	}
+	if b.Every.Duration < 0 || b.Offset.Duration < 0 {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "Check every and offset can't be negative",
+		}
+	}
+	if b.Occurrences < 0 {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "Check occurrences can't be negative",
+		}
+	}
+	if err := b.Priority.Valid(); err != nil {
+		return err
+	}
+	if b.AlignToInterval && b.Every.Duration == 0 {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "Check can only align to interval with every",
+		}
+	}
+	return b.validTags()
+}
+
+func (b Base) validIdentity() error {
+	if !b.ID.Valid() {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "Check ID is invalid",
+		}
+	}
+	if b.Name == "" {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "Check Name can't be empty",
+		}
+	}
+	if !b.OrgID.Valid() {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "Check OrgID is invalid",
+		}
+	}
+	return nil
+}
+
+func (b Base) validQueryType() error {
+	switch b.QueryType {
+	case "", QueryTypeFlux, QueryTypeInfluxQL:
+	case QueryTypePrometheus:
+		u, err := url.Parse(b.ScrapeURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "prometheus query requires the http or https scrapeURL of a metrics endpoint",
+			}
+		}
+		return nil
+	default:
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("invalid query type %s, valid query types are %s, %s and %s", b.QueryType, QueryTypeFlux, QueryTypeInfluxQL, QueryTypePrometheus),
+		}
+	}
+	if b.ScrapeURL != "" {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "Check scrapeURL requires a prometheus query",
+		}
+	}
+	return nil
+}
+
+func (b Base) validStatus() error {
+	if b.Status != influxdb.Active && b.Status != influxdb.Inactive {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "invalid status",
+		}
+	}
+	return nil
+}
+
+func (b Base) validDescription() error {
+	switch b.DescriptionFormat {
+	case "", DescriptionFormatPlain, DescriptionFormatMarkdown:
+	default:
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("invalid description format %s, valid description formats are %s and %s", b.DescriptionFormat, DescriptionFormatPlain, DescriptionFormatMarkdown),
+		}
+	}
+	if b.RunbookURL == "" {
+		return nil
+	}
+	if u, err := url.Parse(b.RunbookURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "Check runbookURL must be an http or https URL",
+		}
+	}
+	return nil
+}
+
+func (b Base) validTags() error {
+	for _, tag := range b.Tags {
+		if tag.Key == "" {
+			return &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "Check Tag key can't be empty",
+			}
+		}
+	}
+	return nil
+}
+
+// GetID implements influxdb.Getter interface.
+func (b Base) GetID() influxdb.ID {
+	return b.ID
+}
+
+// GetOrgID implements influxdb.Getter interface.
+func (b Base) GetOrgID() influxdb.ID {
+	return b.OrgID
+}
+
+// GetCRUDLog implements influxdb.Getter interface.
+func (b Base) GetCRUDLog() influxdb.CRUDLog {
+	return b.CRUDLog
+}
+
+// GetName implements influxdb.Getter interface.
+func (b *Base) GetName() string {
+	return b.Name
+}
+
+// GetDescription implements influxdb.Getter interface.
+func (b *Base) GetDescription() string {
+	return b.Description
+}
+
+// GetDescriptionFormat returns how the description of the check is
+// rendered, plain text if unset.
+func (b *Base) GetDescriptionFormat() string {
+	if b.DescriptionFormat == "" {
+		return DescriptionFormatPlain
+	}
+	return b.DescriptionFormat
+}
+
+// GetRunbookURL returns the runbook of the check, empty if it has none.
+func (b *Base) GetRunbookURL() string {
+	return b.RunbookURL
+}
+
+// GetStatus implements influxdb.Getter interface.
+func (b *Base) GetStatus() influxdb.Status {
+	return b.Status
+}
+
+// GetPriority returns the priority of the check, normal if unset.
+func (b *Base) GetPriority() influxdb.CheckPriority {
+	if b.Priority == "" {
+		return influxdb.CheckPriorityNormal
+	}
+	return b.Priority
+}
+
+// GetTags returns the tags written to each status of the check.
+func (b *Base) GetTags() []notification.Tag {
+	return b.Tags
+}
+
+// GetQuery returns the query of the data of the check, composed from its
+// stages if it has any.
+func (b *Base) GetQuery() influxdb.DashboardQuery {
+	if len(b.Stages) == 0 {
+		return b.Query
+	}
+	q := b.Query
+	q.Text = composeStages(b.Stages)
+	return q
+}
+
+// GetQueryType returns the data source of the query of the check.
+func (b *Base) GetQueryType() string {
+	if b.QueryType == "" {
+		return QueryTypeFlux
+	}
+	return b.QueryType
+}
+
+// GetScrapeURL returns the metrics endpoint scraped by the prometheus query of the check.
+func (b *Base) GetScrapeURL() string {
+	return b.ScrapeURL
+}
+
+// GetEvery returns the interval of the check, zero if it runs on a cron.
+func (b *Base) GetEvery() time.Duration {
+	return b.Every.Duration
+}
+
+// GetCron returns the cron expression of the check, empty if it runs every interval.
+func (b *Base) GetCron() string {
+	return b.Cron
+}
+
+// GetOffset returns the delay of the check after its schedule.
+func (b *Base) GetOffset() time.Duration {
+	return b.Offset.Duration
+}
+
+// GetAlignToInterval returns whether the windows of the check are aligned
+// to the boundaries of its every interval.
+func (b *Base) GetAlignToInterval() bool {
+	return b.AlignToInterval
+}
+
+// GetOccurrences returns how many consecutive evaluations at a level change
+// the level of a series.
+func (b *Base) GetOccurrences() int {
+	if b.Occurrences < 1 {
+		return 1
+	}
+	return b.Occurrences
+}
+
+// GetStatusMessageTemplate returns the template of the messages of the statuses.
+func (b *Base) GetStatusMessageTemplate() string {
+	return b.StatusMessageTemplate
+}
+
+// GetTaskID returns the task running the check.
+func (b *Base) GetTaskID() influxdb.ID {
+	return b.TaskID
+}
+
+// GetAuthorizationID returns the authorization of the task running the check.
+func (b *Base) GetAuthorizationID() influxdb.ID {
+	return b.AuthorizationID
+}
+
+// IsArchived returns whether the check is archived.
+func (b *Base) IsArchived() bool {
+	return b.Archived
+}
+
+// GetPauseReason returns why the check was set inactive automatically,
+// empty if it wasn't.
+func (b *Base) GetPauseReason() string {
+	return b.PauseReason
+}
+
+// SetID will set the primary key.
+func (b *Base) SetID(id influxdb.ID) {
+	b.ID = id
+}
+
+// SetOrgID will set the org key.
+func (b *Base) SetOrgID(id influxdb.ID) {
+	b.OrgID = id
+}
+
+// SetName implements influxdb.Updator interface.
+func (b *Base) SetName(name string) {
+	b.Name = name
+}
+
+// SetDescription implements influxdb.Updator interface.
+func (b *Base) SetDescription(description string) {
+	b.Description = description
+}
+
+// SetStatus implements influxdb.Updator interface.
+func (b *Base) SetStatus(status influxdb.Status) {
+	b.Status = status
+}
+
+// SetEvery runs the check every interval instead of on its cron.
+func (b *Base) SetEvery(every time.Duration) {
+	b.Every = influxdb.Duration{Duration: every}
+	b.Cron = ""
+}
+
+// SetOffset sets the delay of the check after its schedule.
+func (b *Base) SetOffset(offset time.Duration) {
+	b.Offset = influxdb.Duration{Duration: offset}
+}
+
+// SetAlignToInterval aligns the windows of the check to the boundaries of its every interval.
+func (b *Base) SetAlignToInterval(align bool) {
+	b.AlignToInterval = align
+}
+
+// SetTaskID sets the task running the check.
+func (b *Base) SetTaskID(id influxdb.ID) {
+	b.TaskID = id
+}
+
+// SetAuthorizationID sets the authorization of the task running the check.
+func (b *Base) SetAuthorizationID(id influxdb.ID) {
+	b.AuthorizationID = id
+}
+
+// SetArchived archives or unarchives the check.
+func (b *Base) SetArchived(archived bool) {
+	b.Archived = archived
+}
+
+// SetPauseReason sets why the check was set inactive automatically.
+func (b *Base) SetPauseReason(reason string) {
+	b.PauseReason = reason
+}
+
+// AlignTime returns the last boundary of the every interval at or before t,
+// counting the intervals from the Unix epoch, to the nanosecond.
+func AlignTime(t time.Time, every time.Duration) time.Time {
+	if every <= 0 {
+		return t
+	}
+	ns := t.UnixNano()
+	rem := ns % int64(every)
+	if rem < 0 {
+		rem += int64(every)
+	}
+	return time.Unix(0, ns-rem).In(t.Location())
+}
@@ -0,0 +1,843 @@
+package check_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/check"
+	influxTesting "github.com/influxdata/influxdb/testing"
+)
+
+const (
+	id1 = "020f755c3c082000"
+	id2 = "020f755c3c082001"
+)
+
+var (
+	timeGen1 = mock.TimeGenerator{FakeValue: time.Date(2006, time.July, 13, 4, 19, 10, 0, time.UTC)}
+	timeGen2 = mock.TimeGenerator{FakeValue: time.Date(2006, time.July, 14, 5, 23, 53, 10, time.UTC)}
+)
+
+var goodBase = check.Base{
+	ID:     influxTesting.MustIDBase16(id1),
+	Name:   "name1",
+	OrgID:  influxTesting.MustIDBase16(id2),
+	Status: influxdb.Active,
+	Every:  influxdb.Duration{Duration: time.Minute},
+	Query: influxdb.DashboardQuery{
+		Text: `from(bucket: "telegraf") |> range(start: -1m) |> filter(fn: (r) => r._measurement == "cpu")`,
+	},
+}
+
+func TestValidCheck(t *testing.T) {
+	cases := []struct {
+		name string
+		src  influxdb.Check
+		err  error
+	}{
+		{
+			name: "invalid check id",
+			src:  &check.Deadman{},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "Check ID is invalid",
+			},
+		},
+		{
+			name: "empty name",
+			src: &check.Threshold{
+				Base: check.Base{
+					ID: influxTesting.MustIDBase16(id1),
+				},
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "Check Name can't be empty",
+			},
+		},
+		{
+			name: "empty query",
+			src: &check.Threshold{
+				Base: check.Base{
+					ID:     influxTesting.MustIDBase16(id1),
+					Name:   "name1",
+					OrgID:  influxTesting.MustIDBase16(id2),
+					Status: influxdb.Active,
+					Every:  influxdb.Duration{Duration: time.Minute},
+				},
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "Check Query can't be empty",
+			},
+		},
+		{
+			name: "both cron and every",
+			src: &check.Deadman{
+				Base: func() check.Base {
+					b := goodBase
+					b.Cron = "0 * * * *"
+					return b
+				}(),
+				TimeSince: 90,
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "Check requires either cron or every",
+			},
+		},
+		{
+			name: "negative occurrences",
+			src: &check.Deadman{
+				Base: func() check.Base {
+					b := goodBase
+					b.Occurrences = -1
+					return b
+				}(),
+				TimeSince: 90,
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "Check occurrences can't be negative",
+			},
+		},
+		{
+			name: "unknown priority",
+			src: &check.Deadman{
+				Base: func() check.Base {
+					b := goodBase
+					b.Priority = "urgent"
+					return b
+				}(),
+				TimeSince: 90,
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "invalid check priority urgent, valid priorities are critical, normal and low",
+			},
+		},
+		{
+			name: "unknown description format",
+			src: &check.Deadman{
+				Base: func() check.Base {
+					b := goodBase
+					b.DescriptionFormat = "html"
+					return b
+				}(),
+				TimeSince: 90,
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "invalid description format html, valid description formats are plain and markdown",
+			},
+		},
+		{
+			name: "runbook url without a scheme",
+			src: &check.External{
+				Base: check.Base{
+					ID:         influxTesting.MustIDBase16(id1),
+					Name:       "name1",
+					OrgID:      influxTesting.MustIDBase16(id2),
+					Status:     influxdb.Active,
+					RunbookURL: "runbooks.example.com/cpu",
+				},
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "Check runbookURL must be an http or https URL",
+			},
+		},
+		{
+			name: "aligned cron",
+			src: &check.Deadman{
+				Base: func() check.Base {
+					b := goodBase
+					b.Every = influxdb.Duration{}
+					b.Cron = "0 * * * *"
+					b.AlignToInterval = true
+					return b
+				}(),
+				TimeSince: 90,
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "Check can only align to interval with every",
+			},
+		},
+		{
+			name: "unknown query type",
+			src: &check.Deadman{
+				Base: func() check.Base {
+					b := goodBase
+					b.QueryType = "sql"
+					return b
+				}(),
+				TimeSince: 90,
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "invalid query type sql, valid query types are flux, influxql and prometheus",
+			},
+		},
+		{
+			name: "prometheus query without scrape url",
+			src: &check.Threshold{
+				Base: func() check.Base {
+					b := goodBase
+					b.QueryType = check.QueryTypePrometheus
+					b.Query = influxdb.DashboardQuery{Text: `http_requests_total{code="500"}`}
+					b.ScrapeURL = "localhost:9100/metrics"
+					return b
+				}(),
+				Thresholds: []check.ThresholdConfig{
+					&check.Greater{Value: 90},
+				},
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "prometheus query requires the http or https scrapeURL of a metrics endpoint",
+			},
+		},
+		{
+			name: "scrape url of a flux query",
+			src: &check.Deadman{
+				Base: func() check.Base {
+					b := goodBase
+					b.ScrapeURL = "http://localhost:9100/metrics"
+					return b
+				}(),
+				TimeSince: 90,
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "Check scrapeURL requires a prometheus query",
+			},
+		},
+		{
+			name: "slo check with an influxql query",
+			src: &check.SLO{
+				Base: func() check.Base {
+					b := goodBase
+					b.QueryType = check.QueryTypeInfluxQL
+					b.Query = influxdb.DashboardQuery{Text: `SELECT latency FROM "telegraf"."autogen"."http"`}
+					return b
+				}(),
+				Objective:        0.999,
+				Window:           influxdb.Duration{Duration: 30 * 24 * time.Hour},
+				Indicator:        check.LatencyIndicator,
+				LatencyThreshold: 0.3,
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "slo check requires a flux query",
+			},
+		},
+		{
+			name: "query and stages",
+			src: &check.Deadman{
+				Base: func() check.Base {
+					b := goodBase
+					b.Stages = []check.QueryStage{{Name: "fetch", Text: `from(bucket: "telegraf")`}}
+					return b
+				}(),
+				TimeSince: 90,
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "Check query and stages are exclusive",
+			},
+		},
+		{
+			name: "stage named by a keyword",
+			src: &check.Deadman{
+				Base: func() check.Base {
+					b := goodBase
+					b.Query = influxdb.DashboardQuery{}
+					b.Stages = []check.QueryStage{{Name: "return", Text: `from(bucket: "telegraf")`}}
+					return b
+				}(),
+				TimeSince: 90,
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  `invalid query stage name "return", a stage is named by a flux identifier`,
+			},
+		},
+		{
+			name: "duplicate stages",
+			src: &check.Deadman{
+				Base: func() check.Base {
+					b := goodBase
+					b.Query = influxdb.DashboardQuery{}
+					b.Stages = []check.QueryStage{
+						{Name: "fetch", Text: `from(bucket: "telegraf")`},
+						{Name: "fetch", Text: `from(bucket: "baseline")`},
+					}
+					return b
+				}(),
+				TimeSince: 90,
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "duplicate query stage fetch",
+			},
+		},
+		{
+			name: "stages of an influxql query",
+			src: &check.Deadman{
+				Base: func() check.Base {
+					b := goodBase
+					b.Query = influxdb.DashboardQuery{}
+					b.QueryType = check.QueryTypeInfluxQL
+					b.Stages = []check.QueryStage{{Name: "fetch", Text: `SELECT * FROM cpu`}}
+					return b
+				}(),
+				TimeSince: 90,
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "Check query stages require a flux query",
+			},
+		},
+		{
+			name: "valid staged threshold check",
+			src: &check.Threshold{
+				Base: func() check.Base {
+					b := goodBase
+					b.Query = influxdb.DashboardQuery{}
+					b.Stages = []check.QueryStage{
+						{Name: "fetch", Text: `from(bucket: "telegraf") |> range(start: -1h)`},
+						{Name: "normalize", Text: `fetch |> aggregateWindow(every: 5m, fn: mean)`},
+					}
+					return b
+				}(),
+				Thresholds: []check.ThresholdConfig{
+					&check.Greater{Value: 90},
+				},
+			},
+		},
+		{
+			name: "valid prometheus threshold check",
+			src: &check.Threshold{
+				Base: func() check.Base {
+					b := goodBase
+					b.QueryType = check.QueryTypePrometheus
+					b.Query = influxdb.DashboardQuery{Text: `http_requests_total{code="500"}`}
+					b.ScrapeURL = "http://localhost:9100/metrics"
+					return b
+				}(),
+				Thresholds: []check.ThresholdConfig{
+					&check.Greater{Value: 90},
+				},
+			},
+		},
+		{
+			name: "threshold check without thresholds",
+			src: &check.Threshold{
+				Base: goodBase,
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "threshold check requires at least one threshold",
+			},
+		},
+		{
+			name: "greater recovering above its value",
+			src: &check.Threshold{
+				Base: goodBase,
+				Thresholds: []check.ThresholdConfig{
+					&check.Greater{Value: 90, Recover: floatPtr(95)},
+				},
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "greater threshold recover can't be larger than value",
+			},
+		},
+		{
+			name: "lesser recovering below its value",
+			src: &check.Threshold{
+				Base: goodBase,
+				Thresholds: []check.ThresholdConfig{
+					&check.Lesser{Value: 10, Recover: floatPtr(5)},
+				},
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "lesser threshold recover can't be smaller than value",
+			},
+		},
+		{
+			name: "within range recovering inside of it",
+			src: &check.Threshold{
+				Base: goodBase,
+				Thresholds: []check.ThresholdConfig{
+					&check.Range{Min: 10, Max: 90, Within: true, RecoverMin: floatPtr(20)},
+				},
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "range threshold within must recover outside of min and max",
+			},
+		},
+		{
+			name: "outside range recovering outside of it",
+			src: &check.Threshold{
+				Base: goodBase,
+				Thresholds: []check.ThresholdConfig{
+					&check.Range{Min: 10, Max: 90, RecoverMax: floatPtr(95)},
+				},
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "range threshold outside must recover within min and max",
+			},
+		},
+		{
+			name: "invalid range",
+			src: &check.Threshold{
+				Base: goodBase,
+				Thresholds: []check.ThresholdConfig{
+					&check.Range{Min: 100, Max: 90},
+				},
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "range threshold min can't be larger than max",
+			},
+		},
+		{
+			name: "deadman without time since",
+			src: &check.Deadman{
+				Base: goodBase,
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "deadman check timeSince must be larger than 0",
+			},
+		},
+		{
+			name: "slo check objective out of range",
+			src: &check.SLO{
+				Base:       goodBase,
+				Objective:  1,
+				Window:     influxdb.Duration{Duration: 30 * 24 * time.Hour},
+				Indicator:  check.ErrorRatioIndicator,
+				ErrorField: "errors",
+				TotalField: "requests",
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "slo check objective must be between 0 and 1",
+			},
+		},
+		{
+			name: "slo check with unknown indicator",
+			src: &check.SLO{
+				Base:      goodBase,
+				Objective: 0.999,
+				Window:    influxdb.Duration{Duration: 30 * 24 * time.Hour},
+				Indicator: "availability",
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "invalid slo check indicator availability",
+			},
+		},
+		{
+			name: "slo check without alerts shorter than its window",
+			src: &check.SLO{
+				Base:      goodBase,
+				Objective: 0.999,
+				Window:    influxdb.Duration{Duration: 30 * time.Minute},
+				Indicator: check.LatencyIndicator,
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "slo check requires at least one burn rate alert",
+			},
+		},
+		{
+			name: "slo check alert short window longer than long window",
+			src: &check.SLO{
+				Base:      goodBase,
+				Objective: 0.999,
+				Window:    influxdb.Duration{Duration: 30 * 24 * time.Hour},
+				Indicator: check.LatencyIndicator,
+				BurnRateAlerts: []check.BurnRateAlert{
+					{
+						LongWindow:  influxdb.Duration{Duration: time.Hour},
+						ShortWindow: influxdb.Duration{Duration: 2 * time.Hour},
+						BurnRate:    14.4,
+					},
+				},
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "burn rate alert short window must be larger than 0 and shorter than the long window",
+			},
+		},
+		{
+			name: "external check with a schedule",
+			src: &check.External{
+				Base: check.Base{
+					ID:     influxTesting.MustIDBase16(id1),
+					Name:   "name1",
+					OrgID:  influxTesting.MustIDBase16(id2),
+					Status: influxdb.Active,
+					Every:  influxdb.Duration{Duration: time.Minute},
+				},
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "external check can't have a schedule",
+			},
+		},
+		{
+			name: "valid external check",
+			src: &check.External{
+				Base: check.Base{
+					ID:     influxTesting.MustIDBase16(id1),
+					Name:   "name1",
+					OrgID:  influxTesting.MustIDBase16(id2),
+					Status: influxdb.Active,
+				},
+			},
+		},
+		{
+			name: "heartbeat check without every",
+			src: &check.Heartbeat{
+				Base: check.Base{
+					ID:     influxTesting.MustIDBase16(id1),
+					Name:   "name1",
+					OrgID:  influxTesting.MustIDBase16(id2),
+					Status: influxdb.Active,
+				},
+				Level: notification.Critical,
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "heartbeat check requires every, the interval of its pings",
+			},
+		},
+		{
+			name: "valid heartbeat check",
+			src: &check.Heartbeat{
+				Base: check.Base{
+					ID:     influxTesting.MustIDBase16(id1),
+					Name:   "name1",
+					OrgID:  influxTesting.MustIDBase16(id2),
+					Status: influxdb.Active,
+					Every:  influxdb.Duration{Duration: time.Hour},
+				},
+				Level: notification.Critical,
+			},
+		},
+		{
+			name: "lag check without threshold",
+			src: &check.Lag{
+				Base: check.Base{
+					ID:     influxTesting.MustIDBase16(id1),
+					Name:   "name1",
+					OrgID:  influxTesting.MustIDBase16(id2),
+					Status: influxdb.Active,
+					Every:  influxdb.Duration{Duration: time.Minute},
+				},
+				Level: notification.Critical,
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "lag check requires a positive threshold",
+			},
+		},
+		{
+			name: "valid lag check",
+			src: &check.Lag{
+				Base: check.Base{
+					ID:     influxTesting.MustIDBase16(id1),
+					Name:   "name1",
+					OrgID:  influxTesting.MustIDBase16(id2),
+					Status: influxdb.Active,
+					Every:  influxdb.Duration{Duration: time.Minute},
+				},
+				Threshold: influxdb.Duration{Duration: 30 * time.Second},
+				Level:     notification.Critical,
+			},
+		},
+		{
+			name: "valid slo check",
+			src: &check.SLO{
+				Base:             goodBase,
+				Objective:        0.999,
+				Window:           influxdb.Duration{Duration: 30 * 24 * time.Hour},
+				Indicator:        check.LatencyIndicator,
+				LatencyThreshold: 0.3,
+			},
+		},
+		{
+			name: "valid check with a runbook",
+			src: &check.Deadman{
+				Base: func() check.Base {
+					b := goodBase
+					b.Description = "see the *runbook*"
+					b.DescriptionFormat = check.DescriptionFormatMarkdown
+					b.RunbookURL = "https://runbooks.example.com/cpu"
+					return b
+				}(),
+				TimeSince: 90,
+			},
+		},
+		{
+			name: "valid threshold check",
+			src: &check.Threshold{
+				Base: goodBase,
+				Thresholds: []check.ThresholdConfig{
+					&check.Greater{Value: 90},
+				},
+			},
+		},
+	}
+	for _, c := range cases {
+		got := c.src.Valid()
+		influxTesting.ErrorsEqual(t, got, c.err)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	base := goodBase
+	base.Tags = []notification.Tag{{Key: "team", Value: "ops"}}
+	base.StatusMessageTemplate = "${r._check_name} is ${r._level}"
+	base.CRUDLog = influxdb.CRUDLog{
+		CreatedAt: timeGen1.Now(),
+		UpdatedAt: timeGen2.Now(),
+	}
+
+	cases := []struct {
+		name string
+		src  influxdb.Check
+	}{
+		{
+			name: "simple threshold",
+			src: &check.Threshold{
+				Base: base,
+				Thresholds: []check.ThresholdConfig{
+					&check.Greater{ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Critical}, Value: 90},
+					&check.Lesser{ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Info, AllValues: true}, Value: 10},
+					&check.Range{ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Warn}, Min: 70, Max: 90, Within: true},
+				},
+			},
+		},
+		{
+			name: "simple deadman",
+			src: &check.Deadman{
+				Base:       base,
+				TimeSince:  90,
+				ReportZero: true,
+				Level:      notification.Critical,
+			},
+		},
+		{
+			name: "simple slo",
+			src: &check.SLO{
+				Base:       base,
+				Objective:  0.999,
+				Window:     influxdb.Duration{Duration: 30 * 24 * time.Hour},
+				Indicator:  check.ErrorRatioIndicator,
+				ErrorField: "errors",
+				TotalField: "requests",
+				BurnRateAlerts: []check.BurnRateAlert{
+					{
+						Level:       notification.Critical,
+						LongWindow:  influxdb.Duration{Duration: time.Hour},
+						ShortWindow: influxdb.Duration{Duration: 5 * time.Minute},
+						BurnRate:    14.4,
+					},
+				},
+			},
+		},
+		{
+			name: "simple external",
+			src: &check.External{
+				Base: check.Base{
+					ID:     base.ID,
+					Name:   base.Name,
+					OrgID:  base.OrgID,
+					Status: influxdb.Active,
+					Tags:   base.Tags,
+				},
+			},
+		},
+		{
+			name: "simple heartbeat",
+			src: &check.Heartbeat{
+				Base: check.Base{
+					ID:     base.ID,
+					Name:   base.Name,
+					OrgID:  base.OrgID,
+					Status: influxdb.Active,
+					Every:  influxdb.Duration{Duration: time.Hour},
+					Tags:   base.Tags,
+				},
+				Level: notification.Critical,
+			},
+		},
+		{
+			name: "simple lag",
+			src: &check.Lag{
+				Base: check.Base{
+					ID:     base.ID,
+					Name:   base.Name,
+					OrgID:  base.OrgID,
+					Status: influxdb.Active,
+					Every:  influxdb.Duration{Duration: time.Minute},
+					Tags:   base.Tags,
+				},
+				Threshold: influxdb.Duration{Duration: 30 * time.Second},
+				Level:     notification.Warn,
+			},
+		},
+	}
+	for _, c := range cases {
+		b, err := json.Marshal(c.src)
+		if err != nil {
+			t.Fatalf("%s marshal failed, err: %s", c.name, err.Error())
+		}
+		got, err := check.UnmarshalJSON(b)
+		if err != nil {
+			t.Fatalf("%s unmarshal failed, err: %s", c.name, err.Error())
+		}
+		if diff := cmp.Diff(got, c.src); diff != "" {
+			t.Errorf("failed %s, check are different -got/+want\ndiff %s", c.name, diff)
+		}
+	}
+}
+
+func TestUnmarshalJSON_invalid(t *testing.T) {
+	cases := []struct {
+		name string
+		src  string
+		msg  string
+	}{
+		{
+			name: "not json",
+			src:  `{"type": `,
+			msg:  "unable to detect the check type from json",
+		},
+		{
+			name: "missing type",
+			src:  `{"name": "cpu"}`,
+			msg:  "check type is missing",
+		},
+		{
+			name: "unknown type",
+			src:  `{"type": "custom", "name": "cpu"}`,
+			msg:  "invalid check type custom",
+		},
+		{
+			name: "invalid field of its type",
+			src:  `{"type": "deadman", "name": "cpu", "timeSince": "90"}`,
+			msg:  "invalid deadman check",
+		},
+		{
+			name: "unknown threshold type",
+			src:  `{"type": "threshold", "name": "cpu", "thresholds": [{"type": "between"}]}`,
+			msg:  "invalid threshold check",
+		},
+	}
+	for _, c := range cases {
+		_, err := check.UnmarshalJSON([]byte(c.src))
+		if influxdb.ErrorCode(err) != influxdb.EInvalid {
+			t.Errorf("%s: expected an invalid check, got %v", c.name, err)
+			continue
+		}
+		if msg := err.(*influxdb.Error).Msg; msg != c.msg {
+			t.Errorf("%s: unexpected message %q, want %q", c.name, msg, c.msg)
+		}
+	}
+}
+
+func TestAlignTime(t *testing.T) {
+	cases := []struct {
+		name  string
+		t     time.Time
+		every time.Duration
+		want  time.Time
+	}{
+		{
+			name:  "minute mark",
+			t:     time.Date(2019, time.October, 1, 12, 3, 42, 123456789, time.UTC),
+			every: time.Minute,
+			want:  time.Date(2019, time.October, 1, 12, 3, 0, 0, time.UTC),
+		},
+		{
+			name:  "on the boundary",
+			t:     time.Date(2019, time.October, 1, 12, 3, 0, 0, time.UTC),
+			every: time.Minute,
+			want:  time.Date(2019, time.October, 1, 12, 3, 0, 0, time.UTC),
+		},
+		{
+			name:  "intervals counted from the epoch",
+			t:     time.Date(1970, time.January, 1, 0, 15, 0, 1, time.UTC),
+			every: 7 * time.Minute,
+			want:  time.Date(1970, time.January, 1, 0, 14, 0, 0, time.UTC),
+		},
+		{
+			name:  "nanoseconds",
+			t:     time.Unix(0, 1005),
+			every: 10 * time.Nanosecond,
+			want:  time.Unix(0, 1000),
+		},
+		{
+			name:  "no interval",
+			t:     time.Unix(0, 1005),
+			every: 0,
+			want:  time.Unix(0, 1005),
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := check.AlignTime(c.t, c.every); !got.Equal(c.want) {
+				t.Errorf("got %s, want %s", got, c.want)
+			}
+		})
+	}
+}
+
+func floatPtr(v float64) *float64 {
+	return &v
+}
+
+func TestThresholdHolds(t *testing.T) {
+	cases := []struct {
+		name      string
+		threshold check.ThresholdConfig
+		v         float64
+		crossed   bool
+		holds     bool
+	}{
+		{name: "greater above its value", threshold: &check.Greater{Value: 90, Recover: floatPtr(85)}, v: 91, crossed: true, holds: true},
+		{name: "greater between recover and value", threshold: &check.Greater{Value: 90, Recover: floatPtr(85)}, v: 87, holds: true},
+		{name: "greater below recover", threshold: &check.Greater{Value: 90, Recover: floatPtr(85)}, v: 84},
+		{name: "greater without recover", threshold: &check.Greater{Value: 90}, v: 87},
+		{name: "lesser between value and recover", threshold: &check.Lesser{Value: 10, Recover: floatPtr(15)}, v: 12, holds: true},
+		{name: "lesser above recover", threshold: &check.Lesser{Value: 10, Recover: floatPtr(15)}, v: 16},
+		{name: "within between recover bounds", threshold: &check.Range{Min: 10, Max: 20, Within: true, RecoverMin: floatPtr(5), RecoverMax: floatPtr(25)}, v: 22, holds: true},
+		{name: "outside between recover bounds", threshold: &check.Range{Min: 10, Max: 20, RecoverMin: floatPtr(12), RecoverMax: floatPtr(18)}, v: 19, holds: true},
+		{name: "outside within recover bounds", threshold: &check.Range{Min: 10, Max: 20, RecoverMin: floatPtr(12), RecoverMax: floatPtr(18)}, v: 15},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := c.threshold.Crossed(c.v); got != c.crossed {
+				t.Errorf("expected crossed %t, got %t", c.crossed, got)
+			}
+			if got := c.threshold.Holds(c.v); got != c.holds {
+				t.Errorf("expected holds %t, got %t", c.holds, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package check
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/parser"
+	"github.com/influxdata/influxdb"
+)
+
+// Copy returns a deep copy of a check.
+func Copy(c influxdb.Check) (influxdb.Check, error) {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInternal,
+			Err:  err,
+		}
+	}
+	return UnmarshalJSON(b)
+}
+
+// SetQueryBucket makes the query of the check, and its stages, read the
+// bucket instead of the buckets of their from() calls and of the builder
+// config of the query.
+func (b *Base) SetQueryBucket(bucket string) error {
+	if b.GetQueryType() != QueryTypeFlux {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "only the flux queries of checks can read another bucket",
+		}
+	}
+	text, err := replaceFromBuckets(b.Query.Text, bucket)
+	if err != nil {
+		return err
+	}
+	stages := make([]QueryStage, len(b.Stages))
+	for i, s := range b.Stages {
+		if s.Text, err = replaceFromBuckets(s.Text, bucket); err != nil {
+			return err
+		}
+		stages[i] = s
+	}
+
+	b.Query.Text = text
+	if len(b.Query.BuilderConfig.Buckets) > 0 {
+		b.Query.BuilderConfig.Buckets = []string{bucket}
+	}
+	if len(b.Stages) > 0 {
+		b.Stages = stages
+	}
+	return nil
+}
+
+// replaceFromBuckets returns the flux with the bucket in every call of
+// from(bucket: "name"), keeping the rest of the flux as written.
+func replaceFromBuckets(flux, bucket string) (string, error) {
+	if strings.TrimSpace(flux) == "" {
+		return flux, nil
+	}
+	pkg := parser.ParseSource(flux)
+	if ast.Check(pkg) > 0 {
+		return "", &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "check query is invalid",
+			Err:  ast.GetError(pkg),
+		}
+	}
+
+	var literals []*ast.StringLiteral
+	ast.Walk(ast.CreateVisitor(func(n ast.Node) {
+		if call, ok := n.(*ast.CallExpression); ok {
+			if l := fromBucketLiteral(call); l != nil {
+				literals = append(literals, l)
+			}
+		}
+	}), pkg)
+
+	// the literals are replaced from the last one, so the offsets of the
+	// others stay the same.
+	lines := lineOffsets(flux)
+	offset := func(p ast.Position) int {
+		return lines[p.Line-1] + p.Column - 1
+	}
+	sort.Slice(literals, func(i, j int) bool {
+		return offset(literals[i].Loc.Start) > offset(literals[j].Loc.Start)
+	})
+	for _, l := range literals {
+		start, end := offset(l.Loc.Start), offset(l.Loc.End)
+		flux = flux[:start] + fluxString(bucket) + flux[end:]
+	}
+	return flux, nil
+}
+
+// lineOffsets returns the byte offset of the start of each line of s.
+func lineOffsets(s string) []int {
+	offsets := []int{0}
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\n' {
+			offsets = append(offsets, i+1)
+		}
+	}
+	return offsets
+}
@@ -0,0 +1,130 @@
+package check_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/check"
+)
+
+func TestCopy(t *testing.T) {
+	c := &check.Threshold{
+		Base: check.Base{
+			ID:   1,
+			Name: "cpu",
+			Query: influxdb.DashboardQuery{
+				Text: `from(bucket: "telegraf") |> range(start: -1m)`,
+			},
+			Every: influxdb.Duration{Duration: time.Minute},
+			Tags:  []notification.Tag{{Key: "env", Value: "prod"}},
+		},
+		Thresholds: []check.ThresholdConfig{
+			&check.Greater{ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Critical}, Value: 90},
+		},
+	}
+	cp, err := check.Copy(c)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	th, ok := cp.(*check.Threshold)
+	if !ok {
+		t.Fatalf("expected a threshold check, got %T", cp)
+	}
+	th.Tags[0].Value = "dev"
+	th.Thresholds = nil
+	if c.Tags[0].Value != "prod" || len(c.Thresholds) != 1 {
+		t.Errorf("expected the copy not to share the fields of the check, got %+v", c)
+	}
+	if th.GetName() != "cpu" || th.Query.Text != c.Query.Text || th.Every != c.Every {
+		t.Errorf("unexpected copy %+v", th)
+	}
+}
+
+func TestBase_SetQueryBucket(t *testing.T) {
+	cases := []struct {
+		name   string
+		base   check.Base
+		bucket string
+		want   check.Base
+		err    bool
+	}{
+		{
+			name: "every from call",
+			base: check.Base{
+				Query: influxdb.DashboardQuery{
+					Text: "a = from(bucket: \"telegraf\")\n\t|> range(start: -1m)\nb = from(bucket:\"other\", host: \"h\") |> range(start: -1m)\njoin(tables: {a, b}, on: [\"host\"])",
+					BuilderConfig: influxdb.BuilderConfig{
+						Buckets: []string{"telegraf", "other"},
+					},
+				},
+			},
+			bucket: "métriques",
+			want: check.Base{
+				Query: influxdb.DashboardQuery{
+					Text: "a = from(bucket: \"métriques\")\n\t|> range(start: -1m)\nb = from(bucket:\"métriques\", host: \"h\") |> range(start: -1m)\njoin(tables: {a, b}, on: [\"host\"])",
+					BuilderConfig: influxdb.BuilderConfig{
+						Buckets: []string{"métriques"},
+					},
+				},
+			},
+		},
+		{
+			name: "stages",
+			base: check.Base{
+				Stages: []check.QueryStage{
+					{Name: "raw", Text: `from(bucket: "telegraf") |> range(start: -1m)`},
+					{Name: "mean", Text: `raw |> mean()`},
+				},
+			},
+			bucket: "prod",
+			want: check.Base{
+				Stages: []check.QueryStage{
+					{Name: "raw", Text: `from(bucket: "prod") |> range(start: -1m)`},
+					{Name: "mean", Text: `raw |> mean()`},
+				},
+			},
+		},
+		{
+			name: "not a flux query",
+			base: check.Base{
+				QueryType: check.QueryTypePrometheus,
+				Query:     influxdb.DashboardQuery{Text: `up{job="api"}`},
+			},
+			bucket: "prod",
+			err:    true,
+		},
+		{
+			name: "invalid flux",
+			base: check.Base{
+				Query: influxdb.DashboardQuery{Text: `from(bucket: "telegraf") |>`},
+			},
+			bucket: "prod",
+			err:    true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			b := c.base
+			err := b.SetQueryBucket(c.bucket)
+			if (err != nil) != c.err {
+				t.Fatalf("expected error %v, got %v", c.err, err)
+			}
+			if c.err {
+				return
+			}
+			if b.Query.Text != c.want.Query.Text {
+				t.Errorf("expected query\n%s\ngot\n%s", c.want.Query.Text, b.Query.Text)
+			}
+			if len(b.Query.BuilderConfig.Buckets) != len(c.want.Query.BuilderConfig.Buckets) {
+				t.Errorf("expected builder buckets %v, got %v", c.want.Query.BuilderConfig.Buckets, b.Query.BuilderConfig.Buckets)
+			}
+			for i, s := range c.want.Stages {
+				if b.Stages[i] != s {
+					t.Errorf("expected stage %+v, got %+v", s, b.Stages[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package check
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.CheckCoverageService = (*CoverageService)(nil)
+
+// queryCheck is a check with a flux query.
+type queryCheck interface {
+	GetQuery() influxdb.DashboardQuery
+}
+
+// CoverageService cross-references the checks of an organization with the
+// measurements written to its buckets.
+type CoverageService struct {
+	CheckService       influxdb.CheckService
+	BucketService      influxdb.BucketService
+	MeasurementService influxdb.MeasurementService
+}
+
+// FindCheckCoverage returns the coverage of the measurements of the buckets
+// by the checks of an organization, every bucket of the organization if
+// bucketIDs is empty. Inactive checks and checks whose query can't be parsed
+// don't cover any measurement.
+func (s *CoverageService) FindCheckCoverage(ctx context.Context, orgID influxdb.ID, bucketIDs []influxdb.ID) (*influxdb.CheckCoverageReport, error) {
+	buckets, err := s.findBuckets(ctx, orgID, bucketIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	checks, _, err := s.CheckService.FindChecks(ctx, influxdb.CheckFilter{OrgID: &orgID})
+	if err != nil {
+		return nil, err
+	}
+	scopes := make(map[influxdb.ID]QueryScope, len(checks))
+	for _, c := range checks {
+		qc, ok := c.(queryCheck)
+		if !ok || c.GetStatus() != influxdb.Active {
+			continue
+		}
+		scope, err := ParseQueryScope(qc.GetQuery())
+		if err != nil {
+			continue
+		}
+		scopes[c.GetID()] = scope
+	}
+
+	r := &influxdb.CheckCoverageReport{
+		OrgID:   orgID,
+		Buckets: make([]influxdb.BucketCoverage, 0, len(buckets)),
+	}
+	for _, b := range buckets {
+		measurements, err := s.MeasurementService.FindMeasurements(ctx, orgID, b.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		bc := influxdb.BucketCoverage{
+			BucketID:                b.ID,
+			BucketName:              b.Name,
+			MonitoredMeasurements:   []influxdb.MonitoredMeasurement{},
+			UnmonitoredMeasurements: []string{},
+		}
+		for _, m := range measurements {
+			mm := influxdb.MonitoredMeasurement{Name: m}
+			// checks are listed in the order of the check service.
+			for _, c := range checks {
+				if scope, ok := scopes[c.GetID()]; ok && scope.Covers(b.Name, m) {
+					mm.CheckIDs = append(mm.CheckIDs, c.GetID())
+				}
+			}
+			if len(mm.CheckIDs) == 0 {
+				bc.UnmonitoredMeasurements = append(bc.UnmonitoredMeasurements, m)
+				continue
+			}
+			bc.MonitoredMeasurements = append(bc.MonitoredMeasurements, mm)
+		}
+		r.Buckets = append(r.Buckets, bc)
+	}
+	return r, nil
+}
+
+// findBuckets returns the buckets of an org, only the buckets of bucketIDs if any.
+func (s *CoverageService) findBuckets(ctx context.Context, orgID influxdb.ID, bucketIDs []influxdb.ID) ([]*influxdb.Bucket, error) {
+	if len(bucketIDs) == 0 {
+		bs, _, err := s.BucketService.FindBuckets(ctx, influxdb.BucketFilter{OrganizationID: &orgID})
+		return bs, err
+	}
+
+	bs := make([]*influxdb.Bucket, 0, len(bucketIDs))
+	for _, id := range bucketIDs {
+		b, err := s.BucketService.FindBucketByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if b.OrgID != orgID {
+			return nil, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  fmt.Sprintf("bucket %s doesn't belong to the organization", id),
+			}
+		}
+		bs = append(bs, b)
+	}
+	return bs, nil
+}
@@ -0,0 +1,125 @@
+package check_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/notification/check"
+)
+
+func TestCoverageService_FindCheckCoverage(t *testing.T) {
+	orgID := influxdb.ID(1)
+	newCheck := func(id influxdb.ID, status influxdb.Status, query string) influxdb.Check {
+		return &check.Deadman{
+			Base: check.Base{
+				ID:     id,
+				Name:   "check",
+				OrgID:  orgID,
+				Status: status,
+				Every:  influxdb.Duration{Duration: time.Minute},
+				Query:  influxdb.DashboardQuery{Text: query},
+			},
+			TimeSince: 60,
+		}
+	}
+	checks := []influxdb.Check{
+		newCheck(10, influxdb.Active, `from(bucket: "telegraf") |> range(start: -1m) |> filter(fn: (r) => r._measurement == "cpu")`),
+		newCheck(11, influxdb.Active, `from(bucket: "telegraf") |> range(start: -1m) |> filter(fn: (r) => r._measurement =~ /^cpu|mem$/)`),
+		// inactive checks don't cover measurements.
+		newCheck(12, influxdb.Inactive, `from(bucket: "telegraf") |> range(start: -1m)`),
+		newCheck(13, influxdb.Active, `from(bucket: "apps") |> range(start: -1m)`),
+	}
+	buckets := []*influxdb.Bucket{
+		{ID: 20, OrgID: orgID, Name: "telegraf"},
+		{ID: 21, OrgID: orgID, Name: "apps"},
+		{ID: 22, OrgID: influxdb.ID(2), Name: "telegraf"},
+	}
+	measurements := map[influxdb.ID][]string{
+		20: {"cpu", "disk", "mem"},
+		21: {"requests"},
+	}
+
+	bucketSvc := mock.NewBucketService()
+	bucketSvc.FindBucketsFn = func(ctx context.Context, filter influxdb.BucketFilter, opts ...influxdb.FindOptions) ([]*influxdb.Bucket, int, error) {
+		var bs []*influxdb.Bucket
+		for _, b := range buckets {
+			if b.OrgID == *filter.OrganizationID {
+				bs = append(bs, b)
+			}
+		}
+		return bs, len(bs), nil
+	}
+	bucketSvc.FindBucketByIDFn = func(ctx context.Context, id influxdb.ID) (*influxdb.Bucket, error) {
+		for _, b := range buckets {
+			if b.ID == id {
+				return b, nil
+			}
+		}
+		return nil, &influxdb.Error{Code: influxdb.ENotFound, Msg: "bucket not found"}
+	}
+	s := &check.CoverageService{
+		CheckService: &mock.CheckService{
+			FindChecksF: func(ctx context.Context, filter influxdb.CheckFilter, opt ...influxdb.FindOptions) ([]influxdb.Check, int, error) {
+				return checks, len(checks), nil
+			},
+		},
+		BucketService: bucketSvc,
+		MeasurementService: &mock.MeasurementService{
+			FindMeasurementsF: func(ctx context.Context, orgID, bucketID influxdb.ID) ([]string, error) {
+				return measurements[bucketID], nil
+			},
+		},
+	}
+
+	telegraf := influxdb.BucketCoverage{
+		BucketID:   20,
+		BucketName: "telegraf",
+		MonitoredMeasurements: []influxdb.MonitoredMeasurement{
+			{Name: "cpu", CheckIDs: []influxdb.ID{10, 11}},
+			{Name: "mem", CheckIDs: []influxdb.ID{11}},
+		},
+		UnmonitoredMeasurements: []string{"disk"},
+	}
+	apps := influxdb.BucketCoverage{
+		BucketID:   21,
+		BucketName: "apps",
+		MonitoredMeasurements: []influxdb.MonitoredMeasurement{
+			{Name: "requests", CheckIDs: []influxdb.ID{13}},
+		},
+		UnmonitoredMeasurements: []string{},
+	}
+
+	ctx := context.Background()
+	r, err := s.FindCheckCoverage(ctx, orgID, nil)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	want := &influxdb.CheckCoverageReport{
+		OrgID:   orgID,
+		Buckets: []influxdb.BucketCoverage{telegraf, apps},
+	}
+	if diff := cmp.Diff(r, want); diff != "" {
+		t.Errorf("coverage report is different -got/+want\ndiff %s", diff)
+	}
+
+	r, err = s.FindCheckCoverage(ctx, orgID, []influxdb.ID{21})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	want = &influxdb.CheckCoverageReport{
+		OrgID:   orgID,
+		Buckets: []influxdb.BucketCoverage{apps},
+	}
+	if diff := cmp.Diff(r, want); diff != "" {
+		t.Errorf("coverage report of selected buckets is different -got/+want\ndiff %s", diff)
+	}
+
+	// buckets of other orgs can't be selected.
+	if _, err := s.FindCheckCoverage(ctx, orgID, []influxdb.ID{22}); influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Errorf("expected invalid error, got %v", err)
+	}
+}
@@ -0,0 +1,96 @@
+package check
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification"
+)
+
+var _ influxdb.Check = &Deadman{}
+
+// Deadman is the check which writes a status when the data stops arriving.
+type Deadman struct {
+	Base
+	// TimeSince is the number of seconds without data before the check triggers.
+	TimeSince int `json:"timeSince"`
+	// ReportZero also triggers the check when only zero values arrived.
+	ReportZero bool                    `json:"reportZero"`
+	Level      notification.CheckLevel `json:"level"`
+}
+
+type deadmanAlias Deadman
+
+// MarshalJSON implement json.Marshaler interface.
+func (c Deadman) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		struct {
+			deadmanAlias
+			Type string `json:"type"`
+		}{
+			deadmanAlias: deadmanAlias(c),
+			Type:         c.Type(),
+		})
+}
+
+// Valid returns where the config is valid.
+func (c Deadman) Valid() error {
+	if err := c.Base.valid(); err != nil {
+		return err
+	}
+	if c.TimeSince <= 0 {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "deadman check timeSince must be larger than 0",
+		}
+	}
+	return nil
+}
+
+// Type returns the type of the check.
+func (c Deadman) Type() string {
+	return "deadman"
+}
+
+// GenerateFlux returns the flux script of the check. It yields a status per
+// series of the data of the check as "statuses", with the level of the check
+// if the series has no data since TimeSince seconds, or only zero values when
+// it reports zero, and ok otherwise. The statuses are tagged with the tags of
+// the check and the label tags of labels.
+func (c Deadman) GenerateFlux(labels []*influxdb.Label) (string, error) {
+	if err := c.Valid(); err != nil {
+		return "", err
+	}
+
+	imports, query := splitFluxImports(c.GetQuery().Text)
+	var sb strings.Builder
+	sb.WriteString(c.fluxImports(imports))
+	sb.WriteString(c.fluxTaskOption())
+	fmt.Fprintf(&sb, "data = %s\n\n", strings.TrimSpace(query))
+
+	columns := `"_latest"`
+	identity := "_latest: 0"
+	latest := "_latest: if int(v: r._time) > accumulator._latest then int(v: r._time) else accumulator._latest"
+	dead := fmt.Sprintf("int(v: now()) - r._latest >= %d", time.Duration(c.TimeSince)*time.Second)
+	if c.ReportZero {
+		columns += `, "_zero"`
+		identity += ", _zero: true"
+		latest += ", _zero: accumulator._zero and float(v: r._value) == 0.0"
+		dead += " or r._zero"
+	}
+
+	fmt.Fprintf(&sb, `data
+	|> reduce(identity: {%s}, fn: (r, accumulator) => ({%s}))
+	|> map(fn: (r) => ({r with _level: if %s then %s else %s}))
+	|> drop(columns: [%s])
+	|> map(fn: (r) => ({r with _check_id: %s, _check_name: %s, _time: now()%s%s}))
+	|> yield(name: "statuses")
+`, identity, latest, dead, fluxLevel(c.Level), fluxLevel(notification.Ok), columns,
+		strconv.Quote(c.ID.String()), strconv.Quote(c.Name), c.fluxStatusTags(labels), c.fluxStatusMessage(labels, false))
+
+	return sb.String(), nil
+}
@@ -0,0 +1,73 @@
+package check_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/check"
+	influxTesting "github.com/influxdata/influxdb/testing"
+)
+
+func TestDeadman_GenerateFlux(t *testing.T) {
+	base := goodBase
+	base.Name = "cpu"
+	cron := base
+	cron.Cron = "*/5 * * * *"
+	cron.Every = influxdb.Duration{}
+	cron.StatusMessageTemplate = "${r.host} stopped reporting at ${r._time}"
+
+	cases := []struct {
+		name string
+		src  check.Deadman
+		want string
+		err  error
+	}{
+		{
+			name: "time since",
+			src:  check.Deadman{Base: base, TimeSince: 90, Level: notification.Critical},
+			want: `option task = {name: "cpu", every: 1m}
+
+data = from(bucket: "telegraf") |> range(start: -1m) |> filter(fn: (r) => r._measurement == "cpu")
+
+data
+	|> reduce(identity: {_latest: 0}, fn: (r, accumulator) => ({_latest: if int(v: r._time) > accumulator._latest then int(v: r._time) else accumulator._latest}))
+	|> map(fn: (r) => ({r with _level: if int(v: now()) - r._latest >= 90000000000 then "crit" else "ok"}))
+	|> drop(columns: ["_latest"])
+	|> map(fn: (r) => ({r with _check_id: "020f755c3c082000", _check_name: "cpu", _time: now()}))
+	|> yield(name: "statuses")
+`,
+		},
+		{
+			name: "report zero",
+			src:  check.Deadman{Base: cron, TimeSince: 300, ReportZero: true, Level: notification.Warn},
+			want: `option task = {name: "cpu", cron: "*/5 * * * *"}
+
+data = from(bucket: "telegraf") |> range(start: -1m) |> filter(fn: (r) => r._measurement == "cpu")
+
+data
+	|> reduce(identity: {_latest: 0, _zero: true}, fn: (r, accumulator) => ({_latest: if int(v: r._time) > accumulator._latest then int(v: r._time) else accumulator._latest, _zero: accumulator._zero and float(v: r._value) == 0.0}))
+	|> map(fn: (r) => ({r with _level: if int(v: now()) - r._latest >= 300000000000 or r._zero then "warn" else "ok"}))
+	|> drop(columns: ["_latest", "_zero"])
+	|> map(fn: (r) => ({r with _check_id: "020f755c3c082000", _check_name: "cpu", _time: now(), _message: r["host"] + " stopped reporting at " + string(v: now())}))
+	|> yield(name: "statuses")
+`,
+		},
+		{
+			name: "no time since",
+			src:  check.Deadman{Base: base, Level: notification.Critical},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "deadman check timeSince must be larger than 0",
+			},
+		},
+	}
+	for _, c := range cases {
+		got, err := c.src.GenerateFlux(nil)
+		influxTesting.ErrorsEqual(t, err, c.err)
+		if diff := cmp.Diff(got, c.want); diff != "" {
+			t.Errorf("failed %s, flux is different -got/+want\ndiff %s", c.name, diff)
+		}
+	}
+}
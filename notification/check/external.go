@@ -0,0 +1,67 @@
+package check
+
+import (
+	"encoding/json"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.Check = &External{}
+
+// External is the check whose statuses are reported by an external system,
+// rather than evaluated from a query. It has no query nor schedule, its
+// statuses are written when the system reports them.
+type External struct {
+	Base
+}
+
+type externalAlias External
+
+// MarshalJSON implement json.Marshaler interface.
+func (c External) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		struct {
+			externalAlias
+			Type string `json:"type"`
+		}{
+			externalAlias: externalAlias(c),
+			Type:          c.Type(),
+		})
+}
+
+// Valid returns where the config is valid.
+func (c External) Valid() error {
+	if err := c.Base.validIdentity(); err != nil {
+		return err
+	}
+	if err := c.Base.validStatus(); err != nil {
+		return err
+	}
+	if err := c.Base.validDescription(); err != nil {
+		return err
+	}
+	if c.Query.Text != "" || len(c.Stages) > 0 || c.QueryType != "" || c.ScrapeURL != "" {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "external check can't have a query",
+		}
+	}
+	if c.Cron != "" || c.Every.Duration != 0 || c.Offset.Duration != 0 || c.AlignToInterval {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "external check can't have a schedule",
+		}
+	}
+	if c.Occurrences < 0 {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "Check occurrences can't be negative",
+		}
+	}
+	return c.Base.validTags()
+}
+
+// Type returns the type of the check.
+func (c External) Type() string {
+	return "external"
+}
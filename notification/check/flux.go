@@ -0,0 +1,200 @@
+package check
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification"
+)
+
+// fluxTaskOption returns the task option of the flux script of the check.
+func (b Base) fluxTaskOption() string {
+	opts := []string{"name: " + strconv.Quote(b.Name)}
+	if spec, ok := alignedCron(b.Every.Duration); b.AlignToInterval && ok {
+		opts = append(opts, "cron: "+strconv.Quote(spec))
+	} else if b.Cron != "" {
+		opts = append(opts, "cron: "+strconv.Quote(b.Cron))
+	} else {
+		opts = append(opts, "every: "+fluxDuration(b.Every.Duration))
+	}
+	if b.Offset.Duration > 0 {
+		opts = append(opts, "offset: "+fluxDuration(b.Offset.Duration))
+	}
+	if b.Priority != "" {
+		opts = append(opts, "priority: "+strconv.Quote(string(b.Priority)))
+	}
+	return fmt.Sprintf("option task = {%s}\n\n", strings.Join(opts, ", "))
+}
+
+// statusTags returns the tags of the statuses of the check, its tags and
+// then the label tags of labels.
+func (b Base) statusTags(labels []*influxdb.Label) []notification.Tag {
+	tags := make([]notification.Tag, 0, len(b.Tags)+len(labels))
+	tags = append(tags, b.Tags...)
+	for _, l := range labels {
+		tags = append(tags, notification.LabelTag(l.Name))
+	}
+	return tags
+}
+
+// fluxStatusTags returns the properties of the tags of the statuses of the
+// check, prefixed with a comma if there are any.
+func (b Base) fluxStatusTags(labels []*influxdb.Label) string {
+	var sb strings.Builder
+	for _, t := range b.statusTags(labels) {
+		fmt.Fprintf(&sb, ", %s: %s", strconv.Quote(t.Key), strconv.Quote(t.Value))
+	}
+	return sb.String()
+}
+
+// fluxStatusMessage returns the _message property of the statuses of the
+// check, prefixed with a comma, or an empty string if the check has no
+// status message template. The template is compiled into the concatenation
+// of its text and of the values its ${r.key} references, read from the
+// _level and _value columns, if hasValue, and the tag columns of the record
+// r. The tags of the statuses override the tags of the series, as they do in
+// the columns of the statuses, so the other tags the template references
+// must be columns of the data of the check.
+func (b Base) fluxStatusMessage(labels []*influxdb.Label, hasValue bool) string {
+	tmpl := b.StatusMessageTemplate
+	if tmpl == "" {
+		return ""
+	}
+	tags := make(map[string]string)
+	for _, t := range b.statusTags(labels) {
+		tags[t.Key] = t.Value
+	}
+
+	var parts []string
+	var literal strings.Builder
+	text := func(s string) {
+		literal.WriteString(s)
+	}
+	expr := func(e string) {
+		if literal.Len() > 0 {
+			parts = append(parts, fluxString(literal.String()))
+			literal.Reset()
+		}
+		parts = append(parts, e)
+	}
+	last := 0
+	for _, m := range notification.TemplateVarIndex(tmpl) {
+		text(tmpl[last:m[0]])
+		last = m[1]
+		key := tmpl[m[2]:m[3]]
+		switch key {
+		case "_check_id":
+			text(b.ID.String())
+		case "_check_name":
+			text(b.Name)
+		case "_level":
+			expr("strings.toUpper(v: r._level)")
+		case "_value":
+			if hasValue {
+				expr("string(v: r._value)")
+			}
+		case "_time":
+			expr("string(v: now())")
+		case "_runbook":
+			text(b.RunbookURL)
+		case "_message", "_incident_duration":
+		default:
+			if v, ok := tags[key]; ok {
+				text(v)
+			} else {
+				expr(fmt.Sprintf("r[%s]", strconv.Quote(key)))
+			}
+		}
+	}
+	text(tmpl[last:])
+	if literal.Len() > 0 || len(parts) == 0 {
+		parts = append(parts, fluxString(literal.String()))
+	}
+	return ", _message: " + strings.Join(parts, " + ")
+}
+
+// fluxImports returns the imports of the flux script of the check, the
+// imports of its query and the strings package if its status message
+// template needs it.
+func (b Base) fluxImports(queryImports []string) string {
+	imports := queryImports
+	for _, m := range notification.TemplateVarIndex(b.StatusMessageTemplate) {
+		if b.StatusMessageTemplate[m[2]:m[3]] == "_level" {
+			imports = append(imports, `import "strings"`)
+			break
+		}
+	}
+
+	var sb strings.Builder
+	seen := make(map[string]bool, len(imports))
+	for _, imp := range imports {
+		if !seen[imp] {
+			seen[imp] = true
+			sb.WriteString(imp + "\n")
+		}
+	}
+	if sb.Len() > 0 {
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// splitFluxImports returns the import statements the flux text starts with,
+// and the text after them.
+func splitFluxImports(text string) ([]string, string) {
+	var imports []string
+	lines := strings.Split(text, "\n")
+	for len(lines) > 0 {
+		line := strings.TrimSpace(lines[0])
+		if line != "" && !strings.HasPrefix(line, "import ") {
+			break
+		}
+		if line != "" {
+			imports = append(imports, line)
+		}
+		lines = lines[1:]
+	}
+	return imports, strings.Join(lines, "\n")
+}
+
+// fluxString returns the flux string expression of s. The ${ sequences
+// are split in two literals, as flux reads them as string interpolations.
+func fluxString(s string) string {
+	return strings.Replace(strconv.Quote(s), "${", `$" + "{`, -1)
+}
+
+// fluxLevel returns the flux string literal of the level of the statuses.
+func fluxLevel(l notification.CheckLevel) string {
+	return strconv.Quote(strings.ToLower(l.String()))
+}
+
+// bySeverity returns the indexes of thresholds from the most severe level
+// to the least one, in order for the same levels.
+func bySeverity(thresholds []ThresholdConfig) []int {
+	idx := make([]int, len(thresholds))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(i, j int) bool {
+		return severity(thresholds[idx[i]].GetLevel()) > severity(thresholds[idx[j]].GetLevel())
+	})
+	return idx
+}
+
+// severity orders the levels of the statuses.
+func severity(l notification.CheckLevel) int {
+	switch l {
+	case notification.Ok:
+		return 1
+	case notification.Info:
+		return 2
+	case notification.Warn:
+		return 3
+	case notification.Critical:
+		return 4
+	}
+	return 0
+}
@@ -0,0 +1,77 @@
+package check
+
+import (
+	"encoding/json"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification"
+)
+
+var _ influxdb.Check = &Heartbeat{}
+
+// Heartbeat is the check which writes a status when the pings of a job stop,
+// such as a cron job pinging the check each time it runs. It has no query,
+// it expects a ping at least every interval.
+type Heartbeat struct {
+	Base
+	// Level is the level of the status written when no ping arrived in the
+	// every interval of the check.
+	Level notification.CheckLevel `json:"level"`
+}
+
+type heartbeatAlias Heartbeat
+
+// MarshalJSON implement json.Marshaler interface.
+func (c Heartbeat) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		struct {
+			heartbeatAlias
+			Type string `json:"type"`
+		}{
+			heartbeatAlias: heartbeatAlias(c),
+			Type:           c.Type(),
+		})
+}
+
+// Valid returns where the config is valid.
+func (c Heartbeat) Valid() error {
+	if err := c.Base.validIdentity(); err != nil {
+		return err
+	}
+	if err := c.Base.validStatus(); err != nil {
+		return err
+	}
+	if err := c.Base.validDescription(); err != nil {
+		return err
+	}
+	if c.Query.Text != "" || len(c.Stages) > 0 || c.QueryType != "" || c.ScrapeURL != "" {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "heartbeat check can't have a query",
+		}
+	}
+	if c.Cron != "" || c.Every.Duration <= 0 {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "heartbeat check requires every, the interval of its pings",
+		}
+	}
+	if c.Offset.Duration < 0 {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "Check every and offset can't be negative",
+		}
+	}
+	if c.Occurrences < 0 {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "Check occurrences can't be negative",
+		}
+	}
+	return c.Base.validTags()
+}
+
+// Type returns the type of the check.
+func (c Heartbeat) Type() string {
+	return "heartbeat"
+}
@@ -0,0 +1,86 @@
+package check
+
+import (
+	"encoding/json"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification"
+)
+
+var _ influxdb.Check = &Lag{}
+
+// Lag is the system check of the scheduler of the alerting engine: it writes
+// a status at its level when the p90 lag of the evaluations of every check
+// started during its every interval exceeds its threshold, an early warning
+// of an overloaded instance. It has no query, the value of its statuses is
+// the p90 lag in seconds.
+type Lag struct {
+	Base
+	// Threshold is the p90 lag above which the check writes a status at its level.
+	Threshold influxdb.Duration `json:"threshold"`
+	// Level is the level of the status written when the lag exceeds the threshold.
+	Level notification.CheckLevel `json:"level"`
+}
+
+type lagAlias Lag
+
+// MarshalJSON implement json.Marshaler interface.
+func (c Lag) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		struct {
+			lagAlias
+			Type string `json:"type"`
+		}{
+			lagAlias: lagAlias(c),
+			Type:     c.Type(),
+		})
+}
+
+// Valid returns where the config is valid.
+func (c Lag) Valid() error {
+	if err := c.Base.validIdentity(); err != nil {
+		return err
+	}
+	if err := c.Base.validStatus(); err != nil {
+		return err
+	}
+	if err := c.Base.validDescription(); err != nil {
+		return err
+	}
+	if c.Query.Text != "" || len(c.Stages) > 0 || c.QueryType != "" || c.ScrapeURL != "" {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "lag check can't have a query",
+		}
+	}
+	if c.Cron != "" || c.Every.Duration <= 0 {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "lag check requires every, the interval of the evaluations it measures",
+		}
+	}
+	if c.Offset.Duration < 0 {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "Check every and offset can't be negative",
+		}
+	}
+	if c.Threshold.Duration <= 0 {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "lag check requires a positive threshold",
+		}
+	}
+	if c.Occurrences < 0 {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "Check occurrences can't be negative",
+		}
+	}
+	return c.Base.validTags()
+}
+
+// Type returns the type of the check.
+func (c Lag) Type() string {
+	return "lag"
+}
@@ -0,0 +1,470 @@
+package check
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/parser"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification"
+)
+
+// PrometheusRules is a Prometheus rule file of the alerting rules exported
+// from threshold checks, and the checks which couldn't be exported.
+type PrometheusRules struct {
+	Groups  []PrometheusRuleGroup `json:"groups"`
+	Skipped []SkippedCheck        `json:"skipped,omitempty"`
+}
+
+// PrometheusRuleGroup is a group of alerting rules evaluated every interval.
+type PrometheusRuleGroup struct {
+	Name string `json:"name"`
+	// Interval is empty for the checks run on a cron, which are evaluated at
+	// the global interval of Prometheus.
+	Interval string           `json:"interval,omitempty"`
+	Rules    []PrometheusRule `json:"rules"`
+}
+
+// PrometheusRule is a Prometheus alerting rule.
+type PrometheusRule struct {
+	Alert       string            `json:"alert"`
+	Expr        string            `json:"expr"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// SkippedCheck is a check which couldn't be exported.
+type SkippedCheck struct {
+	ID     influxdb.ID `json:"id"`
+	Name   string      `json:"name"`
+	Reason string      `json:"reason"`
+}
+
+// ExportPrometheusRules expresses the threshold checks as Prometheus alerting
+// rules, grouped by the interval of the checks. A check is skipped if it
+// isn't a threshold check or its query can't be translated to PromQL.
+func ExportPrometheusRules(cs []influxdb.Check) *PrometheusRules {
+	groups := make(map[string]*PrometheusRuleGroup)
+	rs := &PrometheusRules{Groups: []PrometheusRuleGroup{}}
+	for _, c := range cs {
+		t, ok := c.(*Threshold)
+		if !ok {
+			rs.Skipped = append(rs.Skipped, SkippedCheck{
+				ID:     c.GetID(),
+				Name:   c.GetName(),
+				Reason: fmt.Sprintf("%s checks can't be expressed as Prometheus rules", c.Type()),
+			})
+			continue
+		}
+		rules, err := t.PrometheusRules()
+		if err != nil {
+			rs.Skipped = append(rs.Skipped, SkippedCheck{
+				ID:     c.GetID(),
+				Name:   c.GetName(),
+				Reason: influxdb.ErrorMessage(err),
+			})
+			continue
+		}
+
+		name, interval := "influxdb", ""
+		if t.Cron == "" {
+			interval = promDuration(t.Every.Duration)
+			name += "_" + interval
+		}
+		g, ok := groups[name]
+		if !ok {
+			g = &PrometheusRuleGroup{Name: name, Interval: interval}
+			groups[name] = g
+		}
+		g.Rules = append(g.Rules, rules...)
+	}
+
+	for _, g := range groups {
+		rs.Groups = append(rs.Groups, *g)
+	}
+	sort.Slice(rs.Groups, func(i, j int) bool {
+		return rs.Groups[i].Name < rs.Groups[j].Name
+	})
+	return rs
+}
+
+// PrometheusRules returns an alerting rule for each threshold of the check,
+// labeled with the tags of the check and the severity of the threshold. The
+// thresholds of the ok level, which Prometheus resolves by itself, have no rule.
+func (c Threshold) PrometheusRules() ([]PrometheusRule, error) {
+	if c.Query.Text == "" {
+		// the query of a redacted check is hidden.
+		return nil, untranslatable("the check has no query")
+	}
+	var expr string
+	switch c.GetQueryType() {
+	case QueryTypePrometheus:
+		// the metric selector is already PromQL.
+		expr = c.Query.Text
+	case QueryTypeFlux:
+		var err error
+		if expr, err = PromQL(c.GetQuery()); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("%s queries can't be translated to PromQL", c.GetQueryType()),
+		}
+	}
+
+	annotations := map[string]string{
+		"influxdb_check_id": c.ID.String(),
+	}
+	if c.Description != "" {
+		annotations["description"] = c.Description
+	}
+	rules := make([]PrometheusRule, 0, len(c.Thresholds))
+	for _, t := range c.Thresholds {
+		if t.GetLevel() == notification.Ok {
+			continue
+		}
+		if t.GetAllValues() {
+			return nil, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "thresholds crossed by all values can't be expressed in PromQL",
+			}
+		}
+		if hasRecover(t) {
+			return nil, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "thresholds with a recover value can't be expressed in PromQL",
+			}
+		}
+		labels := map[string]string{
+			"severity": strings.ToLower(t.GetLevel().String()),
+		}
+		for _, tag := range c.Tags {
+			labels[promName(tag.Key)] = tag.Value
+		}
+		rules = append(rules, PrometheusRule{
+			Alert:       promName(c.Name),
+			Expr:        thresholdPromQL(expr, t),
+			Labels:      labels,
+			Annotations: annotations,
+		})
+	}
+	return rules, nil
+}
+
+// thresholdPromQL returns the PromQL expression of the series of expr crossing a threshold.
+func thresholdPromQL(expr string, t ThresholdConfig) string {
+	f := func(v float64) string {
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+	switch t := t.(type) {
+	case *Greater:
+		return thresholdPromQL(expr, *t)
+	case *Lesser:
+		return thresholdPromQL(expr, *t)
+	case *Range:
+		return thresholdPromQL(expr, *t)
+	case Greater:
+		return expr + " > " + f(t.Value)
+	case Lesser:
+		return expr + " < " + f(t.Value)
+	case Range:
+		if t.Within {
+			return expr + " >= " + f(t.Min) + " and " + expr + " <= " + f(t.Max)
+		}
+		return expr + " < " + f(t.Min) + " or " + expr + " > " + f(t.Max)
+	}
+	return expr
+}
+
+// promAggregates are the PromQL functions aggregating a series over time,
+// by the flux function they translate.
+var promAggregates = map[string]string{
+	"mean":  "avg_over_time",
+	"min":   "min_over_time",
+	"max":   "max_over_time",
+	"sum":   "sum_over_time",
+	"count": "count_over_time",
+}
+
+// PromQL translates the flux query of a check to PromQL. It translates the
+// queries reading a field of a measurement, filtered by its tags and
+// optionally aggregated over time, such as:
+//
+//	from(bucket: "telegraf")
+//	  |> range(start: -5m)
+//	  |> filter(fn: (r) => r._measurement == "cpu" and r._field == "usage_user" and r.host == "a")
+//	  |> aggregateWindow(every: 1m, fn: mean)
+//
+// which is avg_over_time(cpu_usage_user{host="a"}[1m]), the name of the
+// metric of a field joining its measurement and its name like the
+// Prometheus output of telegraf.
+func PromQL(q influxdb.DashboardQuery) (string, error) {
+	pkg := parser.ParseSource(q.Text)
+	if ast.Check(pkg) > 0 {
+		return "", &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "check query is invalid",
+			Err:  ast.GetError(pkg),
+		}
+	}
+	if len(pkg.Files) != 1 || len(pkg.Files[0].Body) != 1 {
+		return "", untranslatable("the query must be a single expression")
+	}
+	stmt, ok := pkg.Files[0].Body[0].(*ast.ExpressionStatement)
+	if !ok {
+		return "", untranslatable("the query must be a single expression")
+	}
+
+	calls := pipeline(stmt.Expression)
+	if len(calls) == 0 {
+		return "", untranslatable("the query must start with from")
+	}
+	if _, ok := fromBucket(calls[0]); !ok {
+		return "", untranslatable("the query must start with from")
+	}
+
+	s := &promSelector{}
+	for _, call := range calls[1:] {
+		if err := s.apply(call); err != nil {
+			return "", err
+		}
+	}
+	return s.String()
+}
+
+// pipeline returns the calls of a chain of pipe expressions, in order.
+func pipeline(e ast.Expression) []*ast.CallExpression {
+	switch e := e.(type) {
+	case *ast.CallExpression:
+		return []*ast.CallExpression{e}
+	case *ast.PipeExpression:
+		calls := pipeline(e.Argument)
+		if calls == nil {
+			return nil
+		}
+		return append(calls, e.Call)
+	}
+	return nil
+}
+
+// promSelector is the PromQL expression built from the calls of a flux query.
+type promSelector struct {
+	measurement, field string
+	matchers           []string
+	// rng is the start of the range of the query.
+	rng time.Duration
+	// aggregate is the PromQL function aggregating the series over window.
+	aggregate string
+	window    time.Duration
+}
+
+func (s *promSelector) apply(call *ast.CallExpression) error {
+	callee, ok := call.Callee.(*ast.Identifier)
+	if !ok {
+		return untranslatable("the query calls an unknown function")
+	}
+	args := callArguments(call)
+	switch name := callee.Name; name {
+	case "range":
+		d, err := durationArgument(args["start"])
+		if err != nil {
+			return err
+		}
+		s.rng = -d
+	case "filter":
+		fn, ok := args["fn"].(*ast.FunctionExpression)
+		if !ok {
+			return untranslatable("filter must have a function")
+		}
+		body, ok := fn.Body.(ast.Expression)
+		if !ok {
+			return untranslatable("the function of filter must be an expression")
+		}
+		return s.filter(body)
+	case "aggregateWindow":
+		fn, ok := args["fn"].(*ast.Identifier)
+		if !ok || promAggregates[fn.Name] == "" {
+			return untranslatable("aggregateWindow must aggregate with mean, min, max, sum or count")
+		}
+		d, err := durationArgument(args["every"])
+		if err != nil {
+			return err
+		}
+		if err := s.aggregateOver(promAggregates[fn.Name], d); err != nil {
+			return err
+		}
+	case "mean", "min", "max", "sum", "count":
+		if s.rng <= 0 {
+			return untranslatable(fmt.Sprintf("%s requires a range", name))
+		}
+		if err := s.aggregateOver(promAggregates[name], s.rng); err != nil {
+			return err
+		}
+	case "yield":
+	default:
+		return untranslatable(fmt.Sprintf("%s has no PromQL equivalent", name))
+	}
+	return nil
+}
+
+func (s *promSelector) aggregateOver(fn string, window time.Duration) error {
+	if s.aggregate != "" {
+		return untranslatable("the query aggregates more than once")
+	}
+	s.aggregate, s.window = fn, window
+	return nil
+}
+
+// filter adds the matchers of the conjunction of the comparisons of e.
+func (s *promSelector) filter(e ast.Expression) error {
+	switch e := e.(type) {
+	case *ast.LogicalExpression:
+		if e.Operator != ast.AndOperator {
+			return untranslatable("filter can only combine its comparisons with and")
+		}
+		if err := s.filter(e.Left); err != nil {
+			return err
+		}
+		return s.filter(e.Right)
+	case *ast.BinaryExpression:
+		m, ok := e.Left.(*ast.MemberExpression)
+		if !ok {
+			return untranslatable("filter must compare the columns of the rows")
+		}
+		column := m.Property.Key()
+		switch v := e.Right.(type) {
+		case *ast.StringLiteral:
+			if e.Operator != ast.EqualOperator && e.Operator != ast.NotEqualOperator {
+				return untranslatable("filter must compare strings with == or !=")
+			}
+			switch column {
+			case measurementKey, "_field":
+				if e.Operator != ast.EqualOperator {
+					return untranslatable(fmt.Sprintf("filter must select a single %s", column))
+				}
+				if column == measurementKey {
+					s.measurement = v.Value
+				} else {
+					s.field = v.Value
+				}
+				return nil
+			}
+			s.matchers = append(s.matchers, fmt.Sprintf("%s%s%q", promName(column), e.Operator, v.Value))
+			return nil
+		case *ast.RegexpLiteral:
+			if column == measurementKey || column == "_field" {
+				return untranslatable(fmt.Sprintf("filter must select a single %s", column))
+			}
+			if e.Operator != ast.RegexpMatchOperator && e.Operator != ast.NotRegexpMatchOperator {
+				return untranslatable("filter must compare regular expressions with =~ or !~")
+			}
+			s.matchers = append(s.matchers, fmt.Sprintf("%s%s%q", promName(column), e.Operator, anchoredRegexp(v.Value)))
+			return nil
+		}
+	}
+	return untranslatable("filter must compare the columns of the rows to strings or regular expressions")
+}
+
+// String returns the PromQL expression of the selector.
+func (s *promSelector) String() (string, error) {
+	if s.measurement == "" || s.field == "" {
+		return "", untranslatable("filter must select a measurement and a field")
+	}
+	expr := promName(s.measurement + "_" + s.field)
+	if len(s.matchers) > 0 {
+		expr += "{" + strings.Join(s.matchers, ",") + "}"
+	}
+	if s.aggregate != "" {
+		expr = fmt.Sprintf("%s(%s[%s])", s.aggregate, expr, promDuration(s.window))
+	}
+	return expr, nil
+}
+
+// callArguments returns the named arguments of a call.
+func callArguments(call *ast.CallExpression) map[string]ast.Expression {
+	args := make(map[string]ast.Expression)
+	if len(call.Arguments) == 0 {
+		return args
+	}
+	obj, ok := call.Arguments[0].(*ast.ObjectExpression)
+	if !ok {
+		return args
+	}
+	for _, p := range obj.Properties {
+		args[p.Key.Key()] = p.Value
+	}
+	return args
+}
+
+// durationArgument returns the duration of a duration literal, negative if negated.
+func durationArgument(e ast.Expression) (time.Duration, error) {
+	sign := time.Duration(1)
+	if u, ok := e.(*ast.UnaryExpression); ok && u.Operator == ast.SubtractionOperator {
+		sign, e = -1, u.Argument
+	}
+	l, ok := e.(*ast.DurationLiteral)
+	if !ok {
+		return 0, untranslatable("the durations of the query must be literals")
+	}
+	d, err := ast.DurationFrom(l, time.Time{})
+	if err != nil {
+		return 0, untranslatable(err.Error())
+	}
+	return sign * d, nil
+}
+
+// anchoredRegexp returns a regular expression matching the same strings as
+// re once anchored, as PromQL anchors the regular expressions of its matchers.
+func anchoredRegexp(re *regexp.Regexp) string {
+	s := re.String()
+	if strings.HasPrefix(s, "^") && strings.HasSuffix(s, "$") {
+		return strings.TrimSuffix(strings.TrimPrefix(s, "^"), "$")
+	}
+	return ".*(?:" + s + ").*"
+}
+
+var promInvalidChars = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// promName returns name with the characters invalid in the names of the
+// metrics and labels of Prometheus replaced with underscores.
+func promName(name string) string {
+	name = promInvalidChars.ReplaceAllString(name, "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "_" + name
+	}
+	return name
+}
+
+// promDuration formats d with its largest unit dividing it, as PromQL
+// doesn't accept the durations with several units.
+func promDuration(d time.Duration) string {
+	units := []struct {
+		unit string
+		d    time.Duration
+	}{
+		{"w", 7 * 24 * time.Hour},
+		{"d", 24 * time.Hour},
+		{"h", time.Hour},
+		{"m", time.Minute},
+		{"s", time.Second},
+	}
+	for _, u := range units {
+		if d%u.d == 0 {
+			return strconv.FormatInt(int64(d/u.d), 10) + u.unit
+		}
+	}
+	return strconv.FormatInt(int64(d/time.Millisecond), 10) + "ms"
+}
+
+func untranslatable(reason string) error {
+	return &influxdb.Error{
+		Code: influxdb.EInvalid,
+		Msg:  "check query can't be translated to PromQL: " + reason,
+	}
+}
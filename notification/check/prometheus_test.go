@@ -0,0 +1,203 @@
+package check_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/check"
+)
+
+func TestPromQL(t *testing.T) {
+	cases := []struct {
+		name  string
+		query string
+		want  string
+		err   string
+	}{
+		{
+			name:  "field of a measurement",
+			query: `from(bucket: "telegraf") |> range(start: -1m) |> filter(fn: (r) => r._measurement == "cpu" and r._field == "usage_user")`,
+			want:  `cpu_usage_user`,
+		},
+		{
+			name:  "tag matchers",
+			query: `from(bucket: "telegraf") |> range(start: -1m) |> filter(fn: (r) => r._measurement == "cpu" and r._field == "usage_user" and r.host != "a" and r["cpu-id"] =~ /^cpu[0-3]$/ and r.region !~ /eu/)`,
+			want:  `cpu_usage_user{host!="a",cpu_id=~"cpu[0-3]",region!~".*(?:eu).*"}`,
+		},
+		{
+			name:  "aggregate window",
+			query: `from(bucket: "telegraf") |> range(start: -5m) |> filter(fn: (r) => r._measurement == "mem" and r._field == "used_percent") |> aggregateWindow(every: 1m, fn: max) |> yield()`,
+			want:  `max_over_time(mem_used_percent[1m])`,
+		},
+		{
+			name:  "aggregate over the range",
+			query: `from(bucket: "telegraf") |> range(start: -2h) |> filter(fn: (r) => r._measurement == "disk" and r._field == "free") |> mean()`,
+			want:  `avg_over_time(disk_free[2h])`,
+		},
+		{
+			name:  "no field",
+			query: `from(bucket: "telegraf") |> range(start: -1m) |> filter(fn: (r) => r._measurement == "cpu")`,
+			err:   "filter must select a measurement and a field",
+		},
+		{
+			name:  "disjunction",
+			query: `from(bucket: "telegraf") |> range(start: -1m) |> filter(fn: (r) => r._measurement == "cpu" or r._field == "usage_user")`,
+			err:   "filter can only combine its comparisons with and",
+		},
+		{
+			name:  "unknown function",
+			query: `from(bucket: "telegraf") |> range(start: -1m) |> filter(fn: (r) => r._measurement == "cpu" and r._field == "usage_user") |> map(fn: (r) => ({r with _value: r._value * 2.0}))`,
+			err:   "map has no PromQL equivalent",
+		},
+		{
+			name:  "several statements",
+			query: "a = 1\nfrom(bucket: \"telegraf\")",
+			err:   "the query must be a single expression",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := check.PromQL(influxdb.DashboardQuery{Text: c.query})
+			if c.err != "" {
+				if err == nil || !strings.Contains(err.Error(), c.err) {
+					t.Fatalf("expected error %q, got %v", c.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != c.want {
+				t.Errorf("expected %s, got %s", c.want, got)
+			}
+		})
+	}
+}
+
+func TestExportPrometheusRules(t *testing.T) {
+	query := influxdb.DashboardQuery{
+		Text: `from(bucket: "telegraf") |> range(start: -1m) |> filter(fn: (r) => r._measurement == "cpu" and r._field == "usage_user")`,
+	}
+	cpu := &check.Threshold{
+		Base: check.Base{
+			ID:          influxdb.ID(1),
+			Name:        "cpu usage",
+			Description: "cpu is busy",
+			Every:       influxdb.Duration{Duration: time.Minute},
+			Query:       query,
+			Tags:        []notification.Tag{{Key: "team", Value: "ops"}},
+		},
+		Thresholds: []check.ThresholdConfig{
+			&check.Greater{ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Critical}, Value: 90},
+			&check.Range{ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Warn}, Min: 70, Max: 90, Within: true},
+			&check.Lesser{ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Ok}, Value: 70},
+		},
+	}
+	nightly := &check.Threshold{
+		Base: check.Base{
+			ID:    influxdb.ID(2),
+			Name:  "nightly",
+			Cron:  "0 0 * * *",
+			Query: query,
+		},
+		Thresholds: []check.ThresholdConfig{
+			&check.Range{ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Info}, Min: 1, Max: 2},
+		},
+	}
+	allValues := &check.Threshold{
+		Base: check.Base{
+			ID:    influxdb.ID(3),
+			Name:  "all values",
+			Every: influxdb.Duration{Duration: time.Minute},
+			Query: query,
+		},
+		Thresholds: []check.ThresholdConfig{
+			&check.Greater{ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Critical, AllValues: true}, Value: 90},
+		},
+	}
+	deadman := &check.Deadman{
+		Base: check.Base{
+			ID:    influxdb.ID(4),
+			Name:  "heartbeat",
+			Every: influxdb.Duration{Duration: time.Minute},
+			Query: query,
+		},
+		TimeSince: 60,
+	}
+
+	got := check.ExportPrometheusRules([]influxdb.Check{cpu, nightly, allValues, deadman})
+	cpuAnnotations := map[string]string{"influxdb_check_id": "0000000000000001", "description": "cpu is busy"}
+	want := []check.PrometheusRuleGroup{
+		{
+			Name: "influxdb",
+			Rules: []check.PrometheusRule{
+				{
+					Alert:       "nightly",
+					Expr:        "cpu_usage_user < 1 or cpu_usage_user > 2",
+					Labels:      map[string]string{"severity": "info"},
+					Annotations: map[string]string{"influxdb_check_id": "0000000000000002"},
+				},
+			},
+		},
+		{
+			Name:     "influxdb_1m",
+			Interval: "1m",
+			Rules: []check.PrometheusRule{
+				{
+					Alert:       "cpu_usage",
+					Expr:        "cpu_usage_user > 90",
+					Labels:      map[string]string{"severity": "crit", "team": "ops"},
+					Annotations: cpuAnnotations,
+				},
+				{
+					Alert:       "cpu_usage",
+					Expr:        "cpu_usage_user >= 70 and cpu_usage_user <= 90",
+					Labels:      map[string]string{"severity": "warn", "team": "ops"},
+					Annotations: cpuAnnotations,
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(got.Groups, want) {
+		t.Errorf("unexpected groups\nwant %+v\ngot  %+v", want, got.Groups)
+	}
+	if len(got.Skipped) != 2 || got.Skipped[0].ID != allValues.ID || got.Skipped[1].ID != deadman.ID {
+		t.Fatalf("expected the all values and deadman checks to be skipped, got %+v", got.Skipped)
+	}
+	if got.Skipped[1].Reason != "deadman checks can't be expressed as Prometheus rules" {
+		t.Errorf("unexpected reason %q", got.Skipped[1].Reason)
+	}
+}
+
+func TestThreshold_PrometheusRulesOfPrometheusQuery(t *testing.T) {
+	c := &check.Threshold{
+		Base: check.Base{
+			ID:        influxdb.ID(1),
+			Name:      "errors",
+			Every:     influxdb.Duration{Duration: time.Minute},
+			QueryType: check.QueryTypePrometheus,
+			Query:     influxdb.DashboardQuery{Text: `http_requests_total{code="500"}`},
+			ScrapeURL: "http://localhost:9100/metrics",
+		},
+		Thresholds: []check.ThresholdConfig{
+			&check.Greater{ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Critical}, Value: 10},
+		},
+	}
+	rules, err := c.PrometheusRules()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rules) != 1 || rules[0].Expr != `http_requests_total{code="500"} > 10` {
+		t.Errorf("unexpected rules %+v", rules)
+	}
+
+	c.QueryType = check.QueryTypeInfluxQL
+	c.ScrapeURL = ""
+	if _, err := c.PrometheusRules(); err == nil || !strings.Contains(err.Error(), "influxql queries can't be translated to PromQL") {
+		t.Errorf("unexpected error %v", err)
+	}
+}
@@ -0,0 +1,56 @@
+package check
+
+import (
+	"github.com/influxdata/influxdb"
+)
+
+// Redact returns a copy of a check without its query logic nor the fields
+// adjacent to its secrets, for the users allowed to read the check but not
+// to write it: the text and builder config of its query, the text of its
+// stages, its scrape url, which may hold credentials, and the authorization
+// its task runs with. The name of the query and of the stages are kept.
+func Redact(c influxdb.Check) influxdb.Check {
+	switch c := c.(type) {
+	case *Deadman:
+		cp := *c
+		cp.Base.redact()
+		return &cp
+	case *Threshold:
+		cp := *c
+		cp.Base.redact()
+		return &cp
+	case *SLO:
+		cp := *c
+		cp.Base.redact()
+		return &cp
+	case *External:
+		cp := *c
+		cp.Base.redact()
+		return &cp
+	case *Heartbeat:
+		cp := *c
+		cp.Base.redact()
+		return &cp
+	case *Lag:
+		cp := *c
+		cp.Base.redact()
+		return &cp
+	}
+	return c
+}
+
+func (b *Base) redact() {
+	b.Query = influxdb.DashboardQuery{
+		Name:     b.Query.Name,
+		EditMode: b.Query.EditMode,
+	}
+	if len(b.Stages) > 0 {
+		stages := make([]QueryStage, len(b.Stages))
+		for i, s := range b.Stages {
+			stages[i] = QueryStage{Name: s.Name}
+		}
+		b.Stages = stages
+	}
+	b.ScrapeURL = ""
+	b.AuthorizationID = 0
+}
@@ -0,0 +1,157 @@
+package check
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/parser"
+	"github.com/influxdata/influxdb"
+)
+
+// Sandbox is what the flux of the query of a check may do besides reading
+// data, so a check can't become an arbitrary egress vector.
+type Sandbox struct {
+	// StatusBucket is the only bucket the flux may write to with to().
+	StatusBucket string
+	// AllowHTTPPost allows the flux to call http.post.
+	AllowHTTPPost bool
+	// Org and OrgID are the organization of the check, which to() may name
+	// as the organization of the status bucket.
+	Org   string
+	OrgID influxdb.ID
+}
+
+// toDestinations are the arguments of to() writing outside the buckets of
+// the organization of the check.
+var toDestinations = map[string]bool{
+	"bucketID": true,
+	"org":      true,
+	"orgID":    true,
+	"host":     true,
+	"token":    true,
+}
+
+// Validate returns a forbidden error if the flux of a query writes with to()
+// to another bucket than the status bucket, refers to to() without calling
+// it, or calls http.post without being allowed to. The flux which doesn't
+// parse is left to the validation of the query, as it never runs.
+func (s Sandbox) Validate(q influxdb.DashboardQuery) error {
+	pkg := parser.ParseSource(q.Text)
+	if ast.Check(pkg) > 0 {
+		return nil
+	}
+
+	// the names the packages are imported as, and the nodes which name a
+	// property rather than refer to a value.
+	imports := make(map[string]string)
+	keys := make(map[ast.Node]bool)
+	callees := make(map[ast.Node]bool)
+	for _, f := range pkg.Files {
+		for _, imp := range f.Imports {
+			name := path.Base(imp.Path.Value)
+			if imp.As != nil {
+				name = imp.As.Name
+				keys[imp.As] = true
+			}
+			imports[name] = imp.Path.Value
+		}
+	}
+
+	var err error
+	ast.Walk(ast.CreateVisitor(func(n ast.Node) {
+		if err != nil {
+			return
+		}
+		switch n := n.(type) {
+		case *ast.Property:
+			keys[n.Key] = true
+		case *ast.CallExpression:
+			callees[n.Callee] = true
+			if isTo(n.Callee, imports) {
+				err = s.validTo(n)
+			}
+		case *ast.MemberExpression:
+			keys[n.Property] = true
+			obj, ok := n.Object.(*ast.Identifier)
+			if !ok || imports[obj.Name] == "" {
+				return
+			}
+			if imports[obj.Name] == "http" && n.Property.Key() == "post" && !s.AllowHTTPPost {
+				err = &influxdb.Error{
+					Code: influxdb.EForbidden,
+					Msg:  "check query can't call http.post, it isn't allowed in the organization",
+				}
+			}
+			if n.Property.Key() == "to" && !callees[n] {
+				err = errToNotCalled
+			}
+		case *ast.Identifier:
+			if n.Name == "to" && !keys[n] && !callees[n] {
+				err = errToNotCalled
+			}
+		}
+	}), pkg)
+	return err
+}
+
+var errToNotCalled = &influxdb.Error{
+	Code: influxdb.EForbidden,
+	Msg:  "check query can only call to() directly",
+}
+
+// isTo returns whether a callee is to(), or the to() of a package.
+func isTo(callee ast.Expression, imports map[string]string) bool {
+	switch c := callee.(type) {
+	case *ast.Identifier:
+		return c.Name == "to"
+	case *ast.MemberExpression:
+		obj, ok := c.Object.(*ast.Identifier)
+		return ok && imports[obj.Name] != "" && c.Property.Key() == "to"
+	}
+	return false
+}
+
+// isOwnOrg returns whether an argument of to() names the organization of
+// the check, as a string.
+func (s Sandbox) isOwnOrg(p *ast.Property) bool {
+	v, ok := p.Value.(*ast.StringLiteral)
+	if !ok {
+		return false
+	}
+	switch p.Key.Key() {
+	case "org":
+		return s.Org != "" && v.Value == s.Org
+	case "orgID":
+		return s.OrgID.Valid() && v.Value == s.OrgID.String()
+	}
+	return false
+}
+
+// validTo returns a forbidden error unless a call of to() writes to the
+// status bucket, by name, in the organization of the check if it names one.
+func (s Sandbox) validTo(call *ast.CallExpression) error {
+	var bucket string
+	if len(call.Arguments) == 1 {
+		if args, ok := call.Arguments[0].(*ast.ObjectExpression); ok {
+			for _, p := range args.Properties {
+				if toDestinations[p.Key.Key()] && !s.isOwnOrg(p) {
+					return &influxdb.Error{
+						Code: influxdb.EForbidden,
+						Msg:  fmt.Sprintf("check query can't call to() with %s, it only writes to the status bucket %s", p.Key.Key(), s.StatusBucket),
+					}
+				}
+				if v, ok := p.Value.(*ast.StringLiteral); ok && p.Key.Key() == "bucket" {
+					bucket = v.Value
+				}
+			}
+		}
+	}
+	if bucket != s.StatusBucket {
+		return &influxdb.Error{
+			Code: influxdb.EForbidden,
+			Msg:  fmt.Sprintf("check query can only call to() with the status bucket %s as a string", s.StatusBucket),
+		}
+	}
+	return nil
+}
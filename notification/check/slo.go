@@ -0,0 +1,324 @@
+package check
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification"
+)
+
+var _ influxdb.Check = &SLO{}
+
+// SLOIndicator is the measure of the events of a service level objective.
+type SLOIndicator string
+
+// consts of the slo indicators.
+const (
+	// ErrorRatioIndicator measures the ratio of bad events to all events.
+	ErrorRatioIndicator SLOIndicator = "errorRatio"
+	// LatencyIndicator measures the ratio of events slower than a threshold.
+	LatencyIndicator SLOIndicator = "latency"
+)
+
+// SLO is the check which tracks a service level objective over a rolling
+// window, and writes a status when the error budget burns too fast over both
+// the long and the short window of a burn rate alert.
+//
+// The query of an SLO check selects the events without a range,
+// the windows are applied by the generated flux.
+type SLO struct {
+	Base
+	// Objective is the ratio of good events to reach, such as 0.999.
+	Objective float64 `json:"objective"`
+	// Window is the rolling window of the objective, such as 30 days.
+	Window    influxdb.Duration `json:"window"`
+	Indicator SLOIndicator      `json:"indicator"`
+	// ErrorField and TotalField are the fields counting the bad events and
+	// all the events of an error ratio indicator.
+	ErrorField string `json:"errorField,omitempty"`
+	TotalField string `json:"totalField,omitempty"`
+	// LatencyThreshold is the value above which an event of a latency indicator is bad.
+	LatencyThreshold float64 `json:"latencyThreshold,omitempty"`
+	// BurnRateAlerts are the alerts of the check,
+	// the DefaultBurnRateAlerts of the window if empty.
+	BurnRateAlerts []BurnRateAlert `json:"burnRateAlerts"`
+}
+
+// BurnRateAlert writes a status of Level when the error budget burns
+// faster than BurnRate over both LongWindow and ShortWindow.
+type BurnRateAlert struct {
+	Level       notification.CheckLevel `json:"level"`
+	LongWindow  influxdb.Duration       `json:"longWindow"`
+	ShortWindow influxdb.Duration       `json:"shortWindow"`
+	BurnRate    float64                 `json:"burnRate"`
+}
+
+// Valid returns where the burn rate alert is valid for the window of an slo.
+func (a BurnRateAlert) Valid(window time.Duration) error {
+	if a.ShortWindow.Duration <= 0 || a.ShortWindow.Duration >= a.LongWindow.Duration {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "burn rate alert short window must be larger than 0 and shorter than the long window",
+		}
+	}
+	if a.LongWindow.Duration > window {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "burn rate alert long window can't be longer than the slo window",
+		}
+	}
+	if a.BurnRate <= 0 {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "burn rate alert burn rate must be larger than 0",
+		}
+	}
+	return nil
+}
+
+// DefaultBurnRateAlerts returns the multiwindow, multi-burn-rate alerts
+// recommended by the Google SRE workbook, which fire when 2% of the error
+// budget of the window is spent in 1 hour or 5% in 6 hours, and warn when
+// 10% is spent in 3 days. Alerts longer than the window are left out.
+func DefaultBurnRateAlerts(window time.Duration) []BurnRateAlert {
+	defaults := []struct {
+		level       notification.CheckLevel
+		budget      float64
+		longWindow  time.Duration
+		shortWindow time.Duration
+	}{
+		{level: notification.Critical, budget: 0.02, longWindow: time.Hour, shortWindow: 5 * time.Minute},
+		{level: notification.Critical, budget: 0.05, longWindow: 6 * time.Hour, shortWindow: 30 * time.Minute},
+		{level: notification.Warn, budget: 0.1, longWindow: 72 * time.Hour, shortWindow: 6 * time.Hour},
+	}
+	alerts := make([]BurnRateAlert, 0, len(defaults))
+	for _, d := range defaults {
+		if d.longWindow > window {
+			continue
+		}
+		alerts = append(alerts, BurnRateAlert{
+			Level:       d.level,
+			LongWindow:  influxdb.Duration{Duration: d.longWindow},
+			ShortWindow: influxdb.Duration{Duration: d.shortWindow},
+			BurnRate:    d.budget * float64(window) / float64(d.longWindow),
+		})
+	}
+	return alerts
+}
+
+type sloAlias SLO
+
+// MarshalJSON implement json.Marshaler interface.
+func (c SLO) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		struct {
+			sloAlias
+			Type string `json:"type"`
+		}{
+			sloAlias: sloAlias(c),
+			Type:     c.Type(),
+		})
+}
+
+// Valid returns where the config is valid.
+func (c SLO) Valid() error {
+	if err := c.Base.valid(); err != nil {
+		return err
+	}
+	if c.GetQueryType() != QueryTypeFlux {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "slo check requires a flux query",
+		}
+	}
+	if len(c.Stages) > 0 {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "slo check doesn't support query stages",
+		}
+	}
+	if c.Objective <= 0 || c.Objective >= 1 {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "slo check objective must be between 0 and 1",
+		}
+	}
+	if c.Window.Duration <= 0 {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "slo check window must be larger than 0",
+		}
+	}
+	switch c.Indicator {
+	case ErrorRatioIndicator:
+		if c.ErrorField == "" || c.TotalField == "" {
+			return &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "slo check error ratio indicator requires errorField and totalField",
+			}
+		}
+	case LatencyIndicator:
+	default:
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("invalid slo check indicator %s", c.Indicator),
+		}
+	}
+	if _, ok := alignedCron(c.Every.Duration); c.AlignToInterval && !ok {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "slo check can only align to an interval dividing a minute, an hour or a day",
+		}
+	}
+	alerts := c.GetBurnRateAlerts()
+	if len(alerts) == 0 {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "slo check requires at least one burn rate alert",
+		}
+	}
+	for _, a := range alerts {
+		if err := a.Valid(c.Window.Duration); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Type returns the type of the check.
+func (c SLO) Type() string {
+	return "slo"
+}
+
+// GetBurnRateAlerts returns the burn rate alerts of the check,
+// the DefaultBurnRateAlerts of its window if it has none.
+func (c SLO) GetBurnRateAlerts() []BurnRateAlert {
+	if len(c.BurnRateAlerts) > 0 {
+		return c.BurnRateAlerts
+	}
+	return DefaultBurnRateAlerts(c.Window.Duration)
+}
+
+// GenerateFlux returns the flux script of the check. It yields the statuses of
+// the burn rate alerts whose windows both burn too fast as "statuses", and the
+// ratio of the error budget of the window left as "budget". The statuses are
+// tagged with the tags of the check and the label tags of labels.
+func (c SLO) GenerateFlux(labels []*influxdb.Label) (string, error) {
+	if err := c.Valid(); err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	sb.WriteString(c.fluxTaskOption())
+
+	fmt.Fprintf(&sb, "data = (start) => %s\n\t|> range(start: start)\n\n", strings.TrimSpace(c.Query.Text))
+
+	switch c.Indicator {
+	case ErrorRatioIndicator:
+		fmt.Fprintf(&sb, `errorRatio = (start) => data(start: start)
+	|> filter(fn: (r) => r._field == %[1]s or r._field == %[2]s)
+	|> group(columns: ["_start", "_field"])
+	|> sum()
+	|> group(columns: ["_start"])
+	|> pivot(rowKey: ["_start"], columnKey: ["_field"], valueColumn: "_value")
+	|> map(fn: (r) => ({_value: if float(v: r[%[2]s]) > 0.0 then float(v: r[%[1]s]) / float(v: r[%[2]s]) else 0.0}))
+
+`, strconv.Quote(c.ErrorField), strconv.Quote(c.TotalField))
+	case LatencyIndicator:
+		fmt.Fprintf(&sb, `errorRatio = (start) => data(start: start)
+	|> group()
+	|> map(fn: (r) => ({_value: if float(v: r._value) > %s then 1.0 else 0.0}))
+	|> mean()
+
+`, fluxFloat(c.LatencyThreshold))
+	}
+
+	budget := fmt.Sprintf("(1.0 - %s)", fluxFloat(c.Objective))
+	checkID := strconv.Quote(c.ID.String())
+	fmt.Fprintf(&sb, `burnRate = (start) => errorRatio(start: start)
+	|> map(fn: (r) => ({_check_id: %s, _value: r._value / %s}))
+
+`, checkID, budget)
+
+	tags := c.fluxStatusTags(labels)
+	alerts := c.GetBurnRateAlerts()
+	names := make([]string, 0, len(alerts))
+	for i, a := range alerts {
+		name := fmt.Sprintf("alert%d", i)
+		names = append(names, name)
+		fmt.Fprintf(&sb, `%s = join(tables: {long: burnRate(start: -%s), short: burnRate(start: -%s)}, on: ["_check_id"])
+	|> filter(fn: (r) => r._value_long > %[4]s and r._value_short > %[4]s)
+	|> map(fn: (r) => ({_check_id: r._check_id, _check_name: %s, _level: %s, _value: r._value_long, _time: now()%s}))
+
+`, name, fluxDuration(a.LongWindow.Duration), fluxDuration(a.ShortWindow.Duration), fluxFloat(a.BurnRate),
+			strconv.Quote(c.Name), strconv.Quote(strings.ToLower(a.Level.String())), tags)
+	}
+
+	if len(names) == 1 {
+		fmt.Fprintf(&sb, "%s\n", names[0])
+	} else {
+		fmt.Fprintf(&sb, "union(tables: [%s])\n", strings.Join(names, ", "))
+	}
+	sb.WriteString("\t|> yield(name: \"statuses\")\n\n")
+
+	fmt.Fprintf(&sb, `errorRatio(start: -%s)
+	|> map(fn: (r) => ({_check_id: %s, _value: 1.0 - r._value / %s}))
+	|> yield(name: "budget")
+`, fluxDuration(c.Window.Duration), checkID, budget)
+
+	return sb.String(), nil
+}
+
+// alignedCron returns the cron expression running a task at the boundaries
+// of every in UTC, if every divides a minute, an hour or a day in whole units.
+// The task runs at its scheduled boundary, while a task run every interval
+// runs relative to the time it was created.
+func alignedCron(every time.Duration) (string, bool) {
+	switch {
+	case every <= 0:
+		return "", false
+	case every == 24*time.Hour:
+		return "TZ=UTC 0 0 0 * * *", true
+	case every%time.Hour == 0 && (24*time.Hour)%every == 0:
+		return fmt.Sprintf("TZ=UTC 0 0 */%d * * *", every/time.Hour), true
+	case every%time.Minute == 0 && time.Hour%every == 0:
+		return fmt.Sprintf("TZ=UTC 0 */%d * * * *", every/time.Minute), true
+	case every%time.Second == 0 && time.Minute%every == 0:
+		return fmt.Sprintf("TZ=UTC */%d * * * * *", every/time.Second), true
+	}
+	return "", false
+}
+
+// fluxDuration returns the flux duration literal of d in its largest whole unit.
+func fluxDuration(d time.Duration) string {
+	units := []struct {
+		unit string
+		d    time.Duration
+	}{
+		{unit: "d", d: 24 * time.Hour},
+		{unit: "h", d: time.Hour},
+		{unit: "m", d: time.Minute},
+		{unit: "s", d: time.Second},
+		{unit: "ms", d: time.Millisecond},
+		{unit: "us", d: time.Microsecond},
+	}
+	for _, u := range units {
+		if d%u.d == 0 {
+			return fmt.Sprintf("%d%s", d/u.d, u.unit)
+		}
+	}
+	return fmt.Sprintf("%dns", d)
+}
+
+// fluxFloat returns the flux float literal of f.
+func fluxFloat(f float64) string {
+	s := strconv.FormatFloat(f, 'f', -1, 64)
+	if !strings.Contains(s, ".") {
+		s += ".0"
+	}
+	return s
+}
@@ -0,0 +1,252 @@
+package check_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/check"
+	influxTesting "github.com/influxdata/influxdb/testing"
+)
+
+func TestDefaultBurnRateAlerts(t *testing.T) {
+	cases := []struct {
+		name   string
+		window time.Duration
+		want   []check.BurnRateAlert
+	}{
+		{
+			name:   "30 days",
+			window: 30 * 24 * time.Hour,
+			want: []check.BurnRateAlert{
+				{
+					Level:       notification.Critical,
+					LongWindow:  influxdb.Duration{Duration: time.Hour},
+					ShortWindow: influxdb.Duration{Duration: 5 * time.Minute},
+					BurnRate:    14.4,
+				},
+				{
+					Level:       notification.Critical,
+					LongWindow:  influxdb.Duration{Duration: 6 * time.Hour},
+					ShortWindow: influxdb.Duration{Duration: 30 * time.Minute},
+					BurnRate:    6,
+				},
+				{
+					Level:       notification.Warn,
+					LongWindow:  influxdb.Duration{Duration: 72 * time.Hour},
+					ShortWindow: influxdb.Duration{Duration: 6 * time.Hour},
+					BurnRate:    1,
+				},
+			},
+		},
+		{
+			name:   "alerts longer than the window are left out",
+			window: 2 * time.Hour,
+			want: []check.BurnRateAlert{
+				{
+					Level:       notification.Critical,
+					LongWindow:  influxdb.Duration{Duration: time.Hour},
+					ShortWindow: influxdb.Duration{Duration: 5 * time.Minute},
+					BurnRate:    0.04,
+				},
+			},
+		},
+	}
+	for _, c := range cases {
+		got := check.DefaultBurnRateAlerts(c.window)
+		if diff := cmp.Diff(got, c.want); diff != "" {
+			t.Errorf("failed %s, alerts are different -got/+want\ndiff %s", c.name, diff)
+		}
+	}
+}
+
+func TestSLO_GenerateFlux(t *testing.T) {
+	base := goodBase
+	base.Name = "api availability"
+	base.Query.Text = `from(bucket: "telegraf") |> filter(fn: (r) => r._measurement == "http")`
+	taggedBase := base
+	taggedBase.Tags = []notification.Tag{{Key: "k1", Value: "v1"}}
+	taggedBase.Priority = influxdb.CheckPriorityCritical
+
+	cases := []struct {
+		name   string
+		src    check.SLO
+		labels []*influxdb.Label
+		want   string
+		err    error
+	}{
+		{
+			name: "error ratio with default alerts",
+			src: check.SLO{
+				Base:       base,
+				Objective:  0.999,
+				Window:     influxdb.Duration{Duration: 30 * 24 * time.Hour},
+				Indicator:  check.ErrorRatioIndicator,
+				ErrorField: "errors",
+				TotalField: "requests",
+			},
+			want: `option task = {name: "api availability", every: 1m}
+
+data = (start) => from(bucket: "telegraf") |> filter(fn: (r) => r._measurement == "http")
+	|> range(start: start)
+
+errorRatio = (start) => data(start: start)
+	|> filter(fn: (r) => r._field == "errors" or r._field == "requests")
+	|> group(columns: ["_start", "_field"])
+	|> sum()
+	|> group(columns: ["_start"])
+	|> pivot(rowKey: ["_start"], columnKey: ["_field"], valueColumn: "_value")
+	|> map(fn: (r) => ({_value: if float(v: r["requests"]) > 0.0 then float(v: r["errors"]) / float(v: r["requests"]) else 0.0}))
+
+burnRate = (start) => errorRatio(start: start)
+	|> map(fn: (r) => ({_check_id: "020f755c3c082000", _value: r._value / (1.0 - 0.999)}))
+
+alert0 = join(tables: {long: burnRate(start: -1h), short: burnRate(start: -5m)}, on: ["_check_id"])
+	|> filter(fn: (r) => r._value_long > 14.4 and r._value_short > 14.4)
+	|> map(fn: (r) => ({_check_id: r._check_id, _check_name: "api availability", _level: "crit", _value: r._value_long, _time: now()}))
+
+alert1 = join(tables: {long: burnRate(start: -6h), short: burnRate(start: -30m)}, on: ["_check_id"])
+	|> filter(fn: (r) => r._value_long > 6.0 and r._value_short > 6.0)
+	|> map(fn: (r) => ({_check_id: r._check_id, _check_name: "api availability", _level: "crit", _value: r._value_long, _time: now()}))
+
+alert2 = join(tables: {long: burnRate(start: -3d), short: burnRate(start: -6h)}, on: ["_check_id"])
+	|> filter(fn: (r) => r._value_long > 1.0 and r._value_short > 1.0)
+	|> map(fn: (r) => ({_check_id: r._check_id, _check_name: "api availability", _level: "warn", _value: r._value_long, _time: now()}))
+
+union(tables: [alert0, alert1, alert2])
+	|> yield(name: "statuses")
+
+errorRatio(start: -30d)
+	|> map(fn: (r) => ({_check_id: "020f755c3c082000", _value: 1.0 - r._value / (1.0 - 0.999)}))
+	|> yield(name: "budget")
+`,
+		},
+		{
+			name: "latency with a single alert",
+			src: check.SLO{
+				Base:             taggedBase,
+				Objective:        0.99,
+				Window:           influxdb.Duration{Duration: 7 * 24 * time.Hour},
+				Indicator:        check.LatencyIndicator,
+				LatencyThreshold: 300,
+				BurnRateAlerts: []check.BurnRateAlert{
+					{
+						Level:       notification.Warn,
+						LongWindow:  influxdb.Duration{Duration: 2 * time.Hour},
+						ShortWindow: influxdb.Duration{Duration: 10 * time.Minute},
+						BurnRate:    2.5,
+					},
+				},
+			},
+			labels: []*influxdb.Label{{Name: "prod"}},
+			want: `option task = {name: "api availability", every: 1m, priority: "critical"}
+
+data = (start) => from(bucket: "telegraf") |> filter(fn: (r) => r._measurement == "http")
+	|> range(start: start)
+
+errorRatio = (start) => data(start: start)
+	|> group()
+	|> map(fn: (r) => ({_value: if float(v: r._value) > 300.0 then 1.0 else 0.0}))
+	|> mean()
+
+burnRate = (start) => errorRatio(start: start)
+	|> map(fn: (r) => ({_check_id: "020f755c3c082000", _value: r._value / (1.0 - 0.99)}))
+
+alert0 = join(tables: {long: burnRate(start: -2h), short: burnRate(start: -10m)}, on: ["_check_id"])
+	|> filter(fn: (r) => r._value_long > 2.5 and r._value_short > 2.5)
+	|> map(fn: (r) => ({_check_id: r._check_id, _check_name: "api availability", _level: "warn", _value: r._value_long, _time: now(), "k1": "v1", "label_prod": "true"}))
+
+alert0
+	|> yield(name: "statuses")
+
+errorRatio(start: -7d)
+	|> map(fn: (r) => ({_check_id: "020f755c3c082000", _value: 1.0 - r._value / (1.0 - 0.99)}))
+	|> yield(name: "budget")
+`,
+		},
+		{
+			name: "invalid check",
+			src: check.SLO{
+				Base:      base,
+				Objective: 0.999,
+				Window:    influxdb.Duration{Duration: 30 * 24 * time.Hour},
+				Indicator: check.ErrorRatioIndicator,
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "slo check error ratio indicator requires errorField and totalField",
+			},
+		},
+	}
+	for _, c := range cases {
+		got, err := c.src.GenerateFlux(c.labels)
+		influxTesting.ErrorsEqual(t, err, c.err)
+		if diff := cmp.Diff(got, c.want); diff != "" {
+			t.Errorf("failed %s, flux is different -got/+want\ndiff %s", c.name, diff)
+		}
+	}
+}
+
+func TestSLO_GenerateFluxAligned(t *testing.T) {
+	cases := []struct {
+		name  string
+		every time.Duration
+		want  string
+		err   error
+	}{
+		{
+			name:  "seconds",
+			every: 15 * time.Second,
+			want:  `option task = {name: "name1", cron: "TZ=UTC */15 * * * * *"}`,
+		},
+		{
+			name:  "minutes",
+			every: 5 * time.Minute,
+			want:  `option task = {name: "name1", cron: "TZ=UTC 0 */5 * * * *"}`,
+		},
+		{
+			name:  "hours",
+			every: 6 * time.Hour,
+			want:  `option task = {name: "name1", cron: "TZ=UTC 0 0 */6 * * *"}`,
+		},
+		{
+			name:  "day",
+			every: 24 * time.Hour,
+			want:  `option task = {name: "name1", cron: "TZ=UTC 0 0 0 * * *"}`,
+		},
+		{
+			name:  "interval not dividing an hour",
+			every: 7 * time.Minute,
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "slo check can only align to an interval dividing a minute, an hour or a day",
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			base := goodBase
+			base.Every = influxdb.Duration{Duration: c.every}
+			base.AlignToInterval = true
+			slo := check.SLO{
+				Base:       base,
+				Objective:  0.999,
+				Window:     influxdb.Duration{Duration: 30 * 24 * time.Hour},
+				Indicator:  check.ErrorRatioIndicator,
+				ErrorField: "errors",
+				TotalField: "requests",
+			}
+			got, err := slo.GenerateFlux(nil)
+			influxTesting.ErrorsEqual(t, err, c.err)
+			if err != nil {
+				return
+			}
+			if line := strings.SplitN(got, "\n", 2)[0]; line != c.want {
+				t.Errorf("got task option %s, want %s", line, c.want)
+			}
+		})
+	}
+}
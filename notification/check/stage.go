@@ -0,0 +1,112 @@
+package check
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/influxdata/influxdb"
+)
+
+// QueryStage is a named stage of the query of a check, such as fetching the
+// data, normalizing it and comparing it to a baseline bucket. The stages are
+// composed into a single flux script binding each stage to its name, so a
+// stage refers to the data of the previous stages by their name, and the
+// data of the check is the data of its last stage.
+type QueryStage struct {
+	Name string `json:"name"`
+	// Text is the flux expression of the stage.
+	Text string `json:"text"`
+}
+
+var stageNamePattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// fluxKeywords can't name a stage.
+var fluxKeywords = map[string]bool{
+	"and": true, "or": true, "not": true, "empty": true, "in": true,
+	"import": true, "package": true, "return": true, "option": true,
+	"builtin": true, "test": true, "if": true, "then": true, "else": true,
+	"exists": true,
+}
+
+func (b Base) validStages() error {
+	if b.Query.Text != "" {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "Check query and stages are exclusive",
+		}
+	}
+	if b.GetQueryType() != QueryTypeFlux {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "Check query stages require a flux query",
+		}
+	}
+	names := make(map[string]bool, len(b.Stages))
+	for _, s := range b.Stages {
+		if !stageNamePattern.MatchString(s.Name) || fluxKeywords[s.Name] {
+			return &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  fmt.Sprintf("invalid query stage name %q, a stage is named by a flux identifier", s.Name),
+			}
+		}
+		if names[s.Name] {
+			return &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  fmt.Sprintf("duplicate query stage %s", s.Name),
+			}
+		}
+		names[s.Name] = true
+		if strings.TrimSpace(s.Text) == "" {
+			return &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  fmt.Sprintf("query stage %s can't be empty", s.Name),
+			}
+		}
+	}
+	return nil
+}
+
+// StageQuery returns the query of the check up to a stage, which yields the
+// data of the stage named after it, so each stage can be previewed. The
+// whole query of the check is returned if the stage is empty.
+func (b *Base) StageQuery(stage string) (influxdb.DashboardQuery, error) {
+	if len(b.Stages) > 0 {
+		if err := b.validStages(); err != nil {
+			return influxdb.DashboardQuery{}, err
+		}
+	}
+	if stage == "" {
+		return b.GetQuery(), nil
+	}
+	if len(b.Stages) == 0 {
+		return influxdb.DashboardQuery{}, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "check query has no stages",
+		}
+	}
+	for i, s := range b.Stages {
+		if s.Name == stage {
+			q := b.Query
+			q.Text = composeStages(b.Stages[:i+1])
+			return q, nil
+		}
+	}
+	return influxdb.DashboardQuery{}, &influxdb.Error{
+		Code: influxdb.ENotFound,
+		Msg:  fmt.Sprintf("query stage %s not found", stage),
+	}
+}
+
+// composeStages returns the flux script binding each stage to its name and
+// yielding the data of the last stage.
+func composeStages(stages []QueryStage) string {
+	var sb strings.Builder
+	for _, s := range stages {
+		fmt.Fprintf(&sb, "%s = %s\n\n", s.Name, strings.TrimSpace(s.Text))
+	}
+	last := stages[len(stages)-1].Name
+	fmt.Fprintf(&sb, "%s\n\t|> yield(name: %s)\n", last, strconv.Quote(last))
+	return sb.String()
+}
@@ -0,0 +1,68 @@
+package check_test
+
+import (
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification/check"
+)
+
+func TestBase_StageQuery(t *testing.T) {
+	b := check.Base{
+		Stages: []check.QueryStage{
+			{Name: "fetch", Text: `from(bucket: "telegraf") |> range(start: -1h) |> filter(fn: (r) => r._measurement == "http" and r._field == "latency")`},
+			{Name: "normalize", Text: "fetch |> aggregateWindow(every: 5m, fn: mean)"},
+			{Name: "compare", Text: `
+join(tables: {now: normalize, baseline: from(bucket: "baseline") |> range(start: -1h)}, on: ["_time"])
+	|> map(fn: (r) => ({r with _value: r._value_now / r._value_baseline}))
+`},
+		},
+	}
+
+	want := `fetch = from(bucket: "telegraf") |> range(start: -1h) |> filter(fn: (r) => r._measurement == "http" and r._field == "latency")
+
+normalize = fetch |> aggregateWindow(every: 5m, fn: mean)
+
+compare = join(tables: {now: normalize, baseline: from(bucket: "baseline") |> range(start: -1h)}, on: ["_time"])
+	|> map(fn: (r) => ({r with _value: r._value_now / r._value_baseline}))
+
+compare
+	|> yield(name: "compare")
+`
+	if got := b.GetQuery().Text; got != want {
+		t.Errorf("unexpected query\ngot:\n%s\nwant:\n%s", got, want)
+	}
+	if q, err := b.StageQuery(""); err != nil || q.Text != want {
+		t.Errorf("expected the whole query without a stage, got %q, %v", q.Text, err)
+	}
+
+	q, err := b.StageQuery("normalize")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = `fetch = from(bucket: "telegraf") |> range(start: -1h) |> filter(fn: (r) => r._measurement == "http" and r._field == "latency")
+
+normalize = fetch |> aggregateWindow(every: 5m, fn: mean)
+
+normalize
+	|> yield(name: "normalize")
+`
+	if q.Text != want {
+		t.Errorf("unexpected stage query\ngot:\n%s\nwant:\n%s", q.Text, want)
+	}
+
+	scope, err := check.ParseQueryScope(b.GetQuery())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(scope.Buckets) != 2 || scope.Buckets[0] != "telegraf" || scope.Buckets[1] != "baseline" {
+		t.Errorf("unexpected buckets %v", scope.Buckets)
+	}
+
+	if _, err := b.StageQuery("missing"); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Errorf("expected a missing stage not to be found, got %v", err)
+	}
+	if _, err := (&check.Base{Query: influxdb.DashboardQuery{Text: "x"}}).StageQuery("fetch"); influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Errorf("expected a query without stages to be invalid, got %v", err)
+	}
+}
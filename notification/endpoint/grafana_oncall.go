@@ -0,0 +1,79 @@
+package endpoint
+
+import (
+	"encoding/json"
+	"net/url"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.NotificationEndpoint = &GrafanaOnCall{}
+
+const grafanaOnCallURLSuffix = "url"
+
+// GrafanaOnCall is the notification endpoint config of a grafana oncall
+// formatted webhook integration.
+type GrafanaOnCall struct {
+	Base
+	// URL is the webhook url of the integration,
+	// it embeds the integration token so it is stored as a secret.
+	URL influxdb.SecretField `json:"url"`
+}
+
+// BackfillSecretKeys fill back fill the secret field key during the unmarshalling
+// if value of that secret field is not nil.
+func (g *GrafanaOnCall) BackfillSecretKeys() {
+	if g.URL.Key == "" && g.URL.Value != nil {
+		g.URL.Key = g.secretKey(grafanaOnCallURLSuffix)
+	}
+}
+
+// SecretFields return available secret fields.
+func (g GrafanaOnCall) SecretFields() []influxdb.SecretField {
+	arr := []influxdb.SecretField{}
+	if g.URL.Key != "" {
+		arr = append(arr, g.URL)
+	}
+	return arr
+}
+
+// Valid returns error if some configuration is invalid
+func (g GrafanaOnCall) Valid() error {
+	if err := g.Base.valid(); err != nil {
+		return err
+	}
+	if g.URL.Key == "" && g.URL.Value == nil {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "grafana oncall endpoint URL is empty",
+		}
+	}
+	if g.URL.Value != nil {
+		if _, err := url.Parse(*g.URL.Value); err != nil {
+			return &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "grafana oncall endpoint URL is invalid: " + err.Error(),
+			}
+		}
+	}
+	return nil
+}
+
+type grafanaOnCallAlias GrafanaOnCall
+
+// MarshalJSON implement json.Marshaler interface.
+func (g GrafanaOnCall) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		struct {
+			grafanaOnCallAlias
+			Type string `json:"type"`
+		}{
+			grafanaOnCallAlias: grafanaOnCallAlias(g),
+			Type:               g.Type(),
+		})
+}
+
+// Type returns the type.
+func (g GrafanaOnCall) Type() string {
+	return "grafanaoncall"
+}
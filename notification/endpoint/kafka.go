@@ -0,0 +1,118 @@
+package endpoint
+
+import (
+	"encoding/json"
+	"net"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.NotificationEndpoint = &Kafka{}
+
+const (
+	kafkaClientCertSuffix = "client-cert"
+	kafkaClientKeySuffix  = "client-key"
+)
+
+// Kafka is the notification endpoint config of a kafka cluster,
+// status events are written as json to the expanded topic template.
+type Kafka struct {
+	Base
+	// Brokers are the host:port addresses of the kafka brokers.
+	Brokers []string `json:"brokers"`
+	// TopicTemplate is the topic events are written to,
+	// it can reference tags with ${r.key}, e.g. alerts-${r.region}.
+	TopicTemplate string `json:"topicTemplate"`
+	// TLS enables tls connections to the brokers.
+	TLS bool `json:"tls,omitempty"`
+	// InsecureSkipVerify disables the verification of the broker certificates.
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+	// ClientCert and ClientKey are the optional PEM encoded client
+	// certificate and key used to authenticate with the brokers.
+	ClientCert influxdb.SecretField `json:"clientCert"`
+	ClientKey  influxdb.SecretField `json:"clientKey"`
+}
+
+// BackfillSecretKeys fill back fill the secret field key during the unmarshalling
+// if value of that secret field is not nil.
+func (k *Kafka) BackfillSecretKeys() {
+	if k.ClientCert.Key == "" && k.ClientCert.Value != nil {
+		k.ClientCert.Key = k.secretKey(kafkaClientCertSuffix)
+	}
+	if k.ClientKey.Key == "" && k.ClientKey.Value != nil {
+		k.ClientKey.Key = k.secretKey(kafkaClientKeySuffix)
+	}
+}
+
+// SecretFields return available secret fields.
+func (k Kafka) SecretFields() []influxdb.SecretField {
+	arr := []influxdb.SecretField{}
+	if k.ClientCert.Key != "" {
+		arr = append(arr, k.ClientCert)
+	}
+	if k.ClientKey.Key != "" {
+		arr = append(arr, k.ClientKey)
+	}
+	return arr
+}
+
+// Valid returns error if some configuration is invalid
+func (k Kafka) Valid() error {
+	if err := k.Base.valid(); err != nil {
+		return err
+	}
+	if len(k.Brokers) == 0 {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "kafka endpoint brokers are empty",
+		}
+	}
+	for _, b := range k.Brokers {
+		if _, _, err := net.SplitHostPort(b); err != nil {
+			return &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "kafka endpoint broker is invalid: " + err.Error(),
+			}
+		}
+	}
+	if k.TopicTemplate == "" {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "kafka endpoint topic template is empty",
+		}
+	}
+	hasCert := k.ClientCert.Key != "" || k.ClientCert.Value != nil
+	hasKey := k.ClientKey.Key != "" || k.ClientKey.Value != nil
+	if hasCert != hasKey {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "kafka endpoint client cert and client key must be set together",
+		}
+	}
+	if hasCert && !k.TLS {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "kafka endpoint client cert requires tls",
+		}
+	}
+	return nil
+}
+
+type kafkaAlias Kafka
+
+// MarshalJSON implement json.Marshaler interface.
+func (k Kafka) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		struct {
+			kafkaAlias
+			Type string `json:"type"`
+		}{
+			kafkaAlias: kafkaAlias(k),
+			Type:       k.Type(),
+		})
+}
+
+// Type returns the type.
+func (k Kafka) Type() string {
+	return "kafka"
+}
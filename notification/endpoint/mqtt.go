@@ -0,0 +1,113 @@
+package endpoint
+
+import (
+	"encoding/json"
+	"net/url"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.NotificationEndpoint = &MQTT{}
+
+const mqttPasswordSuffix = "password"
+
+var mqttSchemes = map[string]bool{
+	"tcp":   true,
+	"mqtt":  true,
+	"ssl":   true,
+	"tls":   true,
+	"mqtts": true,
+}
+
+// MQTT is the notification endpoint config of a mqtt broker,
+// status events are published as json to the expanded topic template.
+type MQTT struct {
+	Base
+	// Broker is the url of the broker, e.g. tcp://localhost:1883 or ssl://localhost:8883.
+	Broker string `json:"broker"`
+	// TopicTemplate is the topic events are published to,
+	// it can reference tags with ${r.key}, e.g. alerts/${r.host}.
+	TopicTemplate string `json:"topicTemplate"`
+	ClientID      string `json:"clientID,omitempty"`
+	Username      string `json:"username,omitempty"`
+	// Password is the optional password of the user.
+	Password influxdb.SecretField `json:"password"`
+	// QoS is the quality of service of published messages, either 0 or 1.
+	QoS    int  `json:"qos"`
+	Retain bool `json:"retain,omitempty"`
+}
+
+// BackfillSecretKeys fill back fill the secret field key during the unmarshalling
+// if value of that secret field is not nil.
+func (m *MQTT) BackfillSecretKeys() {
+	if m.Password.Key == "" && m.Password.Value != nil {
+		m.Password.Key = m.secretKey(mqttPasswordSuffix)
+	}
+}
+
+// SecretFields return available secret fields.
+func (m MQTT) SecretFields() []influxdb.SecretField {
+	arr := []influxdb.SecretField{}
+	if m.Password.Key != "" {
+		arr = append(arr, m.Password)
+	}
+	return arr
+}
+
+// Valid returns error if some configuration is invalid
+func (m MQTT) Valid() error {
+	if err := m.Base.valid(); err != nil {
+		return err
+	}
+	if m.Broker == "" {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "mqtt endpoint broker is empty",
+		}
+	}
+	u, err := url.Parse(m.Broker)
+	if err != nil {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "mqtt endpoint broker is invalid: " + err.Error(),
+		}
+	}
+	if !mqttSchemes[u.Scheme] || u.Host == "" {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "mqtt endpoint broker must be a tcp://, mqtt://, ssl://, tls:// or mqtts:// url",
+		}
+	}
+	if m.TopicTemplate == "" {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "mqtt endpoint topic template is empty",
+		}
+	}
+	if m.QoS != 0 && m.QoS != 1 {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "mqtt endpoint qos must be 0 or 1",
+		}
+	}
+	return nil
+}
+
+type mqttAlias MQTT
+
+// MarshalJSON implement json.Marshaler interface.
+func (m MQTT) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		struct {
+			mqttAlias
+			Type string `json:"type"`
+		}{
+			mqttAlias: mqttAlias(m),
+			Type:      m.Type(),
+		})
+}
+
+// Type returns the type.
+func (m MQTT) Type() string {
+	return "mqtt"
+}
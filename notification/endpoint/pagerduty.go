@@ -0,0 +1,80 @@
+package endpoint
+
+import (
+	"encoding/json"
+	"net/url"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.NotificationEndpoint = &PagerDuty{}
+
+const routingKeySuffix = "routing-key"
+
+// PagerDuty is the notification endpoint config of pagerduty.
+type PagerDuty struct {
+	Base
+	// ClientURL is the url of the client sending the alert,
+	// it is shown as a link in the pagerduty incident.
+	ClientURL string `json:"clientURL,omitempty"`
+	// RoutingKey is the integration key of a pagerduty service.
+	RoutingKey influxdb.SecretField `json:"routingKey"`
+}
+
+// BackfillSecretKeys fill back fill the secret field key during the unmarshalling
+// if value of that secret field is not nil.
+func (p *PagerDuty) BackfillSecretKeys() {
+	if p.RoutingKey.Key == "" && p.RoutingKey.Value != nil {
+		p.RoutingKey.Key = p.secretKey(routingKeySuffix)
+	}
+}
+
+// SecretFields return available secret fields.
+func (p PagerDuty) SecretFields() []influxdb.SecretField {
+	arr := []influxdb.SecretField{}
+	if p.RoutingKey.Key != "" {
+		arr = append(arr, p.RoutingKey)
+	}
+	return arr
+}
+
+// Valid returns error if some configuration is invalid
+func (p PagerDuty) Valid() error {
+	if err := p.Base.valid(); err != nil {
+		return err
+	}
+	if p.RoutingKey.Key == "" && p.RoutingKey.Value == nil {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "pagerduty routing key is empty",
+		}
+	}
+	if p.ClientURL != "" {
+		if _, err := url.Parse(p.ClientURL); err != nil {
+			return &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "pagerduty client URL is invalid: " + err.Error(),
+			}
+		}
+	}
+	return nil
+}
+
+type pagerDutyAlias PagerDuty
+
+// MarshalJSON implement json.Marshaler interface.
+func (p PagerDuty) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		struct {
+			pagerDutyAlias
+			Type string `json:"type"`
+		}{
+			pagerDutyAlias: pagerDutyAlias(p),
+			Type:           p.Type(),
+		})
+}
+
+// Type returns the type.
+func (p PagerDuty) Type() string {
+	return "pagerduty"
+}
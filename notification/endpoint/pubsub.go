@@ -0,0 +1,90 @@
+package endpoint
+
+import (
+	"encoding/json"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.NotificationEndpoint = &PubSub{}
+
+const pubSubServiceAccountKeySuffix = "service-account-key"
+
+// PubSub is the notification endpoint config of a google cloud pub/sub topic,
+// status events are published as json messages to the topic.
+type PubSub struct {
+	Base
+	ProjectID string `json:"projectID"`
+	TopicID   string `json:"topicID"`
+	// ServiceAccountKey is the json key file of the service account
+	// publishing to the topic.
+	ServiceAccountKey influxdb.SecretField `json:"serviceAccountKey"`
+}
+
+// BackfillSecretKeys fill back fill the secret field key during the unmarshalling
+// if value of that secret field is not nil.
+func (p *PubSub) BackfillSecretKeys() {
+	if p.ServiceAccountKey.Key == "" && p.ServiceAccountKey.Value != nil {
+		p.ServiceAccountKey.Key = p.secretKey(pubSubServiceAccountKeySuffix)
+	}
+}
+
+// SecretFields return available secret fields.
+func (p PubSub) SecretFields() []influxdb.SecretField {
+	arr := []influxdb.SecretField{}
+	if p.ServiceAccountKey.Key != "" {
+		arr = append(arr, p.ServiceAccountKey)
+	}
+	return arr
+}
+
+// Valid returns error if some configuration is invalid
+func (p PubSub) Valid() error {
+	if err := p.Base.valid(); err != nil {
+		return err
+	}
+	if p.ProjectID == "" {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "pubsub endpoint project id is empty",
+		}
+	}
+	if p.TopicID == "" {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "pubsub endpoint topic id is empty",
+		}
+	}
+	if p.ServiceAccountKey.Key == "" && p.ServiceAccountKey.Value == nil {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "pubsub service account key is empty",
+		}
+	}
+	if p.ServiceAccountKey.Value != nil && !json.Valid([]byte(*p.ServiceAccountKey.Value)) {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "pubsub service account key is not valid json",
+		}
+	}
+	return nil
+}
+
+type pubSubAlias PubSub
+
+// MarshalJSON implement json.Marshaler interface.
+func (p PubSub) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		struct {
+			pubSubAlias
+			Type string `json:"type"`
+		}{
+			pubSubAlias: pubSubAlias(p),
+			Type:        p.Type(),
+		})
+}
+
+// Type returns the type.
+func (p PubSub) Type() string {
+	return "pubsub"
+}
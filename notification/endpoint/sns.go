@@ -0,0 +1,122 @@
+package endpoint
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.NotificationEndpoint = &SNS{}
+
+const (
+	snsAccessKeyIDSuffix     = "access-key-id"
+	snsSecretAccessKeySuffix = "secret-access-key"
+)
+
+// SNS is the notification endpoint config of an aws sns topic,
+// status events are published as json messages to the topic.
+type SNS struct {
+	Base
+	// TopicARN is the arn of the topic, e.g. arn:aws:sns:us-east-1:123456789012:alerts,
+	// the region of the topic is read from it.
+	TopicARN string `json:"topicARN"`
+	// AccessKeyID and SecretAccessKey are the credentials of the iam user
+	// publishing to the topic.
+	AccessKeyID     influxdb.SecretField `json:"accessKeyID"`
+	SecretAccessKey influxdb.SecretField `json:"secretAccessKey"`
+	// RoleARN is the optional iam role assumed with the credentials
+	// before publishing.
+	RoleARN string `json:"roleARN,omitempty"`
+}
+
+// BackfillSecretKeys fill back fill the secret field key during the unmarshalling
+// if value of that secret field is not nil.
+func (s *SNS) BackfillSecretKeys() {
+	if s.AccessKeyID.Key == "" && s.AccessKeyID.Value != nil {
+		s.AccessKeyID.Key = s.secretKey(snsAccessKeyIDSuffix)
+	}
+	if s.SecretAccessKey.Key == "" && s.SecretAccessKey.Value != nil {
+		s.SecretAccessKey.Key = s.secretKey(snsSecretAccessKeySuffix)
+	}
+}
+
+// SecretFields return available secret fields.
+func (s SNS) SecretFields() []influxdb.SecretField {
+	arr := []influxdb.SecretField{}
+	if s.AccessKeyID.Key != "" {
+		arr = append(arr, s.AccessKeyID)
+	}
+	if s.SecretAccessKey.Key != "" {
+		arr = append(arr, s.SecretAccessKey)
+	}
+	return arr
+}
+
+// Valid returns error if some configuration is invalid
+func (s SNS) Valid() error {
+	if err := s.Base.valid(); err != nil {
+		return err
+	}
+	if s.TopicARN == "" {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "sns endpoint topic arn is empty",
+		}
+	}
+	if !validARN(s.TopicARN, "sns") {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "sns endpoint topic arn is invalid",
+		}
+	}
+	if s.RoleARN != "" && !validARN(s.RoleARN, "iam") {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "sns endpoint role arn is invalid",
+		}
+	}
+	if s.AccessKeyID.Key == "" && s.AccessKeyID.Value == nil {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "sns access key id is empty",
+		}
+	}
+	if s.SecretAccessKey.Key == "" && s.SecretAccessKey.Value == nil {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "sns secret access key is empty",
+		}
+	}
+	return nil
+}
+
+// validARN reports whether arn is an arn of the aws service,
+// arn:partition:service:region:account-id:resource.
+// Only regional services such as sns need a region.
+func validARN(arn, service string) bool {
+	parts := strings.SplitN(arn, ":", 6)
+	if len(parts) != 6 || parts[0] != "arn" || parts[1] == "" || parts[2] != service || parts[5] == "" {
+		return false
+	}
+	return service == "iam" || parts[3] != ""
+}
+
+type snsAlias SNS
+
+// MarshalJSON implement json.Marshaler interface.
+func (s SNS) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		struct {
+			snsAlias
+			Type string `json:"type"`
+		}{
+			snsAlias: snsAlias(s),
+			Type:     s.Type(),
+		})
+}
+
+// Type returns the type.
+func (s SNS) Type() string {
+	return "sns"
+}
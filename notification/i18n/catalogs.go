@@ -0,0 +1,61 @@
+package i18n
+
+// The catalogs shipped with the server.
+func init() {
+	Register("en", Catalog{
+		StatusUnknown:     "%s is in an unknown state",
+		StatusOk:          "%s has recovered",
+		StatusInfo:        "%s reports information",
+		StatusCrit:        "%s is critical",
+		StatusWarn:        "%s has a warning",
+		StatusSynthetic:   "[test] %s",
+		StatusRecovered:   "%s recovered after %s",
+		LabelCheck:        "Check",
+		LabelLevel:        "Level",
+		LabelValue:        "Value",
+		ActionViewCheck:   "View check",
+		ActionAcknowledge: "Acknowledge",
+	})
+	Register("de", Catalog{
+		StatusUnknown:     "%s ist in einem unbekannten Zustand",
+		StatusOk:          "%s hat sich erholt",
+		StatusInfo:        "%s meldet eine Information",
+		StatusCrit:        "%s ist kritisch",
+		StatusWarn:        "%s meldet eine Warnung",
+		StatusSynthetic:   "[Test] %s",
+		StatusRecovered:   "%s hat sich nach %s erholt",
+		LabelCheck:        "Check",
+		LabelLevel:        "Stufe",
+		LabelValue:        "Wert",
+		ActionViewCheck:   "Check anzeigen",
+		ActionAcknowledge: "Bestätigen",
+	})
+	Register("es", Catalog{
+		StatusUnknown:     "%s está en un estado desconocido",
+		StatusOk:          "%s se ha recuperado",
+		StatusInfo:        "%s informa",
+		StatusCrit:        "%s está en estado crítico",
+		StatusWarn:        "%s tiene una advertencia",
+		StatusSynthetic:   "[prueba] %s",
+		StatusRecovered:   "%s se recuperó después de %s",
+		LabelCheck:        "Comprobación",
+		LabelLevel:        "Nivel",
+		LabelValue:        "Valor",
+		ActionViewCheck:   "Ver comprobación",
+		ActionAcknowledge: "Reconocer",
+	})
+	Register("fr", Catalog{
+		StatusUnknown:     "%s est dans un état inconnu",
+		StatusOk:          "%s est rétabli",
+		StatusInfo:        "%s signale une information",
+		StatusCrit:        "%s est critique",
+		StatusWarn:        "%s signale un avertissement",
+		StatusSynthetic:   "[test] %s",
+		StatusRecovered:   "%s s'est rétabli après %s",
+		LabelCheck:        "Vérification",
+		LabelLevel:        "Niveau",
+		LabelValue:        "Valeur",
+		ActionViewCheck:   "Voir la vérification",
+		ActionAcknowledge: "Acquitter",
+	})
+}
@@ -0,0 +1,175 @@
+// Package i18n translates the built-in phrases of notifications, such as
+// "is critical" or "has recovered", with message catalogs compiled into
+// the server. More languages are added by registering their catalog.
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification"
+)
+
+// DefaultLocale is the locale of notifications without a locale,
+// its catalog is used for the phrases missing from other catalogs.
+const DefaultLocale = "en"
+
+// Keys of the phrases of a catalog.
+// Status phrases are formats of the check name, the recovered phrase of the
+// check name and the duration of the incident, and the synthetic phrase is
+// a format of the message of a synthetic status.
+const (
+	StatusUnknown   = "status.unknown"
+	StatusOk        = "status.ok"
+	StatusInfo      = "status.info"
+	StatusCrit      = "status.crit"
+	StatusWarn      = "status.warn"
+	StatusSynthetic = "status.synthetic"
+	StatusRecovered = "status.recovered"
+
+	LabelCheck = "label.check"
+	LabelLevel = "label.level"
+	LabelValue = "label.value"
+
+	ActionViewCheck   = "action.viewCheck"
+	ActionAcknowledge = "action.acknowledge"
+)
+
+var statusKeys = map[notification.CheckLevel]string{
+	notification.Unknown:  StatusUnknown,
+	notification.Ok:       StatusOk,
+	notification.Info:     StatusInfo,
+	notification.Critical: StatusCrit,
+	notification.Warn:     StatusWarn,
+}
+
+// Catalog maps the keys of phrases to their translation in one language.
+type Catalog map[string]string
+
+var (
+	mu       sync.RWMutex
+	catalogs = map[string]Catalog{}
+)
+
+// Register makes the catalog of a locale available to notifications.
+// Locales are language tags such as "de" or "pt-BR".
+// It panics if a catalog is already registered for the locale.
+func Register(locale string, c Catalog) {
+	mu.Lock()
+	defer mu.Unlock()
+	locale = normalize(locale)
+	if _, ok := catalogs[locale]; ok {
+		panic("i18n: Register called twice for locale " + locale)
+	}
+	catalogs[locale] = c
+}
+
+// Locales returns the registered locales in order.
+func Locales() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return locales()
+}
+
+func locales() []string {
+	ls := make([]string, 0, len(catalogs))
+	for l := range catalogs {
+		ls = append(ls, l)
+	}
+	sort.Strings(ls)
+	return ls
+}
+
+// ValidLocale returns an error if no catalog matches the locale.
+// An empty locale is valid and means the default locale.
+func ValidLocale(locale string) error {
+	if locale == "" {
+		return nil
+	}
+	mu.RLock()
+	defer mu.RUnlock()
+	if len(match(locale)) == 0 {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("unsupported locale %s, supported locales are %s", locale, strings.Join(locales(), ", ")),
+		}
+	}
+	return nil
+}
+
+// normalize lower cases the language of a tag and uses - as separator.
+func normalize(locale string) string {
+	locale = strings.Replace(locale, "_", "-", -1)
+	if i := strings.Index(locale, "-"); i >= 0 {
+		return strings.ToLower(locale[:i]) + locale[i:]
+	}
+	return strings.ToLower(locale)
+}
+
+// match returns the catalogs of a locale from the most specific one,
+// "pt-BR" matches the catalogs of "pt-BR" and "pt".
+func match(locale string) []Catalog {
+	locale = normalize(locale)
+	var cs []Catalog
+	for {
+		for l, c := range catalogs {
+			if strings.EqualFold(l, locale) {
+				cs = append(cs, c)
+				break
+			}
+		}
+		i := strings.LastIndex(locale, "-")
+		if i < 0 {
+			return cs
+		}
+		locale = locale[:i]
+	}
+}
+
+// Printer prints the phrases of a locale.
+type Printer struct {
+	catalogs []Catalog
+}
+
+// NewPrinter returns the printer of a locale, phrases missing from the
+// catalogs of the locale are printed in the default locale.
+func NewPrinter(locale string) *Printer {
+	mu.RLock()
+	defer mu.RUnlock()
+	cs := match(locale)
+	if c, ok := catalogs[DefaultLocale]; ok {
+		cs = append(cs, c)
+	}
+	return &Printer{catalogs: cs}
+}
+
+// Sprintf formats the phrase of key with args,
+// the key is printed if no catalog has the phrase.
+func (p *Printer) Sprintf(key string, args ...interface{}) string {
+	for _, c := range p.catalogs {
+		if f, ok := c[key]; ok {
+			return fmt.Sprintf(f, args...)
+		}
+	}
+	return key
+}
+
+// Status prints the phrase of a check reaching a level,
+// such as "cpu is critical".
+func (p *Printer) Status(checkName string, level notification.CheckLevel) string {
+	key, ok := statusKeys[level]
+	if !ok {
+		key = StatusUnknown
+	}
+	return p.Sprintf(key, checkName)
+}
+
+// Recovered prints the phrase of a check recovering after an incident,
+// such as "cpu recovered after 23m".
+func (p *Printer) Recovered(checkName string, d time.Duration) string {
+	return p.Sprintf(StatusRecovered, checkName, notification.FormatDuration(d))
+}
@@ -0,0 +1,64 @@
+package i18n_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/i18n"
+)
+
+func init() {
+	i18n.Register("de-CH", i18n.Catalog{
+		i18n.LabelLevel: "Level",
+	})
+}
+
+func TestPrinter(t *testing.T) {
+	cases := []struct {
+		locale string
+		level  notification.CheckLevel
+		status string
+		label  string
+	}{
+		{locale: "", level: notification.Critical, status: "cpu is critical", label: "Level"},
+		{locale: "en", level: notification.Ok, status: "cpu has recovered", label: "Level"},
+		{locale: "de", level: notification.Critical, status: "cpu ist kritisch", label: "Stufe"},
+		{locale: "DE_de", level: notification.Ok, status: "cpu hat sich erholt", label: "Stufe"},
+		// phrases missing from the catalog of a region are printed in its language.
+		{locale: "de-CH", level: notification.Warn, status: "cpu meldet eine Warnung", label: "Level"},
+		{locale: "fr", level: notification.Info, status: "cpu signale une information", label: "Niveau"},
+		// unknown locales are printed in the default locale.
+		{locale: "xx", level: notification.Critical, status: "cpu is critical", label: "Level"},
+		{locale: "es", level: notification.CheckLevel(42), status: "cpu está en un estado desconocido", label: "Nivel"},
+	}
+	for _, c := range cases {
+		p := i18n.NewPrinter(c.locale)
+		if got := p.Status("cpu", c.level); got != c.status {
+			t.Errorf("%q: expected status %q, got %q", c.locale, c.status, got)
+		}
+		if got := p.Sprintf(i18n.LabelLevel); got != c.label {
+			t.Errorf("%q: expected label %q, got %q", c.locale, c.label, got)
+		}
+	}
+
+	if got := i18n.NewPrinter("fr").Recovered("cpu", 23*time.Minute); got != "cpu s'est rétabli après 23m" {
+		t.Errorf("unexpected recovered phrase %q", got)
+	}
+	if got := i18n.NewPrinter("de").Sprintf("no.such.key"); got != "no.such.key" {
+		t.Errorf("expected the key of a missing phrase, got %q", got)
+	}
+}
+
+func TestValidLocale(t *testing.T) {
+	for _, l := range []string{"", "en", "de", "de-AT", "fr_FR", "es"} {
+		if err := i18n.ValidLocale(l); err != nil {
+			t.Errorf("expected %q to be valid: %v", l, err)
+		}
+	}
+	err := i18n.ValidLocale("tlh")
+	if influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Fatalf("expected invalid error, got %v", err)
+	}
+}
@@ -0,0 +1,51 @@
+package rule
+
+import (
+	"encoding/json"
+
+	"github.com/influxdata/influxdb"
+)
+
+// Alerta is the notification rule config of alerta.
+type Alerta struct {
+	Base
+	MessageTemplate string `json:"messageTemplate"`
+}
+
+type alertaAlias Alerta
+
+// MarshalJSON implement json.Marshaler interface.
+func (c Alerta) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		struct {
+			alertaAlias
+			Type string `json:"type"`
+		}{
+			alertaAlias: alertaAlias(c),
+			Type:        c.Type(),
+		})
+}
+
+// Valid returns where the config is valid.
+func (c Alerta) Valid() error {
+	if err := c.Base.valid(); err != nil {
+		return err
+	}
+	if c.MessageTemplate == "" {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "alerta msg template is empty",
+		}
+	}
+	return nil
+}
+
+// Type returns the type of the rule config.
+func (c Alerta) Type() string {
+	return "alerta"
+}
+
+// GetMessageTemplate returns the template of the messages of the rule.
+func (c Alerta) GetMessageTemplate() string {
+	return c.MessageTemplate
+}
@@ -0,0 +1,51 @@
+package rule
+
+import (
+	"encoding/json"
+
+	"github.com/influxdata/influxdb"
+)
+
+// Exec is the notification rule config of local commands.
+type Exec struct {
+	Base
+	MessageTemplate string `json:"messageTemplate"`
+}
+
+type execAlias Exec
+
+// MarshalJSON implement json.Marshaler interface.
+func (c Exec) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		struct {
+			execAlias
+			Type string `json:"type"`
+		}{
+			execAlias: execAlias(c),
+			Type:      c.Type(),
+		})
+}
+
+// Valid returns where the config is valid.
+func (c Exec) Valid() error {
+	if err := c.Base.valid(); err != nil {
+		return err
+	}
+	if c.MessageTemplate == "" {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "exec msg template is empty",
+		}
+	}
+	return nil
+}
+
+// Type returns the type of the rule config.
+func (c Exec) Type() string {
+	return "exec"
+}
+
+// GetMessageTemplate returns the template of the messages of the rule.
+func (c Exec) GetMessageTemplate() string {
+	return c.MessageTemplate
+}
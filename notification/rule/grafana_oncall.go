@@ -0,0 +1,51 @@
+package rule
+
+import (
+	"encoding/json"
+
+	"github.com/influxdata/influxdb"
+)
+
+// GrafanaOnCall is the notification rule config of grafana oncall.
+type GrafanaOnCall struct {
+	Base
+	MessageTemplate string `json:"messageTemplate"`
+}
+
+type grafanaOnCallAlias GrafanaOnCall
+
+// MarshalJSON implement json.Marshaler interface.
+func (c GrafanaOnCall) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		struct {
+			grafanaOnCallAlias
+			Type string `json:"type"`
+		}{
+			grafanaOnCallAlias: grafanaOnCallAlias(c),
+			Type:               c.Type(),
+		})
+}
+
+// Valid returns where the config is valid.
+func (c GrafanaOnCall) Valid() error {
+	if err := c.Base.valid(); err != nil {
+		return err
+	}
+	if c.MessageTemplate == "" {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "grafana oncall msg template is empty",
+		}
+	}
+	return nil
+}
+
+// Type returns the type of the rule config.
+func (c GrafanaOnCall) Type() string {
+	return "grafanaoncall"
+}
+
+// GetMessageTemplate returns the template of the messages of the rule.
+func (c GrafanaOnCall) GetMessageTemplate() string {
+	return c.MessageTemplate
+}
@@ -0,0 +1,51 @@
+package rule
+
+import (
+	"encoding/json"
+
+	"github.com/influxdata/influxdb"
+)
+
+// Kafka is the notification rule config of kafka.
+type Kafka struct {
+	Base
+	MessageTemplate string `json:"messageTemplate"`
+}
+
+type kafkaAlias Kafka
+
+// MarshalJSON implement json.Marshaler interface.
+func (c Kafka) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		struct {
+			kafkaAlias
+			Type string `json:"type"`
+		}{
+			kafkaAlias: kafkaAlias(c),
+			Type:       c.Type(),
+		})
+}
+
+// Valid returns where the config is valid.
+func (c Kafka) Valid() error {
+	if err := c.Base.valid(); err != nil {
+		return err
+	}
+	if c.MessageTemplate == "" {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "kafka msg template is empty",
+		}
+	}
+	return nil
+}
+
+// Type returns the type of the rule config.
+func (c Kafka) Type() string {
+	return "kafka"
+}
+
+// GetMessageTemplate returns the template of the messages of the rule.
+func (c Kafka) GetMessageTemplate() string {
+	return c.MessageTemplate
+}
@@ -0,0 +1,51 @@
+package rule
+
+import (
+	"encoding/json"
+
+	"github.com/influxdata/influxdb"
+)
+
+// MQTT is the notification rule config of mqtt.
+type MQTT struct {
+	Base
+	MessageTemplate string `json:"messageTemplate"`
+}
+
+type mqttAlias MQTT
+
+// MarshalJSON implement json.Marshaler interface.
+func (c MQTT) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		struct {
+			mqttAlias
+			Type string `json:"type"`
+		}{
+			mqttAlias: mqttAlias(c),
+			Type:      c.Type(),
+		})
+}
+
+// Valid returns where the config is valid.
+func (c MQTT) Valid() error {
+	if err := c.Base.valid(); err != nil {
+		return err
+	}
+	if c.MessageTemplate == "" {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "mqtt msg template is empty",
+		}
+	}
+	return nil
+}
+
+// Type returns the type of the rule config.
+func (c MQTT) Type() string {
+	return "mqtt"
+}
+
+// GetMessageTemplate returns the template of the messages of the rule.
+func (c MQTT) GetMessageTemplate() string {
+	return c.MessageTemplate
+}
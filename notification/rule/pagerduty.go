@@ -2,14 +2,27 @@ package rule
 
 import (
 	"encoding/json"
+	"fmt"
 
 	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification"
 )
 
+// pagerDutySeverities are the severities accepted by the pagerduty events api.
+var pagerDutySeverities = map[string]bool{
+	"critical": true,
+	"error":    true,
+	"warning":  true,
+	"info":     true,
+}
+
 // PagerDuty is the rule config of pagerduty notification.
 type PagerDuty struct {
 	Base
 	MessageTemp string `json:"messageTemplate"`
+	// SeverityMapping overrides the pagerduty severity of check levels,
+	// e.g. {"WARN": "error"}. Levels not in the mapping use the default severity.
+	SeverityMapping map[string]string `json:"severityMapping,omitempty"`
 }
 
 type pagerDutyAlias PagerDuty
@@ -37,6 +50,20 @@ func (c PagerDuty) Valid() error {
 			Msg:  "pagerduty invalid message template",
 		}
 	}
+	for lvl, severity := range c.SeverityMapping {
+		if notification.ParseCheckLevel(lvl) == notification.Unknown && lvl != notification.Unknown.String() {
+			return &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  fmt.Sprintf("pagerduty severity mapping has invalid check level %q", lvl),
+			}
+		}
+		if !pagerDutySeverities[severity] {
+			return &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  fmt.Sprintf("pagerduty severity mapping has invalid severity %q", severity),
+			}
+		}
+	}
 	return nil
 }
 
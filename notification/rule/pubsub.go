@@ -0,0 +1,51 @@
+package rule
+
+import (
+	"encoding/json"
+
+	"github.com/influxdata/influxdb"
+)
+
+// PubSub is the notification rule config of google cloud pub/sub.
+type PubSub struct {
+	Base
+	MessageTemplate string `json:"messageTemplate"`
+}
+
+type pubSubAlias PubSub
+
+// MarshalJSON implement json.Marshaler interface.
+func (c PubSub) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		struct {
+			pubSubAlias
+			Type string `json:"type"`
+		}{
+			pubSubAlias: pubSubAlias(c),
+			Type:        c.Type(),
+		})
+}
+
+// Valid returns where the config is valid.
+func (c PubSub) Valid() error {
+	if err := c.Base.valid(); err != nil {
+		return err
+	}
+	if c.MessageTemplate == "" {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "pubsub msg template is empty",
+		}
+	}
+	return nil
+}
+
+// Type returns the type of the rule config.
+func (c PubSub) Type() string {
+	return "pubsub"
+}
+
+// GetMessageTemplate returns the template of the messages of the rule.
+func (c PubSub) GetMessageTemplate() string {
+	return c.MessageTemplate
+}
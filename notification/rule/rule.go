@@ -7,12 +7,20 @@ import (
 
 	"github.com/influxdata/influxdb"
 	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/i18n"
 )
 
 var typToRule = map[string](func() influxdb.NotificationRule){
-	"slack":     func() influxdb.NotificationRule { return &Slack{} },
-	"smtp":      func() influxdb.NotificationRule { return &SMTP{} },
-	"pagerduty": func() influxdb.NotificationRule { return &PagerDuty{} },
+	"slack":         func() influxdb.NotificationRule { return &Slack{} },
+	"smtp":          func() influxdb.NotificationRule { return &SMTP{} },
+	"pagerduty":     func() influxdb.NotificationRule { return &PagerDuty{} },
+	"alerta":        func() influxdb.NotificationRule { return &Alerta{} },
+	"grafanaoncall": func() influxdb.NotificationRule { return &GrafanaOnCall{} },
+	"mqtt":          func() influxdb.NotificationRule { return &MQTT{} },
+	"kafka":         func() influxdb.NotificationRule { return &Kafka{} },
+	"sns":           func() influxdb.NotificationRule { return &SNS{} },
+	"pubsub":        func() influxdb.NotificationRule { return &PubSub{} },
+	"exec":          func() influxdb.NotificationRule { return &Exec{} },
 }
 
 type rawRuleJSON struct {
@@ -57,6 +65,16 @@ type Base struct {
 	RunbookLink string                    `json:"runbookLink"`
 	TagRules    []notification.TagRule    `json:"tagRules,omitempty"`
 	StatusRules []notification.StatusRule `json:"statusRules,omitempty"`
+	// Locale is the language of the built-in phrases of notifications,
+	// it overrides the locale of the endpoint.
+	Locale string `json:"locale,omitempty"`
+	// NotifyRecovery sends a notification with the duration of the incident
+	// when a series matching the tag rules recovers to ok, whatever the
+	// status rules.
+	NotifyRecovery bool `json:"notifyRecovery,omitempty"`
+	// Routes send the notifications to other endpoints during their
+	// schedules, the first matching route wins over EndpointID.
+	Routes []influxdb.NotificationRoute `json:"routes,omitempty"`
 	*influxdb.Limit
 	influxdb.CRUDLog
 }
@@ -103,6 +121,14 @@ func (b Base) valid() error {
 			return err
 		}
 	}
+	for _, r := range b.Routes {
+		if err := r.Valid(); err != nil {
+			return err
+		}
+	}
+	if err := i18n.ValidLocale(b.Locale); err != nil {
+		return err
+	}
 	if b.Limit != nil {
 		if b.Limit.Every <= 0 || b.Limit.Rate <= 0 {
 			return &influxdb.Error{
@@ -150,6 +176,72 @@ func (b *Base) GetStatus() influxdb.Status {
 	return b.Status
 }
 
+// GetLocale returns the locale of the built-in phrases of notifications.
+func (b *Base) GetLocale() string {
+	return b.Locale
+}
+
+// GetNotifyRecovery returns whether the rule notifies the recoveries of the series.
+func (b *Base) GetNotifyRecovery() bool {
+	return b.NotifyRecovery
+}
+
+// GetEndpointID returns the id of the endpoint the notifications are sent to.
+func (b *Base) GetEndpointID() *influxdb.ID {
+	return b.EndpointID
+}
+
+// GetRoutes returns the routes sending the notifications to other endpoints.
+func (b *Base) GetRoutes() []influxdb.NotificationRoute {
+	return b.Routes
+}
+
+// EndpointAt returns the id of the endpoint a notification sent at t is
+// sent to, the endpoint of the first route whose schedule matches t or
+// EndpointID, nil if none.
+func (b *Base) EndpointAt(t time.Time) *influxdb.ID {
+	for _, r := range b.Routes {
+		if ok, err := r.Match(t); err == nil && ok {
+			id := r.EndpointID
+			return &id
+		}
+	}
+	return b.EndpointID
+}
+
+// GetEndpointIDs returns the ids of every endpoint the rule sends to,
+// EndpointID first.
+func (b *Base) GetEndpointIDs() []influxdb.ID {
+	var ids []influxdb.ID
+	seen := make(map[influxdb.ID]bool, len(b.Routes)+1)
+	if b.EndpointID != nil {
+		ids = append(ids, *b.EndpointID)
+		seen[*b.EndpointID] = true
+	}
+	for _, r := range b.Routes {
+		if !seen[r.EndpointID] {
+			ids = append(ids, r.EndpointID)
+			seen[r.EndpointID] = true
+		}
+	}
+	return ids
+}
+
+// GetAuthorizationID returns the authorization the notifications are sent with.
+func (b *Base) GetAuthorizationID() influxdb.ID {
+	return b.AuthorizationID
+}
+
+// GetTagRules returns the tag rules the statuses must match.
+func (b *Base) GetTagRules() []notification.TagRule {
+	return b.TagRules
+}
+
+// GetStatusRules returns the status rules the levels of the statuses must match.
+func (b *Base) GetStatusRules() []notification.StatusRule {
+	return b.StatusRules
+}
+
 // SetID will set the primary key.
 func (b *Base) SetID(id influxdb.ID) {
 	b.ID = id
@@ -174,3 +266,18 @@ func (b *Base) SetDescription(description string) {
 func (b *Base) SetStatus(status influxdb.Status) {
 	b.Status = status
 }
+
+// SetEndpointID sets the endpoint the notifications are sent to, none if id is nil.
+func (b *Base) SetEndpointID(id *influxdb.ID) {
+	b.EndpointID = id
+}
+
+// SetRoutes sets the routes sending the notifications to other endpoints.
+func (b *Base) SetRoutes(routes []influxdb.NotificationRoute) {
+	b.Routes = routes
+}
+
+// SetAuthorizationID sets the authorization the notifications are sent with.
+func (b *Base) SetAuthorizationID(id influxdb.ID) {
+	b.AuthorizationID = id
+}
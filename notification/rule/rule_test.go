@@ -113,6 +113,24 @@ func TestValidRule(t *testing.T) {
 				Msg:  "invalid status",
 			},
 		},
+		{
+			name: "unsupported locale",
+			src: &rule.Slack{
+				Base: rule.Base{
+					ID:              influxTesting.MustIDBase16(id1),
+					Name:            "name1",
+					AuthorizationID: influxTesting.MustIDBase16(id2),
+					OrgID:           influxTesting.MustIDBase16(id3),
+					Status:          influxdb.Active,
+					Locale:          "tlh",
+				},
+				MessageTemplate: "msg1",
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "unsupported locale tlh, supported locales are de, en, es, fr",
+			},
+		},
 		{
 			name: "empty slack message",
 			src: &rule.Slack{
@@ -166,6 +184,104 @@ func TestValidRule(t *testing.T) {
 				Msg:  "pagerduty invalid message template",
 			},
 		},
+		{
+			name: "empty alerta message",
+			src: &rule.Alerta{
+				Base: goodBase,
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "alerta msg template is empty",
+			},
+		},
+		{
+			name: "empty grafana oncall message",
+			src: &rule.GrafanaOnCall{
+				Base: goodBase,
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "grafana oncall msg template is empty",
+			},
+		},
+		{
+			name: "empty mqtt message",
+			src: &rule.MQTT{
+				Base: goodBase,
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "mqtt msg template is empty",
+			},
+		},
+		{
+			name: "empty kafka message",
+			src: &rule.Kafka{
+				Base: goodBase,
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "kafka msg template is empty",
+			},
+		},
+		{
+			name: "empty sns message",
+			src: &rule.SNS{
+				Base: goodBase,
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "sns msg template is empty",
+			},
+		},
+		{
+			name: "empty pubsub message",
+			src: &rule.PubSub{
+				Base: goodBase,
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "pubsub msg template is empty",
+			},
+		},
+		{
+			name: "empty exec message",
+			src: &rule.Exec{
+				Base: goodBase,
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "exec msg template is empty",
+			},
+		},
+		{
+			name: "bad pagerDuty severity mapping level",
+			src: &rule.PagerDuty{
+				Base:        goodBase,
+				MessageTemp: "msg1",
+				SeverityMapping: map[string]string{
+					"BAD": "error",
+				},
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  `pagerduty severity mapping has invalid check level "BAD"`,
+			},
+		},
+		{
+			name: "bad pagerDuty severity",
+			src: &rule.PagerDuty{
+				Base:        goodBase,
+				MessageTemp: "msg1",
+				SeverityMapping: map[string]string{
+					"WARN": "bad",
+				},
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  `pagerduty severity mapping has invalid severity "bad"`,
+			},
+		},
 		{
 			name: "bad tag rule",
 			src: &rule.SMTP{
@@ -225,6 +341,31 @@ func TestValidRule(t *testing.T) {
 				Msg:  `if limit is set, limit and limitEvery must be larger than 0`,
 			},
 		},
+		{
+			name: "bad route",
+			src: &rule.Slack{
+				Base: rule.Base{
+					ID:              influxTesting.MustIDBase16(id1),
+					AuthorizationID: influxTesting.MustIDBase16(id2),
+					OrgID:           influxTesting.MustIDBase16(id3),
+					Name:            "name1",
+					Status:          influxdb.Active,
+					Routes: []influxdb.NotificationRoute{
+						{
+							EndpointID: influxTesting.MustIDBase16(id1),
+							Days:       []string{"mon"},
+							Start:      "9am",
+							End:        "17:00",
+						},
+					},
+				},
+				MessageTemplate: "msg1",
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  `notification route start "9am" is not a time of day formatted as 15:04`,
+			},
+		},
 	}
 	for _, c := range cases {
 		got := c.src.Valid()
@@ -354,6 +495,9 @@ func TestJSON(t *testing.T) {
 					},
 				},
 				MessageTemp: "msg1",
+				SeverityMapping: map[string]string{
+					"WARN": "error",
+				},
 			},
 		},
 	}
@@ -371,3 +515,33 @@ func TestJSON(t *testing.T) {
 		}
 	}
 }
+
+func TestBase_EndpointAt(t *testing.T) {
+	slackID, pagerDutyID := influxTesting.MustIDBase16(id1), influxTesting.MustIDBase16(id2)
+	b := rule.Base{
+		EndpointID: &pagerDutyID,
+		Routes: []influxdb.NotificationRoute{
+			{
+				EndpointID: slackID,
+				Days:       []string{"mon", "tue", "wed", "thu", "fri"},
+				Start:      "09:00",
+				End:        "17:00",
+			},
+		},
+	}
+
+	// 2019-08-02 is a friday.
+	if got := b.EndpointAt(time.Date(2019, 8, 2, 10, 0, 0, 0, time.UTC)); got == nil || *got != slackID {
+		t.Errorf("expected business hours to be routed to slack, got %v", got)
+	}
+	if got := b.EndpointAt(time.Date(2019, 8, 2, 22, 0, 0, 0, time.UTC)); got == nil || *got != pagerDutyID {
+		t.Errorf("expected nights to be sent to pagerduty, got %v", got)
+	}
+	b.EndpointID = nil
+	if got := b.EndpointAt(time.Date(2019, 8, 3, 10, 0, 0, 0, time.UTC)); got != nil {
+		t.Errorf("expected no endpoint on the weekend, got %v", got)
+	}
+	if diff := cmp.Diff(b.GetEndpointIDs(), []influxdb.ID{slackID}); diff != "" {
+		t.Errorf("unexpected endpoints -got/+want\n%s", diff)
+	}
+}
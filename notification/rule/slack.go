@@ -11,6 +11,9 @@ type Slack struct {
 	Base
 	Channel         string `json:"channel"`
 	MessageTemplate string `json:"messageTemplate"`
+	// PlainText disables the Block Kit formatting,
+	// only the rendered message is sent.
+	PlainText bool `json:"plainText,omitempty"`
 }
 
 type slackAlias Slack
@@ -45,3 +48,8 @@ func (c Slack) Valid() error {
 func (c Slack) Type() string {
 	return "slack"
 }
+
+// GetMessageTemplate returns the template of the messages of the rule.
+func (c Slack) GetMessageTemplate() string {
+	return c.MessageTemplate
+}
@@ -0,0 +1,51 @@
+package rule
+
+import (
+	"encoding/json"
+
+	"github.com/influxdata/influxdb"
+)
+
+// SNS is the notification rule config of aws sns.
+type SNS struct {
+	Base
+	MessageTemplate string `json:"messageTemplate"`
+}
+
+type snsAlias SNS
+
+// MarshalJSON implement json.Marshaler interface.
+func (c SNS) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		struct {
+			snsAlias
+			Type string `json:"type"`
+		}{
+			snsAlias: snsAlias(c),
+			Type:     c.Type(),
+		})
+}
+
+// Valid returns where the config is valid.
+func (c SNS) Valid() error {
+	if err := c.Base.valid(); err != nil {
+		return err
+	}
+	if c.MessageTemplate == "" {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "sns msg template is empty",
+		}
+	}
+	return nil
+}
+
+// Type returns the type of the rule config.
+func (c SNS) Type() string {
+	return "sns"
+}
+
+// GetMessageTemplate returns the template of the messages of the rule.
+func (c SNS) GetMessageTemplate() string {
+	return c.MessageTemplate
+}
@@ -0,0 +1,107 @@
+package sender
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/endpoint"
+)
+
+var _ Sender = (*Alerta)(nil)
+
+// alertaSeverities are the alerta severities of check levels.
+var alertaSeverities = map[notification.CheckLevel]string{
+	notification.Critical: "critical",
+	notification.Warn:     "warning",
+	notification.Info:     "informational",
+	notification.Ok:       "ok",
+	notification.Unknown:  "unknown",
+}
+
+// Alerta sends notifications to the alerta api.
+type Alerta struct {
+	Config
+}
+
+type alertaAlert struct {
+	Resource    string            `json:"resource"`
+	Event       string            `json:"event"`
+	Environment string            `json:"environment"`
+	Severity    string            `json:"severity"`
+	Service     []string          `json:"service,omitempty"`
+	Value       string            `json:"value,omitempty"`
+	Text        string            `json:"text"`
+	Tags        []string          `json:"tags,omitempty"`
+	Attributes  map[string]string `json:"attributes,omitempty"`
+	Origin      string            `json:"origin"`
+	Type        string            `json:"type"`
+	CreateTime  string            `json:"createTime,omitempty"`
+}
+
+// Send implements Sender interface.
+func (a *Alerta) Send(ctx context.Context, n *Notification) error {
+	edp, ok := n.Endpoint.(*endpoint.Alerta)
+	if !ok {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("alerta sender can't send to %s endpoint", n.Endpoint.Type()),
+		}
+	}
+
+	apiKey, err := a.secretValue(ctx, edp.OrgID, edp.APIKey)
+	if err != nil {
+		return err
+	}
+
+	header := http.Header{}
+	if apiKey != "" {
+		header.Set("Authorization", "Key "+apiKey)
+	}
+	return a.postJSON(ctx, "alerta", strings.TrimSuffix(edp.URL, "/")+"/alert", a.alert(edp, n), header)
+}
+
+func (a *Alerta) alert(edp *endpoint.Alerta, n *Notification) alertaAlert {
+	alert := alertaAlert{
+		Resource:    n.Status.CheckName,
+		Event:       n.Status.CheckName,
+		Environment: edp.Environment,
+		Severity:    alertaSeverities[n.Status.Level],
+		Text:        n.message(),
+		Origin:      "influxdb",
+		Type:        "influxdbAlert",
+	}
+
+	tags := make([]string, 0, len(n.Status.Tags))
+	for _, k := range sortedTagKeys(n.Status.Tags) {
+		tags = append(tags, k+"="+n.Status.Tags[k])
+	}
+	if len(tags) > 0 {
+		alert.Tags = tags
+		// Alerta de-duplicates alerts on environment, resource and event,
+		// the tag set makes each series of the check its own resource.
+		alert.Resource = strings.Join(tags, ",")
+	}
+
+	if env, ok := n.Status.Tags[edp.EnvironmentTag]; ok && edp.EnvironmentTag != "" {
+		alert.Environment = env
+	}
+	if svc, ok := n.Status.Tags[edp.ServiceTag]; ok && edp.ServiceTag != "" {
+		alert.Service = []string{svc}
+	}
+	if n.Status.Value != nil {
+		alert.Value = fmt.Sprint(*n.Status.Value)
+	}
+	if u := checkURL(a.BaseURL, n.Status); u != "" {
+		alert.Attributes = map[string]string{
+			"checkURL": u,
+		}
+	}
+	if !n.Status.Time.IsZero() {
+		alert.CreateTime = n.Status.Time.UTC().Format("2006-01-02T15:04:05.000Z")
+	}
+	return alert
+}
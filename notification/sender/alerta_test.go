@@ -0,0 +1,102 @@
+package sender_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/endpoint"
+	"github.com/influxdata/influxdb/notification/rule"
+	"github.com/influxdata/influxdb/notification/sender"
+)
+
+func TestAlertaSend(t *testing.T) {
+	var (
+		body []byte
+		auth string
+		path string
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		path = r.URL.Path
+		body, _ = ioutil.ReadAll(r.Body)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer ts.Close()
+
+	apiKey := "api-key-1"
+	value := 91.5
+	s, err := sender.New("alerta", sender.Config{Client: ts.Client()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.Send(context.Background(), &sender.Notification{
+		Status: notification.Status{
+			CheckID:   influxdb.ID(2),
+			CheckName: "cpu usage",
+			OrgID:     influxdb.ID(3),
+			Level:     notification.Warn,
+			Value:     &value,
+			Tags: map[string]string{
+				"host": "server01",
+				"env":  "staging",
+				"svc":  "web",
+			},
+			Time: time.Date(2006, time.July, 13, 4, 19, 10, 0, time.UTC),
+		},
+		Rule: &rule.Alerta{MessageTemplate: "msg1"},
+		Endpoint: &endpoint.Alerta{
+			Base: endpoint.Base{
+				ID:    influxdb.ID(1),
+				OrgID: influxdb.ID(3),
+			},
+			URL:            ts.URL + "/api/",
+			APIKey:         influxdb.SecretField{Value: &apiKey},
+			Environment:    "production",
+			EnvironmentTag: "env",
+			ServiceTag:     "svc",
+		},
+		Message: "cpu usage is high",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if path != "/api/alert" {
+		t.Errorf("unexpected path %q", path)
+	}
+	if auth != "Key api-key-1" {
+		t.Errorf("unexpected authorization header %q", auth)
+	}
+
+	want := `{
+		"resource": "env=staging,host=server01,svc=web",
+		"event": "cpu usage",
+		"environment": "staging",
+		"severity": "warning",
+		"service": ["web"],
+		"value": "91.5",
+		"text": "cpu usage is high",
+		"tags": ["env=staging", "host=server01", "svc=web"],
+		"origin": "influxdb",
+		"type": "influxdbAlert",
+		"createTime": "2006-07-13T04:19:10.000Z"
+	}`
+	var gotJSON, wantJSON interface{}
+	if err := json.Unmarshal(body, &gotJSON); err != nil {
+		t.Fatalf("invalid request body: %v", err)
+	}
+	if err := json.Unmarshal([]byte(want), &wantJSON); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(gotJSON, wantJSON); diff != "" {
+		t.Errorf("alerta alert is different -got/+want\ndiff %s", diff)
+	}
+}
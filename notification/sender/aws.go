@@ -0,0 +1,187 @@
+package sender
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/influxdata/influxdb"
+)
+
+const (
+	awsDateFormat    = "20060102T150405Z"
+	awsSignAlgorithm = "AWS4-HMAC-SHA256"
+	awsSTSVersion    = "2011-06-15"
+)
+
+type awsCredentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+}
+
+// awsSignV4 signs req with aws signature version 4,
+// every header of req and its host are signed.
+func awsSignV4(req *http.Request, body []byte, creds awsCredentials, service, region string, now time.Time) {
+	amzDate := now.UTC().Format(awsDateFormat)
+	req.Header.Set("X-Amz-Date", amzDate)
+	if creds.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", creds.SessionToken)
+	}
+
+	headers := map[string]string{
+		"host": req.URL.Host,
+	}
+	for k, vs := range req.Header {
+		headers[strings.ToLower(k)] = strings.TrimSpace(strings.Join(vs, ","))
+	}
+	keys := make([]string, 0, len(headers))
+	for k := range headers {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var canonicalHeaders strings.Builder
+	for _, k := range keys {
+		canonicalHeaders.WriteString(k + ":" + headers[k] + "\n")
+	}
+	signedHeaders := strings.Join(keys, ";")
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	bodyHash := sha256.Sum256(body)
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		strings.Replace(req.URL.Query().Encode(), "+", "%20", -1),
+		canonicalHeaders.String(),
+		signedHeaders,
+		hex.EncodeToString(bodyHash[:]),
+	}, "\n")
+
+	scope := strings.Join([]string{amzDate[:8], region, service, "aws4_request"}, "/")
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		awsSignAlgorithm,
+		amzDate,
+		scope,
+		hex.EncodeToString(requestHash[:]),
+	}, "\n")
+
+	key := []byte("AWS4" + creds.SecretAccessKey)
+	for _, s := range []string{amzDate[:8], region, service, "aws4_request"} {
+		key = hmacSHA256(key, s)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", awsSignAlgorithm+
+		" Credential="+creds.AccessKeyID+"/"+scope+
+		", SignedHeaders="+signedHeaders+
+		", Signature="+signature)
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// awsServiceURL returns the regional url of an aws service.
+func awsServiceURL(service, partition, region string) string {
+	domain := "amazonaws.com"
+	if partition == "aws-cn" {
+		domain = "amazonaws.com.cn"
+	}
+	return "https://" + service + "." + region + "." + domain + "/"
+}
+
+type awsErrorResponse struct {
+	Code    string `xml:"Error>Code"`
+	Message string `xml:"Error>Message"`
+}
+
+// awsQuery calls an action of an aws query api, such as sns or sts,
+// and decodes the xml response into v.
+func (c Config) awsQuery(ctx context.Context, serviceURL, service, region string, creds awsCredentials, params url.Values, v interface{}) error {
+	body := []byte(params.Encode())
+	req, err := http.NewRequest(http.MethodPost, serviceURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+	awsSignV4(req, body, creds, service, region, c.now())
+
+	resp, err := c.client().Do(req)
+	if err != nil {
+		return &influxdb.Error{
+			Code: influxdb.EUnavailable,
+			Msg:  "failed to call " + service,
+			Err:  err,
+		}
+	}
+	defer resp.Body.Close()
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode/100 != 2 {
+		var e awsErrorResponse
+		if xml.Unmarshal(b, &e) != nil || e.Code == "" {
+			return unexpectedStatusError(service, resp.StatusCode)
+		}
+		code := influxdb.EUnavailable
+		if resp.StatusCode/100 == 4 {
+			code = influxdb.EInvalid
+		}
+		return &influxdb.Error{
+			Code: code,
+			Msg:  service + " responded with " + e.Code + ": " + e.Message,
+		}
+	}
+	if v == nil {
+		return nil
+	}
+	return xml.Unmarshal(b, v)
+}
+
+type awsAssumeRoleResponse struct {
+	Credentials struct {
+		AccessKeyID     string `xml:"AccessKeyId"`
+		SecretAccessKey string `xml:"SecretAccessKey"`
+		SessionToken    string `xml:"SessionToken"`
+	} `xml:"AssumeRoleResult>Credentials"`
+}
+
+// awsAssumeRole returns the temporary credentials of an iam role.
+func (c Config) awsAssumeRole(ctx context.Context, stsURL, region string, creds awsCredentials, roleARN, sessionName string) (awsCredentials, error) {
+	var resp awsAssumeRoleResponse
+	err := c.awsQuery(ctx, stsURL, "sts", region, creds, url.Values{
+		"Action":          {"AssumeRole"},
+		"Version":         {awsSTSVersion},
+		"RoleArn":         {roleARN},
+		"RoleSessionName": {sessionName},
+		// the credentials are only used for a single publish.
+		"DurationSeconds": {"900"},
+	}, &resp)
+	if err != nil {
+		return awsCredentials{}, err
+	}
+	return awsCredentials{
+		AccessKeyID:     resp.Credentials.AccessKeyID,
+		SecretAccessKey: resp.Credentials.SecretAccessKey,
+		SessionToken:    resp.Credentials.SessionToken,
+	}, nil
+}
@@ -0,0 +1,50 @@
+package sender
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAWSSignV4(t *testing.T) {
+	// test vectors of the aws signature version 4 test suite.
+	creds := awsCredentials{
+		AccessKeyID:     "AKIDEXAMPLE",
+		SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+	}
+	now := time.Date(2015, time.August, 30, 12, 36, 0, 0, time.UTC)
+	cases := []struct {
+		name        string
+		method      string
+		body        string
+		contentType string
+		want        string
+	}{
+		{
+			name:   "get vanilla",
+			method: http.MethodGet,
+			want:   "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, SignedHeaders=host;x-amz-date, Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31",
+		},
+		{
+			name:        "post x-www-form-urlencoded",
+			method:      http.MethodPost,
+			body:        "Param1=value1",
+			contentType: "application/x-www-form-urlencoded",
+			want:        "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, SignedHeaders=content-type;host;x-amz-date, Signature=ff11897932ad3f4e8b18135d722051e5ac45fc38421b1da7b9d196a0fe09473a",
+		},
+	}
+	for _, c := range cases {
+		req, err := http.NewRequest(c.method, "https://example.amazonaws.com/", strings.NewReader(c.body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c.contentType != "" {
+			req.Header.Set("Content-Type", c.contentType)
+		}
+		awsSignV4(req, []byte(c.body), creds, "service", "us-east-1", now)
+		if got := req.Header.Get("Authorization"); got != c.want {
+			t.Errorf("%s: unexpected authorization header\ngot:  %s\nwant: %s", c.name, got, c.want)
+		}
+	}
+}
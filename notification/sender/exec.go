@@ -0,0 +1,175 @@
+package sender
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification/endpoint"
+)
+
+var _ Sender = (*Exec)(nil)
+
+const (
+	// DefaultExecTimeout is the maximum time an exec command runs for
+	// when the operator doesn't configure one.
+	DefaultExecTimeout = 10 * time.Second
+	// DefaultExecMaxConcurrency is the maximum number of exec commands
+	// running at once when the operator doesn't configure one.
+	DefaultExecMaxConcurrency = 4
+
+	// execStderrLimit bounds the stderr of a command kept for error messages.
+	execStderrLimit = 4096
+)
+
+// ExecConfig is the operator configuration of exec endpoints.
+// It is shared by all exec senders so the concurrency cap is global.
+type ExecConfig struct {
+	// AllowedCommands are the command lines exec endpoints may run: the
+	// absolute path of a command followed by its arguments, separated by
+	// spaces. Exec endpoints are disabled when empty.
+	AllowedCommands []string
+	// Timeout is the maximum time a command runs for.
+	Timeout time.Duration
+	// MaxConcurrency is the maximum number of commands running at once.
+	MaxConcurrency int
+
+	once sync.Once
+	sem  chan struct{}
+}
+
+func (c *ExecConfig) timeout() time.Duration {
+	if c.Timeout <= 0 {
+		return DefaultExecTimeout
+	}
+	return c.Timeout
+}
+
+// acquire waits for a free slot to run a command.
+func (c *ExecConfig) acquire(ctx context.Context) (release func(), err error) {
+	c.once.Do(func() {
+		n := c.MaxConcurrency
+		if n <= 0 {
+			n = DefaultExecMaxConcurrency
+		}
+		c.sem = make(chan struct{}, n)
+	})
+	select {
+	case c.sem <- struct{}{}:
+		return func() { <-c.sem }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Exec runs a local command with the status event as json on its stdin,
+// for sites without outbound network access.
+// The command doesn't inherit the environment of the server, it gets
+// the check, level, rule and endpoint of the status as INFLUXDB_ variables.
+type Exec struct {
+	Config
+}
+
+// Send implements Sender interface.
+func (e *Exec) Send(ctx context.Context, n *Notification) error {
+	edp, ok := n.Endpoint.(*endpoint.Exec)
+	if !ok {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("exec sender can't send to %s endpoint", n.Endpoint.Type()),
+		}
+	}
+
+	cfg := e.Exec
+	if cfg == nil {
+		cfg = &ExecConfig{}
+	}
+	if err := edp.CommandAllowed(cfg.AllowedCommands); err != nil {
+		return err
+	}
+
+	timeout := cfg.timeout()
+	if edp.Timeout.Duration > 0 && edp.Timeout.Duration < timeout {
+		timeout = edp.Timeout.Duration
+	}
+
+	payload, err := json.Marshal(newStatusEvent(n))
+	if err != nil {
+		return err
+	}
+
+	release, err := cfg.acquire(ctx)
+	if err != nil {
+		return &influxdb.Error{
+			Code: influxdb.ETooManyRequests,
+			Msg:  "too many exec notifications running",
+			Err:  err,
+		}
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	stderr := &prefixWriter{n: execStderrLimit}
+	cmd := exec.CommandContext(ctx, edp.Command, edp.Args...)
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Stderr = stderr
+	cmd.Env = execEnv(n)
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return &influxdb.Error{
+				Code: influxdb.EUnavailable,
+				Msg:  fmt.Sprintf("exec command timed out after %s", timeout),
+			}
+		}
+		msg := "exec command failed"
+		if s := strings.TrimSpace(stderr.buf.String()); s != "" {
+			msg += ": " + s
+		}
+		return &influxdb.Error{
+			Code: influxdb.EUnavailable,
+			Msg:  msg,
+			Err:  err,
+		}
+	}
+	return nil
+}
+
+func execEnv(n *Notification) []string {
+	env := []string{
+		"PATH=/usr/local/bin:/usr/bin:/bin",
+		"INFLUXDB_CHECK_ID=" + n.Status.CheckID.String(),
+		"INFLUXDB_CHECK_NAME=" + n.Status.CheckName,
+		"INFLUXDB_LEVEL=" + n.Status.Level.String(),
+		"INFLUXDB_ENDPOINT_ID=" + n.Endpoint.GetID().String(),
+	}
+	if n.Rule != nil {
+		env = append(env, "INFLUXDB_RULE_ID="+n.Rule.GetID().String())
+	}
+	return env
+}
+
+// prefixWriter keeps the first n bytes written to it.
+type prefixWriter struct {
+	buf bytes.Buffer
+	n   int
+}
+
+func (w *prefixWriter) Write(p []byte) (int, error) {
+	if rem := w.n - w.buf.Len(); rem > 0 {
+		if len(p) > rem {
+			w.buf.Write(p[:rem])
+		} else {
+			w.buf.Write(p)
+		}
+	}
+	return len(p), nil
+}
@@ -0,0 +1,170 @@
+package sender_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/endpoint"
+	"github.com/influxdata/influxdb/notification/rule"
+	"github.com/influxdata/influxdb/notification/sender"
+)
+
+const execScript = `#!/bin/sh
+case "$1" in
+fail)
+	echo "no route to pager" >&2
+	exit 1
+	;;
+sleep)
+	exec sleep 5
+	;;
+*)
+	cat > "$1"
+	echo "$INFLUXDB_LEVEL $INFLUXDB_CHECK_ID $HOME" > "$1.env"
+	;;
+esac
+`
+
+func TestExecSend(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("exec endpoints run shell scripts in this test")
+	}
+
+	dir, err := ioutil.TempDir("", "exec-sender")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	script := filepath.Join(dir, "notify.sh")
+	if err := ioutil.WriteFile(script, []byte(execScript), 0700); err != nil {
+		t.Fatal(err)
+	}
+	out := filepath.Join(dir, "out.json")
+
+	cases := []struct {
+		name    string
+		cfg     *sender.ExecConfig
+		command string
+		args    []string
+		timeout time.Duration
+		code    string
+		msg     string
+	}{
+		{
+			name:    "writes event to stdin",
+			cfg:     &sender.ExecConfig{AllowedCommands: []string{script + " " + out}},
+			command: script,
+			args:    []string{out},
+		},
+		{
+			name:    "arguments not allowed",
+			cfg:     &sender.ExecConfig{AllowedCommands: []string{script + " " + out}},
+			command: script,
+			args:    []string{out, "; rm -rf /"},
+			code:    influxdb.EForbidden,
+			msg:     "exec endpoint command " + script + " with arguments [\"" + out + "\" \"; rm -rf /\"] is not allowed",
+		},
+		{
+			name:    "disabled",
+			command: script,
+			code:    influxdb.EForbidden,
+			msg:     "exec notification endpoints are disabled",
+		},
+		{
+			name:    "command not allowed",
+			cfg:     &sender.ExecConfig{AllowedCommands: []string{"/usr/local/bin/notify"}},
+			command: script,
+			code:    influxdb.EForbidden,
+			msg:     "exec endpoint command " + script + " is not allowed",
+		},
+		{
+			name:    "command fails",
+			cfg:     &sender.ExecConfig{AllowedCommands: []string{script + " fail"}},
+			command: script,
+			args:    []string{"fail"},
+			code:    influxdb.EUnavailable,
+			msg:     "exec command failed: no route to pager",
+		},
+		{
+			name:    "command times out",
+			cfg:     &sender.ExecConfig{AllowedCommands: []string{script + " sleep"}},
+			command: script,
+			args:    []string{"sleep"},
+			timeout: 100 * time.Millisecond,
+			code:    influxdb.EUnavailable,
+			msg:     "exec command timed out after 100ms",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			s, err := sender.New("exec", sender.Config{Exec: c.cfg})
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = s.Send(context.Background(), &sender.Notification{
+				Status: notification.Status{
+					CheckID:   influxdb.ID(2),
+					CheckName: "cpu",
+					OrgID:     influxdb.ID(3),
+					Level:     notification.Critical,
+				},
+				Rule: &rule.Exec{
+					Base: rule.Base{
+						ID: influxdb.ID(4),
+					},
+					MessageTemplate: "msg1",
+				},
+				Endpoint: &endpoint.Exec{
+					Base: endpoint.Base{
+						ID: influxdb.ID(1),
+					},
+					Command: c.command,
+					Args:    c.args,
+					Timeout: influxdb.Duration{Duration: c.timeout},
+				},
+				Message: "cpu is high",
+			})
+			if c.code != "" {
+				if influxdb.ErrorCode(err) != c.code || influxdb.ErrorMessage(err) != c.msg {
+					t.Fatalf("expected %s error %q, got %v", c.code, c.msg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			b, err := ioutil.ReadFile(out)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ev := map[string]interface{}{}
+			if err := json.Unmarshal(b, &ev); err != nil {
+				t.Fatalf("invalid stdin: %v", err)
+			}
+			if ev["message"] != "cpu is high" || ev["ruleID"] != "0000000000000004" {
+				t.Errorf("unexpected stdin %v", ev)
+			}
+
+			env, err := ioutil.ReadFile(out + ".env")
+			if err != nil {
+				t.Fatal(err)
+			}
+			// the environment of the server is not inherited.
+			if got := strings.TrimSpace(string(env)); got != "CRIT 0000000000000002" {
+				t.Errorf("unexpected environment %q", got)
+			}
+		})
+	}
+}
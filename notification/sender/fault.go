@@ -0,0 +1,115 @@
+package sender
+
+import (
+	"context"
+	"net"
+	"os"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/influxdata/influxdb"
+)
+
+// Fault is a failure injected in the delivery of a notification, as if the
+// endpoint misbehaved. A fault without a status code nor a reset only delays
+// the delivery.
+type Fault struct {
+	// Delay holds the delivery back, as a slow endpoint does, unless the
+	// context of the delivery is done first.
+	Delay time.Duration
+	// StatusCode fails the delivery as if the endpoint responded with it,
+	// such as 503.
+	StatusCode int
+	// Reset fails the delivery as if the endpoint reset the connection.
+	Reset bool
+}
+
+// FaultInjector decides the faults of the deliveries of the notifications,
+// so that tests can make the endpoints misbehave deterministically.
+type FaultInjector interface {
+	// Fault returns the fault of the delivery of n, nil delivers it.
+	Fault(ctx context.Context, n *Notification) *Fault
+}
+
+// FaultScript injects its faults in order, one per delivery, then lets the
+// deliveries through. A nil fault lets its delivery through.
+type FaultScript struct {
+	mu     sync.Mutex
+	faults []*Fault
+	next   int
+}
+
+// NewFaultScript returns a script of faults.
+func NewFaultScript(faults ...*Fault) *FaultScript {
+	return &FaultScript{faults: faults}
+}
+
+// Fault implements FaultInjector.
+func (s *FaultScript) Fault(ctx context.Context, n *Notification) *Fault {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.next >= len(s.faults) {
+		return nil
+	}
+	f := s.faults[s.next]
+	s.next++
+	return f
+}
+
+// Injected returns how many deliveries went through the script.
+func (s *FaultScript) Injected() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.next
+}
+
+// Repeat returns n times f, such as a burst of 5xx responses.
+func Repeat(f *Fault, n int) []*Fault {
+	fs := make([]*Fault, n)
+	for i := range fs {
+		fs[i] = f
+	}
+	return fs
+}
+
+// faultySender injects the faults of its injector in the deliveries of the
+// wrapped sender.
+type faultySender struct {
+	Sender
+	service string
+	faults  FaultInjector
+}
+
+// Send implements Sender, the faults fail the delivery with the errors of
+// a misbehaving endpoint, the wrapped sender isn't called.
+func (s *faultySender) Send(ctx context.Context, n *Notification) error {
+	f := s.faults.Fault(ctx, n)
+	if f == nil {
+		return s.Sender.Send(ctx, n)
+	}
+	if f.Delay > 0 {
+		t := time.NewTimer(f.Delay)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return &influxdb.Error{
+				Code: influxdb.EUnavailable,
+				Msg:  "failed to send " + s.service + " notification",
+				Err:  ctx.Err(),
+			}
+		case <-t.C:
+		}
+	}
+	switch {
+	case f.Reset:
+		return &influxdb.Error{
+			Code: influxdb.EUnavailable,
+			Msg:  "failed to send " + s.service + " notification",
+			Err:  &net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)},
+		}
+	case f.StatusCode != 0:
+		return unexpectedStatusError(s.service, f.StatusCode)
+	}
+	return s.Sender.Send(ctx, n)
+}
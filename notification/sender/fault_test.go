@@ -0,0 +1,91 @@
+package sender_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/endpoint"
+	"github.com/influxdata/influxdb/notification/rule"
+	"github.com/influxdata/influxdb/notification/sender"
+)
+
+func TestFaultScript(t *testing.T) {
+	var received int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&received, 1)
+	}))
+	defer srv.Close()
+
+	// a reset, a burst of 503s, a delivery and a slow delivery.
+	faults := []*sender.Fault{{Reset: true}}
+	faults = append(faults, sender.Repeat(&sender.Fault{StatusCode: http.StatusServiceUnavailable}, 2)...)
+	faults = append(faults, nil, &sender.Fault{Delay: 10 * time.Millisecond})
+	script := sender.NewFaultScript(faults...)
+	s, err := sender.New("slack", sender.Config{Faults: script})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	n := &sender.Notification{
+		Status:   notification.Status{CheckName: "cpu", Level: notification.Critical},
+		Rule:     &rule.Slack{},
+		Endpoint: &endpoint.Slack{URL: srv.URL},
+	}
+	ctx := context.Background()
+
+	err = s.Send(ctx, n)
+	if influxdb.ErrorCode(err) != influxdb.EUnavailable || !strings.Contains(err.Error(), syscall.ECONNRESET.Error()) {
+		t.Errorf("expected a connection reset, got %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		err = s.Send(ctx, n)
+		if want := "slack responded with unexpected status Service Unavailable"; influxdb.ErrorMessage(err) != want {
+			t.Errorf("expected %q, got %v", want, err)
+		}
+	}
+	if got := atomic.LoadInt32(&received); got != 0 {
+		t.Fatalf("expected the faults not to reach the endpoint, got %d deliveries", got)
+	}
+
+	if err := s.Send(ctx, n); err != nil {
+		t.Errorf("expected a nil fault to deliver the notification, got %v", err)
+	}
+	start := time.Now()
+	if err := s.Send(ctx, n); err != nil {
+		t.Errorf("expected a delay to deliver the notification, got %v", err)
+	}
+	if d := time.Since(start); d < 10*time.Millisecond {
+		t.Errorf("expected the delivery to be delayed, took %s", d)
+	}
+	if err := s.Send(ctx, n); err != nil {
+		t.Errorf("expected the deliveries after the script to go through, got %v", err)
+	}
+	if got := atomic.LoadInt32(&received); got != 3 {
+		t.Errorf("expected 3 deliveries, got %d", got)
+	}
+	if got := script.Injected(); got != 5 {
+		t.Errorf("expected the 5 faults of the script to be injected, got %d", got)
+	}
+}
+
+func TestFault_delayCanceled(t *testing.T) {
+	s, err := sender.New("slack", sender.Config{
+		Faults: sender.NewFaultScript(&sender.Fault{Delay: time.Hour}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err = s.Send(ctx, &sender.Notification{Endpoint: &endpoint.Slack{URL: "http://localhost"}})
+	if influxdb.ErrorCode(err) != influxdb.EUnavailable {
+		t.Fatalf("expected the slow delivery to time out, got %v", err)
+	}
+}
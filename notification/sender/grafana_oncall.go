@@ -0,0 +1,55 @@
+package sender
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/endpoint"
+)
+
+var _ Sender = (*GrafanaOnCall)(nil)
+
+// GrafanaOnCall sends notifications to a grafana oncall formatted webhook integration.
+// OK statuses resolve the alert group of the same check and tag set.
+type GrafanaOnCall struct {
+	Config
+}
+
+type grafanaOnCallAlert struct {
+	AlertUID              string `json:"alert_uid"`
+	Title                 string `json:"title"`
+	State                 string `json:"state"`
+	Message               string `json:"message"`
+	LinkToUpstreamDetails string `json:"link_to_upstream_details,omitempty"`
+}
+
+// Send implements Sender interface.
+func (g *GrafanaOnCall) Send(ctx context.Context, n *Notification) error {
+	edp, ok := n.Endpoint.(*endpoint.GrafanaOnCall)
+	if !ok {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("grafana oncall sender can't send to %s endpoint", n.Endpoint.Type()),
+		}
+	}
+
+	url, err := g.secretValue(ctx, edp.OrgID, edp.URL)
+	if err != nil {
+		return err
+	}
+
+	state := "alerting"
+	if n.Status.Level == notification.Ok {
+		state = "ok"
+	}
+	alert := grafanaOnCallAlert{
+		AlertUID:              dedupKey(n.Status),
+		Title:                 fmt.Sprintf("[%s] %s", n.Status.Level, n.Status.CheckName),
+		State:                 state,
+		Message:               n.message(),
+		LinkToUpstreamDetails: checkURL(g.BaseURL, n.Status),
+	}
+	return g.postJSON(ctx, "grafana oncall", url, alert, nil)
+}
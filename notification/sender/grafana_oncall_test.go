@@ -0,0 +1,84 @@
+package sender_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/endpoint"
+	"github.com/influxdata/influxdb/notification/rule"
+	"github.com/influxdata/influxdb/notification/sender"
+)
+
+func TestGrafanaOnCallSend(t *testing.T) {
+	var alerts []map[string]string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		alert := map[string]string{}
+		if err := json.Unmarshal(b, &alert); err != nil {
+			t.Errorf("invalid request body: %v", err)
+		}
+		alerts = append(alerts, alert)
+	}))
+	defer ts.Close()
+
+	s, err := sender.New("grafanaoncall", sender.Config{
+		Client: ts.Client(),
+		SecretService: &mock.SecretService{
+			LoadSecretFn: func(ctx context.Context, orgID influxdb.ID, k string) (string, error) {
+				return ts.URL, nil
+			},
+		},
+		BaseURL: "http://localhost:9999",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	edp := &endpoint.GrafanaOnCall{
+		Base: endpoint.Base{
+			ID:    influxdb.ID(1),
+			OrgID: influxdb.ID(3),
+		},
+		URL: influxdb.SecretField{Key: "0000000000000001-url"},
+	}
+	for _, lvl := range []notification.CheckLevel{notification.Critical, notification.Ok} {
+		err := s.Send(context.Background(), &sender.Notification{
+			Status: notification.Status{
+				CheckID:   influxdb.ID(2),
+				CheckName: "cpu usage",
+				OrgID:     influxdb.ID(3),
+				Level:     lvl,
+				Tags:      map[string]string{"host": "server01"},
+			},
+			Rule:     &rule.GrafanaOnCall{MessageTemplate: "msg1"},
+			Endpoint: edp,
+			Message:  "cpu usage is " + lvl.String(),
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if len(alerts) != 2 {
+		t.Fatalf("expected 2 alerts, got %d", len(alerts))
+	}
+	if alerts[0]["alert_uid"] == "" || alerts[0]["alert_uid"] != alerts[1]["alert_uid"] {
+		t.Errorf("expected both alerts to share the same alert uid, got %q and %q", alerts[0]["alert_uid"], alerts[1]["alert_uid"])
+	}
+	if alerts[0]["state"] != "alerting" || alerts[1]["state"] != "ok" {
+		t.Errorf("unexpected states %q and %q", alerts[0]["state"], alerts[1]["state"])
+	}
+	if alerts[0]["title"] != "[CRIT] cpu usage" {
+		t.Errorf("unexpected title %q", alerts[0]["title"])
+	}
+	if want := "http://localhost:9999/orgs/0000000000000003/alerting/checks/0000000000000002/edit"; alerts[0]["link_to_upstream_details"] != want {
+		t.Errorf("unexpected upstream link %q", alerts[0]["link_to_upstream_details"])
+	}
+}
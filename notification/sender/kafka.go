@@ -0,0 +1,125 @@
+package sender
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/endpoint"
+	"github.com/segmentio/kafka-go"
+)
+
+var _ Sender = (*Kafka)(nil)
+
+// kafkaTimeout bounds the connection to the brokers.
+const kafkaTimeout = 10 * time.Second
+
+type kafkaWriter interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+// Kafka writes status events to a kafka topic.
+// Events are keyed by check and tag set, so the events of a series
+// land on the same partition and keep their order.
+type Kafka struct {
+	Config
+
+	// newWriter creates the writer of a topic, it is replaced in tests.
+	newWriter func(kafka.WriterConfig) kafkaWriter
+}
+
+// Send implements Sender interface.
+func (k *Kafka) Send(ctx context.Context, n *Notification) error {
+	edp, ok := n.Endpoint.(*endpoint.Kafka)
+	if !ok {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("kafka sender can't send to %s endpoint", n.Endpoint.Type()),
+		}
+	}
+
+	topic := notification.ExpandTemplate(edp.TopicTemplate, n.Status)
+	if topic == "" {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "kafka topic template expanded to an empty topic",
+		}
+	}
+
+	tlsConfig, err := k.tlsConfig(ctx, edp)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(newStatusEvent(n))
+	if err != nil {
+		return err
+	}
+
+	newWriter := k.newWriter
+	if newWriter == nil {
+		newWriter = func(cfg kafka.WriterConfig) kafkaWriter {
+			return kafka.NewWriter(cfg)
+		}
+	}
+	w := newWriter(kafka.WriterConfig{
+		Brokers: edp.Brokers,
+		Topic:   topic,
+		Dialer: &kafka.Dialer{
+			ClientID:  "influxdb",
+			Timeout:   kafkaTimeout,
+			DualStack: true,
+			TLS:       tlsConfig,
+		},
+		Balancer: &kafka.Hash{},
+	})
+	defer w.Close()
+
+	if err := w.WriteMessages(ctx, kafka.Message{
+		Key:   []byte(dedupKey(n.Status)),
+		Value: payload,
+	}); err != nil {
+		return &influxdb.Error{
+			Code: influxdb.EUnavailable,
+			Msg:  "failed to write kafka notification",
+			Err:  err,
+		}
+	}
+	return nil
+}
+
+func (k *Kafka) tlsConfig(ctx context.Context, edp *endpoint.Kafka) (*tls.Config, error) {
+	if !edp.TLS {
+		return nil, nil
+	}
+
+	cfg := &tls.Config{
+		InsecureSkipVerify: edp.InsecureSkipVerify,
+	}
+
+	cert, err := k.secretValue(ctx, edp.OrgID, edp.ClientCert)
+	if err != nil {
+		return nil, err
+	}
+	key, err := k.secretValue(ctx, edp.OrgID, edp.ClientKey)
+	if err != nil {
+		return nil, err
+	}
+	if cert != "" || key != "" {
+		pair, err := tls.X509KeyPair([]byte(cert), []byte(key))
+		if err != nil {
+			return nil, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "kafka client cert is invalid",
+				Err:  err,
+			}
+		}
+		cfg.Certificates = []tls.Certificate{pair}
+	}
+	return cfg, nil
+}
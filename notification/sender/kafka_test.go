@@ -0,0 +1,117 @@
+package sender
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/endpoint"
+	"github.com/influxdata/influxdb/notification/rule"
+	"github.com/segmentio/kafka-go"
+)
+
+type fakeKafkaWriter struct {
+	cfg    kafka.WriterConfig
+	msgs   []kafka.Message
+	closed bool
+}
+
+func (w *fakeKafkaWriter) WriteMessages(ctx context.Context, msgs ...kafka.Message) error {
+	w.msgs = append(w.msgs, msgs...)
+	return nil
+}
+
+func (w *fakeKafkaWriter) Close() error {
+	w.closed = true
+	return nil
+}
+
+func TestKafkaSend(t *testing.T) {
+	w := &fakeKafkaWriter{}
+	k := &Kafka{
+		newWriter: func(cfg kafka.WriterConfig) kafkaWriter {
+			w.cfg = cfg
+			return w
+		},
+	}
+	st := notification.Status{
+		CheckID:   influxdb.ID(2),
+		CheckName: "cpu",
+		OrgID:     influxdb.ID(3),
+		Level:     notification.Warn,
+		Tags:      map[string]string{"region": "west", "host": "server01"},
+	}
+	err := k.Send(context.Background(), &Notification{
+		Status: st,
+		Rule: &rule.Kafka{
+			Base: rule.Base{
+				ID:   influxdb.ID(4),
+				Name: "rule1",
+			},
+			MessageTemplate: "msg1",
+		},
+		Endpoint: &endpoint.Kafka{
+			Base: endpoint.Base{
+				ID:    influxdb.ID(1),
+				OrgID: influxdb.ID(3),
+			},
+			Brokers:       []string{"localhost:9092", "localhost:9093"},
+			TopicTemplate: "alerts-${r.region}",
+		},
+		Message: "cpu is high",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if w.cfg.Topic != "alerts-west" {
+		t.Errorf("unexpected topic %q", w.cfg.Topic)
+	}
+	if len(w.cfg.Brokers) != 2 {
+		t.Errorf("unexpected brokers %v", w.cfg.Brokers)
+	}
+	if w.cfg.Dialer.TLS != nil {
+		t.Errorf("expected no tls config")
+	}
+	if !w.closed {
+		t.Errorf("expected writer to be closed")
+	}
+	if len(w.msgs) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(w.msgs))
+	}
+	if key := string(w.msgs[0].Key); key != dedupKey(st) {
+		t.Errorf("unexpected key %q", key)
+	}
+	ev := map[string]interface{}{}
+	if err := json.Unmarshal(w.msgs[0].Value, &ev); err != nil {
+		t.Fatalf("invalid payload: %v", err)
+	}
+	if ev["message"] != "cpu is high" || ev["level"] != "WARN" || ev["ruleID"] != "0000000000000004" {
+		t.Errorf("unexpected payload %v", ev)
+	}
+}
+
+func TestKafkaSendInvalidClientCert(t *testing.T) {
+	cert, key := "not a cert", "not a key"
+	k := &Kafka{
+		newWriter: func(cfg kafka.WriterConfig) kafkaWriter {
+			t.Fatal("writer should not be created")
+			return nil
+		},
+	}
+	err := k.Send(context.Background(), &Notification{
+		Rule: &rule.Kafka{},
+		Endpoint: &endpoint.Kafka{
+			Brokers:       []string{"localhost:9092"},
+			TopicTemplate: "alerts",
+			TLS:           true,
+			ClientCert:    influxdb.SecretField{Value: &cert},
+			ClientKey:     influxdb.SecretField{Value: &key},
+		},
+	})
+	if influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Fatalf("expected invalid error, got %v", err)
+	}
+}
@@ -0,0 +1,125 @@
+package sender
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/influxdata/influxdb"
+)
+
+// Truncation is how a message over the maximum size of its endpoint is cut.
+type Truncation string
+
+// consts of Truncation
+const (
+	// TruncateEllipsis keeps the start of the message and ends it with an ellipsis.
+	TruncateEllipsis Truncation = "ellipsis"
+	// TruncateHead keeps the start of the message.
+	TruncateHead Truncation = "head"
+	// TruncateTail keeps the end of the message and starts it with an ellipsis.
+	TruncateTail Truncation = "tail"
+)
+
+// ellipsis marks where a message was cut.
+const ellipsis = "…"
+
+// slackMessageLimit is the maximum size of the text of a slack message.
+const slackMessageLimit = 4000
+
+// DefaultMessageSizes are the maximum sizes in bytes of the messages of the
+// endpoint types whose services reject or silently drop larger ones.
+var DefaultMessageSizes = map[string]int{
+	"slack":     slackMessageLimit,
+	"pagerduty": pagerDutySummaryLimit,
+}
+
+// MessageLimits is the operator configuration of the maximum sizes of the
+// messages of the notifications.
+type MessageLimits struct {
+	// MaxSize is the maximum size in bytes of the messages of the endpoint
+	// types without a size in MaxSizes nor DefaultMessageSizes, unlimited
+	// when 0.
+	MaxSize int
+	// MaxSizes override the maximum sizes of the messages by endpoint type,
+	// the messages of a type of size 0 are unlimited.
+	MaxSizes map[string]int
+	// Truncation is how the oversized messages are cut, TruncateEllipsis
+	// when empty.
+	Truncation Truncation
+}
+
+// Size returns the maximum size in bytes of the messages of an endpoint
+// type, 0 if they are unlimited.
+func (l MessageLimits) Size(typ string) int {
+	if n, ok := l.MaxSizes[typ]; ok {
+		return n
+	}
+	if n, ok := DefaultMessageSizes[typ]; ok {
+		return n
+	}
+	return l.MaxSize
+}
+
+// Truncate returns a message cut to the maximum size of the messages of an
+// endpoint type, and whether it was cut.
+func (l MessageLimits) Truncate(typ, msg string) (string, bool) {
+	size := l.Size(typ)
+	if size <= 0 || len(msg) <= size {
+		return msg, false
+	}
+	return truncate(msg, size, l.Truncation), true
+}
+
+// truncate cuts s to at most size bytes, without splitting a rune.
+func truncate(s string, size int, t Truncation) string {
+	mark := ellipsis
+	if t == TruncateHead || size <= len(ellipsis) {
+		mark = ""
+	}
+	n := size - len(mark)
+	if t == TruncateTail {
+		i := len(s) - n
+		for i < len(s) && !utf8.RuneStart(s[i]) {
+			i++
+		}
+		return mark + s[i:]
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n] + mark
+}
+
+// ParseTruncation returns the truncation named s, TruncateEllipsis if s is empty.
+func ParseTruncation(s string) (Truncation, error) {
+	switch t := Truncation(s); t {
+	case "":
+		return TruncateEllipsis, nil
+	case TruncateEllipsis, TruncateHead, TruncateTail:
+		return t, nil
+	}
+	return "", &influxdb.Error{
+		Code: influxdb.EInvalid,
+		Msg:  fmt.Sprintf("invalid message truncation %s, valid truncations are %s, %s and %s", s, TruncateEllipsis, TruncateHead, TruncateTail),
+	}
+}
+
+// ParseMessageSizes returns the maximum sizes of the messages by endpoint
+// type, formatted as type=size such as slack=4000.
+func ParseMessageSizes(kvs []string) (map[string]int, error) {
+	sizes := make(map[string]int, len(kvs))
+	for _, kv := range kvs {
+		i := strings.IndexByte(kv, '=')
+		if i <= 0 {
+			return nil, fmt.Errorf("message size %q must be formatted as type=size", kv)
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(kv[i+1:]))
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("message size %q must be a number of bytes, 0 for unlimited", kv)
+		}
+		sizes[strings.TrimSpace(kv[:i])] = n
+	}
+	return sizes, nil
+}
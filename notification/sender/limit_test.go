@@ -0,0 +1,103 @@
+package sender
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMessageLimits_Truncate(t *testing.T) {
+	tests := []struct {
+		name    string
+		limits  MessageLimits
+		typ     string
+		msg     string
+		want    string
+		wantCut bool
+	}{
+		{
+			name: "unlimited",
+			typ:  "mqtt",
+			msg:  strings.Repeat("a", 5000),
+			want: strings.Repeat("a", 5000),
+		},
+		{
+			name: "under the limit",
+			typ:  "slack",
+			msg:  "cpu is high",
+			want: "cpu is high",
+		},
+		{
+			name:    "default size of slack",
+			typ:     "slack",
+			msg:     strings.Repeat("a", 5000),
+			want:    strings.Repeat("a", 3997) + "…",
+			wantCut: true,
+		},
+		{
+			name:    "max size",
+			limits:  MessageLimits{MaxSize: 8},
+			typ:     "mqtt",
+			msg:     "cpu is high",
+			want:    "cpu i…",
+			wantCut: true,
+		},
+		{
+			name:   "override disabling the default size",
+			limits: MessageLimits{MaxSizes: map[string]int{"pagerduty": 0}},
+			typ:    "pagerduty",
+			msg:    strings.Repeat("a", 2000),
+			want:   strings.Repeat("a", 2000),
+		},
+		{
+			name:    "head",
+			limits:  MessageLimits{MaxSize: 6, Truncation: TruncateHead},
+			typ:     "mqtt",
+			msg:     "cpu is high",
+			want:    "cpu is",
+			wantCut: true,
+		},
+		{
+			name:    "tail",
+			limits:  MessageLimits{MaxSize: 7, Truncation: TruncateTail},
+			typ:     "mqtt",
+			msg:     "cpu is high",
+			want:    "…high",
+			wantCut: true,
+		},
+		{
+			name:    "runes are not split",
+			limits:  MessageLimits{MaxSize: 5, Truncation: TruncateHead},
+			typ:     "mqtt",
+			msg:     "température",
+			want:    "temp",
+			wantCut: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, cut := tt.limits.Truncate(tt.typ, tt.msg)
+			if got != tt.want || cut != tt.wantCut {
+				t.Errorf("got %q (cut %v), want %q (cut %v)", got, cut, tt.want, tt.wantCut)
+			}
+		})
+	}
+}
+
+func TestParseMessageSizes(t *testing.T) {
+	sizes, err := ParseMessageSizes([]string{"slack=3000", " mqtt = 0 "})
+	if err != nil {
+		t.Fatalf("failed to parse message sizes: %v", err)
+	}
+	if want := map[string]int{"slack": 3000, "mqtt": 0}; !reflect.DeepEqual(sizes, want) {
+		t.Errorf("got %v, want %v", sizes, want)
+	}
+	for _, kv := range []string{"slack", "=10", "slack=-1", "slack=big"} {
+		if _, err := ParseMessageSizes([]string{kv}); err == nil {
+			t.Errorf("expected %q to be invalid", kv)
+		}
+	}
+	if _, err := ParseTruncation("middle"); err == nil {
+		t.Errorf("expected an unknown truncation to be invalid")
+	}
+}
@@ -0,0 +1,236 @@
+package sender
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/endpoint"
+)
+
+var _ Sender = (*MQTT)(nil)
+
+// mqttTimeout bounds a whole publish when the context has no deadline.
+const mqttTimeout = 10 * time.Second
+
+// mqtt 3.1.1 control packet types.
+const (
+	mqttConnect    byte = 0x10
+	mqttConnack    byte = 0x20
+	mqttPublish    byte = 0x30
+	mqttPuback     byte = 0x40
+	mqttDisconnect byte = 0xE0
+)
+
+var mqttConnackErrors = map[byte]string{
+	1: "unacceptable protocol version",
+	2: "identifier rejected",
+	3: "server unavailable",
+	4: "bad user name or password",
+	5: "not authorized",
+}
+
+// MQTT publishes status events to a mqtt broker.
+// It speaks the small subset of mqtt 3.1.1 needed to publish a single
+// message with qos 0 or 1 over a new clean session.
+type MQTT struct {
+	Config
+}
+
+// Send implements Sender interface.
+func (m *MQTT) Send(ctx context.Context, n *Notification) error {
+	edp, ok := n.Endpoint.(*endpoint.MQTT)
+	if !ok {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("mqtt sender can't send to %s endpoint", n.Endpoint.Type()),
+		}
+	}
+
+	topic := notification.ExpandTemplate(edp.TopicTemplate, n.Status)
+	if topic == "" {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "mqtt topic template expanded to an empty topic",
+		}
+	}
+
+	password, err := m.secretValue(ctx, edp.OrgID, edp.Password)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(newStatusEvent(n))
+	if err != nil {
+		return err
+	}
+
+	conn, err := dialMQTT(ctx, edp.Broker)
+	if err != nil {
+		return &influxdb.Error{
+			Code: influxdb.EUnavailable,
+			Msg:  "failed to connect to mqtt broker",
+			Err:  err,
+		}
+	}
+	defer conn.Close()
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(mqttTimeout)
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		return err
+	}
+
+	clientID := edp.ClientID
+	if clientID == "" {
+		clientID = "influx" + edp.ID.String()
+	}
+	if err := mqttHandshake(conn, clientID, edp.Username, password); err != nil {
+		return err
+	}
+	if err := mqttPublishMessage(conn, topic, payload, edp.QoS, edp.Retain); err != nil {
+		return err
+	}
+	_, err = conn.Write([]byte{mqttDisconnect, 0})
+	return err
+}
+
+func dialMQTT(ctx context.Context, broker string) (net.Conn, error) {
+	u, err := url.Parse(broker)
+	if err != nil {
+		return nil, err
+	}
+
+	host := u.Host
+	useTLS := u.Scheme == "ssl" || u.Scheme == "tls" || u.Scheme == "mqtts"
+	if u.Port() == "" {
+		if useTLS {
+			host = net.JoinHostPort(u.Hostname(), "8883")
+		} else {
+			host = net.JoinHostPort(u.Hostname(), "1883")
+		}
+	}
+
+	d := &net.Dialer{Timeout: mqttTimeout}
+	conn, err := d.DialContext(ctx, "tcp", host)
+	if err != nil {
+		return nil, err
+	}
+	if !useTLS {
+		return conn, nil
+	}
+
+	// the tls handshake happens on the first write, within the deadline of the connection.
+	return tls.Client(conn, &tls.Config{ServerName: u.Hostname()}), nil
+}
+
+func mqttHandshake(rw io.ReadWriter, clientID, username, password string) error {
+	var flags byte = 0x02 // clean session
+	if username != "" {
+		flags |= 0x80
+		if password != "" {
+			flags |= 0x40
+		}
+	}
+
+	body := mqttString("MQTT")
+	body = append(body, 4, flags, 0, 30) // protocol level 4, keep alive 30s
+	body = append(body, mqttString(clientID)...)
+	if username != "" {
+		body = append(body, mqttString(username)...)
+		if password != "" {
+			body = append(body, mqttString(password)...)
+		}
+	}
+	if _, err := rw.Write(mqttPacket(mqttConnect, body)); err != nil {
+		return err
+	}
+
+	ack := make([]byte, 4)
+	if _, err := io.ReadFull(rw, ack); err != nil {
+		return err
+	}
+	if ack[0] != mqttConnack {
+		return &influxdb.Error{
+			Code: influxdb.EUnavailable,
+			Msg:  "mqtt broker did not acknowledge the connection",
+		}
+	}
+	if ack[3] != 0 {
+		msg, ok := mqttConnackErrors[ack[3]]
+		if !ok {
+			msg = fmt.Sprintf("return code %d", ack[3])
+		}
+		return &influxdb.Error{
+			Code: influxdb.EUnavailable,
+			Msg:  "mqtt broker refused the connection: " + msg,
+		}
+	}
+	return nil
+}
+
+func mqttPublishMessage(rw io.ReadWriter, topic string, payload []byte, qos int, retain bool) error {
+	header := mqttPublish | byte(qos<<1)
+	if retain {
+		header |= 0x01
+	}
+
+	body := mqttString(topic)
+	if qos > 0 {
+		body = append(body, 0, 1) // packet identifier
+	}
+	body = append(body, payload...)
+	if _, err := rw.Write(mqttPacket(header, body)); err != nil {
+		return err
+	}
+	if qos == 0 {
+		return nil
+	}
+
+	ack := make([]byte, 4)
+	if _, err := io.ReadFull(rw, ack); err != nil {
+		return err
+	}
+	if ack[0] != mqttPuback || binary.BigEndian.Uint16(ack[2:]) != 1 {
+		return &influxdb.Error{
+			Code: influxdb.EUnavailable,
+			Msg:  "mqtt broker did not acknowledge the message",
+		}
+	}
+	return nil
+}
+
+// mqttPacket prefixes body with the fixed header of a control packet.
+func mqttPacket(header byte, body []byte) []byte {
+	pkt := []byte{header}
+	l := len(body)
+	for {
+		d := byte(l % 128)
+		l /= 128
+		if l > 0 {
+			d |= 0x80
+		}
+		pkt = append(pkt, d)
+		if l == 0 {
+			break
+		}
+	}
+	return append(pkt, body...)
+}
+
+// mqttString encodes s as a length prefixed utf-8 string.
+func mqttString(s string) []byte {
+	b := make([]byte, 2, 2+len(s))
+	binary.BigEndian.PutUint16(b, uint16(len(s)))
+	return append(b, s...)
+}
@@ -0,0 +1,194 @@
+package sender_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/endpoint"
+	"github.com/influxdata/influxdb/notification/rule"
+	"github.com/influxdata/influxdb/notification/sender"
+)
+
+type mqttPacket struct {
+	header byte
+	body   []byte
+}
+
+func readMQTTPacket(r *bufio.Reader) (mqttPacket, error) {
+	header, err := r.ReadByte()
+	if err != nil {
+		return mqttPacket{}, err
+	}
+	var l, mul int = 0, 1
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return mqttPacket{}, err
+		}
+		l += int(b&0x7f) * mul
+		mul *= 128
+		if b&0x80 == 0 {
+			break
+		}
+	}
+	body := make([]byte, l)
+	_, err = io.ReadFull(r, body)
+	return mqttPacket{header: header, body: body}, err
+}
+
+func readMQTTString(b []byte) (string, []byte) {
+	l := binary.BigEndian.Uint16(b)
+	return string(b[2 : 2+l]), b[2+l:]
+}
+
+// fakeMQTTBroker accepts a single connection and records the packets it receives.
+func fakeMQTTBroker(t *testing.T, connackCode byte) (string, <-chan []mqttPacket) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ch := make(chan []mqttPacket, 1)
+	go func() {
+		defer ln.Close()
+		var pkts []mqttPacket
+		defer func() { ch <- pkts }()
+
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		for {
+			pkt, err := readMQTTPacket(r)
+			if err != nil {
+				return
+			}
+			pkts = append(pkts, pkt)
+			switch pkt.header & 0xf0 {
+			case 0x10:
+				conn.Write([]byte{0x20, 2, 0, connackCode})
+			case 0x30:
+				if pkt.header&0x06 != 0 {
+					_, rest := readMQTTString(pkt.body)
+					conn.Write([]byte{0x40, 2, rest[0], rest[1]})
+				}
+			case 0xE0:
+				return
+			}
+		}
+	}()
+	return "tcp://" + ln.Addr().String(), ch
+}
+
+func TestMQTTSend(t *testing.T) {
+	broker, pkts := fakeMQTTBroker(t, 0)
+
+	password := "secret1"
+	s, err := sender.New("mqtt", sender.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.Send(context.Background(), &sender.Notification{
+		Status: notification.Status{
+			CheckID:   influxdb.ID(2),
+			CheckName: "cpu",
+			OrgID:     influxdb.ID(3),
+			Level:     notification.Critical,
+			Tags:      map[string]string{"host": "server01"},
+		},
+		Rule: &rule.MQTT{
+			Base: rule.Base{
+				ID:   influxdb.ID(4),
+				Name: "rule1",
+			},
+			MessageTemplate: "msg1",
+		},
+		Endpoint: &endpoint.MQTT{
+			Base: endpoint.Base{
+				ID:    influxdb.ID(1),
+				OrgID: influxdb.ID(3),
+			},
+			Broker:        broker,
+			TopicTemplate: "alerts/${r.host}/${r._check_name}",
+			Username:      "user1",
+			Password:      influxdb.SecretField{Value: &password},
+			QoS:           1,
+		},
+		Message: "cpu is high",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := <-pkts
+	if len(got) != 3 {
+		t.Fatalf("expected connect, publish and disconnect packets, got %d packets", len(got))
+	}
+
+	connect := got[0]
+	proto, rest := readMQTTString(connect.body)
+	if proto != "MQTT" || rest[0] != 4 {
+		t.Fatalf("unexpected protocol %q level %d", proto, rest[0])
+	}
+	if flags := rest[1]; flags != 0xC2 {
+		t.Errorf("unexpected connect flags %x", flags)
+	}
+	clientID, rest := readMQTTString(rest[4:])
+	user, rest := readMQTTString(rest)
+	pass, _ := readMQTTString(rest)
+	if clientID != "influx0000000000000001" || user != "user1" || pass != "secret1" {
+		t.Errorf("unexpected connect payload %q %q %q", clientID, user, pass)
+	}
+
+	publish := got[1]
+	if publish.header != 0x32 {
+		t.Errorf("unexpected publish header %x", publish.header)
+	}
+	topic, rest := readMQTTString(publish.body)
+	if topic != "alerts/server01/cpu" {
+		t.Errorf("unexpected topic %q", topic)
+	}
+	ev := map[string]interface{}{}
+	if err := json.Unmarshal(rest[2:], &ev); err != nil {
+		t.Fatalf("invalid payload: %v", err)
+	}
+	if ev["message"] != "cpu is high" || ev["level"] != "CRIT" || ev["ruleName"] != "rule1" || ev["checkID"] != "0000000000000002" {
+		t.Errorf("unexpected payload %v", ev)
+	}
+
+	if got[2].header != 0xE0 {
+		t.Errorf("expected disconnect, got %x", got[2].header)
+	}
+}
+
+func TestMQTTSendRefused(t *testing.T) {
+	broker, pkts := fakeMQTTBroker(t, 5)
+
+	s, err := sender.New("mqtt", sender.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.Send(context.Background(), &sender.Notification{
+		Status: notification.Status{
+			CheckID: influxdb.ID(2),
+			OrgID:   influxdb.ID(3),
+		},
+		Rule: &rule.MQTT{},
+		Endpoint: &endpoint.MQTT{
+			Broker:        broker,
+			TopicTemplate: "alerts",
+		},
+	})
+	<-pkts
+	if influxdb.ErrorCode(err) != influxdb.EUnavailable {
+		t.Fatalf("expected unavailable error, got %v", err)
+	}
+}
@@ -0,0 +1,145 @@
+package sender
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/endpoint"
+	"github.com/influxdata/influxdb/notification/rule"
+)
+
+var _ Sender = (*PagerDuty)(nil)
+
+// DefaultPagerDutyEventsURL is the url of the pagerduty events api v2.
+const DefaultPagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+// pagerDutySummaryLimit is the maximum length of an event summary.
+const pagerDutySummaryLimit = 1024
+
+// defaultPagerDutySeverities are the pagerduty severities of check levels,
+// they can be overridden by the severity mapping of the rule.
+var defaultPagerDutySeverities = map[notification.CheckLevel]string{
+	notification.Critical: "critical",
+	notification.Warn:     "warning",
+	notification.Info:     "info",
+	notification.Unknown:  "error",
+}
+
+// PagerDuty sends notifications to the pagerduty events api v2.
+// WARN and CRIT statuses trigger an alert, OK statuses resolve it,
+// other levels are ignored.
+type PagerDuty struct {
+	Config
+	// EventsURL is the url events are posted to,
+	// DefaultPagerDutyEventsURL is used when empty.
+	EventsURL string
+}
+
+type pagerDutyEvent struct {
+	RoutingKey  string            `json:"routing_key"`
+	EventAction string            `json:"event_action"`
+	DedupKey    string            `json:"dedup_key"`
+	Payload     *pagerDutyPayload `json:"payload,omitempty"`
+	Client      string            `json:"client,omitempty"`
+	ClientURL   string            `json:"client_url,omitempty"`
+}
+
+type pagerDutyPayload struct {
+	Summary       string                 `json:"summary"`
+	Source        string                 `json:"source"`
+	Severity      string                 `json:"severity"`
+	Timestamp     string                 `json:"timestamp,omitempty"`
+	CustomDetails map[string]interface{} `json:"custom_details,omitempty"`
+}
+
+// PagerDutyDedupKey returns the dedup key of a status, statuses of the
+// same check and tag set share the same key so that an OK status
+// resolves the alert triggered before.
+func PagerDutyDedupKey(st notification.Status) string {
+	return dedupKey(st)
+}
+
+// Send implements Sender interface.
+func (p *PagerDuty) Send(ctx context.Context, n *Notification) error {
+	edp, ok := n.Endpoint.(*endpoint.PagerDuty)
+	if !ok {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("pagerduty sender can't send to %s endpoint", n.Endpoint.Type()),
+		}
+	}
+
+	var action string
+	switch n.Status.Level {
+	case notification.Critical, notification.Warn:
+		action = "trigger"
+	case notification.Ok:
+		action = "resolve"
+	default:
+		return nil
+	}
+
+	routingKey, err := p.secretValue(ctx, edp.OrgID, edp.RoutingKey)
+	if err != nil {
+		return err
+	}
+
+	event := pagerDutyEvent{
+		RoutingKey:  routingKey,
+		EventAction: action,
+		DedupKey:    PagerDutyDedupKey(n.Status),
+	}
+	if action == "trigger" {
+		event.Client = "influxdata"
+		event.ClientURL = edp.ClientURL
+		if event.ClientURL == "" {
+			event.ClientURL = checkURL(p.BaseURL, n.Status)
+		}
+		event.Payload = p.payload(n)
+	}
+
+	url := p.EventsURL
+	if url == "" {
+		url = DefaultPagerDutyEventsURL
+	}
+	return p.postJSON(ctx, "pagerduty", url, event, nil)
+}
+
+func (p *PagerDuty) payload(n *Notification) *pagerDutyPayload {
+	severity := defaultPagerDutySeverities[n.Status.Level]
+	if r, ok := n.Rule.(*rule.PagerDuty); ok {
+		if s, ok := r.SeverityMapping[n.Status.Level.String()]; ok {
+			severity = s
+		}
+	}
+
+	// the summary is cut to the limit of pagerduty whatever the size of the
+	// messages of the pagerduty endpoints.
+	summary := n.message()
+	if len(summary) > pagerDutySummaryLimit {
+		summary = truncate(summary, pagerDutySummaryLimit, p.MessageLimits.Truncation)
+	}
+
+	details := make(map[string]interface{}, len(n.Status.Tags)+2)
+	for k, v := range n.Status.Tags {
+		details[k] = v
+	}
+	details["level"] = n.Status.Level.String()
+	if n.Status.Value != nil {
+		details["value"] = *n.Status.Value
+	}
+
+	pl := &pagerDutyPayload{
+		Summary:       summary,
+		Source:        n.Status.CheckName,
+		Severity:      severity,
+		CustomDetails: details,
+	}
+	if !n.Status.Time.IsZero() {
+		pl.Timestamp = n.Status.Time.UTC().Format(time.RFC3339Nano)
+	}
+	return pl
+}
@@ -0,0 +1,177 @@
+package sender_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/endpoint"
+	"github.com/influxdata/influxdb/notification/rule"
+	"github.com/influxdata/influxdb/notification/sender"
+)
+
+func TestPagerDutySend(t *testing.T) {
+	routingKey := "routing-key-1"
+	edp := &endpoint.PagerDuty{
+		Base: endpoint.Base{
+			ID:    influxdb.ID(1),
+			OrgID: influxdb.ID(3),
+		},
+		RoutingKey: influxdb.SecretField{
+			Value: &routingKey,
+		},
+	}
+	r := &rule.PagerDuty{
+		MessageTemp: "msg1",
+		SeverityMapping: map[string]string{
+			"WARN": "error",
+		},
+	}
+	tags := map[string]string{
+		"host": "server01",
+	}
+	value := 91.5
+	dedupKey := sender.PagerDutyDedupKey(notification.Status{
+		CheckID: influxdb.ID(2),
+		Tags:    tags,
+	})
+
+	cases := []struct {
+		name  string
+		level notification.CheckLevel
+		want  string
+	}{
+		{
+			name:  "critical triggers",
+			level: notification.Critical,
+			want: `{
+				"routing_key": "routing-key-1",
+				"event_action": "trigger",
+				"dedup_key": "` + dedupKey + `",
+				"client": "influxdata",
+				"client_url": "http://localhost:9999/orgs/0000000000000003/alerting/checks/0000000000000002/edit",
+				"payload": {
+					"summary": "cpu usage is high",
+					"source": "cpu usage",
+					"severity": "critical",
+					"timestamp": "2006-07-13T04:19:10Z",
+					"custom_details": {"host": "server01", "level": "CRIT", "value": 91.5}
+				}
+			}`,
+		},
+		{
+			name:  "warn uses severity mapping",
+			level: notification.Warn,
+			want: `{
+				"routing_key": "routing-key-1",
+				"event_action": "trigger",
+				"dedup_key": "` + dedupKey + `",
+				"client": "influxdata",
+				"client_url": "http://localhost:9999/orgs/0000000000000003/alerting/checks/0000000000000002/edit",
+				"payload": {
+					"summary": "cpu usage is high",
+					"source": "cpu usage",
+					"severity": "error",
+					"timestamp": "2006-07-13T04:19:10Z",
+					"custom_details": {"host": "server01", "level": "WARN", "value": 91.5}
+				}
+			}`,
+		},
+		{
+			name:  "ok resolves",
+			level: notification.Ok,
+			want: `{
+				"routing_key": "routing-key-1",
+				"event_action": "resolve",
+				"dedup_key": "` + dedupKey + `"
+			}`,
+		},
+		{
+			name:  "info is ignored",
+			level: notification.Info,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var body []byte
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ = ioutil.ReadAll(r.Body)
+				w.WriteHeader(http.StatusAccepted)
+			}))
+			defer ts.Close()
+
+			s := &sender.PagerDuty{
+				Config: sender.Config{
+					Client:  ts.Client(),
+					BaseURL: "http://localhost:9999",
+				},
+				EventsURL: ts.URL,
+			}
+			err := s.Send(context.Background(), &sender.Notification{
+				Status: notification.Status{
+					CheckID:   influxdb.ID(2),
+					CheckName: "cpu usage",
+					OrgID:     influxdb.ID(3),
+					Level:     c.level,
+					Value:     &value,
+					Tags:      tags,
+					Time:      time.Date(2006, time.July, 13, 4, 19, 10, 0, time.UTC),
+				},
+				Rule:     r,
+				Endpoint: edp,
+				Message:  "cpu usage is high",
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if c.want == "" {
+				if body != nil {
+					t.Fatalf("expected no event to be sent, got %s", body)
+				}
+				return
+			}
+
+			var got, want interface{}
+			if err := json.Unmarshal(body, &got); err != nil {
+				t.Fatalf("invalid request body: %v", err)
+			}
+			if err := json.Unmarshal([]byte(c.want), &want); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(got, want); diff != "" {
+				t.Errorf("pagerduty event is different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+func TestPagerDutyDedupKey(t *testing.T) {
+	st1 := notification.Status{
+		CheckID: influxdb.ID(1),
+		Tags:    map[string]string{"host": "a", "region": "b"},
+	}
+	st2 := notification.Status{
+		CheckID: influxdb.ID(1),
+		Tags:    map[string]string{"region": "b", "host": "a"},
+		Level:   notification.Ok,
+	}
+	st3 := notification.Status{
+		CheckID: influxdb.ID(1),
+		Tags:    map[string]string{"host": "c", "region": "b"},
+	}
+	if sender.PagerDutyDedupKey(st1) != sender.PagerDutyDedupKey(st2) {
+		t.Errorf("expected statuses of the same check and tag set to share a dedup key")
+	}
+	if sender.PagerDutyDedupKey(st1) == sender.PagerDutyDedupKey(st3) {
+		t.Errorf("expected statuses of different tag sets to have different dedup keys")
+	}
+}
@@ -0,0 +1,101 @@
+package sender
+
+import (
+	"context"
+	"time"
+
+	"github.com/influxdata/influxdb"
+)
+
+// Delivery is what happens to a notification sent to a user.
+type Delivery int
+
+// consts of Delivery
+const (
+	// Deliver sends the notification now.
+	Deliver Delivery = iota
+	// Defer sends the notification when the quiet hours of the user end.
+	Defer
+	// Suppress drops the notification.
+	Suppress
+)
+
+// Decision is the delivery of a notification decided by the preferences of a user.
+type Decision struct {
+	Delivery Delivery
+	// Until is when a deferred notification is sent.
+	Until time.Time
+	// Endpoint is where the notification is sent to, the preferred
+	// endpoint of the user replaces the endpoint of the rule.
+	Endpoint influxdb.NotificationEndpoint
+}
+
+// userAddressed is implemented by the endpoints addressed to a single user.
+type userAddressed interface {
+	GetUserID() *influxdb.ID
+}
+
+// Preferences applies the notification preferences of users to the
+// notifications sent to the endpoints addressed to them.
+type Preferences struct {
+	PreferencesService influxdb.NotificationPreferencesService
+	EndpointService    influxdb.NotificationEndpointService
+	TimeGenerator      influxdb.TimeGenerator
+}
+
+// Decide returns whether the notification is delivered now, deferred or
+// suppressed, and the endpoint it is delivered to. Notifications to
+// endpoints which are not addressed to a user, or to users without
+// preferences, are delivered now to their endpoint.
+func (p *Preferences) Decide(ctx context.Context, n *Notification) (*Decision, error) {
+	d := &Decision{
+		Delivery: Deliver,
+		Endpoint: n.Endpoint,
+	}
+
+	u, ok := n.Endpoint.(userAddressed)
+	if !ok || u.GetUserID() == nil {
+		return d, nil
+	}
+	prefs, err := p.PreferencesService.FindNotificationPreferences(ctx, *u.GetUserID())
+	if influxdb.ErrorCode(err) == influxdb.ENotFound {
+		return d, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if prefs.MinLevel != "" &&
+		influxdb.NotificationLevelRank(n.Status.Level.String()) < influxdb.NotificationLevelRank(prefs.MinLevel) {
+		d.Delivery = Suppress
+		return d, nil
+	}
+
+	if prefs.QuietHours != nil {
+		quiet, end, err := prefs.QuietHours.Window(p.TimeGenerator.Now())
+		if err != nil {
+			return nil, err
+		}
+		if quiet {
+			if prefs.QuietHours.Action == influxdb.QuietHoursSuppress {
+				d.Delivery = Suppress
+				return d, nil
+			}
+			d.Delivery = Defer
+			d.Until = end
+		}
+	}
+
+	if prefs.PreferredEndpointID != nil && *prefs.PreferredEndpointID != n.Endpoint.GetID() {
+		e, err := p.EndpointService.FindNotificationEndpointByID(ctx, *prefs.PreferredEndpointID)
+		if err != nil {
+			return nil, err
+		}
+		// the preferred endpoint must belong to the organization of the rule.
+		if e.GetOrgID() == n.Endpoint.GetOrgID() {
+			d.Endpoint = e
+		}
+	}
+
+	return d, nil
+}
@@ -0,0 +1,138 @@
+package sender_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/endpoint"
+	"github.com/influxdata/influxdb/notification/sender"
+)
+
+func TestPreferencesDecide(t *testing.T) {
+	userID := influxdb.ID(7)
+	channel := &endpoint.Slack{
+		Base: endpoint.Base{ID: influxdb.ID(1), OrgID: influxdb.ID(3), Name: "ops", UserID: &userID},
+	}
+	direct := &endpoint.Slack{
+		Base: endpoint.Base{ID: influxdb.ID(2), OrgID: influxdb.ID(3), Name: "dm", UserID: &userID},
+	}
+	other := &endpoint.Slack{
+		Base: endpoint.Base{ID: influxdb.ID(4), OrgID: influxdb.ID(5), Name: "other org"},
+	}
+	shared := &endpoint.Slack{
+		Base: endpoint.Base{ID: influxdb.ID(6), OrgID: influxdb.ID(3), Name: "shared"},
+	}
+	endpoints := map[influxdb.ID]influxdb.NotificationEndpoint{1: channel, 2: direct, 4: other, 6: shared}
+
+	nightShift := &influxdb.QuietHours{Start: "22:00", End: "07:00", Timezone: "UTC", Action: influxdb.QuietHoursDefer}
+	night := time.Date(2019, 8, 1, 23, 30, 0, 0, time.UTC)
+	day := time.Date(2019, 8, 1, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name     string
+		endpoint influxdb.NotificationEndpoint
+		prefs    *influxdb.NotificationPreferences
+		now      time.Time
+		level    notification.CheckLevel
+		want     sender.Decision
+	}{
+		{
+			name:     "endpoint not addressed to a user",
+			endpoint: shared,
+			prefs:    &influxdb.NotificationPreferences{MinLevel: "CRIT"},
+			level:    notification.Info,
+			want:     sender.Decision{Delivery: sender.Deliver, Endpoint: shared},
+		},
+		{
+			name:     "user without preferences",
+			endpoint: channel,
+			level:    notification.Info,
+			want:     sender.Decision{Delivery: sender.Deliver, Endpoint: channel},
+		},
+		{
+			name:     "status below the min level",
+			endpoint: channel,
+			prefs:    &influxdb.NotificationPreferences{MinLevel: "WARN"},
+			level:    notification.Info,
+			want:     sender.Decision{Delivery: sender.Suppress, Endpoint: channel},
+		},
+		{
+			name:     "status above the min level",
+			endpoint: channel,
+			prefs:    &influxdb.NotificationPreferences{MinLevel: "WARN"},
+			level:    notification.Critical,
+			want:     sender.Decision{Delivery: sender.Deliver, Endpoint: channel},
+		},
+		{
+			name:     "deferred during quiet hours",
+			endpoint: channel,
+			prefs:    &influxdb.NotificationPreferences{QuietHours: nightShift},
+			now:      night,
+			level:    notification.Critical,
+			want: sender.Decision{
+				Delivery: sender.Defer,
+				Until:    time.Date(2019, 8, 2, 7, 0, 0, 0, time.UTC),
+				Endpoint: channel,
+			},
+		},
+		{
+			name:     "suppressed during quiet hours",
+			endpoint: channel,
+			prefs: &influxdb.NotificationPreferences{QuietHours: &influxdb.QuietHours{
+				Start: "22:00", End: "07:00", Action: influxdb.QuietHoursSuppress,
+			}},
+			now:   night,
+			level: notification.Critical,
+			want:  sender.Decision{Delivery: sender.Suppress, Endpoint: channel},
+		},
+		{
+			name:     "outside quiet hours to the preferred endpoint",
+			endpoint: channel,
+			prefs:    &influxdb.NotificationPreferences{QuietHours: nightShift, PreferredEndpointID: &direct.ID},
+			now:      day,
+			level:    notification.Critical,
+			want:     sender.Decision{Delivery: sender.Deliver, Endpoint: direct},
+		},
+		{
+			name:     "preferred endpoint of another organization",
+			endpoint: channel,
+			prefs:    &influxdb.NotificationPreferences{PreferredEndpointID: &other.ID},
+			level:    notification.Critical,
+			want:     sender.Decision{Delivery: sender.Deliver, Endpoint: channel},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			p := &sender.Preferences{
+				PreferencesService: &mock.NotificationPreferencesService{
+					FindNotificationPreferencesF: func(ctx context.Context, id influxdb.ID) (*influxdb.NotificationPreferences, error) {
+						if c.prefs == nil || id != userID {
+							return nil, &influxdb.Error{Code: influxdb.ENotFound, Msg: "notification preferences not found"}
+						}
+						return c.prefs, nil
+					},
+				},
+				EndpointService: &mock.NotificationEndpointService{
+					FindNotificationEndpointByIDF: func(ctx context.Context, id influxdb.ID) (influxdb.NotificationEndpoint, error) {
+						return endpoints[id], nil
+					},
+				},
+				TimeGenerator: mock.TimeGenerator{FakeValue: c.now},
+			}
+			got, err := p.Decide(context.Background(), &sender.Notification{
+				Status:   notification.Status{CheckName: "cpu", Level: c.level},
+				Endpoint: c.endpoint,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Delivery != c.want.Delivery || !got.Until.Equal(c.want.Until) || got.Endpoint != c.want.Endpoint {
+				t.Errorf("expected %+v, got %+v", c.want, *got)
+			}
+		})
+	}
+}
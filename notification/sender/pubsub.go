@@ -0,0 +1,157 @@
+package sender
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification/endpoint"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/jwt"
+)
+
+var _ Sender = (*PubSub)(nil)
+
+const (
+	// DefaultPubSubURL is the url of the google cloud pub/sub api.
+	DefaultPubSubURL = "https://pubsub.googleapis.com"
+
+	pubSubScope    = "https://www.googleapis.com/auth/pubsub"
+	googleTokenURL = "https://oauth2.googleapis.com/token"
+)
+
+// PubSub publishes status events to a google cloud pub/sub topic.
+// The level and check of the status are set as message attributes,
+// so subscriptions can filter on them.
+type PubSub struct {
+	Config
+
+	// URL is the url of the pub/sub api, DefaultPubSubURL is used when empty.
+	URL string
+
+	mu sync.Mutex
+	// tokens caches the token source of each service account key.
+	tokens map[[sha256.Size]byte]oauth2.TokenSource
+}
+
+type pubSubMessage struct {
+	Data       []byte            `json:"data"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+type pubSubPublishRequest struct {
+	Messages []pubSubMessage `json:"messages"`
+}
+
+// googleServiceAccountKey is the json key file of a service account.
+type googleServiceAccountKey struct {
+	Type         string `json:"type"`
+	PrivateKeyID string `json:"private_key_id"`
+	PrivateKey   string `json:"private_key"`
+	ClientEmail  string `json:"client_email"`
+	TokenURI     string `json:"token_uri"`
+}
+
+// Send implements Sender interface.
+func (p *PubSub) Send(ctx context.Context, n *Notification) error {
+	edp, ok := n.Endpoint.(*endpoint.PubSub)
+	if !ok {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("pubsub sender can't send to %s endpoint", n.Endpoint.Type()),
+		}
+	}
+
+	key, err := p.secretValue(ctx, edp.OrgID, edp.ServiceAccountKey)
+	if err != nil {
+		return err
+	}
+	ts, err := p.tokenSource(key)
+	if err != nil {
+		return err
+	}
+	tok, err := ts.Token()
+	if err != nil {
+		return &influxdb.Error{
+			Code: influxdb.EUnavailable,
+			Msg:  "failed to authenticate with pubsub",
+			Err:  err,
+		}
+	}
+
+	payload, err := json.Marshal(newStatusEvent(n))
+	if err != nil {
+		return err
+	}
+
+	baseURL := p.URL
+	if baseURL == "" {
+		baseURL = DefaultPubSubURL
+	}
+	u := strings.TrimSuffix(baseURL, "/") + "/v1/projects/" + url.PathEscape(edp.ProjectID) +
+		"/topics/" + url.PathEscape(edp.TopicID) + ":publish"
+
+	header := http.Header{}
+	tok.SetAuthHeader(&http.Request{Header: header})
+	return p.postJSON(ctx, "pubsub", u, pubSubPublishRequest{
+		Messages: []pubSubMessage{
+			{
+				Data: payload,
+				Attributes: map[string]string{
+					"level":   n.Status.Level.String(),
+					"checkID": n.Status.CheckID.String(),
+				},
+			},
+		},
+	}, header)
+}
+
+// tokenSource returns the cached token source of a service account key.
+func (p *PubSub) tokenSource(key string) (oauth2.TokenSource, error) {
+	sum := sha256.Sum256([]byte(key))
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if ts, ok := p.tokens[sum]; ok {
+		return ts, nil
+	}
+
+	var sa googleServiceAccountKey
+	if err := json.Unmarshal([]byte(key), &sa); err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "pubsub service account key is invalid",
+			Err:  err,
+		}
+	}
+	if sa.Type != "service_account" || sa.ClientEmail == "" || sa.PrivateKey == "" {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "pubsub service account key is not the json key of a service account",
+		}
+	}
+	cfg := &jwt.Config{
+		Email:        sa.ClientEmail,
+		PrivateKey:   []byte(sa.PrivateKey),
+		PrivateKeyID: sa.PrivateKeyID,
+		Scopes:       []string{pubSubScope},
+		TokenURL:     sa.TokenURI,
+	}
+	if cfg.TokenURL == "" {
+		cfg.TokenURL = googleTokenURL
+	}
+
+	// the token source outlives the request, it must not hold its context.
+	ts := cfg.TokenSource(context.WithValue(context.Background(), oauth2.HTTPClient, p.client()))
+	if p.tokens == nil {
+		p.tokens = make(map[[sha256.Size]byte]oauth2.TokenSource)
+	}
+	p.tokens[sum] = ts
+	return ts, nil
+}
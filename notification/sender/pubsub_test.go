@@ -0,0 +1,147 @@
+package sender_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/endpoint"
+	"github.com/influxdata/influxdb/notification/rule"
+	"github.com/influxdata/influxdb/notification/sender"
+)
+
+func TestPubSubSend(t *testing.T) {
+	pk, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(pk),
+	})
+
+	var tokenRequests int
+	var publishes []map[string]interface{}
+	var authHeader string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		tokenRequests++
+		if err := r.ParseForm(); err != nil || r.PostForm.Get("assertion") == "" {
+			t.Errorf("expected a jwt assertion, got %v", r.PostForm)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token": "token1", "token_type": "Bearer", "expires_in": 3600}`))
+	})
+	mux.HandleFunc("/v1/projects/project1/topics/alerts:publish", func(w http.ResponseWriter, r *http.Request) {
+		authHeader = r.Header.Get("Authorization")
+		req := map[string]interface{}{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+		}
+		publishes = append(publishes, req)
+		w.Write([]byte(`{"messageIds": ["1"]}`))
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	saKey, err := json.Marshal(map[string]string{
+		"type":           "service_account",
+		"project_id":     "project1",
+		"private_key_id": "key1",
+		"private_key":    string(keyPEM),
+		"client_email":   "alerts@project1.iam.gserviceaccount.com",
+		"token_uri":      ts.URL + "/token",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	saKeyStr := string(saKey)
+
+	s := &sender.PubSub{
+		Config: sender.Config{
+			Client: ts.Client(),
+		},
+		URL: ts.URL,
+	}
+	n := &sender.Notification{
+		Status: notification.Status{
+			CheckID:   influxdb.ID(2),
+			CheckName: "cpu",
+			OrgID:     influxdb.ID(3),
+			Level:     notification.Warn,
+		},
+		Rule: &rule.PubSub{
+			MessageTemplate: "msg1",
+		},
+		Endpoint: &endpoint.PubSub{
+			Base: endpoint.Base{
+				ID:    influxdb.ID(1),
+				OrgID: influxdb.ID(3),
+			},
+			ProjectID:         "project1",
+			TopicID:           "alerts",
+			ServiceAccountKey: influxdb.SecretField{Value: &saKeyStr},
+		},
+		Message: "cpu is high",
+	}
+	for i := 0; i < 2; i++ {
+		if err := s.Send(context.Background(), n); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if tokenRequests != 1 {
+		t.Errorf("expected the token to be reused, got %d token requests", tokenRequests)
+	}
+	if authHeader != "Bearer token1" {
+		t.Errorf("unexpected authorization %q", authHeader)
+	}
+	if len(publishes) != 2 {
+		t.Fatalf("expected 2 publishes, got %d", len(publishes))
+	}
+
+	var req struct {
+		Messages []struct {
+			Data       []byte            `json:"data"`
+			Attributes map[string]string `json:"attributes"`
+		} `json:"messages"`
+	}
+	b, _ := json.Marshal(publishes[0])
+	if err := json.Unmarshal(b, &req); err != nil || len(req.Messages) != 1 {
+		t.Fatalf("unexpected publish request %s", b)
+	}
+	if req.Messages[0].Attributes["level"] != "WARN" || req.Messages[0].Attributes["checkID"] != "0000000000000002" {
+		t.Errorf("unexpected attributes %v", req.Messages[0].Attributes)
+	}
+	ev := map[string]interface{}{}
+	if err := json.Unmarshal(req.Messages[0].Data, &ev); err != nil {
+		t.Fatalf("invalid message data: %v", err)
+	}
+	if ev["message"] != "cpu is high" {
+		t.Errorf("unexpected message %v", ev)
+	}
+}
+
+func TestPubSubSendInvalidKey(t *testing.T) {
+	key := `{"type": "authorized_user"}`
+	s := &sender.PubSub{}
+	err := s.Send(context.Background(), &sender.Notification{
+		Rule: &rule.PubSub{},
+		Endpoint: &endpoint.PubSub{
+			ProjectID:         "project1",
+			TopicID:           "alerts",
+			ServiceAccountKey: influxdb.SecretField{Value: &key},
+		},
+	})
+	if influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Fatalf("expected invalid error, got %v", err)
+	}
+}
@@ -0,0 +1,188 @@
+package sender
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.SecretProvider = (*AWSSecretsManager)(nil)
+
+// AWSSecretsManager resolves the references of the secret fields of the
+// notification endpoints to the secrets of AWS Secrets Manager,
+// awssm://name-or-arn#field. The field selects a key of a secret string
+// holding a JSON object, the whole secret string is used without it.
+type AWSSecretsManager struct {
+	// AccessKeyID, SecretAccessKey and SessionToken are the credentials
+	// the secrets are read with.
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+	// Region is the region of the secrets referred to by name, the region
+	// of the arn of a secret is used otherwise.
+	Region string
+	// Prefix restricts the names or arns each organization refers to, such
+	// as influxdb/{orgID}/, see influxdb.SecretReference.Under.
+	Prefix string
+
+	// URL overrides the regional urls of secrets manager,
+	// it is used by tests and aws compatible services.
+	URL string
+	// Client is the http client calling secrets manager,
+	// http.DefaultClient is used when nil.
+	Client *http.Client
+	// TimeGenerator is the clock signing the requests,
+	// the real time is used when nil.
+	TimeGenerator influxdb.TimeGenerator
+}
+
+// NewAWSSecretsManager returns a provider resolving the references under
+// prefix, configured with the standard aws environment variables
+// AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY, AWS_SESSION_TOKEN and AWS_REGION.
+func NewAWSSecretsManager(prefix string) (*AWSSecretsManager, error) {
+	p := &AWSSecretsManager{
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+		Region:          os.Getenv("AWS_REGION"),
+		Prefix:          prefix,
+	}
+	if p.Region == "" {
+		p.Region = os.Getenv("AWS_DEFAULT_REGION")
+	}
+	if p.AccessKeyID == "" || p.SecretAccessKey == "" {
+		return nil, fmt.Errorf("AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY are required to read the secrets of aws secrets manager")
+	}
+	return p, nil
+}
+
+type awsGetSecretValueResponse struct {
+	SecretString *string `json:"SecretString"`
+}
+
+type awsJSONErrorResponse struct {
+	Type    string `json:"__type"`
+	Message string `json:"message"`
+}
+
+// ResolveSecret returns the value of the secret a reference refers to.
+func (p *AWSSecretsManager) ResolveSecret(ctx context.Context, orgID influxdb.ID, ref influxdb.SecretReference) (string, error) {
+	if err := ref.Under(p.Prefix, orgID); err != nil {
+		return "", err
+	}
+
+	partition, region := "aws", p.Region
+	// arn:partition:secretsmanager:region:account-id:secret:name
+	if arn := strings.SplitN(ref.Path, ":", 7); len(arn) == 7 && arn[0] == "arn" {
+		partition, region = arn[1], arn[3]
+	}
+	if region == "" {
+		return "", &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("secret reference %s requires an arn, no aws region is configured", ref),
+		}
+	}
+	serviceURL := p.URL
+	if serviceURL == "" {
+		serviceURL = awsServiceURL("secretsmanager", partition, region)
+	}
+
+	body, err := json.Marshal(map[string]string{"SecretId": ref.Path})
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequest(http.MethodPost, serviceURL, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "secretsmanager.GetSecretValue")
+	awsSignV4(req, body, awsCredentials{
+		AccessKeyID:     p.AccessKeyID,
+		SecretAccessKey: p.SecretAccessKey,
+		SessionToken:    p.SessionToken,
+	}, "secretsmanager", region, p.now())
+
+	client := p.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", &influxdb.Error{
+			Code: influxdb.EUnavailable,
+			Msg:  "failed to call secretsmanager",
+			Err:  err,
+		}
+	}
+	defer resp.Body.Close()
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode/100 != 2 {
+		var e awsJSONErrorResponse
+		if json.Unmarshal(b, &e) != nil || e.Type == "" {
+			return "", unexpectedStatusError("secretsmanager", resp.StatusCode)
+		}
+		// the type may be qualified by its namespace.
+		typ := e.Type[strings.LastIndex(e.Type, "#")+1:]
+		code := influxdb.EUnavailable
+		switch {
+		case typ == "ResourceNotFoundException":
+			code = influxdb.ENotFound
+		case resp.StatusCode/100 == 4:
+			code = influxdb.EInvalid
+		}
+		return "", &influxdb.Error{
+			Code: code,
+			Msg:  "secretsmanager responded with " + typ + ": " + e.Message,
+		}
+	}
+
+	var v awsGetSecretValueResponse
+	if err := json.Unmarshal(b, &v); err != nil {
+		return "", err
+	}
+	if v.SecretString == nil {
+		return "", &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("secret %s has no secret string", ref.Path),
+		}
+	}
+	if ref.Field == "" {
+		return *v.SecretString, nil
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal([]byte(*v.SecretString), &fields); err != nil {
+		return "", &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("secret %s isn't a JSON object, its field %s can't be selected", ref.Path, ref.Field),
+		}
+	}
+	s, ok := fields[ref.Field].(string)
+	if !ok {
+		return "", &influxdb.Error{
+			Code: influxdb.ENotFound,
+			Msg:  fmt.Sprintf("secret %s has no field %s", ref.Path, ref.Field),
+		}
+	}
+	return s, nil
+}
+
+func (p *AWSSecretsManager) now() time.Time {
+	if p.TimeGenerator == nil {
+		return time.Now()
+	}
+	return p.TimeGenerator.Now()
+}
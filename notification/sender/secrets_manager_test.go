@@ -0,0 +1,198 @@
+package sender_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/notification/endpoint"
+	"github.com/influxdata/influxdb/notification/rule"
+	"github.com/influxdata/influxdb/notification/sender"
+)
+
+func TestAWSSecretsManager_ResolveSecret(t *testing.T) {
+	secrets := map[string]string{
+		"influxdb/0000000000000003/slack":                                                    `{"token": "token1"}`,
+		"influxdb/0000000000000003/webhook":                                                  "https://hooks.example.com/1",
+		"arn:aws:secretsmanager:eu-west-1:123456789012:secret:influxdb/0000000000000003/arn": `{"token": "token2"}`,
+	}
+	var auths []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auths = append(auths, r.Header.Get("Authorization"))
+		if r.Header.Get("X-Amz-Target") != "secretsmanager.GetSecretValue" {
+			t.Errorf("unexpected target %q", r.Header.Get("X-Amz-Target"))
+		}
+		var req struct{ SecretId string }
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+		}
+		s, ok := secrets[req.SecretId]
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"__type": "ResourceNotFoundException", "message": "Secrets Manager can't find the specified secret."}`))
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"Name": req.SecretId, "SecretString": s})
+	}))
+	defer ts.Close()
+
+	p := &sender.AWSSecretsManager{
+		AccessKeyID:     "AKIAUSER",
+		SecretAccessKey: "user-secret",
+		Region:          "us-west-2",
+		URL:             ts.URL,
+		Client:          ts.Client(),
+		TimeGenerator:   mock.TimeGenerator{FakeValue: time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	cases := []struct {
+		name     string
+		prefix   string
+		ref      string
+		orgID    influxdb.ID
+		want     string
+		wantCode string
+		region   string
+	}{
+		{
+			name:   "field of a JSON secret",
+			ref:    "awssm://influxdb/0000000000000003/slack#token",
+			orgID:  3,
+			want:   "token1",
+			region: "us-west-2",
+		},
+		{
+			name:   "whole secret",
+			ref:    "awssm://influxdb/0000000000000003/webhook",
+			orgID:  3,
+			want:   "https://hooks.example.com/1",
+			region: "us-west-2",
+		},
+		{
+			name:   "secret referred to by arn",
+			prefix: "arn:aws:secretsmanager:eu-west-1:123456789012:secret:influxdb/{orgID}/",
+			ref:    "awssm://arn:aws:secretsmanager:eu-west-1:123456789012:secret:influxdb/0000000000000003/arn#token",
+			orgID:  3,
+			want:   "token2",
+			region: "eu-west-1",
+		},
+		{
+			name:     "secret of another organization",
+			ref:      "awssm://influxdb/0000000000000004/slack#token",
+			orgID:    3,
+			wantCode: influxdb.EForbidden,
+		},
+		{
+			name:     "missing secret",
+			ref:      "awssm://influxdb/0000000000000003/missing#token",
+			orgID:    3,
+			wantCode: influxdb.ENotFound,
+			region:   "us-west-2",
+		},
+		{
+			name:     "missing field",
+			ref:      "awssm://influxdb/0000000000000003/slack#password",
+			orgID:    3,
+			wantCode: influxdb.ENotFound,
+			region:   "us-west-2",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			auths = nil
+			p.Prefix = "influxdb/{orgID}/"
+			if c.prefix != "" {
+				p.Prefix = c.prefix
+			}
+			ref, ok := influxdb.ParseSecretReference(c.ref)
+			if !ok {
+				t.Fatalf("%s isn't a reference", c.ref)
+			}
+			got, err := p.ResolveSecret(context.Background(), c.orgID, ref)
+			if influxdb.ErrorCode(err) != c.wantCode {
+				t.Fatalf("unexpected error %v, want code %q", err, c.wantCode)
+			}
+			if got != c.want {
+				t.Errorf("unexpected secret %q, want %q", got, c.want)
+			}
+			if c.region == "" {
+				if len(auths) != 0 {
+					t.Errorf("expected secrets manager not to be called")
+				}
+				return
+			}
+			if len(auths) != 1 || !strings.Contains(auths[0], "Credential=AKIAUSER/20191001/"+c.region+"/secretsmanager/aws4_request") {
+				t.Errorf("unexpected authorization %q", auths)
+			}
+		})
+	}
+}
+
+type secretProviderFunc func(ctx context.Context, orgID influxdb.ID, ref influxdb.SecretReference) (string, error)
+
+func (f secretProviderFunc) ResolveSecret(ctx context.Context, orgID influxdb.ID, ref influxdb.SecretReference) (string, error) {
+	return f(ctx, orgID, ref)
+}
+
+func TestSend_SecretReference(t *testing.T) {
+	var auth string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+	}))
+	defer ts.Close()
+
+	n := &sender.Notification{
+		Rule: &rule.Slack{},
+		Endpoint: &endpoint.Slack{
+			Base: endpoint.Base{ID: influxdb.ID(1), OrgID: influxdb.ID(3)},
+			URL:  ts.URL,
+			Token: influxdb.SecretField{
+				Key: "vault://secret/data/slack#token",
+			},
+		},
+		Message: "msg",
+	}
+
+	s, err := sender.New("slack", sender.Config{
+		Client: ts.Client(),
+		SecretService: &mock.SecretService{
+			LoadSecretFn: func(ctx context.Context, orgID influxdb.ID, k string) (string, error) {
+				t.Fatalf("unexpected load of the secret %q", k)
+				return "", nil
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Send(context.Background(), n); influxdb.ErrorCode(err) != influxdb.EInvalid || !strings.Contains(err.Error(), "secret provider vault is not configured") {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	s, err = sender.New("slack", sender.Config{
+		Client: ts.Client(),
+		SecretProviders: map[string]influxdb.SecretProvider{
+			influxdb.SecretProviderVault: secretProviderFunc(func(ctx context.Context, orgID influxdb.ID, ref influxdb.SecretReference) (string, error) {
+				if orgID != influxdb.ID(3) || ref.Path != "secret/data/slack" || ref.Field != "token" {
+					t.Errorf("unexpected reference %s of %s", ref, orgID)
+				}
+				return "token1", nil
+			}),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Send(context.Background(), n); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if auth != "Bearer token1" {
+		t.Errorf("unexpected authorization header %q", auth)
+	}
+}
@@ -0,0 +1,271 @@
+// Package sender delivers notifications to the 3rd party services
+// described by notification endpoints.
+package sender
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/i18n"
+)
+
+// Notification is a notification ready to be sent to an endpoint.
+type Notification struct {
+	// Status is the check status which triggered the notification.
+	Status notification.Status
+	// Rule is the notification rule which matched the status.
+	Rule influxdb.NotificationRule
+	// Endpoint is where the notification is sent to.
+	Endpoint influxdb.NotificationEndpoint
+	// Message is the message rendered from the rule's template.
+	Message string
+	// AcknowledgeURL is an optional url to acknowledge the notification.
+	AcknowledgeURL string
+}
+
+// localizer is implemented by the rules and endpoints which select the
+// language of the built-in phrases of notifications.
+type localizer interface {
+	GetLocale() string
+}
+
+// printer returns the printer of the locale of the rule,
+// or else of the locale of the endpoint.
+func (n *Notification) printer() *i18n.Printer {
+	for _, v := range []interface{}{n.Rule, n.Endpoint} {
+		if l, ok := v.(localizer); ok && l.GetLocale() != "" {
+			return i18n.NewPrinter(l.GetLocale())
+		}
+	}
+	return i18n.NewPrinter(i18n.DefaultLocale)
+}
+
+// message returns the message rendered from the rule's template, or
+// the phrase of the status in the locale of the notification, such as
+// "cpu is critical" or "cpu recovered after 23m", when the rule has none.
+// The messages of synthetic statuses are marked as tests.
+func (n *Notification) message() string {
+	p := n.printer()
+	msg := n.Message
+	switch {
+	case msg != "":
+	case n.Status.Level == notification.Ok && n.Status.IncidentDuration > 0:
+		msg = p.Recovered(n.Status.CheckName, n.Status.IncidentDuration)
+	default:
+		msg = p.Status(n.Status.CheckName, n.Status.Level)
+	}
+	if n.Status.Synthetic {
+		msg = p.Sprintf(i18n.StatusSynthetic, msg)
+	}
+	return msg
+}
+
+// Text returns the message of the notification as its endpoint receives it.
+func (n *Notification) Text() string {
+	return n.message()
+}
+
+// Sender sends notifications to a single kind of notification endpoint.
+type Sender interface {
+	Send(ctx context.Context, n *Notification) error
+}
+
+// Config includes the dependencies shared by all senders.
+type Config struct {
+	// Client is the http client used to call the 3rd party service,
+	// http.DefaultClient is used when nil.
+	Client *http.Client
+	// SecretService is used to load the secret fields of endpoints.
+	SecretService influxdb.SecretService
+	// SecretProviders resolve the secret fields referring to the secrets
+	// of external stores, by provider.
+	SecretProviders map[string]influxdb.SecretProvider
+	// BaseURL is the external url of the UI, used to link back to checks.
+	BaseURL string
+	// Exec is the operator configuration of exec endpoints,
+	// exec endpoints are disabled when nil.
+	Exec *ExecConfig
+	// TimeGenerator is the clock signing the requests to aws,
+	// the real time is used when nil.
+	TimeGenerator influxdb.TimeGenerator
+	// Faults injects faults in the deliveries of the notifications, for
+	// tests. The notifications are delivered as is when nil.
+	Faults FaultInjector
+	// MessageLimits are the maximum sizes of the messages by endpoint type.
+	MessageLimits MessageLimits
+}
+
+func (c Config) client() *http.Client {
+	if c.Client == nil {
+		return http.DefaultClient
+	}
+	return c.Client
+}
+
+func (c Config) now() time.Time {
+	if c.TimeGenerator == nil {
+		return time.Now()
+	}
+	return c.TimeGenerator.Now()
+}
+
+// secretValue returns the value of a secret field, loading it from the
+// secret service when only the key is known, or resolving it with its
+// provider when the key refers to a secret of an external store.
+func (c Config) secretValue(ctx context.Context, orgID influxdb.ID, fld influxdb.SecretField) (string, error) {
+	if fld.Value != nil {
+		return *fld.Value, nil
+	}
+	if fld.Key == "" {
+		return "", nil
+	}
+	if ref, ok := fld.Reference(); ok {
+		p, ok := c.SecretProviders[ref.Provider]
+		if !ok {
+			return "", &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  fmt.Sprintf("secret provider %s is not configured", ref.Provider),
+			}
+		}
+		return p.ResolveSecret(ctx, orgID, ref)
+	}
+	if c.SecretService == nil {
+		return "", &influxdb.Error{
+			Code: influxdb.EInternal,
+			Msg:  "secret service is not configured",
+		}
+	}
+	return c.SecretService.LoadSecret(ctx, orgID, fld.Key)
+}
+
+// LoadSecret returns the value of a secret of an organization referenced by
+// key, as the secret fields of the endpoints are loaded.
+func (c Config) LoadSecret(ctx context.Context, orgID influxdb.ID, key string) (string, error) {
+	return c.secretValue(ctx, orgID, influxdb.SecretField{Key: key})
+}
+
+var typeToSender = map[string]func(Config) Sender{
+	"slack":         func(cfg Config) Sender { return &Slack{Config: cfg} },
+	"pagerduty":     func(cfg Config) Sender { return &PagerDuty{Config: cfg} },
+	"alerta":        func(cfg Config) Sender { return &Alerta{Config: cfg} },
+	"grafanaoncall": func(cfg Config) Sender { return &GrafanaOnCall{Config: cfg} },
+	"mqtt":          func(cfg Config) Sender { return &MQTT{Config: cfg} },
+	"kafka":         func(cfg Config) Sender { return &Kafka{Config: cfg} },
+	"sns":           func(cfg Config) Sender { return &SNS{Config: cfg} },
+	"pubsub":        func(cfg Config) Sender { return &PubSub{Config: cfg} },
+	"exec":          func(cfg Config) Sender { return &Exec{Config: cfg} },
+}
+
+// New returns the sender of the notification endpoint type.
+func New(typ string, cfg Config) (Sender, error) {
+	fn, ok := typeToSender[typ]
+	if !ok {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "no sender for notification endpoint type " + typ,
+		}
+	}
+	if cfg.Faults != nil {
+		return &faultySender{Sender: fn(cfg), service: typ, faults: cfg.Faults}, nil
+	}
+	return fn(cfg), nil
+}
+
+func unexpectedStatusError(service string, statusCode int) error {
+	return &influxdb.Error{
+		Code: influxdb.EUnavailable,
+		Msg:  service + " responded with unexpected status " + http.StatusText(statusCode),
+	}
+}
+
+// statusEvent is the json payload of the senders publishing status events.
+type statusEvent struct {
+	notification.Status
+	RuleID   influxdb.ID `json:"ruleID,omitempty"`
+	RuleName string      `json:"ruleName,omitempty"`
+}
+
+// newStatusEvent returns the status event of a notification,
+// the message rendered by the rule replaces the message of the check.
+func newStatusEvent(n *Notification) statusEvent {
+	ev := statusEvent{
+		Status: n.Status,
+	}
+	if n.Message != "" {
+		ev.Message = n.Message
+	}
+	if n.Rule != nil {
+		ev.RuleID = n.Rule.GetID()
+		ev.RuleName = n.Rule.GetName()
+	}
+	return ev
+}
+
+// dedupKey identifies the alert a status belongs to, statuses of the
+// same check and tag set share the same key.
+func dedupKey(st notification.Status) string {
+	h := sha256.New()
+	h.Write([]byte(st.CheckID.String()))
+	for _, k := range sortedTagKeys(st.Tags) {
+		fmt.Fprintf(h, "\n%s=%s", k, st.Tags[k])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func sortedTagKeys(tags map[string]string) []string {
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func checkURL(baseURL string, st notification.Status) string {
+	if baseURL == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/orgs/%s/alerting/checks/%s/edit", baseURL, st.OrgID, st.CheckID)
+}
+
+// postJSON posts v as json to url, service names the 3rd party service in errors.
+func (c Config) postJSON(ctx context.Context, service, url string, v interface{}, header http.Header) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	for k, vs := range header {
+		req.Header[k] = vs
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client().Do(req)
+	if err != nil {
+		return &influxdb.Error{
+			Code: influxdb.EUnavailable,
+			Msg:  "failed to send " + service + " notification",
+			Err:  err,
+		}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return unexpectedStatusError(service, resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package sender
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/endpoint"
+	"github.com/influxdata/influxdb/notification/i18n"
+	"github.com/influxdata/influxdb/notification/rule"
+)
+
+var _ Sender = (*Slack)(nil)
+
+// slackLevelColors are the attachment colors of each check level.
+var slackLevelColors = map[notification.CheckLevel]string{
+	notification.Unknown:  "#545667",
+	notification.Ok:       "#4ED8A0",
+	notification.Info:     "#00C9FF",
+	notification.Warn:     "#FFB94A",
+	notification.Critical: "#DC4E58",
+}
+
+// slackMaxFields is the most fields slack accepts in a section block.
+const slackMaxFields = 10
+
+// Slack sends notifications to slack as Block Kit messages.
+type Slack struct {
+	Config
+}
+
+type slackMessage struct {
+	Channel     string            `json:"channel,omitempty"`
+	Text        string            `json:"text"`
+	Attachments []slackAttachment `json:"attachments,omitempty"`
+}
+
+type slackAttachment struct {
+	Color  string       `json:"color"`
+	Blocks []slackBlock `json:"blocks"`
+}
+
+type slackBlock struct {
+	Type     string         `json:"type"`
+	Text     *slackText     `json:"text,omitempty"`
+	Fields   []slackText    `json:"fields,omitempty"`
+	Elements []slackElement `json:"elements,omitempty"`
+}
+
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type slackElement struct {
+	Type  string    `json:"type"`
+	Text  slackText `json:"text"`
+	URL   string    `json:"url,omitempty"`
+	Style string    `json:"style,omitempty"`
+}
+
+// Send implements Sender interface.
+func (s *Slack) Send(ctx context.Context, n *Notification) error {
+	edp, ok := n.Endpoint.(*endpoint.Slack)
+	if !ok {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("slack sender can't send to %s endpoint", n.Endpoint.Type()),
+		}
+	}
+
+	token, err := s.secretValue(ctx, edp.OrgID, edp.Token)
+	if err != nil {
+		return err
+	}
+
+	header := http.Header{}
+	if token != "" {
+		header.Set("Authorization", "Bearer "+token)
+	}
+	return s.postJSON(ctx, "slack", edp.URL, s.message(n), header)
+}
+
+func (s *Slack) message(n *Notification) slackMessage {
+	p := n.printer()
+	text := n.message()
+	msg := slackMessage{
+		Text: text,
+	}
+
+	r, ok := n.Rule.(*rule.Slack)
+	if ok {
+		msg.Channel = r.Channel
+		if r.PlainText {
+			return msg
+		}
+	}
+
+	fields := []slackText{
+		{Type: "mrkdwn", Text: "*" + p.Sprintf(i18n.LabelCheck) + "*\n" + n.Status.CheckName},
+		{Type: "mrkdwn", Text: "*" + p.Sprintf(i18n.LabelLevel) + "*\n" + n.Status.Level.String()},
+	}
+	if n.Status.Value != nil {
+		fields = append(fields, slackText{
+			Type: "mrkdwn",
+			Text: "*" + p.Sprintf(i18n.LabelValue) + "*\n" + strconv.FormatFloat(*n.Status.Value, 'f', -1, 64),
+		})
+	}
+	// the tags which don't fit in the fields are listed in a section of
+	// their own.
+	var rest []string
+	for _, k := range sortedTagKeys(n.Status.Tags) {
+		if len(fields) == slackMaxFields {
+			rest = append(rest, "*"+k+"*: "+n.Status.Tags[k])
+			continue
+		}
+		fields = append(fields, slackText{
+			Type: "mrkdwn",
+			Text: "*" + k + "*\n" + n.Status.Tags[k],
+		})
+	}
+
+	blocks := []slackBlock{
+		{
+			Type: "section",
+			Text: &slackText{Type: "mrkdwn", Text: text},
+		},
+		{
+			Type:   "section",
+			Fields: fields,
+		},
+	}
+	if len(rest) > 0 {
+		blocks = append(blocks, slackBlock{
+			Type: "section",
+			Text: &slackText{Type: "mrkdwn", Text: strings.Join(rest, "\n")},
+		})
+	}
+
+	var actions []slackElement
+	if u := checkURL(s.BaseURL, n.Status); u != "" {
+		actions = append(actions, slackElement{
+			Type: "button",
+			Text: slackText{Type: "plain_text", Text: p.Sprintf(i18n.ActionViewCheck)},
+			URL:  u,
+		})
+	}
+	if n.AcknowledgeURL != "" {
+		actions = append(actions, slackElement{
+			Type:  "button",
+			Text:  slackText{Type: "plain_text", Text: p.Sprintf(i18n.ActionAcknowledge)},
+			URL:   n.AcknowledgeURL,
+			Style: "primary",
+		})
+	}
+	if len(actions) > 0 {
+		blocks = append(blocks, slackBlock{
+			Type:     "actions",
+			Elements: actions,
+		})
+	}
+
+	msg.Attachments = []slackAttachment{
+		{
+			Color:  slackLevelColors[n.Status.Level],
+			Blocks: blocks,
+		},
+	}
+	return msg
+}
@@ -0,0 +1,267 @@
+package sender_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/endpoint"
+	"github.com/influxdata/influxdb/notification/rule"
+	"github.com/influxdata/influxdb/notification/sender"
+)
+
+func TestSlackSend(t *testing.T) {
+	value := 91.5
+	status := notification.Status{
+		CheckID:   influxdb.ID(2),
+		CheckName: "cpu usage",
+		OrgID:     influxdb.ID(3),
+		Level:     notification.Critical,
+		Value:     &value,
+		Tags: map[string]string{
+			"region": "us-west",
+			"host":   "server01",
+		},
+	}
+
+	cases := []struct {
+		name           string
+		rule           influxdb.NotificationRule
+		message        string
+		acknowledgeURL string
+		want           string
+	}{
+		{
+			name: "block kit message",
+			rule: &rule.Slack{
+				Channel:         "#alerts",
+				MessageTemplate: "msg1",
+			},
+			message:        "cpu usage is CRIT",
+			acknowledgeURL: "http://localhost:9999/ack/1",
+			want: `{
+				"channel": "#alerts",
+				"text": "cpu usage is CRIT",
+				"attachments": [{
+					"color": "#DC4E58",
+					"blocks": [
+						{"type": "section", "text": {"type": "mrkdwn", "text": "cpu usage is CRIT"}},
+						{"type": "section", "fields": [
+							{"type": "mrkdwn", "text": "*Check*\ncpu usage"},
+							{"type": "mrkdwn", "text": "*Level*\nCRIT"},
+							{"type": "mrkdwn", "text": "*Value*\n91.5"},
+							{"type": "mrkdwn", "text": "*host*\nserver01"},
+							{"type": "mrkdwn", "text": "*region*\nus-west"}
+						]},
+						{"type": "actions", "elements": [
+							{"type": "button", "text": {"type": "plain_text", "text": "View check"}, "url": "http://localhost:9999/orgs/0000000000000003/alerting/checks/0000000000000002/edit"},
+							{"type": "button", "text": {"type": "plain_text", "text": "Acknowledge"}, "url": "http://localhost:9999/ack/1", "style": "primary"}
+						]}
+					]
+				}]
+			}`,
+		},
+		{
+			name: "plain text message",
+			rule: &rule.Slack{
+				Channel:         "#alerts",
+				MessageTemplate: "msg1",
+				PlainText:       true,
+			},
+			message:        "cpu usage is CRIT",
+			acknowledgeURL: "http://localhost:9999/ack/1",
+			want: `{
+				"channel": "#alerts",
+				"text": "cpu usage is CRIT"
+			}`,
+		},
+		{
+			name: "localized message",
+			rule: &rule.Slack{
+				Base: rule.Base{
+					Locale: "de",
+				},
+				Channel:         "#alerts",
+				MessageTemplate: "msg1",
+			},
+			want: `{
+				"channel": "#alerts",
+				"text": "cpu usage ist kritisch",
+				"attachments": [{
+					"color": "#DC4E58",
+					"blocks": [
+						{"type": "section", "text": {"type": "mrkdwn", "text": "cpu usage ist kritisch"}},
+						{"type": "section", "fields": [
+							{"type": "mrkdwn", "text": "*Check*\ncpu usage"},
+							{"type": "mrkdwn", "text": "*Stufe*\nCRIT"},
+							{"type": "mrkdwn", "text": "*Wert*\n91.5"},
+							{"type": "mrkdwn", "text": "*host*\nserver01"},
+							{"type": "mrkdwn", "text": "*region*\nus-west"}
+						]},
+						{"type": "actions", "elements": [
+							{"type": "button", "text": {"type": "plain_text", "text": "Check anzeigen"}, "url": "http://localhost:9999/orgs/0000000000000003/alerting/checks/0000000000000002/edit"}
+						]}
+					]
+				}]
+			}`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var (
+				body []byte
+				auth string
+			)
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				auth = r.Header.Get("Authorization")
+				body, _ = ioutil.ReadAll(r.Body)
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer ts.Close()
+
+			s, err := sender.New("slack", sender.Config{
+				Client: ts.Client(),
+				SecretService: &mock.SecretService{
+					LoadSecretFn: func(ctx context.Context, orgID influxdb.ID, k string) (string, error) {
+						if k != "0000000000000001-token" {
+							t.Fatalf("unexpected secret key %q", k)
+						}
+						return "token1", nil
+					},
+				},
+				BaseURL: "http://localhost:9999",
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = s.Send(context.Background(), &sender.Notification{
+				Status: status,
+				Rule:   c.rule,
+				Endpoint: &endpoint.Slack{
+					Base: endpoint.Base{
+						ID:    influxdb.ID(1),
+						OrgID: influxdb.ID(3),
+					},
+					URL: ts.URL,
+					Token: influxdb.SecretField{
+						Key: "0000000000000001-token",
+					},
+				},
+				Message:        c.message,
+				AcknowledgeURL: c.acknowledgeURL,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if auth != "Bearer token1" {
+				t.Errorf("unexpected authorization header %q", auth)
+			}
+
+			var got, want interface{}
+			if err := json.Unmarshal(body, &got); err != nil {
+				t.Fatalf("invalid request body: %v", err)
+			}
+			if err := json.Unmarshal([]byte(c.want), &want); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(got, want); diff != "" {
+				t.Errorf("slack message is different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+func TestSlackSendFailure(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	s, err := sender.New("slack", sender.Config{Client: ts.Client()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.Send(context.Background(), &sender.Notification{
+		Rule: &rule.Slack{},
+		Endpoint: &endpoint.Slack{
+			URL: ts.URL,
+		},
+		Message: "msg",
+	})
+	if influxdb.ErrorCode(err) != influxdb.EUnavailable {
+		t.Fatalf("expected unavailable error, got %v", err)
+	}
+}
+
+func TestSlackSendManyTags(t *testing.T) {
+	var body []byte
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = ioutil.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	s, err := sender.New("slack", sender.Config{Client: ts.Client()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tags := map[string]string{}
+	for _, k := range []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j"} {
+		tags[k] = k + "1"
+	}
+	err = s.Send(context.Background(), &sender.Notification{
+		Status: notification.Status{
+			CheckName: "cpu usage",
+			Level:     notification.Critical,
+			Tags:      tags,
+		},
+		Rule: &rule.Slack{},
+		Endpoint: &endpoint.Slack{
+			URL: ts.URL,
+		},
+		Message: "cpu usage is CRIT",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got struct {
+		Attachments []struct {
+			Blocks []struct {
+				Type string `json:"type"`
+				Text *struct {
+					Text string `json:"text"`
+				} `json:"text"`
+				Fields []struct {
+					Text string `json:"text"`
+				} `json:"fields"`
+			} `json:"blocks"`
+		} `json:"attachments"`
+	}
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatalf("invalid request body: %v", err)
+	}
+	if len(got.Attachments) != 1 || len(got.Attachments[0].Blocks) != 3 {
+		t.Fatalf("expected the message, the fields and the other tags blocks, got %s", body)
+	}
+	blocks := got.Attachments[0].Blocks
+	if n := len(blocks[1].Fields); n != 10 {
+		t.Errorf("expected the 10 fields slack accepts, got %d", n)
+	}
+	if last := blocks[1].Fields[9].Text; last != "*h*\nh1" {
+		t.Errorf("unexpected last field %q", last)
+	}
+	if blocks[2].Text == nil || blocks[2].Text.Text != "*i*: i1\n*j*: j1" {
+		t.Errorf("expected the tags which don't fit in the fields in a section, got %s", body)
+	}
+}
@@ -0,0 +1,104 @@
+package sender
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification/endpoint"
+)
+
+var _ Sender = (*SNS)(nil)
+
+const awsSNSVersion = "2010-03-31"
+
+// SNS publishes status events to an aws sns topic.
+// The level and check of the status are set as message attributes,
+// so subscriptions can filter on them.
+type SNS struct {
+	Config
+
+	// URL overrides the regional urls of sns and sts,
+	// it is used by tests and aws compatible services.
+	URL string
+}
+
+// Send implements Sender interface.
+func (s *SNS) Send(ctx context.Context, n *Notification) error {
+	edp, ok := n.Endpoint.(*endpoint.SNS)
+	if !ok {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("sns sender can't send to %s endpoint", n.Endpoint.Type()),
+		}
+	}
+
+	// arn:partition:sns:region:account-id:topic
+	arn := strings.SplitN(edp.TopicARN, ":", 6)
+	if len(arn) != 6 {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "sns endpoint topic arn is invalid",
+		}
+	}
+	partition, region, topic := arn[1], arn[3], arn[5]
+
+	var creds awsCredentials
+	var err error
+	if creds.AccessKeyID, err = s.secretValue(ctx, edp.OrgID, edp.AccessKeyID); err != nil {
+		return err
+	}
+	if creds.SecretAccessKey, err = s.secretValue(ctx, edp.OrgID, edp.SecretAccessKey); err != nil {
+		return err
+	}
+
+	if edp.RoleARN != "" {
+		stsURL := s.URL
+		if stsURL == "" {
+			stsURL = awsServiceURL("sts", partition, region)
+		}
+		creds, err = s.awsAssumeRole(ctx, stsURL, region, creds, edp.RoleARN, "influxdb-"+edp.ID.String())
+		if err != nil {
+			return err
+		}
+	}
+
+	payload, err := json.Marshal(newStatusEvent(n))
+	if err != nil {
+		return err
+	}
+
+	params := url.Values{
+		"Action":   {"Publish"},
+		"Version":  {awsSNSVersion},
+		"TopicArn": {edp.TopicARN},
+		"Message":  {string(payload)},
+	}
+	attrs := [][2]string{
+		{"level", n.Status.Level.String()},
+		{"checkID", n.Status.CheckID.String()},
+	}
+	for i, attr := range attrs {
+		prefix := fmt.Sprintf("MessageAttributes.entry.%d.", i+1)
+		params.Set(prefix+"Name", attr[0])
+		params.Set(prefix+"Value.DataType", "String")
+		params.Set(prefix+"Value.StringValue", attr[1])
+	}
+	if strings.HasSuffix(topic, ".fifo") {
+		// fifo topics order the events of a series and drop duplicates.
+		params.Set("MessageGroupId", dedupKey(n.Status))
+		sum := sha256.Sum256(payload)
+		params.Set("MessageDeduplicationId", hex.EncodeToString(sum[:]))
+	}
+
+	snsURL := s.URL
+	if snsURL == "" {
+		snsURL = awsServiceURL("sns", partition, region)
+	}
+	return s.awsQuery(ctx, snsURL, "sns", region, creds, params, nil)
+}
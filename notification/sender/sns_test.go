@@ -0,0 +1,184 @@
+package sender_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/endpoint"
+	"github.com/influxdata/influxdb/notification/rule"
+	"github.com/influxdata/influxdb/notification/sender"
+)
+
+const stsAssumeRoleResponse = `<AssumeRoleResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/">
+  <AssumeRoleResult>
+    <Credentials>
+      <AccessKeyId>ASIAROLE</AccessKeyId>
+      <SecretAccessKey>role-secret</SecretAccessKey>
+      <SessionToken>role-token</SessionToken>
+    </Credentials>
+  </AssumeRoleResult>
+</AssumeRoleResponse>`
+
+func TestSNSSend(t *testing.T) {
+	accessKeyID, secretAccessKey := "AKIAUSER", "user-secret"
+	cases := []struct {
+		name      string
+		topicARN  string
+		roleARN   string
+		wantKeyID string
+		wantToken string
+		wantGroup bool
+	}{
+		{
+			name:      "user credentials",
+			topicARN:  "arn:aws:sns:us-west-2:123456789012:alerts",
+			wantKeyID: "AKIAUSER",
+		},
+		{
+			name:      "assumed role",
+			topicARN:  "arn:aws:sns:us-west-2:123456789012:alerts",
+			roleARN:   "arn:aws:iam::123456789012:role/alerts",
+			wantKeyID: "ASIAROLE",
+			wantToken: "role-token",
+		},
+		{
+			name:      "fifo topic",
+			topicARN:  "arn:aws:sns:us-west-2:123456789012:alerts.fifo",
+			wantKeyID: "AKIAUSER",
+			wantGroup: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var assumed bool
+			var publish *http.Request
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if err := r.ParseForm(); err != nil {
+					t.Error(err)
+				}
+				switch r.PostForm.Get("Action") {
+				case "AssumeRole":
+					assumed = true
+					if !strings.Contains(r.Header.Get("Authorization"), "Credential=AKIAUSER/") ||
+						!strings.Contains(r.Header.Get("Authorization"), "/us-west-2/sts/aws4_request") {
+						t.Errorf("unexpected sts authorization %q", r.Header.Get("Authorization"))
+					}
+					if r.PostForm.Get("RoleArn") != c.roleARN {
+						t.Errorf("unexpected role arn %q", r.PostForm.Get("RoleArn"))
+					}
+					w.Write([]byte(stsAssumeRoleResponse))
+				case "Publish":
+					publish = r
+					w.Write([]byte(`<PublishResponse><PublishResult><MessageId>1</MessageId></PublishResult></PublishResponse>`))
+				default:
+					w.WriteHeader(http.StatusBadRequest)
+				}
+			}))
+			defer ts.Close()
+
+			s := &sender.SNS{
+				Config: sender.Config{
+					Client: ts.Client(),
+				},
+				URL: ts.URL,
+			}
+			err := s.Send(context.Background(), &sender.Notification{
+				Status: notification.Status{
+					CheckID:   influxdb.ID(2),
+					CheckName: "cpu",
+					OrgID:     influxdb.ID(3),
+					Level:     notification.Critical,
+					Tags:      map[string]string{"host": "server01"},
+				},
+				Rule: &rule.SNS{
+					MessageTemplate: "msg1",
+				},
+				Endpoint: &endpoint.SNS{
+					Base: endpoint.Base{
+						ID:    influxdb.ID(1),
+						OrgID: influxdb.ID(3),
+					},
+					TopicARN:        c.topicARN,
+					AccessKeyID:     influxdb.SecretField{Value: &accessKeyID},
+					SecretAccessKey: influxdb.SecretField{Value: &secretAccessKey},
+					RoleARN:         c.roleARN,
+				},
+				Message: "cpu is high",
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if assumed != (c.roleARN != "") {
+				t.Errorf("expected role to be assumed: %v", c.roleARN != "")
+			}
+			if publish == nil {
+				t.Fatal("expected a message to be published")
+			}
+			auth := publish.Header.Get("Authorization")
+			if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential="+c.wantKeyID+"/") ||
+				!strings.Contains(auth, "/us-west-2/sns/aws4_request") {
+				t.Errorf("unexpected sns authorization %q", auth)
+			}
+			if got := publish.Header.Get("X-Amz-Security-Token"); got != c.wantToken {
+				t.Errorf("unexpected security token %q", got)
+			}
+
+			form := publish.PostForm
+			if form.Get("TopicArn") != c.topicARN {
+				t.Errorf("unexpected topic arn %q", form.Get("TopicArn"))
+			}
+			if form.Get("MessageAttributes.entry.1.Name") != "level" || form.Get("MessageAttributes.entry.1.Value.StringValue") != "CRIT" {
+				t.Errorf("unexpected level attribute %v", form)
+			}
+			if (form.Get("MessageGroupId") != "") != c.wantGroup || (form.Get("MessageDeduplicationId") != "") != c.wantGroup {
+				t.Errorf("unexpected fifo parameters %v", form)
+			}
+			ev := map[string]interface{}{}
+			if err := json.Unmarshal([]byte(form.Get("Message")), &ev); err != nil {
+				t.Fatalf("invalid message: %v", err)
+			}
+			if ev["message"] != "cpu is high" || ev["checkName"] != "cpu" {
+				t.Errorf("unexpected message %v", ev)
+			}
+		})
+	}
+}
+
+func TestSNSSendError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`<ErrorResponse><Error><Type>Sender</Type><Code>AuthorizationError</Code><Message>not authorized</Message></Error></ErrorResponse>`))
+	}))
+	defer ts.Close()
+
+	accessKeyID, secretAccessKey := "AKIAUSER", "user-secret"
+	s := &sender.SNS{
+		Config: sender.Config{
+			Client: ts.Client(),
+		},
+		URL: ts.URL,
+	}
+	err := s.Send(context.Background(), &sender.Notification{
+		Status: notification.Status{
+			CheckID: influxdb.ID(2),
+			OrgID:   influxdb.ID(3),
+		},
+		Rule: &rule.SNS{},
+		Endpoint: &endpoint.SNS{
+			TopicARN:        "arn:aws:sns:us-west-2:123456789012:alerts",
+			AccessKeyID:     influxdb.SecretField{Value: &accessKeyID},
+			SecretAccessKey: influxdb.SecretField{Value: &secretAccessKey},
+		},
+	})
+	if influxdb.ErrorCode(err) != influxdb.EInvalid || influxdb.ErrorMessage(err) != "sns responded with AuthorizationError: not authorized" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
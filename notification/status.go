@@ -3,10 +3,50 @@ package notification
 import (
 	"encoding/json"
 	"strings"
+	"time"
 
 	"github.com/influxdata/influxdb"
 )
 
+// Status is the result of a single check evaluation,
+// notification rules are matched against it.
+type Status struct {
+	// ID identifies the status when its decision trace is recorded.
+	ID        influxdb.ID       `json:"id,omitempty"`
+	CheckID   influxdb.ID       `json:"checkID"`
+	CheckName string            `json:"checkName"`
+	OrgID     influxdb.ID       `json:"orgID"`
+	Level     CheckLevel        `json:"level"`
+	Message   string            `json:"message"`
+	Value     *float64          `json:"value,omitempty"`
+	Tags      map[string]string `json:"tags,omitempty"`
+	Time      time.Time         `json:"time"`
+	// Synthetic is set on the statuses injected to test the notification rules.
+	Synthetic bool `json:"synthetic,omitempty"`
+	// IncidentDuration is how long the series of a status recovering to ok
+	// wasn't ok, zero for the other statuses.
+	IncidentDuration time.Duration `json:"incidentDuration,omitempty"`
+	// Priority is the priority of the check of the status, empty when it
+	// is normal.
+	Priority influxdb.CheckPriority `json:"priority,omitempty"`
+	// Runbook is the runbook URL of the check of the status, empty when it
+	// has none.
+	Runbook string `json:"runbook,omitempty"`
+}
+
+// FormatDuration formats a duration rounded to the second without its
+// trailing zero units, such as 23m or 1h5m.
+func FormatDuration(d time.Duration) string {
+	s := d.Round(time.Second).String()
+	if strings.HasSuffix(s, "m0s") {
+		s = s[:len(s)-2]
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = s[:len(s)-2]
+	}
+	return s
+}
+
 // StatusRule includes parametes of status rules.
 type StatusRule struct {
 	CurrentLevel  LevelRule  `json:"currentLevel"`
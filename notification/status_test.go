@@ -60,3 +60,22 @@ func TestStatusJSON(t *testing.T) {
 		}
 	}
 }
+
+func TestFormatDuration(t *testing.T) {
+	cases := []struct {
+		d    time.Duration
+		want string
+	}{
+		{d: 23 * time.Minute, want: "23m"},
+		{d: time.Hour + 5*time.Minute, want: "1h5m"},
+		{d: 2 * time.Hour, want: "2h"},
+		{d: 45*time.Second + 400*time.Millisecond, want: "45s"},
+		{d: time.Hour + 30*time.Second, want: "1h0m30s"},
+		{d: 0, want: "0s"},
+	}
+	for _, c := range cases {
+		if got := FormatDuration(c.d); got != c.want {
+			t.Errorf("FormatDuration(%s) = %q, want %q", c.d, got, c.want)
+		}
+	}
+}
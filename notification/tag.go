@@ -2,6 +2,7 @@ package notification
 
 import (
 	"fmt"
+	"regexp"
 
 	"github.com/influxdata/influxdb"
 )
@@ -12,6 +13,16 @@ type Tag struct {
 	Value string `json:"value"`
 }
 
+// LabelTagPrefix prefixes the keys of the tags of the labels of a check on
+// the statuses it emits.
+const LabelTagPrefix = "label_"
+
+// LabelTag returns the tag of the label named name on the statuses of a check,
+// so notification rules can match the labels of the checks.
+func LabelTag(name string) Tag {
+	return Tag{Key: LabelTagPrefix + name, Value: "true"}
+}
+
 // TagRule is the struct of tag rule.
 type TagRule struct {
 	Tag
@@ -46,3 +57,44 @@ func (tr TagRule) Valid() error {
 	}
 	return nil
 }
+
+// Match returns whether the tags satisfy the tag rule.
+// A tag rule whose regular expression doesn't compile is never satisfied.
+func (tr TagRule) Match(tags []Tag) bool {
+	switch tr.Operator {
+	case Equal, NotEqual:
+		found := false
+		for _, t := range tags {
+			if t.Key == tr.Key && t.Value == tr.Value {
+				found = true
+				break
+			}
+		}
+		return found == (tr.Operator == Equal)
+	case RegexEqual, NotRegexEqual:
+		re, err := regexp.Compile(tr.Value)
+		if err != nil {
+			return false
+		}
+		found := false
+		for _, t := range tags {
+			if t.Key == tr.Key && re.MatchString(t.Value) {
+				found = true
+				break
+			}
+		}
+		return found == (tr.Operator == RegexEqual)
+	}
+	return false
+}
+
+// MatchTagRules returns whether the tags satisfy every tag rule,
+// tags always satisfy an empty list of tag rules.
+func MatchTagRules(trs []TagRule, tags []Tag) bool {
+	for _, tr := range trs {
+		if !tr.Match(tags) {
+			return false
+		}
+	}
+	return true
+}
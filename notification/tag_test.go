@@ -0,0 +1,65 @@
+package notification
+
+import "testing"
+
+func TestTagRuleMatch(t *testing.T) {
+	tags := []Tag{
+		{Key: "team", Value: "ops"},
+		{Key: "host", Value: "db-01"},
+	}
+	cases := []struct {
+		name string
+		rule TagRule
+		want bool
+	}{
+		{
+			name: "equal",
+			rule: TagRule{Tag: Tag{Key: "team", Value: "ops"}, Operator: Equal},
+			want: true,
+		},
+		{
+			name: "equal other value",
+			rule: TagRule{Tag: Tag{Key: "team", Value: "dev"}, Operator: Equal},
+			want: false,
+		},
+		{
+			name: "not equal",
+			rule: TagRule{Tag: Tag{Key: "team", Value: "dev"}, Operator: NotEqual},
+			want: true,
+		},
+		{
+			name: "not equal missing key",
+			rule: TagRule{Tag: Tag{Key: "region", Value: "eu"}, Operator: NotEqual},
+			want: true,
+		},
+		{
+			name: "regex",
+			rule: TagRule{Tag: Tag{Key: "host", Value: "^db-"}, Operator: RegexEqual},
+			want: true,
+		},
+		{
+			name: "not regex",
+			rule: TagRule{Tag: Tag{Key: "host", Value: "^db-"}, Operator: NotRegexEqual},
+			want: false,
+		},
+		{
+			name: "invalid regex",
+			rule: TagRule{Tag: Tag{Key: "host", Value: "("}, Operator: NotRegexEqual},
+			want: false,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := c.rule.Match(tags); got != c.want {
+				t.Errorf("expected %v, got %v", c.want, got)
+			}
+		})
+	}
+
+	if !MatchTagRules(nil, tags) {
+		t.Errorf("expected tags to satisfy no tag rules")
+	}
+	if MatchTagRules([]TagRule{cases[0].rule, cases[1].rule}, tags) {
+		t.Errorf("expected tags to not satisfy every tag rule")
+	}
+}
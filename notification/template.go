@@ -0,0 +1,171 @@
+package notification
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/influxdb"
+)
+
+// templateVar matches the ${r.key} references of a template,
+// they follow the flux string interpolation of record columns.
+var templateVar = regexp.MustCompile(`\$\{\s*r\.([A-Za-z0-9_\-]+)\s*\}`)
+
+// ExpandTemplate replaces the ${r.key} references of tmpl with the values of the status.
+// The _check_id, _check_name, _level, _message, _value, _time, _runbook and
+// _incident_duration keys refer to the status itself, any other key refers
+// to a tag. Unknown keys expand to empty strings.
+func ExpandTemplate(tmpl string, st Status) string {
+	return templateVar.ReplaceAllStringFunc(tmpl, func(m string) string {
+		key := templateVar.FindStringSubmatch(m)[1]
+		v, _ := st.templateValue(key)
+		return v
+	})
+}
+
+// TemplateVarIndex returns the indexes of the ${r.key} references of tmpl
+// and of their keys, in pairs like regexp.FindAllStringSubmatchIndex.
+func TemplateVarIndex(tmpl string) [][]int {
+	return templateVar.FindAllStringSubmatchIndex(tmpl, -1)
+}
+
+// partialVar matches the ${partial.name} references of a template to the
+// notification templates of its organization.
+var partialVar = regexp.MustCompile(`\$\{\s*partial\.([A-Za-z0-9_\-]+)\s*\}`)
+
+// RenderMessage renders a message template for a status,
+// it expands the partials first and then the ${r.key} references.
+// The ${secret.key} references are left as is.
+func RenderMessage(tmpl string, partials map[string]string, st Status) (string, error) {
+	msg, err := ExpandPartials(tmpl, partials)
+	if err != nil {
+		return "", err
+	}
+	return ExpandTemplate(msg, st), nil
+}
+
+// messageVar matches the ${r.key} and ${secret.key} references of a template,
+// the latter refer to the secrets of its organization.
+var messageVar = regexp.MustCompile(`\$\{\s*(r|secret)\.([A-Za-z0-9_\-]+)\s*\}`)
+
+// RenderSecretMessage renders a message template for a status like
+// RenderMessage, and replaces its ${secret.key} references with the secrets
+// loaded by load. The secrets are expanded in the same pass as the values of
+// the status, so a status can't reference a secret through its tags.
+func RenderSecretMessage(tmpl string, partials map[string]string, st Status, load func(key string) (string, error)) (string, error) {
+	msg, err := ExpandPartials(tmpl, partials)
+	if err != nil {
+		return "", err
+	}
+	msg = messageVar.ReplaceAllStringFunc(msg, func(m string) string {
+		if err != nil {
+			return ""
+		}
+		ref := messageVar.FindStringSubmatch(m)
+		if ref[1] == "r" {
+			v, _ := st.templateValue(ref[2])
+			return v
+		}
+		var v string
+		v, err = load(ref[2])
+		return v
+	})
+	if err != nil {
+		return "", err
+	}
+	return msg, nil
+}
+
+// ExpandPartials replaces the ${partial.name} references of tmpl with the
+// templates of partials, which can reference other partials in turn.
+// It returns an error if a partial doesn't exist or if partials reference
+// each other in a cycle.
+func ExpandPartials(tmpl string, partials map[string]string) (string, error) {
+	return expandPartials(tmpl, partials, nil)
+}
+
+// CheckPartials returns an error if a partial references a partial
+// which doesn't exist or if partials reference each other in a cycle.
+func CheckPartials(partials map[string]string) error {
+	names := make([]string, 0, len(partials))
+	for name := range partials {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, err := expandPartials(partials[name], partials, []string{name}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func expandPartials(tmpl string, partials map[string]string, path []string) (string, error) {
+	var err error
+	out := partialVar.ReplaceAllStringFunc(tmpl, func(m string) string {
+		if err != nil {
+			return ""
+		}
+		name := partialVar.FindStringSubmatch(m)[1]
+		for i, p := range path {
+			if p == name {
+				err = &influxdb.Error{
+					Code: influxdb.EInvalid,
+					Msg:  "notification templates reference each other in a cycle: " + strings.Join(path[i:], " -> ") + " -> " + name,
+				}
+				return ""
+			}
+		}
+		partial, ok := partials[name]
+		if !ok {
+			err = &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  fmt.Sprintf("notification template %q not found", name),
+			}
+			return ""
+		}
+		var expanded string
+		expanded, err = expandPartials(partial, partials, append(path[:len(path):len(path)], name))
+		return expanded
+	})
+	if err != nil {
+		return "", err
+	}
+	return out, nil
+}
+
+func (s Status) templateValue(key string) (string, bool) {
+	switch key {
+	case "_check_id":
+		return s.CheckID.String(), true
+	case "_check_name":
+		return s.CheckName, true
+	case "_level":
+		return s.Level.String(), true
+	case "_message":
+		return s.Message, true
+	case "_value":
+		if s.Value == nil {
+			return "", false
+		}
+		return strconv.FormatFloat(*s.Value, 'f', -1, 64), true
+	case "_time":
+		if s.Time.IsZero() {
+			return "", false
+		}
+		return s.Time.UTC().Format(time.RFC3339Nano), true
+	case "_runbook":
+		return s.Runbook, s.Runbook != ""
+	case "_incident_duration":
+		if s.IncidentDuration == 0 {
+			return "", false
+		}
+		return FormatDuration(s.IncidentDuration), true
+	}
+	v, ok := s.Tags[key]
+	return v, ok
+}
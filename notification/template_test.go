@@ -0,0 +1,172 @@
+package notification
+
+import (
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+)
+
+func TestExpandTemplate(t *testing.T) {
+	value := 0.75
+	st := Status{
+		CheckID:   influxdb.ID(1),
+		CheckName: "cpu",
+		Level:     Critical,
+		Message:   "cpu is high",
+		Value:     &value,
+		Tags:      map[string]string{"host": "server01"},
+		Runbook:   "https://runbooks.example.com/cpu",
+		Time:      time.Date(2006, time.July, 13, 4, 19, 10, 0, time.UTC),
+	}
+	cases := []struct {
+		tmpl string
+		want string
+	}{
+		{
+			tmpl: "alerts/${r.host}/${r._check_name}",
+			want: "alerts/server01/cpu",
+		},
+		{
+			tmpl: "${r._check_id} ${ r._level } ${r._value} ${r._time}",
+			want: "0000000000000001 CRIT 0.75 2006-07-13T04:19:10Z",
+		},
+		{
+			tmpl: "${r._message}${r.missing}",
+			want: "cpu is high",
+		},
+		{
+			tmpl: "no references",
+			want: "no references",
+		},
+		{
+			tmpl: "recovered after ${r._incident_duration}",
+			want: "recovered after ",
+		},
+		{
+			tmpl: "runbook: ${r._runbook}",
+			want: "runbook: https://runbooks.example.com/cpu",
+		},
+	}
+	for _, c := range cases {
+		if got := ExpandTemplate(c.tmpl, st); got != c.want {
+			t.Errorf("ExpandTemplate(%q) = %q, want %q", c.tmpl, got, c.want)
+		}
+	}
+}
+
+func TestExpandPartials(t *testing.T) {
+	partials := map[string]string{
+		"footer":  "-- ${partial.runbook}",
+		"runbook": "runbook: https://runbooks.example.com/${r._check_name}",
+		"a":       "${partial.b}",
+		"b":       "${partial.c}",
+		"c":       "${partial.a}",
+	}
+	cases := []struct {
+		tmpl string
+		want string
+		err  string
+	}{
+		{
+			tmpl: "${r._check_name} is ${r._level}\n${ partial.footer }",
+			want: "${r._check_name} is ${r._level}\n-- runbook: https://runbooks.example.com/${r._check_name}",
+		},
+		{
+			tmpl: "${partial.runbook} ${partial.runbook}",
+			want: "runbook: https://runbooks.example.com/${r._check_name} runbook: https://runbooks.example.com/${r._check_name}",
+		},
+		{
+			tmpl: "${partial.missing}",
+			err:  `notification template "missing" not found`,
+		},
+		{
+			tmpl: "${partial.a}",
+			err:  "notification templates reference each other in a cycle: a -> b -> c -> a",
+		},
+	}
+	for _, c := range cases {
+		got, err := ExpandPartials(c.tmpl, partials)
+		if c.err != "" {
+			if influxdb.ErrorCode(err) != influxdb.EInvalid || influxdb.ErrorMessage(err) != c.err {
+				t.Errorf("ExpandPartials(%q) expected error %q, got %v", c.tmpl, c.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ExpandPartials(%q) unexpected error: %v", c.tmpl, err)
+		}
+		if got != c.want {
+			t.Errorf("ExpandPartials(%q) = %q, want %q", c.tmpl, got, c.want)
+		}
+	}
+}
+
+func TestCheckPartials(t *testing.T) {
+	if err := CheckPartials(map[string]string{
+		"footer":  "-- ${partial.runbook}",
+		"runbook": "runbook",
+	}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	err := CheckPartials(map[string]string{
+		"footer": "-- ${partial.footer}",
+	})
+	if influxdb.ErrorMessage(err) != "notification templates reference each other in a cycle: footer -> footer" {
+		t.Errorf("expected cycle error, got %v", err)
+	}
+}
+
+func TestRenderMessage(t *testing.T) {
+	st := Status{
+		CheckName: "cpu",
+		Level:     Warn,
+	}
+	got, err := RenderMessage("${r._check_name} is ${r._level}. ${partial.footer}", map[string]string{
+		"footer": "see https://runbooks.example.com/${r._check_name}",
+	}, st)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "cpu is WARN. see https://runbooks.example.com/cpu"; got != want {
+		t.Errorf("RenderMessage() = %q, want %q", got, want)
+	}
+}
+
+func TestRenderSecretMessage(t *testing.T) {
+	st := Status{
+		CheckName: "cpu",
+		Level:     Critical,
+		Tags:      map[string]string{"host": "${secret.token}"},
+	}
+	secrets := map[string]string{"api-key": "s3cr3t", "token": "t0k3n"}
+	load := func(key string) (string, error) {
+		v, ok := secrets[key]
+		if !ok {
+			return "", &influxdb.Error{Code: influxdb.ENotFound, Msg: "secret not found"}
+		}
+		return v, nil
+	}
+	got, err := RenderSecretMessage(`{"key":"${ secret.api-key }","text":"${partial.text}"}`, map[string]string{
+		"text": "${r._check_name} on ${r.host}",
+	}, st, load)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := `{"key":"s3cr3t","text":"cpu on ${secret.token}"}`; got != want {
+		t.Errorf("RenderSecretMessage() = %q, want %q", got, want)
+	}
+
+	if _, err := RenderSecretMessage("${secret.missing}", nil, st, load); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Errorf("expected a missing secret to be not found, got %v", err)
+	}
+
+	got, err = RenderMessage("${secret.api-key}", nil, st)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "${secret.api-key}"; got != want {
+		t.Errorf("RenderMessage() = %q, want %q", got, want)
+	}
+}
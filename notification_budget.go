@@ -0,0 +1,116 @@
+package influxdb
+
+import (
+	"context"
+	"time"
+)
+
+// NotificationBudgetMonthFormat is the format of the months of the usage of
+// notification budgets.
+const NotificationBudgetMonthFormat = "2006-01"
+
+// NotificationBudget is the number of notifications a notification endpoint
+// may send per calendar month in UTC, such as 500 PagerDuty events. The
+// notifications over the budget are sent to the fallback endpoint, or
+// dropped without one.
+type NotificationBudget struct {
+	EndpointID ID `json:"endpointID"`
+	OrgID      ID `json:"orgID"`
+	// Monthly is the number of notifications of a month.
+	Monthly int `json:"monthly"`
+	// AdminEndpointID is notified the first time the budget of a month is exceeded.
+	AdminEndpointID *ID `json:"adminEndpointID,omitempty"`
+	// FallbackEndpointID receives the notifications over the budget.
+	FallbackEndpointID *ID `json:"fallbackEndpointID,omitempty"`
+	CRUDLog
+}
+
+// Valid returns error if some configuration is invalid
+func (b NotificationBudget) Valid() error {
+	if !b.EndpointID.Valid() {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "Notification Budget EndpointID is invalid",
+		}
+	}
+	if b.Monthly <= 0 {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "Notification Budget monthly must be positive",
+		}
+	}
+	for _, id := range []*ID{b.AdminEndpointID, b.FallbackEndpointID} {
+		if id == nil {
+			continue
+		}
+		if !id.Valid() {
+			return &Error{
+				Code: EInvalid,
+				Msg:  "Notification Budget admin and fallback endpoint ids must be valid",
+			}
+		}
+		if *id == b.EndpointID {
+			return &Error{
+				Code: EInvalid,
+				Msg:  "Notification Budget admin and fallback endpoints must differ from its endpoint",
+			}
+		}
+	}
+	return nil
+}
+
+// NotificationBudgetUsage is the number of notifications routed to an
+// endpoint in a month, against its budget.
+type NotificationBudgetUsage struct {
+	EndpointID ID `json:"endpointID"`
+	OrgID      ID `json:"orgID"`
+	// Month is formatted as 2006-01.
+	Month   string `json:"month"`
+	Monthly int    `json:"monthly"`
+	// Notifications counts the notifications over the budget too.
+	Notifications int `json:"notifications"`
+	Remaining     int `json:"remaining"`
+	Overage       int `json:"overage"`
+}
+
+// NewNotificationBudgetUsage returns the usage of a budget by a number of
+// notifications in the month of t.
+func NewNotificationBudgetUsage(b *NotificationBudget, t time.Time, notifications int) *NotificationBudgetUsage {
+	u := &NotificationBudgetUsage{
+		EndpointID:    b.EndpointID,
+		OrgID:         b.OrgID,
+		Month:         t.UTC().Format(NotificationBudgetMonthFormat),
+		Monthly:       b.Monthly,
+		Notifications: notifications,
+	}
+	if notifications > b.Monthly {
+		u.Overage = notifications - b.Monthly
+	} else {
+		u.Remaining = b.Monthly - notifications
+	}
+	return u
+}
+
+// Exceeded returns whether the notifications of the month exceed the budget.
+func (u NotificationBudgetUsage) Exceeded() bool {
+	return u.Overage > 0
+}
+
+// NotificationBudgetService represents a service for managing the notification budgets of endpoints.
+type NotificationBudgetService interface {
+	// FindNotificationBudget returns the notification budget of an endpoint.
+	FindNotificationBudget(ctx context.Context, endpointID ID) (*NotificationBudget, error)
+
+	// PutNotificationBudget creates or replaces the notification budget of an endpoint.
+	PutNotificationBudget(ctx context.Context, b *NotificationBudget) error
+
+	// DeleteNotificationBudget removes the notification budget of an endpoint.
+	DeleteNotificationBudget(ctx context.Context, endpointID ID) error
+
+	// FindNotificationBudgetUsage returns the usage of the budget of an endpoint in the month of t.
+	FindNotificationBudgetUsage(ctx context.Context, endpointID ID, t time.Time) (*NotificationBudgetUsage, error)
+
+	// SpendNotificationBudget counts a notification routed to an endpoint at t,
+	// and returns the usage of the budget of its month.
+	SpendNotificationBudget(ctx context.Context, endpointID ID, t time.Time) (*NotificationBudgetUsage, error)
+}
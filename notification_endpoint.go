@@ -0,0 +1,186 @@
+package influxdb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// NotificationEndpoint is the configuration describing
+// how to call a 3rd party service. E.g. Slack, Pagerduty
+type NotificationEndpoint interface {
+	Valid() error
+	Type() string
+	json.Marshaler
+	Updator
+	Getter
+	// SecretFields returns all the secret fields of the endpoint.
+	SecretFields() []SecretField
+	// BackfillSecretKeys fills the secret key of each secret field
+	// that has a value but no key yet, based on the endpoint ID.
+	BackfillSecretKeys()
+}
+
+// NotificationEndpointFilter represents a set of filter that restrict the returned notification endpoints.
+type NotificationEndpointFilter struct {
+	OrgID        *ID
+	Organization *string
+	UserResourceMappingFilter
+}
+
+// QueryParams Converts NotificationEndpointFilter fields to url query params.
+func (f NotificationEndpointFilter) QueryParams() map[string][]string {
+	qp := map[string][]string{}
+
+	if f.OrgID != nil {
+		qp["orgID"] = []string{f.OrgID.String()}
+	}
+
+	if f.Organization != nil {
+		qp["org"] = []string{*f.Organization}
+	}
+
+	return qp
+}
+
+// NotificationEndpointUpdate is the set changeset of a notification endpoint.
+type NotificationEndpointUpdate struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+	Status      *Status `json:"status,omitempty"`
+}
+
+// Valid returns error if some configuration is invalid
+func (n *NotificationEndpointUpdate) Valid() error {
+	if n.Name != nil && *n.Name == "" {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "Notification Endpoint Name can't be empty",
+		}
+	}
+
+	if n.Description != nil && *n.Description == "" {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "Notification Endpoint Description can't be empty",
+		}
+	}
+
+	if n.Status != nil {
+		if err := n.Status.Valid(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// NotificationEndpointService represents a service for managing notification endpoints.
+type NotificationEndpointService interface {
+	// UserResourceMappingService must be part of all NotificationEndpointService service,
+	// for create, delete.
+	UserResourceMappingService
+	// OrganizationService is needed for search filter
+	OrganizationService
+
+	// FindNotificationEndpointByID returns a single notification endpoint by ID.
+	FindNotificationEndpointByID(ctx context.Context, id ID) (NotificationEndpoint, error)
+
+	// FindNotificationEndpoints returns a list of notification endpoints that match filter and the total count of matching notification endpoints.
+	// Additional options provide pagination & sorting.
+	FindNotificationEndpoints(ctx context.Context, filter NotificationEndpointFilter, opt ...FindOptions) ([]NotificationEndpoint, int, error)
+
+	// CreateNotificationEndpoint creates a new notification endpoint and sets b.ID with the new identifier.
+	CreateNotificationEndpoint(ctx context.Context, ne NotificationEndpoint, userID ID) error
+
+	// UpdateNotificationEndpoint updates a single notification endpoint.
+	// Returns the new notification endpoint after update.
+	UpdateNotificationEndpoint(ctx context.Context, id ID, nr NotificationEndpoint, userID ID) (NotificationEndpoint, error)
+
+	// PatchNotificationEndpoint updates a single  notification endpoint with changeset.
+	// Returns the new notification endpoint state after update.
+	PatchNotificationEndpoint(ctx context.Context, id ID, upd NotificationEndpointUpdate) (NotificationEndpoint, error)
+
+	// DeleteNotificationEndpoint removes a notification endpoint by ID.
+	DeleteNotificationEndpoint(ctx context.Context, id ID) error
+}
+
+// NotificationEndpointInUseError is the error of deleting a notification
+// endpoint which notification rules still send to.
+type NotificationEndpointInUseError struct {
+	EndpointID ID
+	// RuleIDs are the notification rules blocking the deletion.
+	RuleIDs []ID
+}
+
+// Error implements the error interface.
+func (e *NotificationEndpointInUseError) Error() string {
+	ids := make([]string, 0, len(e.RuleIDs))
+	for _, id := range e.RuleIDs {
+		ids = append(ids, id.String())
+	}
+	return fmt.Sprintf("notification endpoint %s is used by the notification rules %s", e.EndpointID, strings.Join(ids, ", "))
+}
+
+// NotificationEndpointInUse returns the notification rules blocking the
+// deletion of a notification endpoint if err is a NotificationEndpointInUseError.
+func NotificationEndpointInUse(err error) ([]ID, bool) {
+	for err != nil {
+		switch e := err.(type) {
+		case *NotificationEndpointInUseError:
+			return e.RuleIDs, true
+		case *Error:
+			err = e.Err
+		default:
+			return nil, false
+		}
+	}
+	return nil, false
+}
+
+// NotificationEndpointCascader deletes the notification endpoints along
+// with the notification rules sending to them.
+type NotificationEndpointCascader interface {
+	// DeleteNotificationEndpointCascade removes a notification endpoint by ID and
+	// the notification rules sending to it, and returns the removed notification rules.
+	DeleteNotificationEndpointCascade(ctx context.Context, id ID) ([]ID, error)
+}
+
+// NotificationEndpointReassign moves the notification rules sending to an
+// endpoint to another one, to decommission the endpoint.
+type NotificationEndpointReassign struct {
+	// TargetEndpointID is the endpoint the rules send to instead, it must have
+	// the type and the organization of the reassigned endpoint.
+	TargetEndpointID ID `json:"targetEndpointID"`
+	// Delete deletes the reassigned endpoint, which is deactivated otherwise.
+	Delete bool `json:"delete,omitempty"`
+}
+
+// Valid returns an error if the reassignment has no valid target.
+func (r NotificationEndpointReassign) Valid() error {
+	if !r.TargetEndpointID.Valid() {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "notification endpoint reassignment requires a valid targetEndpointID",
+		}
+	}
+	return nil
+}
+
+// NotificationEndpointReassignResult is the notification rules moved to the
+// target endpoint of a reassignment.
+type NotificationEndpointReassignResult struct {
+	NotificationRuleIDs []ID `json:"notificationRuleIDs"`
+	// Deleted is whether the reassigned endpoint was deleted, or deactivated.
+	Deleted bool `json:"deleted"`
+}
+
+// NotificationEndpointReassigner moves the notification rules of an endpoint
+// to another one.
+type NotificationEndpointReassigner interface {
+	// ReassignNotificationEndpoint repoints every notification rule sending to
+	// the endpoint, by itself or by its routes, to the target endpoint, then
+	// deletes or deactivates the endpoint, all at once.
+	ReassignNotificationEndpoint(ctx context.Context, id ID, r NotificationEndpointReassign) (*NotificationEndpointReassignResult, error)
+}
@@ -0,0 +1,166 @@
+package influxdb
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// consts of the actions of quiet hours.
+const (
+	// QuietHoursDefer delays the notifications to the end of the quiet hours.
+	QuietHoursDefer = "defer"
+	// QuietHoursSuppress drops the notifications during the quiet hours.
+	QuietHoursSuppress = "suppress"
+)
+
+// notificationLevels are the levels of statuses, from the least to the most severe.
+var notificationLevels = []string{"UNKNOWN", "OK", "INFO", "WARN", "CRIT"}
+
+// NotificationPreferences are the personal preferences of a user for the
+// notifications sent to the endpoints addressed to the user, such as an
+// email address or a direct message.
+type NotificationPreferences struct {
+	UserID ID `json:"userID"`
+	// QuietHours is the time of day notifications are deferred or suppressed.
+	QuietHours *QuietHours `json:"quietHours,omitempty"`
+	// PreferredEndpointID is the endpoint notifications are sent to instead
+	// of the endpoint of the rule.
+	PreferredEndpointID *ID `json:"preferredEndpointID,omitempty"`
+	// MinLevel suppresses the notifications of statuses below the level.
+	MinLevel string `json:"minLevel,omitempty"`
+	CRUDLog
+}
+
+// Valid returns error if some configuration is invalid
+func (p NotificationPreferences) Valid() error {
+	if !p.UserID.Valid() {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "Notification Preferences UserID is invalid",
+		}
+	}
+	if p.PreferredEndpointID != nil && !p.PreferredEndpointID.Valid() {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "Notification Preferences PreferredEndpointID is invalid",
+		}
+	}
+	if p.MinLevel != "" && NotificationLevelRank(p.MinLevel) < 0 {
+		return &Error{
+			Code: EInvalid,
+			Msg:  fmt.Sprintf("invalid min level %s, valid levels are %v", p.MinLevel, notificationLevels),
+		}
+	}
+	if p.QuietHours != nil {
+		return p.QuietHours.Valid()
+	}
+	return nil
+}
+
+// NotificationLevelRank returns the severity rank of a status level,
+// -1 if the level is unknown.
+func NotificationLevelRank(level string) int {
+	for i, l := range notificationLevels {
+		if l == level {
+			return i
+		}
+	}
+	return -1
+}
+
+// QuietHours is a daily time window, such as 22:00 to 07:00, in the
+// timezone of a user.
+type QuietHours struct {
+	// Start and End are times of day formatted as 15:04,
+	// the window spans midnight when End is before Start.
+	Start string `json:"start"`
+	End   string `json:"end"`
+	// Timezone is the IANA name of the timezone of Start and End, UTC by default.
+	Timezone string `json:"timezone,omitempty"`
+	// Action is either defer or suppress.
+	Action string `json:"action"`
+}
+
+// Valid returns error if some configuration is invalid
+func (q QuietHours) Valid() error {
+	start, err := time.Parse("15:04", q.Start)
+	if err != nil {
+		return &Error{
+			Code: EInvalid,
+			Msg:  fmt.Sprintf("quiet hours start %q is not a time of day formatted as 15:04", q.Start),
+		}
+	}
+	end, err := time.Parse("15:04", q.End)
+	if err != nil {
+		return &Error{
+			Code: EInvalid,
+			Msg:  fmt.Sprintf("quiet hours end %q is not a time of day formatted as 15:04", q.End),
+		}
+	}
+	if start.Equal(end) {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "quiet hours start and end are the same",
+		}
+	}
+	if _, err := time.LoadLocation(q.Timezone); err != nil {
+		return &Error{
+			Code: EInvalid,
+			Msg:  fmt.Sprintf("quiet hours timezone %s is unknown", q.Timezone),
+		}
+	}
+	if q.Action != QuietHoursDefer && q.Action != QuietHoursSuppress {
+		return &Error{
+			Code: EInvalid,
+			Msg:  fmt.Sprintf("quiet hours action must be %s or %s", QuietHoursDefer, QuietHoursSuppress),
+		}
+	}
+	return nil
+}
+
+// Window returns whether t is within the quiet hours and, if it is,
+// when the quiet hours end.
+func (q QuietHours) Window(t time.Time) (bool, time.Time, error) {
+	if err := q.Valid(); err != nil {
+		return false, time.Time{}, err
+	}
+	loc, _ := time.LoadLocation(q.Timezone)
+	start, _ := time.Parse("15:04", q.Start)
+	end, _ := time.Parse("15:04", q.End)
+
+	t = t.In(loc)
+	at := func(day time.Time, clock time.Time) time.Time {
+		y, m, d := day.Date()
+		return time.Date(y, m, d, clock.Hour(), clock.Minute(), 0, 0, loc)
+	}
+	startToday, endToday := at(t, start), at(t, end)
+
+	if startToday.Before(endToday) {
+		if !t.Before(startToday) && t.Before(endToday) {
+			return true, endToday, nil
+		}
+		return false, time.Time{}, nil
+	}
+
+	// the window spans midnight.
+	if t.Before(endToday) {
+		return true, endToday, nil
+	}
+	if !t.Before(startToday) {
+		return true, at(t.AddDate(0, 0, 1), end), nil
+	}
+	return false, time.Time{}, nil
+}
+
+// NotificationPreferencesService represents a service for managing the notification preferences of users.
+type NotificationPreferencesService interface {
+	// FindNotificationPreferences returns the notification preferences of a user.
+	FindNotificationPreferences(ctx context.Context, userID ID) (*NotificationPreferences, error)
+
+	// PutNotificationPreferences creates or replaces the notification preferences of a user.
+	PutNotificationPreferences(ctx context.Context, p *NotificationPreferences) error
+
+	// DeleteNotificationPreferences removes the notification preferences of a user.
+	DeleteNotificationPreferences(ctx context.Context, userID ID) error
+}
@@ -0,0 +1,115 @@
+package influxdb_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+)
+
+func TestQuietHoursValid(t *testing.T) {
+	tests := []struct {
+		name    string
+		q       influxdb.QuietHours
+		wantErr bool
+	}{
+		{
+			name: "valid quiet hours",
+			q:    influxdb.QuietHours{Start: "22:00", End: "07:00", Timezone: "Europe/Berlin", Action: influxdb.QuietHoursDefer},
+		},
+		{
+			name:    "start is not a time of day",
+			q:       influxdb.QuietHours{Start: "10pm", End: "07:00", Action: influxdb.QuietHoursDefer},
+			wantErr: true,
+		},
+		{
+			name:    "start and end are the same",
+			q:       influxdb.QuietHours{Start: "07:00", End: "07:00", Action: influxdb.QuietHoursDefer},
+			wantErr: true,
+		},
+		{
+			name:    "unknown timezone",
+			q:       influxdb.QuietHours{Start: "22:00", End: "07:00", Timezone: "Mars/Olympus", Action: influxdb.QuietHoursDefer},
+			wantErr: true,
+		},
+		{
+			name:    "unknown action",
+			q:       influxdb.QuietHours{Start: "22:00", End: "07:00", Action: "snooze"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.q.Valid(); (err != nil) != tt.wantErr {
+				t.Errorf("QuietHours.Valid() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestQuietHoursWindow(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("timezone database is not available")
+	}
+	overnight := influxdb.QuietHours{Start: "22:00", End: "07:00", Timezone: "Europe/Berlin", Action: influxdb.QuietHoursDefer}
+	lunch := influxdb.QuietHours{Start: "12:00", End: "13:30", Action: influxdb.QuietHoursSuppress}
+
+	tests := []struct {
+		name  string
+		q     influxdb.QuietHours
+		t     time.Time
+		quiet bool
+		end   time.Time
+	}{
+		{
+			name:  "before midnight",
+			q:     overnight,
+			t:     time.Date(2019, 8, 1, 23, 0, 0, 0, berlin),
+			quiet: true,
+			end:   time.Date(2019, 8, 2, 7, 0, 0, 0, berlin),
+		},
+		{
+			name:  "after midnight",
+			q:     overnight,
+			t:     time.Date(2019, 8, 2, 3, 0, 0, 0, berlin),
+			quiet: true,
+			end:   time.Date(2019, 8, 2, 7, 0, 0, 0, berlin),
+		},
+		{
+			name:  "in another timezone",
+			q:     overnight,
+			t:     time.Date(2019, 8, 1, 20, 30, 0, 0, time.UTC),
+			quiet: true,
+			end:   time.Date(2019, 8, 2, 7, 0, 0, 0, berlin),
+		},
+		{
+			name: "at the end",
+			q:    overnight,
+			t:    time.Date(2019, 8, 2, 7, 0, 0, 0, berlin),
+		},
+		{
+			name:  "within the same day",
+			q:     lunch,
+			t:     time.Date(2019, 8, 1, 12, 15, 0, 0, time.UTC),
+			quiet: true,
+			end:   time.Date(2019, 8, 1, 13, 30, 0, 0, time.UTC),
+		},
+		{
+			name: "outside the same day",
+			q:    lunch,
+			t:    time.Date(2019, 8, 1, 14, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quiet, end, err := tt.q.Window(tt.t)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if quiet != tt.quiet || !end.Equal(tt.end) {
+				t.Errorf("QuietHours.Window() = %v, %v, want %v, %v", quiet, end, tt.quiet, tt.end)
+			}
+		})
+	}
+}
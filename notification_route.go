@@ -0,0 +1,126 @@
+package influxdb
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// notificationRouteDays are the days of the week of notification routes,
+// indexed by time.Weekday.
+var notificationRouteDays = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+// NotificationRoute sends the notifications of a rule to another endpoint
+// during a weekly schedule, such as the business hours, in a timezone.
+type NotificationRoute struct {
+	EndpointID ID `json:"endpointID"`
+	// Days are the days of the week of the schedule, sun, mon, tue, wed,
+	// thu, fri or sat, every day if empty.
+	Days []string `json:"days,omitempty"`
+	// Start and End are times of day formatted as 15:04, the whole days if
+	// both are empty. The schedule spans midnight when End is before Start,
+	// its nights belong to the day they start.
+	Start string `json:"start,omitempty"`
+	End   string `json:"end,omitempty"`
+	// Timezone is the IANA name of the timezone of the schedule, UTC by default.
+	Timezone string `json:"timezone,omitempty"`
+}
+
+// Valid returns error if some configuration is invalid
+func (r NotificationRoute) Valid() error {
+	if !r.EndpointID.Valid() {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "Notification Route EndpointID is invalid",
+		}
+	}
+	for _, d := range r.Days {
+		if notificationRouteDay(d) < 0 {
+			return &Error{
+				Code: EInvalid,
+				Msg:  fmt.Sprintf("invalid notification route day %s, valid days are %v", d, notificationRouteDays),
+			}
+		}
+	}
+	if r.Start != "" || r.End != "" {
+		start, err := time.Parse("15:04", r.Start)
+		if err != nil {
+			return &Error{
+				Code: EInvalid,
+				Msg:  fmt.Sprintf("notification route start %q is not a time of day formatted as 15:04", r.Start),
+			}
+		}
+		end, err := time.Parse("15:04", r.End)
+		if err != nil {
+			return &Error{
+				Code: EInvalid,
+				Msg:  fmt.Sprintf("notification route end %q is not a time of day formatted as 15:04", r.End),
+			}
+		}
+		if start.Equal(end) {
+			return &Error{
+				Code: EInvalid,
+				Msg:  "notification route start and end are the same",
+			}
+		}
+	}
+	if _, err := time.LoadLocation(r.Timezone); err != nil {
+		return &Error{
+			Code: EInvalid,
+			Msg:  fmt.Sprintf("notification route timezone %s is unknown", r.Timezone),
+		}
+	}
+	return nil
+}
+
+// Match returns whether t is within the schedule of the route.
+func (r NotificationRoute) Match(t time.Time) (bool, error) {
+	if err := r.Valid(); err != nil {
+		return false, err
+	}
+	loc, _ := time.LoadLocation(r.Timezone)
+	t = t.In(loc)
+	if r.Start == "" {
+		return r.onDay(t.Weekday()), nil
+	}
+	start, _ := time.Parse("15:04", r.Start)
+	end, _ := time.Parse("15:04", r.End)
+	clock := t.Hour()*60 + t.Minute()
+	from, to := start.Hour()*60+start.Minute(), end.Hour()*60+end.Minute()
+
+	if from < to {
+		return clock >= from && clock < to && r.onDay(t.Weekday()), nil
+	}
+	// the schedule spans midnight, the early hours belong to the day before.
+	if clock >= from {
+		return r.onDay(t.Weekday()), nil
+	}
+	if clock < to {
+		return r.onDay((t.Weekday() + 6) % 7), nil
+	}
+	return false, nil
+}
+
+// onDay returns whether the schedule of the route starts on a day of the week.
+func (r NotificationRoute) onDay(d time.Weekday) bool {
+	if len(r.Days) == 0 {
+		return true
+	}
+	for _, day := range r.Days {
+		if notificationRouteDay(day) == int(d) {
+			return true
+		}
+	}
+	return false
+}
+
+// notificationRouteDay returns the time.Weekday of a day of a route, -1 if
+// the day is unknown.
+func notificationRouteDay(day string) int {
+	for i, d := range notificationRouteDays {
+		if d == strings.ToLower(day) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,137 @@
+package influxdb_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+)
+
+func TestNotificationRouteValid(t *testing.T) {
+	tests := []struct {
+		name    string
+		r       influxdb.NotificationRoute
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			r:    influxdb.NotificationRoute{EndpointID: 1, Days: []string{"mon", "fri"}, Start: "09:00", End: "17:00", Timezone: "Europe/Berlin"},
+		},
+		{
+			name: "whole days",
+			r:    influxdb.NotificationRoute{EndpointID: 1, Days: []string{"sat", "sun"}},
+		},
+		{
+			name:    "invalid endpoint",
+			r:       influxdb.NotificationRoute{Start: "09:00", End: "17:00"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid day",
+			r:       influxdb.NotificationRoute{EndpointID: 1, Days: []string{"monday"}},
+			wantErr: true,
+		},
+		{
+			name:    "start without end",
+			r:       influxdb.NotificationRoute{EndpointID: 1, Start: "09:00"},
+			wantErr: true,
+		},
+		{
+			name:    "same start and end",
+			r:       influxdb.NotificationRoute{EndpointID: 1, Start: "09:00", End: "09:00"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown timezone",
+			r:       influxdb.NotificationRoute{EndpointID: 1, Timezone: "Mars/Olympus"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.r.Valid(); (err != nil) != tt.wantErr {
+				t.Errorf("NotificationRoute.Valid() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNotificationRouteMatch(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("timezone database is not available")
+	}
+	business := influxdb.NotificationRoute{EndpointID: 1, Days: []string{"mon", "tue", "wed", "thu", "fri"}, Start: "09:00", End: "17:00", Timezone: "America/New_York"}
+	nights := influxdb.NotificationRoute{EndpointID: 2, Days: []string{"fri"}, Start: "18:00", End: "08:00"}
+	weekend := influxdb.NotificationRoute{EndpointID: 3, Days: []string{"sat", "sun"}}
+
+	// 2019-08-02 is a friday.
+	tests := []struct {
+		name  string
+		r     influxdb.NotificationRoute
+		t     time.Time
+		match bool
+	}{
+		{
+			name:  "business hours",
+			r:     business,
+			t:     time.Date(2019, 8, 2, 10, 0, 0, 0, newYork),
+			match: true,
+		},
+		{
+			name:  "business hours in another timezone",
+			r:     business,
+			t:     time.Date(2019, 8, 2, 20, 30, 0, 0, time.UTC),
+			match: true,
+		},
+		{
+			name: "at the end of business hours",
+			r:    business,
+			t:    time.Date(2019, 8, 2, 17, 0, 0, 0, newYork),
+		},
+		{
+			name: "business hours on the weekend",
+			r:    business,
+			t:    time.Date(2019, 8, 3, 10, 0, 0, 0, newYork),
+		},
+		{
+			name:  "night starting on its day",
+			r:     nights,
+			t:     time.Date(2019, 8, 2, 23, 0, 0, 0, time.UTC),
+			match: true,
+		},
+		{
+			name:  "night after midnight",
+			r:     nights,
+			t:     time.Date(2019, 8, 3, 7, 0, 0, 0, time.UTC),
+			match: true,
+		},
+		{
+			name: "night starting the day before",
+			r:    nights,
+			t:    time.Date(2019, 8, 2, 7, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "whole day",
+			r:     weekend,
+			t:     time.Date(2019, 8, 4, 12, 0, 0, 0, time.UTC),
+			match: true,
+		},
+		{
+			name: "another day",
+			r:    weekend,
+			t:    time.Date(2019, 8, 2, 12, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match, err := tt.r.Match(tt.t)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if match != tt.match {
+				t.Errorf("NotificationRoute.Match() = %v, want %v", match, tt.match)
+			}
+		})
+	}
+}
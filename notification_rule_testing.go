@@ -0,0 +1,66 @@
+package influxdb
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// MaxNotificationRuleTestTagSets is the maximum number of tag sets of a
+// notification rule test.
+const MaxNotificationRuleTestTagSets = 20
+
+// NotificationRuleTest runs a matrix of synthetic statuses, at every level
+// and after every previous level of their series, through the matchers of a
+// notification rule. Nothing is sent.
+type NotificationRuleTest struct {
+	// TagSets are the tags of the synthetic statuses, sample tag sets are
+	// derived from the tag rules of the rule when empty.
+	TagSets []map[string]string `json:"tagSets,omitempty"`
+}
+
+// Valid returns error if some configuration is invalid
+func (t NotificationRuleTest) Valid() error {
+	if len(t.TagSets) > MaxNotificationRuleTestTagSets {
+		return &Error{
+			Code: EInvalid,
+			Msg:  fmt.Sprintf("a notification rule test has at most %d tag sets", MaxNotificationRuleTestTagSets),
+		}
+	}
+	return nil
+}
+
+// NotificationRuleTestCase is a synthetic status of a notification rule test,
+// and what the rule would do with it.
+type NotificationRuleTestCase struct {
+	Level string `json:"level"`
+	// PreviousLevel is the level of the series before the status, empty for
+	// the first status of a series.
+	PreviousLevel string            `json:"previousLevel,omitempty"`
+	Tags          map[string]string `json:"tags"`
+	// Notify is whether the rule would notify the status.
+	Notify bool `json:"notify"`
+	// Decision is the decision the rule would take, notified for the
+	// statuses it would notify.
+	Decision RuleDecision `json:"decision"`
+	Reason   string       `json:"reason,omitempty"`
+	// EndpointID and Message are the endpoint the notification would be sent
+	// to, and its message as the endpoint would receive it.
+	EndpointID *ID    `json:"endpointID,omitempty"`
+	Message    string `json:"message,omitempty"`
+}
+
+// NotificationRuleTestResult is what a notification rule would do with each
+// synthetic status of a test, at Time.
+type NotificationRuleTestResult struct {
+	RuleID ID                         `json:"ruleID"`
+	Time   time.Time                  `json:"time"`
+	Cases  []NotificationRuleTestCase `json:"cases"`
+}
+
+// NotificationRuleTestService tests the notification rules with synthetic statuses.
+type NotificationRuleTestService interface {
+	// TestNotificationRule runs the synthetic statuses of a test through the
+	// matchers of a notification rule, without sending anything.
+	TestNotificationRule(ctx context.Context, ruleID ID, t NotificationRuleTest) (*NotificationRuleTestResult, error)
+}
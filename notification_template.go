@@ -0,0 +1,121 @@
+package influxdb
+
+import (
+	"context"
+	"regexp"
+)
+
+// NotificationTemplate is a reusable snippet of notification messages,
+// such as a footer or a block of runbook links. The message templates of
+// rules and checks, as well as other notification templates, include it
+// with ${partial.<name>}.
+type NotificationTemplate struct {
+	ID          ID     `json:"id,omitempty"`
+	OrgID       ID     `json:"orgID"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Template    string `json:"template"`
+	CRUDLog
+}
+
+var notificationTemplateNameRegexp = regexp.MustCompile(`^[A-Za-z0-9_\-]+$`)
+
+// Valid returns error if some configuration is invalid
+func (t NotificationTemplate) Valid() error {
+	if !t.OrgID.Valid() {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "Notification Template OrgID is invalid",
+		}
+	}
+	if t.Name == "" {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "Notification Template Name can't be empty",
+		}
+	}
+	if !notificationTemplateNameRegexp.MatchString(t.Name) {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "Notification Template Name can only contain letters, digits, _ and -",
+		}
+	}
+	return nil
+}
+
+// NotificationTemplateFilter represents a set of filter that restrict the returned notification templates.
+type NotificationTemplateFilter struct {
+	OrgID        *ID
+	Organization *string
+	Name         *string
+}
+
+// QueryParams Converts NotificationTemplateFilter fields to url query params.
+func (f NotificationTemplateFilter) QueryParams() map[string][]string {
+	qp := map[string][]string{}
+
+	if f.OrgID != nil {
+		qp["orgID"] = []string{f.OrgID.String()}
+	}
+
+	if f.Organization != nil {
+		qp["org"] = []string{*f.Organization}
+	}
+
+	if f.Name != nil {
+		qp["name"] = []string{*f.Name}
+	}
+
+	return qp
+}
+
+// NotificationTemplateUpdate is the set changeset of a notification template.
+type NotificationTemplateUpdate struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+	Template    *string `json:"template,omitempty"`
+}
+
+// Valid returns error if some configuration is invalid
+func (u *NotificationTemplateUpdate) Valid() error {
+	if u.Name == nil && u.Description == nil && u.Template == nil {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "No key was provided for notification template update",
+		}
+	}
+	return nil
+}
+
+// Apply applies the changeset to the notification template.
+func (u *NotificationTemplateUpdate) Apply(t *NotificationTemplate) {
+	if u.Name != nil {
+		t.Name = *u.Name
+	}
+	if u.Description != nil {
+		t.Description = *u.Description
+	}
+	if u.Template != nil {
+		t.Template = *u.Template
+	}
+}
+
+// NotificationTemplateService represents a service for managing notification templates.
+type NotificationTemplateService interface {
+	// FindNotificationTemplateByID returns a single notification template by ID.
+	FindNotificationTemplateByID(ctx context.Context, id ID) (*NotificationTemplate, error)
+
+	// FindNotificationTemplates returns a list of notification templates that match filter and the total count of matching notification templates.
+	// Additional options provide pagination & sorting.
+	FindNotificationTemplates(ctx context.Context, filter NotificationTemplateFilter, opt ...FindOptions) ([]*NotificationTemplate, int, error)
+
+	// CreateNotificationTemplate creates a new notification template and sets t.ID with the new identifier.
+	CreateNotificationTemplate(ctx context.Context, t *NotificationTemplate) error
+
+	// UpdateNotificationTemplate updates a single notification template with changeset.
+	// Returns the new notification template state after update.
+	UpdateNotificationTemplate(ctx context.Context, id ID, upd NotificationTemplateUpdate) (*NotificationTemplate, error)
+
+	// DeleteNotificationTemplate removes a notification template by ID.
+	DeleteNotificationTemplate(ctx context.Context, id ID) error
+}
@@ -1,6 +1,12 @@
 package influxdb
 
-import "context"
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
 
 // ErrSecretNotFound is the error msg for a missing secret.
 const ErrSecretNotFound = "secret not found"
@@ -25,3 +31,151 @@ type SecretService interface {
 	// DeleteSecret removes a single secret from the secret store.
 	DeleteSecret(ctx context.Context, orgID ID, ks ...string) error
 }
+
+// SecretField contains a key string, and value pointer.
+type SecretField struct {
+	Key   string  `json:"key"`
+	Value *string `json:"value,omitempty"`
+}
+
+// String returns the key of the secret.
+func (s SecretField) String() string {
+	if s.Key == "" {
+		return ""
+	}
+	return "secret: " + s.Key
+}
+
+// MarshalJSON implement the json marshaler interface.
+func (s SecretField) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// UnmarshalJSON implement the json unmarshaler interface.
+func (s *SecretField) UnmarshalJSON(b []byte) error {
+	var ss string
+	if err := json.Unmarshal(b, &ss); err != nil {
+		return err
+	}
+	if ss == "" {
+		s.Key = ""
+		return nil
+	}
+	if strings.HasPrefix(ss, "secret: ") {
+		s.Key = ss[len("secret: "):]
+	} else if ref, ok := ParseSecretReference(ss); ok && knownSecretProviders[ref.Provider] {
+		// a reference is never a value, it would be stored otherwise.
+		s.Key = ss
+	} else {
+		s.Value = strPtr(ss)
+	}
+	return nil
+}
+
+// Reference returns the reference of the secret field to a secret of an
+// external store, if its key is one.
+func (s SecretField) Reference() (SecretReference, bool) {
+	return ParseSecretReference(s.Key)
+}
+
+// Secret providers resolving the secret references.
+const (
+	// SecretProviderVault resolves the references to the secrets of a
+	// HashiCorp Vault, vault://secret/data/slack#token.
+	SecretProviderVault = "vault"
+	// SecretProviderAWSSecretsManager resolves the references to the
+	// secrets of AWS Secrets Manager by name or arn, awssm://slack#token.
+	SecretProviderAWSSecretsManager = "awssm"
+)
+
+var knownSecretProviders = map[string]bool{
+	SecretProviderVault:             true,
+	SecretProviderAWSSecretsManager: true,
+}
+
+var secretReferencePattern = regexp.MustCompile(`^([a-z][a-z0-9+.-]*)://(.*)$`)
+
+// SecretReference is a reference to a secret of an external store, the key
+// of a secret field of the form provider://path#field, such as
+// vault://secret/data/slack#token. The secret is resolved by its provider
+// each time it is used, its value is never stored.
+type SecretReference struct {
+	Provider string
+	// Path is the path of the secret in the store.
+	Path string
+	// Field selects a field of a secret holding several values.
+	Field string
+}
+
+// ParseSecretReference parses the key of a secret field, it returns false
+// if the key isn't a reference to a secret of an external store.
+func ParseSecretReference(key string) (SecretReference, bool) {
+	m := secretReferencePattern.FindStringSubmatch(key)
+	if m == nil {
+		return SecretReference{}, false
+	}
+	ref := SecretReference{Provider: m[1], Path: m[2]}
+	if i := strings.LastIndex(ref.Path, "#"); i >= 0 {
+		ref.Path, ref.Field = ref.Path[:i], ref.Path[i+1:]
+	}
+	return ref, true
+}
+
+// Valid returns an error if the reference has no path, or its path has a
+// parent segment.
+func (r SecretReference) Valid() error {
+	if r.Path == "" {
+		return &Error{
+			Code: EInvalid,
+			Msg:  fmt.Sprintf("secret reference %s has no path", r),
+		}
+	}
+	for _, seg := range strings.Split(r.Path, "/") {
+		if seg == ".." {
+			return &Error{
+				Code: EInvalid,
+				Msg:  fmt.Sprintf("secret reference %s has a parent segment", r),
+			}
+		}
+	}
+	return nil
+}
+
+// Under returns an error if the path of the reference of the organization
+// orgID isn't under prefix, whose {orgID} is replaced by orgID. The
+// providers restrict the references of each organization to its own
+// secrets with it, any path is allowed under an empty prefix.
+func (r SecretReference) Under(prefix string, orgID ID) error {
+	if err := r.Valid(); err != nil {
+		return err
+	}
+	prefix = strings.Replace(prefix, "{orgID}", orgID.String(), -1)
+	if !strings.HasPrefix(r.Path, prefix) {
+		return &Error{
+			Code: EForbidden,
+			Msg:  fmt.Sprintf("secret reference %s isn't under %s", r, prefix),
+		}
+	}
+	return nil
+}
+
+// String returns the reference as the key of a secret field.
+func (r SecretReference) String() string {
+	if r.Field == "" {
+		return r.Provider + "://" + r.Path
+	}
+	return r.Provider + "://" + r.Path + "#" + r.Field
+}
+
+// SecretProvider resolves the references to the secrets of an external store.
+type SecretProvider interface {
+	// ResolveSecret returns the value of the secret a reference of the
+	// organization orgID refers to.
+	ResolveSecret(ctx context.Context, orgID ID, ref SecretReference) (string, error)
+}
+
+func strPtr(s string) *string {
+	ss := new(string)
+	*ss = s
+	return ss
+}
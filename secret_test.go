@@ -0,0 +1,78 @@
+package influxdb_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+)
+
+func TestParseSecretReference(t *testing.T) {
+	cases := []struct {
+		key  string
+		want influxdb.SecretReference
+		ok   bool
+	}{
+		{
+			key:  "vault://secret/data/slack#token",
+			want: influxdb.SecretReference{Provider: "vault", Path: "secret/data/slack", Field: "token"},
+			ok:   true,
+		},
+		{
+			key:  "awssm://arn:aws:secretsmanager:us-west-2:123456789012:secret:slack",
+			want: influxdb.SecretReference{Provider: "awssm", Path: "arn:aws:secretsmanager:us-west-2:123456789012:secret:slack"},
+			ok:   true,
+		},
+		{
+			key: "020f755c3c082000-token",
+		},
+	}
+	for _, c := range cases {
+		got, ok := influxdb.ParseSecretReference(c.key)
+		if ok != c.ok || got != c.want {
+			t.Errorf("%s: got %+v, %t, want %+v, %t", c.key, got, ok, c.want, c.ok)
+		}
+		if ok && got.String() != c.key {
+			t.Errorf("%s: unexpected string %s", c.key, got)
+		}
+	}
+}
+
+func TestSecretReference_Under(t *testing.T) {
+	ref, _ := influxdb.ParseSecretReference("vault://secret/data/influxdb/0000000000000003/../0000000000000004/slack#token")
+	if err := ref.Under("secret/data/influxdb/{orgID}/", influxdb.ID(3)); influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Errorf("expected a parent segment to be rejected, got %v", err)
+	}
+	ref, _ = influxdb.ParseSecretReference("vault://secret/data/influxdb/0000000000000003/slack#token")
+	if err := ref.Under("secret/data/influxdb/{orgID}/", influxdb.ID(3)); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	if err := ref.Under("secret/data/influxdb/{orgID}/", influxdb.ID(4)); influxdb.ErrorCode(err) != influxdb.EForbidden {
+		t.Errorf("expected the secret of another organization to be forbidden, got %v", err)
+	}
+}
+
+func TestSecretField_UnmarshalJSONReference(t *testing.T) {
+	cases := []struct {
+		json  string
+		key   string
+		value string
+	}{
+		{json: `"secret: vault://secret/data/slack#token"`, key: "vault://secret/data/slack#token"},
+		{json: `"awssm://slack#token"`, key: "awssm://slack#token"},
+		{json: `"https://hooks.example.com/1"`, value: "https://hooks.example.com/1"},
+	}
+	for _, c := range cases {
+		var f influxdb.SecretField
+		if err := json.Unmarshal([]byte(c.json), &f); err != nil {
+			t.Fatal(err)
+		}
+		var value string
+		if f.Value != nil {
+			value = *f.Value
+		}
+		if f.Key != c.key || value != c.value {
+			t.Errorf("%s: unexpected field %q, %q", c.json, f.Key, value)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package influxdb
+
+import (
+	"context"
+	"time"
+)
+
+// Silence mutes the notifications of every check carrying a label, such as
+// team:payments, during a time window. The checks are matched by their
+// labels when their statuses are dispatched, so the checks labeled after
+// the silence is created are silenced too.
+type Silence struct {
+	ID      ID     `json:"id,omitempty"`
+	OrgID   ID     `json:"orgID"`
+	LabelID ID     `json:"labelID"`
+	Comment string `json:"comment,omitempty"`
+	// StartsAt defaults to the creation of the silence.
+	StartsAt time.Time `json:"startsAt"`
+	EndsAt   time.Time `json:"endsAt"`
+	CRUDLog
+}
+
+// Valid returns error if some configuration is invalid
+func (s Silence) Valid() error {
+	if !s.OrgID.Valid() {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "Silence OrgID is invalid",
+		}
+	}
+	if !s.LabelID.Valid() {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "Silence LabelID is invalid",
+		}
+	}
+	if s.EndsAt.IsZero() {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "Silence endsAt is required",
+		}
+	}
+	if !s.StartsAt.IsZero() && !s.EndsAt.After(s.StartsAt) {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "Silence endsAt must be after startsAt",
+		}
+	}
+	return nil
+}
+
+// Active returns whether the silence mutes the notifications sent at t.
+func (s Silence) Active(t time.Time) bool {
+	return !t.Before(s.StartsAt) && t.Before(s.EndsAt)
+}
+
+// SilenceFilter represents a set of filter that restrict the returned silences.
+type SilenceFilter struct {
+	OrgID   *ID
+	LabelID *ID
+}
+
+// QueryParams Converts SilenceFilter fields to url query params.
+func (f SilenceFilter) QueryParams() map[string][]string {
+	qp := map[string][]string{}
+
+	if f.OrgID != nil {
+		qp["orgID"] = []string{f.OrgID.String()}
+	}
+
+	if f.LabelID != nil {
+		qp["labelID"] = []string{f.LabelID.String()}
+	}
+
+	return qp
+}
+
+// SilenceService represents a service for managing the silences of labels.
+type SilenceService interface {
+	// FindSilenceByID returns a single silence by ID.
+	FindSilenceByID(ctx context.Context, id ID) (*Silence, error)
+
+	// FindSilences returns a list of silences that match filter and the total count of matching silences.
+	// Additional options provide pagination & sorting.
+	FindSilences(ctx context.Context, filter SilenceFilter, opt ...FindOptions) ([]*Silence, int, error)
+
+	// CreateSilence creates a new silence and sets s.ID with the new identifier.
+	CreateSilence(ctx context.Context, s *Silence) error
+
+	// DeleteSilence removes a silence by ID, which stops muting its checks.
+	DeleteSilence(ctx context.Context, id ID) error
+}
@@ -0,0 +1,43 @@
+package influxdb
+
+import (
+	"context"
+	"fmt"
+)
+
+// StatusInjection is a synthetic status of a check, dispatched to the
+// notification rules of its organization to test their routing.
+type StatusInjection struct {
+	CheckID ID `json:"checkID"`
+	// Level is the level of the status, such as CRIT.
+	Level string `json:"level"`
+	// Message replaces the message rendered from the template of the check.
+	Message string   `json:"message,omitempty"`
+	Value   *float64 `json:"value,omitempty"`
+	// Tags are added to the tags of the check.
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// Valid returns error if some configuration is invalid
+func (i StatusInjection) Valid() error {
+	if !i.CheckID.Valid() {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "status injection checkID is invalid",
+		}
+	}
+	if NotificationLevelRank(i.Level) < 0 {
+		return &Error{
+			Code: EInvalid,
+			Msg:  fmt.Sprintf("invalid level %s, valid levels are %v", i.Level, notificationLevels),
+		}
+	}
+	return nil
+}
+
+// StatusInjectionService injects synthetic statuses into the alerting pipeline.
+type StatusInjectionService interface {
+	// InjectStatus writes a synthetic status of a check, dispatches it to the
+	// notification rules of its organization and returns its decision trace.
+	InjectStatus(ctx context.Context, i *StatusInjection) (*StatusTrace, error)
+}
@@ -0,0 +1,83 @@
+package influxdb
+
+import (
+	"context"
+	"time"
+)
+
+// RuleDecision is what a notification rule did with a status.
+type RuleDecision string
+
+// consts of RuleDecision
+const (
+	// RuleNotified is the decision of a rule which sent the notification of the status.
+	RuleNotified RuleDecision = "notified"
+	// RuleDeferred is the decision of a rule whose notification waits for the quiet hours of a user to end.
+	RuleDeferred RuleDecision = "deferred"
+	// RuleUnmatched is the decision of a rule whose tag or status rules don't match the status.
+	RuleUnmatched RuleDecision = "unmatched"
+	// RuleMuted is the decision of a rule which is inactive, has no active endpoint,
+	// or whose notification is suppressed by the preferences of a user or dropped
+	// over the budget of its endpoint.
+	RuleMuted RuleDecision = "muted"
+	// RuleDeduplicated is the decision of a rule which already notified the level
+	// of the series of the status, or reached its limit.
+	RuleDeduplicated RuleDecision = "deduplicated"
+	// RuleFailed is the decision of a rule whose notification failed to be sent.
+	RuleFailed RuleDecision = "failed"
+)
+
+// StatusTrace records why each notification rule of the organization of a
+// status did or didn't notify it.
+type StatusTrace struct {
+	StatusID ID          `json:"statusID"`
+	CheckID  ID          `json:"checkID"`
+	OrgID    ID          `json:"orgID"`
+	Level    string      `json:"level"`
+	Message  string      `json:"message,omitempty"`
+	Time     time.Time   `json:"time"`
+	Rules    []RuleTrace `json:"rules"`
+}
+
+// RuleTrace is the decision of a notification rule for a status.
+type RuleTrace struct {
+	RuleID   ID           `json:"ruleID"`
+	RuleName string       `json:"ruleName"`
+	Decision RuleDecision `json:"decision"`
+	Reason   string       `json:"reason,omitempty"`
+	// EndpointID is the endpoint the notification was sent to, the preferred
+	// endpoint of a user may replace the endpoint of the rule.
+	EndpointID *ID `json:"endpointID,omitempty"`
+}
+
+// Valid returns error if some configuration is invalid
+func (t StatusTrace) Valid() error {
+	if !t.StatusID.Valid() {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "status trace statusID is invalid",
+		}
+	}
+	if !t.CheckID.Valid() {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "status trace checkID is invalid",
+		}
+	}
+	if !t.OrgID.Valid() {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "status trace orgID is invalid",
+		}
+	}
+	return nil
+}
+
+// StatusTraceService stores the decision traces of the statuses processed by the alerting engine.
+type StatusTraceService interface {
+	// FindStatusTrace returns the decision trace of a status.
+	FindStatusTrace(ctx context.Context, statusID ID) (*StatusTrace, error)
+
+	// CreateStatusTrace records the decision trace of a status.
+	CreateStatusTrace(ctx context.Context, t *StatusTrace) error
+}
@@ -609,6 +609,38 @@ func (e *Engine) ApplyFnToSeriesIDSet(fn func(*tsdb.SeriesIDSet)) {
 	fn(e.index.SeriesIDSet())
 }
 
+// FindMeasurements returns the names of the measurements of a bucket in order.
+func (e *Engine) FindMeasurements(ctx context.Context, orgID, bucketID platform.ID) ([]string, error) {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.closing == nil {
+		return nil, ErrEngineClosed
+	}
+
+	name := tsdb.EncodeName(orgID, bucketID)
+	itr, err := e.index.TagValueIterator(name[:], models.MeasurementTagKeyBytes)
+	if err != nil {
+		return nil, err
+	} else if itr == nil {
+		return nil, nil
+	}
+	defer itr.Close()
+
+	var names []string
+	for {
+		v, err := itr.Next()
+		if err != nil {
+			return nil, err
+		} else if v == nil {
+			return names, nil
+		}
+		names = append(names, string(v))
+	}
+}
+
 // MeasurementCardinalityStats returns cardinality stats for all measurements.
 func (e *Engine) MeasurementCardinalityStats() tsi1.MeasurementCardinalityStats {
 	return e.index.MeasurementCardinalityStats()
@@ -6,6 +6,7 @@ import (
 	"io/ioutil"
 	"math"
 	"os"
+	"reflect"
 	"testing"
 	"time"
 
@@ -254,6 +255,51 @@ func TestEngine_DeleteBucket(t *testing.T) {
 	}
 }
 
+func TestEngine_FindMeasurements(t *testing.T) {
+	engine := NewDefaultEngine()
+	defer engine.Close()
+	engine.MustOpen()
+
+	orgID, _ := influxdb.IDFromString("3131313131313131")
+	bucketID, _ := influxdb.IDFromString("8888888888888888")
+
+	point := func(org, bucket influxdb.ID, measurement string) models.Point {
+		return models.MustNewPoint(
+			tsdb.EncodeNameString(org, bucket),
+			models.NewTags(map[string]string{models.FieldKeyTagKey: "value", models.MeasurementTagKey: measurement, "host": "server"}),
+			map[string]interface{}{"value": 1.0},
+			time.Unix(1, 2),
+		)
+	}
+	err := engine.Engine.WritePoints(context.TODO(), []models.Point{
+		point(engine.org, engine.bucket, "mem"),
+		point(engine.org, engine.bucket, "cpu"),
+		point(engine.org, engine.bucket, "cpu"),
+		// Same org, different bucket.
+		point(*orgID, *bucketID, "disk"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := engine.FindMeasurements(context.TODO(), engine.org, engine.bucket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp := []string{"cpu", "mem"}; !reflect.DeepEqual(got, exp) {
+		t.Fatalf("got measurements %v, exp %v", got, exp)
+	}
+
+	// A bucket without data has no measurements.
+	got, err = engine.FindMeasurements(context.TODO(), engine.org, *orgID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 0 {
+		t.Fatalf("got measurements %v, exp none", got)
+	}
+}
+
 func TestEngine_DeleteBucket_Predicate(t *testing.T) {
 	engine := NewDefaultEngine()
 	defer engine.Close()
@@ -18,11 +18,45 @@ import (
 	"go.uber.org/zap"
 )
 
+// ExecutorOption configures an executor.
+type ExecutorOption func(*executorConfig)
+
+// executorConfig is the configuration of an executor.
+type executorConfig struct {
+	summarizer backend.RunSummarizer
+	summarized func(*influxdb.Task) bool
+}
+
+// WithRunSummarizer summarizes the results of the runs of the tasks
+// matching match with s.
+func WithRunSummarizer(s backend.RunSummarizer, match func(*influxdb.Task) bool) ExecutorOption {
+	return func(c *executorConfig) {
+		c.summarizer, c.summarized = s, match
+	}
+}
+
+func newExecutorConfig(opts []ExecutorOption) executorConfig {
+	var c executorConfig
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
+
+// summary returns the summary of the run qr of t, nil if its runs aren't summarized.
+func (c executorConfig) summary(t *influxdb.Task, qr backend.QueuedRun) backend.RunSummary {
+	if c.summarizer == nil || (c.summarized != nil && !c.summarized(t)) {
+		return nil
+	}
+	return c.summarizer.SummarizeRun(t, qr)
+}
+
 // queryServiceExecutor is an implementation of backend.Executor that depends on a QueryService.
 type queryServiceExecutor struct {
 	qs     query.QueryService
 	as     influxdb.AuthorizationService
 	ts     influxdb.TaskService
+	cfg    executorConfig
 	logger *zap.Logger
 	wg     sync.WaitGroup
 }
@@ -32,8 +66,8 @@ var _ backend.Executor = (*queryServiceExecutor)(nil)
 // NewQueryServiceExecutor returns a new executor based on the given QueryService.
 // In general, you should prefer NewAsyncQueryServiceExecutor, as that code is smaller and simpler,
 // because asynchronous queries are more in line with the Executor interface.
-func NewQueryServiceExecutor(logger *zap.Logger, qs query.QueryService, as influxdb.AuthorizationService, ts influxdb.TaskService) *queryServiceExecutor {
-	return &queryServiceExecutor{logger: logger, qs: qs, as: as, ts: ts}
+func NewQueryServiceExecutor(logger *zap.Logger, qs query.QueryService, as influxdb.AuthorizationService, ts influxdb.TaskService, opts ...ExecutorOption) *queryServiceExecutor {
+	return &queryServiceExecutor{logger: logger, qs: qs, as: as, ts: ts, cfg: newExecutorConfig(opts)}
 }
 
 // AddTaskService is a temporary solution to a chicken and egg problem. It takes a executor and sets the task service.
@@ -80,6 +114,9 @@ type syncRunPromise struct {
 	logger *zap.Logger
 	logEnd func() // Called to log the end of the run operation.
 
+	// summary summarizes the results of the run, nil if it isn't summarized.
+	summary backend.RunSummary
+
 	finishOnce sync.Once     // Ensure we set the values only once.
 	ready      chan struct{} // Closed inside finish. Indicates Wait will no longer block.
 	res        *runResult
@@ -102,6 +139,8 @@ func newSyncRunPromise(ctx context.Context, auth *influxdb.Authorization, qr bac
 		ctx:    ctx,
 		cancel: cancel,
 		ready:  make(chan struct{}),
+
+		summary: e.cfg.summary(t, qr),
 	}
 
 	e.wg.Add(2)
@@ -176,11 +215,15 @@ func (p *syncRunPromise) doQuery(wg *sync.WaitGroup) {
 	}
 	defer it.Release()
 
+	read := exhaustResultIterators
+	if p.summary != nil {
+		read = p.summary.Read
+	}
 	// Drain the result iterator.
 	for it.More() {
 		// Consume the full iterator so that we don't leak outstanding iterators.
 		res := it.Next()
-		if err = exhaustResultIterators(res); err != nil {
+		if err = read(res); err != nil {
 			p.logger.Info("Error exhausting result iterator", zap.Error(err), zap.String("name", res.Name()))
 		}
 	}
@@ -193,8 +236,12 @@ func (p *syncRunPromise) doQuery(wg *sync.WaitGroup) {
 		err = it.Err()
 	}
 
+	rr := &runResult{err: err, statistics: it.Statistics()}
+	if err == nil {
+		rr.summary = summarize(p.ctx, p.summary, p.logger)
+	}
 	// Is it okay to assume it.Err will be set if the query context is canceled?
-	p.finish(&runResult{err: err, statistics: it.Statistics()}, nil)
+	p.finish(rr, nil)
 }
 
 func (p *syncRunPromise) cancelOnContextDone(wg *sync.WaitGroup) {
@@ -216,6 +263,7 @@ type asyncQueryServiceExecutor struct {
 	qs     query.AsyncQueryService
 	as     influxdb.AuthorizationService
 	ts     influxdb.TaskService
+	cfg    executorConfig
 	logger *zap.Logger
 	wg     sync.WaitGroup
 }
@@ -223,8 +271,8 @@ type asyncQueryServiceExecutor struct {
 var _ backend.Executor = (*asyncQueryServiceExecutor)(nil)
 
 // NewAsyncQueryServiceExecutor returns a new executor based on the given AsyncQueryService.
-func NewAsyncQueryServiceExecutor(logger *zap.Logger, qs query.AsyncQueryService, as influxdb.AuthorizationService, ts influxdb.TaskService) backend.Executor {
-	return &asyncQueryServiceExecutor{logger: logger, qs: qs, as: as, ts: ts}
+func NewAsyncQueryServiceExecutor(logger *zap.Logger, qs query.AsyncQueryService, as influxdb.AuthorizationService, ts influxdb.TaskService, opts ...ExecutorOption) backend.Executor {
+	return &asyncQueryServiceExecutor{logger: logger, qs: qs, as: as, ts: ts, cfg: newExecutorConfig(opts)}
 }
 
 func (e *asyncQueryServiceExecutor) Execute(ctx context.Context, run backend.QueuedRun) (backend.RunPromise, error) {
@@ -257,7 +305,7 @@ func (e *asyncQueryServiceExecutor) Execute(ctx context.Context, run backend.Que
 		return nil, err
 	}
 
-	return newAsyncRunPromise(ctx, run, q, e), nil
+	return newAsyncRunPromise(ctx, run, q, e, e.cfg.summary(t, run)), nil
 }
 
 func (e *asyncQueryServiceExecutor) Wait() {
@@ -269,6 +317,11 @@ type asyncRunPromise struct {
 	qr backend.QueuedRun
 	q  flux.Query
 
+	// ctx and summary summarize the results of the run, summary is nil if
+	// it isn't summarized.
+	ctx     context.Context
+	summary backend.RunSummary
+
 	logger *zap.Logger
 	logEnd func() // Called to log the end of the run operation.
 
@@ -280,7 +333,7 @@ type asyncRunPromise struct {
 
 var _ backend.RunPromise = (*asyncRunPromise)(nil)
 
-func newAsyncRunPromise(ctx context.Context, qr backend.QueuedRun, q flux.Query, e *asyncQueryServiceExecutor) *asyncRunPromise {
+func newAsyncRunPromise(ctx context.Context, qr backend.QueuedRun, q flux.Query, e *asyncQueryServiceExecutor, summary backend.RunSummary) *asyncRunPromise {
 	span, ctx := tracing.StartSpanFromContext(ctx)
 	defer span.Finish()
 
@@ -292,6 +345,9 @@ func newAsyncRunPromise(ctx context.Context, qr backend.QueuedRun, q flux.Query,
 		q:     q,
 		ready: make(chan struct{}),
 
+		ctx:     ctx,
+		summary: summary,
+
 		logger: log,
 		logEnd: logEnd,
 	}
@@ -327,6 +383,10 @@ func (p *asyncRunPromise) followQuery(wg *sync.WaitGroup) {
 	// Always need to call Done after query is finished.
 	defer p.q.Done()
 
+	read := exhaustResultIterators
+	if p.summary != nil {
+		read = p.summary.Read
+	}
 	var rwg sync.WaitGroup
 SelectLoop:
 	for {
@@ -344,7 +404,7 @@ SelectLoop:
 			rwg.Add(1)
 			go func() {
 				defer rwg.Done()
-				if err := exhaustResultIterators(r); err != nil {
+				if err := read(r); err != nil {
 					p.logger.Info("Error exhausting result iterator", zap.Error(err), zap.String("name", r.Name()))
 				}
 			}()
@@ -363,7 +423,7 @@ SelectLoop:
 	// Otherwise, query was successful.
 	// Must call query.Done before collecting statistics. It's safe to call multiple times.
 	p.q.Done()
-	p.finish(&runResult{statistics: p.q.Statistics()}, nil)
+	p.finish(&runResult{statistics: p.q.Statistics(), summary: summarize(p.ctx, p.summary, p.logger)}, nil)
 }
 
 func (p *asyncRunPromise) finish(res *runResult, err error) {
@@ -387,6 +447,7 @@ type runResult struct {
 	err        error
 	retryable  bool
 	statistics flux.Statistics
+	summary    string
 }
 
 var _ backend.RunResult = (*runResult)(nil)
@@ -394,6 +455,21 @@ var _ backend.RunResult = (*runResult)(nil)
 func (rr *runResult) Err() error                  { return rr.err }
 func (rr *runResult) IsRetryable() bool           { return rr.retryable }
 func (rr *runResult) Statistics() flux.Statistics { return rr.statistics }
+func (rr *runResult) Summary() string             { return rr.summary }
+
+// summarize returns the log message of the summary of a run, empty if the run
+// isn't summarized or its summary fails.
+func summarize(ctx context.Context, summary backend.RunSummary, logger *zap.Logger) string {
+	if summary == nil {
+		return ""
+	}
+	msg, err := summary.Summarize(ctx)
+	if err != nil {
+		logger.Info("Failed to summarize run", zap.Error(err))
+		return ""
+	}
+	return msg
+}
 
 // exhaustResultIterators drains all the iterators from a flux query Result.
 func exhaustResultIterators(res flux.Result) error {
@@ -264,9 +264,9 @@ type system struct {
 	i *kv.Service
 }
 
-type createSysFn func() *system
+type createSysFn func(opts ...ExecutorOption) *system
 
-func createAsyncSystem() *system {
+func createAsyncSystem(opts ...ExecutorOption) *system {
 	svc := newFakeQueryService()
 	i := kv.NewService(inmem.NewKVStore())
 	if err := i.Initialize(context.Background()); err != nil {
@@ -277,12 +277,12 @@ func createAsyncSystem() *system {
 		name: "AsyncExecutor",
 		svc:  svc,
 		ts:   i,
-		ex:   NewAsyncQueryServiceExecutor(zap.NewNop(), svc, i, i),
+		ex:   NewAsyncQueryServiceExecutor(zap.NewNop(), svc, i, i, opts...),
 		i:    i,
 	}
 }
 
-func createSyncSystem() *system {
+func createSyncSystem(opts ...ExecutorOption) *system {
 	svc := newFakeQueryService()
 	i := kv.NewService(inmem.NewKVStore())
 	if err := i.Initialize(context.Background()); err != nil {
@@ -300,6 +300,7 @@ func createSyncSystem() *system {
 			},
 			i,
 			i,
+			opts...,
 		),
 		i: i,
 	}
@@ -312,6 +313,7 @@ func TestExecutor(t *testing.T) {
 		testExecutorPromiseCancel(t, fn)
 		testExecutorServiceError(t, fn)
 		testExecutorWait(t, fn)
+		testExecutorRunSummary(t, fn)
 	}
 }
 
@@ -391,6 +393,70 @@ func testExecutorQuerySuccess(t *testing.T, fn createSysFn) {
 	})
 }
 
+// tableSummarizer summarizes the runs by the number of tables of each result.
+type tableSummarizer struct{}
+
+func (tableSummarizer) SummarizeRun(t *platform.Task, qr backend.QueuedRun) backend.RunSummary {
+	return &tableSummary{tables: make(map[string]int)}
+}
+
+type tableSummary struct {
+	mu     sync.Mutex
+	tables map[string]int
+}
+
+func (s *tableSummary) Read(res flux.Result) error {
+	return res.Tables().Do(func(flux.Table) error {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.tables[res.Name()]++
+		return nil
+	})
+}
+
+func (s *tableSummary) Summarize(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return fmt.Sprintf("%v", s.tables), nil
+}
+
+func testExecutorRunSummary(t *testing.T, fn createSysFn) {
+	for _, summarized := range []bool{true, false} {
+		summarized := summarized
+		sys := fn(WithRunSummarizer(tableSummarizer{}, func(*platform.Task) bool { return summarized }))
+		tc := createCreds(t, sys.i)
+		t.Run(fmt.Sprintf("%s/RunSummary/%t", sys.name, summarized), func(t *testing.T) {
+			t.Parallel()
+
+			script := fmt.Sprintf(fmtTestScript, t.Name())
+			ctx := icontext.SetAuthorizer(context.Background(), tc.Auth)
+			task, err := sys.ts.CreateTask(ctx, platform.TaskCreate{OrganizationID: tc.OrgID, Token: tc.Auth.Token, Flux: script})
+			if err != nil {
+				t.Fatal(err)
+			}
+			qr := backend.QueuedRun{TaskID: task.ID, RunID: platform.ID(1), Now: 123}
+			rp, err := sys.ex.Execute(context.Background(), qr)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			sys.svc.WaitForQueryLive(t, script)
+			sys.svc.SucceedQuery(script)
+			res, err := rp.Wait()
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := ""
+			if summarized {
+				want = "map[res:1]"
+			}
+			if got := res.Summary(); got != want {
+				t.Fatalf("expected summary %q, got %q", want, got)
+			}
+		})
+	}
+}
+
 func testExecutorQueryFailure(t *testing.T, fn createSysFn) {
 	sys := fn()
 	tc := createCreds(t, sys.i)
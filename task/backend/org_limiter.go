@@ -0,0 +1,183 @@
+package backend
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	platform "github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/task/options"
+)
+
+// DefaultPriorityAging is how long a queued run waits before it is promoted
+// to the next priority, unless set with WithPriorityAging.
+const DefaultPriorityAging = time.Minute
+
+// WithOrgConcurrency caps the number of runs of the tasks matched by limited
+// executing at once in each organization, such as the tasks of the checks, so
+// that one organization can't take the whole query capacity of a shared
+// deployment. The runs over the limit are queued until a run of the same
+// organization finishes, and start in the order of the priority option of
+// their task. There is no limit when limit is 0.
+func WithOrgConcurrency(limit int, limited func(*platform.Task) bool) TickSchedulerOption {
+	return func(s *TickScheduler) {
+		if limit <= 0 {
+			s.orgLimiter = nil
+			return
+		}
+		s.orgLimiter = &orgLimiter{
+			limit:   limit,
+			limited: limited,
+			queues:  make(map[platform.ID]*orgQueue),
+			metrics: s.metrics,
+		}
+	}
+}
+
+// WithPriorityAging sets how long a run queued for the concurrency limit of
+// its organization waits before it is promoted to the next priority, so the
+// runs of low priority tasks start eventually however many critical runs
+// are queued. The runs aren't promoted when d is 0.
+func WithPriorityAging(d time.Duration) TickSchedulerOption {
+	return func(s *TickScheduler) {
+		s.priorityAging = d
+	}
+}
+
+// priorityRank returns the order of a task priority option, the critical
+// priority first.
+func priorityRank(priority string) int {
+	switch priority {
+	case options.PriorityCritical:
+		return 0
+	case options.PriorityLow:
+		return 2
+	default:
+		return 1
+	}
+}
+
+// orgLimiter is a semaphore per organization, whose waiting runs are
+// ordered by priority.
+type orgLimiter struct {
+	limit   int
+	limited func(*platform.Task) bool
+	aging   time.Duration
+
+	mu     sync.Mutex
+	queues map[platform.ID]*orgQueue // organization ID -> queue.
+
+	metrics *schedulerMetrics
+}
+
+// orgQueue is the runs of an organization.
+type orgQueue struct {
+	running int
+	waiting []*orgWaiter
+}
+
+// orgWaiter is a queued run.
+type orgWaiter struct {
+	rank     int
+	queuedAt time.Time
+	ready    chan struct{}
+}
+
+// next removes and returns the waiting run to start at now, the one of the
+// best priority once promoted by its wait, the longest waiting first within
+// a priority. It returns nil when no run is waiting.
+func (q *orgQueue) next(now time.Time, aging time.Duration) *orgWaiter {
+	best, bestRank := -1, 0
+	for i, w := range q.waiting {
+		rank := w.rank
+		if aging > 0 {
+			rank -= int(now.Sub(w.queuedAt) / aging)
+		}
+		if best < 0 || rank < bestRank || rank == bestRank && w.queuedAt.Before(q.waiting[best].queuedAt) {
+			best, bestRank = i, rank
+		}
+	}
+	if best < 0 {
+		return nil
+	}
+	w := q.waiting[best]
+	q.waiting = append(q.waiting[:best], q.waiting[best+1:]...)
+	return w
+}
+
+// remove removes a waiting run, and returns whether it was waiting.
+func (q *orgQueue) remove(w *orgWaiter) bool {
+	for i := range q.waiting {
+		if q.waiting[i] == w {
+			q.waiting = append(q.waiting[:i], q.waiting[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// acquire waits for a free slot of the organization of task for a run of
+// rank. The slot is freed by calling release. The error of the first of ctx
+// and runnerCtx done is returned when one is done before a slot is free.
+func (l *orgLimiter) acquire(ctx, runnerCtx context.Context, task *platform.Task, rank int) (release func(), err error) {
+	if l == nil || (l.limited != nil && !l.limited(task)) {
+		return func() {}, nil
+	}
+
+	orgID := task.OrganizationID
+	org := orgID.String()
+	release = func() { l.release(orgID) }
+
+	l.mu.Lock()
+	q, ok := l.queues[orgID]
+	if !ok {
+		q = &orgQueue{}
+		l.queues[orgID] = q
+	}
+	if q.running < l.limit && len(q.waiting) == 0 {
+		q.running++
+		l.mu.Unlock()
+		l.metrics.StartOrgRun(org, 0)
+		return release, nil
+	}
+	w := &orgWaiter{rank: rank, queuedAt: time.Now(), ready: make(chan struct{})}
+	q.waiting = append(q.waiting, w)
+	l.mu.Unlock()
+
+	l.metrics.QueueOrgRun(org)
+	defer l.metrics.DequeueOrgRun(org)
+	select {
+	case <-w.ready:
+		l.metrics.StartOrgRun(org, time.Since(w.queuedAt))
+		return release, nil
+	case <-ctx.Done():
+		err = ctx.Err()
+	case <-runnerCtx.Done():
+		err = runnerCtx.Err()
+	}
+
+	l.mu.Lock()
+	waiting := q.remove(w)
+	l.mu.Unlock()
+	if !waiting {
+		// the slot was handed to the run as it was canceled.
+		l.release(orgID)
+	}
+	return nil, err
+}
+
+// release hands the slot of a finished run to the next waiting run of its
+// organization, or frees it.
+func (l *orgLimiter) release(orgID platform.ID) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	q := l.queues[orgID]
+	if w := q.next(time.Now(), l.aging); w != nil {
+		close(w.ready)
+		return
+	}
+	q.running--
+	if q.running == 0 {
+		delete(l.queues, orgID)
+	}
+}
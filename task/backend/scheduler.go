@@ -87,6 +87,28 @@ type RunResult interface {
 
 	// TODO(mr): add more detail here like number of points written, execution time, etc.
 	Statistics() flux.Statistics
+
+	// Summary returns the log message of the summary of the results of the
+	// run, empty if the run isn't summarized.
+	Summary() string
+}
+
+// RunSummarizer summarizes the results of the runs of tasks, the summary of
+// a successful run is added to its log.
+type RunSummarizer interface {
+	// SummarizeRun returns the summary of the run qr of t, which is starting.
+	SummarizeRun(t *platform.Task, qr QueuedRun) RunSummary
+}
+
+// RunSummary reads the results of a run and summarizes them.
+type RunSummary interface {
+	// Read reads a result of the run, consuming its tables. The results of
+	// a run may be read concurrently.
+	Read(res flux.Result) error
+
+	// Summarize returns the log message of the summary of the run, once
+	// every result of the run was read.
+	Summarize(ctx context.Context) (string, error)
 }
 
 // Scheduler accepts tasks and handles their scheduling.
@@ -166,11 +188,15 @@ func NewScheduler(taskControlService TaskControlService, executor Executor, now
 		logger:             zap.NewNop(),
 		wg:                 &sync.WaitGroup{},
 		metrics:            newSchedulerMetrics(),
+		priorityAging:      DefaultPriorityAging,
 	}
 
 	for _, opt := range opts {
 		opt(o)
 	}
+	if o.orgLimiter != nil {
+		o.orgLimiter.aging = o.priorityAging
+	}
 
 	return o
 }
@@ -184,6 +210,10 @@ type TickScheduler struct {
 
 	metrics *schedulerMetrics
 
+	// orgLimiter caps the concurrent runs of each organization, nil when unlimited.
+	orgLimiter    *orgLimiter
+	priorityAging time.Duration
+
 	ctx    context.Context
 	cancel context.CancelFunc
 	wg     *sync.WaitGroup
@@ -358,6 +388,7 @@ func (s *TickScheduler) UpdateTask(authCtx context.Context, task *platform.Task)
 	ts.hasQueue = hasQueue
 	ts.nextDue = next
 	ts.authCtx = authCtx
+	ts.priority = priorityRank(opt.Priority)
 	ts.nextDueMu.Unlock()
 	// check the concurrency
 	// todo(lh): In the near future we may not be using the scheduler to manage concurrency.
@@ -436,10 +467,13 @@ type taskScheduler struct {
 
 	metrics *schedulerMetrics
 
+	orgLimiter *orgLimiter
+
 	nextDueMu     sync.RWMutex // Protects following fields.
 	nextDue       int64        // Unix timestamp of next due.
 	nextDueSource int64        // Run time that produced nextDue.
 	hasQueue      bool         // Whether there is a queue of manual runs.
+	priority      int          // Rank of the priority option of the task.
 }
 
 func newTaskScheduler(
@@ -479,9 +513,11 @@ func newTaskScheduler(
 		running:       make(map[platform.ID]runCtx, maxC),
 		logger:        s.logger.With(zap.String("task_id", task.ID.String())),
 		metrics:       s.metrics,
+		orgLimiter:    s.orgLimiter,
 		nextDue:       firstDue,
 		nextDueSource: math.MinInt64,
 		hasQueue:      len(runs) > 0,
+		priority:      priorityRank(opt.Priority),
 	}
 
 	for i := range ts.runners {
@@ -545,6 +581,13 @@ func (ts *taskScheduler) NextDue() (int64, bool) {
 	return ts.nextDue, ts.hasQueue
 }
 
+// Priority returns the rank of the priority option of the task.
+func (ts *taskScheduler) Priority() int {
+	ts.nextDueMu.RLock()
+	defer ts.nextDueMu.RUnlock()
+	return ts.priority
+}
+
 // SetNextDue sets the next due timestamp and whether the task has a queue,
 // and records the source (the now value of the run who reported nextDue).
 func (ts *taskScheduler) SetNextDue(nextDue int64, hasQueue bool, source int64) {
@@ -702,7 +745,43 @@ func (r *runner) fail(qr QueuedRun, runLogger *zap.Logger, stage string, reason
 	atomic.StoreUint32(r.state, runnerIdle)
 }
 
+// waitOrgSlot queues the run until the concurrency limit of the organization
+// of the task lets it start, after the queued runs of a better priority. When the run is canceled while queued, it is
+// finished as canceled and ok is false.
+func (r *runner) waitOrgSlot(ctx context.Context, qr QueuedRun, runLogger *zap.Logger) (release func(), ok bool) {
+	release, err := r.ts.orgLimiter.acquire(ctx, r.ctx, r.task, r.ts.Priority())
+	if err == nil {
+		return release, true
+	}
+
+	defer r.wg.Done()
+	runLogger.Debug("Run canceled while queued for the concurrency limit of its organization", zap.Error(err))
+	r.clearRunning(qr.RunID)
+	r.taskControlService.AddRunLog(r.ts.authCtx, r.task.ID, qr.RunID, time.Now(), "Canceled while queued for the concurrency limit of the organization")
+	if err := r.taskControlService.UpdateRunState(r.ctx, r.task.ID, qr.RunID, time.Now(), RunCanceled); err != nil {
+		runLogger.Info("Error updating run state", zap.Stringer("state", RunCanceled), zap.Error(err))
+	}
+	if _, err := r.taskControlService.FinishRun(r.ctx, qr.TaskID, qr.RunID); err != nil {
+		runLogger.Error("Failed to finish run", zap.Error(err))
+	}
+
+	if r.ctx.Err() != nil {
+		// The task was released, there is no next run.
+		atomic.StoreUint32(r.state, runnerIdle)
+		return nil, false
+	}
+	// Move on to the next execution, for a canceled run.
+	r.startFromWorking(atomic.LoadInt64(r.ts.now))
+	return nil, false
+}
+
 func (r *runner) executeAndWait(ctx context.Context, qr QueuedRun, runLogger *zap.Logger) {
+	release, ok := r.waitOrgSlot(ctx, qr, runLogger)
+	if !ok {
+		return
+	}
+	defer release()
+
 	r.updateRunState(qr, RunStarted, runLogger)
 
 	defer r.wg.Done()
@@ -783,6 +862,9 @@ func (r *runner) executeAndWait(ctx context.Context, qr QueuedRun, runLogger *za
 		r.ts.nextDueMu.RUnlock()
 		r.taskControlService.AddRunLog(authCtx, r.task.ID, qr.RunID, time.Now(), string(b))
 	}
+	if summary := rr.Summary(); summary != "" {
+		r.taskControlService.AddRunLog(r.ts.authCtx, r.task.ID, qr.RunID, time.Now(), summary)
+	}
 	r.updateRunState(qr, RunSuccess, runLogger)
 	runLogger.Debug("Execution succeeded")
 
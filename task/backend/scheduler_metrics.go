@@ -20,6 +20,9 @@ type schedulerMetrics struct {
 	claimsActive   prometheus.Gauge
 
 	queueDelta prometheus.Summary
+
+	orgRunsQueued *prometheus.GaugeVec
+	orgQueueDelta *prometheus.SummaryVec
 }
 
 func newSchedulerMetrics() *schedulerMetrics {
@@ -72,6 +75,20 @@ func newSchedulerMetrics() *schedulerMetrics {
 			Help:       "The duration in seconds between a run being due to start and actually starting.",
 			Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
 		}),
+
+		orgRunsQueued: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "org_runs_queued",
+			Help:      "Number of runs waiting for the concurrency limit of their organization, split out by organization ID.",
+		}, []string{"org_id"}),
+		orgQueueDelta: prometheus.NewSummaryVec(prometheus.SummaryOpts{
+			Namespace:  namespace,
+			Subsystem:  subsystem,
+			Name:       "org_queue_delta",
+			Help:       "The duration in seconds runs waited for the concurrency limit of their organization, split out by organization ID.",
+			Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+		}, []string{"org_id"}),
 	}
 }
 
@@ -85,6 +102,8 @@ func (sm *schedulerMetrics) PrometheusCollectors() []prometheus.Collector {
 		sm.claimsComplete,
 		sm.claimsActive,
 		sm.queueDelta,
+		sm.orgRunsQueued,
+		sm.orgQueueDelta,
 	}
 }
 
@@ -107,6 +126,21 @@ func (sm *schedulerMetrics) FinishRun(tid string, succeeded bool) {
 	sm.runsComplete.WithLabelValues(tid, status).Inc()
 }
 
+// QueueOrgRun adjusts the metrics to indicate a run waits for the concurrency limit of the given organization ID.
+func (sm *schedulerMetrics) QueueOrgRun(oid string) {
+	sm.orgRunsQueued.WithLabelValues(oid).Inc()
+}
+
+// DequeueOrgRun adjusts the metrics to indicate a run no longer waits for the concurrency limit of the given organization ID.
+func (sm *schedulerMetrics) DequeueOrgRun(oid string) {
+	sm.orgRunsQueued.WithLabelValues(oid).Dec()
+}
+
+// StartOrgRun stores the time a run waited for the concurrency limit of the given organization ID.
+func (sm *schedulerMetrics) StartOrgRun(oid string, queueDelta time.Duration) {
+	sm.orgQueueDelta.WithLabelValues(oid).Observe(queueDelta.Seconds())
+}
+
 // ClaimTask adjusts the metrics to indicate the result of an attempted claim.
 func (sm *schedulerMetrics) ClaimTask(succeeded bool) {
 	status := statusString(succeeded)
@@ -274,6 +274,7 @@ func TestScheduler_LogStatisticsOnSuccess(t *testing.T) {
 
 	rr := mock.NewRunResult(nil, false)
 	rr.Stats = flux.Statistics{Metadata: flux.Metadata{"foo": []interface{}{"bar"}}}
+	rr.SummaryLog = "the summary of the run"
 	p[0].Finish(rr, nil)
 
 	runID := p[0].Run().RunID
@@ -304,6 +305,14 @@ func TestScheduler_LogStatisticsOnSuccess(t *testing.T) {
 	if !reflect.DeepEqual(foo, []interface{}{"bar"}) {
 		t.Fatalf("query statistics were not encoded correctly into logs. expected metadata.foo=[bar], got: %#v", stats)
 	}
+
+	summarized := false
+	for _, log := range run.Log {
+		summarized = summarized || log.Message == rr.SummaryLog
+	}
+	if !summarized {
+		t.Fatalf("expected the summary of the run in its logs, got %+v", run.Log)
+	}
 }
 
 func TestScheduler_Release(t *testing.T) {
@@ -942,6 +951,167 @@ func TestScheduler_Metrics(t *testing.T) {
 	}
 }
 
+func TestScheduler_OrgConcurrency(t *testing.T) {
+	t.Parallel()
+
+	tcs := mock.NewTaskControlService()
+	e := mock.NewExecutor()
+	limited := func(task *platform.Task) bool { return task.Description == "limited" }
+	s := backend.NewScheduler(tcs, e, 5, backend.WithOrgConcurrency(1, limited))
+	s.Start(context.Background())
+	defer s.Stop()
+
+	reg := prom.NewRegistry()
+	reg.MustRegister(s.PrometheusCollectors()...)
+
+	newTask := func(id, orgID platform.ID, description string) *platform.Task {
+		return &platform.Task{
+			ID:              id,
+			OrganizationID:  orgID,
+			Description:     description,
+			Every:           "1s",
+			LatestCompleted: "1970-01-01T00:00:05Z",
+			Flux:            `option task = {name:"x", every:1m} from(bucket:"a") |> to(bucket:"b", org: "o")`,
+		}
+	}
+	tasks := []*platform.Task{
+		newTask(1, 10, "limited"),
+		newTask(2, 10, "limited"),
+		newTask(3, 10, "unlimited"),
+		newTask(4, 20, "limited"),
+	}
+	for _, task := range tasks {
+		tcs.SetTask(task)
+		if err := s.ClaimTask(context.Background(), task); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s.Tick(6)
+	for _, task := range tasks {
+		if _, err := tcs.PollForNumberCreated(task.ID, 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, id := range []platform.ID{3, 4} {
+		if _, err := e.PollForNumberRunning(id, 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Only one of the limited tasks of the organization 10 runs, the other is queued.
+	var running, queued platform.ID
+	for running == 0 {
+		switch {
+		case len(e.RunningFor(1)) == 1:
+			running, queued = 1, 2
+		case len(e.RunningFor(2)) == 1:
+			running, queued = 2, 1
+		default:
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	time.Sleep(50 * time.Millisecond)
+	if n := len(e.RunningFor(queued)); n != 0 {
+		t.Fatalf("expected the run of task %s to be queued, got %d running", queued, n)
+	}
+	mfs := promtest.MustGather(t, reg)
+	m := promtest.MustFindMetric(t, mfs, "task_scheduler_org_runs_queued", map[string]string{"org_id": platform.ID(10).String()})
+	if got := *m.Gauge.Value; got != 1 {
+		t.Fatalf("expected 1 run queued for the organization, got %v", got)
+	}
+
+	// The queued run starts once the running one finishes.
+	e.RunningFor(running)[0].Finish(mock.NewRunResult(nil, false), nil)
+	if _, err := e.PollForNumberRunning(queued, 1); err != nil {
+		t.Fatal(err)
+	}
+	mfs = promtest.MustGather(t, reg)
+	m = promtest.MustFindMetric(t, mfs, "task_scheduler_org_runs_queued", map[string]string{"org_id": platform.ID(10).String()})
+	if got := *m.Gauge.Value; got != 0 {
+		t.Fatalf("expected no run queued for the organization, got %v", got)
+	}
+	m = promtest.MustFindMetric(t, mfs, "task_scheduler_org_queue_delta", map[string]string{"org_id": platform.ID(10).String()})
+	if got := m.Summary.GetSampleCount(); got != 2 {
+		t.Fatalf("expected 2 delta in summary: got: %v", got)
+	}
+}
+
+func TestScheduler_OrgConcurrencyPriority(t *testing.T) {
+	t.Parallel()
+
+	newTask := func(id platform.ID, priority string) *platform.Task {
+		return &platform.Task{
+			ID:              id,
+			OrganizationID:  10,
+			Every:           "1s",
+			LatestCompleted: "1970-01-01T00:00:05Z",
+			Flux:            fmt.Sprintf(`option task = {name:"x", every:1m, priority: %q} from(bucket:"a") |> to(bucket:"b", org: "o")`, priority),
+		}
+	}
+
+	for _, c := range []struct {
+		name  string
+		aging time.Duration
+		wait  time.Duration
+		want  platform.ID
+	}{
+		{name: "critical run starts first", aging: time.Hour, want: 3},
+		{name: "low run waiting long enough is promoted", aging: 20 * time.Millisecond, wait: 100 * time.Millisecond, want: 2},
+	} {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			tcs := mock.NewTaskControlService()
+			e := mock.NewExecutor()
+			s := backend.NewScheduler(tcs, e, 5, backend.WithOrgConcurrency(1, nil), backend.WithPriorityAging(c.aging))
+			s.Start(context.Background())
+			defer s.Stop()
+
+			reg := prom.NewRegistry()
+			reg.MustRegister(s.PrometheusCollectors()...)
+			waitQueued := func(n float64) {
+				t.Helper()
+				for i := 0; i < 100; i++ {
+					mfs := promtest.MustGather(t, reg)
+					m := promtest.FindMetric(mfs, "task_scheduler_org_runs_queued", map[string]string{"org_id": platform.ID(10).String()})
+					if m != nil && *m.Gauge.Value == n {
+						return
+					}
+					time.Sleep(10 * time.Millisecond)
+				}
+				t.Fatalf("expected %v runs queued", n)
+			}
+
+			s.Tick(6)
+			for _, task := range []*platform.Task{newTask(1, "normal"), newTask(2, "low"), newTask(3, "critical")} {
+				tcs.SetTask(task)
+				if err := s.ClaimTask(context.Background(), task); err != nil {
+					t.Fatal(err)
+				}
+				switch task.ID {
+				case 1:
+					if _, err := e.PollForNumberRunning(task.ID, 1); err != nil {
+						t.Fatal(err)
+					}
+				case 2:
+					waitQueued(1)
+					time.Sleep(c.wait)
+				case 3:
+					waitQueued(2)
+				}
+			}
+
+			e.RunningFor(1)[0].Finish(mock.NewRunResult(nil, false), nil)
+			if _, err := e.PollForNumberRunning(c.want, 1); err != nil {
+				t.Fatal(err)
+			}
+			waitQueued(1)
+		})
+	}
+}
+
 type fakeWaitExecutor struct {
 	wait chan struct{}
 }
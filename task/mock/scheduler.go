@@ -322,6 +322,8 @@ type RunResult struct {
 	// Most tests don't care about statistics.
 	// If your test does care, adjust it after the call to NewRunResult.
 	Stats flux.Statistics
+	// SummaryLog is the summary of the run, adjust it like Stats.
+	SummaryLog string
 }
 
 var _ backend.RunResult = (*RunResult)(nil)
@@ -341,3 +343,7 @@ func (rr *RunResult) IsRetryable() bool {
 func (rr *RunResult) Statistics() flux.Statistics {
 	return rr.Stats
 }
+
+func (rr *RunResult) Summary() string {
+	return rr.SummaryLog
+}
@@ -39,8 +39,20 @@ type Options struct {
 	Concurrency *int64 `json:"concurrency,omitempty"`
 
 	Retry *int64 `json:"retry,omitempty"`
+
+	// Priority is the class the runs of the task are ordered by when they
+	// are queued, one of PriorityCritical, PriorityNormal or PriorityLow.
+	// The runs are normal when it is empty.
+	Priority string `json:"priority,omitempty"`
 }
 
+// The priority classes of tasks.
+const (
+	PriorityCritical = "critical"
+	PriorityNormal   = "normal"
+	PriorityLow      = "low"
+)
+
 // Duration is a time span that supports the same units as the flux parser's time duration, as well as negative length time spans.
 type Duration struct {
 	Node ast.DurationLiteral
@@ -128,6 +140,7 @@ func (o *Options) Clear() {
 	o.Offset = nil
 	o.Concurrency = nil
 	o.Retry = nil
+	o.Priority = ""
 }
 
 // IsZero tells us if the options has been zeroed out.
@@ -137,7 +150,8 @@ func (o *Options) IsZero() bool {
 		o.Every.IsZero() &&
 		o.Offset == nil &&
 		o.Concurrency == nil &&
-		o.Retry == nil
+		o.Retry == nil &&
+		o.Priority == ""
 }
 
 // All the task option names we accept.
@@ -148,6 +162,7 @@ const (
 	optOffset      = "offset"
 	optConcurrency = "concurrency"
 	optRetry       = "retry"
+	optPriority    = "priority"
 )
 
 // contains is a helper function to see if an array of strings contains a string
@@ -301,6 +316,13 @@ func FromScript(script string) (Options, error) {
 		opt.Retry = pointer.Int64(retryVal.Int())
 	}
 
+	if priorityVal, ok := optObject.Get(optPriority); ok {
+		if err := checkNature(priorityVal.PolyType().Nature(), semantic.String); err != nil {
+			return opt, err
+		}
+		opt.Priority = priorityVal.Str()
+	}
+
 	if err := opt.Validate(); err != nil {
 		return opt, err
 	}
@@ -361,6 +383,11 @@ func (o *Options) Validate() error {
 			errs = append(errs, fmt.Sprintf("retry exceeded max of %d", maxRetry))
 		}
 	}
+	switch o.Priority {
+	case "", PriorityCritical, PriorityNormal, PriorityLow:
+	default:
+		errs = append(errs, fmt.Sprintf("priority must be one of %s, %s or %s", PriorityCritical, PriorityNormal, PriorityLow))
+	}
 
 	if len(errs) == 0 {
 		return nil
@@ -402,7 +429,7 @@ func validateOptionNames(o values.Object) error {
 	var unexpected []string
 	o.Range(func(name string, _ values.Value) {
 		switch name {
-		case optName, optCron, optEvery, optOffset, optConcurrency, optRetry:
+		case optName, optCron, optEvery, optOffset, optConcurrency, optRetry, optPriority:
 			// Known option. Nothing to do.
 		default:
 			unexpected = append(unexpected, name)
@@ -411,7 +438,7 @@ func validateOptionNames(o values.Object) error {
 
 	if len(unexpected) > 0 {
 		u := strings.Join(unexpected, ", ")
-		v := strings.Join([]string{optName, optCron, optEvery, optOffset, optConcurrency, optRetry}, ", ")
+		v := strings.Join([]string{optName, optCron, optEvery, optOffset, optConcurrency, optRetry, optPriority}, ", ")
 		return fmt.Errorf("unknown task option(s): %s. valid options are %s", u, v)
 	}
 
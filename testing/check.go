@@ -0,0 +1,894 @@
+package testing
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/check"
+)
+
+// CheckFields includes prepopulated data for mapping tests.
+type CheckFields struct {
+	IDGenerator   influxdb.IDGenerator
+	TimeGenerator influxdb.TimeGenerator
+	Checks        []influxdb.Check
+	Orgs          []*influxdb.Organization
+	// Users, Buckets and UserResourceMappings are the owners of the checks
+	// and the buckets read by the tasks running them.
+	Users                []*influxdb.User
+	Buckets              []*influxdb.Bucket
+	UserResourceMappings []*influxdb.UserResourceMapping
+}
+
+var checkCmpOptions = cmp.Options{
+	cmp.Transformer("Sort", func(in []influxdb.Check) []influxdb.Check {
+		out := append([]influxdb.Check(nil), in...)
+		sort.Slice(out, func(i, j int) bool {
+			return out[i].GetID() > out[j].GetID()
+		})
+		return out
+	}),
+}
+
+// checkTaskCmpOptions ignore the tasks run by the checks, and their
+// authorizations, which are generated.
+var checkTaskCmpOptions = cmp.Options{
+	cmpopts.IgnoreFields(check.Base{}, "TaskID", "AuthorizationID"),
+}
+
+// CheckService tests all the service functions.
+func CheckService(
+	init func(CheckFields, *testing.T) (influxdb.CheckService, func()), t *testing.T,
+) {
+	tests := []struct {
+		name string
+		fn   func(init func(CheckFields, *testing.T) (influxdb.CheckService, func()),
+			t *testing.T)
+	}{
+		{
+			name: "CreateCheck",
+			fn:   CreateCheck,
+		},
+		{
+			name: "FindCheckByID",
+			fn:   FindCheckByID,
+		},
+		{
+			name: "FindChecks",
+			fn:   FindChecks,
+		},
+		{
+			name: "UpdateCheck",
+			fn:   UpdateCheck,
+		},
+		{
+			name: "PatchCheck",
+			fn:   PatchCheck,
+		},
+		{
+			name: "DeleteCheck",
+			fn:   DeleteCheck,
+		},
+		{
+			name: "ArchiveCheck",
+			fn:   ArchiveCheck,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(init, t)
+		})
+	}
+}
+
+func checkOrgs() []*influxdb.Organization {
+	return []*influxdb.Organization{
+		{
+			ID:   MustIDBase16(fourID),
+			Name: "theorg",
+		},
+		{
+			ID:   MustIDBase16(fiveID),
+			Name: "otherorg",
+		},
+	}
+}
+
+// checkTaskIDs are the ids generated for the tasks of the checks, their
+// authorizations and the monitoring buckets of checkOrgs.
+var checkTaskIDs = []string{
+	"020f755c3c0820a0",
+	"020f755c3c0820a1",
+	"020f755c3c0820a2",
+	"020f755c3c0820a3",
+	"020f755c3c0820a4",
+	"020f755c3c0820a5",
+}
+
+// checkTaskFields returns f with an owner of its checks, and the buckets of
+// checkOrgs their tasks read. The ids are generated from checkTaskIDs,
+// unless f has an id generator.
+func checkTaskFields(f CheckFields) CheckFields {
+	if f.IDGenerator == nil {
+		f.IDGenerator = &loopIDGenerator{s: checkTaskIDs}
+	}
+	f.Users = []*influxdb.User{{ID: MustIDBase16(sixID), Name: "theuser"}}
+	f.Buckets = []*influxdb.Bucket{
+		{ID: MustIDBase16(sevenID), OrgID: MustIDBase16(fourID), Name: "telegraf"},
+		{ID: MustIDBase16(eightID), OrgID: MustIDBase16(fiveID), Name: "telegraf"},
+	}
+	for _, c := range f.Checks {
+		f.UserResourceMappings = append(f.UserResourceMappings, &influxdb.UserResourceMapping{
+			ResourceID:   c.GetID(),
+			ResourceType: influxdb.ChecksResourceType,
+			UserID:       MustIDBase16(sixID),
+			UserType:     influxdb.Owner,
+		})
+	}
+	return f
+}
+
+func checkCPU() influxdb.Check {
+	return &check.Threshold{
+		Base: check.Base{
+			ID:     MustIDBase16(oneID),
+			Name:   "cpu",
+			OrgID:  MustIDBase16(fourID),
+			Status: influxdb.Active,
+			Every:  influxdb.Duration{Duration: time.Minute},
+			Query: influxdb.DashboardQuery{
+				Text: `from(bucket: "telegraf") |> range(start: -1m) |> filter(fn: (r) => r._measurement == "cpu")`,
+			},
+			Tags: []notification.Tag{{Key: "team", Value: "ops"}},
+			CRUDLog: influxdb.CRUDLog{
+				CreatedAt: timeGen1.Now(),
+				UpdatedAt: timeGen2.Now(),
+			},
+		},
+		Thresholds: []check.ThresholdConfig{
+			&check.Greater{ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Critical}, Value: 90},
+		},
+	}
+}
+
+func checkHeartbeat() influxdb.Check {
+	return &check.Deadman{
+		Base: check.Base{
+			ID:     MustIDBase16(twoID),
+			Name:   "heartbeat",
+			OrgID:  MustIDBase16(fiveID),
+			Status: influxdb.Active,
+			Every:  influxdb.Duration{Duration: time.Minute},
+			Query: influxdb.DashboardQuery{
+				Text: `from(bucket: "telegraf") |> range(start: -5m) |> filter(fn: (r) => r._measurement == "system")`,
+			},
+			CRUDLog: influxdb.CRUDLog{
+				CreatedAt: timeGen1.Now(),
+				UpdatedAt: timeGen2.Now(),
+			},
+		},
+		TimeSince: 90,
+		Level:     notification.Critical,
+	}
+}
+
+// CreateCheck testing.
+func CreateCheck(
+	init func(CheckFields, *testing.T) (influxdb.CheckService, func()),
+	t *testing.T,
+) {
+	type args struct {
+		check  influxdb.Check
+		userID influxdb.ID
+	}
+	type wants struct {
+		err    error
+		checks []influxdb.Check
+	}
+
+	tests := []struct {
+		name   string
+		fields CheckFields
+		args   args
+		wants  wants
+	}{
+		{
+			name: "basic create check",
+			fields: checkTaskFields(CheckFields{
+				IDGenerator:   &loopIDGenerator{s: append([]string{oneID}, checkTaskIDs...)},
+				TimeGenerator: fakeGenerator,
+				Orgs:          checkOrgs(),
+				Checks:        []influxdb.Check{checkHeartbeat()},
+			}),
+			args: args{
+				userID: MustIDBase16(sixID),
+				check: &check.Threshold{
+					Base: check.Base{
+						Name:  "cpu",
+						OrgID: MustIDBase16(fourID),
+						Every: influxdb.Duration{Duration: time.Minute},
+						Query: influxdb.DashboardQuery{
+							Text: `from(bucket: "telegraf") |> range(start: -1m) |> filter(fn: (r) => r._measurement == "cpu")`,
+						},
+						Tags: []notification.Tag{{Key: "team", Value: "ops"}},
+					},
+					Thresholds: []check.ThresholdConfig{
+						&check.Greater{ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Critical}, Value: 90},
+					},
+				},
+			},
+			wants: wants{
+				checks: []influxdb.Check{
+					checkHeartbeat(),
+					func() influxdb.Check {
+						c := checkCPU().(*check.Threshold)
+						c.CRUDLog = influxdb.CRUDLog{
+							CreatedAt: fakeDate,
+							UpdatedAt: fakeDate,
+						}
+						return c
+					}(),
+				},
+			},
+		},
+		{
+			name: "names are unique within an org",
+			fields: CheckFields{
+				IDGenerator:   mock.NewIDGenerator(threeID, t),
+				TimeGenerator: fakeGenerator,
+				Orgs:          checkOrgs(),
+				Checks:        []influxdb.Check{checkCPU()},
+			},
+			args: args{
+				userID: MustIDBase16(sixID),
+				check: &check.Deadman{
+					Base: check.Base{
+						Name:  "cpu",
+						OrgID: MustIDBase16(fourID),
+						Every: influxdb.Duration{Duration: time.Minute},
+						Query: influxdb.DashboardQuery{
+							Text: `from(bucket: "telegraf") |> range(start: -1m)`,
+						},
+					},
+					TimeSince: 60,
+				},
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Code: influxdb.EConflict,
+					Msg:  "check with name cpu already exists",
+				},
+				checks: []influxdb.Check{checkCPU()},
+			},
+		},
+		{
+			name: "create check in an org that doesn't exist",
+			fields: CheckFields{
+				IDGenerator:   mock.NewIDGenerator(threeID, t),
+				TimeGenerator: fakeGenerator,
+			},
+			args: args{
+				userID: MustIDBase16(sixID),
+				check: &check.Deadman{
+					Base: check.Base{
+						Name:  "heartbeat",
+						OrgID: MustIDBase16(fourID),
+						Every: influxdb.Duration{Duration: time.Minute},
+						Query: influxdb.DashboardQuery{
+							Text: `from(bucket: "telegraf") |> range(start: -1m)`,
+						},
+					},
+					TimeSince: 60,
+				},
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Code: influxdb.ENotFound,
+					Msg:  "organization not found",
+				},
+				checks: []influxdb.Check{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(tt.fields, t)
+			defer done()
+			ctx := context.Background()
+			err := s.CreateCheck(ctx, tt.args.check, tt.args.userID)
+			ErrorsEqual(t, err, tt.wants.err)
+
+			cs, _, err := s.FindChecks(ctx, influxdb.CheckFilter{})
+			if err != nil {
+				t.Fatalf("failed to retrieve checks: %v", err)
+			}
+			if diff := cmp.Diff(cs, tt.wants.checks, checkCmpOptions, checkTaskCmpOptions); diff != "" {
+				t.Errorf("checks are different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+// FindCheckByID testing.
+func FindCheckByID(
+	init func(CheckFields, *testing.T) (influxdb.CheckService, func()),
+	t *testing.T,
+) {
+	type args struct {
+		id influxdb.ID
+	}
+	type wants struct {
+		err   error
+		check influxdb.Check
+	}
+
+	fields := CheckFields{
+		Orgs:   checkOrgs(),
+		Checks: []influxdb.Check{checkCPU(), checkHeartbeat()},
+	}
+
+	tests := []struct {
+		name  string
+		args  args
+		wants wants
+	}{
+		{
+			name: "find check by id",
+			args: args{
+				id: MustIDBase16(twoID),
+			},
+			wants: wants{
+				check: checkHeartbeat(),
+			},
+		},
+		{
+			name: "find check by id not exists",
+			args: args{
+				id: MustIDBase16(threeID),
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Code: influxdb.ENotFound,
+					Msg:  "check not found",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(fields, t)
+			defer done()
+			ctx := context.Background()
+
+			c, err := s.FindCheckByID(ctx, tt.args.id)
+			ErrorsEqual(t, err, tt.wants.err)
+			if diff := cmp.Diff(c, tt.wants.check); diff != "" {
+				t.Errorf("check is different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+// FindChecks testing.
+func FindChecks(
+	init func(CheckFields, *testing.T) (influxdb.CheckService, func()),
+	t *testing.T,
+) {
+	type args struct {
+		filter influxdb.CheckFilter
+		opts   influxdb.FindOptions
+	}
+	type wants struct {
+		checks []influxdb.Check
+		// total is the count of the matching checks across every page,
+		// the number of checks when 0.
+		total int
+	}
+
+	// the heartbeat check is created before and updated after the cpu check.
+	heartbeat := func() influxdb.Check {
+		c := checkHeartbeat().(*check.Deadman)
+		c.CRUDLog = influxdb.CRUDLog{
+			CreatedAt: fakeDate,
+			UpdatedAt: timeGen2.Now().Add(time.Hour),
+		}
+		return c
+	}
+	fields := CheckFields{
+		Orgs:   checkOrgs(),
+		Checks: []influxdb.Check{checkCPU(), heartbeat()},
+	}
+
+	tests := []struct {
+		name  string
+		args  args
+		wants wants
+	}{
+		{
+			name: "find all checks",
+			wants: wants{
+				checks: []influxdb.Check{checkCPU(), heartbeat()},
+			},
+		},
+		{
+			name: "find checks by org id",
+			args: args{
+				filter: influxdb.CheckFilter{
+					OrgID: idPtr(MustIDBase16(fiveID)),
+				},
+			},
+			wants: wants{
+				checks: []influxdb.Check{heartbeat()},
+			},
+		},
+		{
+			name: "find checks by org name",
+			args: args{
+				filter: influxdb.CheckFilter{
+					Org: strPtr("theorg"),
+				},
+			},
+			wants: wants{
+				checks: []influxdb.Check{checkCPU()},
+			},
+		},
+		{
+			name: "find checks by name",
+			args: args{
+				filter: influxdb.CheckFilter{
+					Name: strPtr("heartbeat"),
+				},
+			},
+			wants: wants{
+				checks: []influxdb.Check{heartbeat()},
+			},
+		},
+		{
+			name: "find checks by ids",
+			args: args{
+				filter: influxdb.CheckFilter{
+					IDs: []*influxdb.ID{
+						idPtr(MustIDBase16(twoID)),
+						idPtr(MustIDBase16(threeID)),
+						idPtr(MustIDBase16(oneID)),
+						idPtr(MustIDBase16(twoID)),
+					},
+				},
+			},
+			wants: wants{
+				checks: []influxdb.Check{checkCPU(), heartbeat()},
+			},
+		},
+		{
+			name: "find checks by ids of an org",
+			args: args{
+				filter: influxdb.CheckFilter{
+					IDs:   []*influxdb.ID{idPtr(MustIDBase16(oneID)), idPtr(MustIDBase16(twoID))},
+					OrgID: idPtr(MustIDBase16(fourID)),
+				},
+			},
+			wants: wants{
+				checks: []influxdb.Check{checkCPU()},
+			},
+		},
+		{
+			name: "find checks with limit",
+			args: args{
+				opts: influxdb.FindOptions{
+					Limit: 1,
+				},
+			},
+			wants: wants{
+				checks: []influxdb.Check{checkCPU()},
+				total:  2,
+			},
+		},
+		{
+			name: "find checks sorted by createdAt",
+			args: args{
+				opts: influxdb.FindOptions{
+					SortBy: influxdb.CheckSortByCreatedAt,
+				},
+			},
+			wants: wants{
+				checks: []influxdb.Check{heartbeat(), checkCPU()},
+			},
+		},
+		{
+			name: "find checks sorted by updatedAt",
+			args: args{
+				opts: influxdb.FindOptions{
+					SortBy: influxdb.CheckSortByUpdatedAt,
+				},
+			},
+			wants: wants{
+				checks: []influxdb.Check{checkCPU(), heartbeat()},
+			},
+		},
+		{
+			name: "find the last created check",
+			args: args{
+				opts: influxdb.FindOptions{
+					SortBy:     influxdb.CheckSortByCreatedAt,
+					Descending: true,
+					Limit:      1,
+				},
+			},
+			wants: wants{
+				checks: []influxdb.Check{checkCPU()},
+				total:  2,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(fields, t)
+			defer done()
+			ctx := context.Background()
+
+			cs, n, err := s.FindChecks(ctx, tt.args.filter, tt.args.opts)
+			if err != nil {
+				t.Fatalf("failed to retrieve checks: %v", err)
+			}
+			total := tt.wants.total
+			if total == 0 {
+				total = len(tt.wants.checks)
+			}
+			if n != total {
+				t.Errorf("checks total is different got %d, want %d", n, total)
+			}
+			opts := checkCmpOptions
+			if tt.args.opts.SortBy != "" {
+				// sorted checks are compared in order.
+				opts = nil
+			}
+			if diff := cmp.Diff(cs, tt.wants.checks, opts...); diff != "" {
+				t.Errorf("checks are different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+// UpdateCheck testing.
+func UpdateCheck(
+	init func(CheckFields, *testing.T) (influxdb.CheckService, func()),
+	t *testing.T,
+) {
+	type args struct {
+		id    influxdb.ID
+		check influxdb.Check
+	}
+	type wants struct {
+		err   error
+		check influxdb.Check
+	}
+
+	tests := []struct {
+		name   string
+		fields CheckFields
+		args   args
+		wants  wants
+	}{
+		{
+			name: "rename a check",
+			fields: checkTaskFields(CheckFields{
+				TimeGenerator: fakeGenerator,
+				Orgs:          checkOrgs(),
+				Checks:        []influxdb.Check{checkCPU(), checkHeartbeat()},
+			}),
+			args: args{
+				id: MustIDBase16(oneID),
+				check: func() influxdb.Check {
+					c := checkCPU().(*check.Threshold)
+					c.Name = "cpu usage"
+					// the org of a check can't be updated.
+					c.OrgID = MustIDBase16(fiveID)
+					return c
+				}(),
+			},
+			wants: wants{
+				check: func() influxdb.Check {
+					c := checkCPU().(*check.Threshold)
+					c.Name = "cpu usage"
+					c.UpdatedAt = fakeDate
+					return c
+				}(),
+			},
+		},
+		{
+			name: "update a check that doesn't exist",
+			fields: CheckFields{
+				TimeGenerator: fakeGenerator,
+				Orgs:          checkOrgs(),
+				Checks:        []influxdb.Check{checkCPU()},
+			},
+			args: args{
+				id:    MustIDBase16(threeID),
+				check: checkHeartbeat(),
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Code: influxdb.ENotFound,
+					Msg:  "check not found",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(tt.fields, t)
+			defer done()
+			ctx := context.Background()
+
+			c, err := s.UpdateCheck(ctx, tt.args.id, tt.args.check)
+			ErrorsEqual(t, err, tt.wants.err)
+			if diff := cmp.Diff(c, tt.wants.check, checkTaskCmpOptions); diff != "" {
+				t.Errorf("check is different -got/+want\ndiff %s", diff)
+			}
+			if tt.wants.err != nil {
+				return
+			}
+
+			// the stored check keeps its creation time and has the update time.
+			stored, err := s.FindCheckByID(ctx, tt.args.id)
+			if err != nil {
+				t.Fatalf("failed to find check: %v", err)
+			}
+			if diff := cmp.Diff(stored.GetCRUDLog(), tt.wants.check.GetCRUDLog()); diff != "" {
+				t.Errorf("check crud log is different -got/+want\ndiff %s", diff)
+			}
+
+			// the check can be found by its new name.
+			found, err := s.FindCheck(ctx, influxdb.CheckFilter{
+				OrgID: idPtr(tt.wants.check.GetOrgID()),
+				Name:  strPtr(tt.wants.check.GetName()),
+			})
+			if err != nil {
+				t.Fatalf("failed to find check by name: %v", err)
+			}
+			if found.GetID() != tt.wants.check.GetID() {
+				t.Errorf("expected check %s, got %s", tt.wants.check.GetID(), found.GetID())
+			}
+		})
+	}
+}
+
+// PatchCheck testing.
+func PatchCheck(
+	init func(CheckFields, *testing.T) (influxdb.CheckService, func()),
+	t *testing.T,
+) {
+	type args struct {
+		id  influxdb.ID
+		upd influxdb.CheckUpdate
+	}
+	type wants struct {
+		err   error
+		check influxdb.Check
+	}
+
+	inactive := influxdb.Inactive
+
+	tests := []struct {
+		name   string
+		fields CheckFields
+		args   args
+		wants  wants
+	}{
+		{
+			name: "deactivate a check",
+			fields: checkTaskFields(CheckFields{
+				TimeGenerator: fakeGenerator,
+				Orgs:          checkOrgs(),
+				Checks:        []influxdb.Check{checkCPU()},
+			}),
+			args: args{
+				id: MustIDBase16(oneID),
+				upd: influxdb.CheckUpdate{
+					Status:      &inactive,
+					Description: strPtr("noisy"),
+				},
+			},
+			wants: wants{
+				check: func() influxdb.Check {
+					c := checkCPU().(*check.Threshold)
+					c.Status = influxdb.Inactive
+					c.Description = "noisy"
+					c.UpdatedAt = fakeDate
+					return c
+				}(),
+			},
+		},
+		{
+			name: "rename to the name of another check of the org",
+			fields: CheckFields{
+				TimeGenerator: fakeGenerator,
+				Orgs:          checkOrgs(),
+				Checks: []influxdb.Check{
+					checkCPU(),
+					func() influxdb.Check {
+						c := checkHeartbeat().(*check.Deadman)
+						c.OrgID = MustIDBase16(fourID)
+						return c
+					}(),
+				},
+			},
+			args: args{
+				id: MustIDBase16(twoID),
+				upd: influxdb.CheckUpdate{
+					Name: strPtr("cpu"),
+				},
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Code: influxdb.EConflict,
+					Msg:  "check with name cpu already exists",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(tt.fields, t)
+			defer done()
+			ctx := context.Background()
+
+			c, err := s.PatchCheck(ctx, tt.args.id, tt.args.upd)
+			ErrorsEqual(t, err, tt.wants.err)
+			if diff := cmp.Diff(c, tt.wants.check, checkTaskCmpOptions); diff != "" {
+				t.Errorf("check is different -got/+want\ndiff %s", diff)
+			}
+			if tt.wants.err != nil {
+				return
+			}
+
+			stored, err := s.FindCheckByID(ctx, tt.args.id)
+			if err != nil {
+				t.Fatalf("failed to find check: %v", err)
+			}
+			if diff := cmp.Diff(stored.GetCRUDLog(), tt.wants.check.GetCRUDLog()); diff != "" {
+				t.Errorf("check crud log is different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+// DeleteCheck testing.
+func DeleteCheck(
+	init func(CheckFields, *testing.T) (influxdb.CheckService, func()),
+	t *testing.T,
+) {
+	type args struct {
+		id influxdb.ID
+	}
+	type wants struct {
+		err    error
+		checks []influxdb.Check
+	}
+
+	fields := CheckFields{
+		Orgs:   checkOrgs(),
+		Checks: []influxdb.Check{checkCPU(), checkHeartbeat()},
+	}
+
+	tests := []struct {
+		name  string
+		args  args
+		wants wants
+	}{
+		{
+			name: "delete a check",
+			args: args{
+				id: MustIDBase16(oneID),
+			},
+			wants: wants{
+				checks: []influxdb.Check{checkHeartbeat()},
+			},
+		},
+		{
+			name: "delete a check that doesn't exist",
+			args: args{
+				id: MustIDBase16(threeID),
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Code: influxdb.ENotFound,
+					Msg:  "check not found",
+				},
+				checks: []influxdb.Check{checkCPU(), checkHeartbeat()},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(fields, t)
+			defer done()
+			ctx := context.Background()
+
+			err := s.DeleteCheck(ctx, tt.args.id)
+			ErrorsEqual(t, err, tt.wants.err)
+
+			cs, _, err := s.FindChecks(ctx, influxdb.CheckFilter{})
+			if err != nil {
+				t.Fatalf("failed to retrieve checks: %v", err)
+			}
+			if diff := cmp.Diff(cs, tt.wants.checks, checkCmpOptions...); diff != "" {
+				t.Errorf("checks are different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+// ArchiveCheck testing.
+func ArchiveCheck(
+	init func(CheckFields, *testing.T) (influxdb.CheckService, func()),
+	t *testing.T,
+) {
+	fields := CheckFields{
+		TimeGenerator: fakeGenerator,
+		Orgs:          checkOrgs(),
+		Checks:        []influxdb.Check{checkCPU(), checkHeartbeat()},
+	}
+	s, done := init(fields, t)
+	defer done()
+	ctx := context.Background()
+
+	archived := func() influxdb.Check {
+		c := checkCPU().(*check.Threshold)
+		c.Archived = true
+		c.UpdatedAt = fakeDate
+		return c
+	}
+	c, err := s.ArchiveCheck(ctx, MustIDBase16(oneID))
+	if err != nil {
+		t.Fatalf("failed to archive check: %v", err)
+	}
+	if diff := cmp.Diff(c, archived()); diff != "" {
+		t.Errorf("check is different -got/+want\ndiff %s", diff)
+	}
+
+	_, err = s.ArchiveCheck(ctx, MustIDBase16(oneID))
+	ErrorsEqual(t, err, &influxdb.Error{
+		Code: influxdb.EConflict,
+		Msg:  "check is already archived",
+	})
+
+	// archived checks are only found when asked for.
+	cs, _, err := s.FindChecks(ctx, influxdb.CheckFilter{})
+	if err != nil {
+		t.Fatalf("failed to retrieve checks: %v", err)
+	}
+	if diff := cmp.Diff(cs, []influxdb.Check{checkHeartbeat()}, checkCmpOptions...); diff != "" {
+		t.Errorf("checks are different -got/+want\ndiff %s", diff)
+	}
+	cs, _, err = s.FindChecks(ctx, influxdb.CheckFilter{Archived: true})
+	if err != nil {
+		t.Fatalf("failed to retrieve checks: %v", err)
+	}
+	if diff := cmp.Diff(cs, []influxdb.Check{archived()}, checkCmpOptions...); diff != "" {
+		t.Errorf("archived checks are different -got/+want\ndiff %s", diff)
+	}
+
+	c, err = s.UnarchiveCheck(ctx, MustIDBase16(oneID))
+	if err != nil {
+		t.Fatalf("failed to unarchive check: %v", err)
+	}
+	want := checkCPU().(*check.Threshold)
+	want.UpdatedAt = fakeDate
+	if diff := cmp.Diff(c, want); diff != "" {
+		t.Errorf("check is different -got/+want\ndiff %s", diff)
+	}
+
+	_, err = s.UnarchiveCheck(ctx, MustIDBase16(oneID))
+	ErrorsEqual(t, err, &influxdb.Error{
+		Code: influxdb.EConflict,
+		Msg:  "check is not archived",
+	})
+}
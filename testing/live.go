@@ -0,0 +1,320 @@
+package testing
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/check"
+	"github.com/influxdata/influxdb/notification/endpoint"
+	"github.com/influxdata/influxdb/notification/rule"
+)
+
+// LiveFields are the services of a running server the live tests are run
+// against, usually HTTP clients, and the organization they create their
+// resources in.
+type LiveFields struct {
+	CheckService                influxdb.CheckService
+	NotificationEndpointService influxdb.NotificationEndpointService
+	NotificationRuleStore       influxdb.NotificationRuleStore
+	OrgID                       influxdb.ID
+	// Bucket is the name of a bucket of the organization the checks query.
+	Bucket string
+}
+
+// LiveCheckAPI tests the check, notification endpoint and notification rule
+// APIs of a running server, as an acceptance suite for the servers and proxies
+// implementing them. Unlike the other suites it can't populate the services,
+// the resources are created through the services with unique names and are
+// deleted by the tests.
+func LiveCheckAPI(f LiveFields, t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func(f LiveFields, t *testing.T)
+	}{
+		{
+			name: "Checks",
+			fn:   LiveChecks,
+		},
+		{
+			name: "NotificationEndpoints",
+			fn:   LiveNotificationEndpoints,
+		},
+		{
+			name: "NotificationRules",
+			fn:   LiveNotificationRules,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(f, t)
+		})
+	}
+}
+
+// liveName returns a name unlikely to be used by the resources of the organization.
+func liveName(name string) string {
+	return fmt.Sprintf("live %s %d", name, time.Now().UnixNano())
+}
+
+func liveDeadman(f LiveFields, name string) *check.Deadman {
+	return &check.Deadman{
+		Base: check.Base{
+			Name:   liveName(name),
+			OrgID:  f.OrgID,
+			Status: influxdb.Active,
+			Every:  influxdb.Duration{Duration: time.Minute},
+			Query: influxdb.DashboardQuery{
+				Text: fmt.Sprintf(`from(bucket: %q) |> range(start: -5m) |> filter(fn: (r) => r._measurement == "system")`, f.Bucket),
+			},
+			Tags: []notification.Tag{{Key: "team", Value: "ops"}},
+		},
+		TimeSince: 90,
+		Level:     notification.Critical,
+	}
+}
+
+// LiveChecks tests the lifecycle of a check.
+func LiveChecks(f LiveFields, t *testing.T) {
+	ctx := context.Background()
+	s := f.CheckService
+
+	c := liveDeadman(f, "heartbeat")
+	if err := s.CreateCheck(ctx, c, 0); err != nil {
+		t.Fatalf("failed to create check: %v", err)
+	}
+	defer s.DeleteCheck(ctx, c.ID)
+	if !c.ID.Valid() || c.OrgID != f.OrgID || c.CreatedAt.IsZero() {
+		t.Fatalf("expected the check to be created with an ID in org %s, got ID %s in %s created at %s", f.OrgID, c.ID, c.OrgID, c.CreatedAt)
+	}
+
+	found, err := s.FindCheckByID(ctx, c.ID)
+	if err != nil {
+		t.Fatalf("failed to find check: %v", err)
+	}
+	if d, ok := found.(*check.Deadman); !ok || d.Name != c.Name || d.TimeSince != c.TimeSince {
+		t.Errorf("expected to find check %s, got %v", c.Name, found)
+	}
+	if found, err = s.FindCheck(ctx, influxdb.CheckFilter{OrgID: &f.OrgID, Name: &c.Name}); err != nil || found.GetID() != c.ID {
+		t.Errorf("expected to find check %s by name, got %v", c.ID, err)
+	}
+	cs, _, err := s.FindChecks(ctx, influxdb.CheckFilter{OrgID: &f.OrgID, IDs: []*influxdb.ID{&c.ID}})
+	if err != nil {
+		t.Fatalf("failed to find checks: %v", err)
+	}
+	if len(cs) != 1 || cs[0].GetID() != c.ID {
+		t.Errorf("expected to find check %s by id, got %d checks", c.ID, len(cs))
+	}
+
+	dup := liveDeadman(f, "heartbeat")
+	dup.Name = c.Name
+	if err := s.CreateCheck(ctx, dup, 0); influxdb.ErrorCode(err) != influxdb.EConflict {
+		t.Errorf("expected a conflict creating a check with the same name, got %v", err)
+		s.DeleteCheck(ctx, dup.ID)
+	}
+	invalid := liveDeadman(f, "invalid")
+	invalid.Name = ""
+	if err := s.CreateCheck(ctx, invalid, 0); influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Errorf("expected a check without a name to be invalid, got %v", err)
+		s.DeleteCheck(ctx, invalid.ID)
+	}
+
+	name, desc := liveName("heartbeat"), "the heartbeat of the system"
+	patched, err := s.PatchCheck(ctx, c.ID, influxdb.CheckUpdate{Name: &name, Description: &desc})
+	if err != nil {
+		t.Fatalf("failed to patch check: %v", err)
+	}
+	if patched.GetName() != name || patched.GetDescription() != desc {
+		t.Errorf("expected the check to be patched, got name %s and description %s", patched.GetName(), patched.GetDescription())
+	}
+
+	upd := liveDeadman(f, "heartbeat")
+	upd.Name, upd.TimeSince = name, 120
+	updated, err := s.UpdateCheck(ctx, c.ID, upd)
+	if err != nil {
+		t.Fatalf("failed to update check: %v", err)
+	}
+	if d, ok := updated.(*check.Deadman); !ok || d.ID != c.ID || d.TimeSince != 120 {
+		t.Errorf("expected check %s to be updated, got %v", c.ID, updated)
+	}
+
+	if _, err := s.ArchiveCheck(ctx, c.ID); err != nil {
+		t.Fatalf("failed to archive check: %v", err)
+	}
+	if cs, _, err := s.FindChecks(ctx, influxdb.CheckFilter{OrgID: &f.OrgID, IDs: []*influxdb.ID{&c.ID}}); err != nil || len(cs) != 0 {
+		t.Errorf("expected the archived check to be hidden, got %d checks and %v", len(cs), err)
+	}
+	if cs, _, err := s.FindChecks(ctx, influxdb.CheckFilter{OrgID: &f.OrgID, IDs: []*influxdb.ID{&c.ID}, Archived: true}); err != nil || len(cs) != 1 {
+		t.Errorf("expected to find the archived check, got %d checks and %v", len(cs), err)
+	}
+	if _, err := s.UnarchiveCheck(ctx, c.ID); err != nil {
+		t.Fatalf("failed to unarchive check: %v", err)
+	}
+
+	if err := s.DeleteCheck(ctx, c.ID); err != nil {
+		t.Fatalf("failed to delete check: %v", err)
+	}
+	if _, err := s.FindCheckByID(ctx, c.ID); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Errorf("expected the deleted check to be not found, got %v", err)
+	}
+}
+
+func liveSlackEndpoint(f LiveFields) *endpoint.Slack {
+	return &endpoint.Slack{
+		Base: endpoint.Base{
+			Name:   liveName("slack"),
+			OrgID:  f.OrgID,
+			Status: influxdb.Active,
+		},
+		URL: "https://hooks.slack.com/services/live",
+	}
+}
+
+// LiveNotificationEndpoints tests the lifecycle of a notification endpoint.
+func LiveNotificationEndpoints(f LiveFields, t *testing.T) {
+	ctx := context.Background()
+	s := f.NotificationEndpointService
+
+	edp := liveSlackEndpoint(f)
+	if err := s.CreateNotificationEndpoint(ctx, edp, 0); err != nil {
+		t.Fatalf("failed to create notification endpoint: %v", err)
+	}
+	defer s.DeleteNotificationEndpoint(ctx, edp.ID)
+	if !edp.ID.Valid() || edp.OrgID != f.OrgID {
+		t.Fatalf("expected the notification endpoint to be created with an ID in org %s, got ID %s in %s", f.OrgID, edp.ID, edp.OrgID)
+	}
+
+	found, err := s.FindNotificationEndpointByID(ctx, edp.ID)
+	if err != nil {
+		t.Fatalf("failed to find notification endpoint: %v", err)
+	}
+	if sl, ok := found.(*endpoint.Slack); !ok || sl.Name != edp.Name || sl.URL != edp.URL {
+		t.Errorf("expected to find notification endpoint %s, got %v", edp.Name, found)
+	}
+	edps, _, err := s.FindNotificationEndpoints(ctx, influxdb.NotificationEndpointFilter{OrgID: &f.OrgID})
+	if err != nil {
+		t.Fatalf("failed to find notification endpoints: %v", err)
+	}
+	if !liveContains(edp.ID, len(edps), func(i int) influxdb.ID { return edps[i].GetID() }) {
+		t.Errorf("expected the notification endpoints of the organization to include %s", edp.ID)
+	}
+
+	desc, inactive := "the slack of the ops team", influxdb.Inactive
+	patched, err := s.PatchNotificationEndpoint(ctx, edp.ID, influxdb.NotificationEndpointUpdate{Description: &desc, Status: &inactive})
+	if err != nil {
+		t.Fatalf("failed to patch notification endpoint: %v", err)
+	}
+	if patched.GetDescription() != desc || patched.GetStatus() != inactive {
+		t.Errorf("expected the notification endpoint to be patched, got description %s and status %s", patched.GetDescription(), patched.GetStatus())
+	}
+
+	upd := liveSlackEndpoint(f)
+	upd.Name, upd.URL = edp.Name, "https://hooks.slack.com/services/updated"
+	updated, err := s.UpdateNotificationEndpoint(ctx, edp.ID, upd, 0)
+	if err != nil {
+		t.Fatalf("failed to update notification endpoint: %v", err)
+	}
+	if sl, ok := updated.(*endpoint.Slack); !ok || sl.ID != edp.ID || sl.URL != upd.URL {
+		t.Errorf("expected notification endpoint %s to be updated, got %v", edp.ID, updated)
+	}
+
+	if err := s.DeleteNotificationEndpoint(ctx, edp.ID); err != nil {
+		t.Fatalf("failed to delete notification endpoint: %v", err)
+	}
+	if _, err := s.FindNotificationEndpointByID(ctx, edp.ID); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Errorf("expected the deleted notification endpoint to be not found, got %v", err)
+	}
+}
+
+// LiveNotificationRules tests the lifecycle of a notification rule sending to a notification endpoint.
+func LiveNotificationRules(f LiveFields, t *testing.T) {
+	ctx := context.Background()
+	s := f.NotificationRuleStore
+
+	edp := liveSlackEndpoint(f)
+	if err := f.NotificationEndpointService.CreateNotificationEndpoint(ctx, edp, 0); err != nil {
+		t.Fatalf("failed to create notification endpoint: %v", err)
+	}
+	defer f.NotificationEndpointService.DeleteNotificationEndpoint(ctx, edp.ID)
+
+	newRule := func() *rule.Slack {
+		return &rule.Slack{
+			Base: rule.Base{
+				Name:       liveName("page ops"),
+				OrgID:      f.OrgID,
+				EndpointID: &edp.ID,
+				// the API only requires the authorization of a rule to be a valid ID.
+				AuthorizationID: edp.ID,
+				Status:          influxdb.Active,
+				Every:           influxdb.Duration{Duration: time.Minute},
+				TagRules: []notification.TagRule{
+					{Tag: notification.Tag{Key: "team", Value: "ops"}, Operator: notification.Equal},
+				},
+			},
+			Channel:         "#ops",
+			MessageTemplate: "${ r._message }",
+		}
+	}
+	nr := newRule()
+	if err := s.CreateNotificationRule(ctx, nr, 0); err != nil {
+		t.Fatalf("failed to create notification rule: %v", err)
+	}
+	defer s.DeleteNotificationRule(ctx, nr.ID)
+	if !nr.ID.Valid() || nr.OrgID != f.OrgID {
+		t.Fatalf("expected the notification rule to be created with an ID in org %s, got ID %s in %s", f.OrgID, nr.ID, nr.OrgID)
+	}
+
+	found, err := s.FindNotificationRuleByID(ctx, nr.ID)
+	if err != nil {
+		t.Fatalf("failed to find notification rule: %v", err)
+	}
+	if sl, ok := found.(*rule.Slack); !ok || sl.Name != nr.Name || sl.EndpointID == nil || *sl.EndpointID != edp.ID {
+		t.Errorf("expected to find notification rule %s sending to %s, got %v", nr.Name, edp.ID, found)
+	}
+	nrs, _, err := s.FindNotificationRules(ctx, influxdb.NotificationRuleFilter{OrgID: &f.OrgID})
+	if err != nil {
+		t.Fatalf("failed to find notification rules: %v", err)
+	}
+	if !liveContains(nr.ID, len(nrs), func(i int) influxdb.ID { return nrs[i].GetID() }) {
+		t.Errorf("expected the notification rules of the organization to include %s", nr.ID)
+	}
+
+	name := liveName("page ops")
+	patched, err := s.PatchNotificationRule(ctx, nr.ID, influxdb.NotificationRuleUpdate{Name: &name})
+	if err != nil {
+		t.Fatalf("failed to patch notification rule: %v", err)
+	}
+	if patched.GetName() != name {
+		t.Errorf("expected the notification rule to be renamed %s, got %s", name, patched.GetName())
+	}
+
+	upd := newRule()
+	upd.Name, upd.Channel = name, "#oncall"
+	updated, err := s.UpdateNotificationRule(ctx, nr.ID, upd, 0)
+	if err != nil {
+		t.Fatalf("failed to update notification rule: %v", err)
+	}
+	if sl, ok := updated.(*rule.Slack); !ok || sl.ID != nr.ID || sl.Channel != "#oncall" {
+		t.Errorf("expected notification rule %s to be updated, got %v", nr.ID, updated)
+	}
+
+	if err := s.DeleteNotificationRule(ctx, nr.ID); err != nil {
+		t.Fatalf("failed to delete notification rule: %v", err)
+	}
+	if _, err := s.FindNotificationRuleByID(ctx, nr.ID); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Errorf("expected the deleted notification rule to be not found, got %v", err)
+	}
+}
+
+// liveContains returns whether the n IDs returned by id include want.
+func liveContains(want influxdb.ID, n int, id func(i int) influxdb.ID) bool {
+	for i := 0; i < n; i++ {
+		if id(i) == want {
+			return true
+		}
+	}
+	return false
+}
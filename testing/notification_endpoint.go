@@ -0,0 +1,1011 @@
+package testing
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/notification/endpoint"
+)
+
+// NotificationEndpointFields includes prepopulated data for mapping tests.
+type NotificationEndpointFields struct {
+	IDGenerator           influxdb.IDGenerator
+	TimeGenerator         influxdb.TimeGenerator
+	NotificationEndpoints []influxdb.NotificationEndpoint
+	Orgs                  []*influxdb.Organization
+	UserResourceMappings  []*influxdb.UserResourceMapping
+	Secrets               []Secret
+}
+
+var notificationEndpointCmpOptions = cmp.Options{
+	cmp.Transformer("Sort", func(in []influxdb.NotificationEndpoint) []influxdb.NotificationEndpoint {
+		out := append([]influxdb.NotificationEndpoint(nil), in...)
+		sort.Slice(out, func(i, j int) bool {
+			return out[i].GetID() > out[j].GetID()
+		})
+		return out
+	}),
+}
+
+// NotificationEndpointService tests all the service functions.
+func NotificationEndpointService(
+	init func(NotificationEndpointFields, *testing.T) (influxdb.NotificationEndpointService, influxdb.SecretService, func()), t *testing.T,
+) {
+	tests := []struct {
+		name string
+		fn   func(init func(NotificationEndpointFields, *testing.T) (influxdb.NotificationEndpointService, influxdb.SecretService, func()),
+			t *testing.T)
+	}{
+		{
+			name: "CreateNotificationEndpoint",
+			fn:   CreateNotificationEndpoint,
+		},
+		{
+			name: "FindNotificationEndpointByID",
+			fn:   FindNotificationEndpointByID,
+		},
+		{
+			name: "FindNotificationEndpoints",
+			fn:   FindNotificationEndpoints,
+		},
+		{
+			name: "UpdateNotificationEndpoint",
+			fn:   UpdateNotificationEndpoint,
+		},
+		{
+			name: "PatchNotificationEndpoint",
+			fn:   PatchNotificationEndpoint,
+		},
+		{
+			name: "DeleteNotificationEndpoint",
+			fn:   DeleteNotificationEndpoint,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(init, t)
+		})
+	}
+}
+
+// CreateNotificationEndpoint testing.
+func CreateNotificationEndpoint(
+	init func(NotificationEndpointFields, *testing.T) (influxdb.NotificationEndpointService, influxdb.SecretService, func()),
+	t *testing.T,
+) {
+	type args struct {
+		userID               influxdb.ID
+		notificationEndpoint influxdb.NotificationEndpoint
+	}
+	type wants struct {
+		err                   error
+		notificationEndpoints []influxdb.NotificationEndpoint
+		userResourceMapping   []*influxdb.UserResourceMapping
+		secrets               map[string]string
+	}
+
+	tests := []struct {
+		name   string
+		fields NotificationEndpointFields
+		args   args
+		wants  wants
+	}{
+		{
+			name: "basic create notification endpoint",
+			fields: NotificationEndpointFields{
+				IDGenerator:   mock.NewIDGenerator(twoID, t),
+				TimeGenerator: fakeGenerator,
+				UserResourceMappings: []*influxdb.UserResourceMapping{
+					{
+						ResourceID:   MustIDBase16(oneID),
+						ResourceType: influxdb.NotificationEndpointResourceType,
+						UserID:       MustIDBase16(sixID),
+						UserType:     influxdb.Member,
+					},
+				},
+				NotificationEndpoints: []influxdb.NotificationEndpoint{
+					&endpoint.Slack{
+						Base: endpoint.Base{
+							ID:     MustIDBase16(oneID),
+							Name:   "name1",
+							OrgID:  MustIDBase16(fourID),
+							Status: influxdb.Active,
+							CRUDLog: influxdb.CRUDLog{
+								CreatedAt: timeGen1.Now(),
+								UpdatedAt: timeGen2.Now(),
+							},
+						},
+						URL: "https://hooks.slack.com/services/1",
+					},
+				},
+			},
+			args: args{
+				userID: MustIDBase16(sixID),
+				notificationEndpoint: &endpoint.Slack{
+					Base: endpoint.Base{
+						Name:        "name2",
+						Description: "desc2",
+						OrgID:       MustIDBase16(fourID),
+						Status:      influxdb.Active,
+					},
+					URL: "https://hooks.slack.com/services/2",
+					Token: influxdb.SecretField{
+						Value: strPtr("token-value"),
+					},
+				},
+			},
+			wants: wants{
+				notificationEndpoints: []influxdb.NotificationEndpoint{
+					&endpoint.Slack{
+						Base: endpoint.Base{
+							ID:     MustIDBase16(oneID),
+							Name:   "name1",
+							OrgID:  MustIDBase16(fourID),
+							Status: influxdb.Active,
+							CRUDLog: influxdb.CRUDLog{
+								CreatedAt: timeGen1.Now(),
+								UpdatedAt: timeGen2.Now(),
+							},
+						},
+						URL: "https://hooks.slack.com/services/1",
+					},
+					&endpoint.Slack{
+						Base: endpoint.Base{
+							ID:          MustIDBase16(twoID),
+							Name:        "name2",
+							Description: "desc2",
+							OrgID:       MustIDBase16(fourID),
+							Status:      influxdb.Active,
+							CRUDLog: influxdb.CRUDLog{
+								CreatedAt: fakeDate,
+								UpdatedAt: fakeDate,
+							},
+						},
+						URL: "https://hooks.slack.com/services/2",
+						Token: influxdb.SecretField{
+							Key: twoID + "-token",
+						},
+					},
+				},
+				userResourceMapping: []*influxdb.UserResourceMapping{
+					{
+						ResourceID:   MustIDBase16(oneID),
+						ResourceType: influxdb.NotificationEndpointResourceType,
+						UserID:       MustIDBase16(sixID),
+						UserType:     influxdb.Member,
+					},
+					{
+						ResourceID:   MustIDBase16(twoID),
+						ResourceType: influxdb.NotificationEndpointResourceType,
+						UserID:       MustIDBase16(sixID),
+						UserType:     influxdb.Owner,
+					},
+				},
+				secrets: map[string]string{
+					twoID + "-token": "token-value",
+				},
+			},
+		},
+		{
+			name: "names are unique within an org",
+			fields: NotificationEndpointFields{
+				IDGenerator:   mock.NewIDGenerator(twoID, t),
+				TimeGenerator: fakeGenerator,
+				UserResourceMappings: []*influxdb.UserResourceMapping{
+					{
+						ResourceID:   MustIDBase16(oneID),
+						ResourceType: influxdb.NotificationEndpointResourceType,
+						UserID:       MustIDBase16(sixID),
+						UserType:     influxdb.Member,
+					},
+				},
+				NotificationEndpoints: []influxdb.NotificationEndpoint{
+					&endpoint.Slack{
+						Base: endpoint.Base{
+							ID:     MustIDBase16(oneID),
+							Name:   "name1",
+							OrgID:  MustIDBase16(fourID),
+							Status: influxdb.Active,
+							CRUDLog: influxdb.CRUDLog{
+								CreatedAt: timeGen1.Now(),
+								UpdatedAt: timeGen2.Now(),
+							},
+						},
+						URL: "https://hooks.slack.com/services/1",
+					},
+				},
+			},
+			args: args{
+				userID: MustIDBase16(sixID),
+				notificationEndpoint: &endpoint.Slack{
+					Base: endpoint.Base{
+						Name:   "name1",
+						OrgID:  MustIDBase16(fourID),
+						Status: influxdb.Active,
+					},
+					URL: "https://hooks.slack.com/services/2",
+				},
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Code: influxdb.EConflict,
+					Msg:  "notification endpoint with name name1 already exists",
+				},
+				notificationEndpoints: []influxdb.NotificationEndpoint{
+					&endpoint.Slack{
+						Base: endpoint.Base{
+							ID:     MustIDBase16(oneID),
+							Name:   "name1",
+							OrgID:  MustIDBase16(fourID),
+							Status: influxdb.Active,
+							CRUDLog: influxdb.CRUDLog{
+								CreatedAt: timeGen1.Now(),
+								UpdatedAt: timeGen2.Now(),
+							},
+						},
+						URL: "https://hooks.slack.com/services/1",
+					},
+				},
+				userResourceMapping: []*influxdb.UserResourceMapping{
+					{
+						ResourceID:   MustIDBase16(oneID),
+						ResourceType: influxdb.NotificationEndpointResourceType,
+						UserID:       MustIDBase16(sixID),
+						UserType:     influxdb.Member,
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, secretSVC, done := init(tt.fields, t)
+			defer done()
+			ctx := context.Background()
+			err := s.CreateNotificationEndpoint(ctx, tt.args.notificationEndpoint, tt.args.userID)
+			ErrorsEqual(t, err, tt.wants.err)
+			if tt.wants.err == nil && !tt.args.notificationEndpoint.GetID().Valid() {
+				t.Fatalf("notification endpoint ID not set from CreateNotificationEndpoint")
+			}
+
+			urmFilter := influxdb.UserResourceMappingFilter{
+				UserID:       tt.args.userID,
+				ResourceType: influxdb.NotificationEndpointResourceType,
+			}
+
+			filter := influxdb.NotificationEndpointFilter{
+				UserResourceMappingFilter: urmFilter,
+			}
+			edps, _, err := s.FindNotificationEndpoints(ctx, filter)
+			if err != nil {
+				t.Fatalf("failed to retrieve notification endpoints: %v", err)
+			}
+			if diff := cmp.Diff(edps, tt.wants.notificationEndpoints, notificationEndpointCmpOptions...); diff != "" {
+				t.Errorf("notificationEndpoints are different -got/+want\ndiff %s", diff)
+			}
+
+			urms, _, err := s.FindUserResourceMappings(ctx, urmFilter)
+			if err != nil {
+				t.Fatalf("failed to retrieve user resource mappings: %v", err)
+			}
+			if diff := cmp.Diff(urms, tt.wants.userResourceMapping, userResourceMappingCmpOptions...); diff != "" {
+				t.Errorf("user resource mappings are different -got/+want\ndiff %s", diff)
+			}
+
+			for k, v := range tt.wants.secrets {
+				got, err := secretSVC.LoadSecret(ctx, tt.args.notificationEndpoint.GetOrgID(), k)
+				if err != nil {
+					t.Fatalf("failed to load secret %q: %v", k, err)
+				}
+				if got != v {
+					t.Errorf("secret %q is different, got %q, want %q", k, got, v)
+				}
+			}
+		})
+	}
+}
+
+// FindNotificationEndpointByID testing.
+func FindNotificationEndpointByID(
+	init func(NotificationEndpointFields, *testing.T) (influxdb.NotificationEndpointService, influxdb.SecretService, func()),
+	t *testing.T,
+) {
+	type args struct {
+		id influxdb.ID
+	}
+	type wants struct {
+		err                  error
+		notificationEndpoint influxdb.NotificationEndpoint
+	}
+
+	fields := NotificationEndpointFields{
+		NotificationEndpoints: []influxdb.NotificationEndpoint{
+			&endpoint.Slack{
+				Base: endpoint.Base{
+					ID:     MustIDBase16(oneID),
+					Name:   "name1",
+					OrgID:  MustIDBase16(fourID),
+					Status: influxdb.Active,
+					CRUDLog: influxdb.CRUDLog{
+						CreatedAt: timeGen1.Now(),
+						UpdatedAt: timeGen2.Now(),
+					},
+				},
+				URL: "https://hooks.slack.com/services/1",
+			},
+			&endpoint.Slack{
+				Base: endpoint.Base{
+					ID:     MustIDBase16(twoID),
+					Name:   "name2",
+					OrgID:  MustIDBase16(fourID),
+					Status: influxdb.Inactive,
+					CRUDLog: influxdb.CRUDLog{
+						CreatedAt: timeGen1.Now(),
+						UpdatedAt: timeGen2.Now(),
+					},
+				},
+				URL: "https://hooks.slack.com/services/2",
+				Token: influxdb.SecretField{
+					Key: twoID + "-token",
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		fields NotificationEndpointFields
+		args   args
+		wants  wants
+	}{
+		{
+			name:   "basic find notification endpoint by id",
+			fields: fields,
+			args: args{
+				id: MustIDBase16(twoID),
+			},
+			wants: wants{
+				notificationEndpoint: fields.NotificationEndpoints[1],
+			},
+		},
+		{
+			name:   "find notification endpoint by id not exists",
+			fields: fields,
+			args: args{
+				id: MustIDBase16(threeID),
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Code: influxdb.ENotFound,
+					Msg:  "notification endpoint not found",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _, done := init(tt.fields, t)
+			defer done()
+			ctx := context.Background()
+
+			edp, err := s.FindNotificationEndpointByID(ctx, tt.args.id)
+			ErrorsEqual(t, err, tt.wants.err)
+			if diff := cmp.Diff(edp, tt.wants.notificationEndpoint); diff != "" {
+				t.Errorf("notification endpoint is different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+// FindNotificationEndpoints testing.
+func FindNotificationEndpoints(
+	init func(NotificationEndpointFields, *testing.T) (influxdb.NotificationEndpointService, influxdb.SecretService, func()),
+	t *testing.T,
+) {
+	type args struct {
+		filter influxdb.NotificationEndpointFilter
+		opts   influxdb.FindOptions
+	}
+	type wants struct {
+		notificationEndpoints []influxdb.NotificationEndpoint
+		err                   error
+	}
+
+	slack1 := &endpoint.Slack{
+		Base: endpoint.Base{
+			ID:     MustIDBase16(oneID),
+			Name:   "name1",
+			OrgID:  MustIDBase16(fourID),
+			Status: influxdb.Active,
+			CRUDLog: influxdb.CRUDLog{
+				CreatedAt: timeGen1.Now(),
+				UpdatedAt: timeGen2.Now(),
+			},
+		},
+		URL: "https://hooks.slack.com/services/1",
+	}
+	slack2 := &endpoint.Slack{
+		Base: endpoint.Base{
+			ID:     MustIDBase16(twoID),
+			Name:   "name2",
+			OrgID:  MustIDBase16(fourID),
+			Status: influxdb.Active,
+			CRUDLog: influxdb.CRUDLog{
+				CreatedAt: timeGen1.Now(),
+				UpdatedAt: timeGen2.Now(),
+			},
+		},
+		URL: "https://hooks.slack.com/services/2",
+	}
+	slack3 := &endpoint.Slack{
+		Base: endpoint.Base{
+			ID:     MustIDBase16(threeID),
+			Name:   "name3",
+			OrgID:  MustIDBase16(fiveID),
+			Status: influxdb.Active,
+			CRUDLog: influxdb.CRUDLog{
+				CreatedAt: timeGen1.Now(),
+				UpdatedAt: timeGen2.Now(),
+			},
+		},
+		URL: "https://hooks.slack.com/services/3",
+	}
+
+	fields := NotificationEndpointFields{
+		Orgs: []*influxdb.Organization{
+			{
+				ID:   MustIDBase16(fourID),
+				Name: "org4",
+			},
+			{
+				ID:   MustIDBase16(fiveID),
+				Name: "org5",
+			},
+		},
+		UserResourceMappings: []*influxdb.UserResourceMapping{
+			{
+				ResourceID:   MustIDBase16(oneID),
+				ResourceType: influxdb.NotificationEndpointResourceType,
+				UserID:       MustIDBase16(sixID),
+				UserType:     influxdb.Owner,
+			},
+			{
+				ResourceID:   MustIDBase16(twoID),
+				ResourceType: influxdb.NotificationEndpointResourceType,
+				UserID:       MustIDBase16(sixID),
+				UserType:     influxdb.Member,
+			},
+			{
+				ResourceID:   MustIDBase16(threeID),
+				ResourceType: influxdb.NotificationEndpointResourceType,
+				UserID:       MustIDBase16(sixID),
+				UserType:     influxdb.Owner,
+			},
+		},
+		NotificationEndpoints: []influxdb.NotificationEndpoint{slack1, slack2, slack3},
+	}
+
+	tests := []struct {
+		name   string
+		fields NotificationEndpointFields
+		args   args
+		wants  wants
+	}{
+		{
+			name:   "find all notification endpoints of a user",
+			fields: fields,
+			args: args{
+				filter: influxdb.NotificationEndpointFilter{
+					UserResourceMappingFilter: influxdb.UserResourceMappingFilter{
+						UserID:       MustIDBase16(sixID),
+						ResourceType: influxdb.NotificationEndpointResourceType,
+					},
+				},
+			},
+			wants: wants{
+				notificationEndpoints: []influxdb.NotificationEndpoint{slack1, slack2, slack3},
+			},
+		},
+		{
+			name:   "filter by organization id",
+			fields: fields,
+			args: args{
+				filter: influxdb.NotificationEndpointFilter{
+					OrgID: IDPtr(MustIDBase16(fourID)),
+					UserResourceMappingFilter: influxdb.UserResourceMappingFilter{
+						UserID:       MustIDBase16(sixID),
+						ResourceType: influxdb.NotificationEndpointResourceType,
+					},
+				},
+			},
+			wants: wants{
+				notificationEndpoints: []influxdb.NotificationEndpoint{slack1, slack2},
+			},
+		},
+		{
+			name:   "filter by organization name",
+			fields: fields,
+			args: args{
+				filter: influxdb.NotificationEndpointFilter{
+					Organization: strPtr("org5"),
+					UserResourceMappingFilter: influxdb.UserResourceMappingFilter{
+						UserID:       MustIDBase16(sixID),
+						ResourceType: influxdb.NotificationEndpointResourceType,
+					},
+				},
+			},
+			wants: wants{
+				notificationEndpoints: []influxdb.NotificationEndpoint{slack3},
+			},
+		},
+		{
+			name:   "find owners only",
+			fields: fields,
+			args: args{
+				filter: influxdb.NotificationEndpointFilter{
+					UserResourceMappingFilter: influxdb.UserResourceMappingFilter{
+						UserID:       MustIDBase16(sixID),
+						UserType:     influxdb.Owner,
+						ResourceType: influxdb.NotificationEndpointResourceType,
+					},
+				},
+			},
+			wants: wants{
+				notificationEndpoints: []influxdb.NotificationEndpoint{slack1, slack3},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _, done := init(tt.fields, t)
+			defer done()
+			ctx := context.Background()
+
+			edps, n, err := s.FindNotificationEndpoints(ctx, tt.args.filter, tt.args.opts)
+			ErrorsEqual(t, err, tt.wants.err)
+			if n != len(tt.wants.notificationEndpoints) {
+				t.Fatalf("notification endpoints length is different got %d, want %d", n, len(tt.wants.notificationEndpoints))
+			}
+			if diff := cmp.Diff(edps, tt.wants.notificationEndpoints, notificationEndpointCmpOptions...); diff != "" {
+				t.Errorf("notification endpoints are different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+// UpdateNotificationEndpoint testing.
+func UpdateNotificationEndpoint(
+	init func(NotificationEndpointFields, *testing.T) (influxdb.NotificationEndpointService, influxdb.SecretService, func()),
+	t *testing.T,
+) {
+	type args struct {
+		userID               influxdb.ID
+		id                   influxdb.ID
+		notificationEndpoint influxdb.NotificationEndpoint
+	}
+	type wants struct {
+		notificationEndpoint influxdb.NotificationEndpoint
+		secrets              map[string]string
+		err                  error
+	}
+
+	fields := NotificationEndpointFields{
+		TimeGenerator: fakeGenerator,
+		UserResourceMappings: []*influxdb.UserResourceMapping{
+			{
+				ResourceID:   MustIDBase16(oneID),
+				UserID:       MustIDBase16(sixID),
+				UserType:     influxdb.Owner,
+				ResourceType: influxdb.NotificationEndpointResourceType,
+			},
+		},
+		NotificationEndpoints: []influxdb.NotificationEndpoint{
+			&endpoint.Slack{
+				Base: endpoint.Base{
+					ID:     MustIDBase16(oneID),
+					Name:   "name1",
+					OrgID:  MustIDBase16(fourID),
+					Status: influxdb.Active,
+					CRUDLog: influxdb.CRUDLog{
+						CreatedAt: timeGen1.Now(),
+						UpdatedAt: timeGen2.Now(),
+					},
+				},
+				URL: "https://hooks.slack.com/services/1",
+			},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		fields NotificationEndpointFields
+		args   args
+		wants  wants
+	}{
+		{
+			name:   "can't find the id",
+			fields: fields,
+			args: args{
+				userID: MustIDBase16(sixID),
+				id:     MustIDBase16(fourID),
+				notificationEndpoint: &endpoint.Slack{
+					Base: endpoint.Base{
+						Name:   "name2",
+						OrgID:  MustIDBase16(fourID),
+						Status: influxdb.Inactive,
+					},
+					URL: "https://hooks.slack.com/services/2",
+				},
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Code: influxdb.ENotFound,
+					Msg:  "notification endpoint not found",
+				},
+			},
+		},
+		{
+			name:   "regular update",
+			fields: fields,
+			args: args{
+				userID: MustIDBase16(sixID),
+				id:     MustIDBase16(oneID),
+				notificationEndpoint: &endpoint.Slack{
+					Base: endpoint.Base{
+						Name:   "name2",
+						OrgID:  MustIDBase16(fiveID),
+						Status: influxdb.Inactive,
+					},
+					URL: "https://hooks.slack.com/services/2",
+					Token: influxdb.SecretField{
+						Value: strPtr("token-value"),
+					},
+				},
+			},
+			wants: wants{
+				notificationEndpoint: &endpoint.Slack{
+					Base: endpoint.Base{
+						ID:     MustIDBase16(oneID),
+						Name:   "name2",
+						OrgID:  MustIDBase16(fourID),
+						Status: influxdb.Inactive,
+						CRUDLog: influxdb.CRUDLog{
+							CreatedAt: timeGen1.Now(),
+							UpdatedAt: fakeDate,
+						},
+					},
+					URL: "https://hooks.slack.com/services/2",
+					Token: influxdb.SecretField{
+						Key:   oneID + "-token",
+						Value: strPtr("token-value"),
+					},
+				},
+				secrets: map[string]string{
+					oneID + "-token": "token-value",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, secretSVC, done := init(tt.fields, t)
+			defer done()
+			ctx := context.Background()
+
+			edp, err := s.UpdateNotificationEndpoint(ctx, tt.args.id,
+				tt.args.notificationEndpoint, tt.args.userID)
+			ErrorsEqual(t, err, tt.wants.err)
+			if diff := cmp.Diff(edp, tt.wants.notificationEndpoint); tt.wants.err == nil && diff != "" {
+				t.Errorf("notification endpoint is different -got/+want\ndiff %s", diff)
+			}
+
+			for k, v := range tt.wants.secrets {
+				got, err := secretSVC.LoadSecret(ctx, edp.GetOrgID(), k)
+				if err != nil {
+					t.Fatalf("failed to load secret %q: %v", k, err)
+				}
+				if got != v {
+					t.Errorf("secret %q is different, got %q, want %q", k, got, v)
+				}
+			}
+		})
+	}
+}
+
+// PatchNotificationEndpoint testing.
+func PatchNotificationEndpoint(
+	init func(NotificationEndpointFields, *testing.T) (influxdb.NotificationEndpointService, influxdb.SecretService, func()),
+	t *testing.T,
+) {
+	name2 := "name2"
+	status2 := influxdb.Inactive
+
+	type args struct {
+		id  influxdb.ID
+		upd influxdb.NotificationEndpointUpdate
+	}
+	type wants struct {
+		notificationEndpoint influxdb.NotificationEndpoint
+		err                  error
+	}
+
+	fields := NotificationEndpointFields{
+		TimeGenerator: fakeGenerator,
+		NotificationEndpoints: []influxdb.NotificationEndpoint{
+			&endpoint.Slack{
+				Base: endpoint.Base{
+					ID:     MustIDBase16(oneID),
+					Name:   "name1",
+					OrgID:  MustIDBase16(fourID),
+					Status: influxdb.Active,
+					CRUDLog: influxdb.CRUDLog{
+						CreatedAt: timeGen1.Now(),
+						UpdatedAt: timeGen2.Now(),
+					},
+				},
+				URL: "https://hooks.slack.com/services/1",
+			},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		fields NotificationEndpointFields
+		args   args
+		wants  wants
+	}{
+		{
+			name:   "can't find the id",
+			fields: fields,
+			args: args{
+				id: MustIDBase16(fourID),
+				upd: influxdb.NotificationEndpointUpdate{
+					Name:   &name2,
+					Status: &status2,
+				},
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Code: influxdb.ENotFound,
+					Msg:  "notification endpoint not found",
+				},
+			},
+		},
+		{
+			name:   "regular patch",
+			fields: fields,
+			args: args{
+				id: MustIDBase16(oneID),
+				upd: influxdb.NotificationEndpointUpdate{
+					Name:   &name2,
+					Status: &status2,
+				},
+			},
+			wants: wants{
+				notificationEndpoint: &endpoint.Slack{
+					Base: endpoint.Base{
+						ID:     MustIDBase16(oneID),
+						Name:   name2,
+						OrgID:  MustIDBase16(fourID),
+						Status: status2,
+						CRUDLog: influxdb.CRUDLog{
+							CreatedAt: timeGen1.Now(),
+							UpdatedAt: fakeDate,
+						},
+					},
+					URL: "https://hooks.slack.com/services/1",
+				},
+			},
+		},
+		{
+			name: "names are unique within an org",
+			fields: NotificationEndpointFields{
+				TimeGenerator: fakeGenerator,
+				NotificationEndpoints: append(fields.NotificationEndpoints, &endpoint.Slack{
+					Base: endpoint.Base{
+						ID:     MustIDBase16(twoID),
+						Name:   name2,
+						OrgID:  MustIDBase16(fourID),
+						Status: influxdb.Active,
+					},
+					URL: "https://hooks.slack.com/services/2",
+				}),
+			},
+			args: args{
+				id: MustIDBase16(oneID),
+				upd: influxdb.NotificationEndpointUpdate{
+					Name: &name2,
+				},
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Code: influxdb.EConflict,
+					Msg:  "notification endpoint with name name2 already exists",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _, done := init(tt.fields, t)
+			defer done()
+			ctx := context.Background()
+
+			edp, err := s.PatchNotificationEndpoint(ctx, tt.args.id, tt.args.upd)
+			ErrorsEqual(t, err, tt.wants.err)
+			if diff := cmp.Diff(edp, tt.wants.notificationEndpoint); tt.wants.err == nil && diff != "" {
+				t.Errorf("notification endpoint is different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+// DeleteNotificationEndpoint testing.
+func DeleteNotificationEndpoint(
+	init func(NotificationEndpointFields, *testing.T) (influxdb.NotificationEndpointService, influxdb.SecretService, func()),
+	t *testing.T,
+) {
+	type args struct {
+		id     influxdb.ID
+		userID influxdb.ID
+	}
+	type wants struct {
+		notificationEndpoints []influxdb.NotificationEndpoint
+		userResourceMappings  []*influxdb.UserResourceMapping
+		secretKeys            []string
+		err                   error
+	}
+
+	slack1 := &endpoint.Slack{
+		Base: endpoint.Base{
+			ID:     MustIDBase16(oneID),
+			Name:   "name1",
+			OrgID:  MustIDBase16(fourID),
+			Status: influxdb.Active,
+			CRUDLog: influxdb.CRUDLog{
+				CreatedAt: timeGen1.Now(),
+				UpdatedAt: timeGen2.Now(),
+			},
+		},
+		URL: "https://hooks.slack.com/services/1",
+		Token: influxdb.SecretField{
+			Key: oneID + "-token",
+		},
+	}
+	slack2 := &endpoint.Slack{
+		Base: endpoint.Base{
+			ID:     MustIDBase16(twoID),
+			Name:   "name2",
+			OrgID:  MustIDBase16(fourID),
+			Status: influxdb.Active,
+			CRUDLog: influxdb.CRUDLog{
+				CreatedAt: timeGen1.Now(),
+				UpdatedAt: timeGen2.Now(),
+			},
+		},
+		URL: "https://hooks.slack.com/services/2",
+		Token: influxdb.SecretField{
+			Key: twoID + "-token",
+		},
+	}
+	urm1 := &influxdb.UserResourceMapping{
+		ResourceID:   MustIDBase16(oneID),
+		UserID:       MustIDBase16(sixID),
+		UserType:     influxdb.Owner,
+		ResourceType: influxdb.NotificationEndpointResourceType,
+	}
+	urm2 := &influxdb.UserResourceMapping{
+		ResourceID:   MustIDBase16(twoID),
+		UserID:       MustIDBase16(sixID),
+		UserType:     influxdb.Member,
+		ResourceType: influxdb.NotificationEndpointResourceType,
+	}
+
+	fields := NotificationEndpointFields{
+		UserResourceMappings:  []*influxdb.UserResourceMapping{urm1, urm2},
+		NotificationEndpoints: []influxdb.NotificationEndpoint{slack1, slack2},
+		Secrets: []Secret{
+			{
+				OrganizationID: MustIDBase16(fourID),
+				Env: map[string]string{
+					oneID + "-token": "token1",
+					twoID + "-token": "token2",
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		fields NotificationEndpointFields
+		args   args
+		wants  wants
+	}{
+		{
+			name:   "bad id",
+			fields: fields,
+			args: args{
+				id:     MustIDBase16(fiveID),
+				userID: MustIDBase16(sixID),
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Code: influxdb.ENotFound,
+					Msg:  "notification endpoint not found",
+				},
+				notificationEndpoints: []influxdb.NotificationEndpoint{slack1, slack2},
+				userResourceMappings:  []*influxdb.UserResourceMapping{urm1, urm2},
+				secretKeys:            []string{oneID + "-token", twoID + "-token"},
+			},
+		},
+		{
+			name:   "regular delete",
+			fields: fields,
+			args: args{
+				id:     MustIDBase16(twoID),
+				userID: MustIDBase16(sixID),
+			},
+			wants: wants{
+				notificationEndpoints: []influxdb.NotificationEndpoint{slack1},
+				userResourceMappings:  []*influxdb.UserResourceMapping{urm1},
+				secretKeys:            []string{oneID + "-token"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, secretSVC, done := init(tt.fields, t)
+			defer done()
+			ctx := context.Background()
+			err := s.DeleteNotificationEndpoint(ctx, tt.args.id)
+			ErrorsEqual(t, err, tt.wants.err)
+
+			filter := influxdb.NotificationEndpointFilter{
+				UserResourceMappingFilter: influxdb.UserResourceMappingFilter{
+					UserID:       tt.args.userID,
+					ResourceType: influxdb.NotificationEndpointResourceType,
+				},
+			}
+			edps, n, err := s.FindNotificationEndpoints(ctx, filter)
+			if err != nil {
+				t.Fatalf("failed to retrieve notification endpoints: %v", err)
+			}
+			if n != len(tt.wants.notificationEndpoints) {
+				t.Fatalf("notification endpoints length is different got %d, want %d", n, len(tt.wants.notificationEndpoints))
+			}
+			if diff := cmp.Diff(edps, tt.wants.notificationEndpoints, notificationEndpointCmpOptions...); diff != "" {
+				t.Errorf("notification endpoints are different -got/+want\ndiff %s", diff)
+			}
+
+			urms, _, err := s.FindUserResourceMappings(ctx, influxdb.UserResourceMappingFilter{
+				UserID:       tt.args.userID,
+				ResourceType: influxdb.NotificationEndpointResourceType,
+			})
+			if err != nil {
+				t.Fatalf("failed to retrieve user resource mappings: %v", err)
+			}
+			if diff := cmp.Diff(urms, tt.wants.userResourceMappings, userResourceMappingCmpOptions...); diff != "" {
+				t.Errorf("user resource mappings are different -got/+want\ndiff %s", diff)
+			}
+
+			keys, err := secretSVC.GetSecretKeys(ctx, MustIDBase16(fourID))
+			if err != nil {
+				t.Fatalf("failed to retrieve secret keys: %v", err)
+			}
+			sort.Strings(keys)
+			if diff := cmp.Diff(keys, tt.wants.secretKeys); diff != "" {
+				t.Errorf("secret keys are different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,362 @@
+package testing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification/endpoint"
+)
+
+// NotificationPreferencesFields includes prepopulated data for mapping tests.
+type NotificationPreferencesFields struct {
+	TimeGenerator           influxdb.TimeGenerator
+	Users                   []*influxdb.User
+	NotificationEndpoints   []influxdb.NotificationEndpoint
+	NotificationPreferences []*influxdb.NotificationPreferences
+}
+
+// NotificationPreferencesService tests all the service functions.
+func NotificationPreferencesService(
+	init func(NotificationPreferencesFields, *testing.T) (influxdb.NotificationPreferencesService, func()), t *testing.T,
+) {
+	tests := []struct {
+		name string
+		fn   func(init func(NotificationPreferencesFields, *testing.T) (influxdb.NotificationPreferencesService, func()),
+			t *testing.T)
+	}{
+		{
+			name: "PutNotificationPreferences",
+			fn:   PutNotificationPreferences,
+		},
+		{
+			name: "FindNotificationPreferences",
+			fn:   FindNotificationPreferences,
+		},
+		{
+			name: "DeleteNotificationPreferences",
+			fn:   DeleteNotificationPreferences,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(init, t)
+		})
+	}
+}
+
+func notificationPreferencesUser() *influxdb.User {
+	return &influxdb.User{
+		ID:   MustIDBase16(oneID),
+		Name: "user1",
+	}
+}
+
+func notificationPreferencesEndpoint() influxdb.NotificationEndpoint {
+	return &endpoint.Slack{
+		Base: endpoint.Base{
+			ID:     MustIDBase16(twoID),
+			Name:   "dm",
+			OrgID:  MustIDBase16(fourID),
+			UserID: idPtr(MustIDBase16(oneID)),
+			Status: influxdb.Active,
+			CRUDLog: influxdb.CRUDLog{
+				CreatedAt: timeGen1.Now(),
+				UpdatedAt: timeGen1.Now(),
+			},
+		},
+		URL: "https://hooks.slack.com/services/1",
+	}
+}
+
+func notificationPreferencesNight() *influxdb.NotificationPreferences {
+	return &influxdb.NotificationPreferences{
+		UserID: MustIDBase16(oneID),
+		QuietHours: &influxdb.QuietHours{
+			Start:  "22:00",
+			End:    "07:00",
+			Action: influxdb.QuietHoursDefer,
+		},
+		CRUDLog: influxdb.CRUDLog{
+			CreatedAt: timeGen1.Now(),
+			UpdatedAt: timeGen1.Now(),
+		},
+	}
+}
+
+// PutNotificationPreferences testing.
+func PutNotificationPreferences(
+	init func(NotificationPreferencesFields, *testing.T) (influxdb.NotificationPreferencesService, func()),
+	t *testing.T,
+) {
+	type args struct {
+		preferences *influxdb.NotificationPreferences
+	}
+	type wants struct {
+		err         error
+		preferences *influxdb.NotificationPreferences
+	}
+
+	tests := []struct {
+		name   string
+		fields NotificationPreferencesFields
+		args   args
+		wants  wants
+	}{
+		{
+			name: "create notification preferences",
+			fields: NotificationPreferencesFields{
+				TimeGenerator:         fakeGenerator,
+				Users:                 []*influxdb.User{notificationPreferencesUser()},
+				NotificationEndpoints: []influxdb.NotificationEndpoint{notificationPreferencesEndpoint()},
+			},
+			args: args{
+				preferences: &influxdb.NotificationPreferences{
+					UserID:              MustIDBase16(oneID),
+					PreferredEndpointID: idPtr(MustIDBase16(twoID)),
+					MinLevel:            "WARN",
+				},
+			},
+			wants: wants{
+				preferences: &influxdb.NotificationPreferences{
+					UserID:              MustIDBase16(oneID),
+					PreferredEndpointID: idPtr(MustIDBase16(twoID)),
+					MinLevel:            "WARN",
+					CRUDLog: influxdb.CRUDLog{
+						CreatedAt: fakeDate,
+						UpdatedAt: fakeDate,
+					},
+				},
+			},
+		},
+		{
+			name: "replace notification preferences",
+			fields: NotificationPreferencesFields{
+				TimeGenerator:           fakeGenerator,
+				Users:                   []*influxdb.User{notificationPreferencesUser()},
+				NotificationPreferences: []*influxdb.NotificationPreferences{notificationPreferencesNight()},
+			},
+			args: args{
+				preferences: &influxdb.NotificationPreferences{
+					UserID:   MustIDBase16(oneID),
+					MinLevel: "CRIT",
+				},
+			},
+			wants: wants{
+				preferences: &influxdb.NotificationPreferences{
+					UserID:   MustIDBase16(oneID),
+					MinLevel: "CRIT",
+					CRUDLog: influxdb.CRUDLog{
+						CreatedAt: timeGen1.Now(),
+						UpdatedAt: fakeDate,
+					},
+				},
+			},
+		},
+		{
+			name: "user not found",
+			fields: NotificationPreferencesFields{
+				TimeGenerator: fakeGenerator,
+			},
+			args: args{
+				preferences: &influxdb.NotificationPreferences{
+					UserID:   MustIDBase16(oneID),
+					MinLevel: "CRIT",
+				},
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Code: influxdb.ENotFound,
+					Msg:  "user not found",
+				},
+			},
+		},
+		{
+			name: "preferred endpoint not found",
+			fields: NotificationPreferencesFields{
+				TimeGenerator: fakeGenerator,
+				Users:         []*influxdb.User{notificationPreferencesUser()},
+			},
+			args: args{
+				preferences: &influxdb.NotificationPreferences{
+					UserID:              MustIDBase16(oneID),
+					PreferredEndpointID: idPtr(MustIDBase16(twoID)),
+				},
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Code: influxdb.EInvalid,
+					Msg:  "preferred notification endpoint not found",
+				},
+			},
+		},
+		{
+			name: "invalid quiet hours",
+			fields: NotificationPreferencesFields{
+				TimeGenerator: fakeGenerator,
+				Users:         []*influxdb.User{notificationPreferencesUser()},
+			},
+			args: args{
+				preferences: &influxdb.NotificationPreferences{
+					UserID: MustIDBase16(oneID),
+					QuietHours: &influxdb.QuietHours{
+						Start:  "22:00",
+						End:    "07:00",
+						Action: "snooze",
+					},
+				},
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Code: influxdb.EInvalid,
+					Msg:  "quiet hours action must be defer or suppress",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(tt.fields, t)
+			defer done()
+			ctx := context.Background()
+			err := s.PutNotificationPreferences(ctx, tt.args.preferences)
+			ErrorsEqual(t, err, tt.wants.err)
+			if tt.wants.err != nil {
+				return
+			}
+
+			p, err := s.FindNotificationPreferences(ctx, tt.args.preferences.UserID)
+			if err != nil {
+				t.Fatalf("failed to retrieve notification preferences: %v", err)
+			}
+			if diff := cmp.Diff(p, tt.wants.preferences); diff != "" {
+				t.Errorf("notification preferences are different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+// FindNotificationPreferences testing.
+func FindNotificationPreferences(
+	init func(NotificationPreferencesFields, *testing.T) (influxdb.NotificationPreferencesService, func()),
+	t *testing.T,
+) {
+	type args struct {
+		userID influxdb.ID
+	}
+	type wants struct {
+		err         error
+		preferences *influxdb.NotificationPreferences
+	}
+
+	tests := []struct {
+		name   string
+		fields NotificationPreferencesFields
+		args   args
+		wants  wants
+	}{
+		{
+			name: "find notification preferences",
+			fields: NotificationPreferencesFields{
+				Users:                   []*influxdb.User{notificationPreferencesUser()},
+				NotificationPreferences: []*influxdb.NotificationPreferences{notificationPreferencesNight()},
+			},
+			args: args{
+				userID: MustIDBase16(oneID),
+			},
+			wants: wants{
+				preferences: notificationPreferencesNight(),
+			},
+		},
+		{
+			name: "user without notification preferences",
+			fields: NotificationPreferencesFields{
+				Users: []*influxdb.User{notificationPreferencesUser()},
+			},
+			args: args{
+				userID: MustIDBase16(oneID),
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Code: influxdb.ENotFound,
+					Msg:  "notification preferences not found",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(tt.fields, t)
+			defer done()
+			ctx := context.Background()
+			p, err := s.FindNotificationPreferences(ctx, tt.args.userID)
+			ErrorsEqual(t, err, tt.wants.err)
+			if diff := cmp.Diff(p, tt.wants.preferences); diff != "" {
+				t.Errorf("notification preferences are different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+// DeleteNotificationPreferences testing.
+func DeleteNotificationPreferences(
+	init func(NotificationPreferencesFields, *testing.T) (influxdb.NotificationPreferencesService, func()),
+	t *testing.T,
+) {
+	type args struct {
+		userID influxdb.ID
+	}
+	type wants struct {
+		err error
+	}
+
+	tests := []struct {
+		name   string
+		fields NotificationPreferencesFields
+		args   args
+		wants  wants
+	}{
+		{
+			name: "delete notification preferences",
+			fields: NotificationPreferencesFields{
+				Users:                   []*influxdb.User{notificationPreferencesUser()},
+				NotificationPreferences: []*influxdb.NotificationPreferences{notificationPreferencesNight()},
+			},
+			args: args{
+				userID: MustIDBase16(oneID),
+			},
+		},
+		{
+			name: "user without notification preferences",
+			fields: NotificationPreferencesFields{
+				Users: []*influxdb.User{notificationPreferencesUser()},
+			},
+			args: args{
+				userID: MustIDBase16(oneID),
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Code: influxdb.ENotFound,
+					Msg:  "notification preferences not found",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(tt.fields, t)
+			defer done()
+			ctx := context.Background()
+			err := s.DeleteNotificationPreferences(ctx, tt.args.userID)
+			ErrorsEqual(t, err, tt.wants.err)
+
+			if _, err := s.FindNotificationPreferences(ctx, tt.args.userID); influxdb.ErrorCode(err) != influxdb.ENotFound {
+				t.Errorf("expected notification preferences to be deleted, got %v", err)
+			}
+		})
+	}
+}
@@ -10,16 +10,18 @@ import (
 	"github.com/influxdata/influxdb"
 	"github.com/influxdata/influxdb/mock"
 	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/endpoint"
 	"github.com/influxdata/influxdb/notification/rule"
 )
 
 // NotificationRuleFields includes prepopulated data for mapping tests.
 type NotificationRuleFields struct {
-	IDGenerator          influxdb.IDGenerator
-	TimeGenerator        influxdb.TimeGenerator
-	NotificationRules    []influxdb.NotificationRule
-	Orgs                 []*influxdb.Organization
-	UserResourceMappings []*influxdb.UserResourceMapping
+	IDGenerator           influxdb.IDGenerator
+	TimeGenerator         influxdb.TimeGenerator
+	NotificationRules     []influxdb.NotificationRule
+	NotificationEndpoints []influxdb.NotificationEndpoint
+	Orgs                  []*influxdb.Organization
+	UserResourceMappings  []*influxdb.UserResourceMapping
 }
 
 var timeGen1 = mock.TimeGenerator{FakeValue: time.Date(2006, time.July, 13, 4, 19, 10, 0, time.UTC)}
@@ -103,6 +105,17 @@ func CreateNotificationRule(
 			fields: NotificationRuleFields{
 				IDGenerator:   mock.NewIDGenerator(twoID, t),
 				TimeGenerator: fakeGenerator,
+				NotificationEndpoints: []influxdb.NotificationEndpoint{
+					&endpoint.Slack{
+						Base: endpoint.Base{
+							ID:     MustIDBase16(fiveID),
+							Name:   "endpoint1",
+							OrgID:  MustIDBase16(fourID),
+							Status: influxdb.Active,
+						},
+						URL: "https://hooks.slack.com/services/1",
+					},
+				},
 				NotificationRules: []influxdb.NotificationRule{
 					&rule.Slack{
 						Base: rule.Base{
@@ -271,6 +284,114 @@ func CreateNotificationRule(
 				},
 			},
 		},
+		{
+			name: "create notification rule sending to a missing endpoint",
+			fields: NotificationRuleFields{
+				IDGenerator:   mock.NewIDGenerator(twoID, t),
+				TimeGenerator: fakeGenerator,
+			},
+			args: args{
+				userID: MustIDBase16(sixID),
+				notificationRule: &rule.Slack{
+					Base: rule.Base{
+						AuthorizationID: MustIDBase16(threeID),
+						Name:            "name2",
+						OrgID:           MustIDBase16(fourID),
+						EndpointID:      IDPtr(MustIDBase16(fiveID)),
+						Status:          influxdb.Active,
+						Every:           influxdb.Duration{Duration: time.Hour},
+					},
+					Channel:         "channel1",
+					MessageTemplate: "msg1",
+				},
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Code: influxdb.EInvalid,
+					Msg:  "notification endpoint of the notification rule not found",
+				},
+			},
+		},
+		{
+			name: "create notification rule sending to an endpoint of another organization",
+			fields: NotificationRuleFields{
+				IDGenerator:   mock.NewIDGenerator(twoID, t),
+				TimeGenerator: fakeGenerator,
+				NotificationEndpoints: []influxdb.NotificationEndpoint{
+					&endpoint.Slack{
+						Base: endpoint.Base{
+							ID:     MustIDBase16(fiveID),
+							Name:   "endpoint1",
+							OrgID:  MustIDBase16(oneID),
+							Status: influxdb.Active,
+						},
+						URL: "https://hooks.slack.com/services/1",
+					},
+				},
+			},
+			args: args{
+				userID: MustIDBase16(sixID),
+				notificationRule: &rule.Slack{
+					Base: rule.Base{
+						AuthorizationID: MustIDBase16(threeID),
+						Name:            "name2",
+						OrgID:           MustIDBase16(fourID),
+						EndpointID:      IDPtr(MustIDBase16(fiveID)),
+						Status:          influxdb.Active,
+						Every:           influxdb.Duration{Duration: time.Hour},
+					},
+					Channel:         "channel1",
+					MessageTemplate: "msg1",
+				},
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Code: influxdb.EInvalid,
+					Msg:  "notification endpoint of the notification rule must belong to the organization of the rule",
+				},
+			},
+		},
+		{
+			name: "names are unique within an org",
+			fields: NotificationRuleFields{
+				IDGenerator:   mock.NewIDGenerator(twoID, t),
+				TimeGenerator: fakeGenerator,
+				NotificationRules: []influxdb.NotificationRule{
+					&rule.Slack{
+						Base: rule.Base{
+							ID:              MustIDBase16(oneID),
+							AuthorizationID: MustIDBase16(threeID),
+							Name:            "name1",
+							OrgID:           MustIDBase16(fourID),
+							Status:          influxdb.Active,
+							Every:           influxdb.Duration{Duration: time.Hour},
+						},
+						Channel:         "channel1",
+						MessageTemplate: "msg1",
+					},
+				},
+			},
+			args: args{
+				userID: MustIDBase16(sixID),
+				notificationRule: &rule.Slack{
+					Base: rule.Base{
+						AuthorizationID: MustIDBase16(threeID),
+						Name:            "name1",
+						OrgID:           MustIDBase16(fourID),
+						Status:          influxdb.Active,
+						Every:           influxdb.Duration{Duration: time.Hour},
+					},
+					Channel:         "channel2",
+					MessageTemplate: "msg2",
+				},
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Code: influxdb.EConflict,
+					Msg:  "notification rule with name name1 already exists",
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -1639,6 +1760,49 @@ func PatchNotificationRule(
 				},
 			},
 		},
+		{
+			name: "names are unique within an org",
+			fields: NotificationRuleFields{
+				TimeGenerator: fakeGenerator,
+				NotificationRules: []influxdb.NotificationRule{
+					&rule.Slack{
+						Base: rule.Base{
+							ID:              MustIDBase16(oneID),
+							Name:            "name1",
+							AuthorizationID: MustIDBase16(threeID),
+							OrgID:           MustIDBase16(fourID),
+							Status:          influxdb.Active,
+							Every:           influxdb.Duration{Duration: time.Hour},
+						},
+						Channel:         "channel1",
+						MessageTemplate: "msg1",
+					},
+					&rule.PagerDuty{
+						Base: rule.Base{
+							ID:              MustIDBase16(twoID),
+							Name:            name3,
+							AuthorizationID: MustIDBase16(threeID),
+							OrgID:           MustIDBase16(fourID),
+							Status:          influxdb.Active,
+							Every:           influxdb.Duration{Duration: time.Hour},
+						},
+						MessageTemp: "msg",
+					},
+				},
+			},
+			args: args{
+				id: MustIDBase16(oneID),
+				upd: influxdb.NotificationRuleUpdate{
+					Name: &name3,
+				},
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Code: influxdb.EConflict,
+					Msg:  "notification rule with name name2 already exists",
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
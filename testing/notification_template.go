@@ -0,0 +1,661 @@
+package testing
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
+)
+
+// NotificationTemplateFields includes prepopulated data for mapping tests.
+type NotificationTemplateFields struct {
+	IDGenerator           influxdb.IDGenerator
+	TimeGenerator         influxdb.TimeGenerator
+	NotificationTemplates []*influxdb.NotificationTemplate
+	Orgs                  []*influxdb.Organization
+}
+
+var notificationTemplateCmpOptions = cmp.Options{
+	cmp.Transformer("Sort", func(in []*influxdb.NotificationTemplate) []*influxdb.NotificationTemplate {
+		out := append([]*influxdb.NotificationTemplate(nil), in...)
+		sort.Slice(out, func(i, j int) bool {
+			return out[i].ID > out[j].ID
+		})
+		return out
+	}),
+}
+
+// NotificationTemplateService tests all the service functions.
+func NotificationTemplateService(
+	init func(NotificationTemplateFields, *testing.T) (influxdb.NotificationTemplateService, func()), t *testing.T,
+) {
+	tests := []struct {
+		name string
+		fn   func(init func(NotificationTemplateFields, *testing.T) (influxdb.NotificationTemplateService, func()),
+			t *testing.T)
+	}{
+		{
+			name: "CreateNotificationTemplate",
+			fn:   CreateNotificationTemplate,
+		},
+		{
+			name: "FindNotificationTemplateByID",
+			fn:   FindNotificationTemplateByID,
+		},
+		{
+			name: "FindNotificationTemplates",
+			fn:   FindNotificationTemplates,
+		},
+		{
+			name: "UpdateNotificationTemplate",
+			fn:   UpdateNotificationTemplate,
+		},
+		{
+			name: "DeleteNotificationTemplate",
+			fn:   DeleteNotificationTemplate,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(init, t)
+		})
+	}
+}
+
+func notificationTemplateFooter() *influxdb.NotificationTemplate {
+	return &influxdb.NotificationTemplate{
+		ID:       MustIDBase16(oneID),
+		OrgID:    MustIDBase16(fourID),
+		Name:     "footer",
+		Template: "-- sent by influxdb ${partial.runbook}",
+		CRUDLog: influxdb.CRUDLog{
+			CreatedAt: timeGen1.Now(),
+			UpdatedAt: timeGen1.Now(),
+		},
+	}
+}
+
+func notificationTemplateRunbook() *influxdb.NotificationTemplate {
+	return &influxdb.NotificationTemplate{
+		ID:       MustIDBase16(twoID),
+		OrgID:    MustIDBase16(fourID),
+		Name:     "runbook",
+		Template: "see https://wiki.example.com/runbooks/${r._check_name}",
+		CRUDLog: influxdb.CRUDLog{
+			CreatedAt: timeGen1.Now(),
+			UpdatedAt: timeGen1.Now(),
+		},
+	}
+}
+
+// CreateNotificationTemplate testing.
+func CreateNotificationTemplate(
+	init func(NotificationTemplateFields, *testing.T) (influxdb.NotificationTemplateService, func()),
+	t *testing.T,
+) {
+	type args struct {
+		template *influxdb.NotificationTemplate
+	}
+	type wants struct {
+		err       error
+		templates []*influxdb.NotificationTemplate
+	}
+
+	tests := []struct {
+		name   string
+		fields NotificationTemplateFields
+		args   args
+		wants  wants
+	}{
+		{
+			name: "basic create notification template",
+			fields: NotificationTemplateFields{
+				IDGenerator:           mock.NewIDGenerator(oneID, t),
+				TimeGenerator:         fakeGenerator,
+				NotificationTemplates: []*influxdb.NotificationTemplate{notificationTemplateRunbook()},
+			},
+			args: args{
+				template: &influxdb.NotificationTemplate{
+					OrgID:    MustIDBase16(fourID),
+					Name:     "footer",
+					Template: "-- sent by influxdb ${partial.runbook}",
+				},
+			},
+			wants: wants{
+				templates: []*influxdb.NotificationTemplate{
+					notificationTemplateRunbook(),
+					{
+						ID:       MustIDBase16(oneID),
+						OrgID:    MustIDBase16(fourID),
+						Name:     "footer",
+						Template: "-- sent by influxdb ${partial.runbook}",
+						CRUDLog: influxdb.CRUDLog{
+							CreatedAt: fakeDate,
+							UpdatedAt: fakeDate,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "name already exists in the org",
+			fields: NotificationTemplateFields{
+				IDGenerator:           mock.NewIDGenerator(oneID, t),
+				TimeGenerator:         fakeGenerator,
+				NotificationTemplates: []*influxdb.NotificationTemplate{notificationTemplateRunbook()},
+			},
+			args: args{
+				template: &influxdb.NotificationTemplate{
+					OrgID:    MustIDBase16(fourID),
+					Name:     "runbook",
+					Template: "another runbook",
+				},
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Code: influxdb.EConflict,
+					Msg:  "notification template with name runbook already exists",
+				},
+				templates: []*influxdb.NotificationTemplate{notificationTemplateRunbook()},
+			},
+		},
+		{
+			name: "reference to a missing template",
+			fields: NotificationTemplateFields{
+				IDGenerator:   mock.NewIDGenerator(oneID, t),
+				TimeGenerator: fakeGenerator,
+			},
+			args: args{
+				template: &influxdb.NotificationTemplate{
+					OrgID:    MustIDBase16(fourID),
+					Name:     "footer",
+					Template: "-- sent by influxdb ${partial.runbook}",
+				},
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Code: influxdb.EInvalid,
+					Msg:  `notification template "runbook" not found`,
+				},
+				templates: []*influxdb.NotificationTemplate{},
+			},
+		},
+		{
+			name: "templates referencing each other",
+			fields: NotificationTemplateFields{
+				IDGenerator:   mock.NewIDGenerator(twoID, t),
+				TimeGenerator: fakeGenerator,
+				NotificationTemplates: []*influxdb.NotificationTemplate{
+					{
+						ID:       MustIDBase16(oneID),
+						OrgID:    MustIDBase16(fourID),
+						Name:     "footer",
+						Template: "-- ${partial.runbook}",
+					},
+				},
+			},
+			args: args{
+				template: &influxdb.NotificationTemplate{
+					OrgID:    MustIDBase16(fourID),
+					Name:     "runbook",
+					Template: "see the runbook ${partial.footer}",
+				},
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Code: influxdb.EInvalid,
+					Msg:  "notification templates reference each other in a cycle: footer -> runbook -> footer",
+				},
+				templates: []*influxdb.NotificationTemplate{
+					{
+						ID:       MustIDBase16(oneID),
+						OrgID:    MustIDBase16(fourID),
+						Name:     "footer",
+						Template: "-- ${partial.runbook}",
+					},
+				},
+			},
+		},
+		{
+			name: "invalid name",
+			fields: NotificationTemplateFields{
+				IDGenerator:   mock.NewIDGenerator(oneID, t),
+				TimeGenerator: fakeGenerator,
+			},
+			args: args{
+				template: &influxdb.NotificationTemplate{
+					OrgID: MustIDBase16(fourID),
+					Name:  "run book",
+				},
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Code: influxdb.EInvalid,
+					Msg:  "Notification Template Name can only contain letters, digits, _ and -",
+				},
+				templates: []*influxdb.NotificationTemplate{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(tt.fields, t)
+			defer done()
+			ctx := context.Background()
+			err := s.CreateNotificationTemplate(ctx, tt.args.template)
+			ErrorsEqual(t, err, tt.wants.err)
+
+			ts, _, err := s.FindNotificationTemplates(ctx, influxdb.NotificationTemplateFilter{})
+			if err != nil {
+				t.Fatalf("failed to retrieve notification templates: %v", err)
+			}
+			if diff := cmp.Diff(ts, tt.wants.templates, notificationTemplateCmpOptions...); diff != "" {
+				t.Errorf("notification templates are different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+// FindNotificationTemplateByID testing.
+func FindNotificationTemplateByID(
+	init func(NotificationTemplateFields, *testing.T) (influxdb.NotificationTemplateService, func()),
+	t *testing.T,
+) {
+	type args struct {
+		id influxdb.ID
+	}
+	type wants struct {
+		err      error
+		template *influxdb.NotificationTemplate
+	}
+
+	tests := []struct {
+		name   string
+		fields NotificationTemplateFields
+		args   args
+		wants  wants
+	}{
+		{
+			name: "basic find notification template by id",
+			fields: NotificationTemplateFields{
+				NotificationTemplates: []*influxdb.NotificationTemplate{
+					notificationTemplateFooter(),
+					notificationTemplateRunbook(),
+				},
+			},
+			args: args{
+				id: MustIDBase16(twoID),
+			},
+			wants: wants{
+				template: notificationTemplateRunbook(),
+			},
+		},
+		{
+			name: "find notification template by id not found",
+			fields: NotificationTemplateFields{
+				NotificationTemplates: []*influxdb.NotificationTemplate{
+					notificationTemplateRunbook(),
+				},
+			},
+			args: args{
+				id: MustIDBase16(threeID),
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Code: influxdb.ENotFound,
+					Msg:  "notification template not found",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(tt.fields, t)
+			defer done()
+			ctx := context.Background()
+
+			tmpl, err := s.FindNotificationTemplateByID(ctx, tt.args.id)
+			ErrorsEqual(t, err, tt.wants.err)
+			if diff := cmp.Diff(tmpl, tt.wants.template); diff != "" {
+				t.Errorf("notification template is different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+// FindNotificationTemplates testing.
+func FindNotificationTemplates(
+	init func(NotificationTemplateFields, *testing.T) (influxdb.NotificationTemplateService, func()),
+	t *testing.T,
+) {
+	other := &influxdb.NotificationTemplate{
+		ID:       MustIDBase16(threeID),
+		OrgID:    MustIDBase16(oneID),
+		Name:     "footer",
+		Template: "-- other org",
+		CRUDLog: influxdb.CRUDLog{
+			CreatedAt: timeGen1.Now(),
+			UpdatedAt: timeGen1.Now(),
+		},
+	}
+	fields := NotificationTemplateFields{
+		Orgs: []*influxdb.Organization{
+			{
+				ID:   MustIDBase16(fourID),
+				Name: "org4",
+			},
+			{
+				ID:   MustIDBase16(oneID),
+				Name: "org1",
+			},
+		},
+		NotificationTemplates: []*influxdb.NotificationTemplate{
+			notificationTemplateFooter(),
+			notificationTemplateRunbook(),
+			other,
+		},
+	}
+
+	type args struct {
+		filter influxdb.NotificationTemplateFilter
+		opts   []influxdb.FindOptions
+	}
+	type wants struct {
+		err       error
+		templates []*influxdb.NotificationTemplate
+	}
+
+	tests := []struct {
+		name  string
+		args  args
+		wants wants
+	}{
+		{
+			name: "find all notification templates",
+			wants: wants{
+				templates: []*influxdb.NotificationTemplate{
+					notificationTemplateFooter(),
+					notificationTemplateRunbook(),
+					other,
+				},
+			},
+		},
+		{
+			name: "filter by org id",
+			args: args{
+				filter: influxdb.NotificationTemplateFilter{
+					OrgID: idPtr(MustIDBase16(fourID)),
+				},
+			},
+			wants: wants{
+				templates: []*influxdb.NotificationTemplate{
+					notificationTemplateFooter(),
+					notificationTemplateRunbook(),
+				},
+			},
+		},
+		{
+			name: "filter by org name and name",
+			args: args{
+				filter: influxdb.NotificationTemplateFilter{
+					Organization: strPtr("org1"),
+					Name:         strPtr("footer"),
+				},
+			},
+			wants: wants{
+				templates: []*influxdb.NotificationTemplate{other},
+			},
+		},
+		{
+			name: "find with offset and limit",
+			args: args{
+				filter: influxdb.NotificationTemplateFilter{
+					OrgID: idPtr(MustIDBase16(fourID)),
+				},
+				opts: []influxdb.FindOptions{
+					{
+						Offset: 1,
+						Limit:  1,
+					},
+				},
+			},
+			wants: wants{
+				templates: []*influxdb.NotificationTemplate{
+					notificationTemplateRunbook(),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(fields, t)
+			defer done()
+			ctx := context.Background()
+
+			ts, n, err := s.FindNotificationTemplates(ctx, tt.args.filter, tt.args.opts...)
+			ErrorsEqual(t, err, tt.wants.err)
+			if n != len(tt.wants.templates) {
+				t.Fatalf("notification templates length is different got %d, want %d", n, len(tt.wants.templates))
+			}
+			if diff := cmp.Diff(ts, tt.wants.templates, notificationTemplateCmpOptions...); diff != "" {
+				t.Errorf("notification templates are different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+// UpdateNotificationTemplate testing.
+func UpdateNotificationTemplate(
+	init func(NotificationTemplateFields, *testing.T) (influxdb.NotificationTemplateService, func()),
+	t *testing.T,
+) {
+	type args struct {
+		id  influxdb.ID
+		upd influxdb.NotificationTemplateUpdate
+	}
+	type wants struct {
+		err      error
+		template *influxdb.NotificationTemplate
+	}
+
+	fields := NotificationTemplateFields{
+		TimeGenerator: fakeGenerator,
+		NotificationTemplates: []*influxdb.NotificationTemplate{
+			notificationTemplateFooter(),
+			notificationTemplateRunbook(),
+		},
+	}
+
+	tests := []struct {
+		name  string
+		args  args
+		wants wants
+	}{
+		{
+			name: "update template",
+			args: args{
+				id: MustIDBase16(twoID),
+				upd: influxdb.NotificationTemplateUpdate{
+					Template: strPtr("see ${r._check_name} in the wiki"),
+				},
+			},
+			wants: wants{
+				template: &influxdb.NotificationTemplate{
+					ID:       MustIDBase16(twoID),
+					OrgID:    MustIDBase16(fourID),
+					Name:     "runbook",
+					Template: "see ${r._check_name} in the wiki",
+					CRUDLog: influxdb.CRUDLog{
+						CreatedAt: timeGen1.Now(),
+						UpdatedAt: fakeDate,
+					},
+				},
+			},
+		},
+		{
+			name: "rename a template used by another template",
+			args: args{
+				id: MustIDBase16(twoID),
+				upd: influxdb.NotificationTemplateUpdate{
+					Name: strPtr("runbooks"),
+				},
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Code: influxdb.EInvalid,
+					Msg:  `notification template "runbook" not found`,
+				},
+			},
+		},
+		{
+			name: "update creates a cycle",
+			args: args{
+				id: MustIDBase16(twoID),
+				upd: influxdb.NotificationTemplateUpdate{
+					Template: strPtr("${partial.footer}"),
+				},
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Code: influxdb.EInvalid,
+					Msg:  "notification templates reference each other in a cycle: footer -> runbook -> footer",
+				},
+			},
+		},
+		{
+			name: "rename to an existing name",
+			args: args{
+				id: MustIDBase16(oneID),
+				upd: influxdb.NotificationTemplateUpdate{
+					Name: strPtr("runbook"),
+				},
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Code: influxdb.EConflict,
+					Msg:  "notification template with name runbook already exists",
+				},
+			},
+		},
+		{
+			name: "update a template that doesn't exist",
+			args: args{
+				id: MustIDBase16(threeID),
+				upd: influxdb.NotificationTemplateUpdate{
+					Template: strPtr("text"),
+				},
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Code: influxdb.ENotFound,
+					Msg:  "notification template not found",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(fields, t)
+			defer done()
+			ctx := context.Background()
+
+			tmpl, err := s.UpdateNotificationTemplate(ctx, tt.args.id, tt.args.upd)
+			ErrorsEqual(t, err, tt.wants.err)
+			if diff := cmp.Diff(tmpl, tt.wants.template); diff != "" {
+				t.Errorf("notification template is different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+// DeleteNotificationTemplate testing.
+func DeleteNotificationTemplate(
+	init func(NotificationTemplateFields, *testing.T) (influxdb.NotificationTemplateService, func()),
+	t *testing.T,
+) {
+	type args struct {
+		id influxdb.ID
+	}
+	type wants struct {
+		err       error
+		templates []*influxdb.NotificationTemplate
+	}
+
+	fields := NotificationTemplateFields{
+		NotificationTemplates: []*influxdb.NotificationTemplate{
+			notificationTemplateFooter(),
+			notificationTemplateRunbook(),
+		},
+	}
+
+	tests := []struct {
+		name  string
+		args  args
+		wants wants
+	}{
+		{
+			name: "delete a template",
+			args: args{
+				id: MustIDBase16(oneID),
+			},
+			wants: wants{
+				templates: []*influxdb.NotificationTemplate{
+					notificationTemplateRunbook(),
+				},
+			},
+		},
+		{
+			name: "delete a template used by another template",
+			args: args{
+				id: MustIDBase16(twoID),
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Code: influxdb.EConflict,
+					Msg:  "notification template runbook is used by other notification templates",
+				},
+				templates: []*influxdb.NotificationTemplate{
+					notificationTemplateFooter(),
+					notificationTemplateRunbook(),
+				},
+			},
+		},
+		{
+			name: "delete a template that doesn't exist",
+			args: args{
+				id: MustIDBase16(threeID),
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Code: influxdb.ENotFound,
+					Msg:  "notification template not found",
+				},
+				templates: []*influxdb.NotificationTemplate{
+					notificationTemplateFooter(),
+					notificationTemplateRunbook(),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(fields, t)
+			defer done()
+			ctx := context.Background()
+
+			err := s.DeleteNotificationTemplate(ctx, tt.args.id)
+			ErrorsEqual(t, err, tt.wants.err)
+
+			ts, _, err := s.FindNotificationTemplates(ctx, influxdb.NotificationTemplateFilter{})
+			if err != nil {
+				t.Fatalf("failed to retrieve notification templates: %v", err)
+			}
+			if diff := cmp.Diff(ts, tt.wants.templates, notificationTemplateCmpOptions...); diff != "" {
+				t.Errorf("notification templates are different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
@@ -221,6 +221,8 @@ const (
 	fourID   = "020f755c3c082003"
 	fiveID   = "020f755c3c082004"
 	sixID    = "020f755c3c082005"
+	sevenID  = "020f755c3c082006"
+	eightID  = "020f755c3c082007"
 	oneToken = "020f755c3c082008"
 )
 
@@ -50,3 +50,50 @@ type Timespan struct {
 	Start time.Time `json:"start"`
 	Stop  time.Time `json:"stop"`
 }
+
+// AlertingUsage counts the alerting resources of an organization, or of every
+// organization, and the notifications they sent, for capacity planning.
+type AlertingUsage struct {
+	OrgID                 *ID                   `json:"orgID,omitempty"`
+	Checks                AlertingResourceUsage `json:"checks"`
+	NotificationEndpoints AlertingResourceUsage `json:"notificationEndpoints"`
+	NotificationRules     AlertingResourceUsage `json:"notificationRules"`
+	// Notifications counts the notifications sent each day of the range of
+	// the usage, in UTC, by the alerting engine tracing its statuses.
+	Notifications []DailyUsage `json:"notifications"`
+}
+
+// AlertingResourceUsage counts a type of alerting resources. The archived
+// checks aren't counted.
+type AlertingResourceUsage struct {
+	Total    int            `json:"total"`
+	ByType   map[string]int `json:"byType"`
+	ByStatus map[Status]int `json:"byStatus"`
+}
+
+// Add counts a resource of type typ with status.
+func (u *AlertingResourceUsage) Add(typ string, status Status) {
+	if u.ByType == nil {
+		u.ByType = make(map[string]int)
+	}
+	if u.ByStatus == nil {
+		u.ByStatus = make(map[Status]int)
+	}
+	u.Total++
+	u.ByType[typ]++
+	u.ByStatus[status]++
+}
+
+// DailyUsage is a count of a day, formatted as 2006-01-02.
+type DailyUsage struct {
+	Day   string `json:"day"`
+	Count int    `json:"count"`
+}
+
+// AlertingUsageService is a service for accessing the usage of alerting.
+type AlertingUsageService interface {
+	// GetAlertingUsage counts the alerting resources of the organization of
+	// the filter, or of every organization, and the notifications sent
+	// within the range of the filter. The bucket of the filter is ignored.
+	GetAlertingUsage(ctx context.Context, filter UsageFilter) (*AlertingUsage, error)
+}
@@ -17,7 +17,7 @@ var (
 	ErrResourceIDRequired = errors.New("resource id is required")
 )
 
-// UserType can either be owner or member.
+// UserType can either be owner, member or viewer.
 type UserType string
 
 const (
@@ -25,6 +25,9 @@ const (
 	Owner UserType = "owner" // 1
 	// Member can read from a resource.
 	Member UserType = "member" // 2
+	// Viewer can read the dashboards of a resource and the resources they
+	// show, without the query logic of its checks.
+	Viewer UserType = "viewer" // 3
 )
 
 // Valid checks if the UserType is a member of the UserType enum
@@ -32,6 +35,7 @@ func (ut UserType) Valid() (err error) {
 	switch ut {
 	case Owner: // 1
 	case Member: // 2
+	case Viewer: // 3
 	default:
 		err = ErrInvalidUserType
 	}
@@ -164,6 +168,16 @@ func (m *UserResourceMapping) memberPerms() ([]Permission, error) {
 	return ps, nil
 }
 
+func (m *UserResourceMapping) viewerPerms() ([]Permission, error) {
+	ps := []Permission{}
+
+	if m.ResourceType == OrgsResourceType {
+		ps = append(ps, ViewerPermissions(m.ResourceID)...)
+	}
+
+	return ps, nil
+}
+
 // ToPermissions converts a user resource mapping into a set of permissions.
 func (m *UserResourceMapping) ToPermissions() ([]Permission, error) {
 	switch m.UserType {
@@ -171,6 +185,8 @@ func (m *UserResourceMapping) ToPermissions() ([]Permission, error) {
 		return m.ownerPerms()
 	case Member:
 		return m.memberPerms()
+	case Viewer:
+		return m.viewerPerms()
 	default:
 		return nil, ErrInvalidUserType
 	}
@@ -0,0 +1,87 @@
+package vault
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/vault/api"
+	platform "github.com/influxdata/influxdb"
+)
+
+var _ platform.SecretProvider = (*SecretProvider)(nil)
+
+// SecretProvider resolves the references of the secret fields of the
+// notification endpoints to the secrets of a vault, vault://path#field.
+// The path is read as is, the path of a secret of the KV version 2 engine
+// includes its data segment, vault://secret/data/slack#token.
+type SecretProvider struct {
+	Client *api.Client
+	// Prefix restricts the paths each organization refers to, such as
+	// secret/data/influxdb/{orgID}/, see platform.SecretReference.Under.
+	Prefix string
+}
+
+// NewSecretProvider creates an instance of a SecretProvider resolving the
+// references under prefix. The provider is configured using the standard
+// vault environment variables.
+// https://www.vaultproject.io/docs/commands/index.html#environment-variables
+func NewSecretProvider(prefix string) (*SecretProvider, error) {
+	cfg := api.DefaultConfig()
+	if err := cfg.ReadEnvironment(); err != nil {
+		return nil, err
+	}
+
+	c, err := api.NewClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SecretProvider{
+		Client: c,
+		Prefix: prefix,
+	}, nil
+}
+
+// ResolveSecret returns the value of the field of the secret a reference refers to.
+func (p *SecretProvider) ResolveSecret(ctx context.Context, orgID platform.ID, ref platform.SecretReference) (string, error) {
+	if err := ref.Under(p.Prefix, orgID); err != nil {
+		return "", err
+	}
+	if ref.Field == "" {
+		return "", &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  fmt.Sprintf("vault secret reference %s requires a field", ref),
+		}
+	}
+
+	sec, err := p.Client.Logical().Read(ref.Path)
+	if err != nil {
+		return "", &platform.Error{
+			Code: platform.EUnavailable,
+			Msg:  fmt.Sprintf("failed to read the vault secret %s", ref.Path),
+			Err:  err,
+		}
+	}
+	if sec == nil {
+		return "", &platform.Error{
+			Code: platform.ENotFound,
+			Msg:  fmt.Sprintf("vault secret %s not found", ref.Path),
+		}
+	}
+
+	data := sec.Data
+	// the KV version 2 engine nests the fields of a secret with its metadata.
+	if d, ok := data["data"].(map[string]interface{}); ok {
+		if _, ok := data["metadata"]; ok {
+			data = d
+		}
+	}
+	v, ok := data[ref.Field].(string)
+	if !ok {
+		return "", &platform.Error{
+			Code: platform.ENotFound,
+			Msg:  fmt.Sprintf("vault secret %s has no field %s", ref.Path, ref.Field),
+		}
+	}
+	return v, nil
+}
@@ -0,0 +1,90 @@
+package vault_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/vault"
+)
+
+func TestSecretProvider_ResolveSecret(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/secret/data/influxdb/0000000000000003/slack":
+			w.Write([]byte(`{"data": {"data": {"token": "token1"}, "metadata": {"version": 1}}}`))
+		case "/v1/kv/influxdb/0000000000000003/pagerduty":
+			w.Write([]byte(`{"data": {"routingKey": "key1"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors": []}`))
+		}
+	}))
+	defer ts.Close()
+
+	c, err := api.NewClient(&api.Config{Address: ts.URL, HttpClient: ts.Client()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := &vault.SecretProvider{Client: c}
+
+	cases := []struct {
+		name     string
+		prefix   string
+		ref      string
+		want     string
+		wantCode string
+	}{
+		{
+			name: "kv version 2 secret",
+			ref:  "vault://secret/data/influxdb/0000000000000003/slack#token",
+			want: "token1",
+		},
+		{
+			name: "kv version 1 secret",
+			ref:  "vault://kv/influxdb/0000000000000003/pagerduty#routingKey",
+			want: "key1",
+		},
+		{
+			name:     "missing secret",
+			ref:      "vault://secret/data/influxdb/0000000000000003/missing#token",
+			wantCode: influxdb.ENotFound,
+		},
+		{
+			name:     "missing field",
+			ref:      "vault://secret/data/influxdb/0000000000000003/slack#password",
+			wantCode: influxdb.ENotFound,
+		},
+		{
+			name:     "no field",
+			ref:      "vault://secret/data/influxdb/0000000000000003/slack",
+			wantCode: influxdb.EInvalid,
+		},
+		{
+			name:     "secret of another organization",
+			prefix:   "secret/data/influxdb/{orgID}/",
+			ref:      "vault://secret/data/influxdb/0000000000000004/slack#token",
+			wantCode: influxdb.EForbidden,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			p.Prefix = c.prefix
+			ref, ok := influxdb.ParseSecretReference(c.ref)
+			if !ok {
+				t.Fatalf("%s isn't a reference", c.ref)
+			}
+			got, err := p.ResolveSecret(context.Background(), influxdb.ID(3), ref)
+			if influxdb.ErrorCode(err) != c.wantCode {
+				t.Fatalf("unexpected error %v, want code %q", err, c.wantCode)
+			}
+			if got != c.want {
+				t.Errorf("unexpected secret %q, want %q", got, c.want)
+			}
+		})
+	}
+}